@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+// runAreaCommand handles "area import" and "area export", which convert area
+// files between LumenClay JSON and ROM/Merc .are files without starting the
+// server.
+func runAreaCommand(args []string, stdout, stderr io.Writer) int {
+	usage := func() int {
+		fmt.Fprintln(stderr, "usage: LumenClay area import [-out file.json] <file.are>")
+		fmt.Fprintln(stderr, "       LumenClay area export [-vnum 1000] [-out file.are] <file.json>")
+		return 2
+	}
+	if len(args) == 0 {
+		return usage()
+	}
+	fs := flag.NewFlagSet("area "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	out := fs.String("out", "", "Write the converted area to this path (defaults to stdout)")
+	vnum := fs.Int("vnum", 1000, "First vnum used when exporting")
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() != 1 {
+		return usage()
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	var conv game.AreaConversion
+	switch args[0] {
+	case "import":
+		conv, err = game.ImportROMArea(data)
+	case "export":
+		conv, err = game.ExportROMArea(data, *vnum)
+	default:
+		return usage()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	for _, warning := range conv.Warnings {
+		fmt.Fprintln(stderr, "warning:", warning)
+	}
+	target := strings.TrimSpace(*out)
+	if target == "" {
+		_, err = stdout.Write(conv.Data)
+	} else {
+		if dir := filepath.Dir(target); dir != "." {
+			err = os.MkdirAll(dir, 0o755)
+		}
+		if err == nil {
+			err = os.WriteFile(target, conv.Data, 0o644)
+		}
+		if err == nil {
+			fmt.Fprintf(stderr, "Wrote %q (%d rooms) to %s\n", conv.Name, conv.Rooms, target)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
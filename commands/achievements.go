@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Achievements = Define(Definition{
+	Name:        "achievements",
+	Aliases:     []string{"achieve"},
+	Usage:       "achievements",
+	Description: "list achievements and your progress toward them",
+}, func(ctx *Context) bool {
+	statuses := ctx.World.Achievements(ctx.Player)
+	if len(statuses) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nThere are no achievements to earn yet."))
+		return false
+	}
+	earned := 0
+	var lines strings.Builder
+	for _, status := range statuses {
+		mark := fmt.Sprintf("[%d/%d]", status.Progress, status.Count)
+		if status.Unlocked {
+			earned++
+			mark = game.Style("[done]", game.AnsiGreen)
+		}
+		line := fmt.Sprintf("\r\n  %s %s", mark, game.Style(status.Name, game.AnsiBold))
+		if status.Description != "" {
+			line += " - " + status.Description
+		}
+		if status.Title != "" {
+			line += fmt.Sprintf(" (title: %s)", status.Title)
+		}
+		lines.WriteString(line)
+	}
+	header := game.Style(fmt.Sprintf("\r\nAchievements (%d of %d earned):", earned, len(statuses)), game.AnsiBold)
+	ctx.Player.Output.Send(game.Ansi(header + lines.String()))
+	return false
+})
+
+var Title = Define(Definition{
+	Name:        "title",
+	Usage:       "title [<title>|none]",
+	Description: "choose an earned title to show after your name",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		titles := ctx.World.EarnedTitles(ctx.Player)
+		current := "none"
+		if ctx.Player.Title != "" {
+			current = ctx.Player.Title
+		}
+		msg := fmt.Sprintf("\r\nYour title: %s", current)
+		if len(titles) == 0 {
+			msg += "\r\nYou have not earned any titles yet. See 'achievements' for ways to earn them."
+		} else {
+			msg += "\r\nEarned titles: " + strings.Join(titles, ", ") + "\r\nUse 'title <title>' to wear one or 'title none' to remove it."
+		}
+		ctx.Player.Output.Send(game.Ansi(msg))
+		return false
+	}
+	if strings.EqualFold(arg, "none") || strings.EqualFold(arg, "clear") {
+		arg = ""
+	}
+	title, err := ctx.World.SetTitle(ctx.Player, arg)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	if title == "" {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou no longer display a title."))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\nYou are now known as " + game.TitledName(ctx.Player.Name, title) + "."))
+	return false
+})
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestTitleCommandShowsInWhoAndLook(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "A Quiet Chamber", Exits: map[string]game.Exit{}},
+	})
+	hero := newTestPlayer("Hero", "start")
+	watcher := newTestPlayer("Watcher", "start")
+	world.AddPlayerForTest(hero)
+	world.AddPlayerForTest(watcher)
+
+	Dispatch(world, hero, "title")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, "You have not earned any titles yet.") {
+		t.Fatalf("title output = %q", output)
+	}
+	Dispatch(world, hero, "title the Bold")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, `You have not earned the title "the Bold".`) {
+		t.Fatalf("unearned title output = %q", output)
+	}
+
+	hero.Title = "the Bold"
+	Dispatch(world, watcher, "who")
+	if output := strings.Join(drainOutput(watcher.Output), "\n"); !strings.Contains(output, "Hero the Bold") {
+		t.Fatalf("who output = %q", output)
+	}
+	Dispatch(world, watcher, "look hero")
+	if output := strings.Join(drainOutput(watcher.Output), "\n"); !strings.Contains(output, "You look at Hero the Bold.") {
+		t.Fatalf("look output = %q", output)
+	}
+	Dispatch(world, hero, "achievements")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, "There are no achievements to earn yet.") {
+		t.Fatalf("achievements output = %q", output)
+	}
+}
@@ -13,7 +13,7 @@ func TestBuilderCommandTogglesFlag(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "The central hub.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	admin := newTestPlayer("Admin", "start")
@@ -58,7 +58,7 @@ func TestModeratorCommandTogglesFlag(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "The central hub.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	admin := newTestPlayer("Admin", "start")
@@ -103,13 +103,13 @@ func TestGotoRequiresBuilder(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{"east": "second"},
+			Exits:       map[string]game.Exit{"east": {To: "second"}},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	player := newTestPlayer("Traveler", "start")
@@ -139,13 +139,13 @@ func TestGotoMovesBuilder(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{"east": "second"},
+			Exits:       map[string]game.Exit{"east": {To: "second"}},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -190,13 +190,13 @@ func TestTeleportRequiresBuilder(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{"east": "second"},
+			Exits:       map[string]game.Exit{"east": {To: "second"}},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	player := newTestPlayer("Traveler", "start")
@@ -226,13 +226,13 @@ func TestTeleportMovesBuilderToRoom(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{"east": "second"},
+			Exits:       map[string]game.Exit{"east": {To: "second"}},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -290,13 +290,13 @@ func TestTeleportMovesBuilderToPlayer(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{"east": "second"},
+			Exits:       map[string]game.Exit{"east": {To: "second"}},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -343,13 +343,13 @@ func TestSummonMovesTarget(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{"east": "second"},
+			Exits:       map[string]game.Exit{"east": {To: "second"}},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	admin := newTestPlayer("Admin", "start")
@@ -394,13 +394,13 @@ func TestWhereListsLocations(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second",
 			Description: "Second room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
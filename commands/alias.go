@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Alias = Define(Definition{
+	Name:        "alias",
+	Usage:       "alias [name] [commands]",
+	Description: "define a command alias; separate commands with ';' and use $1-$9 or $* for arguments",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		listCommandAliases(ctx)
+		return false
+	}
+	name, expansion, _ := strings.Cut(arg, " ")
+	expansion = strings.TrimSpace(expansion)
+	if expansion == "" {
+		current, ok := ctx.World.CommandAlias(ctx.Player, name)
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nYou have no alias named '%s'.", strings.ToLower(name)), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s => %s", game.Style(strings.ToLower(name), game.AnsiBold), current)))
+		return false
+	}
+	if err := ctx.World.SetCommandAlias(ctx.Player, name, expansion); err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAlias %s now runs: %s", game.Style(strings.ToLower(name), game.AnsiBold), expansion)))
+	return false
+})
+
+var Unalias = Define(Definition{
+	Name:        "unalias",
+	Usage:       "unalias <name>",
+	Description: "remove one of your command aliases",
+}, func(ctx *Context) bool {
+	name := strings.TrimSpace(ctx.Arg)
+	if name == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: unalias <name>", game.AnsiYellow)))
+		return false
+	}
+	if err := ctx.World.SetCommandAlias(ctx.Player, name, ""); err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAlias %s removed.", game.Style(strings.ToLower(name), game.AnsiBold))))
+	return false
+})
+
+var Aliases = Define(Definition{
+	Name:        "aliases",
+	Usage:       "aliases",
+	Description: "list your command aliases",
+}, func(ctx *Context) bool {
+	listCommandAliases(ctx)
+	return false
+})
+
+func listCommandAliases(ctx *Context) {
+	aliases := ctx.World.CommandAliases(ctx.Player)
+	if len(aliases) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou have no aliases. Use alias <name> <commands> to define one."))
+		return
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\n" + game.Style(fmt.Sprintf("Aliases (%d/%d):", len(aliases), game.MaxCommandAliases), game.AnsiBold))
+	for _, alias := range aliases {
+		builder.WriteString(fmt.Sprintf("\r\n  %-12s %s", alias.Name, alias.Expansion))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestAliasExpandsIntoCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Description: "An empty hall.", Exits: map[string]game.Exit{}},
+	})
+	speaker := newTestPlayer("Speaker", "hall")
+	world.AddPlayerForTest(speaker)
+
+	Dispatch(world, speaker, "alias greet say hello $1; say and $*")
+	drainOutput(speaker.Output)
+	Dispatch(world, speaker, "greet Mira all")
+	output := strings.Join(drainOutput(speaker.Output), "")
+	if !strings.Contains(output, "You say: hello Mira") || !strings.Contains(output, "You say: and Mira all") {
+		t.Fatalf("alias did not expand: %q", output)
+	}
+
+	Dispatch(world, speaker, "alias say say echo:")
+	drainOutput(speaker.Output)
+	Dispatch(world, speaker, "say hi")
+	if output := strings.Join(drainOutput(speaker.Output), ""); !strings.Contains(output, "You say: echo: hi") {
+		t.Fatalf("self-referencing alias should run the real command: %q", output)
+	}
+
+	Dispatch(world, speaker, "aliases")
+	output = ansiPattern.ReplaceAllString(strings.Join(drainOutput(speaker.Output), ""), "")
+	if !strings.Contains(output, "greet") || !strings.Contains(output, "say echo:") {
+		t.Fatalf("aliases listing = %q", output)
+	}
+
+	Dispatch(world, speaker, "unalias say")
+	drainOutput(speaker.Output)
+	Dispatch(world, speaker, "say plain")
+	if output := strings.Join(drainOutput(speaker.Output), ""); !strings.Contains(output, "You say: plain") {
+		t.Fatalf("unalias should restore the command: %q", output)
+	}
+}
+
+func TestAliasExpansionLimits(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Description: "An empty hall.", Exits: map[string]game.Exit{}},
+	})
+	player := newTestPlayer("Looper", "hall")
+	world.AddPlayerForTest(player)
+
+	for i := 1; i <= game.MaxAliasDepth+1; i++ {
+		if err := world.SetCommandAlias(player, fmt.Sprintf("l%d", i), fmt.Sprintf("l%d", i+1)); err != nil {
+			t.Fatalf("SetCommandAlias: %v", err)
+		}
+	}
+	Dispatch(world, player, "l1")
+	if output := strings.Join(drainOutput(player.Output), ""); !strings.Contains(output, "nests more than") {
+		t.Fatalf("deep alias chain was not stopped: %q", output)
+	}
+
+	if err := world.SetCommandAlias(player, "many", strings.Repeat("say x;", game.MaxAliasCommands+1)); err != nil {
+		t.Fatalf("SetCommandAlias: %v", err)
+	}
+	Dispatch(world, player, "many")
+	output := strings.Join(drainOutput(player.Output), "")
+	if got := strings.Count(output, "You say: x"); got != game.MaxAliasCommands {
+		t.Fatalf("alias ran %d commands, want %d", got, game.MaxAliasCommands)
+	}
+	if !strings.Contains(output, "runs more than") {
+		t.Fatalf("missing command limit notice: %q", output)
+	}
+}
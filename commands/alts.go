@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Alts = Define(Definition{
+	Name:        "alts",
+	Usage:       "alts <player>",
+	Description: "list the characters linked to a player's account (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may look up linked characters.", game.AnsiYellow)))
+		return false
+	}
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: alts <player>", game.AnsiYellow)))
+		return false
+	}
+	account, characters, ok := ctx.World.LinkedCharacters(target)
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo character or account by that name.", game.AnsiYellow)))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\nCharacters on account %s:", game.Style(account, game.AnsiCyan)))
+	for _, name := range characters {
+		line := "\r\n  " + game.HighlightName(name)
+		if _, online := ctx.World.ActivePlayer(name); online {
+			line += " " + game.Style("(online)", game.AnsiGreen)
+		}
+		builder.WriteString(line)
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
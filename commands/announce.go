@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Announce = Define(Definition{
+	Name:        "announce",
+	Usage:       "announce [in <delay>] [every <interval> [for <duration>]] <message> | announce preview ... | announce list | announce cancel <id>",
+	Description: "broadcast to everyone online now, later, or on a repeating schedule (admins only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may make announcements.", game.AnsiYellow)))
+		return false
+	}
+	fail := func(err error) bool {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	arg := strings.TrimSpace(ctx.Arg)
+	sub, rest, _ := strings.Cut(arg, " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(sub) {
+	case "":
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+		return false
+	case "list":
+		queue := ctx.World.AnnouncementSystem()
+		if queue == nil {
+			return fail(fmt.Errorf("scheduled announcements are unavailable"))
+		}
+		ctx.Player.Output.Send(game.Ansi(listAnnouncements(queue.Announcements())))
+		return false
+	case "cancel":
+		id, err := strconv.Atoi(strings.TrimPrefix(rest, "#"))
+		if err != nil || id <= 0 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: announce cancel <id>", game.AnsiYellow)))
+			return false
+		}
+		cancelled, err := ctx.World.CancelAnnouncement(id)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAnnouncement #%d cancelled after %d send(s).", cancelled.ID, cancelled.Sent)))
+		return false
+	case "preview":
+		a, err := parseAnnouncement(ctx.Player.Name, rest, time.Now())
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nPlayers will see:") + game.AnnouncementText(a.Message) +
+			game.Ansi(describeAnnouncementSchedule(a, time.Now())))
+		return false
+	}
+	now := time.Now()
+	a, err := parseAnnouncement(ctx.Player.Name, arg, now)
+	if err != nil {
+		return fail(err)
+	}
+	if !a.Recurring() && !a.Next.After(now) {
+		heard := ctx.World.Announce(a.Message)
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAnnounced to %d player(s).", heard)))
+		return false
+	}
+	scheduled, err := ctx.World.ScheduleAnnouncement(a)
+	if err != nil {
+		return fail(err)
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAnnouncement #%d scheduled.%s", scheduled.ID, describeAnnouncementSchedule(scheduled, now))))
+	return false
+})
+
+// parseAnnouncement reads the leading "in", "every", and "for" options of
+// an announce command and treats the rest as the message.
+func parseAnnouncement(author, arg string, now time.Time) (game.Announcement, error) {
+	var delay, every, span time.Duration
+	words := strings.Fields(arg)
+	for len(words) >= 2 {
+		d, ok := game.ParseModerationDuration(words[1])
+		if !ok {
+			break
+		}
+		switch strings.ToLower(words[0]) {
+		case "in":
+			delay = d
+		case "every":
+			every = d
+		case "for":
+			span = d
+		default:
+			ok = false
+		}
+		if !ok {
+			break
+		}
+		words = words[2:]
+	}
+	return game.NewAnnouncement(author, strings.Join(words, " "), now, delay, every, span)
+}
+
+func describeAnnouncementSchedule(a game.Announcement, now time.Time) string {
+	var b strings.Builder
+	switch {
+	case a.Recurring() && a.Until.IsZero():
+		b.WriteString(fmt.Sprintf("\r\nIt repeats every %s until cancelled.", formatPortalDuration(a.Every)))
+	case a.Recurring():
+		b.WriteString(fmt.Sprintf("\r\nIt repeats every %s until %s.", formatPortalDuration(a.Every), a.Until.Local().Format("2006-01-02 15:04")))
+	case a.Next.After(now):
+		b.WriteString(fmt.Sprintf("\r\nIt goes out once, in %s.", formatPortalDuration(a.Next.Sub(now))))
+	default:
+		b.WriteString("\r\nIt goes out at once.")
+	}
+	upcoming := a.Upcoming(5)
+	if a.Recurring() && len(upcoming) > 0 {
+		times := make([]string, 0, len(upcoming))
+		for _, at := range upcoming {
+			times = append(times, at.Local().Format("15:04:05"))
+		}
+		b.WriteString("\r\nNext sends: " + strings.Join(times, ", "))
+	}
+	return b.String()
+}
+
+func listAnnouncements(announcements []game.Announcement) string {
+	if len(announcements) == 0 {
+		return "\r\nNo announcements are scheduled."
+	}
+	var b strings.Builder
+	b.WriteString("\r\nScheduled announcements:")
+	for _, a := range announcements {
+		schedule := "once"
+		if a.Recurring() {
+			schedule = "every " + formatPortalDuration(a.Every)
+			if !a.Until.IsZero() {
+				schedule += " until " + a.Until.Local().Format("15:04")
+			}
+		}
+		text := a.Message
+		if runes := []rune(text); len(runes) > 40 {
+			text = string(runes[:37]) + "..."
+		}
+		b.WriteString(fmt.Sprintf("\r\n  #%-3d next %s, %s, by %s: %s", a.ID, a.Next.Local().Format("01-02 15:04"), schedule, a.Author, text))
+	}
+	b.WriteString("\r\nUse 'announce cancel <id>' to stop one.")
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestAnnounceCommand(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	announcements, err := game.NewAnnouncementSystem("")
+	if err != nil {
+		t.Fatalf("NewAnnouncementSystem: %v", err)
+	}
+	world.AttachAnnouncementSystem(announcements)
+	admin := newTestPlayer("Root", "start")
+	admin.IsAdmin = true
+	player := newTestPlayer("Ava", "start")
+	for _, p := range []*game.Player{admin, player} {
+		world.AddPlayerForTest(p)
+	}
+
+	Dispatch(world, player, "announce Free gold!")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Only admins may make announcements.") {
+		t.Fatalf("expected players to be refused, got %q", out)
+	}
+	Dispatch(world, admin, "announce Welcome to the festival.")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "[Announcement] Welcome to the festival.") {
+		t.Fatalf("expected the announcement at once, got %q", out)
+	}
+	drainOutput(admin.Output)
+
+	Dispatch(world, admin, "announce preview every 10m for 1h Restart at the hour.")
+	out := strings.Join(drainOutput(admin.Output), "")
+	if !strings.Contains(out, "Players will see: [Announcement] Restart at the hour.") || !strings.Contains(out, "It repeats every 10m until") {
+		t.Fatalf("expected a preview, got %q", out)
+	}
+	if out := strings.Join(drainOutput(player.Output), ""); out != "" {
+		t.Fatalf("expected a preview not to broadcast, got %q", out)
+	}
+
+	Dispatch(world, admin, "announce in 30m The bridge reopens.")
+	Dispatch(world, admin, "announce every 15s Spam")
+	Dispatch(world, admin, "announce list")
+	out = strings.Join(drainOutput(admin.Output), "")
+	if !strings.Contains(out, "Announcement #1 scheduled. It goes out once, in 30m.") || !strings.Contains(out, "Announcements may repeat at most every 1m.") || !strings.Contains(out, "#1") || !strings.Contains(out, "The bridge reopens.") {
+		t.Fatalf("expected the delayed announcement to be listed, got %q", out)
+	}
+	Dispatch(world, admin, "announce cancel 1")
+	Dispatch(world, admin, "announce list")
+	out = strings.Join(drainOutput(admin.Output), "")
+	if !strings.Contains(out, "Announcement #1 cancelled after 0 send(s).") || !strings.Contains(out, "No announcements are scheduled.") {
+		t.Fatalf("expected the announcement to be cancelled, got %q", out)
+	}
+}
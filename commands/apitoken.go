@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var APIToken = Define(Definition{
+	Name:        "apitoken",
+	Usage:       "apitoken <create <label>|list|revoke <id>>",
+	Description: "manage REST API tokens (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage API tokens.", game.AnsiYellow)))
+		return false
+	}
+	store := ctx.World.APITokens()
+	if store == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nAPI tokens are not configured.", game.AnsiYellow)))
+		return false
+	}
+	action, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(action) {
+	case "create", "new":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: apitoken create <label>", game.AnsiYellow)))
+			return false
+		}
+		token, secret, err := store.Issue(rest, ctx.Player.Name)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to create token: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nCreated API token %s for %s:\r\n  %s",
+			game.Style(token.ID, game.AnsiCyan), token.Label, game.Style(secret, game.AnsiBold))))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nStore this secret now; it will not be shown again.", game.AnsiYellow)))
+	case "list", "":
+		tokens := store.List()
+		if len(tokens) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nNo API tokens have been issued."))
+			return false
+		}
+		var builder strings.Builder
+		builder.WriteString("\r\nAPI tokens:")
+		for _, token := range tokens {
+			used := "never used"
+			if !token.LastUsed.IsZero() {
+				used = "last used " + token.LastUsed.Local().Format(time.RFC1123)
+			}
+			builder.WriteString(fmt.Sprintf("\r\n  %s  %s (by %s, %s)", game.Style(token.ID, game.AnsiCyan), token.Label, token.CreatedBy, used))
+		}
+		ctx.Player.Output.Send(game.Ansi(builder.String()))
+	case "revoke", "delete":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: apitoken revoke <id>", game.AnsiYellow)))
+			return false
+		}
+		if err := store.Revoke(rest); err != nil {
+			if errors.Is(err, game.ErrAPITokenNotFound) {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo API token has that id.", game.AnsiYellow)))
+			} else {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to revoke token: "+err.Error(), game.AnsiYellow)))
+			}
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAPI token %s revoked.", game.Style(rest, game.AnsiCyan))))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: apitoken <create <label>|list|revoke <id>>", game.AnsiYellow)))
+	}
+	return false
+})
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Areas = Define(Definition{
+	Name:        "areas",
+	Usage:       "areas [mine]",
+	Description: "list areas, or the areas you may build in (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may list areas.", game.AnsiYellow)))
+		return false
+	}
+	areas := ctx.World.Areas()
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "":
+	case "mine":
+		if ctx.Player.IsAdmin {
+			ctx.Player.Output.Send(game.Ansi("\r\nAdmins may build in every area."))
+			return false
+		}
+		if !ctx.World.BuilderScoped(ctx.Player.Name) {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou hold no area grants and may build in every area."))
+			return false
+		}
+		granted := ctx.World.BuilderAreas(ctx.Player.Name)
+		if len(granted) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou hold no area grants and may not build until an admin grants one."))
+			return false
+		}
+		mine := areas[:0:0]
+		for _, area := range areas {
+			for _, key := range granted {
+				if area.Key == key {
+					mine = append(mine, area)
+				}
+			}
+		}
+		areas = mine
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: areas [mine]", game.AnsiYellow)))
+		return false
+	}
+	if len(areas) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo areas found."))
+		return false
+	}
+	lines := make([]string, 0, len(areas)+1)
+	lines = append(lines, game.Style("Areas:", game.AnsiBold))
+	for _, area := range areas {
+		lines = append(lines, fmt.Sprintf("  area:%-16s %s (%d rooms)", area.Key, area.Name, area.Rooms))
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\n" + strings.Join(lines, "\r\n")))
+	return false
+})
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+const arenaLadderSize = 10
+
+var Duel = Define(Definition{
+	Name:        "duel",
+	Aliases:     []string{"challenge"},
+	Usage:       "duel [player|decline [player]|cancel]",
+	Description: "challenge another player to a duel in an arena, or accept their challenge",
+}, func(ctx *Context) bool {
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	name := game.HighlightName(ctx.Player.Name)
+	switch strings.ToLower(sub) {
+	case "decline", "cancel":
+		if strings.EqualFold(sub, "cancel") {
+			rest = ""
+		}
+		other, err := ctx.World.DeclineChallenge(ctx.Player, rest)
+		if err != nil {
+			duelError(ctx, err)
+			return false
+		}
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou withdraw your challenge."))
+			if other != nil && other.Output != nil {
+				other.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s withdraws their challenge.", name)))
+			}
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou decline %s's challenge.", game.HighlightName(other.Name))))
+		if other.Output != nil {
+			other.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s declines your challenge.", name)))
+		}
+	default:
+		target := strings.TrimSpace(ctx.Arg)
+		other, started, err := ctx.World.Challenge(ctx.Player, target)
+		if err != nil {
+			duelError(ctx, err)
+			return false
+		}
+		if other == nil {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou issue an open challenge to anyone in the arena."))
+			ctx.World.AnnounceChallenge(ctx.Player)
+			return false
+		}
+		if started {
+			ctx.Player.Output.Send(game.Prompt(ctx.Player))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou challenge %s to a duel.", game.HighlightName(other.Name))))
+		if other.Output != nil {
+			other.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s challenges you to a duel! Type 'duel %s' to accept or 'duel decline %s' to refuse.",
+				name, ctx.Player.Name, ctx.Player.Name)))
+		}
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s challenges %s to a duel!", name, game.HighlightName(other.Name))), ctx.Player, other)
+	}
+	return false
+})
+
+var Arena = Define(Definition{
+	Name:        "arena",
+	Usage:       "arena",
+	Description: "show your arena record and the arena ladder",
+}, func(ctx *Context) bool {
+	var builder strings.Builder
+	if record, ok := ctx.World.ArenaRecord(ctx.Player); ok {
+		builder.WriteString(fmt.Sprintf("\r\nYour arena record: %d wins, %d losses.", record.Wins, record.Losses))
+	}
+	ladder := ctx.World.ArenaLadder(arenaLadderSize)
+	if len(ladder) == 0 {
+		builder.WriteString("\r\nNo duels have been fought yet.")
+		ctx.Player.Output.Send(game.Ansi(builder.String()))
+		return false
+	}
+	builder.WriteString("\r\nArena ladder:")
+	for i, standing := range ladder {
+		builder.WriteString(fmt.Sprintf("\r\n  %2d. %-16s %3d-%d", i+1, standing.Account, standing.Wins, standing.Losses))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
+
+var RoomFlag = Define(Definition{
+	Name:        "roomflag",
+	Usage:       "roomflag [arena|peaceful|protected|noscry|clanhall|capture] [on|off]",
+	Description: "show or set whether the current room is an arena, peaceful, protected, warded against scrying, a clan hall, or a capture point (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use roomflag.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(strings.ToLower(ctx.Arg))
+	if len(fields) == 0 {
+		room, ok := ctx.World.GetRoom(ctx.Player.Room)
+		if !ok {
+			return false
+		}
+		var flags []string
+		if room.Arena {
+			flags = append(flags, "arena")
+		}
+		if room.Peaceful {
+			flags = append(flags, "peaceful")
+		}
+		if room.Protected {
+			flags = append(flags, "protected")
+		}
+		if room.NoScry {
+			flags = append(flags, "noscry")
+		}
+		if room.ClanHall {
+			flags = append(flags, "clanhall")
+		}
+		if room.CapturePoint {
+			flags = append(flags, "capture")
+		}
+		if len(flags) == 0 {
+			flags = append(flags, "none")
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nRoom flags: %s.", strings.Join(flags, ", "))))
+		return false
+	}
+	if len(fields) != 2 || (fields[1] != "on" && fields[1] != "off") {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: roomflag [arena|peaceful|protected|noscry|clanhall|capture] [on|off]", game.AnsiYellow)))
+		return false
+	}
+	on := fields[1] == "on"
+	var err error
+	switch fields[0] {
+	case "arena":
+		err = ctx.World.SetRoomArena(ctx.Player.Room, on, ctx.Player.Name)
+	case "peaceful":
+		err = ctx.World.SetRoomPeaceful(ctx.Player.Room, on, ctx.Player.Name)
+	case "protected":
+		err = ctx.World.SetRoomProtected(ctx.Player.Room, on, ctx.Player.Name)
+	case "noscry":
+		err = ctx.World.SetRoomNoScry(ctx.Player.Room, on, ctx.Player.Name)
+	case "clanhall":
+		err = ctx.World.SetRoomClanHall(ctx.Player.Room, on, ctx.Player.Name)
+	case "capture":
+		err = ctx.World.SetRoomCapturePoint(ctx.Player.Room, on, ctx.Player.Name)
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: roomflag [arena|peaceful|protected|noscry|clanhall|capture] [on|off]", game.AnsiYellow)))
+		return false
+	}
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe %s flag is now %s here.", fields[0], fields[1])))
+	return false
+})
+
+func duelError(ctx *Context, err error) {
+	if errors.Is(err, game.ErrNoChallenge) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo one by that name has challenged you.", game.AnsiYellow)))
+		return
+	}
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
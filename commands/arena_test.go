@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestDuelCommandChallengesAndDeclines(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"pit": {ID: "pit", Title: "Pit", Arena: true, Exits: map[string]game.Exit{}},
+	})
+	alpha := newTestPlayer("Alpha", "pit")
+	bravo := newTestPlayer("Bravo", "pit")
+	world.AddPlayerForTest(alpha)
+	world.AddPlayerForTest(bravo)
+
+	Dispatch(world, alpha, "duel bravo")
+	if msgs := strings.Join(drainOutput(bravo.Output), " "); !strings.Contains(msgs, "challenges you to a duel") {
+		t.Fatalf("bravo was not challenged: %q", msgs)
+	}
+	Dispatch(world, bravo, "duel decline alpha")
+	if msgs := strings.Join(drainOutput(alpha.Output), " "); !strings.Contains(msgs, "declines your challenge") {
+		t.Fatalf("alpha was not told of the refusal: %q", msgs)
+	}
+	Dispatch(world, bravo, "duel decline alpha")
+	if msgs := strings.Join(drainOutput(bravo.Output), " "); !strings.Contains(msgs, "No one by that name has challenged you") {
+		t.Fatalf("expected missing challenge warning, got %q", msgs)
+	}
+	Dispatch(world, alpha, "arena")
+	if msgs := strings.Join(drainOutput(alpha.Output), " "); !strings.Contains(msgs, "No duels have been fought yet") {
+		t.Fatalf("unexpected ladder %q", msgs)
+	}
+}
+
+func TestRoomFlagCommand(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	builder := newTestPlayer("Builder", "start")
+	builder.IsBuilder = true
+	player := newTestPlayer("Player", "start")
+	world.AddPlayerForTest(builder)
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "roomflag peaceful on")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Only builders or admins may use roomflag") {
+		t.Fatalf("expected builder-only warning, got %q", msgs)
+	}
+	Dispatch(world, builder, "roomflag peaceful on")
+	drainOutput(builder.Output)
+	Dispatch(world, builder, "roomflag")
+	if msgs := strings.Join(drainOutput(builder.Output), " "); !strings.Contains(msgs, "Room flags: peaceful.") {
+		t.Fatalf("unexpected flags %q", msgs)
+	}
+	if room, _ := world.GetRoom("start"); !room.Peaceful {
+		t.Fatalf("room should be peaceful")
+	}
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+const auditListLimit = 20
+
+var Audit = Define(Definition{
+	Name:        "audit",
+	Usage:       "audit [search] | audit show <id>",
+	Description: "review the staff audit trail (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may read the audit trail.", game.AnsiYellow)))
+		return false
+	}
+	log := ctx.World.AuditLog()
+	if log == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe audit trail is not configured.", game.AnsiYellow)))
+		return false
+	}
+	arg := strings.TrimSpace(ctx.Arg)
+	if fields := strings.Fields(arg); len(fields) > 0 && strings.EqualFold(fields[0], "show") {
+		if len(fields) != 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: audit show <id>", game.AnsiYellow)))
+			return false
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: audit show <id>", game.AnsiYellow)))
+			return false
+		}
+		entry, ok := log.Entry(id)
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nNo audit entry #%d is retained.", id), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(formatAuditEntry(entry)))
+		return false
+	}
+	entries := log.Entries(game.AuditFilter{Search: arg, Limit: auditListLimit})
+	if len(entries) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo matching audit entries."))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString(game.Style("\r\nRecent staff actions:", game.AnsiBold))
+	for _, entry := range entries {
+		builder.WriteString("\r\n  " + auditSummary(entry))
+	}
+	builder.WriteString("\r\nUse 'audit show <id>' to see what changed.")
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
+
+func auditSummary(entry game.AuditEntry) string {
+	line := fmt.Sprintf("#%d %s %s %s", entry.ID, entry.Time.Local().Format("2006-01-02 15:04"), game.HighlightName(entry.Actor), entry.Action)
+	if entry.Target != "" {
+		line += " " + entry.Target
+	}
+	if entry.Detail != "" {
+		line += " (" + entry.Detail + ")"
+	}
+	return line
+}
+
+func formatAuditEntry(entry game.AuditEntry) string {
+	var builder strings.Builder
+	builder.WriteString("\r\n" + auditSummary(entry))
+	diff := entry.Diff()
+	if len(diff) == 0 {
+		return builder.String()
+	}
+	builder.WriteString("\r\n" + game.Style("Changes:", game.AnsiBold))
+	for _, line := range diff {
+		switch {
+		case strings.HasPrefix(line, "+ "):
+			line = game.Style(line, game.AnsiGreen)
+		case strings.HasPrefix(line, "- "):
+			line = game.Style(line, game.AnsiRed)
+		}
+		builder.WriteString("\r\n  " + line)
+	}
+	return builder.String()
+}
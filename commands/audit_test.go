@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestAuditRecordsStaffCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Starting Room", Description: "The central hub.", Exits: map[string]game.Exit{}},
+	})
+	log, err := game.NewAuditLog("")
+	if err != nil {
+		t.Fatalf("NewAuditLog error: %v", err)
+	}
+	world.AttachAuditLog(log)
+	admin := newTestPlayer("Admin", "start")
+	admin.IsAdmin = true
+	player := newTestPlayer("Target", "start")
+	world.AddPlayerForTest(admin)
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "audit")
+	Dispatch(world, player, "look")
+	if entries := log.Entries(game.AuditFilter{}); len(entries) != 0 {
+		t.Fatalf("non-staff commands should not be audited: %+v", entries)
+	}
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Only admins may read the audit trail") {
+		t.Fatalf("expected refusal, got %q", out)
+	}
+
+	Dispatch(world, admin, "builder Target on")
+	drainOutput(admin.Output)
+	Dispatch(world, admin, "audit builder")
+	out := ansiPattern.ReplaceAllString(strings.Join(drainOutput(admin.Output), ""), "")
+	if !strings.Contains(out, "#1") || !strings.Contains(out, "Admin command builder (builder Target on)") {
+		t.Fatalf("audit listing missing the builder command: %q", out)
+	}
+
+	if _, err := world.UpdateRoomDescription("start", "The quiet hub.", "Admin"); err != nil {
+		t.Fatalf("UpdateRoomDescription error: %v", err)
+	}
+	edit := log.Entries(game.AuditFilter{Action: game.AuditRoomEdit})[0]
+	Dispatch(world, admin, "audit show "+strconv.Itoa(edit.ID))
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(admin.Output), ""), "")
+	if !strings.Contains(out, "- The central hub.") || !strings.Contains(out, "+ The quiet hub.") {
+		t.Fatalf("audit show missing the diff: %q", out)
+	}
+}
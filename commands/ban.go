@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Ban = Define(Definition{
+	Name:        "ban",
+	Usage:       "ban <player|ip[/cidr]> [reason]",
+	Description: "ban an account or address and disconnect it (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may ban players.", game.AnsiYellow)))
+		return false
+	}
+	bans := ctx.World.BanList()
+	if bans == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nBans are not configured.", game.AnsiYellow)))
+		return false
+	}
+	target, reason, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	reason = strings.TrimSpace(reason)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: ban <player|ip[/cidr]> [reason]", game.AnsiYellow)))
+		return false
+	}
+	kickReason := "banned"
+	if reason != "" {
+		kickReason = fmt.Sprintf("banned (%s)", reason)
+	}
+	if game.IsIPBanTarget(target) {
+		entry, err := bans.BanIP(target, reason, ctx.Player.Name)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to ban address: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		kicked := ctx.World.KickBannedAddresses(kickReason)
+		message := fmt.Sprintf("\r\nConnections from %s are now banned.", game.Style(entry.Target, game.AnsiCyan))
+		if len(kicked) > 0 {
+			message += " Disconnected: " + strings.Join(kicked, ", ") + "."
+		}
+		ctx.Player.Output.Send(game.Ansi(message))
+		return false
+	}
+	if strings.EqualFold(target, ctx.Player.Name) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou cannot ban yourself.", game.AnsiYellow)))
+		return false
+	}
+	if online, found := ctx.World.FindPlayer(target); found {
+		target = online.Name
+	}
+	entry, err := bans.BanAccount(target, reason, ctx.Player.Name)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to ban account: "+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	message := fmt.Sprintf("\r\n%s is now banned.", game.HighlightName(entry.Target))
+	if _, err := ctx.World.KickPlayer(entry.Target, kickReason); err == nil {
+		message += " They have been disconnected."
+	}
+	ctx.Player.Output.Send(game.Ansi(message))
+	return false
+})
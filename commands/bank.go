@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Deposit = Define(Definition{
+	Name:        "deposit",
+	Usage:       "deposit <amount|item>",
+	Description: "store gold or an item with a banker",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: deposit <amount|item>", game.AnsiYellow)))
+		return false
+	}
+	if amount, ok := parseGold(arg); ok {
+		if err := ctx.World.DepositGold(ctx.Player, amount); err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+bankError(err), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou deposit %s.", game.Style(fmt.Sprintf("%d gold", amount), game.AnsiYellow))))
+		return false
+	}
+	item, err := ctx.World.DepositItem(ctx.Player, arg)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+bankError(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou place %s in your vault.", game.HighlightItemName(item.Name))))
+	return false
+})
+
+var Withdraw = Define(Definition{
+	Name:        "withdraw",
+	Usage:       "withdraw <amount|item>",
+	Description: "take gold or an item back from a banker",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: withdraw <amount|item>", game.AnsiYellow)))
+		return false
+	}
+	if amount, ok := parseGold(arg); ok {
+		if err := ctx.World.WithdrawGold(ctx.Player, amount); err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+bankError(err), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou withdraw %s.", game.Style(fmt.Sprintf("%d gold", amount), game.AnsiYellow))))
+		return false
+	}
+	item, err := ctx.World.WithdrawItem(ctx.Player, arg)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+bankError(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou take %s from your vault.", game.HighlightItemName(item.Name))))
+	return false
+})
+
+var Balance = Define(Definition{
+	Name:        "balance",
+	Usage:       "balance",
+	Description: "show the gold and items in your bank",
+}, func(ctx *Context) bool {
+	bank, err := ctx.World.BankStatement(ctx.Player)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+bankError(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(formatBank(bank) + formatRent(ctx.World, bank)))
+	return false
+})
+
+var BankAudit = Define(Definition{
+	Name:        "bankaudit",
+	Usage:       "bankaudit <player>",
+	Description: "review the bank shared by a player's account (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may audit banks.", game.AnsiYellow)))
+		return false
+	}
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: bankaudit <player>", game.AnsiYellow)))
+		return false
+	}
+	audit, err := ctx.World.AuditBank(target)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo character or account by that name.", game.AnsiYellow)))
+		return false
+	}
+	names := make([]string, len(audit.Characters))
+	for i, name := range audit.Characters {
+		names[i] = game.HighlightName(name)
+	}
+	header := fmt.Sprintf("\r\nBank for account %s (%s):", game.Style(audit.Account, game.AnsiCyan), strings.Join(names, ", "))
+	ctx.Player.Output.Send(game.Ansi(header + formatBank(audit.Bank) + formatRent(ctx.World, audit.Bank)))
+	return false
+})
+
+// parseGold reads a deposit or withdrawal amount such as "25" or "25 gold".
+func parseGold(arg string) (int, bool) {
+	fields := strings.Fields(arg)
+	if len(fields) == 2 && strings.EqualFold(fields[1], "gold") {
+		fields = fields[:1]
+	}
+	if len(fields) != 1 {
+		return 0, false
+	}
+	amount, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false
+	}
+	return amount, true
+}
+
+func formatBank(bank game.Bank) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\nGold on deposit: %s", game.Style(strconv.Itoa(bank.Gold), game.AnsiYellow)))
+	if len(bank.Vault) == 0 {
+		builder.WriteString(fmt.Sprintf("\r\nVault (0/%d): empty", game.VaultCapacity))
+		return builder.String()
+	}
+	names := make([]string, len(bank.Vault))
+	for i, item := range bank.Vault {
+		names[i] = game.HighlightItemName(item.Name)
+	}
+	builder.WriteString(fmt.Sprintf("\r\nVault (%d/%d): %s", len(bank.Vault), game.VaultCapacity, strings.Join(names, ", ")))
+	return builder.String()
+}
+
+func bankError(err error) string {
+	switch {
+	case errors.Is(err, game.ErrNoBanker):
+		return "There is no banker here."
+	case errors.Is(err, game.ErrInsufficientGold):
+		return "You don't have that much gold."
+	case errors.Is(err, game.ErrVaultFull):
+		return "Your vault is full."
+	case errors.Is(err, game.ErrVaultItemNotFound):
+		return "Your vault holds nothing by that name."
+	case errors.Is(err, game.ErrItemNotCarried):
+		return "You aren't carrying that."
+	case errors.Is(err, game.ErrTooHeavy):
+		return tooHeavyMessage
+	}
+	msg := err.Error()
+	if msg == "" {
+		return "The banker cannot help with that."
+	}
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
+
+// formatRent describes what the vault costs in rent, if anything.
+func formatRent(world *game.World, bank game.Bank) string {
+	daily, owed := world.VaultRent(bank)
+	if daily == 0 && owed == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("\r\nVault rent: %d gold a day, collected when you log in.", daily)
+	if owed > 0 {
+		line += " " + game.Style(fmt.Sprintf("Owed: %d gold.", owed), game.AnsiYellow)
+	}
+	return line
+}
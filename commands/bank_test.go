@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestBankCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"bank": {
+			ID:    "bank",
+			Title: "Bank",
+			NPCs:  []game.NPC{{Name: "Teller", Banker: true}},
+			Exits: map[string]game.Exit{},
+		},
+	})
+	accounts, err := game.NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Saver", "password"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+
+	saver := newTestPlayer("Saver", "bank")
+	saver.Gold = 12
+	saver.Inventory = []game.Item{{Name: "Amber Ring"}}
+	world.AddPlayerForTest(saver)
+
+	Dispatch(world, saver, "deposit 10 gold")
+	Dispatch(world, saver, "deposit amber")
+	drainOutput(saver.Output)
+
+	Dispatch(world, saver, "balance")
+	output := strings.Join(drainOutput(saver.Output), "\n")
+	if !strings.Contains(output, "Gold on deposit: 10") || !strings.Contains(output, "Amber Ring") {
+		t.Fatalf("unexpected balance: %q", output)
+	}
+
+	Dispatch(world, saver, "inventory")
+	output = strings.Join(drainOutput(saver.Output), "\n")
+	if !strings.Contains(output, "Your purse holds 2 gold.") {
+		t.Fatalf("expected purse in inventory, got %q", output)
+	}
+
+	Dispatch(world, saver, "withdraw 20")
+	output = strings.Join(drainOutput(saver.Output), "\n")
+	if !strings.Contains(output, "You don't have that much gold.") {
+		t.Fatalf("expected overdraw refusal, got %q", output)
+	}
+
+	Dispatch(world, saver, "bankaudit Saver")
+	output = strings.Join(drainOutput(saver.Output), "\n")
+	if !strings.Contains(output, "Only staff may audit banks.") {
+		t.Fatalf("expected staff-only refusal, got %q", output)
+	}
+
+	moderator := newTestPlayer("Warden", "bank")
+	moderator.IsModerator = true
+	world.AddPlayerForTest(moderator)
+	Dispatch(world, moderator, "bankaudit saver")
+	output = strings.Join(drainOutput(moderator.Output), "\n")
+	if !strings.Contains(output, "Bank for account Saver") || !strings.Contains(output, "Gold on deposit: 10") {
+		t.Fatalf("unexpected audit: %q", output)
+	}
+}
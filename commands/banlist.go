@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var BanList = Define(Definition{
+	Name:        "banlist",
+	Usage:       "banlist",
+	Description: "list banned accounts and addresses (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may review bans.", game.AnsiYellow)))
+		return false
+	}
+	bans := ctx.World.BanList()
+	if bans == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nBans are not configured.", game.AnsiYellow)))
+		return false
+	}
+	accounts := bans.AccountBans()
+	ips := bans.IPBans()
+	if len(accounts) == 0 && len(ips) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo bans are in effect."))
+		return false
+	}
+	var builder strings.Builder
+	writeSection := func(title string, entries []game.BanEntry) {
+		if len(entries) == 0 {
+			return
+		}
+		builder.WriteString("\r\n" + title + ":")
+		for _, entry := range entries {
+			line := fmt.Sprintf("\r\n  %s (by %s, %s)", game.Style(entry.Target, game.AnsiCyan), entry.IssuedBy, entry.CreatedAt.Local().Format(time.RFC1123))
+			if entry.Reason != "" {
+				line += " - " + entry.Reason
+			}
+			builder.WriteString(line)
+		}
+	}
+	writeSection("Banned accounts", accounts)
+	writeSection("Banned addresses", ips)
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Bio = Define(Definition{
+	Name:        "bio",
+	Usage:       "bio [edit|set <text>|clear] | bio flag|unflag|clear <player> [reason] (staff)",
+	Description: "write the description others see when they look at you",
+}, func(ctx *Context) bool {
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	warn := func(msg string) bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+msg, game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(sub) {
+	case "":
+		ctx.Player.Output.Send(game.Ansi(bioSummary(ctx.Player)))
+	case "edit":
+		openBioEditor(ctx)
+	case "set":
+		if rest == "" {
+			return warn("Usage: bio set <text>")
+		}
+		if err := ctx.World.SetBio(ctx.Player, rest); err != nil {
+			return warn(bioError(err))
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nDescription saved."))
+	case "clear":
+		if rest == "" {
+			if err := ctx.World.SetBio(ctx.Player, ""); err != nil {
+				return warn(bioError(err))
+			}
+			ctx.Player.Output.Send(game.Ansi("\r\nDescription cleared."))
+			return false
+		}
+		fallthrough
+	case "flag", "unflag":
+		if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+			return warn("Only staff may moderate descriptions.")
+		}
+		name, reason, _ := strings.Cut(rest, " ")
+		if name == "" {
+			return warn(fmt.Sprintf("Usage: bio %s <player> [reason]", strings.ToLower(sub)))
+		}
+		var target *game.Player
+		var err error
+		switch strings.ToLower(sub) {
+		case "flag":
+			target, err = ctx.World.FlagBio(ctx.Player.Name, name, reason)
+		case "unflag":
+			target, err = ctx.World.UnflagBio(ctx.Player.Name, name)
+		default:
+			target, err = ctx.World.ClearBio(ctx.Player.Name, name, reason)
+		}
+		if target == nil {
+			return warn(bioError(err))
+		}
+		switch strings.ToLower(sub) {
+		case "flag":
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s's description is hidden until they rewrite it.", game.HighlightName(target.Name))))
+			target.Output.Send(game.Ansi(game.Style("\r\nStaff hid your description ("+target.BioFlag+"). Use 'bio edit' to rewrite it.", game.AnsiYellow)))
+		case "unflag":
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s's description is visible again.", game.HighlightName(target.Name))))
+		default:
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nCleared %s's description.", game.HighlightName(target.Name))))
+			target.Output.Send(game.Ansi(game.Style("\r\nStaff cleared your description.", game.AnsiYellow)))
+		}
+		if err != nil {
+			return warn("The moderation log could not be saved: " + err.Error())
+		}
+	default:
+		return warn("Usage: " + ctx.Command.Usage)
+	}
+	return false
+})
+
+const bioEditorHelp = `Description editor:
+  <text>    add a line
+  /show     show the description so far
+  /undo     remove the last line
+  /clear    start over
+  /save     save the description and leave (also: .)
+  /abort    leave without saving`
+
+func openBioEditor(ctx *Context) {
+	draft := ctx.World.OpenBioDraft(ctx.Player)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nEditing your description (%d of %d lines used).", len(draft.Lines), game.MaxBioLines)))
+	ctx.Player.Output.Send(game.Ansi("\r\nType each line, then '/save' or '.' to finish. '/help' lists editor commands."))
+}
+
+// bioInput handles a line typed while the player has the description editor
+// open.
+func bioInput(world *game.World, player *game.Player, line string) bool {
+	draft := player.BioDraft()
+	line = strings.TrimSpace(line)
+	warn := func(msg string) bool {
+		player.Output.Send(game.Ansi(game.Style("\r\n"+msg, game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(line) {
+	case "/help", "/?":
+		player.Output.Send(game.Ansi("\r\n" + strings.ReplaceAll(bioEditorHelp, "\n", "\r\n")))
+	case "/show":
+		if len(draft.Lines) == 0 {
+			player.Output.Send(game.Ansi("\r\nThe description is empty."))
+			return false
+		}
+		var builder strings.Builder
+		for i, text := range draft.Lines {
+			builder.WriteString(fmt.Sprintf("\r\n%2d  %s", i+1, text))
+		}
+		player.Output.Send(game.Ansi(builder.String()))
+	case "/undo":
+		if len(draft.Lines) == 0 {
+			return warn("There is nothing to undo.")
+		}
+		draft.Lines = draft.Lines[:len(draft.Lines)-1]
+		player.Output.Send(game.Ansi("\r\nRemoved the last line."))
+	case "/clear":
+		draft.Lines = nil
+		player.Output.Send(game.Ansi("\r\nDescription emptied."))
+	case "/save", ".":
+		if err := world.SaveBioDraft(player); err != nil {
+			return warn(bioError(err))
+		}
+		player.Output.Send(game.Ansi("\r\nDescription saved."))
+	case "/abort", "/quit":
+		world.CloseBioDraft(player)
+		player.Output.Send(game.Ansi("\r\nLeft the editor without saving."))
+	default:
+		if strings.HasPrefix(line, "/") {
+			return warn("Unknown editor command. Type '/help', or '/abort' to leave the editor.")
+		}
+		next := append(append([]string(nil), draft.Lines...), line)
+		if err := game.ValidateBio(strings.Join(next, "\n")); err != nil {
+			return warn(bioError(err))
+		}
+		draft.Lines = next
+	}
+	return false
+}
+
+func bioSummary(player *game.Player) string {
+	if player.Bio == "" {
+		return "\r\nYou have no description. Use 'bio edit' or 'describe me' to write one."
+	}
+	summary := "\r\nYour description:\r\n" + strings.ReplaceAll(player.Bio, "\n", "\r\n")
+	if player.BioFlag != "" {
+		summary += game.Style("\r\nStaff hid it from others ("+player.BioFlag+"). Rewrite it to show it again.", game.AnsiYellow)
+	}
+	return summary
+}
+
+func bioError(err error) string {
+	msg := err.Error()
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
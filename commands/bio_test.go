@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestBioEditorAndLook(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "A Quiet Chamber", Exits: map[string]game.Exit{}},
+	})
+	hero := newTestPlayer("Hero", "start")
+	watcher := newTestPlayer("Watcher", "start")
+	world.AddPlayerForTest(hero)
+	world.AddPlayerForTest(watcher)
+
+	Dispatch(world, hero, "describe me")
+	if hero.BioDraft() == nil {
+		t.Fatalf("describe me should open the description editor")
+	}
+	Dispatch(world, hero, "A tall wanderer in a grey cloak.")
+	Dispatch(world, hero, "Mud cakes their boots.")
+	Dispatch(world, hero, "oops")
+	Dispatch(world, hero, "/undo")
+	Dispatch(world, hero, ".")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, "Description saved.") {
+		t.Fatalf("editor output = %q", output)
+	}
+	if hero.BioDraft() != nil || hero.Bio != "A tall wanderer in a grey cloak.\nMud cakes their boots." {
+		t.Fatalf("bio = %q, draft open = %v", hero.Bio, hero.BioDraft() != nil)
+	}
+
+	Dispatch(world, watcher, "look her")
+	output := strings.Join(drainOutput(watcher.Output), "\n")
+	if !strings.Contains(output, "You look at Hero.") || !strings.Contains(output, "Mud cakes their boots.") {
+		t.Fatalf("look output = %q", output)
+	}
+
+	mod := newTestPlayer("Mod", "start")
+	mod.IsModerator = true
+	world.AddPlayerForTest(mod)
+	Dispatch(world, mod, "bio flag hero rude")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, "Staff hid your description (rude).") {
+		t.Fatalf("flag notice = %q", output)
+	}
+	Dispatch(world, watcher, "look hero")
+	if output := strings.Join(drainOutput(watcher.Output), "\n"); strings.Contains(output, "grey cloak") {
+		t.Fatalf("flagged bio should be hidden: %q", output)
+	}
+	Dispatch(world, watcher, "bio clear hero")
+	if output := strings.Join(drainOutput(watcher.Output), "\n"); !strings.Contains(output, "Only staff may moderate descriptions.") {
+		t.Fatalf("player moderation output = %q", output)
+	}
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Bounty = Define(Definition{
+	Name:        "bounty",
+	Aliases:     []string{"wanted"},
+	Usage:       "bounty [set <player> <gold>]",
+	Description: "list the players with a bounty on their head; staff can set a bounty",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) > 0 && strings.EqualFold(fields[0], "set") {
+		if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may set bounties.", game.AnsiYellow)))
+			return false
+		}
+		if len(fields) != 3 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: bounty set <player> <gold>", game.AnsiYellow)))
+			return false
+		}
+		amount, ok := parseGold(fields[2])
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: bounty set <player> <gold>", game.AnsiYellow)))
+			return false
+		}
+		target, err := ctx.World.SetBounty(fields[1], amount)
+		if err != nil {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+			return false
+		}
+		name := game.HighlightName(target.Name)
+		if amount == 0 {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou pardon %s.", name)))
+			if target.Output != nil && target != ctx.Player {
+				target.Output.Send(game.Ansi("\r\nYou have been pardoned. The bounty on your head is lifted."))
+			}
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe bounty on %s is now %d gold.", name, amount)))
+		if target.Output != nil && target != ctx.Player {
+			target.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe bounty on your head is now %d gold.", amount)))
+		}
+		return false
+	}
+	var builder strings.Builder
+	if own := ctx.World.Bounty(ctx.Player); own > 0 {
+		builder.WriteString(fmt.Sprintf("\r\nThere is a bounty of %s gold on your head.", game.Style(strconv.Itoa(own), game.AnsiYellow)))
+	}
+	wanted := ctx.World.WantedPlayers()
+	if len(wanted) == 0 {
+		builder.WriteString("\r\nNo one is wanted right now.")
+		ctx.Player.Output.Send(game.Ansi(builder.String()))
+		return false
+	}
+	builder.WriteString("\r\nWanted:")
+	for _, entry := range wanted {
+		builder.WriteString(fmt.Sprintf("\r\n  %s - %d gold", game.HighlightName(entry.Name), entry.Bounty))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestBountyCommandSetsAndLists(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	mod := newTestPlayer("Mod", "start")
+	mod.IsModerator = true
+	player := newTestPlayer("Player", "start")
+	world.AddPlayerForTest(mod)
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "bounty set mod 50")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Only staff may set bounties") {
+		t.Fatalf("expected staff-only warning, got %q", msgs)
+	}
+	Dispatch(world, player, "wanted")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "No one is wanted right now") {
+		t.Fatalf("unexpected wanted list %q", msgs)
+	}
+	Dispatch(world, mod, "bounty set player 75")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "The bounty on your head is now 75 gold") {
+		t.Fatalf("player was not told of the bounty: %q", msgs)
+	}
+	Dispatch(world, player, "bounty")
+	msgs := ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), " "), "")
+	if !strings.Contains(msgs, "There is a bounty of 75 gold on your head") || !strings.Contains(msgs, "Player - 75 gold") {
+		t.Fatalf("unexpected bounty listing %q", msgs)
+	}
+	Dispatch(world, mod, "bounty set player 0")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "You have been pardoned") {
+		t.Fatalf("player was not pardoned: %q", msgs)
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Bridge = Define(Definition{
+	Name:        "bridge",
+	Usage:       "bridge [on|off|status]",
+	Description: "pause or resume the Discord chat bridge (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage the chat bridge.", game.AnsiYellow)))
+		return false
+	}
+	bridge := ctx.World.Bridge()
+	if bridge == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe Discord bridge is not configured.", game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "on", "enable", "enabled", "resume":
+		bridge.SetEnabled(true)
+		ctx.Player.Output.Send(game.Ansi("\r\nThe Discord bridge is now relaying."))
+	case "off", "disable", "disabled", "pause":
+		bridge.SetEnabled(false)
+		ctx.Player.Output.Send(game.Ansi("\r\nThe Discord bridge is paused."))
+	case "", "status":
+		status := bridge.Status()
+		state := game.Style("paused", game.AnsiYellow)
+		if status.Enabled {
+			state = game.Style("relaying", game.AnsiGreen)
+		}
+		names := make([]string, 0, len(status.Channels))
+		for _, channel := range status.Channels {
+			names = append(names, strings.ToUpper(string(channel)))
+		}
+		directions := make([]string, 0, 2)
+		if status.Outbound {
+			directions = append(directions, "to Discord")
+		}
+		if status.Inbound {
+			directions = append(directions, "from Discord")
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nDiscord bridge: %s\r\n  Channels: %s\r\n  Direction: %s",
+			state, strings.Join(names, ", "), strings.Join(directions, " and "))))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: bridge [on|off|status]", game.AnsiYellow)))
+	}
+	return false
+})
@@ -14,12 +14,12 @@ var Builder = Define(Definition{
 	Group:       GroupAdmin,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly admins may manage builders.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage builders.", game.AnsiYellow)))
 		return false
 	}
 	parts := strings.Fields(ctx.Arg)
 	if len(parts) != 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: builder <player> <on|off>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: builder <player> <on|off>", game.AnsiYellow)))
 		return false
 	}
 	targetName := parts[0]
@@ -31,23 +31,23 @@ var Builder = Define(Definition{
 	case "off", "disable", "disabled", "false", "revoke":
 		enable = false
 	default:
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: builder <player> <on|off>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: builder <player> <on|off>", game.AnsiYellow)))
 		return false
 	}
 	target, err := ctx.World.SetBuilder(targetName, enable)
 	if err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	state := "no longer"
 	if enable {
 		state = "now"
 	}
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n%s is %s a builder.", game.HighlightName(target.Name), state))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s is %s a builder.", game.HighlightName(target.Name), state)))
 	notice := "\r\nYou are now a builder."
 	if !enable {
 		notice = "\r\nYou are no longer a builder."
 	}
-	target.Output <- game.Ansi(notice)
+	target.Output.Send(game.Ansi(notice))
 	return false
 })
@@ -9,10 +9,10 @@ var BuildHelp = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsBuilder && !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may view building commands.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may view building commands.", game.AnsiYellow)))
 		return false
 	}
 	cmds := commandsForGroup(GroupBuilder)
-	ctx.Player.Output <- game.Ansi(helpMessage("Building Commands:", cmds))
+	ctx.Player.Output.Send(game.Ansi(helpMessage("Building Commands:", cmds)))
 	return false
 })
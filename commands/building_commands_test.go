@@ -13,7 +13,7 @@ func TestDigRequiresBuilder(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Seeker", "start")
@@ -40,7 +40,7 @@ func TestDigCreatesRoom(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -75,7 +75,7 @@ func TestDescribeUpdatesRoom(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -100,13 +100,13 @@ func TestSetExitAndClearExit(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 		"hall": {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "Long hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -117,7 +117,7 @@ func TestSetExitAndClearExit(t *testing.T) {
 		t.Fatalf("dispatch returned true, want false")
 	}
 	start, _ := world.GetRoom("start")
-	if dest, ok := start.Exits["north"]; !ok || dest != "hall" {
+	if dest, ok := start.Exits["north"]; !ok || dest.To != "hall" {
 		t.Fatalf("exit not set correctly: %v", start.Exits)
 	}
 	if quit := Dispatch(world, builder, "setexit north none"); quit {
@@ -129,19 +129,61 @@ func TestSetExitAndClearExit(t *testing.T) {
 	}
 }
 
+func TestDoorCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{"north": {To: "hall"}}},
+		"hall":  {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{"south": {To: "start"}}},
+	})
+	builder := newTestPlayer("Builder", "start")
+	builder.IsBuilder = true
+	world.AddPlayerForTest(builder)
+
+	Dispatch(world, builder, "door north locked key Iron Key")
+	start, _ := world.GetRoom("start")
+	if exit := start.Exits["north"]; !exit.Door || !exit.Locked || exit.Key != "Iron Key" {
+		t.Fatalf("door not fitted: %+v", exit)
+	}
+	drainOutput(builder.Output)
+
+	Dispatch(world, builder, "go north")
+	if output := strings.Join(drainOutput(builder.Output), "\n"); !strings.Contains(output, "door is closed") {
+		t.Fatalf("expected closed door to block movement, got %q", output)
+	}
+	Dispatch(world, builder, "unlock north")
+	if output := strings.Join(drainOutput(builder.Output), "\n"); !strings.Contains(output, "don't have the key") {
+		t.Fatalf("expected missing key message, got %q", output)
+	}
+	builder.Inventory = []game.Item{{Name: "Iron Key"}}
+	Dispatch(world, builder, "unlock north")
+	Dispatch(world, builder, "open north")
+	if output := strings.Join(drainOutput(builder.Output), "\n"); !strings.Contains(output, "You open the north door.") {
+		t.Fatalf("expected door to open, got %q", output)
+	}
+	Dispatch(world, builder, "go north")
+	if builder.Room != "hall" {
+		t.Fatalf("expected to walk through the open door, still in %s", builder.Room)
+	}
+
+	Dispatch(world, builder, "door south none")
+	hall, _ := world.GetRoom("hall")
+	if hall.Exits["south"].Door {
+		t.Fatalf("door should be removed")
+	}
+}
+
 func TestLinkCreatesBidirectionalExits(t *testing.T) {
 	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
 		"start": {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 		"hall": {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "Long hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -152,11 +194,11 @@ func TestLinkCreatesBidirectionalExits(t *testing.T) {
 		t.Fatalf("dispatch returned true, want false")
 	}
 	start, _ := world.GetRoom("start")
-	if dest, ok := start.Exits["east"]; !ok || dest != "hall" {
+	if dest, ok := start.Exits["east"]; !ok || dest.To != "hall" {
 		t.Fatalf("forward exit incorrect: %v", start.Exits)
 	}
 	hall, _ := world.GetRoom("hall")
-	if dest, ok := hall.Exits["west"]; !ok || dest != "start" {
+	if dest, ok := hall.Exits["west"]; !ok || dest.To != "start" {
 		t.Fatalf("reverse exit incorrect: %v", hall.Exits)
 	}
 }
@@ -167,7 +209,7 @@ func TestResetRequiresBuilder(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Visitor", "start")
@@ -194,7 +236,7 @@ func TestResetAddNPCAndList(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -236,7 +278,7 @@ func TestResetAddItemAndApply(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -271,13 +313,13 @@ func TestCloneCopiesPopulation(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 		"hall": {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "Marble hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 			NPCs:        []game.NPC{{Name: "Marble Steward", AutoGreet: "Mind the echoes."}},
 			Items:       []game.Item{{Name: "Crystal Torch", Description: "A torch that glows softly."}},
 			Resets: []game.RoomReset{
@@ -314,7 +356,7 @@ func TestNameRoomUpdatesTitle(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -343,7 +385,7 @@ func TestListShowsRoomRevisions(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -373,7 +415,7 @@ func TestRevnumRevertsRoom(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	builder := newTestPlayer("Builder", "start")
@@ -403,3 +445,61 @@ func TestRevnumRevertsRoom(t *testing.T) {
 		t.Fatalf("room description = %q, want First draft", room.Description)
 	}
 }
+
+func TestExitRuleAndSearchRevealHiddenExit(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{"north": {To: "hall"}}},
+		"hall":  {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{"south": {To: "start"}}},
+	})
+	builder := newTestPlayer("Builder", "start")
+	builder.IsBuilder = true
+	world.AddPlayerForTest(builder)
+	explorer := newTestPlayer("Explorer", "start")
+	world.AddPlayerForTest(explorer)
+
+	Dispatch(world, builder, "exitrule north hidden")
+	if output := strings.Join(drainOutput(builder.Output), "\n"); !strings.Contains(output, "Exit north conditions: hidden") {
+		t.Fatalf("unexpected exitrule output %q", output)
+	}
+	Dispatch(world, explorer, "go north")
+	if explorer.Room != "start" {
+		t.Fatalf("hidden exit should block movement")
+	}
+	drainOutput(explorer.Output)
+	Dispatch(world, explorer, "search")
+	if output := strings.Join(drainOutput(explorer.Output), "\n"); !strings.Contains(output, "You discover hidden exits: north") {
+		t.Fatalf("expected search to reveal the exit, got %q", output)
+	}
+	Dispatch(world, explorer, "go north")
+	if explorer.Room != "hall" {
+		t.Fatalf("revealed exit should be usable, still in %s", explorer.Room)
+	}
+}
+
+func TestResetRespawnSetsTimerAndRareSpawn(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {
+			ID:          "start",
+			Title:       "Start",
+			Description: "Start room.",
+			Exits:       map[string]game.Exit{},
+		},
+	})
+	builder := newTestPlayer("Builder", "start")
+	builder.IsBuilder = true
+	world.AddPlayerForTest(builder)
+
+	Dispatch(world, builder, "reset add npc Stone Guide")
+	if quit := Dispatch(world, builder, "reset respawn Stone Guide 120 = 5% Granite Guide"); quit {
+		t.Fatalf("dispatch returned true, want false")
+	}
+	resets := world.RoomResets("start")
+	if len(resets) != 1 || resets[0].Respawn != 120 || resets[0].RareSpawn == nil || resets[0].RareSpawn.Name != "Granite Guide" || resets[0].RareChance != 5 {
+		t.Fatalf("unexpected resets %+v", resets)
+	}
+	drainOutput(builder.Output)
+	Dispatch(world, builder, "reset list")
+	if output := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(output, "respawns in 2m") || !strings.Contains(output, "5% rare") {
+		t.Fatalf("expected the respawn in the listing, got %q", output)
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,23 +10,37 @@ import (
 
 var Cast = Define(Definition{
 	Name:        "cast",
-	Usage:       "cast <spell> [target]",
+	Usage:       "cast <spell> [target|direction]",
 	Description: "invoke a simple spell",
 }, func(ctx *Context) bool {
 	fields := strings.Fields(ctx.Arg)
 	if len(fields) == 0 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: cast <spell> [target]", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: cast <spell> [target|direction]", game.AnsiYellow)))
 		return false
 	}
 
 	spell := strings.ToLower(fields[0])
+	area, isArea := game.LookupAreaSpell(spell)
+	if (spell == "heal" || spell == "bolt" || isArea) && !ctx.World.KnowsSkill(ctx.Player, spell) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYour training does not include "+spell+".", game.AnsiYellow)))
+		return false
+	}
 	ctx.Player.EnsureStats()
+	if isArea {
+		if _, err := ctx.World.CastAreaSpell(ctx.Player, area, strings.Join(fields[1:], " ")); err != nil {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Prompt(ctx.Player))
+		return false
+	}
 
 	switch spell {
 	case "heal":
 		manaCost := 10
 		if ctx.Player.Mana < manaCost {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nYou lack the mana to cast heal.", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou lack the mana to cast heal.", game.AnsiYellow)))
 			return false
 		}
 		ctx.Player.Mana -= manaCost
@@ -34,103 +49,76 @@ var Cast = Define(Definition{
 		if ctx.Player.Health > ctx.Player.MaxHealth {
 			ctx.Player.Health = ctx.Player.MaxHealth
 		}
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou channel restorative energy and recover %d health.", amount))
+		ctx.World.RecordHealingThreat(ctx.Player, amount)
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou channel restorative energy and recover %d health.", amount)))
 		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s is bathed in soothing light.", game.HighlightName(ctx.Player.Name))), ctx.Player)
-		ctx.Player.Output <- game.Prompt(ctx.Player)
+		ctx.Player.Output.Send(game.Prompt(ctx.Player))
 		return false
 	case "bolt":
 		if len(fields) < 2 {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: cast bolt <target>", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: cast bolt <target>", game.AnsiYellow)))
 			return false
 		}
 		manaCost := 15
 		if ctx.Player.Mana < manaCost {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nYou lack the mana to cast bolt.", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou lack the mana to cast bolt.", game.AnsiYellow)))
 			return false
 		}
 		target := strings.Join(fields[1:], " ")
+		if err := ctx.World.CombatAllowed(ctx.Player.Room); err != nil {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+			return false
+		}
 		damage := 10 + ctx.Player.Level*3
 		if result, err := ctx.World.ApplyDamageToNPC(ctx.Player.Room, target, damage); err == nil {
 			ctx.Player.Mana -= manaCost
 			npcName := game.HighlightNPCName(result.NPC.Name)
-			ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nArcs of energy slam into %s for %d damage. (%d/%d HP)", npcName, result.Damage, result.NPC.Health, result.NPC.MaxHealth))
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nArcs of energy slam into %s for %d damage. (%d/%d HP)", npcName, result.Damage, result.NPC.Health, result.NPC.MaxHealth)))
 			ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s hurls a crackling bolt at %s for %d damage!", game.HighlightName(ctx.Player.Name), npcName, result.Damage)), ctx.Player)
+			ctx.World.RecordDamageThreat(ctx.Player, result.NPC.Name, result.Damage)
 			if result.Defeated {
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYour magic fells %s!", npcName))
-				xp := result.NPC.Experience
-				if xp < 1 {
-					xp = result.NPC.Level * 25
-				}
-				levels := ctx.World.AwardExperience(ctx.Player, xp)
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou gain %d experience.", xp))
-				if levels > 0 {
-					ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou advance to level %d!", ctx.Player.Level))
-				}
-				if len(result.Loot) > 0 {
-					names := make([]string, len(result.Loot))
-					for i, item := range result.Loot {
-						names[i] = game.HighlightItemName(item.Name)
-					}
-					lootLine := fmt.Sprintf("\r\n%s drops %s.", npcName, strings.Join(names, ", "))
-					ctx.Player.Output <- game.Ansi(lootLine)
-					ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s leaves behind %s.", npcName, strings.Join(names, ", "))), ctx.Player)
-				}
-				if updates := ctx.World.RecordNPCKill(ctx.Player, result.NPC); len(updates) > 0 {
-					for _, update := range updates {
-						for _, prog := range update.KillProgress {
-							ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n[Quest] %s: %s (%d/%d)",
-								game.HighlightQuestName(update.Quest.Name),
-								game.HighlightNPCName(prog.NPC),
-								prog.Current,
-								prog.Required,
-							))
-						}
-						if update.KillsCompleted {
-							turnIn := update.Quest.TurnIn
-							if strings.TrimSpace(turnIn) == "" {
-								turnIn = update.Quest.Giver
-							}
-							if turnIn != "" {
-								ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n[Quest] %s objectives complete. Visit %s to turn in.",
-									game.HighlightQuestName(update.Quest.Name),
-									game.HighlightNPCName(turnIn),
-								))
-							} else {
-								ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n[Quest] %s objectives complete.",
-									game.HighlightQuestName(update.Quest.Name)))
-							}
-						}
-					}
-				}
+				ctx.World.RewardNPCDefeat(ctx.Player, ctx.Player.Room, result)
 			}
-			ctx.Player.Output <- game.Prompt(ctx.Player)
+			ctx.Player.Output.Send(game.Prompt(ctx.Player))
 			return false
 		}
-		if result, err := ctx.World.ApplyDamageToPlayer(ctx.Player, target, damage); err == nil {
+		result, err := ctx.World.ApplyDamageToPlayer(ctx.Player, target, damage)
+		if err == nil {
 			ctx.Player.Mana -= manaCost
 			targetName := game.HighlightName(result.Target.Name)
 			ctx.World.BroadcastToRoom(result.PreviousRoom, game.Ansi(fmt.Sprintf("\r\n%s unleashes a bolt at %s for %d damage!", game.HighlightName(ctx.Player.Name), targetName, result.Damage)), ctx.Player)
-			if result.Defeated {
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYour bolt overwhelms %s!", targetName))
+			if result.Duel != nil {
+				ctx.World.AnnounceDuelResult(*result.Duel)
+			} else if result.Defeated {
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYour bolt overwhelms %s!", targetName)))
 				ctx.World.BroadcastToRoom(result.PreviousRoom, game.Ansi(fmt.Sprintf("\r\n%s collapses under the magical assault!", targetName)), ctx.Player)
 				if result.Target.Output != nil {
-					result.Target.Output <- game.Ansi(fmt.Sprintf("\r\n%s' bolt overwhelms you!", game.HighlightName(ctx.Player.Name)))
+					result.Target.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s' bolt overwhelms you!", game.HighlightName(ctx.Player.Name))))
+					for _, line := range game.FormatDeathOutcome(result.Death) {
+						result.Target.Output.Send(game.Ansi("\r\n" + line))
+					}
 					game.EnterRoom(ctx.World, result.Target, "defeat")
 				}
 			} else {
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYour bolt scorches %s for %d damage. (%d/%d HP)", targetName, result.Damage, result.Remaining, result.Target.MaxHealth))
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYour bolt scorches %s for %d damage. (%d/%d HP)", targetName, result.Damage, result.Remaining, result.Target.MaxHealth)))
 				if result.Target.Output != nil {
-					result.Target.Output <- game.Ansi(fmt.Sprintf("\r\n%s' bolt burns you for %d damage! (%d/%d HP)", game.HighlightName(ctx.Player.Name), result.Damage, result.Remaining, result.Target.MaxHealth))
-					result.Target.Output <- game.Prompt(result.Target)
+					result.Target.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s' bolt burns you for %d damage! (%d/%d HP)", game.HighlightName(ctx.Player.Name), result.Damage, result.Remaining, result.Target.MaxHealth)))
+					result.Target.Output.Send(game.Prompt(result.Target))
 				}
 			}
-			ctx.Player.Output <- game.Prompt(ctx.Player)
+			ctx.Player.Output.Send(game.Prompt(ctx.Player))
+			return false
+		}
+		if errors.Is(err, game.ErrNotArena) || errors.Is(err, game.ErrNoDuel) || errors.Is(err, game.ErrCrime) {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
 			return false
 		}
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYour spell fails to find a target.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYour spell fails to find a target.", game.AnsiYellow)))
 		return false
 	default:
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou do not know that spell.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou do not know that spell.", game.AnsiYellow)))
 		return false
 	}
 })
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestCastBoltKillPaysGold(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{},
+			NPCs: []game.NPC{{Name: "Rat", Level: 1, Health: 1, MaxHealth: 1, Gold: 12}}},
+	})
+	caster := newTestPlayer("Mage", "start")
+	caster.Mana, caster.MaxMana = 50, 50
+	world.AddPlayerForTest(caster)
+	watcher := newTestPlayer("Watcher", "start")
+	world.AddPlayerForTest(watcher)
+
+	Dispatch(world, caster, "cast bolt rat")
+
+	if caster.Gold != 12 {
+		t.Fatalf("caster gold = %d, want 12", caster.Gold)
+	}
+	report := world.EconomyReport()
+	if report.Total.Created != 12 {
+		t.Fatalf("economy ledger created %d gold, want 12", report.Total.Created)
+	}
+	out := ansiPattern.ReplaceAllString(strings.Join(drainOutput(watcher.Output), ""), "")
+	if !strings.Contains(out, "Mage defeats Rat!") {
+		t.Fatalf("expected the room to see the defeat, got %q", out)
+	}
+}
@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Clan = Define(Definition{
+	Name:        "clan",
+	Aliases:     []string{"guild"},
+	Usage:       "clan [info [clan]|list|create <name>|invite <player>|join <clan>|leave|kick <player>|promote <player>|demote <player>|bank|deposit <amount>|withdraw <amount>|claim]",
+	Description: "found, join, and run a player clan with ranks, a shared bank, and a hall",
+}, func(ctx *Context) bool {
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	sub = strings.ToLower(sub)
+	rest = strings.TrimSpace(rest)
+	fail := func(err error) bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+clanError(err), game.AnsiYellow)))
+		return false
+	}
+	needArg := func(usage string) bool {
+		if rest != "" {
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: clan "+usage, game.AnsiYellow)))
+		return true
+	}
+	switch sub {
+	case "", "info":
+		clans := ctx.World.ClanSystem()
+		if clans == nil {
+			return fail(errors.New("clans are unavailable"))
+		}
+		var clan game.Clan
+		var ok bool
+		if rest != "" {
+			clan, ok = clans.Clan(rest)
+			if !ok {
+				return fail(game.ErrClanNotFound)
+			}
+		} else if clan, ok = clans.ClanOf(ctx.Player.Name); !ok {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou are not in a clan. Found one with 'clan create <name>' for %d gold, or ask an officer for an invitation. 'clan list' shows every clan.", game.ClanFoundingCost)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(describeClan(ctx.World, clan)))
+	case "list":
+		clans := ctx.World.ClanSystem()
+		if clans == nil {
+			return fail(errors.New("clans are unavailable"))
+		}
+		ctx.Player.Output.Send(game.Ansi(listClans(clans.Clans())))
+	case "create", "found":
+		if needArg("create <name>") {
+			return false
+		}
+		clan, err := ctx.World.FoundClan(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou found the clan %s and lead it. Invite others with 'clan invite <player>'.", game.Style(clan.Name, game.AnsiCyan))))
+	case "invite":
+		if needArg("invite <player>") {
+			return false
+		}
+		clan, name, err := ctx.World.InviteToClan(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou invite %s to join %s.", game.HighlightName(name), game.Style(clan.Name, game.AnsiCyan))))
+	case "join", "accept":
+		if needArg("join <clan>") {
+			return false
+		}
+		clan, err := ctx.World.JoinClan(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou join the clan %s as a recruit. Talk to your clan with 'clantalk <message>'.", game.Style(clan.Name, game.AnsiCyan))))
+	case "leave":
+		clan, disbanded, err := ctx.World.LeaveClan(ctx.Player)
+		if err != nil {
+			return fail(err)
+		}
+		if disbanded {
+			msg := fmt.Sprintf("\r\nYou were the last member of %s, so the clan is disbanded.", clan.Name)
+			if clan.Gold > 0 {
+				msg += fmt.Sprintf(" Its bank pays %s out to you.", game.Style(fmt.Sprintf("%d gold", clan.Gold), game.AnsiYellow))
+			}
+			ctx.Player.Output.Send(game.Ansi(msg))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou leave the clan %s.", clan.Name)))
+	case "kick", "remove":
+		if needArg("kick <player>") {
+			return false
+		}
+		clan, name, err := ctx.World.KickFromClan(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou remove %s from %s.", game.HighlightName(name), clan.Name)))
+	case "promote", "demote":
+		if needArg(sub + " <player>") {
+			return false
+		}
+		change := ctx.World.PromoteClanMember
+		if sub == "demote" {
+			change = ctx.World.DemoteClanMember
+		}
+		_, name, rank, err := change(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		msg := fmt.Sprintf("\r\n%s is now a clan %s.", game.HighlightName(name), rank)
+		if rank == game.ClanLeader {
+			msg += " You step down to officer."
+		}
+		ctx.Player.Output.Send(game.Ansi(msg))
+	case "bank":
+		clan, ok := ctx.World.PlayerClan(ctx.Player)
+		if !ok {
+			return fail(game.ErrNotInClan)
+		}
+		ctx.Player.Output.Send(game.Ansi(describeClanBank(clan)))
+	case "deposit", "withdraw":
+		amount, ok := parseGold(rest)
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: clan "+sub+" <amount>", game.AnsiYellow)))
+			return false
+		}
+		move, verb := ctx.World.ClanDeposit, "deposit"
+		if sub == "withdraw" {
+			move, verb = ctx.World.ClanWithdraw, "withdraw"
+		}
+		clan, err := move(ctx.Player, amount)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou %s %s. The clan bank holds %d gold.", verb, game.Style(fmt.Sprintf("%d gold", amount), game.AnsiYellow), clan.Gold)))
+	case "claim", "hall":
+		clan, err := ctx.World.ClaimClanHall(ctx.Player)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThis room is now the hall of %s. Only its members may walk in. The clan bank holds %d gold.", game.Style(clan.Name, game.AnsiCyan), clan.Gold)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+	}
+	return false
+})
+
+var ClanTalk = Define(Definition{
+	Name:        "clantalk",
+	Aliases:     []string{"ct"},
+	Usage:       "clantalk <message>",
+	Description: "talk to the online members of your clan",
+}, func(ctx *Context) bool {
+	msg := ctx.Arg
+	if msg == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nTell your clan what?", game.AnsiYellow)))
+		return false
+	}
+	clan, ok := ctx.World.PlayerClan(ctx.Player)
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+clanError(game.ErrNotInClan), game.AnsiYellow)))
+		return false
+	}
+	if ctx.World.ChannelMuted(ctx.Player, game.ChannelClan) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are muted on the clan channel.", game.AnsiYellow)))
+		return false
+	}
+	if channelSlowed(ctx, game.ChannelClan) {
+		return false
+	}
+	tag := game.Style("["+clan.Name+"]", game.AnsiGreen, game.AnsiBold)
+	ctx.World.BroadcastToClan(clan, game.Ansi(fmt.Sprintf("\r\n%s %s: %s", tag, game.HighlightName(ctx.Player.Name), msg)), ctx.Player)
+	self := game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You ("+clan.Name+"):", game.AnsiBold, game.AnsiGreen), msg))
+	ctx.Player.Output.Send(self)
+	ctx.World.RecordPlayerChannelMessage(ctx.Player, game.ChannelClan, self)
+	return false
+})
+
+var ClanAdmin = Define(Definition{
+	Name:        "clanadmin",
+	Usage:       "clanadmin list | clanadmin show <clan> | clanadmin rename <clan> to <new name> | clanadmin disband <clan>",
+	Description: "review, rename, or disband player clans (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may oversee clans.", game.AnsiYellow)))
+		return false
+	}
+	clans := ctx.World.ClanSystem()
+	if clans == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nClans are unavailable.", game.AnsiYellow)))
+		return false
+	}
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	usage := func() bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(sub) {
+	case "", "list":
+		ctx.Player.Output.Send(game.Ansi(listClans(clans.Clans())))
+	case "show":
+		clan, ok := clans.Clan(rest)
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+clanError(game.ErrClanNotFound), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(describeClan(ctx.World, clan) + describeClanBank(clan)))
+	case "rename":
+		old, name, ok := strings.Cut(rest, " to ")
+		if !ok || strings.TrimSpace(old) == "" || strings.TrimSpace(name) == "" {
+			return usage()
+		}
+		clan, previous, err := ctx.World.RenameClan(ctx.Player, old, name)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+clanError(err), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe clan %s is now %s. Its members have been told by mail.", previous, game.Style(clan.Name, game.AnsiCyan))))
+	case "disband":
+		if rest == "" {
+			return usage()
+		}
+		clan, err := ctx.World.DisbandClan(ctx.Player, rest)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+clanError(err), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe clan %s is disbanded. Its %d member(s) have been told by mail, and %d gold from its bank was mailed to %s.",
+			clan.Name, len(clan.Members), clan.Gold, game.HighlightName(clan.Leader()))))
+	default:
+		return usage()
+	}
+	return false
+})
+
+func describeClan(world *game.World, clan game.Clan) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\r\n%s, founded %s.", game.Style(clan.Name, game.AnsiBold, game.AnsiCyan), clan.Founded.Local().Format("2006-01-02")))
+	for _, name := range clan.Roster() {
+		b.WriteString(fmt.Sprintf("\r\n  %-8s %s", clan.Members[name], game.HighlightName(name)))
+	}
+	if clan.Hall != "" {
+		hall := string(clan.Hall)
+		if room, ok := world.GetRoom(clan.Hall); ok {
+			hall = room.Title
+		}
+		b.WriteString(fmt.Sprintf("\r\nHall: %s", hall))
+	}
+	if clan.Points > 0 {
+		b.WriteString(fmt.Sprintf("\r\nClan points: %d", clan.Points))
+	}
+	return b.String()
+}
+
+func listClans(clans []game.Clan) string {
+	if len(clans) == 0 {
+		return "\r\nNo clans have been founded."
+	}
+	var b strings.Builder
+	b.WriteString("\r\nClans:")
+	for _, clan := range clans {
+		b.WriteString(fmt.Sprintf("\r\n  %-24s %3d member(s), led by %s", clan.Name, len(clan.Members), game.HighlightName(clan.Leader())))
+	}
+	return b.String()
+}
+
+func describeClanBank(clan game.Clan) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\r\nClan bank: %s", game.Style(fmt.Sprintf("%d gold", clan.Gold), game.AnsiYellow)))
+	if len(clan.Ledger) == 0 {
+		b.WriteString("\r\nNo deposits or withdrawals yet.")
+		return b.String()
+	}
+	b.WriteString("\r\nRecent transactions:")
+	for i := len(clan.Ledger) - 1; i >= 0; i-- {
+		entry := clan.Ledger[i]
+		b.WriteString(fmt.Sprintf("\r\n  %s  %-16s %+d", entry.At.Local().Format("2006-01-02 15:04"), entry.Player, entry.Amount))
+	}
+	return b.String()
+}
+
+func clanError(err error) string {
+	if errors.Is(err, game.ErrInsufficientGold) {
+		return "You don't have that much gold."
+	}
+	msg := err.Error()
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
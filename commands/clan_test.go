@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestClanCommandsAndChannel(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Square", Exits: map[string]game.Exit{}},
+	})
+	clans, err := game.NewClanSystem("")
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	world.AttachClanSystem(clans)
+	leader := newTestPlayer("Ava", "start")
+	leader.Gold = game.ClanFoundingCost
+	recruit := newTestPlayer("Bram", "start")
+	staff := newTestPlayer("Warden", "start")
+	staff.IsAdmin = true
+	for _, p := range []*game.Player{leader, recruit, staff} {
+		world.AddPlayerForTest(p)
+	}
+
+	Dispatch(world, leader, "clan")
+	out := strings.Join(drainOutput(leader.Output), "")
+	if !strings.Contains(out, "You are not in a clan.") {
+		t.Fatalf("expected clan info to explain how to start, got %q", out)
+	}
+	Dispatch(world, leader, "clan create Lamplighters")
+	Dispatch(world, leader, "clan invite bram")
+	out = strings.Join(drainOutput(leader.Output), "")
+	if !strings.Contains(out, "You found the clan Lamplighters") || !strings.Contains(out, "You invite Bram to join Lamplighters.") {
+		t.Fatalf("expected the clan to be founded and Bram invited, got %q", out)
+	}
+	Dispatch(world, recruit, "clan join lamplighters")
+	Dispatch(world, recruit, "clan withdraw 5")
+	out = strings.Join(drainOutput(recruit.Output), "")
+	if !strings.Contains(out, "You join the clan Lamplighters as a recruit.") || !strings.Contains(out, "Your clan rank does not allow that.") {
+		t.Fatalf("expected Bram to join but not withdraw, got %q", out)
+	}
+
+	drainOutput(leader.Output)
+	drainOutput(staff.Output)
+	Dispatch(world, recruit, "ct evening all")
+	if out := strings.Join(drainOutput(leader.Output), ""); !strings.Contains(out, "[Lamplighters] Bram: evening all") {
+		t.Fatalf("expected the leader to hear clan talk, got %q", out)
+	}
+	if out := strings.Join(drainOutput(staff.Output), ""); strings.Contains(out, "evening all") {
+		t.Fatalf("expected clan talk to stay in the clan, got %q", out)
+	}
+
+	Dispatch(world, leader, "clan info")
+	out = strings.Join(drainOutput(leader.Output), "")
+	if !strings.Contains(out, "leader Ava") || !strings.Contains(out, "recruit Bram") {
+		t.Fatalf("expected the roster, got %q", out)
+	}
+
+	Dispatch(world, recruit, "clanadmin disband lamplighters")
+	if out := strings.Join(drainOutput(recruit.Output), ""); !strings.Contains(out, "Only staff may oversee clans.") {
+		t.Fatalf("expected players to be refused, got %q", out)
+	}
+	Dispatch(world, staff, "clanadmin rename lamplighters to Lantern Guild")
+	Dispatch(world, staff, "clanadmin list")
+	out = strings.Join(drainOutput(staff.Output), "")
+	if !strings.Contains(out, "The clan Lamplighters is now Lantern Guild.") || !strings.Contains(out, "Lantern Guild") {
+		t.Fatalf("expected the clan to be renamed, got %q", out)
+	}
+	Dispatch(world, staff, "clanadmin disband lantern guild")
+	if out := strings.Join(drainOutput(staff.Output), ""); !strings.Contains(out, "The clan Lantern Guild is disbanded.") {
+		t.Fatalf("expected the clan to be disbanded, got %q", out)
+	}
+	if _, ok := world.PlayerClan(recruit); ok {
+		t.Fatalf("expected Bram to be clanless after the disband")
+	}
+}
@@ -13,18 +13,18 @@ var Clone = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may clone rooms.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may clone rooms.", game.AnsiYellow)))
 		return false
 	}
 	target := strings.TrimSpace(ctx.Arg)
 	if target == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: clone <room id>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: clone <room id>", game.AnsiYellow)))
 		return false
 	}
-	if err := ctx.World.CloneRoomPopulation(game.RoomID(target), ctx.Player.Room); err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+	if err := ctx.World.CloneRoomPopulation(game.RoomID(target), ctx.Player.Room, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
-	ctx.Player.Output <- game.Ansi("\r\nRoom population cloned.")
+	ctx.Player.Output.Send(game.Ansi("\r\nRoom population cloned."))
 	return false
 })
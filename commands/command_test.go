@@ -13,7 +13,7 @@ func TestCommandToggleRequiresAdmin(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Player", "hall")
@@ -34,7 +34,7 @@ func TestCommandToggleDisablesAndEnables(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	admin := newTestPlayer("Admin", "hall")
@@ -93,7 +93,7 @@ func TestCommandToggleCannotDisableItself(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	admin := newTestPlayer("Admin", "hall")
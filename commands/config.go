@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Config = Define(Definition{
+	Name:        "config",
+	Usage:       "config [reload]",
+	Description: "show live server settings or reload them from the config file (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage the server config.", game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "":
+		ctx.Player.Output.Send(game.Ansi(describeTunables(ctx.World.Tunables())))
+	case "reload":
+		result, err := ctx.World.ReloadConfig()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nConfig reload failed: "+err.Error()+".", game.AnsiYellow)))
+			return false
+		}
+		var b strings.Builder
+		if len(result.Applied) == 0 {
+			b.WriteString("\r\nConfig reloaded; no live settings changed.")
+		} else {
+			b.WriteString("\r\nConfig reloaded. Applied: " + strings.Join(result.Applied, ", ") + ".")
+		}
+		if len(result.Overridden) > 0 {
+			b.WriteString("\r\n" + game.Style("Ignored (set on the command line): "+strings.Join(result.Overridden, ", ")+".", game.AnsiYellow))
+		}
+		if len(result.Restart) > 0 {
+			b.WriteString("\r\n" + game.Style("Restart needed for: "+strings.Join(result.Restart, ", ")+".", game.AnsiYellow))
+		}
+		ctx.Player.Output.Send(game.Ansi(b.String()))
+		game.Logger().Info("config reloaded", "by", ctx.Player.Name, "applied", strings.Join(result.Applied, ","))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: config [reload]", game.AnsiYellow)))
+	}
+	return false
+})
+
+func describeTunables(t game.Tunables) string {
+	idle := "off"
+	if t.IdleTimeout > 0 {
+		idle = t.IdleTimeout.String()
+	}
+	var channels []string
+	for _, channel := range game.AllChannels() {
+		if t.Channels[channel] {
+			channels = append(channels, string(channel))
+		}
+	}
+	if len(channels) == 0 {
+		channels = []string{"none"}
+	}
+	rent := "off"
+	if t.RentPerDay > 0 {
+		rent = fmt.Sprintf("%d gold per item per day after %d free", t.RentPerDay, t.RentFreeItems)
+	}
+	blocked := "none"
+	if len(t.BlockedWords) > 0 {
+		blocked = fmt.Sprintf("%d", len(t.BlockedWords))
+	}
+	return fmt.Sprintf("\r\nLive settings:\r\n  Combat round: %s\r\n  XP rate: %gx\r\n  Idle timeout: %s\r\n  New character channels: %s\r\n  Blocked words: %s\r\n  House edge: %d%%\r\n  Gold faucet rate: %gx\r\n  Gold sink rate: %gx\r\n  Vault rent: %s",
+		t.CombatRound, t.XPRate, idle, strings.Join(channels, ", "), blocked, t.HouseEdge, t.GoldFaucetRate, t.GoldSinkRate, rent)
+}
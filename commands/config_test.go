@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestConfigShowsSettingsToAdminsOnly(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	player := newTestPlayer("Hero", "start")
+	world.AddPlayerForTest(player)
+	admin := newTestPlayer("Admin", "start")
+	admin.IsAdmin = true
+	world.AddPlayerForTest(admin)
+
+	Dispatch(world, player, "config")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Only admins") {
+		t.Fatalf("expected non-admin to be refused, got %q", out)
+	}
+
+	Dispatch(world, admin, "config")
+	out := strings.Join(drainOutput(admin.Output), "")
+	if !strings.Contains(out, "Combat round: 4s") || !strings.Contains(out, "Idle timeout: off") {
+		t.Fatalf("expected live settings, got %q", out)
+	}
+
+	Dispatch(world, admin, "config reload")
+	if out := strings.Join(drainOutput(admin.Output), ""); !strings.Contains(out, "not started with a config file") {
+		t.Fatalf("expected reload without a config file to fail, got %q", out)
+	}
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Quaff = Define(Definition{
+	Name:        "quaff",
+	Aliases:     []string{"drink"},
+	Usage:       "quaff <potion>",
+	Description: "drink a potion you are carrying",
+}, func(ctx *Context) bool {
+	return consume(ctx, game.Potion, "quaff", "quaffs")
+})
+
+var Eat = Define(Definition{
+	Name:        "eat",
+	Usage:       "eat <food>",
+	Description: "eat food you are carrying",
+}, func(ctx *Context) bool {
+	return consume(ctx, game.Food, "eat", "eats")
+})
+
+var Recite = Define(Definition{
+	Name:        "recite",
+	Usage:       "recite <scroll>",
+	Description: "read a scroll aloud, using it up",
+}, func(ctx *Context) bool {
+	return consume(ctx, game.Scroll, "recite", "recites")
+})
+
+func consume(ctx *Context, kind game.ConsumableKind, verb, verbs string) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s what?", strings.ToUpper(verb[:1])+verb[1:])))
+		return false
+	}
+	result, err := ctx.World.Consume(ctx.Player, kind, target)
+	switch {
+	case err == nil:
+	case errors.Is(err, game.ErrItemNotCarried):
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
+		return false
+	default:
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	name := result.Item.DisplayName()
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou %s %s.", verb, name)))
+	ctx.World.BroadcastToRoom(result.From, game.Ansi(fmt.Sprintf("\r\n%s %s %s.", game.HighlightName(ctx.Player.Name), verbs, name)), ctx.Player)
+	var restored []string
+	if result.Healed > 0 {
+		restored = append(restored, game.Style(fmt.Sprintf("%d health", result.Healed), game.AnsiGreen))
+	}
+	if result.Mana > 0 {
+		restored = append(restored, game.Style(fmt.Sprintf("%d mana", result.Mana), game.AnsiMagenta))
+	}
+	if result.Moves > 0 {
+		restored = append(restored, game.Style(fmt.Sprintf("%d stamina", result.Moves), game.AnsiYellow))
+	}
+	if len(restored) > 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou recover " + strings.Join(restored, ", ") + "."))
+	}
+	if result.Effect != nil {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou are under the effect of %s for %s.",
+			game.Style(result.Effect.Name, game.AnsiCyan), formatPortalDuration(result.Effect.Remaining(time.Now()).Round(time.Second)))))
+	}
+	if result.Teleport != "" {
+		ctx.World.BroadcastToRoom(result.From, game.Ansi(fmt.Sprintf("\r\n%s vanishes in a swirl of light.", game.HighlightName(ctx.Player.Name))), ctx.Player)
+		ctx.World.BroadcastToRoom(result.Teleport, game.Ansi(fmt.Sprintf("\r\n%s appears in a swirl of light.", game.HighlightName(ctx.Player.Name))), ctx.Player)
+		ctx.Player.Output.Send(game.Ansi("\r\nThe words carry you away."))
+		game.EnterRoom(ctx.World, ctx.Player, "")
+		return false
+	}
+	ctx.Player.Output.Send(game.Prompt(ctx.Player))
+	return false
+}
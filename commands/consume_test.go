@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestQuaffAndStackedInventory(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{}},
+	})
+	player := newTestPlayer("Drinker", "hall")
+	world.AddPlayerForTest(player)
+	draught := game.Item{Name: "Healing Draught", Consumable: &game.Consumable{Kind: game.Potion, Heal: 40}}
+	player.Inventory = []game.Item{draught, draught, {Name: "Star Chart"}, draught}
+	player.Health = 10
+
+	Dispatch(world, player, "inventory")
+	if output := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(output, "Healing Draught (x3), Star Chart") {
+		t.Fatalf("inventory output = %q", output)
+	}
+
+	Dispatch(world, player, "eat draught")
+	if output := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(output, "You can't eat Healing Draught.") {
+		t.Fatalf("eat output = %q", output)
+	}
+
+	Dispatch(world, player, "quaff draught")
+	output := strings.Join(drainOutput(player.Output), "\n")
+	if !strings.Contains(output, "You quaff Healing Draught.") || !strings.Contains(output, "You recover 40 health.") {
+		t.Fatalf("quaff output = %q", output)
+	}
+	if player.Health != 50 || len(player.Inventory) != 3 {
+		t.Fatalf("after quaff health %d, inventory %d", player.Health, len(player.Inventory))
+	}
+}
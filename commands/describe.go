@@ -8,23 +8,27 @@ import (
 
 var Describe = Define(Definition{
 	Name:        "describe",
-	Usage:       "describe <text>",
-	Description: "update the current room description (builders/admins only)",
+	Usage:       "describe <text> | describe me",
+	Description: "update the current room description (builders/admins only), or your own with 'describe me'",
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
+	if strings.EqualFold(strings.TrimSpace(ctx.Arg), "me") {
+		openBioEditor(ctx)
+		return false
+	}
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may use describe.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use describe.", game.AnsiYellow)))
 		return false
 	}
 	desc := strings.TrimSpace(ctx.Arg)
 	if desc == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: describe <text>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: describe <text>", game.AnsiYellow)))
 		return false
 	}
 	if _, err := ctx.World.UpdateRoomDescription(ctx.Player.Room, desc, ctx.Player.Name); err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
-	ctx.Player.Output <- game.Ansi("\r\nRoom description updated.")
+	ctx.Player.Output.Send(game.Ansi("\r\nRoom description updated."))
 	return false
 })
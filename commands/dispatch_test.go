@@ -14,16 +14,16 @@ func TestDispatchGoMovesPlayerAndNotifiesRooms(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits: map[string]game.RoomID{
-				"east": "second",
+			Exits: map[string]game.Exit{
+				"east": {To: "second"},
 			},
 		},
 		"second": {
 			ID:          "second",
 			Title:       "Second Room",
 			Description: "A bustling plaza.",
-			Exits: map[string]game.RoomID{
-				"west": "start",
+			Exits: map[string]game.Exit{
+				"west": {To: "start"},
 			},
 		},
 	})
@@ -79,7 +79,7 @@ func TestDispatchSayBroadcastsToRoomChannel(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	speaker := newTestPlayer("Speaker", "hall")
@@ -108,7 +108,7 @@ func TestDispatchRespectsDisabledCommands(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	speaker := newTestPlayer("Speaker", "hall")
@@ -158,7 +158,7 @@ func TestDispatchSayStripsUnsafeCharacters(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	speaker := newTestPlayer("Speaker", "hall")
@@ -188,7 +188,7 @@ func TestDispatchAutocompletePrefix(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Reader", "hall")
@@ -219,7 +219,7 @@ func TestDispatchAmbiguousPrefixDoesNotFallback(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Reader", "hall")
@@ -248,7 +248,7 @@ func TestDispatchAutocompleteSimilarity(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	speaker := newTestPlayer("Speaker", "hall")
@@ -271,6 +271,30 @@ func TestDispatchAutocompleteSimilarity(t *testing.T) {
 	}
 }
 
+func TestDispatchRunsSocialsBeforeAutocomplete(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{"start": {ID: "start", Title: "Start"}})
+	if err := world.SetSocial(game.Social{Name: "salute", Alone: "$n {salute|salutes}.", Target: "$n {salute|salutes} $N."}); err != nil {
+		t.Fatalf("SetSocial: %v", err)
+	}
+	hero := newTestPlayer("Hero", "start")
+	friend := newTestPlayer("Friend", "start")
+	world.AddPlayerForTest(hero)
+	world.AddPlayerForTest(friend)
+
+	Dispatch(world, hero, "salute friend")
+	if got := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(got, "You salute Friend.") {
+		t.Fatalf("actor saw %q", got)
+	}
+	if got := strings.Join(drainOutput(friend.Output), "\n"); !strings.Contains(got, "Hero salutes you.") {
+		t.Fatalf("target saw %q", got)
+	}
+
+	Dispatch(world, hero, "salute ghost")
+	if got := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(got, "You don't see ghost here.") {
+		t.Fatalf("expected a missing target message, got %q", got)
+	}
+}
+
 func TestShortcutRegistered(t *testing.T) {
 	registryMu.RLock()
 	defer registryMu.RUnlock()
@@ -285,7 +309,7 @@ func TestDispatchChannelToggleDisablesSay(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	talker := newTestPlayer("Talker", "hall")
@@ -385,19 +409,54 @@ func TestMuteCommandsToggleChannelAccess(t *testing.T) {
 	}
 }
 
+func TestModeratorsReviewHistoryAndSlowChannels(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall"},
+	})
+	mod := newTestPlayer("Mod", "hall")
+	mod.IsModerator = true
+	chatter := newTestPlayer("Chatter", "hall")
+	world.AddPlayerForTest(mod)
+	world.AddPlayerForTest(chatter)
+
+	Dispatch(world, chatter, "ooc hello there")
+	drainOutput(chatter.Output)
+	drainOutput(mod.Output)
+
+	Dispatch(world, mod, "review chatter ooc")
+	if got := strings.Join(drainOutput(mod.Output), "\n"); !strings.Contains(got, "hello there") {
+		t.Fatalf("expected review to show the message, got %q", got)
+	}
+
+	Dispatch(world, mod, "slowmode ooc 1m")
+	drainOutput(mod.Output)
+	Dispatch(world, chatter, "ooc one")
+	Dispatch(world, chatter, "ooc two")
+	got := strings.Join(drainOutput(chatter.Output), "\n")
+	if !strings.Contains(got, "one") || strings.Contains(got, "two") || !strings.Contains(got, "slow mode") {
+		t.Fatalf("expected the second message to be held back, got %q", got)
+	}
+
+	Dispatch(world, mod, "modlog")
+	log := strings.Join(drainOutput(mod.Output), "\n")
+	if !strings.Contains(log, "review Chatter OOC") || !strings.Contains(log, "slowmode OOC (1m0s)") {
+		t.Fatalf("expected review and slow mode in the log, got %q", log)
+	}
+}
+
 func TestSetHomeUpdatesRecallPoint(t *testing.T) {
 	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
 		"start": {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "The central hub.",
-			Exits:       map[string]game.RoomID{"east": "hall"},
+			Exits:       map[string]game.Exit{"east": {To: "hall"}},
 		},
 		"hall": {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "A quiet hallway.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	traveler := newTestPlayer("Traveler", "hall")
@@ -430,13 +489,13 @@ func TestRecallReturnsPlayerHome(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "The central hub.",
-			Exits:       map[string]game.RoomID{"east": "hall"},
+			Exits:       map[string]game.Exit{"east": {To: "hall"}},
 		},
 		"hall": {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "A quiet hallway.",
-			Exits:       map[string]game.RoomID{"west": "start"},
+			Exits:       map[string]game.Exit{"west": {To: "start"}},
 		},
 	})
 	traveler := newTestPlayer("Traveler", "hall")
@@ -503,27 +562,23 @@ func newTestPlayer(name string, room game.RoomID) *game.Player {
 		Account:  name,
 		Room:     room,
 		Home:     room,
-		Output:   make(chan string, 32),
+		Output:   game.NewOutputQueue(),
 		Alive:    true,
 		Channels: game.DefaultChannelSettings(),
 	}
 }
 
-var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m|\x1b\][^\x1b]*\x1b\\`)
 
-func drainOutput(ch chan string) []string {
+func drainOutput(out *game.OutputQueue) []string {
 	t := make([]string, 0)
-	for {
-		select {
-		case msg := <-ch:
-			cleaned := game.Trim(ansiPattern.ReplaceAllString(msg, ""))
-			if cleaned != "" {
-				t = append(t, cleaned)
-			}
-		default:
-			return t
+	for _, msg := range out.Drain() {
+		cleaned := game.Trim(ansiPattern.ReplaceAllString(msg, ""))
+		if cleaned != "" {
+			t = append(t, cleaned)
 		}
 	}
+	return t
 }
 
 func isPrompt(msg string) bool {
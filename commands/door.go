@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+const doorUsage = "Usage: door <direction> [open|closed|locked] [key <item>] | door <direction> none"
+
+var Door = Define(Definition{
+	Name:        "door",
+	Usage:       "door <direction> [open|closed|locked] [key <item>] | door <direction> none",
+	Description: "fit or remove a door on an exit (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use door.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) == 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+doorUsage, game.AnsiYellow)))
+		return false
+	}
+	dir := fields[0]
+	rest := fields[1:]
+	if len(rest) == 1 && strings.EqualFold(rest[0], "none") {
+		if err := ctx.World.SetDoor(ctx.Player.Room, dir, nil, ctx.Player.Name); err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nDoor removed."))
+		return false
+	}
+	config := game.DoorConfig{}
+	for i := 0; i < len(rest); i++ {
+		switch strings.ToLower(rest[i]) {
+		case "open":
+			config.Closed, config.Locked = false, false
+		case "closed":
+			config.Closed = true
+		case "locked":
+			config.Closed, config.Locked = true, true
+		case "key":
+			config.Key = strings.Join(rest[i+1:], " ")
+			i = len(rest)
+		default:
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+doorUsage, game.AnsiYellow)))
+			return false
+		}
+	}
+	if err := ctx.World.SetDoor(ctx.Player.Room, dir, &config, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	state := "open"
+	switch {
+	case config.Locked:
+		state = "locked"
+	case config.Closed:
+		state = "closed"
+	}
+	message := fmt.Sprintf("\r\nDoor fitted (%s).", state)
+	if config.Key != "" {
+		message = fmt.Sprintf("\r\nDoor fitted (%s, key: %s).", state, game.HighlightItemName(config.Key))
+	}
+	ctx.Player.Output.Send(game.Ansi(message))
+	return false
+})
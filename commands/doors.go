@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Open = Define(Definition{
+	Name:        "open",
+	Usage:       "open <direction>",
+	Description: "open a door",
+}, func(ctx *Context) bool {
+	return operateDoor(ctx, game.DoorOpen, "opens")
+})
+
+var Close = Define(Definition{
+	Name:        "close",
+	Usage:       "close <direction>",
+	Description: "close a door",
+}, func(ctx *Context) bool {
+	return operateDoor(ctx, game.DoorClose, "closes")
+})
+
+var Lock = Define(Definition{
+	Name:        "lock",
+	Usage:       "lock <direction>",
+	Description: "lock a closed door with its key",
+}, func(ctx *Context) bool {
+	return operateDoor(ctx, game.DoorLock, "locks")
+})
+
+var Unlock = Define(Definition{
+	Name:        "unlock",
+	Usage:       "unlock <direction>",
+	Description: "unlock a door with its key",
+}, func(ctx *Context) bool {
+	return operateDoor(ctx, game.DoorUnlock, "unlocks")
+})
+
+func operateDoor(ctx *Context, action game.DoorAction, verb string) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nUsage: %s <direction>", action), game.AnsiYellow)))
+		return false
+	}
+	dir, err := ctx.World.OperateDoor(ctx.Player, target, action)
+	if err != nil {
+		var message string
+		switch {
+		case errors.Is(err, game.ErrNoDoor):
+			message = fmt.Sprintf("There is no door to the %s.", dir)
+		case errors.Is(err, game.ErrDoorOpen):
+			message = fmt.Sprintf("The %s door is open.", dir)
+		case errors.Is(err, game.ErrDoorAlreadyClosed):
+			message = fmt.Sprintf("The %s door is already closed.", dir)
+		case errors.Is(err, game.ErrDoorLocked):
+			message = fmt.Sprintf("The %s door is locked.", dir)
+		case errors.Is(err, game.ErrDoorUnlocked):
+			message = fmt.Sprintf("The %s door is not locked.", dir)
+		case errors.Is(err, game.ErrDoorHasNoLock):
+			message = fmt.Sprintf("The %s door has no lock.", dir)
+		case errors.Is(err, game.ErrMissingKey):
+			message = fmt.Sprintf("You don't have the key to the %s door.", dir)
+		default:
+			message = err.Error()
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+message, game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou %s the %s door.", action, dir)))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s %s the %s door.", game.HighlightName(ctx.Player.Name), verb, dir)), ctx.Player)
+	return false
+}
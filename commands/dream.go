@@ -13,7 +13,7 @@ var dreamCommand = Define(Definition{
 	Description: "slip into a personalised dreamscape",
 	Group:       GroupGeneral,
 }, func(ctx *Context) bool {
-	ctx.Player.Output <- game.Ansi(renderDreamscape(ctx.Player.Name))
+	ctx.Player.Output.Send(game.Ansi(renderDreamscape(ctx.Player.Name)))
 	return false
 })
 
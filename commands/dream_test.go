@@ -13,7 +13,7 @@ func TestDreamCommandPersonalisedAndDeterministic(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	dreamer := newTestPlayer("Lyra", "hall")
@@ -15,18 +15,18 @@ var Drop = Define(Definition{
 }, func(ctx *Context) bool {
 	target := strings.TrimSpace(ctx.Arg)
 	if target == "" {
-		ctx.Player.Output <- game.Ansi("\r\nDrop what?")
+		ctx.Player.Output.Send(game.Ansi("\r\nDrop what?"))
 		return false
 	}
 	item, err := ctx.World.DropItem(ctx.Player, target)
 	switch {
 	case err == nil:
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou drop %s.", game.HighlightItemName(item.Name)))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou drop %s.", game.HighlightItemName(item.Name))))
 		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s drops %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name))), ctx.Player)
 	case errors.Is(err, game.ErrItemNotCarried):
-		ctx.Player.Output <- game.Ansi("\r\nYou aren't carrying that.")
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
 	default:
-		ctx.Player.Output <- game.Ansi("\r\n" + err.Error())
+		ctx.Player.Output.Send(game.Ansi("\r\n" + err.Error()))
 	}
 	return false
 })
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Economy = Define(Definition{
+	Name:        "economy",
+	Usage:       "economy",
+	Description: "report gold created and destroyed since startup (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may review the economy.", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(describeEconomy(ctx.World.EconomyReport())))
+	return false
+})
+
+func describeEconomy(report game.EconomyReport) string {
+	var b strings.Builder
+	b.WriteString(game.Style("\r\nEconomy ledger:", game.AnsiBold))
+	b.WriteString(fmt.Sprintf(" faucet rate %gx, sink rate %gx", report.FaucetRate, report.SinkRate))
+	if len(report.Flows) == 0 {
+		b.WriteString("\r\nNo gold has been created or destroyed since the server started.")
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("\r\nSince %s:", report.Since.Local().Format("2006-01-02 15:04")))
+	b.WriteString(fmt.Sprintf("\r\n  %-10s %10s %10s %10s %7s", "Source", "Created", "Destroyed", "Net", "Events"))
+	for _, flow := range append(report.Flows, report.Total) {
+		source := string(flow.Source)
+		if source == "" {
+			source = "total"
+		}
+		b.WriteString(fmt.Sprintf("\r\n  %-10s %10d %10d %+10d %7d", source, flow.Created, flow.Destroyed, flow.Net(), flow.Events))
+	}
+	if n := len(report.Hours); n > 0 {
+		last := report.Hours[n-1]
+		b.WriteString(fmt.Sprintf("\r\nHour from %s: %d created, %d destroyed. The staff portal charts the last 48 hours.",
+			last.Hour.Local().Format("15:04"), last.Created, last.Destroyed))
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestEconomyReportForAdmins(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	player := newTestPlayer("Hero", "start")
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "economy")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Only admins") {
+		t.Fatalf("expected non-admins to be refused, got %q", out)
+	}
+
+	player.IsAdmin = true
+	world.AwardGold(player, 25, game.EconomyLoot)
+	Dispatch(world, player, "economy")
+	out := ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "loot") || !strings.Contains(out, "+25") || !strings.Contains(out, "faucet rate 1x") {
+		t.Fatalf("expected the loot faucet in the report, got %q", out)
+	}
+}
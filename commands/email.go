@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Email = Define(Definition{
+	Name:        "email",
+	Usage:       "email [address|clear]",
+	Description: "show, bind, or remove your account email address",
+	Group:       GroupGeneral,
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	switch strings.ToLower(arg) {
+	case "":
+		if email := ctx.World.AccountEmail(ctx.Player.Account); email != "" {
+			ctx.Player.Output.Send(game.Ansi("\r\nYour account email is " + game.Style(email, game.AnsiCyan) + "."))
+		} else {
+			ctx.Player.Output.Send(game.Ansi("\r\nNo email address is bound to your account."))
+		}
+		return false
+	case "clear", "none", "remove":
+		arg = ""
+	}
+	if err := ctx.World.SetAccountEmail(ctx.Player.Account, arg); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to update email: "+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	if arg == "" {
+		ctx.Player.Output.Send(game.Ansi("\r\nYour account email has been removed."))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\nYour account email is now " + game.Style(ctx.World.AccountEmail(ctx.Player.Account), game.AnsiCyan) + "."))
+	return false
+})
@@ -14,10 +14,10 @@ var Emote = Define(Definition{
 }, func(ctx *Context) bool {
 	action := ctx.Arg
 	if action == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nEmote what?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nEmote what?", game.AnsiYellow)))
 		return false
 	}
 	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s %s", game.HighlightName(ctx.Player.Name), action)), ctx.Player)
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You", game.AnsiBold, game.AnsiYellow), action))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You", game.AnsiBold, game.AnsiYellow), action)))
 	return false
 })
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Event = Define(Definition{
+	Name:        "event",
+	Aliases:     []string{"events"},
+	Usage:       "event [list|start <id> [minutes]|stop <id>]",
+	Description: "show the world events under way; admins can start and stop them",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(ctx.Arg)
+	sub := ""
+	if len(fields) > 0 {
+		sub = strings.ToLower(fields[0])
+	}
+	switch sub {
+	case "", "list":
+		listEvents(ctx)
+	case "start", "stop":
+		if !ctx.Player.IsAdmin {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may start or stop events.", game.AnsiYellow)))
+			return false
+		}
+		if len(fields) < 2 || (sub == "stop" && len(fields) > 2) || len(fields) > 3 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: event start <id> [minutes] | event stop <id>", game.AnsiYellow)))
+			return false
+		}
+		if sub == "stop" {
+			event, err := ctx.World.StopEvent(fields[1])
+			if err != nil {
+				eventError(ctx, err)
+				return false
+			}
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou end %s.", game.Style(event.Name, game.AnsiMagenta))))
+			return false
+		}
+		var duration time.Duration
+		if len(fields) == 3 {
+			minutes, err := strconv.Atoi(fields[2])
+			if err != nil || minutes <= 0 {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nMinutes must be a positive number.", game.AnsiYellow)))
+				return false
+			}
+			duration = time.Duration(minutes) * time.Minute
+		}
+		event, err := ctx.World.StartEvent(fields[1], duration)
+		if err != nil {
+			eventError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou start %s.", game.Style(event.Name, game.AnsiMagenta))))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: event [list|start <id> [minutes]|stop <id>]", game.AnsiYellow)))
+	}
+	return false
+})
+
+// listEvents shows players the events under way. Admins also see idle
+// events and when scheduled ones start next.
+func listEvents(ctx *Context) {
+	var builder strings.Builder
+	count := 0
+	for _, event := range ctx.World.Events() {
+		if !event.Running && !ctx.Player.IsAdmin {
+			continue
+		}
+		if count == 0 {
+			builder.WriteString("\r\nWorld events:")
+		}
+		count++
+		builder.WriteString("\r\n  " + game.Style(event.Name, game.AnsiMagenta))
+		if ctx.Player.IsAdmin {
+			builder.WriteString(fmt.Sprintf(" [%s]", event.ID))
+		}
+		if event.Area != "" {
+			builder.WriteString(" in " + event.Area)
+		}
+		switch {
+		case event.Running:
+			builder.WriteString(fmt.Sprintf(" - ends in %s", formatPortalDuration(time.Until(event.Ends).Round(time.Second))))
+		case !event.Next.IsZero():
+			builder.WriteString(fmt.Sprintf(" - starts in %s", formatPortalDuration(time.Until(event.Next).Round(time.Second))))
+		default:
+			builder.WriteString(" - idle")
+		}
+	}
+	if count == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo world events are under way."))
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+}
+
+func eventError(ctx *Context, err error) {
+	if errors.Is(err, game.ErrUnknownEvent) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo event is defined by that id. Type 'event' to list them.", game.AnsiYellow)))
+		return
+	}
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestEventCommandStartsAndLists(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	if err := world.AddEventForTest(game.WorldEvent{ID: "feast", Name: "Harvest Feast", XPMultiplier: 2}); err != nil {
+		t.Fatalf("AddEventForTest: %v", err)
+	}
+	admin := newTestPlayer("Admin", "start")
+	admin.IsAdmin = true
+	player := newTestPlayer("Player", "start")
+	world.AddPlayerForTest(admin)
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "event start feast")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Only admins may start or stop events") {
+		t.Fatalf("expected admin-only warning, got %q", msgs)
+	}
+	Dispatch(world, player, "events")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "No world events are under way") {
+		t.Fatalf("idle events should be hidden from players: %q", msgs)
+	}
+
+	Dispatch(world, admin, "event start feast 5")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Harvest Feast has begun!") {
+		t.Fatalf("player did not hear the announcement: %q", msgs)
+	}
+	Dispatch(world, player, "event")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Harvest Feast") || !strings.Contains(msgs, "ends in") {
+		t.Fatalf("unexpected event listing %q", msgs)
+	}
+	Dispatch(world, admin, "event stop nothing")
+	if msgs := strings.Join(drainOutput(admin.Output), " "); !strings.Contains(msgs, "No event is defined by that id") {
+		t.Fatalf("expected unknown event warning, got %q", msgs)
+	}
+	Dispatch(world, admin, "event stop feast")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Harvest Feast has ended.") {
+		t.Fatalf("player did not hear the event end: %q", msgs)
+	}
+}
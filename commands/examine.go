@@ -15,19 +15,55 @@ var Examine = Define(Definition{
 }, func(ctx *Context) bool {
 	target := strings.TrimSpace(ctx.Arg)
 	if target == "" {
-		ctx.Player.Output <- game.Ansi("\r\nExamine what?")
+		ctx.Player.Output.Send(game.Ansi("\r\nExamine what?"))
 		return false
 	}
 	item, ok := ctx.World.FindInventoryItem(ctx.Player, target)
 	if !ok {
-		ctx.Player.Output <- game.Ansi("\r\nYou aren't carrying that.")
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
 		return false
 	}
 	desc := strings.TrimSpace(item.Description)
 	if desc == "" {
 		desc = "You see nothing special."
 	}
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou examine %s. %s", game.HighlightItemName(item.Name), desc))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou examine %s. %s", item.DisplayName(), desc)))
+	if len(item.Affixes) > 0 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s. %s", game.Style(item.Rarity.Label(), game.AnsiBold), formatItemBonus(item.Bonus()))))
+	}
+	if use := item.Consumable; use != nil {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nIt is a %s; '%s' it to use it up.", use.Kind, use.Kind.Verb())))
+	}
+	if item.IsContainer() {
+		if len(item.Contents) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nIt is empty."))
+		} else {
+			names := make([]string, len(item.Contents))
+			for i, content := range item.Contents {
+				names[i] = game.HighlightItemName(content.Name)
+			}
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nIt holds: %s", strings.Join(names, ", "))))
+		}
+	}
 	ctx.World.TriggerItemInspect(ctx.Player, ctx.Player.Room, item, "inventory")
 	return false
 })
+
+// formatItemBonus describes an item's affix bonuses, e.g. "Carried, it grants
+// +2 Str, +1 damage."
+func formatItemBonus(bonus game.StatModifiers) string {
+	var parts []string
+	for _, attr := range game.AllAttributes() {
+		if value := bonus.Attributes.Get(attr); value != 0 {
+			label := strings.ToUpper(string(attr)[:1]) + string(attr)[1:]
+			parts = append(parts, fmt.Sprintf("%+d %s", value, label))
+		}
+	}
+	if bonus.Damage != 0 {
+		parts = append(parts, fmt.Sprintf("%+d damage", bonus.Damage))
+	}
+	if len(parts) == 0 {
+		return "It grants no bonuses."
+	}
+	return "Carried, it grants " + strings.Join(parts, ", ") + "."
+}
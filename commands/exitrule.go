@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+const exitRuleUsage = "Usage: exitrule <direction> [hidden|visible|level <n>|quest <id>|item <name>|message <text>|clear]"
+
+var ExitRule = Define(Definition{
+	Name:        "exitrule",
+	Usage:       "exitrule <direction> [hidden|visible|level <n>|quest <id>|item <name>|message <text>|clear]",
+	Description: "hide an exit or gate it by level, quest, or item (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use exitrule.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) == 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+exitRuleUsage, game.AnsiYellow)))
+		return false
+	}
+	dir, rule, ok := ctx.World.ExitRuleFor(ctx.Player.Room, fields[0])
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nThere is no exit %s here.", fields[0]), game.AnsiYellow)))
+		return false
+	}
+	if len(fields) == 1 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nExit %s conditions: %s", dir, rule.Describe())))
+		return false
+	}
+	updated, err := game.ParseExitRule(rule, strings.Join(fields[1:], " "))
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error()+"\r\n"+exitRuleUsage, game.AnsiYellow)))
+		return false
+	}
+	if err := ctx.World.SetExitRule(ctx.Player.Room, dir, updated, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nExit %s conditions: %s", dir, updated.Describe())))
+	return false
+})
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Extra = Define(Definition{
+	Name:        "extra",
+	Usage:       "extra [list] | extra <keywords> [on <item>] = <text|none>",
+	Description: "add examinable details to the room or an item reset (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use extra.", game.AnsiYellow)))
+		return false
+	}
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" || strings.EqualFold(arg, "list") {
+		room, ok := ctx.World.GetRoom(ctx.Player.Room)
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are nowhere.", game.AnsiYellow)))
+			return false
+		}
+		lines := []string{game.Style("Extras:", game.AnsiBold)}
+		for _, key := range game.ExtraKeywords(room.Extras) {
+			lines = append(lines, fmt.Sprintf("  %s: %s", key, room.Extras[key]))
+		}
+		for _, reset := range room.Resets {
+			for _, key := range game.ExtraKeywords(reset.Extras) {
+				lines = append(lines, fmt.Sprintf("  %s on %s: %s", key, game.HighlightItemName(reset.Name), reset.Extras[key]))
+			}
+		}
+		if len(lines) == 1 {
+			ctx.Player.Output.Send(game.Ansi("\r\nThis room has no extras."))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\n" + strings.Join(lines, "\r\n")))
+		return false
+	}
+	target, text, ok := strings.Cut(arg, "=")
+	text = strings.TrimSpace(text)
+	if !ok || strings.TrimSpace(target) == "" || text == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: extra <keywords> [on <item>] = <text|none>", game.AnsiYellow)))
+		return false
+	}
+	if strings.EqualFold(text, "none") {
+		text = ""
+	}
+	keywords, item, onItem := splitPhrase(target, "on")
+	var err error
+	if onItem {
+		err = ctx.World.SetItemResetExtra(ctx.Player.Room, item, keywords, text, ctx.Player.Name)
+	} else {
+		err = ctx.World.SetRoomExtra(ctx.Player.Room, keywords, text, ctx.Player.Name)
+	}
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	if text == "" {
+		ctx.Player.Output.Send(game.Ansi("\r\nExtra removed."))
+	} else {
+		ctx.Player.Output.Send(game.Ansi("\r\nExtra saved."))
+	}
+	return false
+})
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Friend = Define(Definition{
+	Name:        "friend",
+	Usage:       "friend <player>",
+	Description: "add or remove a player from your friends list",
+}, func(ctx *Context) bool {
+	if strings.TrimSpace(ctx.Arg) == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: friend <player>", game.AnsiYellow)))
+		return false
+	}
+	name, added, err := ctx.World.ToggleFriend(ctx.Player, ctx.Arg)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	if added {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s is now your friend. You'll hear when they log in or out.", game.HighlightName(name))))
+	} else {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou remove %s from your friends.", game.HighlightName(name))))
+	}
+	return false
+})
+
+var Friends = Define(Definition{
+	Name:        "friends",
+	Usage:       "friends",
+	Description: "show which of your friends are online",
+}, func(ctx *Context) bool {
+	friends := ctx.World.Friends(ctx.Player)
+	if len(friends) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nYour friends list is empty. Use friend <player> to add someone."))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\n" + game.Style("Friends:", game.AnsiBold))
+	for _, friend := range friends {
+		status := game.Style("offline", game.AnsiDim)
+		if friend.Online {
+			status = game.Style("online", game.AnsiGreen)
+		}
+		builder.WriteString(fmt.Sprintf("\r\n  %s - %s", game.HighlightName(friend.Name), status))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Gamble = Define(Definition{
+	Name:        "gamble",
+	Aliases:     []string{"bet", "wager"},
+	Usage:       "gamble [dice|draw <bet> | highlow <bet> <high|low>]",
+	Description: "play dice, high-low, or card draw for gold with a tavern dealer",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(strings.ToLower(ctx.Arg))
+	if len(fields) == 0 {
+		ctx.Player.Output.Send(game.Ansi(describeGames(ctx)))
+		return false
+	}
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: gamble <dice|draw|highlow> <bet> [high|low]", game.AnsiYellow)))
+		return false
+	}
+	bet, ok := parseGold(fields[1])
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nBet a whole amount of gold.", game.AnsiYellow)))
+		return false
+	}
+	call := ""
+	if len(fields) > 2 {
+		call = fields[2]
+	}
+	result, err := ctx.World.Gamble(ctx.Player, game.GambleGame(fields[0]), bet, call, time.Now())
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+gambleError(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(describeGamble(result)))
+	if result.Outcome == game.GambleWin {
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s wins %d gold from %s.", game.HighlightName(ctx.Player.Name), result.Net, game.HighlightNPCName(result.Dealer))), ctx.Player)
+	}
+	return false
+})
+
+func describeGames(ctx *Context) string {
+	dealer, ok := ctx.World.DealerHere(ctx.Player)
+	if !ok {
+		return game.Style("\r\nThere is no one running games here. Look for a dealer in a tavern.", game.AnsiYellow)
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\r\n%s runs these games, for bets of %d to %d gold:", game.HighlightNPCName(dealer.Name), game.MinBet, game.MaxBet))
+	b.WriteString("\r\n  gamble dice <bet>             Your two dice against the dealer's; high total wins.")
+	b.WriteString("\r\n  gamble draw <bet>             One card each; the higher card wins.")
+	b.WriteString("\r\n  gamble highlow <bet> <call>   Call whether the next card is high or low against the dealer's.")
+	b.WriteString(fmt.Sprintf("\r\nWins pay even money less a %d%% house edge; ties return your bet.", ctx.World.Tunables().HouseEdge))
+	return b.String()
+}
+
+func describeGamble(result game.GambleResult) string {
+	dealer := game.HighlightNPCName(result.Dealer)
+	var b strings.Builder
+	switch result.Game {
+	case game.GameDice:
+		b.WriteString(fmt.Sprintf("\r\n%s rolls %s. You roll %s.", dealer, result.House, result.Player))
+	case game.GameDraw:
+		b.WriteString(fmt.Sprintf("\r\n%s draws %s. You draw %s.", dealer, result.House, result.Player))
+	case game.GameHighLow:
+		b.WriteString(fmt.Sprintf("\r\n%s turns up %s. You call %s, and the next card is %s.", dealer, result.House, result.Call, result.Player))
+	}
+	switch result.Outcome {
+	case game.GambleWin:
+		b.WriteString("\r\n" + game.Style(fmt.Sprintf("You win %d gold!", result.Net), game.AnsiGreen))
+	case game.GambleLose:
+		b.WriteString("\r\n" + game.Style(fmt.Sprintf("You lose %d gold.", -result.Net), game.AnsiYellow))
+	default:
+		b.WriteString("\r\nA tie. Your bet is returned.")
+	}
+	return b.String()
+}
+
+func gambleError(err error) string {
+	switch {
+	case errors.Is(err, game.ErrNoDealer):
+		return "There is no dealer here."
+	case errors.Is(err, game.ErrInsufficientGold):
+		return "You don't have that much gold."
+	}
+	msg := err.Error()
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
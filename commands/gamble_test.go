@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestGambleListsGamesAndSettlesBets(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Taproom", Tavern: true, Exits: map[string]game.Exit{}, NPCs: []game.NPC{{Name: "Dicer Vell", Dealer: true}}},
+	})
+	player := newTestPlayer("Hero", "start")
+	player.Gold = 50
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "gamble")
+	out := ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "Dicer Vell runs these games") || !strings.Contains(out, "5% house edge") {
+		t.Fatalf("expected the game list, got %q", out)
+	}
+
+	Dispatch(world, player, "bet highlow 10 sideways")
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "Call high or low.") {
+		t.Fatalf("expected a call to be required, got %q", out)
+	}
+
+	Dispatch(world, player, "gamble dice 10")
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "Dicer Vell rolls") {
+		t.Fatalf("expected the dice to be rolled, got %q", out)
+	}
+	if player.Gold != 40 && player.Gold != 50 && player.Gold != 59 {
+		t.Fatalf("unexpected gold after a 10 gold bet: %d", player.Gold)
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Fish = Define(Definition{
+	Name:        "fish",
+	Usage:       "fish [spot]",
+	Description: "cast a line at a fishing spot in the room",
+}, func(ctx *Context) bool {
+	return startGathering(ctx, game.ProfessionFishing, "You cast your line into %s and wait for a bite...")
+})
+
+var Mine = Define(Definition{
+	Name:        "mine",
+	Usage:       "mine [vein]",
+	Description: "dig at an ore vein in the room",
+}, func(ctx *Context) bool {
+	return startGathering(ctx, game.ProfessionMining, "You set to work on %s with your pick...")
+})
+
+var Forage = Define(Definition{
+	Name:        "forage",
+	Usage:       "forage [patch]",
+	Description: "search a patch of plants in the room for something useful",
+}, func(ctx *Context) bool {
+	return startGathering(ctx, game.ProfessionForaging, "You search through %s...")
+})
+
+func startGathering(ctx *Context, profession game.Profession, start string) bool {
+	node, err := ctx.World.StartGathering(ctx.Player, profession, ctx.Arg, time.Now())
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\n" + fmt.Sprintf(start, game.HighlightItemName(node.Name))))
+	return false
+}
+
+var Professions = Define(Definition{
+	Name:        "professions",
+	Aliases:     []string{"gathering"},
+	Usage:       "professions",
+	Description: "show your fishing, mining, and foraging skill",
+}, func(ctx *Context) bool {
+	skills := ctx.World.ProfessionSkills(ctx.Player)
+	var b strings.Builder
+	b.WriteString("\r\nProfessions:")
+	for _, profession := range game.Professions() {
+		b.WriteString(fmt.Sprintf("\r\n  %-9s %3d/%d  ('%s')", profession, skills[profession], game.MaxProfessionSkill, profession.Verb()))
+	}
+	ctx.Player.Output.Send(game.Ansi(b.String()))
+	return false
+})
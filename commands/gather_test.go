@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestFishStartsGatheringAndProfessionsListsSkills(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}, Gather: []game.GatherNode{{
+			Profession: game.ProfessionFishing, Name: "Reed Pool", Yields: []game.GatherYield{{Item: game.Item{Name: "Perch"}}},
+		}}},
+	})
+	player := newTestPlayer("Hero", "start")
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "mine")
+	out := ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "nowhere to mine here") {
+		t.Fatalf("expected no vein, got %q", out)
+	}
+	Dispatch(world, player, "fish pool")
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "You cast your line into Reed Pool") {
+		t.Fatalf("expected a cast, got %q", out)
+	}
+
+	player.Professions = map[string]int{"fishing": 12}
+	Dispatch(world, player, "professions")
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "fishing 12/100") || !strings.Contains(out, "('forage')") {
+		t.Fatalf("expected profession skills, got %q", out)
+	}
+}
@@ -11,23 +11,37 @@ import (
 var Get = Define(Definition{
 	Name:        "get",
 	Aliases:     []string{"take", "pickup"},
-	Usage:       "get <item>",
-	Description: "pick up an item in the room",
+	Usage:       "get <item> [from <container>]",
+	Description: "pick up an item in the room or from a container",
 }, func(ctx *Context) bool {
 	target := strings.TrimSpace(ctx.Arg)
 	if target == "" {
-		ctx.Player.Output <- game.Ansi("\r\nGet what?")
+		ctx.Player.Output.Send(game.Ansi("\r\nGet what?"))
+		return false
+	}
+	if itemName, containerName, ok := splitPhrase(target, "from"); ok {
+		item, container, err := ctx.World.TakeItemFromContainer(ctx.Player, itemName, containerName)
+		if err != nil {
+			containerError(ctx.Player, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou take %s from %s.", game.HighlightItemName(item.Name), game.HighlightItemName(container.Name))))
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s takes %s from %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name), game.HighlightItemName(container.Name))), ctx.Player)
+		ctx.World.AdvanceTutorial(ctx.Player, game.TutorialGet)
 		return false
 	}
 	item, err := ctx.World.TakeItem(ctx.Player, target)
 	switch {
 	case err == nil:
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou pick up %s.", game.HighlightItemName(item.Name)))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou pick up %s.", game.HighlightItemName(item.Name))))
 		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s picks up %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name))), ctx.Player)
+		ctx.World.AdvanceTutorial(ctx.Player, game.TutorialGet)
 	case errors.Is(err, game.ErrItemNotFound):
-		ctx.Player.Output <- game.Ansi("\r\nYou don't see that here.")
+		ctx.Player.Output.Send(game.Ansi("\r\nYou don't see that here."))
+	case errors.Is(err, game.ErrTooHeavy):
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+tooHeavyMessage, game.AnsiYellow)))
 	default:
-		ctx.Player.Output <- game.Ansi("\r\n" + err.Error())
+		ctx.Player.Output.Send(game.Ansi("\r\n" + err.Error()))
 	}
 	return false
 })
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Give = Define(Definition{
+	Name:        "give",
+	Usage:       "give <item> to <player|npc>",
+	Description: "hand a carried item to someone in the room",
+}, func(ctx *Context) bool {
+	const usage = "\r\nUsage: give <item> to <player|npc>"
+	arg := strings.TrimSpace(ctx.Arg)
+	idx := strings.LastIndex(strings.ToLower(arg), " to ")
+	if idx == -1 {
+		ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+		return false
+	}
+	itemName := strings.TrimSpace(arg[:idx])
+	targetName := strings.TrimSpace(arg[idx+len(" to "):])
+	if itemName == "" || targetName == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+		return false
+	}
+	if target, ok := ctx.World.FindRoomPlayer(ctx.Player.Room, targetName); ok {
+		giveToPlayer(ctx, target, itemName)
+		return false
+	}
+	result, err := ctx.World.GiveItemToNPC(ctx.Player, itemName, targetName)
+	switch {
+	case err == nil:
+	case errors.Is(err, game.ErrItemNotCarried):
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
+		return false
+	case errors.Is(err, game.ErrNPCNotInterested):
+		ctx.Player.Output.Send(game.Ansi("\r\nThey have no use for that."))
+		return false
+	default:
+		ctx.Player.Output.Send(game.Ansi("\r\n" + err.Error()))
+		return false
+	}
+	itemText := result.Item.DisplayName()
+	npcText := game.HighlightNPCName(result.NPC.Name)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou give %s to %s.", itemText, npcText)))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s gives %s to %s.", game.HighlightName(ctx.Player.Name), itemText, npcText)), ctx.Player)
+	for _, msg := range game.FormatQuestUpdates(result.Updates) {
+		ctx.Player.Output.Send(game.Ansi("\r\n" + msg))
+	}
+	ctx.World.TriggerNPCReceive(ctx.Player, result)
+	return false
+})
+
+func giveToPlayer(ctx *Context, target *game.Player, itemName string) {
+	item, err := ctx.World.GiveItemToPlayer(ctx.Player, target, itemName)
+	switch {
+	case err == nil:
+	case errors.Is(err, game.ErrItemNotCarried):
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
+		return
+	default:
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return
+	}
+	itemText := item.DisplayName()
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou give %s to %s.", itemText, game.HighlightName(target.Name))))
+	target.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s gives you %s.", game.HighlightName(ctx.Player.Name), itemText)))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s gives %s to %s.", game.HighlightName(ctx.Player.Name), itemText, game.HighlightName(target.Name))), ctx.Player, target)
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestGiveItemToPlayer(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{}},
+	})
+	giver := newTestPlayer("Giver", "hall")
+	giver.Inventory = []game.Item{{Name: "Star Chart"}, {Name: "Anvil", Weight: 500}}
+	taker := newTestPlayer("Taker", "hall")
+	world.AddPlayerForTest(giver)
+	world.AddPlayerForTest(taker)
+
+	Dispatch(world, giver, "give anvil to taker")
+	if output := strings.Join(drainOutput(giver.Output), "\n"); !strings.Contains(output, "Taker can't carry that much weight.") {
+		t.Fatalf("heavy give output = %q", output)
+	}
+	Dispatch(world, giver, "give chart to taker")
+	if output := strings.Join(drainOutput(taker.Output), "\n"); !strings.Contains(output, "Giver gives you Star Chart.") {
+		t.Fatalf("receiver output = %q", output)
+	}
+	if len(taker.Inventory) != 1 || len(giver.Inventory) != 1 {
+		t.Fatalf("after give giver %d items, taker %d", len(giver.Inventory), len(taker.Inventory))
+	}
+}
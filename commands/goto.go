@@ -14,17 +14,17 @@ var Goto = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may use goto.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use goto.", game.AnsiYellow)))
 		return false
 	}
 	target := strings.TrimSpace(ctx.Arg)
 	if target == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: goto <room>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: goto <room>", game.AnsiYellow)))
 		return false
 	}
 	roomID := game.RoomID(target)
 	if _, ok := ctx.World.GetRoom(roomID); !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nNo such room.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo such room.", game.AnsiYellow)))
 		return false
 	}
 	prev := ctx.Player.Room
@@ -33,7 +33,7 @@ var Goto = Define(Definition{
 		return false
 	}
 	if err := ctx.World.MoveToRoom(ctx.Player, roomID); err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	ctx.World.BroadcastToRoom(prev, game.Ansi(fmt.Sprintf("\r\n%s vanishes in a shimmer of light.", game.HighlightName(ctx.Player.Name))), ctx.Player)
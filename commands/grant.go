@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Grant = Define(Definition{
+	Name:        "grant",
+	Usage:       "grant <player> area:<name>",
+	Description: "limit a builder to the areas they are granted (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may grant areas.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) != 2 || !strings.HasPrefix(strings.ToLower(fields[1]), "area:") {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: grant <player> area:<name>", game.AnsiYellow)))
+		return false
+	}
+	area, err := ctx.World.GrantBuilderArea(fields[0], fields[1])
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s may now build in area %s.", game.HighlightName(fields[0]), area)))
+	if target, ok := ctx.World.FindPlayer(fields[0]); ok && target != ctx.Player {
+		target.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou have been granted building rights in area %s.", area)))
+	}
+	return false
+})
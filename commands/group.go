@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Group = Define(Definition{
+	Name:        "group",
+	Aliases:     []string{"party"},
+	Usage:       "group [invite <player>|join <leader>|leave|kick <player>]",
+	Description: "form a group to share dungeon instances",
+}, func(ctx *Context) bool {
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	name := game.HighlightName(ctx.Player.Name)
+	switch strings.ToLower(sub) {
+	case "":
+		view, err := ctx.World.GroupStatus(ctx.Player)
+		if err != nil {
+			groupError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(formatGroup(view)))
+	case "invite":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: group invite <player>", game.AnsiYellow)))
+			return false
+		}
+		target, err := ctx.World.InviteToGroup(ctx.Player, rest)
+		if err != nil {
+			groupError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou invite %s to your group.", game.HighlightName(target.Name))))
+		target.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s invites you to their group. Type 'group join %s' to accept.", name, ctx.Player.Name)))
+	case "join", "accept":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: group join <leader>", game.AnsiYellow)))
+			return false
+		}
+		view, err := ctx.World.JoinGroup(ctx.Player, rest)
+		if err != nil {
+			groupError(ctx, err)
+			return false
+		}
+		for _, member := range view.Members {
+			if member != ctx.Player {
+				member.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s joins the group.", name)))
+			}
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou join %s's group.", game.HighlightName(view.Leader.Name)) + formatGroup(view)))
+	case "leave":
+		view, err := ctx.World.LeaveGroup(ctx.Player)
+		if err != nil {
+			groupError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nYou leave the group."))
+		notifyGroupDeparture(view, fmt.Sprintf("\r\n%s leaves the group.", name))
+	case "kick", "remove":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: group kick <player>", game.AnsiYellow)))
+			return false
+		}
+		target, err := ctx.World.KickFromGroup(ctx.Player, rest)
+		if err != nil {
+			groupError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou remove %s from the group.", game.HighlightName(target.Name))))
+		target.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s removes you from the group.", name)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: group [invite <player>|join <leader>|leave|kick <player>]", game.AnsiYellow)))
+	}
+	return false
+})
+
+// notifyGroupDeparture tells the members left behind, and who leads them
+// now, or that the group has disbanded.
+func notifyGroupDeparture(view game.GroupView, msg string) {
+	for _, member := range view.Members {
+		switch {
+		case len(view.Members) == 1:
+			member.Output.Send(game.Ansi(msg + " The group disbands."))
+		case member == view.Leader:
+			member.Output.Send(game.Ansi(msg + " You now lead the group."))
+		default:
+			member.Output.Send(game.Ansi(msg))
+		}
+	}
+}
+
+func groupError(ctx *Context, err error) {
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
+
+func formatGroup(view game.GroupView) string {
+	var builder strings.Builder
+	builder.WriteString("\r\nYour group:")
+	for _, member := range view.Members {
+		builder.WriteString("\r\n  " + game.HighlightName(member.Name))
+		if member == view.Leader {
+			builder.WriteString(" (leader)")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestGroupInviteAndJoin(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	leader := newTestPlayer("Leader", "start")
+	friend := newTestPlayer("Friend", "start")
+	world.AddPlayerForTest(leader)
+	world.AddPlayerForTest(friend)
+
+	Dispatch(world, leader, "group invite friend")
+	if msgs := strings.Join(drainOutput(friend.Output), " "); !strings.Contains(msgs, "group join Leader") {
+		t.Fatalf("friend did not get an invitation: %q", msgs)
+	}
+	Dispatch(world, friend, "group join leader")
+	if msgs := strings.Join(drainOutput(leader.Output), " "); !strings.Contains(msgs, "Friend joins the group") {
+		t.Fatalf("leader was not told of the join: %q", msgs)
+	}
+	Dispatch(world, friend, "group")
+	if msgs := strings.Join(drainOutput(friend.Output), " "); !strings.Contains(msgs, "Leader (leader)") {
+		t.Fatalf("unexpected group listing %q", msgs)
+	}
+	Dispatch(world, friend, "instance reset")
+	if msgs := strings.Join(drainOutput(friend.Output), " "); !strings.Contains(msgs, "Only Leader can reset") {
+		t.Fatalf("expected leader-only warning, got %q", msgs)
+	}
+	Dispatch(world, friend, "group leave")
+	if msgs := strings.Join(drainOutput(leader.Output), " "); !strings.Contains(msgs, "The group disbands") {
+		t.Fatalf("leader was not told the group disbanded: %q", msgs)
+	}
+	Dispatch(world, leader, "instance")
+	if msgs := strings.Join(drainOutput(leader.Output), " "); !strings.Contains(msgs, "no open instances") {
+		t.Fatalf("unexpected instance listing %q", msgs)
+	}
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Healer = Define(Definition{
+	Name:        "healer",
+	Usage:       "healer [heal|cure|resurrect]",
+	Description: "see what the healer here offers, or pay for healing, a cure, or resurrection",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		listing, err := ctx.World.HealerOffers(ctx.Player)
+		if err != nil {
+			healerError(ctx, err)
+			return false
+		}
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("\r\n%s offers:", game.HighlightNPCName(listing.Healer.Name)))
+		for _, offer := range listing.Offers {
+			builder.WriteString(fmt.Sprintf("\r\n  %-10s - %d gold", offer.Service, offer.Price))
+		}
+		if len(listing.Offers) == 0 {
+			builder.WriteString("\r\n  Nothing at the moment.")
+		}
+		switch {
+		case listing.Modifier > 0:
+			builder.WriteString(fmt.Sprintf("\r\nYour standing (%s) raises prices by %d%%.", strings.ToLower(string(listing.Standing)), listing.Modifier))
+		case listing.Modifier < 0:
+			builder.WriteString(fmt.Sprintf("\r\nYour standing (%s) lowers prices by %d%%.", strings.ToLower(string(listing.Standing)), -listing.Modifier))
+		}
+		ctx.Player.Output.Send(game.Ansi(builder.String()))
+		return false
+	}
+	service, ok := game.ParseHealerService(arg)
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+		return false
+	}
+	result, err := ctx.World.BuyHealing(ctx.Player, service)
+	if err != nil {
+		healerError(ctx, err)
+		return false
+	}
+	healer := game.HighlightNPCName(result.Healer.Name)
+	player := game.HighlightName(ctx.Player.Name)
+	var builder strings.Builder
+	switch result.Service {
+	case game.HealerHeal:
+		builder.WriteString(fmt.Sprintf("\r\n%s lays hands on you for %d gold. You recover %d health and %d mana.", healer, result.Price, result.Healed, result.Mana))
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s lays hands on %s.", healer, player)), ctx.Player)
+	case game.HealerCure:
+		builder.WriteString(fmt.Sprintf("\r\n%s draws the affliction out of you for %d gold. Cured: %s.", healer, result.Price, strings.Join(result.Cured, ", ")))
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s murmurs a cleansing prayer over %s.", healer, player)), ctx.Player)
+	case game.HealerResurrect:
+		builder.WriteString(fmt.Sprintf("\r\n%s calls your spirit back whole for %d gold.", healer, result.Price))
+		if result.Experience > 0 {
+			builder.WriteString(fmt.Sprintf("\r\nYou regain %d experience lost in death.", result.Experience))
+		}
+		if len(result.Recovered) > 0 {
+			names := make([]string, len(result.Recovered))
+			for i, item := range result.Recovered {
+				names[i] = game.HighlightItemName(item.Name)
+			}
+			builder.WriteString(fmt.Sprintf("\r\nYour belongings return from your corpse: %s.", strings.Join(names, ", ")))
+		}
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s raises %s's spirit in a blaze of light.", healer, player)), ctx.Player)
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	ctx.Player.Output.Send(game.Prompt(ctx.Player))
+	return false
+})
+
+func healerError(ctx *Context, err error) {
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+const heditUsage = "Usage: hedit <topic> [show|text <body>|append <line>|keywords <words>|category <name>|staff <on|off>|delete]"
+
+var HEdit = Define(Definition{
+	Name:        "hedit",
+	Usage:       "hedit <topic> [field] [value]",
+	Description: "create or edit help topics (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	warn := func(msg string) bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+msg, game.AnsiYellow)))
+		return false
+	}
+	if !ctx.Player.IsAdmin {
+		return warn("Only admins may edit help topics.")
+	}
+	name, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	name = strings.ToLower(name)
+	if name == "" {
+		return warn(heditUsage)
+	}
+	field, value, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	field = strings.ToLower(field)
+	value = strings.TrimSpace(value)
+	topic, exists := ctx.World.HelpTopic(name)
+	if !exists {
+		topic = game.HelpTopic{Name: name}
+	}
+	switch field {
+	case "", "show":
+		if !exists {
+			return warn(fmt.Sprintf("There is no help topic %q yet. Use 'hedit %s text <body>' to write it.", name, name))
+		}
+		ctx.Player.Output.Send(game.Ansi(formatHelpTopic(topic)))
+		return false
+	case "delete", "remove":
+		if err := ctx.World.RemoveHelpTopic(name); err != nil {
+			return warn(err.Error())
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nHelp topic %s deleted.", name)))
+		return false
+	case "text", "body":
+		if value == "" {
+			return warn("Usage: hedit <topic> text <body>")
+		}
+		topic.Body = value
+	case "append":
+		if topic.Body == "" {
+			topic.Body = value
+		} else {
+			topic.Body += "\n" + value
+		}
+	case "keywords":
+		topic.Keywords = strings.Fields(strings.ToLower(value))
+	case "category":
+		topic.Category = value
+	case "staff":
+		switch strings.ToLower(value) {
+		case "on", "yes", "true":
+			topic.Staff = true
+		case "off", "no", "false":
+			topic.Staff = false
+		default:
+			return warn("Usage: hedit <topic> staff <on|off>")
+		}
+	default:
+		return warn(heditUsage)
+	}
+	if strings.TrimSpace(topic.Body) == "" {
+		return warn(fmt.Sprintf("Give %s some text first with 'hedit %s text <body>'.", name, name))
+	}
+	if err := ctx.World.SetHelpTopic(topic); err != nil {
+		return warn(err.Error())
+	}
+	verb := "updated"
+	if !exists {
+		verb = "created"
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nHelp topic %s %s.", name, verb)))
+	return false
+})
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"LumenClay/internal/game"
@@ -10,9 +11,13 @@ import (
 var Help = Define(Definition{
 	Name:        "help",
 	Aliases:     []string{"?"},
-	Usage:       "help",
-	Description: "show this message",
+	Usage:       "help [topic|topics|search <text>]",
+	Description: "list commands, or read a help topic",
 }, func(ctx *Context) bool {
+	if query := strings.TrimSpace(ctx.Arg); query != "" {
+		ctx.Player.Output.Send(game.Ansi(helpTopicMessage(ctx, query)))
+		return false
+	}
 	general := generalHelpCommands()
 	message := helpMessage("Commands:", general)
 	if ctx.Player.IsBuilder || ctx.Player.IsAdmin {
@@ -23,8 +28,10 @@ var Help = Define(Definition{
 	}
 	if ctx.Player.IsModerator {
 		message += "\r\nModerators may type 'portal' to request a moderation portal link."
+		message += "\r\nModeration commands: mute, unmute, review, slowmode, modlog."
 	}
-	ctx.Player.Output <- game.Ansi(message)
+	message += "\r\nType 'help <topic>' to read a topic, 'help topics' to browse them, or 'help search <text>'."
+	ctx.Player.Output.Send(game.Ansi(message))
 	return false
 })
 
@@ -36,7 +43,11 @@ func helpMessage(title string, commands []*Command) string {
 		if strings.TrimSpace(usage) == "" {
 			usage = cmd.Name
 		}
-		builder.WriteString(fmt.Sprintf("  %-18s - %s\r\n", usage, cmd.Description))
+		padding := ""
+		if pad := 18 - len(usage); pad > 0 {
+			padding = strings.Repeat(" ", pad)
+		}
+		builder.WriteString(fmt.Sprintf("  %s%s - %s\r\n", game.CommandLink(usage, "help "+cmd.Name), padding, cmd.Description))
 	}
 	return builder.String()
 }
@@ -62,3 +73,167 @@ func generalHelpCommands() []*Command {
 	}
 	return filtered
 }
+
+// helpStaff reports whether the player may read staff-only help topics.
+func helpStaff(player *game.Player) bool {
+	return player.IsAdmin || player.IsModerator || player.IsBuilder
+}
+
+// helpTopicMessage answers "help <query>": an exact topic or keyword, a
+// category listing, a command's usage, a unique prefix, and finally a list of
+// close spellings.
+func helpTopicMessage(ctx *Context, query string) string {
+	topics := ctx.World.HelpTopics(helpStaff(ctx.Player))
+	lower := strings.ToLower(query)
+	switch {
+	case lower == "topics":
+		return helpTopicList("Help topics:", topics)
+	case strings.HasPrefix(lower, "search "):
+		return helpSearch(topics, strings.TrimSpace(query[len("search "):]))
+	}
+	for _, topic := range topics {
+		if topic.Matches(lower) {
+			return formatHelpTopic(topic)
+		}
+	}
+	var category []game.HelpTopic
+	for _, topic := range topics {
+		if strings.EqualFold(topic.Category, query) {
+			category = append(category, topic)
+		}
+	}
+	if len(category) > 0 {
+		return helpTopicList(category[0].Category+" topics:", category)
+	}
+	if cmd, ok := Find(lower); ok {
+		return helpMessage("Command "+cmd.Name+":", []*Command{cmd})
+	}
+	var prefixed []game.HelpTopic
+	for _, topic := range topics {
+		names := append([]string{topic.Name}, topic.Keywords...)
+		for _, name := range names {
+			if strings.HasPrefix(name, lower) {
+				prefixed = append(prefixed, topic)
+				break
+			}
+		}
+	}
+	if len(prefixed) == 1 {
+		return formatHelpTopic(prefixed[0])
+	}
+	if len(prefixed) > 1 {
+		return helpTopicList(fmt.Sprintf("Topics starting with %q:", query), prefixed)
+	}
+	if suggestions := helpSuggestions(topics, lower); len(suggestions) > 0 {
+		return game.Style(fmt.Sprintf("\r\nNo help found for %q. Did you mean: %s?", query, strings.Join(suggestions, ", ")), game.AnsiYellow)
+	}
+	return game.Style(fmt.Sprintf("\r\nNo help found for %q. Try 'help topics' or 'help search <text>'.", query), game.AnsiYellow)
+}
+
+// helpSuggestions lists topic and command names within a small edit
+// distance of query, closest first.
+func helpSuggestions(topics []game.HelpTopic, query string) []string {
+	type candidate struct {
+		name string
+		dist int
+	}
+	seen := make(map[string]bool)
+	var candidates []candidate
+	consider := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		threshold := max(len(name)/3, 1)
+		if dist := levenshtein(query, name); dist <= threshold {
+			candidates = append(candidates, candidate{name, dist})
+		}
+	}
+	for _, topic := range topics {
+		consider(topic.Name)
+		for _, keyword := range topic.Keywords {
+			consider(keyword)
+		}
+	}
+	for _, cmd := range All() {
+		consider(cmd.Name)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	names := make([]string, 0, 5)
+	for _, c := range candidates {
+		if len(names) == cap(names) {
+			break
+		}
+		names = append(names, c.name)
+	}
+	return names
+}
+
+func helpSearch(topics []game.HelpTopic, text string) string {
+	if text == "" {
+		return game.Style("\r\nUsage: help search <text>", game.AnsiYellow)
+	}
+	needle := strings.ToLower(text)
+	var found []game.HelpTopic
+	for _, topic := range topics {
+		haystack := strings.ToLower(topic.Name + " " + strings.Join(topic.Keywords, " ") + " " + topic.Body)
+		if strings.Contains(haystack, needle) {
+			found = append(found, topic)
+		}
+	}
+	if len(found) == 0 {
+		return game.Style(fmt.Sprintf("\r\nNo help topics mention %q.", text), game.AnsiYellow)
+	}
+	return helpTopicList(fmt.Sprintf("Topics mentioning %q:", text), found)
+}
+
+func helpTopicList(title string, topics []game.HelpTopic) string {
+	if len(topics) == 0 {
+		return "\r\nNo help topics are available."
+	}
+	var builder strings.Builder
+	builder.WriteString(game.Style("\r\n"+title, game.AnsiBold, game.AnsiUnderline))
+	category := ""
+	var names []string
+	flush := func() {
+		if len(names) > 0 {
+			builder.WriteString(fmt.Sprintf("\r\n  %s: %s", game.Style(category, game.AnsiBold), strings.Join(names, ", ")))
+		}
+		names = nil
+	}
+	for _, topic := range topics {
+		if topic.Category != category {
+			flush()
+			category = topic.Category
+		}
+		name := topic.Name
+		if topic.Staff {
+			name += "*"
+		}
+		names = append(names, game.CommandLink(name, "help "+topic.Name))
+	}
+	flush()
+	return builder.String()
+}
+
+func formatHelpTopic(topic game.HelpTopic) string {
+	var builder strings.Builder
+	title := strings.ToUpper(topic.Name)
+	if topic.Staff {
+		title += " (staff)"
+	}
+	builder.WriteString(game.Style("\r\n"+title, game.AnsiBold, game.AnsiUnderline))
+	builder.WriteString(game.Style(" ["+topic.Category+"]", game.AnsiDim))
+	for _, line := range strings.Split(topic.Body, "\n") {
+		builder.WriteString("\r\n" + line)
+	}
+	if len(topic.Keywords) > 0 {
+		builder.WriteString(game.Style("\r\nKeywords: "+strings.Join(topic.Keywords, ", "), game.AnsiDim))
+	}
+	return builder.String()
+}
@@ -13,7 +13,7 @@ func TestHelpMentionsPortalForModerators(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	moderator := newTestPlayer("Moderator", "start")
@@ -37,7 +37,7 @@ func TestHelpOmitsPortalNoteForAdventurers(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Start room.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Traveler", "start")
@@ -53,3 +53,69 @@ func TestHelpOmitsPortalNoteForAdventurers(t *testing.T) {
 		t.Fatalf("unexpected moderator portal note for regular player: %v", msgs)
 	}
 }
+
+func TestHelpTopicsMatchAndHideStaffEntries(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Description: "Start room.", Exits: map[string]game.Exit{}},
+	})
+	for _, topic := range []game.HelpTopic{
+		{Name: "combat", Keywords: []string{"fight"}, Category: "Adventuring", Body: "Swing at things.\nThen swing again."},
+		{Name: "moderation", Category: "Staff", Staff: true, Body: "Keep the peace."},
+	} {
+		if err := world.SetHelpTopic(topic); err != nil {
+			t.Fatalf("SetHelpTopic: %v", err)
+		}
+	}
+	player := newTestPlayer("Reader", "start")
+	world.AddPlayerForTest(player)
+
+	cases := map[string]string{
+		"help fight":         "Swing at things.",
+		"help comb":          "Then swing again.",
+		"help adventuring":   "Adventuring topics:",
+		"help search swing":  "Topics mentioning \"swing\":",
+		"help combta":        "Did you mean: combat?",
+		"help moderation":    "No help found for \"moderation\".",
+		"help topics":        "Adventuring: combat",
+		"help tell":          "tell <player> <message>",
+		"help zzzzzzzzzzzzz": "Try 'help topics'",
+	}
+	for input, want := range cases {
+		Dispatch(world, player, input)
+		if got := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(got, want) {
+			t.Fatalf("%s: output missing %q:\n%s", input, want, got)
+		}
+	}
+
+	player.IsModerator = true
+	Dispatch(world, player, "help moderation")
+	if got := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(got, "Keep the peace.") {
+		t.Fatalf("staff should read staff topics, got %q", got)
+	}
+}
+
+func TestHEditCreatesAndDeletesTopics(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Description: "Start room.", Exits: map[string]game.Exit{}},
+	})
+	admin := newTestPlayer("Admin", "start")
+	admin.IsAdmin = true
+	world.AddPlayerForTest(admin)
+
+	Dispatch(world, admin, "hedit fishing category Crafts")
+	if got := strings.Join(drainOutput(admin.Output), "\n"); !strings.Contains(got, "Give fishing some text first") {
+		t.Fatalf("expected a body prompt, got %q", got)
+	}
+	Dispatch(world, admin, "hedit fishing text Cast a line at the docks.")
+	Dispatch(world, admin, "hedit fishing append Bring bait.")
+	Dispatch(world, admin, "hedit fishing category Crafts")
+	drainOutput(admin.Output)
+	topic, ok := world.HelpTopic("fishing")
+	if !ok || topic.Category != "Crafts" || topic.Body != "Cast a line at the docks.\nBring bait." {
+		t.Fatalf("topic = %+v, %v", topic, ok)
+	}
+	Dispatch(world, admin, "hedit fishing delete")
+	if _, ok := world.HelpTopic("fishing"); ok {
+		t.Fatalf("topic should be deleted")
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -22,16 +23,94 @@ func sendChannelStatus(world *game.World, player *game.Player) {
 		}
 		builder.WriteString(fmt.Sprintf("  %-18s %s\r\n", label, state))
 	}
-	player.Output <- game.Ansi(builder.String())
+	player.Output.Send(game.Ansi(builder.String()))
+}
+
+// channelSlowed tells the player when slow mode keeps them from speaking on
+// channel yet.
+func channelSlowed(ctx *Context, channel game.Channel) bool {
+	wait := ctx.World.ChannelSpeakWait(ctx.Player, channel)
+	if wait <= 0 {
+		return false
+	}
+	seconds := int(wait.Seconds() + 0.999)
+	ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\n%s is in slow mode. Wait %d more second(s).", strings.ToUpper(string(channel)), seconds), game.AnsiYellow)))
+	return true
 }
 
 func move(world *game.World, player *game.Player, dir string) bool {
+	step(world, player, dir)
+	return false
+}
+
+// step moves the player through an exit and reports whether they moved,
+// explaining to them why not when they could not.
+func step(world *game.World, player *game.Player, dir string) bool {
 	prev := player.Room
 	if _, err := world.Move(player, dir); err != nil {
-		player.Output <- game.Ansi("\r\n" + err.Error())
+		switch {
+		case errors.Is(err, game.ErrExhausted):
+			player.Output.Send(game.Ansi(game.Style("\r\n"+world.Text(player, "move.exhausted"), game.AnsiYellow)))
+		case errors.Is(err, game.ErrOverburdened):
+			player.Output.Send(game.Ansi(game.Style("\r\n"+world.Text(player, "move.overburdened"), game.AnsiYellow)))
+		case errors.Is(err, game.ErrNoExit):
+			player.Output.Send(game.Ansi("\r\n" + world.Text(player, "move.no_exit")))
+		case errors.Is(err, game.ErrDoorClosed):
+			player.Output.Send(game.Ansi("\r\n" + world.Text(player, "move.door_closed", dir)))
+		case errors.Is(err, game.ErrHazard):
+			// The hazard has already described what happened.
+		default:
+			player.Output.Send(game.Ansi("\r\n" + err.Error()))
+		}
 		return false
 	}
-	world.BroadcastToRoom(prev, game.Ansi(fmt.Sprintf("\r\n%s leaves %s.", game.HighlightName(player.Name), dir)), player)
+	leave := fmt.Sprintf("\r\n%s leaves %s.", game.HighlightName(player.Name), dir)
+	if mount := world.MountName(player); mount != "" {
+		leave = fmt.Sprintf("\r\n%s rides %s on %s.", game.HighlightName(player.Name), dir, game.HighlightNPCName(mount))
+	}
+	world.BroadcastToRoom(prev, game.Ansi(leave), player)
+	world.AdvanceTutorial(player, game.TutorialMove)
 	game.EnterRoom(world, player, dir)
-	return false
+	return true
+}
+
+// splitPhrase divides input around the first standalone keyword, such as
+// "in" for "put gem in pouch". Matching is case-insensitive.
+func splitPhrase(input string, keywords ...string) (string, string, bool) {
+	fields := strings.Fields(input)
+	for i, field := range fields {
+		if i == 0 || i == len(fields)-1 {
+			continue
+		}
+		for _, keyword := range keywords {
+			if strings.EqualFold(field, keyword) {
+				return strings.Join(fields[:i], " "), strings.Join(fields[i+1:], " "), true
+			}
+		}
+	}
+	return strings.TrimSpace(input), "", false
+}
+
+// tooHeavyMessage explains why a player cannot pick something up.
+const tooHeavyMessage = "That is more than you can carry. Drop something first."
+
+func containerError(player *game.Player, err error) {
+	switch {
+	case errors.Is(err, game.ErrItemNotCarried):
+		player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
+	case errors.Is(err, game.ErrContainerNotFound):
+		player.Output.Send(game.Ansi("\r\nYou don't see that container here."))
+	case errors.Is(err, game.ErrNotContainer):
+		player.Output.Send(game.Ansi("\r\nYou can't put things in that."))
+	case errors.Is(err, game.ErrContainerFull):
+		player.Output.Send(game.Ansi("\r\nThere is no room left inside."))
+	case errors.Is(err, game.ErrItemNotInContainer):
+		player.Output.Send(game.Ansi("\r\nThat isn't inside."))
+	case errors.Is(err, game.ErrCorpseNotYours):
+		player.Output.Send(game.Ansi(game.Style("\r\nThat corpse is not yours to loot.", game.AnsiYellow)))
+	case errors.Is(err, game.ErrTooHeavy):
+		player.Output.Send(game.Ansi(game.Style("\r\n"+tooHeavyMessage, game.AnsiYellow)))
+	default:
+		player.Output.Send(game.Ansi("\r\n" + err.Error()))
+	}
 }
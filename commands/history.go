@@ -15,20 +15,20 @@ var History = Define(Definition{
 }, func(ctx *Context) bool {
 	fields := strings.Fields(ctx.Arg)
 	if len(fields) == 0 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: history <channel> [count]", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: history <channel> [count]", game.AnsiYellow)))
 		return false
 	}
 	channelToken := fields[0]
 	channel, ok := ctx.World.ResolveChannelToken(ctx.Player, channelToken)
 	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow)))
 		return false
 	}
 	limit := game.ChannelHistoryDefault
 	if len(fields) > 1 {
 		count, err := strconv.Atoi(fields[1])
 		if err != nil || count <= 0 {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nHistory count must be a positive number.", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nHistory count must be a positive number.", game.AnsiYellow)))
 			return false
 		}
 		if count > game.ChannelHistoryLimit {
@@ -38,7 +38,7 @@ var History = Define(Definition{
 	}
 	entries := ctx.World.ChannelHistory(ctx.Player, channel, limit)
 	if len(entries) == 0 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nNo messages recorded for that channel yet.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo messages recorded for that channel yet.", game.AnsiYellow)))
 		return false
 	}
 	label := strings.ToUpper(string(channel))
@@ -53,6 +53,6 @@ var History = Define(Definition{
 		clean = strings.TrimSuffix(clean, "\r\n")
 		builder.WriteString(fmt.Sprintf("  [%s] %s\r\n", stamp, clean))
 	}
-	ctx.Player.Output <- game.Ansi(builder.String())
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
 	return false
 })
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Ignore = Define(Definition{
+	Name:        "ignore",
+	Usage:       "ignore [player]",
+	Description: "hide a player's tells and channel messages, or list who you ignore",
+}, func(ctx *Context) bool {
+	if strings.TrimSpace(ctx.Arg) == "" {
+		ignored := ctx.World.IgnoredPlayers(ctx.Player)
+		if len(ignored) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou are not ignoring anyone."))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nYou are ignoring: " + strings.Join(game.HighlightNames(ignored), ", ")))
+		return false
+	}
+	name, ignoring, err := ctx.World.ToggleIgnore(ctx.Player, ctx.Arg)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	if ignoring {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou are now ignoring %s.", game.HighlightName(name))))
+	} else {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou stop ignoring %s.", game.HighlightName(name))))
+	}
+	return false
+})
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Instance = Define(Definition{
+	Name:        "instance",
+	Usage:       "instance [reset]",
+	Description: "list your group's dungeon instances, or reset them (leaders only)",
+}, func(ctx *Context) bool {
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "":
+		views := ctx.World.Instances(ctx.Player)
+		if len(views) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nYour group has no open instances."))
+			return false
+		}
+		var builder strings.Builder
+		builder.WriteString("\r\nOpen instances:")
+		for _, view := range views {
+			inside := "empty"
+			if len(view.Players) > 0 {
+				inside = strings.Join(game.HighlightNames(view.Players), ", ")
+			}
+			builder.WriteString(fmt.Sprintf("\r\n  %s: %s", game.Style(view.Area, game.AnsiCyan), inside))
+		}
+		ctx.Player.Output.Send(game.Ansi(builder.String()))
+	case "reset":
+		areas, err := ctx.World.ResetInstances(ctx.Player)
+		if errors.Is(err, game.ErrNoInstance) {
+			ctx.Player.Output.Send(game.Ansi("\r\nYour group has no open instances."))
+			return false
+		}
+		if err != nil {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nReset %s. The next visit starts fresh.", strings.Join(areas, ", "))))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: instance [reset]", game.AnsiYellow)))
+	}
+	return false
+})
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Intermud = Define(Definition{
+	Name:        "intermud",
+	Aliases:     []string{"imc"},
+	Usage:       "intermud [who <mud>|status|on|off|mute <mud>|unmute <mud>]",
+	Description: "list players on another MUD; admins manage the intermud link",
+}, func(ctx *Context) bool {
+	gateway := ctx.World.Intermud()
+	if gateway == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe intermud network is not configured.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	action := "status"
+	if len(fields) > 0 {
+		action = strings.ToLower(fields[0])
+	}
+	if action == "who" {
+		if len(fields) != 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: intermud who <mud>", game.AnsiYellow)))
+			return false
+		}
+		if err := gateway.RequestWho(ctx.Player, fields[1]); err != nil {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou ask %s who is online.", fields[1])))
+		return false
+	}
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage the intermud link.", game.AnsiYellow)))
+		return false
+	}
+	switch action {
+	case "on", "enable", "resume":
+		gateway.SetEnabled(true)
+		ctx.Player.Output.Send(game.Ansi("\r\nThe intermud link is now relaying."))
+	case "off", "disable", "pause":
+		gateway.SetEnabled(false)
+		ctx.Player.Output.Send(game.Ansi("\r\nThe intermud link is paused."))
+	case "mute", "unmute":
+		if len(fields) != 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: intermud "+action+" <mud>", game.AnsiYellow)))
+			return false
+		}
+		if err := gateway.SetMuted(fields[1], action == "mute"); err != nil {
+			msg := err.Error()
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+			return false
+		}
+		if action == "mute" {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nTraffic from %s is now hidden.", fields[1])))
+		} else {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nTraffic from %s is shown again.", fields[1])))
+		}
+	case "status":
+		status := gateway.Status()
+		state := game.Style("paused", game.AnsiYellow)
+		if status.Enabled {
+			state = game.Style("relaying", game.AnsiGreen)
+		}
+		connection := "disconnected"
+		if status.Connected {
+			connection = "connected to " + status.Hub
+		}
+		links := make([]string, 0, len(status.Channels))
+		for channel, remote := range status.Channels {
+			links = append(links, fmt.Sprintf("%s = %s", strings.ToUpper(string(channel)), remote))
+		}
+		sort.Strings(links)
+		muted := "none"
+		if len(status.Muted) > 0 {
+			muted = strings.Join(status.Muted, ", ")
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nIntermud: %s, %s\r\n  Channels: %s\r\n  Muted: %s",
+			state, connection, strings.Join(links, ", "), muted)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+	}
+	return false
+})
@@ -14,14 +14,61 @@ var Inventory = Define(Definition{
 	Description: "list items you are carrying",
 }, func(ctx *Context) bool {
 	items := ctx.World.PlayerInventory(ctx.Player)
+	purse := ""
+	if gold := ctx.World.PlayerGold(ctx.Player); gold > 0 {
+		purse = fmt.Sprintf("\r\nYour purse holds %s.", game.Style(fmt.Sprintf("%d gold", gold), game.AnsiYellow))
+	}
+	enc := ctx.World.Encumbrance(ctx.Player)
+	load := fmt.Sprintf("\r\nLoad: %d/%d", enc.Load, enc.Capacity)
+	switch {
+	case enc.Overloaded():
+		load += game.Style(" (overloaded: you can't move)", game.AnsiYellow, game.AnsiBold)
+	case enc.Burdened():
+		load += game.Style(" (burdened: walking costs double)", game.AnsiYellow)
+	}
 	if len(items) == 0 {
-		ctx.Player.Output <- game.Ansi("\r\nYou aren't carrying anything.")
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying anything." + purse + load))
 		return false
 	}
-	names := make([]string, len(items))
-	for i, item := range items {
-		names[i] = game.HighlightItemName(item.Name)
+	stacks := stackItems(items)
+	names := make([]string, len(stacks))
+	for i, stack := range stacks {
+		verbs := []string{"examine", "drop"}
+		if stack.item.Consumable != nil {
+			verbs = []string{stack.item.Consumable.Kind.Verb(), "examine", "drop"}
+		}
+		names[i] = game.ItemLinkFor(stack.item, verbs...)
+		if stack.count > 1 {
+			names[i] += fmt.Sprintf(" (x%d)", stack.count)
+		}
 	}
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou are carrying: %s", strings.Join(names, ", ")))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou are carrying: %s", strings.Join(names, ", ")) + purse + load))
 	return false
 })
+
+type itemStack struct {
+	item  game.Item
+	count int
+}
+
+// stackItems groups identical items, such as a handful of potions, into a
+// single entry in the order they were first picked up. Containers are never
+// stacked because their contents differ.
+func stackItems(items []game.Item) []itemStack {
+	stacks := make([]itemStack, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.IsContainer() {
+			stacks = append(stacks, itemStack{item: item, count: 1})
+			continue
+		}
+		key := strings.ToLower(item.Name) + "\x00" + string(item.Rarity)
+		if i, ok := index[key]; ok {
+			stacks[i].count++
+			continue
+		}
+		index[key] = len(stacks)
+		stacks = append(stacks, itemStack{item: item, count: 1})
+	}
+	return stacks
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Language = Define(Definition{
+	Name:        "language",
+	Aliases:     []string{"lang"},
+	Usage:       "language [<code>]",
+	Description: "show or choose the language game text is shown in",
+}, func(ctx *Context) bool {
+	languages := ctx.World.Languages()
+	names := make([]string, len(languages))
+	current := ctx.World.LanguageOf(ctx.Player)
+	for i, lang := range languages {
+		names[i] = lang.Code + " (" + lang.Name + ")"
+	}
+	available := strings.Join(names, ", ")
+	code := strings.TrimSpace(ctx.Arg)
+	if code == "" {
+		name := current
+		for _, lang := range languages {
+			if lang.Code == current {
+				name = lang.Name
+			}
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\n" + ctx.World.Text(ctx.Player, "language.current", name) +
+			"\r\n" + ctx.World.Text(ctx.Player, "language.available", available)))
+		return false
+	}
+	if len(strings.Fields(code)) != 1 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+ctx.World.Text(ctx.Player, "language.usage"), game.AnsiYellow)))
+		return false
+	}
+	lang, err := ctx.World.SetLanguage(ctx.Player, code)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+ctx.World.Text(ctx.Player, "language.unknown", strings.ToLower(code), available), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\n" + ctx.World.Text(ctx.Player, "language.set", lang.Name)))
+	return false
+})
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestLanguageSwitchesGameText(t *testing.T) {
+	world, err := game.NewWorld(filepath.Join("..", "data", "areas"))
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	player := newTestPlayer("Greta", game.StartRoom)
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "language")
+	out := strings.Join(drainOutput(player.Output), "")
+	if !strings.Contains(out, "Your language is English.") || !strings.Contains(out, "de (Deutsch)") {
+		t.Fatalf("expected current and available languages, got %q", out)
+	}
+
+	Dispatch(world, player, "language klingon")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "There is no klingon translation") {
+		t.Fatalf("expected unknown language to be refused, got %q", out)
+	}
+
+	Dispatch(world, player, "language de")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Spieltext wird jetzt auf Deutsch angezeigt.") {
+		t.Fatalf("expected confirmation in German, got %q", out)
+	}
+	Dispatch(world, player, "qqqqqqqq")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Unbekannter Befehl") {
+		t.Fatalf("expected German unknown-command text, got %q", out)
+	}
+}
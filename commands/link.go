@@ -14,12 +14,12 @@ var Link = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may use link.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use link.", game.AnsiYellow)))
 		return false
 	}
 	parts := strings.Fields(ctx.Arg)
 	if len(parts) < 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: link <direction> <room> [return-direction]", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: link <direction> <room> [return-direction]", game.AnsiYellow)))
 		return false
 	}
 	dir := parts[0]
@@ -28,14 +28,14 @@ var Link = Define(Definition{
 	if len(parts) >= 3 {
 		reverse = parts[2]
 	}
-	if err := ctx.World.LinkRooms(ctx.Player.Room, dir, target, reverse); err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+	if err := ctx.World.LinkRooms(ctx.Player.Room, dir, target, reverse, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	if reverse != "" {
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nLinked %s to %s and %s back to %s.", dir, target, reverse, ctx.Player.Room))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nLinked %s to %s and %s back to %s.", dir, target, reverse, ctx.Player.Room)))
 	} else {
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nLinked %s to %s.", dir, target))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nLinked %s to %s.", dir, target)))
 	}
 	return false
 })
@@ -14,16 +14,16 @@ var List = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may review revisions.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may review revisions.", game.AnsiYellow)))
 		return false
 	}
 	revisions, err := ctx.World.RoomRevisions(ctx.Player.Room)
 	if err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	if len(revisions) == 0 {
-		ctx.Player.Output <- game.Ansi("\r\nNo revisions recorded for this room.")
+		ctx.Player.Output.Send(game.Ansi("\r\nNo revisions recorded for this room."))
 		return false
 	}
 	var builder strings.Builder
@@ -38,6 +38,6 @@ var List = Define(Definition{
 		}
 		builder.WriteString(fmt.Sprintf("  #%d by %s — title: %q, desc: %d chars\r\n", rev.Number, editor, rev.Title, len(rev.Description)))
 	}
-	ctx.Player.Output <- game.Ansi(builder.String())
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
 	return false
 })
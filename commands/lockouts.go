@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Lockouts = Define(Definition{
+	Name:        "lockouts",
+	Usage:       "lockouts",
+	Description: "list the bosses whose spoils you have claimed and when you may claim them again",
+}, func(ctx *Context) bool {
+	lockouts := ctx.World.BossLockouts(ctx.Player)
+	if len(lockouts) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou are free to claim any boss's spoils."))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\nBoss lockouts:")
+	for _, lockout := range lockouts {
+		builder.WriteString(fmt.Sprintf("\r\n  %s - %s", game.HighlightNPCName(lockout.Boss), formatPortalDuration(time.Until(lockout.Until).Round(time.Second))))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
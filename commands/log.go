@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Log = Define(Definition{
+	Name:        "log",
+	Usage:       "log [on|off]",
+	Description: "toggle live server warnings and errors (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may watch the server log.", game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "on", "enable", "enabled":
+		ctx.World.SetChannel(ctx.Player, game.ChannelLog, true)
+		ctx.Player.Output.Send(game.Ansi("\r\nServer warnings and errors will appear as they happen."))
+	case "off", "disable", "disabled":
+		ctx.World.SetChannel(ctx.Player, game.ChannelLog, false)
+		ctx.Player.Output.Send(game.Ansi("\r\nYou stop watching the server log."))
+	case "", "status":
+		state := game.Style("off", game.AnsiYellow)
+		if ctx.World.ChannelEnabled(ctx.Player, game.ChannelLog) {
+			state = game.Style("on", game.AnsiGreen)
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nServer log channel: " + state))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: log [on|off]", game.AnsiYellow)))
+	}
+	return false
+})
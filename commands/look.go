@@ -15,7 +15,7 @@ var Look = Define(Definition{
 }, func(ctx *Context) bool {
 	room, ok := ctx.World.GetRoom(ctx.Player.Room)
 	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou see only void.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou see only void.", game.AnsiYellow)))
 		return false
 	}
 
@@ -28,7 +28,7 @@ var Look = Define(Definition{
 			if greet := strings.TrimSpace(npc.AutoGreet); greet != "" {
 				line = fmt.Sprintf("%s They say, \"%s\"", line, greet)
 			}
-			ctx.Player.Output <- game.Ansi(line)
+			ctx.Player.Output.Send(game.Ansi(line))
 			if offered := ctx.World.QuestsByNPC(npc.Name); len(offered) > 0 {
 				if available := ctx.World.AvailableQuests(ctx.Player); len(available) > 0 {
 					eligible := make(map[string]struct{}, len(available))
@@ -43,27 +43,50 @@ var Look = Define(Definition{
 						names = append(names, game.HighlightQuestName(quest.Name))
 					}
 					if len(names) > 0 {
-						ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nThey seem ready to offer: %s", strings.Join(names, ", ")))
-						ctx.Player.Output <- game.Ansi("\r\nUse 'quests accept <id>' to begin.")
+						ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThey seem ready to offer: %s", strings.Join(names, ", "))))
+						ctx.Player.Output.Send(game.Ansi("\r\nUse 'quests accept <id>' to begin."))
 					}
 				}
 			}
 			return false
 		}
+		if other, found := ctx.World.FindRoomPlayer(ctx.Player.Room, target); found {
+			if bio, ok := ctx.World.PlayerBio(other); ok {
+				lines := strings.Split(bio, "\n")
+				for i, line := range lines {
+					lines[i] = game.WrapText(line, width)
+				}
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou look at %s.\r\n%s", game.TitledName(other.Name, other.Title), strings.Join(lines, "\r\n"))))
+			} else {
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou look at %s. You see nothing unusual about them.", game.TitledName(other.Name, other.Title))))
+			}
+			return false
+		}
 		if item, found := ctx.World.FindRoomItem(ctx.Player.Room, target); found {
 			desc := strings.TrimSpace(item.Description)
 			if desc == "" {
 				desc = "You see nothing special."
 			}
-			ctx.Player.Output <- game.Ansi(fmt.Sprintf(
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf(
 				"\r\nYou study %s. %s",
-				game.HighlightItemName(item.Name),
+				item.DisplayName(),
 				game.WrapText(desc, width),
-			))
+			)))
+			if len(item.Contents) > 0 {
+				names := make([]string, len(item.Contents))
+				for i, content := range item.Contents {
+					names[i] = game.HighlightItemName(content.Name)
+				}
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nIt holds: %s", strings.Join(names, ", "))))
+			}
 			ctx.World.TriggerItemInspect(ctx.Player, ctx.Player.Room, item, "room")
 			return false
 		}
-		if dir, dest, found := ctx.World.ResolveExit(ctx.Player.Room, target); found {
+		if dir, dest, found := ctx.World.ResolveExit(ctx.Player, target); found {
+			if room.Exits[dir].Closed {
+				ctx.Player.Output.Send(game.Ansi("\r\n" + ctx.World.Text(ctx.Player, "move.door_closed", dir)))
+				return false
+			}
 			message := fmt.Sprintf("\r\nLooking %s you glimpse a passage.", dir)
 			if next, ok := ctx.World.GetRoom(dest); ok {
 				title := game.Style(next.Title, game.AnsiBold, game.AnsiCyan)
@@ -79,40 +102,48 @@ var Look = Define(Definition{
 					message = fmt.Sprintf("\r\nLooking %s you glimpse %s.", dir, title)
 				}
 			}
-			ctx.Player.Output <- game.Ansi(message)
+			ctx.Player.Output.Send(game.Ansi(message))
 			return false
 		}
-		ctx.Player.Output <- game.Ansi("\r\nYou don't see that here.")
+		if text, found := ctx.World.LookExtra(ctx.Player, target); found {
+			ctx.Player.Output.Send(game.Ansi("\r\n" + game.WrapText(text, width)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nYou don't see that here."))
 		return false
 	}
 
 	title := game.Style(room.Title, game.AnsiBold, game.AnsiCyan)
-	desc := game.Style(game.WrapText(room.Description, width), game.AnsiItalic, game.AnsiDim)
-	exits := game.Style(game.ExitList(room), game.AnsiGreen)
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n%s\r\n%s\r\nExits: %s", title, desc, exits))
+	desc, dark := game.DescribeRoom(ctx.World, room, width)
+	exits := game.Style(game.ExitLinks(ctx.World.ExitsFor(ctx.Player, room)), game.AnsiGreen)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s\r\n%s\r\nExits: %s", title, desc, exits)))
+	for _, note := range ctx.World.RoomNotes(ctx.Player) {
+		ctx.Player.Output.Send(game.Ansi("\r\n" + note))
+	}
 
 	others := ctx.World.ListPlayers(true, ctx.Player.Room)
 	if len(others) > 1 {
 		seen := game.FilterOut(others, ctx.Player.Name)
 		colored := game.HighlightNames(seen)
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou see: %s", strings.Join(colored, ", ")))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou see: %s", strings.Join(colored, ", "))))
 	}
 
-	if npcs := ctx.World.RoomNPCs(ctx.Player.Room); len(npcs) > 0 {
+	if npcs := ctx.World.RoomNPCs(ctx.Player.Room); len(npcs) > 0 && !dark {
 		names := make([]string, len(npcs))
 		for i, npc := range npcs {
 			names[i] = game.HighlightNPCName(npc.Name)
 		}
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou notice: %s", strings.Join(names, ", ")))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou notice: %s", strings.Join(names, ", "))))
 	}
 
-	if items := ctx.World.RoomItems(ctx.Player.Room); len(items) > 0 {
+	if items := ctx.World.RoomItems(ctx.Player.Room); len(items) > 0 && !dark {
 		names := make([]string, len(items))
 		for i, item := range items {
-			names[i] = game.HighlightItemName(item.Name)
+			names[i] = game.ItemLinkFor(item, "get", "examine")
 		}
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nOn the ground: %s", strings.Join(names, ", ")))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nOn the ground: %s", strings.Join(names, ", "))))
 	}
 	ctx.World.TriggerRoomLook(ctx.Player)
+	ctx.World.AdvanceTutorial(ctx.Player, game.TutorialLook)
 	return false
 })
@@ -13,14 +13,14 @@ func TestLookListsNPCs(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{"north": "hall"},
+			Exits:       map[string]game.Exit{"north": {To: "hall"}},
 			NPCs:        []game.NPC{{Name: "Guide"}},
 		},
 		"hall": {
 			ID:          "hall",
 			Title:       "Hallway",
 			Description: "A long corridor.",
-			Exits:       map[string]game.RoomID{"south": "start"},
+			Exits:       map[string]game.Exit{"south": {To: "start"}},
 		},
 	})
 	player := newTestPlayer("Hero", "start")
@@ -48,7 +48,7 @@ func TestLookAtNPC(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 			NPCs:        []game.NPC{{Name: "Guide", AutoGreet: "Welcome!"}},
 		},
 	})
@@ -70,7 +70,7 @@ func TestLookAtItem(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 			Items:       []game.Item{{Name: "Golden Key", Description: "It's engraved with runes."}},
 		},
 	})
@@ -99,13 +99,13 @@ func TestLookAtExit(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{"north": "garden"},
+			Exits:       map[string]game.Exit{"north": {To: "garden"}},
 		},
 		"garden": {
 			ID:          "garden",
 			Title:       "Verdant Garden",
 			Description: "A lush garden bathed in sunlight.",
-			Exits:       map[string]game.RoomID{"south": "start"},
+			Exits:       map[string]game.Exit{"south": {To: "start"}},
 		},
 	})
 	player := newTestPlayer("Hero", "start")
@@ -133,7 +133,7 @@ func TestLookUnknownTarget(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Hero", "start")
@@ -154,7 +154,7 @@ func TestExamineInventoryItem(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Hero", "start")
@@ -176,7 +176,7 @@ func TestExamineUnknownInventoryItem(t *testing.T) {
 			ID:          "start",
 			Title:       "Starting Room",
 			Description: "A quiet foyer.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	player := newTestPlayer("Hero", "start")
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Loot = Define(Definition{
+	Name:        "loot",
+	Aliases:     []string{"corpse"},
+	Usage:       "loot [corpse]",
+	Description: "retrieve everything held by a corpse",
+}, func(ctx *Context) bool {
+	corpse, items, err := ctx.World.LootCorpse(ctx.Player, ctx.Arg)
+	switch {
+	case err == nil:
+	case errors.Is(err, game.ErrNoCorpse):
+		ctx.Player.Output.Send(game.Ansi("\r\nYou don't see a corpse here."))
+		return false
+	case errors.Is(err, game.ErrCorpseNotYours):
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThat corpse is not yours to loot.", game.AnsiYellow)))
+		return false
+	case errors.Is(err, game.ErrTooHeavy):
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+tooHeavyMessage, game.AnsiYellow)))
+		return false
+	default:
+		ctx.Player.Output.Send(game.Ansi("\r\n" + err.Error()))
+		return false
+	}
+	if len(items) == 0 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe %s holds nothing of value.", game.HighlightItemName(corpse))))
+		return false
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.DisplayName()
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou recover %s from the %s.", strings.Join(names, ", "), game.HighlightItemName(corpse))))
+	if leftover, ok := ctx.World.FindRoomItem(ctx.Player.Room, corpse); ok && len(leftover.Contents) > 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou can't carry the rest; it stays with the corpse.", game.AnsiYellow)))
+	}
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s searches the %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(corpse))), ctx.Player)
+	return false
+})
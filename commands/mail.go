@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"LumenClay/internal/game"
@@ -11,12 +13,12 @@ const mailTimeLayout = "2006-01-02 15:04"
 
 var Mail = Define(Definition{
 	Name:        "mail",
-	Usage:       "mail boards | mail board <name> | mail write <board> [recipients] = <message>",
-	Description: "read and write public board posts",
+	Usage:       "mail boards | mail board <name> | mail write <board> [recipients] = <message> | mail send <player> [attach <item>] = <message>",
+	Description: "read and write board posts and personal letters",
 }, func(ctx *Context) bool {
 	mail := ctx.World.MailSystem()
 	if mail == nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nThe public boards are currently unavailable.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe public boards are currently unavailable.", game.AnsiYellow)))
 		return false
 	}
 	arg := strings.TrimSpace(ctx.Arg)
@@ -36,6 +38,16 @@ var Mail = Define(Definition{
 		handleMailBoard(ctx, mail, fields)
 	case "write":
 		handleMailWrite(ctx, mail, arg, fields)
+	case "send":
+		handleMailSend(ctx, arg, fields)
+	case "inbox":
+		handleMailBoard(ctx, mail, []string{"board", game.PersonalMailBoard})
+	case "claim":
+		handleMailClaim(ctx, fields)
+	case "forward":
+		handleMailForward(ctx, mail, arg, fields)
+	case "delete":
+		handleMailDelete(ctx, mail, fields)
 	default:
 		// Treat the first token as a board name for convenience.
 		handleMailBoard(ctx, mail, append([]string{"board"}, fields...))
@@ -49,13 +61,18 @@ func sendMailHelp(player *game.Player) {
 	builder.WriteString("  mail boards - List boards and personal posts.\r\n")
 	builder.WriteString("  mail board <name> - Show posts on a board.\r\n")
 	builder.WriteString("  mail write <board> [recipients] = <message> - Post to a board; recipients are comma-separated player names.\r\n")
-	player.Output <- game.Ansi(builder.String())
+	builder.WriteString("  mail send <player> [attach <item>] = <message> - Send a personal letter, optionally carrying an item.\r\n")
+	builder.WriteString("  mail inbox - Read the personal letters addressed to you.\r\n")
+	builder.WriteString("  mail claim <id> - Collect the items attached to a letter.\r\n")
+	builder.WriteString("  mail forward <id> <player> [= note] - Forward a message you can read to another player.\r\n")
+	builder.WriteString("  mail delete <id> - Remove a personal letter from your mailbox.\r\n")
+	player.Output.Send(game.Ansi(builder.String()))
 }
 
 func sendMailBoards(player *game.Player, mail *game.MailSystem, self string) {
 	boards := mail.Boards()
 	if len(boards) == 0 {
-		player.Output <- game.Ansi("\r\nNo boards have any posts yet.")
+		player.Output.Send(game.Ansi("\r\nNo boards have any posts yet."))
 		return
 	}
 	var builder strings.Builder
@@ -74,18 +91,21 @@ func sendMailBoards(player *game.Player, mail *game.MailSystem, self string) {
 		}
 		builder.WriteString(line + "\r\n")
 	}
-	player.Output <- game.Ansi(builder.String())
+	player.Output.Send(game.Ansi(builder.String()))
 }
 
 func handleMailBoard(ctx *Context, mail *game.MailSystem, fields []string) {
 	if len(fields) < 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nWhich board?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWhich board?", game.AnsiYellow)))
 		return
 	}
 	board := fields[1]
 	messages := mail.Messages(board)
+	if strings.EqualFold(board, game.PersonalMailBoard) {
+		messages = personalLetters(messages, ctx.Player.Name)
+	}
 	if len(messages) == 0 {
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nThere are no posts on %s yet.", board))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThere are no posts on %s yet.", board)))
 		return
 	}
 	var builder strings.Builder
@@ -93,7 +113,10 @@ func handleMailBoard(ctx *Context, mail *game.MailSystem, fields []string) {
 	for _, msg := range messages {
 		builder.WriteString(formatMailMessage(msg, ctx.Player.Name))
 	}
-	ctx.Player.Output <- game.Ansi(builder.String())
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	if strings.EqualFold(board, game.PersonalMailBoard) {
+		_ = mail.MarkInboxRead(ctx.Player.Name)
+	}
 }
 
 func formatMailMessage(msg game.MailMessage, viewer string) string {
@@ -107,39 +130,61 @@ func formatMailMessage(msg game.MailMessage, viewer string) string {
 	for _, line := range strings.Split(msg.Body, "\n") {
 		builder.WriteString("       " + line + "\r\n")
 	}
+	if len(msg.Attachments) > 0 || msg.Gold > 0 {
+		names := make([]string, 0, len(msg.Attachments)+1)
+		for _, item := range msg.Attachments {
+			names = append(names, game.HighlightItemName(item.Name))
+		}
+		if msg.Gold > 0 {
+			names = append(names, game.Style(fmt.Sprintf("%d gold", msg.Gold), game.AnsiYellow))
+		}
+		builder.WriteString(fmt.Sprintf("       Attached: %s (mail claim %d)\r\n", strings.Join(names, ", "), msg.ID))
+	} else if msg.ClaimedBy != "" {
+		builder.WriteString(fmt.Sprintf("       Attachments claimed by %s.\r\n", game.HighlightName(msg.ClaimedBy)))
+	}
 	return builder.String()
 }
 
+func personalLetters(messages []game.MailMessage, viewer string) []game.MailMessage {
+	out := make([]game.MailMessage, 0, len(messages))
+	for _, msg := range messages {
+		if len(msg.Recipients) > 0 && msg.AddressedTo(viewer) {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
 func handleMailWrite(ctx *Context, mail *game.MailSystem, arg string, fields []string) {
 	if len(fields) < 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nWhich board should receive the post?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWhich board should receive the post?", game.AnsiYellow)))
 		return
 	}
 	board := fields[1]
 	rest := strings.TrimSpace(arg[len(fields[0]):])
 	rest = strings.TrimSpace(rest[len(board):])
 	if rest == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nProvide recipients (optional) and a message separated by '='.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nProvide recipients (optional) and a message separated by '='.", game.AnsiYellow)))
 		return
 	}
 	parts := strings.SplitN(rest, "=", 2)
 	if len(parts) != 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUse '=' to separate recipients from the message body.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUse '=' to separate recipients from the message body.", game.AnsiYellow)))
 		return
 	}
 	recipients := parseRecipients(parts[0])
 	body := strings.TrimSpace(parts[1])
 	if body == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYour message is empty.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYour message is empty.", game.AnsiYellow)))
 		return
 	}
 	msg, err := mail.Write(board, ctx.Player.Name, recipients, body)
 	if err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return
 	}
 	summary := msg.RecipientSummary()
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou post to %s for %s.\r\n", game.Style(strings.ToUpper(board), game.AnsiCyan, game.AnsiBold), summary))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou post to %s for %s.\r\n", game.Style(strings.ToUpper(board), game.AnsiCyan, game.AnsiBold), summary)))
 }
 
 func parseRecipients(raw string) []string {
@@ -167,3 +212,124 @@ func parseRecipients(raw string) []string {
 	}
 	return cleaned
 }
+
+func handleMailSend(ctx *Context, arg string, fields []string) {
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: mail send <player> [attach <item>] = <message>", game.AnsiYellow)))
+		return
+	}
+	rest := strings.TrimSpace(arg[len(fields[0]):])
+	header, body, ok := strings.Cut(rest, "=")
+	body = strings.TrimSpace(body)
+	if !ok || body == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUse '=' to separate the recipient from the message body.", game.AnsiYellow)))
+		return
+	}
+	recipient, item, _ := splitPhrase(header, "attach")
+	if recipient == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWho should receive the letter?", game.AnsiYellow)))
+		return
+	}
+	msg, err := ctx.World.SendMail(ctx.Player, recipient, body, item)
+	if err != nil {
+		if errors.Is(err, game.ErrItemNotCarried) {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou aren't carrying that.", game.AnsiYellow)))
+			return
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return
+	}
+	line := fmt.Sprintf("\r\nYour letter to %s is on its way.", game.HighlightName(msg.RecipientSummary()))
+	if len(msg.Attachments) > 0 {
+		line = fmt.Sprintf("\r\nYour letter to %s is on its way, carrying %s.", game.HighlightName(msg.RecipientSummary()), game.HighlightItemName(msg.Attachments[0].Name))
+	}
+	ctx.Player.Output.Send(game.Ansi(line))
+	if target, ok := ctx.World.ActivePlayer(msg.Recipients[0]); ok && target != ctx.Player {
+		target.Output.Send(game.Ansi(fmt.Sprintf("\r\nA letter from %s arrives. Type 'mail inbox' to read it.", game.HighlightName(ctx.Player.Name))))
+	}
+}
+
+func parseMailID(ctx *Context, fields []string, usage string) (int, bool) {
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+usage, game.AnsiYellow)))
+		return 0, false
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+	if err != nil || id <= 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nMessage ids are positive numbers.", game.AnsiYellow)))
+		return 0, false
+	}
+	return id, true
+}
+
+func handleMailClaim(ctx *Context, fields []string) {
+	id, ok := parseMailID(ctx, fields, "mail claim <id>")
+	if !ok {
+		return
+	}
+	items, gold, err := ctx.World.ClaimMailAttachments(ctx.Player, id)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+mailErrorText(err), game.AnsiYellow)))
+		return
+	}
+	names := make([]string, 0, len(items)+1)
+	for _, item := range items {
+		names = append(names, game.HighlightItemName(item.Name))
+	}
+	if gold > 0 {
+		names = append(names, game.Style(fmt.Sprintf("%d gold", gold), game.AnsiYellow))
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou collect %s from letter #%d.", strings.Join(names, ", "), id)))
+}
+
+func handleMailForward(ctx *Context, mail *game.MailSystem, arg string, fields []string) {
+	const usage = "mail forward <id> <player> [= note]"
+	id, ok := parseMailID(ctx, fields, usage)
+	if !ok {
+		return
+	}
+	if len(fields) < 3 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+usage, game.AnsiYellow)))
+		return
+	}
+	_, note, _ := strings.Cut(arg, "=")
+	recipient := strings.TrimSpace(strings.SplitN(fields[2], "=", 2)[0])
+	if recipient == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+usage, game.AnsiYellow)))
+		return
+	}
+	msg, err := mail.Forward(id, ctx.Player.Name, recipient, note)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+mailErrorText(err), game.AnsiYellow)))
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou forward message #%d to %s.", id, game.HighlightName(msg.RecipientSummary()))))
+	if target, ok := ctx.World.ActivePlayer(msg.Recipients[0]); ok && target != ctx.Player {
+		target.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s forwarded you a letter. Type 'mail inbox' to read it.", game.HighlightName(ctx.Player.Name))))
+	}
+}
+
+func handleMailDelete(ctx *Context, mail *game.MailSystem, fields []string) {
+	id, ok := parseMailID(ctx, fields, "mail delete <id>")
+	if !ok {
+		return
+	}
+	if err := mail.Delete(id, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+mailErrorText(err), game.AnsiYellow)))
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nLetter #%d deleted.", id)))
+}
+
+func mailErrorText(err error) string {
+	switch {
+	case errors.Is(err, game.ErrMailNotFound):
+		return "You have no message with that id."
+	case errors.Is(err, game.ErrNoAttachments):
+		return "That letter carries nothing to claim."
+	case errors.Is(err, game.ErrAttachmentsPending):
+		return "Claim the attached items and gold before deleting that letter."
+	default:
+		return err.Error()
+	}
+}
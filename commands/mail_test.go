@@ -13,7 +13,7 @@ func TestMailBoardsListsBoards(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "A humble origin.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	mail, err := game.NewMailSystem("")
@@ -52,7 +52,7 @@ func TestMailWriteCommandStoresPost(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "A humble origin.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	mail, err := game.NewMailSystem("")
@@ -95,7 +95,7 @@ func TestMailBoardShowsForYouMarker(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "A humble origin.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	mail, err := game.NewMailSystem("")
@@ -126,3 +126,47 @@ func TestMailBoardShowsForYouMarker(t *testing.T) {
 		t.Fatalf("expected '(for you)' marker in output: %v", output)
 	}
 }
+
+func TestMailSendAttachAndClaim(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Description: "A humble origin.", Exits: map[string]game.Exit{}},
+	})
+	mail, err := game.NewMailSystem("")
+	if err != nil {
+		t.Fatalf("NewMailSystem error: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	sage := newTestPlayer("Sage", "start")
+	hero := newTestPlayer("Hero", "start")
+	world.AddPlayerForTest(sage)
+	world.AddPlayerForTest(hero)
+	sage.Inventory = []game.Item{{Name: "Brass Key"}}
+
+	Dispatch(world, sage, "mail send Hero attach brass key = Keep this safe.")
+	output := strings.Join(drainOutput(sage.Output), "")
+	if !strings.Contains(output, "on its way") {
+		t.Fatalf("expected send confirmation, got %q", output)
+	}
+	if len(sage.Inventory) != 0 {
+		t.Fatalf("expected the key to be mailed, inventory %v", sage.Inventory)
+	}
+	if notice := strings.Join(drainOutput(hero.Output), ""); !strings.Contains(notice, "mail inbox") {
+		t.Fatalf("expected arrival notice, got %q", notice)
+	}
+
+	Dispatch(world, hero, "mail inbox")
+	inbox := strings.Join(drainOutput(hero.Output), "")
+	if !strings.Contains(inbox, "Keep this safe.") || !strings.Contains(inbox, "mail claim 1") {
+		t.Fatalf("inbox missing letter details: %q", inbox)
+	}
+	Dispatch(world, sage, "mail inbox")
+	if other := strings.Join(drainOutput(sage.Output), ""); strings.Contains(other, "Keep this safe.") {
+		t.Fatalf("letters must stay private, got %q", other)
+	}
+
+	Dispatch(world, hero, "mail claim 1")
+	claim := strings.Join(drainOutput(hero.Output), "")
+	if !strings.Contains(claim, "You collect") || len(hero.Inventory) != 1 {
+		t.Fatalf("claim failed: %q, inventory %v", claim, hero.Inventory)
+	}
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Map = Define(Definition{
+	Name:        "map",
+	Usage:       "map [radius]",
+	Description: "draw a map of the nearby rooms",
+	Group:       GroupGeneral,
+}, func(ctx *Context) bool {
+	radius := game.DefaultMapRadius
+	if arg := strings.TrimSpace(ctx.Arg); arg != "" {
+		value, err := strconv.Atoi(arg)
+		if err != nil || value < 1 || value > game.MaxMapRadius {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nUsage: map [radius] (1-%d)", game.MaxMapRadius), game.AnsiYellow)))
+			return false
+		}
+		radius = value
+	}
+	rendered := game.RenderMap(ctx.World, ctx.Player, radius)
+	if rendered == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou cannot make out your surroundings.", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(rendered))
+	return false
+})
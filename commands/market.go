@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Market = Define(Definition{
+	Name:        "market",
+	Aliases:     []string{"auction"},
+	Usage:       "market [list [text]|mine|sell <item> for <price>|buy <id>|cancel <id>]",
+	Description: "browse, buy, and list items on the market board from any market room",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(ctx.Arg)
+	sub := "list"
+	if len(fields) > 0 {
+		sub = strings.ToLower(fields[0])
+	}
+	switch sub {
+	case "list", "browse":
+		showMarket(ctx, strings.Join(fields[min(1, len(fields)):], " "), false)
+	case "mine":
+		showMarket(ctx, "", true)
+	case "sell":
+		handleMarketSell(ctx, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(ctx.Arg), fields[0])))
+	case "buy", "cancel":
+		if len(fields) < 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nUsage: market %s <id>", sub), game.AnsiYellow)))
+			return false
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nListing ids are numbers.", game.AnsiYellow)))
+			return false
+		}
+		if sub == "buy" {
+			handleMarketBuy(ctx, id)
+		} else {
+			handleMarketCancel(ctx, id)
+		}
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: market [list [text]|mine|sell <item> for <price>|buy <id>|cancel <id>]", game.AnsiYellow)))
+	}
+	return false
+})
+
+func showMarket(ctx *Context, filter string, mine bool) {
+	if !ctx.World.InMarket(ctx.Player) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+marketErrorText(game.ErrNotInMarket), game.AnsiYellow)))
+		return
+	}
+	market := ctx.World.MarketSystem()
+	if market == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe market is closed.", game.AnsiYellow)))
+		return
+	}
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	var builder strings.Builder
+	shown := 0
+	for _, listing := range market.Listings() {
+		if mine && !strings.EqualFold(listing.Seller, ctx.Player.Name) {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(listing.Item.Name), filter) {
+			continue
+		}
+		remaining := time.Until(listing.ExpiresAt).Round(time.Minute)
+		if remaining < time.Minute {
+			remaining = time.Minute
+		}
+		builder.WriteString(fmt.Sprintf("\r\n  [%d] %s %s from %s (%s left)",
+			listing.ID,
+			game.HighlightItemName(listing.Item.Name),
+			game.Style(fmt.Sprintf("%d gold", listing.Price), game.AnsiYellow),
+			game.HighlightName(listing.Seller),
+			remaining))
+		shown++
+	}
+	if shown == 0 {
+		if mine {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou have nothing listed on the market."))
+		} else {
+			ctx.Player.Output.Send(game.Ansi("\r\nThe market board is empty."))
+		}
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nMarket listings:%s\r\nType 'market buy <id>' to purchase.", builder.String())))
+}
+
+func handleMarketSell(ctx *Context, arg string) {
+	const usage = "\r\nUsage: market sell <item> for <price>"
+	idx := strings.LastIndex(strings.ToLower(arg), " for ")
+	if idx == -1 {
+		ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+		return
+	}
+	itemName := strings.TrimSpace(arg[:idx])
+	price, ok := parseGold(arg[idx+len(" for "):])
+	if itemName == "" || !ok || price <= 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+		return
+	}
+	listing, err := ctx.World.ListForSale(ctx.Player, itemName, price)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+marketErrorText(err), game.AnsiYellow)))
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou list %s for %s as listing #%d. Unsold items return by mail after %s.",
+		game.HighlightItemName(listing.Item.Name), game.Style(fmt.Sprintf("%d gold", listing.Price), game.AnsiYellow),
+		listing.ID, listing.ExpiresAt.Sub(listing.ListedAt).Round(time.Minute))))
+}
+
+func handleMarketBuy(ctx *Context, id int) {
+	listing, err := ctx.World.BuyListing(ctx.Player, id)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+marketErrorText(err), game.AnsiYellow)))
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou buy %s from %s for %s.",
+		game.HighlightItemName(listing.Item.Name), game.HighlightName(listing.Seller),
+		game.Style(fmt.Sprintf("%d gold", listing.Price), game.AnsiYellow))))
+}
+
+func handleMarketCancel(ctx *Context, id int) {
+	listing, err := ctx.World.CancelListing(ctx.Player, id)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+marketErrorText(err), game.AnsiYellow)))
+		return
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou take %s off the market.", game.HighlightItemName(listing.Item.Name))))
+}
+
+func marketErrorText(err error) string {
+	switch {
+	case errors.Is(err, game.ErrNotInMarket):
+		return "You need to be at a market to trade."
+	case errors.Is(err, game.ErrListingNotFound):
+		return "There is no listing with that id."
+	case errors.Is(err, game.ErrTooManyListings):
+		return fmt.Sprintf("You already have %d items listed.", game.MaxListingsPerSeller)
+	case errors.Is(err, game.ErrOwnListing):
+		return "That is your own listing; use 'market cancel' to take it back."
+	case errors.Is(err, game.ErrNotYourListing):
+		return "You can only cancel your own listings."
+	case errors.Is(err, game.ErrInsufficientGold):
+		return "You don't have enough gold."
+	case errors.Is(err, game.ErrItemNotCarried):
+		return "You aren't carrying that."
+	case errors.Is(err, game.ErrTooHeavy):
+		return tooHeavyMessage
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestMarketCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"stalls": {ID: "stalls", Title: "Stalls", Market: true, Exits: map[string]game.Exit{}},
+	})
+	mail, err := game.NewMailSystem("")
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	market, err := game.NewMarketSystem("")
+	if err != nil {
+		t.Fatalf("NewMarketSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	world.AttachMarketSystem(market)
+
+	seller := newTestPlayer("Seller", "stalls")
+	seller.Inventory = []game.Item{{Name: "Copper Kettle"}}
+	buyer := newTestPlayer("Buyer", "stalls")
+	buyer.Gold = 10
+	world.AddPlayerForTest(seller)
+	world.AddPlayerForTest(buyer)
+
+	Dispatch(world, seller, "market sell copper kettle for 8 gold")
+	output := strings.Join(drainOutput(seller.Output), "\n")
+	if !strings.Contains(output, "You list Copper Kettle for 8 gold as listing #1.") {
+		t.Fatalf("unexpected sell output: %q", output)
+	}
+
+	Dispatch(world, buyer, "auction list kettle")
+	output = strings.Join(drainOutput(buyer.Output), "\n")
+	if !strings.Contains(output, "[1] Copper Kettle 8 gold from Seller") {
+		t.Fatalf("unexpected listing output: %q", output)
+	}
+
+	Dispatch(world, buyer, "market buy 1")
+	output = strings.Join(drainOutput(buyer.Output), "\n")
+	if !strings.Contains(output, "You buy Copper Kettle from Seller for 8 gold.") {
+		t.Fatalf("unexpected buy output: %q", output)
+	}
+
+	Dispatch(world, seller, "mail inbox")
+	output = strings.Join(drainOutput(seller.Output), "\n")
+	if !strings.Contains(output, "Attached: 8 gold") {
+		t.Fatalf("expected proceeds in the inbox, got %q", output)
+	}
+}
@@ -14,12 +14,12 @@ var Moderator = Define(Definition{
 	Group:       GroupAdmin,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly admins may manage moderators.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage moderators.", game.AnsiYellow)))
 		return false
 	}
 	parts := strings.Fields(ctx.Arg)
 	if len(parts) != 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: moderator <player> <on|off>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: moderator <player> <on|off>", game.AnsiYellow)))
 		return false
 	}
 	targetName := parts[0]
@@ -31,23 +31,23 @@ var Moderator = Define(Definition{
 	case "off", "disable", "disabled", "false", "revoke":
 		enable = false
 	default:
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: moderator <player> <on|off>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: moderator <player> <on|off>", game.AnsiYellow)))
 		return false
 	}
 	target, err := ctx.World.SetModerator(targetName, enable)
 	if err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	state := "no longer"
 	if enable {
 		state = "now"
 	}
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n%s is %s a moderator.", game.HighlightName(target.Name), state))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s is %s a moderator.", game.HighlightName(target.Name), state)))
 	notice := "\r\nYou are now a moderator."
 	if !enable {
 		notice = "\r\nYou are no longer a moderator."
 	}
-	target.Output <- game.Ansi(notice)
+	target.Output.Send(game.Ansi(notice))
 	return false
 })
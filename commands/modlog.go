@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var ModLog = Define(Definition{
+	Name:        "modlog",
+	Usage:       "modlog [count]",
+	Description: "show recent moderation actions (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may read the moderation log.", game.AnsiYellow)))
+		return false
+	}
+	limit := 20
+	if arg := strings.TrimSpace(ctx.Arg); arg != "" {
+		count, err := strconv.Atoi(arg)
+		if err != nil || count <= 0 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: modlog [count]", game.AnsiYellow)))
+			return false
+		}
+		limit = count
+	}
+	actions := ctx.World.Moderation().Actions(limit)
+	if len(actions) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo moderation actions have been recorded."))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString(game.Style("\r\nRecent moderation actions:", game.AnsiBold))
+	for _, action := range actions {
+		line := fmt.Sprintf("\r\n  %s %s %s", action.Time.Local().Format("2006-01-02 15:04"), game.HighlightName(action.Actor), action.Action)
+		if action.Target != "" {
+			line += " " + action.Target
+		}
+		if action.Channel != "" {
+			line += " " + strings.ToUpper(string(action.Channel))
+		}
+		if action.Detail != "" {
+			line += " (" + action.Detail + ")"
+		}
+		builder.WriteString(line)
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
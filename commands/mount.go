@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Mount = Define(Definition{
+	Name:        "mount",
+	Aliases:     []string{"ride"},
+	Usage:       "mount <creature>",
+	Description: "climb onto a rideable creature to travel on less stamina",
+}, func(ctx *Context) bool {
+	name := strings.TrimSpace(ctx.Arg)
+	if name == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: mount <creature>", game.AnsiYellow)))
+		return false
+	}
+	mount, err := ctx.World.Mount(ctx.Player, name)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+mountErrorText(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou swing up onto %s.", game.HighlightNPCName(mount.Name))))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s mounts %s.", game.HighlightName(ctx.Player.Name), game.HighlightNPCName(mount.Name))), ctx.Player)
+	return false
+})
+
+var Dismount = Define(Definition{
+	Name:        "dismount",
+	Usage:       "dismount",
+	Description: "climb down from your mount and leave it here",
+}, func(ctx *Context) bool {
+	mount, err := ctx.World.Dismount(ctx.Player)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+mountErrorText(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou climb down from %s.", game.HighlightNPCName(mount.Name))))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s dismounts from %s.", game.HighlightName(ctx.Player.Name), game.HighlightNPCName(mount.Name))), ctx.Player)
+	return false
+})
+
+func mountErrorText(err error) string {
+	switch {
+	case errors.Is(err, game.ErrAlreadyMounted):
+		return "You are already mounted. Dismount first."
+	case errors.Is(err, game.ErrNotMounted):
+		return "You aren't riding anything."
+	case errors.Is(err, game.ErrNoMount):
+		return "You don't see anything here you could ride by that name."
+	default:
+		return err.Error()
+	}
+}
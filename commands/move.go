@@ -25,7 +25,7 @@ var Move = Define(Definition{
 		dir = strings.ToLower(strings.TrimSpace(ctx.Arg))
 	}
 	if dir == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: go <direction>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: go <direction>", game.AnsiYellow)))
 		return false
 	}
 	return move(ctx.World, ctx.Player, dir)
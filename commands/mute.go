@@ -3,42 +3,89 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"LumenClay/internal/game"
 )
 
 var Mute = Define(Definition{
 	Name:        "mute",
-	Usage:       "mute <player> <channel>",
-	Description: "prevent a player from speaking on a channel (admin only)",
+	Usage:       "mute [<player> <channel> [duration] [reason]]",
+	Description: "silence a player on a channel, or list active mutes (staff only)",
 	Group:       GroupAdmin,
 }, func(ctx *Context) bool {
-	if !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly admins may mute players.", game.AnsiYellow))
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may mute players.", game.AnsiYellow)))
 		return false
 	}
 	fields := strings.Fields(ctx.Arg)
-	if len(fields) != 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: mute <player> <channel>", game.AnsiYellow))
+	if len(fields) == 0 {
+		ctx.Player.Output.Send(game.Ansi(formatMutes(ctx.World.Moderation().Mutes())))
 		return false
 	}
-	target, ok := ctx.World.FindPlayer(fields[0])
-	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nThey are not online.", game.AnsiYellow))
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: mute <player> <channel> [duration] [reason]", game.AnsiYellow)))
 		return false
 	}
 	channel, ok := game.ChannelFromString(fields[1])
 	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow)))
 		return false
 	}
-	if ctx.World.ChannelMuted(target, channel) {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nThey are already muted on that channel.", game.AnsiYellow))
+	rest := fields[2:]
+	var duration time.Duration
+	if len(rest) > 0 {
+		if d, ok := game.ParseModerationDuration(rest[0]); ok {
+			duration = d
+			rest = rest[1:]
+		}
+	}
+	reason := strings.Join(rest, " ")
+	target, online := ctx.World.FindPlayer(fields[0])
+	if online && ctx.World.ChannelMuted(target, channel) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThey are already muted on that channel.", game.AnsiYellow)))
+		return false
+	}
+	name := fields[0]
+	if online {
+		name = target.Name
+	}
+	if _, err := ctx.World.MuteChannel(name, channel, duration, reason, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
-	ctx.World.SetChannelMute(target, channel, true)
-	notice := fmt.Sprintf("\r\nYou have been muted on the %s channel by %s.", strings.ToUpper(fields[1]), game.HighlightName(ctx.Player.Name))
-	target.Output <- game.Ansi(notice)
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou mute %s on the %s channel.", game.HighlightName(target.Name), strings.ToUpper(fields[1])))
+	length := ""
+	if duration > 0 {
+		length = " for " + duration.String()
+	}
+	label := strings.ToUpper(string(channel))
+	if online {
+		notice := fmt.Sprintf("\r\nYou have been muted on the %s channel%s by %s.", label, length, game.HighlightName(ctx.Player.Name))
+		if reason != "" {
+			notice += " Reason: " + reason
+		}
+		target.Output.Send(game.Ansi(notice))
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou mute %s on the %s channel%s.", game.HighlightName(name), label, length)))
 	return false
 })
+
+func formatMutes(mutes []game.ChannelMute) string {
+	if len(mutes) == 0 {
+		return "\r\nNo one is muted."
+	}
+	var builder strings.Builder
+	builder.WriteString(game.Style("\r\nActive mutes:", game.AnsiBold))
+	for _, mute := range mutes {
+		until := "until lifted"
+		if !mute.ExpiresAt.IsZero() {
+			until = mute.ExpiresAt.Local().Format("2006-01-02 15:04")
+		}
+		line := fmt.Sprintf("\r\n  %-16s %-8s %s (by %s)", mute.Target, strings.ToUpper(string(mute.Channel)), until, mute.IssuedBy)
+		if mute.Reason != "" {
+			line += " - " + mute.Reason
+		}
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
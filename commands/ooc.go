@@ -13,18 +13,22 @@ var OOC = Define(Definition{
 }, func(ctx *Context) bool {
 	msg := ctx.Arg
 	if msg == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOOC what?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOOC what?", game.AnsiYellow)))
 		return false
 	}
 	if ctx.World.ChannelMuted(ctx.Player, game.ChannelOOC) {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou are muted on OOC.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are muted on OOC.", game.AnsiYellow)))
+		return false
+	}
+	if channelSlowed(ctx, game.ChannelOOC) {
 		return false
 	}
 	tag := game.Style("[OOC]", game.AnsiMagenta, game.AnsiBold)
 	broadcast := game.Ansi(fmt.Sprintf("\r\n%s %s: %s", tag, game.HighlightName(ctx.Player.Name), msg))
 	ctx.World.BroadcastToAllChannel(broadcast, ctx.Player, game.ChannelOOC)
 	self := game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You (OOC):", game.AnsiBold, game.AnsiYellow), msg))
-	ctx.Player.Output <- self
+	ctx.Player.Output.Send(self)
 	ctx.World.RecordPlayerChannelMessage(ctx.Player, game.ChannelOOC, self)
+	ctx.World.RelayChannelMessage(game.ChannelOOC, ctx.Player.Name, msg)
 	return false
 })
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Pager = Define(Definition{
+	Name:        "pager",
+	Aliases:     []string{"more"},
+	Usage:       "pager [auto|off|<lines>]",
+	Description: "pause long output at a --More-- prompt",
+}, func(ctx *Context) bool {
+	arg := strings.ToLower(strings.TrimSpace(ctx.Arg))
+	lines := game.PagerAuto
+	switch arg {
+	case "":
+		ctx.Player.Output.Send(game.Ansi("\r\nPaging: " + describePageLength(ctx.World.PageLength(ctx.Player)) + ". Use pager auto, pager off, or pager <lines>."))
+		return false
+	case "auto", "on":
+	case "off":
+		lines = game.PagerOff
+	default:
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: pager [auto|off|<lines>]", game.AnsiYellow)))
+			return false
+		}
+		lines = n
+	}
+	if err := ctx.World.SetPageLength(ctx.Player, lines); err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\nPaging: " + describePageLength(lines) + "."))
+	return false
+})
+
+func describePageLength(lines int) string {
+	switch lines {
+	case game.PagerOff:
+		return "off"
+	case game.PagerAuto:
+		return "by your window height"
+	}
+	return fmt.Sprintf("%d lines per page", lines)
+}
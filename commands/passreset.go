@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var PassReset = Define(Definition{
+	Name:        "passreset",
+	Usage:       "passreset <player>",
+	Description: "issue a single-use password reset token (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may issue password resets.", game.AnsiYellow)))
+		return false
+	}
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: passreset <player>", game.AnsiYellow)))
+		return false
+	}
+	account, token, err := ctx.World.IssuePasswordReset(target)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to issue reset token: "+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	message := fmt.Sprintf("\r\nReset token for %s (valid for %d minutes):\r\n  %s\r\nThey can type %s at the username prompt to choose a new password.",
+		game.HighlightName(account), int(game.DefaultPasswordResetTTL.Minutes()), game.Style(token, game.AnsiBold), game.Style("reset "+token, game.AnsiCyan))
+	if email := ctx.World.AccountEmail(account); email != "" {
+		message += "\r\nBound email: " + game.Style(email, game.AnsiCyan)
+	}
+	ctx.Player.Output.Send(game.Ansi(message))
+	return false
+})
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Petition = Define(Definition{
+	Name:        "petition",
+	Usage:       "petition [message]",
+	Description: "ask staff for help, or list your petitions",
+}, func(ctx *Context) bool {
+	text := strings.TrimSpace(ctx.Arg)
+	if text == "" {
+		queue := ctx.World.PetitionSystem()
+		if queue == nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nPetitions are unavailable.", game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(listOwnPetitions(queue.Petitions(game.PetitionFilter{Player: ctx.Player.Name, Resolved: true}))))
+		return false
+	}
+	petition, err := ctx.World.FilePetition(ctx.Player, text)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+petitionError(err), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYour petition was filed as #%d. Staff will answer by tell, or by letter if you are away.", petition.ID)))
+	return false
+})
+
+var Tickets = Define(Definition{
+	Name:        "tickets",
+	Usage:       "tickets [all] | tickets show <id> | tickets claim <id> | tickets reply <id> <message> | tickets resolve <id> [note]",
+	Description: "work the queue of player petitions (admins/moderators)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins and moderators may work petitions.", game.AnsiYellow)))
+		return false
+	}
+	queue := ctx.World.PetitionSystem()
+	if queue == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nPetitions are unavailable.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	sub := ""
+	if len(fields) > 0 {
+		sub = strings.ToLower(fields[0])
+	}
+	fail := func(id int, err error) bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nCannot %s petition #%d: %s.", sub, id, err), game.AnsiYellow)))
+		return false
+	}
+	switch sub {
+	case "", "all":
+		ctx.Player.Output.Send(game.Ansi(listPetitions(queue.Petitions(game.PetitionFilter{Resolved: sub == "all"}), sub == "all", time.Now())))
+	case "show":
+		id, ok := petitionID(ctx, fields)
+		if !ok {
+			return false
+		}
+		petition, found := queue.Petition(id)
+		if !found {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nThere is no petition #%d.", id), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(describePetition(petition, time.Now())))
+	case "claim":
+		id, ok := petitionID(ctx, fields)
+		if !ok {
+			return false
+		}
+		petition, err := ctx.World.ClaimPetition(id, ctx.Player.Name)
+		if err != nil {
+			return fail(id, err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou claim petition #%d from %s.", petition.ID, game.HighlightName(petition.Player))))
+	case "reply":
+		id, ok := petitionID(ctx, fields)
+		if !ok {
+			return false
+		}
+		petition, err := ctx.World.ReplyToPetition(id, ctx.Player.Name, strings.Join(fields[2:], " "))
+		if err != nil {
+			return fail(id, err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou answer %s on petition #%d.", game.HighlightName(petition.Player), petition.ID)))
+	case "resolve":
+		id, ok := petitionID(ctx, fields)
+		if !ok {
+			return false
+		}
+		petition, err := ctx.World.ResolvePetition(id, ctx.Player.Name, strings.Join(fields[2:], " "))
+		if err != nil {
+			return fail(id, err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nPetition #%d from %s resolved.", petition.ID, game.HighlightName(petition.Player))))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+	}
+	return false
+})
+
+func petitionID(ctx *Context, fields []string) (int, bool) {
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: tickets "+strings.ToLower(fields[0])+" <id>", game.AnsiYellow)))
+		return 0, false
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+	if err != nil || id <= 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nPetition ids are positive numbers.", game.AnsiYellow)))
+		return 0, false
+	}
+	return id, true
+}
+
+func petitionError(err error) string {
+	msg := err.Error()
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
+
+// petitionAge renders how long a petition has waited, coloured by its SLA
+// grade.
+func petitionAge(petition game.Petition, now time.Time) string {
+	age := petition.Age(now).Round(time.Minute).String()
+	age = strings.TrimSuffix(age, "0s")
+	if age == "" || age == "0m" {
+		age = "<1m"
+	}
+	if !petition.Open() {
+		return age
+	}
+	switch petition.SLA(now) {
+	case game.PetitionOverdue:
+		return game.Style(age+" overdue", game.AnsiRed, game.AnsiBold)
+	case game.PetitionAging:
+		return game.Style(age+" aging", game.AnsiYellow)
+	}
+	return game.Style(age, game.AnsiGreen)
+}
+
+func listPetitions(petitions []game.Petition, all bool, now time.Time) string {
+	if len(petitions) == 0 {
+		if all {
+			return "\r\nNo petitions have been filed."
+		}
+		return "\r\nNo open petitions."
+	}
+	var b strings.Builder
+	if all {
+		b.WriteString("\r\nAll petitions:")
+	} else {
+		b.WriteString("\r\nOpen petitions:")
+	}
+	for _, petition := range petitions {
+		text := petition.Text
+		if runes := []rune(text); len(runes) > 40 {
+			text = string(runes[:37]) + "..."
+		}
+		status := "unclaimed"
+		switch {
+		case !petition.Open():
+			status = game.Style("resolved", game.AnsiGreen)
+		case petition.ClaimedBy != "":
+			status = "claimed by " + petition.ClaimedBy
+		}
+		b.WriteString(fmt.Sprintf("\r\n  #%-4d %-12s %s  %s (%s)", petition.ID, petition.Player, text, petitionAge(petition, now), status))
+	}
+	b.WriteString("\r\nUse 'tickets show <id>' for details.")
+	return b.String()
+}
+
+func listOwnPetitions(petitions []game.Petition) string {
+	if len(petitions) == 0 {
+		return "\r\nYou have not filed any petitions. Ask staff for help with 'petition <message>'."
+	}
+	var b strings.Builder
+	b.WriteString("\r\nYour petitions:")
+	for _, petition := range petitions {
+		status := "waiting for staff"
+		switch {
+		case !petition.Open():
+			status = "resolved by " + petition.ResolvedBy
+		case petition.ClaimedBy != "":
+			status = "being handled by " + petition.ClaimedBy
+		}
+		b.WriteString(fmt.Sprintf("\r\n  #%-4d %s (%s)", petition.ID, petition.Text, status))
+	}
+	return b.String()
+}
+
+func describePetition(petition game.Petition, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\r\nPetition #%d from %s", petition.ID, game.HighlightName(petition.Player)))
+	b.WriteString(fmt.Sprintf("\r\n  Filed: %s in %s, waiting %s", petition.CreatedAt.Format("2006-01-02 15:04 MST"), petition.Room, petitionAge(petition, now)))
+	b.WriteString("\r\n  " + petition.Text)
+	if petition.ClaimedBy != "" {
+		b.WriteString(fmt.Sprintf("\r\n  Claimed by %s on %s", petition.ClaimedBy, petition.ClaimedAt.Format("2006-01-02 15:04 MST")))
+	}
+	for _, reply := range petition.Replies {
+		b.WriteString(fmt.Sprintf("\r\n  %s %s: %s", reply.At.Format("15:04"), reply.Author, reply.Text))
+	}
+	if !petition.Open() {
+		b.WriteString(fmt.Sprintf("\r\n  Resolved by %s on %s", petition.ResolvedBy, petition.ResolvedAt.Format("2006-01-02 15:04 MST")))
+		if petition.Resolution != "" {
+			b.WriteString(": " + petition.Resolution)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestPetitionAndTicketsCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	petitions, err := game.NewPetitionSystem("")
+	if err != nil {
+		t.Fatalf("NewPetitionSystem: %v", err)
+	}
+	world.AttachPetitionSystem(petitions)
+	player := newTestPlayer("Finder", "start")
+	mod := newTestPlayer("Warden", "start")
+	mod.IsModerator = true
+	for _, p := range []*game.Player{player, mod} {
+		world.AddPlayerForTest(p)
+	}
+
+	Dispatch(world, player, "petition My horse vanished.")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Your petition was filed as #1.") {
+		t.Fatalf("expected confirmation, got %q", out)
+	}
+	if out := strings.Join(drainOutput(mod.Output), ""); !strings.Contains(out, "[PETITION] Finder filed petition #1: My horse vanished.") {
+		t.Fatalf("expected the moderator to be alerted, got %q", out)
+	}
+	Dispatch(world, player, "tickets")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Only admins and moderators") {
+		t.Fatalf("expected players to be refused, got %q", out)
+	}
+
+	Dispatch(world, mod, "tickets")
+	if out := strings.Join(drainOutput(mod.Output), ""); !strings.Contains(out, "#1") || !strings.Contains(out, "My horse vanished.") || !strings.Contains(out, "unclaimed") {
+		t.Fatalf("expected the petition in the queue, got %q", out)
+	}
+	Dispatch(world, mod, "tickets claim 1")
+	Dispatch(world, mod, "tickets reply 1 Check the stable.")
+	Dispatch(world, mod, "tickets show 1")
+	out := strings.Join(drainOutput(mod.Output), "")
+	if !strings.Contains(out, "You claim petition #1") || !strings.Contains(out, "Warden: Check the stable.") {
+		t.Fatalf("expected the claim and reply, got %q", out)
+	}
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Warden tells you: [Petition #1] Check the stable.") {
+		t.Fatalf("expected the reply as a tell, got %q", out)
+	}
+	Dispatch(world, mod, "tickets resolve 1 Found it.")
+	Dispatch(world, mod, "tickets resolve 1")
+	out = strings.Join(drainOutput(mod.Output), "")
+	if !strings.Contains(out, "Petition #1 from Finder resolved.") || !strings.Contains(out, "Cannot resolve petition #1: petition already resolved.") {
+		t.Fatalf("expected one resolution, got %q", out)
+	}
+	Dispatch(world, player, "petition")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "resolved by Warden") {
+		t.Fatalf("expected the player's petition list, got %q", out)
+	}
+}
@@ -16,7 +16,7 @@ var Portal = Define(Definition{
 }, func(ctx *Context) bool {
 	provider := ctx.World.Portal()
 	if provider == nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nThe web portal is not configured. Ask an admin to enable TLS (default Certbot fullchain.pem/privkey.pem) or supply --web-addr with a port.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe web portal is not configured. Ask an admin to enable TLS (default Certbot fullchain.pem/privkey.pem) or supply --web-addr with a port.", game.AnsiYellow)))
 		return false
 	}
 
@@ -24,16 +24,16 @@ var Portal = Define(Definition{
 	role, ok := selectPortalRole(ctx.Player, requested)
 	if !ok {
 		if requested != "" {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nYou are not permitted to request that portal.", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are not permitted to request that portal.", game.AnsiYellow)))
 		} else {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nRequest a specific portal with notes, builder, moderator, or admin.", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nRequest a specific portal with notes, builder, moderator, or admin.", game.AnsiYellow)))
 		}
 		return false
 	}
 
 	link, err := provider.GenerateLink(role, ctx.Player.Name)
 	if err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nFailed to generate portal link: "+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to generate portal link: "+err.Error(), game.AnsiYellow)))
 		return false
 	}
 
@@ -45,8 +45,8 @@ var Portal = Define(Definition{
 	label := portalRoleLabel(role)
 	hyperlink := game.Hyperlink(link.URL, "Open portal")
 	message := fmt.Sprintf("\r\n%s portal link (expires in %s): %s\r\n  %s", label, ttlText, hyperlink, link.URL)
-	ctx.Player.Output <- game.Ansi(message)
-	ctx.Player.Output <- game.Ansi(game.Style("\r\nThe link may be used once. Request a new one if it expires.", game.AnsiYellow))
+	ctx.Player.Output.Send(game.Ansi(message))
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThe link may be used once. Request a new one if it expires.", game.AnsiYellow)))
 	return false
 })
 
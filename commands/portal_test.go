@@ -25,7 +25,7 @@ func (f *fakePortal) GenerateLink(role game.PortalRole, player string) (game.Por
 
 func TestPortalCommandRequiresPortal(t *testing.T) {
 	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
-		"start": {ID: "start", Title: "Start", Description: "", Exits: map[string]game.RoomID{}},
+		"start": {ID: "start", Title: "Start", Description: "", Exits: map[string]game.Exit{}},
 	})
 	builder := newTestPlayer("Builder", "start")
 	builder.IsBuilder = true
@@ -42,7 +42,7 @@ func TestPortalCommandRequiresPortal(t *testing.T) {
 
 func TestPortalCommandGeneratesLink(t *testing.T) {
 	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
-		"start": {ID: "start", Title: "Start", Description: "", Exits: map[string]game.RoomID{}},
+		"start": {ID: "start", Title: "Start", Description: "", Exits: map[string]game.Exit{}},
 	})
 	admin := newTestPlayer("Admin", "start")
 	admin.IsAdmin = true
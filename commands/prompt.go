@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Prompt = Define(Definition{
+	Name:        "prompt",
+	Usage:       "prompt [set <format>|combat <format>|reset|combat reset]",
+	Description: "customize your prompt with tokens such as %h health and %r room",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	sub, rest := arg, ""
+	if idx := strings.IndexAny(arg, " \t"); idx >= 0 {
+		sub, rest = arg[:idx], strings.TrimSpace(arg[idx+1:])
+	}
+	switch strings.ToLower(sub) {
+	case "":
+		showPromptFormats(ctx)
+		return false
+	case "reset":
+		if err := ctx.World.SetPromptFormat(ctx.Player, "", false); err != nil {
+			promptError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nYour prompt is back to the default."))
+	case "set":
+		if err := ctx.World.SetPromptFormat(ctx.Player, rest, false); err != nil {
+			promptError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nPrompt updated."))
+	case "combat":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: prompt combat <format|reset>", game.AnsiYellow)))
+			return false
+		}
+		format := rest
+		if strings.EqualFold(rest, "reset") {
+			format = ""
+		}
+		if err := ctx.World.SetPromptFormat(ctx.Player, format, true); err != nil {
+			promptError(ctx, err)
+			return false
+		}
+		if format == "" {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou will use your normal prompt while fighting."))
+		} else {
+			ctx.Player.Output.Send(game.Ansi("\r\nCombat prompt updated."))
+		}
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: prompt [set <format>|combat <format>|reset|combat reset]", game.AnsiYellow)))
+	}
+	return false
+})
+
+func promptError(ctx *Context, err error) {
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
+
+func showPromptFormats(ctx *Context) {
+	format, combat := ctx.World.PromptFormats(ctx.Player)
+	if format == "" {
+		format = "(default)"
+	}
+	if combat == "" {
+		combat = "(same as normal)"
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\n" + game.Style("Prompt:", game.AnsiBold) + " " + format)
+	builder.WriteString("\r\n" + game.Style("Combat prompt:", game.AnsiBold) + " " + combat)
+	builder.WriteString("\r\n" + game.Style("Tokens:", game.AnsiBold))
+	for _, token := range game.PromptTokens {
+		builder.WriteString(fmt.Sprintf("\r\n  %s  %s", token.Token, token.Description))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestPromptSetChangesPrompt(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}}})
+	player := newTestPlayer("Hero", "start")
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "prompt set %h/%Hhp %r >")
+	if got := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(got, "Prompt updated.") {
+		t.Fatalf("unexpected output %q", got)
+	}
+	if got := ansiPattern.ReplaceAllString(game.Prompt(player), ""); got != "\r\n50/50hp Start > " {
+		t.Fatalf("Prompt = %q", got)
+	}
+
+	Dispatch(world, player, "prompt set %z")
+	if got := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(got, "Unknown prompt token") {
+		t.Fatalf("expected an unknown token error, got %q", got)
+	}
+	Dispatch(world, player, "prompt")
+	if got := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(got, "%h/%Hhp %r >") || !strings.Contains(got, "%x") {
+		t.Fatalf("prompt listing missing format or tokens: %q", got)
+	}
+}
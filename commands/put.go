@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"fmt"
+
+	"LumenClay/internal/game"
+)
+
+var Put = Define(Definition{
+	Name:        "put",
+	Usage:       "put <item> in <container>",
+	Description: "place a carried item inside a container",
+}, func(ctx *Context) bool {
+	itemName, containerName, ok := splitPhrase(ctx.Arg, "in", "into")
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: put <item> in <container>", game.AnsiYellow)))
+		return false
+	}
+	item, container, err := ctx.World.PutItemInContainer(ctx.Player, itemName, containerName)
+	if err != nil {
+		containerError(ctx.Player, err)
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou put %s in %s. (%d/%d)", game.HighlightItemName(item.Name), game.HighlightItemName(container.Name), len(container.Contents), container.ContainerCapacity())))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s puts %s in %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name), game.HighlightItemName(container.Name))), ctx.Player)
+	return false
+})
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestPutAndGetFromContainer(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"camp": {
+			ID:    "camp",
+			Title: "Camp",
+			Items: []game.Item{{Name: "Supply Crate", Container: true}},
+		},
+	})
+	player := newTestPlayer("Scout", "camp")
+	world.AddPlayerForTest(player)
+	player.Inventory = []game.Item{{Name: "Rope"}}
+
+	if done := Dispatch(world, player, "put rope in crate"); done {
+		t.Fatalf("dispatch returned true, want false")
+	}
+	output := strings.Join(drainOutput(player.Output), "\n")
+	if !strings.Contains(output, "You put Rope in Supply Crate.") {
+		t.Fatalf("unexpected put output: %q", output)
+	}
+	if len(player.Inventory) != 0 {
+		t.Fatalf("expected rope removed from inventory, got %+v", player.Inventory)
+	}
+
+	if done := Dispatch(world, player, "get rope from crate"); done {
+		t.Fatalf("dispatch returned true, want false")
+	}
+	output = strings.Join(drainOutput(player.Output), "\n")
+	if !strings.Contains(output, "You take Rope from Supply Crate.") {
+		t.Fatalf("unexpected get output: %q", output)
+	}
+	if len(player.Inventory) != 1 || player.Inventory[0].Name != "Rope" {
+		t.Fatalf("expected rope back in inventory, got %+v", player.Inventory)
+	}
+}
+
+func TestGetRefusesItemsOverCapacity(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"quarry": {
+			ID:    "quarry",
+			Title: "Quarry",
+			Items: []game.Item{{Name: "Granite Block", Weight: 120}, {Name: "Chisel", Weight: 80}},
+		},
+	})
+	player := newTestPlayer("Mason", "quarry")
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "get granite")
+	if output := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(output, "That is more than you can carry.") {
+		t.Fatalf("unexpected get output: %q", output)
+	}
+	Dispatch(world, player, "get chisel")
+	Dispatch(world, player, "inventory")
+	if output := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(output, "Load: 80/100 (burdened: walking costs double)") {
+		t.Fatalf("unexpected inventory output: %q", output)
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"LumenClay/internal/game"
 )
@@ -10,7 +11,7 @@ import (
 var Quests = Define(Definition{
 	Name:        "quests",
 	Aliases:     []string{"quest"},
-	Usage:       "quests [available|active|accept <id>|turnin <id>]",
+	Usage:       "quests [available|active|accept <id>|turnin <id>|abandon <id>]",
 	Description: "review active quests or interact with quest givers",
 }, func(ctx *Context) bool {
 	width, _ := ctx.Player.WindowSize()
@@ -27,39 +28,41 @@ var Quests = Define(Definition{
 		return showAvailableQuests(ctx, width)
 	case "accept":
 		if len(parts) < 2 {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: quests accept <id>", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: quests accept <id>", game.AnsiYellow)))
 			return false
 		}
 		questID := strings.ToLower(parts[1])
 		quest, err := ctx.World.AcceptQuest(ctx.Player, questID)
 		if err != nil {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 			return false
 		}
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou accept %s.", game.HighlightQuestName(quest.Name)))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou accept %s.", game.HighlightQuestName(quest.Name))))
 		if desc := strings.TrimSpace(quest.Description); desc != "" {
-			ctx.Player.Output <- game.Ansi("\r\n" + game.WrapText(desc, width))
+			ctx.Player.Output.Send(game.Ansi("\r\n" + game.WrapText(desc, width)))
 		}
+		ctx.World.TriggerQuestAccept(ctx.Player, quest)
+		ctx.World.AdvanceTutorial(ctx.Player, game.TutorialQuest)
 		return false
 	case "turnin", "complete":
 		if len(parts) < 2 {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: quests turnin <id>", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: quests turnin <id>", game.AnsiYellow)))
 			return false
 		}
 		questID := strings.ToLower(parts[1])
 		result, err := ctx.World.CompleteQuest(ctx.Player, questID)
 		if err != nil {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 			return false
 		}
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou complete %s!", game.HighlightQuestName(result.Quest.Name)))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou complete %s!", game.HighlightQuestName(result.Quest.Name))))
 		if strings.TrimSpace(result.CompletionMsg) != "" {
-			ctx.Player.Output <- game.Ansi("\r\n" + game.WrapText(result.CompletionMsg, width))
+			ctx.Player.Output.Send(game.Ansi("\r\n" + game.WrapText(result.CompletionMsg, width)))
 		}
 		if result.RewardXP > 0 {
-			ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou gain %d experience.", result.RewardXP))
+			ctx.Player.Output.Send(game.Ansi("\r\n" + game.FormatExperienceGain(result.RewardXP, result.RestedXP)))
 			if result.LevelsGained > 0 {
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou advance to level %d!", ctx.Player.Level))
+				ctx.Player.Output.Send(game.Ansi("\r\n" + ctx.World.Text(ctx.Player, "combat.level_up", ctx.Player.Level)))
 			}
 		}
 		if len(result.RewardItems) > 0 {
@@ -67,11 +70,28 @@ var Quests = Define(Definition{
 			for i, item := range result.RewardItems {
 				names[i] = game.HighlightItemName(item.Name)
 			}
-			ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nRewards: %s", strings.Join(names, ", ")))
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nRewards: %s", strings.Join(names, ", "))))
 		}
+		for _, line := range game.FormatReputationChanges(result.Reputation) {
+			ctx.Player.Output.Send(game.Ansi("\r\n" + line))
+		}
+		ctx.World.TriggerQuestComplete(ctx.Player, result.Quest)
+		ctx.World.CheckAchievements(ctx.Player)
+		return false
+	case "abandon", "drop":
+		if len(parts) < 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: quests abandon <id>", game.AnsiYellow)))
+			return false
+		}
+		quest, err := ctx.World.AbandonQuest(ctx.Player, parts[1])
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou abandon %s.", game.HighlightQuestName(quest.Name))))
 		return false
 	default:
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUnrecognised quests subcommand.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnrecognised quests subcommand.", game.AnsiYellow)))
 		return false
 	}
 })
@@ -79,7 +99,7 @@ var Quests = Define(Definition{
 func showActiveQuests(ctx *Context, width int) bool {
 	snapshots := ctx.World.SnapshotQuestLog(ctx.Player)
 	if len(snapshots) == 0 {
-		ctx.Player.Output <- game.Ansi("\r\nYou have no active quests.")
+		ctx.Player.Output.Send(game.Ansi("\r\nYou have no active quests."))
 		return false
 	}
 	inventory := ctx.World.PlayerInventory(ctx.Player)
@@ -89,28 +109,48 @@ func showActiveQuests(ctx *Context, width int) bool {
 	}
 	for _, snap := range snapshots {
 		status := "in progress"
-		if snap.Completed {
+		switch {
+		case snap.Completed:
 			status = "completed"
+		case snap.Expired:
+			status = "out of time"
+		case !snap.Deadline.IsZero():
+			status = fmt.Sprintf("in progress, %s left", formatQuestTime(time.Until(snap.Deadline)))
 		}
 		header := fmt.Sprintf("\r\n%s (%s)", game.HighlightQuestName(snap.Quest.Name), status)
-		ctx.Player.Output <- game.Ansi(header)
+		ctx.Player.Output.Send(game.Ansi(header))
 		if desc := strings.TrimSpace(snap.Quest.Description); desc != "" {
-			ctx.Player.Output <- game.Ansi("\r\n  " + game.WrapText(desc, width))
+			ctx.Player.Output.Send(game.Ansi("\r\n  " + game.WrapText(desc, width)))
 		}
 		for _, prog := range snap.KillProgress {
-			ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n  - Defeat %s (%d/%d)",
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Defeat %s (%d/%d)",
 				game.HighlightNPCName(prog.NPC),
 				prog.Current,
 				prog.Required,
-			))
+			)))
+		}
+		for _, visit := range snap.Visits {
+			mark := "not yet"
+			if visit.Visited {
+				mark = "done"
+			}
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Explore %s (%s)", visit.Title, mark)))
+		}
+		for _, delivery := range snap.Deliveries {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Give %s to %s (%d/%d)",
+				game.HighlightItemName(delivery.Item),
+				game.HighlightNPCName(delivery.NPC),
+				delivery.Current,
+				delivery.Required,
+			)))
 		}
 		for _, req := range snap.Quest.RequiredItems {
 			have := itemCounts[strings.ToLower(req.Item)]
-			ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n  - Deliver %s (%d/%d)",
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Deliver %s (%d/%d)",
 				game.HighlightItemName(req.Item),
 				have,
 				req.Count,
-			))
+			)))
 		}
 	}
 	return false
@@ -118,32 +158,71 @@ func showActiveQuests(ctx *Context, width int) bool {
 
 func showAvailableQuests(ctx *Context, width int) bool {
 	quests := ctx.World.AvailableQuests(ctx.Player)
-	if len(quests) == 0 {
-		ctx.Player.Output <- game.Ansi("\r\nNo quests are available here.")
+	locked := ctx.World.LockedQuests(ctx.Player)
+	if len(quests) == 0 && len(locked) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo quests are available here."))
 		return false
 	}
 	for _, quest := range quests {
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n[%s] %s", strings.ToLower(quest.ID), game.HighlightQuestName(quest.Name)))
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n[%s] %s", strings.ToLower(quest.ID), game.HighlightQuestName(quest.Name))))
 		if desc := strings.TrimSpace(quest.Description); desc != "" {
-			ctx.Player.Output <- game.Ansi("\r\n  " + game.WrapText(desc, width))
+			ctx.Player.Output.Send(game.Ansi("\r\n  " + game.WrapText(desc, width)))
 		}
 		if len(quest.RequiredKills) > 0 {
 			for _, req := range quest.RequiredKills {
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n  - Defeat %s (%d)",
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Defeat %s (%d)",
 					game.HighlightNPCName(req.NPC),
 					req.Count,
-				))
+				)))
 			}
 		}
 		if len(quest.RequiredItems) > 0 {
 			for _, req := range quest.RequiredItems {
-				ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\n  - Deliver %s (%d)",
+				ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Deliver %s (%d)",
 					game.HighlightItemName(req.Item),
 					req.Count,
-				))
+				)))
 			}
 		}
-		ctx.Player.Output <- game.Ansi("\r\n")
+		for _, id := range quest.VisitRooms {
+			title := id
+			if room, ok := ctx.World.GetRoom(game.RoomID(id)); ok {
+				title = room.Title
+			}
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Explore %s", title)))
+		}
+		for _, req := range quest.DeliverItems {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Give %s to %s (%d)",
+				game.HighlightItemName(req.Item),
+				game.HighlightNPCName(req.NPC),
+				req.Count,
+			)))
+		}
+		if limit := quest.TimeLimit(); limit > 0 {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Time limit: %s", formatQuestTime(limit))))
+		}
+		switch {
+		case quest.Daily:
+			ctx.Player.Output.Send(game.Ansi("\r\n  - Repeatable once a day"))
+		case quest.Repeatable && quest.Cooldown() > 0:
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  - Repeatable every %s", formatQuestTime(quest.Cooldown()))))
+		case quest.Repeatable:
+			ctx.Player.Output.Send(game.Ansi("\r\n  - Repeatable"))
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\n"))
+	}
+	for _, lock := range locked {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n[%s] %s %s", strings.ToLower(lock.Quest.ID),
+			game.HighlightQuestName(lock.Quest.Name), game.Style("(locked)", game.AnsiYellow))))
+		ctx.Player.Output.Send(game.Ansi("\r\n  To unlock: " + game.WrapText(strings.Join(lock.Requirements(), ", "), width)))
+		ctx.Player.Output.Send(game.Ansi("\r\n"))
 	}
 	return false
 }
+
+func formatQuestTime(d time.Duration) string {
+	if d < time.Minute {
+		return "under a minute"
+	}
+	return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+}
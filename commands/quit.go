@@ -8,6 +8,6 @@ var Quit = Define(Definition{
 	Usage:       "quit",
 	Description: "disconnect",
 }, func(ctx *Context) bool {
-	ctx.Player.Output <- game.Ansi("\r\nGoodbye.\r\n")
+	ctx.Player.Output.Send(game.Ansi("\r\nGoodbye.\r\n"))
 	return true
 })
@@ -1,29 +1,58 @@
 package commands
 
-import "LumenClay/internal/game"
+import (
+	"errors"
+	"strings"
+
+	"LumenClay/internal/game"
+)
 
 var Reboot = Define(Definition{
 	Name:        "reboot",
-	Usage:       "reboot",
-	Description: "reload the world (admin only)",
+	Usage:       "reboot [copyover|world]",
+	Description: "hot reboot the server without dropping players, or reload the world (admin only)",
 	Group:       GroupAdmin,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly admins may reboot the world.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may reboot the world.", game.AnsiYellow)))
 		return false
 	}
 	if ctx.World.CriticalOperationsLocked() {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nWorld reboot is temporarily disabled.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWorld reboot is temporarily disabled.", game.AnsiYellow)))
+		return false
+	}
+	mode := strings.ToLower(strings.TrimSpace(ctx.Arg))
+	switch mode {
+	case "":
+		if ctx.World.CopyoverAvailable() {
+			mode = "copyover"
+		} else {
+			mode = "world"
+		}
+	case "copyover", "world":
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reboot [copyover|world]", game.AnsiYellow)))
+		return false
+	}
+	if mode == "copyover" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nPreparing copyover...", game.AnsiMagenta, game.AnsiBold)))
+		if err := ctx.World.Copyover(); err != nil {
+			if errors.Is(err, game.ErrCopyoverUnavailable) {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nCopyover is not available on this listener. Use 'reboot world' to reload areas instead.", game.AnsiYellow)))
+				return false
+			}
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nCopyover failed: "+err.Error(), game.AnsiYellow)))
+		}
 		return false
 	}
-	ctx.Player.Output <- game.Ansi(game.Style("\r\nRebooting the world...", game.AnsiMagenta, game.AnsiBold))
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\nRebooting the world...", game.AnsiMagenta, game.AnsiBold)))
 	players, err := ctx.World.Reboot()
 	if err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nWorld reload failed: "+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWorld reload failed: "+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	for _, target := range players {
-		target.Output <- game.Ansi(game.Style("\r\nReality shimmers as the world is rebooted.", game.AnsiMagenta))
+		target.Output.Send(game.Ansi(game.Style("\r\nReality shimmers as the world is rebooted.", game.AnsiMagenta)))
 		game.EnterRoom(ctx.World, target, "")
 	}
 	return false
@@ -13,7 +13,7 @@ func TestRebootDisabledWhenCriticalOperationsLocked(t *testing.T) {
 			ID:          "start",
 			Title:       "Start",
 			Description: "Starting point.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	world.ConfigurePrivileges(true, true)
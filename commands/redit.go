@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Redit = Define(Definition{
+	Name:        "redit",
+	Usage:       "redit",
+	Description: "edit the current room in an interactive editor (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use redit.", game.AnsiYellow)))
+		return false
+	}
+	draft, err := ctx.World.OpenRoomDraft(ctx.Player)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi("\r\n" + reditSummary(draft)))
+	ctx.Player.Output.Send(game.Ansi("\r\nType 'help' for editor commands, 'commit' to save, or 'abort' to leave without saving."))
+	return false
+})
+
+const reditHelp = `Room editor commands:
+  show                        list the fields (edited fields are marked *)
+  1 <title>                   set the title (also: title <text>)
+  2 <text>                    replace the description (also: desc <text>)
+  2 + <text>                  add text to the end of the description
+  3 <direction> <room|none>   point an exit at a room, or remove it (also: exit)
+  4 npc <name> [= greeting]   add or update an NPC reset (also: reset)
+  4 item <name> [= desc]      add or update an item reset
+  4 remove <number>           remove a reset by its number
+  5 <keywords> = <text|none>  set or remove an extra detail (also: extra)
+  preview                     show the room as players will see it
+  commit                      save your edits and keep editing
+  done                        save your edits and leave the editor
+  abort                       leave the editor, discarding unsaved edits`
+
+// reditInput handles a line typed while the player has the room editor
+// open.
+func reditInput(world *game.World, player *game.Player, line string) bool {
+	draft := player.RoomDraft()
+	field, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+	rest = strings.TrimSpace(rest)
+	warn := func(msg string) bool {
+		player.Output.Send(game.Ansi(game.Style("\r\n"+msg, game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(field) {
+	case "show", "list", "l", "look":
+		player.Output.Send(game.Ansi("\r\n" + reditSummary(draft)))
+	case "help", "?":
+		player.Output.Send(game.Ansi("\r\n" + strings.ReplaceAll(reditHelp, "\n", "\r\n")))
+	case "preview":
+		player.Output.Send(game.Ansi("\r\n\r\n" + draft.Preview()))
+	case "1", "title":
+		if rest == "" {
+			return warn("Usage: 1 <title>")
+		}
+		draft.Title = rest
+		player.Output.Send(game.Ansi("\r\nTitle set."))
+	case "2", "desc", "description":
+		if rest == "" {
+			return warn("Usage: 2 <text> or 2 + <text>")
+		}
+		if appended, ok := strings.CutPrefix(rest, "+"); ok {
+			draft.Description = strings.TrimSpace(draft.Description + " " + strings.TrimSpace(appended))
+		} else {
+			draft.Description = rest
+		}
+		player.Output.Send(game.Ansi("\r\nDescription set."))
+	case "3", "exit", "exits":
+		parts := strings.Fields(rest)
+		if len(parts) != 2 {
+			return warn("Usage: 3 <direction> <room|none>")
+		}
+		dir := strings.ToLower(parts[0])
+		switch strings.ToLower(parts[1]) {
+		case "none", "remove", "clear":
+			if _, ok := draft.Exits[dir]; !ok {
+				return warn("There is no exit " + dir + ".")
+			}
+			delete(draft.Exits, dir)
+			player.Output.Send(game.Ansi("\r\nExit removed."))
+		default:
+			exit := draft.Exits[dir]
+			exit.To = game.RoomID(parts[1])
+			draft.Exits[dir] = exit
+			player.Output.Send(game.Ansi("\r\nExit set."))
+		}
+	case "4", "reset", "resets":
+		return reditReset(player, draft, rest)
+	case "5", "extra", "extras":
+		keywords, text, ok := strings.Cut(rest, "=")
+		text = strings.TrimSpace(text)
+		if !ok || strings.TrimSpace(keywords) == "" || text == "" {
+			return warn("Usage: 5 <keywords> = <text|none>")
+		}
+		if strings.EqualFold(text, "none") {
+			draft.SetExtra(keywords, "")
+			player.Output.Send(game.Ansi("\r\nExtra removed."))
+		} else {
+			draft.SetExtra(keywords, text)
+			player.Output.Send(game.Ansi("\r\nExtra set."))
+		}
+	case "commit", "save", "done":
+		room, err := world.CommitRoomDraft(player)
+		if err != nil {
+			if errors.Is(err, game.ErrRoomDraftStale) {
+				return warn(err.Error() + ". Type 'abort' and run redit again to pick up the changes.")
+			}
+			return warn(err.Error())
+		}
+		if strings.EqualFold(field, "done") {
+			world.CloseRoomDraft(player)
+			player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSaved %s and left the editor.", room.ID)))
+			return false
+		}
+		player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSaved %s.", room.ID)))
+	case "abort", "quit":
+		changed := len(draft.Changed())
+		world.CloseRoomDraft(player)
+		if changed > 0 {
+			player.Output.Send(game.Ansi(fmt.Sprintf("\r\nLeft the editor, discarding %d unsaved field(s).", changed)))
+		} else {
+			player.Output.Send(game.Ansi("\r\nLeft the editor."))
+		}
+	default:
+		return warn("Unknown editor command. Type 'help', or 'abort' to leave the editor.")
+	}
+	return false
+}
+
+func reditReset(player *game.Player, draft *game.RoomDraft, arg string) bool {
+	warn := func(msg string) bool {
+		player.Output.Send(game.Ansi(game.Style("\r\n"+msg, game.AnsiYellow)))
+		return false
+	}
+	action, rest, _ := strings.Cut(arg, " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(action) {
+	case "npc", "item":
+		name, value, _ := strings.Cut(rest, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "" {
+			return warn("Usage: 4 npc <name> [= greeting] or 4 item <name> [= description]")
+		}
+		kind := game.ResetKindNPC
+		if strings.EqualFold(action, "item") {
+			kind = game.ResetKindItem
+		}
+		for i := range draft.Resets {
+			reset := &draft.Resets[i]
+			if reset.Kind != kind || !strings.EqualFold(reset.Name, name) {
+				continue
+			}
+			if kind == game.ResetKindNPC {
+				reset.AutoGreet = value
+			} else {
+				reset.Description = value
+			}
+			player.Output.Send(game.Ansi(fmt.Sprintf("\r\nReset %d updated.", i+1)))
+			return false
+		}
+		reset := game.RoomReset{Kind: kind, Name: name, Count: 1}
+		if kind == game.ResetKindNPC {
+			reset.AutoGreet = value
+		} else {
+			reset.Description = value
+		}
+		draft.Resets = append(draft.Resets, reset)
+		player.Output.Send(game.Ansi(fmt.Sprintf("\r\nReset %d added.", len(draft.Resets))))
+	case "remove", "delete":
+		n, err := strconv.Atoi(rest)
+		if err != nil || n < 1 || n > len(draft.Resets) {
+			return warn("Usage: 4 remove <number>")
+		}
+		draft.Resets = append(draft.Resets[:n-1:n-1], draft.Resets[n:]...)
+		player.Output.Send(game.Ansi(fmt.Sprintf("\r\nReset %d removed.", n)))
+	default:
+		return warn("Usage: 4 <npc|item|remove> ...")
+	}
+	return false
+}
+
+// reditSummary lists the draft's fields by number, marking edited ones.
+func reditSummary(draft *game.RoomDraft) string {
+	label := func(n int, name, field string) string {
+		mark := " "
+		if draft.FieldChanged(field) {
+			mark = "*"
+		}
+		return fmt.Sprintf("%d)%s %s", n, mark, game.Style(name+":", game.AnsiBold))
+	}
+	status := "no unsaved edits"
+	if changed := draft.Changed(); len(changed) > 0 {
+		status = "unsaved: " + strings.Join(changed, ", ")
+	}
+	lines := []string{
+		game.Style(fmt.Sprintf("Editing room %s (%s)", draft.Room, status), game.AnsiBold, game.AnsiCyan),
+		label(1, "Title", "title") + " " + draft.Title,
+		label(2, "Description", "description"),
+	}
+	description := strings.TrimSpace(draft.Description)
+	if description == "" {
+		description = "(none)"
+	}
+	lines = append(lines, "     "+description)
+	lines = append(lines, label(3, "Exits", "exits"))
+	dirs := draft.ExitDirections()
+	if len(dirs) == 0 {
+		lines = append(lines, "     (none)")
+	}
+	for _, dir := range dirs {
+		exit := draft.Exits[dir]
+		extra := ""
+		switch {
+		case exit.Locked:
+			extra = " (locked door)"
+		case exit.Door:
+			extra = " (door)"
+		}
+		lines = append(lines, fmt.Sprintf("     %-6s -> %s%s", dir, exit.To, extra))
+	}
+	lines = append(lines, label(4, "Resets", "resets"))
+	if len(draft.Resets) == 0 {
+		lines = append(lines, "     (none)")
+	}
+	for i, reset := range draft.Resets {
+		detail := reset.AutoGreet
+		if reset.Kind == game.ResetKindItem {
+			detail = reset.Description
+		}
+		if detail != "" {
+			detail = " = " + detail
+		}
+		lines = append(lines, fmt.Sprintf("     %d. %s %s%s", i+1, reset.Kind, reset.Name, detail))
+	}
+	lines = append(lines, label(5, "Extras", "extras"))
+	keywords := game.ExtraKeywords(draft.Extras)
+	if len(keywords) == 0 {
+		lines = append(lines, "     (none)")
+	}
+	for _, key := range keywords {
+		lines = append(lines, fmt.Sprintf("     %s = %s", key, draft.Extras[key]))
+	}
+	return strings.Join(lines, "\r\n")
+}
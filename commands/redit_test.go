@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestReditEditsAndCommitsRoom(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Description: "Start room.", Exits: map[string]game.Exit{}},
+		"hall":  {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{}},
+	})
+	builder := newTestPlayer("Builder", "start")
+	builder.IsBuilder = true
+	world.AddPlayerForTest(builder)
+
+	Dispatch(world, builder, "redit")
+	if builder.RoomDraft() == nil {
+		t.Fatalf("expected redit to open a draft: %v", drainOutput(builder.Output))
+	}
+	for _, line := range []string{"1 Grand Foyer", "2 + Marble everywhere.", "3 north hall", "4 npc Porter = Welcome!"} {
+		Dispatch(world, builder, line)
+	}
+	room, _ := world.GetRoom("start")
+	if room.Title != "Start" {
+		t.Fatalf("edits leaked into the room before commit: %q", room.Title)
+	}
+	drainOutput(builder.Output)
+	Dispatch(world, builder, "show")
+	summary := strings.Join(drainOutput(builder.Output), "")
+	if !strings.Contains(summary, "unsaved: title, description, exits, resets") || !strings.Contains(summary, "1. npc Porter = Welcome!") {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+
+	Dispatch(world, builder, "3 west nowhere")
+	Dispatch(world, builder, "commit")
+	if msgs := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(msgs, "unknown room nowhere") {
+		t.Fatalf("expected the bad exit to block the commit, got %q", msgs)
+	}
+	Dispatch(world, builder, "3 west none")
+	Dispatch(world, builder, "done")
+	if builder.RoomDraft() != nil {
+		t.Fatalf("expected done to close the editor")
+	}
+	room, _ = world.GetRoom("start")
+	if room.Title != "Grand Foyer" || room.Description != "Start room. Marble everywhere." || room.Exits["north"].To != "hall" {
+		t.Fatalf("unexpected committed room %+v", room)
+	}
+	if len(room.NPCs) != 1 || room.NPCs[0].Name != "Porter" {
+		t.Fatalf("expected the reset to spawn its NPC, got %+v", room.NPCs)
+	}
+
+	Dispatch(world, builder, "redit")
+	Dispatch(world, builder, "4 remove 1")
+	Dispatch(world, builder, "abort")
+	room, _ = world.GetRoom("start")
+	if builder.RoomDraft() != nil || len(room.Resets) != 1 {
+		t.Fatalf("expected abort to discard the edit")
+	}
+	Dispatch(world, builder, "look")
+	if msgs := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(msgs, "Grand Foyer") {
+		t.Fatalf("expected normal commands after leaving the editor, got %q", msgs)
+	}
+}
+
+func TestReditRefusesStaleCommit(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	builder := newTestPlayer("Builder", "start")
+	builder.IsBuilder = true
+	world.AddPlayerForTest(builder)
+
+	Dispatch(world, builder, "redit")
+	Dispatch(world, builder, "1 Mine")
+	if _, err := world.UpdateRoomTitle("start", "Theirs", "Other"); err != nil {
+		t.Fatalf("UpdateRoomTitle: %v", err)
+	}
+	drainOutput(builder.Output)
+	Dispatch(world, builder, "commit")
+	if msgs := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(msgs, "room changed since editing began") {
+		t.Fatalf("expected a stale draft warning, got %q", msgs)
+	}
+	if room, _ := world.GetRoom("start"); room.Title != "Theirs" {
+		t.Fatalf("stale commit overwrote the room: %q", room.Title)
+	}
+}
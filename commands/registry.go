@@ -120,29 +120,73 @@ func Find(name string) (*Command, bool) {
 
 // Dispatch parses the input line, looks up the command, and executes it.
 func Dispatch(world *game.World, player *game.Player, line string) bool {
+	quit := dispatch(world, player, line, nil)
+	player.RememberCommand(line)
+	return quit
+}
+
+// aliasRun tracks the command aliases being expanded for one line of input.
+type aliasRun struct {
+	depth    int
+	commands int
+	active   map[string]bool
+	// stopped reports that a safety limit cut the expansion short.
+	stopped bool
+}
+
+func dispatch(world *game.World, player *game.Player, line string, run *aliasRun) bool {
 	parts := strings.Fields(line)
 	if len(parts) == 0 {
 		return false
 	}
+	if player.RoomDraft() != nil {
+		return reditInput(world, player, line)
+	}
+	if player.BioDraft() != nil {
+		return bioInput(world, player, line)
+	}
 	name := strings.ToLower(parts[0])
 
+	arg := strings.TrimSpace(strings.TrimPrefix(line, parts[0]))
+
+	// An alias that names itself runs the real command rather than
+	// expanding again.
+	if run == nil || !run.active[name] {
+		if expansion, ok := world.CommandAlias(player, name); ok {
+			return runCommandAlias(world, player, name, expansion, arg, run)
+		}
+	}
+	if game.LooksLikeSpeedwalk(line) && world.SpeedwalkEnabled(player) {
+		steps, _ := game.ParseSpeedwalk(line)
+		speedwalk(world, player, steps)
+		return false
+	}
+
 	registryMu.RLock()
 	cmd, ok := registry[name]
+	registryMu.RUnlock()
 	if !ok {
+		if _, isSocial := world.Social(name); isSocial {
+			return runSocial(world, player, name, arg)
+		}
+		registryMu.RLock()
 		cmd = nearestCommandLocked(name)
+		registryMu.RUnlock()
 	}
-	registryMu.RUnlock()
 	if cmd == nil {
-		player.Output <- game.Ansi("\r\nUnknown command. Type 'help'.")
+		player.Output.Send(game.Ansi("\r\n" + world.Text(player, "command.unknown")))
 		return false
 	}
 
 	if world.CommandDisabled(cmd.Name) {
-		player.Output <- game.Ansi(game.Style("\r\nThat command is temporarily disabled.", game.AnsiYellow))
+		player.Output.Send(game.Ansi(game.Style("\r\nThat command is temporarily disabled.", game.AnsiYellow)))
 		return false
 	}
 
-	arg := strings.TrimSpace(strings.TrimPrefix(line, parts[0]))
+	if cmd.Group != GroupGeneral && (player.IsAdmin || player.IsBuilder || player.IsModerator) {
+		world.Audit(game.AuditEntry{Actor: player.Name, Action: game.AuditCommand, Target: cmd.Name, Detail: line})
+	}
+
 	ctx := &Context{
 		World:   world,
 		Player:  player,
@@ -154,6 +198,40 @@ func Dispatch(world *game.World, player *game.Player, line string) bool {
 	return cmd.Handler(ctx)
 }
 
+// runCommandAlias dispatches each command an alias expands to, stopping
+// when the expansion nests too deeply or runs too many commands.
+func runCommandAlias(world *game.World, player *game.Player, name, expansion, arg string, run *aliasRun) bool {
+	if run == nil {
+		run = &aliasRun{active: make(map[string]bool)}
+	}
+	if run.depth >= game.MaxAliasDepth {
+		player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nAlias '%s' nests more than %d aliases deep; stopping.", name, game.MaxAliasDepth), game.AnsiYellow)))
+		run.stopped = true
+		return false
+	}
+	run.depth++
+	run.active[name] = true
+	defer func() {
+		run.depth--
+		delete(run.active, name)
+	}()
+	for _, command := range game.ExpandCommandAlias(expansion, arg) {
+		if run.commands >= game.MaxAliasCommands {
+			player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nAlias '%s' runs more than %d commands; stopping.", name, game.MaxAliasCommands), game.AnsiYellow)))
+			run.stopped = true
+			return false
+		}
+		run.commands++
+		if dispatch(world, player, command, run) {
+			return true
+		}
+		if run.stopped || !player.Alive {
+			return false
+		}
+	}
+	return false
+}
+
 func nearestCommandLocked(name string) *Command {
 	lower := strings.ToLower(name)
 
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Reload = Define(Definition{
+	Name:        "reload",
+	Usage:       "reload <areas|quests|socials|help|achievements|classes|loot|events|factions|scripts|languages>",
+	Description: "hot-reload area files, quests, socials, help, achievements, classes, loot tables, events, factions, scripts, or language catalogs without a reboot (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may reload the world.", game.AnsiYellow)))
+		return false
+	}
+	if ctx.World.CriticalOperationsLocked() {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWorld reload is temporarily disabled.", game.AnsiYellow)))
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "areas":
+		report, err := ctx.World.ReloadAreas()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nArea reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAreas reloaded: %d added, %d removed, %d rebuilt, %d unchanged.",
+			len(report.Added), len(report.Removed), len(report.Rebuilt), report.Unchanged)))
+		for _, line := range []struct {
+			label string
+			rooms []game.RoomID
+		}{{"Added", report.Added}, {"Removed", report.Removed}, {"Rebuilt", report.Rebuilt}} {
+			if len(line.rooms) == 0 {
+				continue
+			}
+			ids := make([]string, len(line.rooms))
+			for i, id := range line.rooms {
+				ids[i] = string(id)
+			}
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n  %s: %s", line.label, strings.Join(ids, ", "))))
+		}
+	case "quests":
+		count, err := ctx.World.ReloadQuests()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nQuest reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nQuests reloaded: %d defined.", count)))
+	case "socials":
+		count, err := ctx.World.ReloadSocials()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nSocial reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSocials reloaded: %d defined.", count)))
+	case "help":
+		count, err := ctx.World.ReloadHelp()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nHelp reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nHelp reloaded: %d topics.", count)))
+	case "achievements":
+		count, err := ctx.World.ReloadAchievements()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nAchievement reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nAchievements reloaded: %d defined.", count)))
+	case "classes":
+		races, classes, err := ctx.World.ReloadClasses()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nClass reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nClasses reloaded: %d races, %d classes.", races, classes)))
+	case "loot":
+		affixes, drops, err := ctx.World.ReloadLoot()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nLoot reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nLoot reloaded: %d affixes, %d drop tables.", affixes, drops)))
+	case "events":
+		count, err := ctx.World.ReloadEvents()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nEvent reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nEvents reloaded: %d defined.", count)))
+	case "factions":
+		count, err := ctx.World.ReloadFactions()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFaction reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nFactions reloaded: %d defined.", count)))
+	case "languages":
+		count, err := ctx.World.ReloadLanguages()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nLanguage reload failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nLanguages reloaded: %d catalogs.", count)))
+	case "scripts":
+		scripts, timers := ctx.World.ReloadScripts()
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nScript cache cleared: %d compiled scripts dropped, %d script timers cancelled.", scripts, timers)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reload <areas|quests|socials|help|achievements|classes|loot|events|factions|scripts|languages>", game.AnsiYellow)))
+	}
+	return false
+})
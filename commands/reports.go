@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Bug = Define(Definition{
+	Name:        "bug",
+	Usage:       "bug <description>",
+	Description: "report something that is broken",
+}, func(ctx *Context) bool {
+	return fileReport(ctx, game.ReportBug)
+})
+
+var Typo = Define(Definition{
+	Name:        "typo",
+	Usage:       "typo <description>",
+	Description: "report a spelling or wording mistake",
+}, func(ctx *Context) bool {
+	return fileReport(ctx, game.ReportTypo)
+})
+
+var Idea = Define(Definition{
+	Name:        "idea",
+	Usage:       "idea <description>",
+	Description: "suggest an improvement to the game",
+}, func(ctx *Context) bool {
+	return fileReport(ctx, game.ReportIdea)
+})
+
+func fileReport(ctx *Context, kind game.ReportKind) bool {
+	text := strings.TrimSpace(ctx.Arg)
+	if text == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nUsage: %s <description>", kind), game.AnsiYellow)))
+		return false
+	}
+	report, err := ctx.World.FileReport(ctx.Player, kind, text)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThanks! Your %s report was filed as #%d. You'll get a letter when staff resolve it.", report.Kind, report.ID)))
+	return false
+}
+
+var Reports = Define(Definition{
+	Name:        "reports",
+	Usage:       "reports [all|bug|typo|idea] | reports show <id> | reports resolve <id> [note]",
+	Description: "review and resolve player bug, typo, and idea reports (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsStaff() {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may review reports.", game.AnsiYellow)))
+		return false
+	}
+	queue := ctx.World.ReportSystem()
+	if queue == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nReports are unavailable.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	sub := ""
+	if len(fields) > 0 {
+		sub = strings.ToLower(fields[0])
+	}
+	switch sub {
+	case "show":
+		id, ok := reportID(ctx, fields)
+		if !ok {
+			return false
+		}
+		report, found := queue.Report(id)
+		if !found {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nThere is no report #%d.", id), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(describeReport(report)))
+	case "resolve":
+		id, ok := reportID(ctx, fields)
+		if !ok {
+			return false
+		}
+		note := strings.TrimSpace(strings.Join(fields[2:], " "))
+		report, err := ctx.World.ResolveReport(id, ctx.Player.Name, note)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nCannot resolve report #%d: %s.", id, err), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nReport #%d from %s resolved.", report.ID, game.HighlightName(report.Reporter))))
+	default:
+		filter := game.ReportFilter{}
+		switch {
+		case sub == "":
+		case sub == "all":
+			filter.Resolved = true
+		default:
+			kind, ok := game.ParseReportKind(sub)
+			if !ok {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+				return false
+			}
+			filter.Kind = kind
+		}
+		ctx.Player.Output.Send(game.Ansi(listReports(queue.Reports(filter), filter.Resolved)))
+	}
+	return false
+})
+
+func reportID(ctx *Context, fields []string) (int, bool) {
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reports "+strings.ToLower(fields[0])+" <id>", game.AnsiYellow)))
+		return 0, false
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+	if err != nil || id <= 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nReport ids are positive numbers.", game.AnsiYellow)))
+		return 0, false
+	}
+	return id, true
+}
+
+func listReports(reports []game.Report, all bool) string {
+	if len(reports) == 0 {
+		if all {
+			return "\r\nNo reports have been filed."
+		}
+		return "\r\nNo open reports."
+	}
+	var b strings.Builder
+	if all {
+		b.WriteString("\r\nAll reports:")
+	} else {
+		b.WriteString("\r\nOpen reports:")
+	}
+	for _, report := range reports {
+		text := report.Text
+		if runes := []rune(text); len(runes) > 50 {
+			text = string(runes[:47]) + "..."
+		}
+		status := ""
+		if !report.Open() {
+			status = " " + game.Style("(resolved)", game.AnsiGreen)
+		}
+		b.WriteString(fmt.Sprintf("\r\n  #%-4d %-5s %-12s %s%s", report.ID, report.Kind, report.Reporter, text, status))
+	}
+	b.WriteString("\r\nUse 'reports show <id>' for details.")
+	return b.String()
+}
+
+func describeReport(report game.Report) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\r\n%s report #%d from %s", strings.ToUpper(string(report.Kind[:1]))+string(report.Kind[1:]), report.ID, game.HighlightName(report.Reporter)))
+	b.WriteString(fmt.Sprintf("\r\n  Filed: %s in %s", report.CreatedAt.Format("2006-01-02 15:04 MST"), report.Room))
+	b.WriteString("\r\n  " + report.Text)
+	if len(report.Context) > 0 {
+		b.WriteString("\r\n  Recent commands:")
+		for _, line := range report.Context {
+			b.WriteString("\r\n    > " + line)
+		}
+	}
+	if !report.Open() {
+		b.WriteString(fmt.Sprintf("\r\n  Resolved by %s on %s", report.ResolvedBy, report.ResolvedAt.Format("2006-01-02 15:04 MST")))
+		if report.Resolution != "" {
+			b.WriteString(": " + report.Resolution)
+		}
+	}
+	return b.String()
+}
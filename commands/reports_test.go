@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestReportCommandsFileAndResolve(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	reports, err := game.NewReportSystem("")
+	if err != nil {
+		t.Fatalf("NewReportSystem: %v", err)
+	}
+	world.AttachReportSystem(reports)
+	player := newTestPlayer("Finder", "start")
+	world.AddPlayerForTest(player)
+	builder := newTestPlayer("Mason", "start")
+	builder.IsBuilder = true
+	world.AddPlayerForTest(builder)
+
+	Dispatch(world, player, "look")
+	Dispatch(world, player, "typo")
+	drainOutput(player.Output)
+	Dispatch(world, player, "typo The sign says 'Wlecome'.")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "filed as #1") {
+		t.Fatalf("expected confirmation, got %q", out)
+	}
+	report, ok := reports.Report(1)
+	if !ok || report.Kind != game.ReportTypo || report.Room != "start" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if strings.Join(report.Context, "|") != "look|typo" {
+		t.Fatalf("expected the preceding commands as context, got %v", report.Context)
+	}
+
+	Dispatch(world, player, "reports")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "Only staff") {
+		t.Fatalf("expected players to be refused, got %q", out)
+	}
+	drainOutput(builder.Output)
+	Dispatch(world, builder, "reports typo")
+	if out := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(out, "#1") || !strings.Contains(out, "Wlecome") {
+		t.Fatalf("expected the typo in the queue, got %q", out)
+	}
+	Dispatch(world, builder, "reports show 1")
+	if out := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(out, "> look") {
+		t.Fatalf("expected the report's recent commands, got %q", out)
+	}
+	Dispatch(world, builder, "reports resolve 1 Spelling fixed.")
+	if out := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(out, "resolved") {
+		t.Fatalf("expected resolution confirmation, got %q", out)
+	}
+	Dispatch(world, builder, "reports")
+	if out := strings.Join(drainOutput(builder.Output), ""); !strings.Contains(out, "No open reports") {
+		t.Fatalf("expected an empty queue, got %q", out)
+	}
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Reputation = Define(Definition{
+	Name:        "reputation",
+	Aliases:     []string{"rep", "factions"},
+	Usage:       "reputation",
+	Description: "show your standing with each faction",
+}, func(ctx *Context) bool {
+	reputations := ctx.World.Reputations(ctx.Player)
+	if len(reputations) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nThere are no factions to earn standing with."))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\nReputation:")
+	for _, rep := range reputations {
+		builder.WriteString(fmt.Sprintf("\r\n  %s: %d (%s)", game.Style(rep.Faction.Name, game.AnsiCyan), rep.Reputation, rep.Standing))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
@@ -2,24 +2,26 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"LumenClay/internal/game"
 )
 
 var Reset = Define(Definition{
 	Name:        "reset",
-	Usage:       "reset <add|remove|list|apply> ...",
+	Usage:       "reset <add|remove|respawn|list|apply> ...",
 	Description: "manage room population resets (builders/admins only)",
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may manage resets.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may manage resets.", game.AnsiYellow)))
 		return false
 	}
 	arg := strings.TrimSpace(ctx.Arg)
 	if arg == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset <add|remove|list|apply> ...", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset <add|remove|respawn|list|apply> ...", game.AnsiYellow)))
 		return false
 	}
 	word := func(input string) (string, string) {
@@ -53,31 +55,71 @@ var Reset = Define(Definition{
 		case "npc":
 			name, greet := nameAndValue(remainder)
 			if strings.TrimSpace(name) == "" {
-				ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset add npc <name> [= auto greet]", game.AnsiYellow))
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset add npc <name> [= auto greet]", game.AnsiYellow)))
 				return false
 			}
-			if _, err := ctx.World.UpsertRoomNPC(ctx.Player.Room, name, greet); err != nil {
-				ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+			if _, err := ctx.World.UpsertRoomNPC(ctx.Player.Room, name, greet, ctx.Player.Name); err != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 				return false
 			}
 			msg := fmt.Sprintf("\r\nNPC %s defined.", game.HighlightNPCName(strings.TrimSpace(name)))
-			ctx.Player.Output <- game.Ansi(msg)
+			ctx.Player.Output.Send(game.Ansi(msg))
 			return false
 		case "item":
 			name, desc := nameAndValue(remainder)
 			if strings.TrimSpace(name) == "" {
-				ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset add item <name> [= description]", game.AnsiYellow))
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset add item <name> [= description]", game.AnsiYellow)))
 				return false
 			}
-			if _, err := ctx.World.UpsertRoomItemReset(ctx.Player.Room, name, desc); err != nil {
-				ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+			if _, err := ctx.World.UpsertRoomItemReset(ctx.Player.Room, name, desc, ctx.Player.Name); err != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 				return false
 			}
 			msg := fmt.Sprintf("\r\nItem spawner %s defined.", game.HighlightItemName(strings.TrimSpace(name)))
-			ctx.Player.Output <- game.Ansi(msg)
+			ctx.Player.Output.Send(game.Ansi(msg))
+			return false
+		case "container":
+			name, desc := nameAndValue(remainder)
+			capacity := 0
+			if fields := strings.Fields(name); len(fields) > 1 {
+				if value, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+					capacity = value
+					name = strings.Join(fields[:len(fields)-1], " ")
+				}
+			}
+			if strings.TrimSpace(name) == "" {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset add container <name> [capacity] [= description]", game.AnsiYellow)))
+				return false
+			}
+			if _, err := ctx.World.UpsertRoomContainerReset(ctx.Player.Room, name, desc, capacity, ctx.Player.Name); err != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+				return false
+			}
+			msg := fmt.Sprintf("\r\nContainer spawner %s defined.", game.HighlightItemName(strings.TrimSpace(name)))
+			ctx.Player.Output.Send(game.Ansi(msg))
+			return false
+		case "book":
+			name, desc := nameAndValue(remainder)
+			pages := 0
+			if fields := strings.Fields(name); len(fields) > 1 {
+				if value, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+					pages = value
+					name = strings.Join(fields[:len(fields)-1], " ")
+				}
+			}
+			if strings.TrimSpace(name) == "" {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset add book <name> [pages] [= description]", game.AnsiYellow)))
+				return false
+			}
+			if _, err := ctx.World.UpsertRoomBookReset(ctx.Player.Room, name, desc, pages, ctx.Player.Name); err != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+				return false
+			}
+			msg := fmt.Sprintf("\r\nBlank writing spawner %s defined.", game.HighlightItemName(strings.TrimSpace(name)))
+			ctx.Player.Output.Send(game.Ansi(msg))
 			return false
 		default:
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset add <npc|item> ...", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset add <npc|item|container|book> ...", game.AnsiYellow)))
 			return false
 		}
 	case "remove":
@@ -85,34 +127,68 @@ var Reset = Define(Definition{
 		kind = strings.ToLower(kind)
 		name := strings.TrimSpace(remainder)
 		if name == "" {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset remove <npc|item> <name>", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset remove <npc|item> <name>", game.AnsiYellow)))
 			return false
 		}
 		switch kind {
 		case "npc":
-			if err := ctx.World.RemoveRoomNPC(ctx.Player.Room, name); err != nil {
-				ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+			if err := ctx.World.RemoveRoomNPC(ctx.Player.Room, name, ctx.Player.Name); err != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 				return false
 			}
 			msg := fmt.Sprintf("\r\nRemoved NPC %s.", game.HighlightNPCName(name))
-			ctx.Player.Output <- game.Ansi(msg)
+			ctx.Player.Output.Send(game.Ansi(msg))
 			return false
 		case "item":
-			if err := ctx.World.RemoveRoomItemReset(ctx.Player.Room, name); err != nil {
-				ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+			if err := ctx.World.RemoveRoomItemReset(ctx.Player.Room, name, ctx.Player.Name); err != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 				return false
 			}
 			msg := fmt.Sprintf("\r\nRemoved item spawner %s.", game.HighlightItemName(name))
-			ctx.Player.Output <- game.Ansi(msg)
+			ctx.Player.Output.Send(game.Ansi(msg))
 			return false
 		default:
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset remove <npc|item> <name>", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset remove <npc|item> <name>", game.AnsiYellow)))
 			return false
 		}
+	case "respawn":
+		usage := "\r\nUsage: reset respawn <npc> <seconds> [= <chance> <rare npc>]"
+		target, rare := nameAndValue(rest)
+		fields := strings.Fields(target)
+		if len(fields) < 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+			return false
+		}
+		seconds, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+			return false
+		}
+		name := strings.Join(fields[:len(fields)-1], " ")
+		chance := 0
+		if rare != "" {
+			first, remainder := word(rare)
+			chance, err = strconv.Atoi(strings.TrimSuffix(first, "%"))
+			if err != nil || remainder == "" {
+				ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+				return false
+			}
+			rare = remainder
+		}
+		if err := ctx.World.SetResetRespawn(ctx.Player.Room, name, time.Duration(seconds)*time.Second, rare, chance, ctx.Player.Name); err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		msg := fmt.Sprintf("\r\n%s now returns %s after defeat.", game.HighlightNPCName(name), formatPortalDuration(time.Duration(seconds)*time.Second))
+		if rare != "" {
+			msg += fmt.Sprintf(" %d%% of the time %s comes instead.", chance, game.HighlightNPCName(rare))
+		}
+		ctx.Player.Output.Send(game.Ansi(msg))
+		return false
 	case "list":
 		resets := ctx.World.RoomResets(ctx.Player.Room)
 		if len(resets) == 0 {
-			ctx.Player.Output <- game.Ansi("\r\nNo resets defined for this room.")
+			ctx.Player.Output.Send(game.Ansi("\r\nNo resets defined for this room."))
 			return false
 		}
 		lines := make([]string, 0, len(resets))
@@ -120,6 +196,15 @@ var Reset = Define(Definition{
 			switch reset.Kind {
 			case game.ResetKindNPC:
 				entry := fmt.Sprintf("NPC %s", game.HighlightNPCName(reset.Name))
+				if reset.Count > 1 {
+					entry = fmt.Sprintf("%s (x%d)", entry, reset.Count)
+				}
+				if reset.Respawn > 0 {
+					entry = fmt.Sprintf("%s [respawns in %s]", entry, formatPortalDuration(time.Duration(reset.Respawn)*time.Second))
+				}
+				if reset.RareSpawn != nil {
+					entry = fmt.Sprintf("%s [%d%% rare: %s]", entry, reset.RareChance, game.HighlightNPCName(reset.RareSpawn.Name))
+				}
 				if strings.TrimSpace(reset.AutoGreet) != "" {
 					entry = fmt.Sprintf("%s — \"%s\"", entry, reset.AutoGreet)
 				}
@@ -129,23 +214,37 @@ var Reset = Define(Definition{
 				if reset.Count > 1 {
 					entry = fmt.Sprintf("%s (x%d)", entry, reset.Count)
 				}
+				if reset.Container {
+					capacity := reset.Capacity
+					if capacity <= 0 {
+						capacity = game.DefaultContainerCapacity
+					}
+					entry = fmt.Sprintf("%s [container, holds %d]", entry, capacity)
+				}
+				if reset.Writing != nil {
+					pages := reset.Writing.MaxPages
+					if pages <= 0 {
+						pages = game.DefaultWritingPages
+					}
+					entry = fmt.Sprintf("%s [writable, %d page(s)]", entry, pages)
+				}
 				if strings.TrimSpace(reset.Description) != "" {
 					entry = fmt.Sprintf("%s — %s", entry, reset.Description)
 				}
 				lines = append(lines, entry)
 			}
 		}
-		ctx.Player.Output <- game.Ansi("\r\n" + strings.Join(lines, "\r\n"))
+		ctx.Player.Output.Send(game.Ansi("\r\n" + strings.Join(lines, "\r\n")))
 		return false
 	case "apply":
-		if err := ctx.World.ApplyRoomResets(ctx.Player.Room); err != nil {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		if err := ctx.World.ApplyRoomResets(ctx.Player.Room, ctx.Player.Name); err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 			return false
 		}
-		ctx.Player.Output <- game.Ansi("\r\nRoom resets applied.")
+		ctx.Player.Output.Send(game.Ansi("\r\nRoom resets applied."))
 		return false
 	default:
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: reset <add|remove|list|apply> ...", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: reset <add|remove|respawn|list|apply> ...", game.AnsiYellow)))
 		return false
 	}
 })
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Review = Define(Definition{
+	Name:        "review",
+	Usage:       "review <player> <channel> [count]",
+	Description: "read a player's recent channel history (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may review channel history.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) < 2 || len(fields) > 3 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: review <player> <channel> [count]", game.AnsiYellow)))
+		return false
+	}
+	channel, ok := game.ChannelFromString(fields[1])
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow)))
+		return false
+	}
+	limit := game.ChannelHistoryDefault
+	if len(fields) == 3 {
+		count, err := strconv.Atoi(fields[2])
+		if err != nil || count <= 0 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nHistory count must be a positive number.", game.AnsiYellow)))
+			return false
+		}
+		limit = min(count, game.ChannelHistoryLimit)
+	}
+	target, entries, err := ctx.World.ReviewChannelHistory(ctx.Player.Name, fields[0], channel, limit)
+	if err != nil && target == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	label := strings.ToUpper(string(channel))
+	if len(entries) == 0 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s has no %s history.", game.HighlightName(target.Name), label)))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\nRecent %s messages seen by %s:\r\n", label, game.HighlightName(target.Name)))
+	for _, entry := range entries {
+		clean := strings.TrimSuffix(strings.TrimPrefix(entry.Message, "\r\n"), "\r\n")
+		builder.WriteString(fmt.Sprintf("  [%s] %s\r\n", entry.Timestamp.Format("15:04:05"), clean))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
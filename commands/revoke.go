@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Revoke = Define(Definition{
+	Name:        "revoke",
+	Usage:       "revoke <player> area:<name>",
+	Description: "remove a builder's area grant (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may revoke areas.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) != 2 || !strings.HasPrefix(strings.ToLower(fields[1]), "area:") {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: revoke <player> area:<name>", game.AnsiYellow)))
+		return false
+	}
+	area, err := ctx.World.RevokeBuilderArea(fields[0], fields[1])
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s no longer builds in area %s.", game.HighlightName(fields[0]), area)))
+	if remaining := ctx.World.BuilderAreas(fields[0]); len(remaining) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nThey hold no area grants and may not build until granted one."))
+	}
+	return false
+})
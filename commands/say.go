@@ -13,18 +13,24 @@ var Say = Define(Definition{
 }, func(ctx *Context) bool {
 	msg := ctx.Arg
 	if msg == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nSay what?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nSay what?", game.AnsiYellow)))
 		return false
 	}
 	if ctx.World.ChannelMuted(ctx.Player, game.ChannelSay) {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou are muted on SAY.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are muted on SAY.", game.AnsiYellow)))
+		return false
+	}
+	if channelSlowed(ctx, game.ChannelSay) {
 		return false
 	}
 	broadcast := game.Ansi(fmt.Sprintf("\r\n%s says: %s", game.HighlightName(ctx.Player.Name), msg))
 	ctx.World.BroadcastToRoomChannel(ctx.Player.Room, broadcast, ctx.Player, game.ChannelSay)
 	self := game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You say:", game.AnsiBold, game.AnsiYellow), msg))
-	ctx.Player.Output <- self
+	ctx.Player.Output.Send(self)
 	ctx.World.RecordPlayerChannelMessage(ctx.Player, game.ChannelSay, self)
 	ctx.World.HandlePlayerSpeech(ctx.Player, msg)
+	if len(ctx.World.RoomNPCs(ctx.Player.Room)) > 0 {
+		ctx.World.AdvanceTutorial(ctx.Player, game.TutorialTalk)
+	}
 	return false
 })
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Scry = Define(Definition{
+	Name:        "scry",
+	Usage:       "scry <player>",
+	Description: "glimpse the room another player stands in",
+}, func(ctx *Context) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: scry <player>", game.AnsiYellow)))
+		return false
+	}
+	vision, err := ctx.World.Scry(ctx.Player, target)
+	if err != nil {
+		scryError(ctx, err)
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYour vision finds %s in %s.%s", game.HighlightName(vision.Name), game.Style(vision.Title, game.AnsiBold), formatVisionExits(vision))))
+	ctx.Player.Output.Send(game.Prompt(ctx.Player))
+	return false
+})
+
+var Locate = Define(Definition{
+	Name:        "locate",
+	Usage:       "locate <item>",
+	Description: "sense where an item lies or who carries it",
+}, func(ctx *Context) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: locate <item>", game.AnsiYellow)))
+		return false
+	}
+	visions, err := ctx.World.Locate(ctx.Player, target)
+	if err != nil {
+		scryError(ctx, err)
+		return false
+	}
+	var b strings.Builder
+	b.WriteString("\r\nYour senses reach out and find:")
+	for _, vision := range visions {
+		place := game.Style(vision.Title, game.AnsiBold)
+		if vision.Holder != "" {
+			b.WriteString(fmt.Sprintf("\r\n  %s, carried by %s in %s.", vision.Name, game.HighlightName(vision.Holder), place))
+		} else {
+			b.WriteString(fmt.Sprintf("\r\n  %s, lying in %s.", vision.Name, place))
+		}
+		b.WriteString(strings.ReplaceAll(formatVisionExits(vision), "\r\n", "\r\n    "))
+	}
+	ctx.Player.Output.Send(game.Ansi(b.String()))
+	ctx.Player.Output.Send(game.Prompt(ctx.Player))
+	return false
+})
+
+func formatVisionExits(vision game.ScryVision) string {
+	if len(vision.Exits) == 0 {
+		return "\r\nNo exits lead from there."
+	}
+	return "\r\nExits: " + strings.Join(vision.Exits, ", ")
+}
+
+func scryError(ctx *Context, err error) {
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
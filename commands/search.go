@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+// searchPageSize is how many search results are shown per page.
+const searchPageSize = 10
+
+var Search = Define(Definition{
+	Name:        "search",
+	Usage:       "search [text [page <n>]]",
+	Description: "search the room for hidden exits; builders/admins can also find rooms, NPCs, items, and quests mentioning a phrase",
+}, func(ctx *Context) bool {
+	if strings.TrimSpace(ctx.Arg) == "" || (!ctx.Player.IsAdmin && !ctx.Player.IsBuilder) {
+		found := ctx.World.SearchForExits(ctx.Player)
+		if len(found) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou search carefully but find nothing hidden."))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou discover hidden exits: %s", game.Style(strings.Join(found, " "), game.AnsiGreen))))
+		ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s searches the area carefully.", game.HighlightName(ctx.Player.Name))), ctx.Player)
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	page := 1
+	if n := len(fields); n >= 3 && strings.EqualFold(fields[n-2], "page") {
+		if value, err := strconv.Atoi(fields[n-1]); err == nil {
+			page = value
+			fields = fields[:n-2]
+		}
+	}
+	query := strings.Join(fields, " ")
+	if query == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: search <text> [page <n>]", game.AnsiYellow)))
+		return false
+	}
+	results := ctx.World.Search(query)
+	if len(results) == 0 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nNothing in the world mentions \"%s\".", query)))
+		return false
+	}
+	pages := (len(results) + searchPageSize - 1) / searchPageSize
+	if page < 1 || page > pages {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nThere are only %d page(s) of results.", pages), game.AnsiYellow)))
+		return false
+	}
+	start := (page - 1) * searchPageSize
+	end := min(start+searchPageSize, len(results))
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\n%d match(es) for \"%s\" (page %d of %d):", len(results), query, page, pages))
+	for _, result := range results[start:end] {
+		location := game.Style("(no room)", game.AnsiDim)
+		if result.Room != "" {
+			location = game.Style(string(result.Room), game.AnsiCyan)
+		}
+		builder.WriteString(fmt.Sprintf("\r\n  [%s] %s %s %s: %s",
+			location, result.Kind, game.Style(result.Name, game.AnsiBold), result.Field, result.Snippet))
+	}
+	if page < pages {
+		builder.WriteString(fmt.Sprintf("\r\nType 'search %s page %d' for more.", query, page+1))
+	}
+	builder.WriteString("\r\nUse 'goto <room>' to visit a result.")
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
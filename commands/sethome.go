@@ -15,17 +15,17 @@ var SetHome = Define(Definition{
 	roomID := ctx.Player.Room
 	room, ok := ctx.World.GetRoom(roomID)
 	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou cannot bind yourself here.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou cannot bind yourself here.", game.AnsiYellow)))
 		return false
 	}
 	if err := ctx.World.SetHome(ctx.Player, roomID); err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	destination := string(roomID)
 	if room != nil && strings.TrimSpace(room.Title) != "" {
 		destination = room.Title
 	}
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou attune yourself to %s.", game.Style(destination, game.AnsiCyan, game.AnsiBold)))
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou attune yourself to %s.", game.Style(destination, game.AnsiCyan, game.AnsiBold))))
 	return false
 })
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Shoot = Define(Definition{
+	Name:        "shoot",
+	Aliases:     []string{"fire"},
+	Usage:       "shoot <target> <direction>",
+	Description: "loose a ranged weapon at a foe in an adjacent room",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: shoot <target> <direction>", game.AnsiYellow)))
+		return false
+	}
+	direction := fields[len(fields)-1]
+	target := strings.Join(fields[:len(fields)-1], " ")
+
+	if err := ctx.World.Shoot(ctx.Player, target, direction); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+shootErrorText(err), game.AnsiYellow)))
+		ctx.Player.Output.Send(game.Prompt(ctx.Player))
+		return false
+	}
+
+	ctx.Player.Output.Send(game.Prompt(ctx.Player))
+	return false
+})
+
+func shootErrorText(err error) string {
+	switch {
+	case errors.Is(err, game.ErrNoRangedWeapon):
+		return "You need a bow, sling, or other ranged weapon to shoot."
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Shop = Define(Definition{
+	Name:        "shop",
+	Aliases:     []string{"wares"},
+	Usage:       "shop",
+	Description: "see what the shopkeeper here sells",
+}, func(ctx *Context) bool {
+	listing, err := ctx.World.ShopWares(ctx.Player)
+	if err != nil {
+		shopError(ctx, err)
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\n%s sells:", game.HighlightNPCName(listing.Keeper)))
+	for _, ware := range listing.Wares {
+		line := fmt.Sprintf("\r\n  %s - %d gold", ware.Item.DisplayName(), ware.Price)
+		if ware.Rare {
+			line += game.Style(" (rare, one in stock)", game.AnsiMagenta)
+		}
+		builder.WriteString(line)
+	}
+	if len(listing.Wares) == 0 {
+		builder.WriteString("\r\n  Nothing until the next restock.")
+	}
+	switch {
+	case listing.Modifier > 0:
+		builder.WriteString(fmt.Sprintf("\r\nYour standing (%s) raises prices by %d%%.", strings.ToLower(string(listing.Standing)), listing.Modifier))
+	case listing.Modifier < 0 && listing.Haggled:
+		builder.WriteString(fmt.Sprintf("\r\nYour haggling and standing lower prices by %d%%.", -listing.Modifier))
+	case listing.Modifier < 0:
+		builder.WriteString(fmt.Sprintf("\r\nYour standing (%s) lowers prices by %d%%.", strings.ToLower(string(listing.Standing)), -listing.Modifier))
+	}
+	if !listing.Restock.IsZero() {
+		builder.WriteString(fmt.Sprintf("\r\nRare stock turns over in %s.", formatRestock(time.Until(listing.Restock))))
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
+
+var Buy = Define(Definition{
+	Name:        "buy",
+	Usage:       "buy <item>",
+	Description: "buy an item from the shopkeeper here",
+}, func(ctx *Context) bool {
+	name := strings.TrimSpace(ctx.Arg)
+	if name == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: buy <item>", game.AnsiYellow)))
+		return false
+	}
+	item, price, err := ctx.World.BuyFromShop(ctx.Player, name)
+	if err != nil {
+		shopError(ctx, err)
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou buy %s for %d gold.", game.HighlightItemName(item.Name), price)))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s buys %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name))), ctx.Player)
+	return false
+})
+
+var Sell = Define(Definition{
+	Name:        "sell",
+	Usage:       "sell <item>",
+	Description: "sell an item to the shopkeeper here",
+}, func(ctx *Context) bool {
+	name := strings.TrimSpace(ctx.Arg)
+	if name == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: sell <item>", game.AnsiYellow)))
+		return false
+	}
+	item, price, err := ctx.World.SellToShop(ctx.Player, name)
+	if err != nil {
+		shopError(ctx, err)
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou sell %s for %d gold.", item.DisplayName(), price)))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s sells %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name))), ctx.Player)
+	return false
+})
+
+var Haggle = Define(Definition{
+	Name:        "haggle",
+	Aliases:     []string{"barter"},
+	Usage:       "haggle",
+	Description: "try to talk the shopkeeper here into better prices",
+}, func(ctx *Context) bool {
+	result, err := ctx.World.Haggle(ctx.Player)
+	if err != nil {
+		shopError(ctx, err)
+		return false
+	}
+	keeper := game.HighlightNPCName(result.Keeper)
+	if result.Success {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s sighs and agrees to knock %d%% off until the next restock.", keeper, game.HaggleDiscount)))
+	} else {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s is unmoved and will not haggle again until the next restock.", keeper)))
+	}
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s haggles with %s.", game.HighlightName(ctx.Player.Name), keeper)), ctx.Player)
+	return false
+})
+
+func formatRestock(d time.Duration) string {
+	minutes := max(1, int(d.Round(time.Minute)/time.Minute))
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+func shopError(ctx *Context, err error) {
+	if errors.Is(err, game.ErrNotForSale) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThat is not for sale here. Type 'shop' to see the wares.", game.AnsiYellow)))
+		return
+	}
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestShopAndBuyCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{},
+			NPCs: []game.NPC{{Name: "Trader", Faction: "guild", Shop: []game.Item{{Name: "Lantern", Price: 40}}}}},
+	})
+	if err := world.AddFactionForTest(game.Faction{ID: "guild", Name: "Guild", Start: 300}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	player := newTestPlayer("Player", "start")
+	player.Gold = 50
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "shop")
+	msgs := ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), " "), "")
+	if !strings.Contains(msgs, "Lantern - 36 gold") || !strings.Contains(msgs, "lowers prices by 10%") {
+		t.Fatalf("unexpected shop listing %q", msgs)
+	}
+	Dispatch(world, player, "buy anvil")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "That is not for sale here") {
+		t.Fatalf("expected not-for-sale warning, got %q", msgs)
+	}
+	Dispatch(world, player, "buy lantern")
+	msgs = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), " "), "")
+	if !strings.Contains(msgs, "You buy Lantern for 36 gold") || player.Gold != 14 {
+		t.Fatalf("unexpected purchase %q, gold %d", msgs, player.Gold)
+	}
+	Dispatch(world, player, "buy lantern")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "You cannot afford that") {
+		t.Fatalf("expected afford warning, got %q", msgs)
+	}
+
+	Dispatch(world, player, "sell lantern")
+	msgs = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), " "), "")
+	if !strings.Contains(msgs, "You sell Lantern for 17 gold") || player.Gold != 31 {
+		t.Fatalf("unexpected sale %q, gold %d", msgs, player.Gold)
+	}
+	Dispatch(world, player, "haggle")
+	Dispatch(world, player, "haggle")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "will not haggle with you again") {
+		t.Fatalf("expected one haggle per restock, got %q", msgs)
+	}
+
+	Dispatch(world, player, "rep")
+	msgs = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), " "), "")
+	if !strings.Contains(msgs, "Guild: 300 (Friendly)") {
+		t.Fatalf("unexpected reputation listing %q", msgs)
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var SlowMode = Define(Definition{
+	Name:        "slowmode",
+	Usage:       "slowmode <channel> [<interval>|off]",
+	Description: "limit how often players may speak on a channel (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may set slow mode.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) < 1 || len(fields) > 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: slowmode <channel> [<interval>|off]", game.AnsiYellow)))
+		return false
+	}
+	channel, ok := game.ChannelFromString(fields[0])
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow)))
+		return false
+	}
+	label := strings.ToUpper(string(channel))
+	if len(fields) == 1 {
+		if interval := ctx.World.ChannelSlowMode(channel); interval > 0 {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s allows one message every %s.", label, interval)))
+		} else {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s is not in slow mode.", label)))
+		}
+		return false
+	}
+	var interval time.Duration
+	if !strings.EqualFold(fields[1], "off") {
+		d, ok := game.ParseModerationDuration(fields[1])
+		if !ok {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nInterval must look like 10s or 1m, or be off.", game.AnsiYellow)))
+			return false
+		}
+		interval = d
+	}
+	if err := ctx.World.SetChannelSlowMode(channel, interval, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	if interval > 0 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s now allows one message every %s per player.", label, interval)))
+	} else {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s slow mode is off.", label)))
+	}
+	return false
+})
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Snapshot = Define(Definition{
+	Name:        "snapshot",
+	Usage:       "snapshot [list|save|restore <id>]",
+	Description: "save, list, or roll back to world snapshots (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may manage world snapshots.", game.AnsiYellow)))
+		return false
+	}
+	fields := strings.Fields(ctx.Arg)
+	action := "list"
+	if len(fields) > 0 {
+		action = strings.ToLower(fields[0])
+	}
+	switch action {
+	case "list":
+		snapshots, err := ctx.World.Snapshots()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to list snapshots: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		if len(snapshots) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nNo world snapshots have been saved."))
+			return false
+		}
+		var builder strings.Builder
+		builder.WriteString("\r\nWorld snapshots (newest first):")
+		for _, info := range snapshots {
+			builder.WriteString(fmt.Sprintf("\r\n  %s  %s  %d rooms, %d players",
+				game.Style(info.ID, game.AnsiCyan), info.Created.Local().Format(time.RFC1123), info.Rooms, info.Players))
+		}
+		ctx.Player.Output.Send(game.Ansi(builder.String()))
+	case "save":
+		info, err := ctx.World.SaveSnapshot()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to save snapshot: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSaved world snapshot %s.", game.Style(info.ID, game.AnsiCyan))))
+	case "restore":
+		if len(fields) < 2 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: snapshot restore <id>", game.AnsiYellow)))
+			return false
+		}
+		if ctx.World.CriticalOperationsLocked() {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nSnapshot restore is temporarily disabled.", game.AnsiYellow)))
+			return false
+		}
+		backup, err := ctx.World.SaveSnapshot()
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to save the current world before restoring: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		players, err := ctx.World.RestoreSnapshot(fields[1])
+		if err != nil {
+			if errors.Is(err, game.ErrSnapshotNotFound) {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo snapshot named "+fields[1]+".", game.AnsiYellow)))
+				return false
+			}
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nSnapshot restore failed: "+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nRestored snapshot %s. The previous state was saved as %s.",
+			game.Style(fields[1], game.AnsiCyan), game.Style(backup.ID, game.AnsiCyan))))
+		for _, target := range players {
+			target.Output.Send(game.Ansi(game.Style("\r\nThe world ripples as time folds back on itself.", game.AnsiMagenta)))
+			game.EnterRoom(ctx.World, target, "")
+		}
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: snapshot [list|save|restore <id>]", game.AnsiYellow)))
+	}
+	return false
+})
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Socials = Define(Definition{
+	Name:        "socials",
+	Usage:       "socials",
+	Description: "list the socials you can perform, such as smile or bow",
+}, func(ctx *Context) bool {
+	socials := ctx.World.Socials()
+	if len(socials) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo socials are defined."))
+		return false
+	}
+	names := make([]string, len(socials))
+	for i, social := range socials {
+		names[i] = social.Name
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s\r\n%s\r\nType a social's name, optionally followed by someone in the room.",
+		game.Style("Socials:", game.AnsiBold), strings.Join(names, ", "))))
+	return false
+})
+
+var Social = Define(Definition{
+	Name:        "social",
+	Usage:       "social add <name> = <message> [| <targeted message>] | social remove <name> | social show <name>",
+	Description: "create, edit, or remove socials (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	warn := func(msg string) bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+msg, game.AnsiYellow)))
+		return false
+	}
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		return warn("Only builders or admins may edit socials.")
+	}
+	action, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(action) {
+	case "add", "set":
+		name, messages, ok := strings.Cut(rest, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return warn("Usage: social add <name> = <message> [| <targeted message>]")
+		}
+		if _, exists := Find(name); exists {
+			return warn(fmt.Sprintf("%s is already a command.", name))
+		}
+		alone, target, _ := strings.Cut(messages, "|")
+		social := game.Social{Name: name, Alone: alone, Target: target}
+		if err := ctx.World.SetSocial(social); err != nil {
+			return warn(err.Error())
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSocial %s saved.", name)))
+	case "remove", "delete":
+		if rest == "" {
+			return warn("Usage: social remove <name>")
+		}
+		if err := ctx.World.RemoveSocial(rest); err != nil {
+			return warn(err.Error())
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSocial %s removed.", strings.ToLower(rest))))
+	case "show":
+		social, ok := ctx.World.Social(rest)
+		if !ok {
+			return warn("There is no social by that name.")
+		}
+		target := social.Target
+		if target == "" {
+			target = "(none)"
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s\r\n  Alone:  %s\r\n  Target: %s",
+			game.Style("Social "+social.Name, game.AnsiBold), social.Alone, target)))
+	default:
+		return warn("Usage: social add <name> = <message> [| <targeted message>] | social remove <name> | social show <name>\r\n" +
+			"Messages use $n for you, $N for the target, $s/$S for possessives, and {smile|smiles} for verbs.")
+	}
+	return false
+})
+
+// runSocial performs a social typed as a command.
+func runSocial(world *game.World, player *game.Player, name, arg string) bool {
+	if err := world.PerformSocial(player, name, arg); err != nil {
+		msg := err.Error()
+		player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Speedwalk = Define(Definition{
+	Name:        "speedwalk",
+	Aliases:     []string{"run"},
+	Usage:       "speedwalk <path> | speedwalk on|off",
+	Description: "walk a path such as 3n2e u, or toggle typing paths directly",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	switch strings.ToLower(arg) {
+	case "":
+		state := "on"
+		if !ctx.World.SpeedwalkEnabled(ctx.Player) {
+			state = "off"
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nSpeedwalk is %s. Type a path such as 3n2e u to walk it, or use speedwalk <path>.", game.Style(state, game.AnsiBold))))
+		return false
+	case "on", "off":
+		enabled := strings.EqualFold(arg, "on")
+		ctx.World.SetSpeedwalk(ctx.Player, enabled)
+		if enabled {
+			ctx.Player.Output.Send(game.Ansi("\r\nTyped paths such as 3n2e u will now be walked."))
+		} else {
+			ctx.Player.Output.Send(game.Ansi("\r\nTyped paths will no longer be walked. Use speedwalk <path> instead."))
+		}
+		return false
+	}
+	steps, err := game.ParseSpeedwalk(arg)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	speedwalk(ctx.World, ctx.Player, steps)
+	return false
+})
+
+// speedwalk takes each step in turn, stopping at the first one that fails or
+// when a fight breaks out along the way.
+func speedwalk(world *game.World, player *game.Player, steps []string) {
+	for i, dir := range steps {
+		if world.InCombat(player) {
+			player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nYou are in a fight! Speedwalk stopped with %d of %d steps taken.", i, len(steps)), game.AnsiYellow)))
+			return
+		}
+		if !step(world, player, dir) {
+			if i > 0 {
+				player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nSpeedwalk stopped with %d of %d steps taken.", i, len(steps)), game.AnsiYellow)))
+			}
+			return
+		}
+		if !player.Alive {
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestSpeedwalkWalksTypedPath(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"a": {ID: "a", Title: "Gate", Exits: map[string]game.Exit{"n": {To: "b"}}},
+		"b": {ID: "b", Title: "Lane", Exits: map[string]game.Exit{"n": {To: "c"}, "s": {To: "a"}}},
+		"c": {ID: "c", Title: "Square", Exits: map[string]game.Exit{"e": {To: "d"}, "s": {To: "b"}}},
+		"d": {ID: "d", Title: "Tower", Exits: map[string]game.Exit{"w": {To: "c"}}},
+	})
+	walker := newTestPlayer("Walker", "a")
+	world.AddPlayerForTest(walker)
+
+	Dispatch(world, walker, "2n e")
+	drainOutput(walker.Output)
+	if walker.Room != "d" {
+		t.Fatalf("walker.Room = %q, want d", walker.Room)
+	}
+
+	Dispatch(world, walker, "w 3s")
+	output := strings.Join(drainOutput(walker.Output), "")
+	if walker.Room != "a" {
+		t.Fatalf("walker.Room = %q, want a", walker.Room)
+	}
+	if !strings.Contains(output, "Speedwalk stopped with 3 of 4 steps taken.") {
+		t.Fatalf("missing abort notice: %q", output)
+	}
+}
+
+func TestSpeedwalkToggle(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"a": {ID: "a", Title: "Gate", Exits: map[string]game.Exit{"n": {To: "b"}}},
+		"b": {ID: "b", Title: "Lane", Exits: map[string]game.Exit{"n": {To: "c"}, "s": {To: "a"}}},
+		"c": {ID: "c", Title: "Square", Exits: map[string]game.Exit{"e": {To: "d"}, "s": {To: "b"}}},
+		"d": {ID: "d", Title: "Tower", Exits: map[string]game.Exit{"w": {To: "c"}}},
+	})
+	walker := newTestPlayer("Walker", "a")
+	world.AddPlayerForTest(walker)
+
+	Dispatch(world, walker, "speedwalk off")
+	drainOutput(walker.Output)
+	Dispatch(world, walker, "2n")
+	drainOutput(walker.Output)
+	if walker.Room != "a" {
+		t.Fatalf("typed paths should be ignored when speedwalk is off, walker is in %q", walker.Room)
+	}
+	Dispatch(world, walker, "run 2n")
+	drainOutput(walker.Output)
+	if walker.Room != "c" {
+		t.Fatalf("run should walk even when speedwalk is off, walker is in %q", walker.Room)
+	}
+}
@@ -10,12 +10,13 @@ import (
 
 var Stats = Define(Definition{
 	Name:        "stats",
+	Aliases:     []string{"score"},
 	Usage:       "stats",
 	Description: "review your account details",
 }, func(ctx *Context) bool {
 	stats, ok := ctx.World.AccountStats(ctx.Player.Account)
 	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nAccount details are unavailable.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nAccount details are unavailable.", game.AnsiYellow)))
 		return false
 	}
 
@@ -27,18 +28,47 @@ var Stats = Define(Definition{
 	builder.WriteString(fmt.Sprintf("  Roles: %s\r\n", formatRoles(ctx.Player)))
 	builder.WriteString(fmt.Sprintf("  Home: %s\r\n", describeRoom(ctx.World, ctx.Player.Home)))
 	builder.WriteString(fmt.Sprintf("  Location: %s\r\n", describeRoom(ctx.World, ctx.Player.Room)))
+	if race, class := ctx.World.ArchetypeNames(ctx.Player); race != "" || class != "" {
+		builder.WriteString(fmt.Sprintf("  Race: %s  Class: %s\r\n", orNone(race), orNone(class)))
+	}
+	if skills, restricted := ctx.World.Skills(ctx.Player); restricted {
+		builder.WriteString(fmt.Sprintf("  Skills: %s\r\n", orNone(strings.Join(skills, ", "))))
+	}
 	builder.WriteString(fmt.Sprintf("  Level: %s\r\n", game.Style(fmt.Sprintf("%d", ctx.Player.Level), game.AnsiGreen, game.AnsiBold)))
 	builder.WriteString(fmt.Sprintf("  Experience: %s\r\n", game.Style(fmt.Sprintf("%d", ctx.Player.Experience), game.AnsiBlue)))
 	builder.WriteString(fmt.Sprintf("  Health: %s\r\n", game.Style(fmt.Sprintf("%d/%d", ctx.Player.Health, ctx.Player.MaxHealth), game.AnsiGreen)))
 	builder.WriteString(fmt.Sprintf("  Mana: %s\r\n", game.Style(fmt.Sprintf("%d/%d", ctx.Player.Mana, ctx.Player.MaxMana), game.AnsiMagenta)))
+	builder.WriteString(fmt.Sprintf("  Stamina: %s\r\n", game.Style(fmt.Sprintf("%d/%d", ctx.Player.Moves, ctx.Player.MaxMoves), game.AnsiYellow)))
+	builder.WriteString(fmt.Sprintf("  Attributes: %s\r\n", formatAttributes(ctx.Player)))
+	builder.WriteString(fmt.Sprintf("  Dodge: %d%%  Carry capacity: %d  Training points: %d\r\n", ctx.Player.DodgeChance(), ctx.Player.CarryCapacity(), ctx.Player.TrainPoints))
+	if effects := ctx.Player.ActiveEffects(time.Now()); len(effects) > 0 {
+		names := make([]string, len(effects))
+		for i, effect := range effects {
+			names[i] = fmt.Sprintf("%s (%s)", game.Style(effect.Name, game.AnsiCyan), formatPortalDuration(effect.Remaining(time.Now()).Round(time.Second)))
+		}
+		builder.WriteString(fmt.Sprintf("  Effects: %s\r\n", strings.Join(names, ", ")))
+	}
+	if mount := ctx.World.MountName(ctx.Player); mount != "" {
+		builder.WriteString(fmt.Sprintf("  Riding: %s\r\n", game.HighlightNPCName(mount)))
+	}
 
 	now := time.Now().UTC()
 	builder.WriteString(fmt.Sprintf("  Created: %s\r\n", formatTimestamp(stats.CreatedAt, now)))
 	builder.WriteString(fmt.Sprintf("  Last login: %s\r\n", formatTimestamp(stats.LastLogin, now)))
 	builder.WriteString(fmt.Sprintf("  Total logins: %s\r\n", game.Style(fmt.Sprintf("%d", stats.TotalLogins), game.AnsiGreen, game.AnsiBold)))
+	playtime := stats.Playtime
+	if !ctx.Player.JoinedAt.IsZero() {
+		playtime += now.Sub(ctx.Player.JoinedAt)
+	}
+	builder.WriteString(fmt.Sprintf("  Playtime: %s\r\n", formatPortalDuration(playtime)))
+	if rested := ctx.World.RestedXP(ctx.Player); rested > 0 {
+		builder.WriteString(fmt.Sprintf("  Rested: %s\r\n", game.Style(fmt.Sprintf("%d bonus experience (x%d on kills and quests)", rested, game.RestedMultiplier), game.AnsiCyan)))
+	} else {
+		builder.WriteString(fmt.Sprintf("  Rested: none (earn %d per hour logged out, up to %d)\r\n", game.RestedXPPerHour, game.RestedXPCap))
+	}
 	builder.WriteString(fmt.Sprintf("  Channels: %s\r\n", formatChannelStatuses(ctx.World, ctx.Player)))
 
-	ctx.Player.Output <- game.Ansi(builder.String())
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
 	return false
 })
 
@@ -161,3 +191,10 @@ func formatRoles(player *game.Player) string {
 	}
 	return strings.Join(styled, game.Style(", ", game.AnsiDim))
 }
+
+func orNone(value string) string {
+	if value == "" {
+		return game.Style("none", game.AnsiDim)
+	}
+	return value
+}
@@ -30,7 +30,7 @@ func TestStatsCommandDisplaysAccountInformation(t *testing.T) {
 			ID:          "start",
 			Title:       "Radiant Nexus",
 			Description: "Light dances around you.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	world.AttachAccountManager(manager)
@@ -74,3 +74,26 @@ func TestStatsCommandDisplaysAccountInformation(t *testing.T) {
 		t.Fatalf("expected disabled channel indicator in output: %q", output)
 	}
 }
+
+func TestScoreShowsRestedBonus(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Radiant Nexus", Exits: map[string]game.Exit{}},
+	})
+	manager, err := game.NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := manager.Register("Napper", "password123"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(manager)
+	player := newTestPlayer("Napper", "start")
+	player.RestedXP = 40
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "score")
+	output := strings.Join(drainOutput(player.Output), "\n")
+	if !strings.Contains(output, "Rested: 40 bonus experience") || !strings.Contains(output, "Playtime:") {
+		t.Fatalf("score output = %q", output)
+	}
+}
@@ -14,12 +14,12 @@ var Teleport = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may use teleport.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use teleport.", game.AnsiYellow)))
 		return false
 	}
 	target := strings.TrimSpace(ctx.Arg)
 	if target == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: teleport <room|player>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: teleport <room|player>", game.AnsiYellow)))
 		return false
 	}
 
@@ -31,7 +31,7 @@ var Teleport = Define(Definition{
 	} else {
 		destination = game.RoomID(target)
 		if _, ok := ctx.World.GetRoom(destination); !ok {
-			ctx.Player.Output <- game.Ansi(game.Style("\r\nNo such room or player.", game.AnsiYellow))
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo such room or player.", game.AnsiYellow)))
 			return false
 		}
 		arrival = fmt.Sprintf("\r\n%s appears in a shimmer of light.", game.HighlightName(ctx.Player.Name))
@@ -43,7 +43,7 @@ var Teleport = Define(Definition{
 		return false
 	}
 	if err := ctx.World.MoveToRoom(ctx.Player, destination); err != nil {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
 	vanish := fmt.Sprintf("\r\n%s vanishes in a shimmer of light.", game.HighlightName(ctx.Player.Name))
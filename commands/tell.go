@@ -15,32 +15,68 @@ var Tell = Define(Definition{
 }, func(ctx *Context) bool {
 	fields := strings.Fields(ctx.Arg)
 	if len(fields) < 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: tell <player> <message>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: tell <player> <message>", game.AnsiYellow)))
 		return false
 	}
 	targetToken := fields[0]
 	message := strings.TrimSpace(strings.TrimPrefix(ctx.Arg, targetToken))
 	if message == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nWhat do you want to say?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWhat do you want to say?", game.AnsiYellow)))
 		return false
 	}
+	sendTell(ctx, targetToken, message)
+	return false
+})
 
-	if target, ok := ctx.World.FindPlayer(targetToken); ok {
-		received := game.Ansi(fmt.Sprintf("\r\n%s tells you: %s", game.HighlightName(ctx.Player.Name), message))
-		target.Output <- received
-		ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou tell %s: %s", game.HighlightName(target.Name), message))
+var Reply = Define(Definition{
+	Name:        "reply",
+	Usage:       "reply <message>",
+	Description: "answer the last player who sent you a tell",
+}, func(ctx *Context) bool {
+	target := ctx.World.ReplyTarget(ctx.Player)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo one has sent you a tell yet.", game.AnsiYellow)))
 		return false
 	}
+	if strings.TrimSpace(ctx.Arg) == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nReply to %s with what?", game.HighlightName(target)), game.AnsiYellow)))
+		return false
+	}
+	sendTell(ctx, target, ctx.Arg)
+	return false
+})
 
-	tell, canonical, err := ctx.World.QueueOfflineTell(ctx.Player, targetToken, message)
-	if err != nil {
-		if errors.Is(err, game.ErrOfflineTellLimit) {
-			ctx.Player.Output <- game.Ansi(game.Style(fmt.Sprintf("\r\nYou already have %d offline tells queued for %s.", game.OfflineTellLimitPerSender, game.HighlightName(canonical)), game.AnsiYellow))
-			return false
-		}
-		ctx.Player.Output <- game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow))
+var Retell = Define(Definition{
+	Name:        "retell",
+	Usage:       "retell <message>",
+	Description: "send another tell to the last player you told",
+}, func(ctx *Context) bool {
+	target := ctx.World.RetellTarget(ctx.Player)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou haven't sent anyone a tell yet.", game.AnsiYellow)))
+		return false
+	}
+	if strings.TrimSpace(ctx.Arg) == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nTell %s what?", game.HighlightName(target)), game.AnsiYellow)))
 		return false
 	}
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou queue an offline tell for %s: %s", game.HighlightName(tell.Recipient), tell.Body))
+	sendTell(ctx, target, ctx.Arg)
 	return false
 })
+
+// sendTell delivers a tell and reports the outcome to the sender.
+func sendTell(ctx *Context, target, message string) {
+	record, err := ctx.World.SendTell(ctx.Player, target, message)
+	switch {
+	case errors.Is(err, game.ErrTellIgnored):
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\n%s is not accepting your tells.", game.HighlightName(record.To)), game.AnsiYellow)))
+	case errors.Is(err, game.ErrOfflineTellLimit):
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nYou already have %d offline tells queued for %s.", game.OfflineTellLimitPerSender, game.HighlightName(record.To)), game.AnsiYellow)))
+	case err != nil:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+	case record.Queued:
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou queue an offline tell for %s: %s", game.HighlightName(record.To), record.Body)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou tell %s: %s", game.HighlightName(record.To), record.Body)))
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,7 +16,7 @@ func TestTellCommandSendsToOnlinePlayer(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	speaker := newTestPlayer("Speaker", "hall")
@@ -43,7 +44,7 @@ func TestTellCommandQueuesOfflineMessage(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	dir := t.TempDir()
@@ -83,7 +84,7 @@ func TestTellCommandRespectsSenderLimit(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "An empty hall.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 	dir := t.TempDir()
@@ -117,3 +118,56 @@ func TestTellCommandRespectsSenderLimit(t *testing.T) {
 		t.Fatalf("unexpected speaker output: %v", speakerMsgs)
 	}
 }
+
+func TestTellCommandRespectsIgnore(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Description: "An empty hall.", Exits: map[string]game.Exit{}},
+	})
+	speaker := newTestPlayer("Speaker", "hall")
+	listener := newTestPlayer("Listener", "hall")
+	world.AddPlayerForTest(speaker)
+	world.AddPlayerForTest(listener)
+
+	Dispatch(world, listener, "ignore speaker")
+	drainOutput(listener.Output)
+	Dispatch(world, speaker, "tell listener Hello there")
+
+	speakerMsgs := drainOutput(speaker.Output)
+	if len(speakerMsgs) == 0 || speakerMsgs[len(speakerMsgs)-1] != "Listener is not accepting your tells." {
+		t.Fatalf("unexpected speaker output: %v", speakerMsgs)
+	}
+	if msgs := drainOutput(listener.Output); len(msgs) != 0 {
+		t.Fatalf("ignored tell was delivered: %v", msgs)
+	}
+}
+
+func TestReplyAndRetellFollowTheConversation(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Description: "An empty hall.", Exits: map[string]game.Exit{}},
+	})
+	alice := newTestPlayer("Alice", "hall")
+	bob := newTestPlayer("Bob", "hall")
+	world.AddPlayerForTest(alice)
+	world.AddPlayerForTest(bob)
+
+	Dispatch(world, bob, "reply hi")
+	if msgs := drainOutput(bob.Output); len(msgs) == 0 || msgs[len(msgs)-1] != "No one has sent you a tell yet." {
+		t.Fatalf("unexpected reply output: %v", msgs)
+	}
+	Dispatch(world, alice, "tell bob Are you there?")
+	Dispatch(world, bob, "reply Yes!")
+	Dispatch(world, alice, "retell Great.")
+	drainOutput(alice.Output)
+	bobMsgs := drainOutput(bob.Output)
+	if len(bobMsgs) == 0 || bobMsgs[len(bobMsgs)-1] != "Alice tells you: Great." {
+		t.Fatalf("unexpected bob output: %v", bobMsgs)
+	}
+
+	Dispatch(world, bob, "tells")
+	out := strings.Join(drainOutput(bob.Output), "\n")
+	for _, want := range []string{"Alice tells you: Are you there?", "You tell Alice: Yes!", "Alice tells you: Great.", "reply goes to Alice."} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("tells output missing %q:\n%s", want, out)
+		}
+	}
+}
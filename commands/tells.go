@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Tells = Define(Definition{
+	Name:        "tells",
+	Usage:       "tells [count]",
+	Description: "show your recent tell conversations",
+}, func(ctx *Context) bool {
+	limit := 10
+	if arg := strings.TrimSpace(ctx.Arg); arg != "" {
+		count, err := strconv.Atoi(arg)
+		if err != nil || count <= 0 {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nTell count must be a positive number.", game.AnsiYellow)))
+			return false
+		}
+		limit = min(count, game.TellHistoryLimit)
+	}
+	history := ctx.World.TellHistory(ctx.Player, limit)
+	if len(history) == 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou haven't sent or received any tells.", game.AnsiYellow)))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\n" + game.Style("Recent tells:", game.AnsiBold))
+	for _, record := range history {
+		stamp := record.Time.Format("15:04:05")
+		line := fmt.Sprintf("%s tells you: %s", game.HighlightName(record.From), record.Body)
+		if strings.EqualFold(record.From, ctx.Player.Name) {
+			line = fmt.Sprintf("You tell %s: %s", game.HighlightName(record.To), record.Body)
+		}
+		if record.Queued {
+			line += game.Style(" (offline)", game.AnsiDim)
+		}
+		builder.WriteString(fmt.Sprintf("\r\n  [%s] %s", stamp, line))
+	}
+	if reply := ctx.World.ReplyTarget(ctx.Player); reply != "" {
+		builder.WriteString("\r\nreply goes to " + game.HighlightName(reply) + ".")
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
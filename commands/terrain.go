@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Terrain = Define(Definition{
+	Name:        "terrain",
+	Usage:       "terrain [inside|road|field|forest|hills|mountain|swamp|water|air]",
+	Description: "show or set the current room's terrain (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use terrain.", game.AnsiYellow)))
+		return false
+	}
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		room, ok := ctx.World.GetRoom(ctx.Player.Room)
+		if !ok {
+			return false
+		}
+		capacity := "no limit"
+		if room.Capacity > 0 {
+			capacity = fmt.Sprintf("%d players", room.Capacity)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nTerrain: %s. Capacity: %s.", room.Terrain.Label(), capacity)))
+		return false
+	}
+	terrain, ok := game.ParseTerrain(arg)
+	if !ok {
+		names := []string{"inside"}
+		for _, t := range game.Terrains() {
+			names = append(names, t.Label())
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown terrain. Choose one of: "+strings.Join(names, ", ")+".", game.AnsiYellow)))
+		return false
+	}
+	if err := ctx.World.SetRoomTerrain(ctx.Player.Room, terrain, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nTerrain set to %s.", terrain.Label())))
+	return false
+})
+
+var RoomCap = Define(Definition{
+	Name:        "roomcap",
+	Usage:       "roomcap <players>",
+	Description: "limit how many players fit in the current room, 0 for no limit (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use roomcap.", game.AnsiYellow)))
+		return false
+	}
+	capacity, err := strconv.Atoi(strings.TrimSpace(ctx.Arg))
+	if err != nil || capacity < 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: roomcap <players>", game.AnsiYellow)))
+		return false
+	}
+	if err := ctx.World.SetRoomCapacity(ctx.Player.Room, capacity, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	if capacity == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nRoom capacity limit removed."))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nRoom capacity set to %d players.", capacity)))
+	return false
+})
+
+var Hazard = Define(Definition{
+	Name:        "hazard",
+	Usage:       "hazard [none|deep_water|cliff|lava]",
+	Description: "show or set the current room's hazard (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use hazard.", game.AnsiYellow)))
+		return false
+	}
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		room, ok := ctx.World.GetRoom(ctx.Player.Room)
+		if !ok {
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nHazard: %s.", room.Hazard.Label())))
+		return false
+	}
+	hazard, ok := game.ParseHazard(arg)
+	if !ok {
+		names := []string{"none"}
+		for _, h := range game.Hazards() {
+			names = append(names, string(h))
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown hazard. Choose one of: "+strings.Join(names, ", ")+".", game.AnsiYellow)))
+		return false
+	}
+	if err := ctx.World.SetRoomHazard(ctx.Player.Room, hazard, ctx.Player.Name); err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	if hazard == game.HazardNone {
+		ctx.Player.Output.Send(game.Ansi("\r\nHazard cleared."))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nHazard set to %s.", hazard.Label())))
+	return false
+})
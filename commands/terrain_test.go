@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestTerrainCommandSetsRoomTerrain(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	player := newTestPlayer("Walker", "start")
+	world.AddPlayerForTest(player)
+	Dispatch(world, player, "terrain swamp")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Only builders or admins may use terrain") {
+		t.Fatalf("expected builder warning, got %q", msgs)
+	}
+
+	player.IsBuilder = true
+	Dispatch(world, player, "terrain swamp")
+	Dispatch(world, player, "roomcap 4")
+	room, _ := world.GetRoom("start")
+	if room.Terrain != game.TerrainSwamp || room.Capacity != 4 {
+		t.Fatalf("room terrain %q capacity %d, want swamp and 4", room.Terrain, room.Capacity)
+	}
+	drainOutput(player.Output)
+	Dispatch(world, player, "terrain")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Terrain: swamp. Capacity: 4 players.") {
+		t.Fatalf("unexpected terrain output %q", msgs)
+	}
+	Dispatch(world, player, "terrain lava")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Unknown terrain") {
+		t.Fatalf("expected unknown terrain warning, got %q", msgs)
+	}
+}
+
+func TestHazardCommandFlagsRoom(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}},
+	})
+	player := newTestPlayer("Walker", "start")
+	player.IsBuilder = true
+	world.AddPlayerForTest(player)
+	Dispatch(world, player, "hazard deep water")
+	room, _ := world.GetRoom("start")
+	if room.Hazard != game.HazardDeepWater {
+		t.Fatalf("room hazard %q, want deep_water", room.Hazard)
+	}
+	drainOutput(player.Output)
+	Dispatch(world, player, "hazard")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Hazard: deep water.") {
+		t.Fatalf("unexpected hazard output %q", msgs)
+	}
+	Dispatch(world, player, "hazard quicksand")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Unknown hazard") {
+		t.Fatalf("expected unknown hazard warning, got %q", msgs)
+	}
+	Dispatch(world, player, "hazard none")
+	if room, _ := world.GetRoom("start"); room.Hazard != game.HazardNone {
+		t.Fatalf("expected hazard cleared, got %q", room.Hazard)
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Taunt = Define(Definition{
+	Name:        "taunt",
+	Usage:       "taunt <target>",
+	Description: "goad a foe into attacking you instead of your allies",
+}, func(ctx *Context) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: taunt <target>", game.AnsiYellow)))
+		return false
+	}
+	npc, err := ctx.World.Taunt(ctx.Player, target)
+	if err != nil {
+		message := err.Error()
+		if errors.Is(err, game.ErrTauntCooldown) {
+			message = "You need a moment to catch your breath before taunting again."
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+message, game.AnsiYellow)))
+		return false
+	}
+	npcName := game.HighlightNPCName(npc.Name)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou taunt %s, drawing its fury onto yourself!", npcName)))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s taunts %s, drawing its fury!", game.HighlightName(ctx.Player.Name), npcName)), ctx.Player)
+	ctx.Player.Output.Send(game.Prompt(ctx.Player))
+	return false
+})
+
+var Consider = Define(Definition{
+	Name:        "consider",
+	Aliases:     []string{"con"},
+	Usage:       "consider <target>",
+	Description: "size up a creature and see who holds its attention",
+}, func(ctx *Context) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: consider <target>", game.AnsiYellow)))
+		return false
+	}
+	info, err := ctx.World.Consider(ctx.Player, target)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.EnsureStats()
+	npcName := game.HighlightNPCName(info.NPC.Name)
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\n%s (level %d, %d/%d HP) %s",
+		npcName, info.NPC.Level, info.NPC.Health, info.NPC.MaxHealth, considerVerdict(info.NPC.Level-ctx.Player.Level)))
+	if len(info.Threat) == 0 {
+		builder.WriteString("\r\nIt isn't fighting anyone.")
+	} else {
+		builder.WriteString("\r\nThreat:")
+		for _, entry := range info.Threat {
+			marker := ""
+			if entry.Player == info.Target {
+				marker = " (target)"
+			}
+			builder.WriteString(fmt.Sprintf("\r\n  %s %d%s", game.HighlightName(entry.Player), entry.Threat, marker))
+		}
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+	return false
+})
+
+func considerVerdict(diff int) string {
+	switch {
+	case diff <= -5:
+		return "would barely notice your attack."
+	case diff <= -2:
+		return "looks like an easy fight."
+	case diff <= 1:
+		return "looks like a fair match."
+	case diff <= 4:
+		return "looks like a tough fight."
+	default:
+		return "would crush you without effort."
+	}
+}
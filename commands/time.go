@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"fmt"
+
+	"LumenClay/internal/game"
+)
+
+var Time = Define(Definition{
+	Name:        "time",
+	Usage:       "time",
+	Description: "show the time of day and the local weather",
+	Group:       GroupGeneral,
+}, func(ctx *Context) bool {
+	hour, phase := ctx.World.Clock()
+	weather := ctx.World.WeatherIn(ctx.Player.Room)
+	line := fmt.Sprintf("\r\nIt is %s, %s.", game.Style(game.FormatClock(hour), game.AnsiBold), phase)
+	if room, ok := ctx.World.GetRoom(ctx.Player.Room); ok && room.Outdoors {
+		line += fmt.Sprintf(" The weather here is %s.", game.Style(string(weather), game.AnsiCyan))
+	} else {
+		line += fmt.Sprintf(" Outside, the weather is %s.", game.Style(string(weather), game.AnsiCyan))
+	}
+	ctx.Player.Output.Send(game.Ansi(line))
+	return false
+})
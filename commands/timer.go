@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Timer = Define(Definition{
+	Name:        "timer",
+	Usage:       "timer <list|every|at|cancel> ...",
+	Description: "schedule recurring room messages (builders/admins only)",
+	Group:       GroupBuilder,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may manage timers.", game.AnsiYellow)))
+		return false
+	}
+	action, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(action) {
+	case "", "list":
+		timers := ctx.World.Timers()
+		if len(timers) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nNo timers are scheduled."))
+			return false
+		}
+		lines := make([]string, 0, len(timers)+1)
+		lines = append(lines, game.Style("Timers:", game.AnsiBold))
+		for _, timer := range timers {
+			where := ""
+			if timer.Room != "" {
+				where = " in " + string(timer.Room)
+			}
+			lines = append(lines, fmt.Sprintf("  #%d %s%s (%s), next %s: %s",
+				timer.ID, timer.Owner, where, timer.Schedule, timer.Next.Format("15:04:05"), timer.Label))
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\n" + strings.Join(lines, "\r\n")))
+		return false
+	case "every", "at":
+		when, message, _ := strings.Cut(rest, " ")
+		message = strings.TrimSpace(message)
+		if when == "" || message == "" {
+			usage := "\r\nUsage: timer every <interval> <message>"
+			if strings.EqualFold(action, "at") {
+				usage = "\r\nUsage: timer at <HH:MM> <message>"
+			}
+			ctx.Player.Output.Send(game.Ansi(game.Style(usage, game.AnsiYellow)))
+			return false
+		}
+		world, room := ctx.World, ctx.Player.Room
+		echo := func() {
+			world.BroadcastToRoom(room, game.Ansi("\r\n"+game.Style(message, game.AnsiItalic)), nil)
+		}
+		owner := game.BuilderTimerOwner(ctx.Player.Name)
+		var (
+			id  int
+			err error
+		)
+		if strings.EqualFold(action, "every") {
+			interval, parseErr := game.ParseTimerInterval(when)
+			if parseErr != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+parseErr.Error(), game.AnsiYellow)))
+				return false
+			}
+			id, err = ctx.World.ScheduleEvery(owner, room, interval, message, echo)
+		} else {
+			hour, minute, parseErr := game.ParseTimerClock(when)
+			if parseErr != nil {
+				ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+parseErr.Error(), game.AnsiYellow)))
+				return false
+			}
+			id, err = ctx.World.ScheduleAt(owner, room, hour, minute, message, echo)
+		}
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nTimer #%d scheduled for %s.", id, room)))
+		return false
+	case "cancel":
+		id, err := strconv.Atoi(strings.TrimPrefix(rest, "#"))
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: timer cancel <id>", game.AnsiYellow)))
+			return false
+		}
+		if !ctx.World.CancelTimer(id) {
+			ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\nNo timer #%d.", id), game.AnsiYellow)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nTimer #%d cancelled.", id)))
+		return false
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: timer <list|every|at|cancel> ...", game.AnsiYellow)))
+		return false
+	}
+})
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Track = Define(Definition{
+	Name:        "track",
+	Usage:       "track [<name>]",
+	Description: "find which way a player or creature went, or read the scents here",
+}, func(ctx *Context) bool {
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		trails, err := ctx.World.Scents(ctx.Player)
+		if err != nil {
+			trackError(ctx, err, "")
+			return false
+		}
+		if len(trails) == 0 {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou find no recent trails here."))
+			return false
+		}
+		now := time.Now()
+		var b strings.Builder
+		b.WriteString("\r\nYou pick out these trails:")
+		for _, trail := range trails {
+			b.WriteString(fmt.Sprintf("\r\n  %s, %s, leading %s", game.HighlightName(trail.Name), trail.Strength(now), trail.Direction))
+		}
+		ctx.Player.Output.Send(game.Ansi(b.String()))
+		return false
+	}
+	result, err := ctx.World.Track(ctx.Player, target)
+	if err != nil {
+		trackError(ctx, err, target)
+		return false
+	}
+	name := game.HighlightName(result.Target)
+	switch {
+	case result.Here:
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s is right here.", name)))
+	case result.Scent != nil:
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou catch a %s scent of %s leading %s.", result.Scent.Strength(time.Now()), name, result.Direction)))
+	case result.Distance <= 2:
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe trail of %s is fresh and close. It leads %s.", name, result.Direction)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou pick up the trail of %s. It leads %s.", name, result.Direction)))
+	}
+	return false
+})
+
+func trackError(ctx *Context, err error, target string) {
+	switch {
+	case errors.Is(err, game.ErrCannotTrack):
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou don't know how to read a trail.", game.AnsiYellow)))
+	case errors.Is(err, game.ErrNoTrail):
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou can't find any trace of %s.", target)))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+	}
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Trade = Define(Definition{
+	Name:        "trade",
+	Usage:       "trade <player|add <item>|gold <amount>|remove <item>|accept|cancel>",
+	Description: "swap items and gold with another player",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		view, err := ctx.World.TradeStatus(ctx.Player)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(formatTrade(view)))
+		return false
+	}
+	sub, rest, _ := strings.Cut(arg, " ")
+	rest = strings.TrimSpace(rest)
+	name := game.HighlightName(ctx.Player.Name)
+	switch strings.ToLower(sub) {
+	case "add", "offer":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: trade add <item>", game.AnsiYellow)))
+			return false
+		}
+		item, view, err := ctx.World.OfferTradeItem(ctx.Player, rest)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou offer %s.", item.DisplayName()) + formatTrade(view)))
+		view.Partner.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s offers %s. Type 'trade' to review.", name, item.DisplayName())))
+	case "gold":
+		amount, err := strconv.Atoi(rest)
+		if err != nil {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: trade gold <amount>", game.AnsiYellow)))
+			return false
+		}
+		view, err := ctx.World.OfferTradeGold(ctx.Player, amount)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		gold := game.Style(fmt.Sprintf("%d gold", amount), game.AnsiYellow)
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou put up %s.", gold) + formatTrade(view)))
+		view.Partner.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s puts up %s. Type 'trade' to review.", name, gold)))
+	case "remove":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: trade remove <item>", game.AnsiYellow)))
+			return false
+		}
+		removed, view, err := ctx.World.WithdrawTradeItem(ctx.Player, rest)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou take back %s.", game.HighlightItemName(removed)) + formatTrade(view)))
+		view.Partner.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s takes back %s.", name, game.HighlightItemName(removed))))
+	case "accept", "confirm":
+		view, done, err := ctx.World.ConfirmTrade(ctx.Player)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		if !done {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou accept the trade. Waiting for %s.", game.HighlightName(view.Partner.Name))))
+			view.Partner.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s accepts the trade. Type 'trade accept' to complete it.", name)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe trade is complete. You receive %s.", formatTradeTerms(view.Theirs))))
+		view.Partner.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe trade is complete. You receive %s.", formatTradeTerms(view.Mine))))
+	case "cancel":
+		partner, err := ctx.World.CancelTrade(ctx.Player)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi("\r\nYou call off the trade."))
+		if partner != nil {
+			partner.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s calls off the trade.", name)))
+		}
+	default:
+		other, opened, err := ctx.World.RequestTrade(ctx.Player, arg)
+		if err != nil {
+			tradeError(ctx, err)
+			return false
+		}
+		if opened {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou begin trading with %s. Use 'trade add <item>' and 'trade gold <amount>', then 'trade accept'.", game.HighlightName(other.Name))))
+			other.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s agrees to trade. Use 'trade add <item>' and 'trade gold <amount>', then 'trade accept'.", name)))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou offer to trade with %s.", game.HighlightName(other.Name))))
+		other.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s wants to trade with you. Type 'trade %s' to begin.", name, ctx.Player.Name)))
+	}
+	return false
+})
+
+func tradeError(ctx *Context, err error) {
+	if errors.Is(err, game.ErrItemNotCarried) {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou aren't carrying that."))
+		return
+	}
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
+
+func formatTrade(view game.TradeView) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\r\nTrading with %s:", game.HighlightName(view.Partner.Name)))
+	builder.WriteString("\r\n  You offer: " + formatTradeTerms(view.Mine) + tradeAccepted(view.Mine))
+	builder.WriteString(fmt.Sprintf("\r\n  %s offers: %s%s", view.Partner.Name, formatTradeTerms(view.Theirs), tradeAccepted(view.Theirs)))
+	return builder.String()
+}
+
+func formatTradeTerms(terms game.TradeTerms) string {
+	parts := make([]string, 0, len(terms.Items)+1)
+	for _, item := range terms.Items {
+		parts = append(parts, game.HighlightItemName(item))
+	}
+	if terms.Gold > 0 {
+		parts = append(parts, game.Style(fmt.Sprintf("%d gold", terms.Gold), game.AnsiYellow))
+	}
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func tradeAccepted(terms game.TradeTerms) string {
+	if terms.Confirmed {
+		return game.Style(" (accepted)", game.AnsiGreen)
+	}
+	return ""
+}
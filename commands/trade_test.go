@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestTradeCommand(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{}},
+	})
+	seller := newTestPlayer("Seller", "hall")
+	seller.Inventory = []game.Item{{Name: "Star Chart"}}
+	buyer := newTestPlayer("Buyer", "hall")
+	buyer.Gold = 25
+	world.AddPlayerForTest(seller)
+	world.AddPlayerForTest(buyer)
+
+	Dispatch(world, seller, "trade buyer")
+	if output := strings.Join(drainOutput(buyer.Output), "\n"); !strings.Contains(output, "Seller wants to trade with you. Type 'trade Seller' to begin.") {
+		t.Fatalf("invite output = %q", output)
+	}
+	Dispatch(world, buyer, "trade seller")
+	Dispatch(world, seller, "trade add chart")
+	Dispatch(world, buyer, "trade gold 20")
+	drainOutput(seller.Output)
+	Dispatch(world, seller, "trade")
+	if output := strings.Join(drainOutput(seller.Output), "\n"); !strings.Contains(output, "You offer: Star Chart") || !strings.Contains(output, "Buyer offers: 20 gold") {
+		t.Fatalf("trade window = %q", output)
+	}
+
+	Dispatch(world, seller, "trade accept")
+	drainOutput(buyer.Output)
+	Dispatch(world, buyer, "trade accept")
+	if output := strings.Join(drainOutput(buyer.Output), "\n"); !strings.Contains(output, "The trade is complete. You receive Star Chart.") {
+		t.Fatalf("buyer completion = %q", output)
+	}
+	if output := strings.Join(drainOutput(seller.Output), "\n"); !strings.Contains(output, "You receive 20 gold.") {
+		t.Fatalf("seller completion = %q", output)
+	}
+	if seller.Gold != 20 || len(buyer.Inventory) != 1 {
+		t.Fatalf("after trade seller gold %d, buyer items %d", seller.Gold, len(buyer.Inventory))
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Train = Define(Definition{
+	Name:        "train",
+	Usage:       "train [str|dex|con|int]",
+	Description: "spend training points on your attributes with a trainer",
+}, func(ctx *Context) bool {
+	arg := strings.TrimSpace(ctx.Arg)
+	if arg == "" {
+		ctx.Player.Output.Send(game.Ansi(trainSummary(ctx.World, ctx.Player)))
+		return false
+	}
+	attr, ok := game.ParseAttribute(arg)
+	if !ok {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+		return false
+	}
+	value, err := ctx.World.TrainAttribute(ctx.Player, attr)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	trainer, _ := ctx.World.TrainerHere(ctx.Player)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\n%s drills you until your %s rises to %s. (%d training points left)",
+		game.HighlightNPCName(trainer.Name), strings.ToLower(attr.Label()), game.Style(fmt.Sprintf("%d", value), game.AnsiGreen, game.AnsiBold), ctx.Player.TrainPoints)))
+	return false
+})
+
+func trainSummary(world *game.World, player *game.Player) string {
+	var builder strings.Builder
+	builder.WriteString("\r\n" + formatAttributes(player))
+	builder.WriteString(fmt.Sprintf("\r\nTraining points: %d (you earn %d each level).", player.TrainPoints, game.AttributePointsPerLevel))
+	if trainer, ok := world.TrainerHere(player); ok {
+		builder.WriteString(fmt.Sprintf("\r\n%s can train you here. Type 'train <attribute>'.", game.HighlightNPCName(trainer.Name)))
+	} else {
+		builder.WriteString("\r\nFind a trainer to spend your points.")
+	}
+	return builder.String()
+}
+
+// formatAttributes renders "Str 12  Dex 10  Con 11  Int 10".
+func formatAttributes(player *game.Player) string {
+	attrs := player.Attributes()
+	parts := make([]string, 0, 4)
+	for _, attr := range game.AllAttributes() {
+		label := strings.ToUpper(string(attr)[:1]) + string(attr)[1:]
+		parts = append(parts, fmt.Sprintf("%s %s", label, game.Style(fmt.Sprintf("%d", attrs.Get(attr)), game.AnsiBold)))
+	}
+	return strings.Join(parts, "  ")
+}
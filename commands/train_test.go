@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestTrainCommand(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]game.Exit{"east": {To: "yard"}}},
+		"yard": {ID: "yard", Title: "Yard", NPCs: []game.NPC{{Name: "Drillmaster", Trainer: true}}, Exits: map[string]game.Exit{"west": {To: "hall"}}},
+	})
+	player := newTestPlayer("Trainee", "hall")
+	player.TrainPoints = 2
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "train str")
+	if output := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(output, "There is no trainer here.") {
+		t.Fatalf("train without trainer output = %q", output)
+	}
+
+	player.Room = "yard"
+	Dispatch(world, player, "train")
+	output := strings.Join(drainOutput(player.Output), "\n")
+	if !strings.Contains(output, "Str 10 Dex 10 Con 10 Int 10") || !strings.Contains(output, "Training points: 2") || !strings.Contains(output, "Drillmaster can train you here") {
+		t.Fatalf("train summary = %q", output)
+	}
+
+	Dispatch(world, player, "train strength")
+	output = strings.Join(drainOutput(player.Output), "\n")
+	if !strings.Contains(output, "your strength rises to 11") || !strings.Contains(output, "1 training points left") {
+		t.Fatalf("train output = %q", output)
+	}
+	if got := player.Attributes().Str; got != 11 {
+		t.Fatalf("strength = %d, want 11", got)
+	}
+
+	Dispatch(world, player, "train luck")
+	if output := strings.Join(drainOutput(player.Output), "\n"); !strings.Contains(output, "Usage: train") {
+		t.Fatalf("unknown attribute output = %q", output)
+	}
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Tutorial = Define(Definition{
+	Name:        "tutorial",
+	Usage:       "tutorial [skip|restart]",
+	Description: "show your tutorial task, skip the tutorial, or start it over",
+}, func(ctx *Context) bool {
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "":
+		progress := ctx.World.TutorialStatus(ctx.Player)
+		if progress.Task == "" {
+			ctx.Player.Output.Send(game.Ansi("\r\nYou are not following the tutorial. Type tutorial restart to run through it."))
+			return false
+		}
+		msg := fmt.Sprintf("\r\n%s (step %d of %d)\r\n%s", game.Style("Tutorial", game.AnsiBold), progress.Number, progress.Total, progress.Task)
+		if progress.Hint != "" {
+			msg += "\r\n" + progress.Hint
+		}
+		msg += "\r\nType tutorial skip to stop the guided tasks."
+		ctx.Player.Output.Send(game.Ansi(msg))
+	case "skip":
+		if !game.TutorialActive(ctx.World.TutorialStatus(ctx.Player).Step) {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are not following the tutorial.", game.AnsiYellow)))
+			return false
+		}
+		ctx.World.SetTutorial(ctx.Player, game.TutorialDone)
+		ctx.Player.Output.Send(game.Ansi("\r\nYou skip the tutorial. Type tutorial restart if you ever want it back."))
+	case "restart":
+		ctx.World.SetTutorial(ctx.Player, game.TutorialMove)
+		ctx.Player.Output.Send(game.Ansi("\r\nYou start the tutorial from the beginning."))
+		ctx.World.ShowTutorialHint(ctx.Player)
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: tutorial [skip|restart]", game.AnsiYellow)))
+	}
+	return false
+})
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestTutorialGuidesMoveAndLook(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start":  {ID: "start", Title: "Start", Exits: map[string]game.Exit{"east": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Exits: map[string]game.Exit{"west": {To: "start"}}},
+	})
+	newbie := newTestPlayer("Newbie", "start")
+	newbie.Tutorial = game.TutorialMove
+	world.AddPlayerForTest(newbie)
+
+	Dispatch(world, newbie, "tutorial")
+	if got := strings.Join(drainOutput(newbie.Output), "\n"); !strings.Contains(got, "step 1 of 5") || !strings.Contains(got, "Exits here: east.") {
+		t.Fatalf("unexpected tutorial status %q", got)
+	}
+
+	Dispatch(world, newbie, "go east")
+	got := strings.Join(drainOutput(newbie.Output), "\n")
+	if newbie.Tutorial != game.TutorialLook || !strings.Contains(got, "Type look") {
+		t.Fatalf("moving should advance to the look step, got %q (%q)", newbie.Tutorial, got)
+	}
+	Dispatch(world, newbie, "look")
+	if newbie.Tutorial != game.TutorialGet {
+		t.Fatalf("Tutorial = %q, want get", newbie.Tutorial)
+	}
+	drainOutput(newbie.Output)
+
+	Dispatch(world, newbie, "tutorial skip")
+	if newbie.Tutorial != game.TutorialDone {
+		t.Fatalf("Tutorial = %q after skip, want done", newbie.Tutorial)
+	}
+	Dispatch(world, newbie, "tutorial")
+	if got := strings.Join(drainOutput(newbie.Output), "\n"); !strings.Contains(got, "not following the tutorial") {
+		t.Fatalf("unexpected status after skip %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Unban = Define(Definition{
+	Name:        "unban",
+	Usage:       "unban <player|ip[/cidr]>",
+	Description: "lift an account or address ban (admin only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsAdmin {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may lift bans.", game.AnsiYellow)))
+		return false
+	}
+	bans := ctx.World.BanList()
+	if bans == nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nBans are not configured.", game.AnsiYellow)))
+		return false
+	}
+	target := strings.TrimSpace(ctx.Arg)
+	if target == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: unban <player|ip[/cidr]>", game.AnsiYellow)))
+		return false
+	}
+	var (
+		removed bool
+		err     error
+	)
+	if game.IsIPBanTarget(target) {
+		removed, err = bans.UnbanIP(target)
+	} else {
+		removed, err = bans.UnbanAccount(target)
+	}
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nFailed to lift ban: "+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	if !removed {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThere is no ban for "+target+".", game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe ban on %s has been lifted.", game.Style(target, game.AnsiCyan))))
+	return false
+})
@@ -10,34 +10,41 @@ import (
 var Unmute = Define(Definition{
 	Name:        "unmute",
 	Usage:       "unmute <player> <channel>",
-	Description: "restore a player's access to a channel (admin only)",
+	Description: "restore a player's access to a channel (staff only)",
 	Group:       GroupAdmin,
 }, func(ctx *Context) bool {
-	if !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly admins may unmute players.", game.AnsiYellow))
+	if !ctx.Player.IsAdmin && !ctx.Player.IsModerator {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may unmute players.", game.AnsiYellow)))
 		return false
 	}
 	fields := strings.Fields(ctx.Arg)
 	if len(fields) != 2 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUsage: unmute <player> <channel>", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: unmute <player> <channel>", game.AnsiYellow)))
 		return false
 	}
-	target, ok := ctx.World.FindPlayer(fields[0])
+	channel, ok := game.ChannelFromString(fields[1])
 	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nThey are not online.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow)))
 		return false
 	}
-	channel, ok := game.ChannelFromString(fields[1])
-	if !ok {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nUnknown channel.", game.AnsiYellow))
+	name := fields[0]
+	target, online := ctx.World.FindPlayer(name)
+	if online {
+		name = target.Name
+	}
+	lifted, err := ctx.World.UnmuteChannel(name, channel, ctx.Player.Name)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
 		return false
 	}
-	if !ctx.World.ChannelMuted(target, channel) {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nThey are not muted on that channel.", game.AnsiYellow))
+	if !lifted {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nThey are not muted on that channel.", game.AnsiYellow)))
 		return false
 	}
-	ctx.World.SetChannelMute(target, channel, false)
-	target.Output <- game.Ansi(fmt.Sprintf("\r\nYou are no longer muted on the %s channel.", strings.ToUpper(fields[1])))
-	ctx.Player.Output <- game.Ansi(fmt.Sprintf("\r\nYou unmute %s on the %s channel.", game.HighlightName(target.Name), strings.ToUpper(fields[1])))
+	label := strings.ToUpper(string(channel))
+	if online {
+		target.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou are no longer muted on the %s channel.", label)))
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou unmute %s on the %s channel.", game.HighlightName(name), label)))
 	return false
 })
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Board = Define(Definition{
+	Name:        "board",
+	Usage:       "board [vehicle]",
+	Description: "go aboard a ferry or caravan docked here",
+}, func(ctx *Context) bool {
+	name, from, err := ctx.World.BoardVehicle(ctx.Player, strings.TrimSpace(ctx.Arg))
+	if err != nil {
+		vehicleError(ctx, err)
+		return false
+	}
+	vehicle := game.Style(name, game.AnsiCyan)
+	ctx.World.BroadcastToRoom(from, game.Ansi(fmt.Sprintf("\r\n%s boards the %s.", game.HighlightName(ctx.Player.Name), vehicle)), ctx.Player)
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s comes aboard.", game.HighlightName(ctx.Player.Name))), ctx.Player)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou board the %s.", vehicle)))
+	game.EnterRoom(ctx.World, ctx.Player, "")
+	return false
+})
+
+var Disembark = Define(Definition{
+	Name:        "disembark",
+	Usage:       "disembark",
+	Description: "leave a docked ferry or caravan at its current stop",
+}, func(ctx *Context) bool {
+	name, from, err := ctx.World.Disembark(ctx.Player)
+	if err != nil {
+		vehicleError(ctx, err)
+		return false
+	}
+	vehicle := game.Style(name, game.AnsiCyan)
+	ctx.World.BroadcastToRoom(from, game.Ansi(fmt.Sprintf("\r\n%s goes ashore.", game.HighlightName(ctx.Player.Name))), ctx.Player)
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s steps off the %s.", game.HighlightName(ctx.Player.Name), vehicle)), ctx.Player)
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou step off the %s.", vehicle)))
+	game.EnterRoom(ctx.World, ctx.Player, "")
+	return false
+})
+
+func vehicleError(ctx *Context, err error) {
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
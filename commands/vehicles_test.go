@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+func TestBoardAndDisembark(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"pier": {ID: "pier", Title: "Pier", Exits: map[string]game.Exit{}},
+		"isle": {ID: "isle", Title: "Isle", Exits: map[string]game.Exit{}},
+		"deck": {ID: "deck", Title: "Deck", Exits: map[string]game.Exit{}},
+	})
+	if err := world.AddVehicleForTest(game.Vehicle{
+		ID: "ferry", Name: "Ferry", Rooms: []game.RoomID{"deck"},
+		Stops: []game.VehicleStop{{Room: "pier"}, {Room: "isle"}},
+	}); err != nil {
+		t.Fatalf("AddVehicleForTest: %v", err)
+	}
+	rider := newTestPlayer("Rider", "pier")
+	world.AddPlayerForTest(rider)
+
+	Dispatch(world, rider, "look")
+	if msgs := strings.Join(drainOutput(rider.Output), " "); !strings.Contains(msgs, "is docked here") {
+		t.Fatalf("look did not show the docked ferry: %q", msgs)
+	}
+	Dispatch(world, rider, "board ferry")
+	if rider.Room != "deck" {
+		t.Fatalf("rider in %s, want deck", rider.Room)
+	}
+	if msgs := strings.Join(drainOutput(rider.Output), " "); !strings.Contains(msgs, "You board the") {
+		t.Fatalf("unexpected board output %q", msgs)
+	}
+
+	now := time.Now().Add(time.Hour)
+	world.AdvanceVehicles(now)
+	Dispatch(world, rider, "disembark")
+	if msgs := strings.Join(drainOutput(rider.Output), " "); rider.Room != "deck" || !strings.Contains(msgs, "until it docks") {
+		t.Fatalf("disembarked underway into %s: %q", rider.Room, msgs)
+	}
+	world.AdvanceVehicles(now.Add(time.Hour))
+	Dispatch(world, rider, "disembark")
+	if rider.Room != "isle" {
+		t.Fatalf("rider in %s, want isle", rider.Room)
+	}
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var War = Define(Definition{
+	Name:        "war",
+	Usage:       "war [status|declare <clan>|truce <clan>|capture]",
+	Description: "declare clan wars, offer truces, and capture territory for your clan",
+}, func(ctx *Context) bool {
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	fail := func(err error) bool {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+clanError(err), game.AnsiYellow)))
+		return false
+	}
+	needArg := func(usage string) bool {
+		if rest != "" {
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: war "+usage, game.AnsiYellow)))
+		return true
+	}
+	switch strings.ToLower(sub) {
+	case "", "status":
+		clans := ctx.World.ClanSystem()
+		if clans == nil {
+			return fail(errors.New("clans are unavailable"))
+		}
+		ctx.Player.Output.Send(game.Ansi(describeWars(ctx.World, clans.Wars(), clans.Holdings(), time.Now())))
+	case "declare":
+		if needArg("declare <clan>") {
+			return false
+		}
+		war, err := ctx.World.DeclareWar(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou lead %s to war against %s.", war.Attacker, game.Style(war.Defender, game.AnsiCyan))))
+	case "truce", "peace":
+		if needArg("truce <clan>") {
+			return false
+		}
+		war, ended, err := ctx.World.OfferTruce(ctx.Player, rest)
+		if err != nil {
+			return fail(err)
+		}
+		if !ended {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou offer %s a truce. The war ends if their leader agrees.", game.Style(war.Opponent(war.TruceOffer), game.AnsiCyan))))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nThe war is over. No new war may be declared until %s.", war.TruceUntil().Local().Format("2006-01-02 15:04"))))
+	case "capture":
+		result, err := ctx.World.CaptureTerritory(ctx.Player)
+		if err != nil {
+			return fail(err)
+		}
+		msg := fmt.Sprintf("\r\nYou raise the banner of %s here.", game.Style(result.Clan, game.AnsiCyan))
+		if result.War != nil {
+			msg += fmt.Sprintf(" Taking it from %s scores %d. %s %d, %s %d.", result.From, game.ClanWarCaptureScore,
+				result.War.Attacker, result.War.AttackerScore, result.War.Defender, result.War.DefenderScore)
+		}
+		ctx.Player.Output.Send(game.Ansi(msg))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: "+ctx.Command.Usage, game.AnsiYellow)))
+	}
+	return false
+})
+
+func describeWars(world *game.World, wars []game.ClanWar, holdings map[game.RoomID]game.CaptureHold, now time.Time) string {
+	var b strings.Builder
+	var active, truces []game.ClanWar
+	for _, war := range wars {
+		switch {
+		case war.Active():
+			active = append(active, war)
+		case now.Before(war.TruceUntil()):
+			truces = append(truces, war)
+		}
+	}
+	if len(active) == 0 {
+		b.WriteString("\r\nNo clans are at war.")
+	} else {
+		b.WriteString("\r\n" + game.Style("Wars:", game.AnsiBold))
+		for _, war := range active {
+			b.WriteString(fmt.Sprintf("\r\n  %s %d vs %s %d, truce in %s", war.Attacker, war.AttackerScore, war.Defender, war.DefenderScore,
+				war.Ends.Sub(now).Round(time.Minute)))
+			if war.TruceOffer != "" {
+				b.WriteString(fmt.Sprintf(" (%s offers a truce)", war.TruceOffer))
+			}
+		}
+	}
+	if len(truces) > 0 {
+		b.WriteString("\r\n" + game.Style("Truces:", game.AnsiBold))
+		for _, war := range truces {
+			b.WriteString(fmt.Sprintf("\r\n  %s and %s until %s", war.Attacker, war.Defender, war.TruceUntil().Local().Format("2006-01-02 15:04")))
+		}
+	}
+	if len(holdings) == 0 {
+		return b.String()
+	}
+	b.WriteString("\r\n" + game.Style("Capture points:", game.AnsiBold))
+	rooms := make([]game.RoomID, 0, len(holdings))
+	for room := range holdings {
+		rooms = append(rooms, room)
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i] < rooms[j] })
+	for _, id := range rooms {
+		title := string(id)
+		if room, ok := world.GetRoom(id); ok {
+			title = room.Title
+		}
+		b.WriteString(fmt.Sprintf("\r\n  %-24s held by %s", title, holdings[id].Clan))
+	}
+	return b.String()
+}
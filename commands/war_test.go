@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+func TestWarCommand(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Windy Ridge", CapturePoint: true, Exits: map[string]game.Exit{}},
+	})
+	clans, err := game.NewClanSystem("")
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	world.AttachClanSystem(clans)
+	ava := newTestPlayer("Ava", "start")
+	bram := newTestPlayer("Bram", "start")
+	for _, p := range []*game.Player{ava, bram} {
+		world.AddPlayerForTest(p)
+	}
+	if _, err := clans.Create("Lamplighters", "Ava", time.Now()); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := clans.Create("Rust Hands", "Bram", time.Now()); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	Dispatch(world, ava, "war")
+	if out := strings.Join(drainOutput(ava.Output), ""); !strings.Contains(out, "No clans are at war.") {
+		t.Fatalf("expected a quiet status, got %q", out)
+	}
+	Dispatch(world, ava, "war declare rust hands")
+	if out := strings.Join(drainOutput(ava.Output), ""); !strings.Contains(out, "You lead Lamplighters to war against Rust Hands.") {
+		t.Fatalf("expected war to be declared, got %q", out)
+	}
+	Dispatch(world, ava, "war capture")
+	Dispatch(world, ava, "war status")
+	out := strings.Join(drainOutput(ava.Output), "")
+	if !strings.Contains(out, "You raise the banner of Lamplighters here.") || !strings.Contains(out, "Lamplighters 0 vs Rust Hands 0") || !strings.Contains(out, "Windy Ridge held by Lamplighters") {
+		t.Fatalf("expected the capture and scoreboard, got %q", out)
+	}
+	Dispatch(world, bram, "war capture")
+	if out := strings.Join(drainOutput(bram.Output), ""); !strings.Contains(out, "Ava stands guard over this point.") {
+		t.Fatalf("expected Ava to guard the point, got %q", out)
+	}
+	Dispatch(world, ava, "war truce rust hands")
+	if out := strings.Join(drainOutput(ava.Output), ""); !strings.Contains(out, "You offer Rust Hands a truce.") {
+		t.Fatalf("expected a truce offer, got %q", out)
+	}
+	Dispatch(world, bram, "war truce lamplighters")
+	if out := strings.Join(drainOutput(bram.Output), ""); !strings.Contains(out, "The war is over.") {
+		t.Fatalf("expected the truce to end the war, got %q", out)
+	}
+}
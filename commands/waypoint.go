@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"LumenClay/internal/game"
+)
+
+var Waypoint = Define(Definition{
+	Name:        "waypoint",
+	Aliases:     []string{"waypoints"},
+	Usage:       "waypoint [list|attune|travel <name>|set <name>|clear]",
+	Description: "attune to waypoints and travel between them",
+}, func(ctx *Context) bool {
+	sub, rest, _ := strings.Cut(strings.TrimSpace(ctx.Arg), " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(sub) {
+	case "", "list":
+		listWaypoints(ctx)
+	case "attune":
+		name, fresh, err := ctx.World.AttuneWaypoint(ctx.Player)
+		if err != nil {
+			waypointError(ctx, err)
+			return false
+		}
+		if !fresh {
+			ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou are already attuned to %s.", game.Style(name, game.AnsiMagenta))))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou lay a hand on the waypoint and attune to %s.", game.Style(name, game.AnsiMagenta))))
+	case "travel", "go":
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: waypoint travel <name>", game.AnsiYellow)))
+			return false
+		}
+		travelToWaypoint(ctx, rest)
+	case "set", "clear":
+		if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may place waypoints.", game.AnsiYellow)))
+			return false
+		}
+		if strings.EqualFold(sub, "set") && rest == "" {
+			ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: waypoint set <name>", game.AnsiYellow)))
+			return false
+		}
+		if strings.EqualFold(sub, "clear") {
+			rest = ""
+		}
+		if err := ctx.World.SetRoomWaypoint(ctx.Player.Room, rest, ctx.Player.Name); err != nil {
+			waypointError(ctx, err)
+			return false
+		}
+		if rest == "" {
+			ctx.Player.Output.Send(game.Ansi("\r\nWaypoint removed."))
+			return false
+		}
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nWaypoint %s placed here.", game.Style(rest, game.AnsiMagenta))))
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: waypoint [list|attune|travel <name>]", game.AnsiYellow)))
+	}
+	return false
+})
+
+func listWaypoints(ctx *Context) {
+	waypoints, wait := ctx.World.Waypoints(ctx.Player)
+	if len(waypoints) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou are not attuned to any waypoints. Find one and type 'waypoint attune'."))
+		return
+	}
+	var builder strings.Builder
+	builder.WriteString("\r\nAttuned waypoints:")
+	for _, waypoint := range waypoints {
+		builder.WriteString("\r\n  " + game.Style(waypoint.Name, game.AnsiMagenta))
+		if waypoint.Here {
+			builder.WriteString(" (here)")
+		}
+	}
+	if wait > 0 {
+		builder.WriteString(fmt.Sprintf("\r\nThe waypoints recharge in %s; travelling sooner costs %d gold.",
+			formatPortalDuration(wait.Round(time.Second)), ctx.World.WaypointFee()))
+	} else {
+		builder.WriteString("\r\nThe waypoints are charged. Type 'waypoint travel <name>' to go.")
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
+}
+
+func travelToWaypoint(ctx *Context, name string) {
+	trip, err := ctx.World.TravelToWaypoint(ctx.Player, name)
+	if err != nil {
+		waypointError(ctx, err)
+		return
+	}
+	player := game.HighlightName(ctx.Player.Name)
+	ctx.World.BroadcastToRoom(trip.From, game.Ansi(fmt.Sprintf("\r\n%s steps into a shimmer of light and is gone.", player)), ctx.Player)
+	ctx.World.BroadcastToRoom(trip.To, game.Ansi(fmt.Sprintf("\r\n%s steps out of the waypoint.", player)), ctx.Player)
+	message := fmt.Sprintf("\r\nThe waypoint carries you to %s.", game.Style(trip.Name, game.AnsiMagenta))
+	if trip.Gold > 0 {
+		message += fmt.Sprintf(" The hurried journey costs you %s.", game.Style(fmt.Sprintf("%d gold", trip.Gold), game.AnsiYellow))
+	}
+	ctx.Player.Output.Send(game.Ansi(message))
+	game.EnterRoom(ctx.World, ctx.Player, "")
+}
+
+func waypointError(ctx *Context, err error) {
+	if errors.Is(err, game.ErrUnknownWaypoint) {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are not attuned to a waypoint by that name. Type 'waypoint' to list yours.", game.AnsiYellow)))
+		return
+	}
+	msg := err.Error()
+	ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestWaypointCommands(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{"e": {To: "grove"}}},
+		"grove": {ID: "grove", Title: "Grove", Exits: map[string]game.Exit{"w": {To: "start"}}},
+	})
+	player := newTestPlayer("Pilgrim", "start")
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "waypoint set Confluence")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Only builders or admins may place waypoints") {
+		t.Fatalf("expected builder warning, got %q", msgs)
+	}
+	player.IsBuilder = true
+	Dispatch(world, player, "waypoint set Confluence")
+	Dispatch(world, player, "look")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "waypoint attune") {
+		t.Fatalf("look did not mention the waypoint: %q", msgs)
+	}
+	Dispatch(world, player, "waypoint attune")
+	Dispatch(world, player, "e")
+	drainOutput(player.Output)
+
+	Dispatch(world, player, "recall confluence")
+	if player.Room != "start" {
+		t.Fatalf("player in %s, want start", player.Room)
+	}
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "The waypoint carries you to") {
+		t.Fatalf("unexpected travel output %q", msgs)
+	}
+	Dispatch(world, player, "waypoint")
+	if msgs := strings.Join(drainOutput(player.Output), " "); !strings.Contains(msgs, "Confluence (here)") || !strings.Contains(msgs, "recharge") {
+		t.Fatalf("unexpected waypoint list %q", msgs)
+	}
+}
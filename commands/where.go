@@ -14,12 +14,12 @@ var Where = Define(Definition{
 	Group:       GroupBuilder,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin && !ctx.Player.IsBuilder {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly builders or admins may use where.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly builders or admins may use where.", game.AnsiYellow)))
 		return false
 	}
 	locations := ctx.World.PlayerLocations()
 	if len(locations) == 0 {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nNo players are currently connected.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nNo players are currently connected.", game.AnsiYellow)))
 		return false
 	}
 	var builder strings.Builder
@@ -31,6 +31,6 @@ var Where = Define(Definition{
 		}
 		builder.WriteString(fmt.Sprintf("  %-18s - %s [%s]\r\n", game.HighlightName(loc.Name), roomName, loc.Room))
 	}
-	ctx.Player.Output <- game.Ansi(builder.String())
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
 	return false
 })
@@ -13,11 +13,14 @@ var Whisper = Define(Definition{
 }, func(ctx *Context) bool {
 	msg := ctx.Arg
 	if msg == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nWhisper what?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nWhisper what?", game.AnsiYellow)))
 		return false
 	}
 	if ctx.World.ChannelMuted(ctx.Player, game.ChannelWhisper) {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou are muted on WHISPER.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are muted on WHISPER.", game.AnsiYellow)))
+		return false
+	}
+	if channelSlowed(ctx, game.ChannelWhisper) {
 		return false
 	}
 	broadcast := game.Ansi(fmt.Sprintf("\r\n%s whispers: %s", game.HighlightName(ctx.Player.Name), msg))
@@ -28,7 +31,7 @@ var Whisper = Define(Definition{
 		ctx.World.BroadcastToRoomsChannel(nearby, echo, ctx.Player, game.ChannelWhisper)
 	}
 	self := game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You whisper:", game.AnsiBold, game.AnsiYellow), msg))
-	ctx.Player.Output <- self
+	ctx.Player.Output.Send(self)
 	ctx.World.RecordPlayerChannelMessage(ctx.Player, game.ChannelWhisper, self)
 	return false
 })
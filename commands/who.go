@@ -1,22 +1,57 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"LumenClay/internal/game"
 )
 
 var Who = Define(Definition{
 	Name:        "who",
-	Usage:       "who",
+	Usage:       "who [builders|moderators|admins|staff|area <name>|level <min>[-<max>]]",
 	Description: "list connected players",
 }, func(ctx *Context) bool {
-	names := ctx.World.ListPlayers(false, "")
-	others := game.FilterOut(names, ctx.Player.Name)
-	if len(others) == 0 {
-		ctx.Player.Output <- game.Ansi("\r\nYou are the only adventurer online.")
+	filter, err := game.ParseWhoFilter(ctx.Arg)
+	if err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+". Usage: "+ctx.Command.Usage, game.AnsiYellow)))
 		return false
 	}
-	ctx.Player.Output <- game.Ansi("\r\nOther adventurers online: " + strings.Join(game.HighlightNames(others), ", "))
+	entries := ctx.World.WhoList(ctx.Player.IsStaff(), filter, time.Now())
+	filtered := strings.TrimSpace(ctx.Arg) != ""
+	if !filtered && (len(entries) == 0 || len(entries) == 1 && entries[0].Name == ctx.Player.Name) {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou are the only adventurer online."))
+		return false
+	}
+	if len(entries) == 0 {
+		ctx.Player.Output.Send(game.Ansi("\r\nNo adventurers match that filter."))
+		return false
+	}
+	var builder strings.Builder
+	builder.WriteString(game.Style(fmt.Sprintf("\r\nAdventurers online (%d):", len(entries)), game.AnsiBold))
+	builder.WriteString(fmt.Sprintf("\r\n  %-3s %-10s %4s  %s", "Lvl", "Class", "Idle", "Name"))
+	for _, entry := range entries {
+		class := entry.Class
+		if class == "" {
+			class = "-"
+		}
+		line := fmt.Sprintf("\r\n  %3d %-10s %4s  %s", entry.Level, class, game.FormatIdle(time.Duration(entry.IdleSeconds)*time.Second), game.TitledName(entry.Name, entry.Title))
+		if roles := entry.Roles[1:]; len(roles) > 0 {
+			line += " [" + strings.Join(roles, ", ") + "]"
+		}
+		if entry.WizInvis {
+			line += game.Style(" (wizinvis)", game.AnsiMagenta)
+		}
+		if entry.Linkdead {
+			line += game.Style(" (linkdead)", game.AnsiYellow)
+		}
+		if entry.Name == ctx.Player.Name {
+			line += " (you)"
+		}
+		builder.WriteString(line)
+	}
+	ctx.Player.Output.Send(game.Ansi(builder.String()))
 	return false
 })
@@ -13,7 +13,7 @@ func TestWhoCommandListsOthersInLoginOrder(t *testing.T) {
 			ID:          "start",
 			Title:       "A Quiet Chamber",
 			Description: "Soft light filters through the air.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 
@@ -30,9 +30,13 @@ func TestWhoCommandListsOthersInLoginOrder(t *testing.T) {
 	}
 
 	output := strings.Join(drainOutput(hero.Output), "\n")
-	want := "Other adventurers online: Watcher, Scout"
-	if !strings.Contains(output, want) {
-		t.Fatalf("who output = %q, want substring %q", output, want)
+	if !strings.Contains(output, "Adventurers online (3):") || !strings.Contains(output, "Hero (you)") {
+		t.Fatalf("who output = %q, want a header and the viewer marked", output)
+	}
+	watcherAt := strings.Index(output, "Watcher")
+	scoutAt := strings.Index(output, "Scout")
+	if watcherAt < 0 || scoutAt < watcherAt {
+		t.Fatalf("who output = %q, want Watcher listed before Scout", output)
 	}
 }
 
@@ -42,7 +46,7 @@ func TestWhoCommandHandlesNoOtherPlayers(t *testing.T) {
 			ID:          "start",
 			Title:       "A Quiet Chamber",
 			Description: "Soft light filters through the air.",
-			Exits:       map[string]game.RoomID{},
+			Exits:       map[string]game.Exit{},
 		},
 	})
 
@@ -59,3 +63,48 @@ func TestWhoCommandHandlesNoOtherPlayers(t *testing.T) {
 		t.Fatalf("who output = %q, want substring %q", output, want)
 	}
 }
+
+func TestWhoCommandFiltersAndWizInvis(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "A Quiet Chamber", Exits: map[string]game.Exit{}},
+	})
+	hero := newTestPlayer("Hero", "start")
+	hero.Level = 3
+	imm := newTestPlayer("Imm", "start")
+	imm.IsBuilder = true
+	imm.Level = 40
+	world.AddPlayerForTest(hero)
+	world.AddPlayerForTest(imm)
+
+	Dispatch(world, imm, "wizinvis")
+	if output := strings.Join(drainOutput(imm.Output), "\n"); !imm.WizInvis || !strings.Contains(output, "You fade from the who list") {
+		t.Fatalf("wizinvis output = %q", output)
+	}
+
+	Dispatch(world, hero, "who")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, "You are the only adventurer online.") {
+		t.Fatalf("player who output = %q, want wizinvis staff hidden", output)
+	}
+	Dispatch(world, hero, "wizinvis")
+	if output := strings.Join(drainOutput(hero.Output), "\n"); !strings.Contains(output, "Only staff may turn invisible.") {
+		t.Fatalf("player wizinvis output = %q", output)
+	}
+
+	Dispatch(world, imm, "who builders")
+	output := strings.Join(drainOutput(imm.Output), "\n")
+	if !strings.Contains(output, "Imm [Builder] (wizinvis) (you)") || strings.Contains(output, "Hero") {
+		t.Fatalf("who builders output = %q", output)
+	}
+	Dispatch(world, imm, "who level 1-5")
+	if output := strings.Join(drainOutput(imm.Output), "\n"); !strings.Contains(output, "Hero") || strings.Contains(output, "Imm") {
+		t.Fatalf("who level output = %q", output)
+	}
+	Dispatch(world, imm, "who level 60")
+	if output := strings.Join(drainOutput(imm.Output), "\n"); !strings.Contains(output, "No adventurers match that filter.") {
+		t.Fatalf("who level 60 output = %q", output)
+	}
+	Dispatch(world, imm, "who wizards")
+	if output := strings.Join(drainOutput(imm.Output), "\n"); !strings.Contains(output, "Unknown filter") {
+		t.Fatalf("who bad filter output = %q", output)
+	}
+}
@@ -9,10 +9,10 @@ var WizHelp = Define(Definition{
 	Group:       GroupAdmin,
 }, func(ctx *Context) bool {
 	if !ctx.Player.IsAdmin {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nOnly admins may view wizard commands.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly admins may view wizard commands.", game.AnsiYellow)))
 		return false
 	}
 	cmds := commandsForGroup(GroupAdmin)
-	ctx.Player.Output <- game.Ansi(helpMessage("Admin Commands:", cmds))
+	ctx.Player.Output.Send(game.Ansi(helpMessage("Admin Commands:", cmds)))
 	return false
 })
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var WizInvis = Define(Definition{
+	Name:        "wizinvis",
+	Usage:       "wizinvis [on|off]",
+	Description: "hide from players' who listings (staff only)",
+	Group:       GroupAdmin,
+}, func(ctx *Context) bool {
+	if !ctx.Player.IsStaff() {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nOnly staff may turn invisible.", game.AnsiYellow)))
+		return false
+	}
+	enabled := !ctx.Player.WizInvis
+	switch strings.ToLower(strings.TrimSpace(ctx.Arg)) {
+	case "":
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: wizinvis [on|off]", game.AnsiYellow)))
+		return false
+	}
+	if err := ctx.World.SetWizInvis(ctx.Player, enabled); err != nil {
+		msg := err.Error()
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+strings.ToUpper(msg[:1])+msg[1:]+".", game.AnsiYellow)))
+		return false
+	}
+	if enabled {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou fade from the who list. Only staff can see you there."))
+	} else {
+		ctx.Player.Output.Send(game.Ansi("\r\nYou are visible on the who list again."))
+	}
+	return false
+})
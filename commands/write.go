@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"LumenClay/internal/game"
+)
+
+var Write = Define(Definition{
+	Name:        "write",
+	Usage:       "write <item> <text>",
+	Description: "write a new page on a sign, letter, or book",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) < 2 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: write <item> <text>", game.AnsiYellow)))
+		return false
+	}
+	// The item is the longest run of leading words that names something
+	// writable; the rest is the text.
+	name, text := "", ""
+	for i := len(fields) - 1; i >= 1; i-- {
+		candidate := strings.Join(fields[:i], " ")
+		if item, err := ctx.World.FindReadable(ctx.Player, candidate); err == nil && item.Writing != nil {
+			name, text = candidate, strings.Join(fields[i:], " ")
+			break
+		}
+	}
+	if name == "" {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou have nothing like that to write on.", game.AnsiYellow)))
+		return false
+	}
+	item, page, err := ctx.World.WriteOnItem(ctx.Player, name, text)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\n"+err.Error(), game.AnsiYellow)))
+		return false
+	}
+	ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nYou write on page %d of %s.", page, game.HighlightItemName(item.Name))))
+	ctx.World.BroadcastToRoom(ctx.Player.Room, game.Ansi(fmt.Sprintf("\r\n%s writes something on %s.", game.HighlightName(ctx.Player.Name), game.HighlightItemName(item.Name))), ctx.Player)
+	return false
+})
+
+var Read = Define(Definition{
+	Name:        "read",
+	Usage:       "read <item> [page]",
+	Description: "read a page of a sign, letter, or book",
+}, func(ctx *Context) bool {
+	fields := strings.Fields(ctx.Arg)
+	if len(fields) == 0 {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nUsage: read <item> [page]", game.AnsiYellow)))
+		return false
+	}
+	page := 1
+	if len(fields) > 1 {
+		if n, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+			page = n
+			fields = fields[:len(fields)-1]
+		}
+	}
+	name := strings.Join(fields, " ")
+	item, err := ctx.World.FindReadable(ctx.Player, name)
+	if err != nil {
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou don't see that here.", game.AnsiYellow)))
+		return false
+	}
+	if item.Writing == nil || len(item.Writing.Pages) == 0 {
+		ctx.Player.Output.Send(game.Ansi(fmt.Sprintf("\r\nNothing is written on %s.", game.HighlightItemName(item.Name))))
+		return false
+	}
+	pages := item.Writing.Pages
+	if page < 1 || page > len(pages) {
+		ctx.Player.Output.Send(game.Ansi(game.Style(fmt.Sprintf("\r\n%s has %d written page(s).", item.Name, len(pages)), game.AnsiYellow)))
+		return false
+	}
+	width, _ := ctx.Player.WindowSize()
+	written := pages[page-1]
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\r\n%s, page %d of %d:", game.HighlightItemName(item.Name), page, len(pages)))
+	b.WriteString("\r\n" + game.WrapText(written.Text, width))
+	if written.Author != "" {
+		b.WriteString("\r\n" + game.Style("-- "+written.Author, game.AnsiDim))
+	}
+	if page < len(pages) {
+		b.WriteString("\r\n" + game.Style(fmt.Sprintf("Type 'read %s %d' for the next page.", name, page+1), game.AnsiDim))
+	}
+	ctx.Player.Output.Send(game.Ansi(b.String()))
+	return false
+})
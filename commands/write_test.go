@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"LumenClay/internal/game"
+)
+
+func TestWriteAndReadPages(t *testing.T) {
+	world := game.NewWorldWithRooms(map[game.RoomID]*game.Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]game.Exit{}, Items: []game.Item{
+			{Name: "Guest Book", Writing: &game.Writing{MaxPages: 5}},
+		}},
+	})
+	player := newTestPlayer("Hero", "start")
+	world.AddPlayerForTest(player)
+
+	Dispatch(world, player, "write guest book Hero was here.")
+	Dispatch(world, player, "write guest And here again.")
+	out := ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "You write on page 1 of Guest Book.") || !strings.Contains(out, "You write on page 2") {
+		t.Fatalf("expected two pages written, got %q", out)
+	}
+
+	Dispatch(world, player, "read guest book")
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "page 1 of 2") || !strings.Contains(out, "Hero was here.") || !strings.Contains(out, "read guest book 2") {
+		t.Fatalf("expected the first page and a hint, got %q", out)
+	}
+	Dispatch(world, player, "read guest book 2")
+	out = ansiPattern.ReplaceAllString(strings.Join(drainOutput(player.Output), ""), "")
+	if !strings.Contains(out, "And here again.") || !strings.Contains(out, "-- Hero") {
+		t.Fatalf("expected the second page, got %q", out)
+	}
+
+	Dispatch(world, player, "write lantern hello")
+	if out := strings.Join(drainOutput(player.Output), ""); !strings.Contains(out, "nothing like that to write on") {
+		t.Fatalf("expected a refusal, got %q", out)
+	}
+}
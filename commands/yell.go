@@ -13,17 +13,21 @@ var Yell = Define(Definition{
 }, func(ctx *Context) bool {
 	msg := ctx.Arg
 	if msg == "" {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYell what?", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYell what?", game.AnsiYellow)))
 		return false
 	}
 	if ctx.World.ChannelMuted(ctx.Player, game.ChannelYell) {
-		ctx.Player.Output <- game.Ansi(game.Style("\r\nYou are muted on YELL.", game.AnsiYellow))
+		ctx.Player.Output.Send(game.Ansi(game.Style("\r\nYou are muted on YELL.", game.AnsiYellow)))
+		return false
+	}
+	if channelSlowed(ctx, game.ChannelYell) {
 		return false
 	}
 	broadcast := game.Ansi(fmt.Sprintf("\r\n%s yells: %s", game.HighlightName(ctx.Player.Name), msg))
 	ctx.World.BroadcastToAllChannel(broadcast, ctx.Player, game.ChannelYell)
 	self := game.Ansi(fmt.Sprintf("\r\n%s %s", game.Style("You yell:", game.AnsiBold, game.AnsiYellow), msg))
-	ctx.Player.Output <- self
+	ctx.Player.Output.Send(self)
 	ctx.World.RecordPlayerChannelMessage(ctx.Player, game.ChannelYell, self)
+	ctx.World.RelayChannelMessage(game.ChannelYell, ctx.Player.Name, msg)
 	return false
 })
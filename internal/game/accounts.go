@@ -1,13 +1,17 @@
 package game
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/mail"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,11 +21,35 @@ import (
 
 const defaultAdminAccount = "admin"
 
+// MaxCharactersPerAccount limits how many characters one account may own.
+const MaxCharactersPerAccount = 5
+
+// DefaultPasswordResetTTL is how long an issued password reset token remains
+// valid.
+const DefaultPasswordResetTTL = time.Hour
+
+// ErrResetTokenInvalid is returned when a reset token is unknown, used, or
+// expired.
+var ErrResetTokenInvalid = errors.New("reset token is invalid or has expired")
+
 type accountRecord struct {
-	Password    string    `json:"password"`
-	CreatedAt   time.Time `json:"created_at,omitempty"`
-	LastLogin   time.Time `json:"last_login,omitempty"`
-	TotalLogins int       `json:"total_logins,omitempty"`
+	Password     string    `json:"password"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	LastLogin    time.Time `json:"last_login,omitempty"`
+	TotalLogins  int       `json:"total_logins,omitempty"`
+	LastLogout   time.Time `json:"last_logout,omitempty"`
+	Playtime     int64     `json:"playtime_seconds,omitempty"`
+	RestedXP     int       `json:"rested_xp,omitempty"`
+	RestedAt     time.Time `json:"rested_at,omitempty"`
+	Email        string    `json:"email,omitempty"`
+	ResetHash    string    `json:"reset_hash,omitempty"`
+	ResetExpires time.Time `json:"reset_expires,omitempty"`
+	Characters   []string  `json:"characters,omitempty"`
+	Bank         *Bank     `json:"bank,omitempty"`
+	BuilderAreas []string  `json:"builder_areas,omitempty"`
+	AreaScoped   bool      `json:"area_scoped,omitempty"`
+	ArenaWins    int       `json:"arena_wins,omitempty"`
+	ArenaLosses  int       `json:"arena_losses,omitempty"`
 }
 
 // AccountStats summarises persistent account metadata used for in-game displays.
@@ -29,6 +57,9 @@ type AccountStats struct {
 	CreatedAt   time.Time
 	LastLogin   time.Time
 	TotalLogins int
+	LastLogout  time.Time
+	Playtime    time.Duration
+	RestedXP    int
 }
 
 type AccountManager struct {
@@ -62,26 +93,85 @@ func (a *AccountManager) loadPlayerProfile(name string) (PlayerProfile, bool) {
 	if a.playersPath == "" {
 		return PlayerProfile{}, false
 	}
-	path := a.playerFilePath(name)
-	data, err := os.ReadFile(path)
+	data, err := documentStorage().Read(a.playerFilePath(name))
 	if err != nil {
 		return PlayerProfile{}, false
 	}
 	type playerRecord struct {
-		Room     RoomID            `json:"room,omitempty"`
-		Home     RoomID            `json:"home,omitempty"`
-		Channels map[string]bool   `json:"channels,omitempty"`
-		Aliases  map[string]string `json:"aliases,omitempty"`
+		Room       RoomID               `json:"room,omitempty"`
+		Home       RoomID               `json:"home,omitempty"`
+		Channels   map[string]bool      `json:"channels,omitempty"`
+		Aliases    map[string]string    `json:"aliases,omitempty"`
+		Inventory  []Item               `json:"inventory,omitempty"`
+		Gold       int                  `json:"gold,omitempty"`
+		Quests     []QuestProgress      `json:"quests,omitempty"`
+		Ignored    []string             `json:"ignored,omitempty"`
+		Friends    []string             `json:"friends,omitempty"`
+		Tutorial   TutorialStep         `json:"tutorial,omitempty"`
+		Prompt     string               `json:"prompt,omitempty"`
+		Combat     string               `json:"combat_prompt,omitempty"`
+		WizInvis   bool                 `json:"wizinvis,omitempty"`
+		SpeedOff   bool                 `json:"speedwalk_off,omitempty"`
+		PageLines  int                  `json:"page_lines,omitempty"`
+		Language   string               `json:"language,omitempty"`
+		Bio        string               `json:"bio,omitempty"`
+		BioFlag    string               `json:"bio_flag,omitempty"`
+		Kills      int                  `json:"kills,omitempty"`
+		Bounty     int                  `json:"bounty,omitempty"`
+		Explored   map[RoomID]bool      `json:"explored,omitempty"`
+		Waypoints  map[RoomID]bool      `json:"waypoints,omitempty"`
+		Reputation map[string]int       `json:"reputation,omitempty"`
+		CmdAliases map[string]string    `json:"command_aliases,omitempty"`
+		Achieved   map[string]time.Time `json:"achievements,omitempty"`
+		Title      string               `json:"title,omitempty"`
+		Race       string               `json:"race,omitempty"`
+		Class      string               `json:"class,omitempty"`
+		Level      int                  `json:"level,omitempty"`
+		Experience int                  `json:"experience,omitempty"`
+		Trained    Attributes           `json:"trained,omitempty"`
+		Points     int                  `json:"train_points,omitempty"`
+		Lockouts   map[string]time.Time `json:"lockouts,omitempty"`
+		Trades     map[string]int       `json:"professions,omitempty"`
 	}
 	var record playerRecord
 	if err := json.Unmarshal(data, &record); err != nil {
 		return PlayerProfile{}, false
 	}
 	profile := PlayerProfile{
-		Room:     record.Room,
-		Home:     record.Home,
-		Channels: decodeChannelSettings(record.Channels),
-		Aliases:  decodeChannelAliases(record.Aliases),
+		Room:       record.Room,
+		Home:       record.Home,
+		Channels:   decodeChannelSettings(record.Channels),
+		Aliases:    decodeChannelAliases(record.Aliases),
+		Inventory:  record.Inventory,
+		Gold:       record.Gold,
+		QuestLog:   decodeQuestLog(record.Quests),
+		Ignored:    record.Ignored,
+		Friends:    record.Friends,
+		Tutorial:   record.Tutorial,
+		Prompt:     record.Prompt,
+		Combat:     record.Combat,
+		WizInvis:   record.WizInvis,
+		SpeedOff:   record.SpeedOff,
+		PageLines:  record.PageLines,
+		Language:   record.Language,
+		Bio:        record.Bio,
+		BioFlag:    record.BioFlag,
+		Kills:      record.Kills,
+		Bounty:     record.Bounty,
+		Explored:   record.Explored,
+		Waypoints:  record.Waypoints,
+		Reputation: record.Reputation,
+		CmdAliases: record.CmdAliases,
+		Achieved:   record.Achieved,
+		Title:      record.Title,
+		Race:       record.Race,
+		Class:      record.Class,
+		Level:      record.Level,
+		Experience: record.Experience,
+		Trained:    record.Trained,
+		Points:     record.Points,
+		Lockouts:   record.Lockouts,
+		Trades:     record.Trades,
 	}
 	return profile, true
 }
@@ -90,40 +180,84 @@ func (a *AccountManager) savePlayerProfile(name string, profile PlayerProfile) e
 	if a.playersPath == "" {
 		return nil
 	}
-	if err := os.MkdirAll(a.playersPath, 0o755); err != nil {
-		return fmt.Errorf("create players directory: %w", err)
-	}
-	tmp, err := os.CreateTemp(a.playersPath, "player-*.tmp")
-	if err != nil {
-		return fmt.Errorf("create temp player file: %w", err)
-	}
 	type playerRecord struct {
-		Room     RoomID            `json:"room,omitempty"`
-		Home     RoomID            `json:"home,omitempty"`
-		Channels map[string]bool   `json:"channels,omitempty"`
-		Aliases  map[string]string `json:"aliases,omitempty"`
+		Room       RoomID               `json:"room,omitempty"`
+		Home       RoomID               `json:"home,omitempty"`
+		Channels   map[string]bool      `json:"channels,omitempty"`
+		Aliases    map[string]string    `json:"aliases,omitempty"`
+		Inventory  []Item               `json:"inventory,omitempty"`
+		Gold       int                  `json:"gold,omitempty"`
+		Quests     []QuestProgress      `json:"quests,omitempty"`
+		Ignored    []string             `json:"ignored,omitempty"`
+		Friends    []string             `json:"friends,omitempty"`
+		Tutorial   TutorialStep         `json:"tutorial,omitempty"`
+		Prompt     string               `json:"prompt,omitempty"`
+		Combat     string               `json:"combat_prompt,omitempty"`
+		WizInvis   bool                 `json:"wizinvis,omitempty"`
+		SpeedOff   bool                 `json:"speedwalk_off,omitempty"`
+		PageLines  int                  `json:"page_lines,omitempty"`
+		Language   string               `json:"language,omitempty"`
+		Bio        string               `json:"bio,omitempty"`
+		BioFlag    string               `json:"bio_flag,omitempty"`
+		Kills      int                  `json:"kills,omitempty"`
+		Bounty     int                  `json:"bounty,omitempty"`
+		Explored   map[RoomID]bool      `json:"explored,omitempty"`
+		Waypoints  map[RoomID]bool      `json:"waypoints,omitempty"`
+		Reputation map[string]int       `json:"reputation,omitempty"`
+		CmdAliases map[string]string    `json:"command_aliases,omitempty"`
+		Achieved   map[string]time.Time `json:"achievements,omitempty"`
+		Title      string               `json:"title,omitempty"`
+		Race       string               `json:"race,omitempty"`
+		Class      string               `json:"class,omitempty"`
+		Level      int                  `json:"level,omitempty"`
+		Experience int                  `json:"experience,omitempty"`
+		Trained    Attributes           `json:"trained,omitempty"`
+		Points     int                  `json:"train_points,omitempty"`
+		Lockouts   map[string]time.Time `json:"lockouts,omitempty"`
+		Trades     map[string]int       `json:"professions,omitempty"`
 	}
 	record := playerRecord{
-		Room:     profile.Room,
-		Home:     profile.Home,
-		Channels: encodeChannelSettings(profile.Channels),
-		Aliases:  encodeChannelAliases(profile.Aliases),
-	}
-	enc := json.NewEncoder(tmp)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(record); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
-		return fmt.Errorf("write player file: %w", err)
-	}
-	if err := tmp.Close(); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("close temp player file: %w", err)
+		Room:       profile.Room,
+		Home:       profile.Home,
+		Channels:   encodeChannelSettings(profile.Channels),
+		Aliases:    encodeChannelAliases(profile.Aliases),
+		Inventory:  profile.Inventory,
+		Gold:       profile.Gold,
+		Quests:     encodeQuestLog(profile.QuestLog),
+		Ignored:    profile.Ignored,
+		Friends:    profile.Friends,
+		Tutorial:   profile.Tutorial,
+		Prompt:     profile.Prompt,
+		Combat:     profile.Combat,
+		WizInvis:   profile.WizInvis,
+		SpeedOff:   profile.SpeedOff,
+		PageLines:  profile.PageLines,
+		Language:   profile.Language,
+		Bio:        profile.Bio,
+		BioFlag:    profile.BioFlag,
+		Kills:      profile.Kills,
+		Bounty:     profile.Bounty,
+		Explored:   profile.Explored,
+		Waypoints:  profile.Waypoints,
+		Reputation: profile.Reputation,
+		CmdAliases: profile.CmdAliases,
+		Achieved:   profile.Achieved,
+		Title:      profile.Title,
+		Race:       profile.Race,
+		Class:      profile.Class,
+		Level:      profile.Level,
+		Experience: profile.Experience,
+		Trained:    profile.Trained,
+		Points:     profile.Points,
+		Lockouts:   profile.Lockouts,
+		Trades:     profile.Trades,
+	}
+	data, err := encodeDocument(record)
+	if err != nil {
+		return fmt.Errorf("encode player file: %w", err)
 	}
-	target := a.playerFilePath(name)
-	if err := os.Rename(tmp.Name(), target); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("replace player file: %w", err)
+	if err := writeLater(a.playerFilePath(name), data); err != nil {
+		return fmt.Errorf("write player file: %w", err)
 	}
 	return nil
 }
@@ -151,7 +285,7 @@ func (a *AccountManager) IsAdmin(name string) bool {
 func (a *AccountManager) load() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	data, err := os.ReadFile(a.path)
+	data, err := documentStorage().Read(a.path)
 	if errors.Is(err, os.ErrNotExist) {
 		a.accounts = make(map[string]accountRecord)
 		return nil
@@ -175,29 +309,13 @@ func (a *AccountManager) load() error {
 }
 
 func (a *AccountManager) saveLocked() error {
-	dir := filepath.Dir(a.path)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("create accounts directory: %w", err)
-	}
-	tmp, err := os.CreateTemp(dir, "accounts-*.tmp")
+	data, err := encodeDocument(a.accounts)
 	if err != nil {
-		return fmt.Errorf("create temp accounts file: %w", err)
+		return fmt.Errorf("encode accounts file: %w", err)
 	}
-	enc := json.NewEncoder(tmp)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(a.accounts); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
+	if err := documentStorage().Write(a.path, data); err != nil {
 		return fmt.Errorf("write accounts file: %w", err)
 	}
-	if err := tmp.Close(); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("close temp accounts file: %w", err)
-	}
-	if err := os.Rename(tmp.Name(), a.path); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("replace accounts file: %w", err)
-	}
 	return nil
 }
 
@@ -218,6 +336,9 @@ func (a *AccountManager) Register(name, pass string) error {
 	if _, ok := a.accounts[name]; ok {
 		return fmt.Errorf("account already exists")
 	}
+	if _, taken := a.characterOwnerLocked(name); taken {
+		return fmt.Errorf("that name is taken")
+	}
 	now := time.Now().UTC()
 	a.accounts[name] = accountRecord{
 		Password:    string(hashed),
@@ -249,6 +370,7 @@ func (a *AccountManager) Profile(name string) PlayerProfile {
 		Room:     StartRoom,
 		Home:     StartRoom,
 		Channels: defaultChannelSettings(),
+		Tutorial: tutorialSteps[0].Step,
 	}
 	if disk, found := a.loadPlayerProfile(name); found {
 		if disk.Room != "" {
@@ -263,17 +385,47 @@ func (a *AccountManager) Profile(name string) PlayerProfile {
 		if disk.Aliases != nil {
 			profile.Aliases = disk.Aliases
 		}
+		profile.Inventory = disk.Inventory
+		profile.Gold = disk.Gold
+		profile.QuestLog = disk.QuestLog
+		profile.Ignored = disk.Ignored
+		profile.Friends = disk.Friends
+		profile.Tutorial = disk.Tutorial
+		profile.Prompt = disk.Prompt
+		profile.Combat = disk.Combat
+		profile.WizInvis = disk.WizInvis
+		profile.SpeedOff = disk.SpeedOff
+		profile.PageLines = disk.PageLines
+		profile.Language = disk.Language
+		profile.Bio = disk.Bio
+		profile.BioFlag = disk.BioFlag
+		profile.Kills = disk.Kills
+		profile.Bounty = disk.Bounty
+		profile.Explored = disk.Explored
+		profile.Waypoints = disk.Waypoints
+		profile.Reputation = disk.Reputation
+		profile.CmdAliases = disk.CmdAliases
+		profile.Achieved = disk.Achieved
+		profile.Title = disk.Title
+		profile.Race = disk.Race
+		profile.Class = disk.Class
+		profile.Level = disk.Level
+		profile.Experience = disk.Experience
+		profile.Trained = disk.Trained
+		profile.Points = disk.Points
+		profile.Lockouts = disk.Lockouts
+		profile.Trades = disk.Trades
 	}
 	return profile
 }
 
-// SaveProfile persists the provided state for the named account.
+// SaveProfile persists the provided state for the named character.
 func (a *AccountManager) SaveProfile(name string, profile PlayerProfile) error {
 	a.mu.RLock()
-	_, ok := a.accounts[name]
+	_, ok := a.characterOwnerLocked(name)
 	a.mu.RUnlock()
 	if !ok {
-		return fmt.Errorf("account not found")
+		return fmt.Errorf("character not found")
 	}
 	if err := a.savePlayerProfile(name, profile); err != nil {
 		return err
@@ -298,6 +450,44 @@ func (a *AccountManager) RecordLogin(name string, when time.Time) error {
 	return a.saveLocked()
 }
 
+// ClaimRestedXP adds the rested bonus earned since the account last logged
+// out and hands the whole pool to the session starting at when. The pool is
+// returned to the account by RecordLogout.
+func (a *AccountManager) ClaimRestedXP(name string, when time.Time) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[name]
+	if !ok {
+		return 0, fmt.Errorf("account not found")
+	}
+	if !record.LastLogout.IsZero() && record.LastLogout.After(record.RestedAt) {
+		record.RestedXP = min(record.RestedXP+restedAccrual(when.Sub(record.LastLogout)), RestedXPCap)
+	}
+	record.RestedAt = when.UTC()
+	rested := record.RestedXP
+	record.RestedXP = 0
+	a.accounts[name] = record
+	return rested, a.saveLocked()
+}
+
+// RecordLogout adds a finished session's playtime to the account and banks
+// the rested bonus it did not spend.
+func (a *AccountManager) RecordLogout(name string, when time.Time, played time.Duration, rested int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[name]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+	record.LastLogout = when.UTC()
+	if played > 0 {
+		record.Playtime += int64(played / time.Second)
+	}
+	record.RestedXP = min(record.RestedXP+max(rested, 0), RestedXPCap)
+	a.accounts[name] = record
+	return a.saveLocked()
+}
+
 // Stats returns account metadata for display purposes.
 func (a *AccountManager) Stats(name string) (AccountStats, bool) {
 	a.mu.RLock()
@@ -310,9 +500,265 @@ func (a *AccountManager) Stats(name string) (AccountStats, bool) {
 		CreatedAt:   record.CreatedAt,
 		LastLogin:   record.LastLogin,
 		TotalLogins: record.TotalLogins,
+		LastLogout:  record.LastLogout,
+		Playtime:    time.Duration(record.Playtime) * time.Second,
+		RestedXP:    record.RestedXP,
 	}, true
 }
 
+// SetEmail binds an email address to the account. An empty address removes
+// the binding.
+func (a *AccountManager) SetEmail(name, email string) error {
+	email = strings.TrimSpace(email)
+	if email != "" {
+		parsed, err := mail.ParseAddress(email)
+		if err != nil || parsed.Name != "" {
+			return fmt.Errorf("invalid email address")
+		}
+		email = parsed.Address
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[name]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+	previous := record
+	record.Email = email
+	a.accounts[name] = record
+	if err := a.saveLocked(); err != nil {
+		a.accounts[name] = previous
+		return err
+	}
+	return nil
+}
+
+// Email returns the address bound to the account, if any.
+func (a *AccountManager) Email(name string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.accounts[name].Email
+}
+
+// BuilderAreas lists the areas the account's builders may edit.
+func (a *AccountManager) BuilderAreas(name string) []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]string(nil), a.accounts[name].BuilderAreas...)
+}
+
+// BuilderScoped reports whether the account's builders are limited to their
+// granted areas. An account is scoped from its first grant on, so revoking
+// every grant leaves its builders unable to edit rather than free to edit
+// anywhere.
+func (a *AccountManager) BuilderScoped(name string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	record := a.accounts[name]
+	return record.AreaScoped || len(record.BuilderAreas) > 0
+}
+
+// SetBuilderArea adds or removes an area grant and reports whether the
+// grants changed.
+func (a *AccountManager) SetBuilderArea(name, area string, granted bool) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[name]
+	if !ok {
+		return false, fmt.Errorf("account not found")
+	}
+	previous := record
+	areas := make([]string, 0, len(record.BuilderAreas)+1)
+	held := false
+	for _, existing := range record.BuilderAreas {
+		if strings.EqualFold(existing, area) {
+			held = true
+			if !granted {
+				continue
+			}
+		}
+		areas = append(areas, existing)
+	}
+	if held == granted {
+		return false, nil
+	}
+	if granted {
+		areas = append(areas, area)
+		sort.Strings(areas)
+	}
+	record.BuilderAreas = areas
+	record.AreaScoped = true
+	if len(areas) == 0 {
+		record.BuilderAreas = nil
+	}
+	a.accounts[name] = record
+	if err := a.saveLocked(); err != nil {
+		a.accounts[name] = previous
+		return false, err
+	}
+	return true, nil
+}
+
+// IssueResetToken creates a single-use password reset token for the account,
+// replacing any earlier token. Only a hash of the token is stored.
+func (a *AccountManager) IssueResetToken(name string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = DefaultPasswordResetTTL
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate reset token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[name]
+	if !ok {
+		return "", fmt.Errorf("account not found")
+	}
+	previous := record
+	record.ResetHash = hashResetToken(token)
+	record.ResetExpires = time.Now().UTC().Add(ttl)
+	a.accounts[name] = record
+	if err := a.saveLocked(); err != nil {
+		a.accounts[name] = previous
+		return "", err
+	}
+	return token, nil
+}
+
+// ResetPassword consumes a reset token and sets a new password, returning the
+// account name it belonged to.
+func (a *AccountManager) ResetPassword(token, pass string) (string, error) {
+	if err := validatePassword(pass); err != nil {
+		return "", err
+	}
+	name, ok := a.AccountForResetToken(token)
+	if !ok {
+		return "", ErrResetTokenInvalid
+	}
+	hash := hashResetToken(strings.TrimSpace(token))
+	hashed, err := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[name]
+	if !ok || record.ResetHash != hash || time.Now().After(record.ResetExpires) {
+		return "", ErrResetTokenInvalid
+	}
+	previous := record
+	record.ResetHash = ""
+	record.ResetExpires = time.Time{}
+	record.Password = string(hashed)
+	a.accounts[name] = record
+	if err := a.saveLocked(); err != nil {
+		a.accounts[name] = previous
+		return "", err
+	}
+	return name, nil
+}
+
+// AccountForResetToken reports which account an unexpired reset token
+// belongs to without consuming it.
+func (a *AccountManager) AccountForResetToken(token string) (string, bool) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", false
+	}
+	hash := hashResetToken(token)
+	now := time.Now()
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for name, record := range a.accounts {
+		if record.ResetHash == "" || now.After(record.ResetExpires) {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(record.ResetHash), []byte(hash)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Characters lists the characters owned by the account. Accounts that have
+// never created an extra character own a single character sharing their name.
+func (a *AccountManager) Characters(account string) []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	record, ok := a.accounts[account]
+	if !ok {
+		return nil
+	}
+	if len(record.Characters) == 0 {
+		return []string{account}
+	}
+	return append([]string(nil), record.Characters...)
+}
+
+// CharacterOwner returns the account that owns the named character.
+func (a *AccountManager) CharacterOwner(name string) (string, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.characterOwnerLocked(name)
+}
+
+func (a *AccountManager) characterOwnerLocked(name string) (string, bool) {
+	if _, ok := a.accounts[name]; ok {
+		return name, true
+	}
+	for account, record := range a.accounts {
+		if strings.EqualFold(account, name) {
+			return account, true
+		}
+		for _, character := range record.Characters {
+			if strings.EqualFold(character, name) {
+				return account, true
+			}
+		}
+	}
+	return "", false
+}
+
+// AddCharacter creates a new character owned by the account. Character names
+// share one namespace with account names.
+func (a *AccountManager) AddCharacter(account, name string) error {
+	name = strings.TrimSpace(name)
+	if err := validateUsername(name); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[account]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+	if _, taken := a.characterOwnerLocked(name); taken {
+		return fmt.Errorf("that name is taken")
+	}
+	previous := record
+	characters := append([]string(nil), record.Characters...)
+	if len(characters) == 0 {
+		characters = []string{account}
+	}
+	if len(characters) >= MaxCharactersPerAccount {
+		return fmt.Errorf("accounts may own at most %d characters", MaxCharactersPerAccount)
+	}
+	record.Characters = append(characters, name)
+	a.accounts[account] = record
+	if err := a.saveLocked(); err != nil {
+		a.accounts[account] = previous
+		return err
+	}
+	return nil
+}
+
 // MatchAccountName resolves the provided token to a registered account name using case-insensitive matching.
 func (a *AccountManager) MatchAccountName(token string) (string, bool) {
 	trimmed := strings.TrimSpace(token)
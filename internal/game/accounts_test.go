@@ -0,0 +1,127 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAccountPasswordResetTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	accounts, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("NewAccountManager error: %v", err)
+	}
+	if err := accounts.Register("Alice", "original"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	if err := accounts.SetEmail("Alice", "not an email"); err == nil {
+		t.Fatalf("expected invalid email to be rejected")
+	}
+	if err := accounts.SetEmail("Alice", " alice@example.com "); err != nil {
+		t.Fatalf("SetEmail error: %v", err)
+	}
+
+	token, err := accounts.IssueResetToken("Alice", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueResetToken error: %v", err)
+	}
+	if _, err := accounts.ResetPassword(token, "short"); err == nil {
+		t.Fatalf("expected weak password to be rejected")
+	}
+	if _, err := accounts.ResetPassword("bogus", "replacement"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("bogus token error = %v, want ErrResetTokenInvalid", err)
+	}
+
+	reloaded, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if email := reloaded.Email("Alice"); email != "alice@example.com" {
+		t.Fatalf("Email = %q, want alice@example.com", email)
+	}
+	name, err := reloaded.ResetPassword(token, "replacement")
+	if err != nil || name != "Alice" {
+		t.Fatalf("ResetPassword = %q, %v", name, err)
+	}
+	if !reloaded.Authenticate("Alice", "replacement") || reloaded.Authenticate("Alice", "original") {
+		t.Fatalf("expected the new password to replace the old one")
+	}
+	if _, err := reloaded.ResetPassword(token, "another-one"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("reused token error = %v, want ErrResetTokenInvalid", err)
+	}
+
+	expired, err := reloaded.IssueResetToken("Alice", time.Nanosecond)
+	if err != nil {
+		t.Fatalf("IssueResetToken error: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if _, ok := reloaded.AccountForResetToken(expired); ok {
+		t.Fatalf("expected expired token to be rejected")
+	}
+}
+
+func TestAccountCharactersHaveSeparateProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	accounts, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("NewAccountManager error: %v", err)
+	}
+	if err := accounts.Register("Alice", "password"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	if err := accounts.Register("Bob", "password"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	if got := accounts.Characters("Alice"); len(got) != 1 || got[0] != "Alice" {
+		t.Fatalf("legacy characters = %v, want [Alice]", got)
+	}
+	if err := accounts.AddCharacter("Alice", "Mallory"); err != nil {
+		t.Fatalf("AddCharacter error: %v", err)
+	}
+	if err := accounts.AddCharacter("Alice", "bob"); err == nil {
+		t.Fatalf("expected another account's name to be rejected")
+	}
+	if err := accounts.AddCharacter("Bob", "MALLORY"); err == nil {
+		t.Fatalf("expected an existing character name to be rejected")
+	}
+	if err := accounts.Register("Mallory", "password"); err == nil {
+		t.Fatalf("expected registering a character name as an account to fail")
+	}
+	if owner, ok := accounts.CharacterOwner("mallory"); !ok || owner != "Alice" {
+		t.Fatalf("CharacterOwner = %q, %v", owner, ok)
+	}
+
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{}},
+	})
+	world.AttachAccountManager(accounts)
+	alt, err := world.addCharacter("Alice", "Mallory", nil, false, accounts.Profile("Mallory"))
+	if err != nil {
+		t.Fatalf("addCharacter error: %v", err)
+	}
+	if alt.Account != "Alice" || alt.Character != "Mallory" {
+		t.Fatalf("unexpected identity: account %q character %q", alt.Account, alt.Character)
+	}
+	alt.Inventory = []Item{{Name: "Lockpick"}}
+	world.PersistPlayer(alt)
+
+	reloaded, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if got := reloaded.Characters("Alice"); len(got) != 2 || got[1] != "Mallory" {
+		t.Fatalf("reloaded characters = %v", got)
+	}
+	if inv := reloaded.Profile("Mallory").Inventory; len(inv) != 1 || inv[0].Name != "Lockpick" {
+		t.Fatalf("expected alt inventory to persist, got %#v", inv)
+	}
+	if inv := reloaded.Profile("Alice").Inventory; len(inv) != 0 {
+		t.Fatalf("main character should not share the alt's inventory, got %#v", inv)
+	}
+	account, characters, ok := world.LinkedCharacters("Mallory")
+	if !ok || account != "Alice" || len(characters) != 2 {
+		t.Fatalf("LinkedCharacters = %q, %v, %v", account, characters, ok)
+	}
+}
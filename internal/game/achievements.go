@@ -0,0 +1,284 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const achievementsFileName = "achievements.json"
+
+// MaxTitleLength bounds the titles shown after a player's name.
+const MaxTitleLength = 40
+
+// AchievementKind names the counter an achievement tracks.
+type AchievementKind string
+
+const (
+	// AchievementKills counts NPCs the player has defeated.
+	AchievementKills AchievementKind = "kills"
+	// AchievementRooms counts distinct rooms the player has entered.
+	AchievementRooms AchievementKind = "rooms"
+	// AchievementQuests counts distinct quests the player has completed.
+	AchievementQuests AchievementKind = "quests"
+	// AchievementLevel tracks the player's level.
+	AchievementLevel AchievementKind = "level"
+)
+
+// Achievement is a milestone unlocked once a counter reaches Count. Earning
+// it may grant a title the player can display.
+type Achievement struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Kind        AchievementKind `json:"kind"`
+	Count       int             `json:"count"`
+	Title       string          `json:"title,omitempty"`
+}
+
+type achievementFile struct {
+	Achievements []Achievement `json:"achievements"`
+}
+
+// AchievementStatus pairs an achievement with the player's progress.
+type AchievementStatus struct {
+	Achievement
+	Progress   int
+	Unlocked   bool
+	UnlockedAt time.Time
+}
+
+func achievementsPath(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), achievementsFileName)
+}
+
+func loadAchievements(areasPath string) ([]Achievement, error) {
+	path := achievementsPath(areasPath)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var parsed achievementFile
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse achievements: %w", err)
+	}
+	seen := make(map[string]bool, len(parsed.Achievements))
+	for i := range parsed.Achievements {
+		achievement := &parsed.Achievements[i]
+		if err := normalizeAchievement(achievement); err != nil {
+			return nil, fmt.Errorf("parse achievements: %w", err)
+		}
+		if seen[achievement.ID] {
+			return nil, fmt.Errorf("parse achievements: duplicate id %s", achievement.ID)
+		}
+		seen[achievement.ID] = true
+	}
+	return parsed.Achievements, nil
+}
+
+func normalizeAchievement(achievement *Achievement) error {
+	achievement.ID = strings.ToLower(strings.TrimSpace(achievement.ID))
+	achievement.Name = strings.TrimSpace(achievement.Name)
+	achievement.Title = strings.TrimSpace(achievement.Title)
+	if achievement.ID == "" {
+		return fmt.Errorf("achievement id must not be empty")
+	}
+	if achievement.Name == "" {
+		achievement.Name = achievement.ID
+	}
+	switch achievement.Kind {
+	case AchievementKills, AchievementRooms, AchievementQuests, AchievementLevel:
+	default:
+		return fmt.Errorf("achievement %s has unknown kind %q", achievement.ID, achievement.Kind)
+	}
+	if achievement.Count < 1 {
+		achievement.Count = 1
+	}
+	if len(achievement.Title) > MaxTitleLength {
+		return fmt.Errorf("achievement %s title is longer than %d characters", achievement.ID, MaxTitleLength)
+	}
+	return nil
+}
+
+// achievementProgressLocked reports p's counter for kind.
+func achievementProgressLocked(p *Player, kind AchievementKind) int {
+	switch kind {
+	case AchievementKills:
+		return p.Kills
+	case AchievementRooms:
+		return len(p.Explored)
+	case AchievementQuests:
+		completed := 0
+		for _, progress := range p.QuestLog {
+			if progress.everCompleted() {
+				completed++
+			}
+		}
+		return completed
+	case AchievementLevel:
+		return p.Level
+	}
+	return 0
+}
+
+// RecordKill counts an NPC defeat toward p's achievements.
+func (w *World) RecordKill(p *Player) {
+	w.mu.Lock()
+	p.Kills++
+	w.mu.Unlock()
+	w.CheckAchievements(p)
+}
+
+// RecordExploration marks p's current room as explored.
+func (w *World) RecordExploration(p *Player) {
+	w.mu.Lock()
+	if p.Explored[p.Room] {
+		w.mu.Unlock()
+		return
+	}
+	if p.Explored == nil {
+		p.Explored = make(map[RoomID]bool)
+	}
+	p.Explored[p.Room] = true
+	w.mu.Unlock()
+	w.CheckAchievements(p)
+}
+
+// CheckAchievements unlocks every achievement p now qualifies for,
+// announcing each one and saving the profile. It returns the new unlocks.
+func (w *World) CheckAchievements(p *Player) []Achievement {
+	w.mu.Lock()
+	var unlocked []Achievement
+	now := time.Now().UTC()
+	for _, achievement := range w.achievements {
+		if _, ok := p.Achievements[achievement.ID]; ok {
+			continue
+		}
+		if achievementProgressLocked(p, achievement.Kind) < achievement.Count {
+			continue
+		}
+		if p.Achievements == nil {
+			p.Achievements = make(map[string]time.Time)
+		}
+		p.Achievements[achievement.ID] = now
+		unlocked = append(unlocked, achievement)
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	for _, achievement := range unlocked {
+		Logger().Info("achievement unlocked", "player", p.Name, "achievement", achievement.ID)
+		if p.Output == nil {
+			continue
+		}
+		msg := fmt.Sprintf("\r\n%s %s", Style("[Achievement]", AnsiBold, AnsiMagenta), Style(achievement.Name, AnsiBold))
+		if achievement.Description != "" {
+			msg += " - " + achievement.Description
+		}
+		if achievement.Title != "" {
+			msg += fmt.Sprintf("\r\nYou may now use the title %q. Type 'title' to choose it.", achievement.Title)
+		}
+		p.Output.Send(Ansi(msg))
+	}
+	return unlocked
+}
+
+// Achievements lists every achievement with p's progress, in file order.
+func (w *World) Achievements(p *Player) []AchievementStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	statuses := make([]AchievementStatus, len(w.achievements))
+	for i, achievement := range w.achievements {
+		status := AchievementStatus{Achievement: achievement, Progress: achievementProgressLocked(p, achievement.Kind)}
+		status.UnlockedAt, status.Unlocked = p.Achievements[achievement.ID]
+		if status.Progress > achievement.Count {
+			status.Progress = achievement.Count
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// EarnedTitles lists the titles p has unlocked, sorted.
+func (w *World) EarnedTitles(p *Player) []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	seen := make(map[string]bool)
+	var titles []string
+	for _, achievement := range w.achievements {
+		if _, ok := p.Achievements[achievement.ID]; !ok || achievement.Title == "" || seen[achievement.Title] {
+			continue
+		}
+		seen[achievement.Title] = true
+		titles = append(titles, achievement.Title)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+// SetTitle displays one of p's earned titles after their name, or clears it
+// when title is empty.
+func (w *World) SetTitle(p *Player, title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title != "" {
+		match := ""
+		for _, earned := range w.EarnedTitles(p) {
+			if strings.EqualFold(earned, title) {
+				match = earned
+				break
+			}
+		}
+		if match == "" {
+			return "", fmt.Errorf("you have not earned the title %q", title)
+		}
+		title = match
+	}
+	w.mu.Lock()
+	p.Title = title
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return title, nil
+}
+
+// TitledName renders a player's name followed by their chosen title.
+func TitledName(name, title string) string {
+	if title == "" {
+		return HighlightName(name)
+	}
+	return HighlightName(name) + " " + title
+}
+
+// ReloadAchievements re-reads the achievements file. Progress and unlocks are
+// kept; players who already qualify for a new achievement earn it the next
+// time one of their counters changes.
+func (w *World) ReloadAchievements() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	achievements, err := loadAchievements(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	w.achievements = achievements
+	w.mu.Unlock()
+	return len(achievements), nil
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShippedAchievementsLoad(t *testing.T) {
+	achievements, err := loadAchievements("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadAchievements error: %v", err)
+	}
+	kinds := make(map[AchievementKind]bool)
+	for _, achievement := range achievements {
+		kinds[achievement.Kind] = true
+	}
+	for _, kind := range []AchievementKind{AchievementKills, AchievementRooms, AchievementQuests} {
+		if !kinds[kind] {
+			t.Fatalf("shipped achievements have no %s milestone", kind)
+		}
+	}
+}
+
+func TestAchievementsUnlockAndGrantTitles(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall":  {ID: "hall", Title: "Hall", Exits: map[string]Exit{"east": {To: "annex"}}},
+		"annex": {ID: "annex", Title: "Annex", Exits: map[string]Exit{"west": {To: "hall"}}},
+	})
+	world.achievements = []Achievement{
+		{ID: "first", Name: "First Blood", Kind: AchievementKills, Count: 1, Title: "the Bold"},
+		{ID: "rooms", Name: "Wayfarer", Kind: AchievementRooms, Count: 2, Title: "the Wayfarer"},
+		{ID: "quest", Name: "Helping Hand", Kind: AchievementQuests, Count: 1},
+	}
+	p := &Player{Name: "Hero", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if _, err := world.SetTitle(p, "the Bold"); err == nil {
+		t.Fatalf("SetTitle should refuse unearned titles")
+	}
+	world.RecordKill(p)
+	if got := stripAnsi(strings.Join(drainOutput(p.Output), "")); !strings.Contains(got, "[Achievement] First Blood") {
+		t.Fatalf("kill unlock output = %q", got)
+	}
+
+	world.RecordExploration(p)
+	world.RecordExploration(p)
+	if _, ok := p.Achievements["rooms"]; ok {
+		t.Fatalf("revisiting a room should not count twice")
+	}
+	p.Room = "annex"
+	world.RecordExploration(p)
+	if _, ok := p.Achievements["rooms"]; !ok {
+		t.Fatalf("exploring two rooms should unlock Wayfarer")
+	}
+
+	p.QuestLog = map[string]*QuestProgress{"errand": {QuestID: "errand", Completed: true}}
+	if unlocked := world.CheckAchievements(p); len(unlocked) != 1 || unlocked[0].ID != "quest" {
+		t.Fatalf("quest unlocks = %+v", unlocked)
+	}
+	if again := world.CheckAchievements(p); len(again) != 0 {
+		t.Fatalf("achievements should unlock once, got %+v", again)
+	}
+
+	if titles := world.EarnedTitles(p); len(titles) != 2 || titles[0] != "the Bold" {
+		t.Fatalf("EarnedTitles = %v", titles)
+	}
+	if title, err := world.SetTitle(p, "THE WAYFARER"); err != nil || p.Title != "the Wayfarer" || title != "the Wayfarer" {
+		t.Fatalf("SetTitle = %q, %v; title %q", title, err, p.Title)
+	}
+	if entries := world.WhoList(false, WhoFilter{}, p.JoinedAt); len(entries) != 1 || entries[0].Title != "the Wayfarer" {
+		t.Fatalf("who entries = %+v", entries)
+	}
+}
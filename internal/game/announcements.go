@@ -0,0 +1,327 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxAnnouncementLength caps the text of a single announcement.
+	MaxAnnouncementLength = 500
+	// MaxScheduledAnnouncements caps how many announcements may wait to be
+	// sent at once.
+	MaxScheduledAnnouncements = 20
+	// MinAnnouncementInterval is the shortest repeat interval a recurring
+	// announcement may use.
+	MinAnnouncementInterval = time.Minute
+	// announcementTick is how often scheduled announcements are checked.
+	announcementTick = 10 * time.Second
+)
+
+// ErrAnnouncementNotFound indicates the scheduled announcement does not
+// exist.
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+// Announcement is a broadcast waiting to be sent. Recurring announcements
+// repeat Every interval until Until, or until cancelled when Until is zero.
+type Announcement struct {
+	ID      int           `json:"id"`
+	Author  string        `json:"author"`
+	Message string        `json:"message"`
+	Created time.Time     `json:"created"`
+	Next    time.Time     `json:"next"`
+	Every   time.Duration `json:"every,omitempty"`
+	Until   time.Time     `json:"until,omitempty"`
+	Sent    int           `json:"sent,omitempty"`
+}
+
+// Recurring reports whether the announcement repeats.
+func (a Announcement) Recurring() bool {
+	return a.Every > 0
+}
+
+// Upcoming lists up to limit of the times the announcement will next be
+// sent.
+func (a Announcement) Upcoming(limit int) []time.Time {
+	var times []time.Time
+	for next := a.Next; len(times) < limit; next = next.Add(a.Every) {
+		if !a.Until.IsZero() && !next.Before(a.Until) {
+			break
+		}
+		times = append(times, next)
+		if !a.Recurring() {
+			break
+		}
+	}
+	return times
+}
+
+// following returns the next send time after now, or false once the
+// announcement is finished.
+func (a Announcement) following(now time.Time) (time.Time, bool) {
+	if !a.Recurring() {
+		return time.Time{}, false
+	}
+	next := a.Next.Add(a.Every)
+	if !next.After(now) {
+		// Skip the sends missed while the server was down rather than
+		// sending them all at once.
+		missed := now.Sub(next)/a.Every + 1
+		next = next.Add(missed * a.Every)
+	}
+	if !a.Until.IsZero() && !next.Before(a.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// AnnouncementText renders msg the way players see an announcement.
+func AnnouncementText(msg string) string {
+	return Ansi("\r\n" + Style("[Announcement] ", AnsiYellow, AnsiBold) + msg)
+}
+
+// NewAnnouncement checks an announcement's text and schedule. The first send
+// is delay after now, and it repeats every interval for the given span when
+// every is positive.
+func NewAnnouncement(author, message string, now time.Time, delay, every, span time.Duration) (Announcement, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return Announcement{}, fmt.Errorf("what do you want to announce?")
+	}
+	if len(message) > MaxAnnouncementLength {
+		return Announcement{}, fmt.Errorf("announcements are limited to %d characters", MaxAnnouncementLength)
+	}
+	if delay < 0 || every < 0 || span < 0 {
+		return Announcement{}, fmt.Errorf("times must not be negative")
+	}
+	if every > 0 && every < MinAnnouncementInterval {
+		return Announcement{}, fmt.Errorf("announcements may repeat at most every %s", formatTimerInterval(MinAnnouncementInterval))
+	}
+	if span > 0 && every == 0 {
+		return Announcement{}, fmt.Errorf("only recurring announcements can run for a set time")
+	}
+	a := Announcement{Author: author, Message: message, Created: now.UTC(), Next: now.Add(delay).UTC(), Every: every}
+	if span > 0 {
+		a.Until = a.Next.Add(span)
+	}
+	return a, nil
+}
+
+// AnnouncementSystem persists scheduled announcements so they survive a
+// restart.
+type AnnouncementSystem struct {
+	mu            sync.RWMutex
+	path          string
+	nextID        int
+	announcements []Announcement
+}
+
+type announcementFile struct {
+	NextID        int            `json:"next_id"`
+	Announcements []Announcement `json:"announcements"`
+}
+
+// NewAnnouncementSystem loads the announcements stored at path. When path is
+// empty the schedule is kept in memory only.
+func NewAnnouncementSystem(path string) (*AnnouncementSystem, error) {
+	announcements := &AnnouncementSystem{path: path, nextID: 1}
+	if strings.TrimSpace(path) == "" {
+		return announcements, nil
+	}
+	data, err := documentStorage().Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return announcements, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read announcements file: %w", err)
+	}
+	if len(data) == 0 {
+		return announcements, nil
+	}
+	var record announcementFile
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decode announcements file: %w", err)
+	}
+	announcements.announcements = record.Announcements
+	announcements.nextID = record.NextID
+	for _, a := range announcements.announcements {
+		if a.ID >= announcements.nextID {
+			announcements.nextID = a.ID + 1
+		}
+	}
+	return announcements, nil
+}
+
+// Schedule stores a and returns it with its ID assigned.
+func (s *AnnouncementSystem) Schedule(a Announcement) (Announcement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.announcements) >= MaxScheduledAnnouncements {
+		return Announcement{}, fmt.Errorf("%d announcements are already scheduled; cancel one first", len(s.announcements))
+	}
+	a.ID = s.nextID
+	s.nextID++
+	s.announcements = append(s.announcements, a)
+	if err := s.saveLocked(); err != nil {
+		s.announcements = s.announcements[:len(s.announcements)-1]
+		s.nextID--
+		return Announcement{}, err
+	}
+	return a, nil
+}
+
+// Cancel removes a scheduled announcement.
+func (s *AnnouncementSystem) Cancel(id int) (Announcement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, a := range s.announcements {
+		if a.ID != id {
+			continue
+		}
+		previous := s.announcements
+		s.announcements = append(append([]Announcement(nil), s.announcements[:i]...), s.announcements[i+1:]...)
+		if err := s.saveLocked(); err != nil {
+			s.announcements = previous
+			return Announcement{}, err
+		}
+		return a, nil
+	}
+	return Announcement{}, ErrAnnouncementNotFound
+}
+
+// Announcements lists the scheduled announcements, soonest first.
+func (s *AnnouncementSystem) Announcements() []Announcement {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := append([]Announcement{}, s.announcements...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Next.Before(out[j].Next) })
+	return out
+}
+
+// Due returns the messages of the announcements due at now, moving
+// recurring ones on to their next send and dropping finished ones.
+func (s *AnnouncementSystem) Due(now time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var messages []string
+	previous := append([]Announcement(nil), s.announcements...)
+	kept := s.announcements[:0:0]
+	for _, a := range s.announcements {
+		if now.Before(a.Next) {
+			kept = append(kept, a)
+			continue
+		}
+		messages = append(messages, a.Message)
+		a.Sent++
+		if next, ok := a.following(now); ok {
+			a.Next = next
+			kept = append(kept, a)
+		}
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	s.announcements = kept
+	if err := s.saveLocked(); err != nil {
+		s.announcements = previous
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (s *AnnouncementSystem) saveLocked() error {
+	if strings.TrimSpace(s.path) == "" {
+		return nil
+	}
+	data, err := encodeDocument(announcementFile{NextID: s.nextID, Announcements: s.announcements})
+	if err != nil {
+		return fmt.Errorf("encode announcements file: %w", err)
+	}
+	if err := documentStorage().Write(s.path, data); err != nil {
+		return fmt.Errorf("write announcements file: %w", err)
+	}
+	return nil
+}
+
+// AttachAnnouncementSystem connects the announcement schedule to the world.
+func (w *World) AttachAnnouncementSystem(announcements *AnnouncementSystem) {
+	w.mu.Lock()
+	w.announcements = announcements
+	w.mu.Unlock()
+}
+
+// AnnouncementSystem exposes the announcement schedule, when configured.
+func (w *World) AnnouncementSystem() *AnnouncementSystem {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.announcements
+}
+
+// Announce broadcasts msg to everyone online at once and returns how many
+// players heard it.
+func (w *World) Announce(msg string) int {
+	return w.BroadcastSystem(AnnouncementText(sanitizeInput(msg)))
+}
+
+// ScheduleAnnouncement stores a for later. One due now goes out at once.
+func (w *World) ScheduleAnnouncement(a Announcement) (Announcement, error) {
+	announcements := w.AnnouncementSystem()
+	if announcements == nil {
+		return Announcement{}, fmt.Errorf("scheduled announcements are unavailable")
+	}
+	a.Message = sanitizeInput(a.Message)
+	scheduled, err := announcements.Schedule(a)
+	if err != nil {
+		return Announcement{}, err
+	}
+	w.SendDueAnnouncements(time.Now())
+	return scheduled, nil
+}
+
+// CancelAnnouncement stops a scheduled announcement.
+func (w *World) CancelAnnouncement(id int) (Announcement, error) {
+	announcements := w.AnnouncementSystem()
+	if announcements == nil {
+		return Announcement{}, fmt.Errorf("scheduled announcements are unavailable")
+	}
+	return announcements.Cancel(id)
+}
+
+// SendDueAnnouncements broadcasts every announcement due at now.
+func (w *World) SendDueAnnouncements(now time.Time) {
+	announcements := w.AnnouncementSystem()
+	if announcements == nil {
+		return
+	}
+	messages, err := announcements.Due(now)
+	if err != nil {
+		Logger().Error("send announcements failed", "error", err)
+		return
+	}
+	for _, msg := range messages {
+		w.BroadcastSystem(AnnouncementText(msg))
+	}
+}
+
+// StartAnnouncementLoop sends scheduled announcements as they fall due until
+// stop is closed.
+func (w *World) StartAnnouncementLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(announcementTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.SendDueAnnouncements(now)
+			}
+		}
+	}()
+}
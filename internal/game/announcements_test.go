@@ -0,0 +1,90 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAnnouncementValidatesSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := NewAnnouncement("Root", "  ", now, 0, 0, 0); err == nil {
+		t.Fatalf("expected an empty announcement to be rejected")
+	}
+	if _, err := NewAnnouncement("Root", "Hi", now, 0, 30*time.Second, 0); err == nil {
+		t.Fatalf("expected too short an interval to be rejected")
+	}
+	if _, err := NewAnnouncement("Root", "Hi", now, 0, 0, time.Hour); err == nil {
+		t.Fatalf("expected a one-off announcement with a duration to be rejected")
+	}
+	a, err := NewAnnouncement("Root", "Restart soon", now, 5*time.Minute, 10*time.Minute, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAnnouncement: %v", err)
+	}
+	upcoming := a.Upcoming(10)
+	if len(upcoming) != 6 || !upcoming[0].Equal(now.Add(5*time.Minute)) || !upcoming[5].Equal(now.Add(55*time.Minute)) {
+		t.Fatalf("expected six sends from 12:05 to 12:55, got %v", upcoming)
+	}
+}
+
+func TestScheduledAnnouncementsRepeatAndSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "announcements.json")
+	announcements, err := NewAnnouncementSystem(path)
+	if err != nil {
+		t.Fatalf("NewAnnouncementSystem: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	world.AttachAnnouncementSystem(announcements)
+	player := &Player{Name: "Ava", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+
+	now := time.Now()
+	a, err := NewAnnouncement("Root", "Maintenance at the hour.", now, 0, 10*time.Minute, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAnnouncement: %v", err)
+	}
+	scheduled, err := world.ScheduleAnnouncement(a)
+	if err != nil || scheduled.ID != 1 {
+		t.Fatalf("ScheduleAnnouncement: %+v, %v", scheduled, err)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(player.Output), "")); !strings.Contains(out, "[Announcement] Maintenance at the hour.") {
+		t.Fatalf("expected the first send at once, got %q", out)
+	}
+	once, _ := NewAnnouncement("Root", "Later.", now, 2*time.Hour, 0, 0)
+	if _, err := world.ScheduleAnnouncement(once); err != nil {
+		t.Fatalf("ScheduleAnnouncement: %v", err)
+	}
+	if out := strings.Join(drainOutput(player.Output), ""); out != "" {
+		t.Fatalf("expected the delayed announcement to wait, got %q", out)
+	}
+
+	reloaded, err := NewAnnouncementSystem(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	pending := reloaded.Announcements()
+	if len(pending) != 2 || pending[0].Sent != 1 || !pending[0].Next.Equal(scheduled.Next.Add(10*time.Minute)) {
+		t.Fatalf("expected the schedule to survive a reload, got %+v", pending)
+	}
+	world.AttachAnnouncementSystem(reloaded)
+
+	// A restart that misses several sends catches up with one, not all.
+	world.SendDueAnnouncements(now.Add(35 * time.Minute))
+	if out := strings.Count(strings.Join(drainOutput(player.Output), ""), "Maintenance"); out != 1 {
+		t.Fatalf("expected one catch-up send, got %d", out)
+	}
+	if next := reloaded.Announcements()[0]; !next.Next.Equal(scheduled.Next.Add(40 * time.Minute)) {
+		t.Fatalf("expected the next send at +40m, got %v", next.Next)
+	}
+	world.SendDueAnnouncements(now.Add(50 * time.Minute))
+	if pending := reloaded.Announcements(); len(pending) != 1 || pending[0].Message != "Later." {
+		t.Fatalf("expected the recurring announcement to finish, got %+v", pending)
+	}
+	if _, err := world.CancelAnnouncement(2); err != nil {
+		t.Fatalf("CancelAnnouncement: %v", err)
+	}
+	if _, err := world.CancelAnnouncement(2); err != ErrAnnouncementNotFound {
+		t.Fatalf("expected a second cancel to fail, got %v", err)
+	}
+}
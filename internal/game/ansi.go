@@ -16,6 +16,7 @@ const (
 	AnsiGreen     = "\x1b[32m"
 	AnsiMagenta   = "\x1b[35m"
 	AnsiBlue      = "\x1b[34m"
+	AnsiRed       = "\x1b[31m"
 )
 
 // Style wraps text with the provided ANSI attributes.
@@ -103,6 +104,49 @@ func Prompt(p *Player) string {
 	if p == nil {
 		return Ansi(Style("\r\n> ", AnsiBold, AnsiYellow))
 	}
-	summary := fmt.Sprintf("\r\n[L%02d HP %d/%d MP %d/%d] > ", p.Level, p.Health, p.MaxHealth, p.Mana, p.MaxMana)
+	if draft := p.roomDraft; draft != nil {
+		marker := ""
+		if draft.Dirty() {
+			marker = "*"
+		}
+		return Ansi(Style(fmt.Sprintf("\r\n[redit %s%s] > ", draft.Room, marker), AnsiBold, AnsiYellow))
+	}
+	if draft := p.bioDraft; draft != nil {
+		return Ansi(Style(fmt.Sprintf("\r\n[bio %d/%d] > ", len(draft.Lines), MaxBioLines), AnsiBold, AnsiYellow))
+	}
+	if p.world != nil {
+		if custom, ok := p.world.renderPrompt(p); ok {
+			return Ansi(Style("\r\n"+custom+" ", AnsiBold, AnsiYellow))
+		}
+	}
+	summary := fmt.Sprintf("\r\n[L%02d HP %d/%d MP %d/%d MV %d/%d] > ", p.Level, p.Health, p.MaxHealth, p.Mana, p.MaxMana, p.Moves, p.MaxMoves)
 	return Ansi(Style(summary, AnsiBold, AnsiYellow))
 }
+
+// plainText removes ANSI colour codes and OSC 8 hyperlinks from s, leaving
+// the visible text.
+func plainText(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\x1b' || i+1 >= len(s) {
+			out.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '[':
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+		case ']':
+			end := strings.Index(s[i:], "\x1b\\")
+			if end < 0 {
+				return out.String()
+			}
+			i += end + 1
+		default:
+			i++
+		}
+	}
+	return out.String()
+}
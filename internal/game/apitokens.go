@@ -0,0 +1,242 @@
+package game
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiTokenIDBytes     = 4
+	apiTokenSecretBytes = 24
+	apiTokenPrefix      = "lc_"
+	// apiTokenUseSaveInterval is how stale a token's saved LastUsed may grow
+	// before Authenticate writes it out. Newer uses wait in memory for Flush.
+	apiTokenUseSaveInterval = 15 * time.Minute
+)
+
+// ErrAPITokenNotFound indicates the requested API token does not exist.
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// APIToken describes a long-lived credential for the REST API. The secret is
+// only revealed when the token is issued; the store keeps a hash.
+type APIToken struct {
+	ID        string    `json:"id"`
+	Label     string    `json:"label"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	LastUsed  time.Time `json:"last_used,omitempty"`
+	Hash      string    `json:"hash"`
+}
+
+// APITokenStore persists API tokens issued by administrators.
+type APITokenStore struct {
+	mu     sync.Mutex
+	path   string
+	tokens map[string]APIToken
+	// dirty is set when a LastUsed update has not been written yet.
+	dirty bool
+}
+
+// NewAPITokenStore loads API tokens from the provided path. When path is empty
+// the store operates purely in-memory without persistence.
+func NewAPITokenStore(path string) (*APITokenStore, error) {
+	store := &APITokenStore{
+		path:   strings.TrimSpace(path),
+		tokens: make(map[string]APIToken),
+	}
+	if store.path == "" {
+		return store, nil
+	}
+	data, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read api tokens: %w", err)
+	}
+	if len(data) == 0 {
+		return store, nil
+	}
+	var file struct {
+		Tokens []APIToken `json:"tokens"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode api tokens: %w", err)
+	}
+	for _, token := range file.Tokens {
+		if token.ID == "" || token.Hash == "" {
+			continue
+		}
+		store.tokens[token.ID] = token
+	}
+	return store, nil
+}
+
+// Issue creates a new API token and returns its metadata along with the secret
+// that clients must present. The secret cannot be recovered later.
+func (s *APITokenStore) Issue(label, createdBy string) (APIToken, string, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return APIToken{}, "", fmt.Errorf("token label must not be empty")
+	}
+	id, err := randomToken(apiTokenIDBytes)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	secret, err := randomToken(apiTokenSecretBytes)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	full := apiTokenPrefix + id + "_" + secret
+	token := APIToken{
+		ID:        id,
+		Label:     label,
+		CreatedBy: strings.TrimSpace(createdBy),
+		CreatedAt: time.Now().UTC(),
+		Hash:      hashAPISecret(full),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[id] = token
+	if err := s.persistLocked(); err != nil {
+		delete(s.tokens, id)
+		return APIToken{}, "", err
+	}
+	return token, full, nil
+}
+
+// Revoke deletes the token with the provided identifier.
+func (s *APITokenStore) Revoke(id string) error {
+	id = strings.ToLower(strings.TrimSpace(id))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[id]
+	if !ok {
+		return ErrAPITokenNotFound
+	}
+	delete(s.tokens, id)
+	if err := s.persistLocked(); err != nil {
+		s.tokens[id] = token
+		return err
+	}
+	return nil
+}
+
+// List returns the issued tokens ordered by creation time.
+func (s *APITokenStore) List() []APIToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]APIToken, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		out = append(out, token)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out
+}
+
+// Authenticate validates a presented secret and records its use.
+func (s *APITokenStore) Authenticate(secret string) (APIToken, bool) {
+	secret = strings.TrimSpace(secret)
+	rest, ok := strings.CutPrefix(secret, apiTokenPrefix)
+	if !ok {
+		return APIToken{}, false
+	}
+	id, _, ok := strings.Cut(rest, "_")
+	if !ok || id == "" {
+		return APIToken{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[id]
+	if !ok {
+		return APIToken{}, false
+	}
+	if subtle.ConstantTimeCompare([]byte(token.Hash), []byte(hashAPISecret(secret))) != 1 {
+		return APIToken{}, false
+	}
+	previous := token.LastUsed
+	token.LastUsed = time.Now().UTC()
+	s.tokens[id] = token
+	if token.LastUsed.Sub(previous) < apiTokenUseSaveInterval {
+		s.dirty = true
+		return token, true
+	}
+	// Usage timestamps are informational, so a failed write should not reject
+	// an otherwise valid request.
+	_ = s.persistLocked()
+	return token, true
+}
+
+// Flush writes usage timestamps that Authenticate kept in memory.
+func (s *APITokenStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+	return s.persistLocked()
+}
+
+func hashAPISecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *APITokenStore) persistLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	tokens := make([]APIToken, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].ID < tokens[j].ID
+	})
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create api tokens directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "api-tokens-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp api tokens file: %w", err)
+	}
+	enc := json.NewEncoder(tmp)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(struct {
+		Tokens []APIToken `json:"tokens"`
+	}{Tokens: tokens}); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write api tokens file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close temp api tokens file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("secure api tokens file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("replace api tokens file: %w", err)
+	}
+	s.dirty = false
+	return nil
+}
@@ -0,0 +1,226 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrAreaNotGranted indicates a builder tried to change a room outside the
+// areas they were granted.
+var ErrAreaNotGranted = errors.New("area not granted")
+
+// builderAreaKey names the area of rooms that were created in-game rather
+// than loaded from an area file.
+const builderAreaKey = "builder"
+
+// AreaKey names the area held by an area file, such as "library" for
+// library.json.
+func AreaKey(file string) string {
+	return strings.ToLower(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+}
+
+// AreaInfo describes one area for listings.
+type AreaInfo struct {
+	Key   string
+	Name  string
+	Rooms int
+}
+
+// roomAreaLocked returns the area key the room belongs to.
+func (w *World) roomAreaLocked(id RoomID) string {
+	if room, ok := w.rooms[id]; ok && room.Area != "" {
+		return room.Area
+	}
+	source := w.roomSources[id]
+	if source == "" || source == builderAreaFile {
+		return builderAreaKey
+	}
+	return AreaKey(source)
+}
+
+// builderAccountLocked resolves the account that owns the named character.
+func (w *World) builderAccountLocked(name string) (string, bool) {
+	if p, ok := w.players[name]; ok && p.Account != "" {
+		return p.Account, true
+	}
+	if w.accounts == nil {
+		return "", false
+	}
+	return w.accounts.CharacterOwner(name)
+}
+
+// buildScopeLocked returns the areas editor may change. Editors who may
+// build anywhere are not scoped: server-side callers that pass no editor,
+// admins, and builders whose account has never been granted an area.
+func (w *World) buildScopeLocked(editor string) ([]string, bool) {
+	editor = strings.TrimSpace(editor)
+	if editor == "" || w.accounts == nil {
+		return nil, false
+	}
+	if p, ok := w.players[editor]; ok && p.IsAdmin {
+		return nil, false
+	}
+	account, ok := w.builderAccountLocked(editor)
+	if !ok || w.accounts.IsAdmin(account) {
+		return nil, false
+	}
+	return w.accounts.BuilderAreas(account), w.accounts.BuilderScoped(account)
+}
+
+// checkBuildScopeLocked reports whether editor may change every listed
+// room. Unknown rooms are skipped so callers report them in their own words.
+func (w *World) checkBuildScopeLocked(editor string, rooms ...RoomID) error {
+	areas, scoped := w.buildScopeLocked(editor)
+	if !scoped {
+		return nil
+	}
+	for _, id := range rooms {
+		if _, ok := w.rooms[id]; !ok {
+			continue
+		}
+		area := w.roomAreaLocked(id)
+		if !containsFold(areas, area) {
+			return fmt.Errorf("%w: %s is in area %s", ErrAreaNotGranted, id, area)
+		}
+	}
+	return nil
+}
+
+// newRoomAreaLocked picks the area for a room editor creates. Scoped
+// builders build into the granted area they stand in, or their first grant.
+func (w *World) newRoomAreaLocked(editor string) (string, error) {
+	areas, scoped := w.buildScopeLocked(editor)
+	if !scoped {
+		return "", nil
+	}
+	if len(areas) == 0 {
+		return "", fmt.Errorf("%w: %s holds no area grants", ErrAreaNotGranted, editor)
+	}
+	if p, ok := w.players[editor]; ok {
+		if area := w.roomAreaLocked(p.Room); containsFold(areas, area) {
+			return area, nil
+		}
+	}
+	return areas[0], nil
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Areas lists every area with rooms loaded, ordered by key.
+func (w *World) Areas() []AreaInfo {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.areasLocked()
+}
+
+func (w *World) areasLocked() []AreaInfo {
+	names := make(map[string]string, len(w.areaMeta))
+	for file, meta := range w.areaMeta {
+		key := AreaKey(file)
+		if file == builderAreaFile {
+			key = builderAreaKey
+		}
+		names[key] = meta.Name
+	}
+	counts := make(map[string]int)
+	for id := range w.rooms {
+		counts[w.roomAreaLocked(id)]++
+	}
+	infos := make([]AreaInfo, 0, len(counts))
+	for key, rooms := range counts {
+		name := names[key]
+		if name == "" {
+			name = key
+		}
+		infos = append(infos, AreaInfo{Key: key, Name: name, Rooms: rooms})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos
+}
+
+// GrantBuilderArea lets the named character's account edit rooms in area.
+// Once an account has held a grant, its builders may edit only granted
+// areas.
+// It returns the area's canonical key.
+func (w *World) GrantBuilderArea(name, area string) (string, error) {
+	return w.setBuilderArea(name, area, true)
+}
+
+// RevokeBuilderArea removes an area grant from the named character's
+// account. Revoking the last grant leaves the account's builders with no
+// area to edit.
+func (w *World) RevokeBuilderArea(name, area string) (string, error) {
+	return w.setBuilderArea(name, area, false)
+}
+
+func (w *World) setBuilderArea(name, area string, granted bool) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(area), "area:")))
+	if key == "" {
+		return "", fmt.Errorf("area must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.accounts == nil {
+		return "", fmt.Errorf("accounts are not available")
+	}
+	account, ok := w.builderAccountLocked(strings.TrimSpace(name))
+	if !ok {
+		return "", fmt.Errorf("no account for %s", strings.TrimSpace(name))
+	}
+	if granted {
+		known := false
+		for _, info := range w.areasLocked() {
+			if info.Key == key {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return "", fmt.Errorf("unknown area: %s", key)
+		}
+	}
+	changed, err := w.accounts.SetBuilderArea(account, key, granted)
+	if err != nil {
+		return "", err
+	}
+	if !changed {
+		if granted {
+			return key, fmt.Errorf("%s already holds area %s", name, key)
+		}
+		return key, fmt.Errorf("%s does not hold area %s", name, key)
+	}
+	return key, nil
+}
+
+// BuilderAreas lists the areas granted to the named character's account.
+func (w *World) BuilderAreas(name string) []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.accounts == nil {
+		return nil
+	}
+	account, ok := w.builderAccountLocked(strings.TrimSpace(name))
+	if !ok {
+		return nil
+	}
+	return w.accounts.BuilderAreas(account)
+}
+
+// BuilderScoped reports whether the named character's account is limited to
+// its granted areas.
+func (w *World) BuilderScoped(name string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	_, scoped := w.buildScopeLocked(strings.TrimSpace(name))
+	return scoped
+}
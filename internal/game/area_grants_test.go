@@ -0,0 +1,132 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuilderAreaGrantsScopeEdits(t *testing.T) {
+	dir := t.TempDir()
+	areasDir := filepath.Join(dir, "areas")
+	if err := os.MkdirAll(areasDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeSnapshotTestArea(t, areasDir)
+	library := `{"name":"Great Library","rooms":[{"id":"stacks","title":"Stacks","description":"","exits":{}}]}`
+	if err := os.WriteFile(filepath.Join(areasDir, "library.json"), []byte(library), 0o644); err != nil {
+		t.Fatalf("write area: %v", err)
+	}
+	world, err := NewWorld(areasDir)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	accounts, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("mason", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	builder := &Player{Name: "mason", Account: "mason", Room: "stacks", IsBuilder: true, Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(builder)
+
+	if _, err := world.UpdateRoomTitle("start", "Open Start", "mason"); err != nil {
+		t.Fatalf("builders without grants should edit anywhere: %v", err)
+	}
+	if _, err := world.GrantBuilderArea("mason", "area:atlantis"); err == nil {
+		t.Fatalf("expected an unknown area to be rejected")
+	}
+	if key, err := world.GrantBuilderArea("mason", "area:library"); err != nil || key != "library" {
+		t.Fatalf("GrantBuilderArea = %q, %v", key, err)
+	}
+	if _, err := world.UpdateRoomTitle("start", "Closed Start", "mason"); !errors.Is(err, ErrAreaNotGranted) {
+		t.Fatalf("expected ErrAreaNotGranted, got %v", err)
+	}
+	if _, err := world.UpdateRoomTitle("stacks", "Dusty Stacks", "mason"); err != nil {
+		t.Fatalf("edit granted room: %v", err)
+	}
+	if err := world.SetExit("stacks", "up", "start", "mason"); err != nil {
+		t.Fatalf("edited rooms should stay in their area: %v", err)
+	}
+	if err := world.LinkRooms("stacks", "north", "start", "south", "mason"); !errors.Is(err, ErrAreaNotGranted) {
+		t.Fatalf("expected linking into another area to fail, got %v", err)
+	}
+	if _, err := world.UpdateRoomTitle("start", "Admin Start", ""); err != nil {
+		t.Fatalf("server-side edits are not scoped: %v", err)
+	}
+	room, err := world.CreateRoom("annex", "Annex", "mason")
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if room.Area != "library" {
+		t.Fatalf("expected new room in the builder's area, got %q", room.Area)
+	}
+	if _, err := world.UpsertRoomNPC("annex", "Librarian", "", "mason"); err != nil {
+		t.Fatalf("edit created room: %v", err)
+	}
+
+	reloaded, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("reload accounts: %v", err)
+	}
+	if areas := reloaded.BuilderAreas("mason"); len(areas) != 1 || areas[0] != "library" {
+		t.Fatalf("expected grants to persist, got %v", areas)
+	}
+	if _, _, _, err := world.ImportROMAreaFile([]byte(sampleROMArea), "mason"); !errors.Is(err, ErrAreaNotGranted) {
+		t.Fatalf("expected area-limited builders to be refused imports, got %v", err)
+	}
+	if _, ok := world.GetRoom("rom3001"); ok {
+		t.Fatalf("a refused import should not load any rooms")
+	}
+}
+
+func TestRevokingLastAreaGrantNeverWidensAccess(t *testing.T) {
+	dir := t.TempDir()
+	areasDir := filepath.Join(dir, "areas")
+	if err := os.MkdirAll(areasDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeSnapshotTestArea(t, areasDir)
+	world, err := NewWorld(areasDir)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	accounts, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("mason", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	builder := &Player{Name: "mason", Account: "mason", Room: "start", IsBuilder: true, Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(builder)
+
+	area := world.Areas()[0].Key
+	if _, err := world.GrantBuilderArea("mason", "area:"+area); err != nil {
+		t.Fatalf("GrantBuilderArea: %v", err)
+	}
+	if _, err := world.RevokeBuilderArea("mason", "area:"+area); err != nil {
+		t.Fatalf("RevokeBuilderArea: %v", err)
+	}
+	if !world.BuilderScoped("mason") || len(world.BuilderAreas("mason")) != 0 {
+		t.Fatalf("expected a scoped builder with no grants, got %v", world.BuilderAreas("mason"))
+	}
+	if _, err := world.UpdateRoomTitle("start", "Free Start", "mason"); !errors.Is(err, ErrAreaNotGranted) {
+		t.Fatalf("revoking the last grant must not lift the scope, got %v", err)
+	}
+	if _, err := world.CreateRoom("annex", "Annex", "mason"); !errors.Is(err, ErrAreaNotGranted) {
+		t.Fatalf("expected room creation to be refused, got %v", err)
+	}
+
+	reloaded, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("reload accounts: %v", err)
+	}
+	if !reloaded.BuilderScoped("mason") {
+		t.Fatalf("expected the scope to persist after the last grant is revoked")
+	}
+}
@@ -0,0 +1,295 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrNoAreaTargets indicates an area spell found nobody it could strike.
+// No mana is spent.
+var ErrNoAreaTargets = errors.New("there is nothing there for your spell to strike")
+
+// AreaSpell strikes every foe in a room at once. Its damage is a single
+// pool split evenly among everyone it hits, so it is strongest against a
+// lone foe and thins out against a crowd.
+type AreaSpell struct {
+	Name     string
+	ManaCost int
+	// Damage and PerLevel set the pool: Damage plus PerLevel for each of
+	// the caster's levels.
+	Damage   int
+	PerLevel int
+	// Reach lets the spell be sent through an exit into the next room.
+	Reach bool
+	// Blast names what the spell looks like, such as "a fireball".
+	Blast string
+}
+
+var areaSpells = []AreaSpell{
+	{Name: "fireball", ManaCost: 25, Damage: 24, PerLevel: 6, Reach: true, Blast: "a fireball"},
+	{Name: "shockwave", ManaCost: 20, Damage: 30, PerLevel: 8, Blast: "a shockwave"},
+}
+
+// LookupAreaSpell returns the area spell called name.
+func LookupAreaSpell(name string) (AreaSpell, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, spell := range areaSpells {
+		if spell.Name == name {
+			return spell, true
+		}
+	}
+	return AreaSpell{}, false
+}
+
+// AreaSpellResult reports what an area spell struck.
+type AreaSpellResult struct {
+	Spell AreaSpell
+	Room  RoomID
+	// Direction is the exit the spell was sent through, or empty when it
+	// burst in the caster's room.
+	Direction string
+	// Share is the damage dealt to each target before it is capped by
+	// their remaining health.
+	Share   int
+	NPCs    []*NPCDamageResult
+	Players []*PlayerDamageResult
+	// Spared lists the players in the blast the spell passed over: the
+	// caster's group and anyone the caster may not fight.
+	Spared []*Player
+}
+
+// bystanderNPC reports whether an area spell passes over npc. Bankers,
+// trainers, healers, guards, dealers, mounts, and shopkeepers are never
+// caught in a blast.
+func bystanderNPC(npc NPC) bool {
+	return npc.Banker || npc.Trainer || npc.Healer != nil || npc.Guard || npc.Dealer || npc.Mount || len(npc.Shop) > 0 || len(npc.Rares) > 0
+}
+
+// areaFoeLocked reports whether an area spell cast by p may strike other.
+// Group members are always spared. Otherwise other must be someone p could
+// fight without committing a crime: a duel opponent in an arena, or a
+// player caught up in a bounty with p.
+func (w *World) areaFoeLocked(p, other *Player) bool {
+	if p.group != nil && other.group == p.group {
+		return false
+	}
+	if w.combatAllowedLocked(other.Room) != nil {
+		return false
+	}
+	if room, ok := w.rooms[other.Room]; ok && room.Arena && p.duel == other && other.duel == p {
+		return true
+	}
+	return other.Bounty > 0 || (p.Bounty > 0 && p.hunters[other.Name])
+}
+
+// splitAreaDamage divides pool evenly among targets, rounding up so every
+// target takes at least one point.
+func splitAreaDamage(pool, targets int) int {
+	if targets < 1 {
+		return 0
+	}
+	return max(1, (pool+targets-1)/targets)
+}
+
+// CastAreaSpell casts spell on every foe in p's room or, when direction is
+// set and the spell can reach, in the room through that exit. Survivors in
+// p's room turn on p; survivors next door charge through the exit.
+func (w *World) CastAreaSpell(p *Player, spell AreaSpell, direction string) (AreaSpellResult, error) {
+	direction = strings.TrimSpace(direction)
+	w.mu.Lock()
+	if !p.Alive {
+		w.mu.Unlock()
+		return AreaSpellResult{}, fmt.Errorf("you are in no condition to fight")
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		w.mu.Unlock()
+		return AreaSpellResult{}, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	target, dir := room, ""
+	if direction != "" {
+		if !spell.Reach {
+			w.mu.Unlock()
+			return AreaSpellResult{}, fmt.Errorf("%s cannot be sent through an exit", spell.Name)
+		}
+		matched, ok := matchExitLocked(room, direction)
+		if !ok || !exitVisibleLocked(p, room.ID, matched, room.Exits[matched]) {
+			w.mu.Unlock()
+			return AreaSpellResult{}, fmt.Errorf("you see no exit %s", direction)
+		}
+		exit := room.Exits[matched]
+		if exit.Closed {
+			w.mu.Unlock()
+			return AreaSpellResult{}, fmt.Errorf("the %s door is closed", matched)
+		}
+		dest, ok := w.rooms[exit.To]
+		if !ok {
+			w.mu.Unlock()
+			return AreaSpellResult{}, fmt.Errorf("you can't see anything %s", matched)
+		}
+		target, dir = dest, matched
+	}
+	if room.Peaceful || target.Peaceful {
+		w.mu.Unlock()
+		return AreaSpellResult{}, ErrPeaceful
+	}
+	p.EnsureStats()
+	if p.Mana < spell.ManaCost {
+		w.mu.Unlock()
+		return AreaSpellResult{}, fmt.Errorf("you lack the mana to cast %s", spell.Name)
+	}
+
+	var npcs []int
+	for i, npc := range target.NPCs {
+		if !bystanderNPC(npc) {
+			npcs = append(npcs, i)
+		}
+	}
+	result := AreaSpellResult{Spell: spell, Room: target.ID, Direction: dir}
+	var foes []*Player
+	for _, other := range w.players {
+		if other == p || !other.Alive || other.Room != target.ID {
+			continue
+		}
+		if w.areaFoeLocked(p, other) {
+			foes = append(foes, other)
+		} else {
+			result.Spared = append(result.Spared, other)
+		}
+	}
+	sort.Slice(foes, func(i, j int) bool { return foes[i].Name < foes[j].Name })
+	sort.Slice(result.Spared, func(i, j int) bool { return result.Spared[i].Name < result.Spared[j].Name })
+	if len(npcs)+len(foes) == 0 {
+		w.mu.Unlock()
+		return AreaSpellResult{}, ErrNoAreaTargets
+	}
+
+	p.Mana -= spell.ManaCost
+	result.Share = splitAreaDamage(spell.Damage+spell.PerLevel*p.Level, len(npcs)+len(foes))
+	// Strike from the back so defeated NPCs leaving the room do not shift
+	// the ones still waiting.
+	for i := len(npcs) - 1; i >= 0; i-- {
+		result.NPCs = append([]*NPCDamageResult{w.damageNPCLocked(target.ID, target, npcs[i], result.Share)}, result.NPCs...)
+	}
+	for _, foe := range foes {
+		if hit, err := w.damagePlayerInRoomLocked(p, target.ID, foe.Name, result.Share); err == nil {
+			result.Players = append(result.Players, hit)
+		}
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+
+	w.announceAreaSpell(p, room.ID, result)
+	for _, hit := range result.NPCs {
+		w.runBossPhases(result.Room, hit)
+	}
+	for _, hit := range result.Players {
+		w.bountyClaimed(p, hit)
+		w.warKillScored(p, hit)
+	}
+	w.provokeAreaSurvivors(p, result)
+	return result, nil
+}
+
+// announceAreaSpell tells the caster, their room, and the struck room what
+// the spell did, and rewards the caster for every NPC it defeated.
+func (w *World) announceAreaSpell(p *Player, casterRoom RoomID, result AreaSpellResult) {
+	blast := result.Spell.Blast
+	caster := HighlightName(p.Name)
+	if result.Direction == "" {
+		if p.Output != nil {
+			p.Output.Send(Ansi(fmt.Sprintf("\r\nYou unleash %s!", blast)))
+		}
+		w.BroadcastToRoom(casterRoom, Ansi(fmt.Sprintf("\r\n%s unleashes %s!", caster, blast)), p)
+	} else {
+		if p.Output != nil {
+			p.Output.Send(Ansi(fmt.Sprintf("\r\nYou send %s %s!", blast, result.Direction)))
+		}
+		w.BroadcastToRoom(casterRoom, Ansi(fmt.Sprintf("\r\n%s sends %s %s.", caster, blast, result.Direction)), p)
+		from := "afar"
+		w.mu.RLock()
+		if dest, ok := w.rooms[result.Room]; ok {
+			if back, ok := exitBackLocked(dest, casterRoom); ok {
+				from = "the " + back
+			}
+		}
+		w.mu.RUnlock()
+		w.BroadcastToRoom(result.Room, Ansi(fmt.Sprintf("\r\n%s flies in from %s and bursts!", strings.ToUpper(blast[:1])+blast[1:], from)), nil)
+	}
+
+	for _, hit := range result.NPCs {
+		name := HighlightNPCName(hit.NPC.Name)
+		if p.Output != nil {
+			p.Output.Send(Ansi(fmt.Sprintf("\r\nYour %s strikes %s for %d damage. (%d/%d HP)", result.Spell.Name, name, hit.Damage, hit.NPC.Health, hit.NPC.MaxHealth)))
+		}
+		w.BroadcastToRoom(result.Room, Ansi(fmt.Sprintf("\r\n%s is caught in the blast for %d damage.", name, hit.Damage)), p)
+		if hit.Defeated {
+			w.RewardNPCDefeat(p, result.Room, hit)
+		}
+	}
+	for _, hit := range result.Players {
+		name := HighlightName(hit.Target.Name)
+		w.BroadcastToRoom(hit.PreviousRoom, Ansi(fmt.Sprintf("\r\n%s is caught in the blast for %d damage.", name, hit.Damage)), p, hit.Target)
+		switch {
+		case hit.Duel != nil:
+			w.AnnounceDuelResult(*hit.Duel)
+		case hit.Defeated:
+			if p.Output != nil {
+				p.Output.Send(Ansi(fmt.Sprintf("\r\nYour %s fells %s!", result.Spell.Name, name)))
+			}
+			if hit.Target.Output != nil {
+				hit.Target.Output.Send(Ansi(fmt.Sprintf("\r\nYou have been defeated by %s!", caster)))
+				notifyDeathOutcome(hit.Target, hit.Death)
+				EnterRoom(w, hit.Target, "defeat")
+			}
+		default:
+			if p.Output != nil {
+				p.Output.Send(Ansi(fmt.Sprintf("\r\nYour %s strikes %s for %d damage. (%d/%d HP)", result.Spell.Name, name, hit.Damage, hit.Remaining, hit.Target.MaxHealth)))
+			}
+			if hit.Target.Output != nil {
+				hit.Target.Output.Send(Ansi(fmt.Sprintf("\r\n%s's %s strikes you for %d damage! (%d/%d HP)", caster, result.Spell.Name, hit.Damage, hit.Remaining, hit.Target.MaxHealth)))
+				hit.Target.Output.Send(Prompt(hit.Target))
+			}
+		}
+	}
+	for _, spared := range result.Spared {
+		if spared.Output != nil {
+			spared.Output.Send(Ansi(fmt.Sprintf("\r\n%s's %s washes harmlessly around you.", caster, result.Spell.Name)))
+		}
+	}
+}
+
+// provokeAreaSurvivors sets the NPCs that lived through an area spell on the
+// caster, adding the damage they took to their threat.
+func (w *World) provokeAreaSurvivors(p *Player, result AreaSpellResult) {
+	var survivors []*NPCDamageResult
+	for _, hit := range result.NPCs {
+		if !hit.Defeated {
+			survivors = append(survivors, hit)
+		}
+	}
+	if len(survivors) == 0 || !p.Alive {
+		return
+	}
+	if result.Direction != "" {
+		for _, hit := range survivors {
+			w.retaliate(result.Room, hit.NPC.Name, p)
+		}
+		return
+	}
+	combat := w.ensureCombat(result.Room)
+	for _, hit := range survivors {
+		combat.addNPC(hit.NPC.Name, combatTarget{kind: combatTargetPlayer, name: p.Name})
+		combat.addThreat(hit.NPC.Name, p.Name, hit.Damage)
+	}
+	combat.mu.Lock()
+	_, engaged := combat.playerTargets[p.Name]
+	combat.mu.Unlock()
+	if !engaged {
+		combat.addPlayer(p.Name, combatTarget{kind: combatTargetNPC, name: survivors[0].NPC.Name})
+	}
+	combat.startLoop()
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAreaSpellSplitsDamageThroughExit(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{"north": {To: "den"}, "east": {To: "shrine"}}},
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{"south": {To: "hall"}}, NPCs: []NPC{
+			{Name: "Rat", Health: 10, MaxHealth: 10, Level: 1},
+			{Name: "Rat", Health: 40, MaxHealth: 40, Level: 1},
+			{Name: "Trainer Olm", Trainer: true},
+		}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, Exits: map[string]Exit{"west": {To: "hall"}}, NPCs: []NPC{{Name: "Imp"}}},
+	})
+	caster := &Player{Name: "Caster", Room: "hall", Level: 1, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(caster)
+	caster.EnsureStats()
+	caster.MaxMana, caster.Mana = 100, 100
+	fireball, ok := LookupAreaSpell("Fireball")
+	if !ok {
+		t.Fatalf("expected fireball to be an area spell")
+	}
+	result, err := world.CastAreaSpell(caster, fireball, "n")
+	if err != nil {
+		t.Fatalf("CastAreaSpell: %v", err)
+	}
+	pool := fireball.Damage + fireball.PerLevel*caster.Level
+	if result.Direction != "north" || result.Share != (pool+1)/2 || len(result.NPCs) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if !result.NPCs[0].Defeated || result.NPCs[1].Defeated {
+		t.Fatalf("expected the weak rat to fall and the strong one to survive, got %+v and %+v", result.NPCs[0], result.NPCs[1])
+	}
+	if caster.Mana != 100-fireball.ManaCost {
+		t.Fatalf("mana = %d, want %d", caster.Mana, 100-fireball.ManaCost)
+	}
+	room, _ := world.GetRoom("den")
+	if len(room.NPCs) != 1 || room.NPCs[0].Name != "Trainer Olm" {
+		t.Fatalf("expected the trainer to be spared and the survivor to charge, got %+v", room.NPCs)
+	}
+	hall, _ := world.GetRoom("hall")
+	if len(hall.NPCs) != 1 || hall.NPCs[0].Name != "Rat" {
+		t.Fatalf("expected the surviving rat to charge into the hall, got %+v", hall.NPCs)
+	}
+}
+
+func TestAreaSpellSparesGroupAndBystanders(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{"north": {To: "den"}, "east": {To: "shrine"}}},
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{"south": {To: "hall"}}, NPCs: []NPC{
+			{Name: "Rat", Health: 10, MaxHealth: 10, Level: 1},
+			{Name: "Rat", Health: 40, MaxHealth: 40, Level: 1},
+			{Name: "Trainer Olm", Trainer: true},
+		}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, Exits: map[string]Exit{"west": {To: "hall"}}, NPCs: []NPC{{Name: "Imp"}}},
+	})
+	caster := &Player{Name: "Caster", Room: "hall", Level: 1, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(caster)
+	caster.EnsureStats()
+	caster.MaxMana, caster.Mana = 100, 100
+	ally := &Player{Name: "Ally", Room: "den", Output: NewOutputQueue(), Alive: true}
+	stranger := &Player{Name: "Stranger", Room: "den", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(ally)
+	world.AddPlayerForTest(stranger)
+	ally.EnsureStats()
+	stranger.EnsureStats()
+	if _, err := world.InviteToGroup(caster, "Ally"); err != nil {
+		t.Fatalf("InviteToGroup: %v", err)
+	}
+	if _, err := world.JoinGroup(ally, "Caster"); err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	health := stranger.Health
+
+	fireball, _ := LookupAreaSpell("fireball")
+	result, err := world.CastAreaSpell(caster, fireball, "north")
+	if err != nil {
+		t.Fatalf("CastAreaSpell: %v", err)
+	}
+	if len(result.Players) != 0 || len(result.Spared) != 2 {
+		t.Fatalf("expected both players to be spared, got %+v", result)
+	}
+	if stranger.Health != health || stranger.Bounty != 0 || caster.Bounty != 0 {
+		t.Fatalf("a bystander should be untouched and no crime committed")
+	}
+	if output := stripAnsi(strings.Join(drainOutput(ally.Output), "")); !strings.Contains(output, "washes harmlessly around you") {
+		t.Fatalf("expected the ally to be told the blast missed them, got %q", output)
+	}
+
+	stranger.Bounty = 50
+	ally.Room = "hall"
+	world.mu.Lock()
+	den := world.rooms["den"]
+	den.NPCs = []NPC{{Name: "Trainer Olm", Trainer: true}}
+	world.mu.Unlock()
+	result, err = world.CastAreaSpell(caster, fireball, "north")
+	if err != nil || len(result.Players) != 1 || result.Players[0].Target != stranger {
+		t.Fatalf("expected the wanted stranger to be caught in the blast, got %+v, %v", result, err)
+	}
+	if result.Share != fireball.Damage+fireball.PerLevel*caster.Level {
+		t.Fatalf("a lone target should take the whole pool, got %d", result.Share)
+	}
+}
+
+func TestAreaSpellRules(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{"north": {To: "den"}, "east": {To: "shrine"}}},
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{"south": {To: "hall"}}, NPCs: []NPC{
+			{Name: "Rat", Health: 10, MaxHealth: 10, Level: 1},
+			{Name: "Rat", Health: 40, MaxHealth: 40, Level: 1},
+			{Name: "Trainer Olm", Trainer: true},
+		}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, Exits: map[string]Exit{"west": {To: "hall"}}, NPCs: []NPC{{Name: "Imp"}}},
+	})
+	caster := &Player{Name: "Caster", Room: "hall", Level: 1, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(caster)
+	caster.EnsureStats()
+	caster.MaxMana, caster.Mana = 100, 100
+	shockwave, _ := LookupAreaSpell("shockwave")
+	if _, err := world.CastAreaSpell(caster, shockwave, "north"); err == nil {
+		t.Fatalf("expected shockwave to be unable to reach through an exit")
+	}
+	if _, err := world.CastAreaSpell(caster, shockwave, ""); !errors.Is(err, ErrNoAreaTargets) {
+		t.Fatalf("expected an empty room to have no targets, got %v", err)
+	}
+	fireball, _ := LookupAreaSpell("fireball")
+	if _, err := world.CastAreaSpell(caster, fireball, "east"); !errors.Is(err, ErrPeaceful) {
+		t.Fatalf("expected the peaceful shrine to refuse the spell, got %v", err)
+	}
+	if caster.Mana != 100 {
+		t.Fatalf("failed casts should cost nothing, mana = %d", caster.Mana)
+	}
+
+	caster.Room = "den"
+	result, err := world.CastAreaSpell(caster, shockwave, "")
+	if err != nil || len(result.NPCs) != 2 {
+		t.Fatalf("expected shockwave to hit both rats, got %+v, %v", result, err)
+	}
+	combat := world.combatIn("den")
+	if combat == nil || !combat.hasNPC("Rat") {
+		t.Fatalf("expected the surviving rat to fight the caster")
+	}
+	combat.stopLoop()
+}
@@ -0,0 +1,391 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DuelChallengeTimeout is how long a duel challenge stays open.
+const DuelChallengeTimeout = 2 * time.Minute
+
+var (
+	// ErrPeaceful indicates the room forbids all fighting.
+	ErrPeaceful = errors.New("a sense of calm keeps you from fighting here")
+	// ErrNotArena indicates players tried to fight each other outside an
+	// arena.
+	ErrNotArena = errors.New("players may only fight each other in an arena")
+	// ErrNoChallenge indicates nobody has an open challenge for the player.
+	ErrNoChallenge = errors.New("no one here has challenged you")
+	// ErrNoDuel indicates the other player has not agreed to a duel.
+	ErrNoDuel = errors.New("they have not agreed to duel you")
+)
+
+// duelChallenge is a challenge one player has issued in an arena. An open
+// challenge has no target and may be taken up by anyone in the room.
+type duelChallenge struct {
+	target *Player
+	room   RoomID
+	issued time.Time
+}
+
+// ArenaStanding is one account's arena record.
+type ArenaStanding struct {
+	Account string
+	Wins    int
+	Losses  int
+}
+
+// DuelOutcome reports a finished duel.
+type DuelOutcome struct {
+	Winner *Player
+	Loser  *Player
+	// Winner and loser records after the duel; zero without account
+	// storage.
+	WinnerRecord ArenaStanding
+	LoserRecord  ArenaStanding
+}
+
+// CombatAllowed reports whether fighting may happen in room.
+func (w *World) CombatAllowed(room RoomID) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.combatAllowedLocked(room)
+}
+
+func (w *World) combatAllowedLocked(room RoomID) error {
+	if r, ok := w.rooms[room]; ok && r.Peaceful {
+		return ErrPeaceful
+	}
+	return nil
+}
+
+// pvpAllowedLocked checks that attacker may hurt target: both must be
+// duelling each other in an arena, their clans must be at war, or one of
+// them must be wanted. Trying to
+// attack a player in a protected room anyway is a crime.
+func (w *World) pvpAllowedLocked(attacker, target *Player) error {
+	if err := w.combatAllowedLocked(target.Room); err != nil {
+		return err
+	}
+	if err := w.combatAllowedLocked(attacker.Room); err != nil {
+		return err
+	}
+	if w.outlawAllowedLocked(attacker, target) {
+		return nil
+	}
+	if w.clansAtWarLocked(attacker, target) {
+		return nil
+	}
+	var err error
+	room, ok := w.rooms[target.Room]
+	switch {
+	case !ok || !room.Arena:
+		err = ErrNotArena
+	case attacker.duel != target || target.duel != attacker:
+		err = fmt.Errorf("%w; type 'duel %s' to challenge %s", ErrNoDuel, target.Name, target.Name)
+	default:
+		return nil
+	}
+	if w.protectedLocked(attacker.Room) || w.protectedLocked(target.Room) {
+		return w.commitCrimeLocked(attacker, target)
+	}
+	return err
+}
+
+// SetRoomArena marks a room as an arena where players may duel, or clears
+// the mark. Arenas cannot also be peaceful.
+func (w *World) SetRoomArena(id RoomID, arena bool, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prevArena, prevPeaceful := room.Arena, room.Peaceful
+		room.Arena = arena
+		if arena {
+			room.Peaceful = false
+		}
+		return func() { room.Arena, room.Peaceful = prevArena, prevPeaceful }
+	})
+}
+
+// SetRoomPeaceful marks a room where nobody may fight, or clears the mark.
+func (w *World) SetRoomPeaceful(id RoomID, peaceful bool, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prevArena, prevPeaceful := room.Arena, room.Peaceful
+		room.Peaceful = peaceful
+		if peaceful {
+			room.Arena = false
+		}
+		return func() { room.Arena, room.Peaceful = prevArena, prevPeaceful }
+	})
+}
+
+// openChallengeLocked returns the challenge p has issued, dropping it once
+// it has expired or p has left the room.
+func openChallengeLocked(p *Player, now time.Time) *duelChallenge {
+	c := p.challenge
+	if c == nil {
+		return nil
+	}
+	if now.Sub(c.issued) >= DuelChallengeTimeout || p.Room != c.room {
+		p.challenge = nil
+		return nil
+	}
+	return c
+}
+
+// Challenge lets p challenge another player in the same arena to a duel, or
+// take up their challenge when they have already challenged p. An empty name
+// posts an open challenge anyone in the arena may accept. It returns the
+// opponent (nil for an open challenge) and whether the duel has begun.
+func (w *World) Challenge(p *Player, name string) (*Player, bool, error) {
+	now := time.Now()
+	w.mu.Lock()
+	room, ok := w.rooms[p.Room]
+	if !ok || !room.Arena {
+		w.mu.Unlock()
+		return nil, false, fmt.Errorf("you can only issue challenges in an arena")
+	}
+	if p.duel != nil {
+		opponent := p.duel.Name
+		w.mu.Unlock()
+		return nil, false, fmt.Errorf("you are already duelling %s", opponent)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		p.challenge = &duelChallenge{room: p.Room, issued: now}
+		w.mu.Unlock()
+		return nil, false, nil
+	}
+	other, ok := w.findPlayerLocked(name)
+	if !ok || other.Room != p.Room {
+		w.mu.Unlock()
+		return nil, false, fmt.Errorf("you don't see %s here", name)
+	}
+	if other == p {
+		w.mu.Unlock()
+		return nil, false, fmt.Errorf("you can't duel yourself")
+	}
+	if other.duel != nil {
+		w.mu.Unlock()
+		return nil, false, fmt.Errorf("%s is already duelling %s", other.Name, other.duel.Name)
+	}
+	if c := openChallengeLocked(other, now); c != nil && (c.target == p || c.target == nil) {
+		w.beginDuelLocked(p, other)
+		w.mu.Unlock()
+		w.announceDuelStart(other, p)
+		if err := w.StartCombat(p, other.Name); err != nil {
+			return other, true, err
+		}
+		return other, true, nil
+	}
+	p.challenge = &duelChallenge{target: other, room: p.Room, issued: now}
+	w.mu.Unlock()
+	return other, false, nil
+}
+
+// DeclineChallenge turns down the challenge issued by the named player, or
+// withdraws p's own challenge when name is empty. It returns the other
+// player, if any.
+func (w *World) DeclineChallenge(p *Player, name string) (*Player, error) {
+	now := time.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	name = strings.TrimSpace(name)
+	if name == "" {
+		c := openChallengeLocked(p, now)
+		if c == nil {
+			return nil, fmt.Errorf("you have not challenged anyone")
+		}
+		p.challenge = nil
+		return c.target, nil
+	}
+	other, ok := w.findPlayerLocked(name)
+	if !ok {
+		return nil, ErrNoChallenge
+	}
+	c := openChallengeLocked(other, now)
+	if c == nil || c.target != p {
+		return nil, ErrNoChallenge
+	}
+	other.challenge = nil
+	return other, nil
+}
+
+func (w *World) beginDuelLocked(a, b *Player) {
+	a.challenge, b.challenge = nil, nil
+	a.duel, b.duel = b, a
+}
+
+// endDuelLocked calls off p's duel, if any, and returns their opponent.
+func (w *World) endDuelLocked(p *Player) *Player {
+	opponent := p.duel
+	p.challenge = nil
+	if opponent == nil {
+		return nil
+	}
+	p.duel = nil
+	if opponent.duel == p {
+		opponent.duel = nil
+	}
+	return opponent
+}
+
+// settleDuelLocked ends a duel that loser has lost. The loser is restored
+// rather than killed, and both accounts' arena records are updated.
+func (w *World) settleDuelLocked(winner, loser *Player) DuelOutcome {
+	w.endDuelLocked(loser)
+	loser.EnsureStats()
+	loser.Health = loser.MaxHealth
+	outcome := DuelOutcome{Winner: winner, Loser: loser}
+	if w.accounts != nil && winner.Account != "" && loser.Account != "" && winner.Account != loser.Account {
+		outcome.WinnerRecord, outcome.LoserRecord, _ = w.accounts.RecordArenaResult(winner.Account, loser.Account)
+	}
+	return outcome
+}
+
+// arenaRoomsLocked lists every arena room.
+func (w *World) arenaRoomsLocked() []RoomID {
+	var rooms []RoomID
+	for id, room := range w.rooms {
+		if room.Arena {
+			rooms = append(rooms, id)
+		}
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i] < rooms[j] })
+	return rooms
+}
+
+// announceArena tells spectators in every arena room about a duel, leaving
+// out the duellists themselves.
+func (w *World) announceArena(msg string, except ...*Player) {
+	w.mu.RLock()
+	rooms := w.arenaRoomsLocked()
+	w.mu.RUnlock()
+	line := Ansi("\r\n" + Style("[Arena] ", AnsiYellow, AnsiBold) + msg)
+	for _, room := range rooms {
+		w.BroadcastToRoom(room, line, except...)
+	}
+}
+
+// AnnounceChallenge tells arena spectators about an open challenge.
+func (w *World) AnnounceChallenge(p *Player) {
+	w.announceArena(fmt.Sprintf("%s issues an open challenge! Type 'duel %s' in the arena to answer.", HighlightName(p.Name), p.Name), p)
+}
+
+func (w *World) announceDuelStart(a, b *Player) {
+	w.announceArena(fmt.Sprintf("%s and %s square off in the arena!", HighlightName(a.Name), HighlightName(b.Name)), a, b)
+}
+
+// AnnounceDuelResult tells both duellists and the arena spectators how a
+// duel ended.
+func (w *World) AnnounceDuelResult(outcome DuelOutcome) {
+	winner, loser := outcome.Winner, outcome.Loser
+	if winner.Output != nil {
+		winner.Output.Send(Ansi(fmt.Sprintf("\r\nYou win your duel against %s!", HighlightName(loser.Name))))
+	}
+	if loser.Output != nil {
+		loser.Output.Send(Ansi(fmt.Sprintf("\r\nYou yield to %s. The duel is over, and your wounds are tended.", HighlightName(winner.Name))))
+	}
+	msg := fmt.Sprintf("%s defeats %s in the arena!", HighlightName(winner.Name), HighlightName(loser.Name))
+	if outcome.WinnerRecord.Account != "" {
+		msg += fmt.Sprintf(" (%d-%d against %d-%d)", outcome.WinnerRecord.Wins, outcome.WinnerRecord.Losses,
+			outcome.LoserRecord.Wins, outcome.LoserRecord.Losses)
+	}
+	w.announceArena(msg, winner, loser)
+}
+
+// ArenaRecord returns the arena record of the account behind p.
+func (w *World) ArenaRecord(p *Player) (ArenaStanding, bool) {
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	if accounts == nil || p.Account == "" {
+		return ArenaStanding{}, false
+	}
+	return accounts.ArenaRecord(p.Account), true
+}
+
+// ArenaLadder lists the accounts with the most arena wins.
+func (w *World) ArenaLadder(limit int) []ArenaStanding {
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	if accounts == nil {
+		return nil
+	}
+	return accounts.ArenaLadder(limit)
+}
+
+// RecordArenaResult adds a win to one account and a loss to the other and
+// returns both records.
+func (a *AccountManager) RecordArenaResult(winner, loser string) (ArenaStanding, ArenaStanding, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	won, ok := a.accounts[winner]
+	if !ok {
+		return ArenaStanding{}, ArenaStanding{}, fmt.Errorf("account not found")
+	}
+	lost, ok := a.accounts[loser]
+	if !ok {
+		return ArenaStanding{}, ArenaStanding{}, fmt.Errorf("account not found")
+	}
+	prevWon, prevLost := won, lost
+	won.ArenaWins++
+	lost.ArenaLosses++
+	a.accounts[winner] = won
+	a.accounts[loser] = lost
+	if err := a.saveLocked(); err != nil {
+		a.accounts[winner] = prevWon
+		a.accounts[loser] = prevLost
+		return ArenaStanding{}, ArenaStanding{}, err
+	}
+	return ArenaStanding{Account: winner, Wins: won.ArenaWins, Losses: won.ArenaLosses},
+		ArenaStanding{Account: loser, Wins: lost.ArenaWins, Losses: lost.ArenaLosses}, nil
+}
+
+// ArenaRecord returns the account's arena wins and losses.
+func (a *AccountManager) ArenaRecord(name string) ArenaStanding {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	record := a.accounts[name]
+	return ArenaStanding{Account: name, Wins: record.ArenaWins, Losses: record.ArenaLosses}
+}
+
+// ArenaLadder lists up to limit accounts that have duelled, most wins
+// first.
+func (a *AccountManager) ArenaLadder(limit int) []ArenaStanding {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	var ladder []ArenaStanding
+	for name, record := range a.accounts {
+		if record.ArenaWins == 0 && record.ArenaLosses == 0 {
+			continue
+		}
+		ladder = append(ladder, ArenaStanding{Account: name, Wins: record.ArenaWins, Losses: record.ArenaLosses})
+	}
+	sort.Slice(ladder, func(i, j int) bool {
+		if ladder[i].Wins != ladder[j].Wins {
+			return ladder[i].Wins > ladder[j].Wins
+		}
+		if ladder[i].Losses != ladder[j].Losses {
+			return ladder[i].Losses < ladder[j].Losses
+		}
+		return ladder[i].Account < ladder[j].Account
+	})
+	if limit > 0 && len(ladder) > limit {
+		ladder = ladder[:limit]
+	}
+	return ladder
+}
+
+// arenaNote describes the room's combat rules, if any.
+func arenaNote(room *Room) string {
+	switch {
+	case room.Arena:
+		return "This is an arena. Type 'duel <player>' to issue a challenge."
+	case room.Peaceful:
+		return "A deep calm lies over this place; no one may fight here."
+	}
+	return ""
+}
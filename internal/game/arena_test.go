@@ -0,0 +1,169 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlayersNeedADuelToFight(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pit":    {ID: "pit", Title: "Pit", Arena: true, Exits: map[string]Exit{"s": {To: "stands"}}},
+		"stands": {ID: "stands", Title: "Stands", Exits: map[string]Exit{"n": {To: "pit"}}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, NPCs: []NPC{{Name: "Temple Rat"}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	for _, name := range []string{"alpha", "bravo"} {
+		if err := accounts.Register(name, "password"); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	world.AttachAccountManager(accounts)
+	alpha := &Player{Name: "Alpha", Account: "alpha", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 5}
+	bravo := &Player{Name: "Bravo", Account: "bravo", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 1}
+	world.AddPlayerForTest(alpha)
+	world.AddPlayerForTest(bravo)
+	if err := world.StartCombat(alpha, "bravo"); !errors.Is(err, ErrNoDuel) {
+		t.Fatalf("StartCombat without a duel = %v, want ErrNoDuel", err)
+	}
+	alpha.Room, bravo.Room = "stands", "stands"
+	if _, _, err := world.Challenge(alpha, "bravo"); err == nil {
+		t.Fatalf("expected challenges outside an arena to fail")
+	}
+	if err := world.StartCombat(alpha, "bravo"); !errors.Is(err, ErrNotArena) {
+		t.Fatalf("StartCombat outside an arena = %v, want ErrNotArena", err)
+	}
+}
+
+func TestDuelRecordsWinsAndSparesTheLoser(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pit":    {ID: "pit", Title: "Pit", Arena: true, Exits: map[string]Exit{"s": {To: "stands"}}},
+		"stands": {ID: "stands", Title: "Stands", Exits: map[string]Exit{"n": {To: "pit"}}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, NPCs: []NPC{{Name: "Temple Rat"}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	for _, name := range []string{"alpha", "bravo"} {
+		if err := accounts.Register(name, "password"); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	world.AttachAccountManager(accounts)
+	alpha := &Player{Name: "Alpha", Account: "alpha", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 5}
+	bravo := &Player{Name: "Bravo", Account: "bravo", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 1}
+	world.AddPlayerForTest(alpha)
+	world.AddPlayerForTest(bravo)
+	spectator := &Player{Name: "Fan", Room: "pit", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(spectator)
+
+	other, started, err := world.Challenge(alpha, "bravo")
+	if err != nil || started || other != bravo {
+		t.Fatalf("Challenge = %v, %v, %v", other, started, err)
+	}
+	if _, err := world.DeclineChallenge(bravo, "fan"); !errors.Is(err, ErrNoChallenge) {
+		t.Fatalf("declining a missing challenge = %v", err)
+	}
+	bravo.Health = 1
+	if _, started, err := world.Challenge(bravo, "alpha"); err != nil || !started {
+		t.Fatalf("accepting the challenge = %v, %v", started, err)
+	}
+	if bravo.duel != nil || alpha.duel != nil {
+		t.Fatalf("duel should be settled after the first round")
+	}
+	if !bravo.Alive || bravo.Room != "pit" || bravo.Health != bravo.MaxHealth {
+		t.Fatalf("loser should be restored in place, got room %s health %d/%d", bravo.Room, bravo.Health, bravo.MaxHealth)
+	}
+	if record, _ := world.ArenaRecord(alpha); record.Wins != 1 || record.Losses != 0 {
+		t.Fatalf("winner record = %+v", record)
+	}
+	if record, _ := world.ArenaRecord(bravo); record.Wins != 0 || record.Losses != 1 {
+		t.Fatalf("loser record = %+v", record)
+	}
+	if ladder := world.ArenaLadder(10); len(ladder) != 2 || ladder[0].Account != "alpha" {
+		t.Fatalf("unexpected ladder %+v", ladder)
+	}
+	output := stripAnsi(strings.Join(drainOutput(spectator.Output), ""))
+	if !strings.Contains(output, "[Arena] Alpha and Bravo square off") || !strings.Contains(output, "Alpha defeats Bravo in the arena! (1-0 against 0-1)") {
+		t.Fatalf("spectator missed the duel, got %q", output)
+	}
+	if err := world.StartCombat(alpha, "bravo"); !errors.Is(err, ErrNoDuel) {
+		t.Fatalf("a finished duel should not allow more fighting, got %v", err)
+	}
+}
+
+func TestLeavingTheArenaCallsOffTheDuel(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pit":    {ID: "pit", Title: "Pit", Arena: true, Exits: map[string]Exit{"s": {To: "stands"}}},
+		"stands": {ID: "stands", Title: "Stands", Exits: map[string]Exit{"n": {To: "pit"}}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, NPCs: []NPC{{Name: "Temple Rat"}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	for _, name := range []string{"alpha", "bravo"} {
+		if err := accounts.Register(name, "password"); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	world.AttachAccountManager(accounts)
+	alpha := &Player{Name: "Alpha", Account: "alpha", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 5}
+	bravo := &Player{Name: "Bravo", Account: "bravo", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 1}
+	world.AddPlayerForTest(alpha)
+	world.AddPlayerForTest(bravo)
+	world.beginDuelLocked(alpha, bravo)
+	if _, err := world.Move(alpha, "s"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if alpha.duel != nil || bravo.duel != nil {
+		t.Fatalf("duel should end when a duellist leaves")
+	}
+	if output := stripAnsi(strings.Join(drainOutput(bravo.Output), "")); !strings.Contains(output, "Alpha leaves the arena") {
+		t.Fatalf("expected bravo to be told, got %q", output)
+	}
+}
+
+func TestPeacefulRoomsForbidCombat(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pit":    {ID: "pit", Title: "Pit", Arena: true, Exits: map[string]Exit{"s": {To: "stands"}}},
+		"stands": {ID: "stands", Title: "Stands", Exits: map[string]Exit{"n": {To: "pit"}}},
+		"shrine": {ID: "shrine", Title: "Shrine", Peaceful: true, NPCs: []NPC{{Name: "Temple Rat"}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	for _, name := range []string{"alpha", "bravo"} {
+		if err := accounts.Register(name, "password"); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	world.AttachAccountManager(accounts)
+	alpha := &Player{Name: "Alpha", Account: "alpha", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 5}
+	bravo := &Player{Name: "Bravo", Account: "bravo", Room: "pit", Output: NewOutputQueue(), Alive: true, Level: 1}
+	world.AddPlayerForTest(alpha)
+	world.AddPlayerForTest(bravo)
+	alpha.Room = "shrine"
+	if err := world.StartCombat(alpha, "rat"); !errors.Is(err, ErrPeaceful) {
+		t.Fatalf("StartCombat in a peaceful room = %v", err)
+	}
+	if _, err := world.ApplyDamageToNPC("shrine", "rat", 5); !errors.Is(err, ErrPeaceful) {
+		t.Fatalf("ApplyDamageToNPC in a peaceful room = %v", err)
+	}
+	if _, err := world.ApplyDamageFromNPC("shrine", "Temple Rat", alpha, 5); !errors.Is(err, ErrPeaceful) {
+		t.Fatalf("ApplyDamageFromNPC in a peaceful room = %v", err)
+	}
+	if err := world.SetRoomArena("shrine", true, ""); err != nil {
+		t.Fatalf("SetRoomArena: %v", err)
+	}
+	room, _ := world.GetRoom("shrine")
+	if !room.Arena || room.Peaceful {
+		t.Fatalf("an arena cannot stay peaceful: %+v", room)
+	}
+}
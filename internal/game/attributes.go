@@ -0,0 +1,203 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// BaseAttribute is every attribute's value before race, class, and
+	// training.
+	BaseAttribute = 10
+	// MaxAttribute caps how high training can raise an attribute.
+	MaxAttribute = 25
+	// AttributePointsPerLevel is how many training points a level grants.
+	AttributePointsPerLevel = 2
+	// MaxDodgeChance caps the percentage of NPC attacks dexterity can avoid.
+	MaxDodgeChance = 30
+
+	// constitutionHealth is the maximum health each point of constitution
+	// adds.
+	constitutionHealth = 5
+	// intelligenceMana is the maximum mana each point of intelligence adds.
+	intelligenceMana = 5
+)
+
+// ErrNoTrainer indicates there is no trainer in the player's room.
+var ErrNoTrainer = errors.New("there is no trainer here")
+
+// Attribute names one of the core attributes.
+type Attribute string
+
+const (
+	// Strength adds melee damage and carry capacity.
+	Strength Attribute = "str"
+	// Dexterity adds the chance to dodge NPC attacks.
+	Dexterity Attribute = "dex"
+	// Constitution adds maximum health.
+	Constitution Attribute = "con"
+	// Intelligence adds maximum mana.
+	Intelligence Attribute = "int"
+)
+
+// AllAttributes lists the attributes in display order.
+func AllAttributes() []Attribute {
+	return []Attribute{Strength, Dexterity, Constitution, Intelligence}
+}
+
+// Label returns the attribute's full name.
+func (a Attribute) Label() string {
+	switch a {
+	case Strength:
+		return "Strength"
+	case Dexterity:
+		return "Dexterity"
+	case Constitution:
+		return "Constitution"
+	case Intelligence:
+		return "Intelligence"
+	}
+	return string(a)
+}
+
+// ParseAttribute accepts an attribute's short or full name.
+func ParseAttribute(name string) (Attribute, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", false
+	}
+	for _, attr := range AllAttributes() {
+		if name == string(attr) || strings.HasPrefix(strings.ToLower(attr.Label()), name) {
+			return attr, true
+		}
+	}
+	return "", false
+}
+
+// Attributes holds a value for each core attribute. The same shape records
+// base values, race and class modifiers, and points spent training.
+type Attributes struct {
+	Str int `json:"str,omitempty"`
+	Dex int `json:"dex,omitempty"`
+	Con int `json:"con,omitempty"`
+	Int int `json:"int,omitempty"`
+}
+
+func (a Attributes) plus(other Attributes) Attributes {
+	return Attributes{Str: a.Str + other.Str, Dex: a.Dex + other.Dex, Con: a.Con + other.Con, Int: a.Int + other.Int}
+}
+
+// Get returns the value of attr.
+func (a Attributes) Get(attr Attribute) int {
+	switch attr {
+	case Strength:
+		return a.Str
+	case Dexterity:
+		return a.Dex
+	case Constitution:
+		return a.Con
+	case Intelligence:
+		return a.Int
+	}
+	return 0
+}
+
+func (a *Attributes) add(attr Attribute, amount int) {
+	switch attr {
+	case Strength:
+		a.Str += amount
+	case Dexterity:
+		a.Dex += amount
+	case Constitution:
+		a.Con += amount
+	case Intelligence:
+		a.Int += amount
+	}
+}
+
+// Attributes returns p's effective attributes: the base, their race and
+// class modifiers, what they have trained, the affixes of items they carry,
+// and active effects.
+func (p *Player) Attributes() Attributes {
+	base := Attributes{Str: BaseAttribute, Dex: BaseAttribute, Con: BaseAttribute, Int: BaseAttribute}
+	return base.plus(p.bonus.Attributes).plus(p.Trained).plus(carriedBonus(p.Inventory).Attributes).plus(p.effectAttributes(time.Now()))
+}
+
+// attributeBonus converts an attribute into a modifier around the base.
+func attributeBonus(value, perPoint int) int {
+	return (value - BaseAttribute) * perPoint
+}
+
+// DodgeChance is the percentage of NPC attacks p avoids.
+func (p *Player) DodgeChance() int {
+	return min(max(attributeBonus(p.Attributes().Dex, 2), 0), MaxDodgeChance)
+}
+
+// CarryCapacity is how much weight p can carry.
+func (p *Player) CarryCapacity() int {
+	return max(100+attributeBonus(p.Attributes().Str, 10), 10)
+}
+
+// dodges rolls whether p avoids an incoming NPC attack.
+func (p *Player) dodges() bool {
+	chance := p.DodgeChance()
+	return chance > 0 && p.world.roll(100) < chance
+}
+
+// trainerInRoomLocked returns the first trainer NPC standing in the room.
+func (w *World) trainerInRoomLocked(room RoomID) (NPC, bool) {
+	r, ok := w.rooms[room]
+	if !ok {
+		return NPC{}, false
+	}
+	for _, npc := range r.NPCs {
+		if npc.Trainer {
+			return npc, true
+		}
+	}
+	return NPC{}, false
+}
+
+// TrainerHere returns the trainer NPC in the player's room, if any.
+func (w *World) TrainerHere(p *Player) (NPC, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.trainerInRoomLocked(p.Room)
+}
+
+// TrainAttribute spends one of p's training points to raise attr by one. A
+// trainer must be present. Health and mana pools grow at once when
+// constitution or intelligence rise. It returns the new value.
+func (w *World) TrainAttribute(p *Player, attr Attribute) (int, error) {
+	w.mu.Lock()
+	if _, ok := w.trainerInRoomLocked(p.Room); !ok {
+		w.mu.Unlock()
+		return 0, ErrNoTrainer
+	}
+	if p.TrainPoints < 1 {
+		w.mu.Unlock()
+		return 0, fmt.Errorf("you have no training points; gain a level to earn more")
+	}
+	value := p.Attributes().Get(attr)
+	if value >= MaxAttribute {
+		w.mu.Unlock()
+		return 0, fmt.Errorf("your %s cannot be trained past %d", strings.ToLower(attr.Label()), MaxAttribute)
+	}
+	p.EnsureStats()
+	p.Trained.add(attr, 1)
+	p.TrainPoints--
+	switch attr {
+	case Constitution:
+		p.MaxHealth += constitutionHealth
+		p.Health += constitutionHealth
+	case Intelligence:
+		p.MaxMana += intelligenceMana
+		p.Mana += intelligenceMana
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return value + 1, nil
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAttributesShapeStats(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	world.characterOptions = CharacterOptions{Races: []Archetype{{ID: "elf", Name: "Elf", Modifiers: StatModifiers{Attributes: Attributes{Dex: 5, Int: 2}}}}}
+	plain := &Player{Name: "Plain", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	strong := &Player{Name: "Strong", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Trained: Attributes{Str: 4, Con: 2}}
+	nimble := &Player{Name: "Nimble", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Race: "elf"}
+	for _, p := range []*Player{plain, strong, nimble} {
+		world.AddPlayerForTest(p)
+	}
+
+	if got, want := strong.AttackDamage(), plain.AttackDamage()+2; got != want {
+		t.Fatalf("damage with 14 str = %d, want %d", got, want)
+	}
+	if strong.MaxHealth != plain.MaxHealth+10 || strong.CarryCapacity() != plain.CarryCapacity()+40 {
+		t.Fatalf("strong health %d carry %d, plain %d %d", strong.MaxHealth, strong.CarryCapacity(), plain.MaxHealth, plain.CarryCapacity())
+	}
+	if nimble.MaxMana != plain.MaxMana+10 || nimble.Attributes().Dex != BaseAttribute+5 {
+		t.Fatalf("elf mana %d attributes %+v", nimble.MaxMana, nimble.Attributes())
+	}
+	if plain.DodgeChance() != 0 || nimble.DodgeChance() != 10 {
+		t.Fatalf("dodge chances = %d and %d", plain.DodgeChance(), nimble.DodgeChance())
+	}
+
+	world.SetDice(DiceFunc(func(int) int { return 9 }))
+	if plain.dodges() || !nimble.dodges() {
+		t.Fatalf("a roll of 9 should only be dodged with a 10%% chance")
+	}
+}
+
+func TestLevelsGrantTrainingPoints(t *testing.T) {
+	p := &Player{Name: "Climber"}
+	if levels := p.GainExperience(experienceForLevel(3)); levels != 2 || p.TrainPoints != 2*AttributePointsPerLevel {
+		t.Fatalf("gained %d levels and %d points", levels, p.TrainPoints)
+	}
+}
+
+func TestTrainAttributeNeedsTrainerAndPoints(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall": {ID: "hall", Exits: map[string]Exit{}},
+		"yard": {ID: "yard", NPCs: []NPC{{Name: "Drillmaster", Trainer: true}}, Exits: map[string]Exit{}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager error: %v", err)
+	}
+	if err := accounts.Register("Trainee", "password"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	p := &Player{Name: "Trainee", Account: "Trainee", Room: "hall", Output: NewOutputQueue(), Alive: true, TrainPoints: 1}
+	world.AddPlayerForTest(p)
+
+	if _, err := world.TrainAttribute(p, Constitution); err != ErrNoTrainer {
+		t.Fatalf("training without a trainer error = %v", err)
+	}
+	p.Room = "yard"
+	health := p.MaxHealth
+	if value, err := world.TrainAttribute(p, Constitution); err != nil || value != BaseAttribute+1 {
+		t.Fatalf("TrainAttribute = %d, %v", value, err)
+	}
+	if p.MaxHealth != health+constitutionHealth || p.TrainPoints != 0 {
+		t.Fatalf("after training health %d (was %d), points %d", p.MaxHealth, health, p.TrainPoints)
+	}
+	if _, err := world.TrainAttribute(p, Strength); err == nil {
+		t.Fatalf("training without points should fail")
+	}
+	p.TrainPoints = 1
+	p.Trained.Str = MaxAttribute - BaseAttribute
+	if _, err := world.TrainAttribute(p, Strength); err == nil {
+		t.Fatalf("training past the cap should fail")
+	}
+
+	saved := accounts.Profile("Trainee")
+	if saved.Trained.Con != 1 || saved.Points != 0 {
+		t.Fatalf("saved training = %+v, %d points", saved.Trained, saved.Points)
+	}
+}
+
+func TestLevelAndExperienceSurviveRelog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	accounts, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("NewAccountManager error: %v", err)
+	}
+	if err := accounts.Register("Climber", "password"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	world.AttachAccountManager(accounts)
+	p, err := world.addCharacter("Climber", "Climber", nil, false, accounts.Profile("Climber"))
+	if err != nil {
+		t.Fatalf("addCharacter error: %v", err)
+	}
+	p.GainExperience(experienceForLevel(3) + 10)
+	world.PersistPlayer(p)
+
+	reloaded, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	relog := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	relog.AttachAccountManager(reloaded)
+	back, err := relog.addCharacter("Climber", "Climber", nil, false, reloaded.Profile("Climber"))
+	if err != nil {
+		t.Fatalf("addCharacter after relog error: %v", err)
+	}
+	if back.Level != 3 || back.Experience != experienceForLevel(3)+10 || back.TrainPoints != 2*AttributePointsPerLevel {
+		t.Fatalf("after relog level %d, xp %d, points %d", back.Level, back.Experience, back.TrainPoints)
+	}
+	if levels := back.GainExperience(1); levels != 0 || back.TrainPoints != 2*AttributePointsPerLevel {
+		t.Fatalf("relogging should not pay out levels again, gained %d levels and has %d points", levels, back.TrainPoints)
+	}
+}
@@ -0,0 +1,315 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AuditMemoryLimit is how many of the newest audit entries are kept in
+	// memory for the audit command and portal viewer. The file keeps them all.
+	AuditMemoryLimit = 2000
+	// auditDiffLimit bounds how many lines each side of a change may have
+	// before the diff falls back to listing both versions whole.
+	auditDiffLimit = 2000
+)
+
+// Audit actions recorded by the server.
+const (
+	AuditCommand         = "command"
+	AuditConsole         = "console"
+	AuditRoomEdit        = "room edit"
+	AuditDocumentSave    = "document save"
+	AuditScriptSave      = "script save"
+	AuditReportResolve   = "report resolve"
+	AuditPetitionClaim   = "petition claim"
+	AuditPetitionReply   = "petition reply"
+	AuditPetitionResolve = "petition resolve"
+	AuditAPIKick         = "api kick"
+	AuditAPIBan          = "api ban"
+	AuditAPIBroadcast    = "api broadcast"
+)
+
+// AuditEntry records one staff action. Before and After hold the changed
+// text when the action edited something.
+type AuditEntry struct {
+	ID     int       `json:"id"`
+	Time   time.Time `json:"time"`
+	Actor  string    `json:"actor"`
+	Action string    `json:"action"`
+	Target string    `json:"target,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+	Before string    `json:"before,omitempty"`
+	After  string    `json:"after,omitempty"`
+}
+
+// AuditFilter narrows an audit listing. Empty fields match everything.
+type AuditFilter struct {
+	Actor  string
+	Action string
+	// Search matches the actor, action, target, or detail.
+	Search string
+	Limit  int
+}
+
+// AuditLog is an append-only trail of staff actions stored one JSON entry
+// per line.
+type AuditLog struct {
+	mu      sync.Mutex
+	path    string
+	nextID  int
+	entries []AuditEntry
+}
+
+// NewAuditLog loads the audit trail at path. An empty path keeps the trail
+// in memory only.
+func NewAuditLog(path string) (*AuditLog, error) {
+	log := &AuditLog{path: strings.TrimSpace(path), nextID: 1}
+	if log.path == "" {
+		return log, nil
+	}
+	data, err := os.ReadFile(log.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// A torn final line from a crash is skipped rather than
+			// refusing to start.
+			continue
+		}
+		log.appendLocked(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return log, nil
+}
+
+func (a *AuditLog) appendLocked(entry AuditEntry) {
+	if entry.ID >= a.nextID {
+		a.nextID = entry.ID + 1
+	}
+	a.entries = append(a.entries, entry)
+	if excess := len(a.entries) - AuditMemoryLimit; excess > 0 {
+		a.entries = append([]AuditEntry(nil), a.entries[excess:]...)
+	}
+}
+
+// Record appends entry to the trail, assigning its ID and time.
+func (a *AuditLog) Record(entry AuditEntry) (AuditEntry, error) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry.ID = a.nextID
+	a.appendLocked(entry)
+	if a.path == "" {
+		return entry, nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return entry, fmt.Errorf("encode audit entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return entry, fmt.Errorf("create directory: %w", err)
+	}
+	file, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return entry, fmt.Errorf("open audit log: %w", err)
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return entry, fmt.Errorf("write audit log: %w", err)
+	}
+	return entry, nil
+}
+
+func (f AuditFilter) matches(entry AuditEntry) bool {
+	if f.Actor != "" && !strings.EqualFold(entry.Actor, f.Actor) {
+		return false
+	}
+	if f.Action != "" && !strings.EqualFold(entry.Action, f.Action) {
+		return false
+	}
+	if search := strings.ToLower(strings.TrimSpace(f.Search)); search != "" {
+		for _, field := range []string{entry.Actor, entry.Action, entry.Target, entry.Detail} {
+			if strings.Contains(strings.ToLower(field), search) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Entries lists the retained entries matching filter, newest first.
+func (a *AuditLog) Entries(filter AuditFilter) []AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var out []AuditEntry
+	for i := len(a.entries) - 1; i >= 0; i-- {
+		if !filter.matches(a.entries[i]) {
+			continue
+		}
+		out = append(out, a.entries[i])
+		if filter.Limit > 0 && len(out) >= filter.Limit {
+			break
+		}
+	}
+	return out
+}
+
+// Entry returns the retained entry with id.
+func (a *AuditLog) Entry(id int) (AuditEntry, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, entry := range a.entries {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return AuditEntry{}, false
+}
+
+// Diff lists the lines removed from Before ("- ") and added in After ("+ "),
+// with unchanged lines ("  ") around each change for context.
+func (e AuditEntry) Diff() []string {
+	if e.Before == "" && e.After == "" {
+		return nil
+	}
+	return lineDiff(splitAuditLines(e.Before), splitAuditLines(e.After), 1)
+}
+
+func splitAuditLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// lineDiff compares two texts line by line using their longest common
+// subsequence and keeps context unchanged lines around each change.
+func lineDiff(before, after []string, context int) []string {
+	if len(before) > auditDiffLimit || len(after) > auditDiffLimit {
+		var out []string
+		for _, line := range before {
+			out = append(out, "- "+line)
+		}
+		for _, line := range after {
+			out = append(out, "+ "+line)
+		}
+		return out
+	}
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var lines []string
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			lines = append(lines, "  "+before[i])
+			i++
+			j++
+		case i < len(before) && (j == len(after) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+before[i])
+			i++
+		default:
+			lines = append(lines, "+ "+after[j])
+			j++
+		}
+	}
+	keep := make([]bool, len(lines))
+	for n, line := range lines {
+		if strings.HasPrefix(line, "  ") {
+			continue
+		}
+		for k := max(0, n-context); k <= min(len(lines)-1, n+context); k++ {
+			keep[k] = true
+		}
+	}
+	var out []string
+	skipped := false
+	for n, line := range lines {
+		if !keep[n] {
+			skipped = true
+			continue
+		}
+		if skipped && len(out) > 0 {
+			out = append(out, "  ...")
+		}
+		skipped = false
+		out = append(out, line)
+	}
+	return out
+}
+
+// AttachAuditLog connects the staff audit trail to the world.
+func (w *World) AttachAuditLog(log *AuditLog) {
+	w.mu.Lock()
+	w.audit = log
+	w.mu.Unlock()
+}
+
+// AuditLog returns the world's staff audit trail, or nil when none is
+// attached.
+func (w *World) AuditLog() *AuditLog {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.audit
+}
+
+// Audit records a staff action in the audit trail when one is attached.
+func (w *World) Audit(entry AuditEntry) {
+	w.mu.RLock()
+	log := w.audit
+	w.mu.RUnlock()
+	w.auditWith(log, entry)
+}
+
+// auditLocked records a staff action while w.mu is held.
+func (w *World) auditLocked(entry AuditEntry) {
+	w.auditWith(w.audit, entry)
+}
+
+func (w *World) auditWith(log *AuditLog, entry AuditEntry) {
+	if log == nil {
+		return
+	}
+	if _, err := log.Record(entry); err != nil {
+		Logger().Warn("failed to record audit entry", "actor", entry.Actor, "action", entry.Action, "error", err)
+	}
+}
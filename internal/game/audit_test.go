@@ -0,0 +1,108 @@
+package game
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogPersistsAndFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatalf("NewAuditLog error: %v", err)
+	}
+	if _, err := log.Record(AuditEntry{Actor: "Warden", Action: AuditCommand, Target: "summon", Detail: "summon Hero"}); err != nil {
+		t.Fatalf("Record error: %v", err)
+	}
+	if _, err := log.Record(AuditEntry{Actor: "Mason", Action: AuditRoomEdit, Target: "start", Before: "old", After: "new"}); err != nil {
+		t.Fatalf("Record error: %v", err)
+	}
+
+	reloaded, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	entry, err := reloaded.Record(AuditEntry{Actor: "Warden", Action: AuditCommand, Target: "ban", Detail: "ban Troll"})
+	if err != nil {
+		t.Fatalf("Record after reload error: %v", err)
+	}
+	if entry.ID != 3 {
+		t.Fatalf("ID after reload = %d, want 3", entry.ID)
+	}
+
+	all := reloaded.Entries(AuditFilter{})
+	if len(all) != 3 || all[0].ID != 3 || all[2].ID != 1 {
+		t.Fatalf("Entries = %+v, want three entries newest first", all)
+	}
+	if byActor := reloaded.Entries(AuditFilter{Actor: "warden"}); len(byActor) != 2 {
+		t.Fatalf("actor filter matched %d entries, want 2", len(byActor))
+	}
+	if search := reloaded.Entries(AuditFilter{Search: "hero"}); len(search) != 1 || search[0].Target != "summon" {
+		t.Fatalf("search matched %+v, want the summon", search)
+	}
+	if limited := reloaded.Entries(AuditFilter{Limit: 1}); len(limited) != 1 || limited[0].ID != 3 {
+		t.Fatalf("limited = %+v, want the newest entry", limited)
+	}
+	edit, ok := reloaded.Entry(2)
+	if !ok || edit.Before != "old" || edit.After != "new" {
+		t.Fatalf("Entry(2) = %+v, %v", edit, ok)
+	}
+}
+
+func TestAuditEntryDiff(t *testing.T) {
+	entry := AuditEntry{
+		Before: "one\ntwo\nthree\nfour\nfive\nsix",
+		After:  "one\ntwo\nTHREE\nfour\nfive\nsix\nseven",
+	}
+	want := []string{"  two", "- three", "+ THREE", "  four", "  ...", "  six", "+ seven"}
+	if got := entry.Diff(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %q, want %q", got, want)
+	}
+	if diff := (AuditEntry{}).Diff(); diff != nil {
+		t.Fatalf("empty entry diff = %q, want nil", diff)
+	}
+}
+
+func TestStaffEditsAreAudited(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Atrium", Description: "A bright hall.", Exits: map[string]Exit{}},
+	})
+	log, err := NewAuditLog("")
+	if err != nil {
+		t.Fatalf("NewAuditLog error: %v", err)
+	}
+	world.AttachAuditLog(log)
+
+	if _, err := world.UpdateRoomDescription("start", "A dim hall.", "Mason"); err != nil {
+		t.Fatalf("UpdateRoomDescription error: %v", err)
+	}
+	edits := log.Entries(AuditFilter{Action: AuditRoomEdit})
+	if len(edits) != 1 || edits[0].Actor != "Mason" || edits[0].Target != "start" {
+		t.Fatalf("room edits = %+v, want Mason's edit of start", edits)
+	}
+	if diff := strings.Join(edits[0].Diff(), "\n"); !strings.Contains(diff, "- A bright hall.") || !strings.Contains(diff, "+ A dim hall.") {
+		t.Fatalf("room edit diff = %q", diff)
+	}
+
+	portal := &PortalServer{world: world, documents: make(map[string]portalDocument)}
+	session := portalSession{Role: PortalRoleBuilder, Player: "Mason"}
+	doc, err := portal.saveDocument(session, "", "Plans", "Build a tower.", "note")
+	if err != nil {
+		t.Fatalf("saveDocument error: %v", err)
+	}
+	if _, err := portal.saveDocument(session, doc.ID, "Plans", "Build two towers.", "note"); err != nil {
+		t.Fatalf("saveDocument update error: %v", err)
+	}
+	if _, err := portal.saveDocument(session, "", "Greeter", "package main\n\nfunc main() {}\n", "script"); err != nil {
+		t.Fatalf("saveDocument script error: %v", err)
+	}
+	saves := log.Entries(AuditFilter{Action: AuditDocumentSave})
+	if len(saves) != 2 || saves[0].Before != "Build a tower." || saves[0].After != "Build two towers." {
+		t.Fatalf("document saves = %+v, want the creation and the edit", saves)
+	}
+	if scripts := log.Entries(AuditFilter{Action: AuditScriptSave}); len(scripts) != 1 || scripts[0].Detail != "Greeter" {
+		t.Fatalf("script saves = %+v, want the Greeter script", scripts)
+	}
+}
@@ -1,8 +1,11 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -12,6 +15,8 @@ const (
 		"╚══════════════════════════════════════╝"
 	loginTagline    = "Where imagination takes shape in radiant hues."
 	copyrightNotice = "All rights reserved, Copyright 2025 Carl Frank Otto III"
+
+	loginThrottledNotice = "Too many failed logins from your address. Please try again later."
 )
 
 func validateUsername(name string) error {
@@ -37,43 +42,76 @@ func validatePassword(password string) error {
 	return nil
 }
 
-func login(session *TelnetSession, accounts *AccountManager) (string, bool, error) {
+// login prompts for credentials. Failed passwords count against addr in the
+// throttle, which may be nil. Prompts switch to the account's language once
+// the username is known.
+func login(session *TelnetSession, world *World, accounts *AccountManager, throttle *LoginThrottle, addr string) (string, bool, error) {
+	lang := DefaultLanguage
+	text := func(key string, args ...any) string {
+		return world.TextIn(lang, key, args...)
+	}
 	_ = session.WriteString(Ansi("\r\n" + Style(loginBanner, AnsiCyan, AnsiBold) + "\r\n"))
 	_ = session.WriteString(Ansi(Style("\r\n"+loginTagline+"\r\n", AnsiGreen)))
 	_ = session.WriteString(Ansi(Style("\r\n"+copyrightNotice+"\r\n", AnsiBlue, AnsiDim)))
-	_ = session.WriteString(Ansi(Style("\r\nLogin required.\r\n", AnsiMagenta, AnsiBold)))
+	_ = session.WriteString(Ansi(Style("\r\n"+text("login.required")+"\r\n", AnsiMagenta, AnsiBold)))
+	_ = session.WriteString(Ansi(Style(text("login.reset_hint")+"\r\n", AnsiDim)))
 	for attempts := 0; attempts < 5; attempts++ {
-		_ = session.WriteString(Ansi("\r\nUsername: "))
+		lang = DefaultLanguage
+		_ = session.WriteString(Ansi("\r\n" + text("login.username")))
 		username, err := session.ReadLine()
 		if err != nil {
 			return "", false, err
 		}
 		username = Trim(username)
+		if token, ok := parseResetRequest(username); ok {
+			name, err := resetPasswordPrompt(session, accounts, token)
+			if err == nil {
+				throttle.Reset(addr)
+				return name, accounts.IsAdmin(name), nil
+			}
+			if !errors.Is(err, ErrResetTokenInvalid) {
+				return "", false, err
+			}
+			_ = session.WriteString(Ansi(Style("\r\n"+err.Error()+".", AnsiYellow)))
+			if throttle.Fail(addr, time.Now()) {
+				_ = session.WriteString(Ansi("\r\n" + loginThrottledNotice + "\r\n"))
+				return "", false, fmt.Errorf("login throttled")
+			}
+			continue
+		}
 		if err := validateUsername(username); err != nil {
 			_ = session.WriteString(Ansi(Style("\r\n"+err.Error(), AnsiYellow)))
 			continue
 		}
 		if accounts.Exists(username) {
+			if saved := accounts.Profile(username).Language; saved != "" {
+				lang = saved
+			}
 			for tries := 0; tries < 3; tries++ {
-				_ = session.WriteString(Ansi("\r\nPassword: "))
-				password, err := session.ReadLine()
+				_ = session.WriteString(Ansi("\r\n" + text("login.password")))
+				password, err := session.ReadPassword()
 				if err != nil {
 					return "", false, err
 				}
 				password = Trim(password)
 				if accounts.Authenticate(username, password) {
-					_ = session.WriteString(Ansi(Style("\r\nWelcome back, "+username+"!", AnsiGreen)))
+					throttle.Reset(addr)
+					_ = session.WriteString(Ansi(Style("\r\n"+text("login.welcome_back", username), AnsiGreen)))
 					return username, accounts.IsAdmin(username), nil
 				}
-				_ = session.WriteString(Ansi(Style("\r\nIncorrect password.", AnsiYellow)))
+				_ = session.WriteString(Ansi(Style("\r\n"+text("login.incorrect"), AnsiYellow)))
+				if throttle.Fail(addr, time.Now()) {
+					_ = session.WriteString(Ansi("\r\n" + loginThrottledNotice + "\r\n"))
+					return "", false, fmt.Errorf("login throttled")
+				}
 			}
-			_ = session.WriteString(Ansi("\r\nToo many failed attempts.\r\n"))
+			_ = session.WriteString(Ansi("\r\n" + text("login.too_many") + "\r\n"))
 			return "", false, fmt.Errorf("authentication failed")
 		}
 
 		for {
-			_ = session.WriteString(Ansi("\r\nSet a password: "))
-			password, err := session.ReadLine()
+			_ = session.WriteString(Ansi("\r\n" + text("login.set_password")))
+			password, err := session.ReadPassword()
 			if err != nil {
 				return "", false, err
 			}
@@ -86,10 +124,172 @@ func login(session *TelnetSession, accounts *AccountManager) (string, bool, erro
 				_ = session.WriteString(Ansi(Style("\r\n"+err.Error(), AnsiYellow)))
 				break
 			}
-			_ = session.WriteString(Ansi(Style("\r\nAccount created. Welcome, "+username+"!", AnsiGreen)))
+			_ = session.WriteString(Ansi(Style("\r\n"+text("login.created", username), AnsiGreen)))
 			return username, accounts.IsAdmin(username), nil
 		}
 	}
-	_ = session.WriteString(Ansi("\r\nLogin cancelled.\r\n"))
+	_ = session.WriteString(Ansi("\r\n" + text("login.cancelled") + "\r\n"))
 	return "", false, fmt.Errorf("login cancelled")
 }
+
+// parseResetRequest recognises "reset <token>" typed at the username prompt.
+func parseResetRequest(input string) (string, bool) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "reset") {
+		return "", false
+	}
+	return fields[1], true
+}
+
+// resetPasswordPrompt asks for a new password for the account owning token and
+// consumes the token once a valid password is supplied.
+func resetPasswordPrompt(session *TelnetSession, accounts *AccountManager, token string) (string, error) {
+	name, ok := accounts.AccountForResetToken(token)
+	if !ok {
+		return "", ErrResetTokenInvalid
+	}
+	_ = session.WriteString(Ansi(Style("\r\nResetting the password for "+name+".", AnsiGreen)))
+	for tries := 0; tries < 3; tries++ {
+		_ = session.WriteString(Ansi("\r\nNew password: "))
+		password, err := session.ReadPassword()
+		if err != nil {
+			return "", err
+		}
+		password = Trim(password)
+		if err := validatePassword(password); err != nil {
+			_ = session.WriteString(Ansi(Style("\r\n"+err.Error(), AnsiYellow)))
+			continue
+		}
+		name, err := accounts.ResetPassword(token, password)
+		if err != nil {
+			return "", err
+		}
+		_ = session.WriteString(Ansi(Style("\r\nPassword updated. Welcome back, "+name+"!", AnsiGreen)))
+		return name, nil
+	}
+	return "", fmt.Errorf("password reset cancelled")
+}
+
+// selectCharacter lists the characters owned by account and lets the player
+// choose one or create a new one. Pressing enter picks the first character.
+func selectCharacter(session *TelnetSession, accounts *AccountManager, account string) (string, error) {
+	for attempts := 0; attempts < 5; attempts++ {
+		characters := accounts.Characters(account)
+		if len(characters) == 0 {
+			return "", fmt.Errorf("account %s has no characters", account)
+		}
+		var menu strings.Builder
+		menu.WriteString("\r\n" + Style("Your characters:", AnsiMagenta, AnsiBold))
+		for i, name := range characters {
+			menu.WriteString(fmt.Sprintf("\r\n  %d. %s", i+1, HighlightName(name)))
+		}
+		menu.WriteString("\r\n" + Style("Choose a character by number or name, or type 'new <name>' to create one.", AnsiGreen))
+		menu.WriteString(fmt.Sprintf("\r\nCharacter [%s]: ", characters[0]))
+		_ = session.WriteString(Ansi(menu.String()))
+		choice, err := session.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		choice = Trim(choice)
+		if choice == "" {
+			return characters[0], nil
+		}
+		if fields := strings.Fields(choice); strings.EqualFold(fields[0], "new") {
+			if len(fields) != 2 {
+				_ = session.WriteString(Ansi(Style("\r\nUsage: new <name>", AnsiYellow)))
+				continue
+			}
+			if err := accounts.AddCharacter(account, fields[1]); err != nil {
+				_ = session.WriteString(Ansi(Style("\r\n"+err.Error(), AnsiYellow)))
+				continue
+			}
+			_ = session.WriteString(Ansi(Style("\r\nCharacter created: "+fields[1], AnsiGreen)))
+			return fields[1], nil
+		}
+		if index, err := strconv.Atoi(choice); err == nil && index >= 1 && index <= len(characters) {
+			return characters[index-1], nil
+		}
+		for _, name := range characters {
+			if strings.EqualFold(name, choice) {
+				return name, nil
+			}
+		}
+		_ = session.WriteString(Ansi(Style("\r\nYou have no character by that name.", AnsiYellow)))
+	}
+	_ = session.WriteString(Ansi("\r\nCharacter selection cancelled.\r\n"))
+	return "", fmt.Errorf("character selection cancelled")
+}
+
+// chooseCharacterOptions walks a new character through picking a race and a
+// class from classes.json and records the choices on profile.
+func chooseCharacterOptions(session *TelnetSession, world *World, profile *PlayerProfile) error {
+	options := world.CharacterOptions()
+	race, err := chooseArchetype(session, "race", options.Races)
+	if err != nil {
+		return err
+	}
+	class, err := chooseArchetype(session, "class", options.Classes)
+	if err != nil {
+		return err
+	}
+	world.ApplyCharacterOptions(profile, race, class)
+	var chosen []string
+	for _, archetype := range []Archetype{race, class} {
+		if archetype.ID != "" {
+			chosen = append(chosen, archetype.Name)
+		}
+	}
+	_ = session.WriteString(Ansi(Style("\r\nYou begin your journey: "+strings.Join(chosen, " ")+".", AnsiGreen)))
+	return nil
+}
+
+// chooseArchetype lists choices and reads one by number or name. An empty
+// list skips the question.
+func chooseArchetype(session *TelnetSession, kind string, choices []Archetype) (Archetype, error) {
+	if len(choices) == 0 {
+		return Archetype{}, nil
+	}
+	for attempts := 0; attempts < 5; attempts++ {
+		var menu strings.Builder
+		menu.WriteString("\r\n" + Style("Choose your "+kind+":", AnsiMagenta, AnsiBold))
+		for i, choice := range choices {
+			menu.WriteString(fmt.Sprintf("\r\n  %d. %s", i+1, Style(choice.Name, AnsiBold)))
+			if choice.Description != "" {
+				menu.WriteString(" - " + choice.Description)
+			}
+			if mods := formatStatModifiers(choice.Modifiers); mods != "" {
+				menu.WriteString(" " + Style("("+mods+")", AnsiDim))
+			}
+		}
+		menu.WriteString(fmt.Sprintf("\r\n%s: ", strings.ToUpper(kind[:1])+kind[1:]))
+		_ = session.WriteString(Ansi(menu.String()))
+		answer, err := session.ReadLine()
+		if err != nil {
+			return Archetype{}, err
+		}
+		answer = Trim(answer)
+		if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(choices) {
+			return choices[index-1], nil
+		}
+		if choice, ok := findArchetype(choices, answer); ok {
+			return choice, nil
+		}
+		_ = session.WriteString(Ansi(Style("\r\nPlease choose one of the listed options.", AnsiYellow)))
+	}
+	_ = session.WriteString(Ansi("\r\nCharacter creation cancelled.\r\n"))
+	return Archetype{}, fmt.Errorf("character creation cancelled")
+}
+
+// formatStatModifiers renders the non-zero modifiers as "+10 health, -5 mana".
+func formatStatModifiers(m StatModifiers) string {
+	var parts []string
+	for _, mod := range []struct {
+		value int
+		label string
+	}{{m.Health, "health"}, {m.Mana, "mana"}, {m.Moves, "stamina"}, {m.Damage, "damage"}, {m.Str, "str"}, {m.Dex, "dex"}, {m.Con, "con"}, {m.Int, "int"}} {
+		if mod.value != 0 {
+			parts = append(parts, fmt.Sprintf("%+d %s", mod.value, mod.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultMapRadius is how many steps the map command explores by default.
+	DefaultMapRadius = 3
+	// MaxMapRadius bounds how far the map command explores.
+	MaxMapRadius = 8
+)
+
+type mapPoint struct {
+	X, Y int
+}
+
+// mapOffsets places compass exits on the grid. Exits in other directions,
+// such as up and down, are not drawn.
+var mapOffsets = map[string]mapPoint{
+	"north":     {0, -1},
+	"south":     {0, 1},
+	"east":      {1, 0},
+	"west":      {-1, 0},
+	"northeast": {1, -1},
+	"northwest": {-1, -1},
+	"southeast": {1, 1},
+	"southwest": {-1, 1},
+	"n":         {0, -1},
+	"s":         {0, 1},
+	"e":         {1, 0},
+	"w":         {-1, 0},
+	"ne":        {1, -1},
+	"nw":        {-1, -1},
+	"se":        {1, 1},
+	"sw":        {-1, 1},
+}
+
+type mapCell struct {
+	links   map[mapPoint]bool
+	players bool
+	npcs    bool
+	items   bool
+}
+
+// mapArea lays out the rooms reachable within radius steps of center on a
+// grid centred on the origin. When two rooms would share a square the one
+// reached first wins.
+func (w *World) mapArea(center RoomID, radius int, viewer string) map[mapPoint]*mapCell {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if _, ok := w.rooms[center]; !ok {
+		return nil
+	}
+	occupied := make(map[RoomID]bool)
+	for _, p := range w.players {
+		if p.Alive && p.Name != viewer {
+			occupied[p.Room] = true
+		}
+	}
+	self := w.players[viewer]
+	type step struct {
+		room  RoomID
+		at    mapPoint
+		depth int
+	}
+	cells := make(map[mapPoint]*mapCell)
+	placed := map[RoomID]bool{center: true}
+	reserved := map[mapPoint]bool{{}: true}
+	queue := []step{{room: center}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		room := w.rooms[current.room]
+		cell := &mapCell{
+			links:   make(map[mapPoint]bool),
+			players: occupied[current.room],
+			npcs:    len(room.NPCs) > 0,
+			items:   len(room.Items) > 0,
+		}
+		cells[current.at] = cell
+		dirs := make([]string, 0, len(room.Exits))
+		for dir, exit := range room.Exits {
+			if exitVisibleLocked(self, current.room, dir, exit) {
+				dirs = append(dirs, dir)
+			}
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			offset, ok := mapOffsets[strings.ToLower(dir)]
+			if !ok {
+				continue
+			}
+			cell.links[offset] = true
+			dest := room.Exits[dir].To
+			if current.depth >= radius || placed[dest] {
+				continue
+			}
+			if _, ok := w.rooms[dest]; !ok {
+				continue
+			}
+			next := mapPoint{current.at.X + offset.X, current.at.Y + offset.Y}
+			if reserved[next] {
+				continue
+			}
+			placed[dest] = true
+			reserved[next] = true
+			queue = append(queue, step{room: dest, at: next, depth: current.depth + 1})
+		}
+	}
+	return cells
+}
+
+// RenderMap draws the rooms within radius steps of the player as an ASCII
+// map no wider than the player's terminal.
+func RenderMap(world *World, p *Player, radius int) string {
+	if radius < 1 {
+		radius = 1
+	}
+	if radius > MaxMapRadius {
+		radius = MaxMapRadius
+	}
+	cells := world.mapArea(p.Room, radius, p.Name)
+	if len(cells) == 0 {
+		return ""
+	}
+	width, _ := p.WindowSize()
+	// Each room takes three columns plus one for the connector to its east,
+	// and the border leaves room for exits that lead off the map.
+	half := ((width-1)/4 - 1) / 2
+	if half < 0 {
+		half = 0
+	}
+	if half > radius {
+		half = radius
+	}
+	minX, maxX, minY, maxY := 0, 0, 0, 0
+	for at := range cells {
+		if at.X < -half || at.X > half || at.Y < -radius || at.Y > radius {
+			delete(cells, at)
+			continue
+		}
+		minX, maxX = min(minX, at.X), max(maxX, at.X)
+		minY, maxY = min(minY, at.Y), max(maxY, at.Y)
+	}
+	cols := (maxX-minX+1)*4 + 1
+	rows := (maxY-minY+1)*2 + 1
+	canvas := make([][]string, rows)
+	for i := range canvas {
+		canvas[i] = make([]string, cols)
+		for j := range canvas[i] {
+			canvas[i][j] = " "
+		}
+	}
+	put := func(row, col int, glyph string) {
+		if row >= 0 && row < rows && col >= 0 && col < cols {
+			canvas[row][col] = glyph
+		}
+	}
+	connectors := map[mapPoint]string{
+		{0, -1}: "|", {0, 1}: "|", {1, 0}: "-", {-1, 0}: "-",
+		{1, -1}: "/", {-1, 1}: "/", {1, 1}: "\\", {-1, -1}: "\\",
+	}
+	for at, cell := range cells {
+		row := (at.Y-minY)*2 + 1
+		col := (at.X-minX)*4 + 1
+		marker := " "
+		switch {
+		case at == mapPoint{}:
+			marker = Style("@", AnsiBold, AnsiGreen)
+		case cell.players:
+			marker = Style("*", AnsiBold, AnsiCyan)
+		case cell.npcs:
+			marker = Style("!", AnsiBold, AnsiMagenta)
+		case cell.items:
+			marker = Style("$", AnsiBold, AnsiYellow)
+		}
+		put(row, col, "[")
+		put(row, col+1, marker)
+		put(row, col+2, "]")
+		for offset := range cell.links {
+			glyph := connectors[offset]
+			switch {
+			case offset.X == 0:
+				put(row+offset.Y, col+1, glyph)
+			case offset.X > 0:
+				put(row+offset.Y, col+3, glyph)
+			default:
+				put(row+offset.Y, col-1, glyph)
+			}
+		}
+	}
+	var builder strings.Builder
+	for _, line := range canvas {
+		text := strings.TrimRight(strings.Join(line, ""), " ")
+		if text == "" {
+			continue
+		}
+		builder.WriteString("\r\n" + text)
+	}
+	builder.WriteString("\r\n" + Style("@", AnsiBold, AnsiGreen) + " you  " +
+		Style("*", AnsiBold, AnsiCyan) + " players  " +
+		Style("!", AnsiBold, AnsiMagenta) + " NPCs  " +
+		Style("$", AnsiBold, AnsiYellow) + " items")
+	return builder.String()
+}
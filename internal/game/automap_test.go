@@ -0,0 +1,72 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func automapTestWorld() *World {
+	return NewWorldWithRooms(map[RoomID]*Room{
+		"center": {ID: "center", Exits: map[string]Exit{"north": {To: "north"}, "east": {To: "east"}, "up": {To: "tower"}}},
+		"north":  {ID: "north", Exits: map[string]Exit{"south": {To: "center"}}, NPCs: []NPC{{Name: "Guard"}}},
+		"east":   {ID: "east", Exits: map[string]Exit{"west": {To: "center"}, "east": {To: "far"}}, Items: []Item{{Name: "coin"}}},
+		"far":    {ID: "far", Exits: map[string]Exit{"west": {To: "east"}}},
+		"tower":  {ID: "tower", Exits: map[string]Exit{"down": {To: "center"}}},
+	})
+}
+
+func TestRenderMapDrawsNearbyRoomsWithMarkers(t *testing.T) {
+	world := automapTestWorld()
+	p := &Player{Name: "Alice", Room: "center", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	rendered := ansiPattern.ReplaceAllString(RenderMap(world, p, 1), "")
+	lines := strings.Split(strings.TrimPrefix(rendered, "\r\n"), "\r\n")
+	want := []string{
+		" [!]",
+		"  |",
+		" [@]-[$]-",
+	}
+	for i, line := range want {
+		if i >= len(lines) || lines[i] != line {
+			t.Fatalf("map line %d = %q, want %q\nfull map:\n%s", i, lines[min(i, len(lines)-1)], line, rendered)
+		}
+	}
+
+	full := ansiPattern.ReplaceAllString(RenderMap(world, p, 2), "")
+	if !strings.Contains(full, "[@]-[$]-[ ]") {
+		t.Fatalf("radius 2 map should reach the far room:\n%s", full)
+	}
+}
+
+func TestRenderMapRespectsTerminalWidth(t *testing.T) {
+	rooms := make(map[RoomID]*Room)
+	ids := []RoomID{"r0", "r1", "r2", "r3", "r4", "r5", "r6", "r7", "r8"}
+	for i, id := range ids {
+		exits := map[string]Exit{}
+		if i > 0 {
+			exits["west"] = Exit{To: ids[i-1]}
+		}
+		if i < len(ids)-1 {
+			exits["east"] = Exit{To: ids[i+1]}
+		}
+		rooms[id] = &Room{ID: id, Exits: exits}
+	}
+	world := NewWorldWithRooms(rooms)
+	p := &Player{Name: "Alice", Room: "r4", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	p.Session = &TelnetSession{width: 20, height: 24}
+
+	rendered := ansiPattern.ReplaceAllString(RenderMap(world, p, MaxMapRadius), "")
+	for _, line := range strings.Split(rendered, "\r\n") {
+		if strings.HasPrefix(line, "@ you") {
+			continue
+		}
+		if len(line) > 20 {
+			t.Fatalf("map line %q is wider than the terminal", line)
+		}
+	}
+	if !strings.Contains(rendered, "[@]") {
+		t.Fatalf("map should keep the player's room visible:\n%s", rendered)
+	}
+}
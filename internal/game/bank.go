@@ -0,0 +1,289 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VaultCapacity limits how many items one account may store in its vault.
+const VaultCapacity = 30
+
+var (
+	// ErrNoBanker indicates there is no banker in the player's room.
+	ErrNoBanker = errors.New("there is no banker here")
+	// ErrInsufficientGold indicates the player or bank lacks the requested gold.
+	ErrInsufficientGold = errors.New("not enough gold")
+	// ErrVaultFull indicates the account's vault has no free space.
+	ErrVaultFull = errors.New("vault is full")
+	// ErrVaultItemNotFound indicates the requested item is not in the vault.
+	ErrVaultItemNotFound = errors.New("item not in vault")
+)
+
+// Bank holds the gold and items an account keeps with its bankers. Every
+// character on the account shares it.
+type Bank struct {
+	Gold  int    `json:"gold,omitempty"`
+	Vault []Item `json:"vault,omitempty"`
+	// RentPaid is when vault rent was last settled. RentOwed is rent still
+	// unpaid, outstanding since RentOverdue.
+	RentPaid    time.Time `json:"rent_paid,omitempty"`
+	RentOwed    int       `json:"rent_owed,omitempty"`
+	RentOverdue time.Time `json:"rent_overdue,omitempty"`
+}
+
+func (b Bank) clone() Bank {
+	b.Vault = cloneItems(b.Vault)
+	return b
+}
+
+// Bank returns a copy of the account's bank.
+func (a *AccountManager) Bank(account string) (Bank, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	record, ok := a.accounts[account]
+	if !ok {
+		return Bank{}, false
+	}
+	if record.Bank == nil {
+		return Bank{}, true
+	}
+	return record.Bank.clone(), true
+}
+
+// updateBank applies fn to the account's bank and saves the accounts file,
+// restoring the previous bank if fn fails or the save does.
+func (a *AccountManager) updateBank(account string, fn func(*Bank) error) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record, ok := a.accounts[account]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+	previous := record.Bank
+	bank := Bank{}
+	if previous != nil {
+		bank = previous.clone()
+	}
+	if err := fn(&bank); err != nil {
+		return err
+	}
+	if bank.Gold == 0 && len(bank.Vault) == 0 {
+		record.Bank = nil
+	} else {
+		record.Bank = &bank
+	}
+	a.accounts[account] = record
+	if err := a.saveLocked(); err != nil {
+		record.Bank = previous
+		a.accounts[account] = record
+		return err
+	}
+	return nil
+}
+
+// bankerInRoomLocked returns the first banker NPC standing in the room.
+func (w *World) bankerInRoomLocked(room RoomID) (NPC, bool) {
+	r, ok := w.rooms[room]
+	if !ok {
+		return NPC{}, false
+	}
+	for _, npc := range r.NPCs {
+		if npc.Banker {
+			return npc, true
+		}
+	}
+	return NPC{}, false
+}
+
+// BankerHere returns the banker NPC in the player's room, if any.
+func (w *World) BankerHere(p *Player) (NPC, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.bankerInRoomLocked(p.Room)
+}
+
+// bankTransaction runs fn with the world locked after checking that a banker
+// is present, then saves the player's profile.
+func (w *World) bankTransaction(p *Player, fn func(accounts *AccountManager) error) error {
+	w.mu.Lock()
+	accounts := w.accounts
+	if accounts == nil {
+		w.mu.Unlock()
+		return fmt.Errorf("banking is unavailable")
+	}
+	if _, ok := w.bankerInRoomLocked(p.Room); !ok {
+		w.mu.Unlock()
+		return ErrNoBanker
+	}
+	if err := fn(accounts); err != nil {
+		w.mu.Unlock()
+		return err
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return nil
+}
+
+// DepositGold moves gold the player carries into their account's bank.
+func (w *World) DepositGold(p *Player, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	return w.bankTransaction(p, func(accounts *AccountManager) error {
+		if p.Gold < amount {
+			return ErrInsufficientGold
+		}
+		if err := accounts.updateBank(p.Account, func(bank *Bank) error {
+			bank.Gold += amount
+			return nil
+		}); err != nil {
+			return err
+		}
+		p.Gold -= amount
+		return nil
+	})
+}
+
+// WithdrawGold moves gold from the account's bank into the player's purse.
+func (w *World) WithdrawGold(p *Player, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	return w.bankTransaction(p, func(accounts *AccountManager) error {
+		if err := accounts.updateBank(p.Account, func(bank *Bank) error {
+			if bank.Gold < amount {
+				return ErrInsufficientGold
+			}
+			bank.Gold -= amount
+			return nil
+		}); err != nil {
+			return err
+		}
+		p.Gold += amount
+		return nil
+	})
+}
+
+// DepositItem moves a carried item into the account's vault.
+func (w *World) DepositItem(p *Player, name string) (*Item, error) {
+	var stored Item
+	err := w.bankTransaction(p, func(accounts *AccountManager) error {
+		idx := findItemIndex(p.Inventory, strings.TrimSpace(name))
+		if idx == -1 {
+			return ErrItemNotCarried
+		}
+		item := p.Inventory[idx]
+		if err := accounts.updateBank(p.Account, func(bank *Bank) error {
+			if len(bank.Vault) >= VaultCapacity {
+				return ErrVaultFull
+			}
+			accrueRent(bank, time.Now(), w.dailyRentLocked(bank.Vault))
+			bank.Vault = append(bank.Vault, item)
+			return nil
+		}); err != nil {
+			return err
+		}
+		p.Inventory = append(p.Inventory[:idx], p.Inventory[idx+1:]...)
+		stored = item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// WithdrawItem moves an item from the account's vault into the player's
+// inventory.
+func (w *World) WithdrawItem(p *Player, name string) (*Item, error) {
+	var taken Item
+	err := w.bankTransaction(p, func(accounts *AccountManager) error {
+		if err := accounts.updateBank(p.Account, func(bank *Bank) error {
+			idx := findItemIndex(bank.Vault, strings.TrimSpace(name))
+			if idx == -1 {
+				return ErrVaultItemNotFound
+			}
+			taken = bank.Vault[idx]
+			if !canCarryLocked(p, taken.TotalWeight()) {
+				return ErrTooHeavy
+			}
+			accrueRent(bank, time.Now(), w.dailyRentLocked(bank.Vault))
+			bank.Vault = append(bank.Vault[:idx], bank.Vault[idx+1:]...)
+			return nil
+		}); err != nil {
+			return err
+		}
+		p.Inventory = append(p.Inventory, taken)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &taken, nil
+}
+
+// BankStatement returns the bank of the player's account. A banker must be
+// present.
+func (w *World) BankStatement(p *Player) (Bank, error) {
+	w.mu.RLock()
+	accounts := w.accounts
+	_, ok := w.bankerInRoomLocked(p.Room)
+	w.mu.RUnlock()
+	if accounts == nil {
+		return Bank{}, fmt.Errorf("banking is unavailable")
+	}
+	if !ok {
+		return Bank{}, ErrNoBanker
+	}
+	bank, _ := accounts.Bank(p.Account)
+	return bank, nil
+}
+
+// BankAudit describes the bank shared by the account that owns a character,
+// for staff review.
+type BankAudit struct {
+	Account    string
+	Characters []string
+	Bank       Bank
+}
+
+// AuditBank looks up the bank belonging to the account that owns the named
+// character or account.
+func (w *World) AuditBank(name string) (BankAudit, error) {
+	account, characters, ok := w.LinkedCharacters(name)
+	if !ok {
+		return BankAudit{}, fmt.Errorf("no character or account named %s", strings.TrimSpace(name))
+	}
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	bank, _ := accounts.Bank(account)
+	return BankAudit{Account: account, Characters: characters, Bank: bank}, nil
+}
+
+// AwardGold adds newly created gold to the player's purse, scaled by the
+// gold faucet rate, records it against source in the economy ledger, and
+// saves their profile. It returns the gold awarded.
+func (w *World) AwardGold(p *Player, amount int, source EconomySource) int {
+	if p == nil || amount <= 0 {
+		return 0
+	}
+	w.mu.Lock()
+	amount = w.faucetGoldLocked(amount)
+	p.Gold += amount
+	w.recordGoldLocked(source, amount)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return amount
+}
+
+// PlayerGold returns the gold the player carries.
+func (w *World) PlayerGold(p *Player) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.Gold
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestBankGoldAndVaultPersistPerAccount(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"vault": {ID: "vault", Title: "Counting House", NPCs: []NPC{{Name: "Teller", Banker: true}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("saver", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	player := &Player{Name: "saver", Account: "saver", Room: "vault", Output: NewOutputQueue(), Alive: true, Gold: 40,
+		Inventory: []Item{{Name: "Glass Locket"}}}
+	world.AddPlayerForTest(player)
+
+	if err := world.DepositGold(player, 50); !errors.Is(err, ErrInsufficientGold) {
+		t.Fatalf("expected ErrInsufficientGold, got %v", err)
+	}
+	if err := world.DepositGold(player, 30); err != nil {
+		t.Fatalf("DepositGold: %v", err)
+	}
+	if _, err := world.DepositItem(player, "locket"); err != nil {
+		t.Fatalf("DepositItem: %v", err)
+	}
+	if player.Gold != 10 || len(player.Inventory) != 0 {
+		t.Fatalf("player kept gold %d and items %v", player.Gold, player.Inventory)
+	}
+
+	reloaded, err := NewAccountManager(accounts.path)
+	if err != nil {
+		t.Fatalf("reload accounts: %v", err)
+	}
+	bank, ok := reloaded.Bank("saver")
+	if !ok || bank.Gold != 30 || len(bank.Vault) != 1 || bank.Vault[0].Name != "Glass Locket" {
+		t.Fatalf("unexpected persisted bank: %#v", bank)
+	}
+
+	if err := world.WithdrawGold(player, 31); !errors.Is(err, ErrInsufficientGold) {
+		t.Fatalf("expected ErrInsufficientGold, got %v", err)
+	}
+	if err := world.WithdrawGold(player, 30); err != nil {
+		t.Fatalf("WithdrawGold: %v", err)
+	}
+	if _, err := world.WithdrawItem(player, "sword"); !errors.Is(err, ErrVaultItemNotFound) {
+		t.Fatalf("expected ErrVaultItemNotFound, got %v", err)
+	}
+	if _, err := world.WithdrawItem(player, "glass"); err != nil {
+		t.Fatalf("WithdrawItem: %v", err)
+	}
+	if player.Gold != 40 || len(player.Inventory) != 1 {
+		t.Fatalf("player should have everything back, got %d gold and %v", player.Gold, player.Inventory)
+	}
+	if bank, _ := accounts.Bank("saver"); bank.Gold != 0 || len(bank.Vault) != 0 {
+		t.Fatalf("bank should be empty, got %#v", bank)
+	}
+}
+
+func TestBankRequiresBankerAndRespectsCapacity(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"vault":  {ID: "vault", Title: "Counting House", NPCs: []NPC{{Name: "Teller", Banker: true}}},
+		"street": {ID: "street", Title: "Street"},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("saver", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	player := &Player{Name: "saver", Account: "saver", Room: "vault", Output: NewOutputQueue(), Alive: true, Gold: 40,
+		Inventory: []Item{{Name: "Glass Locket"}}}
+	world.AddPlayerForTest(player)
+
+	player.Room = "street"
+	if err := world.DepositGold(player, 5); !errors.Is(err, ErrNoBanker) {
+		t.Fatalf("expected ErrNoBanker, got %v", err)
+	}
+	player.Room = "vault"
+
+	if err := accounts.updateBank("saver", func(bank *Bank) error {
+		for len(bank.Vault) < VaultCapacity {
+			bank.Vault = append(bank.Vault, Item{Name: "Pebble"})
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("fill vault: %v", err)
+	}
+	if _, err := world.DepositItem(player, "locket"); !errors.Is(err, ErrVaultFull) {
+		t.Fatalf("expected ErrVaultFull, got %v", err)
+	}
+	if len(player.Inventory) != 1 {
+		t.Fatalf("a refused deposit should leave the item carried")
+	}
+
+	audit, err := world.AuditBank("SAVER")
+	if err != nil {
+		t.Fatalf("AuditBank: %v", err)
+	}
+	if audit.Account != "saver" || len(audit.Bank.Vault) != VaultCapacity {
+		t.Fatalf("unexpected audit: %#v", audit)
+	}
+}
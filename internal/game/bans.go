@@ -0,0 +1,299 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BanEntry records why and by whom an account or address was banned.
+type BanEntry struct {
+	Target    string    `json:"target"`
+	Reason    string    `json:"reason,omitempty"`
+	IssuedBy  string    `json:"issued_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BanList persists account and IP address bans.
+type BanList struct {
+	mu       sync.RWMutex
+	path     string
+	accounts map[string]BanEntry
+	ips      map[string]BanEntry
+}
+
+type banFile struct {
+	Accounts []BanEntry `json:"accounts"`
+	IPs      []BanEntry `json:"ips,omitempty"`
+}
+
+// NewBanList loads bans from the provided path. When path is empty the list
+// operates purely in-memory without persistence.
+func NewBanList(path string) (*BanList, error) {
+	list := &BanList{
+		path:     strings.TrimSpace(path),
+		accounts: make(map[string]BanEntry),
+		ips:      make(map[string]BanEntry),
+	}
+	if list.path == "" {
+		return list, nil
+	}
+	data, err := os.ReadFile(list.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return list, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read bans: %w", err)
+	}
+	if len(data) == 0 {
+		return list, nil
+	}
+	var file banFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode bans: %w", err)
+	}
+	for _, entry := range file.Accounts {
+		key := normalizeBanKey(entry.Target)
+		if key == "" {
+			continue
+		}
+		list.accounts[key] = entry
+	}
+	for _, entry := range file.IPs {
+		key, err := normalizeIPBanKey(entry.Target)
+		if err != nil {
+			continue
+		}
+		entry.Target = key
+		list.ips[key] = entry
+	}
+	return list, nil
+}
+
+// normalizeIPBanKey canonicalises a single address or CIDR range.
+func normalizeIPBanKey(target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", fmt.Errorf("address must not be empty")
+	}
+	if strings.Contains(target, "/") {
+		_, network, err := net.ParseCIDR(target)
+		if err != nil {
+			return "", fmt.Errorf("invalid address range: %s", target)
+		}
+		return network.String(), nil
+	}
+	ip := net.ParseIP(target)
+	if ip == nil {
+		return "", fmt.Errorf("invalid address: %s", target)
+	}
+	return ip.String(), nil
+}
+
+// IsIPBanTarget reports whether target looks like an address or CIDR range
+// rather than an account name.
+func IsIPBanTarget(target string) bool {
+	_, err := normalizeIPBanKey(target)
+	return err == nil
+}
+
+func normalizeBanKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// BanAccount prevents the named account from logging in.
+func (b *BanList) BanAccount(name, reason, issuedBy string) (BanEntry, error) {
+	key := normalizeBanKey(name)
+	if key == "" {
+		return BanEntry{}, fmt.Errorf("account name must not be empty")
+	}
+	entry := BanEntry{
+		Target:    strings.TrimSpace(name),
+		Reason:    strings.TrimSpace(reason),
+		IssuedBy:  strings.TrimSpace(issuedBy),
+		CreatedAt: time.Now().UTC(),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	previous, existed := b.accounts[key]
+	b.accounts[key] = entry
+	if err := b.persistLocked(); err != nil {
+		if existed {
+			b.accounts[key] = previous
+		} else {
+			delete(b.accounts, key)
+		}
+		return BanEntry{}, err
+	}
+	return entry, nil
+}
+
+// UnbanAccount lifts an account ban. It reports whether a ban was removed.
+func (b *BanList) UnbanAccount(name string) (bool, error) {
+	key := normalizeBanKey(name)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.accounts[key]
+	if !ok {
+		return false, nil
+	}
+	delete(b.accounts, key)
+	if err := b.persistLocked(); err != nil {
+		b.accounts[key] = entry
+		return false, err
+	}
+	return true, nil
+}
+
+// AccountBan returns the ban covering the named account, if any.
+func (b *BanList) AccountBan(name string) (BanEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	entry, ok := b.accounts[normalizeBanKey(name)]
+	return entry, ok
+}
+
+// AccountBans lists every banned account ordered by name.
+func (b *BanList) AccountBans() []BanEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]BanEntry, 0, len(b.accounts))
+	for _, entry := range b.accounts {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return normalizeBanKey(out[i].Target) < normalizeBanKey(out[j].Target)
+	})
+	return out
+}
+
+// BanIP prevents connections from an address or CIDR range.
+func (b *BanList) BanIP(target, reason, issuedBy string) (BanEntry, error) {
+	key, err := normalizeIPBanKey(target)
+	if err != nil {
+		return BanEntry{}, err
+	}
+	entry := BanEntry{
+		Target:    key,
+		Reason:    strings.TrimSpace(reason),
+		IssuedBy:  strings.TrimSpace(issuedBy),
+		CreatedAt: time.Now().UTC(),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	previous, existed := b.ips[key]
+	b.ips[key] = entry
+	if err := b.persistLocked(); err != nil {
+		if existed {
+			b.ips[key] = previous
+		} else {
+			delete(b.ips, key)
+		}
+		return BanEntry{}, err
+	}
+	return entry, nil
+}
+
+// UnbanIP lifts an address ban. It reports whether a ban was removed.
+func (b *BanList) UnbanIP(target string) (bool, error) {
+	key, err := normalizeIPBanKey(target)
+	if err != nil {
+		return false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.ips[key]
+	if !ok {
+		return false, nil
+	}
+	delete(b.ips, key)
+	if err := b.persistLocked(); err != nil {
+		b.ips[key] = entry
+		return false, err
+	}
+	return true, nil
+}
+
+// IPBan returns the ban covering the address, either directly or through a
+// banned range.
+func (b *BanList) IPBan(addr string) (BanEntry, bool) {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return BanEntry{}, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if entry, ok := b.ips[ip.String()]; ok {
+		return entry, true
+	}
+	for key, entry := range b.ips {
+		if !strings.Contains(key, "/") {
+			continue
+		}
+		if _, network, err := net.ParseCIDR(key); err == nil && network.Contains(ip) {
+			return entry, true
+		}
+	}
+	return BanEntry{}, false
+}
+
+// IPBans lists every banned address ordered by target.
+func (b *BanList) IPBans() []BanEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]BanEntry, 0, len(b.ips))
+	for _, entry := range b.ips {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Target < out[j].Target })
+	return out
+}
+
+func (b *BanList) persistLocked() error {
+	if b.path == "" {
+		return nil
+	}
+	accounts := make([]BanEntry, 0, len(b.accounts))
+	for _, entry := range b.accounts {
+		accounts = append(accounts, entry)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return normalizeBanKey(accounts[i].Target) < normalizeBanKey(accounts[j].Target)
+	})
+	ips := make([]BanEntry, 0, len(b.ips))
+	for _, entry := range b.ips {
+		ips = append(ips, entry)
+	}
+	sort.Slice(ips, func(i, j int) bool { return ips[i].Target < ips[j].Target })
+	dir := filepath.Dir(b.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create bans directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "bans-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp bans file: %w", err)
+	}
+	enc := json.NewEncoder(tmp)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(banFile{Accounts: accounts, IPs: ips}); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write bans file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close temp bans file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("replace bans file: %w", err)
+	}
+	return nil
+}
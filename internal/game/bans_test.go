@@ -0,0 +1,85 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBanListIPBansPersistAndMatchRanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	bans, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("NewBanList error: %v", err)
+	}
+	if _, err := bans.BanIP("not-an-ip", "", "Admin"); err == nil {
+		t.Fatalf("expected invalid address to be rejected")
+	}
+	if _, err := bans.BanIP("203.0.113.7", "spam", "Admin"); err != nil {
+		t.Fatalf("BanIP error: %v", err)
+	}
+	if _, err := bans.BanIP("198.51.100.9/24", "botnet", "Admin"); err != nil {
+		t.Fatalf("BanIP range error: %v", err)
+	}
+	if _, err := bans.BanAccount("Troll", "abuse", "Admin"); err != nil {
+		t.Fatalf("BanAccount error: %v", err)
+	}
+
+	reloaded, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if entry, ok := reloaded.IPBan("203.0.113.7"); !ok || entry.Reason != "spam" {
+		t.Fatalf("expected exact address ban, got %#v (ok=%v)", entry, ok)
+	}
+	if entry, ok := reloaded.IPBan("198.51.100.200"); !ok || entry.Target != "198.51.100.0/24" {
+		t.Fatalf("expected range ban, got %#v (ok=%v)", entry, ok)
+	}
+	if _, ok := reloaded.IPBan("192.0.2.1"); ok {
+		t.Fatalf("unexpected ban for unrelated address")
+	}
+	if _, ok := reloaded.AccountBan("troll"); !ok {
+		t.Fatalf("expected account ban to survive reload")
+	}
+	removed, err := reloaded.UnbanIP("198.51.100.0/24")
+	if err != nil || !removed {
+		t.Fatalf("UnbanIP = %v, %v", removed, err)
+	}
+	if _, ok := reloaded.IPBan("198.51.100.200"); ok {
+		t.Fatalf("expected range ban to be lifted")
+	}
+}
+
+func TestLoginThrottleLocksOutRepeatedFailures(t *testing.T) {
+	throttle := NewLoginThrottle(3, time.Minute, 5*time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if throttle.Fail("10.0.0.1", now) || throttle.Fail("10.0.0.1", now.Add(time.Second)) {
+		t.Fatalf("locked out before reaching the limit")
+	}
+	if _, blocked := throttle.Blocked("10.0.0.1", now.Add(2*time.Second)); blocked {
+		t.Fatalf("blocked before reaching the limit")
+	}
+	if !throttle.Fail("10.0.0.1", now.Add(3*time.Second)) {
+		t.Fatalf("expected third failure to lock the address out")
+	}
+	if remaining, blocked := throttle.Blocked("10.0.0.1", now.Add(time.Minute)); !blocked || remaining <= 0 {
+		t.Fatalf("expected address to be blocked, got %v (blocked=%v)", remaining, blocked)
+	}
+	if _, blocked := throttle.Blocked("10.0.0.2", now.Add(time.Minute)); blocked {
+		t.Fatalf("other addresses should not be throttled")
+	}
+	if _, blocked := throttle.Blocked("10.0.0.1", now.Add(6*time.Minute)); blocked {
+		t.Fatalf("expected lockout to expire")
+	}
+
+	throttle.Fail("10.0.0.3", now)
+	throttle.Fail("10.0.0.3", now)
+	throttle.Reset("10.0.0.3")
+	if throttle.Fail("10.0.0.3", now) {
+		t.Fatalf("expected a successful login to reset failures")
+	}
+	if throttle.Fail("10.0.0.4", now) || throttle.Fail("10.0.0.4", now.Add(2*time.Minute)) {
+		t.Fatalf("failures outside the window should not accumulate")
+	}
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxBioLines caps how many lines a character description may have.
+	MaxBioLines = 12
+	// MaxBioLength caps the total length of a character description.
+	MaxBioLength = 1200
+)
+
+// BioDraft holds the lines of a character description being written in the
+// editor. Nothing is saved until SaveBioDraft.
+type BioDraft struct {
+	Lines []string
+}
+
+// Text joins the draft into a stored description.
+func (d *BioDraft) Text() string {
+	return strings.Join(d.Lines, "\n")
+}
+
+// BioDraft returns the description the player is editing, or nil.
+func (p *Player) BioDraft() *BioDraft {
+	return p.bioDraft
+}
+
+// OpenBioDraft starts the description editor with the player's current
+// description.
+func (w *World) OpenBioDraft(p *Player) *BioDraft {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	draft := &BioDraft{}
+	if p.Bio != "" {
+		draft.Lines = strings.Split(p.Bio, "\n")
+	}
+	p.bioDraft = draft
+	return draft
+}
+
+// CloseBioDraft leaves the description editor without saving.
+func (w *World) CloseBioDraft(p *Player) {
+	w.mu.Lock()
+	p.bioDraft = nil
+	w.mu.Unlock()
+}
+
+// SaveBioDraft stores the draft as the player's description and closes the
+// editor.
+func (w *World) SaveBioDraft(p *Player) error {
+	draft := p.BioDraft()
+	if draft == nil {
+		return fmt.Errorf("you are not editing your description")
+	}
+	if err := w.SetBio(p, draft.Text()); err != nil {
+		return err
+	}
+	w.CloseBioDraft(p)
+	return nil
+}
+
+// ValidateBio reports why text cannot be used as a character description.
+func ValidateBio(text string) error {
+	if len(text) > MaxBioLength {
+		return fmt.Errorf("descriptions are limited to %d characters", MaxBioLength)
+	}
+	if strings.Count(text, "\n")+1 > MaxBioLines {
+		return fmt.Errorf("descriptions are limited to %d lines", MaxBioLines)
+	}
+	if strings.ContainsRune(text, '\x1b') {
+		return fmt.Errorf("descriptions may not contain escape codes")
+	}
+	return nil
+}
+
+// SetBio replaces the player's description. Writing a new description lifts
+// any staff flag on the old one.
+func (w *World) SetBio(p *Player, text string) error {
+	text = strings.TrimSpace(text)
+	if err := ValidateBio(text); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	p.Bio = text
+	p.BioFlag = ""
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return nil
+}
+
+// PlayerBio returns the description others see when they look at p. A
+// flagged description is hidden until it is rewritten or unflagged.
+func (w *World) PlayerBio(p *Player) (string, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if p.Bio == "" || p.BioFlag != "" {
+		return "", false
+	}
+	return p.Bio, true
+}
+
+// FlagBio hides an online player's description pending a rewrite and
+// records the action in the moderation trail.
+func (w *World) FlagBio(actor, name, reason string) (*Player, error) {
+	return w.moderateBio(actor, name, reason, "bio-flag")
+}
+
+// UnflagBio restores a flagged description.
+func (w *World) UnflagBio(actor, name string) (*Player, error) {
+	return w.moderateBio(actor, name, "", "bio-unflag")
+}
+
+// ClearBio erases an online player's description.
+func (w *World) ClearBio(actor, name, reason string) (*Player, error) {
+	return w.moderateBio(actor, name, reason, "bio-clear")
+}
+
+func (w *World) moderateBio(actor, name, reason, action string) (*Player, error) {
+	reason = strings.TrimSpace(reason)
+	w.mu.Lock()
+	target, ok := w.findPlayerLocked(name)
+	if !ok {
+		w.mu.Unlock()
+		return nil, fmt.Errorf("%s is not online", strings.TrimSpace(name))
+	}
+	switch action {
+	case "bio-flag":
+		if target.Bio == "" {
+			w.mu.Unlock()
+			return nil, fmt.Errorf("%s has no description", target.Name)
+		}
+		target.BioFlag = reason
+		if target.BioFlag == "" {
+			target.BioFlag = "flagged by staff"
+		}
+	case "bio-unflag":
+		if target.BioFlag == "" {
+			w.mu.Unlock()
+			return nil, fmt.Errorf("%s's description is not flagged", target.Name)
+		}
+		target.BioFlag = ""
+	case "bio-clear":
+		target.Bio = ""
+		target.BioFlag = ""
+	}
+	key, snapshot := profileSnapshot(target)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	err := w.Moderation().Record(ModerationAction{Actor: actor, Action: action, Target: target.Name, Detail: reason})
+	return target, err
+}
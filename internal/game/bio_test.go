@@ -0,0 +1,41 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBioModeration(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	p := &Player{Name: "Hero", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if err := world.SetBio(p, strings.Repeat("line\n", MaxBioLines)+"one too many"); err == nil {
+		t.Fatalf("SetBio should reject descriptions over %d lines", MaxBioLines)
+	}
+	if err := world.SetBio(p, "A cheerful \x1b[31mred\x1b[0m face."); err == nil {
+		t.Fatalf("SetBio should reject escape codes")
+	}
+	if err := world.SetBio(p, "A cheerful face."); err != nil {
+		t.Fatalf("SetBio error: %v", err)
+	}
+	if _, err := world.FlagBio("Mod", "hero", ""); err != nil {
+		t.Fatalf("FlagBio error: %v", err)
+	}
+	if _, ok := world.PlayerBio(p); ok || p.BioFlag != "flagged by staff" {
+		t.Fatalf("flagged bio should be hidden, flag = %q", p.BioFlag)
+	}
+	if _, err := world.UnflagBio("Mod", "Hero"); err != nil {
+		t.Fatalf("UnflagBio error: %v", err)
+	}
+	if bio, ok := world.PlayerBio(p); !ok || bio != "A cheerful face." {
+		t.Fatalf("PlayerBio = %q, %v", bio, ok)
+	}
+	if _, err := world.ClearBio("Mod", "Hero", "spam"); err != nil || p.Bio != "" {
+		t.Fatalf("ClearBio = %v, bio %q", err, p.Bio)
+	}
+	actions := world.Moderation().Actions(10)
+	if len(actions) != 3 || actions[2].Action != "bio-clear" || actions[2].Detail != "spam" {
+		t.Fatalf("moderation trail = %+v", actions)
+	}
+}
@@ -0,0 +1,323 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBossLockout is how long a boss withholds its loot from a player
+// who has already claimed it, when the boss sets no lockout of its own.
+const DefaultBossLockout = 2 * time.Hour
+
+// BossPhase is a stage of a boss fight. A phase begins the first time the
+// boss's health falls to or below Health percent.
+type BossPhase struct {
+	Name string `json:"name"`
+	// Health is the percentage of maximum health that starts the phase.
+	Health int `json:"health"`
+	// Message is announced to the room when the phase begins.
+	Message string `json:"message,omitempty"`
+	// Pulse damages every player in the room when the phase begins. It
+	// wounds but never kills.
+	Pulse int `json:"pulse,omitempty"`
+	// Adds are NPCs that join the fight when the phase begins.
+	Adds []NPC `json:"adds,omitempty"`
+}
+
+// BossConfig makes an NPC a boss with scripted phases and loot that every
+// player who fought it claims, once per lockout.
+type BossConfig struct {
+	Phases []BossPhase `json:"phases,omitempty"`
+	// Lockout is how many seconds must pass before a player can claim the
+	// boss's loot again; DefaultBossLockout when unset.
+	Lockout int `json:"lockout,omitempty"`
+	// Loot is handed to every player who fought the boss and is not locked
+	// out, on top of the items left in its corpse.
+	Loot []Item `json:"loot,omitempty"`
+}
+
+func (b *BossConfig) lockout() time.Duration {
+	if b.Lockout > 0 {
+		return time.Duration(b.Lockout) * time.Second
+	}
+	return DefaultBossLockout
+}
+
+// cloneBoss copies a boss so NPCs spawned from one reset do not share it.
+func cloneBoss(boss *BossConfig) *BossConfig {
+	if boss == nil {
+		return nil
+	}
+	copied := *boss
+	copied.Phases = make([]BossPhase, len(boss.Phases))
+	for i, phase := range boss.Phases {
+		phase.Adds = append([]NPC(nil), phase.Adds...)
+		copied.Phases[i] = phase
+	}
+	copied.Loot = cloneItems(boss.Loot)
+	return &copied
+}
+
+// validateBosses checks that every boss's phases run from high health to
+// low and summon NPCs with names.
+func validateBosses(rooms map[RoomID]*Room) error {
+	check := func(boss *BossConfig) error {
+		if boss == nil {
+			return nil
+		}
+		last := 100
+		for _, phase := range boss.Phases {
+			if phase.Health < 1 || phase.Health >= last {
+				return fmt.Errorf("boss phase %q must start below %d%% health", phase.Name, last)
+			}
+			last = phase.Health
+			for _, add := range phase.Adds {
+				if strings.TrimSpace(add.Name) == "" {
+					return fmt.Errorf("boss phase %q has an add with no name", phase.Name)
+				}
+			}
+		}
+		return nil
+	}
+	for id, room := range rooms {
+		for _, npc := range room.NPCs {
+			if err := check(npc.Boss); err != nil {
+				return fmt.Errorf("room %s: npc %s: %w", id, npc.Name, err)
+			}
+		}
+		for _, reset := range room.Resets {
+			if err := check(reset.Boss); err != nil {
+				return fmt.Errorf("room %s: reset %s: %w", id, reset.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// advanceBossLocked begins every phase npc's health has fallen into,
+// bringing each phase's adds into room r, and returns the phases begun.
+func advanceBossLocked(r *Room, npc *NPC) []BossPhase {
+	if npc.Boss == nil || npc.MaxHealth <= 0 {
+		return nil
+	}
+	var begun []BossPhase
+	for npc.phase < len(npc.Boss.Phases) {
+		phase := npc.Boss.Phases[npc.phase]
+		if npc.Health*100 > phase.Health*npc.MaxHealth {
+			break
+		}
+		npc.phase++
+		for _, add := range phase.Adds {
+			normalizeNPC(&add)
+			r.NPCs = append(r.NPCs, add)
+		}
+		begun = append(begun, phase)
+	}
+	return begun
+}
+
+// runBossPhases plays out the phases a blow against a boss began: the
+// announcement, the pulse, the adds joining the fight, and the boss's
+// OnPhase script hook.
+func (w *World) runBossPhases(room RoomID, result *NPCDamageResult) {
+	if result == nil || len(result.Phases) == 0 {
+		return
+	}
+	boss := HighlightNPCName(result.NPC.Name)
+	for _, phase := range result.Phases {
+		message := strings.TrimSpace(phase.Message)
+		if message == "" {
+			message = fmt.Sprintf("%s enters its %s phase!", boss, phase.Name)
+		}
+		w.BroadcastToRoom(room, Ansi(Style("\r\n"+message, AnsiBold, AnsiRed)), nil)
+		if phase.Pulse > 0 {
+			w.bossPulse(room, result.NPC.Name, phase.Pulse)
+		}
+		if len(phase.Adds) > 0 {
+			w.bossAddsJoin(room, result.NPC.Name, phase.Adds)
+		}
+		w.scripts.callNPCOnPhase(w, room, result.NPC, phase)
+	}
+}
+
+// bossPulse wounds every player in room for damage, leaving each with at
+// least one health.
+func (w *World) bossPulse(room RoomID, bossName string, damage int) {
+	type hurt struct {
+		player *Player
+		damage int
+	}
+	var hurts []hurt
+	w.mu.Lock()
+	for _, p := range w.players {
+		if !p.Alive || p.Room != room {
+			continue
+		}
+		p.EnsureStats()
+		dealt := min(damage, p.Health-1)
+		if dealt <= 0 {
+			continue
+		}
+		p.Health -= dealt
+		hurts = append(hurts, hurt{player: p, damage: dealt})
+	}
+	w.mu.Unlock()
+	for _, h := range hurts {
+		if h.player.Output != nil {
+			h.player.Output.Send(Ansi(fmt.Sprintf("\r\nA wave of force from %s hits you for %d damage. (%d/%d HP)", HighlightNPCName(bossName), h.damage, h.player.Health, h.player.MaxHealth)))
+		}
+	}
+}
+
+// bossAddsJoin sets a phase's adds on whoever the boss is fighting.
+func (w *World) bossAddsJoin(room RoomID, bossName string, adds []NPC) {
+	for _, add := range adds {
+		w.BroadcastToRoom(room, Ansi(fmt.Sprintf("\r\n%s joins the fight!", HighlightNPCName(add.Name))), nil)
+	}
+	combat := w.combatIn(room)
+	if combat == nil {
+		return
+	}
+	_, target := combat.threatSnapshot(bossName)
+	if target == "" {
+		return
+	}
+	for _, add := range adds {
+		combat.addNPC(add.Name, combatTarget{kind: combatTargetPlayer, name: target})
+	}
+}
+
+// BossClaim reports what one player took from a defeated boss.
+type BossClaim struct {
+	Player *Player
+	Loot   []Item
+	// LockedUntil is when the player may claim the boss's loot again. When
+	// Loot is empty the player was already locked out until then.
+	LockedUntil time.Time
+}
+
+// ClaimBossLoot hands a defeated boss's loot to attacker and every other
+// player in room who fought it, skipping anyone still locked out, and starts
+// a fresh lockout for those who claim it.
+func (w *World) ClaimBossLoot(attacker *Player, room RoomID, result *NPCDamageResult) []BossClaim {
+	if result == nil || !result.Defeated || result.NPC.Boss == nil {
+		return nil
+	}
+	boss := result.NPC
+	names := map[string]bool{attacker.Name: true}
+	if combat := w.combatIn(room); combat != nil {
+		entries, _ := combat.threatSnapshot(boss.Name)
+		for _, entry := range entries {
+			names[entry.Player] = true
+		}
+	}
+	key := boss.Name
+	now := time.Now()
+	var claims []BossClaim
+	type save struct {
+		key     string
+		profile PlayerProfile
+	}
+	var saves []save
+	w.mu.Lock()
+	for name := range names {
+		p, ok := w.players[name]
+		if !ok || p.Room != room {
+			continue
+		}
+		claim := BossClaim{Player: p, LockedUntil: p.Lockouts[key]}
+		if now.Before(claim.LockedUntil) {
+			claims = append(claims, claim)
+			continue
+		}
+		for _, item := range boss.Boss.Loot {
+			claim.Loot = append(claim.Loot, w.lootTables.rollItem(DiceFunc(w.roll), item, boss.Level))
+		}
+		claim.LockedUntil = now.Add(boss.Boss.lockout())
+		if p.Lockouts == nil {
+			p.Lockouts = make(map[string]time.Time)
+		}
+		p.Lockouts[key] = claim.LockedUntil
+		p.Inventory = append(p.Inventory, claim.Loot...)
+		k, snapshot := profileSnapshot(p)
+		saves = append(saves, save{key: k, profile: snapshot})
+		claims = append(claims, claim)
+	}
+	w.mu.Unlock()
+	for _, s := range saves {
+		w.persistPlayerState(s.key, s.profile)
+	}
+	sort.Slice(claims, func(i, j int) bool { return claims[i].Player.Name < claims[j].Player.Name })
+
+	npcName := HighlightNPCName(boss.Name)
+	for _, claim := range claims {
+		if claim.Player.Output == nil {
+			continue
+		}
+		if len(claim.Loot) == 0 {
+			claim.Player.Output.Send(Ansi(Style(fmt.Sprintf("\r\nYou have already claimed %s's spoils. You may claim them again in %s.", npcName, formatCompactDuration(claim.LockedUntil.Sub(now))), AnsiYellow)))
+			continue
+		}
+		names := make([]string, len(claim.Loot))
+		for i, item := range claim.Loot {
+			names[i] = item.DisplayName()
+		}
+		claim.Player.Output.Send(Ansi(fmt.Sprintf("\r\nYou claim your share of %s's spoils: %s.", npcName, strings.Join(names, ", "))))
+	}
+	return claims
+}
+
+// BossLockout is a boss whose loot p cannot claim again until Until.
+type BossLockout struct {
+	Boss  string
+	Until time.Time
+}
+
+// BossLockouts lists p's unexpired boss lockouts, soonest first, and forgets
+// any that have lapsed.
+func (w *World) BossLockouts(p *Player) []BossLockout {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	var lockouts []BossLockout
+	for boss, until := range p.Lockouts {
+		if !now.Before(until) {
+			delete(p.Lockouts, boss)
+			continue
+		}
+		lockouts = append(lockouts, BossLockout{Boss: boss, Until: until})
+	}
+	sort.Slice(lockouts, func(i, j int) bool {
+		if !lockouts[i].Until.Equal(lockouts[j].Until) {
+			return lockouts[i].Until.Before(lockouts[j].Until)
+		}
+		return lockouts[i].Boss < lockouts[j].Boss
+	})
+	return lockouts
+}
+
+// callNPCOnPhase runs OnPhase on a boss's script as it enters phase.
+func (e *scriptEngine) callNPCOnPhase(world *World, room RoomID, npc NPC, phase BossPhase) {
+	if e == nil || strings.TrimSpace(npc.Script) == "" {
+		return
+	}
+	script, err := e.scriptFor(npc.Script)
+	if err != nil {
+		Logger().Error("NPC script failed to load", "npc", npc.Name, "error", err)
+		return
+	}
+	if script == nil || script.onPhase == nil {
+		return
+	}
+	ctx := &NPCScriptContext{world: world, room: room, npc: npc}
+	payload := e.payloadForNPC(ctx, "")
+	payload["phase"] = phase.Name
+	payload["threshold"] = phase.Health
+	payload["health"] = npc.Health
+	payload["max_health"] = npc.MaxHealth
+	e.invoke(npc.Script, "OnPhase", func() {
+		script.onPhase(payload)
+	})
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBossPhasesPulseAndSummonAdds(t *testing.T) {
+	script := `package main
+
+func OnPhase(ctx map[string]any) {
+    ctx["say"].(func(string))("Now comes my " + ctx["phase"].(string) + "!")
+}`
+	boss := NPC{Name: "Lich", Level: 5, Health: 100, MaxHealth: 100, Script: script, Boss: &BossConfig{
+		Lockout: 3600,
+		Loot:    []Item{{Name: "Phylactery Shard"}},
+		Phases: []BossPhase{
+			{Name: "wrath", Health: 60, Pulse: 5},
+			{Name: "final", Health: 25, Adds: []NPC{{Name: "Skeleton", Level: 1}}},
+		},
+	}}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"crypt": {ID: "crypt", Title: "Crypt", Exits: map[string]Exit{}, NPCs: []NPC{boss}},
+	})
+	tank := &Player{Name: "Tank", Room: "crypt", Alive: true, Output: NewOutputQueue()}
+	healer := &Player{Name: "Cleric", Room: "crypt", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(tank)
+	world.AddPlayerForTest(healer)
+	tank.EnsureStats()
+	healer.EnsureStats()
+	combat := world.ensureCombat("crypt")
+	combat.addNPC("Lich", combatTarget{kind: combatTargetPlayer, name: tank.Name})
+	combat.addThreat("Lich", healer.Name, 5)
+	result, err := world.ApplyDamageToNPC("crypt", "Lich", 30)
+	if err != nil || len(result.Phases) != 0 {
+		t.Fatalf("expected no phase at 70%% health, got %+v, %v", result, err)
+	}
+
+	result, err = world.ApplyDamageToNPC("crypt", "Lich", 15)
+	if err != nil || len(result.Phases) != 1 || result.Phases[0].Name != "wrath" {
+		t.Fatalf("expected the wrath phase, got %+v, %v", result, err)
+	}
+	if tank.Health != tank.MaxHealth-5 || healer.Health != healer.MaxHealth-5 {
+		t.Fatalf("expected the pulse to hit the whole room, got %d and %d", tank.Health, healer.Health)
+	}
+	if output := stripAnsi(strings.Join(drainOutput(tank.Output), "")); !strings.Contains(output, "Now comes my wrath!") {
+		t.Fatalf("expected OnPhase to run, got %q", output)
+	}
+
+	result, _ = world.ApplyDamageToNPC("crypt", "Lich", 5)
+	if len(result.Phases) != 0 {
+		t.Fatalf("a phase should only begin once, got %+v", result.Phases)
+	}
+	result, _ = world.ApplyDamageToNPC("crypt", "Lich", 30)
+	if len(result.Phases) != 1 || result.Phases[0].Name != "final" {
+		t.Fatalf("expected the final phase, got %+v", result.Phases)
+	}
+	crypt, _ := world.GetRoom("crypt")
+	if len(crypt.NPCs) != 2 || crypt.NPCs[1].Name != "Skeleton" {
+		t.Fatalf("expected a skeleton add, got %+v", crypt.NPCs)
+	}
+	if combat := world.combatIn("crypt"); !combat.hasNPC("Skeleton") {
+		t.Fatalf("expected the add to join the fight")
+	}
+}
+
+func TestBossLootIsSharedOncePerLockout(t *testing.T) {
+	boss := NPC{Name: "Lich", Level: 5, Health: 100, MaxHealth: 100, Boss: &BossConfig{
+		Lockout: 3600,
+		Loot:    []Item{{Name: "Phylactery Shard"}},
+		Phases: []BossPhase{
+			{Name: "wrath", Health: 60, Pulse: 5},
+			{Name: "final", Health: 25, Adds: []NPC{{Name: "Skeleton", Level: 1}}},
+		},
+	}}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"crypt": {ID: "crypt", Title: "Crypt", Exits: map[string]Exit{}, NPCs: []NPC{boss}},
+	})
+	tank := &Player{Name: "Tank", Room: "crypt", Alive: true, Output: NewOutputQueue()}
+	healer := &Player{Name: "Cleric", Room: "crypt", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(tank)
+	world.AddPlayerForTest(healer)
+	tank.EnsureStats()
+	healer.EnsureStats()
+	combat := world.ensureCombat("crypt")
+	combat.addNPC("Lich", combatTarget{kind: combatTargetPlayer, name: tank.Name})
+	combat.addThreat("Lich", healer.Name, 5)
+	result, err := world.ApplyDamageToNPC("crypt", "Lich", 100)
+	if err != nil || !result.Defeated || len(result.Phases) != 0 {
+		t.Fatalf("expected the lich to fall outright, got %+v, %v", result, err)
+	}
+	claims := world.ClaimBossLoot(tank, "crypt", result)
+	if len(claims) != 2 || len(claims[0].Loot) != 1 || len(claims[1].Loot) != 1 {
+		t.Fatalf("expected both fighters to claim the shard, got %+v", claims)
+	}
+	if len(tank.Inventory) != 1 || len(healer.Inventory) != 1 {
+		t.Fatalf("expected the shard in both packs, got %+v and %+v", tank.Inventory, healer.Inventory)
+	}
+	lockouts := world.BossLockouts(healer)
+	if len(lockouts) != 1 || lockouts[0].Boss != "Lich" || time.Until(lockouts[0].Until) <= 59*time.Minute {
+		t.Fatalf("expected an hour's lockout, got %+v", lockouts)
+	}
+
+	claims = world.ClaimBossLoot(tank, "crypt", result)
+	if len(claims) != 2 || len(claims[0].Loot)+len(claims[1].Loot) != 0 || len(tank.Inventory) != 1 {
+		t.Fatalf("expected locked out fighters to claim nothing, got %+v", claims)
+	}
+	if output := stripAnsi(strings.Join(drainOutput(tank.Output), "")); !strings.Contains(output, "already claimed") {
+		t.Fatalf("expected the tank to be told about the lockout, got %q", output)
+	}
+	tank.Lockouts["Lich"] = time.Now().Add(-time.Second)
+	if lockouts := world.BossLockouts(tank); len(lockouts) != 0 {
+		t.Fatalf("expected the lapsed lockout to be forgotten, got %+v", lockouts)
+	}
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BountyPerAssault is the bounty added each time a player attacks another
+// player in a protected room.
+const BountyPerAssault = 100
+
+// ErrCrime indicates the attacker was seen assaulting another player in a
+// protected room and now carries a bounty.
+var ErrCrime = errors.New("the guards saw you")
+
+// WantedPlayer is an online player with a bounty on their head.
+type WantedPlayer struct {
+	Name   string
+	Bounty int
+}
+
+func (w *World) protectedLocked(room RoomID) bool {
+	r, ok := w.rooms[room]
+	return ok && r.Protected
+}
+
+// outlawAllowedLocked reports whether attacker may fight target because of
+// a bounty: anyone may hunt a player with a bounty, and a wanted player may
+// strike back at those hunting them.
+func (w *World) outlawAllowedLocked(attacker, target *Player) bool {
+	if target.Bounty > 0 {
+		if target.hunters == nil {
+			target.hunters = make(map[string]bool)
+		}
+		target.hunters[attacker.Name] = true
+		return true
+	}
+	return attacker.Bounty > 0 && attacker.hunters[target.Name]
+}
+
+// commitCrimeLocked raises attacker's bounty for assaulting target.
+func (w *World) commitCrimeLocked(attacker, target *Player) error {
+	attacker.Bounty += BountyPerAssault
+	return fmt.Errorf("%w attack %s! A bounty of %d gold is now on your head", ErrCrime, target.Name, attacker.Bounty)
+}
+
+// claimBountyLocked pays hunter the bounty on a defeated criminal and clears
+// it.
+func (w *World) claimBountyLocked(hunter, criminal *Player) int {
+	bounty := criminal.Bounty
+	hunter.Gold += bounty
+	w.recordGoldLocked(EconomyBounty, bounty)
+	criminal.Bounty = 0
+	criminal.hunters = nil
+	return bounty
+}
+
+func announceBounty(w *World, msg string) {
+	w.BroadcastSystem(Ansi("\r\n" + Style("[Bounty] ", AnsiYellow, AnsiBold) + msg))
+}
+
+// reportCrime saves the criminal's new bounty, posts it, and sets any guards
+// in the room upon them.
+func (w *World) reportCrime(p *Player) {
+	w.PersistPlayer(p)
+	announceBounty(w, fmt.Sprintf("A bounty of %d gold is posted on %s.", w.Bounty(p), HighlightName(p.Name)))
+	w.AlertGuards(p)
+}
+
+// bountyClaimed saves both players after a bounty is paid out and announces
+// it.
+func (w *World) bountyClaimed(hunter *Player, result *PlayerDamageResult) {
+	if result == nil || result.Bounty <= 0 {
+		return
+	}
+	w.PersistPlayer(hunter)
+	w.PersistPlayer(result.Target)
+	announceBounty(w, fmt.Sprintf("%s collects the %d gold bounty on %s.", HighlightName(hunter.Name), result.Bounty, HighlightName(result.Target.Name)))
+}
+
+// AlertGuards sets every guard in p's room upon p when p has a bounty.
+func (w *World) AlertGuards(p *Player) {
+	w.mu.RLock()
+	room, ok := w.rooms[p.Room]
+	if !ok || p.Bounty <= 0 || !p.Alive || room.Peaceful {
+		w.mu.RUnlock()
+		return
+	}
+	var guards []string
+	for _, npc := range room.NPCs {
+		if npc.Guard {
+			guards = append(guards, npc.Name)
+		}
+	}
+	w.mu.RUnlock()
+	w.setNPCsUpon(p, guards, "%s shouts, \"Halt, criminal!\" and attacks you!", "%s shouts, \"Halt, criminal!\" and attacks %s!")
+}
+
+// setNPCsUpon has the named NPCs in p's room attack p. toPlayer is told to
+// p with the NPC's name and toRoom to everyone else with the NPC's and p's.
+func (w *World) setNPCsUpon(p *Player, names []string, toPlayer, toRoom string) {
+	if len(names) == 0 {
+		return
+	}
+	combat := w.ensureCombat(p.Room)
+	for _, name := range names {
+		highlighted := HighlightNPCName(name)
+		if p.Output != nil {
+			p.Output.Send(Ansi("\r\n" + fmt.Sprintf(toPlayer, highlighted)))
+		}
+		w.BroadcastToRoom(p.Room, Ansi("\r\n"+fmt.Sprintf(toRoom, highlighted, HighlightName(p.Name))), p)
+		combat.addNPC(name, combatTarget{kind: combatTargetPlayer, name: p.Name})
+	}
+	combat.mu.Lock()
+	_, fighting := combat.playerTargets[p.Name]
+	combat.mu.Unlock()
+	if !fighting {
+		combat.addPlayer(p.Name, combatTarget{kind: combatTargetNPC, name: names[0]})
+	}
+	combat.startLoop()
+}
+
+// Bounty reports the bounty on p's head.
+func (w *World) Bounty(p *Player) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.Bounty
+}
+
+// WantedPlayers lists online players with a bounty, largest first.
+func (w *World) WantedPlayers() []WantedPlayer {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	var wanted []WantedPlayer
+	for _, p := range w.players {
+		if p.Bounty > 0 {
+			wanted = append(wanted, WantedPlayer{Name: p.Name, Bounty: p.Bounty})
+		}
+	}
+	sort.Slice(wanted, func(i, j int) bool {
+		if wanted[i].Bounty != wanted[j].Bounty {
+			return wanted[i].Bounty > wanted[j].Bounty
+		}
+		return wanted[i].Name < wanted[j].Name
+	})
+	return wanted
+}
+
+// SetBounty sets the bounty on an online player. A bounty of zero pardons
+// them.
+func (w *World) SetBounty(name string, amount int) (*Player, error) {
+	if amount < 0 {
+		return nil, fmt.Errorf("bounty must not be negative")
+	}
+	w.mu.Lock()
+	p, ok := w.findPlayerLocked(name)
+	if !ok {
+		w.mu.Unlock()
+		return nil, fmt.Errorf("no player named %s is online", strings.TrimSpace(name))
+	}
+	p.Bounty = amount
+	if amount == 0 {
+		p.hunters = nil
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return p, nil
+}
+
+// SetRoomProtected marks a room watched by guards, where attacking another
+// player is a crime, or clears the mark.
+func (w *World) SetRoomProtected(id RoomID, protected bool, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.Protected
+		room.Protected = protected
+		return func() { room.Protected = prev }
+	})
+}
+
+func protectedNote(room *Room) string {
+	if !room.Protected || room.Peaceful {
+		return ""
+	}
+	return "Guards keep watch here; attacking another player is a crime."
+}
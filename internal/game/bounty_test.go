@@ -0,0 +1,147 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAssaultInProtectedRoomPostsBounty(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Rogue", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"n": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Protected: true, Exits: map[string]Exit{"s": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Town Guard", Guard: true, Level: 20}}},
+		"alley": {ID: "alley", Title: "Alley", Exits: map[string]Exit{}},
+	})
+	world.AttachAccountManager(accounts)
+	rogue, err := world.addPlayer("Rogue", nil, false, accounts.Profile("Rogue"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	rogue.Output = NewOutputQueue()
+	rogue.Room = "square"
+	victim := &Player{Name: "Victim", Room: "square", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(victim)
+	err = world.StartCombat(rogue, "victim")
+	if !errors.Is(err, ErrCrime) {
+		t.Fatalf("StartCombat = %v, want ErrCrime", err)
+	}
+	if victim.Health != victim.MaxHealth {
+		t.Fatalf("the victim should not be hurt, health %d/%d", victim.Health, victim.MaxHealth)
+	}
+	if rogue.Bounty != BountyPerAssault {
+		t.Fatalf("bounty = %d, want %d", rogue.Bounty, BountyPerAssault)
+	}
+	if saved := accounts.Profile("Rogue").Bounty; saved != BountyPerAssault {
+		t.Fatalf("saved bounty = %d", saved)
+	}
+	combat := world.combatIn("square")
+	if combat == nil || !combat.hasNPC("Town Guard") {
+		t.Fatalf("the guard should attack the criminal")
+	}
+	combat.stopLoop()
+	if output := stripAnsi(strings.Join(drainOutput(victim.Output), "")); !strings.Contains(output, "[Bounty] A bounty of 100 gold is posted on Rogue.") {
+		t.Fatalf("expected the bounty to be announced, got %q", output)
+	}
+
+	rogue.Room, victim.Room = "alley", "alley"
+	if err := world.StartCombat(rogue, "victim"); !errors.Is(err, ErrNotArena) {
+		t.Fatalf("attacks outside protected rooms are refused without a crime, got %v", err)
+	}
+	if rogue.Bounty != BountyPerAssault {
+		t.Fatalf("bounty should not grow outside protected rooms, got %d", rogue.Bounty)
+	}
+}
+
+func TestHunterClaimsBounty(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Rogue", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"n": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Protected: true, Exits: map[string]Exit{"s": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Town Guard", Guard: true, Level: 20}}},
+		"alley": {ID: "alley", Title: "Alley", Exits: map[string]Exit{}},
+	})
+	world.AttachAccountManager(accounts)
+	rogue, err := world.addPlayer("Rogue", nil, false, accounts.Profile("Rogue"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	rogue.Output = NewOutputQueue()
+	rogue.Room = "alley"
+	hunter := &Player{Name: "Victim", Room: "alley", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(hunter)
+	if _, err := world.SetBounty("rogue", 150); err != nil {
+		t.Fatalf("SetBounty: %v", err)
+	}
+	if wanted := world.WantedPlayers(); len(wanted) != 1 || wanted[0].Name != "Rogue" || wanted[0].Bounty != 150 {
+		t.Fatalf("unexpected wanted list %+v", wanted)
+	}
+	if _, err := world.ApplyDamageToPlayer(rogue, "victim", 1); err == nil {
+		t.Fatalf("a criminal may not attack someone who is not hunting them")
+	}
+	if _, err := world.ApplyDamageToPlayer(hunter, "rogue", 1); err != nil {
+		t.Fatalf("hunting a criminal: %v", err)
+	}
+	if _, err := world.ApplyDamageToPlayer(rogue, "victim", 1); err != nil {
+		t.Fatalf("a criminal may strike back at a hunter: %v", err)
+	}
+	result, err := world.ApplyDamageToPlayer(hunter, "rogue", 1000)
+	if err != nil || !result.Defeated || result.Bounty != 150 {
+		t.Fatalf("claiming the bounty = %+v, %v", result, err)
+	}
+	if hunter.Gold != 150 || rogue.Bounty != 0 {
+		t.Fatalf("hunter gold %d, criminal bounty %d", hunter.Gold, rogue.Bounty)
+	}
+	if saved := accounts.Profile("Rogue").Bounty; saved != 0 {
+		t.Fatalf("saved bounty = %d, want 0", saved)
+	}
+	rogue.Room = "alley"
+	if _, err := world.ApplyDamageToPlayer(hunter, "rogue", 1); !errors.Is(err, ErrNotArena) {
+		t.Fatalf("a pardoned player is protected again, got %v", err)
+	}
+}
+
+func TestGuardsClearBountyOnDefeat(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Rogue", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"n": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Protected: true, Exits: map[string]Exit{"s": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Town Guard", Guard: true, Level: 20}}},
+		"alley": {ID: "alley", Title: "Alley", Exits: map[string]Exit{}},
+	})
+	world.AttachAccountManager(accounts)
+	rogue, err := world.addPlayer("Rogue", nil, false, accounts.Profile("Rogue"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	rogue.Output = NewOutputQueue()
+	rogue.Room = "square"
+	rogue.Bounty = 100
+	result, err := world.ApplyDamageFromNPC("square", "Town Guard", rogue, 1000)
+	if err != nil || !result.Defeated {
+		t.Fatalf("ApplyDamageFromNPC = %+v, %v", result, err)
+	}
+	if result.Bounty != 100 || rogue.Bounty != 0 {
+		t.Fatalf("guard kill should clear the bounty, result %d, left %d", result.Bounty, rogue.Bounty)
+	}
+}
@@ -0,0 +1,334 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBridgePollInterval controls how often Discord is polled for new
+	// messages when a bot token is configured.
+	DefaultBridgePollInterval = 5 * time.Second
+	discordAPIBase            = "https://discord.com/api/v10"
+	bridgeQueueSize           = 64
+	bridgeMessageLimit        = 400
+)
+
+// DiscordBridgeConfig describes how chat channels are relayed to Discord.
+// WebhookURL enables outbound relaying, while BotToken and ChannelID enable
+// inbound messages.
+type DiscordBridgeConfig struct {
+	WebhookURL   string
+	BotToken     string
+	ChannelID    string
+	Channels     []Channel
+	PollInterval time.Duration
+}
+
+// BridgeStatus summarises the live state of the Discord bridge.
+type BridgeStatus struct {
+	Enabled  bool
+	Outbound bool
+	Inbound  bool
+	Channels []Channel
+}
+
+type bridgeMessage struct {
+	channel Channel
+	speaker string
+	text    string
+}
+
+type discordMessage struct {
+	ID        string `json:"id"`
+	Content   string `json:"content"`
+	WebhookID string `json:"webhook_id"`
+	Author    struct {
+		Username   string `json:"username"`
+		GlobalName string `json:"global_name"`
+		Bot        bool   `json:"bot"`
+	} `json:"author"`
+}
+
+// DiscordBridge relays selected chat channels to a Discord webhook and injects
+// messages from a Discord channel back into the game.
+type DiscordBridge struct {
+	world    *World
+	cfg      DiscordBridgeConfig
+	channels map[Channel]bool
+	client   *http.Client
+	apiBase  string
+
+	mu      sync.Mutex
+	enabled bool
+	primed  bool
+	lastID  string
+
+	outbound    chan bridgeMessage
+	unsubscribe func()
+	stop        chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+}
+
+// NewDiscordBridge validates the configuration and prepares a bridge. Call
+// Start to begin relaying.
+func NewDiscordBridge(world *World, cfg DiscordBridgeConfig) (*DiscordBridge, error) {
+	if world == nil {
+		return nil, fmt.Errorf("bridge requires world reference")
+	}
+	cfg.WebhookURL = strings.TrimSpace(cfg.WebhookURL)
+	cfg.BotToken = strings.TrimSpace(cfg.BotToken)
+	cfg.ChannelID = strings.TrimSpace(cfg.ChannelID)
+	if cfg.WebhookURL == "" && (cfg.BotToken == "" || cfg.ChannelID == "") {
+		return nil, fmt.Errorf("bridge requires a webhook URL or a bot token with a channel id")
+	}
+	if len(cfg.Channels) == 0 {
+		cfg.Channels = []Channel{ChannelOOC}
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultBridgePollInterval
+	}
+	channels := make(map[Channel]bool, len(cfg.Channels))
+	for _, channel := range cfg.Channels {
+		channels[channel] = true
+	}
+	return &DiscordBridge{
+		world:    world,
+		cfg:      cfg,
+		channels: channels,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		apiBase:  discordAPIBase,
+		enabled:  true,
+		outbound: make(chan bridgeMessage, bridgeQueueSize),
+		stop:     make(chan struct{}),
+	}, nil
+}
+
+// ParseBridgeChannels converts a comma separated channel list into channels.
+func ParseBridgeChannels(list string) ([]Channel, error) {
+	var channels []Channel
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		channel, ok := ChannelFromString(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown channel: %s", name)
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// Start launches the relay goroutines.
+func (b *DiscordBridge) Start() {
+	if b.cfg.WebhookURL != "" {
+		b.unsubscribe = b.world.Subscribe(EventChatMessage, func(ev GameEvent) {
+			b.Relay(ev.Channel, ev.Speaker, ev.Text)
+		})
+		b.wg.Add(1)
+		go b.sendLoop()
+	}
+	if b.cfg.BotToken != "" && b.cfg.ChannelID != "" {
+		b.wg.Add(1)
+		go b.pollLoop()
+	}
+}
+
+// Close stops the bridge and waits for the relay goroutines to exit.
+func (b *DiscordBridge) Close() error {
+	b.stopOnce.Do(func() {
+		if b.unsubscribe != nil {
+			b.unsubscribe()
+		}
+		close(b.stop)
+	})
+	b.wg.Wait()
+	return nil
+}
+
+// SetEnabled pauses or resumes relaying in both directions.
+func (b *DiscordBridge) SetEnabled(enabled bool) {
+	b.mu.Lock()
+	b.enabled = enabled
+	b.mu.Unlock()
+}
+
+// Enabled reports whether the bridge is currently relaying.
+func (b *DiscordBridge) Enabled() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.enabled
+}
+
+// Status describes the bridge configuration and whether it is active.
+func (b *DiscordBridge) Status() BridgeStatus {
+	channels := make([]Channel, 0, len(b.channels))
+	for channel := range b.channels {
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i] < channels[j] })
+	return BridgeStatus{
+		Enabled:  b.Enabled(),
+		Outbound: b.cfg.WebhookURL != "",
+		Inbound:  b.cfg.BotToken != "" && b.cfg.ChannelID != "",
+		Channels: channels,
+	}
+}
+
+// Relay queues a chat line for delivery to Discord. Messages are dropped when
+// the bridge is paused, the channel is not bridged, or the queue is full.
+func (b *DiscordBridge) Relay(channel Channel, speaker, text string) {
+	if b.cfg.WebhookURL == "" || !b.channels[channel] || !b.Enabled() {
+		return
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	select {
+	case b.outbound <- bridgeMessage{channel: channel, speaker: speaker, text: text}:
+	default:
+	}
+}
+
+func (b *DiscordBridge) sendLoop() {
+	defer b.wg.Done()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case msg := <-b.outbound:
+			if err := b.sendWebhook(msg); err != nil {
+				Logger().Warn("discord bridge relay failed", "error", err)
+			}
+		}
+	}
+}
+
+func (b *DiscordBridge) sendWebhook(msg bridgeMessage) error {
+	payload := map[string]any{
+		"username":         msg.speaker,
+		"content":          fmt.Sprintf("[%s] %s", strings.ToUpper(string(msg.channel)), msg.text),
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := b.client.Post(b.cfg.WebhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("send webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (b *DiscordBridge) pollLoop() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if b.Enabled() {
+			if err := b.poll(); err != nil {
+				Logger().Warn("discord bridge poll failed", "error", err)
+			}
+		}
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches new Discord messages and injects them into the first bridged
+// channel. The first successful poll only records the newest message so the
+// channel backlog is not replayed.
+func (b *DiscordBridge) poll() error {
+	b.mu.Lock()
+	after := b.lastID
+	primed := b.primed
+	b.mu.Unlock()
+
+	url := fmt.Sprintf("%s/channels/%s/messages?limit=1", b.apiBase, b.cfg.ChannelID)
+	if primed {
+		url = fmt.Sprintf("%s/channels/%s/messages?limit=50", b.apiBase, b.cfg.ChannelID)
+		if after != "" {
+			url += "&after=" + after
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+b.cfg.BotToken)
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("poll channel: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("poll channel: unexpected status %s", resp.Status)
+	}
+	var messages []discordMessage
+	if err := json.NewDecoder(resp.Body).Decode(&messages); err != nil {
+		return fmt.Errorf("decode messages: %w", err)
+	}
+	// Discord returns the newest message first.
+	sort.Slice(messages, func(i, j int) bool {
+		return snowflakeLess(messages[i].ID, messages[j].ID)
+	})
+	b.mu.Lock()
+	b.primed = true
+	if len(messages) > 0 {
+		b.lastID = messages[len(messages)-1].ID
+	}
+	b.mu.Unlock()
+	if !primed {
+		return nil
+	}
+	for _, msg := range messages {
+		b.inject(msg)
+	}
+	return nil
+}
+
+func snowflakeLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func (b *DiscordBridge) inject(msg discordMessage) {
+	if msg.Author.Bot || msg.WebhookID != "" {
+		return
+	}
+	content := strings.TrimSpace(sanitizeInput(msg.Content))
+	if content == "" {
+		return
+	}
+	if runes := []rune(content); len(runes) > bridgeMessageLimit {
+		content = string(runes[:bridgeMessageLimit]) + "..."
+	}
+	author := strings.TrimSpace(sanitizeInput(msg.Author.GlobalName))
+	if author == "" {
+		author = strings.TrimSpace(sanitizeInput(msg.Author.Username))
+	}
+	channel := b.cfg.Channels[0]
+	tag := Style("[Discord]", AnsiBlue, AnsiBold)
+	line := Ansi(fmt.Sprintf("\r\n%s %s: %s", tag, HighlightName(author), content))
+	b.world.BroadcastToAllChannel(line, nil, channel)
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiscordBridgeRelaysOutbound(t *testing.T) {
+	received := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		received <- payload
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	bridge, err := NewDiscordBridge(world, DiscordBridgeConfig{WebhookURL: server.URL, Channels: []Channel{ChannelOOC}})
+	if err != nil {
+		t.Fatalf("NewDiscordBridge error: %v", err)
+	}
+	world.AttachBridge(bridge)
+	bridge.Start()
+	defer bridge.Close()
+
+	world.RelayChannelMessage(ChannelYell, "Alice", "not bridged")
+	world.RelayChannelMessage(ChannelOOC, "Alice", "hello discord")
+
+	select {
+	case payload := <-received:
+		if payload["username"] != "Alice" || payload["content"] != "[OOC] hello discord" {
+			t.Fatalf("unexpected webhook payload: %#v", payload)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("webhook was not called")
+	}
+
+	bridge.SetEnabled(false)
+	world.RelayChannelMessage(ChannelOOC, "Alice", "while paused")
+	select {
+	case payload := <-received:
+		t.Fatalf("paused bridge relayed %#v", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestDiscordBridgeInjectsInbound(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		calls++
+		call := calls
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch call {
+		case 1:
+			_, _ = w.Write([]byte(`[{"id":"100","content":"old history","author":{"username":"bob"}}]`))
+		default:
+			if r.URL.Query().Get("after") != "100" {
+				t.Errorf("expected after=100, got %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[
+				{"id":"102","content":"echo","webhook_id":"9","author":{"username":"Alice","bot":true}},
+				{"id":"101","content":"hi from discord","author":{"username":"bob","global_name":"Bobby"}}
+			]`))
+		}
+	}))
+	defer server.Close()
+
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	listener := &Player{Name: "Listener", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(listener)
+	bridge, err := NewDiscordBridge(world, DiscordBridgeConfig{BotToken: "secret", ChannelID: "42"})
+	if err != nil {
+		t.Fatalf("NewDiscordBridge error: %v", err)
+	}
+	bridge.apiBase = server.URL
+
+	if err := bridge.poll(); err != nil {
+		t.Fatalf("first poll error: %v", err)
+	}
+	if msgs := drainOutput(listener.Output); len(msgs) != 0 {
+		t.Fatalf("history should not be replayed, got %q", msgs)
+	}
+	if err := bridge.poll(); err != nil {
+		t.Fatalf("second poll error: %v", err)
+	}
+	msgs := drainOutput(listener.Output)
+	if len(msgs) != 1 {
+		t.Fatalf("expected only the inbound message to be injected, not webhook echoes, got %q", msgs)
+	}
+	if !strings.Contains(msgs[0], "Bobby") || !strings.Contains(msgs[0], "hi from discord") {
+		t.Fatalf("unexpected injected message: %q", msgs[0])
+	}
+}
@@ -0,0 +1,159 @@
+package game
+
+import "sync"
+
+// EventType names something that happened in the world, such as a player
+// moving or an NPC being killed.
+type EventType string
+
+const (
+	// EventPlayerMoved fires after a player walks through an exit. From,
+	// Room, and Direction describe the step.
+	EventPlayerMoved EventType = "player.moved"
+	// EventPlayerEntered fires once a player has been shown Room, however
+	// they arrived: walking, recall, teleport, or logging in. Via names
+	// where they came from when known.
+	EventPlayerEntered EventType = "player.entered"
+	// EventNPCKilled fires after a player defeats an NPC in Room.
+	EventNPCKilled EventType = "npc.killed"
+	// EventItemTaken fires after a player picks up Item from Room, or from
+	// Container when it was taken out of one.
+	EventItemTaken EventType = "item.taken"
+	// EventChatMessage fires when Speaker talks on a global Channel. Player
+	// is set when the speaker is online.
+	EventChatMessage EventType = "chat.message"
+	// EventItemWritten fires after Player writes Text as a new page on Item
+	// in Room, so moderation tools can review what players write.
+	EventItemWritten EventType = "item.written"
+)
+
+// GameEvent is published on the world's event bus. Only the fields that
+// apply to its Type are set.
+type GameEvent struct {
+	Type      EventType
+	Player    *Player
+	Room      RoomID
+	From      RoomID
+	Direction string
+	Via       string
+	NPC       NPC
+	Item      Item
+	Container string
+	Speaker   string
+	Channel   Channel
+	Text      string
+}
+
+// EventHandler receives published events.
+type EventHandler func(GameEvent)
+
+// EventBus delivers game events to the subsystems subscribed to them. The
+// zero value is ready to use.
+type EventBus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   map[EventType][]eventSubscription
+}
+
+type eventSubscription struct {
+	id      int
+	handler EventHandler
+}
+
+// Subscribe registers handler for events of type t and returns a function
+// that removes it again.
+func (b *EventBus) Subscribe(t EventType, handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[EventType][]eventSubscription)
+	}
+	b.nextID++
+	id := b.nextID
+	b.subs[t] = append(b.subs[t], eventSubscription{id: id, handler: handler})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			subs := b.subs[t]
+			for i, sub := range subs {
+				if sub.id == id {
+					b.subs[t] = append(subs[:i:i], subs[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// Publish calls every handler subscribed to ev.Type, in the order they
+// subscribed, on the calling goroutine. A handler that panics is logged and
+// skipped so one faulty subscriber cannot break the rest.
+func (b *EventBus) Publish(ev GameEvent) {
+	b.mu.RLock()
+	subs := b.subs[ev.Type]
+	b.mu.RUnlock()
+	for _, sub := range subs {
+		deliverEvent(sub.handler, ev)
+	}
+}
+
+func deliverEvent(handler EventHandler, ev GameEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			Logger().Error("event handler panic", "event", string(ev.Type), "panic", r)
+		}
+	}()
+	handler(ev)
+}
+
+// Subscribe registers handler on the world's event bus. Handlers run on the
+// goroutine that published the event, never with the world lock held, so
+// they may call back into the world.
+func (w *World) Subscribe(t EventType, handler EventHandler) func() {
+	w.busOnce.Do(w.subscribeCoreEvents)
+	return w.bus.Subscribe(t, handler)
+}
+
+// Publish announces ev to every subscriber. Callers must not hold the world
+// lock.
+func (w *World) Publish(ev GameEvent) {
+	w.busOnce.Do(w.subscribeCoreEvents)
+	w.bus.Publish(ev)
+}
+
+// subscribeCoreEvents wires the built-in subsystems that react to game
+// events. They subscribe in the order their messages should reach the
+// player.
+func (w *World) subscribeCoreEvents() {
+	w.bus.Subscribe(EventNPCKilled, func(ev GameEvent) {
+		sendEventLines(ev.Player, FormatQuestUpdates(w.RecordNPCKill(ev.Player, ev.NPC)))
+	})
+	w.bus.Subscribe(EventNPCKilled, func(ev GameEvent) {
+		sendEventLines(ev.Player, FormatReputationChanges(w.RecordFactionKill(ev.Player, ev.NPC)))
+	})
+	w.bus.Subscribe(EventNPCKilled, func(ev GameEvent) {
+		w.ClaimBossLoot(ev.Player, ev.Room, &NPCDamageResult{NPC: ev.NPC, Defeated: true})
+	})
+	w.bus.Subscribe(EventNPCKilled, func(ev GameEvent) {
+		w.RecordKill(ev.Player)
+	})
+	w.bus.Subscribe(EventPlayerEntered, func(ev GameEvent) {
+		sendEventLines(ev.Player, FormatQuestUpdates(w.RecordRoomVisit(ev.Player)))
+	})
+	w.bus.Subscribe(EventPlayerEntered, func(ev GameEvent) {
+		w.RecordExploration(ev.Player)
+	})
+}
+
+// sendEventLines shows a subscriber's messages to the player the event is
+// about.
+func sendEventLines(p *Player, lines []string) {
+	if p == nil || p.Output == nil {
+		return
+	}
+	for _, line := range lines {
+		p.Output.Send(Ansi("\r\n" + line))
+	}
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventBusDeliversInOrderAndUnsubscribes(t *testing.T) {
+	var bus EventBus
+	var got []string
+	first := bus.Subscribe(EventChatMessage, func(ev GameEvent) { got = append(got, "first:"+ev.Text) })
+	bus.Subscribe(EventChatMessage, func(ev GameEvent) { panic("broken subscriber") })
+	bus.Subscribe(EventChatMessage, func(ev GameEvent) { got = append(got, "last:"+ev.Text) })
+	bus.Subscribe(EventItemTaken, func(ev GameEvent) { got = append(got, "item") })
+
+	bus.Publish(GameEvent{Type: EventChatMessage, Text: "hi"})
+	first()
+	first()
+	bus.Publish(GameEvent{Type: EventChatMessage, Text: "again"})
+
+	want := []string{"first:hi", "last:hi", "last:again"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestWorldPublishesMovesAndPickups(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Exits: map[string]Exit{"north": {To: "hall"}}},
+		"hall":  {ID: "hall", Exits: map[string]Exit{}, Items: []Item{{Name: "Lantern"}}},
+	})
+	p := &Player{Name: "Hero", Room: "start", Output: NewOutputQueue(), Alive: true}
+	p.EnsureStats()
+	world.AddPlayerForTest(p)
+
+	var events []GameEvent
+	record := func(ev GameEvent) { events = append(events, ev) }
+	world.Subscribe(EventPlayerMoved, record)
+	world.Subscribe(EventItemTaken, record)
+
+	if _, err := world.Move(p, "north"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := world.TakeItem(p, "lantern"); err != nil {
+		t.Fatalf("TakeItem: %v", err)
+	}
+	if _, err := world.TakeItem(p, "lantern"); err == nil {
+		t.Fatalf("expected second pickup to fail")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected two events, got %#v", events)
+	}
+	moved := events[0]
+	if moved.Type != EventPlayerMoved || moved.Player != p || moved.From != "start" || moved.Room != "hall" || moved.Direction != "north" {
+		t.Fatalf("unexpected move event %#v", moved)
+	}
+	taken := events[1]
+	if taken.Type != EventItemTaken || taken.Room != "hall" || taken.Item.Name != "Lantern" {
+		t.Fatalf("unexpected pickup event %#v", taken)
+	}
+}
+
+func TestNPCKilledEventCountsTowardAchievements(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	p := &Player{Name: "Hero", Room: "start", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	var seen string
+	world.Subscribe(EventNPCKilled, func(ev GameEvent) { seen = ev.NPC.Name })
+	world.Publish(GameEvent{Type: EventNPCKilled, Player: p, Room: "start", NPC: NPC{Name: "Rat"}})
+
+	if p.Kills != 1 {
+		t.Fatalf("expected the built-in subscriber to count the kill, got %d", p.Kills)
+	}
+	if seen != "Rat" {
+		t.Fatalf("expected custom subscriber to see the kill, got %q", seen)
+	}
+}
+
+func TestQuestsTrackKillsAndVisitsThroughTheBus(t *testing.T) {
+	quest := &Quest{
+		ID:            "patrol",
+		Name:          "Patrol",
+		Giver:         "Guide",
+		RequiredKills: []QuestKillRequirement{{NPC: "Rat", Count: 1}},
+		VisitRooms:    []string{"dock"},
+	}
+	normalizeQuest(quest)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Start", NPCs: []NPC{{Name: "Guide"}}, Exits: map[string]Exit{}},
+		"dock":  {ID: "dock", Title: "Old Dock", Exits: map[string]Exit{}},
+	})
+	world.quests = map[string]*Quest{"patrol": quest}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	p := &Player{Name: "Hero", Room: "start", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if _, err := world.AcceptQuest(p, "patrol"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	drainOutput(p.Output)
+
+	world.Publish(GameEvent{Type: EventNPCKilled, Player: p, Room: "start", NPC: NPC{Name: "Rat"}})
+	if progress := p.QuestLog["patrol"]; progress == nil || progress.KillCounts["rat"] != 1 {
+		t.Fatalf("expected the kill to count toward the quest, got %+v", progress)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(p.Output), "")); !strings.Contains(out, "Rat") {
+		t.Fatalf("expected a quest update for the kill, got %q", out)
+	}
+
+	var entered []RoomID
+	world.Subscribe(EventPlayerEntered, func(ev GameEvent) { entered = append(entered, ev.Room) })
+	p.Room = "dock"
+	EnterRoom(world, p, "the sky")
+	if len(entered) != 1 || entered[0] != "dock" {
+		t.Fatalf("expected one entry event for the dock, got %v", entered)
+	}
+	if !p.Explored["dock"] || !p.QuestLog["patrol"].Visited["dock"] {
+		t.Fatalf("expected the teleport to count as exploring and visiting the dock")
+	}
+}
@@ -1,6 +1,9 @@
 package game
 
-import "strings"
+import (
+	"strings"
+	"sync"
+)
 
 // Channel identifies one of the available communication mediums.
 type Channel string
@@ -10,15 +13,24 @@ const (
 	ChannelWhisper Channel = "whisper"
 	ChannelYell    Channel = "yell"
 	ChannelOOC     Channel = "ooc"
+	// ChannelAmbient carries weather, time-of-day, and room ambience messages.
+	ChannelAmbient Channel = "ambient"
+	// ChannelClan carries chat between the members of a clan.
+	ChannelClan Channel = "clan"
+	// ChannelLog carries server warnings and errors to connected admins. It
+	// is toggled with the log command rather than the player channel list.
+	ChannelLog Channel = "log"
 )
 
-var allChannels = []Channel{ChannelSay, ChannelWhisper, ChannelYell, ChannelOOC}
+var allChannels = []Channel{ChannelSay, ChannelWhisper, ChannelYell, ChannelOOC, ChannelAmbient, ChannelClan}
 
 var channelLookup = map[string]Channel{
 	"say":     ChannelSay,
 	"whisper": ChannelWhisper,
 	"yell":    ChannelYell,
 	"ooc":     ChannelOOC,
+	"ambient": ChannelAmbient,
+	"clan":    ChannelClan,
 }
 
 var baseChannelSettings = map[Channel]bool{
@@ -26,6 +38,25 @@ var baseChannelSettings = map[Channel]bool{
 	ChannelWhisper: true,
 	ChannelYell:    true,
 	ChannelOOC:     true,
+	ChannelAmbient: true,
+	ChannelClan:    true,
+}
+
+// channelDefaults are the channels new characters start with enabled. They
+// begin as baseChannelSettings and may be changed by the server config.
+var (
+	channelDefaultsMu sync.RWMutex
+	channelDefaults   = baseChannelSettings
+)
+
+// setChannelDefaults replaces the channels new characters start with.
+func setChannelDefaults(settings map[Channel]bool) {
+	if settings == nil {
+		settings = baseChannelSettings
+	}
+	channelDefaultsMu.Lock()
+	channelDefaults = cloneChannelSettings(settings)
+	channelDefaultsMu.Unlock()
 }
 
 // AllChannels returns the set of available chat channels.
@@ -42,12 +73,14 @@ func ChannelFromString(name string) (Channel, bool) {
 }
 
 func defaultChannelSettings() map[Channel]bool {
-	return cloneChannelSettings(baseChannelSettings)
+	channelDefaultsMu.RLock()
+	defer channelDefaultsMu.RUnlock()
+	return cloneChannelSettings(channelDefaults)
 }
 
 // DefaultChannelSettings exposes the default channel configuration.
 func DefaultChannelSettings() map[Channel]bool {
-	return cloneChannelSettings(baseChannelSettings)
+	return defaultChannelSettings()
 }
 
 func cloneChannelSettings(settings map[Channel]bool) map[Channel]bool {
@@ -115,6 +148,8 @@ func decodeChannelSettings(raw map[string]bool) map[Channel]bool {
 	for name, enabled := range raw {
 		if channel, ok := channelLookup[name]; ok {
 			settings[channel] = enabled
+		} else if name == string(ChannelLog) {
+			settings[ChannelLog] = enabled
 		}
 	}
 	return settings
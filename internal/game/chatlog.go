@@ -0,0 +1,261 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultChatLogLines is how many messages each global channel keeps.
+	DefaultChatLogLines = 500
+	// DefaultChatLogRetention is how long global channel messages are kept.
+	DefaultChatLogRetention = 7 * 24 * time.Hour
+
+	chatLogExt = ".jsonl"
+)
+
+// ChatLogConfig controls where global channel logs are stored and how much
+// of them is kept. Lines bounds each channel; a zero Retention keeps
+// messages until they are pushed out by newer ones.
+type ChatLogConfig struct {
+	Dir       string
+	Lines     int
+	Retention time.Duration
+}
+
+// ChatLogEntry records one message sent on a global channel.
+type ChatLogEntry struct {
+	Time    time.Time `json:"time"`
+	Channel Channel   `json:"channel"`
+	Speaker string    `json:"speaker,omitempty"`
+	Message string    `json:"message"`
+}
+
+// ChatLog keeps a bounded, on-disk scrollback of the global chat channels,
+// one append-only file per channel.
+type ChatLog struct {
+	mu        sync.Mutex
+	dir       string
+	lines     int
+	retention time.Duration
+	entries   map[Channel][]ChatLogEntry
+	written   map[Channel]int
+}
+
+// NewChatLog loads any existing channel logs from cfg.Dir, dropping messages
+// older than the retention window. When Dir is empty the log operates purely
+// in-memory without persistence.
+func NewChatLog(cfg ChatLogConfig) (*ChatLog, error) {
+	log := &ChatLog{
+		dir:       strings.TrimSpace(cfg.Dir),
+		lines:     cfg.Lines,
+		retention: cfg.Retention,
+		entries:   make(map[Channel][]ChatLogEntry),
+		written:   make(map[Channel]int),
+	}
+	if log.lines <= 0 {
+		log.lines = DefaultChatLogLines
+	}
+	if log.dir == "" {
+		return log, nil
+	}
+	files, err := os.ReadDir(log.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read chat logs: %w", err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, chatLogExt) {
+			continue
+		}
+		channel, ok := ChannelFromString(strings.TrimSuffix(name, chatLogExt))
+		if !ok {
+			continue
+		}
+		entries, read, err := readChatLogFile(filepath.Join(log.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		log.entries[channel] = log.pruneLocked(entries, time.Now())
+		log.written[channel] = read
+		if len(log.entries[channel]) != read {
+			if err := log.compactLocked(channel); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return log, nil
+}
+
+func readChatLogFile(path string) ([]ChatLogEntry, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read chat log: %w", err)
+	}
+	var entries []ChatLogEntry
+	lines := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		lines++
+		var entry ChatLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// A torn final line from a crash is dropped rather than
+			// refusing to start.
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("read chat log: %w", err)
+	}
+	return entries, lines, nil
+}
+
+// pruneLocked drops entries past the retention window and beyond the line
+// bound, oldest first.
+func (c *ChatLog) pruneLocked(entries []ChatLogEntry, now time.Time) []ChatLogEntry {
+	start := 0
+	if c.retention > 0 {
+		cutoff := now.Add(-c.retention)
+		for start < len(entries) && entries[start].Time.Before(cutoff) {
+			start++
+		}
+	}
+	if excess := len(entries) - start - c.lines; excess > 0 {
+		start += excess
+	}
+	if start == 0 {
+		return entries
+	}
+	return append([]ChatLogEntry(nil), entries[start:]...)
+}
+
+func (c *ChatLog) path(channel Channel) string {
+	return filepath.Join(c.dir, string(channel)+chatLogExt)
+}
+
+// compactLocked rewrites a channel's file to hold only the retained entries.
+func (c *ChatLog) compactLocked(channel Channel) error {
+	var buf bytes.Buffer
+	for _, entry := range c.entries[channel] {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encode chat log: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := (fileStorage{}).Write(c.path(channel), buf.Bytes()); err != nil {
+		return err
+	}
+	c.written[channel] = len(c.entries[channel])
+	return nil
+}
+
+// Append records a message on channel. The file only grows to twice the
+// line bound before it is compacted, so appends stay cheap.
+func (c *ChatLog) Append(channel Channel, speaker, message string) error {
+	if c == nil {
+		return nil
+	}
+	entry := ChatLogEntry{
+		Time:    time.Now().UTC(),
+		Channel: channel,
+		Speaker: strings.TrimSpace(speaker),
+		Message: message,
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[channel] = c.pruneLocked(append(c.entries[channel], entry), entry.Time)
+	if c.dir == "" {
+		return nil
+	}
+	if c.written[channel]+1 > 2*c.lines {
+		return c.compactLocked(channel)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode chat log: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	file, err := os.OpenFile(c.path(channel), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open chat log: %w", err)
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write chat log: %w", err)
+	}
+	c.written[channel]++
+	return nil
+}
+
+// Recent returns up to limit of the newest messages on channel, oldest
+// first. A non-positive limit returns everything retained.
+func (c *ChatLog) Recent(channel Channel, limit int) []ChatLogEntry {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := c.pruneLocked(c.entries[channel], time.Now())
+	c.entries[channel] = entries
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	out := make([]ChatLogEntry, len(entries))
+	copy(out, entries)
+	return out
+}
+
+// chatLogged reports whether channel is a global channel kept in the chat
+// log rather than only in each listener's personal history.
+func chatLogged(channel Channel) bool {
+	return channel == ChannelOOC || channel == ChannelYell
+}
+
+// AttachChatLog configures the world to keep global channel scrollback.
+func (w *World) AttachChatLog(log *ChatLog) {
+	w.mu.Lock()
+	w.chatLog = log
+	w.mu.Unlock()
+}
+
+// ChatLog exposes the attached global channel log.
+func (w *World) ChatLog() *ChatLog {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.chatLog
+}
+
+// recordChatLogLocked appends a broadcast on a global channel to the chat
+// log. Callers must hold w.mu.
+func (w *World) recordChatLogLocked(channel Channel, speaker, msg string) {
+	if w.chatLog == nil || !chatLogged(channel) {
+		return
+	}
+	if err := w.chatLog.Append(channel, speaker, msg); err != nil {
+		Logger().Warn("chat log append failed", "channel", channel, "error", err)
+	}
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChatLogPersistsAndBoundsScrollback(t *testing.T) {
+	dir := t.TempDir()
+	log, err := NewChatLog(ChatLogConfig{Dir: dir, Lines: 3})
+	if err != nil {
+		t.Fatalf("NewChatLog error: %v", err)
+	}
+	for _, msg := range []string{"one", "two", "three", "four", "five", "six", "seven"} {
+		if err := log.Append(ChannelOOC, "Alice", msg); err != nil {
+			t.Fatalf("Append error: %v", err)
+		}
+	}
+	recent := log.Recent(ChannelOOC, 0)
+	if len(recent) != 3 || recent[0].Message != "five" || recent[2].Message != "seven" {
+		t.Fatalf("recent = %+v, want five..seven", recent)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "ooc.jsonl"))
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines > 6 {
+		t.Fatalf("log file has %d lines, want at most twice the bound", lines)
+	}
+
+	reloaded, err := NewChatLog(ChatLogConfig{Dir: dir, Lines: 3})
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	got := reloaded.Recent(ChannelOOC, 2)
+	if len(got) != 2 || got[0].Message != "six" || got[1].Message != "seven" || got[1].Speaker != "Alice" {
+		t.Fatalf("reloaded recent = %+v", got)
+	}
+}
+
+func TestChatLogDropsExpiredMessages(t *testing.T) {
+	dir := t.TempDir()
+	old := ChatLogEntry{Time: time.Now().Add(-48 * time.Hour).UTC(), Channel: ChannelYell, Message: "stale"}
+	fresh := ChatLogEntry{Time: time.Now().UTC(), Channel: ChannelYell, Message: "fresh"}
+	var body []byte
+	for _, entry := range []ChatLogEntry{old, fresh} {
+		data, _ := json.Marshal(entry)
+		body = append(append(body, data...), '\n')
+	}
+	body = append(body, []byte(`{"time":`)...)
+	if err := os.WriteFile(filepath.Join(dir, "yell.jsonl"), body, 0o644); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+	log, err := NewChatLog(ChatLogConfig{Dir: dir, Lines: 10, Retention: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("NewChatLog error: %v", err)
+	}
+	recent := log.Recent(ChannelYell, 0)
+	if len(recent) != 1 || recent[0].Message != "fresh" {
+		t.Fatalf("recent = %+v, want only the fresh message", recent)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "yell.jsonl"))
+	if strings.Contains(string(data), "stale") {
+		t.Fatalf("expired message should be compacted out of the file: %q", data)
+	}
+}
+
+func TestChannelHistoryUsesChatLogForGlobalChannels(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Title: "Start", Exits: map[string]Exit{}}})
+	log, _ := NewChatLog(ChatLogConfig{})
+	world.AttachChatLog(log)
+	speaker := &Player{Name: "Speaker", Room: "start", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(speaker)
+
+	world.BroadcastToAllChannel("\r\n[OOC] Speaker: hello", speaker, ChannelOOC)
+	world.BroadcastToAllChannel("\r\nsomeone says hi", speaker, ChannelSay)
+
+	late := &Player{Name: "Late", Room: "start", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(late)
+	history := world.ChannelHistory(late, ChannelOOC, 10)
+	if len(history) != 1 || !strings.Contains(history[0].Message, "hello") {
+		t.Fatalf("history = %+v, want scrollback from before login", history)
+	}
+	if got := log.Recent(ChannelSay, 0); len(got) != 0 {
+		t.Fatalf("say should not be chat logged, got %+v", got)
+	}
+}
+
+func TestPortalChatLogExportRequiresStaff(t *testing.T) {
+	portal, world, cookie := newTestBuilderPortal(t, PortalRoleModerator)
+	log, _ := NewChatLog(ChatLogConfig{})
+	world.AttachChatLog(log)
+	_ = log.Append(ChannelOOC, "Alice", Ansi("\r\n"+HighlightName("Alice")+": hi"))
+
+	rec := doBuilderRequest(t, portal.handleChatLogAPI, cookie, http.MethodGet, "/api/chatlog?channel=ooc&format=text", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Alice: hi") || strings.Contains(body, "\x1b") {
+		t.Fatalf("unexpected export %q", body)
+	}
+
+	builder, _, builderCookie := newTestBuilderPortal(t, PortalRoleBuilder)
+	rec = doBuilderRequest(t, builder.handleChatLogAPI, builderCookie, http.MethodGet, "/api/chatlog?channel=ooc", nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("builder status = %d, want forbidden", rec.Code)
+	}
+}
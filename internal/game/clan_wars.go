@@ -0,0 +1,562 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ClanWarDuration is how long a war lasts before a truce is called
+	// automatically.
+	ClanWarDuration = 24 * time.Hour
+	// ClanTruceDuration is how long two clans must wait after a war ends
+	// before either may declare war on the other again.
+	ClanTruceDuration = 48 * time.Hour
+	// ClanWarKillScore is the war score for defeating an enemy clan member.
+	ClanWarKillScore = 3
+	// ClanWarCaptureScore is the war score for taking a capture point from
+	// the enemy.
+	ClanWarCaptureScore = 5
+	// CapturePointInterval is how often a held capture point awards its
+	// clan a point.
+	CapturePointInterval = 5 * time.Minute
+	// clanWarTickInterval is how often wars and capture points are checked.
+	clanWarTickInterval = time.Minute
+	// clanWarHistory caps how many finished wars are kept once their truce
+	// has lapsed.
+	clanWarHistory = 10
+)
+
+// ClanWar is a war one clan declared on another. A war ends when its timer
+// runs out or both leaders agree to a truce.
+type ClanWar struct {
+	Attacker      string    `json:"attacker"`
+	Defender      string    `json:"defender"`
+	Declared      time.Time `json:"declared"`
+	Ends          time.Time `json:"ends"`
+	AttackerScore int       `json:"attacker_score"`
+	DefenderScore int       `json:"defender_score"`
+	// TruceOffer names the clan that has offered to end the war early.
+	TruceOffer string    `json:"truce_offer,omitempty"`
+	Ended      time.Time `json:"ended,omitempty"`
+}
+
+// Active reports whether the war is still being fought.
+func (w ClanWar) Active() bool {
+	return w.Ended.IsZero()
+}
+
+func (w ClanWar) involves(clan string) bool {
+	return strings.EqualFold(w.Attacker, clan) || strings.EqualFold(w.Defender, clan)
+}
+
+func (w ClanWar) between(a, b string) bool {
+	return w.involves(a) && w.involves(b) && !strings.EqualFold(a, b)
+}
+
+// Opponent names the clan fighting clan in this war.
+func (w ClanWar) Opponent(clan string) string {
+	if strings.EqualFold(w.Attacker, clan) {
+		return w.Defender
+	}
+	return w.Attacker
+}
+
+// Score is clan's war score.
+func (w ClanWar) Score(clan string) int {
+	if strings.EqualFold(w.Attacker, clan) {
+		return w.AttackerScore
+	}
+	return w.DefenderScore
+}
+
+func (w *ClanWar) addScore(clan string, points int) {
+	if strings.EqualFold(w.Attacker, clan) {
+		w.AttackerScore += points
+	} else {
+		w.DefenderScore += points
+	}
+}
+
+// Winner names the clan with the higher score, or "" for a draw.
+func (w ClanWar) Winner() string {
+	switch {
+	case w.AttackerScore > w.DefenderScore:
+		return w.Attacker
+	case w.DefenderScore > w.AttackerScore:
+		return w.Defender
+	}
+	return ""
+}
+
+// TruceUntil is when the truce after a finished war lapses.
+func (w ClanWar) TruceUntil() time.Time {
+	return w.Ended.Add(ClanTruceDuration)
+}
+
+// CaptureHold records which clan holds a capture point and when it last
+// earned a point.
+type CaptureHold struct {
+	Clan    string    `json:"clan"`
+	Since   time.Time `json:"since"`
+	Awarded time.Time `json:"awarded"`
+}
+
+// CaptureResult describes a capture point changing hands.
+type CaptureResult struct {
+	Clan string
+	// From is the clan that lost the point, if any.
+	From string
+	// War is the war the capture scored in, when taken from an enemy.
+	War *ClanWar
+}
+
+// WarTick reports what a pass over the wars and capture points changed.
+type WarTick struct {
+	// Ended lists wars whose timer ran out.
+	Ended []ClanWar
+	// Awarded maps clan names to the points their capture points earned.
+	Awarded map[string]int
+}
+
+// activeWarLocked returns the index of the active war between clans a and b.
+func (s *ClanSystem) activeWarLocked(a, b string) int {
+	for i, war := range s.wars {
+		if war.Active() && war.between(a, b) {
+			return i
+		}
+	}
+	return -1
+}
+
+// leaderClanLocked returns the clan p leads.
+func (s *ClanSystem) leaderClanLocked(player string) (*Clan, error) {
+	_, clan := s.clanOfLocked(player)
+	if clan == nil {
+		return nil, ErrNotInClan
+	}
+	if rank, _ := clan.Rank(player); rank != ClanLeader {
+		return nil, ErrClanRank
+	}
+	return clan, nil
+}
+
+// Wars lists the wars under way and those whose truce still holds or that
+// finished recently, newest first.
+func (s *ClanSystem) Wars() []ClanWar {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]ClanWar, len(s.wars))
+	copy(out, s.wars)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Declared.After(out[j].Declared) })
+	return out
+}
+
+// Holdings reports which clan holds each capture point.
+func (s *ClanSystem) Holdings() map[RoomID]CaptureHold {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[RoomID]CaptureHold, len(s.holdings))
+	for room, hold := range s.holdings {
+		out[room] = hold
+	}
+	return out
+}
+
+// AtWar reports whether the clans of players a and b are at war.
+func (s *ClanSystem) AtWar(a, b string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, first := s.clanOfLocked(a)
+	_, second := s.clanOfLocked(b)
+	if first == nil || second == nil {
+		return false
+	}
+	return s.activeWarLocked(first.Name, second.Name) >= 0
+}
+
+// DeclareWar lets the leader of one clan declare war on another. Clans may
+// not fight while a truce from their last war holds.
+func (s *ClanSystem) DeclareWar(leader, target string, now time.Time) (ClanWar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clan, err := s.leaderClanLocked(leader)
+	if err != nil {
+		return ClanWar{}, err
+	}
+	enemy, ok := s.clans[strings.ToLower(strings.Join(strings.Fields(target), " "))]
+	if !ok {
+		return ClanWar{}, ErrClanNotFound
+	}
+	if enemy == clan {
+		return ClanWar{}, fmt.Errorf("you cannot declare war on your own clan")
+	}
+	for _, war := range s.wars {
+		if !war.between(clan.Name, enemy.Name) {
+			continue
+		}
+		if war.Active() {
+			return ClanWar{}, fmt.Errorf("you are already at war with %s", enemy.Name)
+		}
+		if now.Before(war.TruceUntil()) {
+			return ClanWar{}, fmt.Errorf("a truce with %s holds until %s", enemy.Name, war.TruceUntil().Local().Format("2006-01-02 15:04"))
+		}
+	}
+	war := ClanWar{Attacker: clan.Name, Defender: enemy.Name, Declared: now.UTC(), Ends: now.Add(ClanWarDuration).UTC()}
+	s.wars = append(s.wars, war)
+	if err := s.saveLocked(); err != nil {
+		s.wars = s.wars[:len(s.wars)-1]
+		return ClanWar{}, err
+	}
+	return war, nil
+}
+
+// OfferTruce offers to end the war with target early. When target's leader
+// has already offered, the war ends and the second result is true.
+func (s *ClanSystem) OfferTruce(leader, target string, now time.Time) (ClanWar, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clan, err := s.leaderClanLocked(leader)
+	if err != nil {
+		return ClanWar{}, false, err
+	}
+	idx := s.activeWarLocked(clan.Name, strings.Join(strings.Fields(target), " "))
+	if idx < 0 {
+		return ClanWar{}, false, fmt.Errorf("you are not at war with %s", target)
+	}
+	restore := s.snapshotLocked()
+	war := &s.wars[idx]
+	agreed := war.TruceOffer != "" && !strings.EqualFold(war.TruceOffer, clan.Name)
+	if agreed {
+		war.Ended = now.UTC()
+		war.TruceOffer = ""
+	} else {
+		war.TruceOffer = clan.Name
+	}
+	result := *war
+	if err := s.saveLocked(); err != nil {
+		restore()
+		return ClanWar{}, false, err
+	}
+	return result, agreed, nil
+}
+
+// RecordKill scores a defeat for the killer's clan when it is at war with
+// the victim's. It returns the war after scoring.
+func (s *ClanSystem) RecordKill(killer, victim string) (ClanWar, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, mine := s.clanOfLocked(killer)
+	_, theirs := s.clanOfLocked(victim)
+	if mine == nil || theirs == nil {
+		return ClanWar{}, false
+	}
+	idx := s.activeWarLocked(mine.Name, theirs.Name)
+	if idx < 0 {
+		return ClanWar{}, false
+	}
+	s.wars[idx].addScore(mine.Name, ClanWarKillScore)
+	if err := s.saveLocked(); err != nil {
+		s.wars[idx].addScore(mine.Name, -ClanWarKillScore)
+		Logger().Error("record war kill failed", "killer", killer, "victim", victim, "error", err)
+		return ClanWar{}, false
+	}
+	return s.wars[idx], true
+}
+
+// Capture takes the capture point in room for the player's clan. A point
+// held by another clan can only be taken from an enemy at war, and only
+// while none of its members in present stand guard.
+func (s *ClanSystem) Capture(player string, room RoomID, present []string, now time.Time) (CaptureResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, clan := s.clanOfLocked(player)
+	if clan == nil {
+		return CaptureResult{}, ErrNotInClan
+	}
+	result := CaptureResult{Clan: clan.Name}
+	restore := s.snapshotLocked()
+	if hold, held := s.holdings[room]; held {
+		if strings.EqualFold(hold.Clan, clan.Name) {
+			return CaptureResult{}, fmt.Errorf("your clan already holds this point")
+		}
+		idx := s.activeWarLocked(clan.Name, hold.Clan)
+		if idx < 0 {
+			return CaptureResult{}, fmt.Errorf("%s holds this point, and you are not at war with them", hold.Clan)
+		}
+		if holder, ok := s.clans[strings.ToLower(hold.Clan)]; ok {
+			for _, name := range present {
+				if _, guard := holder.member(name); guard {
+					return CaptureResult{}, fmt.Errorf("%s stands guard over this point", name)
+				}
+			}
+		}
+		s.wars[idx].addScore(clan.Name, ClanWarCaptureScore)
+		war := s.wars[idx]
+		result.From = hold.Clan
+		result.War = &war
+	}
+	s.holdings[room] = CaptureHold{Clan: clan.Name, Since: now.UTC(), Awarded: now.UTC()}
+	if err := s.saveLocked(); err != nil {
+		restore()
+		return CaptureResult{}, err
+	}
+	return result, nil
+}
+
+// Tick ends wars whose timer has run out, awards points for capture points
+// held through each CapturePointInterval, and forgets old wars.
+func (s *ClanSystem) Tick(now time.Time) (WarTick, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	restore := s.snapshotLocked()
+	tick := WarTick{Awarded: make(map[string]int)}
+	for i := range s.wars {
+		if s.wars[i].Active() && !now.Before(s.wars[i].Ends) {
+			s.wars[i].Ended = s.wars[i].Ends
+			s.wars[i].TruceOffer = ""
+			tick.Ended = append(tick.Ended, s.wars[i])
+		}
+	}
+	for room, hold := range s.holdings {
+		periods := int(now.Sub(hold.Awarded) / CapturePointInterval)
+		if periods <= 0 {
+			continue
+		}
+		key := strings.ToLower(hold.Clan)
+		clan, ok := s.clans[key]
+		if !ok {
+			delete(s.holdings, room)
+			continue
+		}
+		hold.Awarded = hold.Awarded.Add(time.Duration(periods) * CapturePointInterval)
+		s.holdings[room] = hold
+		updated := clan.clone()
+		updated.Points += periods
+		s.clans[key] = &updated
+		for i := range s.wars {
+			if s.wars[i].Active() && s.wars[i].involves(clan.Name) {
+				s.wars[i].addScore(clan.Name, periods)
+			}
+		}
+		tick.Awarded[clan.Name] += periods
+	}
+	pruned := s.pruneWarsLocked(now)
+	if len(tick.Ended) == 0 && len(tick.Awarded) == 0 && !pruned {
+		return tick, nil
+	}
+	if err := s.saveLocked(); err != nil {
+		restore()
+		return WarTick{}, err
+	}
+	return tick, nil
+}
+
+// pruneWarsLocked drops the oldest finished wars whose truce has lapsed
+// beyond clanWarHistory.
+func (s *ClanSystem) pruneWarsLocked(now time.Time) bool {
+	lapsed := 0
+	for _, war := range s.wars {
+		if !war.Active() && !now.Before(war.TruceUntil()) {
+			lapsed++
+		}
+	}
+	excess := lapsed - clanWarHistory
+	if excess <= 0 {
+		return false
+	}
+	kept := s.wars[:0]
+	for _, war := range s.wars {
+		if excess > 0 && !war.Active() && !now.Before(war.TruceUntil()) {
+			excess--
+			continue
+		}
+		kept = append(kept, war)
+	}
+	s.wars = kept
+	return true
+}
+
+// renameInWarsLocked carries a clan's new name into its wars and holdings.
+func (s *ClanSystem) renameInWarsLocked(old, name string) {
+	for i := range s.wars {
+		if strings.EqualFold(s.wars[i].Attacker, old) {
+			s.wars[i].Attacker = name
+		}
+		if strings.EqualFold(s.wars[i].Defender, old) {
+			s.wars[i].Defender = name
+		}
+		if strings.EqualFold(s.wars[i].TruceOffer, old) {
+			s.wars[i].TruceOffer = name
+		}
+	}
+	for room, hold := range s.holdings {
+		if strings.EqualFold(hold.Clan, old) {
+			hold.Clan = name
+			s.holdings[room] = hold
+		}
+	}
+}
+
+// clansAtWarLocked reports whether the clans of attacker and target are at
+// war, which lets them fight outside an arena.
+func (w *World) clansAtWarLocked(attacker, target *Player) bool {
+	return w.clans != nil && w.clans.AtWar(attacker.Name, target.Name)
+}
+
+// announceWar tells everyone online about a war starting or ending.
+func (w *World) announceWar(msg string) {
+	w.BroadcastSystem(Ansi("\r\n" + Style("[War] ", AnsiRed, AnsiBold) + msg))
+}
+
+// DeclareWar declares war from the clan p leads on target.
+func (w *World) DeclareWar(p *Player, target string) (ClanWar, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return ClanWar{}, err
+	}
+	war, err := clans.DeclareWar(p.Name, target, time.Now())
+	if err != nil {
+		return ClanWar{}, err
+	}
+	w.announceWar(fmt.Sprintf("%s has declared war on %s! A truce falls in %s.",
+		Style(war.Attacker, AnsiCyan), Style(war.Defender, AnsiCyan), ClanWarDuration))
+	return war, nil
+}
+
+// OfferTruce offers to end the war between p's clan and target, or accepts
+// target's offer. The second result reports whether the war ended.
+func (w *World) OfferTruce(p *Player, target string) (ClanWar, bool, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return ClanWar{}, false, err
+	}
+	clan, _ := clans.ClanOf(p.Name)
+	war, ended, err := clans.OfferTruce(p.Name, target, time.Now())
+	if err != nil {
+		return ClanWar{}, false, err
+	}
+	enemy := war.Opponent(clan.Name)
+	if ended {
+		w.announceWar(fmt.Sprintf("%s and %s agree to a truce. %s", Style(war.Attacker, AnsiCyan), Style(war.Defender, AnsiCyan), describeWarResult(war)))
+		return war, true, nil
+	}
+	if other, ok := clans.Clan(enemy); ok {
+		w.notifyClan(other, fmt.Sprintf("%s offers a truce. Your leader may accept with 'war truce %s'.", Style(clan.Name, AnsiCyan), clan.Name), "")
+	}
+	return war, false, nil
+}
+
+// CaptureTerritory takes the capture point in p's room for p's clan.
+func (w *World) CaptureTerritory(p *Player) (CaptureResult, error) {
+	w.mu.RLock()
+	clans := w.clans
+	room, ok := w.rooms[p.Room]
+	var present []string
+	for _, other := range w.players {
+		if other != p && other.Alive && other.Room == p.Room {
+			present = append(present, other.Name)
+		}
+	}
+	w.mu.RUnlock()
+	if clans == nil {
+		return CaptureResult{}, fmt.Errorf("clans are unavailable")
+	}
+	if !ok || !room.CapturePoint {
+		return CaptureResult{}, fmt.Errorf("there is nothing to capture here")
+	}
+	result, err := clans.Capture(p.Name, room.ID, present, time.Now())
+	if err != nil {
+		return CaptureResult{}, err
+	}
+	w.BroadcastToRoom(room.ID, Ansi(fmt.Sprintf("\r\n%s raises the banner of %s over %s.", HighlightName(p.Name), Style(result.Clan, AnsiCyan), room.Title)), p)
+	if result.From != "" {
+		if loser, ok := clans.Clan(result.From); ok {
+			w.notifyClan(loser, fmt.Sprintf("%s of %s has captured %s from you!", HighlightName(p.Name), Style(result.Clan, AnsiCyan), room.Title), "")
+		}
+	}
+	if clan, ok := clans.Clan(result.Clan); ok {
+		w.notifyClan(clan, fmt.Sprintf("%s has captured %s for the clan.", HighlightName(p.Name), room.Title), p.Name)
+	}
+	return result, nil
+}
+
+// warKillScored scores a war kill after a player is defeated and tells both
+// clans.
+func (w *World) warKillScored(killer *Player, result *PlayerDamageResult) {
+	if result == nil || !result.Defeated || result.Duel != nil {
+		return
+	}
+	clans := w.ClanSystem()
+	if clans == nil {
+		return
+	}
+	war, ok := clans.RecordKill(killer.Name, result.Target.Name)
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf("%s has slain %s. %s", HighlightName(killer.Name), HighlightName(result.Target.Name), describeWarScore(war))
+	for _, name := range []string{war.Attacker, war.Defender} {
+		if clan, ok := clans.Clan(name); ok {
+			w.notifyClan(clan, msg, "")
+		}
+	}
+}
+
+// TickClanWars ends expired wars and awards capture point points,
+// announcing any truce that falls.
+func (w *World) TickClanWars(now time.Time) {
+	clans := w.ClanSystem()
+	if clans == nil {
+		return
+	}
+	tick, err := clans.Tick(now)
+	if err != nil {
+		Logger().Error("clan war tick failed", "error", err)
+		return
+	}
+	for _, war := range tick.Ended {
+		w.announceWar(fmt.Sprintf("The war between %s and %s ends in a truce. %s", Style(war.Attacker, AnsiCyan), Style(war.Defender, AnsiCyan), describeWarResult(war)))
+	}
+}
+
+// StartClanWarLoop checks wars and capture points every minute until stop
+// is closed.
+func (w *World) StartClanWarLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(clanWarTickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.TickClanWars(now)
+			}
+		}
+	}()
+}
+
+// capturePointNoteLocked tells players who holds a capture point.
+func (w *World) capturePointNoteLocked(room *Room) string {
+	if !room.CapturePoint || w.clans == nil {
+		return ""
+	}
+	if hold, ok := w.clans.Holdings()[room.ID]; ok {
+		return fmt.Sprintf("The banner of %s flies over this capture point.", Style(hold.Clan, AnsiCyan))
+	}
+	return "This capture point is unclaimed. Take it for your clan with 'war capture'."
+}
+
+func describeWarScore(war ClanWar) string {
+	return fmt.Sprintf("%s %d, %s %d.", war.Attacker, war.AttackerScore, war.Defender, war.DefenderScore)
+}
+
+func describeWarResult(war ClanWar) string {
+	if winner := war.Winner(); winner != "" {
+		return fmt.Sprintf("%s wins, %d to %d.", winner, war.Score(winner), war.Score(war.Opponent(winner)))
+	}
+	return fmt.Sprintf("It is a draw at %d apiece.", war.AttackerScore)
+}
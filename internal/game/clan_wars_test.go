@@ -0,0 +1,146 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClanWarsScoreAndTruce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clans.json")
+	clans, err := NewClanSystem(path)
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, founder := range [][2]string{{"Lamplighters", "Ava"}, {"Rust Hands", "Bram"}} {
+		if _, err := clans.Create(founder[0], founder[1], now); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if _, err := clans.Invite("Ava", "Cole"); err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if _, err := clans.Join("Cole", "Lamplighters"); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+	if _, err := clans.DeclareWar("Cole", "Rust Hands", now); !errors.Is(err, ErrClanRank) {
+		t.Fatalf("expected only the leader to declare war, got %v", err)
+	}
+	if _, err := clans.DeclareWar("Ava", "lamplighters", now); err == nil {
+		t.Fatalf("expected a clan to be unable to fight itself")
+	}
+	if _, err := clans.DeclareWar("Ava", "rust hands", now); err != nil {
+		t.Fatalf("DeclareWar: %v", err)
+	}
+	if !clans.AtWar("cole", "Bram") {
+		t.Fatalf("expected the clans to be at war")
+	}
+	if _, err := clans.DeclareWar("Bram", "Lamplighters", now); err == nil {
+		t.Fatalf("expected a second war between the same clans to be refused")
+	}
+	if war, ok := clans.RecordKill("Bram", "Cole"); !ok || war.DefenderScore != ClanWarKillScore {
+		t.Fatalf("expected the kill to score for Rust Hands, got %+v, %v", war, ok)
+	}
+	if _, ok := clans.RecordKill("Bram", "Dara"); ok {
+		t.Fatalf("expected a clanless victim not to score")
+	}
+
+	if _, err := clans.Capture("Ava", "ridge", nil, now); err != nil {
+		t.Fatalf("Capture: %v", err)
+	}
+	if _, err := clans.Capture("Bram", "ridge", []string{"Cole"}, now); err == nil || !strings.Contains(err.Error(), "Cole stands guard") {
+		t.Fatalf("expected a guard to block the capture, got %v", err)
+	}
+	result, err := clans.Capture("Bram", "ridge", nil, now.Add(time.Minute))
+	if err != nil || result.From != "Lamplighters" || result.War == nil || result.War.DefenderScore != ClanWarKillScore+ClanWarCaptureScore {
+		t.Fatalf("expected Rust Hands to take the point and score, got %+v, %v", result, err)
+	}
+
+	tick, err := clans.Tick(now.Add(time.Minute + 2*CapturePointInterval + time.Second))
+	if err != nil || tick.Awarded["Rust Hands"] != 2 || len(tick.Ended) != 0 {
+		t.Fatalf("expected two intervals of points, got %+v, %v", tick, err)
+	}
+	if clan, _ := clans.Clan("Rust Hands"); clan.Points != 2 {
+		t.Fatalf("expected clan points to accrue, got %d", clan.Points)
+	}
+
+	reloaded, err := NewClanSystem(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if hold := reloaded.Holdings()["ridge"]; hold.Clan != "Rust Hands" {
+		t.Fatalf("expected the holding to survive a reload, got %+v", hold)
+	}
+	tick, err = reloaded.Tick(now.Add(ClanWarDuration))
+	if err != nil || len(tick.Ended) != 1 || tick.Ended[0].Winner() != "Rust Hands" {
+		t.Fatalf("expected the war to end in a truce, got %+v, %v", tick, err)
+	}
+	if reloaded.AtWar("Ava", "Bram") {
+		t.Fatalf("expected the truce to stop the war")
+	}
+	if _, err := reloaded.DeclareWar("Ava", "Rust Hands", now.Add(ClanWarDuration+time.Hour)); err == nil || !strings.Contains(err.Error(), "truce") {
+		t.Fatalf("expected the truce to block a new war, got %v", err)
+	}
+	if _, err := reloaded.DeclareWar("Ava", "Rust Hands", now.Add(ClanWarDuration+ClanTruceDuration)); err != nil {
+		t.Fatalf("expected war once the truce lapses, got %v", err)
+	}
+	if _, agreed, err := reloaded.OfferTruce("Ava", "rust hands", now); err != nil || agreed {
+		t.Fatalf("expected a pending offer, got %v, %v", agreed, err)
+	}
+	if war, agreed, err := reloaded.OfferTruce("Bram", "lamplighters", now); err != nil || !agreed || war.Active() {
+		t.Fatalf("expected both offers to end the war, got %+v, %v, %v", war, agreed, err)
+	}
+}
+
+func TestClanWarPvPAndCaptureAnnouncements(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"ridge": {ID: "ridge", Title: "Windy Ridge", CapturePoint: true, Exits: map[string]Exit{}},
+	})
+	clans, err := NewClanSystem("")
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	world.AttachClanSystem(clans)
+	ava := &Player{Name: "Ava", Room: "ridge", Alive: true, Health: 20, MaxHealth: 20, Output: NewOutputQueue()}
+	bram := &Player{Name: "Bram", Room: "ridge", Alive: true, Health: 20, MaxHealth: 20, Output: NewOutputQueue()}
+	for _, p := range []*Player{ava, bram} {
+		world.AddPlayerForTest(p)
+	}
+	for _, founder := range []struct {
+		clan string
+		p    *Player
+	}{{"Lamplighters", ava}, {"Rust Hands", bram}} {
+		if _, err := clans.Create(founder.clan, founder.p.Name, time.Now()); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if _, err := world.ApplyDamageToPlayer(ava, "bram", 5); err == nil {
+		t.Fatalf("expected players outside a war to be unable to fight")
+	}
+	if _, err := world.DeclareWar(ava, "Rust Hands"); err != nil {
+		t.Fatalf("DeclareWar: %v", err)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(bram.Output), "")); !strings.Contains(out, "Lamplighters has declared war on Rust Hands") {
+		t.Fatalf("expected the war to be announced, got %q", out)
+	}
+	result, err := world.ApplyDamageToPlayer(ava, "bram", 50)
+	if err != nil || !result.Defeated {
+		t.Fatalf("expected Ava to defeat Bram, got %+v, %v", result, err)
+	}
+	if war := clans.Wars()[0]; war.AttackerScore != ClanWarKillScore {
+		t.Fatalf("expected the kill to score, got %+v", war)
+	}
+
+	if notes := stripAnsi(strings.Join(world.RoomNotes(ava), " ")); !strings.Contains(notes, "capture point is unclaimed") {
+		t.Fatalf("expected the capture point to be noted, got %q", notes)
+	}
+	if _, err := world.CaptureTerritory(ava); err != nil {
+		t.Fatalf("CaptureTerritory: %v", err)
+	}
+	if notes := stripAnsi(strings.Join(world.RoomNotes(ava), " ")); !strings.Contains(notes, "banner of Lamplighters") {
+		t.Fatalf("expected the holder to be noted, got %q", notes)
+	}
+}
@@ -0,0 +1,1011 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	// ClanFoundingCost is the gold a player pays to found a clan.
+	ClanFoundingCost = 1000
+	// ClanHallCost is the gold a clan pays from its bank to claim a hall.
+	ClanHallCost = 5000
+	// MinClanNameLength and MaxClanNameLength bound clan names.
+	MinClanNameLength = 3
+	MaxClanNameLength = 24
+	// ClanLedgerLimit caps how many clan bank transactions are kept.
+	ClanLedgerLimit = 20
+	// clanMailAuthor signs the letters sent when staff disband or rename a
+	// clan.
+	clanMailAuthor = "Staff"
+)
+
+var (
+	// ErrClanNotFound indicates no clan goes by the requested name.
+	ErrClanNotFound = errors.New("there is no clan by that name")
+	// ErrNotInClan indicates the player does not belong to a clan.
+	ErrNotInClan = errors.New("you are not in a clan")
+	// ErrClanRank indicates the player's clan rank does not allow the action.
+	ErrClanRank = errors.New("your clan rank does not allow that")
+)
+
+// ClanRank orders the members of a clan.
+type ClanRank string
+
+// Clan ranks from lowest to highest.
+const (
+	ClanRecruit ClanRank = "recruit"
+	ClanMember  ClanRank = "member"
+	ClanOfficer ClanRank = "officer"
+	ClanLeader  ClanRank = "leader"
+)
+
+// ClanRanks lists the clan ranks from lowest to highest.
+func ClanRanks() []ClanRank {
+	return []ClanRank{ClanRecruit, ClanMember, ClanOfficer, ClanLeader}
+}
+
+// level places the rank in ClanRanks; unknown ranks sit below recruits.
+func (r ClanRank) level() int {
+	return slices.Index(ClanRanks(), r)
+}
+
+// ClanLedgerEntry records one deposit or withdrawal from a clan bank.
+// Withdrawals have a negative Amount.
+type ClanLedgerEntry struct {
+	At     time.Time `json:"at"`
+	Player string    `json:"player"`
+	Amount int       `json:"amount"`
+}
+
+// Clan is a player-run guild with ranked members, a shared bank, and
+// optionally a hall.
+type Clan struct {
+	Name    string              `json:"name"`
+	Founded time.Time           `json:"founded"`
+	Members map[string]ClanRank `json:"members"`
+	Invites []string            `json:"invites,omitempty"`
+	Gold    int                 `json:"gold,omitempty"`
+	Ledger  []ClanLedgerEntry   `json:"ledger,omitempty"`
+	Hall    RoomID              `json:"hall,omitempty"`
+	// Points are earned by holding capture points.
+	Points int `json:"points,omitempty"`
+}
+
+func (c Clan) clone() Clan {
+	c.Members = maps.Clone(c.Members)
+	c.Invites = slices.Clone(c.Invites)
+	c.Ledger = slices.Clone(c.Ledger)
+	return c
+}
+
+// member resolves a player name to the name stored in the roster.
+func (c Clan) member(name string) (string, bool) {
+	for stored := range c.Members {
+		if strings.EqualFold(stored, name) {
+			return stored, true
+		}
+	}
+	return "", false
+}
+
+// Rank reports the player's rank in the clan.
+func (c Clan) Rank(player string) (ClanRank, bool) {
+	stored, ok := c.member(player)
+	if !ok {
+		return "", false
+	}
+	return c.Members[stored], true
+}
+
+// Leader names the clan's leader.
+func (c Clan) Leader() string {
+	for name, rank := range c.Members {
+		if rank == ClanLeader {
+			return name
+		}
+	}
+	return ""
+}
+
+// Roster lists the members from the highest rank down, alphabetically
+// within a rank.
+func (c Clan) Roster() []string {
+	names := make([]string, 0, len(c.Members))
+	for name := range c.Members {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := c.Members[names[i]].level(), c.Members[names[j]].level()
+		if a != b {
+			return a > b
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	return names
+}
+
+// invited reports whether the player has an open invitation.
+func (c Clan) invited(player string) bool {
+	return slices.IndexFunc(c.Invites, func(name string) bool { return strings.EqualFold(name, player) }) >= 0
+}
+
+// NormalizeClanName collapses the spacing in a proposed clan name and
+// reports why it cannot be used.
+func NormalizeClanName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if len(name) < MinClanNameLength || len(name) > MaxClanNameLength {
+		return "", fmt.Errorf("clan names run from %d to %d characters", MinClanNameLength, MaxClanNameLength)
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && r != ' ' && r != '\'' && r != '-' {
+			return "", fmt.Errorf("clan names may only use letters, spaces, apostrophes, and hyphens")
+		}
+	}
+	return name, nil
+}
+
+// ClanSystem persists clans, their members, and their banks, along with
+// the wars between them and the capture points they hold.
+type ClanSystem struct {
+	mu       sync.RWMutex
+	path     string
+	clans    map[string]*Clan
+	wars     []ClanWar
+	holdings map[RoomID]CaptureHold
+}
+
+type clanFile struct {
+	Clans    []Clan                 `json:"clans"`
+	Wars     []ClanWar              `json:"wars,omitempty"`
+	Holdings map[RoomID]CaptureHold `json:"holdings,omitempty"`
+}
+
+// NewClanSystem loads the clans stored at path. When path is empty the clans
+// are kept in memory only.
+func NewClanSystem(path string) (*ClanSystem, error) {
+	clans := &ClanSystem{path: path, clans: make(map[string]*Clan), holdings: make(map[RoomID]CaptureHold)}
+	if strings.TrimSpace(path) == "" {
+		return clans, nil
+	}
+	data, err := documentStorage().Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return clans, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read clans file: %w", err)
+	}
+	if len(data) == 0 {
+		return clans, nil
+	}
+	var record clanFile
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decode clans file: %w", err)
+	}
+	for _, clan := range record.Clans {
+		clan := clan
+		if clan.Members == nil {
+			clan.Members = make(map[string]ClanRank)
+		}
+		clans.clans[strings.ToLower(clan.Name)] = &clan
+	}
+	clans.wars = record.Wars
+	for room, hold := range record.Holdings {
+		clans.holdings[room] = hold
+	}
+	return clans, nil
+}
+
+// Clans lists every clan alphabetically.
+func (s *ClanSystem) Clans() []Clan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]Clan, 0, len(s.clans))
+	for _, clan := range s.clans {
+		out = append(out, clan.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out
+}
+
+// Clan returns the clan with the given name.
+func (s *ClanSystem) Clan(name string) (Clan, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	clan, ok := s.clans[strings.ToLower(strings.Join(strings.Fields(name), " "))]
+	if !ok {
+		return Clan{}, false
+	}
+	return clan.clone(), true
+}
+
+// ClanOf returns the clan the player belongs to.
+func (s *ClanSystem) ClanOf(player string) (Clan, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, clan := s.clanOfLocked(player); clan != nil {
+		return clan.clone(), true
+	}
+	return Clan{}, false
+}
+
+// HallOwner returns the clan that claimed room as its hall.
+func (s *ClanSystem) HallOwner(room RoomID) (Clan, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, clan := range s.clans {
+		if clan.Hall != "" && clan.Hall == room {
+			return clan.clone(), true
+		}
+	}
+	return Clan{}, false
+}
+
+func (s *ClanSystem) clanOfLocked(player string) (string, *Clan) {
+	for key, clan := range s.clans {
+		if _, ok := clan.member(player); ok {
+			return key, clan
+		}
+	}
+	return "", nil
+}
+
+// Create founds a clan led by founder.
+func (s *ClanSystem) Create(name, founder string, now time.Time) (Clan, error) {
+	name, err := NormalizeClanName(name)
+	if err != nil {
+		return Clan{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, clan := s.clanOfLocked(founder); clan != nil {
+		return Clan{}, fmt.Errorf("you already belong to %s", clan.Name)
+	}
+	key := strings.ToLower(name)
+	if _, taken := s.clans[key]; taken {
+		return Clan{}, fmt.Errorf("there is already a clan called %s", name)
+	}
+	clan := &Clan{Name: name, Founded: now.UTC(), Members: map[string]ClanRank{founder: ClanLeader}}
+	s.clans[key] = clan
+	if err := s.saveLocked(); err != nil {
+		delete(s.clans, key)
+		return Clan{}, err
+	}
+	return clan.clone(), nil
+}
+
+// updateLocked applies fn to a copy of the clan stored under key and saves
+// it, keeping the original when either fails.
+func (s *ClanSystem) updateLocked(key string, fn func(*Clan) error) (Clan, error) {
+	current, ok := s.clans[key]
+	if !ok {
+		return Clan{}, ErrClanNotFound
+	}
+	next := current.clone()
+	if err := fn(&next); err != nil {
+		return Clan{}, err
+	}
+	s.clans[key] = &next
+	if err := s.saveLocked(); err != nil {
+		s.clans[key] = current
+		return Clan{}, err
+	}
+	return next.clone(), nil
+}
+
+// updateMember applies fn to the clan the player belongs to, passing the
+// player's rank.
+func (s *ClanSystem) updateMember(player string, fn func(*Clan, ClanRank) error) (Clan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, clan := s.clanOfLocked(player)
+	if clan == nil {
+		return Clan{}, ErrNotInClan
+	}
+	rank, _ := clan.Rank(player)
+	return s.updateLocked(key, func(c *Clan) error { return fn(c, rank) })
+}
+
+// Invite lets an officer or leader invite target, who must not already be
+// in a clan.
+func (s *ClanSystem) Invite(actor, target string) (Clan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, clan := s.clanOfLocked(actor)
+	if clan == nil {
+		return Clan{}, ErrNotInClan
+	}
+	if _, other := s.clanOfLocked(target); other != nil {
+		return Clan{}, fmt.Errorf("%s already belongs to %s", target, other.Name)
+	}
+	return s.updateLocked(key, func(c *Clan) error {
+		if rank, _ := c.Rank(actor); rank.level() < ClanOfficer.level() {
+			return ErrClanRank
+		}
+		if c.invited(target) {
+			return fmt.Errorf("%s has already been invited", target)
+		}
+		c.Invites = append(c.Invites, target)
+		return nil
+	})
+}
+
+// Join accepts an invitation, adding the player to the clan as a recruit.
+func (s *ClanSystem) Join(player, name string) (Clan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, clan := s.clanOfLocked(player); clan != nil {
+		return Clan{}, fmt.Errorf("you already belong to %s", clan.Name)
+	}
+	return s.updateLocked(strings.ToLower(strings.Join(strings.Fields(name), " ")), func(c *Clan) error {
+		if !c.invited(player) {
+			return fmt.Errorf("%s has not invited you", c.Name)
+		}
+		c.Invites = slices.DeleteFunc(c.Invites, func(name string) bool { return strings.EqualFold(name, player) })
+		c.Members[player] = ClanRecruit
+		return nil
+	})
+}
+
+// Leave removes the player from their clan. A leader must hand over
+// leadership first unless they are the last member, in which case the clan
+// is disbanded and returned with its bank intact.
+func (s *ClanSystem) Leave(player string) (Clan, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, clan := s.clanOfLocked(player)
+	if clan == nil {
+		return Clan{}, false, ErrNotInClan
+	}
+	if len(clan.Members) == 1 {
+		restore := s.snapshotLocked()
+		s.removeClanLocked(key, time.Now())
+		if err := s.saveLocked(); err != nil {
+			restore()
+			return Clan{}, false, err
+		}
+		return clan.clone(), true, nil
+	}
+	updated, err := s.updateLocked(key, func(c *Clan) error {
+		stored, _ := c.member(player)
+		if c.Members[stored] == ClanLeader {
+			return fmt.Errorf("promote an officer to leader before you leave")
+		}
+		delete(c.Members, stored)
+		return nil
+	})
+	return updated, false, err
+}
+
+// Kick removes target from the actor's clan. Officers and leaders may kick
+// members of lower rank.
+func (s *ClanSystem) Kick(actor, target string) (Clan, string, error) {
+	var removed string
+	clan, err := s.updateMember(actor, func(c *Clan, rank ClanRank) error {
+		stored, ok := c.member(target)
+		if !ok {
+			return fmt.Errorf("%s is not in %s", target, c.Name)
+		}
+		if rank.level() < ClanOfficer.level() || c.Members[stored].level() >= rank.level() {
+			return ErrClanRank
+		}
+		delete(c.Members, stored)
+		removed = stored
+		return nil
+	})
+	return clan, removed, err
+}
+
+// Promote raises target one rank. Members may only be raised below the
+// actor's own rank, except that a leader promoting an officer hands over
+// leadership and becomes an officer.
+func (s *ClanSystem) Promote(actor, target string) (Clan, string, ClanRank, error) {
+	var promoted string
+	var next ClanRank
+	clan, err := s.updateMember(actor, func(c *Clan, rank ClanRank) error {
+		stored, ok := c.member(target)
+		if !ok {
+			return fmt.Errorf("%s is not in %s", target, c.Name)
+		}
+		current := c.Members[stored]
+		if current == ClanLeader {
+			return fmt.Errorf("%s already leads %s", stored, c.Name)
+		}
+		next = ClanRanks()[current.level()+1]
+		switch {
+		case next == ClanLeader && rank == ClanLeader:
+			self, _ := c.member(actor)
+			c.Members[self] = ClanOfficer
+		case next.level() >= rank.level():
+			return ErrClanRank
+		}
+		c.Members[stored] = next
+		promoted = stored
+		return nil
+	})
+	return clan, promoted, next, err
+}
+
+// Demote lowers target one rank. The actor must outrank them.
+func (s *ClanSystem) Demote(actor, target string) (Clan, string, ClanRank, error) {
+	var demoted string
+	var next ClanRank
+	clan, err := s.updateMember(actor, func(c *Clan, rank ClanRank) error {
+		stored, ok := c.member(target)
+		if !ok {
+			return fmt.Errorf("%s is not in %s", target, c.Name)
+		}
+		current := c.Members[stored]
+		if rank.level() < ClanOfficer.level() || current.level() >= rank.level() {
+			return ErrClanRank
+		}
+		if current == ClanRecruit {
+			return fmt.Errorf("%s is already a recruit", stored)
+		}
+		next = ClanRanks()[current.level()-1]
+		c.Members[stored] = next
+		demoted = stored
+		return nil
+	})
+	return clan, demoted, next, err
+}
+
+// addLedger records a bank transaction, keeping the latest ClanLedgerLimit.
+func (c *Clan) addLedger(entry ClanLedgerEntry) {
+	c.Ledger = append(c.Ledger, entry)
+	if excess := len(c.Ledger) - ClanLedgerLimit; excess > 0 {
+		c.Ledger = slices.Delete(c.Ledger, 0, excess)
+	}
+}
+
+// Deposit adds gold the player paid in to their clan's bank.
+func (s *ClanSystem) Deposit(player string, amount int, now time.Time) (Clan, error) {
+	return s.updateMember(player, func(c *Clan, _ ClanRank) error {
+		c.Gold += amount
+		c.addLedger(ClanLedgerEntry{At: now.UTC(), Player: player, Amount: amount})
+		return nil
+	})
+}
+
+// Withdraw takes gold from the player's clan bank. Only officers and the
+// leader may withdraw.
+func (s *ClanSystem) Withdraw(player string, amount int, now time.Time) (Clan, error) {
+	return s.updateMember(player, func(c *Clan, rank ClanRank) error {
+		if rank.level() < ClanOfficer.level() {
+			return ErrClanRank
+		}
+		if c.Gold < amount {
+			return fmt.Errorf("the clan bank holds only %d gold", c.Gold)
+		}
+		c.Gold -= amount
+		c.addLedger(ClanLedgerEntry{At: now.UTC(), Player: player, Amount: -amount})
+		return nil
+	})
+}
+
+// ClaimHall makes room the hall of the leader's clan, paying cost from the
+// clan bank. A clan holds one hall; claiming another gives up the old one.
+func (s *ClanSystem) ClaimHall(leader string, room RoomID, cost int) (Clan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, clan := s.clanOfLocked(leader)
+	if clan == nil {
+		return Clan{}, ErrNotInClan
+	}
+	for other, owner := range s.clans {
+		if owner.Hall == room {
+			if other == key {
+				return Clan{}, fmt.Errorf("this is already your clan's hall")
+			}
+			return Clan{}, fmt.Errorf("this hall belongs to %s", owner.Name)
+		}
+	}
+	return s.updateLocked(key, func(c *Clan) error {
+		if rank, _ := c.Rank(leader); rank != ClanLeader {
+			return ErrClanRank
+		}
+		if c.Gold < cost {
+			return fmt.Errorf("claiming a hall costs %d gold from the clan bank", cost)
+		}
+		c.Gold -= cost
+		c.Hall = room
+		return nil
+	})
+}
+
+// Rename gives a clan a new name.
+func (s *ClanSystem) Rename(name, newName string) (Clan, error) {
+	newName, err := NormalizeClanName(newName)
+	if err != nil {
+		return Clan{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	clan, ok := s.clans[key]
+	if !ok {
+		return Clan{}, ErrClanNotFound
+	}
+	newKey := strings.ToLower(newName)
+	if _, taken := s.clans[newKey]; taken && newKey != key {
+		return Clan{}, fmt.Errorf("there is already a clan called %s", newName)
+	}
+	restore := s.snapshotLocked()
+	renamed := clan.clone()
+	renamed.Name = newName
+	delete(s.clans, key)
+	s.clans[newKey] = &renamed
+	s.renameInWarsLocked(clan.Name, newName)
+	if err := s.saveLocked(); err != nil {
+		restore()
+		return Clan{}, err
+	}
+	return renamed.clone(), nil
+}
+
+// snapshotLocked captures the registry so a change can be undone when it
+// cannot be saved.
+func (s *ClanSystem) snapshotLocked() func() {
+	clans := maps.Clone(s.clans)
+	wars := slices.Clone(s.wars)
+	holdings := maps.Clone(s.holdings)
+	return func() {
+		s.clans, s.wars, s.holdings = clans, wars, holdings
+	}
+}
+
+// removeClanLocked deletes a clan, ending its wars and giving up the
+// capture points it held.
+func (s *ClanSystem) removeClanLocked(key string, now time.Time) {
+	name := s.clans[key].Name
+	delete(s.clans, key)
+	for i := range s.wars {
+		if s.wars[i].Active() && s.wars[i].involves(name) {
+			s.wars[i].Ended = now.UTC()
+		}
+	}
+	for room, hold := range s.holdings {
+		if strings.EqualFold(hold.Clan, name) {
+			delete(s.holdings, room)
+		}
+	}
+}
+
+// Disband removes a clan, returning it as it stood. Its wars end and its
+// capture points fall free.
+func (s *ClanSystem) Disband(name string) (Clan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	clan, ok := s.clans[key]
+	if !ok {
+		return Clan{}, ErrClanNotFound
+	}
+	restore := s.snapshotLocked()
+	s.removeClanLocked(key, time.Now())
+	if err := s.saveLocked(); err != nil {
+		restore()
+		return Clan{}, err
+	}
+	return clan.clone(), nil
+}
+
+func (s *ClanSystem) saveLocked() error {
+	if strings.TrimSpace(s.path) == "" {
+		return nil
+	}
+	record := clanFile{Clans: make([]Clan, 0, len(s.clans)), Wars: s.wars, Holdings: s.holdings}
+	for _, clan := range s.clans {
+		record.Clans = append(record.Clans, *clan)
+	}
+	sort.Slice(record.Clans, func(i, j int) bool {
+		return strings.ToLower(record.Clans[i].Name) < strings.ToLower(record.Clans[j].Name)
+	})
+	data, err := encodeDocument(record)
+	if err != nil {
+		return fmt.Errorf("encode clans file: %w", err)
+	}
+	if err := documentStorage().Write(s.path, data); err != nil {
+		return fmt.Errorf("write clans file: %w", err)
+	}
+	return nil
+}
+
+// AttachClanSystem connects the clan registry to the world.
+func (w *World) AttachClanSystem(clans *ClanSystem) {
+	w.mu.Lock()
+	w.clans = clans
+	w.mu.Unlock()
+}
+
+// ClanSystem exposes the clan registry, when configured.
+func (w *World) ClanSystem() *ClanSystem {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.clans
+}
+
+func (w *World) clanSystem() (*ClanSystem, error) {
+	clans := w.ClanSystem()
+	if clans == nil {
+		return nil, fmt.Errorf("clans are unavailable")
+	}
+	return clans, nil
+}
+
+// PlayerClan returns the clan p belongs to.
+func (w *World) PlayerClan(p *Player) (Clan, bool) {
+	clans := w.ClanSystem()
+	if clans == nil {
+		return Clan{}, false
+	}
+	return clans.ClanOf(p.Name)
+}
+
+// FoundClan creates a clan led by p, who pays ClanFoundingCost.
+func (w *World) FoundClan(p *Player, name string) (Clan, error) {
+	w.mu.Lock()
+	clans := w.clans
+	if clans == nil {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("clans are unavailable")
+	}
+	cost := w.sinkGoldLocked(ClanFoundingCost)
+	if p.Gold < cost {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("founding a clan costs %d gold", cost)
+	}
+	if containsBlockedWord(name, w.tunablesLocked().BlockedWords) {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("that name is not allowed here")
+	}
+	clan, err := clans.Create(name, p.Name, time.Now())
+	if err != nil {
+		w.mu.Unlock()
+		return Clan{}, err
+	}
+	p.Gold -= cost
+	w.recordGoldLocked(EconomyClan, -cost)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return clan, nil
+}
+
+// InviteToClan invites the online player target to join p's clan.
+func (w *World) InviteToClan(p *Player, target string) (Clan, string, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, "", err
+	}
+	other, ok := w.FindPlayer(target)
+	if !ok {
+		return Clan{}, "", fmt.Errorf("%s is not online", target)
+	}
+	if other == p {
+		return Clan{}, "", fmt.Errorf("you are already in your clan")
+	}
+	clan, err := clans.Invite(p.Name, other.Name)
+	if err != nil {
+		return Clan{}, "", err
+	}
+	w.sendToPlayer(other.Name, Ansi(fmt.Sprintf("\r\n%s invites you to join the clan %s. Type 'clan join %s' to accept.",
+		HighlightName(p.Name), Style(clan.Name, AnsiCyan), clan.Name)))
+	return clan, other.Name, nil
+}
+
+// JoinClan accepts p's invitation to the named clan.
+func (w *World) JoinClan(p *Player, name string) (Clan, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, err
+	}
+	clan, err := clans.Join(p.Name, name)
+	if err != nil {
+		return Clan{}, err
+	}
+	w.notifyClan(clan, fmt.Sprintf("%s has joined the clan.", HighlightName(p.Name)), p.Name)
+	return clan, nil
+}
+
+// LeaveClan removes p from their clan. When p was the last member the clan
+// is disbanded, its bank is paid out to p, and the second result is true.
+func (w *World) LeaveClan(p *Player) (Clan, bool, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, false, err
+	}
+	clan, disbanded, err := clans.Leave(p.Name)
+	if err != nil {
+		return Clan{}, false, err
+	}
+	if !disbanded {
+		w.notifyClan(clan, fmt.Sprintf("%s has left the clan.", HighlightName(p.Name)), "")
+		return clan, false, nil
+	}
+	if clan.Gold > 0 {
+		w.mu.Lock()
+		p.Gold += clan.Gold
+		key, snapshot := profileSnapshot(p)
+		w.mu.Unlock()
+		w.persistPlayerState(key, snapshot)
+	}
+	return clan, true, nil
+}
+
+// KickFromClan removes target from p's clan.
+func (w *World) KickFromClan(p *Player, target string) (Clan, string, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, "", err
+	}
+	clan, removed, err := clans.Kick(p.Name, target)
+	if err != nil {
+		return Clan{}, "", err
+	}
+	w.sendToPlayer(removed, Ansi(Style(fmt.Sprintf("\r\n%s has removed you from the clan %s.", p.Name, clan.Name), AnsiYellow)))
+	w.notifyClan(clan, fmt.Sprintf("%s removed %s from the clan.", HighlightName(p.Name), HighlightName(removed)), p.Name)
+	return clan, removed, nil
+}
+
+// PromoteClanMember raises target one rank in p's clan.
+func (w *World) PromoteClanMember(p *Player, target string) (Clan, string, ClanRank, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, "", "", err
+	}
+	clan, name, rank, err := clans.Promote(p.Name, target)
+	if err != nil {
+		return Clan{}, "", "", err
+	}
+	w.notifyClan(clan, fmt.Sprintf("%s is now a clan %s.", HighlightName(name), rank), p.Name)
+	return clan, name, rank, nil
+}
+
+// DemoteClanMember lowers target one rank in p's clan.
+func (w *World) DemoteClanMember(p *Player, target string) (Clan, string, ClanRank, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, "", "", err
+	}
+	clan, name, rank, err := clans.Demote(p.Name, target)
+	if err != nil {
+		return Clan{}, "", "", err
+	}
+	w.notifyClan(clan, fmt.Sprintf("%s is now a clan %s.", HighlightName(name), rank), p.Name)
+	return clan, name, rank, nil
+}
+
+// ClanDeposit moves amount gold from p's purse into their clan's bank.
+func (w *World) ClanDeposit(p *Player, amount int) (Clan, error) {
+	if amount <= 0 {
+		return Clan{}, fmt.Errorf("deposit a positive amount of gold")
+	}
+	w.mu.Lock()
+	clans := w.clans
+	if clans == nil {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("clans are unavailable")
+	}
+	if p.Gold < amount {
+		w.mu.Unlock()
+		return Clan{}, ErrInsufficientGold
+	}
+	clan, err := clans.Deposit(p.Name, amount, time.Now())
+	if err != nil {
+		w.mu.Unlock()
+		return Clan{}, err
+	}
+	p.Gold -= amount
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return clan, nil
+}
+
+// ClanWithdraw moves amount gold from p's clan bank into their purse.
+func (w *World) ClanWithdraw(p *Player, amount int) (Clan, error) {
+	if amount <= 0 {
+		return Clan{}, fmt.Errorf("withdraw a positive amount of gold")
+	}
+	w.mu.Lock()
+	clans := w.clans
+	if clans == nil {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("clans are unavailable")
+	}
+	clan, err := clans.Withdraw(p.Name, amount, time.Now())
+	if err != nil {
+		w.mu.Unlock()
+		return Clan{}, err
+	}
+	p.Gold += amount
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return clan, nil
+}
+
+// ClaimClanHall claims p's room as the hall of the clan p leads, paying
+// ClanHallCost from the clan bank.
+func (w *World) ClaimClanHall(p *Player) (Clan, error) {
+	w.mu.Lock()
+	clans := w.clans
+	if clans == nil {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("clans are unavailable")
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok || !room.ClanHall {
+		w.mu.Unlock()
+		return Clan{}, fmt.Errorf("this room cannot be claimed as a clan hall")
+	}
+	cost := w.sinkGoldLocked(ClanHallCost)
+	clan, err := clans.ClaimHall(p.Name, room.ID, cost)
+	if err != nil {
+		w.mu.Unlock()
+		return Clan{}, err
+	}
+	w.recordGoldLocked(EconomyClan, -cost)
+	w.mu.Unlock()
+	w.notifyClan(clan, fmt.Sprintf("%s claimed %s as the clan hall.", HighlightName(p.Name), Style(room.Title, AnsiCyan)), p.Name)
+	return clan, nil
+}
+
+// SetRoomClanHall marks a room a clan may claim as its hall, or clears the
+// mark.
+func (w *World) SetRoomClanHall(id RoomID, hall bool, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.ClanHall
+		room.ClanHall = hall
+		return func() { room.ClanHall = prev }
+	})
+}
+
+// SetRoomCapturePoint marks a room clans may fight over, or clears the
+// mark.
+func (w *World) SetRoomCapturePoint(id RoomID, capture bool, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.CapturePoint
+		room.CapturePoint = capture
+		return func() { room.CapturePoint = prev }
+	})
+}
+
+// clanHallAllowsLocked refuses entry to a claimed clan hall for anyone
+// outside the clan. Staff may always enter.
+func (w *World) clanHallAllowsLocked(p *Player, room *Room) error {
+	if !room.ClanHall || w.clans == nil || p.IsStaff() {
+		return nil
+	}
+	owner, ok := w.clans.HallOwner(room.ID)
+	if !ok {
+		return nil
+	}
+	if _, member := owner.Rank(p.Name); member {
+		return nil
+	}
+	return fmt.Errorf("%s is the hall of %s, and only its members may enter", room.Title, owner.Name)
+}
+
+// clanHallNoteLocked tells players who holds a clan hall room, or how to
+// claim it.
+func (w *World) clanHallNoteLocked(room *Room) string {
+	if !room.ClanHall || w.clans == nil {
+		return ""
+	}
+	if owner, ok := w.clans.HallOwner(room.ID); ok {
+		return fmt.Sprintf("This is the hall of the clan %s.", Style(owner.Name, AnsiCyan))
+	}
+	return fmt.Sprintf("This hall is unclaimed. A clan leader may claim it for %d gold with 'clan claim'.", w.sinkGoldLocked(ClanHallCost))
+}
+
+// BroadcastToClan delivers msg on the clan channel to the online members of
+// clan other than from.
+func (w *World) BroadcastToClan(clan Clan, msg string, from *Player) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, target := range w.players {
+		if target == from || !target.Alive || !target.channelEnabled(ChannelClan) {
+			continue
+		}
+		if _, ok := clan.Rank(target.Name); !ok {
+			continue
+		}
+		w.deliverChannelMessage(target, from, msg, ChannelClan)
+	}
+}
+
+// notifyClan tells the clan's online members, except skip, about a change
+// to the clan.
+func (w *World) notifyClan(clan Clan, msg, skip string) {
+	line := Ansi(fmt.Sprintf("\r\n%s %s", Style("["+clan.Name+"]", AnsiGreen, AnsiBold), msg))
+	for name := range clan.Members {
+		if !strings.EqualFold(name, skip) {
+			w.sendToPlayer(name, line)
+		}
+	}
+}
+
+// RenameClan renames a clan on behalf of staff, recording the change in the
+// moderation log and writing to its members.
+func (w *World) RenameClan(staff *Player, name, newName string) (Clan, string, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, "", err
+	}
+	if containsBlockedWord(newName, w.Tunables().BlockedWords) {
+		return Clan{}, "", fmt.Errorf("that name is not allowed here")
+	}
+	previous, ok := clans.Clan(name)
+	if !ok {
+		return Clan{}, "", ErrClanNotFound
+	}
+	clan, err := clans.Rename(previous.Name, newName)
+	if err != nil {
+		return Clan{}, "", err
+	}
+	w.recordClanAction(staff, "clan rename", previous.Name, "renamed to "+clan.Name)
+	w.mailClan(clan, fmt.Sprintf("Staff have renamed your clan from %s to %s.", previous.Name, clan.Name), 0)
+	return clan, previous.Name, nil
+}
+
+// DisbandClan dissolves a clan on behalf of staff. Its bank is mailed to
+// the leader and the change recorded in the moderation log.
+func (w *World) DisbandClan(staff *Player, name string) (Clan, error) {
+	clans, err := w.clanSystem()
+	if err != nil {
+		return Clan{}, err
+	}
+	clan, err := clans.Disband(name)
+	if err != nil {
+		return Clan{}, err
+	}
+	w.recordClanAction(staff, "clan disband", clan.Name, fmt.Sprintf("%d members, %d gold returned to %s", len(clan.Members), clan.Gold, clan.Leader()))
+	w.mailClan(clan, fmt.Sprintf("Staff have disbanded the clan %s.", clan.Name), clan.Gold)
+	return clan, nil
+}
+
+func (w *World) recordClanAction(staff *Player, action, clan, detail string) {
+	if err := w.Moderation().Record(ModerationAction{
+		Actor:  staff.Name,
+		Action: action,
+		Target: clan,
+		Detail: detail,
+	}); err != nil {
+		Logger().Error("record clan action failed", "clan", clan, "action", action, "error", err)
+	}
+}
+
+// mailClan writes to every member of clan, enclosing gold in the leader's
+// letter.
+func (w *World) mailClan(clan Clan, body string, gold int) {
+	w.mu.RLock()
+	mail := w.mail
+	w.mu.RUnlock()
+	leader := clan.Leader()
+	for name := range clan.Members {
+		enclosed := 0
+		if name == leader {
+			enclosed = gold
+		}
+		if mail == nil {
+			if enclosed > 0 {
+				Logger().Error("clan gold lost without mail", "clan", clan.Name, "gold", enclosed)
+			}
+			continue
+		}
+		if _, err := mail.Deliver(clanMailAuthor, name, body, nil, enclosed); err != nil {
+			Logger().Error("send clan letter failed", "player", name, "clan", clan.Name, "error", err)
+		}
+	}
+}
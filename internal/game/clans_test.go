@@ -0,0 +1,210 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClanSystemRanksAndPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clans.json")
+	clans, err := NewClanSystem(path)
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := clans.Create("x1", "Ava", now); err == nil {
+		t.Fatalf("expected a short name with digits to be rejected")
+	}
+	if _, err := clans.Create("  Order  of the Lamp ", "Ava", now); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := clans.Create("order of the lamp", "Bram", now); err == nil {
+		t.Fatalf("expected clan names to be unique regardless of case")
+	}
+	if _, err := clans.Invite("Ava", "Bram"); err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if _, err := clans.Join("Cole", "Order of the Lamp"); err == nil {
+		t.Fatalf("expected an uninvited player to be refused")
+	}
+	if _, err := clans.Join("bram", "ORDER OF THE LAMP"); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+	if _, err := clans.Invite("bram", "Cole"); !errors.Is(err, ErrClanRank) {
+		t.Fatalf("expected a recruit to be unable to invite, got %v", err)
+	}
+
+	if _, _, rank, err := clans.Promote("Ava", "Bram"); err != nil || rank != ClanMember {
+		t.Fatalf("expected Bram to become a member, got %q, %v", rank, err)
+	}
+	if _, _, rank, err := clans.Promote("Ava", "Bram"); err != nil || rank != ClanOfficer {
+		t.Fatalf("expected Bram to become an officer, got %q, %v", rank, err)
+	}
+	if _, _, _, err := clans.Promote("Bram", "Bram"); !errors.Is(err, ErrClanRank) {
+		t.Fatalf("expected an officer to be unable to promote themselves, got %v", err)
+	}
+	if _, _, err := clans.Kick("Bram", "Ava"); !errors.Is(err, ErrClanRank) {
+		t.Fatalf("expected an officer to be unable to kick the leader, got %v", err)
+	}
+	if _, _, err := clans.Leave("Ava"); err == nil {
+		t.Fatalf("expected the leader to be unable to leave while others remain")
+	}
+	clan, _, rank, err := clans.Promote("Ava", "Bram")
+	if err != nil || rank != ClanLeader {
+		t.Fatalf("expected leadership to pass to Bram, got %q, %v", rank, err)
+	}
+	if clan.Leader() != "bram" || clan.Members["Ava"] != ClanOfficer {
+		t.Fatalf("expected Ava to step down to officer, got %+v", clan.Members)
+	}
+	if roster := clan.Roster(); len(roster) != 2 || roster[0] != "bram" {
+		t.Fatalf("expected the leader first in the roster, got %v", roster)
+	}
+	if _, _, rank, err := clans.Demote("Bram", "Ava"); err != nil || rank != ClanMember {
+		t.Fatalf("expected Ava to be demoted to member, got %q, %v", rank, err)
+	}
+
+	reloaded, err := NewClanSystem(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	clan, ok := reloaded.ClanOf("AVA")
+	if !ok || clan.Name != "Order of the Lamp" || clan.Members["Ava"] != ClanMember || clan.Leader() != "bram" {
+		t.Fatalf("expected the clan to survive a reload, got %+v, %v", clan, ok)
+	}
+	if _, _, err := reloaded.Kick("Bram", "Ava"); err != nil {
+		t.Fatalf("Kick: %v", err)
+	}
+	clan, disbanded, err := reloaded.Leave("Bram")
+	if err != nil || !disbanded || clan.Name != "Order of the Lamp" {
+		t.Fatalf("expected the last member leaving to disband the clan, got %+v, %v, %v", clan, disbanded, err)
+	}
+	if len(reloaded.Clans()) != 0 {
+		t.Fatalf("expected no clans to remain")
+	}
+}
+
+func TestClanBankHallAndChannel(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Square", Exits: map[string]Exit{"n": {To: "hall"}}},
+		"hall":  {ID: "hall", Title: "Old Hall", ClanHall: true, Exits: map[string]Exit{"s": {To: "start"}}},
+	})
+	clans, err := NewClanSystem("")
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	world.AttachClanSystem(clans)
+	leader := &Player{Name: "Ava", Room: "hall", Alive: true, Gold: ClanFoundingCost + 6000, Output: NewOutputQueue()}
+	recruit := &Player{Name: "Bram", Room: "start", Alive: true, Gold: 100, Output: NewOutputQueue()}
+	outsider := &Player{Name: "Cole", Room: "start", Alive: true, Output: NewOutputQueue()}
+	for _, p := range []*Player{leader, recruit, outsider} {
+		world.AddPlayerForTest(p)
+	}
+
+	if _, err := world.FoundClan(leader, "Lamplighters"); err != nil {
+		t.Fatalf("FoundClan: %v", err)
+	}
+	if leader.Gold != 6000 {
+		t.Fatalf("expected the founding cost to be paid, got %d gold", leader.Gold)
+	}
+	if _, _, err := world.InviteToClan(leader, "bram"); err != nil {
+		t.Fatalf("InviteToClan: %v", err)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(recruit.Output), "")); !strings.Contains(out, "clan join Lamplighters") {
+		t.Fatalf("expected Bram to be told how to accept, got %q", out)
+	}
+	if _, err := world.JoinClan(recruit, "lamplighters"); err != nil {
+		t.Fatalf("JoinClan: %v", err)
+	}
+
+	if _, err := world.ClanDeposit(recruit, 100); err != nil {
+		t.Fatalf("ClanDeposit: %v", err)
+	}
+	if _, err := world.ClanWithdraw(recruit, 50); !errors.Is(err, ErrClanRank) {
+		t.Fatalf("expected a recruit to be unable to withdraw, got %v", err)
+	}
+	if _, err := world.ClanDeposit(leader, 6000); err != nil {
+		t.Fatalf("ClanDeposit: %v", err)
+	}
+	clan, err := world.ClaimClanHall(leader)
+	if err != nil {
+		t.Fatalf("ClaimClanHall: %v", err)
+	}
+	if clan.Hall != "hall" || clan.Gold != 6100-ClanHallCost || len(clan.Ledger) != 2 || clan.Ledger[0].Player != "Bram" {
+		t.Fatalf("unexpected clan after claiming the hall: %+v", clan)
+	}
+	if _, err := world.ClanWithdraw(leader, 100); err != nil || leader.Gold != 100 {
+		t.Fatalf("expected the leader to withdraw, got %d gold, %v", leader.Gold, err)
+	}
+	report := world.EconomyReport()
+	if report.Total.Destroyed != ClanFoundingCost+ClanHallCost {
+		t.Fatalf("expected the founding and hall costs to be sunk, got %+v", report.Total)
+	}
+
+	if _, err := world.Move(outsider, "n"); err == nil || !strings.Contains(err.Error(), "hall of Lamplighters") {
+		t.Fatalf("expected an outsider to be kept out of the hall, got %v", err)
+	}
+	if _, err := world.Move(recruit, "n"); err != nil {
+		t.Fatalf("expected a member to enter the hall, got %v", err)
+	}
+	if notes := strings.Join(world.RoomNotes(recruit), " "); !strings.Contains(stripAnsi(notes), "hall of the clan Lamplighters") {
+		t.Fatalf("expected the hall to name its clan, got %q", notes)
+	}
+
+	drainOutput(leader.Output)
+	drainOutput(outsider.Output)
+	world.BroadcastToClan(clan, "hello clan", recruit)
+	if out := strings.Join(drainOutput(leader.Output), ""); !strings.Contains(out, "hello clan") {
+		t.Fatalf("expected the leader to hear the clan channel, got %q", out)
+	}
+	if out := strings.Join(drainOutput(outsider.Output), ""); out != "" {
+		t.Fatalf("expected outsiders not to hear the clan channel, got %q", out)
+	}
+}
+
+func TestStaffRenameAndDisbandClan(t *testing.T) {
+	dir := t.TempDir()
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	mail, err := NewMailSystem(filepath.Join(dir, "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	clans, err := NewClanSystem(filepath.Join(dir, "clans.json"))
+	if err != nil {
+		t.Fatalf("NewClanSystem: %v", err)
+	}
+	world.AttachClanSystem(clans)
+	if _, err := clans.Create("Rust Hands", "Ava", time.Now()); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := clans.Deposit("Ava", 75, time.Now()); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	staff := &Player{Name: "Warden", IsModerator: true}
+
+	clan, previous, err := world.RenameClan(staff, "rust hands", "Bright Hands")
+	if err != nil || previous != "Rust Hands" || clan.Name != "Bright Hands" {
+		t.Fatalf("RenameClan: %+v, %q, %v", clan, previous, err)
+	}
+	if _, ok := clans.Clan("Rust Hands"); ok {
+		t.Fatalf("expected the old name to be released")
+	}
+	clan, err = world.DisbandClan(staff, "bright hands")
+	if err != nil || clan.Gold != 75 {
+		t.Fatalf("DisbandClan: %+v, %v", clan, err)
+	}
+	if _, err := world.DisbandClan(staff, "bright hands"); !errors.Is(err, ErrClanNotFound) {
+		t.Fatalf("expected a second disband to fail, got %v", err)
+	}
+	letters := mail.MessagesForPlayer(PersonalMailBoard, "Ava")
+	if len(letters) != 2 || letters[1].Gold != 75 || !strings.Contains(letters[1].Body, "disbanded") {
+		t.Fatalf("expected rename and disband letters with the bank enclosed, got %+v", letters)
+	}
+	actions := world.Moderation().Actions(2)
+	if len(actions) != 2 || actions[0].Action != "clan rename" || actions[1].Action != "clan disband" || actions[1].Actor != "Warden" {
+		t.Fatalf("expected the disband to be logged, got %+v", actions)
+	}
+}
@@ -0,0 +1,262 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const classesFileName = "classes.json"
+
+// StatModifiers adjust a character's starting pools, melee damage, and
+// attributes.
+type StatModifiers struct {
+	Health int `json:"health,omitempty"`
+	Mana   int `json:"mana,omitempty"`
+	Moves  int `json:"moves,omitempty"`
+	Damage int `json:"damage,omitempty"`
+	Attributes
+}
+
+func (m StatModifiers) plus(other StatModifiers) StatModifiers {
+	return StatModifiers{
+		Health:     m.Health + other.Health,
+		Mana:       m.Mana + other.Mana,
+		Moves:      m.Moves + other.Moves,
+		Damage:     m.Damage + other.Damage,
+		Attributes: m.Attributes.plus(other.Attributes),
+	}
+}
+
+// Archetype is a race or class offered when a character is created.
+type Archetype struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Modifiers   StatModifiers `json:"modifiers"`
+	// Skills lists the spells the archetype grants for cast. The swim and
+	// climb skills also carry players past hazards.
+	Skills []string `json:"skills,omitempty"`
+	// StartRoom is where new characters of this archetype first appear.
+	StartRoom RoomID `json:"start_room,omitempty"`
+}
+
+// CharacterOptions holds the races and classes defined in classes.json.
+type CharacterOptions struct {
+	Races   []Archetype `json:"races"`
+	Classes []Archetype `json:"classes"`
+}
+
+func classesPath(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), classesFileName)
+}
+
+func loadCharacterOptions(areasPath string) (CharacterOptions, error) {
+	path := classesPath(areasPath)
+	if path == "" {
+		return CharacterOptions{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return CharacterOptions{}, nil
+		}
+		return CharacterOptions{}, err
+	}
+	var parsed CharacterOptions
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return CharacterOptions{}, fmt.Errorf("parse classes: %w", err)
+	}
+	if err := normalizeArchetypes("race", parsed.Races); err != nil {
+		return CharacterOptions{}, fmt.Errorf("parse classes: %w", err)
+	}
+	if err := normalizeArchetypes("class", parsed.Classes); err != nil {
+		return CharacterOptions{}, fmt.Errorf("parse classes: %w", err)
+	}
+	return parsed, nil
+}
+
+func normalizeArchetypes(kind string, list []Archetype) error {
+	seen := make(map[string]bool, len(list))
+	for i := range list {
+		archetype := &list[i]
+		archetype.ID = strings.ToLower(strings.TrimSpace(archetype.ID))
+		archetype.Name = strings.TrimSpace(archetype.Name)
+		if archetype.ID == "" {
+			return fmt.Errorf("%s id must not be empty", kind)
+		}
+		if seen[archetype.ID] {
+			return fmt.Errorf("duplicate %s %s", kind, archetype.ID)
+		}
+		seen[archetype.ID] = true
+		if archetype.Name == "" {
+			archetype.Name = archetype.ID
+		}
+		for j, skill := range archetype.Skills {
+			archetype.Skills[j] = strings.ToLower(strings.TrimSpace(skill))
+		}
+	}
+	return nil
+}
+
+func findArchetype(list []Archetype, name string) (Archetype, bool) {
+	name = strings.TrimSpace(name)
+	for _, archetype := range list {
+		if strings.EqualFold(archetype.ID, name) || strings.EqualFold(archetype.Name, name) {
+			return archetype, true
+		}
+	}
+	return Archetype{}, false
+}
+
+// CharacterOptions returns the races and classes new characters choose from.
+func (w *World) CharacterOptions() CharacterOptions {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return CharacterOptions{
+		Races:   append([]Archetype(nil), w.characterOptions.Races...),
+		Classes: append([]Archetype(nil), w.characterOptions.Classes...),
+	}
+}
+
+// Race looks up a race by id or name.
+func (o CharacterOptions) Race(name string) (Archetype, bool) {
+	return findArchetype(o.Races, name)
+}
+
+// Class looks up a class by id or name.
+func (o CharacterOptions) Class(name string) (Archetype, bool) {
+	return findArchetype(o.Classes, name)
+}
+
+// applyArchetypesLocked caches the stat modifiers granted by p's race and
+// class so EnsureStats and AttackDamage can use them without the world lock.
+func (w *World) applyArchetypesLocked(p *Player) {
+	var bonus StatModifiers
+	if race, ok := w.characterOptions.Race(p.Race); ok {
+		bonus = bonus.plus(race.Modifiers)
+	}
+	if class, ok := w.characterOptions.Class(p.Class); ok {
+		bonus = bonus.plus(class.Modifiers)
+	}
+	p.bonus = bonus
+}
+
+// Skills lists the spells p's race and class grant, sorted. The boolean is
+// false when p has neither, in which case every spell is available.
+func (w *World) Skills(p *Player) ([]string, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	race, hasRace := w.characterOptions.Race(p.Race)
+	class, hasClass := w.characterOptions.Class(p.Class)
+	if !hasRace && !hasClass {
+		return nil, false
+	}
+	seen := make(map[string]bool)
+	var skills []string
+	for _, skill := range append(append([]string(nil), race.Skills...), class.Skills...) {
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		skills = append(skills, skill)
+	}
+	sort.Strings(skills)
+	return skills, true
+}
+
+// KnowsSkill reports whether p may use the named spell.
+func (w *World) KnowsSkill(p *Player, skill string) bool {
+	skills, restricted := w.Skills(p)
+	if !restricted {
+		return true
+	}
+	skill = strings.ToLower(strings.TrimSpace(skill))
+	for _, known := range skills {
+		if known == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchetypeNames returns the display names of p's race and class, or empty
+// strings when they have not chosen one.
+func (w *World) ArchetypeNames(p *Player) (race, class string) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.archetypeNamesLocked(p)
+}
+
+func (w *World) archetypeNamesLocked(p *Player) (race, class string) {
+	if archetype, ok := w.characterOptions.Race(p.Race); ok {
+		race = archetype.Name
+	}
+	if archetype, ok := w.characterOptions.Class(p.Class); ok {
+		class = archetype.Name
+	}
+	return race, class
+}
+
+// NeedsCharacterOptions reports whether a character with profile still has
+// to pick a race and class.
+func (w *World) NeedsCharacterOptions(profile PlayerProfile) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if len(w.characterOptions.Races) == 0 && len(w.characterOptions.Classes) == 0 {
+		return false
+	}
+	return profile.Race == "" && profile.Class == ""
+}
+
+// ApplyCharacterOptions records the chosen race and class on profile. A
+// character still standing in the default start room is moved to the
+// class's starting room, or the race's when the class names none.
+func (w *World) ApplyCharacterOptions(profile *PlayerProfile, race, class Archetype) {
+	profile.Race = race.ID
+	profile.Class = class.ID
+	start := class.StartRoom
+	if start == "" {
+		start = race.StartRoom
+	}
+	if start == "" || (profile.Room != "" && profile.Room != StartRoom) {
+		return
+	}
+	if _, ok := w.GetRoom(start); !ok {
+		Logger().Warn("class start room does not exist", "class", class.ID, "room", start)
+		return
+	}
+	profile.Room = start
+	if profile.Home == "" || profile.Home == StartRoom {
+		profile.Home = start
+	}
+}
+
+// ReloadClasses re-reads the classes file and refreshes the modifiers of
+// online players. Health, mana, and stamina pools change on next login.
+func (w *World) ReloadClasses() (int, int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	options, err := loadCharacterOptions(areasPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	w.mu.Lock()
+	w.characterOptions = options
+	for _, p := range w.players {
+		w.applyArchetypesLocked(p)
+	}
+	w.mu.Unlock()
+	return len(options.Races), len(options.Classes), nil
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShippedClassesLoad(t *testing.T) {
+	options, err := loadCharacterOptions("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadCharacterOptions error: %v", err)
+	}
+	if len(options.Races) == 0 || len(options.Classes) == 0 {
+		t.Fatalf("shipped classes define %d races and %d classes", len(options.Races), len(options.Classes))
+	}
+	rooms, _, _, err := loadRooms("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadRooms error: %v", err)
+	}
+	for _, archetype := range append(options.Races, options.Classes...) {
+		if archetype.StartRoom == "" {
+			continue
+		}
+		if _, ok := rooms[archetype.StartRoom]; !ok {
+			t.Fatalf("%s starts in missing room %s", archetype.ID, archetype.StartRoom)
+		}
+	}
+}
+
+func testCharacterOptions() CharacterOptions {
+	return CharacterOptions{
+		Races: []Archetype{
+			{ID: "dwarf", Name: "Dwarf", Modifiers: StatModifiers{Health: 15, Mana: -5}},
+			{ID: "elf", Name: "Elf", Modifiers: StatModifiers{Mana: 10}, Skills: []string{"heal"}},
+		},
+		Classes: []Archetype{
+			{ID: "warrior", Name: "Warrior", Modifiers: StatModifiers{Health: 20, Damage: 3}, StartRoom: "yard"},
+			{ID: "mage", Name: "Mage", Modifiers: StatModifiers{Mana: 25}, Skills: []string{"bolt"}},
+		},
+	}
+}
+
+func TestArchetypesAdjustStatsAndSkills(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	world.characterOptions = testCharacterOptions()
+
+	plain := &Player{Name: "Plain", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	fighter := &Player{Name: "Brom", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Race: "dwarf", Class: "warrior"}
+	caster := &Player{Name: "Ilya", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Race: "elf", Class: "mage"}
+	for _, p := range []*Player{plain, fighter, caster} {
+		world.AddPlayerForTest(p)
+	}
+
+	if fighter.MaxHealth != plain.MaxHealth+35 || fighter.MaxMana != plain.MaxMana-5 {
+		t.Fatalf("dwarf warrior pools = %d/%d, plain %d/%d", fighter.MaxHealth, fighter.MaxMana, plain.MaxHealth, plain.MaxMana)
+	}
+	if got, want := fighter.AttackDamage(), plain.AttackDamage()+3; got != want {
+		t.Fatalf("warrior damage = %d, want %d", got, want)
+	}
+	if caster.MaxMana != plain.MaxMana+35 {
+		t.Fatalf("elf mage mana = %d, plain %d", caster.MaxMana, plain.MaxMana)
+	}
+
+	if !world.KnowsSkill(plain, "bolt") || !world.KnowsSkill(plain, "heal") {
+		t.Fatalf("characters without a class should keep every spell")
+	}
+	if world.KnowsSkill(fighter, "bolt") {
+		t.Fatalf("warriors should not know bolt")
+	}
+	if skills, _ := world.Skills(caster); strings.Join(skills, ",") != "bolt,heal" {
+		t.Fatalf("elf mage skills = %v", skills)
+	}
+	if entries := world.WhoList(false, WhoFilter{}, fighter.JoinedAt); entries[1].Class != "Warrior" {
+		t.Fatalf("who class = %q", entries[1].Class)
+	}
+}
+
+func TestCharacterCreationChoosesRaceAndClass(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{}},
+		"yard":    {ID: "yard", Exits: map[string]Exit{}},
+	})
+	world.characterOptions = testCharacterOptions()
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager error: %v", err)
+	}
+	if err := accounts.Register("Brom", "password"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+
+	profile := accounts.Profile("Brom")
+	if !world.NeedsCharacterOptions(profile) {
+		t.Fatalf("a new character should be asked for a race and class")
+	}
+	session, client, received := newPipeSession(t)
+	sendInput(t, client, "orc\r\ndwarf\r\n1\r\n")
+	if err := chooseCharacterOptions(session, world, &profile); err != nil {
+		t.Fatalf("chooseCharacterOptions error: %v", err)
+	}
+	var out strings.Builder
+	deadline := time.After(time.Second)
+	for !strings.Contains(out.String(), "journey") {
+		select {
+		case chunk := <-received:
+			out.Write(chunk)
+		case <-deadline:
+			t.Fatalf("creation output = %q", out.String())
+		}
+	}
+	if got := stripAnsi(out.String()); !strings.Contains(got, "Please choose one of the listed options") || !strings.Contains(got, "Dwarf Warrior") {
+		t.Fatalf("creation output = %q", got)
+	}
+	if profile.Race != "dwarf" || profile.Class != "warrior" || profile.Room != "yard" || profile.Home != "yard" {
+		t.Fatalf("profile after creation = %+v", profile)
+	}
+
+	p, err := world.addCharacter("Brom", "Brom", nil, false, profile)
+	if err != nil {
+		t.Fatalf("addCharacter error: %v", err)
+	}
+	if p.Room != "yard" || p.MaxHealth != 50+35 {
+		t.Fatalf("new dwarf warrior in %s with %d health", p.Room, p.MaxHealth)
+	}
+	saved := accounts.Profile("Brom")
+	if saved.Race != "dwarf" || saved.Class != "warrior" || world.NeedsCharacterOptions(saved) {
+		t.Fatalf("saved profile = %+v", saved)
+	}
+}
@@ -2,13 +2,10 @@ package game
 
 import (
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 )
 
-const defaultCombatRound = 4 * time.Second
-
 type combatTargetKind int
 
 const (
@@ -42,19 +39,24 @@ type combatInstance struct {
 	mu            sync.Mutex
 	playerTargets map[string]combatTarget
 	npcTargets    map[string]combatTarget
+	threat        map[string]map[string]int
+	// cooldowns holds when each NPC ability, keyed by NPC and ability
+	// index, may next be used.
+	cooldowns map[string]time.Time
 
 	stop     chan struct{}
 	stopOnce sync.Once
 	loopOnce sync.Once
 }
 
-func newCombatInstance(world *World, room RoomID) *combatInstance {
+func newCombatInstance(world *World, room RoomID, round time.Duration) *combatInstance {
 	return &combatInstance{
 		world:         world,
 		room:          room,
-		roundDuration: defaultCombatRound,
+		roundDuration: round,
 		playerTargets: make(map[string]combatTarget),
 		npcTargets:    make(map[string]combatTarget),
+		threat:        make(map[string]map[string]int),
 		stop:          make(chan struct{}),
 	}
 }
@@ -99,6 +101,9 @@ func (c *combatInstance) addNPC(name string, target combatTarget) {
 	if _, exists := c.npcTargets[name]; !exists {
 		c.npcTargets[name] = target
 	}
+	if target.kind == combatTargetPlayer {
+		c.addThreatLocked(name, target.name, 1)
+	}
 	c.mu.Unlock()
 }
 
@@ -111,16 +116,30 @@ func (c *combatInstance) clearPlayer(name string) {
 func (c *combatInstance) clearNPC(name string) {
 	c.mu.Lock()
 	delete(c.npcTargets, name)
+	delete(c.threat, name)
 	c.mu.Unlock()
 }
 
+func (c *combatInstance) hasNPC(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.npcTargets[name]
+	return ok
+}
+
+// retargetNPC drops the NPC's current target from its threat table and
+// turns it on whoever now holds the most threat, falling back to any player
+// still fighting in the room.
 func (c *combatInstance) retargetNPC(name string) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if len(c.playerTargets) == 0 {
-		delete(c.npcTargets, name)
-		return false
+	if current, ok := c.npcTargets[name]; ok && current.kind == combatTargetPlayer {
+		delete(c.threat[name], current.name)
+	}
+	if player, ok := c.topThreatLocked(name); ok {
+		c.npcTargets[name] = combatTarget{kind: combatTargetPlayer, name: player}
+		return true
 	}
 	for player := range c.playerTargets {
 		c.npcTargets[name] = combatTarget{kind: combatTargetPlayer, name: player}
@@ -130,6 +149,46 @@ func (c *combatInstance) retargetNPC(name string) bool {
 	return false
 }
 
+// dropLinkdeadPlayers stops the fight with players who have been linkdead
+// for a few rounds, turning their foes on someone who can still respond.
+func (c *combatInstance) dropLinkdeadPlayers() {
+	c.mu.Lock()
+	fighters := make(map[string]struct{})
+	for name := range c.playerTargets {
+		fighters[name] = struct{}{}
+	}
+	for _, target := range c.npcTargets {
+		if target.kind == combatTargetPlayer {
+			fighters[target.name] = struct{}{}
+		}
+	}
+	c.mu.Unlock()
+	for name := range fighters {
+		if !c.world.linkdeadPaused(name, c.roundDuration) {
+			continue
+		}
+		c.mu.Lock()
+		delete(c.playerTargets, name)
+		var foes []string
+		for attacker, target := range c.playerTargets {
+			if target.kind == combatTargetPlayer && target.name == name {
+				delete(c.playerTargets, attacker)
+			}
+		}
+		for npc, target := range c.npcTargets {
+			if target.kind == combatTargetPlayer && target.name == name {
+				foes = append(foes, npc)
+			}
+		}
+		c.mu.Unlock()
+		for _, npc := range foes {
+			if !c.retargetNPC(npc) {
+				c.clearNPC(npc)
+			}
+		}
+	}
+}
+
 func (c *combatInstance) snapshotActions() []combatAction {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -145,10 +204,12 @@ func (c *combatInstance) snapshotActions() []combatAction {
 }
 
 func (c *combatInstance) executeRound() bool {
+	c.dropLinkdeadPlayers()
 	actions := c.snapshotActions()
 	if len(actions) == 0 {
 		return false
 	}
+	metrics.combatRound()
 
 	for _, action := range actions {
 		switch action.attackerKind {
@@ -201,69 +262,73 @@ func (c *combatInstance) attackNPC(attacker *Player, name string, damage int) {
 	result, err := c.world.ApplyDamageToNPC(c.room, name, damage)
 	if err != nil {
 		if attacker.Output != nil {
-			attacker.Output <- Ansi(Style(fmt.Sprintf("\r\n%s", err.Error()), AnsiYellow))
+			attacker.Output.Send(Ansi(Style(fmt.Sprintf("\r\n%s", err.Error()), AnsiYellow)))
 		}
 		c.clearPlayer(attacker.Name)
 		return
 	}
 
+	c.addThreat(result.NPC.Name, attacker.Name, result.Damage)
 	npcName := HighlightNPCName(result.NPC.Name)
 	if attacker.Output != nil {
-		attacker.Output <- Ansi(fmt.Sprintf("\r\nYou strike %s for %d damage. (%d/%d HP)", npcName, result.Damage, result.NPC.Health, result.NPC.MaxHealth))
+		attacker.Output.Send(Ansi("\r\n" + c.world.Text(attacker, "combat.strike", npcName, result.Damage, result.NPC.Health, result.NPC.MaxHealth)))
 	}
 	broadcast := fmt.Sprintf("\r\n%s strikes %s for %d damage.", HighlightName(attacker.Name), npcName, result.Damage)
 	c.world.BroadcastToRoom(c.room, Ansi(broadcast), attacker)
 
 	if result.Defeated {
-		if attacker.Output != nil {
-			attacker.Output <- Ansi(fmt.Sprintf("\r\nYou defeat %s!", npcName))
-		}
-		c.world.BroadcastToRoom(c.room, Ansi(fmt.Sprintf("\r\n%s defeats %s!", HighlightName(attacker.Name), npcName)), attacker)
+		c.world.RewardNPCDefeat(attacker, c.room, result)
+		c.clearNPC(result.NPC.Name)
+		c.clearPlayer(attacker.Name)
+	}
+}
 
-		xp := result.NPC.Experience
-		if xp < 1 {
-			xp = result.NPC.Level * 25
-		}
-		levels := c.world.AwardExperience(attacker, xp)
-		if attacker.Output != nil {
-			attacker.Output <- Ansi(fmt.Sprintf("\r\nYou gain %d experience.", xp))
-		}
-		if levels > 0 && attacker.Output != nil {
-			attacker.Output <- Ansi(fmt.Sprintf("\r\nYou advance to level %d!", attacker.Level))
-		}
+// RewardNPCDefeat announces an NPC's defeat in room, grants attacker the
+// experience and gold for the kill, and publishes it for the subsystems that
+// track kills.
+func (w *World) RewardNPCDefeat(attacker *Player, room RoomID, result *NPCDamageResult) {
+	npcName := HighlightNPCName(result.NPC.Name)
+	if attacker.Output != nil {
+		attacker.Output.Send(Ansi("\r\n" + w.Text(attacker, "combat.defeat", npcName)))
+	}
+	w.BroadcastToRoom(room, Ansi(fmt.Sprintf("\r\n%s defeats %s!", HighlightName(attacker.Name), npcName)), attacker)
 
-		if len(result.Loot) > 0 {
-			names := make([]string, len(result.Loot))
-			for i, item := range result.Loot {
-				names[i] = HighlightItemName(item.Name)
-			}
-			lootLine := fmt.Sprintf("\r\n%s drops %s.", npcName, strings.Join(names, ", "))
-			if attacker.Output != nil {
-				attacker.Output <- Ansi(lootLine)
-			}
-			dropLine := fmt.Sprintf("\r\n%s leaves behind %s.", npcName, strings.Join(names, ", "))
-			c.world.BroadcastToRoom(c.room, Ansi(dropLine), attacker)
+	xp := result.NPC.Experience
+	if xp < 1 {
+		xp = result.NPC.Level * 25
+	}
+	xp = w.EventExperience(attacker, xp)
+	levels, rested := w.AwardExperience(attacker, xp)
+	if attacker.Output != nil {
+		attacker.Output.Send(Ansi("\r\n" + FormatExperienceGain(xp, rested)))
+	}
+	if levels > 0 && attacker.Output != nil {
+		attacker.Output.Send(Ansi("\r\n" + w.Text(attacker, "combat.level_up", attacker.Level)))
+	}
+	if gold := result.NPC.Gold; gold > 0 {
+		gold = w.AwardGold(attacker, gold, EconomyLoot)
+		if attacker.Output != nil {
+			attacker.Output.Send(Ansi("\r\n" + w.Text(attacker, "combat.collect_gold", gold)))
 		}
+	}
 
-		if updates := c.world.RecordNPCKill(attacker, result.NPC); len(updates) > 0 {
-			messages := FormatQuestKillUpdates(updates)
-			for _, msg := range messages {
-				if attacker.Output != nil {
-					attacker.Output <- Ansi("\r\n" + msg)
-				}
-			}
+	if line := FormatCorpseDrop(npcName, result.Corpse, result.Loot); line != "" {
+		if attacker.Output != nil {
+			attacker.Output.Send(Ansi("\r\n" + line))
 		}
-
-		c.clearNPC(result.NPC.Name)
-		c.clearPlayer(attacker.Name)
+		w.BroadcastToRoom(room, Ansi("\r\n"+line), attacker)
 	}
+
+	// Quest credit, reputation, boss loot, and achievements subscribe to
+	// the kill.
+	w.Publish(GameEvent{Type: EventNPCKilled, Player: attacker, Room: room, NPC: result.NPC})
 }
 
 func (c *combatInstance) attackPlayer(attacker *Player, name string, damage int) {
 	result, err := c.world.ApplyDamageToPlayer(attacker, name, damage)
 	if err != nil {
 		if attacker.Output != nil {
-			attacker.Output <- Ansi(Style(fmt.Sprintf("\r\n%s", err.Error()), AnsiYellow))
+			attacker.Output.Send(Ansi(Style(fmt.Sprintf("\r\n%s", err.Error()), AnsiYellow)))
 		}
 		c.clearPlayer(attacker.Name)
 		return
@@ -273,13 +338,20 @@ func (c *combatInstance) attackPlayer(attacker *Player, name string, damage int)
 	broadcast := fmt.Sprintf("\r\n%s strikes %s for %d damage.", HighlightName(attacker.Name), targetName, result.Damage)
 	c.world.BroadcastToRoom(result.PreviousRoom, Ansi(broadcast), attacker)
 
+	if result.Duel != nil {
+		c.world.AnnounceDuelResult(*result.Duel)
+		c.clearPlayer(result.Target.Name)
+		c.clearPlayer(attacker.Name)
+		return
+	}
 	if result.Defeated {
 		if attacker.Output != nil {
-			attacker.Output <- Ansi(fmt.Sprintf("\r\nYou defeat %s!", targetName))
+			attacker.Output.Send(Ansi("\r\n" + c.world.Text(attacker, "combat.defeat", targetName)))
 		}
 		c.world.BroadcastToRoom(result.PreviousRoom, Ansi(fmt.Sprintf("\r\n%s collapses in defeat!", targetName)), attacker)
 		if result.Target.Output != nil {
-			result.Target.Output <- Ansi(fmt.Sprintf("\r\nYou have been defeated by %s!", HighlightName(attacker.Name)))
+			result.Target.Output.Send(Ansi(fmt.Sprintf("\r\nYou have been defeated by %s!", HighlightName(attacker.Name))))
+			notifyDeathOutcome(result.Target, result.Death)
 			EnterRoom(c.world, result.Target, "defeat")
 		}
 		c.clearPlayer(result.Target.Name)
@@ -288,10 +360,10 @@ func (c *combatInstance) attackPlayer(attacker *Player, name string, damage int)
 	}
 
 	if attacker.Output != nil {
-		attacker.Output <- Ansi(fmt.Sprintf("\r\nYou strike %s for %d damage. (%d/%d HP)", targetName, result.Damage, result.Remaining, result.Target.MaxHealth))
+		attacker.Output.Send(Ansi("\r\n" + c.world.Text(attacker, "combat.strike", targetName, result.Damage, result.Remaining, result.Target.MaxHealth)))
 	}
 	if result.Target.Output != nil {
-		result.Target.Output <- Ansi(fmt.Sprintf("\r\n%s strikes you for %d damage. (%d/%d HP)", HighlightName(attacker.Name), result.Damage, result.Remaining, result.Target.MaxHealth))
+		result.Target.Output.Send(Ansi(fmt.Sprintf("\r\n%s strikes you for %d damage. (%d/%d HP)", HighlightName(attacker.Name), result.Damage, result.Remaining, result.Target.MaxHealth)))
 	}
 }
 
@@ -315,6 +387,23 @@ func (c *combatInstance) resolveNPCAttack(name string, target combatTarget) {
 		return
 	}
 
+	npcName := HighlightNPCName(npc.Name)
+	use, used := c.chooseAbility(npc.Name, player)
+	if used && use.ability.Kind != AbilityPoison {
+		c.announceAbility(npc.Name, use)
+		return
+	}
+	if used && use.ability.Amount > 0 {
+		damage = use.ability.Amount
+	}
+	if player.dodges() {
+		c.world.BroadcastToRoom(c.room, Ansi(fmt.Sprintf("\r\n%s dodges %s's attack.", HighlightName(player.Name), npcName)), player)
+		if player.Output != nil {
+			player.Output.Send(Ansi(fmt.Sprintf("\r\nYou dodge %s's attack.", npcName)))
+		}
+		return
+	}
+
 	result, err := c.world.ApplyDamageFromNPC(c.room, npc.Name, player, damage)
 	if err != nil {
 		if !c.retargetNPC(name) {
@@ -323,19 +412,31 @@ func (c *combatInstance) resolveNPCAttack(name string, target combatTarget) {
 		return
 	}
 
-	npcName := HighlightNPCName(npc.Name)
 	broadcast := fmt.Sprintf("\r\n%s strikes %s for %d damage.", npcName, HighlightName(player.Name), result.Damage)
 	c.world.BroadcastToRoom(c.room, Ansi(broadcast), player)
 
 	if result.Target.Output != nil {
-		result.Target.Output <- Ansi(fmt.Sprintf("\r\n%s strikes you for %d damage. (%d/%d HP)", npcName, result.Damage, result.Remaining, result.Target.MaxHealth))
+		result.Target.Output.Send(Ansi(fmt.Sprintf("\r\n%s strikes you for %d damage. (%d/%d HP)", npcName, result.Damage, result.Remaining, result.Target.MaxHealth)))
+	}
+	if used && !result.Defeated {
+		c.world.poisonPlayer(result.Target, use.ability)
+		if result.Target.Output != nil {
+			result.Target.Output.Send(Ansi(Style(fmt.Sprintf("\r\n%s's %s leaves you poisoned!", npcName, use.ability.label()), AnsiGreen)))
+		}
 	}
 
 	if result.Defeated {
 		if result.Target.Output != nil {
-			result.Target.Output <- Ansi(fmt.Sprintf("\r\nYou have been defeated by %s!", npcName))
+			result.Target.Output.Send(Ansi(fmt.Sprintf("\r\nYou have been defeated by %s!", npcName)))
+			if result.Bounty > 0 {
+				result.Target.Output.Send(Ansi("\r\nYou have paid for your crimes. The bounty on your head is lifted."))
+			}
+			notifyDeathOutcome(result.Target, result.Death)
 			EnterRoom(c.world, result.Target, "defeat")
 		}
+		if result.Bounty > 0 {
+			c.world.PersistPlayer(result.Target)
+		}
 		c.world.BroadcastToRoom(c.room, Ansi(fmt.Sprintf("\r\n%s collapses in defeat!", HighlightName(player.Name))), result.Target)
 		c.clearPlayer(player.Name)
 		if !c.retargetNPC(name) {
@@ -343,3 +444,12 @@ func (c *combatInstance) resolveNPCAttack(name string, target combatTarget) {
 		}
 	}
 }
+
+func notifyDeathOutcome(target *Player, outcome DeathOutcome) {
+	if target == nil || target.Output == nil {
+		return
+	}
+	for _, line := range FormatDeathOutcome(outcome) {
+		target.Output.Send(Ansi("\r\n" + line))
+	}
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxCommandAliases bounds how many command aliases a player may keep.
+	MaxCommandAliases = 50
+	// MaxCommandAliasLength bounds the length of one alias expansion.
+	MaxCommandAliasLength = 256
+	// MaxAliasDepth bounds how deeply aliases may expand into other aliases.
+	MaxAliasDepth = 5
+	// MaxAliasCommands bounds how many commands one line of input may run
+	// once its aliases are expanded.
+	MaxAliasCommands = 20
+)
+
+// reservedAliasNames cannot be aliased so players can always repair their
+// alias list.
+var reservedAliasNames = map[string]bool{"alias": true, "aliases": true, "unalias": true}
+
+// CommandAliasEntry is one alias in a player's list.
+type CommandAliasEntry struct {
+	Name      string
+	Expansion string
+}
+
+// NormalizeCommandAliasName validates an alias name and returns it lower
+// cased.
+func NormalizeCommandAliasName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case name == "":
+		return "", fmt.Errorf("alias names cannot be empty")
+	case strings.ContainsAny(name, " \t;$"):
+		return "", fmt.Errorf("alias names must be a single word without ';' or '$'")
+	case len(name) > 20:
+		return "", fmt.Errorf("alias names must be 20 characters or fewer")
+	case reservedAliasNames[name]:
+		return "", fmt.Errorf("'%s' cannot be aliased", name)
+	}
+	return name, nil
+}
+
+// SetCommandAlias stores expansion under name for the player, replacing any
+// existing alias. An empty expansion removes the alias.
+func (w *World) SetCommandAlias(p *Player, name, expansion string) error {
+	name, err := NormalizeCommandAliasName(name)
+	if err != nil {
+		return err
+	}
+	expansion = strings.TrimSpace(expansion)
+	if len(expansion) > MaxCommandAliasLength {
+		return fmt.Errorf("alias expansions must be %d characters or fewer", MaxCommandAliasLength)
+	}
+	w.mu.Lock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p {
+		w.mu.Unlock()
+		return fmt.Errorf("%s is not online", p.Name)
+	}
+	if expansion == "" {
+		if _, exists := p.CommandAliases[name]; !exists {
+			w.mu.Unlock()
+			return fmt.Errorf("you have no alias named '%s'", name)
+		}
+		delete(p.CommandAliases, name)
+	} else {
+		if _, exists := p.CommandAliases[name]; !exists && len(p.CommandAliases) >= MaxCommandAliases {
+			w.mu.Unlock()
+			return fmt.Errorf("you may keep at most %d aliases", MaxCommandAliases)
+		}
+		if p.CommandAliases == nil {
+			p.CommandAliases = make(map[string]string)
+		}
+		p.CommandAliases[name] = expansion
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return nil
+}
+
+// CommandAlias returns the expansion stored under name for the player.
+func (w *World) CommandAlias(p *Player, name string) (string, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	expansion, ok := p.CommandAliases[strings.ToLower(name)]
+	return expansion, ok
+}
+
+// CommandAliases lists the player's aliases sorted by name.
+func (w *World) CommandAliases(p *Player) []CommandAliasEntry {
+	w.mu.RLock()
+	aliases := maps.Clone(p.CommandAliases)
+	w.mu.RUnlock()
+	entries := make([]CommandAliasEntry, 0, len(aliases))
+	for name, expansion := range aliases {
+		entries = append(entries, CommandAliasEntry{Name: name, Expansion: expansion})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// ExpandCommandAlias substitutes args into an alias expansion and splits it
+// into the commands it runs. $1 through $9 are replaced by the matching
+// argument and $* by all of them; when the expansion uses no placeholders the
+// arguments are appended to its final command.
+func ExpandCommandAlias(expansion, args string) []string {
+	args = strings.TrimSpace(args)
+	fields := strings.Fields(args)
+	var builder strings.Builder
+	substituted := false
+	for i := 0; i < len(expansion); i++ {
+		if expansion[i] != '$' || i+1 >= len(expansion) {
+			builder.WriteByte(expansion[i])
+			continue
+		}
+		next := expansion[i+1]
+		switch {
+		case next == '*':
+			builder.WriteString(args)
+			substituted = true
+		case next >= '1' && next <= '9':
+			if n, _ := strconv.Atoi(string(next)); n <= len(fields) {
+				builder.WriteString(fields[n-1])
+			}
+			substituted = true
+		case next == '$':
+			builder.WriteByte('$')
+		default:
+			builder.WriteByte('$')
+			continue
+		}
+		i++
+	}
+	expanded := builder.String()
+	if !substituted && args != "" {
+		expanded += " " + args
+	}
+	var commands []string
+	for _, command := range strings.Split(expanded, ";") {
+		if command = strings.TrimSpace(command); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandCommandAlias(t *testing.T) {
+	cases := []struct {
+		expansion, args string
+		want            []string
+	}{
+		{"get sword; south", "", []string{"get sword", "south"}},
+		{"kill", "goblin", []string{"kill goblin"}},
+		{"get $1; give $1 $2", "sword Mira", []string{"get sword", "give sword Mira"}},
+		{"say $*;grin", "hello there", []string{"say hello there", "grin"}},
+		{"say cost $$5 $3", "a", []string{"say cost $5"}},
+		{";;", "", nil},
+	}
+	for _, tc := range cases {
+		if got := ExpandCommandAlias(tc.expansion, tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ExpandCommandAlias(%q, %q) = %q, want %q", tc.expansion, tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestCommandAliasesPersist(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Hero", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Start"}})
+	world.AttachAccountManager(accounts)
+	hero, err := world.addPlayer("Hero", nil, false, accounts.Profile("Hero"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+
+	if err := world.SetCommandAlias(hero, "GS", "get sword; south"); err != nil {
+		t.Fatalf("SetCommandAlias: %v", err)
+	}
+	if expansion, ok := world.CommandAlias(hero, "gs"); !ok || expansion != "get sword; south" {
+		t.Fatalf("CommandAlias = %q, %v", expansion, ok)
+	}
+	if saved := accounts.Profile("Hero").CmdAliases; saved["gs"] != "get sword; south" {
+		t.Fatalf("alias was not saved: %+v", saved)
+	}
+	for _, name := range []string{"alias", "two words", "semi;colon", ""} {
+		if err := world.SetCommandAlias(hero, name, "look"); err == nil {
+			t.Errorf("alias name %q should be rejected", name)
+		}
+	}
+	for i := len(hero.CommandAliases); i < MaxCommandAliases; i++ {
+		if err := world.SetCommandAlias(hero, "a"+string(rune('a'+i/26))+string(rune('a'+i%26)), "look"); err != nil {
+			t.Fatalf("SetCommandAlias %d: %v", i, err)
+		}
+	}
+	if err := world.SetCommandAlias(hero, "extra", "look"); err == nil {
+		t.Fatalf("aliases beyond the cap should be rejected")
+	}
+	if err := world.SetCommandAlias(hero, "gs", "get shield"); err != nil {
+		t.Fatalf("replacing an alias at the cap should succeed: %v", err)
+	}
+	if err := world.SetCommandAlias(hero, "gs", ""); err != nil {
+		t.Fatalf("removing alias: %v", err)
+	}
+	if _, ok := accounts.Profile("Hero").CmdAliases["gs"]; ok {
+		t.Fatalf("removed alias is still saved")
+	}
+	if err := world.SetCommandAlias(hero, "gs", ""); err == nil {
+		t.Fatalf("removing a missing alias should fail")
+	}
+}
@@ -0,0 +1,652 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultCombatRound is how long each combat round lasts.
+	DefaultCombatRound = 4 * time.Second
+	// DefaultXPRate leaves experience rewards unscaled.
+	DefaultXPRate = 1.0
+	// idleCheckInterval is how often idle players are looked for.
+	idleCheckInterval = 30 * time.Second
+)
+
+// Tunables are gameplay settings that can be changed while the server runs
+// with the config reload command.
+type Tunables struct {
+	// CombatRound is the length of a combat round. Fights already under way
+	// keep their pace until they end.
+	CombatRound time.Duration
+	// XPRate multiplies experience earned from kills and quests.
+	XPRate float64
+	// IdleTimeout disconnects players who send nothing for this long. Zero
+	// disables it; admins are never disconnected.
+	IdleTimeout time.Duration
+	// Channels lists which chat channels new characters start with enabled.
+	Channels map[Channel]bool
+	// BlockedWords may not be written on signs, letters, or books.
+	BlockedWords []string
+	// HouseEdge is the percent of a winning bet's payout tavern dealers
+	// keep.
+	HouseEdge int
+	// GoldFaucetRate scales gold dropped by creatures and paid by
+	// shopkeepers; GoldSinkRate scales what shops, healers, and waypoints
+	// charge.
+	GoldFaucetRate float64
+	GoldSinkRate   float64
+	// RentPerDay is the gold each vault item costs per day beyond the
+	// first RentFreeItems; zero turns vault rent off.
+	RentPerDay    int
+	RentFreeItems int
+}
+
+// DefaultTunables returns the built-in gameplay settings.
+func DefaultTunables() Tunables {
+	return Tunables{
+		CombatRound:    DefaultCombatRound,
+		XPRate:         DefaultXPRate,
+		Channels:       cloneChannelSettings(baseChannelSettings),
+		HouseEdge:      DefaultHouseEdge,
+		GoldFaucetRate: DefaultGoldRate,
+		GoldSinkRate:   DefaultGoldRate,
+		RentFreeItems:  DefaultRentFreeItems,
+	}
+}
+
+// reloadableSettings are the config keys ReloadConfig applies without a
+// restart.
+var reloadableSettings = []string{"blocked-words", "channel-defaults", "combat-round", "gold-faucet-rate", "gold-sink-rate", "house-edge", "idle-timeout", "rent-free-items", "rent-per-day", "xp-rate"}
+
+// applySetting parses one reloadable config value into t.
+func (t *Tunables) applySetting(key, value string) error {
+	switch key {
+	case "combat-round":
+		round, err := time.ParseDuration(value)
+		if err != nil || round <= 0 {
+			return fmt.Errorf("combat-round must be a positive duration")
+		}
+		t.CombatRound = round
+	case "xp-rate":
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return fmt.Errorf("xp-rate must be a number zero or higher")
+		}
+		t.XPRate = rate
+	case "idle-timeout":
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return fmt.Errorf("idle-timeout must be a duration zero or higher")
+		}
+		t.IdleTimeout = timeout
+	case "channel-defaults":
+		channels, err := ParseChannelDefaults(value)
+		if err != nil {
+			return err
+		}
+		t.Channels = channels
+	case "blocked-words":
+		t.BlockedWords = ParseBlockedWords(value)
+	case "house-edge":
+		edge, err := strconv.Atoi(value)
+		if err != nil || edge < 0 || edge > MaxHouseEdge {
+			return fmt.Errorf("house-edge must be a percent from 0 to %d", MaxHouseEdge)
+		}
+		t.HouseEdge = edge
+	case "gold-faucet-rate", "gold-sink-rate":
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate <= 0 {
+			return fmt.Errorf("%s must be a positive number", key)
+		}
+		if key == "gold-faucet-rate" {
+			t.GoldFaucetRate = rate
+		} else {
+			t.GoldSinkRate = rate
+		}
+	case "rent-per-day", "rent-free-items":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%s must be a whole number zero or higher", key)
+		}
+		if key == "rent-per-day" {
+			t.RentPerDay = n
+		} else {
+			t.RentFreeItems = n
+		}
+	default:
+		return fmt.Errorf("%s cannot be reloaded", key)
+	}
+	return nil
+}
+
+// ParseChannelDefaults reads a comma separated list of channels that start
+// enabled. Channels left out start disabled.
+func ParseChannelDefaults(spec string) (map[Channel]bool, error) {
+	settings := make(map[Channel]bool, len(allChannels))
+	for _, channel := range allChannels {
+		settings[channel] = false
+	}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		channel, ok := ChannelFromString(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown channel %q in channel-defaults", name)
+		}
+		settings[channel] = true
+	}
+	return settings, nil
+}
+
+// formatChannelDefaults renders the enabled channels the way
+// ParseChannelDefaults reads them.
+func formatChannelDefaults(settings map[Channel]bool) string {
+	var names []string
+	for _, channel := range allChannels {
+		if settings[channel] {
+			names = append(names, string(channel))
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// ConfigureTunables replaces the gameplay settings in effect.
+func (w *World) ConfigureTunables(t Tunables) {
+	t.Channels = cloneChannelSettings(t.Channels)
+	w.mu.Lock()
+	w.tunables = &t
+	w.mu.Unlock()
+	setChannelDefaults(t.Channels)
+}
+
+// Tunables reports the gameplay settings in effect.
+func (w *World) Tunables() Tunables {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	t := w.tunablesLocked()
+	t.Channels = cloneChannelSettings(t.Channels)
+	t.BlockedWords = slices.Clone(t.BlockedWords)
+	return t
+}
+
+func (w *World) tunablesLocked() Tunables {
+	if w.tunables == nil {
+		return DefaultTunables()
+	}
+	return *w.tunables
+}
+
+// scaleExperienceLocked applies the configured experience rate to amount.
+func (w *World) scaleExperienceLocked(amount int) int {
+	rate := w.tunablesLocked().XPRate
+	if rate == DefaultXPRate {
+		return amount
+	}
+	return int(float64(amount)*rate + 0.5)
+}
+
+// DisconnectIdle closes the connection of every non-admin player who has
+// sent nothing for longer than the idle timeout and returns their names.
+func (w *World) DisconnectIdle(now time.Time) []string {
+	w.mu.RLock()
+	timeout := w.tunablesLocked().IdleTimeout
+	var idle []*Player
+	if timeout > 0 {
+		for _, name := range w.playerOrder {
+			p, ok := w.players[name]
+			if !ok || !p.Alive || p.IsAdmin {
+				continue
+			}
+			if now.Sub(p.idleSince()) >= timeout {
+				idle = append(idle, p)
+			}
+		}
+	}
+	w.mu.RUnlock()
+	names := make([]string, 0, len(idle))
+	message := Ansi("\r\n" + Style("You have been idle too long and are being disconnected.", AnsiYellow) + "\r\n")
+	for _, p := range idle {
+		p.linkMu.Lock()
+		session := p.Session
+		p.linkMu.Unlock()
+		if session == nil {
+			continue
+		}
+		session.Send(message)
+		go session.Close()
+		names = append(names, p.Name)
+		Logger().Info("disconnected idle player", "player", p.Name, "timeout", timeout)
+	}
+	return names
+}
+
+// StartIdleLoop periodically disconnects idle players until stop is closed.
+func (w *World) StartIdleLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(idleCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.DisconnectIdle(now)
+			}
+		}
+	}()
+}
+
+// ConfigFile records where the server's settings were loaded from so they can
+// be reloaded.
+type ConfigFile struct {
+	// Path is the YAML or TOML file the settings came from.
+	Path string
+	// Settings holds the values read from the file, keyed by flag name.
+	Settings map[string]string
+	// Overridden lists flags given on the command line, which win over the
+	// file even after a reload.
+	Overridden map[string]bool
+	// Flags defines the settings the file may hold. A reload rejects any
+	// key it does not define; without it only reloadable settings are
+	// accepted.
+	Flags *flag.FlagSet
+}
+
+// ConfigReload summarises the outcome of reloading the config file.
+type ConfigReload struct {
+	// Applied lists reloadable settings whose new values took effect.
+	Applied []string
+	// Overridden lists changed settings ignored because a command-line flag
+	// set them.
+	Overridden []string
+	// Restart lists changed settings that only take effect after a restart.
+	Restart []string
+}
+
+// AttachConfigFile remembers the config file the server started with.
+func (w *World) AttachConfigFile(cfg ConfigFile) {
+	w.mu.Lock()
+	w.configFile = &cfg
+	w.mu.Unlock()
+}
+
+// ReloadConfig rereads the config file and applies the settings that can
+// change while the server runs. Unknown keys fail the reload, as they do at
+// startup, so typos do not go unnoticed.
+func (w *World) ReloadConfig() (ConfigReload, error) {
+	w.mu.RLock()
+	cfg := w.configFile
+	tunables := w.tunablesLocked()
+	w.mu.RUnlock()
+	if cfg == nil {
+		return ConfigReload{}, fmt.Errorf("the server was not started with a config file")
+	}
+	settings, lines, err := loadConfigFile(cfg.Path)
+	if err != nil {
+		return ConfigReload{}, err
+	}
+	keys := make([]string, 0, len(settings)+len(cfg.Settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !cfg.knows(key) {
+			return ConfigReload{}, fmt.Errorf("%s:%d: unknown setting %q in config file", cfg.Path, lines[key], key)
+		}
+	}
+	for key := range cfg.Settings {
+		if _, ok := settings[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var result ConfigReload
+	for _, key := range keys {
+		value, ok := settings[key]
+		if previous, had := cfg.Settings[key]; had && ok && previous == value {
+			continue
+		}
+		switch {
+		case cfg.Overridden[key]:
+			result.Overridden = append(result.Overridden, key)
+		case !slices.Contains(reloadableSettings, key):
+			result.Restart = append(result.Restart, key)
+		case !ok:
+			// A removed setting falls back to its default.
+			if err := tunables.applySetting(key, defaultTunableSetting(key)); err != nil {
+				return ConfigReload{}, err
+			}
+			result.Applied = append(result.Applied, key)
+		default:
+			if err := tunables.applySetting(key, value); err != nil {
+				return ConfigReload{}, fmt.Errorf("%s: %w", cfg.Path, err)
+			}
+			result.Applied = append(result.Applied, key)
+		}
+	}
+	w.ConfigureTunables(tunables)
+	w.mu.Lock()
+	w.configFile = &ConfigFile{Path: cfg.Path, Settings: settings, Overridden: cfg.Overridden, Flags: cfg.Flags}
+	w.mu.Unlock()
+	return result, nil
+}
+
+// knows reports whether key names a setting the config file may hold.
+func (cfg *ConfigFile) knows(key string) bool {
+	if key == "config" {
+		return false
+	}
+	if cfg.Flags == nil {
+		return slices.Contains(reloadableSettings, key)
+	}
+	return cfg.Flags.Lookup(key) != nil
+}
+
+func defaultTunableSetting(key string) string {
+	defaults := DefaultTunables()
+	switch key {
+	case "combat-round":
+		return defaults.CombatRound.String()
+	case "xp-rate":
+		return strconv.FormatFloat(defaults.XPRate, 'f', -1, 64)
+	case "idle-timeout":
+		return defaults.IdleTimeout.String()
+	case "channel-defaults":
+		return formatChannelDefaults(defaults.Channels)
+	case "blocked-words":
+		return strings.Join(defaults.BlockedWords, ",")
+	case "house-edge":
+		return strconv.Itoa(defaults.HouseEdge)
+	case "gold-faucet-rate":
+		return strconv.FormatFloat(defaults.GoldFaucetRate, 'f', -1, 64)
+	case "gold-sink-rate":
+		return strconv.FormatFloat(defaults.GoldSinkRate, 'f', -1, 64)
+	case "rent-per-day":
+		return strconv.Itoa(defaults.RentPerDay)
+	case "rent-free-items":
+		return strconv.Itoa(defaults.RentFreeItems)
+	}
+	return ""
+}
+
+// LoadConfigFile reads server settings from a YAML (.yaml, .yml) or TOML
+// (.toml) file. Only the flat subset of each format is read: one key per
+// line, named after its command-line flag, holding a string, a number, or a
+// list of strings. Lists may be inline, span several lines, or in YAML be a
+// block of "- item" lines; they are joined with commas the way the flags
+// take them.
+func LoadConfigFile(path string) (map[string]string, error) {
+	settings, _, err := loadConfigFile(path)
+	return settings, err
+}
+
+// loadConfigFile reads a config file like LoadConfigFile and also returns
+// the line each key was set on.
+func loadConfigFile(path string) (map[string]string, map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read config: %w", err)
+	}
+	separator := "="
+	yaml := false
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		separator = ":"
+		yaml = true
+	case ".toml":
+	default:
+		return nil, nil, fmt.Errorf("config file %s must end in .yaml, .yml, or .toml", path)
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read config: %w", err)
+	}
+	settings := make(map[string]string)
+	keyLines := make(map[string]int)
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			return nil, nil, fmt.Errorf("%s:%d: sections are not supported; use flag names as keys", path, lineNo)
+		}
+		key, raw, found := strings.Cut(line, separator)
+		if !found {
+			return nil, nil, fmt.Errorf("%s:%d: expected key %s value", path, lineNo, separator)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		if key == "" {
+			return nil, nil, fmt.Errorf("%s:%d: missing key", path, lineNo)
+		}
+		raw = strings.TrimSpace(raw)
+		var (
+			items  []string
+			isList bool
+		)
+		switch {
+		case yaml && (raw == "" || strings.HasPrefix(raw, "#")) && nextBlockItem(lines, i+1):
+			isList = true
+			for i+1 < len(lines) {
+				item := strings.TrimSpace(lines[i+1])
+				if item == "" || strings.HasPrefix(item, "#") {
+					i++
+					continue
+				}
+				if item != "-" && !strings.HasPrefix(item, "- ") {
+					break
+				}
+				i++
+				value, err := parseConfigValue(strings.TrimSpace(strings.TrimPrefix(item, "-")))
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s:%d: %s: %w", path, i+1, key, err)
+				}
+				items = append(items, value)
+			}
+		case strings.HasPrefix(raw, "["):
+			isList = true
+			// An array may continue over the following lines until its
+			// closing bracket.
+			for {
+				items, err = parseConfigList(raw)
+				if !errors.Is(err, errUnterminatedList) || i+1 >= len(lines) {
+					break
+				}
+				i++
+				raw += "\n" + lines[i]
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s:%d: %s: %w", path, lineNo, key, err)
+			}
+		}
+		var value string
+		if isList {
+			for _, item := range items {
+				if strings.Contains(item, ",") {
+					return nil, nil, fmt.Errorf("%s:%d: %s: list item %q must not contain a comma, which separates list values", path, lineNo, key, item)
+				}
+			}
+			value = strings.Join(items, ",")
+		} else if value, err = parseConfigValue(raw); err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %s: %w", path, lineNo, key, err)
+		}
+		if _, dup := settings[key]; dup {
+			return nil, nil, fmt.Errorf("%s:%d: %s is set twice", path, lineNo, key)
+		}
+		settings[key] = value
+		keyLines[key] = lineNo
+	}
+	return settings, keyLines, nil
+}
+
+// nextBlockItem reports whether the first line with content from start on is
+// a YAML "- item" line.
+func nextBlockItem(lines []string, start int) bool {
+	for _, line := range lines[start:] {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line == "-" || strings.HasPrefix(line, "- ")
+	}
+	return false
+}
+
+// parseConfigValue decodes a quoted string or a bare value with any trailing
+// comment removed.
+func parseConfigValue(raw string) (string, error) {
+	if raw == "" || raw[0] == '#' {
+		return "", nil
+	}
+	if raw[0] == '"' || raw[0] == '\'' {
+		value, rest, err := parseConfigString(raw)
+		if err != nil {
+			return "", err
+		}
+		if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected text after string")
+		}
+		return value, nil
+	}
+	if idx := strings.Index(raw, " #"); idx >= 0 {
+		raw = raw[:idx]
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+// errUnterminatedList reports a list whose closing bracket has not been
+// read yet.
+var errUnterminatedList = errors.New("unterminated list")
+
+// parseConfigList decodes a bracketed list of quoted or bare items. Commas
+// and brackets inside quoted items are part of the item, and comments run
+// to the end of their line.
+func parseConfigList(raw string) ([]string, error) {
+	items := []string{}
+	rest := raw[1:]
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		switch {
+		case rest == "":
+			return nil, errUnterminatedList
+		case rest[0] == '#':
+			_, after, found := strings.Cut(rest, "\n")
+			if !found {
+				return nil, errUnterminatedList
+			}
+			rest = after
+			continue
+		case rest[0] == ']':
+			if tail := strings.TrimSpace(rest[1:]); tail != "" && !strings.HasPrefix(tail, "#") {
+				return nil, fmt.Errorf("unexpected text after list")
+			}
+			return items, nil
+		case rest[0] == ',':
+			return nil, fmt.Errorf("empty list item")
+		}
+		var item string
+		if rest[0] == '"' || rest[0] == '\'' {
+			value, after, err := parseConfigString(rest)
+			if err != nil {
+				return nil, err
+			}
+			item, rest = value, after
+		} else {
+			end := strings.IndexAny(rest, ",]#\n")
+			if end < 0 {
+				return nil, errUnterminatedList
+			}
+			item, rest = strings.TrimSpace(rest[:end]), rest[end:]
+		}
+		items = append(items, item)
+		for {
+			rest = strings.TrimLeft(rest, " \t\r\n")
+			if !strings.HasPrefix(rest, "#") {
+				break
+			}
+			_, after, found := strings.Cut(rest, "\n")
+			if !found {
+				return nil, errUnterminatedList
+			}
+			rest = after
+		}
+		switch {
+		case strings.HasPrefix(rest, ","):
+			rest = rest[1:]
+		case rest == "", strings.HasPrefix(rest, "]"):
+		default:
+			return nil, fmt.Errorf("expected a comma between list items")
+		}
+	}
+}
+
+func parseConfigString(raw string) (string, string, error) {
+	quote := raw[0]
+	if quote == '\'' {
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string")
+		}
+		return raw[1 : end+1], raw[end+2:], nil
+	}
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(raw[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("invalid string: %w", err)
+			}
+			return value, raw[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+// ApplyConfig sets each flag in fs from settings unless it was already given
+// on the command line, and returns the flags that were. Unknown keys are an
+// error so typos do not go unnoticed.
+func ApplyConfig(fs *flag.FlagSet, settings map[string]string) (map[string]bool, error) {
+	overridden := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		overridden[f.Name] = true
+	})
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == "config" || fs.Lookup(key) == nil {
+			return nil, fmt.Errorf("unknown setting %q in config file", key)
+		}
+		if overridden[key] {
+			continue
+		}
+		if err := fs.Set(key, settings[key]); err != nil {
+			return nil, fmt.Errorf("config setting %s: %w", key, err)
+		}
+	}
+	return overridden, nil
+}
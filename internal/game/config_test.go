@@ -0,0 +1,191 @@
+package game
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFileReadsYAMLAndTOML(t *testing.T) {
+	yaml := writeConfig(t, "lumenclay.yaml", `---
+# game settings
+addr: ":4001"
+xp-rate: 1.5   # weekend bonus
+channel-defaults: [say, "ooc"]
+log-file: 'logs/server.log'
+`)
+	toml := writeConfig(t, "lumenclay.toml", `addr = ":4001"
+xp-rate = 1.5 # weekend bonus
+channel-defaults = ["say", "ooc"]
+log-file = "logs/server.log"
+`)
+	for _, path := range []string{yaml, toml} {
+		settings, err := LoadConfigFile(path)
+		if err != nil {
+			t.Fatalf("LoadConfigFile(%s): %v", filepath.Base(path), err)
+		}
+		want := map[string]string{"addr": ":4001", "xp-rate": "1.5", "channel-defaults": "say,ooc", "log-file": "logs/server.log"}
+		if len(settings) != len(want) {
+			t.Fatalf("%s: expected %v, got %v", filepath.Base(path), want, settings)
+		}
+		for key, value := range want {
+			if settings[key] != value {
+				t.Fatalf("%s: expected %s=%q, got %q", filepath.Base(path), key, value, settings[key])
+			}
+		}
+	}
+}
+
+func TestLoadConfigFileReadsListForms(t *testing.T) {
+	yaml := writeConfig(t, "lists.yaml", `channel-defaults:
+  - say
+  - "ooc"   # chatter
+# words kept off signs
+blocked-words: ['[redacted]', "x#y"]
+motd:
+`)
+	toml := writeConfig(t, "lists.toml", `channel-defaults = [
+  "say", # always on
+  'ooc',
+]
+blocked-words = ["[redacted]", "x#y"]
+motd = ""
+`)
+	for _, path := range []string{yaml, toml} {
+		settings, err := LoadConfigFile(path)
+		if err != nil {
+			t.Fatalf("LoadConfigFile(%s): %v", filepath.Base(path), err)
+		}
+		if settings["channel-defaults"] != "say,ooc" || settings["blocked-words"] != "[redacted],x#y" || settings["motd"] != "" || len(settings) != 3 {
+			t.Fatalf("%s: unexpected settings %q", filepath.Base(path), settings)
+		}
+	}
+	_, err := LoadConfigFile(writeConfig(t, "comma.toml", `blocked-words = ["a, b", "c"]`+"\n"))
+	if err == nil || !strings.Contains(err.Error(), `list item "a, b" must not contain a comma`) {
+		t.Fatalf("expected a quoted comma to be reported clearly, got %v", err)
+	}
+}
+
+func TestLoadConfigFileRejectsBadInput(t *testing.T) {
+	cases := map[string]string{
+		"sections.toml":  "[server]\naddr = \":4000\"\n",
+		"dup.yaml":       "addr: a\naddr: b\n",
+		"nokey.toml":     "addr\n",
+		"unclosed.yaml":  "motd: \"hello\n",
+		"openlist.toml":  "channel-defaults = [\"say\",\n",
+		"nocomma.yaml":   "channel-defaults: [\"say\" \"ooc\"]\n",
+		"lumenclay.json": "{}",
+	}
+	for name, body := range cases {
+		if _, err := LoadConfigFile(writeConfig(t, name, body)); err == nil {
+			t.Fatalf("expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestApplyConfigLetsFlagsWin(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	addr := fs.String("addr", ":4000", "")
+	rate := fs.Float64("xp-rate", 1, "")
+	if err := fs.Parse([]string{"-addr", ":5000"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	overridden, err := ApplyConfig(fs, map[string]string{"addr": ":4001", "xp-rate": "2"})
+	if err != nil {
+		t.Fatalf("ApplyConfig: %v", err)
+	}
+	if *addr != ":5000" || *rate != 2 {
+		t.Fatalf("expected command-line addr and config xp-rate, got %q and %v", *addr, *rate)
+	}
+	if !overridden["addr"] || overridden["xp-rate"] {
+		t.Fatalf("unexpected overridden set %v", overridden)
+	}
+	if _, err := ApplyConfig(fs, map[string]string{"adr": ":4001"}); err == nil || !strings.Contains(err.Error(), "adr") {
+		t.Fatalf("expected unknown key to be reported, got %v", err)
+	}
+}
+
+func TestReloadConfigAppliesLiveSettings(t *testing.T) {
+	defer setChannelDefaults(nil)
+	path := writeConfig(t, "lumenclay.yaml", "addr: \":4000\"\nxp-rate: 1\ncombat-round: 4s\n")
+	settings, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	world.ConfigureTunables(DefaultTunables())
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, name := range append([]string{"addr"}, reloadableSettings...) {
+		fs.String(name, "", "")
+	}
+	world.AttachConfigFile(ConfigFile{Path: path, Settings: settings, Overridden: map[string]bool{"combat-round": true}, Flags: fs})
+
+	if err := os.WriteFile(path, []byte("addr: \":4001\"\nxp-rate: 2\ncombat-round: 2s\nidle-timeout: 15m\nchannel-defaults: say\n"), 0o644); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+	result, err := world.ReloadConfig()
+	if err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	if strings.Join(result.Applied, ",") != "channel-defaults,idle-timeout,xp-rate" {
+		t.Fatalf("unexpected applied settings %v", result.Applied)
+	}
+	if strings.Join(result.Overridden, ",") != "combat-round" || strings.Join(result.Restart, ",") != "addr" {
+		t.Fatalf("unexpected overridden %v or restart %v", result.Overridden, result.Restart)
+	}
+	tunables := world.Tunables()
+	if tunables.XPRate != 2 || tunables.IdleTimeout != 15*time.Minute || tunables.CombatRound != DefaultCombatRound {
+		t.Fatalf("unexpected tunables %+v", tunables)
+	}
+	if channels := DefaultChannelSettings(); !channels[ChannelSay] || channels[ChannelOOC] {
+		t.Fatalf("expected only say enabled for new characters, got %v", channels)
+	}
+
+	if err := os.WriteFile(path, []byte("addr: \":4001\"\nxp-rate: lots\n"), 0o644); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+	if _, err := world.ReloadConfig(); err == nil {
+		t.Fatalf("expected an invalid value to fail the reload")
+	}
+	if world.Tunables().XPRate != 2 {
+		t.Fatalf("failed reload should leave settings untouched")
+	}
+
+	if err := os.WriteFile(path, []byte("addr: \":4001\"\nxp-rate: 3\nxp-rte: 4\n"), 0o644); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+	_, err = world.ReloadConfig()
+	if err == nil || !strings.Contains(err.Error(), ":3:") || !strings.Contains(err.Error(), `"xp-rte"`) {
+		t.Fatalf("expected the unknown key and its line in the error, got %v", err)
+	}
+	if world.Tunables().XPRate != 2 {
+		t.Fatalf("a reload with an unknown key should leave settings untouched")
+	}
+}
+
+func TestXPRateScalesExperience(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	p := &Player{Name: "Hero", Room: "start", Output: NewOutputQueue(), Alive: true, Level: 1}
+	world.AddPlayerForTest(p)
+	tunables := DefaultTunables()
+	tunables.XPRate = 1.5
+	world.ConfigureTunables(tunables)
+	defer setChannelDefaults(nil)
+
+	world.AwardExperience(p, 10)
+	if p.Experience != 15 {
+		t.Fatalf("expected 15 experience at 1.5x, got %d", p.Experience)
+	}
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ConsumableKind decides which command uses up a consumable item.
+type ConsumableKind string
+
+const (
+	// Potion items are drunk with quaff.
+	Potion ConsumableKind = "potion"
+	// Food items are eaten with eat.
+	Food ConsumableKind = "food"
+	// Scroll items are read aloud with recite.
+	Scroll ConsumableKind = "scroll"
+)
+
+// HomeDestination is the teleport target that sends a player to their home.
+const HomeDestination RoomID = "home"
+
+// Verb returns the command that uses up consumables of this kind.
+func (k ConsumableKind) Verb() string {
+	switch k {
+	case Potion:
+		return "quaff"
+	case Food:
+		return "eat"
+	case Scroll:
+		return "recite"
+	}
+	return "use"
+}
+
+// Consumable describes what an item does when it is used up. Builders set it
+// on items and resets in area files.
+type Consumable struct {
+	Kind ConsumableKind `json:"kind"`
+	// Heal, Mana, and Moves restore the matching pool, up to its maximum.
+	Heal  int `json:"heal,omitempty"`
+	Mana  int `json:"mana,omitempty"`
+	Moves int `json:"moves,omitempty"`
+	// Effect names a status effect lasting Duration seconds that adjusts
+	// melee damage by DamagePercent and raises strength and dexterity.
+	Effect        string     `json:"effect,omitempty"`
+	Duration      int        `json:"duration,omitempty"`
+	DamagePercent int        `json:"damage_percent,omitempty"`
+	Attributes    Attributes `json:"attributes"`
+	// Teleport moves the player to a room, or to their home when it is
+	// "home".
+	Teleport RoomID `json:"teleport,omitempty"`
+}
+
+func (c *Consumable) effect(now time.Time) (StatusEffect, bool) {
+	if strings.TrimSpace(c.Effect) == "" || c.Duration <= 0 {
+		return StatusEffect{}, false
+	}
+	return StatusEffect{
+		Name:          c.Effect,
+		Expires:       now.Add(time.Duration(c.Duration) * time.Second),
+		DamagePercent: c.DamagePercent,
+		Attributes:    Attributes{Str: c.Attributes.Str, Dex: c.Attributes.Dex},
+	}, true
+}
+
+// ConsumeResult reports what using a consumable did.
+type ConsumeResult struct {
+	Item     Item
+	Healed   int
+	Mana     int
+	Moves    int
+	Effect   *StatusEffect
+	From     RoomID
+	Teleport RoomID
+}
+
+// Consume uses up the named item from p's inventory. The item must be a
+// consumable of the given kind. Scrolls that teleport fail, and are kept,
+// when their destination does not exist.
+func (w *World) Consume(p *Player, kind ConsumableKind, name string) (ConsumeResult, error) {
+	target := strings.TrimSpace(name)
+	if target == "" {
+		return ConsumeResult{}, fmt.Errorf("item name must not be empty")
+	}
+	w.mu.Lock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		w.mu.Unlock()
+		return ConsumeResult{}, fmt.Errorf("%s is not online", p.Name)
+	}
+	idx := findItemIndex(p.Inventory, target)
+	if idx == -1 {
+		w.mu.Unlock()
+		return ConsumeResult{}, ErrItemNotCarried
+	}
+	item := p.Inventory[idx]
+	use := item.Consumable
+	if use == nil || use.Kind != kind {
+		w.mu.Unlock()
+		return ConsumeResult{}, fmt.Errorf("you can't %s %s", kind.Verb(), item.Name)
+	}
+	result := ConsumeResult{Item: item, From: p.Room}
+	if use.Teleport != "" {
+		destination := use.Teleport
+		if destination == HomeDestination {
+			destination = p.Home
+			if destination == "" {
+				destination = StartRoom
+			}
+		}
+		if _, ok := w.rooms[destination]; !ok {
+			w.mu.Unlock()
+			return ConsumeResult{}, fmt.Errorf("the words of %s lead nowhere", item.Name)
+		}
+		result.Teleport = destination
+	}
+	p.Inventory = append(p.Inventory[:idx], p.Inventory[idx+1:]...)
+	p.EnsureStats()
+	result.Healed = restore(&p.Health, p.MaxHealth, use.Heal)
+	result.Mana = restore(&p.Mana, p.MaxMana, use.Mana)
+	result.Moves = restore(&p.Moves, p.MaxMoves, use.Moves)
+	if effect, ok := use.effect(time.Now()); ok {
+		p.addEffect(effect)
+		effect.Name = strings.ToLower(strings.TrimSpace(effect.Name))
+		result.Effect = &effect
+	}
+	if result.Teleport != "" {
+		p.Room = result.Teleport
+		w.syncPresenceLocked(p)
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return result, nil
+}
+
+// restore raises *pool by amount without passing limit and returns how much
+// it rose.
+func restore(pool *int, limit, amount int) int {
+	if amount <= 0 || *pool >= limit {
+		return 0
+	}
+	before := *pool
+	*pool = min(*pool+amount, limit)
+	return *pool - before
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConsumeRestoresAndBuffs(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	player := &Player{Name: "Ilya", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	player.Health = player.MaxHealth - 5
+	player.Inventory = []Item{
+		{Name: "Healing Draught", Consumable: &Consumable{Kind: Potion, Heal: 40}},
+		{Name: "Emberroot Tonic", Consumable: &Consumable{Kind: Potion, Effect: "Emberroot", Duration: 60, Attributes: Attributes{Str: 2}}},
+		{Name: "Star Chart"},
+	}
+
+	if _, err := world.Consume(player, Food, "draught"); err == nil || err.Error() != "you can't eat Healing Draught" {
+		t.Fatalf("eating a potion err = %v", err)
+	}
+	if _, err := world.Consume(player, Potion, "chart"); err == nil {
+		t.Fatalf("quaffing a chart should fail")
+	}
+	if _, err := world.Consume(player, Potion, "lantern"); !errors.Is(err, ErrItemNotCarried) {
+		t.Fatalf("missing item err = %v", err)
+	}
+
+	result, err := world.Consume(player, Potion, "draught")
+	if err != nil {
+		t.Fatalf("Consume error: %v", err)
+	}
+	if result.Healed != 5 || player.Health != player.MaxHealth {
+		t.Fatalf("healed %d to %d/%d", result.Healed, player.Health, player.MaxHealth)
+	}
+	if len(player.Inventory) != 2 {
+		t.Fatalf("the draught should be used up: %+v", player.Inventory)
+	}
+
+	strength := player.Attributes().Str
+	result, err = world.Consume(player, Potion, "tonic")
+	if err != nil {
+		t.Fatalf("Consume tonic error: %v", err)
+	}
+	if result.Effect == nil || result.Effect.Name != "emberroot" || !player.HasEffect("emberroot") {
+		t.Fatalf("tonic effect = %+v", result.Effect)
+	}
+	if got := player.Attributes().Str; got != strength+2 {
+		t.Fatalf("strength under emberroot = %d, want %d", got, strength+2)
+	}
+}
+
+func TestRecallScrollTeleportsHome(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{}},
+		"yard":    {ID: "yard", Exits: map[string]Exit{}},
+	})
+	player := &Player{Name: "Brom", Room: StartRoom, Home: "yard", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	player.Inventory = []Item{
+		{Name: "Scroll of Nowhere", Consumable: &Consumable{Kind: Scroll, Teleport: "void"}},
+		{Name: "Scroll of Recall", Consumable: &Consumable{Kind: Scroll, Teleport: HomeDestination}},
+	}
+
+	if _, err := world.Consume(player, Scroll, "nowhere"); err == nil || len(player.Inventory) != 2 {
+		t.Fatalf("a scroll to a missing room should fail and be kept, err = %v", err)
+	}
+	result, err := world.Consume(player, Scroll, "recall")
+	if err != nil {
+		t.Fatalf("Consume error: %v", err)
+	}
+	if result.From != StartRoom || result.Teleport != "yard" || player.Room != "yard" {
+		t.Fatalf("recall result = %+v, room %s", result, player.Room)
+	}
+}
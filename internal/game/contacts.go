@@ -0,0 +1,142 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FriendStatus describes one entry in a player's friends list.
+type FriendStatus struct {
+	Name   string
+	Online bool
+}
+
+func containsName(names []string, name string) bool {
+	for _, existing := range names {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeName(names []string, name string) []string {
+	out := names[:0]
+	for _, existing := range names {
+		if !strings.EqualFold(existing, name) {
+			out = append(out, existing)
+		}
+	}
+	return out
+}
+
+// isIgnoring reports whether p has ignored the named player. Staff are never
+// ignored so moderation notices always arrive.
+func (p *Player) isIgnoring(from *Player) bool {
+	if p == nil || from == nil || from == p || from.IsAdmin || from.IsModerator {
+		return false
+	}
+	return containsName(p.Ignored, from.Name)
+}
+
+// Ignoring reports whether target has ignored messages from sender.
+func (w *World) Ignoring(target, sender *Player) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return target.isIgnoring(sender)
+}
+
+// resolveContactName matches a token against online players and known
+// characters, returning the canonical name.
+func (w *World) resolveContactName(token string) (string, bool) {
+	w.mu.RLock()
+	if p, ok := w.findPlayerLocked(token); ok {
+		w.mu.RUnlock()
+		return p.Name, true
+	}
+	accounts := w.accounts
+	w.mu.RUnlock()
+	if accounts == nil {
+		return "", false
+	}
+	return accounts.MatchAccountName(token)
+}
+
+// ToggleIgnore adds or removes name from the player's ignore list and
+// reports whether the player is now ignoring them.
+func (w *World) ToggleIgnore(p *Player, name string) (string, bool, error) {
+	return w.toggleContact(p, name, func(p *Player) *[]string { return &p.Ignored }, "ignore")
+}
+
+// ToggleFriend adds or removes name from the player's friends list and
+// reports whether they are now a friend.
+func (w *World) ToggleFriend(p *Player, name string) (string, bool, error) {
+	return w.toggleContact(p, name, func(p *Player) *[]string { return &p.Friends }, "befriend")
+}
+
+func (w *World) toggleContact(p *Player, token string, list func(*Player) *[]string, verb string) (string, bool, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", false, fmt.Errorf("who do you want to %s?", verb)
+	}
+	name, ok := w.resolveContactName(token)
+	if !ok {
+		return "", false, fmt.Errorf("%s has not walked the clay yet", token)
+	}
+	if strings.EqualFold(name, p.Name) {
+		return "", false, fmt.Errorf("you cannot %s yourself", verb)
+	}
+	w.mu.Lock()
+	names := list(p)
+	added := !containsName(*names, name)
+	if added {
+		*names = append(*names, name)
+		sort.Strings(*names)
+	} else {
+		*names = removeName(*names, name)
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return name, added, nil
+}
+
+// IgnoredPlayers lists the names the player is ignoring.
+func (w *World) IgnoredPlayers(p *Player) []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]string(nil), p.Ignored...)
+}
+
+// Friends lists the player's friends and whether each is online.
+func (w *World) Friends(p *Player) []FriendStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	list := make([]FriendStatus, 0, len(p.Friends))
+	for _, name := range p.Friends {
+		status := FriendStatus{Name: name}
+		if friend, ok := w.players[name]; ok && friend.Alive {
+			status.Online = true
+		}
+		list = append(list, status)
+	}
+	return list
+}
+
+// NotifyFriends tells everyone who has befriended p that p logged in or out.
+func (w *World) NotifyFriends(p *Player, online bool) {
+	verb := "has logged out"
+	if online {
+		verb = "has logged in"
+	}
+	msg := Ansi(fmt.Sprintf("\r\n%s %s %s.", Style("[Friends]", AnsiGreen, AnsiBold), HighlightName(p.Name), verb))
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, other := range w.players {
+		if other == p || !other.Alive || !containsName(other.Friends, p.Name) || other.isIgnoring(p) {
+			continue
+		}
+		other.Output.Send(msg)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreFiltersChannelMessages(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	loud := &Player{Name: "Loud", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	quiet := &Player{Name: "Quiet", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	mod := &Player{Name: "Mod", Room: "hall", Output: NewOutputQueue(), Alive: true, IsModerator: true}
+	world.AddPlayerForTest(loud)
+	world.AddPlayerForTest(quiet)
+	world.AddPlayerForTest(mod)
+
+	if _, ignoring, err := world.ToggleIgnore(quiet, "loud"); err != nil || !ignoring {
+		t.Fatalf("ToggleIgnore = %v, %v", ignoring, err)
+	}
+	if _, _, err := world.ToggleIgnore(quiet, "mod"); err != nil {
+		t.Fatalf("ToggleIgnore mod: %v", err)
+	}
+	world.BroadcastToAllChannel("\r\n[OOC] Loud: spam", loud, ChannelOOC)
+	world.BroadcastToRoomChannel("hall", "\r\nMod says: behave", mod, ChannelSay)
+	got := strings.Join(drainOutput(quiet.Output), "\n")
+	if strings.Contains(got, "spam") {
+		t.Fatalf("ignored player's message was delivered: %q", got)
+	}
+	if !strings.Contains(got, "behave") {
+		t.Fatalf("staff should never be ignored, got %q", got)
+	}
+
+	if _, ignoring, _ := world.ToggleIgnore(quiet, "Loud"); ignoring {
+		t.Fatalf("second toggle should stop ignoring")
+	}
+	if _, _, err := world.ToggleIgnore(quiet, "quiet"); err == nil {
+		t.Fatalf("expected an error ignoring yourself")
+	}
+}
+
+func TestFriendsPersistAndGetLoginNotices(t *testing.T) {
+	dir := t.TempDir()
+	accounts, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	for _, name := range []string{"Alice", "Bob"} {
+		if err := accounts.Register(name, "password"); err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{}}})
+	world.AttachAccountManager(accounts)
+	alice, err := world.addPlayer("Alice", nil, false, accounts.Profile("Alice"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+
+	if name, added, err := world.ToggleFriend(alice, "bob"); err != nil || !added || name != "Bob" {
+		t.Fatalf("ToggleFriend = %q, %v, %v", name, added, err)
+	}
+	if friends := world.Friends(alice); len(friends) != 1 || friends[0].Online {
+		t.Fatalf("friends = %+v, want Bob offline", friends)
+	}
+	if profile := accounts.Profile("Alice"); len(profile.Friends) != 1 || profile.Friends[0] != "Bob" {
+		t.Fatalf("saved friends = %v", profile.Friends)
+	}
+
+	bob, err := world.addPlayer("Bob", nil, false, accounts.Profile("Bob"))
+	if err != nil {
+		t.Fatalf("addPlayer bob: %v", err)
+	}
+	drainOutput(alice.Output)
+	world.NotifyFriends(bob, true)
+	if got := stripAnsi(strings.Join(drainOutput(alice.Output), "\n")); !strings.Contains(got, "Bob has logged in") {
+		t.Fatalf("expected a login notice, got %q", got)
+	}
+	if friends := world.Friends(alice); !friends[0].Online {
+		t.Fatalf("Bob should be listed online")
+	}
+}
@@ -0,0 +1,185 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultContainerCapacity bounds containers that do not declare a capacity.
+const DefaultContainerCapacity = 10
+
+var (
+	// ErrContainerNotFound indicates the requested container could not be located.
+	ErrContainerNotFound = errors.New("container not found")
+	// ErrNotContainer indicates the target item cannot hold other items.
+	ErrNotContainer = errors.New("that is not a container")
+	// ErrContainerFull indicates the container has no room left.
+	ErrContainerFull = errors.New("container is full")
+	// ErrItemNotInContainer indicates the container does not hold the requested item.
+	ErrItemNotInContainer = errors.New("item not in container")
+)
+
+// IsContainer reports whether the item can hold other items.
+func (i Item) IsContainer() bool {
+	return i.Container || i.Corpse
+}
+
+// ContainerCapacity returns the maximum number of items the container holds.
+func (i Item) ContainerCapacity() int {
+	if i.Capacity > 0 {
+		return i.Capacity
+	}
+	return DefaultContainerCapacity
+}
+
+func cloneItem(item Item) Item {
+	item.Contents = cloneItems(item.Contents)
+	return item
+}
+
+func cloneItems(items []Item) []Item {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]Item, len(items))
+	for i, item := range items {
+		out[i] = item
+		out[i].Contents = cloneItems(item.Contents)
+	}
+	return out
+}
+
+// findContainerLocked resolves a container carried by the player or lying in
+// their room. Carried containers take precedence. The skip index excludes an
+// inventory slot, allowing callers to ignore the item being moved.
+func (w *World) findContainerLocked(p *Player, name string, skip int) (*Item, error) {
+	target := strings.TrimSpace(name)
+	if target == "" {
+		return nil, ErrContainerNotFound
+	}
+	var (
+		candidates []string
+		items      []*Item
+	)
+	for i := range p.Inventory {
+		if i == skip {
+			continue
+		}
+		candidates = append(candidates, p.Inventory[i].Name)
+		items = append(items, &p.Inventory[i])
+	}
+	if idx, ok := uniqueMatch(target, candidates, true); ok {
+		return checkContainer(items[idx])
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return nil, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	idx := findItemIndex(room.Items, target)
+	if idx < 0 {
+		return nil, ErrContainerNotFound
+	}
+	return checkContainer(&room.Items[idx])
+}
+
+// carriedLocked reports whether item points into p's inventory.
+func carriedLocked(p *Player, item *Item) bool {
+	for i := range p.Inventory {
+		if &p.Inventory[i] == item {
+			return true
+		}
+	}
+	return false
+}
+
+func checkContainer(item *Item) (*Item, error) {
+	if !item.IsContainer() {
+		return nil, ErrNotContainer
+	}
+	return item, nil
+}
+
+// PutItemInContainer moves an item from the player's inventory into a
+// container they carry or one in their current room.
+func (w *World) PutItemInContainer(p *Player, itemName, containerName string) (*Item, *Item, error) {
+	target := strings.TrimSpace(itemName)
+	if target == "" {
+		return nil, nil, fmt.Errorf("item name must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		return nil, nil, fmt.Errorf("%s is not online", p.Name)
+	}
+	idx := findItemIndex(p.Inventory, target)
+	if idx == -1 {
+		return nil, nil, ErrItemNotCarried
+	}
+	container, err := w.findContainerLocked(p, containerName, idx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if container.Corpse {
+		return nil, nil, ErrNotContainer
+	}
+	if len(container.Contents) >= container.ContainerCapacity() {
+		return nil, nil, ErrContainerFull
+	}
+	item := p.Inventory[idx]
+	container.Contents = append(container.Contents, item)
+	snapshot := *container
+	snapshot.Contents = cloneItems(container.Contents)
+	// Removing the item may shift the container within the inventory, so the
+	// snapshot above is taken first.
+	p.Inventory = append(p.Inventory[:idx], p.Inventory[idx+1:]...)
+	return &item, &snapshot, nil
+}
+
+// TakeItemFromContainer removes an item from a container the player carries or
+// one in their room and places it in their inventory.
+func (w *World) TakeItemFromContainer(p *Player, itemName, containerName string) (*Item, *Item, error) {
+	item, container, err := w.takeItemFromContainer(p, itemName, containerName)
+	if err != nil {
+		return nil, nil, err
+	}
+	w.mu.RLock()
+	room := p.Room
+	w.mu.RUnlock()
+	w.Publish(GameEvent{Type: EventItemTaken, Player: p, Room: room, Item: *item, Container: container.Name})
+	return item, container, nil
+}
+
+func (w *World) takeItemFromContainer(p *Player, itemName, containerName string) (*Item, *Item, error) {
+	target := strings.TrimSpace(itemName)
+	if target == "" {
+		return nil, nil, fmt.Errorf("item name must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		return nil, nil, fmt.Errorf("%s is not online", p.Name)
+	}
+	container, err := w.findContainerLocked(p, containerName, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if container.Owner != "" && !strings.EqualFold(container.Owner, p.Name) && !p.IsAdmin {
+		return nil, nil, ErrCorpseNotYours
+	}
+	idx := findItemIndex(container.Contents, target)
+	if idx == -1 {
+		return nil, nil, ErrItemNotInContainer
+	}
+	item := container.Contents[idx]
+	if !carriedLocked(p, container) && !canCarryLocked(p, item.TotalWeight()) {
+		return nil, nil, ErrTooHeavy
+	}
+	container.Contents = append(container.Contents[:idx], container.Contents[idx+1:]...)
+	snapshot := *container
+	snapshot.Contents = cloneItems(container.Contents)
+	p.Inventory = append(p.Inventory, item)
+	return &item, &snapshot, nil
+}
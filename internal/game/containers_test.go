@@ -0,0 +1,91 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPutAndTakeFromContainer(t *testing.T) {
+	rooms := map[RoomID]*Room{
+		"vault": {
+			ID:    "vault",
+			Items: []Item{{Name: "Oak Chest", Container: true, Capacity: 1}},
+		},
+	}
+	world := NewWorldWithRooms(rooms)
+	player := &Player{Name: "Keeper", Room: "vault", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	player.Inventory = []Item{{Name: "Brass Key"}, {Name: "Copper Coin"}, {Name: "Pebble"}}
+
+	item, container, err := world.PutItemInContainer(player, "brass", "chest")
+	if err != nil {
+		t.Fatalf("PutItemInContainer: %v", err)
+	}
+	if item.Name != "Brass Key" || container.Name != "Oak Chest" {
+		t.Fatalf("unexpected put result: %+v in %+v", item, container)
+	}
+	if len(player.Inventory) != 2 {
+		t.Fatalf("expected key removed from inventory, got %+v", player.Inventory)
+	}
+	if _, _, err := world.PutItemInContainer(player, "coin", "chest"); !errors.Is(err, ErrContainerFull) {
+		t.Fatalf("expected ErrContainerFull, got %v", err)
+	}
+	if _, _, err := world.PutItemInContainer(player, "coin", "pebble"); !errors.Is(err, ErrNotContainer) {
+		t.Fatalf("expected ErrNotContainer, got %v", err)
+	}
+
+	taken, _, err := world.TakeItemFromContainer(player, "key", "oak chest")
+	if err != nil {
+		t.Fatalf("TakeItemFromContainer: %v", err)
+	}
+	if taken.Name != "Brass Key" || len(rooms["vault"].Items[0].Contents) != 0 {
+		t.Fatalf("expected key taken from chest, got %+v", rooms["vault"].Items[0])
+	}
+	if _, _, err := world.TakeItemFromContainer(player, "key", "chest"); !errors.Is(err, ErrItemNotInContainer) {
+		t.Fatalf("expected ErrItemNotInContainer, got %v", err)
+	}
+}
+
+func TestPutItemIntoCarriedContainer(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"road": {ID: "road"}})
+	player := &Player{Name: "Walker", Room: "road", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	player.Inventory = []Item{{Name: "Herb"}, {Name: "Leather Pouch", Container: true}}
+
+	if _, _, err := world.PutItemInContainer(player, "herb", "pouch"); err != nil {
+		t.Fatalf("PutItemInContainer: %v", err)
+	}
+	if len(player.Inventory) != 1 || player.Inventory[0].Name != "Leather Pouch" {
+		t.Fatalf("inventory = %+v, want pouch only", player.Inventory)
+	}
+	if contents := player.Inventory[0].Contents; len(contents) != 1 || contents[0].Name != "Herb" {
+		t.Fatalf("pouch contents = %+v, want herb", contents)
+	}
+}
+
+func TestContainerResetSpawnsContents(t *testing.T) {
+	room := &Room{
+		ID: "cellar",
+		Resets: []RoomReset{{
+			Kind:      ResetKindItem,
+			Name:      "Barrel",
+			Container: true,
+			Capacity:  3,
+			Contents:  []Item{{Name: "Apple"}},
+		}},
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"cellar": room})
+	if err := world.ApplyRoomResets("cellar", ""); err != nil {
+		t.Fatalf("ApplyRoomResets: %v", err)
+	}
+	if len(room.Items) != 1 || !room.Items[0].Container || room.Items[0].Capacity != 3 {
+		t.Fatalf("expected barrel container, got %+v", room.Items)
+	}
+	if len(room.Items[0].Contents) != 1 || room.Items[0].Contents[0].Name != "Apple" {
+		t.Fatalf("barrel contents = %+v, want apple", room.Items[0].Contents)
+	}
+	room.Items[0].Contents[0].Name = "Core"
+	if room.Resets[0].Contents[0].Name != "Apple" {
+		t.Fatalf("spawned contents should not alias reset definition")
+	}
+}
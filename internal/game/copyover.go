@@ -0,0 +1,467 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// copyoverEnv names the environment variable that points a re-executed
+// server at the state left behind by its predecessor.
+const copyoverEnv = "LUMENCLAY_COPYOVER"
+
+// ErrCopyoverUnavailable is returned when the server cannot hand its sockets
+// to a new process, for example while the MUD listener uses TLS.
+var ErrCopyoverUnavailable = errors.New("copyover is not available on this listener")
+
+var (
+	copyoverStart = func(cmd *exec.Cmd) error { return cmd.Start() }
+	copyoverExit  = os.Exit
+)
+
+type copyoverPlayer struct {
+	FD          int                  `json:"fd"`
+	Name        string               `json:"name"`
+	Account     string               `json:"account"`
+	Character   string               `json:"character,omitempty"`
+	IsAdmin     bool                 `json:"is_admin,omitempty"`
+	IsModerator bool                 `json:"is_moderator,omitempty"`
+	IsBuilder   bool                 `json:"is_builder,omitempty"`
+	Room        RoomID               `json:"room"`
+	Home        RoomID               `json:"home,omitempty"`
+	Channels    map[string]bool      `json:"channels,omitempty"`
+	Aliases     map[string]string    `json:"aliases,omitempty"`
+	Inventory   []Item               `json:"inventory,omitempty"`
+	Gold        int                  `json:"gold,omitempty"`
+	Quests      []QuestProgress      `json:"quests,omitempty"`
+	Ignored     []string             `json:"ignored,omitempty"`
+	Friends     []string             `json:"friends,omitempty"`
+	Tutorial    TutorialStep         `json:"tutorial,omitempty"`
+	Prompt      string               `json:"prompt,omitempty"`
+	Combat      string               `json:"combat_prompt,omitempty"`
+	WizInvis    bool                 `json:"wizinvis,omitempty"`
+	SpeedOff    bool                 `json:"speedwalk_off,omitempty"`
+	PageLines   int                  `json:"page_lines,omitempty"`
+	Language    string               `json:"language,omitempty"`
+	Bio         string               `json:"bio,omitempty"`
+	BioFlag     string               `json:"bio_flag,omitempty"`
+	Kills       int                  `json:"kills,omitempty"`
+	Bounty      int                  `json:"bounty,omitempty"`
+	Explored    map[RoomID]bool      `json:"explored,omitempty"`
+	Waypoints   map[RoomID]bool      `json:"waypoints,omitempty"`
+	Reputation  map[string]int       `json:"reputation,omitempty"`
+	CmdAliases  map[string]string    `json:"command_aliases,omitempty"`
+	Achieved    map[string]time.Time `json:"achievements,omitempty"`
+	Title       string               `json:"title,omitempty"`
+	Race        string               `json:"race,omitempty"`
+	Class       string               `json:"class,omitempty"`
+	Trained     Attributes           `json:"trained,omitempty"`
+	Points      int                  `json:"train_points,omitempty"`
+	Lockouts    map[string]time.Time `json:"lockouts,omitempty"`
+	Trades      map[string]int       `json:"professions,omitempty"`
+	Level       int                  `json:"level,omitempty"`
+	Experience  int                  `json:"experience,omitempty"`
+	RestedXP    int                  `json:"rested_xp,omitempty"`
+	JoinedAt    time.Time            `json:"joined_at,omitempty"`
+	Health      int                  `json:"health,omitempty"`
+	Mana        int                  `json:"mana,omitempty"`
+	Moves       int                  `json:"moves,omitempty"`
+	Mount       *NPC                 `json:"mount,omitempty"`
+	Terminal    telnetState          `json:"terminal"`
+}
+
+type copyoverRoom struct {
+	ID    RoomID `json:"id"`
+	Items []Item `json:"items,omitempty"`
+	NPCs  []NPC  `json:"npcs,omitempty"`
+}
+
+// copyoverState is written to disk before the server re-executes itself.
+// File descriptors are passed as ExtraFiles, so FD indexes are relative to
+// descriptor 3 in the new process.
+type copyoverState struct {
+	ListenerFD int              `json:"listener_fd"`
+	Players    []copyoverPlayer `json:"players"`
+	Rooms      []copyoverRoom   `json:"rooms,omitempty"`
+	AwayNPCs   []NPC            `json:"away_npcs,omitempty"`
+}
+
+type resumedSession struct {
+	conn    net.Conn
+	session *TelnetSession
+	player  *Player
+}
+
+// attachListener records the MUD listener and where copyover state is written.
+func (w *World) attachListener(ln net.Listener, statePath string) {
+	w.mu.Lock()
+	w.listener = ln
+	w.copyoverPath = statePath
+	w.mu.Unlock()
+}
+
+// CopyoverAvailable reports whether Copyover can hand sessions to a new
+// process.
+func (w *World) CopyoverAvailable() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	_, ok := w.listener.(*net.TCPListener)
+	return ok && w.copyoverPath != ""
+}
+
+// Copyover serialises connected players and room contents, re-executes the
+// server binary with the listening socket and player connections inherited,
+// and exits once the new process has started. Players on connections that
+// cannot be inherited are told to reconnect.
+func (w *World) Copyover() error {
+	w.mu.RLock()
+	listener, ok := w.listener.(*net.TCPListener)
+	statePath := w.copyoverPath
+	w.mu.RUnlock()
+	if !ok || statePath == "" {
+		return ErrCopyoverUnavailable
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate server binary: %w", err)
+	}
+	listenerFile, err := listener.File()
+	if err != nil {
+		return fmt.Errorf("copy listener: %w", err)
+	}
+	files := []*os.File{listenerFile}
+	closeFiles := func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}
+
+	state, sessions, skipped := w.copyoverSnapshot(func(conn net.Conn) (int, bool) {
+		tcp, ok := conn.(*net.TCPConn)
+		if !ok {
+			return 0, false
+		}
+		file, err := tcp.File()
+		if err != nil {
+			return 0, false
+		}
+		files = append(files, file)
+		return len(files) - 1, true
+	})
+	if err := writeCopyoverState(statePath, state); err != nil {
+		closeFiles()
+		return err
+	}
+	if err := flushPersistence(); err != nil {
+		Logger().Error("copyover could not flush queued saves", "error", err)
+	}
+	if tokens := w.APITokens(); tokens != nil {
+		if err := tokens.Flush(); err != nil {
+			Logger().Warn("failed to save api token usage", "error", err)
+		}
+	}
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), copyoverEnv+"="+statePath)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	for _, session := range sessions {
+		_ = session.WriteString(Ansi("\r\n" + Style("Time holds still as the world is rebuilt around you...", AnsiMagenta, AnsiBold) + "\r\n"))
+	}
+	if err := copyoverStart(cmd); err != nil {
+		closeFiles()
+		os.Remove(statePath)
+		return fmt.Errorf("start new server: %w", err)
+	}
+	for _, session := range skipped {
+		_ = session.WriteString(Ansi("\r\n" + Style("Your connection cannot survive the copyover. Please reconnect in a moment.", AnsiYellow) + "\r\n"))
+	}
+	Logger().Info("copyover handed off", "pid", cmd.Process.Pid, "players", len(state.Players))
+	copyoverExit(0)
+	return nil
+}
+
+// copyoverSnapshot captures every connected player whose connection can be
+// inherited. inherit returns the descriptor index for a connection.
+func (w *World) copyoverSnapshot(inherit func(net.Conn) (int, bool)) (copyoverState, []*TelnetSession, []*TelnetSession) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	state := copyoverState{ListenerFD: 0}
+	var sessions, skipped []*TelnetSession
+	for _, name := range w.playerOrder {
+		p, ok := w.players[name]
+		if !ok || !p.Alive || p.Session == nil {
+			continue
+		}
+		fd, ok := inherit(p.Session.conn)
+		if !ok {
+			skipped = append(skipped, p.Session)
+			continue
+		}
+		sessions = append(sessions, p.Session)
+		state.Players = append(state.Players, copyoverPlayer{
+			FD:          fd,
+			Name:        p.Name,
+			Account:     p.Account,
+			Character:   p.Character,
+			IsAdmin:     p.IsAdmin,
+			IsModerator: p.IsModerator,
+			IsBuilder:   p.IsBuilder,
+			Room:        p.Room,
+			Home:        p.Home,
+			Channels:    encodeChannelSettings(p.Channels),
+			Aliases:     encodeChannelAliases(p.ChannelAliases),
+			Inventory:   cloneItems(p.Inventory),
+			Gold:        p.Gold,
+			Quests:      encodeQuestLog(p.QuestLog),
+			Ignored:     append([]string(nil), p.Ignored...),
+			Friends:     append([]string(nil), p.Friends...),
+			Tutorial:    p.Tutorial,
+			Prompt:      p.PromptFormat,
+			Combat:      p.CombatPrompt,
+			WizInvis:    p.WizInvis,
+			SpeedOff:    p.SpeedwalkOff,
+			PageLines:   p.PageLines,
+			Language:    p.Language,
+			Bio:         p.Bio,
+			BioFlag:     p.BioFlag,
+			Kills:       p.Kills,
+			Bounty:      p.Bounty,
+			Explored:    maps.Clone(p.Explored),
+			Waypoints:   maps.Clone(p.Waypoints),
+			Reputation:  maps.Clone(p.Reputation),
+			CmdAliases:  maps.Clone(p.CommandAliases),
+			Achieved:    maps.Clone(p.Achievements),
+			Title:       p.Title,
+			Race:        p.Race,
+			Class:       p.Class,
+			Trained:     p.Trained,
+			Points:      p.TrainPoints,
+			Lockouts:    maps.Clone(p.Lockouts),
+			Trades:      maps.Clone(p.Professions),
+			Level:       p.Level,
+			Experience:  p.Experience,
+			RestedXP:    p.RestedXP,
+			JoinedAt:    p.JoinedAt,
+			Health:      p.Health,
+			Mana:        p.Mana,
+			Moves:       p.Moves,
+			Mount:       p.Mount,
+			Terminal:    p.Session.snapshotState(),
+		})
+	}
+	for id, room := range w.rooms {
+		if len(room.Items) == 0 && len(room.NPCs) == 0 {
+			continue
+		}
+		state.Rooms = append(state.Rooms, copyoverRoom{
+			ID:    id,
+			Items: cloneItems(room.Items),
+			NPCs:  append([]NPC(nil), room.NPCs...),
+		})
+	}
+	state.AwayNPCs = append([]NPC(nil), w.awayNPCs...)
+	return state, sessions, skipped
+}
+
+func writeCopyoverState(path string, state copyoverState) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create copyover directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "copyover-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp copyover file: %w", err)
+	}
+	if err := json.NewEncoder(tmp).Encode(state); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write copyover file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close temp copyover file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("replace copyover file: %w", err)
+	}
+	return nil
+}
+
+// resumeCopyover reads the state left by the previous process and rebuilds
+// the listener and player sessions from inherited descriptors.
+func resumeCopyover(path string, world *World) (net.Listener, []resumedSession, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read copyover state: %w", err)
+	}
+	os.Remove(path)
+	var state copyoverState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, nil, fmt.Errorf("decode copyover state: %w", err)
+	}
+	count := state.ListenerFD + 1
+	for _, player := range state.Players {
+		if player.FD+1 > count {
+			count = player.FD + 1
+		}
+	}
+	files := make([]*os.File, count)
+	for i := range files {
+		files[i] = os.NewFile(uintptr(3+i), fmt.Sprintf("copyover-%d", i))
+	}
+	return restoreCopyover(state, files, world)
+}
+
+func restoreCopyover(state copyoverState, files []*os.File, world *World) (net.Listener, []resumedSession, error) {
+	defer func() {
+		for _, file := range files {
+			if file != nil {
+				file.Close()
+			}
+		}
+	}()
+	if state.ListenerFD < 0 || state.ListenerFD >= len(files) || files[state.ListenerFD] == nil {
+		return nil, nil, fmt.Errorf("copyover state is missing the listener")
+	}
+	ln, err := net.FileListener(files[state.ListenerFD])
+	if err != nil {
+		return nil, nil, fmt.Errorf("restore listener: %w", err)
+	}
+	world.restoreRoomContents(state.Rooms, state.AwayNPCs)
+	resumed := make([]resumedSession, 0, len(state.Players))
+	for _, saved := range state.Players {
+		if saved.FD < 0 || saved.FD >= len(files) || files[saved.FD] == nil {
+			continue
+		}
+		conn, err := net.FileConn(files[saved.FD])
+		if err != nil {
+			Logger().Warn("copyover could not restore connection", "player", saved.Name, "error", err)
+			continue
+		}
+		session := resumeTelnetSession(conn, saved.Terminal)
+		p, err := world.resumePlayer(saved, session)
+		if err != nil {
+			Logger().Warn("copyover could not restore player", "player", saved.Name, "error", err)
+			conn.Close()
+			continue
+		}
+		resumed = append(resumed, resumedSession{conn: conn, session: session, player: p})
+	}
+	return ln, resumed, nil
+}
+
+func (w *World) resumePlayer(saved copyoverPlayer, session *TelnetSession) (*Player, error) {
+	character := saved.Character
+	if character == "" {
+		character = saved.Name
+	}
+	if _, ok := w.GetRoom(saved.Room); !ok {
+		saved.Room = StartRoom
+	}
+	profile := PlayerProfile{
+		Room:       saved.Room,
+		Home:       saved.Home,
+		Channels:   decodeChannelSettings(saved.Channels),
+		Aliases:    decodeChannelAliases(saved.Aliases),
+		Inventory:  saved.Inventory,
+		Gold:       saved.Gold,
+		QuestLog:   decodeQuestLog(saved.Quests),
+		Ignored:    saved.Ignored,
+		Friends:    saved.Friends,
+		Tutorial:   saved.Tutorial,
+		Prompt:     saved.Prompt,
+		Combat:     saved.Combat,
+		WizInvis:   saved.WizInvis,
+		SpeedOff:   saved.SpeedOff,
+		PageLines:  saved.PageLines,
+		Language:   saved.Language,
+		Bio:        saved.Bio,
+		BioFlag:    saved.BioFlag,
+		Kills:      saved.Kills,
+		Bounty:     saved.Bounty,
+		Explored:   saved.Explored,
+		Waypoints:  saved.Waypoints,
+		Reputation: saved.Reputation,
+		CmdAliases: saved.CmdAliases,
+		Achieved:   saved.Achieved,
+		Title:      saved.Title,
+		Race:       saved.Race,
+		Class:      saved.Class,
+		Level:      saved.Level,
+		Experience: saved.Experience,
+		Trained:    saved.Trained,
+		Points:     saved.Points,
+		Lockouts:   saved.Lockouts,
+		Trades:     saved.Trades,
+	}
+	p, err := w.addCharacter(saved.Account, character, session, saved.IsAdmin, profile)
+	if err != nil {
+		return nil, err
+	}
+	if saved.Name != "" && saved.Name != p.Name {
+		if err := w.RenamePlayer(p, saved.Name); err != nil {
+			Logger().Warn("copyover could not restore display name", "player", saved.Name, "error", err)
+		}
+	}
+	w.mu.Lock()
+	p.IsModerator = saved.IsModerator
+	p.IsBuilder = saved.IsBuilder
+	if saved.Health > 0 && saved.Health <= p.MaxHealth {
+		p.Health = saved.Health
+	}
+	if saved.Mana >= 0 && saved.Mana <= p.MaxMana {
+		p.Mana = saved.Mana
+	}
+	if saved.Moves > 0 && saved.Moves <= p.MaxMoves {
+		p.Moves = saved.Moves
+	}
+	p.Mount = saved.Mount
+	p.RestedXP = saved.RestedXP
+	if !saved.JoinedAt.IsZero() {
+		p.JoinedAt = saved.JoinedAt
+	}
+	w.mu.Unlock()
+	return p, nil
+}
+
+func (w *World) restoreRoomContents(rooms []copyoverRoom, away []NPC) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, saved := range rooms {
+		room, ok := w.rooms[saved.ID]
+		if !ok {
+			continue
+		}
+		room.Items = cloneItems(saved.Items)
+		for i := range room.Items {
+			if room.Items[i].Corpse {
+				w.armCorpseLocked(room.ID, &room.Items[i])
+			}
+		}
+		room.NPCs = append([]NPC(nil), saved.NPCs...)
+	}
+	w.awayNPCs = append([]NPC(nil), away...)
+	w.placeScheduledNPCsLocked()
+}
+
+// resumeSession continues a session inherited through copyover.
+func resumeSession(conn net.Conn, session *TelnetSession, world *World, dispatcher Dispatcher, p *Player) {
+	metrics.connectionOpened()
+	defer metrics.connectionClosed()
+	defer session.Close()
+	go world.pumpOutput(p, p.Output)
+	p.Output.Send(Ansi("\r\n" + Style("The world settles back into place.", AnsiMagenta, AnsiBold)))
+	EnterRoom(world, p, "")
+	p.Output.Send(Prompt(p))
+	runSession(conn, session, world, dispatcher, p)
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func copyoverTestRooms() map[RoomID]*Room {
+	return map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"north": {To: "hall"}}},
+		"hall":    {ID: "hall", Title: "Hall", Exits: map[string]Exit{"south": {To: StartRoom}}},
+	}
+}
+
+func TestRestoreCopyoverResumesPlayersOnInheritedSockets(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("tcp listener unavailable: %v", err)
+	}
+	defer ln.Close()
+	client, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	serverConn, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	defer serverConn.Close()
+
+	old := NewWorldWithRooms(copyoverTestRooms())
+	old.rooms["hall"].Items = []Item{{Name: "lantern"}}
+	player := &Player{
+		Name:      "Alice",
+		Account:   "alice",
+		Character: "Alice",
+		Room:      "hall",
+		Inventory: []Item{{Name: "map"}},
+		Session:   resumeTelnetSession(serverConn, telnetState{Width: 100, Height: 40}),
+		Output:    NewOutputQueue(),
+		Alive:     true,
+	}
+	old.AddPlayerForTest(player)
+
+	listenerFile, err := ln.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatalf("listener file: %v", err)
+	}
+	files := []*os.File{listenerFile}
+	state, sessions, skipped := old.copyoverSnapshot(func(conn net.Conn) (int, bool) {
+		file, err := conn.(*net.TCPConn).File()
+		if err != nil {
+			return 0, false
+		}
+		files = append(files, file)
+		return len(files) - 1, true
+	})
+	if len(sessions) != 1 || len(skipped) != 0 {
+		t.Fatalf("snapshot sessions = %d, skipped = %d", len(sessions), len(skipped))
+	}
+
+	fresh := NewWorldWithRooms(copyoverTestRooms())
+	restored, resumed, err := restoreCopyover(state, files, fresh)
+	if err != nil {
+		t.Fatalf("restoreCopyover: %v", err)
+	}
+	defer restored.Close()
+	if len(resumed) != 1 {
+		t.Fatalf("resumed %d sessions, want 1", len(resumed))
+	}
+	p := resumed[0].player
+	if p.Room != "hall" {
+		t.Fatalf("player room = %q, want hall", p.Room)
+	}
+	if len(p.Inventory) != 1 || p.Inventory[0].Name != "map" {
+		t.Fatalf("inventory = %#v", p.Inventory)
+	}
+	if width, _ := resumed[0].session.Size(); width != 100 {
+		t.Fatalf("terminal width = %d, want 100", width)
+	}
+	if room, _ := fresh.GetRoom("hall"); len(room.Items) != 1 || room.Items[0].Name != "lantern" {
+		t.Fatalf("room items = %#v", room.Items)
+	}
+
+	if err := resumed[0].session.WriteString("still here\r\n"); err != nil {
+		t.Fatalf("write on inherited connection: %v", err)
+	}
+	client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	line, err := bufio.NewReader(client).ReadString('\n')
+	if err != nil || !strings.Contains(line, "still here") {
+		t.Fatalf("client read %q, %v", line, err)
+	}
+	resumed[0].conn.Close()
+}
+
+func TestCopyoverUnavailableWithoutTCPListener(t *testing.T) {
+	world := NewWorldWithRooms(copyoverTestRooms())
+	if world.CopyoverAvailable() {
+		t.Fatalf("expected copyover to be unavailable without a listener")
+	}
+	if err := world.Copyover(); err != ErrCopyoverUnavailable {
+		t.Fatalf("Copyover error = %v, want ErrCopyoverUnavailable", err)
+	}
+}
@@ -0,0 +1,261 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeathPenalty configures the consequences applied when a player is defeated.
+type DeathPenalty struct {
+	// ExperienceLossPercent is the share of progress towards the next level
+	// that is forfeited on defeat. Players never lose a level.
+	ExperienceLossPercent int
+	// WeaknessDuration controls how long the post-defeat weakness lasts.
+	WeaknessDuration time.Duration
+	// WeaknessPercent reduces melee damage while the weakness is active.
+	WeaknessPercent int
+	// CorpseDecay is how long corpses remain before crumbling away.
+	CorpseDecay time.Duration
+}
+
+// DefaultDeathPenalty is applied when no explicit penalty has been configured.
+var DefaultDeathPenalty = DeathPenalty{
+	ExperienceLossPercent: 10,
+	WeaknessDuration:      2 * time.Minute,
+	WeaknessPercent:       25,
+	CorpseDecay:           5 * time.Minute,
+}
+
+// WeaknessEffect names the status effect applied after a defeat.
+const WeaknessEffect = "weakness"
+
+var (
+	// ErrNoCorpse indicates that no lootable corpse could be found.
+	ErrNoCorpse = errors.New("no corpse here")
+	// ErrCorpseNotYours indicates the corpse belongs to another player.
+	ErrCorpseNotYours = errors.New("that corpse is not yours to loot")
+)
+
+// DeathOutcome summarises the penalties applied to a defeated player.
+type DeathOutcome struct {
+	ExperienceLost int
+	Weakened       time.Duration
+	Corpse         string
+}
+
+// ConfigureDeathPenalty overrides the penalties applied on player defeat.
+func (w *World) ConfigureDeathPenalty(penalty DeathPenalty) {
+	w.mu.Lock()
+	w.deathPenalty = &penalty
+	w.mu.Unlock()
+}
+
+func (w *World) deathPenaltyLocked() DeathPenalty {
+	if w.deathPenalty == nil {
+		return DefaultDeathPenalty
+	}
+	return *w.deathPenalty
+}
+
+func corpseName(name string) string {
+	return fmt.Sprintf("corpse of %s", name)
+}
+
+// spawnCorpseLocked leaves a corpse holding the provided items in the room and
+// schedules it to decay. It returns the corpse's name.
+func (w *World) spawnCorpseLocked(room *Room, name, owner string, contents []Item) string {
+	if room == nil {
+		return ""
+	}
+	corpse := Item{
+		Name:        corpseName(name),
+		Description: fmt.Sprintf("The lifeless remains of %s lie here.", name),
+		Corpse:      true,
+		Owner:       owner,
+		Contents:    append([]Item(nil), contents...),
+	}
+	if decay := w.deathPenaltyLocked().CorpseDecay; decay > 0 {
+		corpse.DecayAt = time.Now().Add(decay)
+	}
+	room.Items = append(room.Items, corpse)
+	w.armCorpseLocked(room.ID, &room.Items[len(room.Items)-1])
+	return corpse.Name
+}
+
+// armCorpseLocked gives a corpse a fresh ID and schedules it to crumble at
+// its DecayAt time. Corpses restored after a copyover are armed again here.
+func (w *World) armCorpseLocked(roomID RoomID, corpse *Item) {
+	w.nextCorpseID++
+	id := w.nextCorpseID
+	corpse.corpseID = id
+	if corpse.DecayAt.IsZero() {
+		return
+	}
+	time.AfterFunc(max(time.Until(corpse.DecayAt), 0), func() {
+		w.decayCorpse(roomID, id)
+	})
+}
+
+func (w *World) decayCorpse(roomID RoomID, id uint64) {
+	w.mu.Lock()
+	room, ok := w.rooms[roomID]
+	if !ok {
+		w.mu.Unlock()
+		return
+	}
+	name := ""
+	for i := range room.Items {
+		if room.Items[i].corpseID != id {
+			continue
+		}
+		name = room.Items[i].Name
+		room.Items = append(room.Items[:i], room.Items[i+1:]...)
+		break
+	}
+	w.mu.Unlock()
+	if name == "" {
+		return
+	}
+	w.BroadcastToRoom(roomID, Ansi(fmt.Sprintf("\r\nThe %s crumbles to dust.", HighlightItemName(name))), nil)
+}
+
+// applyDeathLocked leaves the player's belongings in a corpse, applies the
+// configured penalties, and returns them home at full strength.
+func (w *World) applyDeathLocked(target *Player) DeathOutcome {
+	penalty := w.deathPenaltyLocked()
+	outcome := DeathOutcome{}
+	record := deathRecord{at: time.Now(), room: target.Room}
+	if room, ok := w.rooms[target.Room]; ok && len(target.Inventory) > 0 {
+		outcome.Corpse = w.spawnCorpseLocked(room, target.Name, target.Name, target.Inventory)
+		record.corpse = w.nextCorpseID
+		target.Inventory = nil
+	}
+	if penalty.ExperienceLossPercent > 0 {
+		progress := target.Experience - experienceForLevel(target.Level)
+		if progress > 0 {
+			loss := progress * penalty.ExperienceLossPercent / 100
+			target.Experience -= loss
+			outcome.ExperienceLost = loss
+		}
+	}
+	record.experience = outcome.ExperienceLost
+	target.lastDeath = &record
+	delete(target.Effects, PoisonEffect)
+	if penalty.WeaknessDuration > 0 && penalty.WeaknessPercent > 0 {
+		target.addEffect(StatusEffect{
+			Name:          WeaknessEffect,
+			Expires:       time.Now().Add(penalty.WeaknessDuration),
+			DamagePercent: -penalty.WeaknessPercent,
+		})
+		outcome.Weakened = penalty.WeaknessDuration
+	}
+	if target.Home == "" {
+		target.Home = StartRoom
+	}
+	target.Room = target.Home
+	w.syncPresenceLocked(target)
+	target.EnsureStats()
+	target.Health = target.MaxHealth
+	target.Mana = target.MaxMana
+	return outcome
+}
+
+// LootCorpse moves everything held by a corpse in the player's room into their
+// inventory, leaving behind whatever would exceed their carry capacity. Player
+// corpses may only be looted by their owner or by admins.
+func (w *World) LootCorpse(p *Player, name string) (string, []Item, error) {
+	target := strings.TrimSpace(name)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		return "", nil, fmt.Errorf("%s is not online", p.Name)
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	var (
+		candidates []string
+		indexes    []int
+	)
+	for i, item := range room.Items {
+		if !item.Corpse {
+			continue
+		}
+		candidates = append(candidates, item.Name)
+		indexes = append(indexes, i)
+	}
+	if len(candidates) == 0 {
+		return "", nil, ErrNoCorpse
+	}
+	idx := -1
+	if target == "" || strings.EqualFold(target, "corpse") {
+		for i, roomIdx := range indexes {
+			owner := room.Items[roomIdx].Owner
+			if owner == "" || strings.EqualFold(owner, p.Name) || p.IsAdmin {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return "", nil, ErrCorpseNotYours
+		}
+	} else {
+		match, ok := uniqueMatch(target, candidates, true)
+		if !ok {
+			return "", nil, ErrNoCorpse
+		}
+		idx = match
+	}
+	corpse := &room.Items[indexes[idx]]
+	if corpse.Owner != "" && !strings.EqualFold(corpse.Owner, p.Name) && !p.IsAdmin {
+		return "", nil, ErrCorpseNotYours
+	}
+	var looted, left []Item
+	for _, item := range corpse.Contents {
+		if canCarryLocked(p, item.TotalWeight()) {
+			looted = append(looted, item)
+			p.Inventory = append(p.Inventory, item)
+		} else {
+			left = append(left, item)
+		}
+	}
+	corpse.Contents = left
+	if len(looted) == 0 && len(left) > 0 {
+		return corpse.Name, nil, ErrTooHeavy
+	}
+	return corpse.Name, looted, nil
+}
+
+// FormatDeathOutcome describes the penalties a defeated player has suffered.
+func FormatDeathOutcome(outcome DeathOutcome) []string {
+	var lines []string
+	if outcome.Corpse != "" {
+		lines = append(lines, fmt.Sprintf("Your belongings remain with your %s. Return and 'loot' it before it decays.", HighlightItemName(outcome.Corpse)))
+	}
+	if outcome.ExperienceLost > 0 {
+		lines = append(lines, fmt.Sprintf("You lose %d experience.", outcome.ExperienceLost))
+	}
+	if outcome.Weakened > 0 {
+		lines = append(lines, fmt.Sprintf("You feel weakened. Your blows will be feeble for %s.", formatCompactDuration(outcome.Weakened)))
+	}
+	return lines
+}
+
+// FormatCorpseDrop describes a fallen NPC's corpse and the loot it holds.
+func FormatCorpseDrop(npcName, corpse string, loot []Item) string {
+	if corpse == "" {
+		return ""
+	}
+	if len(loot) == 0 {
+		return fmt.Sprintf("%s leaves behind a %s.", npcName, HighlightItemName(corpse))
+	}
+	names := make([]string, len(loot))
+	for i, item := range loot {
+		names[i] = item.DisplayName()
+	}
+	return fmt.Sprintf("%s leaves behind a %s holding %s.", npcName, HighlightItemName(corpse), strings.Join(names, ", "))
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPlayerDeathLeavesCorpseAndAppliesPenalty(t *testing.T) {
+	rooms := map[RoomID]*Room{
+		"arena": {ID: "arena"},
+		"home":  {ID: "home"},
+	}
+	world := NewWorldWithRooms(rooms)
+	world.ConfigureDeathPenalty(DeathPenalty{ExperienceLossPercent: 50, WeaknessDuration: time.Minute, WeaknessPercent: 50})
+
+	victim := &Player{Name: "Victim", Room: "arena", Home: "home", Output: NewOutputQueue(), Alive: true, Level: 2}
+	world.AddPlayerForTest(victim)
+	victim.Experience = 140
+	victim.Inventory = []Item{{Name: "Silver Ring"}}
+	baseDamage := victim.AttackDamage()
+
+	result, err := world.ApplyDamageFromNPC("arena", "Ogre", victim, victim.MaxHealth+10)
+	if err != nil {
+		t.Fatalf("ApplyDamageFromNPC: %v", err)
+	}
+	if !result.Defeated {
+		t.Fatalf("expected victim to be defeated")
+	}
+	if victim.Room != "home" {
+		t.Fatalf("victim.Room = %q, want home", victim.Room)
+	}
+	if len(victim.Inventory) != 0 {
+		t.Fatalf("expected inventory to be left behind, got %+v", victim.Inventory)
+	}
+	if got, want := result.Death.ExperienceLost, 20; got != want {
+		t.Fatalf("experience lost = %d, want %d", got, want)
+	}
+	if victim.Experience != 120 {
+		t.Fatalf("victim.Experience = %d, want 120", victim.Experience)
+	}
+	if !victim.HasEffect(WeaknessEffect) {
+		t.Fatalf("expected weakness effect after defeat")
+	}
+	if got := victim.AttackDamage(); got >= baseDamage {
+		t.Fatalf("weakened damage = %d, want less than %d", got, baseDamage)
+	}
+
+	items := world.rooms["arena"].Items
+	if len(items) != 1 || !items[0].Corpse || items[0].Owner != "Victim" {
+		t.Fatalf("expected victim corpse in arena, got %+v", items)
+	}
+	if len(items[0].Contents) != 1 || items[0].Contents[0].Name != "Silver Ring" {
+		t.Fatalf("corpse contents = %+v, want Silver Ring", items[0].Contents)
+	}
+}
+
+func TestLootCorpseRespectsOwnership(t *testing.T) {
+	rooms := map[RoomID]*Room{"arena": {ID: "arena"}}
+	world := NewWorldWithRooms(rooms)
+	world.ConfigureDeathPenalty(DeathPenalty{})
+	world.mu.Lock()
+	world.spawnCorpseLocked(rooms["arena"], "Owner", "Owner", []Item{{Name: "Lantern"}})
+	world.mu.Unlock()
+
+	thief := &Player{Name: "Thief", Room: "arena", Output: NewOutputQueue(), Alive: true}
+	owner := &Player{Name: "Owner", Room: "arena", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(thief)
+	world.AddPlayerForTest(owner)
+
+	if _, _, err := world.LootCorpse(thief, ""); !errors.Is(err, ErrCorpseNotYours) {
+		t.Fatalf("expected ErrCorpseNotYours, got %v", err)
+	}
+	name, items, err := world.LootCorpse(owner, "corpse")
+	if err != nil {
+		t.Fatalf("LootCorpse: %v", err)
+	}
+	if name != "corpse of Owner" {
+		t.Fatalf("corpse name = %q", name)
+	}
+	if len(items) != 1 || len(owner.Inventory) != 1 || owner.Inventory[0].Name != "Lantern" {
+		t.Fatalf("expected lantern recovered, got %+v / %+v", items, owner.Inventory)
+	}
+}
+
+func TestCorpseDecays(t *testing.T) {
+	rooms := map[RoomID]*Room{"crypt": {ID: "crypt"}}
+	world := NewWorldWithRooms(rooms)
+	world.ConfigureDeathPenalty(DeathPenalty{CorpseDecay: 10 * time.Millisecond})
+
+	rooms["crypt"].NPCs = []NPC{{Name: "Ghoul", Health: 1, MaxHealth: 1}}
+	if _, err := world.ApplyDamageToNPC("crypt", "ghoul", 5); err != nil {
+		t.Fatalf("ApplyDamageToNPC: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if len(world.RoomItems("crypt")) == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("corpse did not decay: %+v", world.RoomItems("crypt"))
+}
+
+func TestCorpseStillDecaysAfterCopyover(t *testing.T) {
+	old := NewWorldWithRooms(map[RoomID]*Room{"crypt": {ID: "crypt", NPCs: []NPC{{Name: "Ghoul", Health: 1, MaxHealth: 1}}}})
+	old.ConfigureDeathPenalty(DeathPenalty{CorpseDecay: 50 * time.Millisecond})
+	if _, err := old.ApplyDamageToNPC("crypt", "ghoul", 5); err != nil {
+		t.Fatalf("ApplyDamageToNPC: %v", err)
+	}
+	state, _, _ := old.copyoverSnapshot(func(net.Conn) (int, bool) { return 0, false })
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshal copyover state: %v", err)
+	}
+	var saved copyoverState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("unmarshal copyover state: %v", err)
+	}
+
+	fresh := NewWorldWithRooms(map[RoomID]*Room{"crypt": {ID: "crypt"}})
+	fresh.restoreRoomContents(saved.Rooms, saved.AwayNPCs)
+	items := fresh.RoomItems("crypt")
+	if len(items) != 1 || !items[0].Corpse || items[0].DecayAt.IsZero() {
+		t.Fatalf("expected the corpse and its decay time to survive, got %+v", items)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if len(fresh.RoomItems("crypt")) == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("restored corpse did not decay: %+v", fresh.RoomItems("crypt"))
+}
@@ -0,0 +1,35 @@
+package game
+
+import "math/rand/v2"
+
+// Dice is the world's source of randomness. Every chance roll in the game
+// goes through it so tests can make outcomes deterministic.
+type Dice interface {
+	// IntN returns a number in [0, n).
+	IntN(n int) int
+}
+
+// DiceFunc adapts a plain function to Dice.
+type DiceFunc func(n int) int
+
+// IntN implements Dice.
+func (f DiceFunc) IntN(n int) int { return f(n) }
+
+// SetDice replaces the world's dice. Passing nil restores real randomness.
+func (w *World) SetDice(d Dice) {
+	if d == nil {
+		w.dice.Store(nil)
+		return
+	}
+	w.dice.Store(&d)
+}
+
+// roll returns a number in [0, n) from the world's dice.
+func (w *World) roll(n int) int {
+	if w != nil {
+		if d := w.dice.Load(); d != nil {
+			return (*d).IntN(n)
+		}
+	}
+	return rand.IntN(n)
+}
@@ -0,0 +1,30 @@
+package game
+
+import "testing"
+
+// scriptedDice returns its rolls in order, each wrapped to the requested
+// range, then zeros once it runs out.
+type scriptedDice []int
+
+func (d *scriptedDice) IntN(n int) int {
+	if len(*d) == 0 {
+		return 0
+	}
+	roll := (*d)[0]
+	*d = (*d)[1:]
+	return roll % n
+}
+
+func TestWorldDiceCanBeReplacedAndRestored(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom}})
+	world.SetDice(&scriptedDice{7, 12})
+	if a, b := world.roll(10), world.roll(10); a != 7 || b != 2 {
+		t.Fatalf("scripted rolls = %d and %d, want 7 and 2", a, b)
+	}
+	world.SetDice(nil)
+	for range 20 {
+		if roll := world.roll(3); roll < 0 || roll >= 3 {
+			t.Fatalf("random roll %d out of range", roll)
+		}
+	}
+}
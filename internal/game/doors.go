@@ -0,0 +1,271 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNoDoor indicates the exit has no door to operate.
+	ErrNoDoor = errors.New("there is no door that way")
+	// ErrDoorClosed indicates a closed door blocks the way.
+	ErrDoorClosed = errors.New("the door is closed")
+	// ErrDoorOpen indicates the door is already open.
+	ErrDoorOpen = errors.New("the door is already open")
+	// ErrDoorAlreadyClosed indicates the door is already closed.
+	ErrDoorAlreadyClosed = errors.New("the door is already closed")
+	// ErrDoorLocked indicates the door is locked.
+	ErrDoorLocked = errors.New("the door is locked")
+	// ErrDoorUnlocked indicates the door is not locked.
+	ErrDoorUnlocked = errors.New("the door is not locked")
+	// ErrDoorHasNoLock indicates the door cannot be locked because it has no key.
+	ErrDoorHasNoLock = errors.New("the door has no lock")
+	// ErrMissingKey indicates the player does not carry the door's key.
+	ErrMissingKey = errors.New("you lack the key")
+)
+
+// doorClosedError names the direction of a closed door and matches
+// ErrDoorClosed.
+type doorClosedError string
+
+func (e doorClosedError) Error() string {
+	return fmt.Sprintf("the %s door is closed", string(e))
+}
+
+func (e doorClosedError) Is(target error) bool {
+	return target == ErrDoorClosed
+}
+
+// Exit connects a room to a neighbouring room, optionally through a door and
+// subject to an ExitRule. Exits with neither are stored in JSON as a bare
+// room ID.
+type Exit struct {
+	To     RoomID `json:"to"`
+	Door   bool   `json:"door,omitempty"`
+	Closed bool   `json:"closed,omitempty"`
+	Locked bool   `json:"locked,omitempty"`
+	// Key names the item that locks and unlocks the door. Doors without a
+	// key can be opened and closed but never locked.
+	Key string `json:"key,omitempty"`
+	ExitRule
+}
+
+type exitFields Exit
+
+// MarshalJSON writes exits without a door or rule as a bare room ID so area
+// files stay compact.
+func (e Exit) MarshalJSON() ([]byte, error) {
+	if !e.Door && e.ExitRule.empty() {
+		return json.Marshal(e.To)
+	}
+	return json.Marshal(exitFields(e))
+}
+
+// UnmarshalJSON accepts either a bare room ID or an exit object.
+func (e *Exit) UnmarshalJSON(data []byte) error {
+	var to RoomID
+	if err := json.Unmarshal(data, &to); err == nil {
+		*e = Exit{To: to}
+		return nil
+	}
+	var fields exitFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*e = Exit(fields)
+	if !e.Door {
+		e.Closed, e.Locked, e.Key = false, false, ""
+	}
+	if e.Locked {
+		e.Closed = true
+	}
+	return nil
+}
+
+// DoorAction names the ways a player can operate a door.
+type DoorAction string
+
+const (
+	DoorOpen   DoorAction = "open"
+	DoorClose  DoorAction = "close"
+	DoorLock   DoorAction = "lock"
+	DoorUnlock DoorAction = "unlock"
+)
+
+// OperateDoor opens, closes, locks, or unlocks the door in the given
+// direction from the player's room. The door on the far side of the exit, if
+// one leads back, changes with it. It returns the canonical direction.
+func (w *World) OperateDoor(p *Player, direction string, action DoorAction) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return "", fmt.Errorf("unknown room: %s", p.Room)
+	}
+	dir, ok := matchExitLocked(room, direction)
+	if !ok || !exitVisibleLocked(p, room.ID, dir, room.Exits[dir]) {
+		return "", fmt.Errorf("you see no exit %s", strings.TrimSpace(direction))
+	}
+	exit := room.Exits[dir]
+	if !exit.Door {
+		return dir, ErrNoDoor
+	}
+	switch action {
+	case DoorOpen:
+		if exit.Locked {
+			return dir, ErrDoorLocked
+		}
+		if !exit.Closed {
+			return dir, ErrDoorOpen
+		}
+		exit.Closed = false
+	case DoorClose:
+		if exit.Closed {
+			return dir, ErrDoorAlreadyClosed
+		}
+		exit.Closed = true
+	case DoorLock, DoorUnlock:
+		if exit.Key == "" {
+			return dir, ErrDoorHasNoLock
+		}
+		if action == DoorLock && exit.Locked {
+			return dir, ErrDoorLocked
+		}
+		if action == DoorUnlock && !exit.Locked {
+			return dir, ErrDoorUnlocked
+		}
+		if action == DoorLock && !exit.Closed {
+			return dir, ErrDoorOpen
+		}
+		if !carriesKey(p.Inventory, exit.Key) {
+			return dir, ErrMissingKey
+		}
+		exit.Locked = action == DoorLock
+	default:
+		return dir, fmt.Errorf("unknown door action: %s", action)
+	}
+	room.Exits[dir] = exit
+	w.syncReverseDoorLocked(room.ID, exit)
+	return dir, nil
+}
+
+// syncReverseDoorLocked copies a door's state onto the matching door on the
+// far side of the exit so both rooms agree.
+func (w *World) syncReverseDoorLocked(from RoomID, exit Exit) {
+	dest, ok := w.rooms[exit.To]
+	if !ok {
+		return
+	}
+	for dir, back := range dest.Exits {
+		if back.To != from || !back.Door {
+			continue
+		}
+		back.Closed = exit.Closed
+		back.Locked = exit.Locked
+		dest.Exits[dir] = back
+	}
+}
+
+func carriesKey(items []Item, key string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item.Name, key) {
+			return true
+		}
+		if carriesKey(item.Contents, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchExitLocked(room *Room, direction string) (string, bool) {
+	target := strings.TrimSpace(direction)
+	if target == "" || len(room.Exits) == 0 {
+		return "", false
+	}
+	if _, ok := room.Exits[strings.ToLower(target)]; ok {
+		return strings.ToLower(target), true
+	}
+	names := make([]string, 0, len(room.Exits))
+	for dir := range room.Exits {
+		names = append(names, dir)
+	}
+	idx, ok := uniqueMatch(target, names, true)
+	if !ok {
+		return "", false
+	}
+	return names[idx], true
+}
+
+// DoorConfig describes the door a builder places on an exit.
+type DoorConfig struct {
+	Closed bool
+	Locked bool
+	Key    string
+}
+
+// SetDoor places a door on the exit in the given direction, or removes it
+// when config is nil. A matching exit leading back from the destination gets
+// the same door. Both rooms are saved to the builder area.
+func (w *World) SetDoor(roomID RoomID, direction string, config *DoorConfig, editor string) error {
+	if config != nil && config.Locked && strings.TrimSpace(config.Key) == "" {
+		return fmt.Errorf("a locked door needs a key")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		return err
+	}
+	room, ok := w.rooms[roomID]
+	if !ok {
+		return fmt.Errorf("unknown room: %s", roomID)
+	}
+	dir, ok := matchExitLocked(room, direction)
+	if !ok {
+		return fmt.Errorf("no exit %s from %s", strings.TrimSpace(direction), roomID)
+	}
+	type change struct {
+		room       *Room
+		dir        string
+		prev       Exit
+		prevSource string
+		hadSource  bool
+	}
+	var changes []change
+	apply := func(r *Room, dir string) {
+		prev := r.Exits[dir]
+		next := Exit{To: prev.To, ExitRule: prev.ExitRule}
+		if config != nil {
+			next.Door = true
+			next.Closed = config.Closed || config.Locked
+			next.Locked = config.Locked
+			next.Key = strings.TrimSpace(config.Key)
+		}
+		r.Exits[dir] = next
+		prevSource, hadSource := w.markRoomAsBuilderLocked(r.ID)
+		changes = append(changes, change{room: r, dir: dir, prev: prev, prevSource: prevSource, hadSource: hadSource})
+	}
+	apply(room, dir)
+	if dest, ok := w.rooms[room.Exits[dir].To]; ok && dest != room {
+		for backDir, back := range dest.Exits {
+			if back.To == roomID {
+				apply(dest, backDir)
+			}
+		}
+	}
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		for i := len(changes) - 1; i >= 0; i-- {
+			c := changes[i]
+			c.room.Exits[c.dir] = c.prev
+			if c.hadSource {
+				w.roomSources[c.room.ID] = c.prevSource
+			} else {
+				delete(w.roomSources, c.room.ID)
+			}
+		}
+		return err
+	}
+	return nil
+}
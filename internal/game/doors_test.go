@@ -0,0 +1,110 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExitJSONKeepsPlainExitsCompact(t *testing.T) {
+	var exits map[string]Exit
+	data := `{"north": "vault", "east": {"to": "yard", "door": true, "closed": true, "key": "Brass Key"}}`
+	if err := json.Unmarshal([]byte(data), &exits); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if exits["north"] != (Exit{To: "vault"}) {
+		t.Fatalf("north = %+v", exits["north"])
+	}
+	if want := (Exit{To: "yard", Door: true, Closed: true, Key: "Brass Key"}); exits["east"] != want {
+		t.Fatalf("east = %+v, want %+v", exits["east"], want)
+	}
+	encoded, err := json.Marshal(exits)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if got := string(encoded); !strings.Contains(got, `"north":"vault"`) || !strings.Contains(got, `"door":true`) {
+		t.Fatalf("unexpected encoding %s", got)
+	}
+}
+
+func TestLockedDoorNeedsKeyAndSyncsBothSides(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall":  {ID: "hall", Exits: map[string]Exit{"north": {To: "vault", Door: true, Closed: true, Locked: true, Key: "Iron Key"}}},
+		"vault": {ID: "vault", Exits: map[string]Exit{"south": {To: "hall", Door: true, Closed: true, Locked: true, Key: "Iron Key"}}},
+	})
+	p := &Player{Name: "Keeper", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if _, err := world.Move(p, "north"); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Fatalf("expected closed door to block movement, got %v", err)
+	}
+	if _, err := world.OperateDoor(p, "north", DoorOpen); !errors.Is(err, ErrDoorLocked) {
+		t.Fatalf("open locked door err = %v, want ErrDoorLocked", err)
+	}
+	if _, err := world.OperateDoor(p, "north", DoorUnlock); !errors.Is(err, ErrMissingKey) {
+		t.Fatalf("unlock without key err = %v, want ErrMissingKey", err)
+	}
+	p.Inventory = []Item{{Name: "Satchel", Container: true, Contents: []Item{{Name: "Iron Key"}}}}
+	if dir, err := world.OperateDoor(p, "n", DoorUnlock); err != nil || dir != "north" {
+		t.Fatalf("unlock = %q, %v", dir, err)
+	}
+	if _, err := world.OperateDoor(p, "north", DoorOpen); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	vault, _ := world.GetRoom("vault")
+	if back := vault.Exits["south"]; back.Closed || back.Locked {
+		t.Fatalf("far side of the door did not follow: %+v", back)
+	}
+	if _, err := world.Move(p, "north"); err != nil {
+		t.Fatalf("move through open door: %v", err)
+	}
+	if _, err := world.OperateDoor(p, "south", DoorLock); !errors.Is(err, ErrDoorOpen) {
+		t.Fatalf("lock open door err = %v, want ErrDoorOpen", err)
+	}
+}
+
+func TestSetDoorPersistsBothRooms(t *testing.T) {
+	dir := t.TempDir()
+	rooms := map[RoomID]*Room{
+		"hall":  {ID: "hall", Exits: map[string]Exit{"north": {To: "vault"}}},
+		"vault": {ID: "vault", Exits: map[string]Exit{"south": {To: "hall"}}},
+	}
+	world := NewWorldWithRooms(rooms)
+	world.builderPath = filepath.Join(dir, builderAreaFile)
+
+	if err := world.SetDoor("hall", "north", &DoorConfig{Locked: true}, ""); err == nil {
+		t.Fatalf("expected a locked door without a key to be rejected")
+	}
+	if err := world.SetDoor("hall", "north", &DoorConfig{Locked: true, Key: "Iron Key"}, ""); err != nil {
+		t.Fatalf("SetDoor: %v", err)
+	}
+	data, err := os.ReadFile(world.builderPath)
+	if err != nil {
+		t.Fatalf("read builder area: %v", err)
+	}
+	var file areaFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("decode builder area: %v", err)
+	}
+	if len(file.Rooms) != 2 {
+		t.Fatalf("expected both rooms saved, got %d", len(file.Rooms))
+	}
+	for _, room := range file.Rooms {
+		for _, exit := range room.Exits {
+			if !exit.Door || !exit.Locked || exit.Key != "Iron Key" {
+				t.Fatalf("room %s exit not saved with door: %+v", room.ID, exit)
+			}
+		}
+	}
+
+	if err := world.SetDoor("vault", "south", nil, ""); err != nil {
+		t.Fatalf("remove door: %v", err)
+	}
+	hall, _ := world.GetRoom("hall")
+	if hall.Exits["north"] != (Exit{To: "vault"}) {
+		t.Fatalf("door not removed from far side: %+v", hall.Exits["north"])
+	}
+}
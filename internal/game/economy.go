@@ -0,0 +1,164 @@
+package game
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultGoldRate leaves gold faucets and sinks unscaled.
+	DefaultGoldRate = 1.0
+	// economyHours is how many hours of gold flow the ledger keeps for
+	// charting.
+	economyHours = 48
+)
+
+// EconomySource names where gold entered or left the game.
+type EconomySource string
+
+const (
+	// EconomyLoot is gold dropped by defeated creatures.
+	EconomyLoot EconomySource = "loot"
+	// EconomyShop is gold paid to and by shopkeepers.
+	EconomyShop EconomySource = "shop"
+	// EconomyHealer is gold paid to healers.
+	EconomyHealer EconomySource = "healer"
+	// EconomyWaypoint is gold paid to travel before the waypoints recharge.
+	EconomyWaypoint EconomySource = "waypoint"
+	// EconomyGambling is gold won from and lost to tavern dealers.
+	EconomyGambling EconomySource = "gambling"
+	// EconomyBounty is gold paid out for defeating criminals.
+	EconomyBounty EconomySource = "bounty"
+	// EconomyRent is gold paid in vault rent.
+	EconomyRent EconomySource = "rent"
+	// EconomyClan is gold paid to found clans and claim clan halls.
+	EconomyClan EconomySource = "clan"
+)
+
+// EconomyFlow totals the gold one source created and destroyed.
+type EconomyFlow struct {
+	Source    EconomySource
+	Created   int
+	Destroyed int
+	// Events counts the transactions recorded for the source.
+	Events int
+}
+
+// Net is the gold the source added to the world, negative when it removed
+// more than it created.
+func (f EconomyFlow) Net() int {
+	return f.Created - f.Destroyed
+}
+
+// EconomyHour totals the gold created and destroyed during one hour.
+type EconomyHour struct {
+	Hour      time.Time
+	Created   int
+	Destroyed int
+}
+
+// EconomyReport summarizes the economy ledger since the server started.
+type EconomyReport struct {
+	Since time.Time
+	// Flows lists each source that has moved gold, busiest first.
+	Flows []EconomyFlow
+	// Hours lists the last economyHours hours that saw gold move, oldest
+	// first.
+	Hours      []EconomyHour
+	Total      EconomyFlow
+	FaucetRate float64
+	SinkRate   float64
+}
+
+// economyLedger tracks gold entering and leaving the game; guarded by the
+// world's mutex.
+type economyLedger struct {
+	since time.Time
+	flows map[EconomySource]*EconomyFlow
+	hours []EconomyHour
+}
+
+// recordGoldLocked notes amount gold created by source, or destroyed when
+// amount is negative.
+func (w *World) recordGoldLocked(source EconomySource, amount int) {
+	if amount == 0 {
+		return
+	}
+	now := time.Now()
+	ledger := &w.economy
+	if ledger.flows == nil {
+		ledger.since = now
+		ledger.flows = make(map[EconomySource]*EconomyFlow)
+	}
+	flow, ok := ledger.flows[source]
+	if !ok {
+		flow = &EconomyFlow{Source: source}
+		ledger.flows[source] = flow
+	}
+	hour := now.Truncate(time.Hour)
+	if n := len(ledger.hours); n == 0 || !ledger.hours[n-1].Hour.Equal(hour) {
+		ledger.hours = append(ledger.hours, EconomyHour{Hour: hour})
+		if len(ledger.hours) > economyHours {
+			ledger.hours = ledger.hours[len(ledger.hours)-economyHours:]
+		}
+	}
+	bucket := &ledger.hours[len(ledger.hours)-1]
+	flow.Events++
+	if amount > 0 {
+		flow.Created += amount
+		bucket.Created += amount
+	} else {
+		flow.Destroyed -= amount
+		bucket.Destroyed -= amount
+	}
+}
+
+// EconomyReport returns the gold created and destroyed by each source since
+// the server started, with an hourly series for charting.
+func (w *World) EconomyReport() EconomyReport {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	tunables := w.tunablesLocked()
+	report := EconomyReport{
+		Since:      w.economy.since,
+		Hours:      append([]EconomyHour(nil), w.economy.hours...),
+		FaucetRate: tunables.GoldFaucetRate,
+		SinkRate:   tunables.GoldSinkRate,
+	}
+	for _, flow := range w.economy.flows {
+		report.Flows = append(report.Flows, *flow)
+		report.Total.Created += flow.Created
+		report.Total.Destroyed += flow.Destroyed
+		report.Total.Events += flow.Events
+	}
+	sort.Slice(report.Flows, func(i, j int) bool {
+		a, b := report.Flows[i], report.Flows[j]
+		if a.Created+a.Destroyed != b.Created+b.Destroyed {
+			return a.Created+a.Destroyed > b.Created+b.Destroyed
+		}
+		return a.Source < b.Source
+	})
+	return report
+}
+
+// scaleGold applies a balancing rate to a positive amount of gold, never
+// scaling it below one piece. A rate of zero leaves the amount alone.
+func scaleGold(amount int, rate float64) int {
+	if amount <= 0 || rate <= 0 || rate == DefaultGoldRate {
+		return amount
+	}
+	return max(1, int(math.Round(float64(amount)*rate)))
+}
+
+// faucetGoldLocked scales gold the world is about to create by the
+// configured faucet rate.
+func (w *World) faucetGoldLocked(amount int) int {
+	return scaleGold(amount, w.tunablesLocked().GoldFaucetRate)
+}
+
+// sinkGoldLocked scales a price the world charges by the configured sink
+// rate.
+func (w *World) sinkGoldLocked(amount int) int {
+	return scaleGold(amount, w.tunablesLocked().GoldSinkRate)
+}
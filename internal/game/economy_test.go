@@ -0,0 +1,96 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEconomyLedgerTracksFaucetsAndSinks(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{},
+			NPCs: []NPC{{Name: "Trader", Shop: []Item{{Name: "Rope", Price: 20}},
+				Rares: []Item{{Name: "Amber Idol", Price: 50, Rarity: Rare}, {Name: "Jade Comb", Price: 30}}, RareStock: 1}}},
+	})
+	player := &Player{Name: "Hero", Room: "stall", Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	tunables := DefaultTunables()
+	tunables.GoldSinkRate = 1.5
+	tunables.GoldFaucetRate = 0.5
+	world.ConfigureTunables(tunables)
+
+	if _, price, err := world.BuyFromShop(player, "rope"); err != nil || price != 30 {
+		t.Fatalf("BuyFromShop = %d, %v; want the sink rate to raise 20 to 30", price, err)
+	}
+	if awarded := world.AwardGold(player, 15, EconomyLoot); awarded != 8 {
+		t.Fatalf("AwardGold = %d, want the faucet rate to halve 15 to 8", awarded)
+	}
+	if player.Gold != 478 {
+		t.Fatalf("gold = %d, want 478", player.Gold)
+	}
+
+	report := world.EconomyReport()
+	if len(report.Flows) != 2 || report.Flows[0].Source != EconomyShop || report.Flows[0].Destroyed != 30 || report.Flows[1].Created != 8 {
+		t.Fatalf("unexpected flows %+v", report.Flows)
+	}
+	if report.Total.Net() != -22 || report.Total.Events != 2 || report.SinkRate != 1.5 {
+		t.Fatalf("unexpected totals %+v", report)
+	}
+	if len(report.Hours) != 1 || report.Hours[0].Created != 8 || report.Hours[0].Destroyed != 30 {
+		t.Fatalf("unexpected hourly series %+v", report.Hours)
+	}
+}
+
+func TestEconomySettingsReload(t *testing.T) {
+	tunables := DefaultTunables()
+	if err := tunables.applySetting("gold-sink-rate", "0"); err == nil {
+		t.Fatalf("expected a zero sink rate to be refused")
+	}
+	if err := tunables.applySetting("gold-faucet-rate", "0.75"); err != nil || tunables.GoldFaucetRate != 0.75 {
+		t.Fatalf("applySetting = %v, rate %g", err, tunables.GoldFaucetRate)
+	}
+	if got := scaleGold(3, 0.1); got != 1 {
+		t.Fatalf("scaleGold kept %d, want at least one piece", got)
+	}
+}
+
+func TestPortalEconomyChart(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{},
+			NPCs: []NPC{{Name: "Trader", Shop: []Item{{Name: "Rope", Price: 20}},
+				Rares: []Item{{Name: "Amber Idol", Price: 50, Rarity: Rare}, {Name: "Jade Comb", Price: 30}}, RareStock: 1}}},
+	})
+	player := &Player{Name: "Hero", Room: "stall", Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	world.AwardGold(player, 40, EconomyLoot)
+	world.BuyFromShop(player, "rope")
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions: map[string]portalSession{
+			"mod":   {Role: PortalRoleModerator, Player: "Warden", Expires: time.Now().Add(time.Hour)},
+			"admin": {Role: PortalRoleAdmin, Player: "Root", Expires: time.Now().Add(time.Hour)},
+		},
+		documents: make(map[string]portalDocument),
+	}
+
+	if rec := doInboxRequest(t, portal.handleEconomyAPI, "mod", http.MethodGet, "/api/economy", nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("moderator status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	var view portalEconomyView
+	rec := doInboxRequest(t, portal.handleEconomyAPI, "admin", http.MethodGet, "/api/economy", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode economy: %v", err)
+	}
+	if len(view.Sources) != 2 || view.Total.Net != 20 || len(view.Hours) != 1 || view.Hours[0].Created != 40 {
+		t.Fatalf("unexpected economy %+v", view)
+	}
+
+	rec = doInboxRequest(t, portal.handleInterface, "admin", http.MethodGet, "/interface", nil)
+	if body := rec.Body.String(); !strings.Contains(body, "<h2>Economy</h2>") || !strings.Contains(body, `style="width: 100%"`) {
+		t.Fatalf("expected the economy chart on the dashboard")
+	}
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// StatusEffect is a temporary modifier applied to a player.
+type StatusEffect struct {
+	Name          string
+	Expires       time.Time
+	DamagePercent int
+	// Attributes raises strength and dexterity while the effect lasts.
+	Attributes Attributes
+	// Poison is the damage the effect deals every poison tick.
+	Poison int
+}
+
+// Remaining reports how long the effect has left relative to now.
+func (e StatusEffect) Remaining(now time.Time) time.Duration {
+	if e.Expires.IsZero() {
+		return 0
+	}
+	left := e.Expires.Sub(now)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+func (p *Player) addEffect(effect StatusEffect) {
+	if p == nil {
+		return
+	}
+	name := strings.ToLower(strings.TrimSpace(effect.Name))
+	if name == "" {
+		return
+	}
+	effect.Name = name
+	if p.Effects == nil {
+		p.Effects = make(map[string]StatusEffect)
+	}
+	p.Effects[name] = effect
+}
+
+// ActiveEffects returns the player's unexpired effects sorted by name and
+// discards any that have lapsed.
+func (p *Player) ActiveEffects(now time.Time) []StatusEffect {
+	if p == nil || len(p.Effects) == 0 {
+		return nil
+	}
+	active := make([]StatusEffect, 0, len(p.Effects))
+	for name, effect := range p.Effects {
+		if !effect.Expires.IsZero() && !now.Before(effect.Expires) {
+			delete(p.Effects, name)
+			continue
+		}
+		active = append(active, effect)
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Name < active[j].Name
+	})
+	return active
+}
+
+// HasEffect reports whether the named effect is currently active.
+func (p *Player) HasEffect(name string) bool {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, effect := range p.ActiveEffects(time.Now()) {
+		if effect.Name == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Player) effectAttributes(now time.Time) Attributes {
+	var total Attributes
+	for _, effect := range p.ActiveEffects(now) {
+		total = total.plus(Attributes{Str: effect.Attributes.Str, Dex: effect.Attributes.Dex})
+	}
+	return total
+}
+
+func (p *Player) damageModifier(now time.Time) int {
+	total := 0
+	for _, effect := range p.ActiveEffects(now) {
+		total += effect.DamagePercent
+	}
+	return total
+}
@@ -0,0 +1,70 @@
+package game
+
+import "errors"
+
+const (
+	// DefaultItemWeight is the weight of items that do not set one.
+	DefaultItemWeight = 1
+	// BurdenedPercent is the share of carry capacity above which walking
+	// costs twice the stamina.
+	BurdenedPercent = 75
+)
+
+var (
+	// ErrTooHeavy indicates an item would put the player over their carry
+	// capacity.
+	ErrTooHeavy = errors.New("you can't carry that much weight")
+	// ErrOverburdened indicates the player carries more than they can move
+	// with.
+	ErrOverburdened = errors.New("you are carrying too much to move")
+)
+
+// TotalWeight is the item's weight plus the weight of everything inside it.
+func (i Item) TotalWeight() int {
+	weight := i.Weight
+	if weight <= 0 {
+		weight = DefaultItemWeight
+	}
+	return weight + itemsWeight(i.Contents)
+}
+
+func itemsWeight(items []Item) int {
+	total := 0
+	for _, item := range items {
+		total += item.TotalWeight()
+	}
+	return total
+}
+
+// Encumbrance describes how much a player carries against what they can.
+type Encumbrance struct {
+	Load     int
+	Capacity int
+}
+
+// Burdened reports whether the load slows the player down.
+func (e Encumbrance) Burdened() bool {
+	return e.Load*100 > e.Capacity*BurdenedPercent
+}
+
+// Overloaded reports whether the load keeps the player from moving.
+func (e Encumbrance) Overloaded() bool {
+	return e.Load > e.Capacity
+}
+
+func (p *Player) encumbrance() Encumbrance {
+	return Encumbrance{Load: itemsWeight(p.Inventory), Capacity: p.CarryCapacity()}
+}
+
+// Encumbrance returns p's current load and carry capacity.
+func (w *World) Encumbrance(p *Player) Encumbrance {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.encumbrance()
+}
+
+// canCarryLocked reports whether p can pick up items weighing extra more.
+func canCarryLocked(p *Player, extra int) bool {
+	enc := p.encumbrance()
+	return enc.Load+extra <= enc.Capacity
+}
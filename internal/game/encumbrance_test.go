@@ -0,0 +1,64 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestItemWeightIncludesContents(t *testing.T) {
+	pack := Item{Name: "Pack", Weight: 5, Container: true, Contents: []Item{{Name: "Rope", Weight: 3}, {Name: "Note"}}}
+	if got := pack.TotalWeight(); got != 5+3+DefaultItemWeight {
+		t.Fatalf("pack weight = %d", got)
+	}
+}
+
+func TestCarryCapacityLimitsTakingAndMoving(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"yard": {ID: "yard", Items: []Item{{Name: "Anvil", Weight: 90}, {Name: "Crate", Weight: 30}}, Exits: map[string]Exit{"east": {To: "road"}}},
+		"road": {ID: "road", Exits: map[string]Exit{"west": {To: "yard"}}},
+	})
+	p := &Player{Name: "Porter", Room: "yard", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if _, err := world.TakeItem(p, "anvil"); err != nil {
+		t.Fatalf("TakeItem anvil: %v", err)
+	}
+	if _, err := world.TakeItem(p, "crate"); !errors.Is(err, ErrTooHeavy) {
+		t.Fatalf("taking past capacity error = %v", err)
+	}
+	if enc := world.Encumbrance(p); enc.Load != 90 || enc.Capacity != 100 || !enc.Burdened() || enc.Overloaded() {
+		t.Fatalf("encumbrance = %+v", enc)
+	}
+	if _, err := world.Move(p, "east"); err != nil {
+		t.Fatalf("Move while burdened: %v", err)
+	}
+	if p.Moves != p.MaxMoves-2*WalkMoveCost {
+		t.Fatalf("burdened walk left %d of %d stamina", p.Moves, p.MaxMoves)
+	}
+
+	p.Inventory = append(p.Inventory, Item{Name: "Crate", Weight: 30})
+	if _, err := world.Move(p, "west"); !errors.Is(err, ErrOverburdened) {
+		t.Fatalf("overloaded move error = %v", err)
+	}
+}
+
+func TestLootLeavesWhatDoesNotFit(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"field": {ID: "field", Items: []Item{{Name: "corpse of a golem", Corpse: true, Container: true,
+			Contents: []Item{{Name: "Iron Heart", Weight: 80}, {Name: "Boulder", Weight: 50}, {Name: "Gem"}}}}, Exits: map[string]Exit{}},
+	})
+	p := &Player{Name: "Looter", Room: "field", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	_, looted, err := world.LootCorpse(p, "")
+	if err != nil || len(looted) != 2 || looted[0].Name != "Iron Heart" || looted[1].Name != "Gem" {
+		t.Fatalf("LootCorpse = %+v, %v", looted, err)
+	}
+	corpse, _ := world.FindRoomItem("field", "corpse")
+	if len(corpse.Contents) != 1 || corpse.Contents[0].Name != "Boulder" {
+		t.Fatalf("corpse kept %+v", corpse.Contents)
+	}
+	if _, _, err := world.LootCorpse(p, ""); !errors.Is(err, ErrTooHeavy) {
+		t.Fatalf("looting only heavy items error = %v", err)
+	}
+}
@@ -0,0 +1,526 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	eventsFileName = "events.json"
+	eventTick      = time.Second
+	// defaultEventDuration applies when an event leaves its duration unset.
+	defaultEventDuration = 30 * time.Minute
+)
+
+var (
+	// ErrUnknownEvent indicates no event is defined with that id.
+	ErrUnknownEvent = errors.New("no event is defined by that id")
+	// ErrEventRunning indicates the event has already started.
+	ErrEventRunning = errors.New("that event is already running")
+	// ErrEventNotRunning indicates the event is not under way.
+	ErrEventNotRunning = errors.New("that event is not running")
+)
+
+// WorldEvent is a timed world event defined in events.json, such as an
+// invasion, a double experience weekend, or a treasure hunt. Events start on
+// their schedule or when an admin starts them, and everything they spawned is
+// cleared away when they end.
+type WorldEvent struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Area limits the event to one area's rooms, by area key. Events without
+	// an area span the whole world.
+	Area string `json:"area,omitempty"`
+	// Duration is how many seconds the event lasts.
+	Duration int `json:"duration,omitempty"`
+	// Every starts the event again this many seconds after it last started.
+	Every int `json:"every,omitempty"`
+	// Days runs the event all day on the listed weekdays (UTC), such as
+	// "saturday" and "sunday" for a weekend event.
+	Days         []string `json:"days,omitempty"`
+	StartMessage string   `json:"start_message,omitempty"`
+	EndMessage   string   `json:"end_message,omitempty"`
+	// XPMultiplier scales experience earned in the event's area while it
+	// runs.
+	XPMultiplier int `json:"xp_multiplier,omitempty"`
+	// Waves spawn NPCs into random rooms of the area as the event goes on.
+	Waves []EventWave `json:"waves,omitempty"`
+	// Treasure is hidden in random rooms of the area when the event starts.
+	Treasure []Item `json:"treasure,omitempty"`
+	// Drops adds one of these items to the loot of every NPC slain in the
+	// area while the event runs.
+	Drops []Item `json:"drops,omitempty"`
+}
+
+// EventWave is one group of NPCs an event spawns.
+type EventWave struct {
+	// Delay is how many seconds after the event starts the wave arrives.
+	Delay   int    `json:"delay,omitempty"`
+	Message string `json:"message,omitempty"`
+	NPCs    []NPC  `json:"npcs"`
+}
+
+// EventStatus describes a defined event for listings.
+type EventStatus struct {
+	ID      string
+	Name    string
+	Area    string
+	Running bool
+	// Ends is when a running event finishes.
+	Ends time.Time
+	// Next is when a scheduled event starts again; zero when it only starts
+	// by hand or is running.
+	Next time.Time
+}
+
+// activeEvent tracks a running event.
+type activeEvent struct {
+	def     WorldEvent
+	started time.Time
+	ends    time.Time
+	wave    int
+}
+
+// eventSchedule tracks when an event next starts on its own.
+type eventSchedule struct {
+	next time.Time
+	// skipUntil holds off a day-scheduled event an admin stopped early.
+	skipUntil time.Time
+}
+
+func (e WorldEvent) duration() time.Duration {
+	if e.Duration > 0 {
+		return time.Duration(e.Duration) * time.Second
+	}
+	return defaultEventDuration
+}
+
+func (e WorldEvent) runsOn(day time.Weekday) bool {
+	for _, name := range e.Days {
+		if strings.EqualFold(name, day.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// dayRunEnd returns the midnight that ends the run of scheduled days that
+// includes now.
+func (e WorldEvent) dayRunEnd(now time.Time) time.Time {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	for i := 0; i < 6 && e.runsOn(end.Weekday()); i++ {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+func eventsPath(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), eventsFileName)
+}
+
+func loadWorldEvents(areasPath string) (map[string]WorldEvent, error) {
+	path := eventsPath(areasPath)
+	if path == "" {
+		return map[string]WorldEvent{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]WorldEvent{}, nil
+		}
+		return nil, err
+	}
+	var parsed struct {
+		Events []WorldEvent `json:"events"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse events: %w", err)
+	}
+	events := make(map[string]WorldEvent, len(parsed.Events))
+	for _, event := range parsed.Events {
+		if err := normalizeWorldEvent(&event); err != nil {
+			return nil, fmt.Errorf("parse events: %w", err)
+		}
+		if _, exists := events[event.ID]; exists {
+			return nil, fmt.Errorf("parse events: duplicate event %s", event.ID)
+		}
+		events[event.ID] = event
+	}
+	return events, nil
+}
+
+func normalizeWorldEvent(e *WorldEvent) error {
+	e.ID = strings.ToLower(strings.TrimSpace(e.ID))
+	e.Name = strings.TrimSpace(e.Name)
+	e.Area = strings.ToLower(strings.TrimSpace(e.Area))
+	if e.ID == "" || e.Name == "" {
+		return fmt.Errorf("events need an id and a name")
+	}
+	if e.Duration < 0 || e.Every < 0 || e.XPMultiplier < 0 {
+		return fmt.Errorf("event %s: timings and multipliers must not be negative", e.ID)
+	}
+	if e.Every > 0 && len(e.Days) > 0 {
+		return fmt.Errorf("event %s: use either every or days, not both", e.ID)
+	}
+	if e.Every > 0 && e.Every < e.Duration {
+		return fmt.Errorf("event %s: every is shorter than its duration", e.ID)
+	}
+	for _, day := range e.Days {
+		known := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(day, d.String()) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("event %s: unknown day %q", e.ID, day)
+		}
+	}
+	sort.SliceStable(e.Waves, func(i, j int) bool { return e.Waves[i].Delay < e.Waves[j].Delay })
+	for i := range e.Waves {
+		for j := range e.Waves[i].NPCs {
+			normalizeNPC(&e.Waves[i].NPCs[j])
+		}
+	}
+	return nil
+}
+
+// scheduleEventsLocked sets when each interval event first starts, keeping
+// the times of events that were already scheduled.
+func (w *World) scheduleEventsLocked(now time.Time) {
+	schedules := make(map[string]*eventSchedule, len(w.events))
+	for id, def := range w.events {
+		if previous, ok := w.eventSchedules[id]; ok {
+			schedules[id] = previous
+			continue
+		}
+		schedule := &eventSchedule{}
+		if def.Every > 0 {
+			schedule.next = now.Add(time.Duration(def.Every) * time.Second)
+		}
+		schedules[id] = schedule
+	}
+	w.eventSchedules = schedules
+}
+
+// eventRoomsLocked lists the rooms an event may spawn into, leaving out
+// instances and vehicles.
+func (w *World) eventRoomsLocked(area string) []RoomID {
+	var rooms []RoomID
+	for id := range w.rooms {
+		if isInstanceRoomID(id) || w.vehicleAboardLocked(id) != nil {
+			continue
+		}
+		if area != "" && w.roomAreaLocked(id) != area {
+			continue
+		}
+		rooms = append(rooms, id)
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i] < rooms[j] })
+	return rooms
+}
+
+// eventCoversLocked reports whether a running event reaches room.
+func (w *World) eventCoversLocked(active *activeEvent, room RoomID) bool {
+	if isInstanceRoomID(room) {
+		return false
+	}
+	return active.def.Area == "" || w.roomAreaLocked(room) == active.def.Area
+}
+
+func (w *World) startEventLocked(def WorldEvent, now time.Time, ends time.Time) []string {
+	if w.activeEvents == nil {
+		w.activeEvents = make(map[string]*activeEvent)
+	}
+	active := &activeEvent{def: def, started: now, ends: ends}
+	w.activeEvents[def.ID] = active
+	rooms := w.eventRoomsLocked(def.Area)
+	if len(rooms) > 0 {
+		for _, item := range def.Treasure {
+			room := w.rooms[rooms[w.roll(len(rooms))]]
+			item.event = def.ID
+			room.Items = append(room.Items, item)
+		}
+	}
+	msg := def.StartMessage
+	if msg == "" {
+		msg = fmt.Sprintf("%s has begun!", def.Name)
+	}
+	return append([]string{msg}, w.spawnEventWavesLocked(active, now)...)
+}
+
+// spawnEventWavesLocked releases the waves that are due and returns their
+// messages.
+func (w *World) spawnEventWavesLocked(active *activeEvent, now time.Time) []string {
+	var messages []string
+	rooms := w.eventRoomsLocked(active.def.Area)
+	for active.wave < len(active.def.Waves) {
+		wave := active.def.Waves[active.wave]
+		if now.Before(active.started.Add(time.Duration(wave.Delay) * time.Second)) {
+			break
+		}
+		active.wave++
+		if len(rooms) == 0 {
+			continue
+		}
+		for _, npc := range wave.NPCs {
+			room := w.rooms[rooms[w.roll(len(rooms))]]
+			npc.Loot = append([]Item(nil), npc.Loot...)
+			npc.event = active.def.ID
+			room.NPCs = append(room.NPCs, npc)
+		}
+		if wave.Message != "" {
+			messages = append(messages, wave.Message)
+		}
+	}
+	return messages
+}
+
+// endEventLocked stops an event and clears away the NPCs and treasure it
+// left in the world. Items players picked up are theirs to keep.
+func (w *World) endEventLocked(id string) string {
+	active, ok := w.activeEvents[id]
+	if !ok {
+		return ""
+	}
+	delete(w.activeEvents, id)
+	for _, room := range w.rooms {
+		npcs := room.NPCs[:0]
+		for _, npc := range room.NPCs {
+			if npc.event != id {
+				npcs = append(npcs, npc)
+			}
+		}
+		room.NPCs = npcs
+		items := room.Items[:0]
+		for _, item := range room.Items {
+			if item.event != id {
+				items = append(items, item)
+			}
+		}
+		room.Items = items
+	}
+	if active.def.EndMessage != "" {
+		return active.def.EndMessage
+	}
+	return fmt.Sprintf("%s has ended.", active.def.Name)
+}
+
+// AdvanceEvents starts scheduled events that are due, releases their waves,
+// and ends those whose time is up. Everyone online hears the announcements.
+func (w *World) AdvanceEvents(now time.Time) {
+	var announcements []string
+	w.mu.Lock()
+	ids := make([]string, 0, len(w.events))
+	for id := range w.events {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		def := w.events[id]
+		if active, ok := w.activeEvents[id]; ok {
+			if !now.Before(active.ends) {
+				announcements = append(announcements, w.endEventLocked(id))
+				continue
+			}
+			announcements = append(announcements, w.spawnEventWavesLocked(active, now)...)
+			continue
+		}
+		schedule := w.eventSchedules[id]
+		if schedule == nil {
+			continue
+		}
+		switch {
+		case def.Every > 0 && !now.Before(schedule.next):
+			schedule.next = now.Add(time.Duration(def.Every) * time.Second)
+			announcements = append(announcements, w.startEventLocked(def, now, now.Add(def.duration()))...)
+		case len(def.Days) > 0 && def.runsOn(now.UTC().Weekday()) && !now.Before(schedule.skipUntil):
+			announcements = append(announcements, w.startEventLocked(def, now, def.dayRunEnd(now))...)
+		}
+	}
+	w.mu.Unlock()
+	for _, msg := range announcements {
+		w.BroadcastSystem(Ansi("\r\n" + Style("[Event] ", AnsiMagenta, AnsiBold) + msg))
+	}
+}
+
+// StartEventLoop keeps world events on schedule until stop is closed.
+func (w *World) StartEventLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(eventTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.AdvanceEvents(now)
+			}
+		}
+	}()
+}
+
+// StartEvent begins an event by hand. It runs for its usual duration, or
+// for the given one when that is positive.
+func (w *World) StartEvent(id string, duration time.Duration) (WorldEvent, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	now := time.Now()
+	w.mu.Lock()
+	def, ok := w.events[id]
+	if !ok {
+		w.mu.Unlock()
+		return WorldEvent{}, ErrUnknownEvent
+	}
+	if _, running := w.activeEvents[id]; running {
+		w.mu.Unlock()
+		return WorldEvent{}, ErrEventRunning
+	}
+	if duration <= 0 {
+		duration = def.duration()
+	}
+	announcements := w.startEventLocked(def, now, now.Add(duration))
+	w.mu.Unlock()
+	for _, msg := range announcements {
+		w.BroadcastSystem(Ansi("\r\n" + Style("[Event] ", AnsiMagenta, AnsiBold) + msg))
+	}
+	return def, nil
+}
+
+// StopEvent ends a running event early. Day-scheduled events stay off for
+// the rest of their run.
+func (w *World) StopEvent(id string) (WorldEvent, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	now := time.Now()
+	w.mu.Lock()
+	def, ok := w.events[id]
+	active, running := w.activeEvents[id]
+	if !running {
+		w.mu.Unlock()
+		if !ok {
+			return WorldEvent{}, ErrUnknownEvent
+		}
+		return WorldEvent{}, ErrEventNotRunning
+	}
+	if schedule := w.eventSchedules[id]; schedule != nil && len(active.def.Days) > 0 && active.def.runsOn(now.UTC().Weekday()) {
+		schedule.skipUntil = active.def.dayRunEnd(now)
+	}
+	msg := w.endEventLocked(id)
+	w.mu.Unlock()
+	w.BroadcastSystem(Ansi("\r\n" + Style("[Event] ", AnsiMagenta, AnsiBold) + msg))
+	if !ok {
+		def = active.def
+	}
+	return def, nil
+}
+
+// Events lists the defined events and any still running after their
+// definition was removed.
+func (w *World) Events() []EventStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	statuses := make([]EventStatus, 0, len(w.events))
+	add := func(def WorldEvent) {
+		status := EventStatus{ID: def.ID, Name: def.Name, Area: def.Area}
+		if active, ok := w.activeEvents[def.ID]; ok {
+			status.Running = true
+			status.Ends = active.ends
+		} else if schedule := w.eventSchedules[def.ID]; schedule != nil && def.Every > 0 {
+			status.Next = schedule.next
+		}
+		statuses = append(statuses, status)
+	}
+	for _, def := range w.events {
+		add(def)
+	}
+	for id, active := range w.activeEvents {
+		if _, ok := w.events[id]; !ok {
+			add(active.def)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+// eventExperienceLocked scales experience earned in room by the largest
+// multiplier among the events running there.
+func (w *World) eventExperienceLocked(room RoomID, amount int) int {
+	multiplier := 1
+	for _, active := range w.activeEvents {
+		if active.def.XPMultiplier > multiplier && w.eventCoversLocked(active, room) {
+			multiplier = active.def.XPMultiplier
+		}
+	}
+	return amount * multiplier
+}
+
+// EventExperience scales experience p earns by any event running where they
+// stand.
+func (w *World) EventExperience(p *Player, amount int) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.eventExperienceLocked(p.Room, amount)
+}
+
+// eventDropsLocked picks one drop from each event running in room.
+func (w *World) eventDropsLocked(room RoomID) []Item {
+	var drops []Item
+	ids := make([]string, 0, len(w.activeEvents))
+	for id := range w.activeEvents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		active := w.activeEvents[id]
+		if len(active.def.Drops) == 0 || !w.eventCoversLocked(active, room) {
+			continue
+		}
+		drops = append(drops, active.def.Drops[w.roll(len(active.def.Drops))])
+	}
+	return drops
+}
+
+// ReloadEvents re-reads the events file. Running events carry on with their
+// old definition until they end.
+func (w *World) ReloadEvents() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	events, err := loadWorldEvents(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	w.events = events
+	w.scheduleEventsLocked(time.Now())
+	w.mu.Unlock()
+	return len(events), nil
+}
+
+// AddEventForTest registers an event definition.
+func (w *World) AddEventForTest(def WorldEvent) error {
+	if err := normalizeWorldEvent(&def); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.events == nil {
+		w.events = make(map[string]WorldEvent)
+	}
+	w.events[def.ID] = def
+	w.scheduleEventsLocked(time.Now())
+	return nil
+}
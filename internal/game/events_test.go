@@ -0,0 +1,210 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShippedEventsLoad(t *testing.T) {
+	events, err := loadWorldEvents("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadWorldEvents error: %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatalf("expected shipped events")
+	}
+	_, sources, _, err := loadRooms("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadRooms error: %v", err)
+	}
+	areas := make(map[string]bool)
+	for _, source := range sources {
+		areas[AreaKey(source)] = true
+	}
+	for id, event := range events {
+		if event.Area != "" && !areas[event.Area] {
+			t.Fatalf("event %s names unknown area %s", id, event.Area)
+		}
+	}
+}
+
+func TestWorldEventRejectsBadSchedules(t *testing.T) {
+	for _, event := range []WorldEvent{
+		{ID: "both", Name: "Both", Every: 600, Days: []string{"monday"}},
+		{ID: "overlap", Name: "Overlap", Every: 60, Duration: 120},
+		{ID: "caturday", Name: "Caturday", Days: []string{"caturday"}},
+	} {
+		if err := normalizeWorldEvent(&event); err == nil {
+			t.Fatalf("event %s should be rejected", event.ID)
+		}
+	}
+}
+
+func TestInvasionSpawnsWavesAndTearsDown(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"gate":  {ID: "gate", Title: "Gate", Area: "town"},
+		"crypt": {ID: "crypt", Title: "Crypt", Area: "crypt"},
+	})
+	p := &Player{Name: "Hero", Room: "crypt", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if err := world.AddEventForTest(WorldEvent{
+		ID: "raid", Name: "Bone Raid", Area: "crypt", Every: 3600, Duration: 600, XPMultiplier: 2,
+		Waves: []EventWave{
+			{NPCs: []NPC{{Name: "Bone Raider", Health: 5, MaxHealth: 5}}},
+			{Delay: 60, Message: "The Bone Lord arrives!", NPCs: []NPC{{Name: "Bone Lord"}}},
+		},
+		Treasure: []Item{{Name: "Grave Coin"}},
+		Drops:    []Item{{Name: "Raider Fang"}},
+	}); err != nil {
+		t.Fatalf("AddEventForTest: %v", err)
+	}
+	start := time.Now().Add(time.Hour)
+	world.AdvanceEvents(start)
+	if got := strings.Join(drainOutput(p.Output), ""); !strings.Contains(got, "Bone Raid has begun!") {
+		t.Fatalf("expected start announcement, got %q", got)
+	}
+	crypt, _ := world.GetRoom("crypt")
+	gate, _ := world.GetRoom("gate")
+	if len(crypt.NPCs) != 1 || len(crypt.Items) != 1 || len(gate.NPCs) != 0 {
+		t.Fatalf("first wave spawned %d NPCs and %d items in the crypt, %d NPCs outside", len(crypt.NPCs), len(crypt.Items), len(gate.NPCs))
+	}
+	if got := world.EventExperience(p, 10); got != 20 {
+		t.Fatalf("EventExperience in area = %d, want 20", got)
+	}
+	world.AdvanceEvents(start.Add(time.Minute))
+	if got := strings.Join(drainOutput(p.Output), ""); !strings.Contains(got, "The Bone Lord arrives!") || len(crypt.NPCs) != 2 {
+		t.Fatalf("second wave: %q, %d NPCs", got, len(crypt.NPCs))
+	}
+
+	result, err := world.ApplyDamageToNPC("crypt", "bone raider", 5)
+	if err != nil {
+		t.Fatalf("ApplyDamageToNPC: %v", err)
+	}
+	if len(result.Loot) != 1 || result.Loot[0].Name != "Raider Fang" || result.Loot[0].event != "" {
+		t.Fatalf("expected an event drop, got %+v", result.Loot)
+	}
+
+	world.AdvanceEvents(start.Add(10 * time.Minute))
+	if got := strings.Join(drainOutput(p.Output), ""); !strings.Contains(got, "Bone Raid has ended.") {
+		t.Fatalf("expected end announcement, got %q", got)
+	}
+	for _, npc := range crypt.NPCs {
+		t.Fatalf("event NPC %s survived teardown", npc.Name)
+	}
+	for _, item := range crypt.Items {
+		if !item.Corpse {
+			t.Fatalf("event item %s survived teardown", item.Name)
+		}
+	}
+	if got := world.EventExperience(p, 10); got != 10 {
+		t.Fatalf("EventExperience after the event = %d, want 10", got)
+	}
+}
+
+func TestAdminStartsAndStopsEvents(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"gate":  {ID: "gate", Title: "Gate", Area: "town"},
+		"crypt": {ID: "crypt", Title: "Crypt", Area: "crypt"},
+	})
+	p := &Player{Name: "Hero", Room: "crypt", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if err := world.AddEventForTest(WorldEvent{ID: "hunt", Name: "Hunt", Treasure: []Item{{Name: "Token"}}}); err != nil {
+		t.Fatalf("AddEventForTest: %v", err)
+	}
+	if _, err := world.StopEvent("hunt"); err != ErrEventNotRunning {
+		t.Fatalf("StopEvent before start = %v", err)
+	}
+	if _, err := world.StartEvent("missing", 0); err != ErrUnknownEvent {
+		t.Fatalf("StartEvent unknown = %v", err)
+	}
+	if _, err := world.StartEvent("hunt", time.Minute); err != nil {
+		t.Fatalf("StartEvent: %v", err)
+	}
+	if _, err := world.StartEvent("hunt", 0); err != ErrEventRunning {
+		t.Fatalf("second StartEvent = %v", err)
+	}
+	statuses := world.Events()
+	if len(statuses) != 1 || !statuses[0].Running || time.Until(statuses[0].Ends) > time.Minute {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+	if _, err := world.StopEvent("hunt"); err != nil {
+		t.Fatalf("StopEvent: %v", err)
+	}
+	for _, id := range []RoomID{"gate", "crypt"} {
+		room, _ := world.GetRoom(id)
+		if len(room.Items) != 0 {
+			t.Fatalf("treasure left in %s after stop", id)
+		}
+	}
+	if got := strings.Join(drainOutput(p.Output), ""); !strings.Contains(got, "Hunt has begun!") || !strings.Contains(got, "Hunt has ended.") {
+		t.Fatalf("expected announcements, got %q", got)
+	}
+}
+
+func TestDayEventsRunThroughTheirDays(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"gate":  {ID: "gate", Title: "Gate", Area: "town"},
+		"crypt": {ID: "crypt", Title: "Crypt", Area: "crypt"},
+	})
+	p := &Player{Name: "Hero", Room: "crypt", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if err := world.AddEventForTest(WorldEvent{ID: "weekend", Name: "Weekend", Days: []string{"Saturday", "sunday"}, XPMultiplier: 3}); err != nil {
+		t.Fatalf("AddEventForTest: %v", err)
+	}
+	friday := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	world.AdvanceEvents(friday)
+	if got := world.EventExperience(p, 10); got != 10 {
+		t.Fatalf("weekend event ran on a Friday")
+	}
+	saturday := friday.AddDate(0, 0, 1)
+	world.AdvanceEvents(saturday)
+	if got := world.EventExperience(p, 10); got != 30 {
+		t.Fatalf("EventExperience on Saturday = %d, want 30", got)
+	}
+	statuses := world.Events()
+	if want := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC); !statuses[0].Ends.Equal(want) {
+		t.Fatalf("weekend ends %s, want %s", statuses[0].Ends, want)
+	}
+	world.AdvanceEvents(time.Date(2026, time.October, 19, 0, 0, 1, 0, time.UTC))
+	if got := world.EventExperience(p, 10); got != 10 {
+		t.Fatalf("weekend event outlived Sunday")
+	}
+}
+
+func TestEventSpawnsAreNotSavedWithBuilderRooms(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"gate":  {ID: "gate", Title: "Gate", Area: "town"},
+		"crypt": {ID: "crypt", Title: "Crypt", Area: "crypt"},
+	})
+	world.builderPath = t.TempDir() + "/builder.json"
+	world.roomSources["gate"] = builderAreaFile
+	if err := world.AddEventForTest(WorldEvent{ID: "raid", Name: "Raid", Waves: []EventWave{{NPCs: []NPC{{Name: "Raider"}}}}}); err != nil {
+		t.Fatalf("AddEventForTest: %v", err)
+	}
+	world.SetDice(DiceFunc(func(n int) int { return 1 % n }))
+	if _, err := world.StartEvent("raid", 0); err != nil {
+		t.Fatalf("StartEvent: %v", err)
+	}
+	world.mu.Lock()
+	err := world.persistBuilderRoomsLocked()
+	world.mu.Unlock()
+	if err != nil {
+		t.Fatalf("persistBuilderRoomsLocked: %v", err)
+	}
+	data, err := os.ReadFile(world.builderPath)
+	if err != nil {
+		t.Fatalf("read builder rooms: %v", err)
+	}
+	var file areaFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("decode builder rooms: %v", err)
+	}
+	for _, room := range file.Rooms {
+		if len(room.NPCs) != 0 {
+			t.Fatalf("event NPC saved in %s", room.ID)
+		}
+	}
+}
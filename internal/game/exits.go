@@ -0,0 +1,276 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExitRule restricts who may see or use an exit. The zero value leaves the
+// exit open to everyone.
+type ExitRule struct {
+	// Hidden exits stay out of exit lists and cannot be used until a player
+	// reveals them with search or a script reveals them.
+	Hidden bool `json:"hidden,omitempty"`
+	// MinLevel is the lowest player level allowed through.
+	MinLevel int `json:"min_level,omitempty"`
+	// Quest is the ID of a quest the player must have completed.
+	Quest string `json:"quest,omitempty"`
+	// Item names an item the player must carry.
+	Item string `json:"item,omitempty"`
+	// Message replaces the default refusal shown when a condition fails.
+	Message string `json:"message,omitempty"`
+}
+
+func (r ExitRule) empty() bool {
+	return r == ExitRule{}
+}
+
+// Describe summarises the rule for builders.
+func (r ExitRule) Describe() string {
+	var parts []string
+	if r.Hidden {
+		parts = append(parts, "hidden")
+	}
+	if r.MinLevel > 0 {
+		parts = append(parts, fmt.Sprintf("level %d", r.MinLevel))
+	}
+	if r.Quest != "" {
+		parts = append(parts, "quest "+r.Quest)
+	}
+	if r.Item != "" {
+		parts = append(parts, "item "+r.Item)
+	}
+	if r.Message != "" {
+		parts = append(parts, fmt.Sprintf("message %q", r.Message))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// exitVisibleLocked reports whether p can see the exit: it is not hidden or
+// p has already revealed it.
+func exitVisibleLocked(p *Player, room RoomID, dir string, exit Exit) bool {
+	if !exit.Hidden {
+		return true
+	}
+	return p != nil && p.revealedExits[room][dir]
+}
+
+// exitAllowsLocked checks the exit's conditions against p.
+func (w *World) exitAllowsLocked(p *Player, dir string, exit Exit) error {
+	refuse := func(reason string) error {
+		if exit.Message != "" {
+			return fmt.Errorf("%s", exit.Message)
+		}
+		return fmt.Errorf("%s", reason)
+	}
+	if exit.MinLevel > 0 && p.Level < exit.MinLevel {
+		return refuse(fmt.Sprintf("you must be level %d to go %s", exit.MinLevel, dir))
+	}
+	if exit.Quest != "" {
+		progress, ok := p.QuestLog[strings.ToLower(exit.Quest)]
+		if !ok || !progress.everCompleted() {
+			name := exit.Quest
+			if quest, ok := w.quests[strings.ToLower(exit.Quest)]; ok {
+				name = quest.Name
+			}
+			return refuse(fmt.Sprintf("you must complete %s to go %s", name, dir))
+		}
+	}
+	if exit.Item != "" && !carriesKey(p.Inventory, exit.Item) {
+		return refuse(fmt.Sprintf("you need %s to go %s", exit.Item, dir))
+	}
+	return nil
+}
+
+// PlayerExitList renders the exits p can see in r, marking those behind a
+// closed door.
+func PlayerExitList(r *Room, p *Player) string {
+	keys := make([]string, 0, len(r.Exits))
+	for k, exit := range r.Exits {
+		if exitVisibleLocked(p, r.ID, k, exit) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return "none"
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		if r.Exits[k].Closed {
+			keys[i] = k + "(closed)"
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+// ExitsFor renders the exits p can see in r.
+func (w *World) ExitsFor(p *Player, r *Room) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return PlayerExitList(r, p)
+}
+
+// RevealExit shows the named hidden exit in p's current room to p. It
+// reports whether a hidden exit was newly revealed.
+func (w *World) RevealExit(p *Player, direction string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return false
+	}
+	dir := strings.ToLower(strings.TrimSpace(direction))
+	exit, ok := room.Exits[dir]
+	if !ok || !exit.Hidden || p.revealedExits[room.ID][dir] {
+		return false
+	}
+	p.revealExitLocked(room.ID, dir)
+	return true
+}
+
+// SearchForExits reveals every hidden exit in p's current room and returns
+// the newly found directions.
+func (w *World) SearchForExits(p *Player) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return nil
+	}
+	var found []string
+	for dir, exit := range room.Exits {
+		if !exit.Hidden || p.revealedExits[room.ID][dir] {
+			continue
+		}
+		p.revealExitLocked(room.ID, dir)
+		found = append(found, dir)
+	}
+	sort.Strings(found)
+	return found
+}
+
+func (p *Player) revealExitLocked(room RoomID, dir string) {
+	if p.revealedExits == nil {
+		p.revealedExits = make(map[RoomID]map[string]bool)
+	}
+	if p.revealedExits[room] == nil {
+		p.revealedExits[room] = make(map[string]bool)
+	}
+	p.revealedExits[room][dir] = true
+}
+
+// SetExitRule replaces the conditions on an exit and saves the room to the
+// builder area.
+func (w *World) SetExitRule(roomID RoomID, direction string, rule ExitRule, editor string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		return err
+	}
+	room, ok := w.rooms[roomID]
+	if !ok {
+		return fmt.Errorf("unknown room: %s", roomID)
+	}
+	dir, ok := matchExitLocked(room, direction)
+	if !ok {
+		return fmt.Errorf("no exit %s from %s", strings.TrimSpace(direction), roomID)
+	}
+	if rule.Quest != "" {
+		if _, ok := w.quests[strings.ToLower(rule.Quest)]; !ok {
+			return fmt.Errorf("unknown quest: %s", rule.Quest)
+		}
+	}
+	prev := room.Exits[dir]
+	next := prev
+	next.ExitRule = rule
+	room.Exits[dir] = next
+	prevSource, hadSource := w.markRoomAsBuilderLocked(roomID)
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		room.Exits[dir] = prev
+		if hadSource {
+			w.roomSources[roomID] = prevSource
+		} else {
+			delete(w.roomSources, roomID)
+		}
+		return err
+	}
+	return nil
+}
+
+// ExitRuleFor returns the conditions on an exit from the given room along
+// with the exit's canonical direction.
+func (w *World) ExitRuleFor(roomID RoomID, direction string) (string, ExitRule, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	room, ok := w.rooms[roomID]
+	if !ok {
+		return "", ExitRule{}, false
+	}
+	dir, ok := matchExitLocked(room, direction)
+	if !ok {
+		return "", ExitRule{}, false
+	}
+	return dir, room.Exits[dir].ExitRule, true
+}
+
+// ParseExitRule applies builder arguments such as "hidden", "level 5",
+// "quest lost_chart", "item Silver Key", "message The gate will not budge.",
+// or "clear" to rule. Each keyword may be prefixed with "no" to remove that
+// condition.
+func ParseExitRule(rule ExitRule, args string) (ExitRule, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return rule, fmt.Errorf("no conditions given")
+	}
+	keyword := strings.ToLower(fields[0])
+	value := strings.TrimSpace(strings.Join(fields[1:], " "))
+	needValue := func() error {
+		if value == "" {
+			return fmt.Errorf("%s needs a value", keyword)
+		}
+		return nil
+	}
+	switch keyword {
+	case "clear":
+		return ExitRule{}, nil
+	case "hidden":
+		rule.Hidden = true
+	case "visible", "nohidden":
+		rule.Hidden = false
+	case "level":
+		if err := needValue(); err != nil {
+			return rule, err
+		}
+		level, err := strconv.Atoi(value)
+		if err != nil || level < 0 {
+			return rule, fmt.Errorf("level must be a non-negative number")
+		}
+		rule.MinLevel = level
+	case "nolevel":
+		rule.MinLevel = 0
+	case "quest":
+		if err := needValue(); err != nil {
+			return rule, err
+		}
+		rule.Quest = value
+	case "noquest":
+		rule.Quest = ""
+	case "item":
+		if err := needValue(); err != nil {
+			return rule, err
+		}
+		rule.Item = value
+	case "noitem":
+		rule.Item = ""
+	case "message":
+		rule.Message = value
+	default:
+		return rule, fmt.Errorf("unknown condition: %s", fields[0])
+	}
+	return rule, nil
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHiddenExitNeedsRevealing(t *testing.T) {
+	rooms := map[RoomID]*Room{
+		"study": {ID: "study", Exits: map[string]Exit{
+			"east":  {To: "hall"},
+			"north": {To: "passage", ExitRule: ExitRule{Hidden: true}},
+		}},
+		"hall":    {ID: "hall", Exits: map[string]Exit{"west": {To: "study"}}},
+		"passage": {ID: "passage", Exits: map[string]Exit{"south": {To: "study"}}},
+	}
+	world := NewWorldWithRooms(rooms)
+	p := &Player{Name: "Seeker", Room: "study", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if got := world.ExitsFor(p, rooms["study"]); got != "east" {
+		t.Fatalf("exits before search = %q, want only east", got)
+	}
+	if _, _, ok := world.ResolveExit(p, "north"); ok {
+		t.Fatalf("hidden exit should not resolve before it is found")
+	}
+	if _, err := world.Move(p, "north"); err == nil {
+		t.Fatalf("expected hidden exit to block movement")
+	}
+	if found := world.SearchForExits(p); len(found) != 1 || found[0] != "north" {
+		t.Fatalf("SearchForExits = %v, want [north]", found)
+	}
+	if found := world.SearchForExits(p); len(found) != 0 {
+		t.Fatalf("second search found %v again", found)
+	}
+	if got := world.ExitsFor(p, rooms["study"]); got != "east north" {
+		t.Fatalf("exits after search = %q", got)
+	}
+	other := &Player{Name: "Bystander", Room: "study", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(other)
+	if got := world.ExitsFor(other, rooms["study"]); got != "east" {
+		t.Fatalf("another player should not see the revealed exit, got %q", got)
+	}
+	if _, err := world.Move(p, "north"); err != nil {
+		t.Fatalf("move through revealed exit: %v", err)
+	}
+}
+
+func TestConditionalExitsCheckLevelQuestAndItem(t *testing.T) {
+	rooms := map[RoomID]*Room{
+		"gate": {ID: "gate", Exits: map[string]Exit{
+			"north": {To: "keep", ExitRule: ExitRule{MinLevel: 5}},
+			"east":  {To: "keep", ExitRule: ExitRule{Quest: "lost_chart", Message: "The warden bars your way."}},
+			"west":  {To: "keep", ExitRule: ExitRule{Item: "Sun Pass"}},
+		}},
+		"keep": {ID: "keep"},
+	}
+	world := NewWorldWithRooms(rooms)
+	world.quests["lost_chart"] = &Quest{ID: "lost_chart", Name: "The Lost Chart"}
+	p := &Player{Name: "Squire", Room: "gate", Level: 2, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if _, err := world.Move(p, "north"); err == nil || !strings.Contains(err.Error(), "level 5") {
+		t.Fatalf("level gate err = %v", err)
+	}
+	if _, err := world.Move(p, "east"); err == nil || err.Error() != "The warden bars your way." {
+		t.Fatalf("quest gate err = %v", err)
+	}
+	if _, err := world.Move(p, "west"); err == nil || !strings.Contains(err.Error(), "Sun Pass") {
+		t.Fatalf("item gate err = %v", err)
+	}
+
+	p.Level = 5
+	if _, err := world.Move(p, "north"); err != nil {
+		t.Fatalf("level gate should open: %v", err)
+	}
+	p.Room = "gate"
+	p.QuestLog = map[string]*QuestProgress{"lost_chart": {QuestID: "lost_chart", Completed: true, CompletedAt: time.Now()}}
+	if _, err := world.Move(p, "east"); err != nil {
+		t.Fatalf("quest gate should open: %v", err)
+	}
+	p.Room = "gate"
+	p.Inventory = []Item{{Name: "Sun Pass"}}
+	if _, err := world.Move(p, "west"); err != nil {
+		t.Fatalf("item gate should open: %v", err)
+	}
+}
+
+func TestExitRuleJSONAndParsing(t *testing.T) {
+	rule, err := ParseExitRule(ExitRule{}, "level 3")
+	if err != nil {
+		t.Fatalf("ParseExitRule: %v", err)
+	}
+	if rule, err = ParseExitRule(rule, "hidden"); err != nil {
+		t.Fatalf("ParseExitRule: %v", err)
+	}
+	if rule != (ExitRule{Hidden: true, MinLevel: 3}) {
+		t.Fatalf("rule = %+v", rule)
+	}
+	if _, err := ParseExitRule(rule, "level many"); err == nil {
+		t.Fatalf("expected bad level to be rejected")
+	}
+	encoded, err := json.Marshal(map[string]Exit{"down": {To: "crypt", ExitRule: rule}, "up": {To: "tower"}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"down":{"to":"crypt","hidden":true,"min_level":3},"up":"tower"}`
+	if string(encoded) != want {
+		t.Fatalf("encoded %s, want %s", encoded, want)
+	}
+	var decoded map[string]Exit
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded["down"].ExitRule != rule {
+		t.Fatalf("decoded rule = %+v", decoded["down"].ExitRule)
+	}
+}
+
+func TestRoomScriptCanRevealExit(t *testing.T) {
+	script := `package main
+
+func OnLook(ctx map[string]any) {
+    reveal := ctx["reveal"].(func(string))
+    reveal("down")
+}`
+	rooms := map[RoomID]*Room{
+		"shrine": {ID: "shrine", Script: script, Exits: map[string]Exit{"down": {To: "crypt", ExitRule: ExitRule{Hidden: true}}}},
+		"crypt":  {ID: "crypt"},
+	}
+	world := NewWorldWithRooms(rooms)
+	p := &Player{Name: "Pilgrim", Room: "shrine", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	world.TriggerRoomLook(p)
+	output := stripAnsi(strings.Join(drainOutput(p.Output), ""))
+	if !strings.Contains(output, "hidden exit leading down") {
+		t.Fatalf("expected reveal message, got %q", output)
+	}
+	if _, err := world.Move(p, "down"); err != nil {
+		t.Fatalf("move through script-revealed exit: %v", err)
+	}
+}
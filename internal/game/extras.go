@@ -0,0 +1,179 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// normalizeExtraKeywords lower-cases a keyword list and collapses spacing so
+// "Old  Fresco" and "old fresco" name the same extra.
+func normalizeExtraKeywords(keywords string) string {
+	return strings.ToLower(strings.Join(strings.Fields(keywords), " "))
+}
+
+// matchExtra finds the extra whose keyword list contains target, or whose
+// whole keyword list equals it.
+func matchExtra(extras map[string]string, target string) (string, bool) {
+	target = normalizeExtraKeywords(target)
+	if target == "" || len(extras) == 0 {
+		return "", false
+	}
+	keys := make([]string, 0, len(extras))
+	for key := range extras {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		normalized := normalizeExtraKeywords(key)
+		if normalized == target {
+			return extras[key], true
+		}
+		for _, word := range strings.Fields(normalized) {
+			if word == target {
+				return extras[key], true
+			}
+		}
+	}
+	return "", false
+}
+
+// withExtra returns a copy of extras with keywords set to text, or removed
+// when text is empty. Extras maps are shared between cloned items, so they
+// are never changed in place.
+func withExtra(extras map[string]string, keywords, text string) map[string]string {
+	key := normalizeExtraKeywords(keywords)
+	if key == "" {
+		return extras
+	}
+	next := make(map[string]string, len(extras)+1)
+	for existing, value := range extras {
+		if normalizeExtraKeywords(existing) != key {
+			next[existing] = value
+		}
+	}
+	if text = strings.TrimSpace(text); text != "" {
+		next[key] = text
+	}
+	if len(next) == 0 {
+		return nil
+	}
+	return next
+}
+
+// ExtraKeywords lists an extras map's keyword lists in order.
+func ExtraKeywords(extras map[string]string) []string {
+	keys := make([]string, 0, len(extras))
+	for key := range extras {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LookExtra finds detail text for "look <target>" in the room's extras, then
+// the extras of items in the room and the player's inventory, and finally
+// any extras the room's OnLook script adds for target.
+func (w *World) LookExtra(p *Player, target string) (string, bool) {
+	w.mu.RLock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		w.mu.RUnlock()
+		return "", false
+	}
+	if text, ok := matchExtra(room.Extras, target); ok {
+		w.mu.RUnlock()
+		return text, true
+	}
+	for _, items := range [][]Item{room.Items, p.Inventory} {
+		for _, item := range items {
+			if text, ok := matchExtra(item.Extras, target); ok {
+				w.mu.RUnlock()
+				return text, true
+			}
+		}
+	}
+	w.mu.RUnlock()
+	if w.scripts == nil {
+		return "", false
+	}
+	return matchExtra(w.scripts.callRoomOnLook(w, room, p, target), target)
+}
+
+// SetRoomExtra sets or, with empty text, removes an extra on a room.
+func (w *World) SetRoomExtra(roomID RoomID, keywords, text, editor string) error {
+	if normalizeExtraKeywords(keywords) == "" {
+		return fmt.Errorf("keywords must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		return err
+	}
+	room, ok := w.rooms[roomID]
+	if !ok {
+		return fmt.Errorf("unknown room: %s", roomID)
+	}
+	if strings.TrimSpace(text) == "" {
+		if _, ok := room.Extras[normalizeExtraKeywords(keywords)]; !ok {
+			return fmt.Errorf("no extra %q here", normalizeExtraKeywords(keywords))
+		}
+	}
+	prevExtras := room.Extras
+	room.Extras = withExtra(room.Extras, keywords, text)
+	prevSource, hadSource := w.markRoomAsBuilderLocked(roomID)
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		room.Extras = prevExtras
+		if hadSource {
+			w.roomSources[roomID] = prevSource
+		} else {
+			delete(w.roomSources, roomID)
+		}
+		return err
+	}
+	w.recordRoomRevisionLocked(room, editor)
+	return nil
+}
+
+// SetItemResetExtra sets or, with empty text, removes an extra on an item
+// reset and the matching items already in the room.
+func (w *World) SetItemResetExtra(roomID RoomID, item, keywords, text, editor string) error {
+	if normalizeExtraKeywords(keywords) == "" {
+		return fmt.Errorf("keywords must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		return err
+	}
+	room, ok := w.rooms[roomID]
+	if !ok {
+		return fmt.Errorf("unknown room: %s", roomID)
+	}
+	idx := findResetIndex(room.Resets, ResetKindItem, strings.TrimSpace(item))
+	if idx == -1 {
+		return fmt.Errorf("item reset %s not found", strings.TrimSpace(item))
+	}
+	prevItems := append([]Item(nil), room.Items...)
+	prevResets := append([]RoomReset(nil), room.Resets...)
+	extras := withExtra(room.Resets[idx].Extras, keywords, text)
+	room.Resets[idx].Extras = extras
+	for i := range room.Items {
+		if strings.EqualFold(room.Items[i].Name, room.Resets[idx].Name) {
+			room.Items[i].Extras = extras
+		}
+	}
+	prevSource, hadSource := w.markRoomAsBuilderLocked(roomID)
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		room.Items = prevItems
+		room.Resets = prevResets
+		if hadSource {
+			w.roomSources[roomID] = prevSource
+		} else {
+			delete(w.roomSources, roomID)
+		}
+		return err
+	}
+	w.recordRoomRevisionLocked(room, editor)
+	return nil
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookExtraFindsRoomItemAndScriptExtras(t *testing.T) {
+	script := `package main
+
+func OnLook(ctx map[string]any) {
+    if ctx["target"].(string) != "stars" {
+        return
+    }
+    addExtra := ctx["add_extra"].(func(string, string))
+    addExtra("stars sky", "The stars wheel slowly overhead.")
+}`
+	rooms := map[RoomID]*Room{
+		"hall": {
+			ID:     "hall",
+			Script: script,
+			Extras: map[string]string{"fresco wall": "A faded fresco of the founding."},
+			Resets: []RoomReset{{Kind: ResetKindItem, Name: "Statue"}},
+		},
+	}
+	world := NewWorldWithRooms(rooms)
+	p := &Player{Name: "Visitor", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	p.Inventory = []Item{{Name: "Ring", Extras: map[string]string{"inscription": "Forever."}}}
+	world.AddPlayerForTest(p)
+
+	if text, ok := world.LookExtra(p, "Fresco"); !ok || text != "A faded fresco of the founding." {
+		t.Fatalf("room extra = %q, %v", text, ok)
+	}
+	if text, ok := world.LookExtra(p, "fresco wall"); !ok || text == "" {
+		t.Fatalf("expected the full keyword list to match, got %q, %v", text, ok)
+	}
+	if text, ok := world.LookExtra(p, "inscription"); !ok || text != "Forever." {
+		t.Fatalf("inventory extra = %q, %v", text, ok)
+	}
+	if text, ok := world.LookExtra(p, "sky"); ok {
+		t.Fatalf("script extras should only answer the looked-at target, got %q", text)
+	}
+	if text, ok := world.LookExtra(p, "stars"); !ok || text != "The stars wheel slowly overhead." {
+		t.Fatalf("script extra = %q, %v", text, ok)
+	}
+
+	if err := world.SetItemResetExtra("hall", "statue", "plinth", "A worn stone plinth.", ""); err != nil {
+		t.Fatalf("SetItemResetExtra: %v", err)
+	}
+	if err := world.ApplyRoomResets("hall", ""); err != nil {
+		t.Fatalf("ApplyRoomResets: %v", err)
+	}
+	if text, ok := world.LookExtra(p, "plinth"); !ok || text != "A worn stone plinth." {
+		t.Fatalf("item reset extra = %q, %v", text, ok)
+	}
+	if err := world.SetRoomExtra("hall", "fresco wall", "", ""); err != nil {
+		t.Fatalf("remove room extra: %v", err)
+	}
+	if _, ok := world.LookExtra(p, "fresco"); ok {
+		t.Fatalf("expected the fresco extra to be removed")
+	}
+	if err := world.SetRoomExtra("hall", "fresco", "", ""); err == nil {
+		t.Fatalf("expected removing a missing extra to fail")
+	}
+}
+
+func TestRoomExtrasPersistToBuilderArea(t *testing.T) {
+	dir := t.TempDir()
+	writeSnapshotTestArea(t, dir)
+	world, err := NewWorld(dir)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	if err := world.SetRoomExtra("start", "Old  Fresco", "Paint flakes from the plaster.", "builder"); err != nil {
+		t.Fatalf("SetRoomExtra: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, builderAreaFile)); err != nil {
+		t.Fatalf("expected builder area to be written: %v", err)
+	}
+	reloaded, err := NewWorld(dir)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	room, ok := reloaded.GetRoom("start")
+	if !ok {
+		t.Fatalf("start room missing after reload")
+	}
+	if room.Extras["old fresco"] != "Paint flakes from the plaster." {
+		t.Fatalf("unexpected extras after reload: %+v", room.Extras)
+	}
+}
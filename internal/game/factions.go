@@ -0,0 +1,377 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const factionsFileName = "factions.json"
+
+const (
+	// ReputationMin and ReputationMax bound a player's reputation with a
+	// faction.
+	ReputationMin = -1000
+	ReputationMax = 1000
+	// DefaultKillPenalty is the reputation lost for slaying a faction
+	// member when the faction does not set its own.
+	DefaultKillPenalty = 25
+	// DefaultRivalBonus is the reputation gained for slaying a member of a
+	// rival faction when the faction does not set its own.
+	DefaultRivalBonus = 10
+)
+
+// Standing names a band of reputation.
+type Standing string
+
+const (
+	StandingHated      Standing = "Hated"
+	StandingHostile    Standing = "Hostile"
+	StandingUnfriendly Standing = "Unfriendly"
+	StandingNeutral    Standing = "Neutral"
+	StandingFriendly   Standing = "Friendly"
+	StandingHonored    Standing = "Honored"
+)
+
+// StandingFor returns the standing a reputation falls in.
+func StandingFor(reputation int) Standing {
+	switch {
+	case reputation <= -500:
+		return StandingHated
+	case reputation <= -200:
+		return StandingHostile
+	case reputation < 0:
+		return StandingUnfriendly
+	case reputation < 200:
+		return StandingNeutral
+	case reputation < 500:
+		return StandingFriendly
+	}
+	return StandingHonored
+}
+
+// Hostile reports whether members of a faction attack on sight at this
+// standing.
+func (s Standing) Hostile() bool {
+	return s == StandingHated || s == StandingHostile
+}
+
+// Unwelcome reports whether members of a faction refuse to greet players at
+// this standing.
+func (s Standing) Unwelcome() bool {
+	return s == StandingUnfriendly || s.Hostile()
+}
+
+// PriceModifier is the percentage a faction's shopkeepers add to their
+// prices at this standing, and whether they trade at all.
+func (s Standing) PriceModifier() (int, bool) {
+	switch s {
+	case StandingHated, StandingHostile:
+		return 0, false
+	case StandingUnfriendly:
+		return 25, true
+	case StandingFriendly:
+		return -10, true
+	case StandingHonored:
+		return -20, true
+	}
+	return 0, true
+}
+
+// Faction is a group NPCs can belong to. Players earn or lose reputation
+// with it through kills and quests.
+type Faction struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Start is the reputation every player begins with.
+	Start int `json:"start,omitempty"`
+	// KillPenalty is the reputation lost for slaying one of its members.
+	KillPenalty int `json:"kill_penalty,omitempty"`
+	// Rivals are factions whose members the faction is glad to see slain,
+	// earning RivalBonus reputation each.
+	Rivals     []string `json:"rivals,omitempty"`
+	RivalBonus int      `json:"rival_bonus,omitempty"`
+}
+
+// ReputationChange reports a shift in a player's reputation with a faction.
+type ReputationChange struct {
+	Faction  string
+	Delta    int
+	Total    int
+	Standing Standing
+}
+
+// FactionReputation is a player's reputation with one faction.
+type FactionReputation struct {
+	Faction    Faction
+	Reputation int
+	Standing   Standing
+}
+
+func factionsPath(areasPath string) string {
+	if areasPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), factionsFileName)
+}
+
+func loadFactions(areasPath string) (map[string]Faction, error) {
+	path := factionsPath(areasPath)
+	if path == "" {
+		return map[string]Faction{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]Faction{}, nil
+		}
+		return nil, err
+	}
+	var parsed struct {
+		Factions []Faction `json:"factions"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse factions: %w", err)
+	}
+	factions := make(map[string]Faction, len(parsed.Factions))
+	for _, faction := range parsed.Factions {
+		if err := normalizeFaction(&faction); err != nil {
+			return nil, fmt.Errorf("parse factions: %w", err)
+		}
+		if _, exists := factions[faction.ID]; exists {
+			return nil, fmt.Errorf("parse factions: duplicate faction %s", faction.ID)
+		}
+		factions[faction.ID] = faction
+	}
+	for _, faction := range factions {
+		for _, rival := range faction.Rivals {
+			if _, ok := factions[rival]; !ok {
+				return nil, fmt.Errorf("parse factions: %s names unknown rival %s", faction.ID, rival)
+			}
+		}
+	}
+	return factions, nil
+}
+
+func normalizeFaction(f *Faction) error {
+	f.ID = strings.ToLower(strings.TrimSpace(f.ID))
+	f.Name = strings.TrimSpace(f.Name)
+	if f.ID == "" || f.Name == "" {
+		return fmt.Errorf("factions need an id and a name")
+	}
+	f.Description = strings.TrimSpace(f.Description)
+	f.Start = clampReputation(f.Start)
+	if f.KillPenalty <= 0 {
+		f.KillPenalty = DefaultKillPenalty
+	}
+	if f.RivalBonus <= 0 {
+		f.RivalBonus = DefaultRivalBonus
+	}
+	for i := range f.Rivals {
+		f.Rivals[i] = strings.ToLower(strings.TrimSpace(f.Rivals[i]))
+	}
+	return nil
+}
+
+func factionKey(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+func clampReputation(value int) int {
+	return min(max(value, ReputationMin), ReputationMax)
+}
+
+func (w *World) reputationLocked(p *Player, faction Faction) int {
+	if value, ok := p.Reputation[faction.ID]; ok {
+		return value
+	}
+	return faction.Start
+}
+
+// adjustReputationLocked shifts p's reputation with the faction id.
+func (w *World) adjustReputationLocked(p *Player, id string, delta int) (ReputationChange, bool) {
+	faction, ok := w.factions[factionKey(id)]
+	if !ok || delta == 0 {
+		return ReputationChange{}, false
+	}
+	before := w.reputationLocked(p, faction)
+	after := clampReputation(before + delta)
+	if after == before {
+		return ReputationChange{}, false
+	}
+	if p.Reputation == nil {
+		p.Reputation = make(map[string]int)
+	}
+	p.Reputation[faction.ID] = after
+	return ReputationChange{Faction: faction.Name, Delta: after - before, Total: after, Standing: StandingFor(after)}, true
+}
+
+// npcStandingLocked reports p's standing with the faction npc belongs to.
+func (w *World) npcStandingLocked(p *Player, npc NPC) (Standing, bool) {
+	faction, ok := w.factions[factionKey(npc.Faction)]
+	if !ok {
+		return "", false
+	}
+	return StandingFor(w.reputationLocked(p, faction)), true
+}
+
+// NPCStanding reports p's standing with the faction npc belongs to.
+func (w *World) NPCStanding(p *Player, npc NPC) (Standing, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.npcStandingLocked(p, npc)
+}
+
+// RecordFactionKill adjusts p's reputation for slaying npc: its own faction
+// thinks less of p and the factions that count it a rival think more.
+func (w *World) RecordFactionKill(p *Player, npc NPC) []ReputationChange {
+	w.mu.Lock()
+	faction, ok := w.factions[factionKey(npc.Faction)]
+	if !ok {
+		w.mu.Unlock()
+		return nil
+	}
+	var changes []ReputationChange
+	if change, ok := w.adjustReputationLocked(p, faction.ID, -faction.KillPenalty); ok {
+		changes = append(changes, change)
+	}
+	ids := make([]string, 0, len(w.factions))
+	for id := range w.factions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		other := w.factions[id]
+		if !slices.Contains(other.Rivals, faction.ID) {
+			continue
+		}
+		if change, ok := w.adjustReputationLocked(p, other.ID, other.RivalBonus); ok {
+			changes = append(changes, change)
+		}
+	}
+	w.mu.Unlock()
+	if len(changes) > 0 {
+		w.PersistPlayer(p)
+	}
+	return changes
+}
+
+// AdjustReputation shifts p's reputation with a faction and saves it.
+func (w *World) AdjustReputation(p *Player, id string, delta int) (ReputationChange, error) {
+	w.mu.Lock()
+	if _, ok := w.factions[factionKey(id)]; !ok {
+		w.mu.Unlock()
+		return ReputationChange{}, fmt.Errorf("no faction is called %s", strings.TrimSpace(id))
+	}
+	change, _ := w.adjustReputationLocked(p, id, delta)
+	w.mu.Unlock()
+	w.PersistPlayer(p)
+	return change, nil
+}
+
+// Reputations lists p's reputation with every faction, by name.
+func (w *World) Reputations(p *Player) []FactionReputation {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	out := make([]FactionReputation, 0, len(w.factions))
+	for _, faction := range w.factions {
+		value := w.reputationLocked(p, faction)
+		out = append(out, FactionReputation{Faction: faction, Reputation: value, Standing: StandingFor(value)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Faction.Name < out[j].Faction.Name })
+	return out
+}
+
+// ProvokeFactions sets the NPCs in p's room whose faction is hostile to p
+// upon them.
+func (w *World) ProvokeFactions(p *Player) {
+	w.mu.RLock()
+	room, ok := w.rooms[p.Room]
+	if !ok || !p.Alive || room.Peaceful {
+		w.mu.RUnlock()
+		return
+	}
+	var hostile []string
+	for _, npc := range room.NPCs {
+		if standing, ok := w.npcStandingLocked(p, npc); ok && standing.Hostile() {
+			hostile = append(hostile, npc.Name)
+		}
+	}
+	w.mu.RUnlock()
+	w.setNPCsUpon(p, hostile, "%s recognises you and attacks!", "%s recognises %s and attacks!")
+}
+
+// FormatReputationChanges renders reputation shifts for the player.
+func FormatReputationChanges(changes []ReputationChange) []string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		direction := "rises"
+		if change.Delta < 0 {
+			direction = "falls"
+		}
+		lines = append(lines, fmt.Sprintf("Your reputation with %s %s to %d (%s).", Style(change.Faction, AnsiCyan), direction, change.Total, change.Standing))
+	}
+	return lines
+}
+
+// ReloadFactions re-reads factions.json.
+func (w *World) ReloadFactions() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	factions, err := loadFactions(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	w.factions = factions
+	w.mu.Unlock()
+	return len(factions), nil
+}
+
+// AddFactionForTest registers a faction definition.
+func (w *World) AddFactionForTest(faction Faction) error {
+	if err := normalizeFaction(&faction); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.factions == nil {
+		w.factions = make(map[string]Faction)
+	}
+	w.factions[faction.ID] = faction
+	return nil
+}
+
+func addFactionHelpers(payload map[string]any, w *World, p *Player) {
+	if w == nil || p == nil {
+		return
+	}
+	payload["reputation"] = func(faction string) int {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+		f, ok := w.factions[factionKey(faction)]
+		if !ok {
+			return 0
+		}
+		return w.reputationLocked(p, f)
+	}
+	payload["standing"] = func(faction string) string {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+		f, ok := w.factions[factionKey(faction)]
+		if !ok {
+			return ""
+		}
+		return string(StandingFor(w.reputationLocked(p, f)))
+	}
+}
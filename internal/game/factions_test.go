@@ -0,0 +1,239 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShippedFactionsLoad(t *testing.T) {
+	factions, err := loadFactions("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadFactions: %v", err)
+	}
+	if len(factions) == 0 {
+		t.Fatalf("expected shipped factions")
+	}
+	rooms, _, _, err := loadRooms("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadRooms: %v", err)
+	}
+	for id, room := range rooms {
+		for _, npc := range room.NPCs {
+			if npc.Faction == "" {
+				continue
+			}
+			if _, ok := factions[factionKey(npc.Faction)]; !ok {
+				t.Fatalf("%s in %s belongs to unknown faction %q", npc.Name, id, npc.Faction)
+			}
+		}
+	}
+}
+
+func TestFactionKillAdjustsReputation(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Hero", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"e": {To: "stall"}, "w": {To: "den"}}},
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{"w": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Trader", Faction: "guild", AutoGreet: "Welcome, friend.",
+				Shop: []Item{{Name: "Lantern", Price: 100}, {Name: "Rope"}}}}},
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{"e": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Cutthroat", Faction: "thieves", Level: 20}}},
+	})
+	world.AttachAccountManager(accounts)
+	if err := world.AddFactionForTest(Faction{ID: "guild", Name: "Guild", Rivals: []string{"thieves"}}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	if err := world.AddFactionForTest(Faction{ID: "thieves", Name: "Thieves", Start: -300, KillPenalty: 5}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	hero, err := world.addPlayer("Hero", nil, false, accounts.Profile("Hero"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	hero.Output = NewOutputQueue()
+	changes := world.RecordFactionKill(hero, NPC{Name: "Cutthroat", Faction: "thieves"})
+	if len(changes) != 2 {
+		t.Fatalf("expected two reputation changes, got %+v", changes)
+	}
+	if changes[0].Faction != "Thieves" || changes[0].Delta != -5 || changes[0].Total != -305 {
+		t.Fatalf("unexpected penalty %+v", changes[0])
+	}
+	if changes[1].Faction != "Guild" || changes[1].Delta != DefaultRivalBonus || changes[1].Standing != StandingNeutral {
+		t.Fatalf("unexpected rival bonus %+v", changes[1])
+	}
+	saved := accounts.Profile("Hero").Reputation
+	if saved["thieves"] != -305 || saved["guild"] != DefaultRivalBonus {
+		t.Fatalf("reputation was not saved: %+v", saved)
+	}
+	if changes := world.RecordFactionKill(hero, NPC{Name: "Rat"}); len(changes) != 0 {
+		t.Fatalf("unaffiliated kills should not change reputation, got %+v", changes)
+	}
+	change, err := world.AdjustReputation(hero, "guild", -50)
+	if err != nil {
+		t.Fatalf("AdjustReputation: %v", err)
+	}
+	lines := stripAnsi(strings.Join(FormatReputationChanges([]ReputationChange{change}), ""))
+	if lines != "Your reputation with Guild falls to -40 (Unfriendly)." {
+		t.Fatalf("unexpected formatted change %q", lines)
+	}
+	if _, err := world.AdjustReputation(hero, "pirates", 10); err == nil {
+		t.Fatalf("expected an unknown faction to be rejected")
+	}
+}
+
+func TestQuestRewardsReputation(t *testing.T) {
+	quest := &Quest{ID: "favour", Name: "A Favour", Giver: "Guide", Reputation: map[string]int{"guild": 250}}
+	normalizeQuest(quest)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", NPCs: []NPC{{Name: "Guide"}}},
+	})
+	world.quests = map[string]*Quest{"favour": quest}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	player := &Player{Name: "Hero", Room: "start", Alive: true}
+	world.AddPlayerForTest(player)
+	if err := world.AddFactionForTest(Faction{ID: "guild", Name: "Guild"}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	if _, err := world.AcceptQuest(player, "favour"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	result, err := world.CompleteQuest(player, "favour")
+	if err != nil {
+		t.Fatalf("CompleteQuest: %v", err)
+	}
+	if len(result.Reputation) != 1 || result.Reputation[0].Total != 250 || result.Reputation[0].Standing != StandingFriendly {
+		t.Fatalf("unexpected quest reputation %+v", result.Reputation)
+	}
+}
+
+func TestShopPricesFollowStanding(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Hero", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"e": {To: "stall"}, "w": {To: "den"}}},
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{"w": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Trader", Faction: "guild", AutoGreet: "Welcome, friend.",
+				Shop: []Item{{Name: "Lantern", Price: 100}, {Name: "Rope"}}}}},
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{"e": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Cutthroat", Faction: "thieves", Level: 20}}},
+	})
+	world.AttachAccountManager(accounts)
+	if err := world.AddFactionForTest(Faction{ID: "guild", Name: "Guild", Rivals: []string{"thieves"}}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	if err := world.AddFactionForTest(Faction{ID: "thieves", Name: "Thieves", Start: -300, KillPenalty: 5}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	hero, err := world.addPlayer("Hero", nil, false, accounts.Profile("Hero"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	hero.Output = NewOutputQueue()
+	hero.Room = "stall"
+	hero.Gold = 200
+
+	listing, err := world.ShopWares(hero)
+	if err != nil {
+		t.Fatalf("ShopWares: %v", err)
+	}
+	if listing.Keeper != "Trader" || len(listing.Wares) != 2 || listing.Wares[0].Price != 100 || listing.Wares[1].Price != DefaultShopPrice {
+		t.Fatalf("unexpected neutral listing %+v", listing)
+	}
+
+	if _, err := world.AdjustReputation(hero, "guild", 250); err != nil {
+		t.Fatalf("AdjustReputation: %v", err)
+	}
+	item, price, err := world.BuyFromShop(hero, "lantern")
+	if err != nil {
+		t.Fatalf("BuyFromShop: %v", err)
+	}
+	if item.Name != "Lantern" || price != 90 || hero.Gold != 110 {
+		t.Fatalf("bought %s for %d, gold left %d", item.Name, price, hero.Gold)
+	}
+	if _, _, err := world.BuyFromShop(hero, "anvil"); !errors.Is(err, ErrNotForSale) {
+		t.Fatalf("expected ErrNotForSale, got %v", err)
+	}
+	hero.Gold = 0
+	if _, _, err := world.BuyFromShop(hero, "rope"); !errors.Is(err, ErrNotEnoughGold) {
+		t.Fatalf("expected ErrNotEnoughGold, got %v", err)
+	}
+
+	if _, err := world.AdjustReputation(hero, "guild", -600); err != nil {
+		t.Fatalf("AdjustReputation: %v", err)
+	}
+	if _, err := world.ShopWares(hero); err == nil || !strings.Contains(err.Error(), "Trader refuses to trade with you") {
+		t.Fatalf("expected a hostile keeper to refuse, got %v", err)
+	}
+	hero.Room = StartRoom
+	if _, err := world.ShopWares(hero); !errors.Is(err, ErrNoShop) {
+		t.Fatalf("expected ErrNoShop, got %v", err)
+	}
+}
+
+func TestHostileFactionsAttackAndShunPlayers(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Hero", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Exits: map[string]Exit{"e": {To: "stall"}, "w": {To: "den"}}},
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{"w": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Trader", Faction: "guild", AutoGreet: "Welcome, friend.",
+				Shop: []Item{{Name: "Lantern", Price: 100}, {Name: "Rope"}}}}},
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{"e": {To: StartRoom}},
+			NPCs: []NPC{{Name: "Cutthroat", Faction: "thieves", Level: 20}}},
+	})
+	world.AttachAccountManager(accounts)
+	if err := world.AddFactionForTest(Faction{ID: "guild", Name: "Guild", Rivals: []string{"thieves"}}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	if err := world.AddFactionForTest(Faction{ID: "thieves", Name: "Thieves", Start: -300, KillPenalty: 5}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	hero, err := world.addPlayer("Hero", nil, false, accounts.Profile("Hero"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	hero.Output = NewOutputQueue()
+	if err := world.MoveToRoom(hero, "den"); err != nil {
+		t.Fatalf("MoveToRoom: %v", err)
+	}
+	EnterRoom(world, hero, "w")
+	combat := world.combatIn("den")
+	if combat == nil || !combat.hasNPC("Cutthroat") {
+		t.Fatalf("a hostile faction should attack on sight")
+	}
+	combat.stopLoop()
+	if output := stripAnsi(strings.Join(drainOutput(hero.Output), "")); !strings.Contains(output, "Cutthroat recognises you and attacks!") {
+		t.Fatalf("expected the attack to be announced, got %q", output)
+	}
+
+	if _, err := world.AdjustReputation(hero, "guild", -100); err != nil {
+		t.Fatalf("AdjustReputation: %v", err)
+	}
+	hero.Room = "stall"
+	EnterRoom(world, hero, "e")
+	output := stripAnsi(strings.Join(drainOutput(hero.Output), ""))
+	if !strings.Contains(output, "Trader eyes you coldly and says nothing.") || strings.Contains(output, "Welcome, friend.") {
+		t.Fatalf("an unfriendly NPC should not greet, got %q", output)
+	}
+	if combat := world.combatIn("stall"); combat != nil && combat.hasNPC("Trader") {
+		t.Fatalf("an unfriendly NPC should not attack")
+	}
+}
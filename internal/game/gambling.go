@@ -0,0 +1,221 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultHouseEdge is the percent of a winning bet's payout the house
+	// keeps.
+	DefaultHouseEdge = 5
+	// MaxHouseEdge caps the configurable house edge.
+	MaxHouseEdge = 50
+	// MinBet and MaxBet bound a single wager.
+	MinBet = 5
+	MaxBet = 500
+	// GambleCooldown is how long a player waits between bets.
+	GambleCooldown = 3 * time.Second
+)
+
+// ErrNoDealer indicates there is no dealer in the player's room.
+var ErrNoDealer = errors.New("there is no dealer here")
+
+// GambleGame names a game of chance a dealer runs.
+type GambleGame string
+
+const (
+	// GameDice pits two dice against the dealer's two.
+	GameDice GambleGame = "dice"
+	// GameHighLow has the player call whether the next card beats the
+	// dealer's.
+	GameHighLow GambleGame = "highlow"
+	// GameDraw deals one card each; the higher card wins.
+	GameDraw GambleGame = "draw"
+)
+
+// GambleGames lists the games dealers run.
+func GambleGames() []GambleGame {
+	return []GambleGame{GameDice, GameHighLow, GameDraw}
+}
+
+// GambleOutcome is how a bet was settled.
+type GambleOutcome string
+
+const (
+	GambleWin  GambleOutcome = "win"
+	GambleLose GambleOutcome = "lose"
+	GamblePush GambleOutcome = "push"
+)
+
+// GambleResult describes one settled bet. House is what the dealer rolled or
+// turned up and Player is the player's roll or card; in high-low, Player is
+// the card turned after the dealer's. Net is the change to the player's gold.
+type GambleResult struct {
+	Game    GambleGame
+	Dealer  string
+	Bet     int
+	Call    string
+	House   string
+	Player  string
+	Outcome GambleOutcome
+	Net     int
+}
+
+var (
+	cardRanks = []string{"Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"}
+	cardSuits = []string{"Moons", "Suns", "Stars", "Tides"}
+)
+
+// cardName describes a card numbered 0-51 from a four-suit deck.
+func cardName(card int) string {
+	return fmt.Sprintf("the %s of %s", cardRanks[card%len(cardRanks)], cardSuits[card/len(cardRanks)])
+}
+
+// drawTwoCards deals two different cards from a fresh deck.
+func drawTwoCards(dice Dice) (int, int) {
+	first := dice.IntN(52)
+	second := dice.IntN(51)
+	if second >= first {
+		second++
+	}
+	return first, second
+}
+
+// rollTwoDice rolls 2d6, describing the dice and returning their total.
+func rollTwoDice(dice Dice) (string, int) {
+	a, b := dice.IntN(6)+1, dice.IntN(6)+1
+	return fmt.Sprintf("%d and %d", a, b), a + b
+}
+
+// settle compares the player's score with the house's; ties push.
+func settle(player, house int) GambleOutcome {
+	switch {
+	case player > house:
+		return GambleWin
+	case player < house:
+		return GambleLose
+	}
+	return GamblePush
+}
+
+// winnings is what a winning bet earns beyond the stake once the house takes
+// its edge.
+func winnings(bet, edge int) int {
+	return bet * (100 - edge) / 100
+}
+
+// dealerInRoomLocked returns the first dealer NPC standing in the room.
+func (w *World) dealerInRoomLocked(room RoomID) (NPC, bool) {
+	r, ok := w.rooms[room]
+	if !ok {
+		return NPC{}, false
+	}
+	for _, npc := range r.NPCs {
+		if npc.Dealer {
+			return npc, true
+		}
+	}
+	return NPC{}, false
+}
+
+// DealerHere returns the dealer NPC in the player's tavern, if any.
+func (w *World) DealerHere(p *Player) (NPC, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if r, ok := w.rooms[p.Room]; !ok || !r.Tavern {
+		return NPC{}, false
+	}
+	return w.dealerInRoomLocked(p.Room)
+}
+
+// Gamble plays one round of game against the dealer in p's tavern for bet
+// gold. High-low needs a call of "high" or "low". The result is saved with
+// the player and recorded in the moderation log for staff to review.
+func (w *World) Gamble(p *Player, game GambleGame, bet int, call string, now time.Time) (GambleResult, error) {
+	switch game {
+	case GameDice, GameDraw:
+	case GameHighLow:
+		if call != "high" && call != "low" {
+			return GambleResult{}, fmt.Errorf("call high or low")
+		}
+	default:
+		return GambleResult{}, fmt.Errorf("unknown game %q", game)
+	}
+	if bet < MinBet || bet > MaxBet {
+		return GambleResult{}, fmt.Errorf("bets run from %d to %d gold", MinBet, MaxBet)
+	}
+	w.mu.Lock()
+	room, ok := w.rooms[p.Room]
+	if !ok || !room.Tavern {
+		w.mu.Unlock()
+		return GambleResult{}, fmt.Errorf("you can only gamble in a tavern")
+	}
+	dealer, ok := w.dealerInRoomLocked(p.Room)
+	if !ok {
+		w.mu.Unlock()
+		return GambleResult{}, ErrNoDealer
+	}
+	if now.Before(p.lastGamble.Add(GambleCooldown)) {
+		w.mu.Unlock()
+		return GambleResult{}, fmt.Errorf("%s is still settling your last bet", dealer.Name)
+	}
+	if p.Gold < bet {
+		w.mu.Unlock()
+		return GambleResult{}, ErrInsufficientGold
+	}
+	result := GambleResult{Game: game, Dealer: dealer.Name, Bet: bet, Call: call}
+	dice := DiceFunc(w.roll)
+	switch game {
+	case GameDice:
+		var house, mine int
+		result.House, house = rollTwoDice(dice)
+		result.Player, mine = rollTwoDice(dice)
+		result.Outcome = settle(mine, house)
+	case GameDraw:
+		house, mine := drawTwoCards(dice)
+		result.House, result.Player = cardName(house), cardName(mine)
+		result.Outcome = settle(mine%13, house%13)
+	case GameHighLow:
+		shown, next := drawTwoCards(dice)
+		result.House, result.Player = cardName(shown), cardName(next)
+		result.Outcome = settle(next%13, shown%13)
+		if call == "low" && result.Outcome != GamblePush {
+			result.Outcome = settle(shown%13, next%13)
+		}
+	}
+	switch result.Outcome {
+	case GambleWin:
+		result.Net = winnings(bet, w.tunablesLocked().HouseEdge)
+	case GambleLose:
+		result.Net = -bet
+	}
+	p.Gold += result.Net
+	w.recordGoldLocked(EconomyGambling, result.Net)
+	p.lastGamble = now
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	if err := w.Moderation().Record(ModerationAction{
+		Actor:  p.Name,
+		Action: "gamble",
+		Target: dealer.Name,
+		Detail: fmt.Sprintf("%s: bet %d gold, %s", game, bet, describeGambleNet(result)),
+	}); err != nil {
+		Logger().Error("record gamble failed", "player", p.Name, "dealer", dealer.Name, "error", err)
+	}
+	return result, nil
+}
+
+// describeGambleNet summarizes how a bet changed the player's gold for the
+// moderation log.
+func describeGambleNet(result GambleResult) string {
+	switch result.Outcome {
+	case GambleWin:
+		return fmt.Sprintf("won %d", result.Net)
+	case GambleLose:
+		return fmt.Sprintf("lost %d", -result.Net)
+	}
+	return "push"
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGambleDicePaysLessHouseEdgeAndLogs(t *testing.T) {
+	// Dealer rolls 1 and 2; the player rolls 6 and 6.
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tavern": {ID: "tavern", Title: "Tavern", Tavern: true, Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Dicer", Dealer: true}}},
+	})
+	gambler := &Player{Name: "Gambler", Room: "tavern", Alive: true, Gold: 100, Output: NewOutputQueue()}
+	world.AddPlayerForTest(gambler)
+	world.SetDice(&scriptedDice{0, 1, 5, 5})
+	result, err := world.Gamble(gambler, GameDice, 20, "", time.Now())
+	if err != nil {
+		t.Fatalf("Gamble: %v", err)
+	}
+	if result.Outcome != GambleWin || result.Net != 19 || gambler.Gold != 119 {
+		t.Fatalf("expected a 19 gold win at a 5%% edge, got %+v with %d gold", result, gambler.Gold)
+	}
+	actions := world.Moderation().Actions(1)
+	if len(actions) != 1 || actions[0].Action != "gamble" || !strings.Contains(actions[0].Detail, "dice: bet 20 gold, won 19") {
+		t.Fatalf("expected the bet in the moderation log, got %+v", actions)
+	}
+}
+
+func TestGambleHighLowAndDrawSettleOnRank(t *testing.T) {
+	// High-low: the Five of Moons, then the Five of Suns (a push); then the
+	// Ten of Moons followed by the Two of Moons.
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tavern": {ID: "tavern", Title: "Tavern", Tavern: true, Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Dicer", Dealer: true}}},
+	})
+	gambler := &Player{Name: "Gambler", Room: "tavern", Alive: true, Gold: 100, Output: NewOutputQueue()}
+	world.AddPlayerForTest(gambler)
+	world.SetDice(&scriptedDice{3, 15, 8, 0})
+	start := time.Now()
+	if result, _ := world.Gamble(gambler, GameHighLow, 10, "high", start); result.Outcome != GamblePush || gambler.Gold != 100 {
+		t.Fatalf("expected equal ranks to push, got %+v", result)
+	}
+	if _, err := world.Gamble(gambler, GameDraw, 10, "", start.Add(time.Second)); err == nil {
+		t.Fatalf("expected the cooldown to refuse a quick bet")
+	}
+	result, err := world.Gamble(gambler, GameHighLow, 10, "low", start.Add(GambleCooldown))
+	if err != nil || result.Outcome != GambleWin || result.House != "the Ten of Moons" || result.Player != "the Two of Moons" {
+		t.Fatalf("expected a correct low call to win, got %+v, %v", result, err)
+	}
+}
+
+func TestGambleRequiresTavernDealerAndStake(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tavern": {ID: "tavern", Title: "Tavern", Tavern: true, Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Dicer", Dealer: true}}},
+		"street": {ID: "street", Title: "Street", Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Busker", Dealer: true}}},
+	})
+	gambler := &Player{Name: "Gambler", Room: "tavern", Alive: true, Gold: 100, Output: NewOutputQueue()}
+	world.AddPlayerForTest(gambler)
+	now := time.Now()
+	if _, err := world.Gamble(gambler, GameHighLow, 10, "", now); err == nil {
+		t.Fatalf("expected high-low to need a call")
+	}
+	if _, err := world.Gamble(gambler, GameDice, MaxBet+1, "", now); err == nil {
+		t.Fatalf("expected an oversized bet to be refused")
+	}
+	gambler.Gold = 5
+	if _, err := world.Gamble(gambler, GameDice, 10, "", now); err != ErrInsufficientGold {
+		t.Fatalf("expected insufficient gold, got %v", err)
+	}
+	gambler.Room = "street"
+	if _, err := world.Gamble(gambler, GameDice, 5, "", now); err == nil || !strings.Contains(err.Error(), "tavern") {
+		t.Fatalf("expected gambling outside a tavern to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,410 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Profession is a gathering trade players improve by practising it.
+type Profession string
+
+const (
+	ProfessionFishing  Profession = "fishing"
+	ProfessionMining   Profession = "mining"
+	ProfessionForaging Profession = "foraging"
+)
+
+// Professions lists the gathering professions in display order.
+func Professions() []Profession {
+	return []Profession{ProfessionFishing, ProfessionMining, ProfessionForaging}
+}
+
+func (p Profession) valid() bool {
+	switch p {
+	case ProfessionFishing, ProfessionMining, ProfessionForaging:
+		return true
+	}
+	return false
+}
+
+// Verb is the command that practises the profession.
+func (p Profession) Verb() string {
+	switch p {
+	case ProfessionFishing:
+		return "fish"
+	case ProfessionMining:
+		return "mine"
+	}
+	return "forage"
+}
+
+const (
+	// MaxProfessionSkill caps a player's skill in each profession.
+	MaxProfessionSkill = 100
+	// DefaultNodeCharges is how many times a node can be gathered before it
+	// is depleted, when it sets no number of its own.
+	DefaultNodeCharges = 3
+	// DefaultNodeRespawn is how long a depleted node takes to return when it
+	// sets no delay of its own.
+	DefaultNodeRespawn = 5 * time.Minute
+	// gatherTime is how long an attempt to gather takes.
+	gatherTime = 4 * time.Second
+	// gatherSweep is how often the world finishes attempts and returns
+	// depleted nodes.
+	gatherSweep = time.Second
+	// gatherTrivial is how far above a node's skill a player stops learning
+	// anything from it.
+	gatherTrivial = 30
+)
+
+// GatherNode is a spot in a room where players can fish, mine, or forage.
+type GatherNode struct {
+	Profession Profession `json:"profession"`
+	Name       string     `json:"name"`
+	// Skill is the profession skill a player needs to work the node.
+	Skill int `json:"skill,omitempty"`
+	// Charges is how many times the node yields before it is depleted;
+	// DefaultNodeCharges when unset.
+	Charges int `json:"charges,omitempty"`
+	// Respawn is how many seconds a depleted node takes to return;
+	// DefaultNodeRespawn when unset.
+	Respawn int `json:"respawn,omitempty"`
+	// Yields is the table one item is drawn from on each success.
+	Yields []GatherYield `json:"yields"`
+}
+
+// GatherYield is an entry in a node's yield table.
+type GatherYield struct {
+	Item Item `json:"item"`
+	// Weight is the entry's share of the draw; one when unset.
+	Weight int `json:"weight,omitempty"`
+	// Skill is the profession skill needed before the entry can be drawn.
+	Skill int `json:"skill,omitempty"`
+}
+
+func (n GatherNode) charges() int {
+	if n.Charges > 0 {
+		return n.Charges
+	}
+	return DefaultNodeCharges
+}
+
+func (n GatherNode) respawn() time.Duration {
+	if n.Respawn > 0 {
+		return time.Duration(n.Respawn) * time.Second
+	}
+	return DefaultNodeRespawn
+}
+
+// validateGatherNodes checks every room's nodes name a profession and have
+// something to yield.
+func validateGatherNodes(rooms map[RoomID]*Room) error {
+	for id, room := range rooms {
+		for _, node := range room.Gather {
+			if strings.TrimSpace(node.Name) == "" {
+				return fmt.Errorf("room %s: gather node has no name", id)
+			}
+			if !node.Profession.valid() {
+				return fmt.Errorf("room %s: gather node %s: unknown profession %q", id, node.Name, node.Profession)
+			}
+			if len(node.Yields) == 0 {
+				return fmt.Errorf("room %s: gather node %s yields nothing", id, node.Name)
+			}
+			for _, yield := range node.Yields {
+				if strings.TrimSpace(yield.Item.Name) == "" || yield.Weight < 0 {
+					return fmt.Errorf("room %s: gather node %s has a yield with no name or a negative weight", id, node.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// nodeState tracks how much a node has left and, once depleted, when it
+// returns.
+type nodeState struct {
+	used    int
+	returns time.Time
+}
+
+// gatherAttempt is a player's gathering in progress.
+type gatherAttempt struct {
+	room RoomID
+	node string
+	done time.Time
+}
+
+func nodeKey(room RoomID, name string) string {
+	return string(room) + "|" + strings.ToLower(name)
+}
+
+// nodeAvailableLocked reports whether node in room has not been depleted.
+func (w *World) nodeAvailableLocked(room RoomID, node GatherNode) bool {
+	state, ok := w.gatherNodes[nodeKey(room, node.Name)]
+	return !ok || state.returns.IsZero()
+}
+
+// findNodeLocked finds an available node of profession in room, matching
+// name when one is given.
+func (w *World) findNodeLocked(room *Room, profession Profession, name string) (GatherNode, error) {
+	var nodes []GatherNode
+	var names []string
+	for _, node := range room.Gather {
+		if node.Profession == profession && w.nodeAvailableLocked(room.ID, node) {
+			nodes = append(nodes, node)
+			names = append(names, node.Name)
+		}
+	}
+	if len(nodes) == 0 {
+		return GatherNode{}, fmt.Errorf("there is nowhere to %s here", profession.Verb())
+	}
+	if strings.TrimSpace(name) == "" {
+		return nodes[0], nil
+	}
+	idx, ok := uniqueMatch(name, names, true)
+	if !ok {
+		return GatherNode{}, fmt.Errorf("you see no %s to %s here", name, profession.Verb())
+	}
+	return nodes[idx], nil
+}
+
+// StartGathering begins working a node of profession in p's room, the one
+// matching name if given. The attempt finishes after a few seconds unless p
+// leaves the room or falls. It returns the node being worked.
+func (w *World) StartGathering(p *Player, profession Profession, name string, now time.Time) (GatherNode, error) {
+	if w.InCombat(p) {
+		return GatherNode{}, fmt.Errorf("you are too busy fighting")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return GatherNode{}, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	node, err := w.findNodeLocked(room, profession, name)
+	if err != nil {
+		return GatherNode{}, err
+	}
+	if skill := p.Professions[string(profession)]; skill < node.Skill {
+		return GatherNode{}, fmt.Errorf("you need %d %s skill to work %s; you have %d", node.Skill, profession, node.Name, skill)
+	}
+	if w.gathering == nil {
+		w.gathering = make(map[string]gatherAttempt)
+	}
+	w.gathering[p.Name] = gatherAttempt{room: room.ID, node: node.Name, done: now.Add(gatherTime)}
+	return node, nil
+}
+
+// gatherFailChance is the percent chance an attempt comes up empty for a
+// player with skill working a node needing nodeSkill.
+func gatherFailChance(skill, nodeSkill int) int {
+	return min(max(30-(skill-nodeSkill)*2, 5), 60)
+}
+
+// drawYield picks an item from node's yield table among the entries skill
+// has unlocked, drawing from dice.
+func drawYield(dice Dice, node GatherNode, skill int) (Item, bool) {
+	total := 0
+	for _, yield := range node.Yields {
+		if yield.Skill <= skill {
+			total += max(yield.Weight, 1)
+		}
+	}
+	if total == 0 {
+		return Item{}, false
+	}
+	roll := dice.IntN(total)
+	for _, yield := range node.Yields {
+		if yield.Skill > skill {
+			continue
+		}
+		roll -= max(yield.Weight, 1)
+		if roll < 0 {
+			item := yield.Item
+			item.Contents = cloneItems(item.Contents)
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// gatherOutcome is a finished attempt to report.
+type gatherOutcome struct {
+	player     *Player
+	profession Profession
+	node       string
+	item       Item
+	got        bool
+	dropped    bool
+	skill      int
+	learned    bool
+	depleted   bool
+	gone       bool
+}
+
+// GatherTick finishes every gathering attempt that has run its time, handing
+// out yields, raising skills, and depleting nodes, and returns depleted nodes
+// whose time is up.
+func (w *World) GatherTick(now time.Time) {
+	type saved struct {
+		key     string
+		profile PlayerProfile
+	}
+	type returned struct {
+		room RoomID
+		node string
+	}
+	var outcomes []gatherOutcome
+	var saves []saved
+	var returns []returned
+	w.mu.Lock()
+	for key, state := range w.gatherNodes {
+		if state.returns.IsZero() || now.Before(state.returns) {
+			continue
+		}
+		delete(w.gatherNodes, key)
+		room, name, _ := strings.Cut(key, "|")
+		if r, ok := w.rooms[RoomID(room)]; ok {
+			for _, node := range r.Gather {
+				if strings.EqualFold(node.Name, name) {
+					returns = append(returns, returned{room: r.ID, node: node.Name})
+				}
+			}
+		}
+	}
+	for name, attempt := range w.gathering {
+		if now.Before(attempt.done) {
+			continue
+		}
+		delete(w.gathering, name)
+		p, ok := w.players[name]
+		if !ok || !p.Alive || p.Room != attempt.room {
+			continue
+		}
+		room := w.rooms[attempt.room]
+		var node GatherNode
+		found := false
+		for _, candidate := range room.Gather {
+			if strings.EqualFold(candidate.Name, attempt.node) {
+				node, found = candidate, true
+			}
+		}
+		outcome := gatherOutcome{player: p, profession: node.Profession, node: attempt.node}
+		if !found || !w.nodeAvailableLocked(room.ID, node) {
+			outcome.gone = true
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+		skill := p.Professions[string(node.Profession)]
+		if w.roll(100) >= gatherFailChance(skill, node.Skill) {
+			outcome.item, outcome.got = drawYield(DiceFunc(w.roll), node, skill)
+		}
+		if outcome.got {
+			if canCarryLocked(p, outcome.item.TotalWeight()) {
+				p.Inventory = append(p.Inventory, outcome.item)
+			} else {
+				room.Items = append(room.Items, outcome.item)
+				outcome.dropped = true
+			}
+			if skill < MaxProfessionSkill && skill < node.Skill+gatherTrivial {
+				if p.Professions == nil {
+					p.Professions = make(map[string]int)
+				}
+				p.Professions[string(node.Profession)] = skill + 1
+				outcome.learned = true
+			}
+			key := nodeKey(room.ID, node.Name)
+			state := w.gatherNodes[key]
+			state.used++
+			if state.used >= node.charges() {
+				state.returns = now.Add(node.respawn())
+				outcome.depleted = true
+			}
+			if w.gatherNodes == nil {
+				w.gatherNodes = make(map[string]nodeState)
+			}
+			w.gatherNodes[key] = state
+			k, snapshot := profileSnapshot(p)
+			saves = append(saves, saved{key: k, profile: snapshot})
+		}
+		outcome.skill = p.Professions[string(node.Profession)]
+		outcomes = append(outcomes, outcome)
+	}
+	w.mu.Unlock()
+	for _, s := range saves {
+		w.persistPlayerState(s.key, s.profile)
+	}
+	for _, r := range returns {
+		w.BroadcastToRoom(r.room, Ansi(fmt.Sprintf("\r\n%s is ready to be worked again.", HighlightItemName(r.node))), nil)
+	}
+	for _, outcome := range outcomes {
+		w.reportGather(outcome)
+	}
+}
+
+func (w *World) reportGather(o gatherOutcome) {
+	p := o.player
+	node := HighlightItemName(o.node)
+	switch {
+	case o.gone:
+		p.Output.Send(Ansi(Style(fmt.Sprintf("\r\n%s has nothing left to give.", node), AnsiYellow)))
+		return
+	case !o.got:
+		p.Output.Send(Ansi(fmt.Sprintf("\r\nYou work %s but come away empty-handed.", node)))
+		return
+	}
+	p.Output.Send(Ansi(fmt.Sprintf("\r\nYou %s %s from %s.", o.profession.Verb(), HighlightItemName(o.item.Name), node)))
+	if o.dropped {
+		p.Output.Send(Ansi(Style("\r\nYou cannot carry it, so you set it on the ground.", AnsiYellow)))
+	}
+	w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s gathers %s from %s.", HighlightName(p.Name), HighlightItemName(o.item.Name), node)), p)
+	if o.learned {
+		p.Output.Send(Ansi(Style(fmt.Sprintf("\r\nYour %s skill rises to %d.", o.profession, o.skill), AnsiGreen)))
+	}
+	if o.depleted {
+		w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s is exhausted for now.", node)), nil)
+	}
+}
+
+// StartGatherLoop finishes gathering attempts and returns depleted nodes
+// until stop is closed.
+func (w *World) StartGatherLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(gatherSweep)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.GatherTick(now)
+			}
+		}
+	}()
+}
+
+// gatherNoteLocked lists the nodes in room that can be worked now.
+func (w *World) gatherNoteLocked(room *Room) string {
+	var parts []string
+	for _, node := range room.Gather {
+		if w.nodeAvailableLocked(room.ID, node) {
+			parts = append(parts, fmt.Sprintf("%s (%s)", HighlightItemName(node.Name), node.Profession.Verb()))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "You could gather here: " + strings.Join(parts, ", ") + "."
+}
+
+// ProfessionSkills lists p's skill in each profession.
+func (w *World) ProfessionSkills(p *Player) map[Profession]int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	skills := make(map[Profession]int, len(Professions()))
+	for _, profession := range Professions() {
+		skills[profession] = p.Professions[string(profession)]
+	}
+	return skills
+}
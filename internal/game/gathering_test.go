@@ -0,0 +1,96 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGatheringYieldsTrainsSkillAndDepletesNode(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pier": {ID: "pier", Title: "Pier", Exits: map[string]Exit{"n": {To: "shore"}}, Gather: []GatherNode{{
+			Profession: ProfessionFishing, Name: "Silverfin Shoal", Charges: 2, Respawn: 60,
+			Yields: []GatherYield{{Item: Item{Name: "Silverfin"}, Weight: 9}, {Item: Item{Name: "Moon Pearl"}, Skill: 5}},
+		}}},
+		"shore": {ID: "shore", Title: "Shore", Exits: map[string]Exit{}},
+	})
+	angler := &Player{Name: "Angler", Room: "pier", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(angler)
+	angler.EnsureStats()
+	world.SetDice(DiceFunc(func(n int) int { return n - 1 }))
+	start := time.Now()
+	if _, err := world.StartGathering(angler, ProfessionMining, "", start); err == nil {
+		t.Fatalf("expected no mining on a pier")
+	}
+	if _, err := world.StartGathering(angler, ProfessionFishing, "", start); err != nil {
+		t.Fatalf("StartGathering: %v", err)
+	}
+	world.GatherTick(start.Add(time.Second))
+	if len(angler.Inventory) != 0 {
+		t.Fatalf("the catch should wait for the attempt to finish")
+	}
+	world.GatherTick(start.Add(gatherTime))
+	if len(angler.Inventory) != 1 || angler.Inventory[0].Name != "Silverfin" {
+		t.Fatalf("expected a silverfin, got %+v", angler.Inventory)
+	}
+	if skill := world.ProfessionSkills(angler)[ProfessionFishing]; skill != 1 {
+		t.Fatalf("expected fishing skill 1, got %d", skill)
+	}
+
+	world.StartGathering(angler, ProfessionFishing, "shoal", start)
+	world.GatherTick(start.Add(gatherTime))
+	output := stripAnsi(strings.Join(drainOutput(angler.Output), ""))
+	if !strings.Contains(output, "Silverfin Shoal is exhausted") {
+		t.Fatalf("expected the shoal to be depleted, got %q", output)
+	}
+	if _, err := world.StartGathering(angler, ProfessionFishing, "", start); err == nil {
+		t.Fatalf("expected a depleted node to refuse gathering")
+	}
+	if notes := world.RoomNotes(angler); len(notes) != 0 {
+		t.Fatalf("depleted nodes should not be listed, got %v", notes)
+	}
+
+	world.GatherTick(start.Add(gatherTime + 61*time.Second))
+	if _, err := world.StartGathering(angler, ProfessionFishing, "", start); err != nil {
+		t.Fatalf("expected the shoal to return: %v", err)
+	}
+}
+
+func TestGatheringUnlocksRareYieldsAndCancelsOnLeaving(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pier": {ID: "pier", Title: "Pier", Exits: map[string]Exit{"n": {To: "shore"}}, Gather: []GatherNode{{
+			Profession: ProfessionFishing, Name: "Silverfin Shoal", Charges: 2, Respawn: 60,
+			Yields: []GatherYield{{Item: Item{Name: "Silverfin"}, Weight: 9}, {Item: Item{Name: "Moon Pearl"}, Skill: 5}},
+		}}},
+		"shore": {ID: "shore", Title: "Shore", Exits: map[string]Exit{}},
+	})
+	angler := &Player{Name: "Angler", Room: "pier", Alive: true, Output: NewOutputQueue(),
+		Professions: map[string]int{string(ProfessionFishing): 5}}
+	world.AddPlayerForTest(angler)
+	angler.EnsureStats()
+	world.SetDice(DiceFunc(func(n int) int { return n - 1 }))
+	start := time.Now()
+	world.StartGathering(angler, ProfessionFishing, "", start)
+	world.GatherTick(start.Add(gatherTime))
+	if len(angler.Inventory) != 1 || angler.Inventory[0].Name != "Moon Pearl" {
+		t.Fatalf("expected skill to unlock the pearl, got %+v", angler.Inventory)
+	}
+
+	world.StartGathering(angler, ProfessionFishing, "", start)
+	world.mu.Lock()
+	angler.Room = "shore"
+	world.mu.Unlock()
+	world.GatherTick(start.Add(gatherTime))
+	if len(angler.Inventory) != 1 {
+		t.Fatalf("walking away should abandon the attempt, got %+v", angler.Inventory)
+	}
+}
+
+func TestGatheringFailsMoreOftenOnHarderNodes(t *testing.T) {
+	if easy, hard := gatherFailChance(20, 0), gatherFailChance(0, 20); easy != 5 || hard != 60 {
+		t.Fatalf("fail chances = %d and %d, want 5 and 60", easy, hard)
+	}
+	if err := validateGatherNodes(map[RoomID]*Room{"x": {ID: "x", Gather: []GatherNode{{Profession: "knitting", Name: "Yarn", Yields: []GatherYield{{Item: Item{Name: "Wool"}}}}}}}); err == nil {
+		t.Fatalf("expected an unknown profession to be rejected")
+	}
+}
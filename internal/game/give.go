@@ -0,0 +1,100 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNPCNotInterested indicates the NPC has no use for the offered item.
+var ErrNPCNotInterested = errors.New("they have no use for that")
+
+// GiveResult describes an item handed to an NPC.
+type GiveResult struct {
+	Item    Item
+	NPC     NPC
+	Updates []QuestProgressUpdate
+	// QuestItem reports whether an active quest asked for the delivery.
+	QuestItem bool
+}
+
+// GiveItemToNPC hands an item from p's inventory to an NPC in the same room.
+// NPCs accept items an active quest asks p to deliver to them, and anything
+// at all when their script defines OnReceive.
+func (w *World) GiveItemToNPC(p *Player, itemName, npcName string) (*GiveResult, error) {
+	itemName = strings.TrimSpace(itemName)
+	npcName = strings.TrimSpace(npcName)
+	w.mu.Lock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		w.mu.Unlock()
+		return nil, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	npcIdx := findNPCIndex(room.NPCs, npcName)
+	if npcIdx == -1 {
+		w.mu.Unlock()
+		return nil, fmt.Errorf("you don't see %s here", npcName)
+	}
+	itemIdx := findItemIndex(p.Inventory, itemName)
+	if itemIdx == -1 {
+		w.mu.Unlock()
+		return nil, ErrItemNotCarried
+	}
+	item := p.Inventory[itemIdx]
+	npc := room.NPCs[npcIdx]
+	update, wanted := w.recordDeliveryLocked(p, item.BaseName(), npc.Name, time.Now())
+	if !wanted && !w.scripts.receives(npc) {
+		w.mu.Unlock()
+		return nil, ErrNPCNotInterested
+	}
+	p.Inventory = append(p.Inventory[:itemIdx], p.Inventory[itemIdx+1:]...)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	result := &GiveResult{Item: item, NPC: npc, QuestItem: wanted}
+	if wanted {
+		result.Updates = []QuestProgressUpdate{update}
+	}
+	return result, nil
+}
+
+// TriggerNPCReceive runs the OnReceive hook of an NPC p just gave an item.
+func (w *World) TriggerNPCReceive(p *Player, result *GiveResult) {
+	if w == nil || w.scripts == nil || result == nil {
+		return
+	}
+	w.scripts.callNPCOnReceive(w, result.NPC, p, result.Item, result.QuestItem)
+}
+
+// GiveItemToPlayer hands an item from p's inventory to another player in the
+// same room, provided they can carry it.
+func (w *World) GiveItemToPlayer(p, target *Player, itemName string) (Item, error) {
+	itemName = strings.TrimSpace(itemName)
+	if target == p {
+		return Item{}, fmt.Errorf("you already have it")
+	}
+	w.mu.Lock()
+	if stored, ok := w.players[target.Name]; !ok || stored != target || !target.Alive || target.Room != p.Room {
+		w.mu.Unlock()
+		return Item{}, fmt.Errorf("%s is not here", target.Name)
+	}
+	itemIdx := findItemIndex(p.Inventory, itemName)
+	if itemIdx == -1 {
+		w.mu.Unlock()
+		return Item{}, ErrItemNotCarried
+	}
+	item := p.Inventory[itemIdx]
+	if !canCarryLocked(target, item.TotalWeight()) {
+		w.mu.Unlock()
+		return Item{}, fmt.Errorf("%s can't carry that much weight", target.Name)
+	}
+	p.Inventory = append(p.Inventory[:itemIdx], p.Inventory[itemIdx+1:]...)
+	target.Inventory = append(target.Inventory, item)
+	key, snapshot := profileSnapshot(p)
+	targetKey, targetSnapshot := profileSnapshot(target)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	w.persistPlayerState(targetKey, targetSnapshot)
+	return item, nil
+}
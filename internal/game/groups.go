@@ -0,0 +1,145 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotInGroup indicates the player has not joined a group.
+var ErrNotInGroup = errors.New("you are not in a group")
+
+// group is a party of players adventuring together. The leader invites new
+// members; when the leader leaves the longest-standing member takes over.
+type group struct {
+	id      int
+	leader  *Player
+	members []*Player
+	invited map[*Player]bool
+}
+
+// GroupView describes a player's group.
+type GroupView struct {
+	Leader  *Player
+	Members []*Player
+}
+
+func (g *group) view() GroupView {
+	return GroupView{Leader: g.leader, Members: append([]*Player(nil), g.members...)}
+}
+
+// InviteToGroup lets p invite another player to their group, forming one
+// with p as leader when they have none.
+func (w *World) InviteToGroup(p *Player, name string) (*Player, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	target, ok := w.findPlayerLocked(name)
+	if !ok {
+		return nil, fmt.Errorf("%s is not online", strings.TrimSpace(name))
+	}
+	if target == p {
+		return nil, fmt.Errorf("you can't invite yourself")
+	}
+	if target.group != nil {
+		return nil, fmt.Errorf("%s is already in a group", target.Name)
+	}
+	if p.group != nil && p.group.leader != p {
+		return nil, fmt.Errorf("only %s can invite players to your group", p.group.leader.Name)
+	}
+	if p.group == nil {
+		w.nextGroupID++
+		p.group = &group{id: w.nextGroupID, leader: p, members: []*Player{p}, invited: make(map[*Player]bool)}
+	}
+	p.group.invited[target] = true
+	return target, nil
+}
+
+// JoinGroup adds p to the group led by leader, which must have invited them.
+func (w *World) JoinGroup(p *Player, leader string) (GroupView, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if p.group != nil {
+		return GroupView{}, fmt.Errorf("you are already in a group")
+	}
+	other, ok := w.findPlayerLocked(leader)
+	if !ok || other.group == nil || !other.group.invited[p] {
+		return GroupView{}, fmt.Errorf("%s has not invited you to a group", strings.TrimSpace(leader))
+	}
+	g := other.group
+	delete(g.invited, p)
+	g.members = append(g.members, p)
+	p.group = g
+	return g.view(), nil
+}
+
+// LeaveGroup removes p from their group and returns who remains. A group left
+// with a single member disbands.
+func (w *World) LeaveGroup(p *Player) (GroupView, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if p.group == nil {
+		return GroupView{}, ErrNotInGroup
+	}
+	return w.leaveGroupLocked(p), nil
+}
+
+// KickFromGroup lets the leader remove a member.
+func (w *World) KickFromGroup(p *Player, name string) (*Player, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	g := p.group
+	if g == nil {
+		return nil, ErrNotInGroup
+	}
+	if g.leader != p {
+		return nil, fmt.Errorf("only %s can remove members", g.leader.Name)
+	}
+	names := make([]string, len(g.members))
+	for i, member := range g.members {
+		names[i] = member.Name
+	}
+	idx, ok := uniqueMatch(name, names, false)
+	if !ok || g.members[idx] == p {
+		return nil, fmt.Errorf("%s is not in your group", strings.TrimSpace(name))
+	}
+	target := g.members[idx]
+	w.leaveGroupLocked(target)
+	return target, nil
+}
+
+// GroupStatus returns p's group.
+func (w *World) GroupStatus(p *Player) (GroupView, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if p.group == nil {
+		return GroupView{}, ErrNotInGroup
+	}
+	return p.group.view(), nil
+}
+
+// leaveGroupLocked takes p out of their group, handing leadership on and
+// disbanding a group with one member left.
+func (w *World) leaveGroupLocked(p *Player) GroupView {
+	g := p.group
+	if g == nil {
+		return GroupView{}
+	}
+	p.group = nil
+	for i, member := range g.members {
+		if member == p {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	if g.leader == p && len(g.members) > 0 {
+		g.leader = g.members[0]
+	}
+	remaining := g.view()
+	if len(g.members) <= 1 {
+		for _, member := range g.members {
+			member.group = nil
+		}
+		g.members = nil
+	}
+	return remaining
+}
@@ -0,0 +1,247 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// hazardTick is how often players lingering in a hazardous room are tested
+// again.
+const hazardTick = 10 * time.Second
+
+// ErrHazard indicates a hazard turned the player back or overcame them. The
+// player has already been told what happened.
+var ErrHazard = errors.New("the hazard got the better of you")
+
+// Hazard marks a room as dangerous to enter and to stay in.
+type Hazard string
+
+const (
+	// HazardNone is the default for rooms without a hazard.
+	HazardNone Hazard = ""
+	// HazardDeepWater turns back and half-drowns players who cannot swim.
+	HazardDeepWater Hazard = "deep_water"
+	// HazardCliff throws back players who cannot climb it.
+	HazardCliff Hazard = "cliff"
+	// HazardLava lets anyone in but burns those who cannot bear the heat.
+	HazardLava Hazard = "lava"
+)
+
+// hazardRule describes how a hazard tests players. A player passes when
+// safe says so, when their race or class teaches skill, or when a d20 roll
+// plus their attribute modifier meets difficulty. Failing costs damage, and
+// when blocks is set it also keeps the player out.
+type hazardRule struct {
+	skill      string
+	attribute  Attribute
+	difficulty int
+	damage     int
+	blocks     bool
+	safe       func(*Player) bool
+	warning    string
+	enterFail  string
+	stayFail   string
+}
+
+var hazardRules = map[Hazard]hazardRule{
+	HazardDeepWater: {
+		skill:      "swim",
+		attribute:  Strength,
+		difficulty: 12,
+		damage:     6,
+		blocks:     true,
+		safe:       (*Player).canSwim,
+		warning:    "The water here is deep enough to drown in.",
+		enterFail:  "The current drags you under and throws you back, choking.",
+		stayFail:   "You struggle to keep your head above the water.",
+	},
+	HazardCliff: {
+		skill:      "climb",
+		attribute:  Dexterity,
+		difficulty: 12,
+		damage:     10,
+		blocks:     true,
+		safe:       (*Player).canClimb,
+		warning:    "A sheer drop yawns below the narrow handholds.",
+		enterFail:  "You lose your grip and tumble back down the rock face.",
+		stayFail:   "Loose stones give way beneath your feet.",
+	},
+	HazardLava: {
+		attribute:  Constitution,
+		difficulty: 14,
+		damage:     12,
+		safe:       (*Player).canFly,
+		warning:    "Molten rock glows through cracks in the ground.",
+		enterFail:  "Searing heat blisters your skin as you step onto the molten rock.",
+		stayFail:   "The molten rock scorches you.",
+	},
+}
+
+// Hazards lists the hazards builders may set, in display order.
+func Hazards() []Hazard {
+	return []Hazard{HazardDeepWater, HazardCliff, HazardLava}
+}
+
+// ParseHazard accepts a hazard name, or "none" to clear it. Spaces and
+// dashes may stand in for underscores.
+func ParseHazard(name string) (Hazard, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	if name == "none" || name == "off" {
+		return HazardNone, true
+	}
+	for _, hazard := range Hazards() {
+		if name == string(hazard) {
+			return hazard, true
+		}
+	}
+	return "", false
+}
+
+// Label returns the hazard's display name.
+func (h Hazard) Label() string {
+	if h == HazardNone {
+		return "none"
+	}
+	return strings.ReplaceAll(string(h), "_", " ")
+}
+
+// Warning is the line shown under the description of a hazardous room.
+func (h Hazard) Warning() string {
+	return hazardRules[h].warning
+}
+
+func (p *Player) canClimb() bool {
+	if p.canFly() {
+		return true
+	}
+	for _, item := range p.Inventory {
+		if item.Climb {
+			return true
+		}
+	}
+	return false
+}
+
+// hazardOutcome is the result of testing a player against a hazard.
+type hazardOutcome struct {
+	message   string
+	damage    int
+	remaining int
+	defeated  bool
+	death     DeathOutcome
+}
+
+// hasSkillLocked reports whether p's race or class explicitly teaches
+// skill. Unlike KnowsSkill, players without a race or class know nothing.
+func (w *World) hasSkillLocked(p *Player, skill string) bool {
+	if skill == "" {
+		return false
+	}
+	race, _ := w.characterOptions.Race(p.Race)
+	class, _ := w.characterOptions.Class(p.Class)
+	for _, known := range append(append([]string(nil), race.Skills...), class.Skills...) {
+		if known == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// testHazardLocked checks p against the room's hazard and applies the
+// damage of a failure. ok is false when the player failed.
+func (w *World) testHazardLocked(p *Player, room *Room, entering bool) (hazardOutcome, bool) {
+	rule, found := hazardRules[room.Hazard]
+	if !found || (rule.safe != nil && rule.safe(p)) || w.hasSkillLocked(p, rule.skill) {
+		return hazardOutcome{}, true
+	}
+	d20 := w.roll(20) + 1
+	if d20+attributeBonus(p.Attributes().Get(rule.attribute), 1) >= rule.difficulty {
+		return hazardOutcome{}, true
+	}
+	outcome := hazardOutcome{message: rule.stayFail, damage: rule.damage}
+	if entering {
+		outcome.message = rule.enterFail
+	}
+	p.EnsureStats()
+	outcome.damage = min(outcome.damage, p.Health)
+	p.Health -= outcome.damage
+	outcome.remaining = p.Health
+	if p.Health <= 0 {
+		outcome.defeated = true
+		outcome.death = w.applyDeathLocked(p)
+	}
+	return outcome, false
+}
+
+// reportHazard tells p how a hazard hurt them, and brings them round at
+// home if it killed them.
+func (w *World) reportHazard(p *Player, outcome hazardOutcome) {
+	if p.Output != nil {
+		p.Output.Send(Ansi(Style(fmt.Sprintf("\r\n%s (%d damage, %d/%d HP)", outcome.message, outcome.damage, outcome.remaining, p.MaxHealth), AnsiRed)))
+	}
+	if !outcome.defeated {
+		return
+	}
+	if p.Output != nil {
+		p.Output.Send(Ansi("\r\nThe hazard overcomes you and everything goes dark."))
+		notifyDeathOutcome(p, outcome.death)
+		EnterRoom(w, p, "defeat")
+	}
+	w.PersistPlayer(p)
+}
+
+// HazardTick tests every player standing in a hazardous room and hurts
+// those who fail.
+func (w *World) HazardTick() {
+	type hurt struct {
+		player  *Player
+		outcome hazardOutcome
+	}
+	var hurts []hurt
+	w.mu.Lock()
+	for _, p := range w.players {
+		if !p.Alive {
+			continue
+		}
+		room, ok := w.rooms[p.Room]
+		if !ok || room.Hazard == HazardNone {
+			continue
+		}
+		if outcome, ok := w.testHazardLocked(p, room, false); !ok {
+			hurts = append(hurts, hurt{player: p, outcome: outcome})
+		}
+	}
+	w.mu.Unlock()
+	for _, h := range hurts {
+		w.reportHazard(h.player, h.outcome)
+	}
+}
+
+// StartHazardLoop periodically tests players in hazardous rooms until stop
+// is closed.
+func (w *World) StartHazardLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(hazardTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				w.HazardTick()
+			}
+		}
+	}()
+}
+
+// SetRoomHazard changes a room's hazard and saves it with the builder rooms.
+func (w *World) SetRoomHazard(id RoomID, hazard Hazard, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.Hazard
+		room.Hazard = hazard
+		return func() { room.Hazard = prev }
+	})
+}
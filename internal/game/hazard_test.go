@@ -0,0 +1,118 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFailedClimbBlocksAndHurts(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Ledge", Exits: map[string]Exit{
+			"up":   {To: "cliff"},
+			"east": {To: "pool"},
+			"down": {To: "vent"},
+		}},
+		"cliff": {ID: "cliff", Title: "Cliff", Hazard: HazardCliff, Exits: map[string]Exit{"down": {To: StartRoom}}},
+		"pool":  {ID: "pool", Title: "Pool", Hazard: HazardDeepWater, Exits: map[string]Exit{"west": {To: StartRoom}}},
+		"vent":  {ID: "vent", Title: "Vent", Hazard: HazardLava, Exits: map[string]Exit{"up": {To: StartRoom}}},
+	})
+	p := &Player{Name: "Climber", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	p.EnsureStats()
+	world.SetDice(DiceFunc(func(int) int { return 1 })) // a d20 of 2
+	health := p.Health
+	if _, err := world.Move(p, "up"); !errors.Is(err, ErrHazard) {
+		t.Fatalf("expected ErrHazard, got %v", err)
+	}
+	if p.Room != StartRoom || p.Health != health-10 {
+		t.Fatalf("room %s health %d, want %s and %d", p.Room, p.Health, StartRoom, health-10)
+	}
+	output := stripAnsi(strings.Join(drainOutput(p.Output), ""))
+	if !strings.Contains(output, "tumble back down the rock face") {
+		t.Fatalf("expected the fall to be described, got %q", output)
+	}
+
+	p.Inventory = append(p.Inventory, Item{Name: "Climbing Rope", Climb: true})
+	if _, err := world.Move(p, "up"); err != nil || p.Room != "cliff" {
+		t.Fatalf("expected rope to get the climber up, got %v in %s", err, p.Room)
+	}
+}
+
+func TestHazardChecksUseAttributesAndSkills(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Ledge", Exits: map[string]Exit{
+			"up":   {To: "cliff"},
+			"east": {To: "pool"},
+			"down": {To: "vent"},
+		}},
+		"cliff": {ID: "cliff", Title: "Cliff", Hazard: HazardCliff, Exits: map[string]Exit{"down": {To: StartRoom}}},
+		"pool":  {ID: "pool", Title: "Pool", Hazard: HazardDeepWater, Exits: map[string]Exit{"west": {To: StartRoom}}},
+		"vent":  {ID: "vent", Title: "Vent", Hazard: HazardLava, Exits: map[string]Exit{"up": {To: StartRoom}}},
+	})
+	p := &Player{Name: "Climber", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	p.EnsureStats()
+	world.SetDice(DiceFunc(func(int) int { return 11 })) // a d20 of 12
+	if _, err := world.Move(p, "east"); err != nil || p.Room != "pool" {
+		t.Fatalf("a roll meeting the difficulty should pass, got %v in %s", err, p.Room)
+	}
+	p.Room = StartRoom
+
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+	world.characterOptions = CharacterOptions{Races: []Archetype{{ID: "merfolk", Name: "Merfolk", Skills: []string{"swim"}}}}
+	p.Race = "merfolk"
+	if _, err := world.Move(p, "east"); err != nil || p.Room != "pool" {
+		t.Fatalf("a race that swims should pass, got %v in %s", err, p.Room)
+	}
+}
+
+func TestLavaBurnsOnEntryAndEachTick(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Ledge", Exits: map[string]Exit{
+			"up":   {To: "cliff"},
+			"east": {To: "pool"},
+			"down": {To: "vent"},
+		}},
+		"cliff": {ID: "cliff", Title: "Cliff", Hazard: HazardCliff, Exits: map[string]Exit{"down": {To: StartRoom}}},
+		"pool":  {ID: "pool", Title: "Pool", Hazard: HazardDeepWater, Exits: map[string]Exit{"west": {To: StartRoom}}},
+		"vent":  {ID: "vent", Title: "Vent", Hazard: HazardLava, Exits: map[string]Exit{"up": {To: StartRoom}}},
+	})
+	p := &Player{Name: "Climber", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	p.EnsureStats()
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+	health := p.Health
+	if _, err := world.Move(p, "down"); err != nil || p.Room != "vent" {
+		t.Fatalf("lava should not block, got %v in %s", err, p.Room)
+	}
+	if p.Health != health-12 {
+		t.Fatalf("health %d, want %d", p.Health, health-12)
+	}
+	drainOutput(p.Output)
+
+	world.HazardTick()
+	if p.Health != health-24 {
+		t.Fatalf("health after tick %d, want %d", p.Health, health-24)
+	}
+	if output := stripAnsi(strings.Join(drainOutput(p.Output), "")); !strings.Contains(output, "molten rock scorches you") {
+		t.Fatalf("expected the tick to be described, got %q", output)
+	}
+
+	p.Health = 5
+	world.HazardTick()
+	if p.Room == "vent" || p.Health != p.MaxHealth {
+		t.Fatalf("expected the player to die and wake at home, got %s with %d health", p.Room, p.Health)
+	}
+}
+
+func TestParseHazard(t *testing.T) {
+	for input, want := range map[string]Hazard{"deep water": HazardDeepWater, "Deep-Water": HazardDeepWater, "cliff": HazardCliff, "none": HazardNone} {
+		if got, ok := ParseHazard(input); !ok || got != want {
+			t.Fatalf("ParseHazard(%q) = %q, %v", input, got, ok)
+		}
+	}
+	if _, ok := ParseHazard("quicksand"); ok {
+		t.Fatalf("expected unknown hazard to be rejected")
+	}
+}
@@ -0,0 +1,258 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ResurrectWindow is how long after a death a healer can still undo it.
+const ResurrectWindow = 30 * time.Minute
+
+var (
+	// ErrNoHealer indicates there is no healer in the player's room.
+	ErrNoHealer = errors.New("there is no healer here")
+	// ErrNoResurrection indicates the player has no recent death to undo.
+	ErrNoResurrection = errors.New("you have no recent death to undo")
+)
+
+// HealerServices lists what a healer NPC offers and the base price of each
+// in gold. A service with no price is not offered. Faction standing raises
+// or lowers prices the same way it does at shops.
+type HealerServices struct {
+	// Heal restores health and mana to full.
+	Heal int `json:"heal,omitempty"`
+	// Cure lifts harmful effects such as post-defeat weakness.
+	Cure int `json:"cure,omitempty"`
+	// Resurrect undoes the player's last death within ResurrectWindow.
+	Resurrect int `json:"resurrect,omitempty"`
+}
+
+// HealerService names one service a healer may sell.
+type HealerService string
+
+const (
+	HealerHeal      HealerService = "heal"
+	HealerCure      HealerService = "cure"
+	HealerResurrect HealerService = "resurrect"
+)
+
+// HealerServiceNames lists every healer service in display order.
+func HealerServiceNames() []HealerService {
+	return []HealerService{HealerHeal, HealerCure, HealerResurrect}
+}
+
+// ParseHealerService accepts a service name, or "raise" for resurrect.
+func ParseHealerService(name string) (HealerService, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "raise" {
+		return HealerResurrect, true
+	}
+	for _, service := range HealerServiceNames() {
+		if name == string(service) {
+			return service, true
+		}
+	}
+	return "", false
+}
+
+func (h HealerServices) price(service HealerService) int {
+	switch service {
+	case HealerHeal:
+		return h.Heal
+	case HealerCure:
+		return h.Cure
+	case HealerResurrect:
+		return h.Resurrect
+	}
+	return 0
+}
+
+// cloneHealer copies services so NPCs spawned from one reset do not share
+// them.
+func cloneHealer(services *HealerServices) *HealerServices {
+	if services == nil {
+		return nil
+	}
+	copied := *services
+	return &copied
+}
+
+// deathRecord remembers what a player's last death cost them so a healer
+// can give it back.
+type deathRecord struct {
+	at         time.Time
+	room       RoomID
+	corpse     uint64
+	experience int
+}
+
+// harmful reports whether a healer's cure lifts the effect.
+func (e StatusEffect) harmful() bool {
+	return e.Name == WeaknessEffect || e.Poison > 0 || e.DamagePercent < 0 || e.Attributes.Str < 0 || e.Attributes.Dex < 0
+}
+
+// HealerOffer is a service a healer sells and what it costs p.
+type HealerOffer struct {
+	Service HealerService
+	Price   int
+}
+
+// HealerListing describes the healer in p's room and their prices.
+type HealerListing struct {
+	Healer NPC
+	// Standing is p's standing with the healer's faction, empty when the
+	// healer has none.
+	Standing Standing
+	// Modifier is the percentage standing adds to or takes off prices.
+	Modifier int
+	Offers   []HealerOffer
+}
+
+// HealerResult reports what a healer did for p.
+type HealerResult struct {
+	Healer  NPC
+	Service HealerService
+	Price   int
+	Healed  int
+	Mana    int
+	// Cured lists the effects that were lifted.
+	Cured []string
+	// Experience is what a resurrection gave back, and Recovered the
+	// belongings it returned from the corpse.
+	Experience int
+	Recovered  []Item
+}
+
+// healerLocked returns the first healer NPC in p's room.
+func (w *World) healerLocked(p *Player) (NPC, bool) {
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return NPC{}, false
+	}
+	for _, npc := range room.NPCs {
+		if npc.Healer != nil {
+			return npc, true
+		}
+	}
+	return NPC{}, false
+}
+
+// healerTermsLocked reports the price modifier healer offers p, refusing
+// players its faction is hostile to.
+func (w *World) healerTermsLocked(p *Player, healer NPC) (Standing, int, error) {
+	standing, ok := w.npcStandingLocked(p, healer)
+	if !ok {
+		return "", 0, nil
+	}
+	modifier, trades := standing.PriceModifier()
+	if !trades {
+		return standing, 0, fmt.Errorf("%s refuses to tend you", healer.Name)
+	}
+	return standing, modifier, nil
+}
+
+func healerPrice(base, modifier int) int {
+	return max(1, base*(100+modifier)/100)
+}
+
+// HealerOffers lists the services the healer in p's room sells and what
+// each costs p.
+func (w *World) HealerOffers(p *Player) (HealerListing, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	healer, ok := w.healerLocked(p)
+	if !ok {
+		return HealerListing{}, ErrNoHealer
+	}
+	standing, modifier, err := w.healerTermsLocked(p, healer)
+	if err != nil {
+		return HealerListing{}, err
+	}
+	listing := HealerListing{Healer: healer, Standing: standing, Modifier: modifier}
+	for _, service := range HealerServiceNames() {
+		if base := healer.Healer.price(service); base > 0 {
+			listing.Offers = append(listing.Offers, HealerOffer{Service: service, Price: w.sinkGoldLocked(healerPrice(base, modifier))})
+		}
+	}
+	return listing, nil
+}
+
+// BuyHealing pays the healer in p's room for service. Gold is only taken
+// when the service has something to do.
+func (w *World) BuyHealing(p *Player, service HealerService) (HealerResult, error) {
+	w.mu.Lock()
+	healer, ok := w.healerLocked(p)
+	if !ok {
+		w.mu.Unlock()
+		return HealerResult{}, ErrNoHealer
+	}
+	base := healer.Healer.price(service)
+	if base <= 0 {
+		w.mu.Unlock()
+		return HealerResult{}, fmt.Errorf("%s does not offer to %s", healer.Name, service)
+	}
+	_, modifier, err := w.healerTermsLocked(p, healer)
+	if err != nil {
+		w.mu.Unlock()
+		return HealerResult{}, err
+	}
+	result := HealerResult{Healer: healer, Service: service, Price: w.sinkGoldLocked(healerPrice(base, modifier))}
+	if p.Gold < result.Price {
+		w.mu.Unlock()
+		return HealerResult{}, ErrNotEnoughGold
+	}
+	now := time.Now()
+	p.EnsureStats()
+	switch service {
+	case HealerHeal:
+		if p.Health >= p.MaxHealth && p.Mana >= p.MaxMana {
+			w.mu.Unlock()
+			return HealerResult{}, fmt.Errorf("you are already in perfect health")
+		}
+		result.Healed = restore(&p.Health, p.MaxHealth, p.MaxHealth)
+		result.Mana = restore(&p.Mana, p.MaxMana, p.MaxMana)
+	case HealerCure:
+		for _, effect := range p.ActiveEffects(now) {
+			if effect.harmful() {
+				result.Cured = append(result.Cured, effect.Name)
+			}
+		}
+		if len(result.Cured) == 0 {
+			w.mu.Unlock()
+			return HealerResult{}, fmt.Errorf("nothing ails you")
+		}
+		for _, name := range result.Cured {
+			delete(p.Effects, name)
+		}
+	case HealerResurrect:
+		record := p.lastDeath
+		if record == nil || now.Sub(record.at) >= ResurrectWindow {
+			w.mu.Unlock()
+			return HealerResult{}, ErrNoResurrection
+		}
+		p.lastDeath = nil
+		p.Experience += record.experience
+		result.Experience = record.experience
+		delete(p.Effects, WeaknessEffect)
+		if room, ok := w.rooms[record.room]; ok && record.corpse != 0 {
+			for i := range room.Items {
+				if room.Items[i].corpseID == record.corpse {
+					result.Recovered = room.Items[i].Contents
+					room.Items = append(room.Items[:i], room.Items[i+1:]...)
+					break
+				}
+			}
+		}
+		p.Inventory = append(p.Inventory, result.Recovered...)
+		result.Healed = restore(&p.Health, p.MaxHealth, p.MaxHealth)
+		result.Mana = restore(&p.Mana, p.MaxMana, p.MaxMana)
+	}
+	p.Gold -= result.Price
+	w.recordGoldLocked(EconomyHealer, -result.Price)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return result, nil
+}
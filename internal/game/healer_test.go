@@ -0,0 +1,120 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHealerHealsAndCuresForGold(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Temple", Exits: map[string]Exit{"east": {To: "pit"}},
+			NPCs: []NPC{{Name: "Mender", Faction: "order", Healer: &HealerServices{Heal: 20, Cure: 30, Resurrect: 100}}}},
+		"pit": {ID: "pit", Title: "Pit", Exits: map[string]Exit{"west": {To: StartRoom}}},
+	})
+	if err := world.AddFactionForTest(Faction{ID: "order", Name: "Order"}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	player := &Player{Name: "Patient", Room: StartRoom, Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	player.EnsureStats()
+	if _, err := world.BuyHealing(player, HealerHeal); err == nil {
+		t.Fatalf("expected a healthy player to be turned away")
+	}
+	player.Health = 1
+	result, err := world.BuyHealing(player, HealerHeal)
+	if err != nil {
+		t.Fatalf("BuyHealing: %v", err)
+	}
+	if player.Health != player.MaxHealth || result.Price != 20 || player.Gold != 480 {
+		t.Fatalf("unexpected heal %+v, health %d, gold %d", result, player.Health, player.Gold)
+	}
+
+	player.addEffect(StatusEffect{Name: WeaknessEffect, Expires: time.Now().Add(time.Minute), DamagePercent: -25})
+	player.addEffect(StatusEffect{Name: "emberroot", Expires: time.Now().Add(time.Minute), DamagePercent: 10})
+	result, err = world.BuyHealing(player, HealerCure)
+	if err != nil || len(result.Cured) != 1 || result.Cured[0] != WeaknessEffect {
+		t.Fatalf("expected only the weakness to be cured, got %+v, %v", result, err)
+	}
+	if player.HasEffect(WeaknessEffect) || !player.HasEffect("emberroot") {
+		t.Fatalf("unexpected effects after cure: %+v", player.Effects)
+	}
+	if _, err := world.BuyHealing(player, HealerCure); err == nil {
+		t.Fatalf("expected nothing left to cure")
+	}
+
+	player.Room = "pit"
+	if _, err := world.BuyHealing(player, HealerHeal); !errors.Is(err, ErrNoHealer) {
+		t.Fatalf("expected no healer in the pit, got %v", err)
+	}
+}
+
+func TestHealerPricesFollowStanding(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Temple", Exits: map[string]Exit{"east": {To: "pit"}},
+			NPCs: []NPC{{Name: "Mender", Faction: "order", Healer: &HealerServices{Heal: 20, Cure: 30, Resurrect: 100}}}},
+		"pit": {ID: "pit", Title: "Pit", Exits: map[string]Exit{"west": {To: StartRoom}}},
+	})
+	if err := world.AddFactionForTest(Faction{ID: "order", Name: "Order"}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	player := &Player{Name: "Patient", Room: StartRoom, Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	player.EnsureStats()
+	player.Reputation = map[string]int{"order": 500}
+	listing, err := world.HealerOffers(player)
+	if err != nil {
+		t.Fatalf("HealerOffers: %v", err)
+	}
+	if listing.Modifier != -20 || len(listing.Offers) != 3 || listing.Offers[2].Price != 80 {
+		t.Fatalf("expected honored prices, got %+v", listing)
+	}
+	player.Reputation["order"] = -300
+	if _, err := world.HealerOffers(player); err == nil {
+		t.Fatalf("expected a hostile faction to refuse service")
+	}
+}
+
+func TestHealerResurrectionUndoesDeath(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Temple", Exits: map[string]Exit{"east": {To: "pit"}},
+			NPCs: []NPC{{Name: "Mender", Faction: "order", Healer: &HealerServices{Heal: 20, Cure: 30, Resurrect: 100}}}},
+		"pit": {ID: "pit", Title: "Pit", Exits: map[string]Exit{"west": {To: StartRoom}}},
+	})
+	if err := world.AddFactionForTest(Faction{ID: "order", Name: "Order"}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+	player := &Player{Name: "Patient", Room: StartRoom, Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	player.EnsureStats()
+	if _, err := world.BuyHealing(player, HealerResurrect); !errors.Is(err, ErrNoResurrection) {
+		t.Fatalf("expected nothing to undo, got %v", err)
+	}
+	player.Level = 2
+	player.Experience = experienceForLevel(2) + 100
+	player.Inventory = []Item{{Name: "Lucky Coin"}}
+	player.Room = "pit"
+	world.mu.Lock()
+	outcome := world.applyDeathLocked(player)
+	world.mu.Unlock()
+	if outcome.ExperienceLost != 10 || len(player.Inventory) != 0 {
+		t.Fatalf("unexpected death %+v", outcome)
+	}
+
+	result, err := world.BuyHealing(player, HealerResurrect)
+	if err != nil {
+		t.Fatalf("BuyHealing: %v", err)
+	}
+	if result.Experience != 10 || player.Experience != experienceForLevel(2)+100 {
+		t.Fatalf("expected lost experience back, got %+v", result)
+	}
+	if len(player.Inventory) != 1 || player.HasEffect(WeaknessEffect) {
+		t.Fatalf("expected belongings back and weakness lifted, got %+v", player.Inventory)
+	}
+	if pit, _ := world.GetRoom("pit"); len(pit.Items) != 0 {
+		t.Fatalf("expected the corpse to be gone, got %+v", pit.Items)
+	}
+	if _, err := world.BuyHealing(player, HealerResurrect); !errors.Is(err, ErrNoResurrection) {
+		t.Fatalf("expected a death to be undone only once, got %v", err)
+	}
+}
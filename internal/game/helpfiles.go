@@ -0,0 +1,209 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const helpFileName = "help.json"
+
+// DefaultHelpCategory files topics that were saved without a category.
+const DefaultHelpCategory = "General"
+
+// HelpTopic is one entry in the help files. Keywords are extra names the
+// topic answers to; staff topics are hidden from regular players.
+type HelpTopic struct {
+	Name     string   `json:"name"`
+	Keywords []string `json:"keywords,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Staff    bool     `json:"staff,omitempty"`
+	Body     string   `json:"body"`
+}
+
+type helpFile struct {
+	Topics []HelpTopic `json:"topics"`
+}
+
+// Matches reports whether name is the topic's name or one of its keywords.
+func (t HelpTopic) Matches(name string) bool {
+	if strings.EqualFold(t.Name, name) {
+		return true
+	}
+	for _, keyword := range t.Keywords {
+		if strings.EqualFold(keyword, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func helpPath(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), helpFileName)
+}
+
+func loadHelpTopics(areasPath string) (map[string]*HelpTopic, error) {
+	path := helpPath(areasPath)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var parsed helpFile
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse help: %w", err)
+	}
+	topics := make(map[string]*HelpTopic, len(parsed.Topics))
+	for i := range parsed.Topics {
+		topic := &parsed.Topics[i]
+		if err := normalizeHelpTopic(topic); err != nil {
+			return nil, fmt.Errorf("parse help: %w", err)
+		}
+		topics[topic.Name] = topic
+	}
+	return topics, nil
+}
+
+func normalizeHelpTopic(topic *HelpTopic) error {
+	topic.Name = strings.ToLower(strings.TrimSpace(topic.Name))
+	if topic.Name == "" || strings.ContainsAny(topic.Name, " \t") {
+		return fmt.Errorf("help topic name must be a single word")
+	}
+	keywords := make([]string, 0, len(topic.Keywords))
+	for _, keyword := range topic.Keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" && keyword != topic.Name && !containsName(keywords, keyword) {
+			keywords = append(keywords, keyword)
+		}
+	}
+	topic.Keywords = keywords
+	topic.Category = strings.TrimSpace(topic.Category)
+	if topic.Category == "" {
+		topic.Category = DefaultHelpCategory
+	}
+	topic.Body = strings.TrimSpace(strings.ReplaceAll(topic.Body, "\r\n", "\n"))
+	return nil
+}
+
+// HelpTopics lists the help topics ordered by category and name. Staff
+// topics are only included when staff is true.
+func (w *World) HelpTopics(staff bool) []HelpTopic {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	list := make([]HelpTopic, 0, len(w.help))
+	for _, topic := range w.help {
+		if topic.Staff && !staff {
+			continue
+		}
+		copy := *topic
+		copy.Keywords = append([]string(nil), topic.Keywords...)
+		list = append(list, copy)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !strings.EqualFold(list[i].Category, list[j].Category) {
+			return strings.ToLower(list[i].Category) < strings.ToLower(list[j].Category)
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// HelpTopic looks up a help topic by its exact name.
+func (w *World) HelpTopic(name string) (HelpTopic, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	topic, ok := w.help[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return HelpTopic{}, false
+	}
+	copy := *topic
+	copy.Keywords = append([]string(nil), topic.Keywords...)
+	return copy, true
+}
+
+// SetHelpTopic adds or replaces a help topic and saves the help file.
+func (w *World) SetHelpTopic(topic HelpTopic) error {
+	if err := normalizeHelpTopic(&topic); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev, existed := w.help[topic.Name]
+	if w.help == nil {
+		w.help = make(map[string]*HelpTopic)
+	}
+	w.help[topic.Name] = &topic
+	if err := w.persistHelpLocked(); err != nil {
+		if existed {
+			w.help[topic.Name] = prev
+		} else {
+			delete(w.help, topic.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveHelpTopic deletes a help topic and saves the help file.
+func (w *World) RemoveHelpTopic(name string) error {
+	key := strings.ToLower(strings.TrimSpace(name))
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev, ok := w.help[key]
+	if !ok {
+		return fmt.Errorf("unknown help topic: %s", key)
+	}
+	delete(w.help, key)
+	if err := w.persistHelpLocked(); err != nil {
+		w.help[key] = prev
+		return err
+	}
+	return nil
+}
+
+// ReloadHelp re-reads the help file.
+func (w *World) ReloadHelp() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	topics, err := loadHelpTopics(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	w.help = topics
+	w.mu.Unlock()
+	return len(topics), nil
+}
+
+func (w *World) persistHelpLocked() error {
+	path := helpPath(w.areasPath)
+	if path == "" {
+		return nil
+	}
+	file := helpFile{Topics: make([]HelpTopic, 0, len(w.help))}
+	for _, topic := range w.help {
+		file.Topics = append(file.Topics, *topic)
+	}
+	sort.Slice(file.Topics, func(i, j int) bool { return file.Topics[i].Name < file.Topics[j].Name })
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode help: %w", err)
+	}
+	return fileStorage{}.Write(path, append(data, '\n'))
+}
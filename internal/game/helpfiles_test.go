@@ -0,0 +1,51 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBundledHelpLoads(t *testing.T) {
+	topics, err := loadHelpTopics("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadHelpTopics: %v", err)
+	}
+	newbie, ok := topics["newbie"]
+	if !ok || newbie.Body == "" || newbie.Staff {
+		t.Fatalf("expected a public newbie topic, got %+v", newbie)
+	}
+	if moderation, ok := topics["moderation"]; !ok || !moderation.Staff {
+		t.Fatalf("expected a staff moderation topic, got %+v", moderation)
+	}
+}
+
+func TestSetHelpTopicPersistsAndReloads(t *testing.T) {
+	dir := t.TempDir()
+	areas := filepath.Join(dir, "areas")
+	if err := os.MkdirAll(areas, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start"}})
+	world.areasPath = areas
+	if err := world.SetHelpTopic(HelpTopic{Name: " Fishing ", Keywords: []string{"Bait", "fishing"}, Body: "Cast a line."}); err != nil {
+		t.Fatalf("SetHelpTopic: %v", err)
+	}
+	if staff := world.HelpTopics(false); len(staff) != 1 || staff[0].Category != DefaultHelpCategory {
+		t.Fatalf("topics = %+v", staff)
+	}
+	count, err := world.ReloadHelp()
+	if err != nil || count != 1 {
+		t.Fatalf("ReloadHelp = %d, %v", count, err)
+	}
+	topic, ok := world.HelpTopic("fishing")
+	if !ok || len(topic.Keywords) != 1 || topic.Keywords[0] != "bait" || !topic.Matches("BAIT") {
+		t.Fatalf("reloaded topic = %+v", topic)
+	}
+	if err := world.RemoveHelpTopic("fishing"); err != nil {
+		t.Fatalf("RemoveHelpTopic: %v", err)
+	}
+	if topics, _ := loadHelpTopics(areas); len(topics) != 0 {
+		t.Fatalf("help file should be empty after removal, got %+v", topics)
+	}
+}
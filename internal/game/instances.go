@@ -0,0 +1,258 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// InstanceIdleTimeout is how long an instance may stand empty before it
+	// is torn down.
+	InstanceIdleTimeout = 10 * time.Minute
+	instanceSweep       = time.Minute
+)
+
+// ErrNoInstance indicates the player's group has no open instance.
+var ErrNoInstance = errors.New("your group has no open instance")
+
+// instance is one group's private copy of an instanced area. Every room of
+// the template is copied under its own ID with fresh NPCs and items, and
+// exits between template rooms are pointed at the copies.
+type instance struct {
+	id         int
+	key        string
+	source     string
+	rooms      map[RoomID]RoomID
+	emptySince time.Time
+}
+
+// InstanceView describes one of a group's instances.
+type InstanceView struct {
+	Area    string
+	Players []string
+}
+
+func instanceRoomID(template RoomID, id int) RoomID {
+	return RoomID(fmt.Sprintf("%s#%d", template, id))
+}
+
+// isInstanceRoomID reports whether id names a room inside an instance.
+func isInstanceRoomID(id RoomID) bool {
+	return strings.Contains(string(id), "#")
+}
+
+// instanceKeyLocked identifies whose instances p shares: their group's, or
+// their own when they are not grouped.
+func instanceKeyLocked(p *Player) string {
+	if p.group != nil {
+		return fmt.Sprintf("group:%d", p.group.id)
+	}
+	return "player:" + strings.ToLower(p.Name)
+}
+
+func cloneInstanceRoom(template *Room, id RoomID, copies map[RoomID]RoomID) *Room {
+	room := *template
+	room.ID = id
+	room.Exits = make(map[string]Exit, len(template.Exits))
+	for dir, exit := range template.Exits {
+		if to, ok := copies[exit.To]; ok {
+			exit.To = to
+		}
+		room.Exits[dir] = exit
+	}
+	room.NPCs = append([]NPC(nil), template.NPCs...)
+	room.Items = cloneItems(template.Items)
+	room.Resets = append([]RoomReset(nil), template.Resets...)
+	room.Extras = maps.Clone(template.Extras)
+	return &room
+}
+
+// instanceEntryLocked returns the room p arrives in when moving from one
+// room into another. Stepping into an instanced area from outside leads to
+// the group's own copy, which is created on first entry.
+func (w *World) instanceEntryLocked(p *Player, from, to RoomID) RoomID {
+	source, ok := w.roomSources[to]
+	if !ok || !w.areaMeta[source].Instanced || w.roomSources[from] == source || isInstanceRoomID(to) {
+		return to
+	}
+	key := instanceKeyLocked(p)
+	inst, ok := w.instances[key+"|"+source]
+	if !ok {
+		inst = w.createInstanceLocked(key, source)
+	}
+	if copyID, ok := inst.rooms[to]; ok {
+		return copyID
+	}
+	return to
+}
+
+func (w *World) createInstanceLocked(key, source string) *instance {
+	w.nextInstanceID++
+	inst := &instance{id: w.nextInstanceID, key: key, source: source, rooms: make(map[RoomID]RoomID)}
+	for id, from := range w.roomSources {
+		if from == source && !isInstanceRoomID(id) {
+			inst.rooms[id] = instanceRoomID(id, inst.id)
+		}
+	}
+	if w.instances == nil {
+		w.instances = make(map[string]*instance)
+	}
+	if w.instanceRooms == nil {
+		w.instanceRooms = make(map[RoomID]*instance)
+	}
+	for template, id := range inst.rooms {
+		room, ok := w.rooms[template]
+		if !ok {
+			continue
+		}
+		w.rooms[id] = cloneInstanceRoom(room, id, inst.rooms)
+		w.roomSources[id] = source
+		w.instanceRooms[id] = inst
+	}
+	w.instances[key+"|"+source] = inst
+	return inst
+}
+
+// occupantsLocked lists the players inside an instance, including those who
+// have lost their link.
+func (w *World) occupantsLocked(inst *instance) []string {
+	var names []string
+	for _, p := range w.players {
+		if w.instanceRooms[p.Room] == inst {
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// removeInstanceLocked deletes an instance's rooms and returns the fights
+// that were running in them so the caller can stop them once unlocked.
+func (w *World) removeInstanceLocked(inst *instance) []*combatInstance {
+	var stale []*combatInstance
+	for _, id := range inst.rooms {
+		delete(w.rooms, id)
+		delete(w.roomSources, id)
+		delete(w.instanceRooms, id)
+		delete(w.roomFlags, id)
+		if combat := w.combats[id]; combat != nil {
+			stale = append(stale, combat)
+			delete(w.combats, id)
+		}
+	}
+	delete(w.instances, inst.key+"|"+inst.source)
+	return stale
+}
+
+// CollectInstances tears down instances that have stood empty for
+// InstanceIdleTimeout and returns how many were removed.
+func (w *World) CollectInstances(now time.Time) int {
+	var stale []*combatInstance
+	removed := 0
+	w.mu.Lock()
+	for _, inst := range w.instances {
+		if len(w.occupantsLocked(inst)) > 0 {
+			inst.emptySince = time.Time{}
+			continue
+		}
+		if inst.emptySince.IsZero() {
+			inst.emptySince = now
+			continue
+		}
+		if now.Sub(inst.emptySince) >= InstanceIdleTimeout {
+			stale = append(stale, w.removeInstanceLocked(inst)...)
+			removed++
+		}
+	}
+	w.mu.Unlock()
+	for _, combat := range stale {
+		combat.stopLoop()
+	}
+	return removed
+}
+
+// StartInstanceLoop periodically removes idle instances until stop is
+// closed.
+func (w *World) StartInstanceLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(instanceSweep)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.CollectInstances(now)
+			}
+		}
+	}()
+}
+
+func (w *World) groupInstancesLocked(p *Player) []*instance {
+	key := instanceKeyLocked(p)
+	var found []*instance
+	for _, inst := range w.instances {
+		if inst.key == key {
+			found = append(found, inst)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].id < found[j].id })
+	return found
+}
+
+func (w *World) instanceAreaLocked(inst *instance) string {
+	if name := strings.TrimSpace(w.areaMeta[inst.source].Name); name != "" {
+		return name
+	}
+	return strings.TrimSuffix(inst.source, ".json")
+}
+
+// Instances lists the instances open for p's group.
+func (w *World) Instances(p *Player) []InstanceView {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	var views []InstanceView
+	for _, inst := range w.groupInstancesLocked(p) {
+		views = append(views, InstanceView{Area: w.instanceAreaLocked(inst), Players: w.occupantsLocked(inst)})
+	}
+	return views
+}
+
+// ResetInstances discards the instances open for p's group so the next
+// entry starts fresh. Only the group leader may reset, and only once
+// everyone has left. It returns the names of the areas reset.
+func (w *World) ResetInstances(p *Player) ([]string, error) {
+	w.mu.Lock()
+	if p.group != nil && p.group.leader != p {
+		leader := p.group.leader.Name
+		w.mu.Unlock()
+		return nil, fmt.Errorf("only %s can reset your group's instances", leader)
+	}
+	found := w.groupInstancesLocked(p)
+	if len(found) == 0 {
+		w.mu.Unlock()
+		return nil, ErrNoInstance
+	}
+	for _, inst := range found {
+		if occupants := w.occupantsLocked(inst); len(occupants) > 0 {
+			area := w.instanceAreaLocked(inst)
+			w.mu.Unlock()
+			return nil, fmt.Errorf("everyone must leave %s first (%s still inside)", area, strings.Join(occupants, ", "))
+		}
+	}
+	var stale []*combatInstance
+	areas := make([]string, 0, len(found))
+	for _, inst := range found {
+		areas = append(areas, w.instanceAreaLocked(inst))
+		stale = append(stale, w.removeInstanceLocked(inst)...)
+	}
+	w.mu.Unlock()
+	for _, combat := range stale {
+		combat.stopLoop()
+	}
+	return areas, nil
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupsShareAnInstance(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"camp":  {ID: "camp", Title: "Camp", Exits: map[string]Exit{"d": {To: "crypt"}}},
+		"crypt": {ID: "crypt", Title: "Crypt", Exits: map[string]Exit{"u": {To: "camp"}, "n": {To: "tomb"}}},
+		"tomb": {ID: "tomb", Title: "Tomb", Exits: map[string]Exit{"s": {To: "crypt"}},
+			NPCs: []NPC{{Name: "Barrow Wight", Health: 30, MaxHealth: 30}}},
+	})
+	world.roomSources = map[RoomID]string{"camp": "town.json", "crypt": "crypt.json", "tomb": "crypt.json"}
+	world.areaMeta["crypt.json"] = areaMetadata{Name: "Barrow Crypt", Instanced: true}
+	leader := &Player{Name: "Leader", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	friend := &Player{Name: "Friend", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	stranger := &Player{Name: "Stranger", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	for _, p := range []*Player{leader, friend, stranger} {
+		world.AddPlayerForTest(p)
+	}
+	if _, err := world.InviteToGroup(leader, "friend"); err != nil {
+		t.Fatalf("InviteToGroup: %v", err)
+	}
+	if _, err := world.JoinGroup(stranger, "leader"); err == nil {
+		t.Fatalf("expected uninvited join to fail")
+	}
+	if _, err := world.JoinGroup(friend, "leader"); err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+
+	for _, p := range []*Player{leader, friend, stranger} {
+		if _, err := world.Move(p, "d"); err != nil {
+			t.Fatalf("Move %s: %v", p.Name, err)
+		}
+	}
+	if leader.Room == "crypt" || !strings.HasPrefix(string(leader.Room), "crypt#") {
+		t.Fatalf("leader entered %s, want an instance of crypt", leader.Room)
+	}
+	if friend.Room != leader.Room {
+		t.Fatalf("group split across %s and %s", leader.Room, friend.Room)
+	}
+	if stranger.Room == leader.Room || !strings.HasPrefix(string(stranger.Room), "crypt#") {
+		t.Fatalf("stranger entered %s, want their own instance", stranger.Room)
+	}
+
+	if _, err := world.Move(leader, "n"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	room, _ := world.GetRoom(leader.Room)
+	if room.Title != "Tomb" || len(room.NPCs) != 1 {
+		t.Fatalf("instance tomb = %+v", room)
+	}
+	room.NPCs = nil
+	if template, _ := world.GetRoom("tomb"); len(template.NPCs) != 1 {
+		t.Fatalf("clearing the instance emptied the template")
+	}
+	if _, err := world.Move(leader, "s"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := world.Move(leader, "u"); err != nil || leader.Room != "camp" {
+		t.Fatalf("left instance to %s: %v", leader.Room, err)
+	}
+
+	if _, err := world.ResetInstances(friend); err == nil || !strings.Contains(err.Error(), "only Leader") {
+		t.Fatalf("expected only the leader to reset, got %v", err)
+	}
+	if _, err := world.ResetInstances(leader); err == nil || !strings.Contains(err.Error(), "Friend still inside") {
+		t.Fatalf("expected occupied instance to refuse reset, got %v", err)
+	}
+	if _, err := world.Move(friend, "u"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	old := leader.Room
+	areas, err := world.ResetInstances(leader)
+	if err != nil || len(areas) != 1 || areas[0] != "Barrow Crypt" {
+		t.Fatalf("ResetInstances = %v, %v", areas, err)
+	}
+	if _, err := world.Move(leader, "d"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := world.Move(leader, "n"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if room, _ := world.GetRoom(leader.Room); len(room.NPCs) != 1 {
+		t.Fatalf("reset instance was not fresh")
+	}
+	if _, ok := world.GetRoom(old); ok && old != "camp" {
+		t.Fatalf("old instance room %s survived the reset", old)
+	}
+}
+
+func TestEmptyInstancesAreCollected(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"camp":  {ID: "camp", Title: "Camp", Exits: map[string]Exit{"d": {To: "crypt"}}},
+		"crypt": {ID: "crypt", Title: "Crypt", Exits: map[string]Exit{"u": {To: "camp"}, "n": {To: "tomb"}}},
+		"tomb": {ID: "tomb", Title: "Tomb", Exits: map[string]Exit{"s": {To: "crypt"}},
+			NPCs: []NPC{{Name: "Barrow Wight", Health: 30, MaxHealth: 30}}},
+	})
+	world.roomSources = map[RoomID]string{"camp": "town.json", "crypt": "crypt.json", "tomb": "crypt.json"}
+	world.areaMeta["crypt.json"] = areaMetadata{Name: "Barrow Crypt", Instanced: true}
+	p := &Player{Name: "Delver", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if _, err := world.Move(p, "d"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	inside := p.Room
+	now := time.Now()
+	if removed := world.CollectInstances(now); removed != 0 {
+		t.Fatalf("collected an occupied instance")
+	}
+	if _, err := world.Move(p, "u"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	world.CollectInstances(now)
+	if removed := world.CollectInstances(now.Add(InstanceIdleTimeout / 2)); removed != 0 {
+		t.Fatalf("collected an instance before the timeout")
+	}
+	if removed := world.CollectInstances(now.Add(InstanceIdleTimeout)); removed != 1 {
+		t.Fatalf("removed %d instances, want 1", removed)
+	}
+	if _, ok := world.GetRoom(inside); ok {
+		t.Fatalf("instance room %s survived collection", inside)
+	}
+	if views := world.Instances(p); len(views) != 0 {
+		t.Fatalf("instances still listed: %+v", views)
+	}
+}
+
+func TestLeavingAGroupHandsOnLeadership(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"camp":  {ID: "camp", Title: "Camp", Exits: map[string]Exit{"d": {To: "crypt"}}},
+		"crypt": {ID: "crypt", Title: "Crypt", Exits: map[string]Exit{"u": {To: "camp"}, "n": {To: "tomb"}}},
+		"tomb": {ID: "tomb", Title: "Tomb", Exits: map[string]Exit{"s": {To: "crypt"}},
+			NPCs: []NPC{{Name: "Barrow Wight", Health: 30, MaxHealth: 30}}},
+	})
+	world.roomSources = map[RoomID]string{"camp": "town.json", "crypt": "crypt.json", "tomb": "crypt.json"}
+	world.areaMeta["crypt.json"] = areaMetadata{Name: "Barrow Crypt", Instanced: true}
+	a := &Player{Name: "Ada", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	b := &Player{Name: "Bram", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	c := &Player{Name: "Cass", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	for _, p := range []*Player{a, b, c} {
+		world.AddPlayerForTest(p)
+	}
+	for _, p := range []*Player{b, c} {
+		if _, err := world.InviteToGroup(a, p.Name); err != nil {
+			t.Fatalf("InviteToGroup: %v", err)
+		}
+		if _, err := world.JoinGroup(p, "Ada"); err != nil {
+			t.Fatalf("JoinGroup: %v", err)
+		}
+	}
+	view, err := world.LeaveGroup(a)
+	if err != nil || view.Leader != b || len(view.Members) != 2 {
+		t.Fatalf("LeaveGroup = %+v, %v", view, err)
+	}
+	if _, err := world.KickFromGroup(b, "cass"); err != nil {
+		t.Fatalf("KickFromGroup: %v", err)
+	}
+	if _, err := world.GroupStatus(b); err != ErrNotInGroup {
+		t.Fatalf("group of one should disband, got %v", err)
+	}
+}
@@ -0,0 +1,536 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultIntermudReconnect is how long the intermud gateway waits before
+	// reconnecting to the hub after the connection drops.
+	DefaultIntermudReconnect = 30 * time.Second
+	// DefaultIntermudName is the name the MUD announces itself as when no
+	// other name is configured.
+	DefaultIntermudName = "LumenClay"
+	intermudQueueSize   = 64
+	intermudDialTimeout = 10 * time.Second
+)
+
+// IntermudConfig describes how the MUD joins an IMC2 intermud network. Each
+// entry in Channels links an in-game channel to a network channel such as
+// "Server01:ichat".
+type IntermudConfig struct {
+	Address        string
+	MudName        string
+	ClientPassword string
+	ServerPassword string
+	Channels       map[Channel]string
+	ReconnectDelay time.Duration
+}
+
+// IntermudStatus summarises the live state of the intermud gateway.
+type IntermudStatus struct {
+	Enabled   bool
+	Connected bool
+	Hub       string
+	Channels  map[Channel]string
+	Muted     []string
+}
+
+// imcPacket is one IMC2 packet: "sender@origin sequence route type
+// target@destination key=value ...".
+type imcPacket struct {
+	Sender string
+	Origin string
+	Type   string
+	Target string
+	Dest   string
+	Data   map[string]string
+}
+
+// Intermud relays linked chat channels and who requests to other MUDs
+// through an IMC2 hub.
+type Intermud struct {
+	world   *World
+	cfg     IntermudConfig
+	remotes map[string]Channel
+
+	mu        sync.Mutex
+	enabled   bool
+	connected bool
+	hub       string
+	muted     map[string]bool
+	sequence  int64
+	conn      net.Conn
+
+	outbound    chan string
+	unsubscribe func()
+	stop        chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+}
+
+// NewIntermud validates the configuration and prepares the gateway. Call
+// Start to connect to the hub.
+func NewIntermud(world *World, cfg IntermudConfig) (*Intermud, error) {
+	if world == nil {
+		return nil, fmt.Errorf("intermud requires world reference")
+	}
+	cfg.Address = strings.TrimSpace(cfg.Address)
+	cfg.MudName = strings.TrimSpace(cfg.MudName)
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("intermud requires a hub address")
+	}
+	if cfg.MudName == "" {
+		cfg.MudName = DefaultIntermudName
+	}
+	if strings.ContainsAny(cfg.MudName, " @") {
+		return nil, fmt.Errorf("intermud name %q must not contain spaces or @", cfg.MudName)
+	}
+	if strings.TrimSpace(cfg.ClientPassword) == "" {
+		return nil, fmt.Errorf("intermud requires a client password")
+	}
+	if len(cfg.Channels) == 0 {
+		return nil, fmt.Errorf("intermud requires at least one linked channel")
+	}
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = DefaultIntermudReconnect
+	}
+	remotes := make(map[string]Channel, len(cfg.Channels))
+	for channel, remote := range cfg.Channels {
+		remotes[strings.ToLower(remote)] = channel
+	}
+	return &Intermud{
+		world:    world,
+		cfg:      cfg,
+		remotes:  remotes,
+		enabled:  true,
+		muted:    make(map[string]bool),
+		outbound: make(chan string, intermudQueueSize),
+		stop:     make(chan struct{}),
+	}, nil
+}
+
+// ParseIntermudChannels reads a comma separated list of local=remote channel
+// links such as "ooc=Server01:ichat".
+func ParseIntermudChannels(list string) (map[Channel]string, error) {
+	links := make(map[Channel]string)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		local, remote, ok := strings.Cut(entry, "=")
+		remote = strings.TrimSpace(remote)
+		if !ok || remote == "" || strings.ContainsAny(remote, " \"") {
+			return nil, fmt.Errorf("intermud channel %q must look like ooc=Server01:ichat", entry)
+		}
+		channel, ok := ChannelFromString(strings.TrimSpace(local))
+		if !ok {
+			return nil, fmt.Errorf("unknown channel: %s", local)
+		}
+		links[channel] = remote
+	}
+	return links, nil
+}
+
+// Start launches the connection loop.
+func (m *Intermud) Start() {
+	m.unsubscribe = m.world.Subscribe(EventChatMessage, func(ev GameEvent) {
+		m.Relay(ev.Channel, ev.Speaker, ev.Text)
+	})
+	m.wg.Add(1)
+	go m.run()
+}
+
+// Close disconnects from the hub and waits for the gateway to stop.
+func (m *Intermud) Close() error {
+	m.stopOnce.Do(func() {
+		if m.unsubscribe != nil {
+			m.unsubscribe()
+		}
+		close(m.stop)
+		m.mu.Lock()
+		if m.conn != nil {
+			m.conn.Close()
+		}
+		m.mu.Unlock()
+	})
+	m.wg.Wait()
+	return nil
+}
+
+// SetEnabled pauses or resumes intermud traffic in both directions.
+func (m *Intermud) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	m.enabled = enabled
+	m.mu.Unlock()
+}
+
+// Enabled reports whether the gateway is relaying.
+func (m *Intermud) Enabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.enabled
+}
+
+// SetMuted hides, or stops hiding, traffic from a remote MUD.
+func (m *Intermud) SetMuted(mud string, muted bool) error {
+	mud = strings.TrimSpace(mud)
+	if mud == "" {
+		return fmt.Errorf("name the MUD to mute")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if muted {
+		m.muted[strings.ToLower(mud)] = true
+	} else {
+		delete(m.muted, strings.ToLower(mud))
+	}
+	return nil
+}
+
+func (m *Intermud) mutedMud(mud string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.muted[strings.ToLower(mud)]
+}
+
+// Status describes the gateway configuration and connection.
+func (m *Intermud) Status() IntermudStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	channels := make(map[Channel]string, len(m.cfg.Channels))
+	for channel, remote := range m.cfg.Channels {
+		channels[channel] = remote
+	}
+	muted := make([]string, 0, len(m.muted))
+	for mud := range m.muted {
+		muted = append(muted, mud)
+	}
+	sort.Strings(muted)
+	return IntermudStatus{Enabled: m.enabled, Connected: m.connected, Hub: m.hub, Channels: channels, Muted: muted}
+}
+
+// Relay sends a chat line to the network channel linked to channel. Lines
+// are dropped while the gateway is paused or disconnected, or when the
+// queue is full.
+func (m *Intermud) Relay(channel Channel, speaker, text string) {
+	remote, ok := m.cfg.Channels[channel]
+	text = strings.TrimSpace(text)
+	if !ok || text == "" {
+		return
+	}
+	m.queue(speaker, "ice-msg-b", "*", "*", map[string]string{
+		"channel": remote,
+		"text":    text,
+		"emote":   "0",
+		"echo":    "0",
+	})
+}
+
+// RequestWho asks a remote MUD for its who list. The reply is delivered to
+// the requesting player when it arrives.
+func (m *Intermud) RequestWho(p *Player, mud string) error {
+	mud = strings.TrimSpace(mud)
+	if mud == "" || strings.ContainsAny(mud, " @") {
+		return fmt.Errorf("name the MUD to ask")
+	}
+	m.mu.Lock()
+	ready := m.enabled && m.connected
+	m.mu.Unlock()
+	if !ready {
+		return fmt.Errorf("the intermud network is not connected")
+	}
+	m.queue(p.Name, "who", "*", mud, map[string]string{"type": "who"})
+	return nil
+}
+
+func (m *Intermud) queue(sender, kind, target, dest string, data map[string]string) {
+	m.mu.Lock()
+	ready := m.enabled && m.connected
+	m.sequence++
+	sequence := m.sequence
+	m.mu.Unlock()
+	if !ready {
+		return
+	}
+	line := formatIMCPacket(sender, m.cfg.MudName, sequence, kind, target, dest, data)
+	select {
+	case m.outbound <- line:
+	default:
+	}
+}
+
+func (m *Intermud) run() {
+	defer m.wg.Done()
+	for {
+		if err := m.session(); err != nil {
+			Logger().Warn("intermud connection failed", "hub", m.cfg.Address, "error", err)
+		}
+		select {
+		case <-m.stop:
+			return
+		case <-time.After(m.cfg.ReconnectDelay):
+		}
+	}
+}
+
+// session connects to the hub, authenticates, and relays packets until the
+// connection drops.
+func (m *Intermud) session() error {
+	conn, err := net.DialTimeout("tcp", m.cfg.Address, intermudDialTimeout)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	select {
+	case <-m.stop:
+		m.mu.Unlock()
+		conn.Close()
+		return nil
+	default:
+	}
+	m.conn = conn
+	m.mu.Unlock()
+	defer func() {
+		conn.Close()
+		m.mu.Lock()
+		m.conn = nil
+		m.connected = false
+		m.mu.Unlock()
+	}()
+
+	hello := fmt.Sprintf("PW %s %s version=2 autosetup %s\r\n", m.cfg.MudName, m.cfg.ClientPassword, m.cfg.ServerPassword)
+	if _, err := conn.Write([]byte(hello)); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(conn)
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("read handshake: %w", err)
+	}
+	fields := strings.Fields(reply)
+	if len(fields) < 2 || (fields[0] != "PW" && fields[0] != "autosetup") {
+		return fmt.Errorf("hub refused the connection: %s", strings.TrimSpace(reply))
+	}
+	m.mu.Lock()
+	m.connected = true
+	m.hub = fields[1]
+	m.mu.Unlock()
+	Logger().Info("intermud connected", "hub", fields[1])
+
+	done := make(chan struct{})
+	defer close(done)
+	go m.writeLoop(conn, done)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("read packet: %w", err)
+		}
+		packet, ok := parseIMCPacket(line)
+		if !ok {
+			continue
+		}
+		m.handle(packet)
+	}
+}
+
+func (m *Intermud) writeLoop(conn net.Conn, done chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-m.stop:
+			return
+		case line := <-m.outbound:
+			conn.SetWriteDeadline(time.Now().Add(intermudDialTimeout))
+			if _, err := conn.Write([]byte(line + "\r\n")); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+func (m *Intermud) handle(packet imcPacket) {
+	if strings.EqualFold(packet.Origin, m.cfg.MudName) || !m.Enabled() || m.mutedMud(packet.Origin) {
+		return
+	}
+	switch packet.Type {
+	case "ice-msg-b":
+		m.injectChat(packet)
+	case "who":
+		m.replyWho(packet)
+	case "who-reply":
+		m.deliverWho(packet)
+	case "keepalive-request":
+		m.queue("*", "is-alive", "*", packet.Origin, map[string]string{"versionid": DefaultIntermudName})
+	}
+}
+
+func (m *Intermud) injectChat(packet imcPacket) {
+	remote := packet.Data["channel"]
+	channel, ok := m.remotes[strings.ToLower(remote)]
+	if !ok {
+		return
+	}
+	text := strings.TrimSpace(sanitizeInput(stripIMCColor(packet.Data["text"])))
+	speaker := strings.TrimSpace(sanitizeInput(packet.Sender))
+	if text == "" || speaker == "" {
+		return
+	}
+	if runes := []rune(text); len(runes) > bridgeMessageLimit {
+		text = string(runes[:bridgeMessageLimit]) + "..."
+	}
+	tag := Style("["+remote+"]", AnsiMagenta, AnsiBold)
+	name := HighlightName(speaker) + "@" + sanitizeInput(packet.Origin)
+	if packet.Data["emote"] == "1" {
+		m.world.BroadcastToAllChannel(Ansi(fmt.Sprintf("\r\n%s %s %s", tag, name, text)), nil, channel)
+		return
+	}
+	m.world.BroadcastToAllChannel(Ansi(fmt.Sprintf("\r\n%s %s: %s", tag, name, text)), nil, channel)
+}
+
+func (m *Intermud) replyWho(packet imcPacket) {
+	entries := m.world.WhoList(false, WhoFilter{}, time.Now())
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Players on %s (%d):", m.cfg.MudName, len(entries)))
+	for _, entry := range entries {
+		class := entry.Class
+		if class == "" {
+			class = "-"
+		}
+		builder.WriteString(fmt.Sprintf("\n  %3d %-10s %s", entry.Level, class, TitledName(entry.Name, entry.Title)))
+	}
+	m.queue("*", "who-reply", packet.Sender, packet.Origin, map[string]string{"text": builder.String()})
+}
+
+func (m *Intermud) deliverWho(packet imcPacket) {
+	p, ok := m.world.FindPlayer(packet.Target)
+	if !ok || p.Output == nil {
+		return
+	}
+	lines := strings.Split(stripIMCColor(packet.Data["text"]), "\n")
+	for i, line := range lines {
+		lines[i] = sanitizeInput(strings.TrimRight(line, "\r"))
+	}
+	header := Style(fmt.Sprintf("[Who@%s]", sanitizeInput(packet.Origin)), AnsiMagenta, AnsiBold)
+	p.Output.Send(Ansi("\r\n" + header + "\r\n" + strings.Join(lines, "\r\n")))
+}
+
+// formatIMCPacket renders a packet, quoting values that need it.
+func formatIMCPacket(sender, origin string, sequence int64, kind, target, dest string, data map[string]string) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s@%s %d %s %s %s@%s", sender, origin, sequence, origin, kind, target, dest))
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		builder.WriteString(" " + key + "=" + quoteIMCValue(data[key]))
+	}
+	return builder.String()
+}
+
+func quoteIMCValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \"\\\n\r=") {
+		return value
+	}
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r")
+	return "\"" + replacer.Replace(value) + "\""
+}
+
+// parseIMCPacket reads one packet line, reporting false for malformed lines.
+func parseIMCPacket(line string) (imcPacket, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	fields := strings.SplitN(line, " ", 6)
+	if len(fields) < 5 {
+		return imcPacket{}, false
+	}
+	sender, origin, ok := strings.Cut(fields[0], "@")
+	if !ok {
+		return imcPacket{}, false
+	}
+	if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return imcPacket{}, false
+	}
+	target, dest, ok := strings.Cut(fields[4], "@")
+	if !ok {
+		return imcPacket{}, false
+	}
+	packet := imcPacket{Sender: sender, Origin: origin, Type: strings.ToLower(fields[3]), Target: target, Dest: dest, Data: map[string]string{}}
+	if len(fields) == 6 {
+		packet.Data = parseIMCData(fields[5])
+	}
+	return packet, true
+}
+
+func parseIMCData(rest string) map[string]string {
+	data := make(map[string]string)
+	for {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			return data
+		}
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			return data
+		}
+		if !strings.HasPrefix(value, "\"") {
+			word, remaining, _ := strings.Cut(value, " ")
+			data[key] = word
+			rest = remaining
+			continue
+		}
+		var builder strings.Builder
+		i := 1
+		for ; i < len(value); i++ {
+			c := value[i]
+			if c == '"' {
+				break
+			}
+			if c == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					builder.WriteByte('\n')
+				case 'r':
+					builder.WriteByte('\r')
+				default:
+					builder.WriteByte(value[i])
+				}
+				continue
+			}
+			builder.WriteByte(c)
+		}
+		data[key] = builder.String()
+		if i >= len(value) {
+			return data
+		}
+		rest = value[i+1:]
+	}
+}
+
+// stripIMCColor removes IMC2 "~x" color codes, leaving "~~" as a tilde.
+func stripIMCColor(text string) string {
+	var builder strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '~' || i+1 >= len(text) {
+			builder.WriteByte(text[i])
+			continue
+		}
+		i++
+		if text[i] == '~' {
+			builder.WriteByte('~')
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIMCPacketRoundTrip(t *testing.T) {
+	line := formatIMCPacket("Alice", "LumenClay", 7, "ice-msg-b", "*", "*", map[string]string{
+		"channel": "Server01:ichat",
+		"text":    "hello \"there\"\nfriend",
+	})
+	if !strings.HasPrefix(line, "Alice@LumenClay 7 LumenClay ice-msg-b *@* channel=Server01:ichat text=") {
+		t.Fatalf("unexpected packet %q", line)
+	}
+	packet, ok := parseIMCPacket(line + "\r\n")
+	if !ok {
+		t.Fatalf("parseIMCPacket rejected %q", line)
+	}
+	if packet.Sender != "Alice" || packet.Origin != "LumenClay" || packet.Type != "ice-msg-b" || packet.Dest != "*" {
+		t.Fatalf("unexpected packet %+v", packet)
+	}
+	if packet.Data["channel"] != "Server01:ichat" || packet.Data["text"] != "hello \"there\"\nfriend" {
+		t.Fatalf("unexpected data %#v", packet.Data)
+	}
+	if _, ok := parseIMCPacket("garbage"); ok {
+		t.Fatalf("expected malformed packets to be rejected")
+	}
+	if got := stripIMCColor("~Rred~~ and ~Wwhite"); got != "red~ and white" {
+		t.Fatalf("stripIMCColor = %q", got)
+	}
+}
+
+func TestParseIntermudChannels(t *testing.T) {
+	links, err := ParseIntermudChannels("ooc=Server01:ichat, yell=Server01:igossip")
+	if err != nil {
+		t.Fatalf("ParseIntermudChannels: %v", err)
+	}
+	if links[ChannelOOC] != "Server01:ichat" || links[ChannelYell] != "Server01:igossip" {
+		t.Fatalf("unexpected links %#v", links)
+	}
+	if _, err := ParseIntermudChannels("ooc"); err == nil {
+		t.Fatalf("expected a link without a remote channel to fail")
+	}
+	if _, err := ParseIntermudChannels("shout=Server01:ichat"); err == nil {
+		t.Fatalf("expected an unknown channel to fail")
+	}
+}
+
+func TestIntermudRelaysChatAndWho(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	alice := &Player{Name: "Alice", Room: "start", Alive: true, Level: 3, Output: NewOutputQueue(), Channels: defaultChannelSettings()}
+	world.AddPlayerForTest(alice)
+	gateway, err := NewIntermud(world, IntermudConfig{
+		Address:        listener.Addr().String(),
+		MudName:        "LumenClay",
+		ClientPassword: "secret",
+		Channels:       map[Channel]string{ChannelOOC: "Server01:ichat"},
+	})
+	if err != nil {
+		t.Fatalf("NewIntermud: %v", err)
+	}
+	world.AttachIntermud(gateway)
+	gateway.Start()
+	defer gateway.Close()
+
+	var conn net.Conn
+	select {
+	case conn = <-accepted:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("gateway did not connect")
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	hello, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(hello, "PW LumenClay secret version=2") {
+		t.Fatalf("unexpected handshake %q, %v", hello, err)
+	}
+	conn.Write([]byte("PW Hub hubpass version=2 TestNet\r\n"))
+	deadline := time.Now().Add(2 * time.Second)
+	for !gateway.Status().Connected {
+		if time.Now().After(deadline) {
+			t.Fatalf("gateway did not finish the handshake")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	world.RelayChannelMessage(ChannelOOC, "Alice", "hello network")
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.Contains(line, "ice-msg-b *@* channel=Server01:ichat echo=0 emote=0 text=\"hello network\"") {
+		t.Fatalf("unexpected outbound packet %q, %v", line, err)
+	}
+
+	conn.Write([]byte("Bob@FarMUD 1 FarMUD ice-msg-b *@* channel=Server01:ichat text=\"~Ghi there\" emote=0\r\n"))
+	if got := stripAnsi(awaitOutput(alice.Output, 2*time.Second)); !strings.Contains(got, "[Server01:ichat] Bob@FarMUD: hi there") {
+		t.Fatalf("inbound chat was not delivered, got %q", got)
+	}
+
+	conn.Write([]byte("Bob@FarMUD 2 FarMUD who *@LumenClay type=who\r\n"))
+	line, err = reader.ReadString('\n')
+	if err != nil || !strings.Contains(line, "who-reply Bob@FarMUD") || !strings.Contains(line, "Players on LumenClay (1):") || !strings.Contains(line, "Alice") {
+		t.Fatalf("unexpected who reply %q, %v", line, err)
+	}
+
+	if err := gateway.RequestWho(alice, "FarMUD"); err != nil {
+		t.Fatalf("RequestWho: %v", err)
+	}
+	line, err = reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "Alice@LumenClay ") || !strings.Contains(line, " who *@FarMUD type=who") {
+		t.Fatalf("unexpected who request %q, %v", line, err)
+	}
+	conn.Write([]byte("*@FarMUD 3 FarMUD who-reply Alice@LumenClay text=\"Players on FarMUD (1):\\n  Bob\"\r\n"))
+	if got := stripAnsi(awaitOutput(alice.Output, 2*time.Second)); !strings.Contains(got, "[Who@FarMUD]\r\nPlayers on FarMUD (1):\r\n  Bob") {
+		t.Fatalf("who reply was not delivered, got %q", got)
+	}
+
+	if err := gateway.SetMuted("farmud", true); err != nil {
+		t.Fatalf("SetMuted: %v", err)
+	}
+	conn.Write([]byte("Bob@FarMUD 4 FarMUD ice-msg-b *@* channel=Server01:ichat text=muted\r\n"))
+	if msg := awaitOutput(alice.Output, 100*time.Millisecond); msg != "" {
+		t.Fatalf("muted MUD was relayed: %q", msg)
+	}
+	if status := gateway.Status(); len(status.Muted) != 1 || status.Muted[0] != "farmud" {
+		t.Fatalf("unexpected muted list %+v", status.Muted)
+	}
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is used for players who have not chosen a language and for
+// any message a catalog does not translate.
+const DefaultLanguage = "en"
+
+const languageDirName = "lang"
+
+// MessageCatalog holds one language's translations, keyed by message id.
+// Messages are fmt templates; translators may reorder arguments with
+// explicit indexes such as %[2]s.
+type MessageCatalog struct {
+	Code     string            `json:"code"`
+	Name     string            `json:"name"`
+	Messages map[string]string `json:"messages"`
+}
+
+// englishMessages is the built-in catalog every other language falls back
+// to.
+var englishMessages = map[string]string{
+	"login.required":      "Login required.",
+	"login.reset_hint":    "Have a password reset token? Enter 'reset <token>' as your username.",
+	"login.username":      "Username: ",
+	"login.password":      "Password: ",
+	"login.welcome_back":  "Welcome back, %s!",
+	"login.incorrect":     "Incorrect password.",
+	"login.too_many":      "Too many failed attempts.",
+	"login.set_password":  "Set a password: ",
+	"login.created":       "Account created. Welcome, %s!",
+	"login.cancelled":     "Login cancelled.",
+	"move.no_exit":        "You can't go that way.",
+	"move.door_closed":    "The %s door is closed.",
+	"move.exhausted":      "You are too exhausted to move. Rest a moment to recover.",
+	"move.overburdened":   "You are carrying too much to move. Drop something first.",
+	"combat.strike":       "You strike %s for %d damage. (%d/%d HP)",
+	"combat.defeat":       "You defeat %s!",
+	"combat.collect_gold": "You collect %d gold.",
+	"combat.level_up":     "You advance to level %d!",
+	"command.unknown":     "Unknown command. Type 'help'.",
+	"language.current":    "Your language is %s.",
+	"language.available":  "Available languages: %s",
+	"language.set":        "You will now see game text in %s.",
+	"language.unknown":    "There is no %s translation. Available languages: %s",
+	"language.usage":      "Usage: language [<code>]",
+	"language.name":       "English",
+}
+
+func languageDir(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), languageDirName)
+}
+
+// loadMessageCatalogs reads every <code>.json catalog beside the areas
+// directory. The built-in English catalog is always present, and an en.json
+// file may override its messages.
+func loadMessageCatalogs(areasPath string) (map[string]*MessageCatalog, error) {
+	catalogs := map[string]*MessageCatalog{
+		DefaultLanguage: {Code: DefaultLanguage, Name: englishMessages["language.name"], Messages: englishMessages},
+	}
+	dir := languageDir(areasPath)
+	if dir == "" {
+		return catalogs, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return catalogs, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var catalog MessageCatalog
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		code := normalizeLanguage(strings.TrimSuffix(entry.Name(), ".json"))
+		if catalog.Code != "" && normalizeLanguage(catalog.Code) != code {
+			return nil, fmt.Errorf("parse %s: code %q does not match the file name", path, catalog.Code)
+		}
+		catalog.Code = code
+		if code == DefaultLanguage {
+			merged := make(map[string]string, len(englishMessages)+len(catalog.Messages))
+			for key, msg := range englishMessages {
+				merged[key] = msg
+			}
+			for key, msg := range catalog.Messages {
+				merged[key] = msg
+			}
+			catalog.Messages = merged
+		}
+		if strings.TrimSpace(catalog.Name) == "" {
+			catalog.Name = catalog.Messages["language.name"]
+		}
+		if strings.TrimSpace(catalog.Name) == "" {
+			catalog.Name = code
+		}
+		catalogs[code] = &catalog
+	}
+	return catalogs, nil
+}
+
+func normalizeLanguage(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// translate renders key in lang, falling back to English and then to the
+// key itself.
+func translate(catalogs map[string]*MessageCatalog, lang, key string, args ...any) string {
+	template, ok := "", false
+	if catalog := catalogs[lang]; catalog != nil {
+		template, ok = catalog.Messages[key]
+	}
+	if !ok {
+		if catalog := catalogs[DefaultLanguage]; catalog != nil {
+			template, ok = catalog.Messages[key]
+		}
+	}
+	if !ok {
+		template, ok = englishMessages[key]
+	}
+	if !ok {
+		template = key
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+func (w *World) messageCatalogs() map[string]*MessageCatalog {
+	if catalogs := w.catalogs.Load(); catalogs != nil {
+		return *catalogs
+	}
+	return nil
+}
+
+// Text renders the message key in p's language. It takes no world lock, so
+// it is safe to call from anywhere.
+func (w *World) Text(p *Player, key string, args ...any) string {
+	return translate(w.messageCatalogs(), p.language(), key, args...)
+}
+
+// TextIn renders the message key in the given language.
+func (w *World) TextIn(lang, key string, args ...any) string {
+	return translate(w.messageCatalogs(), normalizeLanguage(lang), key, args...)
+}
+
+// language reports the player's chosen language code.
+func (p *Player) language() string {
+	if p == nil {
+		return DefaultLanguage
+	}
+	p.linkMu.Lock()
+	defer p.linkMu.Unlock()
+	if p.Language == "" {
+		return DefaultLanguage
+	}
+	return p.Language
+}
+
+// LanguageOf reports p's language code.
+func (w *World) LanguageOf(p *Player) string {
+	return p.language()
+}
+
+// Languages lists the available catalogs sorted by code.
+func (w *World) Languages() []MessageCatalog {
+	catalogs := w.messageCatalogs()
+	if catalogs == nil {
+		catalogs = map[string]*MessageCatalog{DefaultLanguage: {Code: DefaultLanguage, Name: englishMessages["language.name"]}}
+	}
+	out := make([]MessageCatalog, 0, len(catalogs))
+	for _, catalog := range catalogs {
+		out = append(out, MessageCatalog{Code: catalog.Code, Name: catalog.Name})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// SetLanguage switches the language p sees game text in.
+func (w *World) SetLanguage(p *Player, code string) (MessageCatalog, error) {
+	code = normalizeLanguage(code)
+	var catalog *MessageCatalog
+	if catalogs := w.messageCatalogs(); catalogs != nil {
+		catalog = catalogs[code]
+	} else if code == DefaultLanguage {
+		catalog = &MessageCatalog{Code: DefaultLanguage, Name: englishMessages["language.name"]}
+	}
+	if catalog == nil {
+		return MessageCatalog{}, fmt.Errorf("unknown language %q", code)
+	}
+	stored := code
+	if stored == DefaultLanguage {
+		stored = ""
+	}
+	w.mu.Lock()
+	p.linkMu.Lock()
+	p.Language = stored
+	p.linkMu.Unlock()
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return MessageCatalog{Code: catalog.Code, Name: catalog.Name}, nil
+}
+
+// ReloadLanguages rereads the message catalogs from disk.
+func (w *World) ReloadLanguages() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	catalogs, err := loadMessageCatalogs(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.catalogs.Store(&catalogs)
+	return len(catalogs), nil
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTranslateFallsBackToEnglish(t *testing.T) {
+	catalogs := map[string]*MessageCatalog{
+		DefaultLanguage: {Code: DefaultLanguage, Messages: englishMessages},
+		"de":            {Code: "de", Messages: map[string]string{"combat.defeat": "Du besiegst %s!"}},
+	}
+	if got := translate(catalogs, "de", "combat.defeat", "Rat"); got != "Du besiegst Rat!" {
+		t.Fatalf("expected German text, got %q", got)
+	}
+	if got := translate(catalogs, "de", "combat.level_up", 3); got != "You advance to level 3!" {
+		t.Fatalf("expected English fallback, got %q", got)
+	}
+	if got := translate(catalogs, "xx", "move.no_exit"); got != "You can't go that way." {
+		t.Fatalf("expected English for an unknown language, got %q", got)
+	}
+	if got := translate(nil, "de", "no.such.key"); got != "no.such.key" {
+		t.Fatalf("expected the key for a missing message, got %q", got)
+	}
+}
+
+func TestLanguageSelectionUsesCatalogsAndPersists(t *testing.T) {
+	root := t.TempDir()
+	areas := filepath.Join(root, "areas")
+	lang := filepath.Join(root, "lang")
+	for _, dir := range []string{areas, lang} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	write := func(path, body string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	write(filepath.Join(areas, "core.json"), `{"name":"Core","rooms":[{"id":"start","title":"Start","description":"","exits":{}}]}`)
+	write(filepath.Join(lang, "de.json"), `{"name":"Deutsch","messages":{"move.no_exit":"Dort geht es nicht weiter."}}`)
+	world, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	accounts, err := NewAccountManager(filepath.Join(root, "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Greta", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	p, err := world.addCharacter("Greta", "Greta", nil, false, accounts.Profile("Greta"))
+	if err != nil {
+		t.Fatalf("addCharacter: %v", err)
+	}
+
+	var codes []string
+	for _, catalog := range world.Languages() {
+		codes = append(codes, catalog.Code+"="+catalog.Name)
+	}
+	if strings.Join(codes, ",") != "de=Deutsch,en=English" {
+		t.Fatalf("unexpected languages %v", codes)
+	}
+	if _, err := world.SetLanguage(p, "fr"); err == nil {
+		t.Fatalf("expected an unknown language to be rejected")
+	}
+	if _, err := world.SetLanguage(p, " DE "); err != nil {
+		t.Fatalf("SetLanguage: %v", err)
+	}
+	if got := world.Text(p, "move.no_exit"); got != "Dort geht es nicht weiter." {
+		t.Fatalf("expected German text, got %q", got)
+	}
+	if got := world.Text(p, "move.exhausted"); !strings.HasPrefix(got, "You are too exhausted") {
+		t.Fatalf("expected English fallback, got %q", got)
+	}
+	if got := accounts.Profile("Greta").Language; got != "de" {
+		t.Fatalf("expected the language to be saved, got %q", got)
+	}
+
+	write(filepath.Join(lang, "fr.json"), `{"code":"fr","messages":{"language.name":"Français"}}`)
+	if count, err := world.ReloadLanguages(); err != nil || count != 3 {
+		t.Fatalf("ReloadLanguages = %d, %v", count, err)
+	}
+	if _, err := world.SetLanguage(p, "en"); err != nil {
+		t.Fatalf("SetLanguage: %v", err)
+	}
+	if got := accounts.Profile("Greta").Language; got != "" {
+		t.Fatalf("expected English to clear the saved language, got %q", got)
+	}
+
+	write(filepath.Join(lang, "es.json"), `{"code":"pt","messages":{}}`)
+	if _, err := world.ReloadLanguages(); err == nil {
+		t.Fatalf("expected a mismatched catalog code to fail the reload")
+	}
+}
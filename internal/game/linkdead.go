@@ -0,0 +1,189 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultLinkdeadGrace is how long a dropped player stays in the world
+// waiting to reconnect.
+const DefaultLinkdeadGrace = 3 * time.Minute
+
+// linkdeadCombatRounds is how many combat rounds a linkdead player keeps
+// fighting before foes lose interest in them.
+const linkdeadCombatRounds = 3
+
+// linkdeadBufferLimit caps how many messages are held for a linkdead player
+// to read when they reconnect.
+const linkdeadBufferLimit = 100
+
+// ConfigureLinkdeadGrace sets how long dropped players stay in the world. A
+// zero duration logs them out as soon as their connection drops.
+func (w *World) ConfigureLinkdeadGrace(grace time.Duration) {
+	w.mu.Lock()
+	w.linkdeadGrace = &grace
+	w.mu.Unlock()
+}
+
+func (w *World) linkdeadGraceLocked() time.Duration {
+	if w.linkdeadGrace == nil {
+		return DefaultLinkdeadGrace
+	}
+	return *w.linkdeadGrace
+}
+
+// Linkdead reports whether p lost their connection and is waiting to
+// reconnect.
+func (w *World) Linkdead(p *Player) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.linkdead
+}
+
+// MarkLinkdead keeps p in the world after session dropped. It reports false
+// when no grace period is configured or p has already moved to another
+// session, in which case the caller logs p out as usual.
+func (w *World) MarkLinkdead(p *Player, session *TelnetSession) bool {
+	w.mu.Lock()
+	grace := w.linkdeadGraceLocked()
+	if grace <= 0 || !p.Alive || p.Session != session {
+		w.mu.Unlock()
+		return false
+	}
+	p.linkMu.Lock()
+	p.Session = nil
+	p.linkdead = true
+	// Output paused behind a --More-- prompt is replayed on reconnect.
+	p.linkdeadOutput = p.resetPagerLocked()
+	p.linkMu.Unlock()
+	p.linkdeadSince = time.Now()
+	since := p.linkdeadSince
+	p.linkdeadTimer = time.AfterFunc(grace, func() { w.expireLinkdead(p, since) })
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s has lost their link.", HighlightName(p.Name))), p)
+	Logger().Info("player linkdead", "player", p.Name, "grace", grace)
+	return true
+}
+
+// Reconnect attaches session to a linkdead player, returning the messages
+// they missed while away.
+func (w *World) Reconnect(name string, session *TelnetSession) (*Player, []string, bool) {
+	w.mu.Lock()
+	p, ok := w.players[name]
+	if !ok || !p.Alive || !p.linkdead {
+		w.mu.Unlock()
+		return nil, nil, false
+	}
+	if p.linkdeadTimer != nil {
+		p.linkdeadTimer.Stop()
+		p.linkdeadTimer = nil
+	}
+	p.linkMu.Lock()
+	missed := p.linkdeadOutput
+	p.linkdeadOutput = nil
+	p.linkdead = false
+	p.Session = session
+	p.resetPagerLocked()
+	p.linkMu.Unlock()
+	p.linkdeadSince = time.Time{}
+	w.mu.Unlock()
+	w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s has reconnected.", HighlightName(p.Name))), p)
+	Logger().Info("player reconnected", "player", p.Name)
+	return p, missed, true
+}
+
+// expireLinkdead logs out a player whose grace period ran out.
+func (w *World) expireLinkdead(p *Player, since time.Time) {
+	w.mu.Lock()
+	if !p.linkdead || !p.linkdeadSince.Equal(since) {
+		w.mu.Unlock()
+		return
+	}
+	p.linkMu.Lock()
+	p.linkdead = false
+	p.linkMu.Unlock()
+	p.linkdeadTimer = nil
+	p.Alive = false
+	w.syncPresenceLocked(p)
+	w.mu.Unlock()
+	w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s fades away.", HighlightName(p.Name))), p)
+	w.NotifyFriends(p, false)
+	w.PersistPlayer(p)
+	w.removePlayer(p.Name)
+	Logger().Info("linkdead player logged out", "player", p.Name)
+}
+
+// linkdeadPaused reports whether p has been linkdead long enough that combat
+// should stop targeting them.
+func (w *World) linkdeadPaused(name string, round time.Duration) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	p, ok := w.players[name]
+	if !ok || !p.linkdead {
+		return false
+	}
+	return time.Since(p.linkdeadSince) >= linkdeadCombatRounds*round
+}
+
+// holdLinkdeadLocked keeps msg for a linkdead player to read on reconnect,
+// dropping the oldest held message past linkdeadBufferLimit. Callers must
+// hold p.linkMu.
+func (p *Player) holdLinkdeadLocked(msg string) {
+	p.linkdeadOutput = append(p.linkdeadOutput, msg)
+	if excess := len(p.linkdeadOutput) - linkdeadBufferLimit; excess > 0 {
+		p.linkdeadOutput = append([]string(nil), p.linkdeadOutput[excess:]...)
+	}
+}
+
+// pumpOutput writes everything sent to output to the player's current
+// session, paged to fit their screen, and any attached portal console. While
+// the player is linkdead the messages are held so they can catch up after
+// reconnecting. It uses the player's link lock rather than the world lock so
+// a busy world never holds up a player's output. If the queue overflows the
+// client is disconnected.
+func (w *World) pumpOutput(p *Player, output *OutputQueue) {
+	for {
+		msgs, ok := output.out.next()
+		if !ok {
+			break
+		}
+		for _, out := range msgs {
+			w.pumpMessage(p, out)
+		}
+	}
+	if !output.out.overflow() {
+		return
+	}
+	p.linkMu.Lock()
+	session := p.Session
+	p.linkMu.Unlock()
+	if session != nil {
+		Logger().Warn("disconnecting player whose output fell behind", "player", p.Name, "queued", OutboxLimit)
+		go session.Close()
+	}
+}
+
+func (w *World) pumpMessage(p *Player, out string) {
+	p.linkMu.Lock()
+	session := p.Session
+	console := p.console
+	write := ""
+	if session != nil {
+		write = p.pageLocked(out)
+	}
+	if session == nil && p.linkdead {
+		p.holdLinkdeadLocked(out)
+	}
+	p.linkMu.Unlock()
+	if write != "" {
+		session.Send(write)
+	}
+	if console != nil {
+		select {
+		case console <- out:
+		default:
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinkdeadPlayerStaysAndReconnects(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	dropped := &Player{Name: "Dropped", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	watcher := &Player{Name: "Watcher", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(dropped)
+	world.AddPlayerForTest(watcher)
+	done := make(chan struct{})
+	go func() {
+		world.pumpOutput(dropped, dropped.Output)
+		close(done)
+	}()
+
+	if !world.MarkLinkdead(dropped, nil) {
+		t.Fatalf("MarkLinkdead returned false with the default grace period")
+	}
+	if _, ok := world.ActivePlayer("Dropped"); !ok || !world.Linkdead(dropped) {
+		t.Fatalf("linkdead player should stay in the world")
+	}
+	if got := stripAnsi(strings.Join(drainOutput(watcher.Output), "")); !strings.Contains(got, "Dropped has lost their link.") {
+		t.Fatalf("room was not told about the drop: %q", got)
+	}
+
+	dropped.Output.Send("\r\nWatcher says: are you there?")
+	deadline := time.Now().Add(time.Second)
+	for {
+		dropped.linkMu.Lock()
+		held := len(dropped.linkdeadOutput)
+		dropped.linkMu.Unlock()
+		if held == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("output was not held while linkdead")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	p, missed, ok := world.Reconnect("Dropped", nil)
+	if !ok || p != dropped || len(missed) != 1 || !strings.Contains(missed[0], "are you there?") {
+		t.Fatalf("Reconnect = %v, %q, %v", p, missed, ok)
+	}
+	if world.Linkdead(dropped) {
+		t.Fatalf("player should no longer be linkdead")
+	}
+	if _, _, ok := world.Reconnect("Dropped", nil); ok {
+		t.Fatalf("reconnecting a connected player should fail")
+	}
+	world.removePlayer("Dropped")
+	<-done
+}
+
+func TestLinkdeadGraceExpiryLogsOut(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	world.ConfigureLinkdeadGrace(10 * time.Millisecond)
+	p := &Player{Name: "Gone", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if !world.MarkLinkdead(p, nil) {
+		t.Fatalf("MarkLinkdead returned false")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := world.ActivePlayer("Gone"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("linkdead player was not logged out after the grace period")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	world.ConfigureLinkdeadGrace(0)
+	other := &Player{Name: "Other", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(other)
+	if world.MarkLinkdead(other, nil) {
+		t.Fatalf("a zero grace period should log players out immediately")
+	}
+}
+
+func TestCombatDropsLongLinkdeadPlayers(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"pit": {ID: "pit", Title: "Pit", Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Rat"}}}})
+	p := &Player{Name: "Fighter", Room: "pit", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	combat := world.ensureCombat("pit")
+	combat.addPlayer(p.Name, combatTarget{kind: combatTargetNPC, name: "Rat"})
+	combat.addNPC("Rat", combatTarget{kind: combatTargetPlayer, name: p.Name})
+
+	if !world.MarkLinkdead(p, nil) {
+		t.Fatalf("MarkLinkdead returned false")
+	}
+	combat.dropLinkdeadPlayers()
+	if !combat.hasNPC("Rat") {
+		t.Fatalf("a freshly linkdead player should keep fighting for a few rounds")
+	}
+
+	world.mu.Lock()
+	p.linkdeadSince = time.Now().Add(-linkdeadCombatRounds * combat.roundDuration)
+	world.mu.Unlock()
+	combat.dropLinkdeadPlayers()
+	if combat.hasNPC("Rat") {
+		t.Fatalf("the rat should lose interest in a long linkdead player")
+	}
+	combat.mu.Lock()
+	_, fighting := combat.playerTargets[p.Name]
+	combat.mu.Unlock()
+	if fighting {
+		t.Fatalf("the linkdead player should stop attacking")
+	}
+}
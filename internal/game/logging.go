@@ -0,0 +1,272 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultLogMaxBytes is the size at which the server log file is rotated.
+	DefaultLogMaxBytes = 10 << 20
+	// DefaultLogMaxBackups is how many rotated log files are kept.
+	DefaultLogMaxBackups = 5
+	// logFeedHistory is how many recent records the live log feed replays to
+	// new subscribers.
+	logFeedHistory = 200
+)
+
+// LogConfig describes where server logs are written and how verbose they are.
+// An empty Path keeps logging on standard output only.
+type LogConfig struct {
+	Path       string
+	Level      slog.Level
+	MaxBytes   int64
+	MaxBackups int
+}
+
+var (
+	logger   atomic.Pointer[slog.Logger]
+	logWorld atomic.Pointer[World]
+	logs     = &logFeed{subscribers: make(map[chan LogEntry]struct{})}
+)
+
+// LogEntry is a server log record as streamed to the portal console.
+type LogEntry struct {
+	Time  time.Time `json:"time"`
+	Level string    `json:"level"`
+	Text  string    `json:"text"`
+}
+
+// logFeed fans log records out to live subscribers and keeps the most recent
+// ones for late joiners.
+type logFeed struct {
+	mu          sync.Mutex
+	recent      []LogEntry
+	subscribers map[chan LogEntry]struct{}
+}
+
+func (f *logFeed) publish(entry LogEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recent = append(f.recent, entry)
+	if excess := len(f.recent) - logFeedHistory; excess > 0 {
+		f.recent = append([]LogEntry(nil), f.recent[excess:]...)
+	}
+	for ch := range f.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+// SubscribeLogs streams server log records as they are written, starting
+// with the most recent history. Records are dropped rather than block the
+// logger when the subscriber falls behind. Call cancel to unsubscribe.
+func SubscribeLogs() (history []LogEntry, entries <-chan LogEntry, cancel func()) {
+	ch := make(chan LogEntry, 64)
+	logs.mu.Lock()
+	history = append([]LogEntry(nil), logs.recent...)
+	logs.subscribers[ch] = struct{}{}
+	logs.mu.Unlock()
+	var once sync.Once
+	return history, ch, func() {
+		once.Do(func() {
+			logs.mu.Lock()
+			delete(logs.subscribers, ch)
+			logs.mu.Unlock()
+		})
+	}
+}
+
+func init() {
+	logger.Store(slog.New(newLogHandler(os.Stdout, slog.LevelInfo)))
+}
+
+// Logger returns the shared server logger.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// ParseLogLevel converts debug, info, warn, or error into a slog level.
+func ParseLogLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unknown log level: %s", name)
+	}
+	return level, nil
+}
+
+// ConfigureLogging replaces the shared logger. Records are always written to
+// standard output and, when cfg.Path is set, to a size-rotated log file. The
+// returned closer releases the log file.
+func ConfigureLogging(cfg LogConfig) (io.Closer, error) {
+	var out io.Writer = os.Stdout
+	var closer io.Closer = io.NopCloser(nil)
+	if path := strings.TrimSpace(cfg.Path); path != "" {
+		file, err := newRotatingFile(path, cfg.MaxBytes, cfg.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		out = io.MultiWriter(os.Stdout, file)
+		closer = file
+	}
+	logger.Store(slog.New(newLogHandler(out, cfg.Level)))
+	return closer, nil
+}
+
+// attachLogWorld routes warnings and errors to admins connected to world.
+func attachLogWorld(world *World) {
+	logWorld.Store(world)
+}
+
+// logHandler writes text records, feeds them to the live log feed, and mirrors
+// warnings and errors onto the in-game log channel.
+type logHandler struct {
+	slog.Handler
+	attrs []slog.Attr
+}
+
+func newLogHandler(out io.Writer, level slog.Level) *logHandler {
+	return &logHandler{Handler: slog.NewTextHandler(out, &slog.HandlerOptions{Level: level})}
+}
+
+func (h *logHandler) Handle(ctx context.Context, record slog.Record) error {
+	err := h.Handler.Handle(ctx, record)
+	logs.publish(LogEntry{Time: record.Time, Level: record.Level.String(), Text: formatLogLine(record, h.attrs)})
+	if record.Level >= slog.LevelWarn {
+		if world := logWorld.Load(); world != nil {
+			// Deliver asynchronously so records logged while the world lock
+			// is held cannot deadlock.
+			go world.BroadcastLog(record.Level, formatLogLine(record, h.attrs))
+		}
+	}
+	return err
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, attrs...)
+	return &logHandler{Handler: h.Handler.WithAttrs(attrs), attrs: merged}
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{Handler: h.Handler.WithGroup(name), attrs: h.attrs}
+}
+
+func formatLogLine(record slog.Record, attrs []slog.Attr) string {
+	var builder strings.Builder
+	builder.WriteString(record.Message)
+	write := func(attr slog.Attr) bool {
+		fmt.Fprintf(&builder, " %s=%v", attr.Key, attr.Value.Resolve())
+		return true
+	}
+	for _, attr := range attrs {
+		write(attr)
+	}
+	record.Attrs(write)
+	return builder.String()
+}
+
+// rotatingFile is an io.Writer that renames the log to path.1, path.2, ...
+// once it grows beyond maxBytes.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newRotatingFile(path string, maxBytes int64, maxBackups int) (*rotatingFile, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultLogMaxBytes
+	}
+	if maxBackups < 0 {
+		maxBackups = 0
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	r := &rotatingFile{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+	if r.maxBackups == 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return r.open()
+	}
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", r.path, i)
+		to := fmt.Sprintf("%s.%d", r.path, i+1)
+		if err := os.Rename(from, to); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
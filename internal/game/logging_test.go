@@ -0,0 +1,82 @@
+package game
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileKeepsBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "server.log")
+	file, err := newRotatingFile(path, 16, 2)
+	if err != nil {
+		t.Fatalf("newRotatingFile error: %v", err)
+	}
+	defer file.Close()
+	for _, line := range []string{"first line\n", "second line\n", "third line\n", "fourth line\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Write error: %v", err)
+		}
+	}
+	expect := map[string]string{
+		path:        "fourth line\n",
+		path + ".1": "third line\n",
+		path + ".2": "second line\n",
+	}
+	for name, want := range expect {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(data) != want {
+			t.Fatalf("%s = %q, want %q", name, data, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected only two backups, stat err = %v", err)
+	}
+}
+
+func TestLogHandlerForwardsWarningsToAdmins(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	admin := &Player{Name: "Admin", Room: "start", Alive: true, IsAdmin: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(admin)
+	muted := &Player{Name: "Quiet", Room: "start", Alive: true, IsAdmin: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(muted)
+	world.SetChannel(muted, ChannelLog, false)
+	player := &Player{Name: "Player", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+
+	attachLogWorld(world)
+	defer attachLogWorld(nil)
+	var buf bytes.Buffer
+	log := slog.New(newLogHandler(&buf, slog.LevelInfo)).With("area", "midgaard")
+
+	log.Info("routine message")
+	log.Warn("script failed", "room", "start")
+
+	msg := awaitOutput(admin.Output, 2*time.Second)
+	if !strings.Contains(msg, "[LOG WARN]") || !strings.Contains(msg, "script failed area=midgaard room=start") {
+		t.Fatalf("unexpected admin log line: %q", msg)
+	}
+	if strings.Contains(msg, "routine message") {
+		t.Fatalf("info records should stay off the log channel, got %q", msg)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if msg := awaitOutput(admin.Output, 0); msg != "" {
+		t.Fatalf("info records should stay off the log channel, got %q", msg)
+	}
+	if msg := awaitOutput(muted.Output, 0); msg != "" {
+		t.Fatalf("admin with the log channel off received %q", msg)
+	}
+	if msg := awaitOutput(player.Output, 0); msg != "" {
+		t.Fatalf("non-admin received %q", msg)
+	}
+	if !strings.Contains(buf.String(), "routine message") || !strings.Contains(buf.String(), "level=WARN") {
+		t.Fatalf("expected both records in the text log, got %q", buf.String())
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLoginFailureLimit is how many failed logins an address may make
+	// within DefaultLoginFailureWindow before it is locked out.
+	DefaultLoginFailureLimit = 5
+	// DefaultLoginFailureWindow is the period over which failures are counted.
+	DefaultLoginFailureWindow = 10 * time.Minute
+	// DefaultLoginLockout is how long an address is refused after reaching
+	// the failure limit.
+	DefaultLoginLockout = 15 * time.Minute
+)
+
+type loginFailures struct {
+	count       int
+	first       time.Time
+	lockedUntil time.Time
+}
+
+// LoginThrottle rate-limits repeated failed logins per remote address.
+type LoginThrottle struct {
+	mu       sync.Mutex
+	limit    int
+	window   time.Duration
+	lockout  time.Duration
+	failures map[string]*loginFailures
+}
+
+// NewLoginThrottle creates a throttle that locks an address out for lockout
+// after limit failures within window. A non-positive limit disables it.
+func NewLoginThrottle(limit int, window, lockout time.Duration) *LoginThrottle {
+	return &LoginThrottle{
+		limit:    limit,
+		window:   window,
+		lockout:  lockout,
+		failures: make(map[string]*loginFailures),
+	}
+}
+
+// Blocked reports whether the address is locked out and for how much longer.
+func (t *LoginThrottle) Blocked(addr string, now time.Time) (time.Duration, bool) {
+	if t == nil || t.limit <= 0 {
+		return 0, false
+	}
+	key := strings.TrimSpace(addr)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	record, ok := t.failures[key]
+	if !ok {
+		return 0, false
+	}
+	if now.Before(record.lockedUntil) {
+		return record.lockedUntil.Sub(now), true
+	}
+	if !record.lockedUntil.IsZero() || now.Sub(record.first) > t.window {
+		delete(t.failures, key)
+	}
+	return 0, false
+}
+
+// Fail records a failed login and reports whether the address is now locked
+// out.
+func (t *LoginThrottle) Fail(addr string, now time.Time) bool {
+	if t == nil || t.limit <= 0 {
+		return false
+	}
+	key := strings.TrimSpace(addr)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pruneLocked(now)
+	record, ok := t.failures[key]
+	if !ok || now.Sub(record.first) > t.window {
+		record = &loginFailures{first: now}
+		t.failures[key] = record
+	}
+	record.count++
+	if record.count >= t.limit {
+		record.lockedUntil = now.Add(t.lockout)
+		return true
+	}
+	return false
+}
+
+// Reset forgets failures for the address after a successful login.
+func (t *LoginThrottle) Reset(addr string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	delete(t.failures, strings.TrimSpace(addr))
+	t.mu.Unlock()
+}
+
+func (t *LoginThrottle) pruneLocked(now time.Time) {
+	for key, record := range t.failures {
+		if now.Before(record.lockedUntil) {
+			continue
+		}
+		if !record.lockedUntil.IsZero() || now.Sub(record.first) > t.window {
+			delete(t.failures, key)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const lootFileName = "loot.json"
+
+// Rarity grades an item dropped by an NPC. Rarer items carry more affixes.
+type Rarity string
+
+const (
+	// Common items drop unchanged.
+	Common Rarity = "common"
+	// Uncommon items carry one affix.
+	Uncommon Rarity = "uncommon"
+	// Rare items carry a prefix and a suffix.
+	Rare Rarity = "rare"
+	// Epic items carry a prefix and a suffix at double strength.
+	Epic Rarity = "epic"
+)
+
+// Rarities lists the tiers from most to least common.
+func Rarities() []Rarity {
+	return []Rarity{Common, Uncommon, Rare, Epic}
+}
+
+// Label returns the tier's display name.
+func (r Rarity) Label() string {
+	switch r {
+	case Common, "":
+		return "Common"
+	case Uncommon:
+		return "Uncommon"
+	case Rare:
+		return "Rare"
+	case Epic:
+		return "Epic"
+	}
+	return string(r)
+}
+
+func (r Rarity) valid() bool {
+	for _, known := range Rarities() {
+		if r == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rarity) affixCount() int {
+	switch r {
+	case Uncommon:
+		return 1
+	case Rare, Epic:
+		return 2
+	}
+	return 0
+}
+
+func (r Rarity) power() int {
+	if r == Epic {
+		return 2
+	}
+	return 1
+}
+
+func (r Rarity) color() string {
+	switch r {
+	case Uncommon:
+		return AnsiGreen
+	case Rare:
+		return AnsiBlue
+	case Epic:
+		return AnsiMagenta
+	}
+	return AnsiYellow
+}
+
+// Affix is a named bonus rolled onto a dropped item, such as "of the Bear"
+// granting strength. Prefixes go before the item's name and suffixes after.
+type Affix struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     bool       `json:"prefix,omitempty"`
+	Attributes Attributes `json:"attributes"`
+	Damage     int        `json:"damage,omitempty"`
+}
+
+// DropTable sets the percentage chance of each rarity for NPCs within a level
+// band. Whatever the chances leave over drops as common.
+type DropTable struct {
+	MinLevel int            `json:"min_level"`
+	MaxLevel int            `json:"max_level,omitempty"`
+	Chances  map[Rarity]int `json:"chances"`
+}
+
+func (t DropTable) covers(level int) bool {
+	return level >= t.MinLevel && (t.MaxLevel <= 0 || level <= t.MaxLevel)
+}
+
+// LootTables holds the affixes and drop tables defined in loot.json.
+type LootTables struct {
+	Affixes []Affix     `json:"affixes"`
+	Drops   []DropTable `json:"drops"`
+}
+
+func lootPath(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), lootFileName)
+}
+
+func loadLootTables(areasPath string) (LootTables, error) {
+	path := lootPath(areasPath)
+	if path == "" {
+		return LootTables{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return LootTables{}, nil
+		}
+		return LootTables{}, err
+	}
+	var parsed LootTables
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return LootTables{}, fmt.Errorf("parse loot: %w", err)
+	}
+	if err := parsed.normalize(); err != nil {
+		return LootTables{}, fmt.Errorf("parse loot: %w", err)
+	}
+	return parsed, nil
+}
+
+func (t *LootTables) normalize() error {
+	seen := make(map[string]bool, len(t.Affixes))
+	for i := range t.Affixes {
+		affix := &t.Affixes[i]
+		affix.ID = strings.ToLower(strings.TrimSpace(affix.ID))
+		affix.Name = strings.TrimSpace(affix.Name)
+		if affix.ID == "" || affix.Name == "" {
+			return fmt.Errorf("affixes need an id and a name")
+		}
+		if seen[affix.ID] {
+			return fmt.Errorf("duplicate affix %s", affix.ID)
+		}
+		seen[affix.ID] = true
+		// Pools are sized at login, so only attributes read on demand
+		// can come from items.
+		if affix.Attributes.Con != 0 || affix.Attributes.Int != 0 {
+			return fmt.Errorf("affix %s: only str and dex bonuses are supported", affix.ID)
+		}
+	}
+	for i, table := range t.Drops {
+		if table.MaxLevel > 0 && table.MaxLevel < table.MinLevel {
+			return fmt.Errorf("drop table %d ends below its minimum level", i+1)
+		}
+		total := 0
+		for rarity, chance := range table.Chances {
+			if !rarity.valid() {
+				return fmt.Errorf("drop table %d: unknown rarity %q", i+1, rarity)
+			}
+			if chance < 0 {
+				return fmt.Errorf("drop table %d: negative chance for %s", i+1, rarity)
+			}
+			total += chance
+		}
+		if total > 100 {
+			return fmt.Errorf("drop table %d: chances add up to %d%%", i+1, total)
+		}
+	}
+	return nil
+}
+
+// rollRarity picks a tier from the first drop table covering level.
+func (t LootTables) rollRarity(dice Dice, level int) Rarity {
+	for _, table := range t.Drops {
+		if !table.covers(level) {
+			continue
+		}
+		roll := dice.IntN(100)
+		for i := len(Rarities()) - 1; i > 0; i-- {
+			rarity := Rarities()[i]
+			if roll < table.Chances[rarity] {
+				return rarity
+			}
+			roll -= table.Chances[rarity]
+		}
+		return Common
+	}
+	return Common
+}
+
+// rollItem rolls a rarity for an item dropped by an NPC of the given level
+// and attaches that many affixes, drawing from dice. Corpses and items that
+// already carry a rarity drop unchanged.
+func (t LootTables) rollItem(dice Dice, item Item, level int) Item {
+	if item.Corpse || item.Rarity != "" || len(t.Affixes) == 0 {
+		return item
+	}
+	rarity := t.rollRarity(dice, level)
+	if rarity == Common {
+		return item
+	}
+	var prefixes, suffixes []Affix
+	for _, affix := range t.Affixes {
+		if affix.Prefix {
+			prefixes = append(prefixes, affix)
+		} else {
+			suffixes = append(suffixes, affix)
+		}
+	}
+	var chosen []Affix
+	if rarity.affixCount() == 1 {
+		chosen = append(chosen, t.Affixes[dice.IntN(len(t.Affixes))])
+	} else {
+		if len(prefixes) > 0 {
+			chosen = append(chosen, prefixes[dice.IntN(len(prefixes))])
+		}
+		if len(suffixes) > 0 {
+			chosen = append(chosen, suffixes[dice.IntN(len(suffixes))])
+		}
+	}
+	power := rarity.power()
+	for i := range chosen {
+		chosen[i].Attributes = Attributes{Str: chosen[i].Attributes.Str * power, Dex: chosen[i].Attributes.Dex * power}
+		chosen[i].Damage *= power
+	}
+	item.Contents = append([]Item(nil), item.Contents...)
+	item.Base = item.Name
+	item.Rarity = rarity
+	item.Affixes = chosen
+	item.Name = affixedName(item.Base, chosen)
+	return item
+}
+
+func affixedName(base string, affixes []Affix) string {
+	parts := make([]string, 0, len(affixes)+1)
+	for _, affix := range affixes {
+		if affix.Prefix {
+			parts = append(parts, affix.Name)
+		}
+	}
+	parts = append(parts, base)
+	for _, affix := range affixes {
+		if !affix.Prefix {
+			parts = append(parts, affix.Name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// rollLootLocked copies an NPC's loot, rolling rarity and affixes for each
+// item.
+func (w *World) rollLootLocked(npc NPC) []Item {
+	loot := make([]Item, len(npc.Loot))
+	for i, item := range npc.Loot {
+		loot[i] = w.lootTables.rollItem(DiceFunc(w.roll), item, npc.Level)
+	}
+	return loot
+}
+
+// BaseName is the item's name without affixes. Quests match on it.
+func (i Item) BaseName() string {
+	if i.Base != "" {
+		return i.Base
+	}
+	return i.Name
+}
+
+// DisplayName highlights the item's name in its rarity's color.
+func (i Item) DisplayName() string {
+	return Style(i.Name, AnsiBold, i.Rarity.color())
+}
+
+// Bonus totals the attributes and damage the item's affixes grant.
+func (i Item) Bonus() StatModifiers {
+	var bonus StatModifiers
+	for _, affix := range i.Affixes {
+		bonus.Attributes = bonus.Attributes.plus(affix.Attributes)
+		bonus.Damage += affix.Damage
+	}
+	return bonus
+}
+
+// carriedBonus is the best bonus to each stat among the carried items.
+// Bonuses from several items do not stack.
+func carriedBonus(items []Item) StatModifiers {
+	var best StatModifiers
+	for _, item := range items {
+		bonus := item.Bonus()
+		best.Str = max(best.Str, bonus.Str)
+		best.Dex = max(best.Dex, bonus.Dex)
+		best.Damage = max(best.Damage, bonus.Damage)
+	}
+	return best
+}
+
+// ReloadLoot re-reads the loot file. Items already dropped keep their affixes.
+func (w *World) ReloadLoot() (int, int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	tables, err := loadLootTables(areasPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	w.mu.Lock()
+	w.lootTables = tables
+	w.mu.Unlock()
+	return len(tables.Affixes), len(tables.Drops), nil
+}
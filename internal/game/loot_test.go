@@ -0,0 +1,111 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShippedLootLoads(t *testing.T) {
+	tables, err := loadLootTables("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadLootTables error: %v", err)
+	}
+	if len(tables.Affixes) == 0 || len(tables.Drops) == 0 {
+		t.Fatalf("shipped loot defines %d affixes and %d drop tables", len(tables.Affixes), len(tables.Drops))
+	}
+}
+
+func TestLootTablesRejectBadChances(t *testing.T) {
+	tables := LootTables{Drops: []DropTable{{MinLevel: 1, Chances: map[Rarity]int{Rare: 60, Uncommon: 50}}}}
+	if err := tables.normalize(); err == nil {
+		t.Fatalf("chances over 100%% should be rejected")
+	}
+	tables = LootTables{Drops: []DropTable{{MinLevel: 1, Chances: map[Rarity]int{"mythic": 5}}}}
+	if err := tables.normalize(); err == nil {
+		t.Fatalf("unknown rarity should be rejected")
+	}
+}
+
+func testLootTables() LootTables {
+	return LootTables{
+		Affixes: []Affix{
+			{ID: "sturdy", Name: "Sturdy", Prefix: true, Attributes: Attributes{Str: 1}},
+			{ID: "bear", Name: "of the Bear", Attributes: Attributes{Str: 2}},
+			{ID: "striking", Name: "of Striking", Damage: 2},
+		},
+		Drops: []DropTable{
+			{MinLevel: 1, MaxLevel: 3, Chances: map[Rarity]int{Uncommon: 20}},
+			{MinLevel: 4, Chances: map[Rarity]int{Epic: 10, Rare: 10}},
+		},
+	}
+}
+
+func TestRollItemAddsAffixesByRarity(t *testing.T) {
+	tables := testLootTables()
+	core := Item{Name: "Resonant Core"}
+
+	if item := tables.rollItem(&scriptedDice{50}, core, 2); item.Name != "Resonant Core" || item.Rarity != "" {
+		t.Fatalf("a roll past every chance should drop common, got %+v", item)
+	}
+
+	item := tables.rollItem(&scriptedDice{5, 1}, core, 2)
+	if item.Rarity != Uncommon || item.Name != "Resonant Core of the Bear" || item.BaseName() != "Resonant Core" {
+		t.Fatalf("uncommon roll = %+v", item)
+	}
+
+	item = tables.rollItem(&scriptedDice{12, 0, 1}, core, 5)
+	if item.Rarity != Rare || item.Name != "Sturdy Resonant Core of Striking" {
+		t.Fatalf("rare roll = %+v", item)
+	}
+
+	item = tables.rollItem(&scriptedDice{3, 0, 0}, core, 9)
+	if item.Rarity != Epic || item.Name != "Sturdy Resonant Core of the Bear" {
+		t.Fatalf("epic roll = %+v", item)
+	}
+	if bonus := item.Bonus(); bonus.Str != 6 {
+		t.Fatalf("epic affixes should double, got %+v", bonus)
+	}
+	if !strings.Contains(item.DisplayName(), AnsiMagenta) {
+		t.Fatalf("epic items should display in magenta: %q", item.DisplayName())
+	}
+}
+
+func TestNPCDropsRollAffixes(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{}, NPCs: []NPC{{
+			Name:  "Ember Warden",
+			Level: 2,
+			Loot:  []Item{{Name: "Resonant Core"}},
+		}}},
+	})
+	world.lootTables = testLootTables()
+	world.SetDice(&scriptedDice{0, 1})
+
+	result, err := world.ApplyDamageToNPC(StartRoom, "Ember Warden", 1000)
+	if err != nil {
+		t.Fatalf("ApplyDamageToNPC error: %v", err)
+	}
+	if len(result.Loot) != 1 || result.Loot[0].Name != "Resonant Core of the Bear" {
+		t.Fatalf("loot = %+v", result.Loot)
+	}
+	if !strings.Contains(FormatCorpseDrop("Ember Warden", result.Corpse, result.Loot), AnsiGreen) {
+		t.Fatalf("uncommon drops should be announced in green")
+	}
+}
+
+func TestCarriedAffixesDoNotStack(t *testing.T) {
+	player := &Player{Name: "Brom", Output: NewOutputQueue(), Alive: true}
+	base := player.Attributes().Str
+	damage := player.AttackDamage()
+	player.Inventory = []Item{
+		{Name: "Ring of the Bear", Affixes: []Affix{{Name: "of the Bear", Attributes: Attributes{Str: 2}}}},
+		{Name: "Band of the Bear", Affixes: []Affix{{Name: "of the Bear", Attributes: Attributes{Str: 2}}}},
+		{Name: "Blade of Striking", Affixes: []Affix{{Name: "of Striking", Damage: 2}}},
+	}
+	if got := player.Attributes().Str; got != base+2 {
+		t.Fatalf("strength = %d, want %d", got, base+2)
+	}
+	if got := player.AttackDamage(); got != damage+2+1 {
+		t.Fatalf("damage = %d, want %d", got, damage+3)
+	}
+}
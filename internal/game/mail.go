@@ -5,21 +5,44 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 )
 
+// PersonalMailBoard holds player-to-player letters sent with `mail send`.
+const PersonalMailBoard = "personal"
+
+// DefaultMailboxQuota caps the personal letters stored for each recipient.
+const DefaultMailboxQuota = 50
+
+var (
+	// ErrMailNotFound indicates the requested message does not exist or is not visible.
+	ErrMailNotFound = errors.New("message not found")
+	// ErrMailboxFull indicates a recipient has reached their mailbox quota.
+	ErrMailboxFull = errors.New("mailbox is full")
+	// ErrNoAttachments indicates the message carries nothing to claim.
+	ErrNoAttachments = errors.New("message has no attachments")
+	// ErrAttachmentsPending prevents deleting letters whose attachments are unclaimed.
+	ErrAttachmentsPending = errors.New("claim the attachments before deleting the message")
+)
+
 // MailMessage represents a single entry on a public board.
 type MailMessage struct {
-	ID         int       `json:"id"`
-	Board      string    `json:"board"`
-	Author     string    `json:"author"`
-	Recipients []string  `json:"recipients,omitempty"`
-	Body       string    `json:"body"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID            int       `json:"id"`
+	Board         string    `json:"board"`
+	Author        string    `json:"author"`
+	Recipients    []string  `json:"recipients,omitempty"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+	Attachments   []Item    `json:"attachments,omitempty"`
+	Gold          int       `json:"gold,omitempty"`
+	ClaimedBy     string    `json:"claimed_by,omitempty"`
+	ClaimedAt     time.Time `json:"claimed_at,omitempty"`
+	ForwardedFrom int       `json:"forwarded_from,omitempty"`
+	// ReadAt records when the recipient first read a personal letter.
+	ReadAt time.Time `json:"read_at,omitempty"`
 }
 
 // MailSystem manages persistent public board messages.
@@ -28,6 +51,7 @@ type MailSystem struct {
 	path   string
 	nextID int
 	boards map[string][]MailMessage
+	quota  int
 }
 
 // NewMailSystem constructs a mail system backed by the provided file path.
@@ -37,11 +61,12 @@ func NewMailSystem(path string) (*MailSystem, error) {
 		path:   path,
 		nextID: 1,
 		boards: make(map[string][]MailMessage),
+		quota:  DefaultMailboxQuota,
 	}
 	if strings.TrimSpace(path) == "" {
 		return ms, nil
 	}
-	data, err := os.ReadFile(path)
+	data, err := documentStorage().Read(path)
 	if errors.Is(err, os.ErrNotExist) {
 		return ms, nil
 	}
@@ -201,20 +226,294 @@ func (m *MailSystem) Write(board, author string, recipients []string, body strin
 	if body == "" {
 		return MailMessage{}, fmt.Errorf("message body is required")
 	}
-	author = strings.TrimSpace(author)
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	msg := MailMessage{
-		ID:         m.nextID,
+	return m.appendLocked(MailMessage{
 		Board:      key,
-		Author:     author,
+		Author:     strings.TrimSpace(author),
 		Recipients: normalizeRecipients(recipients),
 		Body:       body,
-		CreatedAt:  time.Now().UTC(),
+	})
+}
+
+// Send delivers a personal letter to a single recipient, optionally carrying
+// item attachments the recipient can claim later.
+func (m *MailSystem) Send(author, recipient, body string, attachments []Item) (MailMessage, error) {
+	recipients := normalizeRecipients([]string{recipient})
+	if len(recipients) == 0 {
+		return MailMessage{}, fmt.Errorf("recipient is required")
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return MailMessage{}, fmt.Errorf("message body is required")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.appendLocked(MailMessage{
+		Board:       PersonalMailBoard,
+		Author:      strings.TrimSpace(author),
+		Recipients:  recipients,
+		Body:        body,
+		Attachments: cloneItems(attachments),
+	})
+}
+
+// Deliver posts a personal letter carrying items or gold on behalf of the
+// game itself, such as market proceeds. Unlike Send it ignores the mailbox
+// quota so that nothing owed to a player is lost to a full mailbox.
+func (m *MailSystem) Deliver(author, recipient, body string, attachments []Item, gold int) (MailMessage, error) {
+	recipients := normalizeRecipients([]string{recipient})
+	if len(recipients) == 0 {
+		return MailMessage{}, fmt.Errorf("recipient is required")
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return MailMessage{}, fmt.Errorf("message body is required")
+	}
+	if gold < 0 {
+		gold = 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.insertLocked(MailMessage{
+		Board:       PersonalMailBoard,
+		Author:      strings.TrimSpace(author),
+		Recipients:  recipients,
+		Body:        body,
+		Attachments: cloneItems(attachments),
+		Gold:        gold,
+	})
+}
+
+// Forward sends a copy of a message visible to forwarder on to a new
+// recipient as a personal letter. Attachments stay with the original.
+func (m *MailSystem) Forward(id int, forwarder, recipient, note string) (MailMessage, error) {
+	recipients := normalizeRecipients([]string{recipient})
+	if len(recipients) == 0 {
+		return MailMessage{}, fmt.Errorf("recipient is required")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	original, ok := m.findLocked(id)
+	if !ok || !original.AddressedTo(forwarder) {
+		return MailMessage{}, ErrMailNotFound
+	}
+	var body strings.Builder
+	if trimmed := strings.TrimSpace(note); trimmed != "" {
+		body.WriteString(trimmed)
+		body.WriteString("\n")
+	}
+	body.WriteString(fmt.Sprintf("--- Forwarded message #%d from %s ---\n", original.ID, original.Author))
+	body.WriteString(original.Body)
+	return m.appendLocked(MailMessage{
+		Board:         PersonalMailBoard,
+		Author:        strings.TrimSpace(forwarder),
+		Recipients:    recipients,
+		Body:          body.String(),
+		ForwardedFrom: original.ID,
+	})
+}
+
+// Message returns the message with the provided identifier.
+func (m *MailSystem) Message(id int) (MailMessage, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	msg, ok := m.findLocked(id)
+	if !ok {
+		return MailMessage{}, false
 	}
+	return copyMailMessage(*msg), true
+}
+
+// AllMessages returns every stored message ordered by identifier for audits.
+func (m *MailSystem) AllMessages() []MailMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []MailMessage
+	for _, list := range m.boards {
+		for _, msg := range list {
+			out = append(out, copyMailMessage(msg))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// SetMailboxQuota changes how many personal letters each player may hold. A
+// value of zero or less disables the quota.
+func (m *MailSystem) SetMailboxQuota(quota int) {
+	m.mu.Lock()
+	m.quota = quota
+	m.mu.Unlock()
+}
+
+// MailboxQuota reports the personal letter quota.
+func (m *MailSystem) MailboxQuota() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.quota
+}
+
+// MailboxCount reports how many personal letters are addressed to player.
+func (m *MailSystem) MailboxCount(player string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mailboxCountLocked(player)
+}
+
+func (m *MailSystem) mailboxCountLocked(player string) int {
+	count := 0
+	for _, msg := range m.boards[PersonalMailBoard] {
+		if len(msg.Recipients) > 0 && msg.AddressedTo(player) {
+			count++
+		}
+	}
+	return count
+}
+
+// UnreadCount reports how many personal letters addressed to player have not
+// been read.
+func (m *MailSystem) UnreadCount(player string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	count := 0
+	for _, msg := range m.boards[PersonalMailBoard] {
+		if len(msg.Recipients) > 0 && msg.AddressedTo(player) && msg.ReadAt.IsZero() {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkRead records that player has read the personal letter id. Letters
+// already read keep their original time.
+func (m *MailSystem) MarkRead(id int, player string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msg, ok := m.findLocked(id)
+	if !ok || msg.Board != PersonalMailBoard || len(msg.Recipients) == 0 || !msg.AddressedTo(player) {
+		return ErrMailNotFound
+	}
+	if !msg.ReadAt.IsZero() {
+		return nil
+	}
+	msg.ReadAt = time.Now().UTC()
+	if err := m.saveLocked(); err != nil {
+		msg.ReadAt = time.Time{}
+		return err
+	}
+	return nil
+}
+
+// MarkInboxRead records that player has read every personal letter addressed
+// to them.
+func (m *MailSystem) MarkInboxRead(player string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := m.boards[PersonalMailBoard]
+	now := time.Now().UTC()
+	var marked []int
+	for i := range list {
+		if len(list[i].Recipients) > 0 && list[i].AddressedTo(player) && list[i].ReadAt.IsZero() {
+			list[i].ReadAt = now
+			marked = append(marked, i)
+		}
+	}
+	if len(marked) == 0 {
+		return nil
+	}
+	if err := m.saveLocked(); err != nil {
+		for _, i := range marked {
+			list[i].ReadAt = time.Time{}
+		}
+		return err
+	}
+	return nil
+}
+
+// takeAttachments removes unclaimed attachments and gold from a letter
+// addressed to player and records the claim.
+func (m *MailSystem) takeAttachments(id int, player string) ([]Item, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msg, ok := m.findLocked(id)
+	if !ok || len(msg.Recipients) == 0 || !msg.AddressedTo(player) {
+		return nil, 0, ErrMailNotFound
+	}
+	if len(msg.Attachments) == 0 && msg.Gold == 0 {
+		return nil, 0, ErrNoAttachments
+	}
+	previous := *msg
+	items, gold := msg.Attachments, msg.Gold
+	msg.Attachments = nil
+	msg.Gold = 0
+	msg.ClaimedBy = strings.TrimSpace(player)
+	msg.ClaimedAt = time.Now().UTC()
+	if err := m.saveLocked(); err != nil {
+		*msg = previous
+		return nil, 0, err
+	}
+	return items, gold, nil
+}
+
+// Delete removes a personal letter from the player's mailbox.
+func (m *MailSystem) Delete(id int, player string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := m.boards[PersonalMailBoard]
+	idx := -1
+	for i := range list {
+		if list[i].ID == id && len(list[i].Recipients) > 0 && list[i].AddressedTo(player) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ErrMailNotFound
+	}
+	if len(list[idx].Attachments) > 0 || list[idx].Gold > 0 {
+		return ErrAttachmentsPending
+	}
+	updated := make([]MailMessage, 0, len(list)-1)
+	updated = append(updated, list[:idx]...)
+	updated = append(updated, list[idx+1:]...)
+	m.boards[PersonalMailBoard] = updated
+	if err := m.saveLocked(); err != nil {
+		m.boards[PersonalMailBoard] = list
+		return err
+	}
+	return nil
+}
+
+func (m *MailSystem) findLocked(id int) (*MailMessage, bool) {
+	for board, list := range m.boards {
+		for i := range list {
+			if list[i].ID == id {
+				return &m.boards[board][i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (m *MailSystem) appendLocked(msg MailMessage) (MailMessage, error) {
+	if msg.Board == PersonalMailBoard && m.quota > 0 {
+		for _, recipient := range msg.Recipients {
+			if m.mailboxCountLocked(recipient) >= m.quota {
+				return MailMessage{}, fmt.Errorf("%w: %s", ErrMailboxFull, recipient)
+			}
+		}
+	}
+	return m.insertLocked(msg)
+}
+
+func (m *MailSystem) insertLocked(msg MailMessage) (MailMessage, error) {
+	msg.ID = m.nextID
+	msg.CreatedAt = time.Now().UTC()
 	if msg.ID <= 0 {
 		msg.ID = m.computeNextID()
 	}
+	key := msg.Board
 	m.boards[key] = append(m.boards[key], msg)
 	m.nextID = msg.ID + 1
 	if err := m.saveLocked(); err != nil {
@@ -224,21 +523,19 @@ func (m *MailSystem) Write(board, author string, recipients []string, body strin
 		m.nextID = msg.ID
 		return MailMessage{}, err
 	}
-	return msg, nil
+	return copyMailMessage(msg), nil
+}
+
+func copyMailMessage(msg MailMessage) MailMessage {
+	msg.Recipients = append([]string(nil), msg.Recipients...)
+	msg.Attachments = cloneItems(msg.Attachments)
+	return msg
 }
 
 func (m *MailSystem) saveLocked() error {
 	if strings.TrimSpace(m.path) == "" {
 		return nil
 	}
-	dir := filepath.Dir(m.path)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("create mail directory: %w", err)
-	}
-	tmp, err := os.CreateTemp(dir, "mail-*.tmp")
-	if err != nil {
-		return fmt.Errorf("create temp mail file: %w", err)
-	}
 	record := struct {
 		NextID int                      `json:"next_id"`
 		Boards map[string][]MailMessage `json:"boards"`
@@ -246,20 +543,12 @@ func (m *MailSystem) saveLocked() error {
 		NextID: m.nextID,
 		Boards: m.boards,
 	}
-	enc := json.NewEncoder(tmp)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(record); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
-		return fmt.Errorf("write mail file: %w", err)
-	}
-	if err := tmp.Close(); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("close temp mail file: %w", err)
+	data, err := encodeDocument(record)
+	if err != nil {
+		return fmt.Errorf("encode mail file: %w", err)
 	}
-	if err := os.Rename(tmp.Name(), m.path); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("replace mail file: %w", err)
+	if err := documentStorage().Write(m.path, data); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
 	}
 	return nil
 }
@@ -284,3 +573,98 @@ func (msg MailMessage) AddressedTo(player string) bool {
 	}
 	return false
 }
+
+// SendMail delivers a personal letter from p. When itemName is not empty the
+// named item leaves p's inventory and travels with the letter.
+func (w *World) SendMail(p *Player, recipient, body, itemName string) (MailMessage, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	mail := w.mail
+	if mail == nil {
+		return MailMessage{}, fmt.Errorf("mail is unavailable")
+	}
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		return MailMessage{}, fmt.Errorf("%s is not online", p.Name)
+	}
+	target, err := w.resolveMailRecipientLocked(recipient)
+	if err != nil {
+		return MailMessage{}, err
+	}
+	item := strings.TrimSpace(itemName)
+	if item == "" {
+		return mail.Send(p.Name, target, body, nil)
+	}
+	idx := findItemIndex(p.Inventory, item)
+	if idx == -1 {
+		return MailMessage{}, ErrItemNotCarried
+	}
+	attachment := p.Inventory[idx]
+	msg, err := mail.Send(p.Name, target, body, []Item{attachment})
+	if err != nil {
+		return MailMessage{}, err
+	}
+	p.Inventory = append(p.Inventory[:idx], p.Inventory[idx+1:]...)
+	return msg, nil
+}
+
+// ClaimMailAttachments moves the attachments of a letter into p's inventory
+// and any enclosed gold into p's purse.
+func (w *World) ClaimMailAttachments(p *Player, id int) ([]Item, int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	mail := w.mail
+	if mail == nil {
+		return nil, 0, fmt.Errorf("mail is unavailable")
+	}
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		return nil, 0, fmt.Errorf("%s is not online", p.Name)
+	}
+	items, gold, err := mail.takeAttachments(id, p.Name)
+	if err != nil {
+		return nil, 0, err
+	}
+	p.Inventory = append(p.Inventory, items...)
+	p.Gold += gold
+	return cloneItems(items), gold, nil
+}
+
+// SendLetter delivers a personal letter from author, who need not be online,
+// such as one written on the web portal. An online recipient is told it has
+// arrived.
+func (w *World) SendLetter(author, recipient, body string) (MailMessage, error) {
+	w.mu.Lock()
+	mail := w.mail
+	if mail == nil {
+		w.mu.Unlock()
+		return MailMessage{}, fmt.Errorf("mail is unavailable")
+	}
+	target, err := w.resolveMailRecipientLocked(recipient)
+	w.mu.Unlock()
+	if err != nil {
+		return MailMessage{}, err
+	}
+	msg, err := mail.Send(author, target, body, nil)
+	if err != nil {
+		return MailMessage{}, err
+	}
+	if p, ok := w.ActivePlayer(target); ok && p.Output != nil {
+		p.Output.Send(Ansi(fmt.Sprintf("\r\nA letter from %s arrives. Type 'mail inbox' to read it.", HighlightName(author))))
+	}
+	return msg, nil
+}
+
+func (w *World) resolveMailRecipientLocked(name string) (string, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if trimmed == "" {
+		return "", fmt.Errorf("recipient is required")
+	}
+	if online, ok := w.findPlayerLocked(trimmed); ok && strings.EqualFold(online.Name, trimmed) {
+		return online.Name, nil
+	}
+	if w.accounts == nil || w.accounts.Exists(trimmed) {
+		return trimmed, nil
+	}
+	return "", fmt.Errorf("no player named %s", trimmed)
+}
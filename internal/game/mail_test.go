@@ -1,8 +1,10 @@
 package game
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -70,3 +72,127 @@ func TestMailSystemMessagesForPlayerFilters(t *testing.T) {
 		t.Fatalf("sageMsgs len = %d, want 1", len(sageMsgs))
 	}
 }
+
+func TestMailAttachmentsAndForwarding(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	mail, err := NewMailSystem(filepath.Join(t.TempDir(), "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem error: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	sender := &Player{Name: "Sage", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(sender)
+	sender.Inventory = []Item{{Name: "Silver Ring"}}
+	hero := &Player{Name: "Hero", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(hero)
+
+	msg, err := world.SendMail(sender, "hero", "A gift", "ring")
+	if err != nil {
+		t.Fatalf("SendMail error: %v", err)
+	}
+	if len(sender.Inventory) != 0 {
+		t.Fatalf("expected the ring to leave the sender's inventory")
+	}
+	if msg.Recipients[0] != "Hero" || len(msg.Attachments) != 1 {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if err := mail.Delete(msg.ID, "Hero"); !errors.Is(err, ErrAttachmentsPending) {
+		t.Fatalf("Delete error = %v, want ErrAttachmentsPending", err)
+	}
+	if _, _, err := world.ClaimMailAttachments(sender, msg.ID); !errors.Is(err, ErrMailNotFound) {
+		t.Fatalf("sender claim error = %v, want ErrMailNotFound", err)
+	}
+	items, _, err := world.ClaimMailAttachments(hero, msg.ID)
+	if err != nil || len(items) != 1 || len(hero.Inventory) != 1 {
+		t.Fatalf("claim = %v, %v; inventory %v", items, err, hero.Inventory)
+	}
+	if _, _, err := world.ClaimMailAttachments(hero, msg.ID); !errors.Is(err, ErrNoAttachments) {
+		t.Fatalf("second claim error = %v, want ErrNoAttachments", err)
+	}
+
+	forwarded, err := mail.Forward(msg.ID, "Hero", "Sage", "Thanks!")
+	if err != nil {
+		t.Fatalf("Forward error: %v", err)
+	}
+	if forwarded.ForwardedFrom != msg.ID || !strings.Contains(forwarded.Body, "A gift") || !strings.HasPrefix(forwarded.Body, "Thanks!") {
+		t.Fatalf("unexpected forward: %#v", forwarded)
+	}
+	if len(forwarded.Attachments) != 0 {
+		t.Fatalf("forwarded letters must not duplicate attachments")
+	}
+
+	reloaded, err := NewMailSystem(mail.path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	stored, ok := reloaded.Message(msg.ID)
+	if !ok || stored.ClaimedBy != "Hero" || len(stored.Attachments) != 0 {
+		t.Fatalf("unexpected persisted message: %#v", stored)
+	}
+}
+
+func TestMailboxQuota(t *testing.T) {
+	mail, err := NewMailSystem("")
+	if err != nil {
+		t.Fatalf("NewMailSystem error: %v", err)
+	}
+	mail.SetMailboxQuota(2)
+	for i := 0; i < 2; i++ {
+		if _, err := mail.Send("Sage", "Hero", "hello", nil); err != nil {
+			t.Fatalf("Send %d error: %v", i, err)
+		}
+	}
+	if _, err := mail.Send("Sage", "Hero", "one too many", nil); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("Send error = %v, want ErrMailboxFull", err)
+	}
+	if err := mail.Delete(1, "hero"); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if _, err := mail.Send("Sage", "Hero", "fits again", nil); err != nil {
+		t.Fatalf("Send after delete error: %v", err)
+	}
+	if got := mail.MailboxCount("Hero"); got != 2 {
+		t.Fatalf("MailboxCount = %d, want 2", got)
+	}
+}
+
+func TestMailUnreadAndMarkRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.json")
+	mail, err := NewMailSystem(path)
+	if err != nil {
+		t.Fatalf("NewMailSystem error: %v", err)
+	}
+	first, err := mail.Send("Sage", "Hero", "Meet me at dawn.", nil)
+	if err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	if _, err := mail.Send("Sage", "Hero", "Bring a lantern.", nil); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	if got := mail.UnreadCount("hero"); got != 2 {
+		t.Fatalf("UnreadCount = %d, want 2", got)
+	}
+	if err := mail.MarkRead(first.ID, "Sage"); !errors.Is(err, ErrMailNotFound) {
+		t.Fatalf("MarkRead by author error = %v, want ErrMailNotFound", err)
+	}
+	if err := mail.MarkRead(first.ID, "Hero"); err != nil {
+		t.Fatalf("MarkRead error: %v", err)
+	}
+	if got := mail.UnreadCount("Hero"); got != 1 {
+		t.Fatalf("UnreadCount after MarkRead = %d, want 1", got)
+	}
+
+	reloaded, err := NewMailSystem(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if got := reloaded.UnreadCount("Hero"); got != 1 {
+		t.Fatalf("UnreadCount after reload = %d, want 1", got)
+	}
+	if err := reloaded.MarkInboxRead("Hero"); err != nil {
+		t.Fatalf("MarkInboxRead error: %v", err)
+	}
+	if got := reloaded.UnreadCount("Hero"); got != 0 {
+		t.Fatalf("UnreadCount after MarkInboxRead = %d, want 0", got)
+	}
+}
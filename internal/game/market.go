@@ -0,0 +1,402 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultListingDuration is how long an item stays on the market board
+	// before it is returned to the seller.
+	DefaultListingDuration = 48 * time.Hour
+	// MaxListingsPerSeller caps how many items one player may have listed.
+	MaxListingsPerSeller = 10
+	// marketSweepInterval is how often expired listings are returned.
+	marketSweepInterval = time.Minute
+	// marketMailAuthor signs the letters the market sends.
+	marketMailAuthor = "Market"
+)
+
+var (
+	// ErrNotInMarket indicates the player is not standing in a market room.
+	ErrNotInMarket = errors.New("you are not at a market")
+	// ErrListingNotFound indicates the listing does not exist.
+	ErrListingNotFound = errors.New("listing not found")
+	// ErrTooManyListings indicates the seller has reached MaxListingsPerSeller.
+	ErrTooManyListings = errors.New("too many listings")
+	// ErrOwnListing indicates a player tried to buy their own listing.
+	ErrOwnListing = errors.New("that listing is your own")
+	// ErrNotYourListing indicates a player tried to cancel someone else's listing.
+	ErrNotYourListing = errors.New("that listing is not yours")
+)
+
+// MarketListing is an item held in escrow by the market until it sells or
+// expires.
+type MarketListing struct {
+	ID        int       `json:"id"`
+	Seller    string    `json:"seller"`
+	Item      Item      `json:"item"`
+	Price     int       `json:"price"`
+	ListedAt  time.Time `json:"listed_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MarketSystem persists the listings on the shared market board. Every market
+// room in the world sees the same board.
+type MarketSystem struct {
+	mu       sync.RWMutex
+	path     string
+	nextID   int
+	listings []MarketListing
+	duration time.Duration
+}
+
+// NewMarketSystem constructs a market backed by the provided file path. When
+// path is empty the market operates purely in-memory without persistence.
+func NewMarketSystem(path string) (*MarketSystem, error) {
+	market := &MarketSystem{path: path, nextID: 1, duration: DefaultListingDuration}
+	if strings.TrimSpace(path) == "" {
+		return market, nil
+	}
+	data, err := documentStorage().Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return market, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read market file: %w", err)
+	}
+	if len(data) == 0 {
+		return market, nil
+	}
+	var record struct {
+		NextID   int             `json:"next_id"`
+		Listings []MarketListing `json:"listings"`
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decode market file: %w", err)
+	}
+	market.listings = record.Listings
+	market.nextID = record.NextID
+	for _, listing := range market.listings {
+		if listing.ID >= market.nextID {
+			market.nextID = listing.ID + 1
+		}
+	}
+	return market, nil
+}
+
+// SetListingDuration changes how long new listings stay on the board.
+// Durations of zero or less restore the default.
+func (m *MarketSystem) SetListingDuration(d time.Duration) {
+	if d <= 0 {
+		d = DefaultListingDuration
+	}
+	m.mu.Lock()
+	m.duration = d
+	m.mu.Unlock()
+}
+
+// Listings returns a copy of every active listing, oldest first.
+func (m *MarketSystem) Listings() []MarketListing {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]MarketListing, len(m.listings))
+	for i, listing := range m.listings {
+		listing.Item = cloneItem(listing.Item)
+		out[i] = listing
+	}
+	return out
+}
+
+// Listing returns the listing with the given ID.
+func (m *MarketSystem) Listing(id int) (MarketListing, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, listing := range m.listings {
+		if listing.ID == id {
+			listing.Item = cloneItem(listing.Item)
+			return listing, true
+		}
+	}
+	return MarketListing{}, false
+}
+
+func (m *MarketSystem) add(seller string, item Item, price int, now time.Time) (MarketListing, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, listing := range m.listings {
+		if strings.EqualFold(listing.Seller, seller) {
+			count++
+		}
+	}
+	if count >= MaxListingsPerSeller {
+		return MarketListing{}, ErrTooManyListings
+	}
+	listing := MarketListing{
+		ID:        m.nextID,
+		Seller:    seller,
+		Item:      cloneItem(item),
+		Price:     price,
+		ListedAt:  now.UTC(),
+		ExpiresAt: now.Add(m.duration).UTC(),
+	}
+	m.listings = append(m.listings, listing)
+	m.nextID++
+	if err := m.saveLocked(); err != nil {
+		m.listings = m.listings[:len(m.listings)-1]
+		m.nextID--
+		return MarketListing{}, err
+	}
+	return listing, nil
+}
+
+// take removes a listing from the board. check may refuse the removal.
+func (m *MarketSystem) take(id int, check func(MarketListing) error) (MarketListing, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, listing := range m.listings {
+		if listing.ID != id {
+			continue
+		}
+		if check != nil {
+			if err := check(listing); err != nil {
+				return MarketListing{}, err
+			}
+		}
+		previous := m.listings
+		m.listings = append(append([]MarketListing(nil), previous[:i]...), previous[i+1:]...)
+		if err := m.saveLocked(); err != nil {
+			m.listings = previous
+			return MarketListing{}, err
+		}
+		return listing, nil
+	}
+	return MarketListing{}, ErrListingNotFound
+}
+
+// restore puts a listing taken from the board back in its place after a
+// failed delivery.
+func (m *MarketSystem) restore(listing MarketListing) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listings = append(m.listings, listing)
+	sort.Slice(m.listings, func(i, j int) bool { return m.listings[i].ID < m.listings[j].ID })
+	if err := m.saveLocked(); err != nil {
+		Logger().Error("restore market listing failed", "listing", listing.ID, "error", err)
+	}
+}
+
+func (m *MarketSystem) expired(now time.Time) []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var ids []int
+	for _, listing := range m.listings {
+		if !now.Before(listing.ExpiresAt) {
+			ids = append(ids, listing.ID)
+		}
+	}
+	return ids
+}
+
+func (m *MarketSystem) saveLocked() error {
+	if strings.TrimSpace(m.path) == "" {
+		return nil
+	}
+	record := struct {
+		NextID   int             `json:"next_id"`
+		Listings []MarketListing `json:"listings"`
+	}{NextID: m.nextID, Listings: m.listings}
+	data, err := encodeDocument(record)
+	if err != nil {
+		return fmt.Errorf("encode market file: %w", err)
+	}
+	if err := documentStorage().Write(m.path, data); err != nil {
+		return fmt.Errorf("write market file: %w", err)
+	}
+	return nil
+}
+
+// AttachMarketSystem connects the market board to the world.
+func (w *World) AttachMarketSystem(market *MarketSystem) {
+	w.mu.Lock()
+	w.market = market
+	w.mu.Unlock()
+}
+
+// MarketSystem exposes the shared market board, when configured.
+func (w *World) MarketSystem() *MarketSystem {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.market
+}
+
+// marketLocked returns the market and mail systems when p stands in a market
+// room.
+func (w *World) marketLocked(p *Player) (*MarketSystem, *MailSystem, error) {
+	if w.market == nil || w.mail == nil {
+		return nil, nil, fmt.Errorf("the market is closed")
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok || !room.Market {
+		return nil, nil, ErrNotInMarket
+	}
+	return w.market, w.mail, nil
+}
+
+// InMarket reports whether p stands in a market room.
+func (w *World) InMarket(p *Player) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	room, ok := w.rooms[p.Room]
+	return ok && room.Market
+}
+
+// ListForSale moves a carried item onto the market board at the given price.
+func (w *World) ListForSale(p *Player, itemName string, price int) (MarketListing, error) {
+	if price <= 0 {
+		return MarketListing{}, fmt.Errorf("price must be positive")
+	}
+	w.mu.Lock()
+	market, _, err := w.marketLocked(p)
+	if err != nil {
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	idx := findItemIndex(p.Inventory, strings.TrimSpace(itemName))
+	if idx == -1 {
+		w.mu.Unlock()
+		return MarketListing{}, ErrItemNotCarried
+	}
+	listing, err := market.add(p.Name, p.Inventory[idx], price, time.Now())
+	if err != nil {
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	p.Inventory = append(p.Inventory[:idx], p.Inventory[idx+1:]...)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return listing, nil
+}
+
+// BuyListing pays for a listing from p's purse and hands over the item. The
+// price is mailed to the seller.
+func (w *World) BuyListing(p *Player, id int) (MarketListing, error) {
+	w.mu.Lock()
+	market, mail, err := w.marketLocked(p)
+	if err != nil {
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	listing, err := market.take(id, func(listing MarketListing) error {
+		if strings.EqualFold(listing.Seller, p.Name) {
+			return ErrOwnListing
+		}
+		if p.Gold < listing.Price {
+			return ErrInsufficientGold
+		}
+		if !canCarryLocked(p, listing.Item.TotalWeight()) {
+			return ErrTooHeavy
+		}
+		return nil
+	})
+	if err != nil {
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	body := fmt.Sprintf("%s bought your %s for %d gold. Claim your proceeds with 'mail claim'.", p.Name, listing.Item.Name, listing.Price)
+	if _, err := mail.Deliver(marketMailAuthor, listing.Seller, body, nil, listing.Price); err != nil {
+		market.restore(listing)
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	p.Gold -= listing.Price
+	p.Inventory = append(p.Inventory, cloneItem(listing.Item))
+	seller, online := w.players[listing.Seller]
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	if online && seller.Alive && seller.Output != nil {
+		seller.Output.Send(Ansi(fmt.Sprintf("\r\nYour %s sold on the market for %d gold. Check your mail.", HighlightItemName(listing.Item.Name), listing.Price)))
+	}
+	return listing, nil
+}
+
+// CancelListing takes one of p's listings off the board and returns the item
+// to p's inventory.
+func (w *World) CancelListing(p *Player, id int) (MarketListing, error) {
+	w.mu.Lock()
+	market, _, err := w.marketLocked(p)
+	if err != nil {
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	listing, err := market.take(id, func(listing MarketListing) error {
+		if !strings.EqualFold(listing.Seller, p.Name) {
+			return ErrNotYourListing
+		}
+		return nil
+	})
+	if err != nil {
+		w.mu.Unlock()
+		return MarketListing{}, err
+	}
+	p.Inventory = append(p.Inventory, cloneItem(listing.Item))
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return listing, nil
+}
+
+// ExpireListings mails every listing whose time ran out back to its seller
+// and returns how many were returned.
+func (w *World) ExpireListings(now time.Time) int {
+	w.mu.RLock()
+	market, mail := w.market, w.mail
+	w.mu.RUnlock()
+	if market == nil || mail == nil {
+		return 0
+	}
+	returned := 0
+	for _, id := range market.expired(now) {
+		listing, err := market.take(id, nil)
+		if err != nil {
+			continue
+		}
+		body := fmt.Sprintf("Your %s did not sell before its listing expired. It is enclosed.", listing.Item.Name)
+		if _, err := mail.Deliver(marketMailAuthor, listing.Seller, body, []Item{listing.Item}, 0); err != nil {
+			Logger().Error("return expired listing failed", "listing", listing.ID, "error", err)
+			market.restore(listing)
+			continue
+		}
+		returned++
+		if seller, ok := w.ActivePlayer(listing.Seller); ok && seller.Output != nil {
+			seller.Output.Send(Ansi(fmt.Sprintf("\r\nYour market listing for %s expired and was mailed back to you.", HighlightItemName(listing.Item.Name))))
+		}
+	}
+	return returned
+}
+
+// StartMarketLoop periodically returns expired listings until stop is
+// closed.
+func (w *World) StartMarketLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(marketSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.ExpireListings(now)
+			}
+		}
+	}()
+}
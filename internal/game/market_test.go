@@ -0,0 +1,121 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarketSaleMailsProceedsToSeller(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"bazaar": {ID: "bazaar", Title: "Bazaar", Market: true, Exits: map[string]Exit{"out": {To: "lane"}}},
+		"lane":   {ID: "lane", Title: "Lane", Exits: map[string]Exit{"in": {To: "bazaar"}}},
+	})
+	dir := t.TempDir()
+	mail, err := NewMailSystem(filepath.Join(dir, "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	market, err := NewMarketSystem(filepath.Join(dir, "market.json"))
+	if err != nil {
+		t.Fatalf("NewMarketSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	world.AttachMarketSystem(market)
+	seller := &Player{Name: "Seller", Room: "bazaar", Output: NewOutputQueue(), Alive: true,
+		Inventory: []Item{{Name: "Opal Brooch"}, {Name: "Tin Cup"}}}
+	buyer := &Player{Name: "Buyer", Room: "bazaar", Output: NewOutputQueue(), Alive: true, Gold: 50}
+	world.AddPlayerForTest(seller)
+	world.AddPlayerForTest(buyer)
+
+	listing, err := world.ListForSale(seller, "opal", 30)
+	if err != nil {
+		t.Fatalf("ListForSale: %v", err)
+	}
+	if len(seller.Inventory) != 1 {
+		t.Fatalf("listed item should leave the seller's inventory")
+	}
+	if _, err := world.BuyListing(seller, listing.ID); !errors.Is(err, ErrOwnListing) {
+		t.Fatalf("expected ErrOwnListing, got %v", err)
+	}
+	buyer.Room = "lane"
+	if _, err := world.BuyListing(buyer, listing.ID); !errors.Is(err, ErrNotInMarket) {
+		t.Fatalf("expected ErrNotInMarket, got %v", err)
+	}
+	buyer.Room = "bazaar"
+	if _, err := world.BuyListing(buyer, listing.ID); err != nil {
+		t.Fatalf("BuyListing: %v", err)
+	}
+	if buyer.Gold != 20 || len(buyer.Inventory) != 1 || buyer.Inventory[0].Name != "Opal Brooch" {
+		t.Fatalf("buyer should hold the brooch and 20 gold, got %d gold and %v", buyer.Gold, buyer.Inventory)
+	}
+	if len(market.Listings()) != 0 {
+		t.Fatalf("sold listing should leave the board")
+	}
+	letters := mail.MessagesForPlayer(PersonalMailBoard, "Seller")
+	if len(letters) != 1 || letters[0].Gold != 30 || letters[0].Author != "Market" {
+		t.Fatalf("expected proceeds letter, got %#v", letters)
+	}
+	if !strings.Contains(stripAnsi(strings.Join(drainOutput(seller.Output), "")), "sold on the market") {
+		t.Fatalf("expected the seller to be told about the sale")
+	}
+	if _, gold, err := world.ClaimMailAttachments(seller, letters[0].ID); err != nil || gold != 30 || seller.Gold != 30 {
+		t.Fatalf("claim = %d, %v; seller gold %d", gold, err, seller.Gold)
+	}
+}
+
+func TestMarketExpiryReturnsItemsByMail(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"bazaar": {ID: "bazaar", Title: "Bazaar", Market: true, Exits: map[string]Exit{"out": {To: "lane"}}},
+		"lane":   {ID: "lane", Title: "Lane", Exits: map[string]Exit{"in": {To: "bazaar"}}},
+	})
+	dir := t.TempDir()
+	mail, err := NewMailSystem(filepath.Join(dir, "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	market, err := NewMarketSystem(filepath.Join(dir, "market.json"))
+	if err != nil {
+		t.Fatalf("NewMarketSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	world.AttachMarketSystem(market)
+	seller := &Player{Name: "Seller", Room: "bazaar", Output: NewOutputQueue(), Alive: true,
+		Inventory: []Item{{Name: "Opal Brooch"}, {Name: "Tin Cup"}}}
+	buyer := &Player{Name: "Buyer", Room: "bazaar", Output: NewOutputQueue(), Alive: true, Gold: 50}
+	world.AddPlayerForTest(seller)
+	world.AddPlayerForTest(buyer)
+	market.SetListingDuration(time.Hour)
+
+	listing, err := world.ListForSale(seller, "tin cup", 500)
+	if err != nil {
+		t.Fatalf("ListForSale: %v", err)
+	}
+	if _, err := world.BuyListing(buyer, listing.ID); !errors.Is(err, ErrInsufficientGold) {
+		t.Fatalf("expected ErrInsufficientGold, got %v", err)
+	}
+	if n := world.ExpireListings(time.Now()); n != 0 {
+		t.Fatalf("listing expired early")
+	}
+
+	reloaded, err := NewMarketSystem(market.path)
+	if err != nil {
+		t.Fatalf("reload market: %v", err)
+	}
+	if got := reloaded.Listings(); len(got) != 1 || got[0].Item.Name != "Tin Cup" {
+		t.Fatalf("listing not persisted: %#v", got)
+	}
+
+	if n := world.ExpireListings(time.Now().Add(2 * time.Hour)); n != 1 {
+		t.Fatalf("ExpireListings returned %d, want 1", n)
+	}
+	letters := mail.MessagesForPlayer(PersonalMailBoard, "Seller")
+	if len(letters) != 1 || len(letters[0].Attachments) != 1 || letters[0].Attachments[0].Name != "Tin Cup" {
+		t.Fatalf("expected the unsold cup by mail, got %#v", letters)
+	}
+	if _, err := world.CancelListing(seller, listing.ID); !errors.Is(err, ErrListingNotFound) {
+		t.Fatalf("expected ErrListingNotFound, got %v", err)
+	}
+}
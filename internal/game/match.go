@@ -5,7 +5,8 @@ import "strings"
 // uniqueMatch attempts to resolve the provided target string against a slice of
 // candidate names. It performs a case-insensitive comparison, supports prefix
 // matching, and optionally considers word-level prefixes. The function returns
-// the index of the uniquely matched candidate and true. If no match or an
+// the index of the uniquely matched candidate and true. Candidates with the
+// same name count as one, and the first is returned. If no match or an
 // ambiguous match is found, it returns -1 and false.
 func uniqueMatch(target string, names []string, matchWords bool) (int, bool) {
 	trimmed := strings.TrimSpace(target)
@@ -34,7 +35,11 @@ func uniqueMatch(target string, names []string, matchWords bool) (int, bool) {
 
 		if match {
 			if partial != -1 {
-				ambiguous = true
+				// Copies of the same thing, such as stacked potions,
+				// are interchangeable.
+				if !strings.EqualFold(strings.TrimSpace(names[partial]), strings.TrimSpace(name)) {
+					ambiguous = true
+				}
 				continue
 			}
 			partial = i
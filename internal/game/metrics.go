@@ -0,0 +1,209 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// scriptLatencyBuckets are the upper bounds, in seconds, of the script
+// execution latency histogram.
+var scriptLatencyBuckets = []float64{0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+type latencyHistogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// serverMetrics collects counters exported on the Prometheus endpoint.
+type serverMetrics struct {
+	connections      atomic.Int64
+	connectionsTotal atomic.Uint64
+	commandsTotal    atomic.Uint64
+	combatRounds     atomic.Uint64
+	outputOverflows  atomic.Uint64
+	stalledClients   atomic.Uint64
+	persistPending   atomic.Int64
+	persistFailures  atomic.Uint64
+
+	mu      sync.Mutex
+	scripts map[string]*latencyHistogram
+}
+
+var metrics = newServerMetrics()
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{scripts: make(map[string]*latencyHistogram)}
+}
+
+func (m *serverMetrics) connectionOpened() {
+	m.connections.Add(1)
+	m.connectionsTotal.Add(1)
+}
+
+func (m *serverMetrics) connectionClosed() {
+	m.connections.Add(-1)
+}
+
+func (m *serverMetrics) commandDispatched() {
+	m.commandsTotal.Add(1)
+}
+
+func (m *serverMetrics) combatRound() {
+	m.combatRounds.Add(1)
+}
+
+func (m *serverMetrics) outputOverflowed() {
+	m.outputOverflows.Add(1)
+}
+
+func (m *serverMetrics) clientStalled() {
+	m.stalledClients.Add(1)
+}
+
+func (m *serverMetrics) persistQueued(pending int) {
+	m.persistPending.Store(int64(pending))
+}
+
+func (m *serverMetrics) persistFailed() {
+	m.persistFailures.Add(1)
+}
+
+func (m *serverMetrics) observeScript(hook string, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hist, ok := m.scripts[hook]
+	if !ok {
+		hist = &latencyHistogram{buckets: make([]uint64, len(scriptLatencyBuckets))}
+		m.scripts[hook] = hist
+	}
+	for i, bound := range scriptLatencyBuckets {
+		if seconds <= bound {
+			hist.buckets[i]++
+		}
+	}
+	hist.count++
+	hist.sum += seconds
+}
+
+// AreaPopulation reports how many connected players are in each area. Rooms
+// that do not belong to a named area are counted under "unknown".
+func (w *World) AreaPopulation() map[string]int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	population := make(map[string]int)
+	for _, p := range w.players {
+		if !p.Alive {
+			continue
+		}
+		area := "unknown"
+		if source, ok := w.roomSources[p.Room]; ok {
+			if meta, ok := w.areaMeta[source]; ok && strings.TrimSpace(meta.Name) != "" {
+				area = meta.Name
+			} else if source != "" {
+				area = source
+			}
+		}
+		population[area]++
+	}
+	return population
+}
+
+// MetricsHandler serves server health metrics in the Prometheus text
+// exposition format.
+func MetricsHandler(world *World) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, world, metrics)
+	})
+}
+
+func writeMetrics(out io.Writer, world *World, m *serverMetrics) {
+	writeMetric := func(name, kind, help string, value any) {
+		fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+	}
+	writeMetric("lumenclay_connections", "gauge", "Open telnet connections.", m.connections.Load())
+	writeMetric("lumenclay_connections_total", "counter", "Telnet connections accepted since startup.", m.connectionsTotal.Load())
+	writeMetric("lumenclay_commands_total", "counter", "Player commands dispatched since startup.", m.commandsTotal.Load())
+	writeMetric("lumenclay_combat_rounds_total", "counter", "Combat rounds resolved since startup.", m.combatRounds.Load())
+	writeMetric("lumenclay_output_overflows_total", "counter", "Clients disconnected because their output queue overflowed.", m.outputOverflows.Load())
+	writeMetric("lumenclay_stalled_disconnects_total", "counter", "Clients disconnected because writes to them stalled.", m.stalledClients.Load())
+	writeMetric("lumenclay_persist_pending", "gauge", "Player and builder documents waiting to be written.", m.persistPending.Load())
+	writeMetric("lumenclay_persist_failures_total", "counter", "Failed background writes of player and builder documents.", m.persistFailures.Load())
+
+	if world != nil {
+		population := world.AreaPopulation()
+		total := 0
+		areas := make([]string, 0, len(population))
+		for area, count := range population {
+			areas = append(areas, area)
+			total += count
+		}
+		sort.Strings(areas)
+		writeMetric("lumenclay_players_online", "gauge", "Players currently in the world.", total)
+		fmt.Fprintf(out, "# HELP lumenclay_area_players Players currently in each area.\n# TYPE lumenclay_area_players gauge\n")
+		for _, area := range areas {
+			fmt.Fprintf(out, "lumenclay_area_players{area=%q} %d\n", area, population[area])
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hooks := make([]string, 0, len(m.scripts))
+	for hook := range m.scripts {
+		hooks = append(hooks, hook)
+	}
+	sort.Strings(hooks)
+	fmt.Fprintf(out, "# HELP lumenclay_script_duration_seconds Script hook execution latency.\n# TYPE lumenclay_script_duration_seconds histogram\n")
+	for _, hook := range hooks {
+		hist := m.scripts[hook]
+		for i, bound := range scriptLatencyBuckets {
+			fmt.Fprintf(out, "lumenclay_script_duration_seconds_bucket{hook=%q,le=\"%g\"} %d\n", hook, bound, hist.buckets[i])
+		}
+		fmt.Fprintf(out, "lumenclay_script_duration_seconds_bucket{hook=%q,le=\"+Inf\"} %d\n", hook, hist.count)
+		fmt.Fprintf(out, "lumenclay_script_duration_seconds_sum{hook=%q} %g\n", hook, hist.sum)
+		fmt.Fprintf(out, "lumenclay_script_duration_seconds_count{hook=%q} %d\n", hook, hist.count)
+	}
+}
+
+// metricsServer exposes MetricsHandler on a dedicated plain HTTP listener so
+// scrapers do not need portal credentials.
+type metricsServer struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+func startMetricsServer(addr string, world *World) (*metricsServer, error) {
+	listener, err := netListenFunc("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler(world))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			Logger().Error("metrics endpoint stopped", "error", err)
+		}
+	}()
+	Logger().Info("metrics endpoint listening", "addr", listener.Addr().String())
+	return &metricsServer{server: server, listener: listener}, nil
+}
+
+func (s *metricsServer) Close() error {
+	return s.server.Close()
+}
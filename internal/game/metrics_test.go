@@ -0,0 +1,60 @@
+package game
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsHandlerExportsCounters(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Exits: map[string]Exit{}},
+		"field": {ID: "field", Exits: map[string]Exit{}},
+	})
+	world.roomSources = map[RoomID]string{"start": "town.json", "field": "wilds.json"}
+	world.areaMeta = map[string]areaMetadata{"town.json": {Name: "Town"}}
+	world.AddPlayerForTest(&Player{Name: "Alice", Room: "start", Alive: true, Output: NewOutputQueue()})
+	world.AddPlayerForTest(&Player{Name: "Bob", Room: "start", Alive: true, Output: NewOutputQueue()})
+	world.AddPlayerForTest(&Player{Name: "Cara", Room: "field", Alive: true, Output: NewOutputQueue()})
+
+	m := newServerMetrics()
+	m.connectionOpened()
+	m.connectionOpened()
+	m.connectionClosed()
+	m.commandDispatched()
+	m.combatRound()
+	m.observeScript("OnEnter", 2*time.Millisecond)
+
+	var out strings.Builder
+	writeMetrics(&out, world, m)
+	body := out.String()
+	for _, want := range []string{
+		"lumenclay_connections 1\n",
+		"lumenclay_connections_total 2\n",
+		"lumenclay_commands_total 1\n",
+		"lumenclay_combat_rounds_total 1\n",
+		"lumenclay_players_online 3\n",
+		`lumenclay_area_players{area="Town"} 2`,
+		`lumenclay_area_players{area="wilds.json"} 1`,
+		`lumenclay_script_duration_seconds_bucket{hook="OnEnter",le="0.001"} 0`,
+		`lumenclay_script_duration_seconds_bucket{hook="OnEnter",le="0.005"} 1`,
+		`lumenclay_script_duration_seconds_count{hook="OnEnter"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("metrics output missing %q:\n%s", want, body)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	MetricsHandler(world).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("GET status = %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	rec = httptest.NewRecorder()
+	MetricsHandler(world).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("POST status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,396 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ModerationActionLimit caps how many moderation actions are kept.
+const ModerationActionLimit = 1000
+
+// ChannelMute silences a player on one channel across every session until
+// it expires. A zero ExpiresAt never expires.
+type ChannelMute struct {
+	Target    string    `json:"target"`
+	Channel   Channel   `json:"channel"`
+	Reason    string    `json:"reason,omitempty"`
+	IssuedBy  string    `json:"issued_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// Active reports whether the mute is still in force at now.
+func (m ChannelMute) Active(now time.Time) bool {
+	return m.ExpiresAt.IsZero() || now.Before(m.ExpiresAt)
+}
+
+// ModerationAction records one staff action taken on the channels.
+type ModerationAction struct {
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target,omitempty"`
+	Channel Channel   `json:"channel,omitempty"`
+	Detail  string    `json:"detail,omitempty"`
+}
+
+// ModerationLog persists channel mutes, slow-mode settings, and the trail
+// of moderation actions.
+type ModerationLog struct {
+	mu      sync.RWMutex
+	path    string
+	mutes   map[string]ChannelMute
+	slow    map[Channel]time.Duration
+	actions []ModerationAction
+}
+
+type moderationFile struct {
+	Mutes    []ChannelMute      `json:"mutes,omitempty"`
+	SlowMode map[Channel]string `json:"slow_mode,omitempty"`
+	Actions  []ModerationAction `json:"actions,omitempty"`
+}
+
+// NewModerationLog loads moderation state from the provided path. When path
+// is empty the log operates purely in-memory without persistence.
+func NewModerationLog(path string) (*ModerationLog, error) {
+	log := &ModerationLog{
+		path:  strings.TrimSpace(path),
+		mutes: make(map[string]ChannelMute),
+		slow:  make(map[Channel]time.Duration),
+	}
+	if log.path == "" {
+		return log, nil
+	}
+	data, err := os.ReadFile(log.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read moderation log: %w", err)
+	}
+	if len(data) == 0 {
+		return log, nil
+	}
+	var file moderationFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode moderation log: %w", err)
+	}
+	for _, mute := range file.Mutes {
+		log.mutes[muteKey(mute.Target, mute.Channel)] = mute
+	}
+	for channel, raw := range file.SlowMode {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			log.slow[channel] = d
+		}
+	}
+	log.actions = file.Actions
+	return log, nil
+}
+
+// ParseModerationDuration reads a mute length such as "30m", "2h", "3d", or
+// "1w".
+func ParseModerationDuration(input string) (time.Duration, bool) {
+	trimmed := strings.ToLower(strings.TrimSpace(input))
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(trimmed, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(trimmed, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit > 0 {
+		days, err := strconv.Atoi(trimmed[:len(trimmed)-1])
+		if err != nil || days <= 0 {
+			return 0, false
+		}
+		return time.Duration(days) * unit, true
+	}
+	d, err := time.ParseDuration(trimmed)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+func muteKey(target string, channel Channel) string {
+	return strings.ToLower(strings.TrimSpace(target)) + "|" + string(channel)
+}
+
+// Mute silences target on channel for duration, or until lifted when
+// duration is zero.
+func (m *ModerationLog) Mute(target string, channel Channel, duration time.Duration, reason, issuedBy string) (ChannelMute, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return ChannelMute{}, fmt.Errorf("player name must not be empty")
+	}
+	now := time.Now().UTC()
+	mute := ChannelMute{
+		Target:    target,
+		Channel:   channel,
+		Reason:    strings.TrimSpace(reason),
+		IssuedBy:  strings.TrimSpace(issuedBy),
+		CreatedAt: now,
+	}
+	if duration > 0 {
+		mute.ExpiresAt = now.Add(duration)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mutes[muteKey(target, channel)] = mute
+	m.appendLocked(ModerationAction{Time: now, Actor: mute.IssuedBy, Action: "mute", Target: target, Channel: channel, Detail: muteDetail(duration, mute.Reason)})
+	return mute, m.saveLocked()
+}
+
+// Unmute lifts target's mute on channel. It reports whether a mute was in
+// force.
+func (m *ModerationLog) Unmute(target string, channel Channel, issuedBy string) (bool, error) {
+	key := muteKey(target, channel)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mute, ok := m.mutes[key]
+	if !ok {
+		return false, nil
+	}
+	delete(m.mutes, key)
+	m.appendLocked(ModerationAction{Time: time.Now().UTC(), Actor: strings.TrimSpace(issuedBy), Action: "unmute", Target: strings.TrimSpace(target), Channel: channel})
+	return mute.Active(time.Now()), m.saveLocked()
+}
+
+// Muted returns target's mute on channel when one is in force.
+func (m *ModerationLog) Muted(target string, channel Channel) (ChannelMute, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	mute, ok := m.mutes[muteKey(target, channel)]
+	if !ok || !mute.Active(time.Now()) {
+		return ChannelMute{}, false
+	}
+	return mute, true
+}
+
+// Mutes lists the mutes currently in force, ordered by target and channel.
+func (m *ModerationLog) Mutes() []ChannelMute {
+	now := time.Now()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	list := make([]ChannelMute, 0, len(m.mutes))
+	for _, mute := range m.mutes {
+		if mute.Active(now) {
+			list = append(list, mute)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return muteKey(list[i].Target, list[i].Channel) < muteKey(list[j].Target, list[j].Channel)
+	})
+	return list
+}
+
+// SetSlowMode limits how often each player may speak on channel. A zero
+// interval turns slow mode off.
+func (m *ModerationLog) SetSlowMode(channel Channel, interval time.Duration, issuedBy string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	action := ModerationAction{Time: time.Now().UTC(), Actor: strings.TrimSpace(issuedBy), Action: "slowmode", Channel: channel}
+	if interval > 0 {
+		m.slow[channel] = interval
+		action.Detail = interval.String()
+	} else {
+		delete(m.slow, channel)
+		action.Detail = "off"
+	}
+	m.appendLocked(action)
+	return m.saveLocked()
+}
+
+// SlowMode returns the minimum interval between messages on channel.
+func (m *ModerationLog) SlowMode(channel Channel) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.slow[channel]
+}
+
+// Record appends an action to the moderation trail.
+func (m *ModerationLog) Record(action ModerationAction) error {
+	if action.Time.IsZero() {
+		action.Time = time.Now().UTC()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.appendLocked(action)
+	return m.saveLocked()
+}
+
+// Actions returns up to limit of the most recent actions, oldest first.
+func (m *ModerationLog) Actions(limit int) []ModerationAction {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	start := 0
+	if limit > 0 && len(m.actions) > limit {
+		start = len(m.actions) - limit
+	}
+	return append([]ModerationAction(nil), m.actions[start:]...)
+}
+
+func (m *ModerationLog) appendLocked(action ModerationAction) {
+	m.actions = append(m.actions, action)
+	if excess := len(m.actions) - ModerationActionLimit; excess > 0 {
+		m.actions = append([]ModerationAction(nil), m.actions[excess:]...)
+	}
+	Logger().Info("moderation", "actor", action.Actor, "action", action.Action, "target", action.Target, "channel", string(action.Channel), "detail", action.Detail)
+}
+
+func (m *ModerationLog) saveLocked() error {
+	if m.path == "" {
+		return nil
+	}
+	now := time.Now()
+	file := moderationFile{Actions: m.actions}
+	for _, mute := range m.mutes {
+		if mute.Active(now) {
+			file.Mutes = append(file.Mutes, mute)
+		}
+	}
+	sort.Slice(file.Mutes, func(i, j int) bool {
+		return muteKey(file.Mutes[i].Target, file.Mutes[i].Channel) < muteKey(file.Mutes[j].Target, file.Mutes[j].Channel)
+	})
+	if len(m.slow) > 0 {
+		file.SlowMode = make(map[Channel]string, len(m.slow))
+		for channel, interval := range m.slow {
+			file.SlowMode[channel] = interval.String()
+		}
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode moderation log: %w", err)
+	}
+	return fileStorage{}.Write(m.path, append(data, '\n'))
+}
+
+func muteDetail(duration time.Duration, reason string) string {
+	detail := "until lifted"
+	if duration > 0 {
+		detail = "for " + duration.String()
+	}
+	if reason != "" {
+		detail += ": " + reason
+	}
+	return detail
+}
+
+// moderationKeyLocked names the record a player's mutes are stored under:
+// their account when known, so mutes follow them across characters.
+func (w *World) moderationKeyLocked(name string) (string, bool) {
+	if p, ok := w.findPlayerLocked(name); ok {
+		if p.Account != "" {
+			return p.Account, true
+		}
+		return p.Name, true
+	}
+	if w.accounts != nil {
+		if account, ok := w.accounts.CharacterOwner(strings.TrimSpace(name)); ok {
+			return account, true
+		}
+	}
+	return "", false
+}
+
+// AttachModeration connects the persistent moderation log to the world.
+func (w *World) AttachModeration(log *ModerationLog) {
+	w.mu.Lock()
+	w.moderation = log
+	w.mu.Unlock()
+}
+
+// Moderation returns the world's moderation log.
+func (w *World) Moderation() *ModerationLog {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.moderation == nil {
+		w.moderation, _ = NewModerationLog("")
+	}
+	return w.moderation
+}
+
+// MuteChannel silences the named player on channel across sessions and
+// characters for duration, or until lifted when duration is zero.
+func (w *World) MuteChannel(name string, channel Channel, duration time.Duration, reason, actor string) (ChannelMute, error) {
+	log := w.Moderation()
+	w.mu.RLock()
+	key, ok := w.moderationKeyLocked(name)
+	w.mu.RUnlock()
+	if !ok {
+		return ChannelMute{}, fmt.Errorf("no player named %s", strings.TrimSpace(name))
+	}
+	return log.Mute(key, channel, duration, reason, actor)
+}
+
+// UnmuteChannel lifts the named player's mute on channel, including a
+// session mute set with SetChannelMute. It reports whether a mute was lifted.
+func (w *World) UnmuteChannel(name string, channel Channel, actor string) (bool, error) {
+	log := w.Moderation()
+	w.mu.RLock()
+	key, ok := w.moderationKeyLocked(name)
+	target, online := w.findPlayerLocked(name)
+	w.mu.RUnlock()
+	if !ok {
+		return false, fmt.Errorf("no player named %s", strings.TrimSpace(name))
+	}
+	lifted := false
+	if online && target.muted(channel) {
+		w.SetChannelMute(target, channel, false)
+		lifted = true
+	}
+	removed, err := log.Unmute(key, channel, actor)
+	return lifted || removed, err
+}
+
+// SetChannelSlowMode limits how often each player may speak on channel.
+func (w *World) SetChannelSlowMode(channel Channel, interval time.Duration, actor string) error {
+	return w.Moderation().SetSlowMode(channel, interval, actor)
+}
+
+// ChannelSlowMode returns the minimum interval between messages on channel.
+func (w *World) ChannelSlowMode(channel Channel) time.Duration {
+	return w.Moderation().SlowMode(channel)
+}
+
+// ChannelSpeakWait reports how long p must wait before speaking on channel
+// under slow mode. When no wait is needed the message is counted, so the
+// next one waits a full interval. Staff are never slowed.
+func (w *World) ChannelSpeakWait(p *Player, channel Channel) time.Duration {
+	interval := w.ChannelSlowMode(channel)
+	if interval <= 0 || p == nil || p.IsAdmin || p.IsModerator {
+		return 0
+	}
+	now := time.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if last, ok := p.lastSpoke[channel]; ok {
+		if wait := last.Add(interval).Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	if p.lastSpoke == nil {
+		p.lastSpoke = make(map[Channel]time.Time)
+	}
+	p.lastSpoke[channel] = now
+	return 0
+}
+
+// ReviewChannelHistory returns an online player's recent channel history for
+// staff review and records the review in the moderation trail.
+func (w *World) ReviewChannelHistory(actor, name string, channel Channel, limit int) (*Player, []ChannelLogEntry, error) {
+	target, ok := w.FindPlayer(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not online", strings.TrimSpace(name))
+	}
+	entries := target.snapshotChannelHistory(channel, limit)
+	err := w.Moderation().Record(ModerationAction{Actor: actor, Action: "review", Target: target.Name, Channel: channel})
+	return target, entries, err
+}
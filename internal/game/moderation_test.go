@@ -0,0 +1,96 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestModerationMutesPersistAndExpire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "moderation.json")
+	log, err := NewModerationLog(path)
+	if err != nil {
+		t.Fatalf("NewModerationLog: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall"}})
+	world.AttachModeration(log)
+	p := &Player{Name: "Loud", Account: "loud", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+
+	if _, err := world.MuteChannel("loud", ChannelOOC, 0, "spam", "Mod"); err != nil {
+		t.Fatalf("MuteChannel: %v", err)
+	}
+	if !world.ChannelMuted(p, ChannelOOC) || world.ChannelMuted(p, ChannelSay) {
+		t.Fatalf("expected only OOC to be muted")
+	}
+	reloaded, err := NewModerationLog(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, ok := reloaded.Muted("loud", ChannelOOC); !ok {
+		t.Fatalf("expected the mute to persist")
+	}
+	if lifted, err := world.UnmuteChannel("Loud", ChannelOOC, "Mod"); err != nil || !lifted {
+		t.Fatalf("UnmuteChannel = %v, %v", lifted, err)
+	}
+	if world.ChannelMuted(p, ChannelOOC) {
+		t.Fatalf("expected the mute to be lifted")
+	}
+
+	mute, err := log.Mute("loud", ChannelYell, time.Minute, "", "Mod")
+	if err != nil {
+		t.Fatalf("Mute: %v", err)
+	}
+	if mute.Active(time.Now().Add(2 * time.Minute)) {
+		t.Fatalf("expected the mute to expire")
+	}
+	if _, err := world.MuteChannel("nobody", ChannelOOC, 0, "", "Mod"); err == nil {
+		t.Fatalf("expected an unknown player to be rejected")
+	}
+	actions := log.Actions(0)
+	if len(actions) != 3 || actions[0].Action != "mute" || actions[1].Action != "unmute" {
+		t.Fatalf("unexpected moderation trail %+v", actions)
+	}
+}
+
+func TestChannelSpeakWaitEnforcesSlowMode(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall"}})
+	p := &Player{Name: "Chatty", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	staff := &Player{Name: "Mod", Room: "hall", Output: NewOutputQueue(), Alive: true, IsModerator: true}
+	world.AddPlayerForTest(p)
+	world.AddPlayerForTest(staff)
+
+	if wait := world.ChannelSpeakWait(p, ChannelOOC); wait != 0 {
+		t.Fatalf("no slow mode should mean no wait, got %s", wait)
+	}
+	if err := world.SetChannelSlowMode(ChannelOOC, time.Minute, "Mod"); err != nil {
+		t.Fatalf("SetChannelSlowMode: %v", err)
+	}
+	if wait := world.ChannelSpeakWait(p, ChannelOOC); wait != 0 {
+		t.Fatalf("first message should not wait, got %s", wait)
+	}
+	if wait := world.ChannelSpeakWait(p, ChannelOOC); wait <= 0 {
+		t.Fatalf("second message should wait")
+	}
+	if wait := world.ChannelSpeakWait(p, ChannelSay); wait != 0 {
+		t.Fatalf("slow mode should only apply to OOC, got %s", wait)
+	}
+	world.ChannelSpeakWait(staff, ChannelOOC)
+	if wait := world.ChannelSpeakWait(staff, ChannelOOC); wait != 0 {
+		t.Fatalf("staff should not be slowed, got %s", wait)
+	}
+}
+
+func TestParseModerationDuration(t *testing.T) {
+	cases := map[string]time.Duration{"30m": 30 * time.Minute, "2h": 2 * time.Hour, "3d": 72 * time.Hour, "1w": 168 * time.Hour}
+	for input, want := range cases {
+		if got, ok := ParseModerationDuration(input); !ok || got != want {
+			t.Fatalf("ParseModerationDuration(%q) = %s, %v", input, got, ok)
+		}
+	}
+	for _, input := range []string{"spam", "0d", "-5m", ""} {
+		if _, ok := ParseModerationDuration(input); ok {
+			t.Fatalf("expected %q to be rejected", input)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// WalkMoveCost is the stamina spent moving one room on foot.
+	WalkMoveCost = 3
+	// RideMoveCost is the stamina spent moving one room while mounted.
+	RideMoveCost = 1
+	// staminaTick is how often players recover stamina.
+	staminaTick = 5 * time.Second
+)
+
+var (
+	// ErrExhausted indicates the player lacks the stamina to move.
+	ErrExhausted = errors.New("you are too exhausted to move")
+	// ErrAlreadyMounted indicates the player is already riding.
+	ErrAlreadyMounted = errors.New("you are already mounted")
+	// ErrNotMounted indicates the player is not riding anything.
+	ErrNotMounted = errors.New("you are not riding anything")
+	// ErrNoMount indicates no rideable creature by that name is present.
+	ErrNoMount = errors.New("there is nothing here to ride by that name")
+)
+
+// moveCost returns the stamina p spends moving into terrain. Rough ground
+// costs more and roads less, and a burdened walker pays double.
+func (p *Player) moveCost(terrain Terrain) int {
+	if p.Mount != nil {
+		return max(1, RideMoveCost+terrain.surcharge())
+	}
+	cost := WalkMoveCost + terrain.surcharge()
+	if p.encumbrance().Burdened() {
+		return cost * 2
+	}
+	return cost
+}
+
+// staminaRegen returns how much stamina p recovers each tick.
+func (p *Player) staminaRegen() int {
+	return max(1, p.MaxMoves/10)
+}
+
+// MountName returns the name of the creature p rides, or "" on foot.
+func (w *World) MountName(p *Player) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if p.Mount == nil {
+		return ""
+	}
+	return p.Mount.Name
+}
+
+// Mount takes a rideable NPC out of p's room and seats p on it.
+func (w *World) Mount(p *Player, name string) (NPC, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if p.Mount != nil {
+		return NPC{}, ErrAlreadyMounted
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return NPC{}, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	idx := findNPCIndex(room.NPCs, strings.TrimSpace(name))
+	if idx == -1 || !room.NPCs[idx].Mount {
+		return NPC{}, ErrNoMount
+	}
+	if combat, ok := w.combats[p.Room]; ok && combat.hasNPC(room.NPCs[idx].Name) {
+		return NPC{}, fmt.Errorf("%s is in no mood to be ridden", room.NPCs[idx].Name)
+	}
+	mount := room.NPCs[idx]
+	room.NPCs = append(room.NPCs[:idx], room.NPCs[idx+1:]...)
+	p.Mount = &mount
+	return mount, nil
+}
+
+// Dismount sets p's mount down in p's current room.
+func (w *World) Dismount(p *Player) (NPC, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if p.Mount == nil {
+		return NPC{}, ErrNotMounted
+	}
+	mount := *p.Mount
+	w.releaseMountLocked(p)
+	return mount, nil
+}
+
+// releaseMountLocked returns p's mount, if any, to p's current room.
+func (w *World) releaseMountLocked(p *Player) {
+	if p.Mount == nil {
+		return
+	}
+	if room, ok := w.rooms[p.Room]; ok {
+		room.NPCs = append(room.NPCs, *p.Mount)
+	}
+	p.Mount = nil
+}
+
+// RegenerateStamina restores a share of every connected player's stamina.
+func (w *World) RegenerateStamina() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, p := range w.players {
+		if !p.Alive || p.Moves >= p.MaxMoves {
+			continue
+		}
+		p.Moves = min(p.MaxMoves, p.Moves+p.staminaRegen())
+	}
+}
+
+// StartStaminaLoop periodically restores player stamina until stop is
+// closed.
+func (w *World) StartStaminaLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(staminaTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				w.RegenerateStamina()
+			}
+		}
+	}()
+}
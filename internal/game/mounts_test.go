@@ -0,0 +1,99 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMovementSpendsStaminaAndRidingCostsLess(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stable": {ID: "stable", Title: "Stable", NPCs: []NPC{{Name: "Dune Strider", Mount: true}, {Name: "Groom"}},
+			Exits: map[string]Exit{"east": {To: "road"}}},
+		"road": {ID: "road", Title: "Road", Exits: map[string]Exit{"west": {To: "stable"}}},
+	})
+	rider := &Player{Name: "Rider", Room: "stable", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(rider)
+	if rider.Moves != rider.MaxMoves || rider.MaxMoves != 100 {
+		t.Fatalf("stamina = %d/%d, want 100/100", rider.Moves, rider.MaxMoves)
+	}
+
+	if _, err := world.Move(rider, "east"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if rider.Moves != 100-WalkMoveCost {
+		t.Fatalf("walking left %d stamina", rider.Moves)
+	}
+	if _, err := world.Move(rider, "west"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+
+	if _, err := world.Mount(rider, "groom"); !errors.Is(err, ErrNoMount) {
+		t.Fatalf("expected ErrNoMount, got %v", err)
+	}
+	if _, err := world.Mount(rider, "strider"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if _, err := world.Mount(rider, "strider"); !errors.Is(err, ErrAlreadyMounted) {
+		t.Fatalf("expected ErrAlreadyMounted, got %v", err)
+	}
+	before := rider.Moves
+	if _, err := world.Move(rider, "east"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if rider.Moves != before-RideMoveCost {
+		t.Fatalf("riding cost %d stamina, want %d", before-rider.Moves, RideMoveCost)
+	}
+
+	rider.Moves = 0
+	if _, err := world.Move(rider, "west"); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("expected ErrExhausted, got %v", err)
+	}
+	world.RegenerateStamina()
+	if rider.Moves != 10 {
+		t.Fatalf("regenerated to %d, want 10", rider.Moves)
+	}
+}
+
+func TestDismountLeavesMountInRoomAndArrivalsMentionIt(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stable": {ID: "stable", Title: "Stable", NPCs: []NPC{{Name: "Dune Strider", Mount: true}, {Name: "Groom"}},
+			Exits: map[string]Exit{"east": {To: "road"}}},
+		"road": {ID: "road", Title: "Road", Exits: map[string]Exit{"west": {To: "stable"}}},
+	})
+	rider := &Player{Name: "Rider", Room: "stable", Output: NewOutputQueue(), Alive: true}
+	watcher := &Player{Name: "Watcher", Room: "road", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(rider)
+	world.AddPlayerForTest(watcher)
+	if _, err := world.Mount(rider, "dune"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if room, _ := world.GetRoom("stable"); len(room.NPCs) != 1 {
+		t.Fatalf("mounted creature should leave the room, got %v", room.NPCs)
+	}
+	if _, err := world.Move(rider, "east"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	EnterRoom(world, rider, "west")
+	if got := stripAnsi(strings.Join(drainOutput(watcher.Output), "")); !strings.Contains(got, "Rider rides in from west on Dune Strider.") {
+		t.Fatalf("unexpected arrival message: %q", got)
+	}
+
+	if _, err := world.Dismount(rider); err != nil {
+		t.Fatalf("Dismount: %v", err)
+	}
+	if _, err := world.Dismount(rider); !errors.Is(err, ErrNotMounted) {
+		t.Fatalf("expected ErrNotMounted, got %v", err)
+	}
+	if room, _ := world.GetRoom("road"); len(room.NPCs) != 1 || room.NPCs[0].Name != "Dune Strider" {
+		t.Fatalf("mount should wait on the road, got %v", room.NPCs)
+	}
+
+	if _, err := world.Mount(rider, "dune"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	world.removePlayer(rider.Name)
+	if room, _ := world.GetRoom("road"); len(room.NPCs) != 1 {
+		t.Fatalf("logging out should leave the mount behind, got %v", room.NPCs)
+	}
+}
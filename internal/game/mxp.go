@@ -0,0 +1,140 @@
+package game
+
+import (
+	"strings"
+)
+
+// Links are embedded in outgoing text as private OSC markers so messages can
+// be built once and rendered per session: MXP clients receive clickable
+// <SEND> tags while every other terminal sees only the label. plainText
+// already strips OSC sequences, so logs and transcripts stay readable.
+const (
+	mxpMarker = "\x1b]mxp;"
+	mxpEnd    = "\x1b\\"
+	// mxpTempSecure lets the following tag through while the client stays
+	// in MXP's default open mode.
+	mxpTempSecure = "\x1b[4z"
+)
+
+// CommandLink makes label clickable for MXP clients. Clicking sends the first
+// command; further commands are offered in a right-click menu.
+func CommandLink(label string, commands ...string) string {
+	cleaned := make([]string, 0, len(commands))
+	for _, command := range commands {
+		command = strings.Map(func(r rune) rune {
+			if r < ' ' || r == '|' || r == 0x7f {
+				return -1
+			}
+			return r
+		}, strings.TrimSpace(command))
+		if command != "" {
+			cleaned = append(cleaned, command)
+		}
+	}
+	if len(cleaned) == 0 || label == "" {
+		return label
+	}
+	return mxpMarker + strings.Join(cleaned, "|") + mxpEnd + label + mxpMarker + mxpEnd
+}
+
+// ItemLink highlights an item name and links it to the given commands, each
+// of which is followed by the item's name.
+func ItemLink(name string, verbs ...string) string {
+	commands := make([]string, len(verbs))
+	for i, verb := range verbs {
+		commands[i] = verb + " " + name
+	}
+	return CommandLink(HighlightItemName(name), commands...)
+}
+
+// ItemLinkFor links an item like ItemLink but highlights it in its rarity's
+// color.
+func ItemLinkFor(item Item, verbs ...string) string {
+	commands := make([]string, len(verbs))
+	for i, verb := range verbs {
+		commands[i] = verb + " " + item.Name
+	}
+	return CommandLink(item.DisplayName(), commands...)
+}
+
+// ExitLinks turns a rendered exit list such as "east north(closed)" into
+// links that walk through each exit.
+func ExitLinks(list string) string {
+	if list == "none" {
+		return list
+	}
+	exits := strings.Fields(list)
+	for i, exit := range exits {
+		dir := strings.TrimSuffix(exit, "(closed)")
+		exits[i] = CommandLink(exit, "go "+dir)
+	}
+	return strings.Join(exits, " ")
+}
+
+// renderMXP converts link markers for the session. With MXP enabled the
+// surrounding text is escaped so stray angle brackets are not read as tags.
+func renderMXP(msg string, enabled bool) string {
+	if !strings.Contains(msg, mxpMarker) && !enabled {
+		return msg
+	}
+	var out strings.Builder
+	for {
+		idx := strings.Index(msg, mxpMarker)
+		if idx < 0 {
+			break
+		}
+		writeMXPText(&out, msg[:idx], enabled)
+		rest := msg[idx+len(mxpMarker):]
+		end := strings.Index(rest, mxpEnd)
+		if end < 0 {
+			msg = ""
+			break
+		}
+		href := rest[:end]
+		msg = rest[end+len(mxpEnd):]
+		if !enabled {
+			continue
+		}
+		if href == "" {
+			out.WriteString(mxpTempSecure + "</SEND>")
+			continue
+		}
+		out.WriteString(mxpTempSecure + `<SEND HREF="` + mxpAttrEscaper.Replace(href) + `">`)
+	}
+	writeMXPText(&out, msg, enabled)
+	return out.String()
+}
+
+func writeMXPText(out *strings.Builder, text string, enabled bool) {
+	if enabled {
+		text = mxpTextEscaper.Replace(text)
+	}
+	out.WriteString(text)
+}
+
+var (
+	mxpTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	mxpAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// MXP reports whether the client negotiated MXP.
+func (s *TelnetSession) MXP() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mxp
+}
+
+func (s *TelnetSession) setMXP(enabled bool) {
+	s.mu.Lock()
+	s.mxp = enabled
+	s.mu.Unlock()
+}
+
+// startMXP switches the client into MXP mode after it agrees to the option.
+func (s *TelnetSession) startMXP() {
+	if s.MXP() {
+		return
+	}
+	s.setMXP(true)
+	_ = s.writeSubnegotiation(telnetOptMXP, nil)
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderMXPDegradesToPlainText(t *testing.T) {
+	msg := "Exits: " + ExitLinks("east north(closed)") + "\r\nUsage: get <item> & more " + ItemLink("lantern", "get", "examine")
+
+	plain := plainText(renderMXP(msg, false))
+	if want := "Exits: east north(closed)\r\nUsage: get <item> & more lantern"; plain != want {
+		t.Fatalf("plain render = %q, want %q", plain, want)
+	}
+
+	rich := renderMXP(msg, true)
+	for _, want := range []string{
+		"\x1b[4z<SEND HREF=\"go east\">east\x1b[4z</SEND>",
+		"\x1b[4z<SEND HREF=\"go north\">north(closed)\x1b[4z</SEND>",
+		"<SEND HREF=\"get lantern|examine lantern\">",
+		"get &lt;item&gt; &amp; more",
+	} {
+		if !strings.Contains(rich, want) {
+			t.Fatalf("MXP render missing %q in %q", want, rich)
+		}
+	}
+	if got := renderMXP("plain <text>", false); got != "plain <text>" {
+		t.Fatalf("text without links should pass through, got %q", got)
+	}
+}
+
+func TestTelnetSessionNegotiatesMXP(t *testing.T) {
+	s, _, received := newPipeSession(t)
+
+	if err := s.WriteString(CommandLink("north", "go north")); err != nil {
+		t.Fatalf("WriteString error: %v", err)
+	}
+	if got := <-received; string(got) != "north" {
+		t.Fatalf("non-MXP output = %q, want the bare label", got)
+	}
+
+	s.handleNegotiation(telnetDO, telnetOptMXP)
+	if got := <-received; !bytes.Equal(got, []byte{telnetIAC, telnetSB, telnetOptMXP, telnetIAC, telnetSE}) {
+		t.Fatalf("MXP start = %v", got)
+	}
+	if !s.MXP() || !s.snapshotState().MXP {
+		t.Fatalf("expected MXP to be enabled and captured for copyover")
+	}
+	if err := s.WriteString(CommandLink("north", "go north")); err != nil {
+		t.Fatalf("WriteString error: %v", err)
+	}
+	if got := string(<-received); !strings.Contains(got, `<SEND HREF="go north">north`) {
+		t.Fatalf("MXP output = %q", got)
+	}
+}
@@ -0,0 +1,330 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// PoisonEffect names the status effect a poison strike leaves behind.
+	PoisonEffect = "poison"
+	// poisonTick is how often poison hurts the players it afflicts.
+	poisonTick = 6 * time.Second
+	// defaultAbilityChance is how likely an NPC is to use a ready ability
+	// in a round when the ability sets no chance of its own.
+	defaultAbilityChance = 50
+	// defaultAbilityCooldown applies to abilities that set no cooldown.
+	defaultAbilityCooldown = 15 * time.Second
+)
+
+// NPCAbilityKind identifies what an NPC ability does.
+type NPCAbilityKind string
+
+const (
+	// AbilityHeal restores Amount health to the NPC once it falls below
+	// half health.
+	AbilityHeal NPCAbilityKind = "heal"
+	// AbilityPoison strikes for Amount damage and poisons the target for
+	// Duration seconds, costing Poison health every few seconds.
+	AbilityPoison NPCAbilityKind = "poison"
+	// AbilitySummon calls Summon into the fight, up to Limit at a time.
+	AbilitySummon NPCAbilityKind = "summon"
+)
+
+// NPCAbility is something an NPC may do in a combat round instead of its
+// usual attack. Each round an NPC tries its ready abilities in order and
+// uses the first one whose situation fits and whose chance comes up.
+type NPCAbility struct {
+	Kind NPCAbilityKind `json:"kind"`
+	// Name is what players see, such as "venomous bite".
+	Name     string `json:"name,omitempty"`
+	Amount   int    `json:"amount,omitempty"`
+	Poison   int    `json:"poison,omitempty"`
+	Duration int    `json:"duration,omitempty"`
+	// Cooldown is how many seconds pass before the ability can be used
+	// again; 15 when unset.
+	Cooldown int `json:"cooldown,omitempty"`
+	// Chance is the percentage chance to use a ready ability each round;
+	// 50 when unset.
+	Chance int  `json:"chance,omitempty"`
+	Summon *NPC `json:"summon,omitempty"`
+	Limit  int  `json:"limit,omitempty"`
+}
+
+func (a NPCAbility) label() string {
+	if name := strings.TrimSpace(a.Name); name != "" {
+		return name
+	}
+	switch a.Kind {
+	case AbilityHeal:
+		return "healing"
+	case AbilityPoison:
+		return "poison strike"
+	case AbilitySummon:
+		return "summoning"
+	}
+	return string(a.Kind)
+}
+
+func (a NPCAbility) cooldown() time.Duration {
+	if a.Cooldown > 0 {
+		return time.Duration(a.Cooldown) * time.Second
+	}
+	return defaultAbilityCooldown
+}
+
+func (a NPCAbility) chance() int {
+	if a.Chance > 0 {
+		return a.Chance
+	}
+	return defaultAbilityChance
+}
+
+// validateNPCAbilities checks the abilities of every NPC and NPC reset.
+func validateNPCAbilities(rooms map[RoomID]*Room) error {
+	for id, room := range rooms {
+		for _, npc := range room.NPCs {
+			if err := validateAbilities(npc.Abilities); err != nil {
+				return fmt.Errorf("room %s: npc %s: %w", id, npc.Name, err)
+			}
+		}
+		for _, reset := range room.Resets {
+			if err := validateAbilities(reset.Abilities); err != nil {
+				return fmt.Errorf("room %s: reset %s: %w", id, reset.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAbilities checks that NPC abilities are complete enough to use.
+func validateAbilities(abilities []NPCAbility) error {
+	for _, ability := range abilities {
+		switch ability.Kind {
+		case AbilityHeal:
+			if ability.Amount <= 0 {
+				return fmt.Errorf("heal ability needs an amount")
+			}
+		case AbilityPoison:
+			if ability.Poison <= 0 || ability.Duration <= 0 {
+				return fmt.Errorf("poison ability needs poison and duration")
+			}
+		case AbilitySummon:
+			if ability.Summon == nil || strings.TrimSpace(ability.Summon.Name) == "" {
+				return fmt.Errorf("summon ability needs an NPC to summon")
+			}
+		default:
+			return fmt.Errorf("unknown ability kind %q", ability.Kind)
+		}
+	}
+	return nil
+}
+
+// cloneAbilities copies abilities so NPCs spawned from one reset do not share
+// their summons.
+func cloneAbilities(abilities []NPCAbility) []NPCAbility {
+	if abilities == nil {
+		return nil
+	}
+	out := make([]NPCAbility, len(abilities))
+	for i, ability := range abilities {
+		if ability.Summon != nil {
+			summon := *ability.Summon
+			ability.Summon = &summon
+		}
+		out[i] = ability
+	}
+	return out
+}
+
+// abilityUse records an ability an NPC chose this round.
+type abilityUse struct {
+	index   int
+	ability NPCAbility
+	// healed is the health a heal restored, and summoned the NPC a summon
+	// brought in.
+	healed   int
+	summoned string
+}
+
+// abilityReady reports whether npc's ability at index is off cooldown.
+func (c *combatInstance) abilityReady(npc string, index int, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !now.Before(c.cooldowns[fmt.Sprintf("%s|%d", strings.ToLower(npc), index)])
+}
+
+func (c *combatInstance) startCooldown(npc string, index int, until time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cooldowns == nil {
+		c.cooldowns = make(map[string]time.Time)
+	}
+	c.cooldowns[fmt.Sprintf("%s|%d", strings.ToLower(npc), index)] = until
+}
+
+// chooseAbility picks the ability npc uses against target this round, if
+// any. Heals and summons take effect at once; a poison strike is returned
+// for the caller to land like a normal attack.
+func (c *combatInstance) chooseAbility(name string, target *Player) (abilityUse, bool) {
+	npc, ok := c.world.FindRoomNPC(c.room, name)
+	if !ok || len(npc.Abilities) == 0 {
+		return abilityUse{}, false
+	}
+	now := time.Now()
+	var ready []int
+	for i := range npc.Abilities {
+		if c.abilityReady(npc.Name, i, now) {
+			ready = append(ready, i)
+		}
+	}
+	if len(ready) == 0 {
+		return abilityUse{}, false
+	}
+
+	c.world.mu.Lock()
+	room, ok := c.world.rooms[c.room]
+	idx := -1
+	if ok {
+		idx = findNPCIndex(room.NPCs, name)
+	}
+	if idx < 0 {
+		c.world.mu.Unlock()
+		return abilityUse{}, false
+	}
+	self := &room.NPCs[idx]
+	normalizeNPC(self)
+	var use abilityUse
+	found := false
+	for _, i := range ready {
+		if i >= len(self.Abilities) {
+			break
+		}
+		ability := self.Abilities[i]
+		switch ability.Kind {
+		case AbilityHeal:
+			if self.Health*2 >= self.MaxHealth {
+				continue
+			}
+		case AbilityPoison:
+			if target.HasEffect(PoisonEffect) {
+				continue
+			}
+		case AbilitySummon:
+			if ability.Summon == nil || countNPCs(room, ability.Summon.Name) >= max(1, ability.Limit) {
+				continue
+			}
+		default:
+			continue
+		}
+		if c.world.roll(100) >= ability.chance() {
+			continue
+		}
+		use, found = abilityUse{index: i, ability: ability}, true
+		break
+	}
+	if !found {
+		c.world.mu.Unlock()
+		return abilityUse{}, false
+	}
+	switch use.ability.Kind {
+	case AbilityHeal:
+		use.healed = restore(&self.Health, self.MaxHealth, use.ability.Amount)
+	case AbilitySummon:
+		summoned := *use.ability.Summon
+		summoned.Abilities = cloneAbilities(summoned.Abilities)
+		normalizeNPC(&summoned)
+		room.NPCs = append(room.NPCs, summoned)
+		use.summoned = summoned.Name
+	}
+	c.world.mu.Unlock()
+
+	c.startCooldown(name, use.index, now.Add(use.ability.cooldown()))
+	if use.summoned != "" {
+		c.addNPC(use.summoned, combatTarget{kind: combatTargetPlayer, name: target.Name})
+	}
+	return use, true
+}
+
+func countNPCs(room *Room, name string) int {
+	count := 0
+	for _, npc := range room.NPCs {
+		if strings.EqualFold(npc.Name, name) {
+			count++
+		}
+	}
+	return count
+}
+
+// announceAbility tells the room about a heal or summon.
+func (c *combatInstance) announceAbility(npcName string, use abilityUse) {
+	name := HighlightNPCName(npcName)
+	switch use.ability.Kind {
+	case AbilityHeal:
+		c.world.BroadcastToRoom(c.room, Ansi(fmt.Sprintf("\r\n%s uses %s and recovers %d health.", name, use.ability.label(), use.healed)), nil)
+	case AbilitySummon:
+		c.world.BroadcastToRoom(c.room, Ansi(fmt.Sprintf("\r\n%s uses %s, and %s answers the call!", name, use.ability.label(), HighlightNPCName(use.summoned))), nil)
+	}
+}
+
+// poisonPlayer leaves a poison effect on p from ability.
+func (w *World) poisonPlayer(p *Player, ability NPCAbility) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	p.addEffect(StatusEffect{
+		Name:    PoisonEffect,
+		Expires: time.Now().Add(time.Duration(ability.Duration) * time.Second),
+		Poison:  ability.Poison,
+	})
+}
+
+// PoisonTick hurts every poisoned player. Poison wears a player down but
+// always leaves them at least one health.
+func (w *World) PoisonTick() {
+	type hurt struct {
+		player *Player
+		damage int
+	}
+	var hurts []hurt
+	now := time.Now()
+	w.mu.Lock()
+	for _, p := range w.players {
+		if !p.Alive {
+			continue
+		}
+		damage := 0
+		for _, effect := range p.ActiveEffects(now) {
+			damage += effect.Poison
+		}
+		p.EnsureStats()
+		damage = min(damage, p.Health-1)
+		if damage <= 0 {
+			continue
+		}
+		p.Health -= damage
+		hurts = append(hurts, hurt{player: p, damage: damage})
+	}
+	w.mu.Unlock()
+	for _, h := range hurts {
+		if h.player.Output != nil {
+			h.player.Output.Send(Ansi(Style(fmt.Sprintf("\r\nPoison burns through your veins for %d damage. (%d/%d HP)", h.damage, h.player.Health, h.player.MaxHealth), AnsiGreen)))
+		}
+	}
+}
+
+// StartPoisonLoop periodically hurts poisoned players until stop is closed.
+func (w *World) StartPoisonLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(poisonTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				w.PoisonTick()
+			}
+		}
+	}()
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNPCHealsWhenHurtThenWaitsOutCooldown(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"lair": {ID: "lair", Title: "Lair", Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Troll", Level: 2, Health: 10, MaxHealth: 100,
+			Abilities: []NPCAbility{{Kind: AbilityHeal, Amount: 30, Cooldown: 60}}}}},
+	})
+	hero := &Player{Name: "Hero", Room: "lair", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(hero)
+	hero.EnsureStats()
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+	combat := world.ensureCombat("lair")
+	combat.addNPC("Troll", combatTarget{kind: combatTargetPlayer, name: hero.Name})
+	target := combatTarget{kind: combatTargetPlayer, name: hero.Name}
+
+	combat.resolveNPCAttack("Troll", target)
+	troll, _ := world.FindRoomNPC("lair", "Troll")
+	if troll.Health != 40 || hero.Health != hero.MaxHealth {
+		t.Fatalf("expected the troll to heal instead of attacking, troll %d, hero %d", troll.Health, hero.Health)
+	}
+
+	combat.resolveNPCAttack("Troll", target)
+	troll, _ = world.FindRoomNPC("lair", "Troll")
+	if troll.Health != 40 || hero.Health >= hero.MaxHealth {
+		t.Fatalf("expected a plain attack while the heal cools down, troll %d, hero %d", troll.Health, hero.Health)
+	}
+}
+
+func TestNPCPoisonStrikeTicksButNeverKills(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"lair": {ID: "lair", Title: "Lair", Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Spider", Level: 1,
+			Abilities: []NPCAbility{{Kind: AbilityPoison, Amount: 5, Poison: 4, Duration: 60, Cooldown: 1}}}}},
+	})
+	hero := &Player{Name: "Hero", Room: "lair", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(hero)
+	hero.EnsureStats()
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+	combat := world.ensureCombat("lair")
+	combat.addNPC("Spider", combatTarget{kind: combatTargetPlayer, name: hero.Name})
+	combat.resolveNPCAttack("Spider", combatTarget{kind: combatTargetPlayer, name: hero.Name})
+	if hero.Health != hero.MaxHealth-5 || !hero.HasEffect(PoisonEffect) {
+		t.Fatalf("expected a poisoned 5 damage bite, health %d, effects %+v", hero.Health, hero.Effects)
+	}
+
+	world.PoisonTick()
+	if hero.Health != hero.MaxHealth-9 {
+		t.Fatalf("health after tick = %d, want %d", hero.Health, hero.MaxHealth-9)
+	}
+	hero.Health = 3
+	world.PoisonTick()
+	world.PoisonTick()
+	if hero.Health != 1 {
+		t.Fatalf("poison should leave one health, got %d", hero.Health)
+	}
+}
+
+func TestNPCSummonsReinforcementUpToLimit(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"lair": {ID: "lair", Title: "Lair", Exits: map[string]Exit{}, NPCs: []NPC{{Name: "Matriarch", Level: 3,
+			Abilities: []NPCAbility{{Kind: AbilitySummon, Cooldown: 1, Summon: &NPC{Name: "Hatchling", Level: 1}}}}}},
+	})
+	hero := &Player{Name: "Hero", Room: "lair", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(hero)
+	hero.EnsureStats()
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+	combat := world.ensureCombat("lair")
+	combat.addNPC("Matriarch", combatTarget{kind: combatTargetPlayer, name: hero.Name})
+	combat.resolveNPCAttack("Matriarch", combatTarget{kind: combatTargetPlayer, name: hero.Name})
+	lair, _ := world.GetRoom("lair")
+	if len(lair.NPCs) != 2 || lair.NPCs[1].Name != "Hatchling" || lair.NPCs[1].Health == 0 {
+		t.Fatalf("expected a hatchling to join, got %+v", lair.NPCs)
+	}
+	if !combat.hasNPC("Hatchling") || hero.Health != hero.MaxHealth {
+		t.Fatalf("expected the hatchling to join the fight in place of an attack")
+	}
+
+	combat.mu.Lock()
+	combat.cooldowns = nil
+	combat.mu.Unlock()
+	combat.resolveNPCAttack("Matriarch", combatTarget{kind: combatTargetPlayer, name: hero.Name})
+	lair, _ = world.GetRoom("lair")
+	if len(lair.NPCs) != 2 || hero.Health >= hero.MaxHealth {
+		t.Fatalf("expected the matriarch to attack once its limit is reached, got %+v", lair.NPCs)
+	}
+	combat.stopLoop()
+}
+
+func TestValidateAbilitiesRejectsIncompleteAbilities(t *testing.T) {
+	for _, ability := range []NPCAbility{
+		{Kind: AbilityHeal},
+		{Kind: AbilityPoison, Poison: 2},
+		{Kind: AbilitySummon},
+		{Kind: "dance"},
+	} {
+		if err := validateAbilities([]NPCAbility{ability}); err == nil {
+			t.Fatalf("expected %+v to be rejected", ability)
+		}
+	}
+	if err := validateAbilities([]NPCAbility{{Kind: AbilityHeal, Amount: 10}}); err != nil {
+		t.Fatalf("validateAbilities: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/traefik/yaegi/interp"
 	"github.com/traefik/yaegi/stdlib"
@@ -21,6 +22,8 @@ type NPCScriptContext struct {
 	npc     NPC
 	Speaker *NPCSpeaker
 	Message string
+	player  *Player
+	quest   *Quest
 }
 
 func (ctx *NPCScriptContext) NPCName() string {
@@ -72,6 +75,8 @@ type RoomScriptContext struct {
 	room   *Room
 	player *Player
 	via    string
+	quest  *Quest
+	extras map[string]string
 }
 
 func (ctx *RoomScriptContext) Broadcast(text string) {
@@ -96,15 +101,36 @@ func (ctx *RoomScriptContext) Narrate(text string) {
 	}
 	width, _ := ctx.player.WindowSize()
 	wrapped := WrapText(cleaned, width)
-	ctx.player.Output <- Ansi(fmt.Sprintf("\r\n%s", Style(wrapped, AnsiItalic, AnsiDim)))
+	ctx.player.Output.Send(Ansi(fmt.Sprintf("\r\n%s", Style(wrapped, AnsiItalic, AnsiDim))))
+}
+
+// Reveal shows a hidden exit in the room to the player who triggered the
+// hook.
+func (ctx *RoomScriptContext) Reveal(direction string) {
+	if ctx == nil || ctx.world == nil || ctx.player == nil {
+		return
+	}
+	if ctx.world.RevealExit(ctx.player, direction) {
+		ctx.player.Output.Send(Ansi(fmt.Sprintf("\r\nYou discover a hidden exit leading %s!", strings.ToLower(strings.TrimSpace(direction)))))
+	}
 }
 
 type AreaScriptContext struct {
 	world  *World
 	area   areaMetadata
+	source string
 	room   *Room
 	player *Player
 	via    string
+	clock  *areaClock
+}
+
+// areaClock carries the time and weather passed to OnTime and OnWeather.
+type areaClock struct {
+	hour     int
+	phase    TimeOfDay
+	weather  Weather
+	previous Weather
 }
 
 func (ctx *AreaScriptContext) Narrate(text string) {
@@ -118,11 +144,13 @@ func (ctx *AreaScriptContext) Narrate(text string) {
 	width, _ := ctx.player.WindowSize()
 	wrapped := WrapText(cleaned, width)
 	prefix := Style(fmt.Sprintf("[%s]", ctx.area.Name), AnsiBold, AnsiMagenta)
-	ctx.player.Output <- Ansi(fmt.Sprintf("\r\n%s %s", prefix, Style(wrapped, AnsiItalic)))
+	ctx.player.Output.Send(Ansi(fmt.Sprintf("\r\n%s %s", prefix, Style(wrapped, AnsiItalic))))
 }
 
+// Broadcast speaks to the room that triggered the hook, or to everyone in
+// the area when the hook was triggered by the clock or weather.
 func (ctx *AreaScriptContext) Broadcast(text string) {
-	if ctx == nil || ctx.world == nil || ctx.room == nil {
+	if ctx == nil || ctx.world == nil {
 		return
 	}
 	cleaned := strings.TrimSpace(text)
@@ -131,7 +159,13 @@ func (ctx *AreaScriptContext) Broadcast(text string) {
 	}
 	prefix := Style(fmt.Sprintf("[%s]", ctx.area.Name), AnsiBold, AnsiMagenta)
 	message := Ansi(fmt.Sprintf("\r\n%s %s", prefix, cleaned))
-	ctx.world.BroadcastToRoom(ctx.room.ID, message, nil)
+	if ctx.room != nil {
+		ctx.world.BroadcastToRoom(ctx.room.ID, message, nil)
+		return
+	}
+	if ctx.source != "" {
+		ctx.world.broadcastToArea(ctx.source, message)
+	}
 }
 
 type ItemScriptContext struct {
@@ -152,7 +186,7 @@ func (ctx *ItemScriptContext) Describe(text string) {
 	}
 	width, _ := ctx.player.WindowSize()
 	wrapped := WrapText(cleaned, width)
-	ctx.player.Output <- Ansi(fmt.Sprintf("\r\n%s", Style(wrapped, AnsiItalic)))
+	ctx.player.Output.Send(Ansi(fmt.Sprintf("\r\n%s", Style(wrapped, AnsiItalic))))
 }
 
 type scriptEntry struct {
@@ -165,6 +199,25 @@ type compiledScript struct {
 	onHear    func(map[string]any)
 	onLook    func(map[string]any)
 	onInspect func(map[string]any)
+	onTime    func(map[string]any)
+	onWeather func(map[string]any)
+
+	onQuestAccept   func(map[string]any)
+	onQuestComplete func(map[string]any)
+	onReceive       func(map[string]any)
+	onSchedule      func(map[string]any)
+	onPhase         func(map[string]any)
+}
+
+// questHook returns the quest hook named hook, if the script defines it.
+func (c *compiledScript) questHook(hook string) func(map[string]any) {
+	switch hook {
+	case "OnQuestAccept":
+		return c.onQuestAccept
+	case "OnQuestComplete":
+		return c.onQuestComplete
+	}
+	return nil
 }
 
 type scriptEngine struct {
@@ -182,13 +235,13 @@ func (e *scriptEngine) callNPCOnEnter(world *World, room RoomID, npc NPC, speake
 	}
 	script, err := e.scriptFor(npc.Script)
 	if err != nil {
-		fmt.Printf("NPC script failed to load: %v\n", err)
+		Logger().Error("NPC script failed to load", "npc", npc.Name, "error", err)
 		return
 	}
 	if script == nil || script.onEnter == nil {
 		return
 	}
-	ctx := &NPCScriptContext{world: world, room: room, npc: npc, Speaker: speaker}
+	ctx := &NPCScriptContext{world: world, room: room, npc: npc, Speaker: speaker, player: world.speakingPlayer(speaker)}
 	payload := e.payloadForNPC(ctx, "")
 	e.invoke(npc.Script, "OnEnter", func() {
 		script.onEnter(payload)
@@ -201,26 +254,63 @@ func (e *scriptEngine) callNPCOnHear(world *World, room RoomID, npc NPC, speaker
 	}
 	script, err := e.scriptFor(npc.Script)
 	if err != nil {
-		fmt.Printf("NPC script failed to load: %v\n", err)
+		Logger().Error("NPC script failed to load", "npc", npc.Name, "error", err)
 		return
 	}
 	if script == nil || script.onHear == nil {
 		return
 	}
-	ctx := &NPCScriptContext{world: world, room: room, npc: npc, Speaker: speaker, Message: message}
+	ctx := &NPCScriptContext{world: world, room: room, npc: npc, Speaker: speaker, Message: message, player: world.speakingPlayer(speaker)}
 	payload := e.payloadForNPC(ctx, message)
 	e.invoke(npc.Script, "OnHear", func() {
 		script.onHear(payload)
 	})
 }
 
+// receives reports whether the NPC's script defines OnReceive, which makes
+// the NPC accept any item handed to it.
+func (e *scriptEngine) receives(npc NPC) bool {
+	if e == nil || strings.TrimSpace(npc.Script) == "" {
+		return false
+	}
+	script, err := e.scriptFor(npc.Script)
+	if err != nil {
+		Logger().Error("NPC script failed to load", "npc", npc.Name, "error", err)
+		return false
+	}
+	return script != nil && script.onReceive != nil
+}
+
+// callNPCOnReceive runs OnReceive after player gives item to the NPC.
+func (e *scriptEngine) callNPCOnReceive(world *World, npc NPC, player *Player, item Item, questItem bool) {
+	if e == nil || player == nil {
+		return
+	}
+	script, err := e.scriptFor(npc.Script)
+	if err != nil {
+		Logger().Error("NPC script failed to load", "npc", npc.Name, "error", err)
+		return
+	}
+	if script == nil || script.onReceive == nil {
+		return
+	}
+	ctx := &NPCScriptContext{world: world, room: player.Room, npc: npc, Speaker: &NPCSpeaker{Name: player.Name}, player: player}
+	payload := e.payloadForNPC(ctx, "")
+	payload["item"] = item.Name
+	payload["base_item"] = item.BaseName()
+	payload["quest_item"] = questItem
+	e.invoke(npc.Script, "OnReceive", func() {
+		script.onReceive(payload)
+	})
+}
+
 func (e *scriptEngine) callRoomOnEnter(world *World, room *Room, player *Player, via string) {
 	if e == nil || room == nil || strings.TrimSpace(room.Script) == "" {
 		return
 	}
 	script, err := e.scriptFor(room.Script)
 	if err != nil {
-		fmt.Printf("Room %s script failed to load: %v\n", room.ID, err)
+		Logger().Error("room script failed to load", "room", room.ID, "error", err)
 		return
 	}
 	if script == nil || script.onEnter == nil {
@@ -233,23 +323,75 @@ func (e *scriptEngine) callRoomOnEnter(world *World, room *Room, player *Player,
 	})
 }
 
-func (e *scriptEngine) callRoomOnLook(world *World, room *Room, player *Player) {
+// callRoomOnLook runs OnLook for a look at the room, or at target when the
+// player looked at something nothing else in the room matched. It returns
+// the extras the script added.
+func (e *scriptEngine) callRoomOnLook(world *World, room *Room, player *Player, target string) map[string]string {
 	if e == nil || room == nil || strings.TrimSpace(room.Script) == "" {
-		return
+		return nil
 	}
 	script, err := e.scriptFor(room.Script)
 	if err != nil {
-		fmt.Printf("Room %s script failed to load: %v\n", room.ID, err)
-		return
+		Logger().Error("room script failed to load", "room", room.ID, "error", err)
+		return nil
 	}
 	if script == nil || script.onLook == nil {
-		return
+		return nil
 	}
 	ctx := &RoomScriptContext{world: world, room: room, player: player}
 	payload := e.payloadForRoom(ctx, "OnLook")
+	payload["target"] = target
+	payload["add_extra"] = func(keywords, text string) {
+		ctx.extras = withExtra(ctx.extras, keywords, text)
+	}
 	e.invoke(fmt.Sprintf("room:%s", room.ID), "OnLook", func() {
 		script.onLook(payload)
 	})
+	return ctx.extras
+}
+
+// callNPCOnQuest runs OnQuestAccept or OnQuestComplete on the script of the
+// NPC that handed out or received the quest.
+func (e *scriptEngine) callNPCOnQuest(world *World, npc NPC, player *Player, quest *Quest, hook string) {
+	if e == nil || player == nil || quest == nil {
+		return
+	}
+	script, err := e.scriptFor(npc.Script)
+	if err != nil {
+		Logger().Error("NPC script failed to load", "npc", npc.Name, "error", err)
+		return
+	}
+	if script == nil || script.questHook(hook) == nil {
+		return
+	}
+	ctx := &NPCScriptContext{world: world, room: player.Room, npc: npc, Speaker: &NPCSpeaker{Name: player.Name}, player: player, quest: quest}
+	payload := e.payloadForNPC(ctx, "")
+	fn := script.questHook(hook)
+	e.invoke(npc.Script, hook, func() {
+		fn(payload)
+	})
+}
+
+// callRoomOnQuest runs OnQuestAccept or OnQuestComplete on the script of the
+// room where the player accepted or turned in the quest.
+func (e *scriptEngine) callRoomOnQuest(world *World, room *Room, player *Player, quest *Quest, hook string) {
+	if e == nil || room == nil || player == nil || quest == nil || strings.TrimSpace(room.Script) == "" {
+		return
+	}
+	script, err := e.scriptFor(room.Script)
+	if err != nil {
+		Logger().Error("room script failed to load", "room", room.ID, "error", err)
+		return
+	}
+	if script == nil || script.questHook(hook) == nil {
+		return
+	}
+	ctx := &RoomScriptContext{world: world, room: room, player: player, quest: quest}
+	payload := e.payloadForRoom(ctx, hook)
+	fn := script.questHook(hook)
+	e.invoke(fmt.Sprintf("room:%s", room.ID), hook, func() {
+		fn(payload)
+	})
 }
 
 func (e *scriptEngine) callAreaOnEnter(world *World, area areaMetadata, room *Room, player *Player, via string) {
@@ -258,7 +400,7 @@ func (e *scriptEngine) callAreaOnEnter(world *World, area areaMetadata, room *Ro
 	}
 	script, err := e.scriptFor(area.Script)
 	if err != nil {
-		fmt.Printf("Area %s script failed to load: %v\n", area.Name, err)
+		Logger().Error("area script failed to load", "area", area.Name, "error", err)
 		return
 	}
 	if script == nil || script.onEnter == nil {
@@ -271,13 +413,51 @@ func (e *scriptEngine) callAreaOnEnter(world *World, area areaMetadata, room *Ro
 	})
 }
 
+func (e *scriptEngine) callAreaOnTime(world *World, source string, area areaMetadata, clock areaClock) {
+	if e == nil || strings.TrimSpace(area.Script) == "" {
+		return
+	}
+	script, err := e.scriptFor(area.Script)
+	if err != nil {
+		Logger().Error("area script failed to load", "area", area.Name, "error", err)
+		return
+	}
+	if script == nil || script.onTime == nil {
+		return
+	}
+	ctx := &AreaScriptContext{world: world, area: area, source: source, clock: &clock}
+	payload := e.payloadForArea(ctx)
+	e.invoke(fmt.Sprintf("area:%s", area.Name), "OnTime", func() {
+		script.onTime(payload)
+	})
+}
+
+func (e *scriptEngine) callAreaOnWeather(world *World, source string, area areaMetadata, clock areaClock) {
+	if e == nil || strings.TrimSpace(area.Script) == "" {
+		return
+	}
+	script, err := e.scriptFor(area.Script)
+	if err != nil {
+		Logger().Error("area script failed to load", "area", area.Name, "error", err)
+		return
+	}
+	if script == nil || script.onWeather == nil {
+		return
+	}
+	ctx := &AreaScriptContext{world: world, area: area, source: source, clock: &clock}
+	payload := e.payloadForArea(ctx)
+	e.invoke(fmt.Sprintf("area:%s", area.Name), "OnWeather", func() {
+		script.onWeather(payload)
+	})
+}
+
 func (e *scriptEngine) callItemOnInspect(world *World, room RoomID, item *Item, player *Player, location string) {
 	if e == nil || item == nil || strings.TrimSpace(item.Script) == "" {
 		return
 	}
 	script, err := e.scriptFor(item.Script)
 	if err != nil {
-		fmt.Printf("Item %s script failed to load: %v\n", item.Name, err)
+		Logger().Error("item script failed to load", "item", item.Name, "error", err)
 		return
 	}
 	if script == nil || script.onInspect == nil {
@@ -291,9 +471,11 @@ func (e *scriptEngine) callItemOnInspect(world *World, room RoomID, item *Item,
 }
 
 func (e *scriptEngine) invoke(name, hook string, fn func()) {
+	start := time.Now()
 	defer func() {
+		metrics.observeScript(hook, time.Since(start))
 		if r := recover(); r != nil {
-			fmt.Printf("script %s %s panic: %v\n", name, hook, r)
+			Logger().Error("script panic", "script", name, "hook", hook, "panic", r)
 		}
 	}()
 	fn()
@@ -321,6 +503,10 @@ func (e *scriptEngine) payloadForNPC(ctx *NPCScriptContext, message string) map[
 	if strings.TrimSpace(message) != "" {
 		payload["message"] = message
 	}
+	addQuestHelpers(payload, ctx.world, ctx.player, ctx.quest)
+	addFactionHelpers(payload, ctx.world, ctx.player)
+	addWorldHelpers(payload, ctx.world, ctx.room, ctx.player)
+	e.addTimerHelpers(payload, ctx.world, fmt.Sprintf("npc:%s@%s", ctx.npc.Name, ctx.room), ctx.room)
 	return payload
 }
 
@@ -338,7 +524,14 @@ func (e *scriptEngine) payloadForRoom(ctx *RoomScriptContext, hook string) map[s
 	if ctx.player != nil {
 		payload["player"] = ctx.player.Name
 		payload["via"] = ctx.via
+		payload["reveal"] = func(direction string) {
+			ctx.Reveal(direction)
+		}
 	}
+	addQuestHelpers(payload, ctx.world, ctx.player, ctx.quest)
+	addFactionHelpers(payload, ctx.world, ctx.player)
+	addWorldHelpers(payload, ctx.world, ctx.room.ID, ctx.player)
+	e.addTimerHelpers(payload, ctx.world, fmt.Sprintf("room:%s", ctx.room.ID), ctx.room.ID)
 	return payload
 }
 
@@ -352,13 +545,23 @@ func (e *scriptEngine) payloadForArea(ctx *AreaScriptContext) map[string]any {
 		},
 		"area": ctx.area.Name,
 	}
+	e.addTimerHelpers(payload, ctx.world, fmt.Sprintf("area:%s", ctx.area.Name), "")
 	if ctx.room != nil {
 		payload["room"] = string(ctx.room.ID)
+		addWorldHelpers(payload, ctx.world, ctx.room.ID, ctx.player)
 	}
 	if ctx.player != nil {
 		payload["player"] = ctx.player.Name
 		payload["via"] = ctx.via
 	}
+	if ctx.clock != nil {
+		payload["hour"] = ctx.clock.hour
+		payload["phase"] = string(ctx.clock.phase)
+		payload["weather"] = string(ctx.clock.weather)
+		if ctx.clock.previous != "" {
+			payload["previous"] = string(ctx.clock.previous)
+		}
+	}
 	return payload
 }
 
@@ -410,41 +613,35 @@ func (e *scriptEngine) compile(source string) (*compiledScript, error) {
 		return nil, fmt.Errorf("compile: %w", err)
 	}
 	compiled := &compiledScript{}
-	if value, err := interpreter.Eval("OnEnter"); err == nil {
-		fn, ok := value.Interface().(func(map[string]any))
-		if !ok {
-			return nil, fmt.Errorf("OnEnter has unexpected type %T", value.Interface())
+	hooks := []struct {
+		name string
+		fn   *func(map[string]any)
+	}{
+		{"OnEnter", &compiled.onEnter},
+		{"OnHear", &compiled.onHear},
+		{"OnLook", &compiled.onLook},
+		{"OnInspect", &compiled.onInspect},
+		{"OnTime", &compiled.onTime},
+		{"OnWeather", &compiled.onWeather},
+		{"OnQuestAccept", &compiled.onQuestAccept},
+		{"OnQuestComplete", &compiled.onQuestComplete},
+		{"OnReceive", &compiled.onReceive},
+		{"OnSchedule", &compiled.onSchedule},
+		{"OnPhase", &compiled.onPhase},
+	}
+	for _, hook := range hooks {
+		value, err := interpreter.Eval(hook.name)
+		if err != nil {
+			if isUndefinedSymbol(err) {
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", hook.name, err)
 		}
-		compiled.onEnter = fn
-	} else if !isUndefinedSymbol(err) {
-		return nil, fmt.Errorf("OnEnter: %w", err)
-	}
-	if value, err := interpreter.Eval("OnHear"); err == nil {
-		fn, ok := value.Interface().(func(map[string]any))
-		if !ok {
-			return nil, fmt.Errorf("OnHear has unexpected type %T", value.Interface())
-		}
-		compiled.onHear = fn
-	} else if !isUndefinedSymbol(err) {
-		return nil, fmt.Errorf("OnHear: %w", err)
-	}
-	if value, err := interpreter.Eval("OnLook"); err == nil {
-		fn, ok := value.Interface().(func(map[string]any))
-		if !ok {
-			return nil, fmt.Errorf("OnLook has unexpected type %T", value.Interface())
-		}
-		compiled.onLook = fn
-	} else if !isUndefinedSymbol(err) {
-		return nil, fmt.Errorf("OnLook: %w", err)
-	}
-	if value, err := interpreter.Eval("OnInspect"); err == nil {
 		fn, ok := value.Interface().(func(map[string]any))
 		if !ok {
-			return nil, fmt.Errorf("OnInspect has unexpected type %T", value.Interface())
+			return nil, fmt.Errorf("%s has unexpected type %T", hook.name, value.Interface())
 		}
-		compiled.onInspect = fn
-	} else if !isUndefinedSymbol(err) {
-		return nil, fmt.Errorf("OnInspect: %w", err)
+		*hook.fn = fn
 	}
 	return compiled, nil
 }
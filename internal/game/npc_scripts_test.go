@@ -1,22 +1,28 @@
 package game
 
 import (
+	"errors"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
-var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]|\x1b\][^\x1b]*\x1b\\`)
 
-func drainOutput(ch chan string) []string {
-	var out []string
+func drainOutput(out *OutputQueue) []string {
+	return out.Drain()
+}
+
+// awaitOutput waits up to timeout for output delivered from another
+// goroutine and takes everything queued.
+func awaitOutput(out *OutputQueue, timeout time.Duration) string {
+	deadline := time.Now().Add(timeout)
 	for {
-		select {
-		case msg := <-ch:
-			out = append(out, msg)
-		default:
-			return out
+		if msgs := out.Drain(); len(msgs) > 0 || time.Now().After(deadline) {
+			return strings.Join(msgs, "")
 		}
+		time.Sleep(5 * time.Millisecond)
 	}
 }
 
@@ -57,7 +63,7 @@ func OnHear(ctx map[string]any) {
 	}
 	world := NewWorldWithRooms(rooms)
 
-	player := &Player{Name: "Tester", Room: StartRoom, Output: make(chan string, 16), Alive: true}
+	player := &Player{Name: "Tester", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
 	world.AddPlayerForTest(player)
 
 	EnterRoom(world, player, "")
@@ -108,7 +114,7 @@ func OnEnter(ctx map[string]any) {
 	world.roomSources[StartRoom] = "start.json"
 	world.areaMeta["start.json"] = areaMetadata{Name: "Lumen Clay Convergence", Script: areaScript}
 
-	player := &Player{Name: "Scholar", Room: StartRoom, Output: make(chan string, 16), Alive: true}
+	player := &Player{Name: "Scholar", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
 	world.AddPlayerForTest(player)
 
 	EnterRoom(world, player, "")
@@ -144,7 +150,7 @@ func OnInspect(ctx map[string]any) {
 		},
 	}
 	world := NewWorldWithRooms(rooms)
-	player := &Player{Name: "Artisan", Room: StartRoom, Output: make(chan string, 16), Alive: true}
+	player := &Player{Name: "Artisan", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
 	world.AddPlayerForTest(player)
 
 	item := &rooms[StartRoom].Items[0]
@@ -154,3 +160,211 @@ func OnInspect(ctx map[string]any) {
 		t.Fatalf("expected item inspect flourish, got %q", outputs)
 	}
 }
+
+func TestQuestScriptHooks(t *testing.T) {
+	npcScript := `package main
+
+func OnQuestAccept(ctx map[string]any) {
+    quest := ctx["quest"].(string)
+    ctx["grant_item"].(func(string, string))("Sealed Letter", "A letter stamped with the warden's seal.")
+    if !ctx["advance_objective"].(func(string, string) bool)(quest, "Warden") {
+        ctx["say"].(func(string))("failed to advance")
+    }
+}
+
+func OnHear(ctx map[string]any) {
+    if ctx["has_quest"].(func(string) bool)("ember_trial") {
+        ctx["tell"].(func(string))("The warden already sleeps.")
+    }
+}`
+
+	roomScript := `package main
+
+func OnQuestComplete(ctx map[string]any) {
+    ctx["narrate"].(func(string))("The braziers flare for " + ctx["quest_name"].(string) + ".")
+}`
+
+	quest := &Quest{
+		ID:            "ember_trial",
+		Name:          "Ember Trial",
+		Giver:         "Guide",
+		RequiredKills: []QuestKillRequirement{{NPC: "Warden", Count: 1}},
+	}
+	normalizeQuest(quest)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {
+			ID:     StartRoom,
+			Title:  "Ember Hall",
+			NPCs:   []NPC{{Name: "Guide", Script: npcScript}},
+			Script: roomScript,
+		},
+	})
+	world.quests = map[string]*Quest{quest.ID: quest}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	player := &Player{Name: "Hero", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+
+	if _, err := world.AcceptQuest(player, quest.ID); err != nil {
+		t.Fatalf("AcceptQuest returned error: %v", err)
+	}
+	world.TriggerQuestAccept(player, quest)
+	outputs := stripAnsi(strings.Join(drainOutput(player.Output), "\n"))
+	if !strings.Contains(outputs, "You receive Sealed Letter.") {
+		t.Fatalf("expected granted item message, got %q", outputs)
+	}
+	if !strings.Contains(outputs, "Ember Trial objectives complete") {
+		t.Fatalf("expected scripted objective progress, got %q", outputs)
+	}
+	if _, ok := world.FindInventoryItem(player, "Sealed Letter"); !ok {
+		t.Fatalf("expected granted item in inventory, got %+v", player.Inventory)
+	}
+
+	world.HandlePlayerSpeech(player, "hello")
+	outputs = stripAnsi(strings.Join(drainOutput(player.Output), "\n"))
+	if !strings.Contains(outputs, "The warden already sleeps.") {
+		t.Fatalf("expected has_quest to see the active quest, got %q", outputs)
+	}
+
+	result, err := world.CompleteQuest(player, quest.ID)
+	if err != nil {
+		t.Fatalf("CompleteQuest returned error: %v", err)
+	}
+	world.TriggerQuestComplete(player, result.Quest)
+	outputs = stripAnsi(strings.Join(drainOutput(player.Output), "\n"))
+	if !strings.Contains(outputs, "The braziers flare for Ember Trial.") {
+		t.Fatalf("expected room completion hook, got %q", outputs)
+	}
+	if world.HasActiveQuest(player, quest.ID) {
+		t.Fatalf("expected completed quest to no longer be active")
+	}
+	if _, err := world.AdvanceQuestObjective(player, quest.ID, "Warden"); err == nil {
+		t.Fatalf("expected advancing a completed quest to fail")
+	}
+}
+
+func TestTrustedScriptsMutateWorld(t *testing.T) {
+	roomScript := `package main
+
+func OnLook(ctx map[string]any) {
+    spawn, ok := ctx["spawn_item"].(func(string, string) bool)
+    if !ok {
+        ctx["narrate"].(func(string))("Nothing stirs.")
+        return
+    }
+    if ctx["room_flag"].(func(string) bool)("lit") {
+        return
+    }
+    ctx["set_room_flag"].(func(string, bool))("lit", true)
+    spawn("Glowing Key", "A key of banked embers.")
+    ctx["open_exit"].(func(string) bool)("north")
+}`
+
+	npcScript := `package main
+
+func OnHear(ctx map[string]any) {
+    if ctx["message"].(string) == "descend" {
+        ctx["move_player"].(func(string) bool)("vault")
+    }
+}`
+
+	rooms := map[RoomID]*Room{
+		StartRoom: {
+			ID:     StartRoom,
+			Title:  "Ember Gate",
+			Exits:  map[string]Exit{"north": {To: "vault", Door: true, Closed: true, Locked: true, Key: "Glowing Key"}},
+			NPCs:   []NPC{{Name: "Porter", Script: npcScript}},
+			Script: roomScript,
+		},
+		"vault": {
+			ID:    "vault",
+			Title: "Ember Vault",
+			Exits: map[string]Exit{"south": {To: StartRoom, Door: true, Closed: true, Locked: true, Key: "Glowing Key"}},
+		},
+		"annex": {ID: "annex", Title: "Annex", Script: roomScript},
+	}
+	world := NewWorldWithRooms(rooms)
+	world.roomSources[StartRoom] = "gate.json"
+	world.roomSources["vault"] = "gate.json"
+	world.roomSources["annex"] = "annex.json"
+	world.areaMeta["gate.json"] = areaMetadata{Name: "Ember Gate", Trusted: true}
+	world.areaMeta["annex.json"] = areaMetadata{Name: "Annex"}
+
+	player := &Player{Name: "Seeker", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+
+	world.TriggerRoomLook(player)
+	if !world.RoomFlag(StartRoom, "lit") {
+		t.Fatalf("expected trusted script to raise the room flag")
+	}
+	if _, ok := world.FindRoomItem(StartRoom, "Glowing Key"); !ok {
+		t.Fatalf("expected trusted script to spawn an item")
+	}
+	if exit := rooms[StartRoom].Exits["north"]; exit.Closed || exit.Locked {
+		t.Fatalf("expected north door to open, got %+v", exit)
+	}
+	if exit := rooms["vault"].Exits["south"]; exit.Closed {
+		t.Fatalf("expected the far side of the door to open, got %+v", exit)
+	}
+
+	world.HandlePlayerSpeech(player, "descend")
+	if player.Room != "vault" {
+		t.Fatalf("expected trusted NPC script to move the player, got %s", player.Room)
+	}
+	drainOutput(player.Output)
+
+	if err := world.MoveToRoom(player, "annex"); err != nil {
+		t.Fatalf("MoveToRoom returned error: %v", err)
+	}
+	world.TriggerRoomLook(player)
+	outputs := stripAnsi(strings.Join(drainOutput(player.Output), "\n"))
+	if !strings.Contains(outputs, "Nothing stirs.") {
+		t.Fatalf("expected untrusted script to lack world helpers, got %q", outputs)
+	}
+	if world.RoomFlag("annex", "lit") {
+		t.Fatalf("expected untrusted script to leave flags untouched")
+	}
+}
+
+func TestNPCScriptOnReceive(t *testing.T) {
+	script := `package main
+
+func OnReceive(ctx map[string]any) {
+    if ctx["base_item"].(string) == "Cracked Lens" {
+        ctx["say"].(func(string))("Just what I needed, " + ctx["speaker"].(string) + ".")
+        ctx["grant_item"].(func(string, string))("Polished Lens", "")
+        return
+    }
+    ctx["emote"].(func(string))("pockets the " + ctx["item"].(string) + " with a shrug.")
+}`
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, NPCs: []NPC{{Name: "Tinker", Script: script}, {Name: "Guard"}}},
+	})
+	player := &Player{Name: "Hero", Room: StartRoom, Output: NewOutputQueue(), Alive: true,
+		Inventory: []Item{{Name: "Cracked Lens"}, {Name: "Pebble"}}}
+	world.AddPlayerForTest(player)
+
+	if _, err := world.GiveItemToNPC(player, "pebble", "guard"); !errors.Is(err, ErrNPCNotInterested) {
+		t.Fatalf("an unscripted NPC should refuse, err = %v", err)
+	}
+	result, err := world.GiveItemToNPC(player, "lens", "tinker")
+	if err != nil {
+		t.Fatalf("GiveItemToNPC error: %v", err)
+	}
+	world.TriggerNPCReceive(player, result)
+	outputs := stripAnsi(strings.Join(drainOutput(player.Output), "\n"))
+	if !strings.Contains(outputs, `Tinker says, "Just what I needed, Hero."`) || !strings.Contains(outputs, "You receive Polished Lens.") {
+		t.Fatalf("OnReceive output = %q", outputs)
+	}
+
+	result, err = world.GiveItemToNPC(player, "pebble", "tinker")
+	if err != nil {
+		t.Fatalf("GiveItemToNPC pebble error: %v", err)
+	}
+	world.TriggerNPCReceive(player, result)
+	if outputs := stripAnsi(strings.Join(drainOutput(player.Output), "\n")); !strings.Contains(outputs, "Tinker pockets the Pebble with a shrug.") {
+		t.Fatalf("OnReceive fallback output = %q", outputs)
+	}
+	if len(player.Inventory) != 1 || player.Inventory[0].Name != "Polished Lens" {
+		t.Fatalf("inventory = %+v", player.Inventory)
+	}
+}
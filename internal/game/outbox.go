@@ -0,0 +1,205 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// OutboxLimit caps how many messages may wait to be written to one
+	// client. A client that falls this far behind is disconnected rather
+	// than having its output dropped or reordered.
+	OutboxLimit = 1024
+	// outboxFlushTimeout bounds how long closing a session waits for queued
+	// output, such as a farewell, to be written.
+	outboxFlushTimeout = 2 * time.Second
+)
+
+// outboxStallTimeout is how long a single write may block before the client
+// is treated as stalled and disconnected.
+var outboxStallTimeout = 30 * time.Second
+
+// outbox is a FIFO queue of messages. Each player's output and each
+// session's writes go through one. Pushing never blocks; once OutboxLimit
+// messages are waiting the outbox overflows and closes instead of dropping
+// or reordering anything, and its reader disconnects the client.
+type outbox struct {
+	mu         sync.Mutex
+	queue      []string
+	closed     bool
+	overflowed bool
+	wake       chan struct{}
+	done       chan struct{}
+}
+
+func newOutbox() *outbox {
+	return &outbox{wake: make(chan struct{}, 1), done: make(chan struct{})}
+}
+
+// push queues msg unless the outbox is closed. It reports true when msg
+// overflowed the outbox and closed it.
+func (o *outbox) push(msg string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return false
+	}
+	if len(o.queue) >= OutboxLimit {
+		o.overflowed = true
+		o.closeLocked()
+		metrics.outputOverflowed()
+		return true
+	}
+	o.queue = append(o.queue, msg)
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return false
+}
+
+// drain takes every queued message. It reports false once the outbox is
+// closed and empty.
+func (o *outbox) drain() ([]string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	msgs := o.queue
+	o.queue = nil
+	return msgs, len(msgs) > 0 || !o.closed
+}
+
+// next waits for queued messages and takes them all. It reports false once
+// the outbox is closed and empty.
+func (o *outbox) next() ([]string, bool) {
+	for {
+		msgs, ok := o.drain()
+		if !ok || len(msgs) > 0 {
+			return msgs, ok
+		}
+		<-o.wake
+	}
+}
+
+// overflow reports whether the outbox closed because its reader fell too
+// far behind.
+func (o *outbox) overflow() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.overflowed
+}
+
+func (o *outbox) close() {
+	o.mu.Lock()
+	o.closeLocked()
+	o.mu.Unlock()
+}
+
+func (o *outbox) closeLocked() {
+	if !o.closed {
+		o.closed = true
+		close(o.wake)
+	}
+}
+
+// OutputQueue is a player's ordered output. Every message for the player
+// goes through Send, which never blocks, so it is safe to call while holding
+// the world lock. The player's pump pages the messages in the order they
+// were sent and hands them to the session; a player whose queue overflows
+// is disconnected.
+type OutputQueue struct {
+	out *outbox
+}
+
+// NewOutputQueue returns an empty output queue.
+func NewOutputQueue() *OutputQueue {
+	return &OutputQueue{out: newOutbox()}
+}
+
+// Send queues msg for the player. A nil or closed queue discards it.
+func (q *OutputQueue) Send(msg string) {
+	if q == nil {
+		return
+	}
+	q.out.push(msg)
+}
+
+// Drain takes everything queued without waiting for more.
+func (q *OutputQueue) Drain() []string {
+	if q == nil {
+		return nil
+	}
+	msgs, _ := q.out.drain()
+	return msgs
+}
+
+// Close stops accepting messages. What is already queued is still
+// delivered.
+func (q *OutputQueue) Close() {
+	if q == nil {
+		return
+	}
+	q.out.close()
+}
+
+// Send queues msg for the session's writer goroutine, which coalesces
+// whatever has queued into a single write. Unlike WriteString it never blocks
+// on a slow client; a client that falls OutboxLimit messages behind is
+// disconnected.
+func (s *TelnetSession) Send(msg string) {
+	s.outboxOnce.Do(func() {
+		s.out = newOutbox()
+		go s.writeOutbox(s.out)
+	})
+	if s.out.push(msg) {
+		Logger().Warn("disconnecting client that fell behind", "addr", s.RemoteIP(), "queued", OutboxLimit)
+		if s.conn != nil {
+			_ = s.conn.Close()
+		}
+	}
+}
+
+func (s *TelnetSession) writeOutbox(o *outbox) {
+	defer close(o.done)
+	for {
+		msgs, ok := o.next()
+		if !ok {
+			return
+		}
+		text := strings.Join(msgs, "")
+		if s.conn != nil {
+			_ = s.conn.SetWriteDeadline(time.Now().Add(outboxStallTimeout))
+		}
+		err := s.WriteString(text)
+		if s.conn != nil {
+			_ = s.conn.SetWriteDeadline(time.Time{})
+		}
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				metrics.clientStalled()
+				Logger().Warn("disconnecting stalled client", "addr", s.RemoteIP(), "timeout", outboxStallTimeout)
+			}
+			o.close()
+			if s.conn != nil {
+				_ = s.conn.Close()
+			}
+			return
+		}
+	}
+}
+
+// flushOutbox stops accepting output and waits briefly for what is queued
+// to be written.
+func (s *TelnetSession) flushOutbox() {
+	s.outboxOnce.Do(func() {
+		s.out = newOutbox()
+		close(s.out.done)
+	})
+	s.out.close()
+	select {
+	case <-s.out.done:
+	case <-time.After(outboxFlushTimeout):
+	}
+}
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutboxClosesInsteadOfDroppingWhenFull(t *testing.T) {
+	o := newOutbox()
+	for i := 0; i < OutboxLimit; i++ {
+		if o.push(strconv.Itoa(i)) {
+			t.Fatalf("message %d overflowed an outbox below its limit", i)
+		}
+	}
+	if !o.push("one too many") || !o.overflow() {
+		t.Fatalf("the message past OutboxLimit should overflow the outbox")
+	}
+	o.push("late")
+	msgs, ok := o.drain()
+	if !ok || len(msgs) != OutboxLimit || msgs[0] != "0" || msgs[len(msgs)-1] != strconv.Itoa(OutboxLimit-1) {
+		t.Fatalf("drain = %d messages, %v; want every message before the overflow in order", len(msgs), ok)
+	}
+	if msgs, ok := o.drain(); ok || len(msgs) != 0 {
+		t.Fatalf("an overflowed outbox should end once drained, got %q", msgs)
+	}
+}
+
+func TestPlayerOutputStaysInOrder(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	server, client := net.Pipe()
+	s := &TelnetSession{conn: server, reader: bufio.NewReader(server), termTypes: make(map[string]struct{})}
+	read := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(client)
+		read <- string(data)
+	}()
+	p := &Player{Name: "Busy", Room: "hall", Session: s, Output: NewOutputQueue(), Alive: true, PageLines: PagerOff}
+	world.AddPlayerForTest(p)
+	// Queue far more than a screen before the pump starts, the way a busy
+	// world lock would, and then keep sending while it runs.
+	var want strings.Builder
+	for i := 0; i < 200; i++ {
+		msg := strconv.Itoa(i) + " "
+		want.WriteString(msg)
+		p.Output.Send(msg)
+	}
+	done := make(chan struct{})
+	go func() {
+		world.pumpOutput(p, p.Output)
+		close(done)
+	}()
+	for i := 200; i < 400; i++ {
+		msg := strconv.Itoa(i) + " "
+		want.WriteString(msg)
+		p.Output.Send(msg)
+	}
+	p.Output.Close()
+	<-done
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case got := <-read:
+		if got != want.String() {
+			t.Fatalf("client read %q, want every message in order", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("output was not written")
+	}
+}
+
+func TestOutputOverflowDisconnectsClient(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	server, client := net.Pipe()
+	defer client.Close()
+	s := &TelnetSession{conn: server, reader: bufio.NewReader(server), termTypes: make(map[string]struct{})}
+	p := &Player{Name: "Flooded", Room: "hall", Session: s, Output: NewOutputQueue(), Alive: true, PageLines: PagerOff}
+	world.AddPlayerForTest(p)
+	before := metrics.outputOverflows.Load()
+	for i := 0; i <= OutboxLimit; i++ {
+		p.Output.Send("spam ")
+	}
+	world.pumpOutput(p, p.Output)
+	if metrics.outputOverflows.Load() != before+1 {
+		t.Fatalf("the overflow should be counted")
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.ReadLine()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatalf("ReadLine should fail once the flooded client is dropped")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("flooded client was not disconnected")
+	}
+	<-s.out.done
+}
+
+func TestSendWritesInOrderAndFlushesOnClose(t *testing.T) {
+	server, client := net.Pipe()
+	s := &TelnetSession{conn: server, reader: bufio.NewReader(server), termTypes: make(map[string]struct{})}
+	read := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(client)
+		read <- string(data)
+	}()
+	for _, msg := range []string{"one ", "two ", "three"} {
+		s.Send(msg)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case got := <-read:
+		if got != "one two three" {
+			t.Fatalf("client read %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("queued output was not flushed on close")
+	}
+	s.Send("after close")
+}
+
+func TestSendDisconnectsStalledClient(t *testing.T) {
+	previous := outboxStallTimeout
+	outboxStallTimeout = 50 * time.Millisecond
+	defer func() { outboxStallTimeout = previous }()
+
+	server, client := net.Pipe()
+	defer client.Close()
+	s := &TelnetSession{conn: server, reader: bufio.NewReader(server), termTypes: make(map[string]struct{})}
+	// Nothing reads from client, so the write stalls.
+	s.Send("hello")
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.ReadLine()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatalf("ReadLine should fail once the stalled client is dropped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stalled client was not disconnected")
+	}
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PagerAuto pages output by the height the player's client reports.
+	PagerAuto = 0
+	// PagerOff sends output without pausing.
+	PagerOff = -1
+	// MinPageLines and MaxPageLines bound a fixed page length.
+	MinPageLines = 5
+	MaxPageLines = 200
+	// pagerHeldLimit caps how many messages wait behind a --More-- prompt.
+	pagerHeldLimit = 500
+)
+
+// pagerMore is shown when output pauses for the player to read.
+var pagerMore = AnsiReset + "\r\n" + Ansi(Style("--More-- (c)ontinue, (q)uit", AnsiBold, AnsiYellow))
+
+// pagerErase clears the --More-- prompt before output resumes.
+const pagerErase = "\r\x1b[K"
+
+// outputPager holds output that arrived after a page filled until the player
+// asks for more. It is guarded by the player's link lock.
+type outputPager struct {
+	// lines counts the lines written since the player last typed.
+	lines  int
+	paused bool
+	held   []string
+}
+
+// pageSizeLocked returns how many lines fit on one page, or zero when
+// output should not be paged. The caller holds p.linkMu.
+func (p *Player) pageSizeLocked() int {
+	switch {
+	case p.PageLines == PagerOff:
+		return 0
+	case p.PageLines > 0:
+		return p.PageLines - 1
+	}
+	_, height := p.WindowSize()
+	return height - 1
+}
+
+// pageLocked filters out through the pager, returning the text to write to
+// the session now. Output past the end of a page is held until the player
+// continues. The caller holds p.linkMu.
+func (p *Player) pageLocked(out string) string {
+	if p.pager.paused {
+		p.holdLocked(out)
+		return ""
+	}
+	size := p.pageSizeLocked()
+	if size <= 0 {
+		return out
+	}
+	for i := 0; i < len(out); i++ {
+		if out[i] != '\n' {
+			continue
+		}
+		if p.pager.lines < size {
+			p.pager.lines++
+			continue
+		}
+		rest := out[i+1:]
+		if rest == "" {
+			break
+		}
+		head := out[:i]
+		head = strings.TrimSuffix(head, "\r")
+		p.pager.paused = true
+		p.holdLocked(rest)
+		return head + pagerMore
+	}
+	return out
+}
+
+func (p *Player) holdLocked(out string) {
+	p.pager.held = append(p.pager.held, out)
+	if excess := len(p.pager.held) - pagerHeldLimit; excess > 0 {
+		p.pager.held = append([]string(nil), p.pager.held[excess:]...)
+	}
+}
+
+// resetPagerLocked discards held output and returns what was held.
+func (p *Player) resetPagerLocked() []string {
+	held := p.pager.held
+	p.pager = outputPager{}
+	return held
+}
+
+// PagerInput handles a line the player typed. While output is paused, an
+// empty line or "c" shows the next page and "q" discards the rest; both
+// report true because the line was meant for the pager. Any other line
+// discards the rest and is run as a command.
+func (p *Player) PagerInput(line string) bool {
+	p.linkMu.Lock()
+	if !p.pager.paused {
+		p.pager.lines = 0
+		p.linkMu.Unlock()
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "c":
+		held := p.resetPagerLocked()
+		// The next page starts on the line the prompt held.
+		p.pager.lines = 1
+		var builder strings.Builder
+		builder.WriteString(pagerErase)
+		for _, out := range held {
+			builder.WriteString(p.pageLocked(out))
+		}
+		if p.Session != nil {
+			p.Session.Send(builder.String())
+		}
+		p.linkMu.Unlock()
+		return true
+	case "q":
+		p.resetPagerLocked()
+		p.linkMu.Unlock()
+		p.Output.Send(Prompt(p))
+		return true
+	}
+	p.resetPagerLocked()
+	p.linkMu.Unlock()
+	return false
+}
+
+// PageLength reports the player's page length setting: PagerAuto, PagerOff,
+// or a fixed number of lines.
+func (w *World) PageLength(p *Player) int {
+	p.linkMu.Lock()
+	defer p.linkMu.Unlock()
+	return p.PageLines
+}
+
+// SetPageLength changes how the player's output is paged. lines is
+// PagerAuto, PagerOff, or a length between MinPageLines and MaxPageLines.
+func (w *World) SetPageLength(p *Player, lines int) error {
+	if lines != PagerAuto && lines != PagerOff && (lines < MinPageLines || lines > MaxPageLines) {
+		return fmt.Errorf("page length must be between %d and %d lines", MinPageLines, MaxPageLines)
+	}
+	w.mu.Lock()
+	p.linkMu.Lock()
+	p.PageLines = lines
+	p.linkMu.Unlock()
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return nil
+}
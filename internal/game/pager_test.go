@@ -0,0 +1,80 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPagerHoldsOutputPastAPage(t *testing.T) {
+	p := &Player{Name: "Reader", PageLines: 5, Output: NewOutputQueue()}
+	var lines []string
+	for i := 1; i <= 10; i++ {
+		lines = append(lines, fmt.Sprintf("L%d", i))
+	}
+	long := "\r\n" + strings.Join(lines, "\r\n")
+
+	p.linkMu.Lock()
+	first := p.pageLocked(long)
+	held := p.pageLocked("\r\n> ")
+	p.linkMu.Unlock()
+	if !strings.HasPrefix(first, "\r\nL1\r\nL2\r\nL3\r\nL4") || strings.Contains(first, "L5") || !strings.Contains(first, "--More--") {
+		t.Fatalf("first page = %q", first)
+	}
+	if held != "" {
+		t.Fatalf("output after a full page should be held, got %q", held)
+	}
+
+	if p.PagerInput("look") {
+		t.Fatalf("a command should not be swallowed by the pager")
+	}
+	if p.pager.paused || len(p.pager.held) != 0 {
+		t.Fatalf("running a command should discard held output: %+v", p.pager)
+	}
+
+	p.linkMu.Lock()
+	p.pageLocked(long)
+	p.linkMu.Unlock()
+	if !p.PagerInput("c") {
+		t.Fatalf("c should continue paused output")
+	}
+	if !p.pager.paused || len(p.pager.held) != 1 || !strings.HasPrefix(p.pager.held[0], "L9") {
+		t.Fatalf("continuing should show one more page and hold the rest: %+v", p.pager)
+	}
+	if !p.PagerInput("q") {
+		t.Fatalf("q should stop paging")
+	}
+	if p.pager.paused || len(p.pager.held) != 0 {
+		t.Fatalf("q should discard held output: %+v", p.pager)
+	}
+	if got := drainOutput(p.Output); len(got) != 1 {
+		t.Fatalf("q should send a fresh prompt, got %q", got)
+	}
+
+	p.PageLines = PagerOff
+	p.linkMu.Lock()
+	out := p.pageLocked(long)
+	p.linkMu.Unlock()
+	if out != long {
+		t.Fatalf("paging off should pass output through, got %q", out)
+	}
+}
+
+func TestSetPageLengthValidates(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Start"}})
+	p := &Player{Name: "Reader", Room: StartRoom, Alive: true}
+	world.AddPlayerForTest(p)
+	for _, lines := range []int{PagerAuto, PagerOff, MinPageLines, MaxPageLines} {
+		if err := world.SetPageLength(p, lines); err != nil {
+			t.Fatalf("SetPageLength(%d): %v", lines, err)
+		}
+		if got := world.PageLength(p); got != lines {
+			t.Fatalf("PageLength = %d, want %d", got, lines)
+		}
+	}
+	for _, lines := range []int{-2, MinPageLines - 1, MaxPageLines + 1} {
+		if err := world.SetPageLength(p, lines); err == nil {
+			t.Fatalf("SetPageLength(%d) should fail", lines)
+		}
+	}
+}
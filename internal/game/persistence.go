@@ -0,0 +1,253 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// persistRetryMax caps the backoff between attempts to write a document
+	// that keeps failing.
+	persistRetryMax = 30 * time.Second
+	// persistFlushTimeout bounds how long shutdown and copyover wait for
+	// queued documents to reach storage.
+	persistFlushTimeout = 10 * time.Second
+)
+
+// persistBatchDelay is how long the writer waits after the first queued
+// document so a burst of saves is written as one batch.
+var persistBatchDelay = 100 * time.Millisecond
+
+// persistRetryDelay is the first backoff after a failed batch. It doubles
+// with each consecutive failure up to persistRetryMax.
+var persistRetryDelay = time.Second
+
+// writeBehind wraps a Storage so player profiles and builder rooms can be
+// saved without waiting on disk. Queued documents are coalesced by key,
+// written in batches by a background goroutine, and retried until they
+// succeed. Reads see queued documents before they reach storage.
+type writeBehind struct {
+	Storage
+
+	mu      sync.Mutex
+	pending map[string]*pendingDocument
+	serial  uint64
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	closed  bool
+
+	// writeMu serialises writes to the wrapped storage so a slow queued
+	// write can never land after a newer direct one for the same key.
+	writeMu sync.Mutex
+}
+
+type pendingDocument struct {
+	data   []byte
+	serial uint64
+}
+
+func newWriteBehind(storage Storage) *writeBehind {
+	q := &writeBehind{
+		Storage: storage,
+		pending: make(map[string]*pendingDocument),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// startWriteBehind queues deferred writes in front of the active storage
+// backend. The returned function flushes the queue and restores the
+// backend.
+func startWriteBehind() func() {
+	q := newWriteBehind(documentStorage())
+	restore := useStorage(q)
+	return func() {
+		if err := q.Close(); err != nil {
+			Logger().Error("persistence queue did not drain", "error", err)
+		}
+		restore()
+	}
+}
+
+// writeLater stores data under key. When a write-behind queue is active the
+// write happens in the background and only an error from a queue that has
+// shut down is returned; otherwise it is written immediately.
+func writeLater(key string, data []byte) error {
+	if q, ok := documentStorage().(*writeBehind); ok {
+		return q.Queue(key, data)
+	}
+	return documentStorage().Write(key, data)
+}
+
+// flushPersistence waits for any queued writes to reach storage.
+func flushPersistence() error {
+	if q, ok := documentStorage().(*writeBehind); ok {
+		return q.Flush(persistFlushTimeout)
+	}
+	return nil
+}
+
+// Queue schedules data to be written under key, replacing any write still
+// waiting for the same key.
+func (q *writeBehind) Queue(key string, data []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return fmt.Errorf("persistence queue closed")
+	}
+	q.serial++
+	q.pending[key] = &pendingDocument{data: data, serial: q.serial}
+	metrics.persistQueued(len(q.pending))
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (q *writeBehind) Read(key string) ([]byte, error) {
+	q.mu.Lock()
+	doc, ok := q.pending[key]
+	q.mu.Unlock()
+	if ok {
+		return append([]byte(nil), doc.data...), nil
+	}
+	return q.Storage.Read(key)
+}
+
+// Write stores data immediately, superseding anything queued for key.
+func (q *writeBehind) Write(key string, data []byte) error {
+	q.writeMu.Lock()
+	defer q.writeMu.Unlock()
+	q.discard(key)
+	return q.Storage.Write(key, data)
+}
+
+func (q *writeBehind) Remove(key string) error {
+	q.writeMu.Lock()
+	defer q.writeMu.Unlock()
+	q.discard(key)
+	return q.Storage.Remove(key)
+}
+
+func (q *writeBehind) discard(key string) {
+	q.mu.Lock()
+	if _, ok := q.pending[key]; ok {
+		delete(q.pending, key)
+		metrics.persistQueued(len(q.pending))
+	}
+	q.mu.Unlock()
+}
+
+// Pending reports how many documents are waiting to be written.
+func (q *writeBehind) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Flush writes every queued document on the calling goroutine, retrying
+// failures until timeout passes, in which case it reports how many are
+// still waiting.
+func (q *writeBehind) Flush(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !q.writeBatch() {
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%d documents still waiting to be written", q.Pending())
+		}
+		time.Sleep(min(persistRetryDelay, time.Until(deadline)))
+	}
+	return nil
+}
+
+// Close stops accepting writes, flushes what is queued, and stops the
+// background writer. The wrapped storage is left open.
+func (q *writeBehind) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+	close(q.stop)
+	<-q.done
+	return q.Flush(persistFlushTimeout)
+}
+
+func (q *writeBehind) run() {
+	defer close(q.done)
+	delay := persistRetryDelay
+	for {
+		select {
+		case <-q.wake:
+		case <-q.stop:
+			return
+		}
+		select {
+		case <-time.After(persistBatchDelay):
+		case <-q.stop:
+			return
+		}
+		if q.writeBatch() {
+			delay = persistRetryDelay
+			continue
+		}
+		select {
+		case <-time.After(delay):
+		case <-q.stop:
+			return
+		}
+		delay = min(2*delay, persistRetryMax)
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// writeBatch writes every queued document, keeping failures queued for the
+// next attempt. It reports whether the whole batch succeeded.
+func (q *writeBehind) writeBatch() bool {
+	q.mu.Lock()
+	keys := make([]string, 0, len(q.pending))
+	for key := range q.pending {
+		keys = append(keys, key)
+	}
+	q.mu.Unlock()
+	ok := true
+	for _, key := range keys {
+		if !q.writePending(key) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (q *writeBehind) writePending(key string) bool {
+	q.writeMu.Lock()
+	defer q.writeMu.Unlock()
+	q.mu.Lock()
+	doc, queued := q.pending[key]
+	q.mu.Unlock()
+	if !queued {
+		return true
+	}
+	if err := q.Storage.Write(key, doc.data); err != nil {
+		metrics.persistFailed()
+		Logger().Error("queued write failed; will retry", "key", key, "error", err)
+		return false
+	}
+	q.mu.Lock()
+	if current, ok := q.pending[key]; ok && current.serial == doc.serial {
+		delete(q.pending, key)
+		metrics.persistQueued(len(q.pending))
+	}
+	q.mu.Unlock()
+	return true
+}
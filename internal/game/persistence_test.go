@@ -0,0 +1,180 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingStorage records writes and fails the first few of them.
+type countingStorage struct {
+	mu       sync.Mutex
+	docs     map[string][]byte
+	writes   int
+	failures int
+}
+
+func (s *countingStorage) Read(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.docs[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func (s *countingStorage) Write(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("disk full")
+	}
+	s.writes++
+	s.docs[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *countingStorage) Remove(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.docs, key)
+	return nil
+}
+
+func (s *countingStorage) Close() error { return nil }
+
+func (s *countingStorage) stats() (int, map[string][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writes, s.docs
+}
+
+func slowPersistence(t *testing.T) {
+	t.Helper()
+	prevBatch, prevRetry := persistBatchDelay, persistRetryDelay
+	persistBatchDelay, persistRetryDelay = time.Hour, 5*time.Millisecond
+	t.Cleanup(func() {
+		persistBatchDelay, persistRetryDelay = prevBatch, prevRetry
+	})
+}
+
+func TestWriteBehindCoalescesAndServesQueuedReads(t *testing.T) {
+	slowPersistence(t)
+	inner := &countingStorage{docs: make(map[string][]byte)}
+	q := newWriteBehind(inner)
+	defer q.Close()
+
+	for _, data := range []string{"one", "two", "three"} {
+		if err := q.Queue("hero.json", []byte(data)); err != nil {
+			t.Fatalf("Queue: %v", err)
+		}
+	}
+	if got, err := q.Read("hero.json"); err != nil || string(got) != "three" {
+		t.Fatalf("expected queued read to see the latest document, got %q, %v", got, err)
+	}
+	if writes, _ := inner.stats(); writes != 0 {
+		t.Fatalf("expected no writes before the batch runs, got %d", writes)
+	}
+	if err := q.Flush(time.Second); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	writes, docs := inner.stats()
+	if writes != 1 || string(docs["hero.json"]) != "three" {
+		t.Fatalf("expected one coalesced write of the latest document, got %d writes and %q", writes, docs["hero.json"])
+	}
+	if q.Pending() != 0 {
+		t.Fatalf("expected queue to be empty after flush, got %d", q.Pending())
+	}
+}
+
+func TestWriteBehindRetriesFailedWrites(t *testing.T) {
+	slowPersistence(t)
+	inner := &countingStorage{docs: make(map[string][]byte), failures: 2}
+	q := newWriteBehind(inner)
+	defer q.Close()
+	before := metrics.persistFailures.Load()
+
+	if err := q.Queue("builder.json", []byte("rooms")); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	if err := q.Flush(time.Second); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if _, docs := inner.stats(); string(docs["builder.json"]) != "rooms" {
+		t.Fatalf("expected document written after retries, got %q", docs["builder.json"])
+	}
+	if failed := metrics.persistFailures.Load() - before; failed != 2 {
+		t.Fatalf("expected two failures counted, got %d", failed)
+	}
+}
+
+func TestWriteBehindDirectWriteSupersedesQueued(t *testing.T) {
+	slowPersistence(t)
+	inner := &countingStorage{docs: make(map[string][]byte)}
+	q := newWriteBehind(inner)
+
+	if err := q.Queue("accounts.json", []byte("stale")); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	if err := q.Write("accounts.json", []byte("fresh")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if writes, docs := inner.stats(); writes != 1 || string(docs["accounts.json"]) != "fresh" {
+		t.Fatalf("expected only the direct write to land, got %d writes and %q", writes, docs["accounts.json"])
+	}
+	if err := q.Queue("accounts.json", []byte("late")); err == nil {
+		t.Fatalf("expected queueing after close to fail")
+	}
+}
+
+func TestWriteBehindPersistsProfilesOnClose(t *testing.T) {
+	slowPersistence(t)
+	dir := t.TempDir()
+	accounts, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("hero", "secret"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	stop := startWriteBehind()
+	stopped := false
+	defer func() {
+		if !stopped {
+			stop()
+		}
+	}()
+
+	profile := accounts.Profile("hero")
+	profile.Gold = 42
+	if err := accounts.SaveProfile("hero", profile); err != nil {
+		t.Fatalf("SaveProfile: %v", err)
+	}
+	if _, err := os.Stat(accounts.playerFilePath("hero")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the profile write to be deferred, got %v", err)
+	}
+	if got := accounts.Profile("hero").Gold; got != 42 {
+		t.Fatalf("expected queued profile to be visible, got %d gold", got)
+	}
+
+	stop()
+	stopped = true
+	if _, err := os.Stat(accounts.playerFilePath("hero")); err != nil {
+		t.Fatalf("expected profile flushed on shutdown: %v", err)
+	}
+	reloaded, err := NewAccountManager(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got := reloaded.Profile("hero").Gold; got != 42 {
+		t.Fatalf("expected flushed profile to persist, got %d gold", got)
+	}
+}
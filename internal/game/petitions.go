@@ -0,0 +1,438 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxPetitionLength caps the text of a petition or a staff reply.
+	MaxPetitionLength = 1000
+	// MaxOpenPetitions is how many unresolved petitions a player may have
+	// at once.
+	MaxOpenPetitions = 3
+	// PetitionAgingAfter is how long a petition may wait before it is
+	// flagged as aging.
+	PetitionAgingAfter = 15 * time.Minute
+	// PetitionOverdueAfter is how long a petition may wait before it is
+	// flagged as overdue.
+	PetitionOverdueAfter = time.Hour
+)
+
+// PetitionSLA grades how long an open petition has waited.
+type PetitionSLA string
+
+// Petition age grades, from freshest to stalest.
+const (
+	PetitionNew     PetitionSLA = "new"
+	PetitionAging   PetitionSLA = "aging"
+	PetitionOverdue PetitionSLA = "overdue"
+)
+
+var (
+	// ErrPetitionNotFound indicates the petition does not exist.
+	ErrPetitionNotFound = errors.New("petition not found")
+	// ErrPetitionResolved indicates the petition was already resolved.
+	ErrPetitionResolved = errors.New("petition already resolved")
+)
+
+// PetitionReply is a message staff sent the petitioner.
+type PetitionReply struct {
+	At     time.Time `json:"at"`
+	Author string    `json:"author"`
+	Text   string    `json:"text"`
+}
+
+// Petition is a request for help a player filed for staff.
+type Petition struct {
+	ID         int             `json:"id"`
+	Player     string          `json:"player"`
+	Room       RoomID          `json:"room"`
+	Text       string          `json:"text"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ClaimedBy  string          `json:"claimed_by,omitempty"`
+	ClaimedAt  time.Time       `json:"claimed_at,omitempty"`
+	Replies    []PetitionReply `json:"replies,omitempty"`
+	ResolvedBy string          `json:"resolved_by,omitempty"`
+	ResolvedAt time.Time       `json:"resolved_at,omitempty"`
+	Resolution string          `json:"resolution,omitempty"`
+}
+
+// Open reports whether the petition still awaits staff.
+func (p Petition) Open() bool {
+	return p.ResolvedBy == ""
+}
+
+// Age is how long the petition has waited, or waited before it was
+// resolved.
+func (p Petition) Age(now time.Time) time.Duration {
+	if !p.Open() {
+		return p.ResolvedAt.Sub(p.CreatedAt)
+	}
+	return now.Sub(p.CreatedAt)
+}
+
+// SLA grades the petition's age against PetitionAgingAfter and
+// PetitionOverdueAfter.
+func (p Petition) SLA(now time.Time) PetitionSLA {
+	switch age := p.Age(now); {
+	case age >= PetitionOverdueAfter:
+		return PetitionOverdue
+	case age >= PetitionAgingAfter:
+		return PetitionAging
+	}
+	return PetitionNew
+}
+
+func (p Petition) clone() Petition {
+	p.Replies = append([]PetitionReply(nil), p.Replies...)
+	return p
+}
+
+// PetitionFilter narrows a petition listing. Empty fields match everything.
+type PetitionFilter struct {
+	Player string
+	// Resolved includes resolved petitions alongside open ones.
+	Resolved bool
+	Limit    int
+}
+
+// PetitionSystem persists player petitions for staff to work.
+type PetitionSystem struct {
+	mu        sync.RWMutex
+	path      string
+	nextID    int
+	petitions []Petition
+}
+
+type petitionFile struct {
+	NextID    int        `json:"next_id"`
+	Petitions []Petition `json:"petitions"`
+}
+
+// NewPetitionSystem loads the petitions stored at path. When path is empty
+// the petitions are kept in memory only.
+func NewPetitionSystem(path string) (*PetitionSystem, error) {
+	petitions := &PetitionSystem{path: path, nextID: 1}
+	if strings.TrimSpace(path) == "" {
+		return petitions, nil
+	}
+	data, err := documentStorage().Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return petitions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read petitions file: %w", err)
+	}
+	if len(data) == 0 {
+		return petitions, nil
+	}
+	var record petitionFile
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decode petitions file: %w", err)
+	}
+	petitions.petitions = record.Petitions
+	petitions.nextID = record.NextID
+	for _, petition := range petitions.petitions {
+		if petition.ID >= petitions.nextID {
+			petitions.nextID = petition.ID + 1
+		}
+	}
+	return petitions, nil
+}
+
+func checkPetitionText(text, empty string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.New(empty)
+	}
+	if len(text) > MaxPetitionLength {
+		return "", fmt.Errorf("petitions and replies are limited to %d characters", MaxPetitionLength)
+	}
+	return text, nil
+}
+
+// File records a new petition and returns it with its ID assigned.
+func (s *PetitionSystem) File(player string, room RoomID, text string) (Petition, error) {
+	text, err := checkPetitionText(text, "describe what you need help with")
+	if err != nil {
+		return Petition{}, err
+	}
+	petition := Petition{Player: player, Room: room, Text: text, CreatedAt: time.Now().UTC()}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	open := 0
+	for _, existing := range s.petitions {
+		if existing.Open() && strings.EqualFold(existing.Player, player) {
+			open++
+		}
+	}
+	if open >= MaxOpenPetitions {
+		return Petition{}, fmt.Errorf("you already have %d open petitions; staff will answer them soon", open)
+	}
+	petition.ID = s.nextID
+	s.nextID++
+	s.petitions = append(s.petitions, petition)
+	if err := s.saveLocked(); err != nil {
+		s.petitions = s.petitions[:len(s.petitions)-1]
+		s.nextID--
+		return Petition{}, err
+	}
+	return petition, nil
+}
+
+// Petitions lists the petitions matching filter, oldest first so the queue
+// is worked in the order it was filed.
+func (s *PetitionSystem) Petitions(filter PetitionFilter) []Petition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []Petition{}
+	for _, petition := range s.petitions {
+		if filter.Player != "" && !strings.EqualFold(petition.Player, filter.Player) {
+			continue
+		}
+		if !filter.Resolved && !petition.Open() {
+			continue
+		}
+		out = append(out, petition.clone())
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out
+}
+
+// Petition returns the petition with the given ID.
+func (s *PetitionSystem) Petition(id int) (Petition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, petition := range s.petitions {
+		if petition.ID == id {
+			return petition.clone(), true
+		}
+	}
+	return Petition{}, false
+}
+
+// OpenCount tallies unresolved petitions.
+func (s *PetitionSystem) OpenCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, petition := range s.petitions {
+		if petition.Open() {
+			count++
+		}
+	}
+	return count
+}
+
+// update applies fn to an open petition and saves, restoring the petition
+// if the save fails.
+func (s *PetitionSystem) update(id int, fn func(*Petition) error) (Petition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.petitions {
+		if s.petitions[i].ID != id {
+			continue
+		}
+		if !s.petitions[i].Open() {
+			return Petition{}, ErrPetitionResolved
+		}
+		previous := s.petitions[i].clone()
+		if err := fn(&s.petitions[i]); err != nil {
+			s.petitions[i] = previous
+			return Petition{}, err
+		}
+		if err := s.saveLocked(); err != nil {
+			s.petitions[i] = previous
+			return Petition{}, err
+		}
+		return s.petitions[i].clone(), nil
+	}
+	return Petition{}, ErrPetitionNotFound
+}
+
+// Claim assigns an open petition to staff so others know it is being
+// handled. Claiming a petition someone else holds takes it over.
+func (s *PetitionSystem) Claim(id int, staff string) (Petition, error) {
+	return s.update(id, func(petition *Petition) error {
+		if strings.EqualFold(petition.ClaimedBy, staff) {
+			return fmt.Errorf("you have already claimed petition #%d", id)
+		}
+		petition.ClaimedBy = staff
+		petition.ClaimedAt = time.Now().UTC()
+		return nil
+	})
+}
+
+// Reply records a staff answer to an open petition, claiming it for staff
+// if nobody has.
+func (s *PetitionSystem) Reply(id int, staff, text string) (Petition, error) {
+	text, err := checkPetitionText(text, "what do you want to tell the petitioner?")
+	if err != nil {
+		return Petition{}, err
+	}
+	return s.update(id, func(petition *Petition) error {
+		now := time.Now().UTC()
+		if petition.ClaimedBy == "" {
+			petition.ClaimedBy = staff
+			petition.ClaimedAt = now
+		}
+		petition.Replies = append(petition.Replies, PetitionReply{At: now, Author: staff, Text: text})
+		return nil
+	})
+}
+
+// Resolve closes a petition on behalf of staff with an optional note for
+// the petitioner.
+func (s *PetitionSystem) Resolve(id int, staff, note string) (Petition, error) {
+	return s.update(id, func(petition *Petition) error {
+		petition.ResolvedBy = staff
+		petition.ResolvedAt = time.Now().UTC()
+		petition.Resolution = strings.TrimSpace(note)
+		return nil
+	})
+}
+
+func (s *PetitionSystem) saveLocked() error {
+	if strings.TrimSpace(s.path) == "" {
+		return nil
+	}
+	data, err := encodeDocument(petitionFile{NextID: s.nextID, Petitions: s.petitions})
+	if err != nil {
+		return fmt.Errorf("encode petitions file: %w", err)
+	}
+	if err := documentStorage().Write(s.path, data); err != nil {
+		return fmt.Errorf("write petitions file: %w", err)
+	}
+	return nil
+}
+
+// AttachPetitionSystem connects the petition queue to the world.
+func (w *World) AttachPetitionSystem(petitions *PetitionSystem) {
+	w.mu.Lock()
+	w.petitions = petitions
+	w.mu.Unlock()
+}
+
+// PetitionSystem exposes the petition queue, when configured.
+func (w *World) PetitionSystem() *PetitionSystem {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.petitions
+}
+
+func (w *World) petitionSystem() (*PetitionSystem, error) {
+	if petitions := w.PetitionSystem(); petitions != nil {
+		return petitions, nil
+	}
+	return nil, fmt.Errorf("petitions are unavailable")
+}
+
+// alertModerators sends msg to every online admin and moderator except
+// skip.
+func (w *World) alertModerators(msg string, skip *Player) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, target := range w.players {
+		if target == skip || !target.Alive || !(target.IsAdmin || target.IsModerator) || target.Output == nil {
+			continue
+		}
+		target.Output.Send(msg)
+	}
+}
+
+// FilePetition records a petition from p and alerts online admins and
+// moderators.
+func (w *World) FilePetition(p *Player, text string) (Petition, error) {
+	petitions, err := w.petitionSystem()
+	if err != nil {
+		return Petition{}, err
+	}
+	w.mu.RLock()
+	room := p.Room
+	w.mu.RUnlock()
+	petition, err := petitions.File(p.Name, room, sanitizeInput(text))
+	if err != nil {
+		return Petition{}, err
+	}
+	w.alertModerators(Ansi(fmt.Sprintf("\r\n%s %s filed petition #%d: %s", Style("[PETITION]", AnsiMagenta, AnsiBold), HighlightName(p.Name), petition.ID, petition.Text)), p)
+	return petition, nil
+}
+
+// ClaimPetition assigns a petition to staff and lets the petitioner know
+// someone is looking at it.
+func (w *World) ClaimPetition(id int, staff string) (Petition, error) {
+	petitions, err := w.petitionSystem()
+	if err != nil {
+		return Petition{}, err
+	}
+	petition, err := petitions.Claim(id, staff)
+	if err != nil {
+		return Petition{}, err
+	}
+	w.notifyPetitioner(petition, staff, fmt.Sprintf("I'm looking into your petition #%d.", petition.ID))
+	return petition, nil
+}
+
+// ReplyToPetition sends the petitioner a staff answer.
+func (w *World) ReplyToPetition(id int, staff, text string) (Petition, error) {
+	petitions, err := w.petitionSystem()
+	if err != nil {
+		return Petition{}, err
+	}
+	petition, err := petitions.Reply(id, staff, sanitizeInput(text))
+	if err != nil {
+		return Petition{}, err
+	}
+	reply := petition.Replies[len(petition.Replies)-1]
+	w.notifyPetitioner(petition, staff, fmt.Sprintf("[Petition #%d] %s", petition.ID, reply.Text))
+	return petition, nil
+}
+
+// ResolvePetition closes a petition and lets the petitioner know.
+func (w *World) ResolvePetition(id int, staff, note string) (Petition, error) {
+	petitions, err := w.petitionSystem()
+	if err != nil {
+		return Petition{}, err
+	}
+	petition, err := petitions.Resolve(id, staff, sanitizeInput(note))
+	if err != nil {
+		return Petition{}, err
+	}
+	msg := fmt.Sprintf("Your petition #%d (%q) has been resolved.", petition.ID, petition.Text)
+	if petition.Resolution != "" {
+		msg += " " + petition.Resolution
+	}
+	w.notifyPetitioner(petition, staff, msg)
+	return petition, nil
+}
+
+// notifyPetitioner delivers msg from staff as a tell when the petitioner is
+// online, so they can answer with reply, and as a letter otherwise.
+func (w *World) notifyPetitioner(petition Petition, staff, msg string) {
+	w.mu.Lock()
+	target, online := w.findPlayerLocked(petition.Player)
+	if online && strings.EqualFold(target.Name, petition.Player) {
+		target.rememberTellLocked(TellRecord{Time: time.Now(), From: staff, To: target.Name, Body: msg})
+		target.replyTo = staff
+		output := target.Output
+		w.mu.Unlock()
+		output.Send(Ansi(fmt.Sprintf("\r\n%s tells you: %s", HighlightName(staff), msg)))
+		return
+	}
+	mail := w.mail
+	w.mu.Unlock()
+	if mail == nil {
+		return
+	}
+	if _, err := mail.Deliver(staff, petition.Player, msg, nil, 0); err != nil {
+		Logger().Error("petition letter failed", "petition", petition.ID, "error", err)
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPetitionWorkflowNotifiesPetitioner(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "petitions.json")
+	petitions, err := NewPetitionSystem(path)
+	if err != nil {
+		t.Fatalf("NewPetitionSystem: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	mail, err := NewMailSystem(filepath.Join(dir, "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	world.AttachPetitionSystem(petitions)
+	player := &Player{Name: "Finder", Room: "start", Alive: true, Output: NewOutputQueue()}
+	builder := &Player{Name: "Mason", Room: "start", Alive: true, IsBuilder: true, Output: NewOutputQueue()}
+	mod := &Player{Name: "Warden", Room: "start", Alive: true, IsModerator: true, Output: NewOutputQueue()}
+	for _, p := range []*Player{player, builder, mod} {
+		world.AddPlayerForTest(p)
+	}
+
+	petition, err := world.FilePetition(player, "I'm stuck in a wall.")
+	if err != nil {
+		t.Fatalf("FilePetition: %v", err)
+	}
+	if petition.ID != 1 || petition.Room != "start" || petition.SLA(petition.CreatedAt) != PetitionNew {
+		t.Fatalf("unexpected petition %+v", petition)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(mod.Output), "")); !strings.Contains(out, "Finder filed petition #1") {
+		t.Fatalf("expected moderators to be alerted, got %q", out)
+	}
+	if out := strings.Join(drainOutput(builder.Output), ""); out != "" {
+		t.Fatalf("expected builders not to be alerted, got %q", out)
+	}
+	if petition.SLA(petition.CreatedAt.Add(PetitionAgingAfter)) != PetitionAging || petition.SLA(petition.CreatedAt.Add(PetitionOverdueAfter)) != PetitionOverdue {
+		t.Fatalf("expected the petition to age")
+	}
+
+	if _, err := world.ClaimPetition(1, "Warden"); err != nil {
+		t.Fatalf("ClaimPetition: %v", err)
+	}
+	if _, err := world.ReplyToPetition(1, "Warden", "Try 'recall'."); err != nil {
+		t.Fatalf("ReplyToPetition: %v", err)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(player.Output), "")); !strings.Contains(out, "Warden tells you: I'm looking into your petition #1.") || !strings.Contains(out, "Warden tells you: [Petition #1] Try 'recall'.") {
+		t.Fatalf("expected the claim and reply as tells, got %q", out)
+	}
+	if world.ReplyTarget(player) != "Warden" {
+		t.Fatalf("expected the player to be able to reply to staff")
+	}
+
+	world.removePlayer("Finder")
+	if _, err := world.ResolvePetition(1, "Warden", "Moved you out."); err != nil {
+		t.Fatalf("ResolvePetition: %v", err)
+	}
+	if _, err := world.ResolvePetition(1, "Warden", ""); err != ErrPetitionResolved {
+		t.Fatalf("expected a second resolve to fail, got %v", err)
+	}
+	letters := mail.MessagesForPlayer(PersonalMailBoard, "Finder")
+	if len(letters) != 1 || letters[0].Author != "Warden" || !strings.Contains(letters[0].Body, "Moved you out.") {
+		t.Fatalf("expected the resolution by letter while offline, got %+v", letters)
+	}
+
+	for i := 0; i < MaxOpenPetitions; i++ {
+		if _, err := petitions.File("Finder", "start", "Help."); err != nil {
+			t.Fatalf("File: %v", err)
+		}
+	}
+	if _, err := petitions.File("finder", "start", "More help."); err == nil {
+		t.Fatalf("expected the open petition limit to apply")
+	}
+	reloaded, err := NewPetitionSystem(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	all := reloaded.Petitions(PetitionFilter{Player: "finder", Resolved: true})
+	if len(all) != MaxOpenPetitions+1 || all[0].ClaimedBy != "Warden" || len(all[0].Replies) != 1 || all[0].Resolution != "Moved you out." {
+		t.Fatalf("expected petitions to survive a reload, got %+v", all)
+	}
+	if reloaded.OpenCount() != MaxOpenPetitions {
+		t.Fatalf("expected %d open petitions, got %d", MaxOpenPetitions, reloaded.OpenCount())
+	}
+}
+
+func TestPortalPetitionQueue(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	petitions, _ := NewPetitionSystem("")
+	world.AttachPetitionSystem(petitions)
+	audit, _ := NewAuditLog("")
+	world.AttachAuditLog(audit)
+	if _, err := petitions.File("Finder", "start", "Lost my sword."); err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions: map[string]portalSession{
+			"builder": {Role: PortalRoleBuilder, Player: "Mason", Expires: time.Now().Add(time.Hour)},
+			"mod":     {Role: PortalRoleModerator, Player: "Warden", Expires: time.Now().Add(time.Hour)},
+		},
+		documents: make(map[string]portalDocument),
+	}
+
+	if rec := doInboxRequest(t, portal.handlePetitionsAPI, "builder", http.MethodGet, "/api/petitions", nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("builder listing status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	var views []portalPetitionView
+	rec := doInboxRequest(t, portal.handlePetitionsAPI, "mod", http.MethodGet, "/api/petitions", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &views); err != nil {
+		t.Fatalf("decode petitions: %v", err)
+	}
+	if len(views) != 1 || views[0].Text != "Lost my sword." || views[0].SLA != string(PetitionNew) {
+		t.Fatalf("unexpected queue %+v", views)
+	}
+
+	rec = doInboxRequest(t, portal.handlePetitionActionAPI, "mod", http.MethodPost, "/api/petitions/reply", map[string]any{"id": 1, "text": "Check your vault."})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reply status = %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = doInboxRequest(t, portal.handlePetitionActionAPI, "mod", http.MethodPost, "/api/petitions/resolve", map[string]any{"id": 1})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("resolve status = %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = doInboxRequest(t, portal.handlePetitionActionAPI, "mod", http.MethodPost, "/api/petitions/claim", map[string]any{"id": 1})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("claim after resolve status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	petition, _ := petitions.Petition(1)
+	if petition.ClaimedBy != "Warden" || petition.ResolvedBy != "Warden" || petition.Replies[0].Text != "Check your vault." {
+		t.Fatalf("unexpected petition %+v", petition)
+	}
+	if entries := audit.Entries(AuditFilter{Action: AuditPetitionReply}); len(entries) != 1 || entries[0].Target != "#1" {
+		t.Fatalf("expected the reply to be audited, got %+v", entries)
+	}
+}
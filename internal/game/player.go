@@ -10,10 +10,11 @@ import (
 type Player struct {
 	Name             string
 	Account          string
+	Character        string
 	Session          *TelnetSession
 	Room             RoomID
 	Home             RoomID
-	Output           chan string
+	Output           *OutputQueue
 	Alive            bool
 	IsAdmin          bool
 	IsModerator      bool
@@ -28,19 +29,117 @@ type Player struct {
 	MaxHealth        int
 	Mana             int
 	MaxMana          int
+	Moves            int
+	MaxMoves         int
+	Mount            *NPC
+	Gold             int
 	history          []time.Time
 	channelHistory   map[Channel][]ChannelLogEntry
 	channelHistoryMu sync.Mutex
 	MutedChannels    map[Channel]bool
+	lastSpoke        map[Channel]time.Time
+	tellHistory      []TellRecord
+	replyTo          string
+	retellTo         string
 	QuestLog         map[string]*QuestProgress
+	Ignored          []string
+	Friends          []string
+	Tutorial         TutorialStep
+	tutorialHint     string
+	PromptFormat     string
+	CombatPrompt     string
+	WizInvis         bool
+	SpeedwalkOff     bool
+	PageLines        int
+	Language         string
+	Bio              string
+	BioFlag          string
+	bioDraft         *BioDraft
+	Kills            int
+	Bounty           int
+	Explored         map[RoomID]bool
+	Waypoints        map[RoomID]bool
+	Reputation       map[string]int
+	CommandAliases   map[string]string
+	Achievements     map[string]time.Time
+	Title            string
+	Race             string
+	Class            string
+	bonus            StatModifiers
+	Trained          Attributes
+	TrainPoints      int
+	Lockouts         map[string]time.Time
+	Professions      map[string]int
+	RestedXP         int
+	lastInput        time.Time
+	// recentCommands holds the last few input lines for reports; guarded
+	// by linkMu.
+	recentCommands []string
+	world          *World
+	// listedIn is the room p is filed under in the world's presence index;
+	// guarded by the world lock.
+	listedIn       RoomID
+	linkMu         sync.Mutex
+	linkdead       bool
+	linkdeadSince  time.Time
+	linkdeadTimer  *time.Timer
+	linkdeadOutput []string
+	// console mirrors output to an attached portal console; guarded by linkMu.
+	console       chan string
+	Effects       map[string]StatusEffect
+	revealedExits map[RoomID]map[string]bool
+	lastTaunt     time.Time
+	scriptMoves   int
+	roomDraft     *RoomDraft
+	trade         *trade
+	group         *group
+	challenge     *duelChallenge
+	duel          *Player
+	hunters       map[string]bool
+	waypointReady time.Time
+	lastGamble    time.Time
+	lastDeath     *deathRecord
+	// pager pauses long output at a --More-- prompt; guarded by linkMu,
+	// which PageLines and Language are also written under.
+	pager outputPager
 }
 
 // PlayerProfile captures persistent player state and preferences.
 type PlayerProfile struct {
-	Room     RoomID
-	Home     RoomID
-	Channels map[Channel]bool
-	Aliases  map[Channel]string
+	Room       RoomID
+	Home       RoomID
+	Channels   map[Channel]bool
+	Aliases    map[Channel]string
+	Inventory  []Item
+	Gold       int
+	QuestLog   map[string]*QuestProgress
+	Ignored    []string
+	Friends    []string
+	Tutorial   TutorialStep
+	Prompt     string
+	Combat     string
+	WizInvis   bool
+	SpeedOff   bool
+	PageLines  int
+	Language   string
+	Bio        string
+	BioFlag    string
+	Kills      int
+	Bounty     int
+	Explored   map[RoomID]bool
+	Waypoints  map[RoomID]bool
+	Reputation map[string]int
+	CmdAliases map[string]string
+	Achieved   map[string]time.Time
+	Title      string
+	Race       string
+	Class      string
+	Level      int
+	Experience int
+	Trained    Attributes
+	Points     int
+	Lockouts   map[string]time.Time
+	Trades     map[string]int
 }
 
 const (
@@ -71,6 +170,31 @@ func (p *Player) allowCommand(now time.Time) bool {
 	return true
 }
 
+// markActive records when the player last sent input so who can report idle
+// time. It shares the link lock because the session goroutine writes it while
+// the world reads it.
+func (p *Player) markActive(now time.Time) {
+	p.linkMu.Lock()
+	p.lastInput = now
+	p.linkMu.Unlock()
+}
+
+// idleSince reports when the player last sent input, falling back to when
+// they joined.
+func (p *Player) idleSince() time.Time {
+	p.linkMu.Lock()
+	defer p.linkMu.Unlock()
+	if p.lastInput.IsZero() {
+		return p.JoinedAt
+	}
+	return p.lastInput
+}
+
+// IsStaff reports whether the player holds any staff role.
+func (p *Player) IsStaff() bool {
+	return p.IsAdmin || p.IsModerator || p.IsBuilder
+}
+
 func (p *Player) channelEnabled(channel Channel) bool {
 	if p.Channels == nil {
 		return true
@@ -89,7 +213,8 @@ func (p *Player) channelAlias(channel Channel) string {
 	return p.ChannelAliases[channel]
 }
 
-// EnsureStats normalizes the player's level, health, and mana pools.
+// EnsureStats normalizes the player's level, health, mana, and stamina pools,
+// including the modifiers granted by their race, class, and attributes.
 func (p *Player) EnsureStats() {
 	if p == nil {
 		return
@@ -97,32 +222,43 @@ func (p *Player) EnsureStats() {
 	if p.Level < 1 {
 		p.Level = 1
 	}
+	attrs := p.Attributes()
 	if p.MaxHealth <= 0 {
-		p.MaxHealth = 50 + (p.Level-1)*10
+		p.MaxHealth = max(50+(p.Level-1)*10+p.bonus.Health+attributeBonus(attrs.Con, constitutionHealth), 1)
 	}
 	if p.Health <= 0 || p.Health > p.MaxHealth {
 		p.Health = p.MaxHealth
 	}
-	if p.MaxMana < 0 {
-		p.MaxMana = 25 + (p.Level-1)*5
+	if p.MaxMana <= 0 {
+		p.MaxMana = max(25+(p.Level-1)*5+p.bonus.Mana+attributeBonus(attrs.Int, intelligenceMana), 0)
 	}
 	if p.Mana < 0 || p.Mana > p.MaxMana {
 		p.Mana = p.MaxMana
 	}
+	if p.MaxMoves <= 0 {
+		p.MaxMoves = max(100+(p.Level-1)*10+p.bonus.Moves, 1)
+	}
+	if p.Moves < 0 || p.Moves > p.MaxMoves {
+		p.Moves = p.MaxMoves
+	}
 }
 
 // AttackDamage estimates the base damage dealt by the player in melee combat.
 func (p *Player) AttackDamage() int {
 	p.EnsureStats()
-	base := 5 + p.Level*2
+	base := 5 + p.Level*2 + p.bonus.Damage + carriedBonus(p.Inventory).Damage + attributeBonus(p.Attributes().Str, 1)/2
+	if modifier := p.damageModifier(time.Now()); modifier != 0 {
+		base = base * (100 + modifier) / 100
+	}
 	if base < 1 {
 		return 1
 	}
 	return base
 }
 
-// GainExperience awards experience points and handles level progression.
-// It returns the number of levels gained.
+// GainExperience awards experience points and handles level progression,
+// granting training points for each level. It returns the number of levels
+// gained.
 func (p *Player) GainExperience(amount int) int {
 	if p == nil || amount <= 0 {
 		return 0
@@ -137,10 +273,13 @@ func (p *Player) GainExperience(amount int) int {
 		}
 		p.Level++
 		levelsGained++
+		p.TrainPoints += AttributePointsPerLevel
 		p.MaxHealth += 10
 		p.MaxMana += 5
+		p.MaxMoves += 10
 		p.Health = p.MaxHealth
 		p.Mana = p.MaxMana
+		p.Moves = p.MaxMoves
 	}
 	return levelsGained
 }
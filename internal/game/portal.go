@@ -56,6 +56,9 @@ type PortalConfig struct {
 	KeyFile    string
 	TokenTTL   time.Duration
 	SessionTTL time.Duration
+	// Dispatcher runs commands typed into the admin console. Without one the
+	// console only streams the server log.
+	Dispatcher Dispatcher
 }
 
 var portalFactory = newPortalServer
@@ -111,6 +114,7 @@ type PortalServer struct {
 	baseURL    string
 	tokenTTL   time.Duration
 	sessionTTL time.Duration
+	dispatcher Dispatcher
 
 	mu        sync.Mutex
 	tokens    map[string]portalToken
@@ -150,7 +154,7 @@ func newPortalServer(world *World, cfg PortalConfig) (PortalProvider, error) {
 		return nil, err
 	}
 	if created {
-		fmt.Printf("Generated self-signed TLS certificate for web portal at %s and %s\n", certFile, keyFile)
+		Logger().Info("generated self-signed TLS certificate for web portal", "cert", certFile, "key", keyFile)
 	}
 	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
 	listener, err := tlsListenFunc("tcp", addr, tlsConfig)
@@ -174,6 +178,7 @@ func newPortalServer(world *World, cfg PortalConfig) (PortalProvider, error) {
 		baseURL:    baseURL,
 		tokenTTL:   tokenTTL,
 		sessionTTL: sessionTTL,
+		dispatcher: cfg.Dispatcher,
 		tokens:     make(map[string]portalToken),
 		sessions:   make(map[string]portalSession),
 		documents:  make(map[string]portalDocument),
@@ -187,18 +192,48 @@ func newPortalServer(world *World, cfg PortalConfig) (PortalProvider, error) {
 	mux.HandleFunc("/portal/", portal.handleToken)
 	mux.HandleFunc("/interface", portal.handleInterface)
 	mux.HandleFunc("/api/players", portal.handlePlayersAPI)
+	mux.HandleFunc("/api/who", portal.handleWhoAPI)
 	mux.HandleFunc("/api/overview", portal.handleOverviewAPI)
 	mux.HandleFunc("/api/documents", portal.handleDocumentsAPI)
+	mux.HandleFunc("/api/mail", portal.handleMailAPI)
+	mux.HandleFunc("/api/mail/inbox", portal.handleMailInboxAPI)
+	mux.HandleFunc("/api/mail/unread", portal.handleMailUnreadAPI)
+	mux.HandleFunc("/api/mail/read", portal.handleMailReadAPI)
+	mux.HandleFunc("/api/mail/send", portal.handleMailSendAPI)
+	mux.HandleFunc("/api/mail/tells", portal.handleMailTellsAPI)
+	mux.HandleFunc("/api/chatlog", portal.handleChatLogAPI)
+	mux.HandleFunc("/api/audit", portal.handleAuditAPI)
+	mux.HandleFunc("/api/economy", portal.handleEconomyAPI)
+	mux.HandleFunc("/api/wars", portal.handleWarsAPI)
+	mux.HandleFunc("/api/reports", portal.handleReportsAPI)
+	mux.HandleFunc("/api/reports/resolve", portal.handleReportResolveAPI)
+	mux.HandleFunc("/api/petitions", portal.handlePetitionsAPI)
+	mux.HandleFunc("/api/petitions/", portal.handlePetitionActionAPI)
+	mux.HandleFunc("/console", portal.handleConsolePage)
+	mux.HandleFunc("/api/console", portal.handleConsoleSocket)
+	mux.HandleFunc("/builder", portal.handleBuilderPage)
+	mux.HandleFunc("/builder.js", portal.handleBuilderScript)
+	mux.HandleFunc("/api/rooms", portal.handleRoomsAPI)
+	mux.HandleFunc("/api/rooms/exits", portal.handleRoomExitsAPI)
+	mux.HandleFunc("/api/rooms/resets", portal.handleRoomResetsAPI)
+	mux.HandleFunc("/api/areas/import", portal.handleAreaImportAPI)
+	mux.HandleFunc("/api/areas/export", portal.handleAreaExportAPI)
+	mux.HandleFunc("/api/v1/players", portal.handleAPIv1Players)
+	mux.HandleFunc("/api/v1/who", portal.handleAPIv1Who)
+	mux.HandleFunc("/api/v1/players/kick", portal.handleAPIv1Kick)
+	mux.HandleFunc("/api/v1/players/ban", portal.handleAPIv1Ban)
+	mux.HandleFunc("/api/v1/rooms", portal.handleAPIv1Rooms)
+	mux.HandleFunc("/api/v1/broadcast", portal.handleAPIv1Broadcast)
 	server.Handler = portal.addSecurityHeaders(mux)
 
 	go func() {
 		close(portal.ready)
 		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			fmt.Printf("Web portal error: %v\n", err)
+			Logger().Error("web portal stopped", "error", err)
 		}
 	}()
 
-	fmt.Printf("Web portal listening on %s\n", baseURL)
+	Logger().Info("web portal listening", "url", baseURL)
 	return portal, nil
 }
 
@@ -342,6 +377,28 @@ func (p *PortalServer) handleInterface(w http.ResponseWriter, r *http.Request) {
 	} else {
 		views = []portalPlayerView{}
 	}
+	var mailAudit []portalMailView
+	if roleAllowsMailAudit(session.Role) {
+		mailAudit = p.mailAuditViews(portalMailAuditLimit)
+	}
+	var reports []portalReportView
+	if roleAllowsReports(session.Role) {
+		reports = p.reportViews(ReportFilter{})
+	}
+	var petitions []portalPetitionView
+	if roleAllowsPetitions(session.Role) {
+		petitions = p.petitionViews(PetitionFilter{})
+	}
+	var audit []portalAuditView
+	if roleAllowsAudit(session.Role) {
+		audit = p.auditViews(AuditFilter{Limit: portalAuditLimit})
+	}
+	var economy portalEconomyView
+	if roleAllowsEconomy(session.Role) {
+		economy = p.economyView()
+	}
+	wars := p.warsView()
+	unread := p.portalUnreadCounts(session.Player)
 	documents := p.documentSnapshotsForRole(session.Role)
 	if documents == nil {
 		documents = []portalDocumentView{}
@@ -364,6 +421,22 @@ func (p *PortalServer) handleInterface(w http.ResponseWriter, r *http.Request) {
 		DocumentsJSON:    template.JS(documentsBytes),
 		ShowStaffPanels:  isStaffPortalRole(session.Role),
 		AllowScripts:     roleAllowsScripts(session.Role),
+		AllowBuilding:    roleAllowsBuilding(session.Role),
+		AllowConsole:     roleAllowsConsole(session.Role),
+		ShowMailAudit:    roleAllowsMailAudit(session.Role),
+		MailAudit:        mailAudit,
+		ShowReports:      roleAllowsReports(session.Role),
+		Reports:          reports,
+		ShowPetitions:    roleAllowsPetitions(session.Role),
+		Petitions:        petitions,
+		ShowAudit:        roleAllowsAudit(session.Role),
+		Audit:            audit,
+		ShowEconomy:      roleAllowsEconomy(session.Role),
+		Economy:          economy,
+		ShowWars:         len(wars.Clans) > 0,
+		Wars:             wars,
+		UnreadMail:       unread.Mail,
+		UnreadTells:      unread.Tells,
 		DocumentLimit:    portalDocumentLimit,
 		DocumentMaxSize:  portalDocumentMaxBytes,
 		DocumentMaxLabel: formatDocumentSize(portalDocumentMaxBytes),
@@ -392,6 +465,32 @@ func (p *PortalServer) handlePlayersAPI(w http.ResponseWriter, r *http.Request)
 	_, _ = w.Write(data)
 }
 
+// handleWhoAPI serves the who listing as JSON. The optional filter parameter
+// takes the same arguments as the who command. Only staff sessions see
+// wizinvis staff.
+func (p *PortalServer) handleWhoAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	p.writeWho(w, r, isStaffPortalRole(session.Role))
+}
+
+func (p *PortalServer) writeWho(w http.ResponseWriter, r *http.Request, staffView bool) {
+	filter, err := ParseWhoFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, p.world.WhoList(staffView, filter, time.Now()))
+}
+
 func (p *PortalServer) collectPortalData(now time.Time) ([]portalPlayerView, portalOverview) {
 	snapshots := p.world.PlayerSnapshots()
 	views := make([]portalPlayerView, 0, len(snapshots))
@@ -695,6 +794,7 @@ func (p *PortalServer) saveDocument(session portalSession, id, title, content, r
 			if !allowedDocumentTypes(session.Role)[doc.Type] {
 				return portalDocumentView{}, portalDocumentError{status: http.StatusForbidden, message: "you cannot modify this document"}
 			}
+			p.auditDocument(editor, doc.ID, title, doc.Content, content, docType)
 			doc.Title = title
 			doc.Content = content
 			doc.Type = docType
@@ -724,9 +824,19 @@ func (p *PortalServer) saveDocument(session portalSession, id, title, content, r
 	}
 	p.documents[newID] = doc
 	p.promoteDocumentLocked(newID)
+	p.auditDocument(editor, newID, title, "", content, docType)
 	return doc.view(), nil
 }
 
+// auditDocument records a document or script save in the staff audit trail.
+func (p *PortalServer) auditDocument(editor, id, title, before, after string, docType portalDocumentType) {
+	action := AuditDocumentSave
+	if docType == portalDocumentTypeScript {
+		action = AuditScriptSave
+	}
+	p.world.Audit(AuditEntry{Actor: editor, Action: action, Target: id, Detail: title, Before: before, After: after})
+}
+
 func (p *PortalServer) promoteDocumentLocked(id string) {
 	order := make([]string, 0, len(p.docOrder)+1)
 	order = append(order, id)
@@ -894,6 +1004,22 @@ type portalPageData struct {
 	DocumentsJSON    template.JS
 	ShowStaffPanels  bool
 	AllowScripts     bool
+	AllowBuilding    bool
+	AllowConsole     bool
+	ShowMailAudit    bool
+	MailAudit        []portalMailView
+	ShowReports      bool
+	Reports          []portalReportView
+	ShowPetitions    bool
+	Petitions        []portalPetitionView
+	ShowAudit        bool
+	Audit            []portalAuditView
+	ShowEconomy      bool
+	Economy          portalEconomyView
+	ShowWars         bool
+	Wars             portalWarsView
+	UnreadMail       int
+	UnreadTells      int
 	DocumentLimit    int
 	DocumentMaxSize  int
 	DocumentMaxLabel string
@@ -1001,6 +1127,12 @@ section h2 { margin-top: 0; font-size: 1.4rem; color: #38bdf8; }
 .stat-subtext { font-size: 0.85rem; color: #94a3b8; margin-top: 0.4rem; }
 .empty-state { padding: 1.2rem 0; color: #94a3b8; font-style: italic; }
 .table-note { margin: 0.75rem 0 0; font-size: 0.85rem; color: #94a3b8; }
+.sla-new { color: #34d399; }
+.sla-aging { color: #fbbf24; }
+.sla-overdue { color: #f87171; font-weight: 600; }
+.economy-bar { height: 0.55rem; border-radius: 999px; margin: 0.15rem 0; min-width: 2px; }
+.economy-bar.created { background: #34d399; }
+.economy-bar.destroyed { background: #f87171; }
 table { width: 100%; border-collapse: collapse; margin-top: 1rem; }
 thead { background: rgba(15, 23, 42, 0.85); }
 th, td { padding: 0.75rem; text-align: left; border-bottom: 1px solid rgba(148, 163, 184, 0.2); }
@@ -1039,6 +1171,11 @@ tr:last-child td { border-bottom: none; }
 .doc-actions button.secondary { background: rgba(148, 163, 184, 0.2); color: #e2e8f0; }
 .doc-actions button.secondary:hover { background: rgba(148, 163, 184, 0.3); }
 .doc-status { font-size: 0.85rem; color: #94a3b8; min-height: 1.2rem; }
+.inbox-entry { border-bottom: 1px solid rgba(148, 163, 184, 0.2); padding: 0.75rem 0; }
+.inbox-entry:last-child { border-bottom: none; }
+.inbox-entry.unread strong::after { content: " · new"; color: #38bdf8; font-weight: 400; font-size: 0.8rem; }
+.inbox-entry p { margin: 0.35rem 0; white-space: pre-wrap; }
+.inbox-entry button { border: none; border-radius: 999px; padding: 0.3rem 0.8rem; margin-right: 0.4rem; font-size: 0.8rem; cursor: pointer; background: rgba(148, 163, 184, 0.2); color: #e2e8f0; }
 footer { text-align: center; font-size: 0.8rem; color: #94a3b8; padding: 2rem 0 3rem; }
 @media (max-width: 720px) {
  header, main { padding-left: 6vw; padding-right: 6vw; }
@@ -1058,6 +1195,9 @@ footer { text-align: center; font-size: 0.8rem; color: #94a3b8; padding: 2rem 0
 <h1>{{.RoleTitle}}</h1>
 <p>Welcome, {{.Player}}. {{.RoleDescription}}</p>
 <p><small>Session active until {{.SessionExpiry}} · Refreshed {{.Generated}}</small></p>
+{{if .Player}}<p id="unread-line">&#9993; <span id="unread-mail">{{.UnreadMail}}</span> unread letters · <span id="unread-tells">{{.UnreadTells}}</span> offline tells</p>{{end}}
+{{if .AllowBuilding}}<p><a href="/builder" style="color: #0f172a; font-weight: 600;">Open the area editor</a></p>{{end}}
+{{if .AllowConsole}}<p><a href="/console" style="color: #0f172a; font-weight: 600;">Open the live console</a></p>{{end}}
 </header>
 <main>
 {{if .ShowStaffPanels}}
@@ -1094,6 +1234,195 @@ footer { text-align: center; font-size: 0.8rem; color: #94a3b8; padding: 2rem 0
 <p class="table-note">Data updates every 10 seconds while this page stays open.</p>
 </section>
 {{end}}
+{{if .Player}}
+<section>
+<h2>Inbox</h2>
+<p>Letters and offline tells waiting for {{.Player}}. Reading tells here removes them from the in-game queue and lets their senders know.</p>
+<h3>Letters</h3>
+<div id="inbox-letters"><p class="empty-state">Loading letters…</p></div>
+<h3>Offline tells</h3>
+<div id="inbox-tells"><p class="empty-state">Loading tells…</p></div>
+<div class="doc-actions" style="margin-bottom: 1rem;"><div class="doc-buttons"><button type="button" class="secondary" id="inbox-tells-read">Mark tells read</button></div></div>
+<h3>Write a letter</h3>
+<div class="doc-editor">
+<label class="doc-label" for="inbox-to">To</label>
+<input id="inbox-to" type="text" placeholder="Recipient" autocomplete="off" />
+<label class="doc-label" for="inbox-body">Letter</label>
+<textarea id="inbox-body" spellcheck="true" style="min-height: 120px;"></textarea>
+<div class="doc-actions">
+<div class="doc-buttons"><button type="button" class="primary" id="inbox-send">Send letter</button></div>
+<span class="doc-status" id="inbox-status"></span>
+</div>
+</div>
+</section>
+{{end}}
+{{if .ShowReports}}
+<section>
+<h2>Player Reports</h2>
+<p>Open bugs, typos, and ideas filed in game, oldest first. Resolving one mails the reporter your note. The full queue, including resolved reports, is at <code>/api/reports?status=all&amp;kind=</code>.</p>
+{{if .Reports}}
+<table id="report-queue">
+<thead><tr><th>#</th><th>Kind</th><th>From</th><th>Room</th><th>Report</th><th>Filed</th><th></th></tr></thead>
+<tbody>
+{{range .Reports}}
+<tr>
+<td>{{.ID}}</td>
+<td>{{.Kind}}</td>
+<td>{{.Reporter}}</td>
+<td>{{.Room}}</td>
+<td>{{.Text}}{{if .Context}}<details><summary>Recent commands</summary><pre class="code-preview">{{range .Context}}&gt; {{.}}
+{{end}}</pre></details>{{end}}</td>
+<td>{{.CreatedAt}}</td>
+<td><button type="button" class="secondary" data-resolve="{{.ID}}">Resolve</button></td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{else}}
+<p class="table-note">No open reports.</p>
+{{end}}
+</section>
+{{end}}
+{{if .ShowPetitions}}
+<section>
+<h2>Petitions</h2>
+<p>Open requests for help filed with <code>petition</code>, oldest first. Ages turn amber after 15 minutes and red after an hour. Replies reach the player as a tell, or by letter when they are away. The full queue is at <code>/api/petitions?status=all&amp;player=</code>.</p>
+{{if .Petitions}}
+<table id="petition-queue">
+<thead><tr><th>#</th><th>From</th><th>Room</th><th>Petition</th><th>Waiting</th><th>Claimed by</th><th></th></tr></thead>
+<tbody>
+{{range .Petitions}}
+<tr>
+<td>{{.ID}}</td>
+<td>{{.Player}}</td>
+<td>{{.Room}}</td>
+<td>{{.Text}}{{if .Replies}}<details><summary>Replies</summary><pre class="code-preview">{{range .Replies}}{{.Author}}: {{.Text}}
+{{end}}</pre></details>{{end}}</td>
+<td class="sla-{{.SLA}}">{{.Age}}</td>
+<td data-claimed="{{.ID}}">{{.ClaimedBy}}</td>
+<td><button type="button" class="secondary" data-petition="claim" data-id="{{.ID}}">Claim</button> <button type="button" class="secondary" data-petition="reply" data-id="{{.ID}}">Reply</button> <button type="button" class="secondary" data-petition="resolve" data-id="{{.ID}}">Resolve</button></td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{else}}
+<p class="table-note">No open petitions.</p>
+{{end}}
+</section>
+{{end}}
+{{if .ShowAudit}}
+<section>
+<h2>Audit Trail</h2>
+<p>Recent staff commands, builder edits, console commands, and document and script saves. Filter the full trail at <code>/api/audit?actor=&amp;action=&amp;q=</code>.</p>
+{{if .Audit}}
+<table>
+<thead><tr><th>#</th><th>When</th><th>Who</th><th>Action</th><th>Target</th><th>Detail</th></tr></thead>
+<tbody>
+{{range .Audit}}
+<tr>
+<td>{{.ID}}</td>
+<td>{{.Time}}</td>
+<td>{{.Actor}}</td>
+<td>{{.Action}}</td>
+<td>{{if .Target}}{{.Target}}{{else}}&mdash;{{end}}</td>
+<td>{{.Detail}}{{if .Diff}}<details><summary>Changes</summary><pre class="code-preview">{{range .Diff}}{{.}}
+{{end}}</pre></details>{{end}}</td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{else}}
+<p class="table-note">No staff actions have been recorded yet.</p>
+{{end}}
+</section>
+{{end}}
+{{if .ShowEconomy}}
+<section>
+<h2>Economy</h2>
+<p>Gold created (green) and destroyed (red) by loot, shops, healers, waypoints, rent, clans, gambling, and bounties since the server started. Faucet rate {{.Economy.FaucetRate}}x, sink rate {{.Economy.SinkRate}}x. The full series is at <code>/api/economy</code>.</p>
+{{if .Economy.Sources}}
+<table>
+<thead><tr><th>Source</th><th>Created</th><th>Destroyed</th><th>Net</th><th>Events</th></tr></thead>
+<tbody>
+{{range .Economy.Sources}}
+<tr><td>{{.Source}}</td><td>{{.Created}}</td><td>{{.Destroyed}}</td><td>{{.Net}}</td><td>{{.Events}}</td></tr>
+{{end}}
+<tr><td><strong>Total</strong></td><td>{{.Economy.Total.Created}}</td><td>{{.Economy.Total.Destroyed}}</td><td>{{.Economy.Total.Net}}</td><td>{{.Economy.Total.Events}}</td></tr>
+</tbody>
+</table>
+<table>
+<thead><tr><th>Hour (UTC)</th><th>Gold flow</th><th>Net</th></tr></thead>
+<tbody>
+{{range .Economy.Hours}}
+<tr>
+<td>{{.Hour}}</td>
+<td><div class="economy-bar created" style="width: {{.CreatedBar}}%" title="{{.Created}} created"></div><div class="economy-bar destroyed" style="width: {{.DestroyedBar}}%" title="{{.Destroyed}} destroyed"></div></td>
+<td>{{.Net}}</td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{else}}
+<p class="table-note">No gold has changed hands with the world yet.</p>
+{{end}}
+</section>
+{{end}}
+{{if .ShowWars}}
+<section>
+<h2>Clan Wars</h2>
+<p>Clans earn a point for every five minutes they hold a capture point. Wars score kills and captures and end in a truce after a day. The same scoreboard is at <code>/api/wars</code>.</p>
+<table>
+<thead><tr><th>Clan</th><th>Members</th><th>Points</th><th>Capture points</th></tr></thead>
+<tbody>
+{{range .Wars.Clans}}
+<tr><td>{{.Clan}}</td><td>{{.Members}}</td><td>{{.Points}}</td><td>{{.Holds}}</td></tr>
+{{end}}
+</tbody>
+</table>
+{{if .Wars.Wars}}
+<table>
+<thead><tr><th>War</th><th>Score</th><th>Declared (UTC)</th><th>Status</th></tr></thead>
+<tbody>
+{{range .Wars.Wars}}
+<tr>
+<td>{{.Attacker}} vs {{.Defender}}</td>
+<td>{{.AttackerScore}} - {{.DefenderScore}}</td>
+<td>{{.Declared}}</td>
+<td>{{if .Active}}Truce at {{.Ends}}{{else}}{{if .Winner}}Won by {{.Winner}}{{else}}Draw{{end}}, truce until {{.TruceUntil}}{{end}}</td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{else}}
+<p class="table-note">No clan has declared war yet.</p>
+{{end}}
+</section>
+{{end}}
+{{if .ShowMailAudit}}
+<section>
+<h2>Mail Audit</h2>
+<p>Recent board posts and letters, including item attachments and claims. The full record is available at <code>/api/mail</code>.</p>
+{{if .MailAudit}}
+<table>
+<thead><tr><th>#</th><th>Board</th><th>From</th><th>To</th><th>Attachments</th><th>Sent</th></tr></thead>
+<tbody>
+{{range .MailAudit}}
+<tr>
+<td>{{.ID}}{{if .ForwardedFrom}} (fwd #{{.ForwardedFrom}}){{end}}</td>
+<td>{{.Board}}</td>
+<td>{{.Author}}</td>
+<td>{{if .Recipients}}{{range $i, $r := .Recipients}}{{if $i}}, {{end}}{{$r}}{{end}}{{else}}everyone{{end}}</td>
+<td>{{if .Attachments}}{{range $i, $a := .Attachments}}{{if $i}}, {{end}}{{$a}}{{end}}{{else if .ClaimedBy}}claimed by {{.ClaimedBy}}{{else}}&mdash;{{end}}</td>
+<td>{{.CreatedAt}}</td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{else}}
+<p class="table-note">No mail has been sent yet.</p>
+{{end}}
+</section>
+{{end}}
 <section>
 <h2>Collaborative Notes</h2>
 <p>Draft descriptions, quest scripts, and planning notes together.</p>
@@ -1609,7 +1938,198 @@ if (docSaveButton) {
     }
   });
 }
+const inboxLetters = document.getElementById('inbox-letters');
+const inboxTells = document.getElementById('inbox-tells');
+const inboxTo = document.getElementById('inbox-to');
+const inboxBody = document.getElementById('inbox-body');
+const inboxStatus = document.getElementById('inbox-status');
+const inboxSend = document.getElementById('inbox-send');
+const inboxTellsRead = document.getElementById('inbox-tells-read');
+let inboxReplyTo = 0;
+const postInbox = async (path, payload) => {
+  const response = await fetch(path, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    credentials: 'same-origin',
+    body: JSON.stringify(payload || {}),
+  });
+  if (!response.ok) {
+    const text = (await response.text()).trim();
+    throw new Error(text || 'Request failed');
+  }
+  return response.json();
+};
+const renderUnread = (counts) => {
+  const mail = document.getElementById('unread-mail');
+  const tells = document.getElementById('unread-tells');
+  if (mail && counts) {
+    mail.textContent = counts.mail;
+  }
+  if (tells && counts) {
+    tells.textContent = counts.tells;
+  }
+};
+const renderLetters = (letters) => {
+  if (!inboxLetters) {
+    return;
+  }
+  if (!Array.isArray(letters) || letters.length === 0) {
+    inboxLetters.innerHTML = '<p class="empty-state">No letters.</p>';
+    return;
+  }
+  inboxLetters.innerHTML = letters.map((letter) => {
+    const attachments = Array.isArray(letter.attachments) && letter.attachments.length > 0
+      ? '<p><small>Attached: ' + escapeHTML(letter.attachments.join(', ')) + ' — claim in game with <code>mail claim ' + letter.id + '</code>.</small></p>'
+      : '';
+    const markRead = letter.read ? '' : '<button type="button" data-read="' + letter.id + '">Mark read</button>';
+    return '<div class="inbox-entry' + (letter.read ? '' : ' unread') + '">'
+      + '<strong>#' + letter.id + ' from ' + escapeHTML(letter.from) + '</strong> <small>' + escapeHTML(new Date(letter.sent_at).toLocaleString()) + '</small>'
+      + '<p>' + escapeHTML(letter.body) + '</p>' + attachments
+      + markRead + '<button type="button" data-reply="' + letter.id + '" data-author="' + escapeHTML(letter.from) + '">Reply</button>'
+      + '</div>';
+  }).join('');
+};
+const renderTells = (tells) => {
+  if (!inboxTells) {
+    return;
+  }
+  if (!Array.isArray(tells) || tells.length === 0) {
+    inboxTells.innerHTML = '<p class="empty-state">No offline tells.</p>';
+    return;
+  }
+  inboxTells.innerHTML = tells.map((tell) => '<div class="inbox-entry unread"><strong>' + escapeHTML(tell.from) + '</strong> <small>' + escapeHTML(new Date(tell.sent_at).toLocaleString()) + '</small><p>' + escapeHTML(tell.body) + '</p></div>').join('');
+};
+const refreshInbox = async () => {
+  if (!inboxLetters) {
+    return;
+  }
+  try {
+    const [letters, tells, unread] = await Promise.all([
+      fetch('/api/mail/inbox', { credentials: 'same-origin' }).then((r) => r.json()),
+      fetch('/api/mail/tells', { credentials: 'same-origin' }).then((r) => r.json()),
+      fetch('/api/mail/unread', { credentials: 'same-origin' }).then((r) => r.json()),
+    ]);
+    renderLetters(letters);
+    renderTells(tells);
+    renderUnread(unread);
+  } catch (err) {
+    console.warn('Inbox refresh failed', err);
+  }
+};
+if (inboxLetters) {
+  inboxLetters.addEventListener('click', async (event) => {
+    const target = event.target;
+    if (!(target instanceof HTMLElement)) {
+      return;
+    }
+    if (target.dataset.read) {
+      try {
+        renderUnread(await postInbox('/api/mail/read', { id: Number(target.dataset.read) }));
+        refreshInbox();
+      } catch (err) {
+        console.warn('Mark read failed', err);
+      }
+    } else if (target.dataset.reply) {
+      inboxReplyTo = Number(target.dataset.reply);
+      inboxTo.value = target.dataset.author || '';
+      inboxStatus.textContent = 'Replying to letter #' + inboxReplyTo + '.';
+      inboxBody.focus();
+    }
+  });
+}
+if (inboxTellsRead) {
+  inboxTellsRead.addEventListener('click', async () => {
+    try {
+      await postInbox('/api/mail/tells');
+      refreshInbox();
+    } catch (err) {
+      console.warn('Mark tells read failed', err);
+    }
+  });
+}
+if (inboxTo) {
+  inboxTo.addEventListener('input', () => {
+    inboxReplyTo = 0;
+  });
+}
+if (inboxSend) {
+  inboxSend.addEventListener('click', async () => {
+    inboxStatus.textContent = 'Sending…';
+    try {
+      const sent = await postInbox('/api/mail/send', { to: inboxTo.value, body: inboxBody.value, reply_to: inboxReplyTo });
+      inboxStatus.textContent = 'Letter #' + sent.id + ' sent.';
+      inboxBody.value = '';
+      inboxReplyTo = 0;
+    } catch (err) {
+      inboxStatus.textContent = err && err.message ? err.message : 'Send failed — retry?';
+    }
+  });
+}
+refreshInbox();
+const reportQueue = document.getElementById('report-queue');
+if (reportQueue) {
+  reportQueue.addEventListener('click', async (event) => {
+    const target = event.target;
+    if (!(target instanceof HTMLElement) || !target.dataset.resolve) {
+      return;
+    }
+    const note = window.prompt('Note for the reporter (optional):', '');
+    if (note === null) {
+      return;
+    }
+    target.disabled = true;
+    try {
+      await postInbox('/api/reports/resolve', { id: Number(target.dataset.resolve), note: note });
+      const row = target.closest('tr');
+      if (row) {
+        row.remove();
+      }
+    } catch (err) {
+      target.disabled = false;
+      console.warn('Resolve report failed', err);
+    }
+  });
+}
+const petitionQueue = document.getElementById('petition-queue');
+if (petitionQueue) {
+  petitionQueue.addEventListener('click', async (event) => {
+    const target = event.target;
+    if (!(target instanceof HTMLElement) || !target.dataset.petition) {
+      return;
+    }
+    const action = target.dataset.petition;
+    let text = '';
+    if (action === 'reply') {
+      text = window.prompt('Reply to the player:', '');
+      if (!text) {
+        return;
+      }
+    } else if (action === 'resolve') {
+      text = window.prompt('Note for the player (optional):', '');
+      if (text === null) {
+        return;
+      }
+    }
+    target.disabled = true;
+    try {
+      const petition = await postInbox('/api/petitions/' + action, { id: Number(target.dataset.id), text: text });
+      const row = target.closest('tr');
+      if (action === 'resolve' && row) {
+        row.remove();
+        return;
+      }
+      const claimed = row ? row.querySelector('[data-claimed]') : null;
+      if (claimed) {
+        claimed.textContent = petition.claimed_by || '';
+      }
+    } catch (err) {
+      console.warn('Petition ' + action + ' failed', err);
+    }
+    target.disabled = false;
+  });
+}
 const refresh = async () => {
+  refreshInbox();
   try {
     const [playersResult, overviewResult] = await Promise.allSettled([
       fetch('/api/players', { credentials: 'same-origin' }),
@@ -0,0 +1,192 @@
+package game
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxAPIBroadcastLength bounds announcements sent through the REST API.
+const maxAPIBroadcastLength = 500
+
+type apiPlayerActionView struct {
+	Name         string `json:"name"`
+	Disconnected bool   `json:"disconnected"`
+	Banned       bool   `json:"banned,omitempty"`
+}
+
+type apiBroadcastView struct {
+	Delivered int `json:"delivered"`
+}
+
+// apiToken authenticates a REST API request using the bearer token in the
+// Authorization header. It writes an error response when access is denied.
+func (p *PortalServer) apiToken(w http.ResponseWriter, r *http.Request) (APIToken, bool) {
+	store := p.world.APITokens()
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	secret, ok := strings.CutPrefix(header, "Bearer ")
+	if store == nil || !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="LumenClay"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return APIToken{}, false
+	}
+	token, ok := store.Authenticate(secret)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="LumenClay"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return APIToken{}, false
+	}
+	return token, true
+}
+
+func apiActor(token APIToken) string {
+	return "api:" + token.Label
+}
+
+func (p *PortalServer) handleAPIv1Players(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := p.apiToken(w, r); !ok {
+		return
+	}
+	views, _ := p.collectPortalData(time.Now())
+	writePortalJSON(w, http.StatusOK, views)
+}
+
+func (p *PortalServer) handleAPIv1Who(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := p.apiToken(w, r); !ok {
+		return
+	}
+	p.writeWho(w, r, true)
+}
+
+func (p *PortalServer) handleAPIv1Kick(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := p.apiToken(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	var payload struct {
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	}
+	if !decodePortalPayload(w, r, &payload) {
+		return
+	}
+	target, err := p.world.KickPlayer(payload.Name, payload.Reason)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	p.world.Audit(AuditEntry{Actor: apiActor(token), Action: AuditAPIKick, Target: target.Name, Detail: payload.Reason})
+	writePortalJSON(w, http.StatusOK, apiPlayerActionView{Name: target.Name, Disconnected: true})
+}
+
+func (p *PortalServer) handleAPIv1Ban(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := p.apiToken(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	var payload struct {
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	}
+	if !decodePortalPayload(w, r, &payload) {
+		return
+	}
+	bans := p.world.BanList()
+	if bans == nil {
+		http.Error(w, "bans are not configured", http.StatusServiceUnavailable)
+		return
+	}
+	name := strings.TrimSpace(payload.Name)
+	if online, found := p.world.FindPlayer(name); found {
+		name = online.Name
+	}
+	entry, err := bans.BanAccount(name, payload.Reason, apiActor(token))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p.world.Audit(AuditEntry{Actor: apiActor(token), Action: AuditAPIBan, Target: entry.Target, Detail: entry.Reason})
+	view := apiPlayerActionView{Name: entry.Target, Banned: true}
+	reason := "banned"
+	if entry.Reason != "" {
+		reason = fmt.Sprintf("banned (%s)", entry.Reason)
+	}
+	if _, err := p.world.KickPlayer(entry.Target, reason); err == nil {
+		view.Disconnected = true
+	}
+	writePortalJSON(w, http.StatusOK, view)
+}
+
+func (p *PortalServer) handleAPIv1Rooms(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := p.apiToken(w, r); !ok {
+		return
+	}
+	roomID := strings.TrimSpace(r.URL.Query().Get("id"))
+	if roomID == "" {
+		writePortalJSON(w, http.StatusOK, p.roomSummaryViews())
+		return
+	}
+	view, found := p.roomView(RoomID(roomID))
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, view)
+}
+
+func (p *PortalServer) handleAPIv1Broadcast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := p.apiToken(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if !decodePortalPayload(w, r, &payload) {
+		return
+	}
+	message := strings.TrimSpace(sanitizeInput(payload.Message))
+	if message == "" {
+		http.Error(w, "message must not be empty", http.StatusBadRequest)
+		return
+	}
+	if len(message) > maxAPIBroadcastLength {
+		http.Error(w, "message is too long", http.StatusBadRequest)
+		return
+	}
+	line := fmt.Sprintf("\r\n%s %s", Style("[Announcement]", AnsiBold, AnsiMagenta), message)
+	if token.Label != "" {
+		line += Style(" ("+token.Label+")", AnsiDim)
+	}
+	delivered := p.world.BroadcastSystem(Ansi(line))
+	p.world.Audit(AuditEntry{Actor: apiActor(token), Action: AuditAPIBroadcast, Detail: message})
+	writePortalJSON(w, http.StatusOK, apiBroadcastView{Delivered: delivered})
+}
@@ -0,0 +1,211 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestAPIPortal(t *testing.T) (*PortalServer, *World, string) {
+	t.Helper()
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Atrium", Description: "A bright hall.", Exits: map[string]Exit{}},
+	})
+	tokens, err := NewAPITokenStore(filepath.Join(t.TempDir(), "api_tokens.json"))
+	if err != nil {
+		t.Fatalf("NewAPITokenStore error: %v", err)
+	}
+	bans, err := NewBanList("")
+	if err != nil {
+		t.Fatalf("NewBanList error: %v", err)
+	}
+	world.AttachAPITokens(tokens)
+	world.AttachBanList(bans)
+	_, secret, err := tokens.Issue("discord", "Admin")
+	if err != nil {
+		t.Fatalf("Issue error: %v", err)
+	}
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions:   make(map[string]portalSession),
+		documents:  make(map[string]portalDocument),
+	}
+	return portal, world, secret
+}
+
+func doAPIRequest(t *testing.T, handler http.HandlerFunc, secret, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func TestAPIv1RequiresToken(t *testing.T) {
+	portal, _, secret := newTestAPIPortal(t)
+
+	if rec := doAPIRequest(t, portal.handleAPIv1Players, "", http.MethodGet, "/api/v1/players", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("missing token status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := doAPIRequest(t, portal.handleAPIv1Players, secret+"x", http.MethodGet, "/api/v1/players", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("bad token status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := doAPIRequest(t, portal.handleAPIv1Players, secret, http.MethodGet, "/api/v1/players", nil); rec.Code != http.StatusOK {
+		t.Fatalf("valid token status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	store := portal.world.APITokens()
+	tokens := store.List()
+	if len(tokens) != 1 || tokens[0].LastUsed.IsZero() {
+		t.Fatalf("expected token usage to be recorded, got %#v", tokens)
+	}
+	if err := store.Revoke(tokens[0].ID); err != nil {
+		t.Fatalf("Revoke error: %v", err)
+	}
+	if rec := doAPIRequest(t, portal.handleAPIv1Players, secret, http.MethodGet, "/api/v1/players", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("revoked token status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAPIv1BanAndBroadcast(t *testing.T) {
+	portal, world, secret := newTestAPIPortal(t)
+	troll := &Player{Name: "Troll", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(troll)
+	watcher := &Player{Name: "Watcher", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(watcher)
+
+	rec := doAPIRequest(t, portal.handleAPIv1Ban, secret, http.MethodPost, "/api/v1/players/ban", map[string]string{"name": "troll", "reason": "spam"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ban status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	var view apiPlayerActionView
+	if err := json.Unmarshal(rec.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode ban response: %v", err)
+	}
+	if view.Name != "Troll" || !view.Banned || !view.Disconnected {
+		t.Fatalf("unexpected ban response: %#v", view)
+	}
+	entry, banned := world.BanList().AccountBan("Troll")
+	if !banned || entry.Reason != "spam" || entry.IssuedBy != "api:discord" {
+		t.Fatalf("unexpected ban entry: %#v (banned=%v)", entry, banned)
+	}
+	if _, ok := world.ActivePlayer("Troll"); ok {
+		t.Fatalf("expected banned player to be disconnected")
+	}
+
+	rec = doAPIRequest(t, portal.handleAPIv1Broadcast, secret, http.MethodPost, "/api/v1/broadcast", map[string]string{"message": "Server restart soon"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("broadcast status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if msg := strings.Join(drainOutput(watcher.Output), ""); !strings.Contains(msg, "Server restart soon") {
+		t.Fatalf("expected watcher to receive the broadcast, got %q", msg)
+	}
+}
+
+func TestAPITokenStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_tokens.json")
+	store, err := NewAPITokenStore(path)
+	if err != nil {
+		t.Fatalf("NewAPITokenStore error: %v", err)
+	}
+	_, secret, err := store.Issue("metrics", "Admin")
+	if err != nil {
+		t.Fatalf("Issue error: %v", err)
+	}
+	reloaded, err := NewAPITokenStore(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	token, ok := reloaded.Authenticate(secret)
+	if !ok || token.Label != "metrics" || token.CreatedBy != "Admin" {
+		t.Fatalf("expected reloaded token to authenticate, got %#v (ok=%v)", token, ok)
+	}
+}
+
+func TestAPITokenUseIsSavedCoarsely(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_tokens.json")
+	store, err := NewAPITokenStore(path)
+	if err != nil {
+		t.Fatalf("NewAPITokenStore error: %v", err)
+	}
+	issued, secret, err := store.Issue("metrics", "Admin")
+	if err != nil {
+		t.Fatalf("Issue error: %v", err)
+	}
+	savedUse := func() time.Time {
+		t.Helper()
+		reloaded, err := NewAPITokenStore(path)
+		if err != nil {
+			t.Fatalf("reload error: %v", err)
+		}
+		return reloaded.List()[0].LastUsed
+	}
+
+	first, _ := store.Authenticate(secret)
+	if !savedUse().Equal(first.LastUsed) {
+		t.Fatalf("the first use should be written straight away")
+	}
+	second, ok := store.Authenticate(secret)
+	if !ok || second.ID != issued.ID {
+		t.Fatalf("second Authenticate failed")
+	}
+	if !savedUse().Equal(first.LastUsed) {
+		t.Fatalf("a use moments later should stay in memory")
+	}
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if !savedUse().Equal(second.LastUsed) {
+		t.Fatalf("Flush should write the latest use, got %v want %v", savedUse(), second.LastUsed)
+	}
+}
+
+func TestAPIv1ActionsAreAudited(t *testing.T) {
+	portal, world, secret := newTestAPIPortal(t)
+	audit, err := NewAuditLog("")
+	if err != nil {
+		t.Fatalf("NewAuditLog error: %v", err)
+	}
+	world.AttachAuditLog(audit)
+	world.AddPlayerForTest(&Player{Name: "Troll", Room: "start", Alive: true, Output: NewOutputQueue()})
+	world.AddPlayerForTest(&Player{Name: "Pest", Room: "start", Alive: true, Output: NewOutputQueue()})
+
+	if rec := doAPIRequest(t, portal.handleAPIv1Kick, secret, http.MethodPost, "/api/v1/players/kick", map[string]string{"name": "pest", "reason": "cool off"}); rec.Code != http.StatusOK {
+		t.Fatalf("kick status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if rec := doAPIRequest(t, portal.handleAPIv1Ban, secret, http.MethodPost, "/api/v1/players/ban", map[string]string{"name": "troll", "reason": "spam"}); rec.Code != http.StatusOK {
+		t.Fatalf("ban status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if rec := doAPIRequest(t, portal.handleAPIv1Broadcast, secret, http.MethodPost, "/api/v1/broadcast", map[string]string{"message": "Server restart soon"}); rec.Code != http.StatusOK {
+		t.Fatalf("broadcast status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	entries := audit.Entries(AuditFilter{Actor: "api:discord"})
+	got := make([]string, len(entries))
+	for i, entry := range entries {
+		got[i] = entry.Action + " " + entry.Target + " " + entry.Detail
+	}
+	want := []string{"api kick Pest cool off", "api ban Troll spam", "api broadcast  Server restart soon"}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("audit entries = %q, want %q", got, want)
+	}
+}
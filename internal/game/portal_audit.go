@@ -0,0 +1,80 @@
+package game
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// portalAuditLimit bounds how many recent staff actions the dashboard lists.
+const portalAuditLimit = 25
+
+type portalAuditView struct {
+	ID     int      `json:"id"`
+	Time   string   `json:"time"`
+	Actor  string   `json:"actor"`
+	Action string   `json:"action"`
+	Target string   `json:"target,omitempty"`
+	Detail string   `json:"detail,omitempty"`
+	Diff   []string `json:"diff,omitempty"`
+}
+
+func roleAllowsAudit(role PortalRole) bool {
+	return role == PortalRoleAdmin
+}
+
+// auditViews lists the staff actions matching filter, newest first.
+func (p *PortalServer) auditViews(filter AuditFilter) []portalAuditView {
+	views := []portalAuditView{}
+	log := p.world.AuditLog()
+	if log == nil {
+		return views
+	}
+	for _, entry := range log.Entries(filter) {
+		views = append(views, portalAuditView{
+			ID:     entry.ID,
+			Time:   entry.Time.UTC().Format(time.RFC3339),
+			Actor:  entry.Actor,
+			Action: entry.Action,
+			Target: entry.Target,
+			Detail: entry.Detail,
+			Diff:   entry.Diff(),
+		})
+	}
+	return views
+}
+
+// handleAuditAPI lists the staff audit trail for admins. actor, action, and
+// q narrow the listing; limit caps it.
+func (p *PortalServer) handleAuditAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsAudit(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	filter := AuditFilter{
+		Actor:  strings.TrimSpace(query.Get("actor")),
+		Action: strings.TrimSpace(query.Get("action")),
+		Search: strings.TrimSpace(query.Get("q")),
+	}
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+	writePortalJSON(w, http.StatusOK, p.auditViews(filter))
+}
@@ -0,0 +1,681 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type portalRoomSummaryView struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Source string `json:"source,omitempty"`
+	Exits  int    `json:"exits"`
+	Resets int    `json:"resets"`
+}
+
+type portalRoomExitView struct {
+	Direction string `json:"direction"`
+	Target    string `json:"target"`
+}
+
+type portalRoomResetView struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Count       int    `json:"count,omitempty"`
+	AutoGreet   string `json:"auto_greet,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type portalRoomRevisionView struct {
+	Number    int    `json:"number"`
+	Editor    string `json:"editor,omitempty"`
+	Title     string `json:"title"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+type portalRoomView struct {
+	ID          string                   `json:"id"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Exits       []portalRoomExitView     `json:"exits"`
+	NPCs        []string                 `json:"npcs"`
+	Items       []string                 `json:"items"`
+	Resets      []portalRoomResetView    `json:"resets"`
+	Revisions   []portalRoomRevisionView `json:"revisions"`
+}
+
+type portalBuilderPageData struct {
+	Player    string
+	Role      PortalRole
+	RoleTitle string
+}
+
+func roleAllowsBuilding(role PortalRole) bool {
+	return role == PortalRoleBuilder || role == PortalRoleAdmin
+}
+
+func writePortalJSON(w http.ResponseWriter, status int, value any) {
+	data, _ := json.Marshal(value)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// builderSession validates the request's portal session and confirms the
+// session may edit rooms. It writes an error response when access is denied.
+func (p *PortalServer) builderSession(w http.ResponseWriter, r *http.Request) (portalSession, bool) {
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return portalSession{}, false
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsBuilding(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return portalSession{}, false
+	}
+	return session, true
+}
+
+func (p *PortalServer) handleBuilderPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsBuilding(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	data := portalBuilderPageData{
+		Player:    session.Player,
+		Role:      session.Role,
+		RoleTitle: portalRoleTitle(session.Role),
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := portalBuilderTemplate.Execute(w, data); err != nil {
+		http.Error(w, "render error", http.StatusInternalServerError)
+	}
+}
+
+func (p *PortalServer) handleBuilderScript(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write([]byte(portalBuilderScript))
+}
+
+func (p *PortalServer) handleRoomsAPI(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut:
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, ok := p.builderSession(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		roomID := strings.TrimSpace(r.URL.Query().Get("id"))
+		if roomID == "" {
+			writePortalJSON(w, http.StatusOK, p.roomSummaryViews())
+			return
+		}
+		view, found := p.roomView(RoomID(roomID))
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		writePortalJSON(w, http.StatusOK, view)
+	case http.MethodPost:
+		defer r.Body.Close()
+		var payload struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		}
+		if !decodePortalPayload(w, r, &payload) {
+			return
+		}
+		room, err := p.world.CreateRoom(RoomID(payload.ID), payload.Title, session.Player)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		view, _ := p.roomView(room.ID)
+		writePortalJSON(w, http.StatusCreated, view)
+	case http.MethodPut:
+		defer r.Body.Close()
+		var payload struct {
+			ID          string  `json:"id"`
+			Title       *string `json:"title"`
+			Description *string `json:"description"`
+		}
+		if !decodePortalPayload(w, r, &payload) {
+			return
+		}
+		roomID := RoomID(strings.TrimSpace(payload.ID))
+		if payload.Title != nil {
+			if _, err := p.world.UpdateRoomTitle(roomID, *payload.Title, session.Player); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if payload.Description != nil {
+			if _, err := p.world.UpdateRoomDescription(roomID, *payload.Description, session.Player); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		view, found := p.roomView(roomID)
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		writePortalJSON(w, http.StatusOK, view)
+	}
+}
+
+func (p *PortalServer) handleRoomExitsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, ok := p.builderSession(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	var payload struct {
+		Room      string `json:"room"`
+		Direction string `json:"direction"`
+		Target    string `json:"target"`
+		Back      string `json:"back"`
+	}
+	if !decodePortalPayload(w, r, &payload) {
+		return
+	}
+	roomID := RoomID(strings.TrimSpace(payload.Room))
+	target := strings.TrimSpace(payload.Target)
+	var err error
+	switch {
+	case target == "":
+		err = p.world.ClearExit(roomID, payload.Direction, session.Player)
+	case strings.TrimSpace(payload.Back) != "":
+		err = p.world.LinkRooms(roomID, payload.Direction, RoomID(target), payload.Back, session.Player)
+	default:
+		err = p.world.SetExit(roomID, payload.Direction, RoomID(target), session.Player)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	view, found := p.roomView(roomID)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, view)
+}
+
+func (p *PortalServer) handleRoomResetsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, ok := p.builderSession(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	var payload struct {
+		Room   string `json:"room"`
+		Action string `json:"action"`
+		Kind   string `json:"kind"`
+		Name   string `json:"name"`
+		Value  string `json:"value"`
+	}
+	if !decodePortalPayload(w, r, &payload) {
+		return
+	}
+	roomID := RoomID(strings.TrimSpace(payload.Room))
+	kind := ResetKind(strings.ToLower(strings.TrimSpace(payload.Kind)))
+	var err error
+	switch strings.ToLower(strings.TrimSpace(payload.Action)) {
+	case "add":
+		switch kind {
+		case ResetKindNPC:
+			_, err = p.world.UpsertRoomNPC(roomID, payload.Name, payload.Value, session.Player)
+		case ResetKindItem:
+			_, err = p.world.UpsertRoomItemReset(roomID, payload.Name, payload.Value, session.Player)
+		default:
+			http.Error(w, "unknown reset kind", http.StatusBadRequest)
+			return
+		}
+	case "remove":
+		switch kind {
+		case ResetKindNPC:
+			err = p.world.RemoveRoomNPC(roomID, payload.Name, session.Player)
+		case ResetKindItem:
+			err = p.world.RemoveRoomItemReset(roomID, payload.Name, session.Player)
+		default:
+			http.Error(w, "unknown reset kind", http.StatusBadRequest)
+			return
+		}
+	case "apply":
+		err = p.world.ApplyRoomResets(roomID, session.Player)
+	default:
+		http.Error(w, "unknown reset action", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	view, found := p.roomView(roomID)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, view)
+}
+
+// maxAreaUploadBytes bounds the size of an uploaded .are file.
+const maxAreaUploadBytes = 2 << 20
+
+// handleAreaImportAPI converts an uploaded ROM/Merc .are file into a new
+// area file and loads it.
+func (p *PortalServer) handleAreaImportAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, ok := p.builderSession(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAreaUploadBytes))
+	if err != nil {
+		http.Error(w, "area file too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	file, conv, report, err := p.world.ImportROMAreaFile(data, session.Player)
+	if errors.Is(err, ErrAreaNotGranted) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writePortalJSON(w, http.StatusCreated, struct {
+		File     string   `json:"file"`
+		Name     string   `json:"name"`
+		Rooms    int      `json:"rooms"`
+		Added    int      `json:"added"`
+		Warnings []string `json:"warnings,omitempty"`
+	}{file, conv.Name, conv.Rooms, len(report.Added), conv.Warnings})
+}
+
+// handleAreaExportAPI returns an area file converted to ROM .are format.
+func (p *PortalServer) handleAreaExportAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := p.builderSession(w, r); !ok {
+		return
+	}
+	query := r.URL.Query()
+	vnum := 1000
+	if text := strings.TrimSpace(query.Get("vnum")); text != "" {
+		parsed, err := strconv.Atoi(text)
+		if err != nil {
+			http.Error(w, "invalid vnum", http.StatusBadRequest)
+			return
+		}
+		vnum = parsed
+	}
+	file := strings.TrimSpace(query.Get("file"))
+	conv, err := p.world.ExportROMAreaFile(file, vnum)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+strings.TrimSuffix(file, filepath.Ext(file))+`.are"`)
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(conv.Data)
+}
+
+func decodePortalPayload(w http.ResponseWriter, r *http.Request, target any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (p *PortalServer) roomSummaryViews() []portalRoomSummaryView {
+	summaries := p.world.RoomSummaries()
+	views := make([]portalRoomSummaryView, 0, len(summaries))
+	for _, summary := range summaries {
+		views = append(views, portalRoomSummaryView{
+			ID:     string(summary.ID),
+			Title:  summary.Title,
+			Source: summary.Source,
+			Exits:  summary.Exits,
+			Resets: summary.Resets,
+		})
+	}
+	return views
+}
+
+func (p *PortalServer) roomView(id RoomID) (portalRoomView, bool) {
+	room, ok := p.world.RoomSnapshot(id)
+	if !ok {
+		return portalRoomView{}, false
+	}
+	view := portalRoomView{
+		ID:          string(room.ID),
+		Title:       room.Title,
+		Description: room.Description,
+		Exits:       make([]portalRoomExitView, 0, len(room.Exits)),
+		NPCs:        make([]string, 0, len(room.NPCs)),
+		Items:       make([]string, 0, len(room.Items)),
+		Resets:      make([]portalRoomResetView, 0, len(room.Resets)),
+		Revisions:   []portalRoomRevisionView{},
+	}
+	for dir, target := range room.Exits {
+		view.Exits = append(view.Exits, portalRoomExitView{Direction: dir, Target: string(target.To)})
+	}
+	sort.Slice(view.Exits, func(i, j int) bool {
+		return view.Exits[i].Direction < view.Exits[j].Direction
+	})
+	for _, npc := range room.NPCs {
+		view.NPCs = append(view.NPCs, npc.Name)
+	}
+	for _, item := range room.Items {
+		view.Items = append(view.Items, item.Name)
+	}
+	for _, reset := range room.Resets {
+		view.Resets = append(view.Resets, portalRoomResetView{
+			Kind:        string(reset.Kind),
+			Name:        reset.Name,
+			Count:       reset.Count,
+			AutoGreet:   reset.AutoGreet,
+			Description: reset.Description,
+		})
+	}
+	if revisions, err := p.world.RoomRevisions(id); err == nil {
+		for _, rev := range revisions {
+			entry := portalRoomRevisionView{Number: rev.Number, Editor: rev.Editor, Title: rev.Title}
+			if !rev.Timestamp.IsZero() {
+				entry.Timestamp = rev.Timestamp.UTC().Format(time.RFC3339)
+			}
+			view.Revisions = append(view.Revisions, entry)
+		}
+	}
+	return view, true
+}
+
+var portalBuilderTemplate = template.Must(template.New("builder").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8" />
+<title>{{.RoleTitle}} · Area Editor</title>
+<meta name="viewport" content="width=device-width, initial-scale=1" />
+<style>
+body { font-family: "Segoe UI", Roboto, Helvetica, Arial, sans-serif; margin: 0; background: #0f172a; color: #e2e8f0; }
+header { background: linear-gradient(120deg, #3b82f6, #06b6d4); padding: 1.5rem 3vw; }
+header h1 { margin: 0 0 0.25rem 0; font-size: 1.8rem; }
+header a { color: #0f172a; font-weight: 600; }
+main { display: flex; gap: 1.5rem; padding: 2rem 3vw; flex-wrap: wrap; }
+aside { flex: 0 0 280px; }
+section { background: rgba(15, 23, 42, 0.7); border: 1px solid rgba(148, 163, 184, 0.2); border-radius: 1rem; padding: 1.25rem; margin-bottom: 1.5rem; }
+section h2 { margin-top: 0; font-size: 1.2rem; color: #38bdf8; }
+#editor { flex: 1 1 480px; }
+.room-list { max-height: 520px; overflow-y: auto; display: flex; flex-direction: column; gap: 0.35rem; }
+.room-entry { text-align: left; background: transparent; border: 1px solid transparent; color: #e2e8f0; padding: 0.5rem 0.65rem; border-radius: 0.65rem; cursor: pointer; }
+.room-entry:hover, .room-entry.active { background: rgba(56, 189, 248, 0.15); border-color: rgba(56, 189, 248, 0.4); }
+.room-entry small { display: block; color: #94a3b8; }
+label { display: block; font-size: 0.75rem; text-transform: uppercase; letter-spacing: 0.08em; color: #94a3b8; margin: 0.6rem 0 0.25rem; }
+input[type="text"], select, textarea { width: 100%; box-sizing: border-box; border-radius: 0.6rem; border: 1px solid rgba(148, 163, 184, 0.25); background: rgba(15, 23, 42, 0.75); color: #f8fafc; padding: 0.55rem 0.7rem; font-size: 0.95rem; }
+textarea { min-height: 160px; resize: vertical; }
+.row { display: flex; gap: 0.5rem; align-items: flex-end; flex-wrap: wrap; }
+.row > div { flex: 1 1 120px; }
+button { border: none; border-radius: 999px; padding: 0.45rem 1rem; font-weight: 600; cursor: pointer; background: linear-gradient(120deg, #38bdf8, #3b82f6); color: #0f172a; }
+button.secondary { background: rgba(148, 163, 184, 0.2); color: #e2e8f0; }
+ul.plain { list-style: none; padding: 0; margin: 0.5rem 0; }
+ul.plain li { display: flex; justify-content: space-between; align-items: center; padding: 0.35rem 0; border-bottom: 1px solid rgba(148, 163, 184, 0.15); }
+.status { min-height: 1.2rem; font-size: 0.85rem; color: #94a3b8; margin-top: 0.5rem; }
+.status.error { color: #fca5a5; }
+</style>
+</head>
+<body>
+<header>
+<h1>Area Editor</h1>
+<p>Signed in as {{.Player}} ({{.Role}}). Changes are saved to builder rooms and recorded in room history. <a href="/interface">Back to dashboard</a></p>
+</header>
+<main>
+<aside>
+<section>
+<h2>Rooms</h2>
+<input id="room-filter" type="text" placeholder="Filter rooms" autocomplete="off" />
+<div class="room-list" id="room-list"></div>
+</section>
+<section>
+<h2>New Room</h2>
+<label for="new-room-id">Room ID</label>
+<input id="new-room-id" type="text" autocomplete="off" />
+<label for="new-room-title">Title</label>
+<input id="new-room-title" type="text" autocomplete="off" />
+<p><button type="button" id="new-room-create">Create room</button></p>
+</section>
+</aside>
+<div id="editor">
+<section>
+<h2 id="room-heading">Select a room</h2>
+<label for="room-title">Title</label>
+<input id="room-title" type="text" autocomplete="off" />
+<label for="room-description">Description</label>
+<textarea id="room-description"></textarea>
+<p><button type="button" id="room-save">Save text</button></p>
+</section>
+<section>
+<h2>Exits</h2>
+<ul class="plain" id="exit-list"></ul>
+<div class="row">
+<div><label for="exit-direction">Direction</label><input id="exit-direction" type="text" autocomplete="off" /></div>
+<div><label for="exit-target">Target room</label><input id="exit-target" type="text" list="room-ids" autocomplete="off" /></div>
+<div><label for="exit-back">Return direction</label><input id="exit-back" type="text" autocomplete="off" /></div>
+<div><button type="button" id="exit-save">Link exit</button></div>
+</div>
+<datalist id="room-ids"></datalist>
+</section>
+<section>
+<h2>Resets</h2>
+<ul class="plain" id="reset-list"></ul>
+<div class="row">
+<div><label for="reset-kind">Kind</label><select id="reset-kind"><option value="npc">NPC</option><option value="item">Item</option></select></div>
+<div><label for="reset-name">Name</label><input id="reset-name" type="text" autocomplete="off" /></div>
+<div><label for="reset-value">Greeting / description</label><input id="reset-value" type="text" autocomplete="off" /></div>
+<div><button type="button" id="reset-save">Add reset</button></div>
+</div>
+<p><button type="button" class="secondary" id="reset-apply">Apply resets now</button></p>
+</section>
+<section>
+<h2>Revision History</h2>
+<ul class="plain" id="revision-list"></ul>
+</section>
+<div class="status" id="status"></div>
+</div>
+</main>
+<script src="/builder.js"></script>
+</body>
+</html>`))
+
+const portalBuilderScript = `(() => {
+const byId = (id) => document.getElementById(id);
+const statusEl = byId('status');
+let rooms = [];
+let current = null;
+
+const setStatus = (message, isError) => {
+  statusEl.textContent = message || '';
+  statusEl.className = isError ? 'status error' : 'status';
+};
+
+const request = async (url, options) => {
+  const response = await fetch(url, Object.assign({ credentials: 'same-origin', headers: { 'Content-Type': 'application/json' } }, options || {}));
+  if (!response.ok) {
+    throw new Error((await response.text()).trim() || response.statusText);
+  }
+  return response.json();
+};
+
+const listItem = (text, actionLabel, action) => {
+  const li = document.createElement('li');
+  const span = document.createElement('span');
+  span.textContent = text;
+  li.appendChild(span);
+  if (actionLabel) {
+    const button = document.createElement('button');
+    button.type = 'button';
+    button.className = 'secondary';
+    button.textContent = actionLabel;
+    button.addEventListener('click', action);
+    li.appendChild(button);
+  }
+  return li;
+};
+
+const renderRooms = () => {
+  const filter = byId('room-filter').value.trim().toLowerCase();
+  const list = byId('room-list');
+  const ids = byId('room-ids');
+  list.replaceChildren();
+  ids.replaceChildren();
+  rooms.forEach((room) => {
+    const option = document.createElement('option');
+    option.value = room.id;
+    ids.appendChild(option);
+    if (filter && !room.id.toLowerCase().includes(filter) && !room.title.toLowerCase().includes(filter)) {
+      return;
+    }
+    const button = document.createElement('button');
+    button.type = 'button';
+    button.className = 'room-entry' + (current && current.id === room.id ? ' active' : '');
+    button.textContent = room.title || room.id;
+    const meta = document.createElement('small');
+    meta.textContent = room.id + ' · ' + room.exits + ' exits · ' + room.resets + ' resets';
+    button.appendChild(meta);
+    button.addEventListener('click', () => selectRoom(room.id));
+    list.appendChild(button);
+  });
+};
+
+const renderRoom = (room) => {
+  current = room;
+  byId('room-heading').textContent = room.title + ' (' + room.id + ')';
+  byId('room-title').value = room.title;
+  byId('room-description').value = room.description;
+  const exits = byId('exit-list');
+  exits.replaceChildren();
+  room.exits.forEach((exit) => {
+    exits.appendChild(listItem(exit.direction + ' → ' + exit.target, 'Remove', () => saveExit(exit.direction, '', '')));
+  });
+  const resets = byId('reset-list');
+  resets.replaceChildren();
+  room.resets.forEach((reset) => {
+    const detail = reset.kind === 'npc' ? reset.auto_greet : reset.description;
+    const label = reset.kind.toUpperCase() + ' ' + reset.name + (detail ? ' — ' + detail : '');
+    resets.appendChild(listItem(label, 'Remove', () => saveReset('remove', reset.kind, reset.name, '')));
+  });
+  const revisions = byId('revision-list');
+  revisions.replaceChildren();
+  room.revisions.slice().reverse().forEach((rev) => {
+    const who = rev.editor ? ' by ' + rev.editor : '';
+    const when = rev.timestamp ? ' at ' + new Date(rev.timestamp).toLocaleString() : '';
+    revisions.appendChild(listItem('#' + rev.number + ' ' + rev.title + who + when));
+  });
+  renderRooms();
+};
+
+const loadRooms = async () => {
+  rooms = await request('/api/rooms');
+  renderRooms();
+};
+
+const selectRoom = async (id) => {
+  try {
+    renderRoom(await request('/api/rooms?id=' + encodeURIComponent(id)));
+    setStatus('');
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+};
+
+const refreshAfter = async (promise, message) => {
+  try {
+    renderRoom(await promise);
+    await loadRooms();
+    setStatus(message);
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+};
+
+const saveExit = (direction, target, back) => {
+  if (!current) return;
+  refreshAfter(request('/api/rooms/exits', { method: 'POST', body: JSON.stringify({ room: current.id, direction, target, back }) }), 'Exits updated.');
+};
+
+const saveReset = (action, kind, name, value) => {
+  if (!current) return;
+  refreshAfter(request('/api/rooms/resets', { method: 'POST', body: JSON.stringify({ room: current.id, action, kind, name, value }) }), 'Resets updated.');
+};
+
+byId('room-filter').addEventListener('input', renderRooms);
+byId('room-save').addEventListener('click', () => {
+  if (!current) return;
+  const body = { id: current.id, title: byId('room-title').value, description: byId('room-description').value };
+  refreshAfter(request('/api/rooms', { method: 'PUT', body: JSON.stringify(body) }), 'Room saved.');
+});
+byId('exit-save').addEventListener('click', () => {
+  saveExit(byId('exit-direction').value, byId('exit-target').value, byId('exit-back').value);
+});
+byId('reset-save').addEventListener('click', () => {
+  saveReset('add', byId('reset-kind').value, byId('reset-name').value, byId('reset-value').value);
+});
+byId('reset-apply').addEventListener('click', () => saveReset('apply', '', '', ''));
+byId('new-room-create').addEventListener('click', () => {
+  const body = { id: byId('new-room-id').value, title: byId('new-room-title').value };
+  refreshAfter(request('/api/rooms', { method: 'POST', body: JSON.stringify(body) }), 'Room created.');
+});
+
+loadRooms().catch((err) => setStatus(err.message, true));
+})();
+`
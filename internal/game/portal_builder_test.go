@@ -0,0 +1,122 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestBuilderPortal(t *testing.T, role PortalRole) (*PortalServer, *World, *http.Cookie) {
+	t.Helper()
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Atrium", Description: "A bright hall.", Exits: map[string]Exit{}},
+	})
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions:   make(map[string]portalSession),
+		documents:  make(map[string]portalDocument),
+	}
+	id, _, err := portal.createSession(role, "Builder")
+	if err != nil {
+		t.Fatalf("createSession error: %v", err)
+	}
+	return portal, world, &http.Cookie{Name: portalCookieName, Value: id}
+}
+
+func doBuilderRequest(t *testing.T, handler http.HandlerFunc, cookie *http.Cookie, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func TestPortalRoomsAPIEditsRooms(t *testing.T) {
+	portal, world, cookie := newTestBuilderPortal(t, PortalRoleBuilder)
+
+	rec := doBuilderRequest(t, portal.handleRoomsAPI, cookie, http.MethodPost, "/api/rooms", map[string]string{"id": "vault", "title": "Vault"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = doBuilderRequest(t, portal.handleRoomsAPI, cookie, http.MethodPut, "/api/rooms", map[string]string{"id": "vault", "description": "Cold stone walls."})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = doBuilderRequest(t, portal.handleRoomExitsAPI, cookie, http.MethodPost, "/api/rooms/exits", map[string]string{"room": "start", "direction": "down", "target": "vault", "back": "up"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("exit status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = doBuilderRequest(t, portal.handleRoomResetsAPI, cookie, http.MethodPost, "/api/rooms/resets", map[string]string{"room": "vault", "action": "add", "kind": "item", "name": "Lantern", "value": "A brass lantern."})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reset status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = doBuilderRequest(t, portal.handleRoomsAPI, cookie, http.MethodGet, "/api/rooms?id=vault", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("get status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	var view portalRoomView
+	if err := json.Unmarshal(rec.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode room view: %v", err)
+	}
+	if view.Description != "Cold stone walls." {
+		t.Fatalf("description = %q", view.Description)
+	}
+	if len(view.Exits) != 1 || view.Exits[0].Direction != "up" || view.Exits[0].Target != "start" {
+		t.Fatalf("unexpected exits: %#v", view.Exits)
+	}
+	if len(view.Resets) != 1 || view.Resets[0].Name != "Lantern" {
+		t.Fatalf("unexpected resets: %#v", view.Resets)
+	}
+	if len(view.Revisions) < 2 || view.Revisions[len(view.Revisions)-1].Editor != "Builder" {
+		t.Fatalf("expected revision by Builder, got %#v", view.Revisions)
+	}
+
+	start, ok := world.GetRoom("start")
+	if !ok || start.Exits["down"].To != "vault" {
+		t.Fatalf("start room missing exit to vault")
+	}
+
+	rec = doBuilderRequest(t, portal.handleRoomsAPI, cookie, http.MethodGet, "/api/rooms", nil)
+	var summaries []portalRoomSummaryView
+	if err := json.Unmarshal(rec.Body.Bytes(), &summaries); err != nil {
+		t.Fatalf("decode summaries: %v", err)
+	}
+	if len(summaries) != 2 || summaries[0].ID != "start" || summaries[1].ID != "vault" {
+		t.Fatalf("unexpected summaries: %#v", summaries)
+	}
+}
+
+func TestPortalRoomsAPIRequiresBuilder(t *testing.T) {
+	portal, _, cookie := newTestBuilderPortal(t, PortalRoleModerator)
+
+	rec := doBuilderRequest(t, portal.handleRoomsAPI, cookie, http.MethodGet, "/api/rooms", nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("moderator status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = doBuilderRequest(t, portal.handleRoomsAPI, nil, http.MethodGet, "/api/rooms", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = doBuilderRequest(t, portal.handleBuilderPage, cookie, http.MethodGet, "/builder", nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("builder page status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
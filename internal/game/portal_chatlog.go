@@ -0,0 +1,69 @@
+package game
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type portalChatLogView struct {
+	Time    string `json:"time"`
+	Channel string `json:"channel"`
+	Speaker string `json:"speaker,omitempty"`
+	Text    string `json:"text"`
+}
+
+func roleAllowsModeration(role PortalRole) bool {
+	return role == PortalRoleModerator || role == PortalRoleAdmin
+}
+
+// handleChatLogAPI exports a global channel's retained scrollback for
+// staff. format=text downloads a plain transcript; the default is JSON.
+func (p *PortalServer) handleChatLogAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsModeration(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	channel, ok := ChannelFromString(strings.TrimSpace(query.Get("channel")))
+	if !ok || !chatLogged(channel) {
+		http.Error(w, "unknown channel", http.StatusBadRequest)
+		return
+	}
+	entries := p.world.ChatLog().Recent(channel, 0)
+	filename := fmt.Sprintf("%s-%s", channel, time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Cache-Control", "no-store")
+	if strings.EqualFold(query.Get("format"), "text") {
+		var builder strings.Builder
+		for _, entry := range entries {
+			line := strings.TrimSpace(plainText(entry.Message))
+			builder.WriteString(fmt.Sprintf("[%s] %s\n", entry.Time.UTC().Format(time.RFC3339), line))
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.txt"`)
+		_, _ = w.Write([]byte(builder.String()))
+		return
+	}
+	views := make([]portalChatLogView, 0, len(entries))
+	for _, entry := range entries {
+		views = append(views, portalChatLogView{
+			Time:    entry.Time.UTC().Format(time.RFC3339),
+			Channel: string(entry.Channel),
+			Speaker: entry.Speaker,
+			Text:    strings.TrimSpace(plainText(entry.Message)),
+		})
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.json"`)
+	writePortalJSON(w, http.StatusOK, views)
+}
@@ -0,0 +1,339 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// consoleMessage is a frame sent to the portal console: a server log record,
+// output from the admin's character, or a notice from the console itself.
+type consoleMessage struct {
+	Type  string    `json:"type"`
+	Time  time.Time `json:"time,omitempty"`
+	Level string    `json:"level,omitempty"`
+	Text  string    `json:"text"`
+}
+
+// consoleAttachment ties a portal console to the character it drives.
+type consoleAttachment struct {
+	player *Player
+	output chan string
+	// owned reports that the console brought the character into the world,
+	// so closing the console logs them out again.
+	owned bool
+}
+
+func roleAllowsConsole(role PortalRole) bool {
+	return role == PortalRoleAdmin
+}
+
+// attachConsole mirrors the named character's output to a new console. A
+// character that is not online is logged in without a connection for as long
+// as the console stays open.
+func (w *World) attachConsole(name string) (*consoleAttachment, error) {
+	output := make(chan string, 64)
+	if p, ok := w.ActivePlayer(name); ok {
+		p.linkMu.Lock()
+		defer p.linkMu.Unlock()
+		if p.console != nil {
+			return nil, fmt.Errorf("a console is already attached to %s", name)
+		}
+		p.console = output
+		return &consoleAttachment{player: p, output: output}, nil
+	}
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	owner, isAdmin := name, true
+	var profile PlayerProfile
+	if accounts != nil {
+		if account, ok := accounts.CharacterOwner(name); ok {
+			owner = account
+		}
+		isAdmin = accounts.IsAdmin(owner)
+		profile = accounts.Profile(name)
+	}
+	if !isAdmin {
+		return nil, fmt.Errorf("%s is not an admin", name)
+	}
+	p, err := w.addCharacter(owner, name, nil, isAdmin, profile)
+	if err != nil {
+		return nil, err
+	}
+	p.linkMu.Lock()
+	p.console = output
+	p.linkMu.Unlock()
+	go w.pumpOutput(p, p.Output)
+	Logger().Info("portal console logged in", "player", name)
+	EnterRoom(w, p, "")
+	return &consoleAttachment{player: p, output: output, owned: true}, nil
+}
+
+// detachConsole stops mirroring output to c. A character the console logged
+// in is logged out unless a client has since connected to it.
+func (w *World) detachConsole(c *consoleAttachment) {
+	p := c.player
+	p.linkMu.Lock()
+	if p.console == c.output {
+		p.console = nil
+	}
+	connected := p.Session != nil || p.linkdead
+	p.linkMu.Unlock()
+	if !c.owned || connected || !p.Alive {
+		return
+	}
+	w.markOffline(p)
+	w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s leaves.", HighlightName(p.Name))), p)
+	w.PersistPlayer(p)
+	w.removePlayer(p.Name)
+	Logger().Info("portal console logged out", "player", p.Name)
+}
+
+// auditConsoleCommand records a command an admin ran from the console in the
+// audit and moderation trails.
+func (w *World) auditConsoleCommand(admin, line string) {
+	w.Audit(AuditEntry{Actor: admin, Action: AuditConsole, Detail: line})
+	if log := w.Moderation(); log != nil {
+		if err := log.Record(ModerationAction{Actor: admin, Action: "console", Detail: line}); err != nil {
+			Logger().Warn("failed to record console command", "admin", admin, "error", err)
+		}
+		return
+	}
+	Logger().Info("portal console command", "admin", admin, "command", line)
+}
+
+func (p *PortalServer) handleConsolePage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsConsole(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	data := portalBuilderPageData{
+		Player:    session.Player,
+		Role:      session.Role,
+		RoleTitle: portalRoleTitle(session.Role),
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := portalConsoleTemplate.Execute(w, data); err != nil {
+		http.Error(w, "render error", http.StatusInternalServerError)
+	}
+}
+
+// handleConsoleSocket streams the server log and the admin's character
+// output over a WebSocket, and runs each message received as a command.
+func (p *PortalServer) handleConsoleSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, _, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !roleAllowsConsole(session.Role) || session.Player == "" {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	ws, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+	send := func(msg consoleMessage) error {
+		data, _ := json.Marshal(msg)
+		return ws.WriteText(string(data))
+	}
+
+	history, entries, unsubscribe := SubscribeLogs()
+	defer unsubscribe()
+	for _, entry := range history {
+		if err := send(consoleMessage{Type: "log", Time: entry.Time, Level: entry.Level, Text: entry.Text}); err != nil {
+			return
+		}
+	}
+
+	var attachment *consoleAttachment
+	if p.dispatcher == nil {
+		_ = send(consoleMessage{Type: "notice", Text: "Commands are unavailable; showing the server log only."})
+	} else if attachment, err = p.world.attachConsole(session.Player); err != nil {
+		_ = send(consoleMessage{Type: "notice", Text: "Commands are unavailable: " + err.Error() + "."})
+	} else {
+		defer p.world.detachConsole(attachment)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	var output <-chan string
+	if attachment != nil {
+		output = attachment.output
+	}
+	go func() {
+		for {
+			var msg consoleMessage
+			select {
+			case <-done:
+				return
+			case entry := <-entries:
+				msg = consoleMessage{Type: "log", Time: entry.Time, Level: entry.Level, Text: entry.Text}
+			case out := <-output:
+				msg = consoleMessage{Type: "output", Text: plainText(out)}
+			}
+			if err := send(msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		line := Trim(sanitizeInput(message))
+		if line == "" || attachment == nil {
+			continue
+		}
+		player := attachment.player
+		if !player.Alive {
+			return
+		}
+		now := time.Now()
+		if !player.allowCommand(now) {
+			_ = send(consoleMessage{Type: "notice", Text: "You are sending commands too quickly. Please wait."})
+			continue
+		}
+		player.markActive(now)
+		p.world.auditConsoleCommand(session.Player, line)
+		if quit := p.dispatcher(p.world, player, line); quit || !player.Alive {
+			return
+		}
+		player.Output.Send(Prompt(player))
+	}
+}
+
+var portalConsoleTemplate = template.Must(template.New("console").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8" />
+<title>{{.RoleTitle}} — Live Console</title>
+<meta name="viewport" content="width=device-width, initial-scale=1" />
+<style>
+body { font-family: "Segoe UI", Roboto, Helvetica, Arial, sans-serif; margin: 0; background: #0f172a; color: #e2e8f0; display: flex; flex-direction: column; height: 100vh; }
+header { background: linear-gradient(120deg, #3b82f6, #06b6d4); padding: 1rem 3vw; }
+header h1 { margin: 0; font-size: 1.5rem; }
+header p { margin: 0.25rem 0 0; }
+header a { color: #0f172a; font-weight: 600; }
+main { flex: 1; display: flex; flex-direction: column; padding: 1rem 3vw; gap: 0.75rem; min-height: 0; }
+#console { flex: 1; overflow-y: auto; margin: 0; background: rgba(6, 11, 27, 0.85); border: 1px solid rgba(148, 163, 184, 0.25); border-radius: 0.75rem; padding: 0.75rem; font-family: "Fira Code", "SFMono-Regular", Menlo, Consolas, monospace; font-size: 0.9rem; line-height: 1.4; white-space: pre-wrap; }
+.log { color: #94a3b8; }
+.log-WARN { color: #facc15; }
+.log-ERROR { color: #fca5a5; }
+.notice { color: #38bdf8; }
+.input { color: #a5b4fc; }
+form { display: flex; gap: 0.5rem; }
+#command { flex: 1; border-radius: 0.75rem; border: 1px solid rgba(148, 163, 184, 0.25); background: rgba(15, 23, 42, 0.75); color: #f8fafc; padding: 0.65rem 0.75rem; font-family: "Fira Code", "SFMono-Regular", Menlo, Consolas, monospace; font-size: 1rem; }
+button { border: none; border-radius: 999px; padding: 0.5rem 1.1rem; font-weight: 600; cursor: pointer; background: linear-gradient(120deg, #38bdf8, #3b82f6); color: #0f172a; }
+label { font-size: 0.85rem; color: #94a3b8; }
+</style>
+</head>
+<body>
+<header>
+<h1>Live Console</h1>
+<p>Commands run as {{.Player}} and are recorded in the moderation trail. <a href="/interface">Back to the dashboard</a></p>
+</header>
+<main>
+<label><input type="checkbox" id="show-logs" checked /> Show server log</label>
+<pre id="console"></pre>
+<form id="command-form">
+<input id="command" type="text" autocomplete="off" placeholder="Type a command" autofocus />
+<button type="submit">Send</button>
+</form>
+</main>
+<script>
+const consoleView = document.getElementById('console');
+const commandForm = document.getElementById('command-form');
+const commandInput = document.getElementById('command');
+const showLogs = document.getElementById('show-logs');
+const history = [];
+let historyIndex = 0;
+let socket = null;
+const append = (className, text) => {
+  const atBottom = consoleView.scrollTop + consoleView.clientHeight >= consoleView.scrollHeight - 4;
+  const line = document.createElement('div');
+  line.className = className;
+  line.textContent = text;
+  if (className.startsWith('log') && !showLogs.checked) {
+    line.hidden = true;
+  }
+  consoleView.appendChild(line);
+  while (consoleView.childNodes.length > 2000) {
+    consoleView.removeChild(consoleView.firstChild);
+  }
+  if (atBottom) {
+    consoleView.scrollTop = consoleView.scrollHeight;
+  }
+};
+const connect = () => {
+  socket = new WebSocket((location.protocol === 'https:' ? 'wss://' : 'ws://') + location.host + '/api/console');
+  socket.addEventListener('message', (event) => {
+    const msg = JSON.parse(event.data);
+    if (msg.type === 'log') {
+      append('log log-' + msg.level, new Date(msg.time).toLocaleTimeString() + ' ' + msg.level + ' ' + msg.text);
+    } else if (msg.type === 'output') {
+      append('output', msg.text.replace(/^\r?\n/, ''));
+    } else {
+      append('notice', msg.text);
+    }
+  });
+  socket.addEventListener('close', () => {
+    append('notice', 'Console disconnected. Reconnecting in 5 seconds…');
+    setTimeout(connect, 5000);
+  });
+};
+showLogs.addEventListener('change', () => {
+  consoleView.querySelectorAll('.log').forEach((line) => {
+    line.hidden = !showLogs.checked;
+  });
+});
+commandForm.addEventListener('submit', (event) => {
+  event.preventDefault();
+  const command = commandInput.value;
+  if (!socket || socket.readyState !== WebSocket.OPEN) {
+    return;
+  }
+  socket.send(command);
+  append('input', '> ' + command);
+  if (command.trim() !== '') {
+    history.push(command);
+  }
+  historyIndex = history.length;
+  commandInput.value = '';
+});
+commandInput.addEventListener('keydown', (event) => {
+  if (event.key === 'ArrowUp' && historyIndex > 0) {
+    historyIndex--;
+    commandInput.value = history[historyIndex];
+    event.preventDefault();
+  } else if (event.key === 'ArrowDown' && historyIndex < history.length) {
+    historyIndex++;
+    commandInput.value = history[historyIndex] || '';
+    event.preventDefault();
+  }
+});
+connect();
+</script>
+</body>
+</html>`))
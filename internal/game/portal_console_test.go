@@ -0,0 +1,195 @@
+package game
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dialTestConsole performs a WebSocket handshake against the console
+// endpoint of server using the given portal session cookie.
+func dialTestConsole(t *testing.T, server *httptest.Server, sessionID string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial console: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	request := "GET /api/console HTTP/1.1\r\n" +
+		"Host: " + strings.TrimPrefix(server.URL, "http://") + "\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: " + key + "\r\n" +
+		"Cookie: " + portalCookieName + "=" + sessionID + "\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want %d", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("Sec-WebSocket-Accept = %q", got)
+	}
+	return conn, reader
+}
+
+func writeTestFrame(t *testing.T, conn net.Conn, text string) {
+	t.Helper()
+	var mask [4]byte
+	_, _ = rand.Read(mask[:])
+	frame := []byte{0x80 | wsOpText, 0x80 | byte(len(text))}
+	frame = append(frame, mask[:]...)
+	for i := 0; i < len(text); i++ {
+		frame = append(frame, text[i]^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatalf("write frame: %v", err)
+	}
+}
+
+// readConsoleUntil reads console messages until one satisfies match.
+func readConsoleUntil(t *testing.T, conn net.Conn, reader *bufio.Reader, match func(consoleMessage) bool) consoleMessage {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			t.Fatalf("read frame: %v", err)
+		}
+		length := int(header[1] & 0x7f)
+		switch length {
+		case 126:
+			var ext [2]byte
+			_, _ = io.ReadFull(reader, ext[:])
+			length = int(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			_, _ = io.ReadFull(reader, ext[:])
+			length = int(binary.BigEndian.Uint64(ext[:]))
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			t.Fatalf("read payload: %v", err)
+		}
+		if header[0]&0x0f != wsOpText {
+			continue
+		}
+		var msg consoleMessage
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			t.Fatalf("decode console message: %v", err)
+		}
+		if match(msg) {
+			return msg
+		}
+	}
+}
+
+func TestPortalConsoleStreamsLogsAndRunsCommands(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Atrium", Description: "A bright hall.", Exits: map[string]Exit{}},
+	})
+	moderation, err := NewModerationLog(filepath.Join(t.TempDir(), "moderation.json"))
+	if err != nil {
+		t.Fatalf("NewModerationLog error: %v", err)
+	}
+	world.AttachModeration(moderation)
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions: map[string]portalSession{
+			"admin":   {Role: PortalRoleAdmin, Player: "Warden", Expires: time.Now().Add(time.Hour)},
+			"builder": {Role: PortalRoleBuilder, Player: "Mason", Expires: time.Now().Add(time.Hour)},
+		},
+		documents: make(map[string]portalDocument),
+		dispatcher: func(w *World, p *Player, line string) bool {
+			p.Output.Send("echo: " + line)
+			return line == "quit"
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(portal.handleConsoleSocket))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/console", nil)
+	req.AddCookie(&http.Cookie{Name: portalCookieName, Value: "builder"})
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("builder request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("builder console status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+
+	Logger().Info("console history marker")
+	conn, reader := dialTestConsole(t, server, "admin")
+	readConsoleUntil(t, conn, reader, func(msg consoleMessage) bool {
+		return msg.Type == "log" && strings.Contains(msg.Text, "console history marker")
+	})
+	if _, ok := world.ActivePlayer("Warden"); !ok {
+		t.Fatalf("console should log the offline admin in")
+	}
+
+	Logger().Warn("console live marker")
+	readConsoleUntil(t, conn, reader, func(msg consoleMessage) bool {
+		return msg.Type == "log" && msg.Level == "WARN" && strings.Contains(msg.Text, "console live marker")
+	})
+
+	writeTestFrame(t, conn, "say hello")
+	readConsoleUntil(t, conn, reader, func(msg consoleMessage) bool {
+		return msg.Type == "output" && msg.Text == "echo: say hello"
+	})
+	actions := moderation.Actions(0)
+	if len(actions) != 1 || actions[0].Actor != "Warden" || actions[0].Action != "console" || actions[0].Detail != "say hello" {
+		t.Fatalf("moderation trail = %+v, want the console command", actions)
+	}
+
+	writeTestFrame(t, conn, "quit")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := world.ActivePlayer("Warden"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("closing the console should log the admin out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebSocketRejectsCrossOrigin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := upgradeWebSocket(w, r); err == nil {
+			t.Errorf("cross-origin upgrade should fail")
+		}
+	}))
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Origin", "https://evil.example")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("cross-origin status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
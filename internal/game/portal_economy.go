@@ -0,0 +1,97 @@
+package game
+
+import (
+	"net/http"
+	"time"
+)
+
+type portalEconomyFlowView struct {
+	Source    string `json:"source"`
+	Created   int    `json:"created"`
+	Destroyed int    `json:"destroyed"`
+	Net       int    `json:"net"`
+	Events    int    `json:"events"`
+}
+
+type portalEconomyHourView struct {
+	Hour      string `json:"hour"`
+	Created   int    `json:"created"`
+	Destroyed int    `json:"destroyed"`
+	Net       int    `json:"net"`
+	// CreatedBar and DestroyedBar size the dashboard chart's bars as a
+	// percent of the busiest hour.
+	CreatedBar   int `json:"-"`
+	DestroyedBar int `json:"-"`
+}
+
+type portalEconomyView struct {
+	Since      string                  `json:"since,omitempty"`
+	FaucetRate float64                 `json:"faucet_rate"`
+	SinkRate   float64                 `json:"sink_rate"`
+	Sources    []portalEconomyFlowView `json:"sources"`
+	Total      portalEconomyFlowView   `json:"total"`
+	Hours      []portalEconomyHourView `json:"hours"`
+}
+
+func roleAllowsEconomy(role PortalRole) bool {
+	return role == PortalRoleAdmin
+}
+
+func newPortalEconomyFlowView(flow EconomyFlow) portalEconomyFlowView {
+	return portalEconomyFlowView{Source: string(flow.Source), Created: flow.Created, Destroyed: flow.Destroyed, Net: flow.Net(), Events: flow.Events}
+}
+
+// economyView reports the economy ledger with its hourly series ready to
+// chart.
+func (p *PortalServer) economyView() portalEconomyView {
+	report := p.world.EconomyReport()
+	view := portalEconomyView{
+		FaucetRate: report.FaucetRate,
+		SinkRate:   report.SinkRate,
+		Sources:    []portalEconomyFlowView{},
+		Total:      newPortalEconomyFlowView(report.Total),
+		Hours:      []portalEconomyHourView{},
+	}
+	view.Total.Source = "total"
+	if !report.Since.IsZero() {
+		view.Since = report.Since.UTC().Format(time.RFC3339)
+	}
+	for _, flow := range report.Flows {
+		view.Sources = append(view.Sources, newPortalEconomyFlowView(flow))
+	}
+	busiest := 1
+	for _, hour := range report.Hours {
+		busiest = max(busiest, hour.Created, hour.Destroyed)
+	}
+	for _, hour := range report.Hours {
+		view.Hours = append(view.Hours, portalEconomyHourView{
+			Hour:         hour.Hour.UTC().Format(time.RFC3339),
+			Created:      hour.Created,
+			Destroyed:    hour.Destroyed,
+			Net:          hour.Created - hour.Destroyed,
+			CreatedBar:   hour.Created * 100 / busiest,
+			DestroyedBar: hour.Destroyed * 100 / busiest,
+		})
+	}
+	return view
+}
+
+// handleEconomyAPI reports gold created and destroyed by source since the
+// server started, with an hourly series for charting, to admins.
+func (p *PortalServer) handleEconomyAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsEconomy(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, p.economyView())
+}
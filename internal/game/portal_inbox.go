@@ -0,0 +1,224 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// portalLetterView is a personal letter as shown in a player's portal inbox.
+type portalLetterView struct {
+	ID          int      `json:"id"`
+	From        string   `json:"from"`
+	Body        string   `json:"body"`
+	SentAt      string   `json:"sent_at"`
+	Read        bool     `json:"read"`
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// portalTellView is an offline tell waiting for a player.
+type portalTellView struct {
+	From   string `json:"from"`
+	Body   string `json:"body"`
+	SentAt string `json:"sent_at"`
+}
+
+// portalUnread counts what a player has not read yet.
+type portalUnread struct {
+	Mail  int `json:"mail"`
+	Tells int `json:"tells"`
+}
+
+// portalLetters lists the personal letters addressed to player, newest first.
+func (p *PortalServer) portalLetters(player string) []portalLetterView {
+	views := []portalLetterView{}
+	mail := p.world.MailSystem()
+	if mail == nil || strings.TrimSpace(player) == "" {
+		return views
+	}
+	messages := mail.Messages(PersonalMailBoard)
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		if len(msg.Recipients) == 0 || !msg.AddressedTo(player) {
+			continue
+		}
+		view := portalLetterView{
+			ID:     msg.ID,
+			From:   msg.Author,
+			Body:   msg.Body,
+			SentAt: msg.CreatedAt.UTC().Format(time.RFC3339),
+			Read:   !msg.ReadAt.IsZero(),
+		}
+		for _, item := range msg.Attachments {
+			view.Attachments = append(view.Attachments, item.Name)
+		}
+		if msg.Gold > 0 {
+			view.Attachments = append(view.Attachments, fmt.Sprintf("%d gold", msg.Gold))
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func portalTellViews(tells []OfflineTell) []portalTellView {
+	sort.SliceStable(tells, func(i, j int) bool { return tells[i].CreatedAt.Before(tells[j].CreatedAt) })
+	views := make([]portalTellView, 0, len(tells))
+	for _, tell := range tells {
+		views = append(views, portalTellView{From: tell.Sender, Body: tell.Body, SentAt: tell.CreatedAt.UTC().Format(time.RFC3339)})
+	}
+	return views
+}
+
+// portalUnreadCounts reports player's unread letters and waiting tells.
+func (p *PortalServer) portalUnreadCounts(player string) portalUnread {
+	var unread portalUnread
+	if strings.TrimSpace(player) == "" {
+		return unread
+	}
+	if mail := p.world.MailSystem(); mail != nil {
+		unread.Mail = mail.UnreadCount(player)
+	}
+	unread.Tells = len(p.world.PendingOfflineTells(player))
+	return unread
+}
+
+// inboxSession authenticates a request to the player's own mail endpoints.
+func (p *PortalServer) inboxSession(w http.ResponseWriter, r *http.Request, method string) (portalSession, bool) {
+	if r.Method != method {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return portalSession{}, false
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return portalSession{}, false
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if strings.TrimSpace(session.Player) == "" {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return portalSession{}, false
+	}
+	return session, true
+}
+
+func (p *PortalServer) handleMailInboxAPI(w http.ResponseWriter, r *http.Request) {
+	session, ok := p.inboxSession(w, r, http.MethodGet)
+	if !ok {
+		return
+	}
+	writePortalJSON(w, http.StatusOK, p.portalLetters(session.Player))
+}
+
+func (p *PortalServer) handleMailUnreadAPI(w http.ResponseWriter, r *http.Request) {
+	session, ok := p.inboxSession(w, r, http.MethodGet)
+	if !ok {
+		return
+	}
+	writePortalJSON(w, http.StatusOK, p.portalUnreadCounts(session.Player))
+}
+
+func (p *PortalServer) handleMailReadAPI(w http.ResponseWriter, r *http.Request) {
+	session, ok := p.inboxSession(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+	var payload struct {
+		ID int `json:"id"`
+	}
+	if err := decodePortalJSON(r, &payload); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	mail := p.world.MailSystem()
+	if mail == nil {
+		http.Error(w, "mail is unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	if err := mail.MarkRead(payload.ID, session.Player); err != nil {
+		if errors.Is(err, ErrMailNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "unable to save", http.StatusInternalServerError)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, p.portalUnreadCounts(session.Player))
+}
+
+// handleMailSendAPI sends a letter from the session's player. A reply_to id
+// addresses the letter to the author of a letter the player received.
+func (p *PortalServer) handleMailSendAPI(w http.ResponseWriter, r *http.Request) {
+	session, ok := p.inboxSession(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+	var payload struct {
+		To      string `json:"to"`
+		ReplyTo int    `json:"reply_to"`
+		Body    string `json:"body"`
+	}
+	if err := decodePortalJSON(r, &payload); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	mail := p.world.MailSystem()
+	if mail == nil {
+		http.Error(w, "mail is unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	recipient := strings.TrimSpace(payload.To)
+	if payload.ReplyTo > 0 {
+		original, found := mail.Message(payload.ReplyTo)
+		if !found || original.Board != PersonalMailBoard || !original.AddressedTo(session.Player) {
+			http.NotFound(w, r)
+			return
+		}
+		recipient = original.Author
+	}
+	body := sanitizeInput(payload.Body)
+	msg, err := p.world.SendLetter(session.Player, recipient, body)
+	if err != nil {
+		if errors.Is(err, ErrMailboxFull) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writePortalJSON(w, http.StatusCreated, portalLetterView{
+		ID:     msg.ID,
+		From:   msg.Author,
+		Body:   msg.Body,
+		SentAt: msg.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// handleMailTellsAPI lists the offline tells waiting for the session's
+// player. POST marks them read, removing them and sending read receipts.
+func (p *PortalServer) handleMailTellsAPI(w http.ResponseWriter, r *http.Request) {
+	method := http.MethodGet
+	if r.Method == http.MethodPost {
+		method = http.MethodPost
+	}
+	session, ok := p.inboxSession(w, r, method)
+	if !ok {
+		return
+	}
+	if method == http.MethodPost {
+		writePortalJSON(w, http.StatusOK, portalTellViews(p.world.ReadOfflineTells(session.Player)))
+		return
+	}
+	writePortalJSON(w, http.StatusOK, portalTellViews(p.world.PendingOfflineTells(session.Player)))
+}
+
+// decodePortalJSON decodes a request body, rejecting unknown fields.
+func decodePortalJSON(r *http.Request, dest any) error {
+	defer r.Body.Close()
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(dest)
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func doInboxRequest(t *testing.T, handler http.HandlerFunc, sessionID, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	if sessionID != "" {
+		req.AddCookie(&http.Cookie{Name: portalCookieName, Value: sessionID})
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func TestPortalInboxMailAndTells(t *testing.T) {
+	dir := t.TempDir()
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Atrium", Description: "A bright hall.", Exits: map[string]Exit{}},
+	})
+	mail, err := NewMailSystem(filepath.Join(dir, "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem error: %v", err)
+	}
+	tells, err := NewTellSystem(filepath.Join(dir, "tells.json"))
+	if err != nil {
+		t.Fatalf("NewTellSystem error: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	world.AttachTellSystem(tells)
+	sage := &Player{Name: "Sage", Room: "start", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(sage)
+
+	letter, err := mail.Send("Sage", "Seeker", "Meet me in the atrium.", nil)
+	if err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	if _, err := tells.Queue("Sage", "Seeker", "Are you there?", time.Now()); err != nil {
+		t.Fatalf("Queue error: %v", err)
+	}
+
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions:   map[string]portalSession{"seeker": {Role: PortalRolePlayer, Player: "Seeker", Expires: time.Now().Add(time.Hour)}},
+		documents:  make(map[string]portalDocument),
+	}
+
+	if rec := doInboxRequest(t, portal.handleMailInboxAPI, "", http.MethodGet, "/api/mail/inbox", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("inbox without session status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	var unread portalUnread
+	rec := doInboxRequest(t, portal.handleMailUnreadAPI, "seeker", http.MethodGet, "/api/mail/unread", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &unread); err != nil {
+		t.Fatalf("decode unread: %v", err)
+	}
+	if unread.Mail != 1 || unread.Tells != 1 {
+		t.Fatalf("unread = %+v, want 1 letter and 1 tell", unread)
+	}
+
+	var letters []portalLetterView
+	rec = doInboxRequest(t, portal.handleMailInboxAPI, "seeker", http.MethodGet, "/api/mail/inbox", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &letters); err != nil {
+		t.Fatalf("decode inbox: %v", err)
+	}
+	if len(letters) != 1 || letters[0].ID != letter.ID || letters[0].From != "Sage" || letters[0].Read {
+		t.Fatalf("inbox = %+v, want the unread letter from Sage", letters)
+	}
+
+	rec = doInboxRequest(t, portal.handleMailReadAPI, "seeker", http.MethodPost, "/api/mail/read", map[string]int{"id": letter.ID})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mark read status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := mail.UnreadCount("Seeker"); got != 0 {
+		t.Fatalf("UnreadCount after read = %d, want 0", got)
+	}
+
+	rec = doInboxRequest(t, portal.handleMailSendAPI, "seeker", http.MethodPost, "/api/mail/send", map[string]any{"reply_to": letter.ID, "body": "On my way."})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("reply status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	replies := mail.MessagesForPlayer(PersonalMailBoard, "Sage")
+	if len(replies) != 1 || replies[0].Author != "Seeker" || replies[0].Body != "On my way." {
+		t.Fatalf("Sage's letters = %+v, want the reply from Seeker", replies)
+	}
+	if notice := strings.Join(drainOutput(sage.Output), ""); !strings.Contains(stripAnsi(notice), "A letter from Seeker arrives") {
+		t.Fatalf("Sage was not told about the reply: %q", notice)
+	}
+
+	rec = doInboxRequest(t, portal.handleMailSendAPI, "seeker", http.MethodPost, "/api/mail/send", map[string]any{"reply_to": replies[0].ID, "body": "Snooping."})
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("reply to someone else's letter status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	var pending []portalTellView
+	rec = doInboxRequest(t, portal.handleMailTellsAPI, "seeker", http.MethodGet, "/api/mail/tells", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &pending); err != nil {
+		t.Fatalf("decode tells: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Body != "Are you there?" {
+		t.Fatalf("tells = %+v, want the queued tell", pending)
+	}
+	if rec := doInboxRequest(t, portal.handleMailTellsAPI, "seeker", http.MethodPost, "/api/mail/tells", nil); rec.Code != http.StatusOK {
+		t.Fatalf("read tells status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if left := tells.PendingFor("Seeker"); len(left) != 0 {
+		t.Fatalf("tells still pending after reading: %+v", left)
+	}
+	if receipt := strings.Join(drainOutput(sage.Output), ""); !strings.Contains(stripAnsi(receipt), "Seeker") {
+		t.Fatalf("Sage did not get a read receipt: %q", receipt)
+	}
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// portalMailAuditLimit bounds how many recent messages the dashboard lists.
+const portalMailAuditLimit = 25
+
+type portalMailView struct {
+	ID            int      `json:"id"`
+	Board         string   `json:"board"`
+	Author        string   `json:"author"`
+	Recipients    []string `json:"recipients"`
+	Body          string   `json:"body"`
+	CreatedAt     string   `json:"created_at"`
+	Attachments   []string `json:"attachments"`
+	ClaimedBy     string   `json:"claimed_by,omitempty"`
+	ClaimedAt     string   `json:"claimed_at,omitempty"`
+	ForwardedFrom int      `json:"forwarded_from,omitempty"`
+}
+
+func roleAllowsMailAudit(role PortalRole) bool {
+	return role == PortalRoleModerator || role == PortalRoleAdmin
+}
+
+// mailAuditViews lists stored mail newest first. A positive limit restricts
+// the result to the most recent messages.
+func (p *PortalServer) mailAuditViews(limit int) []portalMailView {
+	mail := p.world.MailSystem()
+	if mail == nil {
+		return []portalMailView{}
+	}
+	messages := mail.AllMessages()
+	views := make([]portalMailView, 0, len(messages))
+	for i := len(messages) - 1; i >= 0; i-- {
+		if limit > 0 && len(views) >= limit {
+			break
+		}
+		msg := messages[i]
+		view := portalMailView{
+			ID:            msg.ID,
+			Board:         msg.Board,
+			Author:        msg.Author,
+			Recipients:    append([]string{}, msg.Recipients...),
+			Body:          msg.Body,
+			CreatedAt:     msg.CreatedAt.UTC().Format(time.RFC3339),
+			Attachments:   make([]string, 0, len(msg.Attachments)),
+			ClaimedBy:     msg.ClaimedBy,
+			ForwardedFrom: msg.ForwardedFrom,
+		}
+		for _, item := range msg.Attachments {
+			view.Attachments = append(view.Attachments, item.Name)
+		}
+		if msg.Gold > 0 {
+			view.Attachments = append(view.Attachments, fmt.Sprintf("%d gold", msg.Gold))
+		}
+		if !msg.ClaimedAt.IsZero() {
+			view.ClaimedAt = msg.ClaimedAt.UTC().Format(time.RFC3339)
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func (p *PortalServer) handleMailAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsMailAudit(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	writePortalJSON(w, http.StatusOK, p.mailAuditViews(0))
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type portalPetitionReplyView struct {
+	At     string `json:"at"`
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+type portalPetitionView struct {
+	ID         int                       `json:"id"`
+	Player     string                    `json:"player"`
+	Room       string                    `json:"room"`
+	Text       string                    `json:"text"`
+	CreatedAt  string                    `json:"created_at"`
+	Age        string                    `json:"age"`
+	SLA        string                    `json:"sla"`
+	ClaimedBy  string                    `json:"claimed_by,omitempty"`
+	Replies    []portalPetitionReplyView `json:"replies"`
+	ResolvedBy string                    `json:"resolved_by,omitempty"`
+	ResolvedAt string                    `json:"resolved_at,omitempty"`
+	Resolution string                    `json:"resolution,omitempty"`
+}
+
+func roleAllowsPetitions(role PortalRole) bool {
+	return role == PortalRoleModerator || role == PortalRoleAdmin
+}
+
+func newPortalPetitionView(petition Petition, now time.Time) portalPetitionView {
+	view := portalPetitionView{
+		ID:         petition.ID,
+		Player:     petition.Player,
+		Room:       string(petition.Room),
+		Text:       petition.Text,
+		CreatedAt:  petition.CreatedAt.UTC().Format(time.RFC3339),
+		Age:        petition.Age(now).Round(time.Minute).String(),
+		SLA:        string(petition.SLA(now)),
+		ClaimedBy:  petition.ClaimedBy,
+		Replies:    []portalPetitionReplyView{},
+		ResolvedBy: petition.ResolvedBy,
+		Resolution: petition.Resolution,
+	}
+	for _, reply := range petition.Replies {
+		view.Replies = append(view.Replies, portalPetitionReplyView{At: reply.At.UTC().Format(time.RFC3339), Author: reply.Author, Text: reply.Text})
+	}
+	if !petition.ResolvedAt.IsZero() {
+		view.ResolvedAt = petition.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// petitionViews lists the petitions matching filter, oldest first.
+func (p *PortalServer) petitionViews(filter PetitionFilter) []portalPetitionView {
+	views := []portalPetitionView{}
+	queue := p.world.PetitionSystem()
+	if queue == nil {
+		return views
+	}
+	now := time.Now()
+	for _, petition := range queue.Petitions(filter) {
+		views = append(views, newPortalPetitionView(petition, now))
+	}
+	return views
+}
+
+// petitionsSession authenticates a request to the petition queue endpoints.
+func (p *PortalServer) petitionsSession(w http.ResponseWriter, r *http.Request, method string) (portalSession, bool) {
+	if r.Method != method {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return portalSession{}, false
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return portalSession{}, false
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsPetitions(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return portalSession{}, false
+	}
+	return session, true
+}
+
+// handlePetitionsAPI lists player petitions for admins and moderators.
+// status=all includes resolved petitions, player narrows the listing to one
+// petitioner, and limit caps it.
+func (p *PortalServer) handlePetitionsAPI(w http.ResponseWriter, r *http.Request) {
+	if _, ok := p.petitionsSession(w, r, http.MethodGet); !ok {
+		return
+	}
+	query := r.URL.Query()
+	filter := PetitionFilter{Player: strings.TrimSpace(query.Get("player"))}
+	switch strings.ToLower(strings.TrimSpace(query.Get("status"))) {
+	case "", "open":
+	case "all":
+		filter.Resolved = true
+	default:
+		http.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+	writePortalJSON(w, http.StatusOK, p.petitionViews(filter))
+}
+
+// handlePetitionActionAPI claims, answers, or resolves a petition from the
+// portal queue. The action is the last element of the request path.
+func (p *PortalServer) handlePetitionActionAPI(w http.ResponseWriter, r *http.Request) {
+	session, ok := p.petitionsSession(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+	var payload struct {
+		ID   int    `json:"id"`
+		Text string `json:"text"`
+	}
+	if err := decodePortalJSON(r, &payload); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	staff := strings.TrimSpace(session.Player)
+	if staff == "" {
+		staff = string(session.Role)
+	}
+	var (
+		petition Petition
+		err      error
+		action   string
+	)
+	switch strings.TrimPrefix(r.URL.Path, "/api/petitions/") {
+	case "claim":
+		action = AuditPetitionClaim
+		petition, err = p.world.ClaimPetition(payload.ID, staff)
+	case "reply":
+		action = AuditPetitionReply
+		petition, err = p.world.ReplyToPetition(payload.ID, staff, payload.Text)
+	case "resolve":
+		action = AuditPetitionResolve
+		petition, err = p.world.ResolvePetition(payload.ID, staff, payload.Text)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	switch {
+	case errors.Is(err, ErrPetitionNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, ErrPetitionResolved):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p.world.Audit(AuditEntry{Actor: staff, Action: action, Target: "#" + strconv.Itoa(petition.ID), Detail: strings.TrimSpace(payload.Text)})
+	writePortalJSON(w, http.StatusOK, newPortalPetitionView(petition, time.Now()))
+}
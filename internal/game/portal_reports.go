@@ -0,0 +1,145 @@
+package game
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type portalReportView struct {
+	ID         int      `json:"id"`
+	Kind       string   `json:"kind"`
+	Reporter   string   `json:"reporter"`
+	Room       string   `json:"room"`
+	Text       string   `json:"text"`
+	Context    []string `json:"context"`
+	CreatedAt  string   `json:"created_at"`
+	ResolvedBy string   `json:"resolved_by,omitempty"`
+	ResolvedAt string   `json:"resolved_at,omitempty"`
+	Resolution string   `json:"resolution,omitempty"`
+}
+
+func roleAllowsReports(role PortalRole) bool {
+	return isStaffPortalRole(role)
+}
+
+func newPortalReportView(report Report) portalReportView {
+	view := portalReportView{
+		ID:         report.ID,
+		Kind:       string(report.Kind),
+		Reporter:   report.Reporter,
+		Room:       string(report.Room),
+		Text:       report.Text,
+		Context:    append([]string{}, report.Context...),
+		CreatedAt:  report.CreatedAt.UTC().Format(time.RFC3339),
+		ResolvedBy: report.ResolvedBy,
+		Resolution: report.Resolution,
+	}
+	if !report.ResolvedAt.IsZero() {
+		view.ResolvedAt = report.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// reportViews lists the reports matching filter, oldest first.
+func (p *PortalServer) reportViews(filter ReportFilter) []portalReportView {
+	views := []portalReportView{}
+	queue := p.world.ReportSystem()
+	if queue == nil {
+		return views
+	}
+	for _, report := range queue.Reports(filter) {
+		views = append(views, newPortalReportView(report))
+	}
+	return views
+}
+
+// reportsSession authenticates a request to the report queue endpoints.
+func (p *PortalServer) reportsSession(w http.ResponseWriter, r *http.Request, method string) (portalSession, bool) {
+	if r.Method != method {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return portalSession{}, false
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return portalSession{}, false
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	if !roleAllowsReports(session.Role) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return portalSession{}, false
+	}
+	return session, true
+}
+
+// handleReportsAPI lists player reports for staff. kind narrows the listing,
+// status=all includes resolved reports, and limit caps it.
+func (p *PortalServer) handleReportsAPI(w http.ResponseWriter, r *http.Request) {
+	if _, ok := p.reportsSession(w, r, http.MethodGet); !ok {
+		return
+	}
+	query := r.URL.Query()
+	var filter ReportFilter
+	if raw := strings.TrimSpace(query.Get("kind")); raw != "" {
+		kind, ok := ParseReportKind(raw)
+		if !ok {
+			http.Error(w, "invalid kind", http.StatusBadRequest)
+			return
+		}
+		filter.Kind = kind
+	}
+	switch strings.ToLower(strings.TrimSpace(query.Get("status"))) {
+	case "", "open":
+	case "all":
+		filter.Resolved = true
+	default:
+		http.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+	writePortalJSON(w, http.StatusOK, p.reportViews(filter))
+}
+
+// handleReportResolveAPI closes a report from the portal review queue.
+func (p *PortalServer) handleReportResolveAPI(w http.ResponseWriter, r *http.Request) {
+	session, ok := p.reportsSession(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+	var payload struct {
+		ID   int    `json:"id"`
+		Note string `json:"note"`
+	}
+	if err := decodePortalJSON(r, &payload); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	staff := strings.TrimSpace(session.Player)
+	if staff == "" {
+		staff = string(session.Role)
+	}
+	report, err := p.world.ResolveReport(payload.ID, staff, payload.Note)
+	switch {
+	case errors.Is(err, ErrReportNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, ErrReportResolved):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	p.world.Audit(AuditEntry{Actor: staff, Action: AuditReportResolve, Target: "#" + strconv.Itoa(report.ID), Detail: report.Resolution})
+	writePortalJSON(w, http.StatusOK, newPortalReportView(report))
+}
@@ -20,9 +20,9 @@ func TestPortalLinkSingleUse(t *testing.T) {
 	cert := filepath.Join(dir, "portal-cert.pem")
 	key := filepath.Join(dir, "portal-key.pem")
 	world := NewWorldWithRooms(map[RoomID]*Room{
-		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]RoomID{}},
+		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]Exit{}},
 	})
-	player := &Player{Name: "Builder", Room: "start", Alive: true, Output: make(chan string, 1)}
+	player := &Player{Name: "Builder", Room: "start", Alive: true, Output: NewOutputQueue()}
 	player.IsBuilder = true
 	world.AddPlayerForTest(player)
 
@@ -112,9 +112,9 @@ func TestPortalDocumentsAPI(t *testing.T) {
 	cert := filepath.Join(dir, "portal-cert.pem")
 	key := filepath.Join(dir, "portal-key.pem")
 	world := NewWorldWithRooms(map[RoomID]*Room{
-		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]RoomID{}},
+		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]Exit{}},
 	})
-	player := &Player{Name: "Builder", Room: "start", Alive: true, Output: make(chan string, 1)}
+	player := &Player{Name: "Builder", Room: "start", Alive: true, Output: NewOutputQueue()}
 	player.IsBuilder = true
 	world.AddPlayerForTest(player)
 
@@ -250,9 +250,9 @@ func TestPortalScriptFormatting(t *testing.T) {
 	cert := filepath.Join(dir, "portal-cert.pem")
 	key := filepath.Join(dir, "portal-key.pem")
 	world := NewWorldWithRooms(map[RoomID]*Room{
-		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]RoomID{}},
+		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]Exit{}},
 	})
-	builder := &Player{Name: "Builder", Room: "start", Alive: true, Output: make(chan string, 1)}
+	builder := &Player{Name: "Builder", Room: "start", Alive: true, Output: NewOutputQueue()}
 	builder.IsBuilder = true
 	world.AddPlayerForTest(builder)
 
@@ -377,12 +377,12 @@ func TestPortalPlayerAccessNotesOnly(t *testing.T) {
 	cert := filepath.Join(dir, "portal-cert.pem")
 	key := filepath.Join(dir, "portal-key.pem")
 	world := NewWorldWithRooms(map[RoomID]*Room{
-		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]RoomID{}},
+		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]Exit{}},
 	})
-	builder := &Player{Name: "Builder", Room: "start", Alive: true, Output: make(chan string, 1)}
+	builder := &Player{Name: "Builder", Room: "start", Alive: true, Output: NewOutputQueue()}
 	builder.IsBuilder = true
 	world.AddPlayerForTest(builder)
-	player := &Player{Name: "Seeker", Room: "start", Alive: true, Output: make(chan string, 1)}
+	player := &Player{Name: "Seeker", Room: "start", Alive: true, Output: NewOutputQueue()}
 	world.AddPlayerForTest(player)
 
 	cfg := PortalConfig{Addr: "127.0.0.1:0", CertFile: cert, KeyFile: key}
@@ -550,9 +550,9 @@ func TestPortalDocumentLimit(t *testing.T) {
 	cert := filepath.Join(dir, "portal-cert.pem")
 	key := filepath.Join(dir, "portal-key.pem")
 	world := NewWorldWithRooms(map[RoomID]*Room{
-		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]RoomID{}},
+		"start": {ID: "start", Title: "Atrium", Description: "", Exits: map[string]Exit{}},
 	})
-	builder := &Player{Name: "Builder", Room: "start", Alive: true, Output: make(chan string, 1)}
+	builder := &Player{Name: "Builder", Room: "start", Alive: true, Output: NewOutputQueue()}
 	builder.IsBuilder = true
 	world.AddPlayerForTest(builder)
 
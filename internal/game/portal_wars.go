@@ -0,0 +1,96 @@
+package game
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+type portalClanScoreView struct {
+	Clan    string `json:"clan"`
+	Members int    `json:"members"`
+	Points  int    `json:"points"`
+	Holds   int    `json:"holds"`
+}
+
+type portalWarView struct {
+	Attacker      string `json:"attacker"`
+	Defender      string `json:"defender"`
+	AttackerScore int    `json:"attacker_score"`
+	DefenderScore int    `json:"defender_score"`
+	Declared      string `json:"declared"`
+	Ends          string `json:"ends"`
+	Active        bool   `json:"active"`
+	TruceUntil    string `json:"truce_until,omitempty"`
+	Winner        string `json:"winner,omitempty"`
+}
+
+type portalHoldingView struct {
+	Room  string `json:"room"`
+	Title string `json:"title"`
+	Clan  string `json:"clan"`
+	Since string `json:"since"`
+}
+
+type portalWarsView struct {
+	Clans    []portalClanScoreView `json:"clans"`
+	Wars     []portalWarView       `json:"wars"`
+	Holdings []portalHoldingView   `json:"holdings"`
+}
+
+// warsView reports clan points, wars, and capture points for the
+// scoreboard.
+func (p *PortalServer) warsView() portalWarsView {
+	view := portalWarsView{Clans: []portalClanScoreView{}, Wars: []portalWarView{}, Holdings: []portalHoldingView{}}
+	clans := p.world.ClanSystem()
+	if clans == nil {
+		return view
+	}
+	holdings := clans.Holdings()
+	holds := make(map[string]int)
+	for id, hold := range holdings {
+		holds[hold.Clan]++
+		title := string(id)
+		if room, ok := p.world.GetRoom(id); ok {
+			title = room.Title
+		}
+		view.Holdings = append(view.Holdings, portalHoldingView{Room: string(id), Title: title, Clan: hold.Clan, Since: hold.Since.UTC().Format(time.RFC3339)})
+	}
+	sort.Slice(view.Holdings, func(i, j int) bool { return view.Holdings[i].Room < view.Holdings[j].Room })
+	for _, clan := range clans.Clans() {
+		view.Clans = append(view.Clans, portalClanScoreView{Clan: clan.Name, Members: len(clan.Members), Points: clan.Points, Holds: holds[clan.Name]})
+	}
+	sort.SliceStable(view.Clans, func(i, j int) bool { return view.Clans[i].Points > view.Clans[j].Points })
+	for _, war := range clans.Wars() {
+		entry := portalWarView{
+			Attacker:      war.Attacker,
+			Defender:      war.Defender,
+			AttackerScore: war.AttackerScore,
+			DefenderScore: war.DefenderScore,
+			Declared:      war.Declared.UTC().Format(time.RFC3339),
+			Ends:          war.Ends.UTC().Format(time.RFC3339),
+			Active:        war.Active(),
+		}
+		if !war.Active() {
+			entry.TruceUntil = war.TruceUntil().UTC().Format(time.RFC3339)
+			entry.Winner = war.Winner()
+		}
+		view.Wars = append(view.Wars, entry)
+	}
+	return view
+}
+
+// handleWarsAPI reports the clan war scoreboard to any signed-in player.
+func (p *PortalServer) handleWarsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	session, id, ok := p.sessionForRequest(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p.setSessionCookie(w, id, session.Expires)
+	writePortalJSON(w, http.StatusOK, p.warsView())
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"slices"
+	"sync"
+)
+
+// presenceShards is the number of locks the room presence index is split
+// across. Rooms hash onto a shard, so broadcasts to different rooms rarely
+// wait on each other.
+const presenceShards = 64
+
+// roomPresence indexes online players by the room they stand in so room
+// broadcasts do not need the world lock. Writers hold w.mu and then the
+// shard lock; readers take only the shard lock. The lock order is w.mu,
+// then a shard lock, then p.linkMu.
+type roomPresence struct {
+	shards [presenceShards]presenceShard
+}
+
+type presenceShard struct {
+	mu    sync.RWMutex
+	rooms map[RoomID][]*Player
+}
+
+// shard returns the shard that holds room, using FNV-1a so the lookup does
+// not allocate.
+func (rp *roomPresence) shard(room RoomID) *presenceShard {
+	hash := uint32(2166136261)
+	for i := 0; i < len(room); i++ {
+		hash ^= uint32(room[i])
+		hash *= 16777619
+	}
+	return &rp.shards[hash%presenceShards]
+}
+
+func (rp *roomPresence) add(room RoomID, p *Player) {
+	s := rp.shard(room)
+	s.mu.Lock()
+	if s.rooms == nil {
+		s.rooms = make(map[RoomID][]*Player)
+	}
+	s.rooms[room] = append(s.rooms[room], p)
+	s.mu.Unlock()
+}
+
+func (rp *roomPresence) remove(room RoomID, p *Player) {
+	s := rp.shard(room)
+	s.mu.Lock()
+	occupants := s.rooms[room]
+	for i, other := range occupants {
+		if other == p {
+			// Copy rather than shift in place so a slice handed out earlier
+			// is never rewritten underneath its reader.
+			occupants = append(occupants[:i:i], occupants[i+1:]...)
+			break
+		}
+	}
+	if len(occupants) == 0 {
+		delete(s.rooms, room)
+	} else {
+		s.rooms[room] = occupants
+	}
+	s.mu.Unlock()
+}
+
+// send delivers msg to everyone listed in room other than those in except.
+func (rp *roomPresence) send(room RoomID, msg string, except []*Player) {
+	s := rp.shard(room)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range s.rooms[room] {
+		if !slices.Contains(except, p) {
+			p.Output.Send(msg)
+		}
+	}
+}
+
+// syncPresenceLocked lists p under p.Room while they are alive and
+// registered, and unlists them otherwise. Call it after changing a player's
+// room, liveness or registration. p.Output may only be replaced while p is
+// unlisted. The caller must hold w.mu.
+func (w *World) syncPresenceLocked(p *Player) {
+	var want RoomID
+	if p.Alive && w.players[p.Name] == p {
+		want = p.Room
+	}
+	if want == p.listedIn {
+		return
+	}
+	if p.listedIn != "" {
+		w.presence.remove(p.listedIn, p)
+	}
+	if want != "" {
+		w.presence.add(want, p)
+	}
+	p.listedIn = want
+}
+
+// markOffline flags p as leaving the world so room broadcasts skip them
+// while the logout finishes.
+func (w *World) markOffline(p *Player) {
+	w.mu.Lock()
+	p.Alive = false
+	w.syncPresenceLocked(p)
+	w.mu.Unlock()
+}
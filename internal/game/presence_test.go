@@ -0,0 +1,46 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBroadcastToRoomFollowsPresence(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Square", Exits: map[string]Exit{"north": {To: "hall"}}},
+		"hall":    {ID: "hall", Title: "Hall", Exits: map[string]Exit{"south": {To: StartRoom}}},
+	})
+	walker := &Player{Name: "Walker", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(walker)
+	watcher := &Player{Name: "Watcher", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(watcher)
+
+	world.BroadcastToRoom(StartRoom, "a bell", watcher)
+	if got := strings.Join(drainOutput(walker.Output), ""); got != "a bell" {
+		t.Fatalf("walker heard %q, want the bell", got)
+	}
+	if got := drainOutput(watcher.Output); len(got) != 0 {
+		t.Fatalf("excluded player heard %q", got)
+	}
+
+	if _, err := world.Move(walker, "north"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	drainOutput(walker.Output)
+	drainOutput(watcher.Output)
+	world.BroadcastToRoom(StartRoom, "a gong")
+	if got := drainOutput(walker.Output); len(got) != 0 {
+		t.Fatalf("walker heard the room they left: %q", got)
+	}
+	world.BroadcastToRoom("hall", "a drum")
+	if got := strings.Join(drainOutput(walker.Output), ""); got != "a drum" {
+		t.Fatalf("walker heard %q in the hall, want the drum", got)
+	}
+
+	drainOutput(watcher.Output)
+	world.markOffline(watcher)
+	world.BroadcastToRoom(StartRoom, "a horn")
+	if got := drainOutput(watcher.Output); len(got) != 0 {
+		t.Fatalf("player logging out heard %q", got)
+	}
+}
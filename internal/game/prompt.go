@@ -0,0 +1,172 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxPromptLength caps the length of a custom prompt format.
+const MaxPromptLength = 120
+
+// PromptTokens documents the substitutions available in prompt formats.
+var PromptTokens = []struct {
+	Token       string
+	Description string
+}{
+	{"%h", "health"},
+	{"%H", "maximum health"},
+	{"%m", "mana"},
+	{"%M", "maximum mana"},
+	{"%v", "moves"},
+	{"%V", "maximum moves"},
+	{"%l", "level"},
+	{"%x", "experience needed to level"},
+	{"%g", "gold"},
+	{"%r", "room name"},
+	{"%t", "time of day"},
+	{"%e", "who you are fighting"},
+	{"%%", "a literal percent sign"},
+}
+
+func promptToken(b byte) bool {
+	for _, token := range PromptTokens {
+		if token.Token[1] == b {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePromptFormat checks a custom prompt for length and unknown tokens,
+// returning the trimmed format.
+func ValidatePromptFormat(format string) (string, error) {
+	format = strings.TrimSpace(format)
+	if format == "" {
+		return "", fmt.Errorf("prompt format must not be empty")
+	}
+	if len(format) > MaxPromptLength {
+		return "", fmt.Errorf("prompt format must be at most %d characters", MaxPromptLength)
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] < ' ' || format[i] == 0x7f {
+			return "", fmt.Errorf("prompt format must not contain control characters")
+		}
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 >= len(format) || !promptToken(format[i+1]) {
+			return "", fmt.Errorf("unknown prompt token at %q", format[i:])
+		}
+		i++
+	}
+	return format, nil
+}
+
+// SetPromptFormat stores the player's prompt format, or the one used while
+// fighting when combat is true. An empty format restores the default.
+func (w *World) SetPromptFormat(p *Player, format string, combat bool) error {
+	if format != "" {
+		var err error
+		if format, err = ValidatePromptFormat(format); err != nil {
+			return err
+		}
+	}
+	w.mu.Lock()
+	if combat {
+		p.CombatPrompt = format
+	} else {
+		p.PromptFormat = format
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return nil
+}
+
+// PromptFormats reports the player's normal and combat prompt formats.
+func (w *World) PromptFormats(p *Player) (string, string) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.PromptFormat, p.CombatPrompt
+}
+
+// combatFoe names who p is fighting, or "" when p is not in combat.
+func (w *World) combatFoe(p *Player) string {
+	combat := w.combatIn(p.Room)
+	if combat == nil {
+		return ""
+	}
+	combat.mu.Lock()
+	defer combat.mu.Unlock()
+	if target, ok := combat.playerTargets[p.Name]; ok {
+		return target.name
+	}
+	for name, target := range combat.npcTargets {
+		if target.kind == combatTargetPlayer && target.name == p.Name {
+			return name
+		}
+	}
+	return ""
+}
+
+// renderPrompt expands the player's custom prompt, preferring the combat
+// variant while they are fighting. It reports false when the player has no
+// custom prompt that applies.
+func (w *World) renderPrompt(p *Player) (string, bool) {
+	format, combatFormat := w.PromptFormats(p)
+	foe := w.combatFoe(p)
+	if foe != "" && combatFormat != "" {
+		format = combatFormat
+	}
+	if format == "" {
+		return "", false
+	}
+	var out strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 >= len(format) {
+			out.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'h':
+			out.WriteString(strconv.Itoa(p.Health))
+		case 'H':
+			out.WriteString(strconv.Itoa(p.MaxHealth))
+		case 'm':
+			out.WriteString(strconv.Itoa(p.Mana))
+		case 'M':
+			out.WriteString(strconv.Itoa(p.MaxMana))
+		case 'v':
+			out.WriteString(strconv.Itoa(p.Moves))
+		case 'V':
+			out.WriteString(strconv.Itoa(p.MaxMoves))
+		case 'l':
+			out.WriteString(strconv.Itoa(p.Level))
+		case 'x':
+			remaining := experienceForLevel(p.Level+1) - p.Experience
+			if remaining < 0 {
+				remaining = 0
+			}
+			out.WriteString(strconv.Itoa(remaining))
+		case 'g':
+			out.WriteString(strconv.Itoa(p.Gold))
+		case 'r':
+			if room, ok := w.GetRoom(p.Room); ok {
+				out.WriteString(room.Title)
+			}
+		case 't':
+			hour, _ := w.Clock()
+			out.WriteString(FormatClock(hour))
+		case 'e':
+			out.WriteString(foe)
+		case '%':
+			out.WriteByte('%')
+		default:
+			out.WriteByte('%')
+			out.WriteByte(format[i])
+		}
+	}
+	return out.String(), true
+}
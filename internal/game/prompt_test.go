@@ -0,0 +1,52 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCustomPromptExpandsTokens(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Great Hall", Exits: map[string]Exit{}}})
+	world.SetClock(13)
+	p := &Player{Name: "Hero", Room: "hall", Output: NewOutputQueue(), Alive: true, Level: 2, Experience: 150}
+	world.AddPlayerForTest(p)
+	p.EnsureStats()
+
+	if err := world.SetPromptFormat(p, "%h/%Hhp %x tnl <%r @ %t> 100%%", false); err != nil {
+		t.Fatalf("SetPromptFormat error: %v", err)
+	}
+	got := stripAnsi(Prompt(p))
+	want := "\r\n60/60hp 50 tnl <Great Hall @ 1 pm> 100% "
+	if got != want {
+		t.Fatalf("Prompt = %q, want %q", got, want)
+	}
+
+	if err := world.SetPromptFormat(p, "%h [%e] >", true); err != nil {
+		t.Fatalf("SetPromptFormat combat error: %v", err)
+	}
+	if got := stripAnsi(Prompt(p)); !strings.Contains(got, "Great Hall") {
+		t.Fatalf("combat prompt should only apply while fighting, got %q", got)
+	}
+	world.ensureCombat("hall").addPlayer(p.Name, combatTarget{kind: combatTargetNPC, name: "Rat"})
+	if got := stripAnsi(Prompt(p)); got != "\r\n60 [Rat] > " {
+		t.Fatalf("combat Prompt = %q", got)
+	}
+
+	if err := world.SetPromptFormat(p, "", false); err != nil {
+		t.Fatalf("reset error: %v", err)
+	}
+	if err := world.SetPromptFormat(p, "", true); err != nil {
+		t.Fatalf("reset combat error: %v", err)
+	}
+	if got := stripAnsi(Prompt(p)); !strings.HasPrefix(got, "\r\n[L02 HP") {
+		t.Fatalf("reset prompt = %q, want the default", got)
+	}
+}
+
+func TestValidatePromptFormatRejectsBadInput(t *testing.T) {
+	for _, format := range []string{"%q", "trailing %", "bell\a", strings.Repeat("x", MaxPromptLength+1)} {
+		if _, err := ValidatePromptFormat(format); err == nil {
+			t.Fatalf("ValidatePromptFormat(%q) succeeded, want error", format)
+		}
+	}
+}
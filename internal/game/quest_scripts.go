@@ -0,0 +1,181 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNoSuchObjective indicates a script tried to advance an objective the
+// quest does not have, or one that is already finished.
+var ErrNoSuchObjective = errors.New("no such objective")
+
+// speakingPlayer resolves the online player behind a script speaker.
+func (w *World) speakingPlayer(speaker *NPCSpeaker) *Player {
+	if w == nil || speaker == nil || strings.TrimSpace(speaker.Name) == "" {
+		return nil
+	}
+	p, ok := w.FindPlayer(speaker.Name)
+	if !ok {
+		return nil
+	}
+	return p
+}
+
+// addQuestHelpers exposes quest state for player to an NPC or room script.
+// Quest hooks also receive the id and name of the quest that fired them.
+func addQuestHelpers(payload map[string]any, w *World, p *Player, quest *Quest) {
+	if w == nil || p == nil {
+		return
+	}
+	if quest != nil {
+		payload["quest"] = quest.ID
+		payload["quest_name"] = quest.Name
+	}
+	payload["has_quest"] = func(id string) bool {
+		return w.HasActiveQuest(p, id)
+	}
+	payload["advance_objective"] = func(id, target string) bool {
+		update, err := w.AdvanceQuestObjective(p, id, target)
+		if err != nil {
+			return false
+		}
+		if p.Output != nil {
+			for _, msg := range FormatQuestUpdates([]QuestProgressUpdate{update}) {
+				p.Output.Send(Ansi("\r\n" + msg))
+			}
+		}
+		return true
+	}
+	payload["grant_item"] = func(name, description string) {
+		item, ok := w.GrantItem(p, Item{Name: name, Description: description})
+		if ok && p.Output != nil {
+			p.Output.Send(Ansi(fmt.Sprintf("\r\nYou receive %s.", HighlightItemName(item.Name))))
+		}
+	}
+}
+
+// HasActiveQuest reports whether p is on questID and has neither finished
+// it nor run out of time.
+func (w *World) HasActiveQuest(p *Player, questID string) bool {
+	trimmed := strings.ToLower(strings.TrimSpace(questID))
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	progress, ok := p.QuestLog[trimmed]
+	return ok && !progress.Completed && !progress.expired(time.Now())
+}
+
+// AdvanceQuestObjective credits one step of an objective on p's active quest.
+// target names an NPC to count as slain, a room to mark explored, or an item
+// to count as delivered, letting scripts complete objectives the
+// declarative quest format cannot express.
+func (w *World) AdvanceQuestObjective(p *Player, questID, target string) (QuestProgressUpdate, error) {
+	update, err := w.advanceQuestObjective(p, questID, target)
+	if err == nil {
+		w.PersistPlayer(p)
+	}
+	return update, err
+}
+
+func (w *World) advanceQuestObjective(p *Player, questID, target string) (QuestProgressUpdate, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(questID))
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return QuestProgressUpdate{}, fmt.Errorf("objective must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	quest, ok := w.quests[trimmed]
+	progress, active := p.QuestLog[trimmed]
+	if !ok || !active || progress.Completed || progress.expired(time.Now()) {
+		return QuestProgressUpdate{}, fmt.Errorf("you are not on that quest")
+	}
+	if kills, changed := progress.incrementKill(quest, target); changed && len(kills) > 0 {
+		return QuestProgressUpdate{
+			Quest:              quest,
+			KillProgress:       kills,
+			ObjectivesComplete: progress.objectivesComplete(quest),
+		}, nil
+	}
+	for _, room := range quest.VisitRooms {
+		key := strings.ToLower(room)
+		if !strings.EqualFold(room, target) || progress.Visited[key] {
+			continue
+		}
+		if progress.Visited == nil {
+			progress.Visited = make(map[string]bool)
+		}
+		progress.Visited[key] = true
+		id := RoomID(room)
+		return QuestProgressUpdate{
+			Quest:              quest,
+			Visits:             []QuestVisitProgress{{Room: id, Title: w.roomTitleLocked(id), Visited: true}},
+			ObjectivesComplete: progress.objectivesComplete(quest),
+		}, nil
+	}
+	for _, req := range quest.DeliverItems {
+		key := deliveryKey(req.Item, req.NPC)
+		if !strings.EqualFold(req.Item, target) || progress.Delivered[key] >= req.Count {
+			continue
+		}
+		if progress.Delivered == nil {
+			progress.Delivered = make(map[string]int)
+		}
+		progress.Delivered[key]++
+		return QuestProgressUpdate{
+			Quest:              quest,
+			Deliveries:         []QuestDeliveryProgress{{Item: req.Item, NPC: req.NPC, Current: progress.Delivered[key], Required: req.Count}},
+			ObjectivesComplete: progress.objectivesComplete(quest),
+		}, nil
+	}
+	return QuestProgressUpdate{}, fmt.Errorf("%w %q on %s", ErrNoSuchObjective, target, quest.Name)
+}
+
+// GrantItem places a new item in p's inventory. It reports false when the
+// item has no name or p is no longer online.
+func (w *World) GrantItem(p *Player, item Item) (Item, bool) {
+	item.Name = strings.TrimSpace(item.Name)
+	item.Description = strings.TrimSpace(item.Description)
+	if item.Name == "" {
+		return Item{}, false
+	}
+	w.mu.Lock()
+	if stored, ok := w.players[p.Name]; !ok || stored != p {
+		w.mu.Unlock()
+		return Item{}, false
+	}
+	p.Inventory = append(p.Inventory, item)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return item, true
+}
+
+// TriggerQuestAccept runs the OnQuestAccept hooks of the quest giver and the
+// room where p accepted quest.
+func (w *World) TriggerQuestAccept(p *Player, quest *Quest) {
+	w.triggerQuestHook(p, quest, "OnQuestAccept")
+}
+
+// TriggerQuestComplete runs the OnQuestComplete hooks of the NPC that took
+// the turn-in and the room where p completed quest.
+func (w *World) TriggerQuestComplete(p *Player, quest *Quest) {
+	w.triggerQuestHook(p, quest, "OnQuestComplete")
+}
+
+func (w *World) triggerQuestHook(p *Player, quest *Quest, hook string) {
+	if w == nil || w.scripts == nil || p == nil || quest == nil {
+		return
+	}
+	name := quest.Giver
+	if hook == "OnQuestComplete" && strings.TrimSpace(quest.TurnIn) != "" {
+		name = quest.TurnIn
+	}
+	if npc, ok := w.FindRoomNPC(p.Room, name); ok && strings.TrimSpace(npc.Script) != "" {
+		w.scripts.callNPCOnQuest(w, *npc, p, quest, hook)
+	}
+	if room, ok := w.GetRoom(p.Room); ok {
+		w.scripts.callRoomOnQuest(w, room, p, quest, hook)
+	}
+}
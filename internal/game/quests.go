@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -15,16 +17,36 @@ const questsFileName = "quests.json"
 
 // Quest describes a structured task offered by NPCs.
 type Quest struct {
-	ID                string                 `json:"id"`
-	Name              string                 `json:"name"`
-	Description       string                 `json:"description"`
-	Giver             string                 `json:"giver"`
-	TurnIn            string                 `json:"turn_in,omitempty"`
-	RequiredKills     []QuestKillRequirement `json:"required_kills,omitempty"`
-	RequiredItems     []QuestItemRequirement `json:"required_items,omitempty"`
-	RewardXP          int                    `json:"reward_xp,omitempty"`
-	RewardItems       []Item                 `json:"reward_items,omitempty"`
-	CompletionMessage string                 `json:"completion_message,omitempty"`
+	ID                string                     `json:"id"`
+	Name              string                     `json:"name"`
+	Description       string                     `json:"description"`
+	Giver             string                     `json:"giver"`
+	TurnIn            string                     `json:"turn_in,omitempty"`
+	Prerequisites     []string                   `json:"prerequisites,omitempty"`
+	MinLevel          int                        `json:"min_level,omitempty"`
+	Repeatable        bool                       `json:"repeatable,omitempty"`
+	Daily             bool                       `json:"daily,omitempty"`
+	CooldownMinutes   int                        `json:"cooldown_minutes,omitempty"`
+	TimeLimitMinutes  int                        `json:"time_limit_minutes,omitempty"`
+	RequiredKills     []QuestKillRequirement     `json:"required_kills,omitempty"`
+	RequiredItems     []QuestItemRequirement     `json:"required_items,omitempty"`
+	VisitRooms        []string                   `json:"visit_rooms,omitempty"`
+	DeliverItems      []QuestDeliveryRequirement `json:"deliver_item_to_npc,omitempty"`
+	RewardXP          int                        `json:"reward_xp,omitempty"`
+	RewardItems       []Item                     `json:"reward_items,omitempty"`
+	Reputation        map[string]int             `json:"reputation,omitempty"`
+	CompletionMessage string                     `json:"completion_message,omitempty"`
+}
+
+// Cooldown returns how long a repeatable quest rests after completion.
+func (q *Quest) Cooldown() time.Duration {
+	return time.Duration(q.CooldownMinutes) * time.Minute
+}
+
+// TimeLimit returns how long the player has to finish the quest once
+// accepted, or zero when it is untimed.
+func (q *Quest) TimeLimit() time.Duration {
+	return time.Duration(q.TimeLimitMinutes) * time.Minute
 }
 
 // QuestKillRequirement tracks how many times a specific NPC must be defeated.
@@ -39,6 +61,17 @@ type QuestItemRequirement struct {
 	Count int    `json:"count,omitempty"`
 }
 
+// QuestDeliveryRequirement asks the player to give an item to an NPC.
+type QuestDeliveryRequirement struct {
+	Item  string `json:"item"`
+	NPC   string `json:"npc"`
+	Count int    `json:"count,omitempty"`
+}
+
+func deliveryKey(item, npc string) string {
+	return strings.ToLower(item) + "|" + strings.ToLower(npc)
+}
+
 type questFile struct {
 	Quests []Quest `json:"quests"`
 }
@@ -75,9 +108,55 @@ func loadQuestData(areasPath string) (map[string]*Quest, error) {
 	if len(quests) == 0 {
 		return nil, nil
 	}
+	if err := validateQuestChains(quests); err != nil {
+		return nil, err
+	}
 	return quests, nil
 }
 
+// validateQuestChains checks that every prerequisite names a known quest and
+// that no chain loops back on itself.
+func validateQuestChains(quests map[string]*Quest) error {
+	ids := make([]string, 0, len(quests))
+	for id, quest := range quests {
+		ids = append(ids, id)
+		for _, prereq := range quest.Prerequisites {
+			if _, ok := quests[strings.ToLower(prereq)]; !ok {
+				return fmt.Errorf("quest %s: unknown prerequisite %q", quest.ID, prereq)
+			}
+		}
+	}
+	sort.Strings(ids)
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(quests))
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("quest %s: prerequisite chain loops back on itself", quests[id].ID)
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, prereq := range quests[id].Prerequisites {
+			if err := visit(strings.ToLower(prereq)); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+	for _, id := range ids {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func normalizeQuest(q *Quest) {
 	if q == nil {
 		return
@@ -90,6 +169,25 @@ func normalizeQuest(q *Quest) {
 	if q.TurnIn == "" {
 		q.TurnIn = q.Giver
 	}
+	prereqs := q.Prerequisites[:0]
+	for _, prereq := range q.Prerequisites {
+		if trimmed := strings.TrimSpace(prereq); trimmed != "" {
+			prereqs = append(prereqs, trimmed)
+		}
+	}
+	q.Prerequisites = prereqs
+	if q.MinLevel < 0 {
+		q.MinLevel = 0
+	}
+	if q.Daily {
+		q.Repeatable = true
+	}
+	if q.TimeLimitMinutes < 0 {
+		q.TimeLimitMinutes = 0
+	}
+	if q.CooldownMinutes < 0 {
+		q.CooldownMinutes = 0
+	}
 	for i := range q.RequiredKills {
 		q.RequiredKills[i].NPC = strings.TrimSpace(q.RequiredKills[i].NPC)
 		if q.RequiredKills[i].Count <= 0 {
@@ -102,6 +200,20 @@ func normalizeQuest(q *Quest) {
 			q.RequiredItems[i].Count = 1
 		}
 	}
+	rooms := q.VisitRooms[:0]
+	for _, room := range q.VisitRooms {
+		if trimmed := strings.TrimSpace(room); trimmed != "" {
+			rooms = append(rooms, trimmed)
+		}
+	}
+	q.VisitRooms = rooms
+	for i := range q.DeliverItems {
+		q.DeliverItems[i].Item = strings.TrimSpace(q.DeliverItems[i].Item)
+		q.DeliverItems[i].NPC = strings.TrimSpace(q.DeliverItems[i].NPC)
+		if q.DeliverItems[i].Count <= 0 {
+			q.DeliverItems[i].Count = 1
+		}
+	}
 	for i := range q.RewardItems {
 		q.RewardItems[i].Name = strings.TrimSpace(q.RewardItems[i].Name)
 		q.RewardItems[i].Description = strings.TrimSpace(q.RewardItems[i].Description)
@@ -134,11 +246,76 @@ func indexQuestsByNPC(quests map[string]*Quest) map[string][]*Quest {
 
 // QuestProgress captures in-progress quest objectives.
 type QuestProgress struct {
-	QuestID     string
-	AcceptedAt  time.Time
-	CompletedAt time.Time
-	Completed   bool
-	KillCounts  map[string]int
+	QuestID     string          `json:"quest_id"`
+	AcceptedAt  time.Time       `json:"accepted_at"`
+	CompletedAt time.Time       `json:"completed_at,omitempty"`
+	Completed   bool            `json:"completed,omitempty"`
+	Completions int             `json:"completions,omitempty"`
+	Deadline    time.Time       `json:"deadline,omitempty"`
+	KillCounts  map[string]int  `json:"kill_counts,omitempty"`
+	Visited     map[string]bool `json:"visited,omitempty"`
+	Delivered   map[string]int  `json:"delivered,omitempty"`
+}
+
+// cloneQuestLog deep-copies a quest log so profiles can be persisted
+// without sharing maps with the live player.
+func cloneQuestLog(log map[string]*QuestProgress) map[string]*QuestProgress {
+	if len(log) == 0 {
+		return nil
+	}
+	out := make(map[string]*QuestProgress, len(log))
+	for id, progress := range log {
+		if progress == nil {
+			continue
+		}
+		copied := *progress
+		copied.KillCounts = maps.Clone(progress.KillCounts)
+		copied.Visited = maps.Clone(progress.Visited)
+		copied.Delivered = maps.Clone(progress.Delivered)
+		out[id] = &copied
+	}
+	return out
+}
+
+// encodeQuestLog flattens a quest log into a stable list for storage.
+func encodeQuestLog(log map[string]*QuestProgress) []QuestProgress {
+	if len(log) == 0 {
+		return nil
+	}
+	encoded := make([]QuestProgress, 0, len(log))
+	for id, progress := range log {
+		if progress == nil {
+			continue
+		}
+		entry := *progress
+		entry.QuestID = id
+		encoded = append(encoded, entry)
+	}
+	sort.Slice(encoded, func(i, j int) bool {
+		return encoded[i].QuestID < encoded[j].QuestID
+	})
+	return encoded
+}
+
+// decodeQuestLog rebuilds a quest log from stored entries.
+func decodeQuestLog(entries []QuestProgress) map[string]*QuestProgress {
+	if len(entries) == 0 {
+		return nil
+	}
+	log := make(map[string]*QuestProgress, len(entries))
+	for i := range entries {
+		id := strings.ToLower(strings.TrimSpace(entries[i].QuestID))
+		if id == "" {
+			continue
+		}
+		entry := entries[i]
+		entry.QuestID = id
+		if entry.KillCounts == nil {
+			entry.KillCounts = make(map[string]int)
+		}
+		log[id] = &entry
+	}
+	return log
 }
 
 func newQuestProgress(quest *Quest) *QuestProgress {
@@ -146,6 +323,11 @@ func newQuestProgress(quest *Quest) *QuestProgress {
 		QuestID:    strings.ToLower(quest.ID),
 		AcceptedAt: time.Now().UTC(),
 		KillCounts: make(map[string]int, len(quest.RequiredKills)),
+		Visited:    make(map[string]bool, len(quest.VisitRooms)),
+		Delivered:  make(map[string]int, len(quest.DeliverItems)),
+	}
+	if limit := quest.TimeLimit(); limit > 0 {
+		progress.Deadline = progress.AcceptedAt.Add(limit)
 	}
 	for _, req := range quest.RequiredKills {
 		key := strings.ToLower(req.NPC)
@@ -159,6 +341,17 @@ func newQuestProgress(quest *Quest) *QuestProgress {
 	return progress
 }
 
+// expired reports whether an unfinished timed quest ran out of time.
+func (p *QuestProgress) expired(now time.Time) bool {
+	return !p.Completed && !p.Deadline.IsZero() && now.After(p.Deadline)
+}
+
+// everCompleted reports whether the quest was finished at least once, even
+// if a repeat is now under way.
+func (p *QuestProgress) everCompleted() bool {
+	return p.Completed || p.Completions > 0
+}
+
 func (p *QuestProgress) incrementKill(quest *Quest, npcName string) ([]QuestKillProgress, bool) {
 	if p == nil || quest == nil {
 		return nil, false
@@ -217,6 +410,30 @@ func (p *QuestProgress) killsComplete(quest *Quest) bool {
 	return true
 }
 
+func (p *QuestProgress) visitsComplete(quest *Quest) bool {
+	for _, room := range quest.VisitRooms {
+		if !p.Visited[strings.ToLower(room)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *QuestProgress) deliveriesComplete(quest *Quest) bool {
+	for _, req := range quest.DeliverItems {
+		if p.Delivered[deliveryKey(req.Item, req.NPC)] < req.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// objectivesComplete reports whether every kill, visit, and delivery
+// objective is done. Required items are checked at turn-in.
+func (p *QuestProgress) objectivesComplete(quest *Quest) bool {
+	return p.killsComplete(quest) && p.visitsComplete(quest) && p.deliveriesComplete(quest)
+}
+
 // QuestKillProgress summarises a kill objective.
 type QuestKillProgress struct {
 	NPC      string
@@ -224,20 +441,119 @@ type QuestKillProgress struct {
 	Required int
 }
 
+// QuestVisitProgress summarises a room the quest sends the player to.
+type QuestVisitProgress struct {
+	Room    RoomID
+	Title   string
+	Visited bool
+}
+
+// QuestDeliveryProgress summarises a delivery objective.
+type QuestDeliveryProgress struct {
+	Item     string
+	NPC      string
+	Current  int
+	Required int
+}
+
 // QuestProgressSnapshot captures quest progress for presentation.
 type QuestProgressSnapshot struct {
 	Quest        *Quest
 	Completed    bool
 	AcceptedAt   time.Time
 	CompletedAt  time.Time
+	Deadline     time.Time
+	Expired      bool
+	Completions  int
 	KillProgress []QuestKillProgress
+	Visits       []QuestVisitProgress
+	Deliveries   []QuestDeliveryProgress
+}
+
+// QuestLock explains why a player cannot yet accept a quest.
+type QuestLock struct {
+	Quest         *Quest
+	MinLevel      int
+	Prerequisites []*Quest
+	AvailableAt   time.Time
+}
+
+// Requirements lists what the player still has to do to unlock the quest.
+func (l QuestLock) Requirements() []string {
+	var reqs []string
+	if l.MinLevel > 0 {
+		reqs = append(reqs, fmt.Sprintf("reach level %d", l.MinLevel))
+	}
+	for _, quest := range l.Prerequisites {
+		reqs = append(reqs, "complete "+quest.Name)
+	}
+	if !l.AvailableAt.IsZero() {
+		reqs = append(reqs, "wait until "+l.AvailableAt.UTC().Format("Jan 2 15:04 UTC"))
+	}
+	return reqs
+}
+
+type questStanding int
+
+const (
+	questOpen questStanding = iota
+	questLocked
+	questUnavailable
+)
+
+// nextDailyReset returns the UTC midnight after t, when daily quests reopen.
+func nextDailyReset(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
+
+// questStandingLocked reports whether p may accept quest now, is locked out
+// of it, or has it active or finished for good.
+func (w *World) questStandingLocked(p *Player, quest *Quest, now time.Time) (QuestLock, questStanding) {
+	lock := QuestLock{Quest: quest}
+	if progress, ok := p.QuestLog[strings.ToLower(quest.ID)]; ok {
+		switch {
+		case !progress.Completed && !progress.expired(now):
+			return lock, questUnavailable
+		case progress.Completed && !quest.Repeatable:
+			return lock, questUnavailable
+		case progress.Completed:
+			var ready time.Time
+			if quest.Daily {
+				ready = nextDailyReset(progress.CompletedAt)
+			}
+			if cooldown := progress.CompletedAt.Add(quest.Cooldown()); cooldown.After(ready) {
+				ready = cooldown
+			}
+			if now.Before(ready) {
+				lock.AvailableAt = ready
+			}
+		}
+	}
+	if quest.MinLevel > 0 && p.Level < quest.MinLevel {
+		lock.MinLevel = quest.MinLevel
+	}
+	for _, prereq := range quest.Prerequisites {
+		key := strings.ToLower(prereq)
+		if progress, ok := p.QuestLog[key]; ok && progress.everCompleted() {
+			continue
+		}
+		if required, ok := w.quests[key]; ok {
+			lock.Prerequisites = append(lock.Prerequisites, required)
+		}
+	}
+	if lock.MinLevel > 0 || len(lock.Prerequisites) > 0 || !lock.AvailableAt.IsZero() {
+		return lock, questLocked
+	}
+	return lock, questOpen
 }
 
 // QuestProgressUpdate reports incremental changes after quest progress changes.
 type QuestProgressUpdate struct {
-	Quest          *Quest
-	KillProgress   []QuestKillProgress
-	KillsCompleted bool
+	Quest              *Quest
+	KillProgress       []QuestKillProgress
+	Visits             []QuestVisitProgress
+	Deliveries         []QuestDeliveryProgress
+	ObjectivesComplete bool
 }
 
 // QuestCompletionResult describes the rewards granted for finishing a quest.
@@ -245,8 +561,10 @@ type QuestCompletionResult struct {
 	Quest         *Quest
 	RewardItems   []Item
 	RewardXP      int
+	RestedXP      int
 	LevelsGained  int
 	CompletionMsg string
+	Reputation    []ReputationChange
 }
 
 // QuestsByNPC lists quests offered by the specified NPC.
@@ -270,16 +588,34 @@ func (w *World) QuestsByNPC(name string) []*Quest {
 func (w *World) AvailableQuests(p *Player) []*Quest {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
+	available, _ := w.roomQuestOffersLocked(p)
+	return available
+}
+
+// LockedQuests returns quests offered in the player's room that they cannot
+// accept yet, with what still stands in the way.
+func (w *World) LockedQuests(p *Player) []QuestLock {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	_, locked := w.roomQuestOffersLocked(p)
+	return locked
+}
+
+func (w *World) roomQuestOffersLocked(p *Player) ([]*Quest, []QuestLock) {
 	stored, ok := w.players[p.Name]
 	if !ok || stored != p || !p.Alive {
-		return nil
+		return nil, nil
 	}
 	room, ok := w.rooms[p.Room]
 	if !ok {
-		return nil
+		return nil, nil
 	}
+	now := time.Now()
 	seen := make(map[string]struct{})
-	var available []*Quest
+	var (
+		available []*Quest
+		locked    []QuestLock
+	)
 	for _, npc := range room.NPCs {
 		key := strings.ToLower(strings.TrimSpace(npc.Name))
 		if key == "" {
@@ -294,24 +630,35 @@ func (w *World) AvailableQuests(p *Player) []*Quest {
 			if _, exists := seen[id]; exists {
 				continue
 			}
-			if _, active := p.QuestLog[id]; active {
-				continue
-			}
-			available = append(available, quest)
 			seen[id] = struct{}{}
+			lock, standing := w.questStandingLocked(p, quest, now)
+			switch standing {
+			case questOpen:
+				available = append(available, quest)
+			case questLocked:
+				locked = append(locked, lock)
+			}
 		}
 	}
-	if len(available) == 0 {
-		return nil
-	}
 	sort.SliceStable(available, func(i, j int) bool {
 		return available[i].Name < available[j].Name
 	})
-	return available
+	sort.SliceStable(locked, func(i, j int) bool {
+		return locked[i].Quest.Name < locked[j].Quest.Name
+	})
+	return available, locked
 }
 
 // AcceptQuest marks a quest as active for the player.
 func (w *World) AcceptQuest(p *Player, questID string) (*Quest, error) {
+	quest, err := w.acceptQuest(p, questID)
+	if err == nil {
+		w.PersistPlayer(p)
+	}
+	return quest, err
+}
+
+func (w *World) acceptQuest(p *Player, questID string) (*Quest, error) {
 	trimmed := strings.ToLower(strings.TrimSpace(questID))
 	if trimmed == "" {
 		return nil, fmt.Errorf("quest id must not be empty")
@@ -345,16 +692,25 @@ func (w *World) AcceptQuest(p *Player, questID string) (*Quest, error) {
 	if !present {
 		return nil, fmt.Errorf("%s is not here", quest.Giver)
 	}
-	if p.QuestLog == nil {
-		p.QuestLog = make(map[string]*QuestProgress)
-	}
-	if progress, exists := p.QuestLog[trimmed]; exists {
-		if progress.Completed {
+	lock, standing := w.questStandingLocked(p, quest, time.Now())
+	switch standing {
+	case questUnavailable:
+		if p.QuestLog[trimmed].Completed {
 			return nil, fmt.Errorf("you have already completed that quest")
 		}
 		return nil, fmt.Errorf("you are already on that quest")
+	case questLocked:
+		return nil, fmt.Errorf("to accept %s you must %s", quest.Name, strings.Join(lock.Requirements(), ", "))
+	}
+	if p.QuestLog == nil {
+		p.QuestLog = make(map[string]*QuestProgress)
+	}
+	progress := newQuestProgress(quest)
+	if previous, exists := p.QuestLog[trimmed]; exists {
+		progress.Completions = previous.Completions
+		progress.CompletedAt = previous.CompletedAt
 	}
-	p.QuestLog[trimmed] = newQuestProgress(quest)
+	p.QuestLog[trimmed] = progress
 	return quest, nil
 }
 
@@ -366,6 +722,7 @@ func (w *World) SnapshotQuestLog(p *Player) []QuestProgressSnapshot {
 	if !ok || stored != p || len(p.QuestLog) == 0 {
 		return nil
 	}
+	now := time.Now()
 	snapshots := make([]QuestProgressSnapshot, 0, len(p.QuestLog))
 	for id, progress := range p.QuestLog {
 		quest, exists := w.quests[id]
@@ -377,6 +734,9 @@ func (w *World) SnapshotQuestLog(p *Player) []QuestProgressSnapshot {
 			Completed:   progress.Completed,
 			AcceptedAt:  progress.AcceptedAt,
 			CompletedAt: progress.CompletedAt,
+			Deadline:    progress.Deadline,
+			Expired:     progress.expired(now),
+			Completions: progress.Completions,
 		}
 		if len(quest.RequiredKills) > 0 {
 			kills := make([]QuestKillProgress, len(quest.RequiredKills))
@@ -393,6 +753,21 @@ func (w *World) SnapshotQuestLog(p *Player) []QuestProgressSnapshot {
 			}
 			snapshot.KillProgress = kills
 		}
+		for _, id := range quest.VisitRooms {
+			snapshot.Visits = append(snapshot.Visits, QuestVisitProgress{
+				Room:    RoomID(id),
+				Title:   w.roomTitleLocked(RoomID(id)),
+				Visited: progress.Visited[strings.ToLower(id)],
+			})
+		}
+		for _, req := range quest.DeliverItems {
+			snapshot.Deliveries = append(snapshot.Deliveries, QuestDeliveryProgress{
+				Item:     req.Item,
+				NPC:      req.NPC,
+				Current:  progress.Delivered[deliveryKey(req.Item, req.NPC)],
+				Required: req.Count,
+			})
+		}
 		snapshots = append(snapshots, snapshot)
 	}
 	if len(snapshots) == 0 {
@@ -406,6 +781,14 @@ func (w *World) SnapshotQuestLog(p *Player) []QuestProgressSnapshot {
 
 // RecordNPCKill updates quest progress after an NPC is defeated.
 func (w *World) RecordNPCKill(p *Player, npc NPC) []QuestProgressUpdate {
+	updates := w.recordNPCKill(p, npc)
+	if len(updates) > 0 {
+		w.PersistPlayer(p)
+	}
+	return updates
+}
+
+func (w *World) recordNPCKill(p *Player, npc NPC) []QuestProgressUpdate {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	stored, ok := w.players[p.Name]
@@ -416,9 +799,10 @@ func (w *World) RecordNPCKill(p *Player, npc NPC) []QuestProgressUpdate {
 	if normalized == "" {
 		return nil
 	}
+	now := time.Now()
 	updates := make([]QuestProgressUpdate, 0, len(p.QuestLog))
 	for id, progress := range p.QuestLog {
-		if progress.Completed {
+		if progress.Completed || progress.expired(now) {
 			continue
 		}
 		quest := w.quests[id]
@@ -430,9 +814,9 @@ func (w *World) RecordNPCKill(p *Player, npc NPC) []QuestProgressUpdate {
 			continue
 		}
 		updates = append(updates, QuestProgressUpdate{
-			Quest:          quest,
-			KillProgress:   killUpdates,
-			KillsCompleted: progress.killsComplete(quest),
+			Quest:              quest,
+			KillProgress:       killUpdates,
+			ObjectivesComplete: progress.objectivesComplete(quest),
 		})
 	}
 	if len(updates) == 0 {
@@ -441,35 +825,138 @@ func (w *World) RecordNPCKill(p *Player, npc NPC) []QuestProgressUpdate {
 	return updates
 }
 
-// FormatQuestKillUpdates renders quest progress updates into player-facing messages.
-func FormatQuestKillUpdates(updates []QuestProgressUpdate) []string {
+// roomTitleLocked returns the title of room, falling back to its ID.
+func (w *World) roomTitleLocked(id RoomID) string {
+	if room, ok := w.rooms[id]; ok && room.Title != "" {
+		return room.Title
+	}
+	return string(id)
+}
+
+// RecordRoomVisit marks p's current room as explored for every active
+// quest that sends them there.
+func (w *World) RecordRoomVisit(p *Player) []QuestProgressUpdate {
+	updates := w.recordRoomVisit(p)
+	if len(updates) > 0 {
+		w.PersistPlayer(p)
+	}
+	return updates
+}
+
+func (w *World) recordRoomVisit(p *Player) []QuestProgressUpdate {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || len(p.QuestLog) == 0 {
+		return nil
+	}
+	now := time.Now()
+	here := strings.ToLower(string(p.Room))
+	var updates []QuestProgressUpdate
+	for id, progress := range p.QuestLog {
+		if progress.Completed || progress.expired(now) {
+			continue
+		}
+		quest := w.quests[id]
+		if quest == nil {
+			continue
+		}
+		for _, room := range quest.VisitRooms {
+			key := strings.ToLower(room)
+			if key != here || progress.Visited[key] {
+				continue
+			}
+			if progress.Visited == nil {
+				progress.Visited = make(map[string]bool)
+			}
+			progress.Visited[key] = true
+			updates = append(updates, QuestProgressUpdate{
+				Quest:              quest,
+				Visits:             []QuestVisitProgress{{Room: p.Room, Title: w.roomTitleLocked(p.Room), Visited: true}},
+				ObjectivesComplete: progress.objectivesComplete(quest),
+			})
+			break
+		}
+	}
+	return updates
+}
+
+// recordDeliveryLocked credits the first active quest waiting on item from
+// npc. It reports false when no quest wants the delivery.
+func (w *World) recordDeliveryLocked(p *Player, item, npc string, now time.Time) (QuestProgressUpdate, bool) {
+	ids := make([]string, 0, len(p.QuestLog))
+	for id := range p.QuestLog {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		progress := p.QuestLog[id]
+		quest := w.quests[id]
+		if quest == nil || progress.Completed || progress.expired(now) {
+			continue
+		}
+		for _, req := range quest.DeliverItems {
+			if !strings.EqualFold(req.Item, item) || !strings.EqualFold(req.NPC, npc) {
+				continue
+			}
+			key := deliveryKey(req.Item, req.NPC)
+			if progress.Delivered[key] >= req.Count {
+				continue
+			}
+			if progress.Delivered == nil {
+				progress.Delivered = make(map[string]int)
+			}
+			progress.Delivered[key]++
+			return QuestProgressUpdate{
+				Quest:              quest,
+				Deliveries:         []QuestDeliveryProgress{{Item: req.Item, NPC: req.NPC, Current: progress.Delivered[key], Required: req.Count}},
+				ObjectivesComplete: progress.objectivesComplete(quest),
+			}, true
+		}
+	}
+	return QuestProgressUpdate{}, false
+}
+
+// FormatQuestUpdates renders quest progress updates into player-facing messages.
+func FormatQuestUpdates(updates []QuestProgressUpdate) []string {
 	if len(updates) == 0 {
 		return nil
 	}
 	messages := make([]string, 0, len(updates)*2)
 	for _, update := range updates {
+		name := HighlightQuestName(update.Quest.Name)
 		for _, prog := range update.KillProgress {
-			line := fmt.Sprintf("[Quest] %s: %s (%d/%d)",
-				HighlightQuestName(update.Quest.Name),
+			messages = append(messages, fmt.Sprintf("[Quest] %s: %s (%d/%d)",
+				name,
 				HighlightNPCName(prog.NPC),
 				prog.Current,
 				prog.Required,
-			)
-			messages = append(messages, line)
+			))
+		}
+		for _, visit := range update.Visits {
+			messages = append(messages, fmt.Sprintf("[Quest] %s: explored %s", name, visit.Title))
 		}
-		if update.KillsCompleted {
+		for _, delivery := range update.Deliveries {
+			messages = append(messages, fmt.Sprintf("[Quest] %s: delivered %s to %s (%d/%d)",
+				name,
+				HighlightItemName(delivery.Item),
+				HighlightNPCName(delivery.NPC),
+				delivery.Current,
+				delivery.Required,
+			))
+		}
+		if update.ObjectivesComplete {
 			turnIn := strings.TrimSpace(update.Quest.TurnIn)
 			if turnIn == "" {
 				turnIn = update.Quest.Giver
 			}
 			if trimmed := strings.TrimSpace(turnIn); trimmed != "" {
 				messages = append(messages, fmt.Sprintf("[Quest] %s objectives complete. Visit %s to turn in.",
-					HighlightQuestName(update.Quest.Name),
+					name,
 					HighlightNPCName(trimmed),
 				))
 			} else {
-				messages = append(messages, fmt.Sprintf("[Quest] %s objectives complete.",
-					HighlightQuestName(update.Quest.Name)))
+				messages = append(messages, fmt.Sprintf("[Quest] %s objectives complete.", name))
 			}
 		}
 	}
@@ -478,6 +965,14 @@ func FormatQuestKillUpdates(updates []QuestProgressUpdate) []string {
 
 // CompleteQuest checks requirements and awards quest rewards.
 func (w *World) CompleteQuest(p *Player, questID string) (*QuestCompletionResult, error) {
+	result, err := w.completeQuest(p, questID)
+	if err == nil {
+		w.PersistPlayer(p)
+	}
+	return result, err
+}
+
+func (w *World) completeQuest(p *Player, questID string) (*QuestCompletionResult, error) {
 	trimmed := strings.ToLower(strings.TrimSpace(questID))
 	if trimmed == "" {
 		return nil, fmt.Errorf("quest id must not be empty")
@@ -499,6 +994,9 @@ func (w *World) CompleteQuest(p *Player, questID string) (*QuestCompletionResult
 	if progress.Completed {
 		return nil, fmt.Errorf("you have already completed that quest")
 	}
+	if progress.expired(time.Now()) {
+		return nil, fmt.Errorf("you ran out of time for %s; accept it again to retry", quest.Name)
+	}
 	room, ok := w.rooms[p.Room]
 	if !ok {
 		return nil, fmt.Errorf("unknown room: %s", p.Room)
@@ -517,13 +1015,13 @@ func (w *World) CompleteQuest(p *Player, questID string) (*QuestCompletionResult
 	if !present {
 		return nil, fmt.Errorf("%s is not here", turnIn)
 	}
-	if !progress.killsComplete(quest) {
+	if !progress.objectivesComplete(quest) {
 		return nil, fmt.Errorf("you have not completed the objectives")
 	}
 	if len(quest.RequiredItems) > 0 {
 		inventoryCounts := make(map[string]int)
 		for _, item := range p.Inventory {
-			inventoryCounts[strings.ToLower(item.Name)]++
+			inventoryCounts[strings.ToLower(item.BaseName())]++
 		}
 		for _, req := range quest.RequiredItems {
 			key := strings.ToLower(req.Item)
@@ -550,7 +1048,7 @@ func (w *World) CompleteQuest(p *Player, questID string) (*QuestCompletionResult
 			}
 			filtered := p.Inventory[:0]
 			for _, item := range p.Inventory {
-				if remaining > 0 && strings.EqualFold(item.Name, req.Item) {
+				if remaining > 0 && strings.EqualFold(item.BaseName(), req.Item) {
 					remaining--
 					continue
 				}
@@ -564,19 +1062,65 @@ func (w *World) CompleteQuest(p *Player, questID string) (*QuestCompletionResult
 	if len(rewardItems) > 0 {
 		p.Inventory = append(p.Inventory, rewardItems...)
 	}
-	rewardXP := quest.RewardXP
+	rewardXP := w.eventExperienceLocked(p.Room, quest.RewardXP)
 	levels := 0
+	rested := 0
 	if rewardXP > 0 {
-		levels = p.GainExperience(rewardXP)
+		rested = restedBonusLocked(p, rewardXP)
+		levels = p.GainExperience(rewardXP + rested)
+	}
+	var reputation []ReputationChange
+	for _, id := range slices.Sorted(maps.Keys(quest.Reputation)) {
+		if change, ok := w.adjustReputationLocked(p, id, quest.Reputation[id]); ok {
+			reputation = append(reputation, change)
+		}
 	}
 	progress.Completed = true
+	progress.Completions++
 	progress.CompletedAt = time.Now().UTC()
 	result := &QuestCompletionResult{
 		Quest:         quest,
 		RewardItems:   rewardItems,
 		RewardXP:      rewardXP,
+		RestedXP:      rested,
 		LevelsGained:  levels,
 		CompletionMsg: quest.CompletionMessage,
+		Reputation:    reputation,
 	}
 	return result, nil
 }
+
+// AbandonQuest drops an unfinished quest from p's log. A repeatable quest
+// abandoned mid-run keeps the record of its earlier completions.
+func (w *World) AbandonQuest(p *Player, questID string) (*Quest, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(questID))
+	if trimmed == "" {
+		return nil, fmt.Errorf("quest id must not be empty")
+	}
+	w.mu.Lock()
+	quest, ok := w.quests[trimmed]
+	progress, active := p.QuestLog[trimmed]
+	switch {
+	case !ok || !active:
+		w.mu.Unlock()
+		return nil, fmt.Errorf("you are not on that quest")
+	case progress.Completed:
+		w.mu.Unlock()
+		return nil, fmt.Errorf("you have already completed that quest")
+	}
+	if progress.Completions > 0 {
+		p.QuestLog[trimmed] = &QuestProgress{
+			QuestID:     progress.QuestID,
+			AcceptedAt:  progress.AcceptedAt,
+			CompletedAt: progress.CompletedAt,
+			Completed:   true,
+			Completions: progress.Completions,
+		}
+	} else {
+		delete(p.QuestLog, trimmed)
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return quest, nil
+}
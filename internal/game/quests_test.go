@@ -1,8 +1,11 @@
 package game
 
 import (
+	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestQuestLifecycle(t *testing.T) {
@@ -95,3 +98,259 @@ func TestQuestLifecycle(t *testing.T) {
 		t.Fatalf("expected reward shard in inventory, got %+v", player.Inventory)
 	}
 }
+
+func TestQuestPrerequisitesAndMinimumLevel(t *testing.T) {
+	first := &Quest{ID: "first", Name: "First Steps", Giver: "Guide"}
+	second := &Quest{ID: "second", Name: "Second Wind", Giver: "Guide", Prerequisites: []string{"first"}, MinLevel: 3}
+	normalizeQuest(first)
+	normalizeQuest(second)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", NPCs: []NPC{{Name: "Guide"}}},
+	})
+	world.quests = map[string]*Quest{"first": first, "second": second}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	player := &Player{Name: "Hero", Room: "start", Alive: true}
+	world.AddPlayerForTest(player)
+
+	locked := world.LockedQuests(player)
+	if len(locked) != 1 || locked[0].Quest != second {
+		t.Fatalf("expected second quest to be locked, got %+v", locked)
+	}
+	reqs := strings.Join(locked[0].Requirements(), ", ")
+	if reqs != "reach level 3, complete First Steps" {
+		t.Fatalf("requirements = %q", reqs)
+	}
+	if _, err := world.AcceptQuest(player, "second"); err == nil || !strings.Contains(err.Error(), "complete First Steps") {
+		t.Fatalf("expected locked quest to be refused, got %v", err)
+	}
+
+	if _, err := world.AcceptQuest(player, "first"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	if _, err := world.CompleteQuest(player, "first"); err != nil {
+		t.Fatalf("CompleteQuest: %v", err)
+	}
+	player.Level = 3
+	if available := world.AvailableQuests(player); len(available) != 1 || available[0] != second {
+		t.Fatalf("expected second quest to unlock, got %+v", available)
+	}
+	if locked := world.LockedQuests(player); len(locked) != 0 {
+		t.Fatalf("expected nothing locked, got %+v", locked)
+	}
+}
+
+func TestRepeatableDailyAndTimedQuests(t *testing.T) {
+	chore := &Quest{ID: "chore", Name: "Sweep Up", Giver: "Guide", Repeatable: true}
+	daily := &Quest{ID: "daily", Name: "Morning Rounds", Giver: "Guide", Daily: true}
+	timed := &Quest{ID: "timed", Name: "Hurry", Giver: "Guide", TimeLimitMinutes: 5, RequiredKills: []QuestKillRequirement{{NPC: "Rat"}}}
+	normalizeQuest(chore)
+	normalizeQuest(daily)
+	normalizeQuest(timed)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", NPCs: []NPC{{Name: "Guide"}}},
+	})
+	world.quests = map[string]*Quest{"chore": chore, "daily": daily, "timed": timed}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	player := &Player{Name: "Hero", Room: "start", Alive: true}
+	world.AddPlayerForTest(player)
+
+	for i := 0; i < 2; i++ {
+		if _, err := world.AcceptQuest(player, "chore"); err != nil {
+			t.Fatalf("AcceptQuest #%d: %v", i+1, err)
+		}
+		if _, err := world.CompleteQuest(player, "chore"); err != nil {
+			t.Fatalf("CompleteQuest #%d: %v", i+1, err)
+		}
+	}
+	if got := player.QuestLog["chore"].Completions; got != 2 {
+		t.Fatalf("completions = %d, want 2", got)
+	}
+
+	if _, err := world.AcceptQuest(player, "daily"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	if _, err := world.CompleteQuest(player, "daily"); err != nil {
+		t.Fatalf("CompleteQuest: %v", err)
+	}
+	if _, err := world.AcceptQuest(player, "daily"); err == nil || !strings.Contains(err.Error(), "wait until") {
+		t.Fatalf("expected daily quest to wait for the reset, got %v", err)
+	}
+	player.QuestLog["daily"].CompletedAt = player.QuestLog["daily"].CompletedAt.Add(-24 * time.Hour)
+	if _, err := world.AcceptQuest(player, "daily"); err != nil {
+		t.Fatalf("expected daily quest to reopen, got %v", err)
+	}
+
+	if _, err := world.AcceptQuest(player, "timed"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	progress := player.QuestLog["timed"]
+	if progress.Deadline.Sub(progress.AcceptedAt) != 5*time.Minute {
+		t.Fatalf("deadline not set from time limit: %v", progress.Deadline)
+	}
+	progress.Deadline = time.Now().Add(-time.Second)
+	if updates := world.RecordNPCKill(player, NPC{Name: "Rat"}); len(updates) != 0 {
+		t.Fatalf("expired quest should not record kills, got %+v", updates)
+	}
+	if _, err := world.CompleteQuest(player, "timed"); err == nil || !strings.Contains(err.Error(), "ran out of time") {
+		t.Fatalf("expected expiry error, got %v", err)
+	}
+	if _, err := world.AcceptQuest(player, "timed"); err != nil {
+		t.Fatalf("expected expired quest to be accepted again, got %v", err)
+	}
+}
+
+func TestValidateQuestChains(t *testing.T) {
+	unknown := map[string]*Quest{"a": {ID: "a", Prerequisites: []string{"missing"}}}
+	if err := validateQuestChains(unknown); err == nil || !strings.Contains(err.Error(), "unknown prerequisite") {
+		t.Fatalf("expected unknown prerequisite error, got %v", err)
+	}
+	cycle := map[string]*Quest{
+		"a": {ID: "a", Prerequisites: []string{"b"}},
+		"b": {ID: "b", Prerequisites: []string{"a"}},
+	}
+	if err := validateQuestChains(cycle); err == nil || !strings.Contains(err.Error(), "loops") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	chain := map[string]*Quest{
+		"a": {ID: "a"},
+		"b": {ID: "b", Prerequisites: []string{"A"}},
+	}
+	if err := validateQuestChains(chain); err != nil {
+		t.Fatalf("valid chain rejected: %v", err)
+	}
+}
+
+func TestQuestVisitAndDeliveryObjectives(t *testing.T) {
+	quest := &Quest{
+		ID:           "rounds",
+		Name:         "Rounds",
+		Giver:        "Guide",
+		VisitRooms:   []string{"dock"},
+		DeliverItems: []QuestDeliveryRequirement{{Item: "Letter", NPC: "Clerk", Count: 2}},
+	}
+	normalizeQuest(quest)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", NPCs: []NPC{{Name: "Guide"}, {Name: "Clerk"}}, Exits: map[string]Exit{"east": {To: "dock"}}},
+		"dock":  {ID: "dock", Title: "Old Dock", Exits: map[string]Exit{"west": {To: "start"}}},
+	})
+	world.quests = map[string]*Quest{"rounds": quest}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	player := &Player{Name: "Hero", Room: "start", Alive: true}
+	world.AddPlayerForTest(player)
+	player.Inventory = []Item{{Name: "Letter"}, {Name: "Letter"}, {Name: "Pebble"}}
+
+	if _, err := world.GiveItemToNPC(player, "letter", "clerk"); !errors.Is(err, ErrNPCNotInterested) {
+		t.Fatalf("expected clerk to refuse before the quest, got %v", err)
+	}
+	if _, err := world.AcceptQuest(player, "rounds"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	if _, err := world.GiveItemToNPC(player, "pebble", "clerk"); !errors.Is(err, ErrNPCNotInterested) {
+		t.Fatalf("expected clerk to refuse the pebble, got %v", err)
+	}
+	for i := 1; i <= 2; i++ {
+		result, err := world.GiveItemToNPC(player, "letter", "clerk")
+		if err != nil {
+			t.Fatalf("GiveItemToNPC #%d: %v", i, err)
+		}
+		if got := result.Updates[0].Deliveries[0].Current; got != i {
+			t.Fatalf("delivery progress = %d, want %d", got, i)
+		}
+		if result.Updates[0].ObjectivesComplete {
+			t.Fatalf("objectives complete before the dock was explored")
+		}
+	}
+	if len(player.Inventory) != 1 {
+		t.Fatalf("expected letters to leave the inventory, got %+v", player.Inventory)
+	}
+	if _, err := world.CompleteQuest(player, "rounds"); err == nil {
+		t.Fatalf("expected turn-in to wait for the visit")
+	}
+
+	if _, err := world.Move(player, "east"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	updates := world.RecordRoomVisit(player)
+	if len(updates) != 1 || updates[0].Visits[0].Title != "Old Dock" || !updates[0].ObjectivesComplete {
+		t.Fatalf("unexpected visit updates: %+v", updates)
+	}
+	if again := world.RecordRoomVisit(player); len(again) != 0 {
+		t.Fatalf("revisiting should not report progress again, got %+v", again)
+	}
+	log := world.SnapshotQuestLog(player)
+	if len(log) != 1 || !log[0].Visits[0].Visited || log[0].Deliveries[0].Current != 2 {
+		t.Fatalf("unexpected quest log: %+v", log)
+	}
+
+	if _, err := world.Move(player, "west"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := world.CompleteQuest(player, "rounds"); err != nil {
+		t.Fatalf("CompleteQuest: %v", err)
+	}
+}
+
+func TestQuestLogPersistsAndAbandon(t *testing.T) {
+	chore := &Quest{ID: "chore", Name: "Sweep Up", Giver: "Guide", Repeatable: true, CooldownMinutes: 30}
+	hunt := &Quest{ID: "hunt", Name: "Rat Hunt", Giver: "Guide", RequiredKills: []QuestKillRequirement{{NPC: "Rat", Count: 3}}}
+	normalizeQuest(chore)
+	normalizeQuest(hunt)
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", NPCs: []NPC{{Name: "Guide"}}},
+	})
+	world.quests = map[string]*Quest{"chore": chore, "hunt": hunt}
+	world.questsByNPC = indexQuestsByNPC(world.quests)
+	player := &Player{Name: "Hero", Room: "start", Alive: true}
+	world.AddPlayerForTest(player)
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Hero", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	player.Account = "Hero"
+
+	if _, err := world.AcceptQuest(player, "hunt"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	world.RecordNPCKill(player, NPC{Name: "Rat"})
+	if _, err := world.AcceptQuest(player, "chore"); err != nil {
+		t.Fatalf("AcceptQuest: %v", err)
+	}
+	if _, err := world.CompleteQuest(player, "chore"); err != nil {
+		t.Fatalf("CompleteQuest: %v", err)
+	}
+
+	saved := accounts.Profile("Hero").QuestLog
+	if saved["hunt"] == nil || saved["hunt"].KillCounts["rat"] != 1 {
+		t.Fatalf("kill progress not persisted: %+v", saved["hunt"])
+	}
+	if saved["chore"] == nil || !saved["chore"].Completed || saved["chore"].CompletedAt.IsZero() {
+		t.Fatalf("completion not persisted: %+v", saved["chore"])
+	}
+
+	if _, err := world.AcceptQuest(player, "chore"); err == nil || !strings.Contains(err.Error(), "wait until") {
+		t.Fatalf("expected the cooldown to hold the chore, got %v", err)
+	}
+	player.QuestLog["chore"].CompletedAt = time.Now().Add(-time.Hour)
+	if _, err := world.AcceptQuest(player, "chore"); err != nil {
+		t.Fatalf("AcceptQuest after cooldown: %v", err)
+	}
+	if _, err := world.AbandonQuest(player, "chore"); err != nil {
+		t.Fatalf("AbandonQuest: %v", err)
+	}
+	if progress := player.QuestLog["chore"]; progress == nil || !progress.Completed || progress.Completions != 1 {
+		t.Fatalf("abandoning a repeat should keep the earlier completion, got %+v", progress)
+	}
+	if _, err := world.AbandonQuest(player, "hunt"); err != nil {
+		t.Fatalf("AbandonQuest: %v", err)
+	}
+	if _, err := world.AbandonQuest(player, "hunt"); err == nil {
+		t.Fatalf("expected abandoning twice to fail")
+	}
+	if _, ok := accounts.Profile("Hero").QuestLog["hunt"]; ok {
+		t.Fatalf("abandoned quest still persisted")
+	}
+}
@@ -0,0 +1,266 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoRangedWeapon indicates the player carries nothing to shoot with.
+	ErrNoRangedWeapon = errors.New("you have no ranged weapon")
+	// ErrOutOfAmmo indicates the player lacks ammunition for their weapon.
+	ErrOutOfAmmo = errors.New("you are out of ammunition")
+)
+
+// rangedWeapon returns the first ranged weapon in items.
+func rangedWeapon(items []Item) (Item, bool) {
+	for _, item := range items {
+		if item.Ranged {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// takeOneItem removes one item named name from items, searching inside
+// containers so quivers and pouches can hold ammunition.
+func takeOneItem(items []Item, name string) ([]Item, bool) {
+	for i := range items {
+		if strings.EqualFold(items[i].Name, name) {
+			return append(items[:i], items[i+1:]...), true
+		}
+	}
+	for i := range items {
+		if contents, ok := takeOneItem(items[i].Contents, name); ok {
+			items[i].Contents = contents
+			return items, true
+		}
+	}
+	return items, false
+}
+
+// RangedDamage estimates the damage dealt by a shot from weapon.
+func (p *Player) RangedDamage(weapon Item) int {
+	p.EnsureStats()
+	base := weapon.Damage
+	if base <= 0 {
+		base = 4
+	}
+	base += p.Level * 2
+	if modifier := p.damageModifier(time.Now()); modifier != 0 {
+		base = base * (100 + modifier) / 100
+	}
+	return max(1, base)
+}
+
+// exitBackLocked returns the open exit in room that leads to target.
+func exitBackLocked(room *Room, target RoomID) (string, bool) {
+	for dir, exit := range room.Exits {
+		if exit.To == target && !exit.Closed {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// Shoot fires p's ranged weapon through the exit in direction at an NPC or
+// player in the adjacent room. The exit must be visible to p and not shut by
+// a door. A wounded NPC charges back along the exit to fight the shooter.
+func (w *World) Shoot(p *Player, targetName, direction string) error {
+	targetName = strings.TrimSpace(targetName)
+	if targetName == "" {
+		return fmt.Errorf("target must not be empty")
+	}
+	w.mu.Lock()
+	if !p.Alive {
+		w.mu.Unlock()
+		return fmt.Errorf("you are in no condition to fight")
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		w.mu.Unlock()
+		return fmt.Errorf("unknown room: %s", p.Room)
+	}
+	dir, ok := matchExitLocked(room, direction)
+	if !ok || !exitVisibleLocked(p, room.ID, dir, room.Exits[dir]) {
+		w.mu.Unlock()
+		return fmt.Errorf("you see no exit %s", strings.TrimSpace(direction))
+	}
+	exit := room.Exits[dir]
+	if exit.Closed {
+		w.mu.Unlock()
+		return fmt.Errorf("the %s door is closed", dir)
+	}
+	dest, ok := w.rooms[exit.To]
+	if !ok {
+		w.mu.Unlock()
+		return fmt.Errorf("you can't see anything %s", dir)
+	}
+	if room.Peaceful || dest.Peaceful {
+		w.mu.Unlock()
+		return ErrPeaceful
+	}
+	weapon, ok := rangedWeapon(p.Inventory)
+	if !ok {
+		w.mu.Unlock()
+		return ErrNoRangedWeapon
+	}
+	npcIdx := findNPCIndex(dest.NPCs, targetName)
+	var targetPlayer *Player
+	if npcIdx == -1 {
+		var candidates []string
+		var matches []*Player
+		for _, other := range w.players {
+			if other != p && other.Alive && other.Room == dest.ID {
+				candidates = append(candidates, other.Name)
+				matches = append(matches, other)
+			}
+		}
+		idx, ok := uniqueMatch(targetName, candidates, true)
+		if !ok {
+			w.mu.Unlock()
+			return fmt.Errorf("you see no %s to the %s", targetName, dir)
+		}
+		targetPlayer = matches[idx]
+		if err := w.pvpAllowedLocked(p, targetPlayer); err != nil {
+			w.mu.Unlock()
+			if errors.Is(err, ErrCrime) {
+				w.reportCrime(p)
+			}
+			return err
+		}
+	}
+	if weapon.Ammo != "" {
+		inventory, ok := takeOneItem(p.Inventory, weapon.Ammo)
+		if !ok {
+			w.mu.Unlock()
+			return fmt.Errorf("%w for your %s", ErrOutOfAmmo, weapon.Name)
+		}
+		p.Inventory = inventory
+	}
+	damage := p.RangedDamage(weapon)
+	from := "afar"
+	if back, ok := exitBackLocked(dest, room.ID); ok {
+		from = "the " + back
+	}
+	projectile := "A shot from " + weapon.Name
+	if weapon.Ammo != "" {
+		projectile = "A " + weapon.Ammo
+	}
+	shooterRoom, destRoom := room.ID, dest.ID
+	key, snapshot := profileSnapshot(p)
+	var (
+		npcName   string
+		playerHit *PlayerDamageResult
+	)
+	if targetPlayer != nil {
+		playerHit, _ = w.damagePlayerInRoomLocked(p, destRoom, targetPlayer.Name, damage)
+	} else {
+		npcName = dest.NPCs[npcIdx].Name
+	}
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+
+	w.BroadcastToRoom(shooterRoom, Ansi(fmt.Sprintf("\r\n%s shoots %s %s.", HighlightName(p.Name), HighlightItemName(weapon.Name), dir)), p)
+
+	if targetPlayer != nil {
+		w.announcePlayerShot(p, playerHit, projectile, from)
+		w.bountyClaimed(p, playerHit)
+		w.warKillScored(p, playerHit)
+		return nil
+	}
+
+	result, err := w.ApplyDamageToNPC(destRoom, npcName, damage)
+	if err != nil {
+		return err
+	}
+	highlighted := HighlightNPCName(result.NPC.Name)
+	if p.Output != nil {
+		p.Output.Send(Ansi(fmt.Sprintf("\r\nYou shoot %s %s, hitting %s for %d damage. (%d/%d HP)", HighlightItemName(weapon.Name), dir, highlighted, result.Damage, result.NPC.Health, result.NPC.MaxHealth)))
+	}
+	w.BroadcastToRoom(destRoom, Ansi(fmt.Sprintf("\r\n%s flies in from %s and strikes %s for %d damage.", projectile, from, highlighted, result.Damage)), nil)
+	if result.Defeated {
+		w.RewardNPCDefeat(p, destRoom, result)
+		return nil
+	}
+	w.retaliate(destRoom, result.NPC.Name, p)
+	return nil
+}
+
+func (w *World) announcePlayerShot(p *Player, result *PlayerDamageResult, projectile, from string) {
+	if result == nil {
+		return
+	}
+	targetName := HighlightName(result.Target.Name)
+	w.BroadcastToRoom(result.PreviousRoom, Ansi(fmt.Sprintf("\r\n%s flies in from %s and strikes %s for %d damage.", projectile, from, targetName, result.Damage)), result.Target)
+	if result.Duel != nil {
+		w.AnnounceDuelResult(*result.Duel)
+		return
+	}
+	if result.Defeated {
+		if p.Output != nil {
+			p.Output.Send(Ansi(fmt.Sprintf("\r\nYour shot fells %s!", targetName)))
+		}
+		if result.Target.Output != nil {
+			result.Target.Output.Send(Ansi(fmt.Sprintf("\r\nYou have been defeated by %s!", HighlightName(p.Name))))
+			notifyDeathOutcome(result.Target, result.Death)
+			EnterRoom(w, result.Target, "defeat")
+		}
+		return
+	}
+	if p.Output != nil {
+		p.Output.Send(Ansi(fmt.Sprintf("\r\nYour shot strikes %s for %d damage. (%d/%d HP)", targetName, result.Damage, result.Remaining, result.Target.MaxHealth)))
+	}
+	if result.Target.Output != nil {
+		result.Target.Output.Send(Ansi(fmt.Sprintf("\r\n%s shoots you from %s for %d damage. (%d/%d HP)", HighlightName(p.Name), from, result.Damage, result.Remaining, result.Target.MaxHealth)))
+	}
+}
+
+// retaliate sends a wounded NPC from room along an open exit to the
+// shooter's room and engages them in combat. NPCs with no way through stay
+// put.
+func (w *World) retaliate(room RoomID, name string, shooter *Player) {
+	w.mu.Lock()
+	src, ok := w.rooms[room]
+	if !ok || !shooter.Alive {
+		w.mu.Unlock()
+		return
+	}
+	idx := findNPCIndex(src.NPCs, name)
+	if idx == -1 {
+		w.mu.Unlock()
+		return
+	}
+	npc := src.NPCs[idx]
+	target, ok := w.rooms[shooter.Room]
+	dir, open := exitBackLocked(src, shooter.Room)
+	if !ok || !open {
+		w.mu.Unlock()
+		w.BroadcastToRoom(room, Ansi(fmt.Sprintf("\r\n%s howls, unable to reach its attacker.", HighlightNPCName(npc.Name))), nil)
+		return
+	}
+	src.NPCs = append(src.NPCs[:idx], src.NPCs[idx+1:]...)
+	target.NPCs = append(target.NPCs, npc)
+	from := "afar"
+	if back, ok := exitBackLocked(target, room); ok {
+		from = "the " + back
+	}
+	previous := w.combats[room]
+	w.mu.Unlock()
+
+	if previous != nil {
+		previous.clearNPC(npc.Name)
+	}
+	highlighted := HighlightNPCName(npc.Name)
+	w.BroadcastToRoom(room, Ansi(fmt.Sprintf("\r\n%s charges off %s.", highlighted, dir)), nil)
+	w.BroadcastToRoom(shooter.Room, Ansi(fmt.Sprintf("\r\n%s charges in from %s, seeking %s!", highlighted, from, HighlightName(shooter.Name))), shooter)
+	if shooter.Output != nil {
+		shooter.Output.Send(Ansi(fmt.Sprintf("\r\n%s charges in from %s and attacks you!", highlighted, from)))
+	}
+	combat := w.ensureCombat(shooter.Room)
+	combat.addPlayer(shooter.Name, combatTarget{kind: combatTargetNPC, name: npc.Name})
+	combat.addNPC(npc.Name, combatTarget{kind: combatTargetPlayer, name: shooter.Name})
+	combat.startLoop()
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestShootRequiresWeaponAmmoAndOpenExit(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tower": {ID: "tower", Title: "Tower", Exits: map[string]Exit{
+			"north": {To: "field"},
+			"east":  {To: "cellar", Door: true, Closed: true},
+		}},
+		"field":  {ID: "field", Title: "Field", NPCs: []NPC{{Name: "Dune Wolf", Health: 100, MaxHealth: 100}}, Exits: map[string]Exit{"south": {To: "tower"}}},
+		"cellar": {ID: "cellar", Title: "Cellar", NPCs: []NPC{{Name: "Rat"}}, Exits: map[string]Exit{"west": {To: "tower"}}},
+	})
+	archer := &Player{Name: "Archer", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(archer)
+	if err := world.Shoot(archer, "wolf", "north"); !errors.Is(err, ErrNoRangedWeapon) {
+		t.Fatalf("expected ErrNoRangedWeapon, got %v", err)
+	}
+	archer.Inventory = []Item{{Name: "Short Bow", Ranged: true, Damage: 5, Ammo: "Arrow"}}
+	if err := world.Shoot(archer, "wolf", "north"); !errors.Is(err, ErrOutOfAmmo) {
+		t.Fatalf("expected ErrOutOfAmmo, got %v", err)
+	}
+	archer.Inventory = append(archer.Inventory, Item{Name: "Quiver", Container: true, Contents: []Item{{Name: "Arrow"}, {Name: "Arrow"}}})
+	if err := world.Shoot(archer, "rat", "east"); err == nil || !strings.Contains(err.Error(), "door is closed") {
+		t.Fatalf("expected closed door to block the shot, got %v", err)
+	}
+	if err := world.Shoot(archer, "ghost", "north"); err == nil {
+		t.Fatalf("expected missing target to fail")
+	}
+	if got := len(archer.Inventory[1].Contents); got != 2 {
+		t.Fatalf("failed shots spent ammunition: %d arrows left", got)
+	}
+}
+
+func TestShootDamagesNPCAndProvokesRetaliation(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tower": {ID: "tower", Title: "Tower", Exits: map[string]Exit{
+			"north": {To: "field"},
+			"east":  {To: "cellar", Door: true, Closed: true},
+		}},
+		"field":  {ID: "field", Title: "Field", NPCs: []NPC{{Name: "Dune Wolf", Health: 100, MaxHealth: 100}}, Exits: map[string]Exit{"south": {To: "tower"}}},
+		"cellar": {ID: "cellar", Title: "Cellar", NPCs: []NPC{{Name: "Rat"}}, Exits: map[string]Exit{"west": {To: "tower"}}},
+	})
+	archer := &Player{Name: "Archer", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(archer)
+	archer.Inventory = []Item{
+		{Name: "Short Bow", Ranged: true, Damage: 5, Ammo: "Arrow"},
+		{Name: "Quiver", Container: true, Contents: []Item{{Name: "Arrow"}, {Name: "Arrow"}}},
+	}
+	drainOutput(archer.Output)
+
+	if err := world.Shoot(archer, "wolf", "north"); err != nil {
+		t.Fatalf("Shoot: %v", err)
+	}
+	if got := len(archer.Inventory[1].Contents); got != 1 {
+		t.Fatalf("expected one arrow left, got %d", got)
+	}
+	output := stripAnsi(strings.Join(drainOutput(archer.Output), ""))
+	if !strings.Contains(output, "hitting Dune Wolf for") {
+		t.Fatalf("expected hit message, got %q", output)
+	}
+	if !strings.Contains(output, "Dune Wolf charges in from the north") {
+		t.Fatalf("expected the wolf to charge the archer, got %q", output)
+	}
+
+	world.mu.RLock()
+	tower, field := world.rooms["tower"], world.rooms["field"]
+	combat := world.combats["tower"]
+	world.mu.RUnlock()
+	if len(field.NPCs) != 0 || len(tower.NPCs) != 1 || tower.NPCs[0].Name != "Dune Wolf" {
+		t.Fatalf("expected the wolf to move into the tower, field=%v tower=%v", field.NPCs, tower.NPCs)
+	}
+	if tower.NPCs[0].Health >= tower.NPCs[0].MaxHealth {
+		t.Fatalf("expected the wolf to be wounded, got %d/%d", tower.NPCs[0].Health, tower.NPCs[0].MaxHealth)
+	}
+	if combat == nil || !combat.hasNPC("Dune Wolf") {
+		t.Fatalf("expected the wolf to engage the archer")
+	}
+	combat.stopLoop()
+}
+
+func TestShootHitsPlayerInAdjacentRoom(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tower": {ID: "tower", Title: "Tower", Exits: map[string]Exit{
+			"north": {To: "field"},
+			"east":  {To: "cellar", Door: true, Closed: true},
+		}},
+		"field":  {ID: "field", Title: "Field", NPCs: []NPC{{Name: "Dune Wolf", Health: 100, MaxHealth: 100}}, Exits: map[string]Exit{"south": {To: "tower"}}},
+		"cellar": {ID: "cellar", Title: "Cellar", NPCs: []NPC{{Name: "Rat"}}, Exits: map[string]Exit{"west": {To: "tower"}}},
+	})
+	archer := &Player{Name: "Archer", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(archer)
+	archer.Inventory = []Item{{Name: "Sling", Ranged: true}}
+	target := &Player{Name: "Scout", Room: "field", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(target)
+	drainOutput(target.Output)
+
+	if err := world.Shoot(archer, "scout", "n"); !errors.Is(err, ErrNotArena) {
+		t.Fatalf("Shoot outside an arena = %v, want ErrNotArena", err)
+	}
+	world.rooms["field"].Arena = true
+	world.beginDuelLocked(archer, target)
+
+	if err := world.Shoot(archer, "scout", "n"); err != nil {
+		t.Fatalf("Shoot: %v", err)
+	}
+	if target.Health >= target.MaxHealth {
+		t.Fatalf("expected scout to take damage, got %d/%d", target.Health, target.MaxHealth)
+	}
+	output := stripAnsi(strings.Join(drainOutput(target.Output), ""))
+	if !strings.Contains(output, "Archer shoots you from the south") {
+		t.Fatalf("expected the scout to learn where the shot came from, got %q", output)
+	}
+}
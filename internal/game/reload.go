@@ -0,0 +1,272 @@
+package game
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AreaReloadReport summarises what a hot reload of the area files changed.
+type AreaReloadReport struct {
+	Added     []RoomID
+	Removed   []RoomID
+	Rebuilt   []RoomID
+	Unchanged int
+}
+
+// roomDigests fingerprints each room as loaded from its area file so a
+// later reload can tell which rooms were edited.
+func roomDigests(rooms map[RoomID]*Room) map[RoomID]string {
+	digests := make(map[RoomID]string, len(rooms))
+	for id, room := range rooms {
+		data, err := json.Marshal(room)
+		if err != nil {
+			continue
+		}
+		sum := sha1.Sum(data)
+		digests[id] = hex.EncodeToString(sum[:])
+	}
+	return digests
+}
+
+// ReloadAreas re-reads the area files without a reboot. Unchanged rooms keep
+// their live NPCs, items, and fights. Edited rooms that players occupy are
+// rebuilt in place, keeping whoever and whatever is inside, and those
+// players see the room again. Players standing in a room that no longer
+// exists are moved to the start room.
+func (w *World) ReloadAreas() (AreaReloadReport, error) {
+	var report AreaReloadReport
+	w.mu.Lock()
+	if w.areasPath == "" {
+		w.mu.Unlock()
+		return report, fmt.Errorf("world does not have an areas path configured")
+	}
+	rooms, sources, areas, err := loadRooms(w.areasPath)
+	if err != nil {
+		w.mu.Unlock()
+		return report, err
+	}
+	if _, ok := rooms[StartRoom]; !ok {
+		w.mu.Unlock()
+		return report, fmt.Errorf("reloaded areas have no %s room", StartRoom)
+	}
+	vehicles, err := buildVehicles(areas, rooms, time.Now())
+	if err != nil {
+		w.mu.Unlock()
+		return report, err
+	}
+	keepVehiclePositions(w.vehicles, vehicles)
+	digests := roomDigests(rooms)
+	occupied := make(map[RoomID]bool)
+	for _, p := range w.players {
+		if p.Alive {
+			occupied[p.Room] = true
+		}
+	}
+	if w.roomHistories == nil {
+		w.roomHistories = make(map[RoomID]*roomHistory)
+	}
+	next := make(map[RoomID]*Room, len(rooms))
+	for id, fresh := range rooms {
+		current, exists := w.rooms[id]
+		switch {
+		case !exists:
+			next[id] = fresh
+			history := &roomHistory{}
+			history.append(fresh, "")
+			w.roomHistories[id] = history
+			report.Added = append(report.Added, id)
+			continue
+		case digests[id] != "" && digests[id] == w.roomDigests[id]:
+			next[id] = current
+			report.Unchanged++
+			continue
+		case occupied[id]:
+			npcs, items := current.NPCs, current.Items
+			*current = *fresh
+			current.NPCs, current.Items = npcs, items
+			next[id] = current
+		default:
+			next[id] = fresh
+		}
+		if history, ok := w.roomHistories[id]; ok {
+			history.append(next[id], "reload")
+		}
+		report.Rebuilt = append(report.Rebuilt, id)
+	}
+	var stale []*combatInstance
+	for id, room := range w.rooms {
+		if _, ok := rooms[id]; ok {
+			continue
+		}
+		if w.instanceRooms[id] != nil {
+			// Instances keep running on the rooms they were copied from.
+			next[id] = room
+			sources[id] = w.roomSources[id]
+			continue
+		}
+		report.Removed = append(report.Removed, id)
+		delete(w.roomHistories, id)
+		if combat := w.combats[id]; combat != nil {
+			stale = append(stale, combat)
+			delete(w.combats, id)
+		}
+	}
+	rebuilt := make(map[RoomID]bool, len(report.Rebuilt))
+	for _, id := range report.Rebuilt {
+		rebuilt[id] = true
+	}
+	var reshaped, displaced []*Player
+	for _, p := range w.players {
+		if _, ok := next[p.Room]; !ok {
+			p.Room = StartRoom
+			w.syncPresenceLocked(p)
+			displaced = append(displaced, p)
+			continue
+		}
+		if p.Alive && rebuilt[p.Room] {
+			reshaped = append(reshaped, p)
+		}
+	}
+	w.rooms = next
+	w.roomSources = sources
+	w.roomDigests = digests
+	w.areaMeta = areas
+	w.vehicles = vehicles
+	w.placeScheduledNPCsLocked()
+	w.mu.Unlock()
+
+	for _, combat := range stale {
+		combat.stopLoop()
+	}
+	for _, p := range displaced {
+		w.PersistPlayer(p)
+		if p.Output != nil {
+			p.Output.Send(Ansi(Style("\r\nThe place you stood dissolves, and you find yourself somewhere familiar.", AnsiMagenta)))
+			EnterRoom(w, p, "")
+		}
+	}
+	for _, p := range reshaped {
+		if p.Output != nil {
+			p.Output.Send(Ansi(Style("\r\nThe world shifts as this place is rebuilt around you.", AnsiMagenta)))
+			EnterRoom(w, p, "")
+		}
+	}
+	for _, list := range [][]RoomID{report.Added, report.Removed, report.Rebuilt} {
+		sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	}
+	return report, nil
+}
+
+// ReloadQuests re-reads the quest definitions. Progress in player quest
+// logs is kept; entries for quests that no longer exist are ignored.
+func (w *World) ReloadQuests() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	quests, err := loadQuestData(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	w.quests = quests
+	w.questsByNPC = indexQuestsByNPC(quests)
+	w.mu.Unlock()
+	return len(quests), nil
+}
+
+// ReloadScripts drops every compiled script so each is recompiled the next
+// time it runs, and cancels the timers scripts had scheduled. It returns the
+// number of scripts and timers discarded.
+func (w *World) ReloadScripts() (int, int) {
+	if w.scripts == nil {
+		return 0, 0
+	}
+	return w.scripts.reset(), w.cancelScriptTimers()
+}
+
+// reset empties the compiled script cache.
+func (e *scriptEngine) reset() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	count := len(e.scripts)
+	e.scripts = make(map[string]*scriptEntry)
+	return count
+}
+
+// areaFingerprint summarises the area and quest files on disk. Builder rooms
+// are left out because in-game edits already apply live.
+func areaFingerprint(areasPath string) (string, string) {
+	var areas strings.Builder
+	entries, err := os.ReadDir(areasPath)
+	if err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || entry.Name() == builderAreaFile || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+				continue
+			}
+			if info, err := entry.Info(); err == nil {
+				fmt.Fprintf(&areas, "%s:%d:%d;", entry.Name(), info.Size(), info.ModTime().UnixNano())
+			}
+		}
+	}
+	quests := ""
+	if info, err := os.Stat(filepath.Join(filepath.Dir(areasPath), questsFileName)); err == nil {
+		quests = fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+	}
+	return areas.String(), quests
+}
+
+// StartAreaWatcher polls the area and quest files every interval and
+// reloads whichever changed until stop is closed. A zero interval disables
+// the watcher.
+func (w *World) StartAreaWatcher(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return
+	}
+	go func() {
+		areas, quests := areaFingerprint(areasPath)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				nextAreas, nextQuests := areaFingerprint(areasPath)
+				if nextAreas != areas {
+					areas = nextAreas
+					report, err := w.ReloadAreas()
+					if err != nil {
+						Logger().Error("area reload failed", "error", err)
+					} else {
+						Logger().Info("areas reloaded", "added", len(report.Added), "removed", len(report.Removed), "rebuilt", len(report.Rebuilt))
+					}
+				}
+				if nextQuests != quests {
+					quests = nextQuests
+					count, err := w.ReloadQuests()
+					if err != nil {
+						Logger().Error("quest reload failed", "error", err)
+					} else {
+						Logger().Info("quests reloaded", "quests", count)
+					}
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReloadAreasRebuildsRoomsInPlace(t *testing.T) {
+	root := t.TempDir()
+	areas := filepath.Join(root, "areas")
+	if err := os.Mkdir(areas, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	write := func(area string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(areas, "core.json"), []byte(area), 0o644); err != nil {
+			t.Fatalf("write area: %v", err)
+		}
+	}
+	write(`{"name":"Core","rooms":[
+		{"id":"start","title":"Start","description":"","exits":{"north":"hall"}},
+		{"id":"hall","title":"Hall","description":"Bare stone.","exits":{"south":"start"}},
+		{"id":"cellar","title":"Cellar","description":"","exits":{}}]}`)
+	world, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	visitor := &Player{Name: "Visitor", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	lurker := &Player{Name: "Lurker", Room: "cellar", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(visitor)
+	world.AddPlayerForTest(lurker)
+	hall, _ := world.GetRoom("hall")
+	hall.Items = append(hall.Items, Item{Name: "Dropped Glove"})
+	start, _ := world.GetRoom(StartRoom)
+
+	write(`{"name":"Core","rooms":[
+		{"id":"start","title":"Start","description":"","exits":{"north":"hall"}},
+		{"id":"hall","title":"Great Hall","description":"Banners now hang here.","exits":{"south":"start","east":"garden"}},
+		{"id":"garden","title":"Garden","description":"","exits":{"west":"hall"}}]}`)
+	report, err := world.ReloadAreas()
+	if err != nil {
+		t.Fatalf("ReloadAreas: %v", err)
+	}
+	if len(report.Added) != 1 || report.Added[0] != "garden" {
+		t.Fatalf("expected garden added, got %+v", report)
+	}
+	if len(report.Removed) != 1 || report.Removed[0] != "cellar" {
+		t.Fatalf("expected cellar removed, got %+v", report)
+	}
+	if len(report.Rebuilt) != 1 || report.Rebuilt[0] != "hall" || report.Unchanged != 1 {
+		t.Fatalf("expected only hall rebuilt, got %+v", report)
+	}
+
+	rebuilt, _ := world.GetRoom("hall")
+	if rebuilt != hall || rebuilt.Title != "Great Hall" {
+		t.Fatalf("expected occupied hall to be updated in place, got %+v", rebuilt)
+	}
+	if _, ok := rebuilt.Exits["east"]; !ok {
+		t.Fatalf("expected new exit on rebuilt room, got %+v", rebuilt.Exits)
+	}
+	if findItemIndex(rebuilt.Items, "Dropped Glove") == -1 {
+		t.Fatalf("expected live items to survive the rebuild, got %+v", rebuilt.Items)
+	}
+	if same, _ := world.GetRoom(StartRoom); same != start {
+		t.Fatalf("expected unchanged room to be kept")
+	}
+	if lurker.Room != StartRoom {
+		t.Fatalf("expected player in removed room to be moved, got %s", lurker.Room)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(visitor.Output), "\n")); !strings.Contains(out, "rebuilt around you") || !strings.Contains(out, "Great Hall") {
+		t.Fatalf("expected visitor to be shown the rebuilt room, got %q", out)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(lurker.Output), "\n")); !strings.Contains(out, "somewhere familiar") {
+		t.Fatalf("expected displaced player to be told, got %q", out)
+	}
+}
+
+func TestReloadQuestsAndScripts(t *testing.T) {
+	root := t.TempDir()
+	areas := filepath.Join(root, "areas")
+	if err := os.Mkdir(areas, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeSnapshotTestArea(t, areas)
+	world, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	quests := `{"quests":[{"id":"first_steps","name":"First Steps","giver":"Guide"}]}`
+	if err := os.WriteFile(filepath.Join(root, questsFileName), []byte(quests), 0o644); err != nil {
+		t.Fatalf("write quests: %v", err)
+	}
+	count, err := world.ReloadQuests()
+	if err != nil || count != 1 {
+		t.Fatalf("ReloadQuests = %d, %v", count, err)
+	}
+	if got := world.QuestsByNPC("Guide"); len(got) != 1 {
+		t.Fatalf("expected reloaded quest to be indexed, got %+v", got)
+	}
+
+	if _, err := world.scripts.scriptFor("package main\nfunc OnEnter(ctx map[string]any) {}"); err != nil {
+		t.Fatalf("scriptFor: %v", err)
+	}
+	if _, err := world.ScheduleEvery("room:start", StartRoom, MinTimerInterval, "script", func() {}); err != nil {
+		t.Fatalf("ScheduleEvery: %v", err)
+	}
+	if _, err := world.ScheduleEvery(BuilderTimerOwner("Ada"), StartRoom, MinTimerInterval, "hello", func() {}); err != nil {
+		t.Fatalf("ScheduleEvery: %v", err)
+	}
+	scripts, timers := world.ReloadScripts()
+	if scripts != 1 || timers != 1 {
+		t.Fatalf("ReloadScripts = %d scripts, %d timers", scripts, timers)
+	}
+	if left := world.Timers(); len(left) != 1 || left[0].Owner != BuilderTimerOwner("Ada") {
+		t.Fatalf("expected builder timer to survive, got %+v", left)
+	}
+	world.CancelTimers(BuilderTimerOwner("Ada"))
+}
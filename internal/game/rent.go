@@ -0,0 +1,209 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRentFreeItems is how many vault items an account stores
+	// without rent.
+	DefaultRentFreeItems = 5
+	// RentPeriod is how often vault rent falls due.
+	RentPeriod = 24 * time.Hour
+	// RentGracePeriod is how long rent may go unpaid before the bank sells
+	// stored items to settle it.
+	RentGracePeriod = 7 * 24 * time.Hour
+	// rentMailAuthor signs the letters the bank sends about rent.
+	rentMailAuthor = "Bank"
+)
+
+// RentNotice reports what happened to an account's vault rent when one of
+// its characters logged in.
+type RentNotice struct {
+	// Paid is the rent collected from the character's purse and the bank.
+	Paid int
+	// Owed is the rent still unpaid, due by Deadline.
+	Owed     int
+	Deadline time.Time
+	// Sold lists the vault items the bank sold to settle overdue rent.
+	Sold []Item
+}
+
+// rentable reports whether item is charged vault rent.
+func rentable(item Item) bool {
+	return !item.NoRent
+}
+
+// dailyRentLocked is the rent a vault holding items owes each RentPeriod,
+// after the free allowance and the gold sink rate.
+func (w *World) dailyRentLocked(vault []Item) int {
+	tunables := w.tunablesLocked()
+	if tunables.RentPerDay <= 0 {
+		return 0
+	}
+	charged := -tunables.RentFreeItems
+	for _, item := range vault {
+		if rentable(item) {
+			charged++
+		}
+	}
+	if charged <= 0 {
+		return 0
+	}
+	return w.sinkGoldLocked(tunables.RentPerDay * charged)
+}
+
+// accrueRent adds the rent for each whole period since the bank's rent was
+// last settled. A vault that owes nothing restarts the clock, so rent never
+// falls due for time it spent empty.
+func accrueRent(bank *Bank, now time.Time, daily int) {
+	if daily <= 0 || bank.RentPaid.IsZero() {
+		bank.RentPaid = now
+		return
+	}
+	periods := int(now.Sub(bank.RentPaid) / RentPeriod)
+	if periods <= 0 {
+		return
+	}
+	bank.RentOwed += periods * daily
+	bank.RentPaid = bank.RentPaid.Add(time.Duration(periods) * RentPeriod)
+}
+
+// VaultRent reports the rent the account's vault costs each day and what is
+// still owed.
+func (w *World) VaultRent(bank Bank) (int, int) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.dailyRentLocked(bank.Vault), bank.RentOwed
+}
+
+// CollectRent settles the vault rent of p's account, called when p logs in.
+// It does nothing while rent is off.
+// Rent comes out of p's purse first and then the bank. Rent left unpaid is
+// announced by mail, and once it has gone unpaid for RentGracePeriod the bank
+// sells stored items at shop prices to cover it, banking anything left over.
+func (w *World) CollectRent(p *Player, now time.Time) RentNotice {
+	var notice RentNotice
+	var warn bool
+	w.mu.Lock()
+	accounts := w.accounts
+	if accounts == nil || p.Account == "" {
+		w.mu.Unlock()
+		return notice
+	}
+	rentOn := w.tunablesLocked().RentPerDay > 0
+	if bank, ok := accounts.Bank(p.Account); !ok || (!rentOn && bank.RentPaid.IsZero() && bank.RentOwed == 0) {
+		w.mu.Unlock()
+		return notice
+	}
+	err := accounts.updateBank(p.Account, func(bank *Bank) error {
+		if !rentOn {
+			// Turning rent off forgives what was owed, and the clock
+			// starts afresh if it is turned back on.
+			bank.RentPaid, bank.RentOwed, bank.RentOverdue = time.Time{}, 0, time.Time{}
+			return nil
+		}
+		accrueRent(bank, now, w.dailyRentLocked(bank.Vault))
+		if bank.RentOwed == 0 {
+			bank.RentOverdue = time.Time{}
+			return nil
+		}
+		fromPurse := min(p.Gold, bank.RentOwed)
+		fromBank := min(bank.Gold, bank.RentOwed-fromPurse)
+		p.Gold -= fromPurse
+		bank.Gold -= fromBank
+		bank.RentOwed -= fromPurse + fromBank
+		notice.Paid = fromPurse + fromBank
+		if bank.RentOwed > 0 && bank.RentOverdue.IsZero() {
+			bank.RentOverdue = now
+			warn = true
+		}
+		if bank.RentOwed > 0 && now.Sub(bank.RentOverdue) >= RentGracePeriod {
+			notice.Sold = sellForRent(bank)
+		}
+		if bank.RentOwed == 0 {
+			bank.RentOverdue = time.Time{}
+		}
+		notice.Owed = bank.RentOwed
+		if notice.Owed > 0 {
+			notice.Deadline = bank.RentOverdue.Add(RentGracePeriod)
+		}
+		return nil
+	})
+	if err != nil {
+		w.mu.Unlock()
+		Logger().Error("collect rent failed", "account", p.Account, "error", err)
+		return RentNotice{}
+	}
+	w.recordGoldLocked(EconomyRent, -notice.Paid)
+	key, snapshot := profileSnapshot(p)
+	mail := w.mail
+	w.mu.Unlock()
+	if notice.Paid > 0 {
+		w.persistPlayerState(key, snapshot)
+	}
+	if mail != nil && (warn || len(notice.Sold) > 0) {
+		if _, err := mail.Deliver(rentMailAuthor, p.Name, rentLetter(notice), nil, 0); err != nil {
+			Logger().Error("send rent letter failed", "player", p.Name, "error", err)
+		}
+	}
+	if len(notice.Sold) > 0 {
+		Logger().Info("vault items sold for rent", "account", p.Account, "items", len(notice.Sold))
+	}
+	return notice
+}
+
+// FormatRentNotice describes a RentNotice to the player who logged in.
+func FormatRentNotice(notice RentNotice) []string {
+	var lines []string
+	if notice.Paid > 0 {
+		lines = append(lines, fmt.Sprintf("The bank collects %d gold in vault rent.", notice.Paid))
+	}
+	if notice.Owed > 0 {
+		lines = append(lines, Style(fmt.Sprintf("Your vault still owes %d gold in rent. Stored items will be sold to cover it after %s.",
+			notice.Owed, notice.Deadline.Local().Format("2006-01-02 15:04")), AnsiYellow))
+	}
+	if len(notice.Sold) > 0 {
+		lines = append(lines, Style(fmt.Sprintf("The bank sold %d item(s) from your vault to settle overdue rent. Check your mail.", len(notice.Sold)), AnsiYellow))
+	}
+	return lines
+}
+
+// sellForRent sells rentable vault items, newest first, until the rent owed
+// is covered. Proceeds beyond the debt are banked, and a debt the vault can
+// no longer cover is written off.
+func sellForRent(bank *Bank) []Item {
+	var sold []Item
+	for i := len(bank.Vault) - 1; i >= 0 && bank.RentOwed > 0; i-- {
+		item := bank.Vault[i]
+		if !rentable(item) {
+			continue
+		}
+		bank.Vault = append(bank.Vault[:i:i], bank.Vault[i+1:]...)
+		sold = append(sold, item)
+		proceeds := sellPrice(item, 0)
+		covered := min(proceeds, bank.RentOwed)
+		bank.RentOwed -= covered
+		bank.Gold += proceeds - covered
+	}
+	bank.RentOwed = 0
+	return sold
+}
+
+// rentLetter writes the bank's letter about unpaid rent or items sold to
+// settle it.
+func rentLetter(notice RentNotice) string {
+	if len(notice.Sold) > 0 {
+		names := make([]string, len(notice.Sold))
+		for i, item := range notice.Sold {
+			names[i] = item.Name
+		}
+		return fmt.Sprintf("Your vault rent went unpaid for %d days, so the following were sold to settle it: %s.",
+			int(RentGracePeriod/RentPeriod), strings.Join(names, ", "))
+	}
+	return fmt.Sprintf("Your vault owes %d gold in rent. It is collected from your purse and bank when you log in; "+
+		"if it is still unpaid by %s, stored items will be sold to cover it.",
+		notice.Owed, notice.Deadline.UTC().Format("2006-01-02 15:04 UTC"))
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stockVault(t *testing.T, accounts *AccountManager, paid time.Time, gold int, items ...Item) {
+	t.Helper()
+	if err := accounts.updateBank("saver", func(bank *Bank) error {
+		bank.Gold = gold
+		bank.Vault = items
+		bank.RentPaid = paid
+		return nil
+	}); err != nil {
+		t.Fatalf("updateBank: %v", err)
+	}
+}
+
+func TestRentCollectsFromPurseThenBank(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"vault": {ID: "vault", Title: "Counting House", NPCs: []NPC{{Name: "Teller", Banker: true}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("saver", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	player := &Player{Name: "saver", Account: "saver", Room: "vault", Output: NewOutputQueue(), Alive: true, Gold: 40}
+	world.AddPlayerForTest(player)
+	mail, err := NewMailSystem("")
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	tunables := DefaultTunables()
+	tunables.RentPerDay = 3
+	tunables.RentFreeItems = 1
+	world.ConfigureTunables(tunables)
+	now := time.Now()
+	// Three items, one exempt and one free: 3 gold a day for one item.
+	stockVault(t, accounts, now.Add(-50*time.Hour), 100, Item{Name: "Lamp"}, Item{Name: "Map"}, Item{Name: "Heirloom", NoRent: true})
+	if daily, _ := world.VaultRent(mustBank(t, accounts)); daily != 3 {
+		t.Fatalf("daily rent = %d, want 3", daily)
+	}
+	player.Gold = 4
+
+	notice := world.CollectRent(player, now)
+	if notice.Paid != 6 || notice.Owed != 0 || player.Gold != 0 {
+		t.Fatalf("unexpected notice %+v with %d gold carried", notice, player.Gold)
+	}
+	bank := mustBank(t, accounts)
+	if bank.Gold != 98 || bank.RentOwed != 0 || now.Sub(bank.RentPaid) != 2*time.Hour {
+		t.Fatalf("unexpected bank %+v", bank)
+	}
+	if report := world.EconomyReport(); report.Total.Destroyed != 6 {
+		t.Fatalf("expected rent in the economy ledger, got %+v", report.Flows)
+	}
+	if letters := mail.MessagesForPlayer(PersonalMailBoard, "saver"); len(letters) != 0 {
+		t.Fatalf("paid rent should not send mail, got %+v", letters)
+	}
+}
+
+func TestUnpaidRentWarnsThenSellsItemsAfterGrace(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"vault": {ID: "vault", Title: "Counting House", NPCs: []NPC{{Name: "Teller", Banker: true}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("saver", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	player := &Player{Name: "saver", Account: "saver", Room: "vault", Output: NewOutputQueue(), Alive: true, Gold: 40}
+	world.AddPlayerForTest(player)
+	mail, err := NewMailSystem("")
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	tunables := DefaultTunables()
+	tunables.RentPerDay = 3
+	tunables.RentFreeItems = 1
+	world.ConfigureTunables(tunables)
+	start := time.Now()
+	stockVault(t, accounts, start.Add(-72*time.Hour), 0, Item{Name: "Lamp", Price: 10}, Item{Name: "Silver Harp", Price: 200})
+	player.Gold = 0
+
+	notice := world.CollectRent(player, start)
+	if notice.Owed != 9 || notice.Deadline != start.Add(RentGracePeriod) {
+		t.Fatalf("expected 9 gold owed with a deadline, got %+v", notice)
+	}
+	letters := mail.MessagesForPlayer(PersonalMailBoard, "saver")
+	if len(letters) != 1 || letters[0].Author != "Bank" || !strings.Contains(letters[0].Body, "owes 9 gold") {
+		t.Fatalf("expected a warning letter, got %+v", letters)
+	}
+	if notice := world.CollectRent(player, start.Add(time.Hour)); notice.Owed != 9 || len(mail.MessagesForPlayer(PersonalMailBoard, "saver")) != 1 {
+		t.Fatalf("expected no second warning, got %+v", notice)
+	}
+
+	notice = world.CollectRent(player, start.Add(RentGracePeriod))
+	if len(notice.Sold) != 1 || notice.Sold[0].Name != "Silver Harp" || notice.Owed != 0 {
+		t.Fatalf("expected the harp to be sold, got %+v", notice)
+	}
+	bank := mustBank(t, accounts)
+	// Seven more days at 3 gold bring the debt to 30, and the harp fetches
+	// 80, so 50 is banked.
+	if len(bank.Vault) != 1 || bank.RentOwed != 0 || !bank.RentOverdue.IsZero() || bank.Gold != sellPrice(Item{Price: 200}, 0)-30 {
+		t.Fatalf("unexpected bank after the sale %+v", bank)
+	}
+	if letters := mail.MessagesForPlayer(PersonalMailBoard, "saver"); len(letters) != 2 || !strings.Contains(letters[1].Body, "Silver Harp") {
+		t.Fatalf("expected a letter listing the sale, got %+v", letters)
+	}
+}
+
+func TestRentOffForgivesDebt(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"vault": {ID: "vault", Title: "Counting House", NPCs: []NPC{{Name: "Teller", Banker: true}}},
+	})
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("saver", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world.AttachAccountManager(accounts)
+	player := &Player{Name: "saver", Account: "saver", Room: "vault", Output: NewOutputQueue(), Alive: true, Gold: 40}
+	world.AddPlayerForTest(player)
+	mail, err := NewMailSystem("")
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	tunables := DefaultTunables()
+	tunables.RentPerDay = 3
+	tunables.RentFreeItems = 1
+	world.ConfigureTunables(tunables)
+	stockVault(t, accounts, time.Now().Add(-48*time.Hour), 0, Item{Name: "Lamp"}, Item{Name: "Map"})
+	player.Gold = 0
+	world.CollectRent(player, time.Now())
+	world.ConfigureTunables(DefaultTunables())
+	world.CollectRent(player, time.Now())
+	if bank := mustBank(t, accounts); bank.RentOwed != 0 || !bank.RentPaid.IsZero() {
+		t.Fatalf("expected turning rent off to clear it, got %+v", bank)
+	}
+}
+
+func mustBank(t *testing.T, accounts *AccountManager) Bank {
+	t.Helper()
+	bank, ok := accounts.Bank("saver")
+	if !ok {
+		t.Fatalf("missing bank")
+	}
+	return bank
+}
@@ -0,0 +1,330 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ReportContextLines is how many of the player's recent commands are
+	// attached to each report.
+	ReportContextLines = 5
+	// MaxReportLength caps the text of a single report.
+	MaxReportLength = 1000
+	// reportMailAuthor signs the letters sent when a report is resolved.
+	reportMailAuthor = "Staff"
+)
+
+// ReportKind classifies a player report.
+type ReportKind string
+
+// Report kinds players may file.
+const (
+	ReportBug  ReportKind = "bug"
+	ReportTypo ReportKind = "typo"
+	ReportIdea ReportKind = "idea"
+)
+
+// ReportKinds lists every report kind in display order.
+func ReportKinds() []ReportKind {
+	return []ReportKind{ReportBug, ReportTypo, ReportIdea}
+}
+
+// ParseReportKind matches name against the known report kinds.
+func ParseReportKind(name string) (ReportKind, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, kind := range ReportKinds() {
+		if string(kind) == name {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+var (
+	// ErrReportNotFound indicates the report does not exist.
+	ErrReportNotFound = errors.New("report not found")
+	// ErrReportResolved indicates the report was already resolved.
+	ErrReportResolved = errors.New("report already resolved")
+)
+
+// Report is a bug, typo, or idea filed by a player, along with where they
+// stood and what they had just typed.
+type Report struct {
+	ID         int        `json:"id"`
+	Kind       ReportKind `json:"kind"`
+	Reporter   string     `json:"reporter"`
+	Room       RoomID     `json:"room"`
+	Text       string     `json:"text"`
+	Context    []string   `json:"context,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedBy string     `json:"resolved_by,omitempty"`
+	ResolvedAt time.Time  `json:"resolved_at,omitempty"`
+	Resolution string     `json:"resolution,omitempty"`
+}
+
+// Open reports whether the report still awaits staff attention.
+func (r Report) Open() bool {
+	return r.ResolvedBy == ""
+}
+
+// ReportFilter narrows a report listing. Empty fields match everything.
+type ReportFilter struct {
+	Kind ReportKind
+	// Resolved includes resolved reports alongside open ones.
+	Resolved bool
+	Limit    int
+}
+
+// ReportSystem persists player reports for staff review.
+type ReportSystem struct {
+	mu      sync.RWMutex
+	path    string
+	nextID  int
+	reports []Report
+}
+
+type reportFile struct {
+	NextID  int      `json:"next_id"`
+	Reports []Report `json:"reports"`
+}
+
+// NewReportSystem loads the reports stored at path. When path is empty the
+// reports are kept in memory only.
+func NewReportSystem(path string) (*ReportSystem, error) {
+	reports := &ReportSystem{path: path, nextID: 1}
+	if strings.TrimSpace(path) == "" {
+		return reports, nil
+	}
+	data, err := documentStorage().Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return reports, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read reports file: %w", err)
+	}
+	if len(data) == 0 {
+		return reports, nil
+	}
+	var record reportFile
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decode reports file: %w", err)
+	}
+	reports.reports = record.Reports
+	reports.nextID = record.NextID
+	for _, report := range reports.reports {
+		if report.ID >= reports.nextID {
+			reports.nextID = report.ID + 1
+		}
+	}
+	return reports, nil
+}
+
+// File records a new report and returns it with its ID assigned.
+func (s *ReportSystem) File(kind ReportKind, reporter string, room RoomID, text string, context []string) (Report, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Report{}, fmt.Errorf("describe what you want to report")
+	}
+	if len(text) > MaxReportLength {
+		return Report{}, fmt.Errorf("reports are limited to %d characters", MaxReportLength)
+	}
+	report := Report{
+		Kind:      kind,
+		Reporter:  reporter,
+		Room:      room,
+		Text:      text,
+		Context:   append([]string(nil), context...),
+		CreatedAt: time.Now().UTC(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report.ID = s.nextID
+	s.nextID++
+	s.reports = append(s.reports, report)
+	if err := s.saveLocked(); err != nil {
+		s.reports = s.reports[:len(s.reports)-1]
+		s.nextID--
+		return Report{}, err
+	}
+	return report, nil
+}
+
+// Reports lists the reports matching filter, oldest first so the queue is
+// worked in the order it was filed.
+func (s *ReportSystem) Reports(filter ReportFilter) []Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []Report{}
+	for _, report := range s.reports {
+		if filter.Kind != "" && report.Kind != filter.Kind {
+			continue
+		}
+		if !filter.Resolved && !report.Open() {
+			continue
+		}
+		report.Context = append([]string(nil), report.Context...)
+		out = append(out, report)
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out
+}
+
+// Report returns the report with the given ID.
+func (s *ReportSystem) Report(id int) (Report, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, report := range s.reports {
+		if report.ID == id {
+			report.Context = append([]string(nil), report.Context...)
+			return report, true
+		}
+	}
+	return Report{}, false
+}
+
+// Resolve closes a report on behalf of staff with an optional note for the
+// reporter.
+func (s *ReportSystem) Resolve(id int, staff, note string) (Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.reports {
+		if s.reports[i].ID != id {
+			continue
+		}
+		if !s.reports[i].Open() {
+			return Report{}, ErrReportResolved
+		}
+		previous := s.reports[i]
+		s.reports[i].ResolvedBy = staff
+		s.reports[i].ResolvedAt = time.Now().UTC()
+		s.reports[i].Resolution = strings.TrimSpace(note)
+		if err := s.saveLocked(); err != nil {
+			s.reports[i] = previous
+			return Report{}, err
+		}
+		return s.reports[i], nil
+	}
+	return Report{}, ErrReportNotFound
+}
+
+// OpenCounts tallies unresolved reports by kind.
+func (s *ReportSystem) OpenCounts() map[ReportKind]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	counts := make(map[ReportKind]int)
+	for _, report := range s.reports {
+		if report.Open() {
+			counts[report.Kind]++
+		}
+	}
+	return counts
+}
+
+func (s *ReportSystem) saveLocked() error {
+	if strings.TrimSpace(s.path) == "" {
+		return nil
+	}
+	data, err := encodeDocument(reportFile{NextID: s.nextID, Reports: s.reports})
+	if err != nil {
+		return fmt.Errorf("encode reports file: %w", err)
+	}
+	if err := documentStorage().Write(s.path, data); err != nil {
+		return fmt.Errorf("write reports file: %w", err)
+	}
+	return nil
+}
+
+// AttachReportSystem connects the report queue to the world.
+func (w *World) AttachReportSystem(reports *ReportSystem) {
+	w.mu.Lock()
+	w.reports = reports
+	w.mu.Unlock()
+}
+
+// ReportSystem exposes the report queue, when configured.
+func (w *World) ReportSystem() *ReportSystem {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.reports
+}
+
+// FileReport records a report from p, capturing their room and recent
+// commands, and alerts online staff.
+func (w *World) FileReport(p *Player, kind ReportKind, text string) (Report, error) {
+	w.mu.RLock()
+	reports := w.reports
+	room := p.Room
+	w.mu.RUnlock()
+	if reports == nil {
+		return Report{}, fmt.Errorf("reports are unavailable")
+	}
+	report, err := reports.File(kind, p.Name, room, sanitizeInput(text), p.RecentCommands())
+	if err != nil {
+		return Report{}, err
+	}
+	alert := Ansi(fmt.Sprintf("\r\n%s %s filed %s #%d in %s.", Style("[REPORT]", AnsiCyan, AnsiBold), HighlightName(p.Name), report.Kind, report.ID, report.Room))
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, target := range w.players {
+		if target == p || !target.Alive || !target.IsStaff() || target.Output == nil {
+			continue
+		}
+		target.Output.Send(alert)
+	}
+	return report, nil
+}
+
+// ResolveReport closes a report and lets the reporter know by letter.
+func (w *World) ResolveReport(id int, staff, note string) (Report, error) {
+	w.mu.RLock()
+	reports := w.reports
+	mail := w.mail
+	w.mu.RUnlock()
+	if reports == nil {
+		return Report{}, fmt.Errorf("reports are unavailable")
+	}
+	report, err := reports.Resolve(id, staff, sanitizeInput(note))
+	if err != nil {
+		return Report{}, err
+	}
+	if mail != nil {
+		body := fmt.Sprintf("Your %s report #%d (%q) has been resolved by %s.", report.Kind, report.ID, report.Text, report.ResolvedBy)
+		if report.Resolution != "" {
+			body += " " + report.Resolution
+		}
+		if _, err := mail.Deliver(reportMailAuthor, report.Reporter, body, nil, 0); err != nil {
+			Logger().Error("report resolution letter failed", "report", report.ID, "error", err)
+		}
+	}
+	return report, nil
+}
+
+// RememberCommand adds line to the recent commands attached to the player's
+// reports. It shares the link lock with the rest of the input state.
+func (p *Player) RememberCommand(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	p.linkMu.Lock()
+	defer p.linkMu.Unlock()
+	p.recentCommands = append(p.recentCommands, line)
+	if excess := len(p.recentCommands) - ReportContextLines; excess > 0 {
+		p.recentCommands = append([]string(nil), p.recentCommands[excess:]...)
+	}
+}
+
+// RecentCommands returns the player's last few commands, oldest first.
+func (p *Player) RecentCommands() []string {
+	p.linkMu.Lock()
+	defer p.linkMu.Unlock()
+	return append([]string(nil), p.recentCommands...)
+}
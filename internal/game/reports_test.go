@@ -0,0 +1,124 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportSystemPersistsAndResolves(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reports.json")
+	reports, err := NewReportSystem(path)
+	if err != nil {
+		t.Fatalf("NewReportSystem: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	mail, err := NewMailSystem(filepath.Join(dir, "mail.json"))
+	if err != nil {
+		t.Fatalf("NewMailSystem: %v", err)
+	}
+	world.AttachMailSystem(mail)
+	world.AttachReportSystem(reports)
+	reporter := &Player{Name: "Finder", Room: "start", Alive: true, Output: NewOutputQueue()}
+	builder := &Player{Name: "Mason", Room: "start", Alive: true, IsBuilder: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(reporter)
+	world.AddPlayerForTest(builder)
+
+	for i := 0; i < ReportContextLines+2; i++ {
+		reporter.RememberCommand("look " + string(rune('a'+i)))
+	}
+	bug, err := world.FileReport(reporter, ReportBug, "The fountain swallows my coins.")
+	if err != nil {
+		t.Fatalf("FileReport: %v", err)
+	}
+	if bug.ID != 1 || bug.Room != "start" || len(bug.Context) != ReportContextLines || bug.Context[0] != "look c" {
+		t.Fatalf("unexpected report %+v", bug)
+	}
+	if out := stripAnsi(strings.Join(drainOutput(builder.Output), "")); !strings.Contains(out, "Finder filed bug #1") {
+		t.Fatalf("expected staff to be alerted, got %q", out)
+	}
+	if _, err := world.FileReport(reporter, ReportTypo, "   "); err == nil {
+		t.Fatalf("expected an empty report to be rejected")
+	}
+	if _, err := world.FileReport(reporter, ReportTypo, "Teh gate."); err != nil {
+		t.Fatalf("FileReport: %v", err)
+	}
+
+	if _, err := world.ResolveReport(bug.ID, "Mason", "Fixed the drain."); err != nil {
+		t.Fatalf("ResolveReport: %v", err)
+	}
+	if _, err := world.ResolveReport(bug.ID, "Mason", ""); err != ErrReportResolved {
+		t.Fatalf("expected a second resolve to fail, got %v", err)
+	}
+	if _, err := world.ResolveReport(99, "Mason", ""); err != ErrReportNotFound {
+		t.Fatalf("expected a missing report to fail, got %v", err)
+	}
+	letters := mail.MessagesForPlayer(PersonalMailBoard, "Finder")
+	if len(letters) != 1 || letters[0].Author != reportMailAuthor || !strings.Contains(letters[0].Body, "Fixed the drain.") {
+		t.Fatalf("expected a resolution letter, got %+v", letters)
+	}
+
+	reloaded, err := NewReportSystem(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if open := reloaded.Reports(ReportFilter{}); len(open) != 1 || open[0].Kind != ReportTypo {
+		t.Fatalf("expected only the typo to be open, got %+v", open)
+	}
+	if all := reloaded.Reports(ReportFilter{Resolved: true, Kind: ReportBug}); len(all) != 1 || all[0].ResolvedBy != "Mason" {
+		t.Fatalf("expected the resolved bug, got %+v", all)
+	}
+	if next, err := reloaded.File(ReportIdea, "Finder", "start", "More fountains.", nil); err != nil || next.ID != 3 {
+		t.Fatalf("expected IDs to continue after reload, got %+v, %v", next, err)
+	}
+}
+
+func TestPortalReportQueue(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{"start": {ID: "start", Exits: map[string]Exit{}}})
+	reports, _ := NewReportSystem("")
+	world.AttachReportSystem(reports)
+	if _, err := reports.File(ReportIdea, "Finder", "start", "Add boats.", []string{"look"}); err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	portal := &PortalServer{
+		world:      world,
+		sessionTTL: time.Hour,
+		tokens:     make(map[string]portalToken),
+		sessions: map[string]portalSession{
+			"player": {Role: PortalRolePlayer, Player: "Finder", Expires: time.Now().Add(time.Hour)},
+			"mod":    {Role: PortalRoleModerator, Player: "Warden", Expires: time.Now().Add(time.Hour)},
+		},
+		documents: make(map[string]portalDocument),
+	}
+
+	if rec := doInboxRequest(t, portal.handleReportsAPI, "player", http.MethodGet, "/api/reports", nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("player listing status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	var views []portalReportView
+	rec := doInboxRequest(t, portal.handleReportsAPI, "mod", http.MethodGet, "/api/reports?kind=idea", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &views); err != nil {
+		t.Fatalf("decode reports: %v", err)
+	}
+	if len(views) != 1 || views[0].Text != "Add boats." || views[0].Context[0] != "look" {
+		t.Fatalf("unexpected queue %+v", views)
+	}
+
+	rec = doInboxRequest(t, portal.handleReportResolveAPI, "mod", http.MethodPost, "/api/reports/resolve", map[string]any{"id": 1, "note": "Planned."})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("resolve status = %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = doInboxRequest(t, portal.handleReportResolveAPI, "mod", http.MethodPost, "/api/reports/resolve", map[string]any{"id": 1})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("second resolve status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if open := reports.Reports(ReportFilter{}); len(open) != 0 {
+		t.Fatalf("expected the queue to be empty, got %+v", open)
+	}
+	if report, _ := reports.Report(1); report.ResolvedBy != "Warden" || report.Resolution != "Planned." {
+		t.Fatalf("unexpected resolution %+v", report)
+	}
+}
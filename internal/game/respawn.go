@@ -0,0 +1,208 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRespawnDelay is how long a defeated reset NPC stays gone when
+	// its reset sets no delay of its own.
+	DefaultRespawnDelay = 5 * time.Minute
+	// respawnSweep is how often the world checks for NPCs due to return.
+	respawnSweep = 10 * time.Second
+)
+
+func (r RoomReset) respawnDelay() time.Duration {
+	if r.Respawn > 0 {
+		return time.Duration(r.Respawn) * time.Second
+	}
+	return DefaultRespawnDelay
+}
+
+// npcFromReset builds the NPC an NPC reset in room places there.
+func npcFromReset(reset RoomReset, room RoomID) NPC {
+	npc := NPC{Name: reset.Name, AutoGreet: reset.AutoGreet, Script: reset.Script, Banker: reset.Banker, Trainer: reset.Trainer, Mount: reset.Mount, Guard: reset.Guard, Dealer: reset.Dealer, Healer: cloneHealer(reset.Healer), Abilities: cloneAbilities(reset.Abilities), Boss: cloneBoss(reset.Boss), Faction: reset.Faction, Shop: cloneItems(reset.Shop), Rares: cloneItems(reset.Rares), RareStock: reset.RareStock, Schedule: append([]ScheduleEntry(nil), reset.Schedule...)}
+	if len(npc.Schedule) > 0 {
+		npc.Home = room
+	}
+	normalizeNPC(&npc)
+	return npc
+}
+
+// rareFromReset builds the rare NPC an NPC reset may place instead of its
+// usual one.
+func rareFromReset(reset RoomReset) NPC {
+	npc := *reset.RareSpawn
+	npc.Abilities = cloneAbilities(npc.Abilities)
+	npc.Boss = cloneBoss(npc.Boss)
+	npc.Loot = cloneItems(npc.Loot)
+	normalizeNPC(&npc)
+	return npc
+}
+
+func respawnKey(room RoomID, name string) string {
+	return string(room) + "|" + strings.ToLower(strings.TrimSpace(name))
+}
+
+// resetPopulationLocked counts the NPCs in the world that fill reset's
+// slots in room: its NPC or its rare spawn standing there, or, for a
+// scheduled NPC, anywhere it calls room home.
+func (w *World) resetPopulationLocked(room *Room, reset RoomReset) int {
+	matches := func(npc NPC) bool {
+		if strings.EqualFold(npc.Name, reset.Name) {
+			return true
+		}
+		return reset.RareSpawn != nil && strings.EqualFold(npc.Name, reset.RareSpawn.Name)
+	}
+	if len(reset.Schedule) == 0 {
+		count := 0
+		for _, npc := range room.NPCs {
+			if matches(npc) {
+				count++
+			}
+		}
+		return count
+	}
+	count := 0
+	for _, other := range w.rooms {
+		for _, npc := range other.NPCs {
+			if npc.Home == room.ID && matches(npc) {
+				count++
+			}
+		}
+	}
+	for _, npc := range w.awayNPCs {
+		if npc.Home == room.ID && matches(npc) {
+			count++
+		}
+	}
+	return count
+}
+
+// validateRespawns checks that every rare spawn has a name and a chance
+// that can come up.
+func validateRespawns(rooms map[RoomID]*Room) error {
+	for id, room := range rooms {
+		for _, reset := range room.Resets {
+			if reset.RareSpawn == nil {
+				continue
+			}
+			if strings.TrimSpace(reset.RareSpawn.Name) == "" {
+				return fmt.Errorf("room %s: reset %s: rare spawn has no name", id, reset.Name)
+			}
+			if reset.RareChance < 1 || reset.RareChance > 100 {
+				return fmt.Errorf("room %s: reset %s: rare chance must be between 1 and 100", id, reset.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// respawnArrival is an NPC that returned to a room.
+type respawnArrival struct {
+	room RoomID
+	npc  string
+	rare bool
+}
+
+// RespawnTick starts a timer for every NPC reset that is missing an NPC and
+// brings back one NPC for each timer that has run out. Each returning NPC
+// may instead be the reset's rare spawn.
+func (w *World) RespawnTick(now time.Time) {
+	var arrivals []respawnArrival
+	w.mu.Lock()
+	for _, room := range w.rooms {
+		for _, reset := range room.Resets {
+			if reset.Kind != ResetKindNPC {
+				continue
+			}
+			key := respawnKey(room.ID, reset.Name)
+			if w.resetPopulationLocked(room, reset) >= max(1, reset.Count) {
+				delete(w.respawns, key)
+				continue
+			}
+			due, pending := w.respawns[key]
+			if !pending {
+				if w.respawns == nil {
+					w.respawns = make(map[string]time.Time)
+				}
+				w.respawns[key] = now.Add(reset.respawnDelay())
+				continue
+			}
+			if now.Before(due) {
+				continue
+			}
+			delete(w.respawns, key)
+			npc := npcFromReset(reset, room.ID)
+			rare := reset.RareSpawn != nil && w.roll(100) < reset.RareChance
+			if rare {
+				npc = rareFromReset(reset)
+			}
+			room.NPCs = append(room.NPCs, npc)
+			arrivals = append(arrivals, respawnArrival{room: room.ID, npc: npc.Name, rare: rare})
+		}
+	}
+	w.mu.Unlock()
+	for _, arrival := range arrivals {
+		message := fmt.Sprintf("\r\n%s arrives.", HighlightNPCName(arrival.npc))
+		if arrival.rare {
+			message = Style(fmt.Sprintf("\r\nA rare sight: %s has appeared!", HighlightNPCName(arrival.npc)), AnsiBold)
+		}
+		w.BroadcastToRoom(arrival.room, Ansi(message), nil)
+	}
+}
+
+// StartRespawnLoop periodically returns defeated reset NPCs until stop is
+// closed.
+func (w *World) StartRespawnLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(respawnSweep)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.RespawnTick(now)
+			}
+		}
+	}()
+}
+
+// SetResetRespawn sets how long the NPC reset named name in room waits
+// before bringing its NPC back, and the percent chance it brings back a
+// rare NPC named rare instead. An empty rare removes the rare spawn.
+func (w *World) SetResetRespawn(id RoomID, name string, delay time.Duration, rare string, chance int, editor string) error {
+	name = strings.TrimSpace(name)
+	rare = strings.TrimSpace(rare)
+	if delay < time.Second {
+		return fmt.Errorf("respawn delay must be at least a second")
+	}
+	if rare != "" && (chance < 1 || chance > 100) {
+		return fmt.Errorf("rare spawn chance must be between 1 and 100")
+	}
+	if findResetIndex(w.RoomResets(id), ResetKindNPC, name) < 0 {
+		return fmt.Errorf("no npc reset named %s here", name)
+	}
+	return w.editRoom(id, editor, func(room *Room) func() {
+		idx := findResetIndex(room.Resets, ResetKindNPC, name)
+		if idx < 0 {
+			return func() {}
+		}
+		previous := room.Resets[idx]
+		reset := &room.Resets[idx]
+		reset.Respawn = int(delay / time.Second)
+		reset.RareSpawn, reset.RareChance = nil, 0
+		if rare != "" {
+			reset.RareSpawn = &NPC{Name: rare}
+			reset.RareChance = chance
+		}
+		return func() {
+			if idx < len(room.Resets) {
+				room.Resets[idx] = previous
+			}
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRespawnReturnsDefeatedNPCAfterDelay(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{}, Resets: []RoomReset{
+			{Kind: ResetKindNPC, Name: "Wolf", Count: 1, Respawn: 60, RareSpawn: &NPC{Name: "Dire Wolf", Level: 4}, RareChance: 10},
+		}},
+	})
+	watcher := &Player{Name: "Watcher", Room: "den", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(watcher)
+	world.SetDice(DiceFunc(func(int) int { return 50 }))
+
+	start := time.Now()
+	world.RespawnTick(start)
+	world.RespawnTick(start.Add(30 * time.Second))
+	if den, _ := world.GetRoom("den"); len(den.NPCs) != 0 {
+		t.Fatalf("expected the wolf to stay gone until its timer runs out, got %+v", den.NPCs)
+	}
+	world.RespawnTick(start.Add(61 * time.Second))
+	den, _ := world.GetRoom("den")
+	if len(den.NPCs) != 1 || den.NPCs[0].Name != "Wolf" || den.NPCs[0].Health == 0 {
+		t.Fatalf("expected the wolf to return, got %+v", den.NPCs)
+	}
+	if output := stripAnsi(strings.Join(drainOutput(watcher.Output), "")); !strings.Contains(output, "Wolf arrives.") {
+		t.Fatalf("expected the return to be announced, got %q", output)
+	}
+
+	world.mu.Lock()
+	world.rooms["den"].NPCs = nil
+	world.mu.Unlock()
+	world.SetDice(DiceFunc(func(int) int { return 5 }))
+	world.RespawnTick(start.Add(2 * time.Minute))
+	world.RespawnTick(start.Add(4 * time.Minute))
+	den, _ = world.GetRoom("den")
+	if len(den.NPCs) != 1 || den.NPCs[0].Name != "Dire Wolf" || den.NPCs[0].Level != 4 {
+		t.Fatalf("expected the rare spawn, got %+v", den.NPCs)
+	}
+	world.RespawnTick(start.Add(10 * time.Minute))
+	if den, _ := world.GetRoom("den"); len(den.NPCs) != 1 {
+		t.Fatalf("the rare spawn should fill the reset, got %+v", den.NPCs)
+	}
+}
+
+func TestSetResetRespawn(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"den": {ID: "den", Title: "Den", Exits: map[string]Exit{}, Resets: []RoomReset{{Kind: ResetKindNPC, Name: "Wolf", Count: 1}}},
+	})
+	if err := world.SetResetRespawn("den", "Bear", time.Minute, "", 0, "Builder"); err == nil {
+		t.Fatalf("expected a missing reset to be refused")
+	}
+	if err := world.SetResetRespawn("den", "Wolf", time.Minute, "Dire Wolf", 0, "Builder"); err == nil {
+		t.Fatalf("expected a rare spawn without a chance to be refused")
+	}
+	if err := world.SetResetRespawn("den", "wolf", 90*time.Second, "Dire Wolf", 15, "Builder"); err != nil {
+		t.Fatalf("SetResetRespawn: %v", err)
+	}
+	reset := world.RoomResets("den")[0]
+	if reset.Respawn != 90 || reset.RareSpawn == nil || reset.RareSpawn.Name != "Dire Wolf" || reset.RareChance != 15 {
+		t.Fatalf("unexpected reset %+v", reset)
+	}
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// RestedXPPerHour is the rested bonus an account earns for each hour
+	// spent logged out.
+	RestedXPPerHour = 5
+	// RestedXPCap limits how much rested bonus an account can bank.
+	RestedXPCap = 150
+	// RestedMultiplier scales experience from kills and quests while the
+	// rested bonus lasts.
+	RestedMultiplier = 2
+)
+
+// restedAccrual converts time spent logged out into rested experience.
+func restedAccrual(away time.Duration) int {
+	if away <= 0 {
+		return 0
+	}
+	return int(away/time.Hour) * RestedXPPerHour
+}
+
+// restedBonusLocked spends p's rested pool on an award of amount experience
+// and returns the extra experience granted. Callers must hold the world lock.
+func restedBonusLocked(p *Player, amount int) int {
+	if amount <= 0 || p.RestedXP <= 0 {
+		return 0
+	}
+	bonus := amount * (RestedMultiplier - 1)
+	if bonus > p.RestedXP {
+		bonus = p.RestedXP
+	}
+	p.RestedXP -= bonus
+	return bonus
+}
+
+// RestedXP reports the rested bonus p has left this session.
+func (w *World) RestedXP(p *Player) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.RestedXP
+}
+
+// claimRestedXP hands the account's rested pool to p for the session.
+func (w *World) claimRestedXP(p *Player) {
+	accounts := w.accounts
+	if accounts == nil || p.Account == "" {
+		return
+	}
+	rested, err := accounts.ClaimRestedXP(p.Account, time.Now().UTC())
+	if err != nil {
+		Logger().Warn("failed to claim rested experience", "account", p.Account, "error", err)
+		return
+	}
+	w.mu.Lock()
+	p.RestedXP = rested
+	w.mu.Unlock()
+}
+
+// recordLogout banks p's playtime and unspent rested bonus on their account.
+func (w *World) recordLogout(p *Player) {
+	accounts := w.accounts
+	if accounts == nil || p.Account == "" {
+		return
+	}
+	now := time.Now().UTC()
+	var played time.Duration
+	if !p.JoinedAt.IsZero() && now.After(p.JoinedAt) {
+		played = now.Sub(p.JoinedAt)
+	}
+	w.mu.RLock()
+	rested := p.RestedXP
+	w.mu.RUnlock()
+	if err := accounts.RecordLogout(p.Account, now, played, rested); err != nil {
+		Logger().Warn("failed to record logout", "account", p.Account, "error", err)
+	}
+}
+
+// FormatExperienceGain describes an experience award and its rested bonus.
+func FormatExperienceGain(xp, rested int) string {
+	if rested > 0 {
+		return fmt.Sprintf("You gain %d experience (+%d rested).", xp, rested)
+	}
+	return fmt.Sprintf("You gain %d experience.", xp)
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRestedXPAccruesWhileAwayAndIsSpentOnAwards(t *testing.T) {
+	manager, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := manager.Register("Casual", "password123"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	start := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	if rested, err := manager.ClaimRestedXP("Casual", start); err != nil || rested != 0 {
+		t.Fatalf("first claim = %d, %v; want nothing banked", rested, err)
+	}
+	if err := manager.RecordLogout("Casual", start.Add(2*time.Hour), 2*time.Hour, 0); err != nil {
+		t.Fatalf("RecordLogout: %v", err)
+	}
+	rested, err := manager.ClaimRestedXP("Casual", start.Add(12*time.Hour))
+	if err != nil || rested != 10*RestedXPPerHour {
+		t.Fatalf("claim after ten hours away = %d, %v", rested, err)
+	}
+	if again, _ := manager.ClaimRestedXP("Casual", start.Add(20*time.Hour)); again != 0 {
+		t.Fatalf("a second claim without logging out should be empty, got %d", again)
+	}
+
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	p := &Player{Name: "Casual", Account: "Casual", Room: "hall", Alive: true, Output: NewOutputQueue(), RestedXP: rested}
+	world.AddPlayerForTest(p)
+	if _, bonus := world.AwardExperience(p, 30); bonus != 30 || p.Experience != 60 {
+		t.Fatalf("first award bonus = %d, experience = %d", bonus, p.Experience)
+	}
+	if _, bonus := world.AwardExperience(p, 30); bonus != 20 || world.RestedXP(p) != 0 {
+		t.Fatalf("second award bonus = %d, left = %d", bonus, world.RestedXP(p))
+	}
+
+	if err := manager.RecordLogout("Casual", start.Add(1000*time.Hour), 3*time.Hour, 7); err != nil {
+		t.Fatalf("RecordLogout: %v", err)
+	}
+	stats, _ := manager.Stats("Casual")
+	if stats.Playtime != 5*time.Hour || stats.RestedXP != 7 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if capped, _ := manager.ClaimRestedXP("Casual", start.Add(2000*time.Hour)); capped != RestedXPCap {
+		t.Fatalf("rested pool = %d, want the cap %d", capped, RestedXPCap)
+	}
+}
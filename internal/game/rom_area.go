@@ -0,0 +1,879 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AreaConversion is the result of converting an area between the LumenClay
+// JSON format and a ROM/Merc .are file.
+type AreaConversion struct {
+	Name     string
+	Rooms    int
+	Data     []byte
+	Warnings []string
+}
+
+// romDirections lists exit names by ROM direction number. Stock ROM stops at
+// down; the diagonals follow the common six-to-nine extension.
+var romDirections = []string{"n", "e", "s", "w", "u", "d", "ne", "nw", "se", "sw"}
+
+// romDirectionNumber maps LumenClay exit names to ROM direction numbers.
+var romDirectionNumber = map[string]int{
+	"n": 0, "north": 0,
+	"e": 1, "east": 1,
+	"s": 2, "south": 2,
+	"w": 3, "west": 3,
+	"u": 4, "up": 4,
+	"d": 5, "down": 5,
+}
+
+const (
+	romRoomIndoors  = 8 // ROOM_INDOORS, flag D
+	romSectorInside = 0
+	romSectorField  = 2
+)
+
+var romColorCode = regexp.MustCompile(`\{.`)
+
+// romReader tokenises .are files the way ROM's fread_* helpers do.
+type romReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *romReader) eof() bool {
+	return r.pos >= len(r.data)
+}
+
+func (r *romReader) skipSpace() {
+	for r.pos < len(r.data) && unicode.IsSpace(rune(r.data[r.pos])) {
+		r.pos++
+	}
+}
+
+// word returns the next whitespace separated token.
+func (r *romReader) word() string {
+	r.skipSpace()
+	start := r.pos
+	for r.pos < len(r.data) && !unicode.IsSpace(rune(r.data[r.pos])) {
+		r.pos++
+	}
+	return string(r.data[start:r.pos])
+}
+
+// number reads a ROM number, which may also be written as letter flags.
+func (r *romReader) number() int {
+	return romFlag(r.word())
+}
+
+// str reads a tilde terminated string.
+func (r *romReader) str() string {
+	r.skipSpace()
+	end := bytes.IndexByte(r.data[r.pos:], '~')
+	if end < 0 {
+		text := string(r.data[r.pos:])
+		r.pos = len(r.data)
+		return strings.ReplaceAll(text, "\r", "")
+	}
+	text := string(r.data[r.pos : r.pos+end])
+	r.pos += end + 1
+	return strings.ReplaceAll(text, "\r", "")
+}
+
+// line returns the rest of the current line.
+func (r *romReader) line() string {
+	start := r.pos
+	for r.pos < len(r.data) && r.data[r.pos] != '\n' {
+		r.pos++
+	}
+	text := string(r.data[start:r.pos])
+	if r.pos < len(r.data) {
+		r.pos++
+	}
+	return strings.TrimSpace(text)
+}
+
+// nextLineEndsWithTilde reports whether the next non-blank line holds a
+// complete string, as ROM's race line does.
+func (r *romReader) nextLineEndsWithTilde() bool {
+	save := r.pos
+	r.skipSpace()
+	text := r.line()
+	r.pos = save
+	return strings.HasSuffix(text, "~")
+}
+
+// skipToHash advances to the next line that starts with '#'.
+func (r *romReader) skipToHash() {
+	for !r.eof() {
+		if (r.pos == 0 || r.data[r.pos-1] == '\n') && r.data[r.pos] == '#' {
+			return
+		}
+		r.pos++
+	}
+}
+
+// skipSection advances to the next section header such as #ROOMS.
+func (r *romReader) skipSection() {
+	for !r.eof() {
+		r.skipToHash()
+		if r.pos+1 < len(r.data) {
+			next := r.data[r.pos+1]
+			if next == '$' || (next >= 'A' && next <= 'Z') {
+				return
+			}
+		}
+		r.pos++
+	}
+}
+
+// romFlag parses a number or a ROM letter flag such as "ABD" or "A|C".
+func romFlag(word string) int {
+	if n, err := strconv.Atoi(word); err == nil {
+		return n
+	}
+	total := 0
+	for _, part := range strings.Split(word, "|") {
+		if n, err := strconv.Atoi(part); err == nil {
+			total |= n
+			continue
+		}
+		for _, c := range part {
+			switch {
+			case c >= 'A' && c <= 'Z':
+				total |= 1 << (c - 'A')
+			case c >= 'a' && c <= 'z':
+				total |= 1 << (26 + c - 'a')
+			}
+		}
+	}
+	return total
+}
+
+// romText collapses ROM's hard-wrapped text into a single paragraph and
+// strips colour codes.
+func romText(text string) string {
+	return strings.Join(strings.Fields(romColorCode.ReplaceAllString(text, "")), " ")
+}
+
+// romName turns a short description such as "the cityguard" into a
+// LumenClay name such as "Cityguard".
+func romName(short string) string {
+	name := romText(short)
+	lower := strings.ToLower(name)
+	for _, article := range []string{"a ", "an ", "the ", "some "} {
+		if strings.HasPrefix(lower, article) && len(name) > len(article) {
+			name = name[len(article):]
+			break
+		}
+	}
+	if name == "" {
+		return ""
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func romRoomID(vnum int) RoomID {
+	return RoomID(fmt.Sprintf("rom%d", vnum))
+}
+
+type romMobile struct {
+	name  string
+	level int
+}
+
+type romObject struct {
+	name        string
+	description string
+	container   bool
+	capacity    int
+	light       bool
+	extras      map[string]string
+}
+
+type romExit struct {
+	dir  string
+	to   int
+	door bool
+	key  int
+}
+
+type romRoom struct {
+	vnum     int
+	title    string
+	desc     string
+	outdoors bool
+	exits    []romExit
+	extras   map[string]string
+}
+
+type romReset struct {
+	command string
+	args    []int
+}
+
+// ImportROMArea converts a ROM or Merc .are file into a LumenClay area.
+// Rooms become "rom<vnum>", mobiles and objects placed by resets become the
+// room's NPCs and items, and items given to a mobile become its loot. Exits
+// that lead outside the file are dropped with a warning.
+func ImportROMArea(data []byte) (AreaConversion, error) {
+	var conv AreaConversion
+	r := &romReader{data: data}
+	mobiles := make(map[int]romMobile)
+	objects := make(map[int]romObject)
+	rooms := make(map[int]*romRoom)
+	var resets []romReset
+	for {
+		r.skipSpace()
+		if r.eof() {
+			break
+		}
+		header := r.word()
+		switch header {
+		case "#$":
+			r.pos = len(r.data)
+		case "#AREA":
+			conv.Name = romAreaName(r)
+		case "#AREADATA":
+			for !r.eof() {
+				key := r.word()
+				if key == "End" || key == "" {
+					break
+				}
+				if key == "Name" {
+					conv.Name = romText(r.str())
+					continue
+				}
+				r.line()
+			}
+		case "#MOBILES":
+			if err := readROMMobiles(r, mobiles); err != nil {
+				return conv, err
+			}
+		case "#OBJECTS":
+			if err := readROMObjects(r, objects); err != nil {
+				return conv, err
+			}
+		case "#ROOMS":
+			if err := readROMRooms(r, rooms, &conv); err != nil {
+				return conv, err
+			}
+		case "#RESETS":
+			resets = readROMResets(r)
+		default:
+			if !strings.HasPrefix(header, "#") {
+				return conv, fmt.Errorf("unexpected %q outside a section", header)
+			}
+			r.skipSection()
+		}
+	}
+	if len(rooms) == 0 {
+		return conv, fmt.Errorf("no rooms found")
+	}
+	if conv.Name == "" {
+		conv.Name = "Imported Area"
+	}
+
+	built := make(map[int]*Room, len(rooms))
+	vnums := make([]int, 0, len(rooms))
+	for vnum := range rooms {
+		vnums = append(vnums, vnum)
+	}
+	sort.Ints(vnums)
+	for _, vnum := range vnums {
+		src := rooms[vnum]
+		room := &Room{ID: romRoomID(vnum), Title: src.title, Description: src.desc, Exits: make(map[string]Exit), Outdoors: src.outdoors, Extras: src.extras}
+		for _, exit := range src.exits {
+			if _, ok := rooms[exit.to]; !ok {
+				conv.Warnings = append(conv.Warnings, fmt.Sprintf("room %d: exit %s leads to room %d outside this file", vnum, exit.dir, exit.to))
+				continue
+			}
+			out := Exit{To: romRoomID(exit.to), Door: exit.door}
+			if exit.door && exit.key > 0 {
+				if key, ok := objects[exit.key]; ok {
+					out.Key = key.name
+				} else {
+					conv.Warnings = append(conv.Warnings, fmt.Sprintf("room %d: key %d for the %s door is not defined", vnum, exit.key, exit.dir))
+				}
+			}
+			room.Exits[exit.dir] = out
+		}
+		built[vnum] = room
+	}
+
+	var lastRoom *Room
+	lastNPC, lastItem := -1, -1
+	for _, reset := range resets {
+		arg := func(i int) int {
+			if i < len(reset.args) {
+				return reset.args[i]
+			}
+			return 0
+		}
+		switch reset.command {
+		case "M":
+			mob, ok := mobiles[arg(1)]
+			room := built[arg(3)]
+			if !ok || room == nil {
+				conv.Warnings = append(conv.Warnings, fmt.Sprintf("reset M %d in room %d skipped", arg(1), arg(3)))
+				lastRoom, lastNPC = nil, -1
+				continue
+			}
+			npc := NPC{Name: mob.name, Level: mob.level}
+			normalizeNPC(&npc)
+			room.NPCs = append(room.NPCs, npc)
+			lastRoom, lastNPC = room, len(room.NPCs)-1
+		case "O":
+			obj, ok := objects[arg(1)]
+			room := built[arg(3)]
+			if !ok || room == nil {
+				conv.Warnings = append(conv.Warnings, fmt.Sprintf("reset O %d in room %d skipped", arg(1), arg(3)))
+				lastItem = -1
+				continue
+			}
+			room.Items = append(room.Items, obj.item())
+			lastRoom, lastItem = room, len(room.Items)-1
+		case "G", "E":
+			obj, ok := objects[arg(1)]
+			if !ok || lastRoom == nil || lastNPC < 0 {
+				continue
+			}
+			lastRoom.NPCs[lastNPC].Loot = append(lastRoom.NPCs[lastNPC].Loot, obj.item())
+		case "P":
+			obj, ok := objects[arg(1)]
+			if !ok || lastRoom == nil || lastItem < 0 || !lastRoom.Items[lastItem].Container {
+				continue
+			}
+			container := &lastRoom.Items[lastItem]
+			container.Contents = append(container.Contents, obj.item())
+		case "D":
+			room := built[arg(1)]
+			dir := arg(2)
+			if room == nil || dir < 0 || dir >= len(romDirections) {
+				continue
+			}
+			exit, ok := room.Exits[romDirections[dir]]
+			if !ok || !exit.Door {
+				continue
+			}
+			exit.Closed = arg(3) >= 1
+			exit.Locked = arg(3) >= 2 && exit.Key != ""
+			room.Exits[romDirections[dir]] = exit
+		}
+	}
+
+	file := areaFile{Name: conv.Name, Rooms: make([]Room, 0, len(vnums))}
+	for _, vnum := range vnums {
+		file.Rooms = append(file.Rooms, *built[vnum])
+	}
+	encoded, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return conv, fmt.Errorf("encode area: %w", err)
+	}
+	conv.Data = append(encoded, '\n')
+	conv.Rooms = len(file.Rooms)
+	return conv, nil
+}
+
+func (o romObject) item() Item {
+	return Item{Name: o.name, Description: o.description, Container: o.container, Capacity: o.capacity, Light: o.light, Extras: o.extras}
+}
+
+// romAreaName reads the #AREA header, which holds file name, area name and
+// credits in ROM but only the credits-prefixed name in Merc.
+func romAreaName(r *romReader) string {
+	start := r.pos
+	r.skipSection()
+	parts := strings.Split(string(r.data[start:r.pos]), "~")
+	var strs []string
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			strs = append(strs, trimmed)
+		}
+	}
+	switch {
+	case len(strs) >= 3:
+		return romText(strs[1])
+	case len(strs) >= 1:
+		name := strs[0]
+		if strings.HasPrefix(name, "{") {
+			if end := strings.Index(name, "}"); end >= 0 {
+				name = name[end+1:]
+			}
+		}
+		return romText(name)
+	}
+	return ""
+}
+
+func readROMVnum(r *romReader) (int, bool, error) {
+	token := r.word()
+	if !strings.HasPrefix(token, "#") {
+		return 0, false, fmt.Errorf("expected #vnum, found %q", token)
+	}
+	vnum, err := strconv.Atoi(token[1:])
+	if err != nil {
+		return 0, false, fmt.Errorf("bad vnum %q", token)
+	}
+	return vnum, vnum != 0, nil
+}
+
+func readROMMobiles(r *romReader, mobiles map[int]romMobile) error {
+	for {
+		vnum, ok, err := readROMVnum(r)
+		if err != nil {
+			return fmt.Errorf("mobiles: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		r.str() // keywords
+		short := r.str()
+		r.str() // long description
+		r.str() // description
+		r.line()
+		if r.nextLineEndsWithTilde() {
+			r.str() // ROM race
+			r.line()
+		}
+		r.skipSpace()
+		r.line() // act, affect, alignment
+		level := r.number()
+		mobiles[vnum] = romMobile{name: romName(short), level: level}
+		r.skipToHash()
+	}
+}
+
+func readROMObjects(r *romReader, objects map[int]romObject) error {
+	for {
+		vnum, ok, err := readROMVnum(r)
+		if err != nil {
+			return fmt.Errorf("objects: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		r.str() // keywords
+		short := r.str()
+		long := r.str()
+		r.str() // ROM material or Merc action description
+		kind := strings.ToLower(r.word())
+		r.word() // extra flags
+		r.word() // wear flags
+		value0, _ := strconv.Atoi(strings.Trim(r.word(), "'"))
+		obj := romObject{name: romName(short), description: romText(long)}
+		switch kind {
+		case "light", "1":
+			obj.light = true
+		case "container", "15":
+			obj.container = true
+			obj.capacity = value0
+			if obj.capacity < 1 || obj.capacity > 20 {
+				obj.capacity = 10
+			}
+		}
+		for !r.eof() && (r.data[r.pos] != '#' || (r.pos > 0 && r.data[r.pos-1] != '\n')) {
+			if r.line() == "E" {
+				keywords := r.str()
+				obj.extras = withExtra(obj.extras, keywords, romText(r.str()))
+			}
+		}
+		objects[vnum] = obj
+	}
+}
+
+func readROMRooms(r *romReader, rooms map[int]*romRoom, conv *AreaConversion) error {
+	for {
+		vnum, ok, err := readROMVnum(r)
+		if err != nil {
+			return fmt.Errorf("rooms: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		room := &romRoom{vnum: vnum, title: romText(r.str()), desc: romText(r.str())}
+		r.number() // area number
+		flags := r.number()
+		sector := r.number()
+		room.outdoors = sector != romSectorInside && flags&romRoomIndoors == 0
+		for {
+			token := r.word()
+			switch {
+			case token == "S":
+				rooms[vnum] = room
+			case strings.HasPrefix(token, "D") && len(token) > 1:
+				dir, err := strconv.Atoi(token[1:])
+				r.str() // exit description
+				r.str() // door keywords
+				locks, key, to := r.number(), r.number(), r.number()
+				if err != nil || dir < 0 || dir >= len(romDirections) {
+					conv.Warnings = append(conv.Warnings, fmt.Sprintf("room %d: unknown exit %s skipped", vnum, token))
+					continue
+				}
+				room.exits = append(room.exits, romExit{dir: romDirections[dir], to: to, door: locks != 0, key: key})
+				continue
+			case token == "E":
+				keywords := r.str()
+				room.extras = withExtra(room.extras, keywords, romText(r.str()))
+				continue
+			case token == "H" || token == "M":
+				r.number()
+				continue
+			case token == "C" || token == "O":
+				r.str()
+				continue
+			default:
+				return fmt.Errorf("rooms: room %d has unexpected %q", vnum, token)
+			}
+			break
+		}
+	}
+}
+
+func readROMResets(r *romReader) []romReset {
+	var resets []romReset
+	for !r.eof() {
+		r.skipSpace()
+		text := r.line()
+		fields := strings.Fields(text)
+		if len(fields) == 0 || fields[0] == "*" {
+			continue
+		}
+		if fields[0] == "S" {
+			break
+		}
+		reset := romReset{command: strings.ToUpper(fields[0])}
+		for _, field := range fields[1:] {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				break
+			}
+			reset.args = append(reset.args, n)
+		}
+		resets = append(resets, reset)
+	}
+	return resets
+}
+
+// ExportROMArea converts a LumenClay area into a ROM 2.4 .are file. Rooms,
+// mobiles, and objects are numbered from baseVnum. Content ROM cannot hold,
+// such as scripts, diagonal exits, and exits to other areas, is left out
+// with a warning.
+func ExportROMArea(data []byte, baseVnum int) (AreaConversion, error) {
+	var conv AreaConversion
+	var file areaFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return conv, fmt.Errorf("decode area: %w", err)
+	}
+	if len(file.Rooms) == 0 {
+		return conv, fmt.Errorf("area has no rooms")
+	}
+	if baseVnum < 1 {
+		return conv, fmt.Errorf("base vnum must be positive")
+	}
+	conv.Name = file.Name
+	conv.Rooms = len(file.Rooms)
+	sort.Slice(file.Rooms, func(i, j int) bool { return file.Rooms[i].ID < file.Rooms[j].ID })
+
+	roomVnums := make(map[RoomID]int, len(file.Rooms))
+	for i, room := range file.Rooms {
+		roomVnums[room.ID] = baseVnum + i
+	}
+	var mobiles []NPC
+	mobVnums := make(map[string]int)
+	addMobile := func(npc NPC) int {
+		key := strings.ToLower(npc.Name)
+		if vnum, ok := mobVnums[key]; ok {
+			return vnum
+		}
+		vnum := baseVnum + len(mobiles)
+		mobVnums[key] = vnum
+		mobiles = append(mobiles, npc)
+		return vnum
+	}
+	var objects []Item
+	objVnums := make(map[string]int)
+	var addObject func(item Item) int
+	addObject = func(item Item) int {
+		key := strings.ToLower(item.Name)
+		if vnum, ok := objVnums[key]; ok {
+			return vnum
+		}
+		vnum := baseVnum + len(objects)
+		objVnums[key] = vnum
+		objects = append(objects, item)
+		for _, inner := range item.Contents {
+			addObject(inner)
+		}
+		return vnum
+	}
+	scripted := strings.TrimSpace(file.Script) != ""
+
+	var rooms, resets strings.Builder
+	for _, room := range file.Rooms {
+		vnum := roomVnums[room.ID]
+		scripted = scripted || room.Script != ""
+		flags, sector := romRoomIndoors, romSectorInside
+		if room.Outdoors {
+			flags, sector = 0, romSectorField
+		}
+		fmt.Fprintf(&rooms, "#%d\n%s~\n%s\n~\n0 %d %d\n", vnum, romString(room.Title), romWrap(room.Description), flags, sector)
+		dirs := make([]string, 0, len(room.Exits))
+		for dir := range room.Exits {
+			dirs = append(dirs, dir)
+		}
+		sort.Slice(dirs, func(i, j int) bool { return romDirOrder(dirs[i]) < romDirOrder(dirs[j]) })
+		for _, dir := range dirs {
+			exit := room.Exits[dir]
+			number, ok := romDirectionNumber[strings.ToLower(dir)]
+			if !ok {
+				conv.Warnings = append(conv.Warnings, fmt.Sprintf("room %s: exit %s has no ROM direction", room.ID, dir))
+				continue
+			}
+			to, ok := roomVnums[exit.To]
+			if !ok {
+				conv.Warnings = append(conv.Warnings, fmt.Sprintf("room %s: exit %s leads to %s outside this area", room.ID, dir, exit.To))
+				continue
+			}
+			locks, key := 0, 0
+			if exit.Door {
+				locks = 1
+				if exit.Key != "" {
+					key = addObject(Item{Name: exit.Key})
+				}
+				state := 0
+				if exit.Locked {
+					state = 2
+				} else if exit.Closed {
+					state = 1
+				}
+				if state > 0 {
+					fmt.Fprintf(&resets, "D 0 %d %d %d\n", vnum, number, state)
+				}
+			}
+			fmt.Fprintf(&rooms, "D%d\n~\n%s~\n%d %d %d\n", number, romKeywords(dir), locks, key, to)
+		}
+		rooms.WriteString(romExtras(room.Extras))
+		rooms.WriteString("S\n")
+
+		npcs := append([]NPC(nil), room.NPCs...)
+		for _, reset := range room.Resets {
+			if reset.Kind == ResetKindNPC && findNPCIndex(npcs, reset.Name) == -1 {
+				npcs = append(npcs, NPC{Name: reset.Name})
+			}
+		}
+		for _, npc := range npcs {
+			scripted = scripted || npc.Script != ""
+			fmt.Fprintf(&resets, "M 0 %d 1 %d 1\n", addMobile(npc), vnum)
+			for _, loot := range npc.Loot {
+				fmt.Fprintf(&resets, "G 0 %d 0\n", addObject(loot))
+			}
+		}
+		items := append([]Item(nil), room.Items...)
+		for _, reset := range room.Resets {
+			if reset.Kind == ResetKindItem && findItemIndex(items, reset.Name) == -1 {
+				items = append(items, Item{Name: reset.Name, Description: reset.Description, Container: reset.Container, Capacity: reset.Capacity, Contents: reset.Contents, Light: reset.Light, Weight: reset.Weight, Consumable: reset.Consumable, Swim: reset.Swim, Climb: reset.Climb, Fly: reset.Fly, Extras: reset.Extras})
+			}
+		}
+		for _, item := range items {
+			if item.Corpse {
+				continue
+			}
+			scripted = scripted || item.Script != ""
+			obj := addObject(item)
+			fmt.Fprintf(&resets, "O 0 %d 0 %d\n", obj, vnum)
+			for _, inner := range item.Contents {
+				fmt.Fprintf(&resets, "P 0 %d 0 %d 1\n", addObject(inner), obj)
+			}
+		}
+	}
+	if scripted {
+		conv.Warnings = append(conv.Warnings, "scripts are not exported")
+	}
+
+	var out strings.Builder
+	last := baseVnum + max(len(file.Rooms), len(mobiles), len(objects)) - 1
+	fmt.Fprintf(&out, "#AREA\n%s.are~\n%s~\n{ 1 50} LumenClay %s~\n%d %d\n\n", areaSlug(file.Name), romString(file.Name), romString(file.Name), baseVnum, last)
+	out.WriteString("#MOBILES\n")
+	for i, npc := range mobiles {
+		level := max(npc.Level, 1)
+		short := romString(npc.Name)
+		fmt.Fprintf(&out, "#%d\n%s~\n%s~\n%s is here.\n~\n%s\n~\nhuman~\nA 0 0 0\n%d 0 1d1+0 1d1+0 1d4+0 punch\n0 0 0 0\n0 0 0 0\nstand stand none %d\n0 0 medium unknown\n",
+			baseVnum+i, romKeywords(npc.Name), short, short, romWrap(npc.AutoGreet), level, npc.Gold)
+	}
+	out.WriteString("#0\n\n#OBJECTS\n")
+	for i, item := range objects {
+		kind, value0 := "trash", 0
+		switch {
+		case item.Container:
+			kind, value0 = "container", max(item.Capacity, 1)
+		case item.Light:
+			kind = "light"
+		}
+		long := romString(item.Description)
+		if long == "" {
+			long = romString(item.Name) + " lies here."
+		}
+		fmt.Fprintf(&out, "#%d\n%s~\n%s~\n%s~\nunknown~\n%s 0 A\n%d 0 0 0 0\n0 %d 0 P\n%s",
+			baseVnum+i, romKeywords(item.Name), romString(item.Name), long, kind, value0, max(item.Weight, DefaultItemWeight), romExtras(item.Extras))
+	}
+	out.WriteString("#0\n\n#ROOMS\n")
+	out.WriteString(rooms.String())
+	out.WriteString("#0\n\n#RESETS\n")
+	out.WriteString(resets.String())
+	out.WriteString("S\n\n#$\n")
+	conv.Data = []byte(out.String())
+	return conv, nil
+}
+
+// romExtras writes extra descriptions as ROM "E" entries.
+func romExtras(extras map[string]string) string {
+	var out strings.Builder
+	for _, keywords := range ExtraKeywords(extras) {
+		fmt.Fprintf(&out, "E\n%s~\n%s\n~\n", romString(keywords), romWrap(extras[keywords]))
+	}
+	return out.String()
+}
+
+func romDirOrder(dir string) int {
+	if n, ok := romDirectionNumber[strings.ToLower(dir)]; ok {
+		return n
+	}
+	return len(romDirections)
+}
+
+// romString removes the tilde ROM uses as a string terminator.
+func romString(text string) string {
+	return strings.ReplaceAll(strings.TrimSpace(text), "~", "-")
+}
+
+// romKeywords builds a lower-case keyword list from a name.
+func romKeywords(name string) string {
+	return strings.ToLower(romString(name))
+}
+
+// romWrap hard-wraps text at 72 columns as ROM descriptions expect.
+func romWrap(text string) string {
+	var lines []string
+	var current strings.Builder
+	for _, word := range strings.Fields(romString(text)) {
+		if current.Len() > 0 && current.Len()+1+len(word) > 72 {
+			lines = append(lines, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteByte(' ')
+		}
+		current.WriteString(word)
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// areaSlug turns an area name into a file name stem.
+func areaSlug(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, c := range strings.ToLower(name) {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			b.WriteRune(c)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "_")
+	if slug == "" {
+		return "imported"
+	}
+	return slug
+}
+
+// ImportROMAreaFile converts a .are file, saves it beside the other area
+// files, and reloads the areas so the rooms are live at once. Existing area
+// files are never overwritten. Builders limited to granted areas may not
+// import, since the new area is not theirs and the reload touches every
+// area.
+func (w *World) ImportROMAreaFile(data []byte, editor string) (string, AreaConversion, AreaReloadReport, error) {
+	w.mu.RLock()
+	_, scoped := w.buildScopeLocked(editor)
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if scoped {
+		return "", AreaConversion{}, AreaReloadReport{}, fmt.Errorf("%w: %s may only edit granted areas", ErrAreaNotGranted, editor)
+	}
+	conv, err := ImportROMArea(data)
+	if err != nil {
+		return "", conv, AreaReloadReport{}, err
+	}
+	if areasPath == "" {
+		return "", conv, AreaReloadReport{}, fmt.Errorf("world does not have an areas path configured")
+	}
+	name := areaSlug(conv.Name) + ".json"
+	path := filepath.Join(areasPath, name)
+	handle, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return name, conv, AreaReloadReport{}, fmt.Errorf("area file %s already exists", name)
+		}
+		return name, conv, AreaReloadReport{}, err
+	}
+	_, err = handle.Write(conv.Data)
+	if closeErr := handle.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return name, conv, AreaReloadReport{}, err
+	}
+	report, err := w.ReloadAreas()
+	if err != nil {
+		os.Remove(path)
+		return name, conv, report, err
+	}
+	return name, conv, report, nil
+}
+
+// ExportROMAreaFile converts the named area file into a .are file.
+func (w *World) ExportROMAreaFile(name string, baseVnum int) (AreaConversion, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || filepath.Base(name) != name || !strings.EqualFold(filepath.Ext(name), ".json") {
+		return AreaConversion{}, fmt.Errorf("invalid area file %q", name)
+	}
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return AreaConversion{}, fmt.Errorf("world does not have an areas path configured")
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if name == builderAreaFile {
+		data, err = documentStorage().Read(filepath.Join(areasPath, name))
+	} else {
+		data, err = os.ReadFile(filepath.Join(areasPath, name))
+	}
+	if err != nil {
+		return AreaConversion{}, err
+	}
+	return ExportROMArea(data, baseVnum)
+}
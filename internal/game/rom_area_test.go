@@ -0,0 +1,240 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleROMArea = `#AREA
+sample.are~
+Sample Keep~
+{ 5 15} Tester  Sample Keep~
+3000 3099
+
+#MOBILES
+#3000
+guard cityguard~
+the {Ycityguard{x~
+A cityguard stands here.
+~
+A big, burly guard.
+~
+human~
+ABT 0 1000 0
+5 0 2d8+40 1d1+99 1d6+1 punch
+-1 -1 -1 -1
+0 0 0 0
+stand stand male 20
+0 0 medium unknown
+#0
+
+#OBJECTS
+#3010
+chest wooden~
+a wooden chest~
+A wooden chest sits in the corner.~
+wood~
+container 0 A
+8 A 0 0 0
+0 50 0 P
+E
+lid carvings~
+Vines are carved
+into the lid.
+~
+#3011
+key brass~
+a brass key~
+A brass key lies here.~
+brass~
+key 0 A
+0 0 0 0 0
+0 1 0 P
+#3012
+torch~
+a torch~
+A torch lies here.~
+wood~
+light 0 A
+0 0 100 0 0
+1 1 0 P
+#0
+
+#ROOMS
+#3001
+The Gatehouse~
+A narrow gatehouse guards the
+entrance to the keep.
+~
+0 D 0
+D0
+~
+door~
+1 3011 3002
+D2
+~
+~
+0 0 9999
+S
+#3002
+The Courtyard~
+Open sky above a muddy yard.
+~
+0 0 2
+D2
+~
+door~
+1 3011 3001
+E
+mud~
+It is very muddy.
+~
+S
+#0
+
+#SPECIALS
+M 3000 spec_guard
+S
+
+#RESETS
+* The guard
+M 0 3000 1 3001 1
+G 0 3012 0
+O 0 3010 0 3002
+P 0 3011 0 3010 1
+D 0 3001 0 2
+S
+
+#$
+`
+
+func TestImportROMArea(t *testing.T) {
+	conv, err := ImportROMArea([]byte(sampleROMArea))
+	if err != nil {
+		t.Fatalf("ImportROMArea: %v", err)
+	}
+	if conv.Name != "Sample Keep" || conv.Rooms != 2 {
+		t.Fatalf("unexpected conversion %q with %d rooms", conv.Name, conv.Rooms)
+	}
+	if len(conv.Warnings) != 1 || !strings.Contains(conv.Warnings[0], "9999") {
+		t.Fatalf("expected a warning about the outside exit, got %v", conv.Warnings)
+	}
+	var file areaFile
+	if err := json.Unmarshal(conv.Data, &file); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	rooms := make(map[RoomID]Room)
+	for _, room := range file.Rooms {
+		rooms[room.ID] = room
+	}
+	gate, yard := rooms["rom3001"], rooms["rom3002"]
+	if gate.Description != "A narrow gatehouse guards the entrance to the keep." {
+		t.Fatalf("unexpected description %q", gate.Description)
+	}
+	if gate.Outdoors || !yard.Outdoors {
+		t.Fatalf("expected only the courtyard outdoors")
+	}
+	north := gate.Exits["n"]
+	if north.To != "rom3002" || !north.Door || !north.Locked || north.Key != "Brass key" {
+		t.Fatalf("unexpected gate door %+v", north)
+	}
+	if _, ok := gate.Exits["s"]; ok {
+		t.Fatalf("expected the outside exit to be dropped")
+	}
+	if len(gate.NPCs) != 1 || gate.NPCs[0].Name != "Cityguard" || gate.NPCs[0].Level != 5 {
+		t.Fatalf("unexpected NPCs %+v", gate.NPCs)
+	}
+	if loot := gate.NPCs[0].Loot; len(loot) != 1 || !loot[0].Light {
+		t.Fatalf("expected the guard to carry a torch, got %+v", loot)
+	}
+	if len(yard.Items) != 1 || !yard.Items[0].Container || yard.Items[0].Capacity != 8 {
+		t.Fatalf("unexpected yard items %+v", yard.Items)
+	}
+	if contents := yard.Items[0].Contents; len(contents) != 1 || contents[0].Name != "Brass key" {
+		t.Fatalf("expected the key in the chest, got %+v", contents)
+	}
+	if yard.Extras["mud"] != "It is very muddy." {
+		t.Fatalf("unexpected room extras %+v", yard.Extras)
+	}
+	if yard.Items[0].Extras["lid carvings"] != "Vines are carved into the lid." {
+		t.Fatalf("unexpected chest extras %+v", yard.Items[0].Extras)
+	}
+}
+
+func TestROMAreaRoundTrip(t *testing.T) {
+	original, err := ImportROMArea([]byte(sampleROMArea))
+	if err != nil {
+		t.Fatalf("ImportROMArea: %v", err)
+	}
+	exported, err := ExportROMArea(original.Data, 5000)
+	if err != nil {
+		t.Fatalf("ExportROMArea: %v", err)
+	}
+	if !strings.HasSuffix(string(exported.Data), "#$\n") {
+		t.Fatalf("export is missing its terminator")
+	}
+	again, err := ImportROMArea(exported.Data)
+	if err != nil {
+		t.Fatalf("re-import: %v\n%s", err, exported.Data)
+	}
+	if again.Name != original.Name || again.Rooms != original.Rooms {
+		t.Fatalf("round trip changed the area: %q/%d", again.Name, again.Rooms)
+	}
+	var file areaFile
+	if err := json.Unmarshal(again.Data, &file); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	gate := file.Rooms[0]
+	if gate.Title != "The Gatehouse" || !gate.Exits["n"].Locked || gate.Exits["n"].Key != "Brass key" {
+		t.Fatalf("unexpected round-tripped gate %+v", gate)
+	}
+	if len(gate.NPCs) != 1 || gate.NPCs[0].Name != "Cityguard" || len(gate.NPCs[0].Loot) != 1 {
+		t.Fatalf("unexpected round-tripped NPCs %+v", gate.NPCs)
+	}
+	yard := file.Rooms[1]
+	if len(yard.Items) != 1 || len(yard.Items[0].Contents) != 1 || !yard.Outdoors {
+		t.Fatalf("unexpected round-tripped yard %+v", yard)
+	}
+	if yard.Extras["mud"] == "" || yard.Items[0].Extras["lid carvings"] == "" {
+		t.Fatalf("extras were lost in the round trip: %+v %+v", yard.Extras, yard.Items[0].Extras)
+	}
+}
+
+func TestImportROMAreaFileLoadsRooms(t *testing.T) {
+	dir := t.TempDir()
+	areasDir := filepath.Join(dir, "areas")
+	if err := os.MkdirAll(areasDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeSnapshotTestArea(t, areasDir)
+	world, err := NewWorld(areasDir)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	name, _, report, err := world.ImportROMAreaFile([]byte(sampleROMArea), "")
+	if err != nil {
+		t.Fatalf("ImportROMAreaFile: %v", err)
+	}
+	if name != "sample_keep.json" || len(report.Added) != 2 {
+		t.Fatalf("unexpected import %q with %d added rooms", name, len(report.Added))
+	}
+	if _, ok := world.GetRoom("rom3001"); !ok {
+		t.Fatalf("expected imported room to be live")
+	}
+	if _, _, _, err := world.ImportROMAreaFile([]byte(sampleROMArea), ""); err == nil {
+		t.Fatalf("expected a second import to refuse to overwrite")
+	}
+	exported, err := world.ExportROMAreaFile(name, 100)
+	if err != nil {
+		t.Fatalf("ExportROMAreaFile: %v", err)
+	}
+	if !strings.Contains(string(exported.Data), "The Gatehouse~") {
+		t.Fatalf("export is missing the imported room")
+	}
+	if _, err := world.ExportROMAreaFile("../secret.json", 100); err == nil {
+		t.Fatalf("expected path traversal to be rejected")
+	}
+}
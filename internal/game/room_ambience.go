@@ -0,0 +1,106 @@
+package game
+
+import (
+	"time"
+)
+
+const (
+	// DefaultAmbienceEvery is roughly how often a room with ambience lines
+	// speaks up when it sets no interval of its own.
+	DefaultAmbienceEvery = 2 * time.Minute
+	// ambienceSweep is how often the world checks for rooms due to speak.
+	ambienceSweep = 5 * time.Second
+)
+
+// ambienceState tracks when an occupied room next speaks and what it said
+// last.
+type ambienceState struct {
+	due  time.Time
+	last int
+}
+
+func (r *Room) ambienceEvery() time.Duration {
+	if r.AmbienceEvery > 0 {
+		return time.Duration(r.AmbienceEvery) * time.Second
+	}
+	return DefaultAmbienceEvery
+}
+
+// ambienceWait picks the wait before a room's next ambience line,
+// somewhere between half and one and a half times every.
+func (w *World) ambienceWait(every time.Duration) time.Duration {
+	return every/2 + time.Duration(w.roll(int(every/time.Second)+1))*time.Second
+}
+
+// roomAmbience is an ambience line due in a room.
+type roomAmbience struct {
+	room RoomID
+	line string
+}
+
+// AmbienceTick shows a line from each occupied room's ambience list once its
+// randomized wait has run out. Rooms start waiting when someone arrives and
+// forget their wait when the last player leaves, and no line repeats twice in
+// a row.
+func (w *World) AmbienceTick(now time.Time) {
+	var due []roomAmbience
+	w.mu.Lock()
+	occupied := make(map[RoomID]bool)
+	for _, p := range w.players {
+		if p.Alive {
+			occupied[p.Room] = true
+		}
+	}
+	for id := range w.ambience {
+		if room, ok := w.rooms[id]; !ok || !occupied[id] || len(room.Ambience) == 0 {
+			delete(w.ambience, id)
+		}
+	}
+	for id := range occupied {
+		room, ok := w.rooms[id]
+		if !ok || len(room.Ambience) == 0 {
+			continue
+		}
+		state, ok := w.ambience[id]
+		if !ok {
+			if w.ambience == nil {
+				w.ambience = make(map[RoomID]ambienceState)
+			}
+			w.ambience[id] = ambienceState{due: now.Add(w.ambienceWait(room.ambienceEvery())), last: -1}
+			continue
+		}
+		if now.Before(state.due) {
+			continue
+		}
+		pick := w.roll(len(room.Ambience))
+		if pick == state.last && len(room.Ambience) > 1 {
+			pick = (pick + 1) % len(room.Ambience)
+		}
+		w.ambience[id] = ambienceState{due: now.Add(w.ambienceWait(room.ambienceEvery())), last: pick}
+		due = append(due, roomAmbience{room: id, line: room.Ambience[pick]})
+	}
+	w.mu.Unlock()
+	for _, ambience := range due {
+		line := renderRoomTemplate(ambience.line, w.roomTemplateVars(ambience.room))
+		w.broadcastAmbient(Style(line, AnsiItalic), func(p *Player, room *Room) bool {
+			return room.ID == ambience.room
+		})
+	}
+}
+
+// StartAmbienceLoop periodically shows room ambience lines until stop is
+// closed.
+func (w *World) StartAmbienceLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(ambienceSweep)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.AmbienceTick(now)
+			}
+		}
+	}()
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAmbienceTickRotatesLinesForListeners(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Moonlit Terrace", Outdoors: true, AmbienceEvery: 20,
+		Ambience: []string{"A bell chimes in the {weather}.", "Tiles click as they cool."}}})
+	player := &Player{Name: "Watcher", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	muted := &Player{Name: "Sleeper", Room: StartRoom, Output: NewOutputQueue(), Alive: true,
+		Channels: map[Channel]bool{ChannelAmbient: false}}
+	world.AddPlayerForTest(muted)
+
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+
+	start := time.Now()
+	world.AmbienceTick(start)
+	world.AmbienceTick(start.Add(5 * time.Second))
+	if got := drainOutput(player.Output); len(got) != 0 {
+		t.Fatalf("expected the room to wait before speaking, got %q", got)
+	}
+
+	world.AmbienceTick(start.Add(10 * time.Second))
+	output := stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "A bell chimes in the clear.") {
+		t.Fatalf("expected the first ambience line, got %q", output)
+	}
+	world.AmbienceTick(start.Add(20 * time.Second))
+	output = stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "Tiles click") {
+		t.Fatalf("expected the line not to repeat, got %q", output)
+	}
+	if got := drainOutput(muted.Output); len(got) != 0 {
+		t.Fatalf("muted player heard ambience: %q", got)
+	}
+
+	world.mu.Lock()
+	player.Room, muted.Room = "cellar", "cellar"
+	world.mu.Unlock()
+	world.AmbienceTick(start.Add(30 * time.Second))
+	if _, ok := world.ambience[StartRoom]; ok {
+		t.Fatalf("expected an empty room to forget its wait")
+	}
+}
@@ -0,0 +1,238 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrRoomDraftStale indicates the room was changed by someone else after the
+// draft was opened.
+var ErrRoomDraftStale = errors.New("room changed since editing began")
+
+// RoomDraft holds a builder's uncommitted edits to one room. The fields may
+// be changed freely; nothing reaches the world until CommitRoomDraft.
+type RoomDraft struct {
+	Room        RoomID
+	Title       string
+	Description string
+	Exits       map[string]Exit
+	Resets      []RoomReset
+	Extras      map[string]string
+	base        *RoomDraft
+}
+
+func newRoomDraft(room *Room) *RoomDraft {
+	draft := &RoomDraft{
+		Room:        room.ID,
+		Title:       room.Title,
+		Description: room.Description,
+		Exits:       make(map[string]Exit, len(room.Exits)),
+		Resets:      append([]RoomReset(nil), room.Resets...),
+		Extras:      make(map[string]string, len(room.Extras)),
+	}
+	for dir, exit := range room.Exits {
+		draft.Exits[dir] = exit
+	}
+	for key, text := range room.Extras {
+		draft.Extras[key] = text
+	}
+	return draft
+}
+
+func (d *RoomDraft) copy() *RoomDraft {
+	clone := *d
+	clone.base = nil
+	clone.Exits = make(map[string]Exit, len(d.Exits))
+	for dir, exit := range d.Exits {
+		clone.Exits[dir] = exit
+	}
+	clone.Resets = append([]RoomReset(nil), d.Resets...)
+	clone.Extras = make(map[string]string, len(d.Extras))
+	for key, text := range d.Extras {
+		clone.Extras[key] = text
+	}
+	return &clone
+}
+
+func (d *RoomDraft) fieldChanged(field string, other *RoomDraft) bool {
+	switch field {
+	case "title":
+		return d.Title != other.Title
+	case "description":
+		return d.Description != other.Description
+	case "exits":
+		return !reflect.DeepEqual(d.Exits, other.Exits)
+	case "resets":
+		return len(d.Resets) != len(other.Resets) || (len(d.Resets) > 0 && !reflect.DeepEqual(d.Resets, other.Resets))
+	case "extras":
+		return !reflect.DeepEqual(d.Extras, other.Extras)
+	}
+	return false
+}
+
+// roomDraftFields lists the editable fields in display order.
+var roomDraftFields = []string{"title", "description", "exits", "resets", "extras"}
+
+// Changed lists the fields edited since the draft was opened or last
+// committed.
+func (d *RoomDraft) Changed() []string {
+	if d.base == nil {
+		return nil
+	}
+	var changed []string
+	for _, field := range roomDraftFields {
+		if d.fieldChanged(field, d.base) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// Dirty reports whether the draft holds uncommitted edits.
+func (d *RoomDraft) Dirty() bool {
+	return len(d.Changed()) > 0
+}
+
+// FieldChanged reports whether one field holds uncommitted edits.
+func (d *RoomDraft) FieldChanged(field string) bool {
+	return d.base != nil && d.fieldChanged(field, d.base)
+}
+
+// SetExtra sets or, with empty text, removes one of the draft's extras.
+func (d *RoomDraft) SetExtra(keywords, text string) {
+	d.Extras = withExtra(d.Extras, keywords, text)
+	if d.Extras == nil {
+		d.Extras = make(map[string]string)
+	}
+}
+
+// ExitDirections lists the draft's exits in a stable order.
+func (d *RoomDraft) ExitDirections() []string {
+	dirs := make([]string, 0, len(d.Exits))
+	for dir := range d.Exits {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Preview renders the draft the way players will see the room.
+func (d *RoomDraft) Preview() string {
+	room := &Room{ID: d.Room, Title: d.Title, Description: d.Description, Exits: d.Exits}
+	return fmt.Sprintf("%s\r\n%s\r\nExits: %s", Style(d.Title, AnsiBold, AnsiCyan), d.Description, Style(ExitList(room), AnsiGreen))
+}
+
+// RoomDraft returns the room the player is editing, if any.
+func (p *Player) RoomDraft() *RoomDraft {
+	return p.roomDraft
+}
+
+// OpenRoomDraft starts an editing session on the player's current room.
+func (w *World) OpenRoomDraft(p *Player) (*RoomDraft, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return nil, fmt.Errorf("unknown room: %s", p.Room)
+	}
+	if err := w.checkBuildScopeLocked(p.Name, room.ID); err != nil {
+		return nil, err
+	}
+	draft := newRoomDraft(room)
+	draft.base = draft.copy()
+	p.roomDraft = draft
+	return draft, nil
+}
+
+// CloseRoomDraft ends the player's editing session, discarding any
+// uncommitted edits.
+func (w *World) CloseRoomDraft(p *Player) {
+	w.mu.Lock()
+	p.roomDraft = nil
+	w.mu.Unlock()
+}
+
+// CommitRoomDraft writes the player's draft to the room and the builder
+// area. NPCs and items whose resets were removed leave the room, and the
+// remaining resets are applied. The commit is refused when the room was
+// changed by someone else in the meantime.
+func (w *World) CommitRoomDraft(p *Player) (*Room, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	draft := p.roomDraft
+	if draft == nil {
+		return nil, fmt.Errorf("you are not editing a room")
+	}
+	room, ok := w.rooms[draft.Room]
+	if !ok {
+		return nil, fmt.Errorf("unknown room: %s", draft.Room)
+	}
+	if err := w.checkBuildScopeLocked(p.Name, room.ID); err != nil {
+		return nil, err
+	}
+	current := newRoomDraft(room)
+	for _, field := range roomDraftFields {
+		if current.fieldChanged(field, draft.base) {
+			return nil, fmt.Errorf("%w: %s was edited", ErrRoomDraftStale, field)
+		}
+	}
+	if strings.TrimSpace(draft.Title) == "" {
+		return nil, fmt.Errorf("room title must not be empty")
+	}
+	for _, dir := range draft.ExitDirections() {
+		if _, ok := w.rooms[draft.Exits[dir].To]; !ok {
+			return nil, fmt.Errorf("exit %s leads to unknown room %s", dir, draft.Exits[dir].To)
+		}
+	}
+	if !draft.Dirty() {
+		return room, nil
+	}
+
+	prev := *room
+	prev.NPCs = append([]NPC(nil), room.NPCs...)
+	prev.Items = append([]Item(nil), room.Items...)
+	removed := func(kind ResetKind, name string) bool {
+		return findResetIndex(draft.base.Resets, kind, name) >= 0 && findResetIndex(draft.Resets, kind, name) == -1
+	}
+	room.Title = draft.Title
+	room.Description = draft.Description
+	room.Exits = draft.copy().Exits
+	room.Resets = append([]RoomReset(nil), draft.Resets...)
+	room.Extras = nil
+	if len(draft.Extras) > 0 {
+		room.Extras = draft.copy().Extras
+	}
+	if draft.FieldChanged("resets") {
+		npcs := room.NPCs[:0:0]
+		for _, npc := range room.NPCs {
+			if !removed(ResetKindNPC, npc.Name) {
+				npcs = append(npcs, npc)
+			}
+		}
+		items := room.Items[:0:0]
+		for _, item := range room.Items {
+			if !removed(ResetKindItem, item.Name) {
+				items = append(items, item)
+			}
+		}
+		room.NPCs, room.Items = npcs, items
+		w.applyRoomResetsLocked(room)
+	}
+	prevSource, hadSource := w.markRoomAsBuilderLocked(room.ID)
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		*room = prev
+		if hadSource {
+			w.roomSources[room.ID] = prevSource
+		} else {
+			delete(w.roomSources, room.ID)
+		}
+		return nil, err
+	}
+	w.recordRoomRevisionLocked(room, p.Name)
+	draft.base = newRoomDraft(room)
+	draft.Resets = append([]RoomReset(nil), room.Resets...)
+	return room, nil
+}
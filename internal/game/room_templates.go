@@ -0,0 +1,174 @@
+package game
+
+import (
+	"strings"
+)
+
+// Room descriptions may embed template tokens that are filled in each time
+// the room is shown:
+//
+//	{time_of_day}  dawn, day, dusk or night
+//	{weather}      clear, cloudy, rain, storm or fog
+//	{occupancy}    quiet, busy or crowded, by how many players are present
+//
+// Text between {if <condition>} and {end} only appears while the condition
+// holds, with an optional {else} for the alternative. A condition names a
+// time of day, weather or occupancy word, several of them joined by | when
+// any will do, and a leading ! negates it. Blocks may nest. Braces that do
+// not form a known token are left as written.
+
+const (
+	OccupancyQuiet   = "quiet"
+	OccupancyBusy    = "busy"
+	OccupancyCrowded = "crowded"
+)
+
+// occupancyFor names how full a room with count players feels.
+func occupancyFor(count int) string {
+	switch {
+	case count >= 5:
+		return OccupancyCrowded
+	case count >= 2:
+		return OccupancyBusy
+	default:
+		return OccupancyQuiet
+	}
+}
+
+// roomTemplateVars holds the values a room description's tokens resolve to.
+type roomTemplateVars struct {
+	phase     TimeOfDay
+	weather   Weather
+	occupancy string
+}
+
+func (v roomTemplateVars) value(token string) (string, bool) {
+	switch token {
+	case "time_of_day":
+		return string(v.phase), true
+	case "weather":
+		return string(v.weather), true
+	case "occupancy":
+		return v.occupancy, true
+	}
+	return "", false
+}
+
+// matches reports whether a {if} condition holds.
+func (v roomTemplateVars) matches(condition string) bool {
+	condition = strings.ToLower(strings.TrimSpace(condition))
+	negate := strings.HasPrefix(condition, "!")
+	if negate {
+		condition = strings.TrimSpace(condition[1:])
+	}
+	held := false
+	for _, option := range strings.Split(condition, "|") {
+		switch strings.TrimSpace(option) {
+		case string(v.phase), string(v.weather), v.occupancy:
+			held = true
+		}
+	}
+	return held != negate
+}
+
+// roomTemplateVars gathers the clock, weather and occupancy of room.
+func (w *World) roomTemplateVars(room RoomID) roomTemplateVars {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	count := 0
+	for _, p := range w.players {
+		if p.Alive && p.Room == room {
+			count++
+		}
+	}
+	return roomTemplateVars{
+		phase:     phaseForHour(w.clockHour),
+		weather:   w.weatherLocked(w.roomSources[room]),
+		occupancy: occupancyFor(count),
+	}
+}
+
+// renderRoomTemplate resolves the tokens and conditional blocks in a room
+// description and tidies the blank lines left by blocks that were dropped.
+func renderRoomTemplate(text string, vars roomTemplateVars) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	var out strings.Builder
+	// shown records, for each open {if}, whether its current branch is
+	// shown; parents records whether everything enclosing it is.
+	var shown, parents []bool
+	visible := func() bool {
+		return len(shown) == 0 || (shown[len(shown)-1] && parents[len(parents)-1])
+	}
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			if visible() {
+				out.WriteString(text)
+			}
+			break
+		}
+		if visible() {
+			out.WriteString(text[:open])
+		}
+		text = text[open:]
+		end := strings.IndexByte(text, '}')
+		if end < 0 {
+			if visible() {
+				out.WriteString(text)
+			}
+			break
+		}
+		token := strings.TrimSpace(text[1:end])
+		literal := text[:end+1]
+		text = text[end+1:]
+		lower := strings.ToLower(token)
+		switch {
+		case strings.HasPrefix(lower, "if "):
+			parents = append(parents, visible())
+			shown = append(shown, vars.matches(token[3:]))
+		case lower == "else" && len(shown) > 0:
+			shown[len(shown)-1] = !shown[len(shown)-1]
+		case lower == "end" && len(shown) > 0:
+			shown, parents = shown[:len(shown)-1], parents[:len(parents)-1]
+		default:
+			if !visible() {
+				continue
+			}
+			if value, ok := vars.value(lower); ok {
+				out.WriteString(value)
+			} else {
+				out.WriteString(literal)
+			}
+		}
+	}
+	return tidyParagraphs(out.String())
+}
+
+// tidyParagraphs trims each line's trailing space and folds runs of blank
+// lines into one, dropping any at the start or end.
+func tidyParagraphs(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if strings.TrimSpace(line) == "" {
+			if len(kept) == 0 || kept[len(kept)-1] == "" {
+				continue
+			}
+			line = ""
+		}
+		kept = append(kept, line)
+	}
+	for len(kept) > 0 && kept[len(kept)-1] == "" {
+		kept = kept[:len(kept)-1]
+	}
+	return strings.Join(kept, "\n")
+}
+
+// RoomDescription returns room r's description with its template tokens and
+// conditional blocks resolved for the moment it is viewed.
+func (w *World) RoomDescription(r *Room) string {
+	return renderRoomTemplate(r.Description, w.roomTemplateVars(r.ID))
+}
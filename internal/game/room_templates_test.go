@@ -0,0 +1,51 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderRoomTemplateResolvesTokensAndBlocks(t *testing.T) {
+	vars := roomTemplateVars{phase: TimeNight, weather: WeatherRain, occupancy: OccupancyBusy}
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"It is {time_of_day} and the {weather} falls.", "It is night and the rain falls."},
+		{"The hall feels {occupancy}.", "The hall feels busy."},
+		{"Lamps{if night} burn{else} sit cold{end}.", "Lamps burn."},
+		{"{if day|dusk}Sunlit.{else}Dark.{end}", "Dark."},
+		{"{if !storm}Calm{end} and {if rain}{if busy}crowded and wet{end}{end}", "Calm and crowded and wet"},
+		{"Carved into stone: {unknown} and {braces", "Carved into stone: {unknown} and {braces"},
+		{"Gate.\n\n{if day}Guards stand watch.{end}\n\nA road runs north.", "Gate.\n\nA road runs north."},
+	}
+	for _, tc := range cases {
+		if got := renderRoomTemplate(tc.text, vars); got != tc.want {
+			t.Errorf("renderRoomTemplate(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestDescribeRoomFollowsClockWeatherAndCrowd(t *testing.T) {
+	cellar := &Room{ID: "cellar", Title: "Cellar",
+		Description: "The cellar is {occupancy}.\n\n{if night}Rats scuttle in the dark.{else}Light seeps through the hatch.{end}"}
+	world := NewWorldWithRooms(map[RoomID]*Room{"cellar": cellar})
+	world.AddPlayerForTest(&Player{Name: "Watcher", Room: "cellar", Output: NewOutputQueue(), Alive: true})
+
+	world.SetClock(12)
+	desc, _ := DescribeRoom(world, cellar, 80)
+	desc = stripAnsi(desc)
+	if !strings.Contains(desc, "The cellar is quiet.") || !strings.Contains(desc, "Light seeps") || strings.Contains(desc, "Rats") {
+		t.Fatalf("expected a quiet daytime cellar, got %q", desc)
+	}
+
+	world.SetClock(23)
+	for _, name := range []string{"Ada", "Bo"} {
+		world.AddPlayerForTest(&Player{Name: name, Room: "cellar", Alive: true, Output: NewOutputQueue()})
+	}
+	desc, _ = DescribeRoom(world, cellar, 80)
+	desc = stripAnsi(desc)
+	if !strings.Contains(desc, "The cellar is busy.") || !strings.Contains(desc, "Rats scuttle") || strings.Contains(desc, "Light seeps") {
+		t.Fatalf("expected a busy night cellar, got %q", desc)
+	}
+}
@@ -2,7 +2,6 @@ package game
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 )
 
@@ -11,56 +10,93 @@ import (
 func EnterRoom(world *World, p *Player, via string) {
 	r, ok := world.GetRoom(p.Room)
 	if !ok {
-		p.Output <- Ansi(Style("\r\nYou seem to be nowhere.", AnsiYellow))
+		p.Output.Send(Ansi(Style("\r\nYou seem to be nowhere.", AnsiYellow)))
 		return
 	}
 	width, _ := p.WindowSize()
 	if via != "" {
-		world.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s arrives from %s.", HighlightName(p.Name), via)), p)
+		arrive := fmt.Sprintf("\r\n%s arrives from %s.", HighlightName(p.Name), via)
+		if mount := world.MountName(p); mount != "" {
+			arrive = fmt.Sprintf("\r\n%s rides in from %s on %s.", HighlightName(p.Name), via, HighlightNPCName(mount))
+		}
+		world.BroadcastToRoom(p.Room, Ansi(arrive), p)
 	}
 	title := Style(r.Title, AnsiBold, AnsiCyan)
-	desc := Style(WrapText(r.Description, width), AnsiItalic, AnsiDim)
-	exits := Style(ExitList(r), AnsiGreen)
-	p.Output <- Ansi(fmt.Sprintf("\r\n\r\n%s\r\n%s\r\nExits: %s", title, desc, exits))
+	desc, dark := DescribeRoom(world, r, width)
+	exits := Style(ExitLinks(world.ExitsFor(p, r)), AnsiGreen)
+	p.Output.Send(Ansi(fmt.Sprintf("\r\n\r\n%s\r\n%s\r\nExits: %s", title, desc, exits)))
+	for _, note := range world.RoomNotes(p) {
+		p.Output.Send(Ansi("\r\n" + note))
+	}
 	others := world.ListPlayers(true, p.Room)
 	if len(others) > 1 {
 		seen := FilterOut(others, p.Name)
 		colored := HighlightNames(seen)
-		p.Output <- Ansi(fmt.Sprintf("\r\nYou see: %s", strings.Join(colored, ", ")))
+		p.Output.Send(Ansi(fmt.Sprintf("\r\nYou see: %s", strings.Join(colored, ", "))))
 	}
-	if items := world.RoomItems(p.Room); len(items) > 0 {
+	if items := world.RoomItems(p.Room); len(items) > 0 && !dark {
 		names := make([]string, len(items))
 		for i, item := range items {
-			names[i] = HighlightItemName(item.Name)
+			names[i] = ItemLinkFor(item, "get", "examine")
 		}
-		p.Output <- Ansi(fmt.Sprintf("\r\nOn the ground: %s", strings.Join(names, ", ")))
+		p.Output.Send(Ansi(fmt.Sprintf("\r\nOn the ground: %s", strings.Join(names, ", "))))
 	}
 	if len(r.NPCs) > 0 {
 		for _, npc := range r.NPCs {
 			if strings.TrimSpace(npc.AutoGreet) == "" {
 				continue
 			}
+			if standing, ok := world.NPCStanding(p, npc); ok && standing.Unwelcome() {
+				p.Output.Send(Ansi(fmt.Sprintf("\r\n%s eyes you coldly and says nothing.", HighlightNPCName(npc.Name))))
+				continue
+			}
 			msg := fmt.Sprintf("\r\n%s says, \"%s\"", HighlightNPCName(npc.Name), npc.AutoGreet)
-			p.Output <- Ansi(msg)
+			p.Output.Send(Ansi(msg))
 		}
 	}
 	world.triggerAreaEnter(r, p, via)
 	world.triggerRoomEnter(r, p, via)
 	world.triggerNPCEnter(p.Room, p.Name)
-	p.Output <- Prompt(p)
+	world.AlertGuards(p)
+	world.ProvokeFactions(p)
+	world.Publish(GameEvent{Type: EventPlayerEntered, Player: p, Room: p.Room, Via: via})
+	world.ShowTutorialHint(p)
+	p.Output.Send(Prompt(p))
 }
 
-// ExitList renders the exits for a room in a deterministic order.
-func ExitList(r *Room) string {
-	if len(r.Exits) == 0 {
-		return "none"
-	}
-	keys := make([]string, 0, len(r.Exits))
-	for k := range r.Exits {
-		keys = append(keys, k)
+// RoomNotes lists the waypoint, gathering nodes, clan hall, and vehicles in
+// p's room, shown beneath the exits.
+func (w *World) RoomNotes(p *Player) []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	var notes []string
+	if room, ok := w.rooms[p.Room]; ok {
+		if note := arenaNote(room); note != "" {
+			notes = append(notes, note)
+		}
+		if note := protectedNote(room); note != "" {
+			notes = append(notes, note)
+		}
+		if note := w.waypointNoteLocked(p, room); note != "" {
+			notes = append(notes, note)
+		}
+		if note := w.gatherNoteLocked(room); note != "" {
+			notes = append(notes, note)
+		}
+		if note := w.clanHallNoteLocked(room); note != "" {
+			notes = append(notes, note)
+		}
+		if note := w.capturePointNoteLocked(room); note != "" {
+			notes = append(notes, note)
+		}
 	}
-	sort.Strings(keys)
-	return strings.Join(keys, " ")
+	return append(notes, w.vehicleNotesLocked(p.Room)...)
+}
+
+// ExitList renders the exits anyone can see in a room in a deterministic
+// order, marking those behind a closed door.
+func ExitList(r *Room) string {
+	return PlayerExitList(r, nil)
 }
 
 // FilterOut returns a copy of list without the provided name.
@@ -7,10 +7,10 @@ import (
 
 func TestExitListSortsDirections(t *testing.T) {
 	r := &Room{
-		Exits: map[string]RoomID{
-			"west":  "room_w",
-			"north": "room_n",
-			"east":  "room_e",
+		Exits: map[string]Exit{
+			"west":  {To: "room_w"},
+			"north": {To: "room_n"},
+			"east":  {To: "room_e"},
 		},
 	}
 
@@ -23,7 +23,7 @@ func TestExitListSortsDirections(t *testing.T) {
 }
 
 func TestExitListHandlesNoExits(t *testing.T) {
-	r := &Room{Exits: map[string]RoomID{}}
+	r := &Room{Exits: map[string]Exit{}}
 	if got := ExitList(r); got != "none" {
 		t.Fatalf("ExitList() = %q, want %q", got, "none")
 	}
@@ -53,7 +53,7 @@ func TestEnterRoomTriggersNPCGreeting(t *testing.T) {
 				ID:          "start",
 				Title:       "Test Room",
 				Description: "A place for testing.",
-				Exits:       map[string]RoomID{},
+				Exits:       map[string]Exit{},
 				NPCs: []NPC{
 					{
 						Name:      "Guide",
@@ -67,17 +67,20 @@ func TestEnterRoomTriggersNPCGreeting(t *testing.T) {
 	player := &Player{
 		Name:   "Hero",
 		Room:   "start",
-		Output: make(chan string, 4),
+		Output: NewOutputQueue(),
 		Alive:  true,
 	}
 	world.players[player.Name] = player
 
 	EnterRoom(world, player, "")
 
-	// First output is the room description.
-	<-player.Output
-	// Second output should contain the NPC greeting.
-	greet := <-player.Output
+	// First output is the room description; the second should contain the
+	// NPC greeting.
+	output := drainOutput(player.Output)
+	if len(output) < 2 {
+		t.Fatalf("expected a description and a greeting, got %q", output)
+	}
+	greet := output[1]
 	if !strings.Contains(greet, "Guide") {
 		t.Fatalf("NPC greeting missing name: %q", greet)
 	}
@@ -93,7 +96,7 @@ func TestEnterRoomListsItems(t *testing.T) {
 				ID:          "start",
 				Title:       "Item Room",
 				Description: "A room stocked with treasures.",
-				Exits:       map[string]RoomID{},
+				Exits:       map[string]Exit{},
 				Items: []Item{
 					{Name: "Lantern"},
 					{Name: "Rope"},
@@ -105,15 +108,18 @@ func TestEnterRoomListsItems(t *testing.T) {
 	player := &Player{
 		Name:   "Hero",
 		Room:   "start",
-		Output: make(chan string, 4),
+		Output: NewOutputQueue(),
 		Alive:  true,
 	}
 	world.players[player.Name] = player
 
 	EnterRoom(world, player, "")
 
-	<-player.Output // room description
-	items := <-player.Output
+	output := drainOutput(player.Output)
+	if len(output) < 2 {
+		t.Fatalf("expected a description and an item list, got %q", output)
+	}
+	items := output[1]
 	if !strings.Contains(items, "Lantern") || !strings.Contains(items, "Rope") {
 		t.Fatalf("expected item list to mention Lantern and Rope, got %q", items)
 	}
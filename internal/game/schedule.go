@@ -0,0 +1,325 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScheduleEntry places an NPC for part of the in-game day. The entry covers
+// hours From up to but not including To, wrapping past midnight when To is
+// not after From; equal hours cover the whole day. During the entry the NPC
+// stands in Room, walks Patrol one room per hour, or is Away off duty. An
+// entry naming none of them sends the NPC home. Outside every entry the NPC
+// is home as well.
+type ScheduleEntry struct {
+	From   int      `json:"from"`
+	To     int      `json:"to"`
+	Room   RoomID   `json:"room,omitempty"`
+	Patrol []RoomID `json:"patrol,omitempty"`
+	Away   bool     `json:"away,omitempty"`
+}
+
+// covers reports whether the entry applies at hour.
+func (e ScheduleEntry) covers(hour int) bool {
+	switch {
+	case e.From == e.To:
+		return true
+	case e.From < e.To:
+		return hour >= e.From && hour < e.To
+	default:
+		return hour >= e.From || hour < e.To
+	}
+}
+
+// scheduleTarget works out where npc belongs at hour. away is true when the
+// NPC should be off duty and out of every room.
+func scheduleTarget(npc NPC, hour int) (RoomID, bool) {
+	for _, entry := range npc.Schedule {
+		if !entry.covers(hour) {
+			continue
+		}
+		switch {
+		case entry.Away:
+			return "", true
+		case len(entry.Patrol) > 0:
+			step := ((hour-entry.From)%24 + 24) % 24
+			return entry.Patrol[step%len(entry.Patrol)], false
+		case entry.Room != "":
+			return entry.Room, false
+		}
+		return npc.Home, false
+	}
+	return npc.Home, false
+}
+
+// validateSchedules checks that every NPC schedule uses real hours and
+// rooms.
+func validateSchedules(rooms map[RoomID]*Room) error {
+	for id, room := range rooms {
+		for _, npc := range room.NPCs {
+			if err := validateSchedule(npc.Schedule, rooms); err != nil {
+				return fmt.Errorf("room %s: npc %s: %w", id, npc.Name, err)
+			}
+			if npc.Home != "" {
+				if _, ok := rooms[npc.Home]; !ok {
+					return fmt.Errorf("room %s: npc %s: unknown home room %s", id, npc.Name, npc.Home)
+				}
+			}
+		}
+		for _, reset := range room.Resets {
+			if err := validateSchedule(reset.Schedule, rooms); err != nil {
+				return fmt.Errorf("room %s: reset %s: %w", id, reset.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSchedule(schedule []ScheduleEntry, rooms map[RoomID]*Room) error {
+	for _, entry := range schedule {
+		if entry.From < 0 || entry.From > 23 || entry.To < 0 || entry.To > 23 {
+			return fmt.Errorf("schedule hours must be between 0 and 23")
+		}
+		targets := append([]RoomID(nil), entry.Patrol...)
+		if entry.Room != "" {
+			targets = append(targets, entry.Room)
+		}
+		for _, target := range targets {
+			if _, ok := rooms[target]; !ok {
+				return fmt.Errorf("schedule names unknown room %s", target)
+			}
+		}
+	}
+	return nil
+}
+
+// schedulePlan is where one scheduled NPC is and where it should go this
+// hour. An empty from or to means off duty.
+type schedulePlan struct {
+	npc  NPC
+	key  string
+	from RoomID
+	to   RoomID
+}
+
+// scheduleMove records an NPC that changed rooms so it can be announced once
+// the world lock is released.
+type scheduleMove struct {
+	name      string
+	from      RoomID
+	to        RoomID
+	departure string
+	arrival   string
+}
+
+func scheduleKey(npc NPC) string {
+	return strings.ToLower(npc.Name) + "|" + string(npc.Home)
+}
+
+// planSchedulesLocked collects every scheduled NPC along with where its
+// schedule puts it at hour. NPCs learn their home the first time they are
+// seen, and duplicates left behind by a reload are dropped.
+func (w *World) planSchedulesLocked(hour int) []schedulePlan {
+	ids := make([]RoomID, 0, len(w.rooms))
+	for id, room := range w.rooms {
+		if w.instanceRooms[id] != nil {
+			continue
+		}
+		for _, npc := range room.NPCs {
+			if len(npc.Schedule) > 0 {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	seen := make(map[string]bool)
+	var plans []schedulePlan
+	keep := func(npc *NPC, at RoomID) bool {
+		if npc.Home == "" {
+			npc.Home = at
+		}
+		key := scheduleKey(*npc)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		to, away := scheduleTarget(*npc, hour)
+		if away {
+			to = ""
+		}
+		plans = append(plans, schedulePlan{npc: *npc, key: key, from: at, to: to})
+		return true
+	}
+	for _, id := range ids {
+		room := w.rooms[id]
+		npcs := room.NPCs[:0]
+		for _, npc := range room.NPCs {
+			if len(npc.Schedule) == 0 || keep(&npc, id) {
+				npcs = append(npcs, npc)
+			}
+		}
+		room.NPCs = npcs
+	}
+	away := w.awayNPCs[:0]
+	for _, npc := range w.awayNPCs {
+		if keep(&npc, "") {
+			away = append(away, npc)
+		}
+	}
+	w.awayNPCs = away
+	return plans
+}
+
+// applySchedulesLocked moves each planned NPC that is not already where it
+// belongs. NPCs in the middle of a fight stay put, and plans naming rooms
+// that no longer exist are ignored.
+func (w *World) applySchedulesLocked(plans []schedulePlan) []scheduleMove {
+	var moves []scheduleMove
+	for _, plan := range plans {
+		if plan.from == plan.to {
+			continue
+		}
+		var dest *Room
+		if plan.to != "" {
+			room, ok := w.rooms[plan.to]
+			if !ok {
+				continue
+			}
+			dest = room
+		}
+		npc, ok := w.takeScheduledNPCLocked(plan)
+		if !ok {
+			continue
+		}
+		move := scheduleMove{name: npc.Name, from: plan.from, to: plan.to}
+		if src, ok := w.rooms[plan.from]; ok && dest != nil {
+			move.departure, _ = exitBackLocked(src, plan.to)
+			move.arrival, _ = exitBackLocked(dest, plan.from)
+			if move.departure != "" {
+				w.leaveScentLocked(plan.from, npc.Name, move.departure, time.Now())
+			}
+		}
+		if dest == nil {
+			w.awayNPCs = append(w.awayNPCs, npc)
+		} else {
+			dest.NPCs = append(dest.NPCs, npc)
+		}
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// takeScheduledNPCLocked removes the planned NPC from where it was seen.
+func (w *World) takeScheduledNPCLocked(plan schedulePlan) (NPC, bool) {
+	if plan.from == "" {
+		for i, npc := range w.awayNPCs {
+			if len(npc.Schedule) > 0 && scheduleKey(npc) == plan.key {
+				w.awayNPCs = append(w.awayNPCs[:i], w.awayNPCs[i+1:]...)
+				return npc, true
+			}
+		}
+		return NPC{}, false
+	}
+	room, ok := w.rooms[plan.from]
+	if !ok {
+		return NPC{}, false
+	}
+	for i, npc := range room.NPCs {
+		if len(npc.Schedule) == 0 || scheduleKey(npc) != plan.key {
+			continue
+		}
+		if combat := w.combats[plan.from]; combat != nil && combat.hasNPC(npc.Name) {
+			return NPC{}, false
+		}
+		room.NPCs = append(room.NPCs[:i], room.NPCs[i+1:]...)
+		return npc, true
+	}
+	return NPC{}, false
+}
+
+// placeScheduledNPCsLocked puts every scheduled NPC where the clock says it
+// belongs without running scripts or announcing anything. It is used when
+// the world is built or its areas are reloaded.
+func (w *World) placeScheduledNPCsLocked() {
+	w.applySchedulesLocked(w.planSchedulesLocked(w.clockHour))
+}
+
+// runSchedules moves scheduled NPCs for the current hour. Each NPC's script
+// may override its plan through OnSchedule. When announce is set, players
+// see NPCs leave and arrive.
+func (w *World) runSchedules(announce bool) {
+	w.mu.Lock()
+	hour := w.clockHour
+	plans := w.planSchedulesLocked(hour)
+	w.mu.Unlock()
+	if len(plans) == 0 {
+		return
+	}
+	phase := phaseForHour(hour)
+	for i := range plans {
+		w.scripts.callNPCOnSchedule(w, &plans[i], hour, phase)
+	}
+	w.mu.Lock()
+	moves := w.applySchedulesLocked(plans)
+	w.mu.Unlock()
+	if !announce {
+		return
+	}
+	for _, move := range moves {
+		highlighted := HighlightNPCName(move.name)
+		if move.from != "" {
+			msg := fmt.Sprintf("\r\n%s heads off.", highlighted)
+			if move.departure != "" {
+				msg = fmt.Sprintf("\r\n%s leaves %s.", highlighted, move.departure)
+			}
+			w.BroadcastToRoom(move.from, Ansi(msg))
+		}
+		if move.to != "" {
+			msg := fmt.Sprintf("\r\n%s arrives.", highlighted)
+			if move.arrival != "" {
+				msg = fmt.Sprintf("\r\n%s arrives from the %s.", highlighted, move.arrival)
+			}
+			w.BroadcastToRoom(move.to, Ansi(msg))
+		}
+	}
+}
+
+// callNPCOnSchedule lets an NPC's script adjust where its schedule sends it
+// this hour. The hook can keep the NPC where it is with stay() or redirect
+// it with send(room); send("") takes it off duty.
+func (e *scriptEngine) callNPCOnSchedule(world *World, plan *schedulePlan, hour int, phase TimeOfDay) {
+	if e == nil || plan == nil || strings.TrimSpace(plan.npc.Script) == "" {
+		return
+	}
+	script, err := e.scriptFor(plan.npc.Script)
+	if err != nil {
+		Logger().Error("NPC script failed to load", "npc", plan.npc.Name, "error", err)
+		return
+	}
+	if script == nil || script.onSchedule == nil {
+		return
+	}
+	room := plan.from
+	if room == "" {
+		room = plan.npc.Home
+	}
+	ctx := &NPCScriptContext{world: world, room: room, npc: plan.npc}
+	payload := e.payloadForNPC(ctx, "")
+	payload["hour"] = hour
+	payload["phase"] = string(phase)
+	payload["home"] = string(plan.npc.Home)
+	payload["from"] = string(plan.from)
+	payload["to"] = string(plan.to)
+	payload["stay"] = func() {
+		plan.to = plan.from
+	}
+	payload["send"] = func(room string) {
+		plan.to = RoomID(strings.TrimSpace(room))
+	}
+	e.invoke(plan.npc.Script, "OnSchedule", func() {
+		script.onSchedule(payload)
+	})
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func npcsIn(world *World, room RoomID) []string {
+	world.mu.RLock()
+	defer world.mu.RUnlock()
+	var names []string
+	for _, npc := range world.rooms[room].NPCs {
+		names = append(names, npc.Name)
+	}
+	return names
+}
+
+func TestScheduleEntryCoversWrapsPastMidnight(t *testing.T) {
+	night := ScheduleEntry{From: 20, To: 6}
+	for hour, want := range map[int]bool{19: false, 20: true, 23: true, 0: true, 5: true, 6: false, 12: false} {
+		if got := night.covers(hour); got != want {
+			t.Fatalf("covers(%d) = %v, want %v", hour, got, want)
+		}
+	}
+	if !(ScheduleEntry{From: 3, To: 3}).covers(17) {
+		t.Fatalf("expected an entry with equal hours to cover the whole day")
+	}
+}
+
+func TestAdvanceClockMovesScheduledNPCs(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"shop": {ID: "shop", Title: "Shop", Exits: map[string]Exit{"east": {To: "square"}}, NPCs: []NPC{{
+			Name:     "Shopkeeper",
+			Shop:     []Item{{Name: "Lantern", Price: 10}},
+			Schedule: []ScheduleEntry{{From: 20, To: 8, Away: true}},
+		}}},
+		"square": {ID: "square", Title: "Square", Exits: map[string]Exit{"west": {To: "shop"}, "north": {To: "gate"}}, NPCs: []NPC{{
+			Name:     "Watchman",
+			Guard:    true,
+			Schedule: []ScheduleEntry{{From: 20, To: 6, Patrol: []RoomID{"gate", "square"}}},
+		}}},
+		"gate": {ID: "gate", Title: "Gate", Exits: map[string]Exit{"south": {To: "square"}}},
+	})
+	player := &Player{Name: "Walker", Room: "square", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	world.SetClock(19)
+	world.SetDice(DiceFunc(func(n int) int { return n - 1 }))
+	drainOutput(player.Output)
+
+	world.AdvanceClock()
+
+	if got := npcsIn(world, "shop"); len(got) != 0 {
+		t.Fatalf("expected the shop to close at 20:00, found %v", got)
+	}
+	if got := npcsIn(world, "gate"); len(got) != 1 || got[0] != "Watchman" {
+		t.Fatalf("expected the watchman to start its patrol at the gate, got %v", got)
+	}
+	output := stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "Watchman leaves north.") {
+		t.Fatalf("expected the departure to be announced, got %q", output)
+	}
+
+	world.AdvanceClock()
+	if got := npcsIn(world, "square"); len(got) != 1 || got[0] != "Watchman" {
+		t.Fatalf("expected the patrol to reach the square at 21:00, got %v", got)
+	}
+	output = stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "Watchman arrives from the north.") {
+		t.Fatalf("expected the arrival to be announced, got %q", output)
+	}
+
+	world.SetClock(8)
+	if got := npcsIn(world, "shop"); len(got) != 1 || got[0] != "Shopkeeper" {
+		t.Fatalf("expected the shopkeeper back at 08:00, got %v", got)
+	}
+	if got := npcsIn(world, "square"); len(got) != 1 || got[0] != "Watchman" {
+		t.Fatalf("expected the watchman home by day, got %v", got)
+	}
+}
+
+func TestOnScheduleHookOverridesPlan(t *testing.T) {
+	script := `package main
+
+func OnSchedule(ctx map[string]any) {
+    if ctx["to"].(string) == "" && ctx["hour"].(int) < 22 {
+        ctx["send"].(func(string))("gate")
+    }
+}`
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"shop": {ID: "shop", Title: "Shop", Exits: map[string]Exit{"east": {To: "square"}}, NPCs: []NPC{{
+			Name:     "Shopkeeper",
+			Script:   script,
+			Shop:     []Item{{Name: "Lantern", Price: 10}},
+			Schedule: []ScheduleEntry{{From: 20, To: 8, Away: true}},
+		}}},
+		"square": {ID: "square", Title: "Square", Exits: map[string]Exit{"west": {To: "shop"}, "north": {To: "gate"}}, NPCs: []NPC{{
+			Name:     "Watchman",
+			Guard:    true,
+			Schedule: []ScheduleEntry{{From: 20, To: 6, Patrol: []RoomID{"gate", "square"}}},
+		}}},
+		"gate": {ID: "gate", Title: "Gate", Exits: map[string]Exit{"south": {To: "square"}}},
+	})
+
+	world.SetClock(20)
+	if got := npcsIn(world, "gate"); len(got) != 2 {
+		t.Fatalf("expected the script to send the shopkeeper to the gate, got %v", got)
+	}
+	world.SetClock(23)
+	if got := npcsIn(world, "gate"); len(got) != 0 {
+		t.Fatalf("expected the shopkeeper off duty late at night, got %v", got)
+	}
+	world.mu.RLock()
+	away := len(world.awayNPCs)
+	world.mu.RUnlock()
+	if away != 1 {
+		t.Fatalf("expected one NPC off duty, got %d", away)
+	}
+}
+
+func TestValidateSchedulesRejectsUnknownRooms(t *testing.T) {
+	rooms := map[RoomID]*Room{
+		"shop": {ID: "shop", NPCs: []NPC{{Name: "Clerk", Schedule: []ScheduleEntry{{From: 8, To: 20, Room: "nowhere"}}}}},
+	}
+	if err := validateSchedules(rooms); err == nil || !strings.Contains(err.Error(), "nowhere") {
+		t.Fatalf("expected unknown schedule room to be rejected, got %v", err)
+	}
+	rooms["shop"].NPCs[0].Schedule = []ScheduleEntry{{From: 8, To: 24}}
+	if err := validateSchedules(rooms); err == nil {
+		t.Fatalf("expected hour 24 to be rejected")
+	}
+}
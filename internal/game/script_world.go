@@ -0,0 +1,184 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scriptsTrustedLocked reports whether scripts attached to room may change
+// the world. Only rooms saved by builders or sourced from an area marked
+// trusted_scripts qualify.
+func (w *World) scriptsTrustedLocked(room RoomID) bool {
+	source, ok := w.roomSources[room]
+	if !ok {
+		return false
+	}
+	if source == builderAreaFile {
+		return true
+	}
+	return w.areaMeta[source].Trusted
+}
+
+// ScriptsTrusted reports whether scripts attached to room may change the
+// world.
+func (w *World) ScriptsTrusted(room RoomID) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.scriptsTrustedLocked(room)
+}
+
+// RoomFlag reports whether a script has raised flag in room.
+func (w *World) RoomFlag(room RoomID, flag string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.roomFlags[room][strings.ToLower(strings.TrimSpace(flag))]
+}
+
+// SetRoomFlag raises or clears a named flag in room. Flags hold puzzle state
+// for scripts and last until the server restarts.
+func (w *World) SetRoomFlag(room RoomID, flag string, value bool) {
+	key := strings.ToLower(strings.TrimSpace(flag))
+	if key == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !value {
+		delete(w.roomFlags[room], key)
+		return
+	}
+	if w.roomFlags == nil {
+		w.roomFlags = make(map[RoomID]map[string]bool)
+	}
+	if w.roomFlags[room] == nil {
+		w.roomFlags[room] = make(map[string]bool)
+	}
+	w.roomFlags[room][key] = true
+}
+
+// SpawnItem places a new item on the floor of room.
+func (w *World) SpawnItem(room RoomID, item Item) error {
+	item.Name = strings.TrimSpace(item.Name)
+	item.Description = strings.TrimSpace(item.Description)
+	if item.Name == "" {
+		return fmt.Errorf("item name must not be empty")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r, ok := w.rooms[room]
+	if !ok {
+		return fmt.Errorf("unknown room: %s", room)
+	}
+	r.Items = append(r.Items, item)
+	return nil
+}
+
+// OpenExit unlocks and opens the door in direction from room, along with the
+// door leading back. It reports false when there is no closed door that way.
+func (w *World) OpenExit(room RoomID, direction string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r, ok := w.rooms[room]
+	if !ok {
+		return false
+	}
+	dir, ok := matchExitLocked(r, direction)
+	if !ok {
+		return false
+	}
+	exit := r.Exits[dir]
+	if !exit.Door || !exit.Closed {
+		return false
+	}
+	exit.Closed, exit.Locked = false, false
+	r.Exits[dir] = exit
+	w.syncReverseDoorLocked(r.ID, exit)
+	return true
+}
+
+// maxScriptMoves bounds how many script moves may nest for one player, so
+// rooms whose OnEnter hooks send players back and forth cannot recurse
+// forever.
+const maxScriptMoves = 3
+
+// ScriptMovePlayer carries p to room and shows them their new surroundings.
+func (w *World) ScriptMovePlayer(p *Player, room RoomID) error {
+	previous := p.Room
+	if previous == room {
+		return fmt.Errorf("%s is already there", p.Name)
+	}
+	w.mu.Lock()
+	if p.scriptMoves >= maxScriptMoves {
+		w.mu.Unlock()
+		return fmt.Errorf("too many nested script moves for %s", p.Name)
+	}
+	p.scriptMoves++
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		p.scriptMoves--
+		w.mu.Unlock()
+	}()
+	if err := w.MoveToRoom(p, room); err != nil {
+		return err
+	}
+	w.BroadcastToRoom(previous, Ansi(fmt.Sprintf("\r\n%s is swept away.", HighlightName(p.Name))), p)
+	w.BroadcastToRoom(room, Ansi(fmt.Sprintf("\r\n%s is swept in.", HighlightName(p.Name))), p)
+	if p.Output != nil {
+		EnterRoom(w, p, "")
+	}
+	return nil
+}
+
+// ScriptStartCombat sets the named NPC in p's room upon p.
+func (w *World) ScriptStartCombat(p *Player, npcName string) error {
+	if !p.Alive {
+		return fmt.Errorf("%s is in no condition to fight", p.Name)
+	}
+	npc, ok := w.FindRoomNPC(p.Room, npcName)
+	if !ok {
+		return fmt.Errorf("no %s here", strings.TrimSpace(npcName))
+	}
+	highlighted := HighlightNPCName(npc.Name)
+	if p.Output != nil {
+		p.Output.Send(Ansi(fmt.Sprintf("\r\n%s attacks you!", highlighted)))
+	}
+	w.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s attacks %s!", highlighted, HighlightName(p.Name))), p)
+	combat := w.ensureCombat(p.Room)
+	combat.addPlayer(p.Name, combatTarget{kind: combatTargetNPC, name: npc.Name})
+	combat.addNPC(npc.Name, combatTarget{kind: combatTargetPlayer, name: p.Name})
+	combat.startLoop()
+	return nil
+}
+
+// addWorldHelpers exposes room flags to every script and, for scripts from
+// trusted areas, helpers that change the world around room.
+func addWorldHelpers(payload map[string]any, w *World, room RoomID, p *Player) {
+	if w == nil || room == "" {
+		return
+	}
+	payload["room_flag"] = func(flag string) bool {
+		return w.RoomFlag(room, flag)
+	}
+	if !w.ScriptsTrusted(room) {
+		return
+	}
+	payload["set_room_flag"] = func(flag string, value bool) {
+		w.SetRoomFlag(room, flag, value)
+	}
+	payload["spawn_item"] = func(name, description string) bool {
+		return w.SpawnItem(room, Item{Name: name, Description: description}) == nil
+	}
+	payload["open_exit"] = func(direction string) bool {
+		return w.OpenExit(room, direction)
+	}
+	if p == nil {
+		return
+	}
+	payload["move_player"] = func(destination string) bool {
+		return w.ScriptMovePlayer(p, RoomID(strings.TrimSpace(destination))) == nil
+	}
+	payload["start_combat"] = func(npc string) bool {
+		return w.ScriptStartCombat(p, npc) == nil
+	}
+}
@@ -0,0 +1,222 @@
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ScrySkill and LocateSkill are the race or class skills needed to
+	// scry on players and locate items.
+	ScrySkill   = "scry"
+	LocateSkill = "locate"
+	// ScryManaCost and LocateManaCost are the mana each attempt spends.
+	ScryManaCost   = 20
+	LocateManaCost = 15
+	// ScryCooldown and LocateCooldown are how long the caster waits before
+	// trying again. They run as status effects so stats shows them.
+	ScryCooldown   = time.Minute
+	LocateCooldown = 30 * time.Second
+	// ScryCooldownEffect and LocateCooldownEffect name those effects.
+	ScryCooldownEffect   = "scry cooldown"
+	LocateCooldownEffect = "locate cooldown"
+	// SanctuaryEffect hides a player, and everything they carry, from
+	// scrying and locate magic.
+	SanctuaryEffect = "sanctuary"
+	// maxLocateResults caps how many places locate reports.
+	maxLocateResults = 5
+)
+
+var (
+	// ErrCannotScry indicates the player's race and class do not teach the
+	// spell they tried.
+	ErrCannotScry = errors.New("your training does not include that magic")
+	// ErrScryCooldown indicates the spell was cast too recently.
+	ErrScryCooldown = errors.New("your inner eye needs time to recover")
+	// ErrScryMana indicates the player lacks the mana to cast.
+	ErrScryMana = errors.New("you lack the mana for that")
+	// ErrScryBlocked indicates a warded room or sanctuary turned the
+	// vision aside. The attempt still costs mana and starts the cooldown.
+	ErrScryBlocked = errors.New("a veil of warding clouds your vision")
+	// ErrScryNoTarget indicates nothing matching could be found.
+	ErrScryNoTarget = errors.New("your vision finds nothing by that name")
+)
+
+// ScryVision is what a scrying spell shows of a distant room.
+type ScryVision struct {
+	// Name is the player or item that was found.
+	Name string
+	// Holder names the player carrying a located item, if any.
+	Holder string
+	Room   RoomID
+	Title  string
+	// Exits lists the room's visible exits, sorted.
+	Exits []string
+}
+
+// scryVisionLocked describes room as p would see it from afar.
+func scryVisionLocked(p *Player, room *Room, name string) ScryVision {
+	exits := make([]string, 0, len(room.Exits))
+	for dir, exit := range room.Exits {
+		if exitVisibleLocked(p, room.ID, dir, exit) {
+			exits = append(exits, dir)
+		}
+	}
+	sort.Strings(exits)
+	return ScryVision{Name: name, Room: room.ID, Title: room.Title, Exits: exits}
+}
+
+// scryWardedLocked reports whether scrying magic is kept out of room or
+// away from holder.
+func scryWardedLocked(room *Room, holder *Player) bool {
+	if room.NoScry {
+		return true
+	}
+	return holder != nil && holder.HasEffect(SanctuaryEffect)
+}
+
+// beginScryLocked checks p's cooldown and mana before casting.
+func beginScryLocked(p *Player, cooldown string, cost int) error {
+	if p.HasEffect(cooldown) {
+		return ErrScryCooldown
+	}
+	p.EnsureStats()
+	if p.Mana < cost {
+		return ErrScryMana
+	}
+	return nil
+}
+
+// finishScryLocked spends the mana and starts the cooldown once a cast has
+// reached for its target.
+func finishScryLocked(p *Player, cooldown string, cost int, wait time.Duration) {
+	p.Mana -= cost
+	p.addEffect(StatusEffect{Name: cooldown, Expires: time.Now().Add(wait)})
+}
+
+// Scry shows p the room another online player stands in. Players under
+// sanctuary and rooms flagged no_scry turn the vision aside.
+func (w *World) Scry(p *Player, name string) (ScryVision, error) {
+	name = strings.TrimSpace(name)
+	if !w.KnowsSkill(p, ScrySkill) {
+		return ScryVision{}, ErrCannotScry
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := beginScryLocked(p, ScryCooldownEffect, ScryManaCost); err != nil {
+		return ScryVision{}, err
+	}
+	var candidates []*Player
+	var names []string
+	for _, other := range w.players {
+		if other != p && other.Alive {
+			candidates = append(candidates, other)
+			names = append(names, other.Name)
+		}
+	}
+	idx, ok := uniqueMatch(name, names, false)
+	if !ok {
+		return ScryVision{}, ErrScryNoTarget
+	}
+	target := candidates[idx]
+	room, ok := w.rooms[target.Room]
+	if !ok {
+		return ScryVision{}, ErrScryNoTarget
+	}
+	finishScryLocked(p, ScryCooldownEffect, ScryManaCost, ScryCooldown)
+	if scryWardedLocked(room, target) {
+		return ScryVision{}, ErrScryBlocked
+	}
+	return scryVisionLocked(p, room, target.Name), nil
+}
+
+// Locate finds where items matching name lie on the ground or are carried
+// by online players, up to maxLocateResults places. Items in no_scry rooms
+// or carried by someone under sanctuary stay hidden; when that hides every
+// match, ErrScryBlocked is returned.
+func (w *World) Locate(p *Player, name string) ([]ScryVision, error) {
+	name = strings.TrimSpace(name)
+	if !w.KnowsSkill(p, LocateSkill) {
+		return nil, ErrCannotScry
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := beginScryLocked(p, LocateCooldownEffect, LocateManaCost); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, ErrScryNoTarget
+	}
+	matches := func(item Item) bool {
+		_, ok := uniqueMatch(name, []string{item.Name}, true)
+		return ok
+	}
+	var visions []ScryVision
+	warded := false
+	ids := make([]RoomID, 0, len(w.rooms))
+	for id := range w.rooms {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		room := w.rooms[id]
+		for _, item := range room.Items {
+			if !matches(item) {
+				continue
+			}
+			if scryWardedLocked(room, nil) {
+				warded = true
+				continue
+			}
+			visions = append(visions, scryVisionLocked(p, room, item.Name))
+		}
+	}
+	holders := make([]*Player, 0, len(w.players))
+	for _, other := range w.players {
+		if other.Alive {
+			holders = append(holders, other)
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i].Name < holders[j].Name })
+	for _, holder := range holders {
+		room, ok := w.rooms[holder.Room]
+		if !ok {
+			continue
+		}
+		for _, item := range holder.Inventory {
+			if !matches(item) {
+				continue
+			}
+			if scryWardedLocked(room, holder) {
+				warded = true
+				continue
+			}
+			vision := scryVisionLocked(p, room, item.Name)
+			vision.Holder = holder.Name
+			visions = append(visions, vision)
+		}
+	}
+	if len(visions) == 0 && !warded {
+		return nil, ErrScryNoTarget
+	}
+	finishScryLocked(p, LocateCooldownEffect, LocateManaCost, LocateCooldown)
+	if len(visions) == 0 {
+		return nil, ErrScryBlocked
+	}
+	if len(visions) > maxLocateResults {
+		visions = visions[:maxLocateResults]
+	}
+	return visions, nil
+}
+
+// SetRoomNoScry wards a room against scrying and locate magic, or lifts the
+// ward.
+func (w *World) SetRoomNoScry(id RoomID, noScry bool, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.NoScry
+		room.NoScry = noScry
+		return func() { room.NoScry = prev }
+	})
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScryRevealsRoomAndStartsCooldown(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tower":  {ID: "tower", Title: "Tower", Exits: map[string]Exit{"down": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Exits: map[string]Exit{"up": {To: "tower"}, "east": {To: "vault"}, "crack": {To: "vault", ExitRule: ExitRule{Hidden: true}}}},
+		"vault":  {ID: "vault", Title: "Vault", NoScry: true, Exits: map[string]Exit{"west": {To: "square"}}, Items: []Item{{Name: "Silver Key"}}},
+	})
+	world.characterOptions = CharacterOptions{Classes: []Archetype{
+		{ID: "mage", Name: "Mage", Skills: []string{ScrySkill, LocateSkill}},
+		{ID: "warrior", Name: "Warrior", Skills: []string{"bash"}},
+	}}
+	seer := &Player{Name: "Seer", Class: "mage", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	target := &Player{Name: "Wanderer", Room: "square", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(seer)
+	world.AddPlayerForTest(target)
+	seer.EnsureStats()
+	seer.MaxMana, seer.Mana = 100, 100
+	vision, err := world.Scry(seer, "wand")
+	if err != nil {
+		t.Fatalf("Scry: %v", err)
+	}
+	if vision.Name != "Wanderer" || vision.Title != "Square" || len(vision.Exits) != 2 || vision.Exits[0] != "east" || vision.Exits[1] != "up" {
+		t.Fatalf("unexpected vision %+v", vision)
+	}
+	if seer.Mana != 100-ScryManaCost || !seer.HasEffect(ScryCooldownEffect) {
+		t.Fatalf("expected mana spent and a cooldown, got %d mana and effects %+v", seer.Mana, seer.Effects)
+	}
+	if _, err := world.Scry(seer, "wanderer"); !errors.Is(err, ErrScryCooldown) {
+		t.Fatalf("expected a cooldown, got %v", err)
+	}
+
+	delete(seer.Effects, ScryCooldownEffect)
+	target.addEffect(StatusEffect{Name: SanctuaryEffect, Expires: time.Now().Add(time.Minute)})
+	if _, err := world.Scry(seer, "wanderer"); !errors.Is(err, ErrScryBlocked) {
+		t.Fatalf("expected sanctuary to block the vision, got %v", err)
+	}
+	if seer.Mana != 100-2*ScryManaCost {
+		t.Fatalf("a blocked scry should still cost mana, got %d", seer.Mana)
+	}
+
+	delete(seer.Effects, ScryCooldownEffect)
+	delete(target.Effects, SanctuaryEffect)
+	target.Room = "vault"
+	if _, err := world.Scry(seer, "wanderer"); !errors.Is(err, ErrScryBlocked) {
+		t.Fatalf("expected the warded room to block the vision, got %v", err)
+	}
+
+	seer.Class = "warrior"
+	if _, err := world.Scry(seer, "wanderer"); !errors.Is(err, ErrCannotScry) {
+		t.Fatalf("expected warriors to be unable to scry, got %v", err)
+	}
+}
+
+func TestLocateFindsCarriedItemsAndRespectsWards(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tower":  {ID: "tower", Title: "Tower", Exits: map[string]Exit{"down": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Exits: map[string]Exit{"up": {To: "tower"}, "east": {To: "vault"}, "crack": {To: "vault", ExitRule: ExitRule{Hidden: true}}}},
+		"vault":  {ID: "vault", Title: "Vault", NoScry: true, Exits: map[string]Exit{"west": {To: "square"}}, Items: []Item{{Name: "Silver Key"}}},
+	})
+	world.characterOptions = CharacterOptions{Classes: []Archetype{
+		{ID: "mage", Name: "Mage", Skills: []string{ScrySkill, LocateSkill}},
+		{ID: "warrior", Name: "Warrior", Skills: []string{"bash"}},
+	}}
+	seer := &Player{Name: "Seer", Class: "mage", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	target := &Player{Name: "Wanderer", Room: "square", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(seer)
+	world.AddPlayerForTest(target)
+	seer.EnsureStats()
+	seer.MaxMana, seer.Mana = 100, 100
+	if _, err := world.Locate(seer, "key"); !errors.Is(err, ErrScryBlocked) {
+		t.Fatalf("expected the key in the vault to be hidden, got %v", err)
+	}
+	if !seer.HasEffect(LocateCooldownEffect) {
+		t.Fatalf("expected a blocked locate to start the cooldown")
+	}
+
+	delete(seer.Effects, LocateCooldownEffect)
+	target.Inventory = append(target.Inventory, Item{Name: "Brass Key"})
+	visions, err := world.Locate(seer, "key")
+	if err != nil || len(visions) != 1 {
+		t.Fatalf("expected one visible key, got %+v, %v", visions, err)
+	}
+	if visions[0].Name != "Brass Key" || visions[0].Holder != "Wanderer" || visions[0].Title != "Square" {
+		t.Fatalf("unexpected vision %+v", visions[0])
+	}
+
+	delete(seer.Effects, LocateCooldownEffect)
+	mana := seer.Mana
+	if _, err := world.Locate(seer, "lantern"); !errors.Is(err, ErrScryNoTarget) {
+		t.Fatalf("expected nothing to be found, got %v", err)
+	}
+	if seer.Mana != mana || seer.HasEffect(LocateCooldownEffect) {
+		t.Fatalf("finding nothing should cost nothing")
+	}
+
+	seer.Mana = LocateManaCost - 1
+	if _, err := world.Locate(seer, "key"); !errors.Is(err, ErrScryMana) {
+		t.Fatalf("expected too little mana, got %v", err)
+	}
+}
+
+func TestSetRoomNoScryWardsRoom(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"tower":  {ID: "tower", Title: "Tower", Exits: map[string]Exit{"down": {To: "square"}}},
+		"square": {ID: "square", Title: "Square", Exits: map[string]Exit{"up": {To: "tower"}, "east": {To: "vault"}, "crack": {To: "vault", ExitRule: ExitRule{Hidden: true}}}},
+		"vault":  {ID: "vault", Title: "Vault", NoScry: true, Exits: map[string]Exit{"west": {To: "square"}}, Items: []Item{{Name: "Silver Key"}}},
+	})
+	world.characterOptions = CharacterOptions{Classes: []Archetype{
+		{ID: "mage", Name: "Mage", Skills: []string{ScrySkill, LocateSkill}},
+		{ID: "warrior", Name: "Warrior", Skills: []string{"bash"}},
+	}}
+	seer := &Player{Name: "Seer", Class: "mage", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	target := &Player{Name: "Wanderer", Room: "square", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(seer)
+	world.AddPlayerForTest(target)
+	seer.EnsureStats()
+	seer.MaxMana, seer.Mana = 100, 100
+	if err := world.SetRoomNoScry("square", true, "Builder"); err != nil {
+		t.Fatalf("SetRoomNoScry: %v", err)
+	}
+	if _, err := world.Scry(seer, "wanderer"); !errors.Is(err, ErrScryBlocked) {
+		t.Fatalf("expected the newly warded room to block the vision, got %v", err)
+	}
+}
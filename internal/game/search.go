@@ -0,0 +1,139 @@
+package game
+
+import (
+	"sort"
+	"strings"
+)
+
+// searchSnippetRadius is how many characters of context surround a match in
+// search result snippets.
+const searchSnippetRadius = 30
+
+// SearchResult describes where a phrase was found in the world data.
+type SearchResult struct {
+	// Kind is "room", "npc", "item", or "quest".
+	Kind string
+	// Room is the room holding the match. Quests report their giver's room
+	// when the giver can be found.
+	Room RoomID
+	// Name identifies the matching NPC, item, or quest.
+	Name string
+	// Field names the text that matched, such as "title" or "description".
+	Field   string
+	Snippet string
+}
+
+// Search returns every room title and description, NPC, item, and quest
+// whose text contains query, ignoring case. Results are ordered by room and
+// then by the order the fields were scanned.
+func (w *World) Search(query string) []SearchResult {
+	needle := strings.ToLower(strings.TrimSpace(query))
+	if needle == "" {
+		return nil
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	ids := make([]RoomID, 0, len(w.rooms))
+	for id := range w.rooms {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var results []SearchResult
+	add := func(kind string, room RoomID, name, field, text string) {
+		if snippet, ok := searchSnippet(text, needle); ok {
+			results = append(results, SearchResult{Kind: kind, Room: room, Name: name, Field: field, Snippet: snippet})
+		}
+	}
+	var addItem func(room RoomID, item Item, field string)
+	addItem = func(room RoomID, item Item, field string) {
+		add("item", room, item.Name, field+" name", item.Name)
+		add("item", room, item.Name, field+" description", item.Description)
+		for _, inner := range item.Contents {
+			addItem(room, inner, field)
+		}
+	}
+	npcRooms := make(map[string]RoomID)
+	for _, id := range ids {
+		room := w.rooms[id]
+		add("room", id, room.Title, "title", room.Title)
+		add("room", id, room.Title, "description", room.Description)
+		for _, npc := range room.NPCs {
+			key := strings.ToLower(npc.Name)
+			if _, ok := npcRooms[key]; !ok {
+				npcRooms[key] = id
+			}
+			add("npc", id, npc.Name, "name", npc.Name)
+			add("npc", id, npc.Name, "greeting", npc.AutoGreet)
+			for _, loot := range npc.Loot {
+				addItem(id, loot, "loot")
+			}
+		}
+		for _, item := range room.Items {
+			if item.Corpse {
+				continue
+			}
+			addItem(id, item, "item")
+		}
+		for _, reset := range room.Resets {
+			if reset.Kind != ResetKindItem {
+				continue
+			}
+			add("item", id, reset.Name, "reset name", reset.Name)
+			add("item", id, reset.Name, "reset description", reset.Description)
+		}
+	}
+
+	questIDs := make([]string, 0, len(w.quests))
+	for id := range w.quests {
+		questIDs = append(questIDs, id)
+	}
+	sort.Strings(questIDs)
+	for _, id := range questIDs {
+		quest := w.quests[id]
+		room := npcRooms[strings.ToLower(quest.Giver)]
+		add("quest", room, quest.Name, "name", quest.Name)
+		add("quest", room, quest.Name, "description", quest.Description)
+		add("quest", room, quest.Name, "completion message", quest.CompletionMessage)
+	}
+	return results
+}
+
+// searchSnippet returns text around the first case-insensitive occurrence
+// of needle, which must already be lower case.
+func searchSnippet(text, needle string) (string, bool) {
+	lower := strings.ToLower(text)
+	index := strings.Index(lower, needle)
+	if index < 0 {
+		return "", false
+	}
+	if len(lower) != len(text) {
+		// Case folding changed the byte length, so offsets into lower do
+		// not line up with text; fall back to the whole string.
+		return strings.Join(strings.Fields(text), " "), true
+	}
+	start := index - searchSnippetRadius
+	prefix := "..."
+	if start <= 0 {
+		start = 0
+		prefix = ""
+	}
+	end := index + len(needle) + searchSnippetRadius
+	suffix := "..."
+	if end >= len(text) {
+		end = len(text)
+		suffix = ""
+	}
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+	return prefix + strings.Join(strings.Fields(text[start:end]), " ") + suffix, true
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorldSearchFindsRoomsNPCsItemsAndQuests(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall": {
+			ID:          "hall",
+			Title:       "Lantern Hall",
+			Description: "Rows of brass lanterns hang from the vaulted ceiling.",
+			NPCs:        []NPC{{Name: "Keeper", AutoGreet: "Mind the lanterns, traveller."}},
+		},
+		"vault": {
+			ID:    "vault",
+			Title: "Vault",
+			Items: []Item{{Name: "chest", Container: true, Contents: []Item{{Name: "lantern wick"}}}},
+		},
+	})
+	world.quests = map[string]*Quest{
+		"wicks": {ID: "wicks", Name: "Trim the Wicks", Description: "Bring a lantern wick to the Keeper.", Giver: "keeper"},
+	}
+
+	results := world.Search("LANTERN")
+	kinds := make(map[string]int)
+	for _, result := range results {
+		kinds[result.Kind]++
+		if !strings.Contains(strings.ToLower(result.Snippet), "lantern") {
+			t.Fatalf("snippet %q does not contain the query", result.Snippet)
+		}
+	}
+	if kinds["room"] != 2 || kinds["npc"] != 1 || kinds["item"] != 1 || kinds["quest"] != 1 {
+		t.Fatalf("unexpected result kinds %v in %#v", kinds, results)
+	}
+	for _, result := range results {
+		if result.Kind == "quest" && result.Room != "hall" {
+			t.Fatalf("quest result room = %q, want giver's room hall", result.Room)
+		}
+		if result.Kind == "item" && result.Room != "vault" {
+			t.Fatalf("nested item result room = %q, want vault", result.Room)
+		}
+	}
+	if got := world.Search("   "); got != nil {
+		t.Fatalf("blank query returned %#v", got)
+	}
+}
+
+func TestSearchSnippetTrimsLongText(t *testing.T) {
+	text := strings.Repeat("a", 100) + " needle " + strings.Repeat("b", 100)
+	snippet, ok := searchSnippet(text, "needle")
+	if !ok {
+		t.Fatalf("expected a match")
+	}
+	if !strings.HasPrefix(snippet, "...") || !strings.HasSuffix(snippet, "...") || len(snippet) > 2*searchSnippetRadius+20 {
+		t.Fatalf("snippet %q was not trimmed around the match", snippet)
+	}
+}
@@ -12,8 +12,11 @@ import (
 	"math/big"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -30,9 +33,25 @@ type serverConfig struct {
 }
 
 type serverOptions struct {
-	mailPath  string
-	tellsPath string
-	portalCfg *PortalConfig
+	mailPath    string
+	tellsPath   string
+	tokenPath   string
+	portalCfg   *PortalConfig
+	bridgeCfg   *DiscordBridgeConfig
+	intermudCfg *IntermudConfig
+	death       *DeathPenalty
+	mailQuota   *int
+	logCfg      *LogConfig
+	metrics     string
+	snapshots   *SnapshotConfig
+	chatLog     *ChatLogConfig
+	storage     string
+	gameHour    *time.Duration
+	listing     *time.Duration
+	watch       time.Duration
+	linkdead    *time.Duration
+	tunables    *Tunables
+	configFile  *ConfigFile
 }
 
 // ServerOption customises the behaviour of ListenAndServe and ListenAndServeTLS.
@@ -52,6 +71,31 @@ func WithTellPath(path string) ServerOption {
 	}
 }
 
+// WithAPITokenPath overrides the default REST API token storage location.
+func WithAPITokenPath(path string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.tokenPath = strings.TrimSpace(path)
+	}
+}
+
+// WithMailboxQuota overrides how many personal letters each player may hold.
+// Zero disables the quota.
+func WithMailboxQuota(quota int) ServerOption {
+	return func(opts *serverOptions) {
+		value := quota
+		opts.mailQuota = &value
+	}
+}
+
+// WithMarketListingDuration overrides how long market listings stay up
+// before unsold items are mailed back.
+func WithMarketListingDuration(d time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		value := d
+		opts.listing = &value
+	}
+}
+
 // WithStoragePaths overrides both the mail and offline tell storage locations.
 func WithStoragePaths(mailPath, tellsPath string) ServerOption {
 	return func(opts *serverOptions) {
@@ -68,11 +112,127 @@ func WithPortalConfig(cfg PortalConfig) ServerOption {
 	}
 }
 
+// WithDiscordBridge relays chat channels to Discord using the provided configuration.
+func WithDiscordBridge(cfg DiscordBridgeConfig) ServerOption {
+	return func(opts *serverOptions) {
+		copy := cfg
+		opts.bridgeCfg = &copy
+	}
+}
+
+// WithIntermud joins an IMC2 intermud network using the provided configuration.
+func WithIntermud(cfg IntermudConfig) ServerOption {
+	return func(opts *serverOptions) {
+		copy := cfg
+		opts.intermudCfg = &copy
+	}
+}
+
+// WithDeathPenalty overrides the penalties applied when players are defeated.
+func WithDeathPenalty(penalty DeathPenalty) ServerOption {
+	return func(opts *serverOptions) {
+		copy := penalty
+		opts.death = &copy
+	}
+}
+
+// WithLogConfig routes server logs to a rotating file at the given level.
+func WithLogConfig(cfg LogConfig) ServerOption {
+	return func(opts *serverOptions) {
+		copy := cfg
+		opts.logCfg = &copy
+	}
+}
+
+// WithMetricsAddr serves Prometheus metrics at /metrics on the given address.
+func WithMetricsAddr(addr string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.metrics = strings.TrimSpace(addr)
+	}
+}
+
+// WithSnapshotConfig overrides where world snapshots are stored and how often
+// they are taken.
+func WithSnapshotConfig(cfg SnapshotConfig) ServerOption {
+	return func(opts *serverOptions) {
+		copy := cfg
+		opts.snapshots = &copy
+	}
+}
+
+// WithChatLogConfig overrides where global channel scrollback is stored and
+// how much of it is kept.
+func WithChatLogConfig(cfg ChatLogConfig) ServerOption {
+	return func(opts *serverOptions) {
+		copy := cfg
+		opts.chatLog = &copy
+	}
+}
+
+// WithGameHour sets how much real time passes for each in-game hour. A zero
+// duration stops the clock.
+func WithGameHour(hour time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		copy := hour
+		opts.gameHour = &copy
+	}
+}
+
+// WithLinkdeadGrace sets how long players whose connection drops stay in the
+// world waiting to reconnect. A zero duration logs them out immediately.
+func WithLinkdeadGrace(grace time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		copy := grace
+		opts.linkdead = &copy
+	}
+}
+
+// WithTunables sets the combat pace, experience rate, idle timeout, and
+// default channels.
+func WithTunables(t Tunables) ServerOption {
+	return func(opts *serverOptions) {
+		copy := t
+		opts.tunables = &copy
+	}
+}
+
+// WithConfigFile records the config file the settings were read from so
+// admins can reload it while the server runs.
+func WithConfigFile(cfg ConfigFile) ServerOption {
+	return func(opts *serverOptions) {
+		copy := cfg
+		opts.configFile = &copy
+	}
+}
+
+// WithAreaWatch polls the area and quest files at the given interval and
+// hot-reloads them when they change. A zero interval disables watching.
+func WithAreaWatch(interval time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.watch = interval
+	}
+}
+
+// WithStorage selects the persistence backend for accounts, mail, tells, and
+// builder rooms. See OpenStorage for the accepted specs.
+func WithStorage(spec string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.storage = strings.TrimSpace(spec)
+	}
+}
+
 var (
 	accountManagerFactory = NewAccountManager
 	worldFactory          = NewWorld
 	mailSystemFactory     = NewMailSystem
+	marketSystemFactory   = NewMarketSystem
+	reportSystemFactory   = NewReportSystem
+	petitionSystemFactory = NewPetitionSystem
+	announcementFactory   = NewAnnouncementSystem
+	clanSystemFactory     = NewClanSystem
 	tellSystemFactory     = NewTellSystem
+	apiTokenStoreFactory  = NewAPITokenStore
+	banListFactory        = NewBanList
 	netListenFunc         = net.Listen
 	tlsListenFunc         = tls.Listen
 	ensureCertificateFunc = ensureCertificate
@@ -173,19 +333,57 @@ func generateSelfSignedCert(certFile, keyFile, addr string) error {
 }
 
 func handleConn(conn net.Conn, world *World, accounts *AccountManager, dispatcher Dispatcher) {
+	metrics.connectionOpened()
+	defer metrics.connectionClosed()
 	session := NewTelnetSession(conn)
 	defer session.Close()
-	username, isAdmin, err := login(session, accounts)
+	addr := session.RemoteIP()
+	bans := world.BanList()
+	if bans != nil {
+		if entry, banned := bans.IPBan(addr); banned {
+			notice := "Connections from your address have been banned."
+			if entry.Reason != "" {
+				notice = "Connections from your address have been banned: " + entry.Reason
+			}
+			_ = session.WriteString(Ansi("\r\n" + Style(notice, AnsiYellow) + "\r\n"))
+			return
+		}
+	}
+	throttle := world.LoginThrottle()
+	if _, blocked := throttle.Blocked(addr, time.Now()); blocked {
+		_ = session.WriteString(Ansi("\r\n" + Style(loginThrottledNotice, AnsiYellow) + "\r\n"))
+		return
+	}
+	username, isAdmin, err := login(session, world, accounts, throttle, addr)
 	if err != nil {
 		return
 	}
+	if rejectBannedAccount(session, bans, username) {
+		return
+	}
+	character, err := selectCharacter(session, accounts, username)
+	if err != nil {
+		return
+	}
+	if character != username && rejectBannedAccount(session, bans, character) {
+		return
+	}
+
+	if p, missed, ok := world.Reconnect(character, session); ok {
+		if err := accounts.RecordLogin(username, time.Now().UTC()); err != nil {
+			Logger().Warn("failed to record login", "account", username, "error", err)
+		}
+		resumeLinkdead(session, p, missed)
+		runSession(conn, session, world, dispatcher, p)
+		return
+	}
 
 	for {
-		if _, ok := world.ActivePlayer(username); !ok {
+		if _, ok := world.ActivePlayer(character); !ok {
 			break
 		}
 
-		notice := "\r\n" + Style("Another session for "+HighlightName(username)+" is already active.", AnsiYellow)
+		notice := "\r\n" + Style("Another session for "+HighlightName(character)+" is already active.", AnsiYellow)
 		_ = session.WriteString(Ansi(notice))
 		_ = session.WriteString(Ansi("\r\nTake over the existing session? (yes/no): "))
 		response, err := session.ReadLine()
@@ -195,17 +393,14 @@ func handleConn(conn net.Conn, world *World, accounts *AccountManager, dispatche
 		answer := strings.ToLower(Trim(response))
 		switch answer {
 		case "y", "yes":
-			oldSession, oldOutput, ok := world.PrepareTakeover(username)
+			oldSession, oldOutput, ok := world.PrepareTakeover(character)
 			if !ok {
 				continue
 			}
 			takeover := Ansi("\r\n" + Style("Your connection has been claimed from another location.", AnsiYellow) + "\r\n")
 			if oldOutput != nil {
-				select {
-				case oldOutput <- takeover:
-				default:
-				}
-				close(oldOutput)
+				oldOutput.Send(takeover)
+				oldOutput.Close()
 			}
 			if oldSession != nil {
 				_ = oldSession.Close()
@@ -220,65 +415,101 @@ func handleConn(conn net.Conn, world *World, accounts *AccountManager, dispatche
 		}
 	}
 
-	profile := accounts.Profile(username)
-	p, err := world.addPlayer(username, session, isAdmin, profile)
+	profile := accounts.Profile(character)
+	if world.NeedsCharacterOptions(profile) {
+		if err := chooseCharacterOptions(session, world, &profile); err != nil {
+			return
+		}
+	}
+	p, err := world.addCharacter(username, character, session, isAdmin, profile)
 	if err != nil {
 		_ = session.WriteString(Ansi(Style("\r\n"+err.Error()+"\r\n", AnsiYellow)))
 		return
 	}
 
 	if err := accounts.RecordLogin(username, time.Now().UTC()); err != nil {
-		fmt.Printf("failed to record login for %s: %v\n", username, err)
+		Logger().Warn("failed to record login", "account", username, "error", err)
 	}
 
-	go func() {
-		for out := range p.Output {
-			_ = session.WriteString(out)
-		}
-	}()
+	go world.pumpOutput(p, p.Output)
 
-	p.Output <- Ansi("\r\n" + Style(postLoginAtmosphere, AnsiMagenta, AnsiBold) + "\r\n")
-	p.Output <- Ansi("Welcome, " + HighlightName(p.Name) + Style("!\r\n", AnsiMagenta))
-	p.Output <- Ansi(Style(postLoginPrompt+"\r\n", AnsiGreen))
+	p.Output.Send(Ansi("\r\n" + Style(postLoginAtmosphere, AnsiMagenta, AnsiBold) + "\r\n"))
+	p.Output.Send(Ansi("Welcome, " + HighlightName(p.Name) + Style("!\r\n", AnsiMagenta)))
+	p.Output.Send(Ansi(Style(postLoginPrompt+"\r\n", AnsiGreen)))
+	for _, line := range FormatRentNotice(world.CollectRent(p, time.Now())) {
+		p.Output.Send(Ansi(line + "\r\n"))
+	}
 	EnterRoom(world, p, "")
 	world.DeliverOfflineTells(p)
+	world.DeliverTellReceipts(p)
+	world.NotifyFriends(p, true)
+
+	runSession(conn, session, world, dispatcher, p)
+}
+
+// resumeLinkdead replays what a linkdead player missed and returns them to
+// the prompt without re-entering their room.
+func resumeLinkdead(session *TelnetSession, p *Player, missed []string) {
+	session.Send(Ansi("\r\n" + Style("You reconnect and pick up where you left off.", AnsiGreen)))
+	if len(missed) > 0 {
+		session.Send(Ansi("\r\n" + Style("While you were away:", AnsiBold)))
+		for _, out := range missed {
+			session.Send(out)
+		}
+	}
+	session.Send(Prompt(p))
+}
 
+// runSession reads and dispatches commands for a logged in player until the
+// connection closes, then logs the player out.
+func runSession(conn net.Conn, session *TelnetSession, world *World, dispatcher Dispatcher, p *Player) {
 	_ = conn.SetReadDeadline(time.Time{})
 
+	dropped := false
 	for {
 		line, err := session.ReadLine()
 		if err != nil {
+			dropped = true
 			break
 		}
+		p.markActive(time.Now())
 		line = Trim(line)
+		if p.PagerInput(line) {
+			continue
+		}
 		if line == "" {
-			p.Output <- Prompt(p)
+			p.Output.Send(Prompt(p))
 			continue
 		}
 		if !p.allowCommand(time.Now()) {
-			p.Output <- Ansi(Style("\r\nYou are sending commands too quickly. Please wait.", AnsiYellow))
-			p.Output <- Prompt(p)
+			p.Output.Send(Ansi(Style("\r\nYou are sending commands too quickly. Please wait.", AnsiYellow)))
+			p.Output.Send(Prompt(p))
 			continue
 		}
 		if !p.Alive {
 			break
 		}
+		metrics.commandDispatched()
 		if quit := dispatcher(world, p, line); quit {
 			break
 		}
-		p.Output <- Prompt(p)
+		p.Output.Send(Prompt(p))
 	}
 
 	if p.Session != session {
 		return
 	}
+	if dropped && world.MarkLinkdead(p, session) {
+		return
+	}
 
 	farewell := "\r\n" + Style(logoffAtmosphere, AnsiMagenta, AnsiBold) + "\r\n"
-	p.Output <- Ansi(farewell)
-	p.Output <- Ansi("Until next time, " + HighlightName(p.Name) + Style(".\r\n", AnsiMagenta))
-	p.Output <- Ansi(Style("\r\n"+copyrightNotice+"\r\n", AnsiBlue, AnsiDim))
-	p.Alive = false
+	p.Output.Send(Ansi(farewell))
+	p.Output.Send(Ansi("Until next time, " + HighlightName(p.Name) + Style(".\r\n", AnsiMagenta)))
+	p.Output.Send(Ansi(Style("\r\n"+copyrightNotice+"\r\n", AnsiBlue, AnsiDim)))
+	world.markOffline(p)
 	world.BroadcastToRoom(p.Room, Ansi(fmt.Sprintf("\r\n%s leaves.", HighlightName(p.Name))), p)
+	world.NotifyFriends(p, false)
 	world.PersistPlayer(p)
 	world.removePlayer(p.Name)
 }
@@ -312,6 +543,24 @@ func ListenAndServeTLS(addr, accountsPath, areasPath, certFile, keyFile, adminAc
 	return listenAndServe(addr, accountsPath, areasPath, adminAccount, dispatcher, cfg, opts...)
 }
 
+// rejectBannedAccount tells the session it is banned and reports true when name
+// is on the ban list.
+func rejectBannedAccount(session *TelnetSession, bans *BanList, name string) bool {
+	if bans == nil {
+		return false
+	}
+	entry, banned := bans.AccountBan(name)
+	if !banned {
+		return false
+	}
+	notice := "This account has been banned."
+	if entry.Reason != "" {
+		notice = "This account has been banned: " + entry.Reason
+	}
+	_ = session.WriteString(Ansi("\r\n" + Style(notice, AnsiYellow) + "\r\n"))
+	return true
+}
+
 func listenAndServe(addr, accountsPath, areasPath, adminAccount string, dispatcher Dispatcher, cfg serverConfig, opts ...ServerOption) error {
 	if dispatcher == nil {
 		return fmt.Errorf("dispatcher must not be nil")
@@ -328,19 +577,81 @@ func listenAndServe(addr, accountsPath, areasPath, adminAccount string, dispatch
 		}
 	}
 
+	if options.logCfg != nil {
+		closer, err := ConfigureLogging(*options.logCfg)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+	}
+	if options.storage != "" {
+		storage, err := OpenStorage(options.storage)
+		if err != nil {
+			return err
+		}
+		defer storage.Close()
+		defer useStorage(storage)()
+	}
+	defer startWriteBehind()()
+
 	accounts, err := accountManagerFactory(accountsPath)
 	if err != nil {
 		return err
 	}
 	accounts.SetAdminAccount(adminAccount)
+	accountsDir := filepath.Dir(accountsPath)
+	snapshotCfg := SnapshotConfig{Interval: DefaultSnapshotInterval, Keep: DefaultSnapshotKeep}
+	if options.snapshots != nil {
+		snapshotCfg = *options.snapshots
+	}
+	if snapshotCfg.Dir == "" {
+		snapshotCfg.Dir = filepath.Join(accountsDir, "snapshots")
+	}
+	if err := recoverBuilderArea(areasPath, snapshotCfg.Dir); err != nil {
+		return err
+	}
 	world, err := worldFactory(areasPath)
 	if err != nil {
 		return err
 	}
+	world.ConfigureSnapshots(snapshotCfg.Dir, snapshotCfg.Keep)
+	stopSnapshots := make(chan struct{})
+	defer close(stopSnapshots)
+	world.StartSnapshotLoop(snapshotCfg.Interval, stopSnapshots)
+	gameHour := DefaultGameHour
+	if options.gameHour != nil {
+		gameHour = *options.gameHour
+	}
+	stopClock := make(chan struct{})
+	defer close(stopClock)
+	world.StartClock(gameHour, stopClock)
+	world.StartStaminaLoop(stopClock)
+	world.StartHazardLoop(stopClock)
+	world.StartPoisonLoop(stopClock)
+	world.StartRespawnLoop(stopClock)
+	world.StartAmbienceLoop(stopClock)
+	world.StartGatherLoop(stopClock)
+	world.StartVehicleLoop(stopClock)
+	world.StartInstanceLoop(stopClock)
+	world.StartEventLoop(stopClock)
+	world.StartAreaWatcher(options.watch, stopClock)
 	world.ConfigurePrivileges(cfg.forceAllAdmin, cfg.lockCriticalOps)
+	attachLogWorld(world)
+	defer attachLogWorld(nil)
 	world.AttachAccountManager(accounts)
-
-	accountsDir := filepath.Dir(accountsPath)
+	if options.death != nil {
+		world.ConfigureDeathPenalty(*options.death)
+	}
+	if options.linkdead != nil {
+		world.ConfigureLinkdeadGrace(*options.linkdead)
+	}
+	if options.tunables != nil {
+		world.ConfigureTunables(*options.tunables)
+	}
+	if options.configFile != nil {
+		world.AttachConfigFile(*options.configFile)
+	}
+	world.StartIdleLoop(stopClock)
 
 	mailPath := options.mailPath
 	if mailPath == "" {
@@ -350,8 +661,53 @@ func listenAndServe(addr, accountsPath, areasPath, adminAccount string, dispatch
 	if err != nil {
 		return err
 	}
+	if options.mailQuota != nil {
+		mail.SetMailboxQuota(*options.mailQuota)
+	}
 	world.AttachMailSystem(mail)
 
+	market, err := marketSystemFactory(filepath.Join(accountsDir, "market.json"))
+	if err != nil {
+		return err
+	}
+	if options.listing != nil {
+		market.SetListingDuration(*options.listing)
+	}
+	world.AttachMarketSystem(market)
+	stopMarket := make(chan struct{})
+	defer close(stopMarket)
+	world.StartMarketLoop(stopMarket)
+	stopClanWars := make(chan struct{})
+	defer close(stopClanWars)
+	world.StartClanWarLoop(stopClanWars)
+
+	reports, err := reportSystemFactory(filepath.Join(accountsDir, "reports.json"))
+	if err != nil {
+		return err
+	}
+	world.AttachReportSystem(reports)
+
+	petitions, err := petitionSystemFactory(filepath.Join(accountsDir, "petitions.json"))
+	if err != nil {
+		return err
+	}
+	world.AttachPetitionSystem(petitions)
+
+	announcements, err := announcementFactory(filepath.Join(accountsDir, "announcements.json"))
+	if err != nil {
+		return err
+	}
+	world.AttachAnnouncementSystem(announcements)
+	stopAnnouncements := make(chan struct{})
+	defer close(stopAnnouncements)
+	world.StartAnnouncementLoop(stopAnnouncements)
+
+	clans, err := clanSystemFactory(filepath.Join(accountsDir, "clans.json"))
+	if err != nil {
+		return err
+	}
+	world.AttachClanSystem(clans)
+
 	tellsPath := options.tellsPath
 	if tellsPath == "" {
 		tellsPath = filepath.Join(accountsDir, "tells.json")
@@ -362,9 +718,55 @@ func listenAndServe(addr, accountsPath, areasPath, adminAccount string, dispatch
 	}
 	world.AttachTellSystem(tells)
 
+	tokenPath := options.tokenPath
+	if tokenPath == "" {
+		tokenPath = filepath.Join(accountsDir, "api_tokens.json")
+	}
+	apiTokens, err := apiTokenStoreFactory(tokenPath)
+	if err != nil {
+		return err
+	}
+	world.AttachAPITokens(apiTokens)
+	defer func() {
+		if err := apiTokens.Flush(); err != nil {
+			Logger().Warn("failed to save api token usage", "error", err)
+		}
+	}()
+
+	bans, err := banListFactory(filepath.Join(accountsDir, "bans.json"))
+	if err != nil {
+		return err
+	}
+	world.AttachBanList(bans)
+	moderation, err := NewModerationLog(filepath.Join(accountsDir, "moderation.json"))
+	if err != nil {
+		return err
+	}
+	world.AttachModeration(moderation)
+	audit, err := NewAuditLog(filepath.Join(accountsDir, "audit.jsonl"))
+	if err != nil {
+		return err
+	}
+	world.AttachAuditLog(audit)
+	chatLogCfg := ChatLogConfig{Lines: DefaultChatLogLines, Retention: DefaultChatLogRetention}
+	if options.chatLog != nil {
+		chatLogCfg = *options.chatLog
+	}
+	if chatLogCfg.Dir == "" {
+		chatLogCfg.Dir = filepath.Join(accountsDir, "chatlogs")
+	}
+	chatLog, err := NewChatLog(chatLogCfg)
+	if err != nil {
+		return err
+	}
+	world.AttachChatLog(chatLog)
+	world.AttachLoginThrottle(NewLoginThrottle(DefaultLoginFailureLimit, DefaultLoginFailureWindow, DefaultLoginLockout))
+
 	var portal PortalProvider
 	if options.portalCfg != nil {
-		portal, err = portalFactory(world, *options.portalCfg)
+		portalCfg := *options.portalCfg
+		portalCfg.Dispatcher = dispatcher
+		portal, err = portalFactory(world, portalCfg)
 		if err != nil {
 			return err
 		}
@@ -378,33 +780,110 @@ func listenAndServe(addr, accountsPath, areasPath, adminAccount string, dispatch
 		}
 	}
 
-	var ln net.Listener
-	if cfg.enableTLS {
+	if options.bridgeCfg != nil {
+		bridge, err := NewDiscordBridge(world, *options.bridgeCfg)
+		if err != nil {
+			return err
+		}
+		world.AttachBridge(bridge)
+		bridge.Start()
+		defer bridge.Close()
+	}
+
+	if options.intermudCfg != nil {
+		gateway, err := NewIntermud(world, *options.intermudCfg)
+		if err != nil {
+			return err
+		}
+		world.AttachIntermud(gateway)
+		gateway.Start()
+		defer gateway.Close()
+	}
+
+	if options.metrics != "" {
+		metricsSrv, err := startMetricsServer(options.metrics, world)
+		if err != nil {
+			return err
+		}
+		defer metricsSrv.Close()
+	}
+
+	var (
+		ln      net.Listener
+		resumed []resumedSession
+	)
+	if statePath := os.Getenv(copyoverEnv); statePath != "" {
+		os.Unsetenv(copyoverEnv)
+		ln, resumed, err = resumeCopyover(statePath, world)
+		if err != nil {
+			Logger().Error("copyover resume failed", "error", err)
+			ln = nil
+		} else {
+			Logger().Info("copyover resumed", "addr", ln.Addr().String(), "players", len(resumed))
+		}
+	}
+	switch {
+	case ln != nil:
+		// The listener was inherited from the previous process.
+	case cfg.enableTLS:
 		cert, created, err := ensureCertificateFunc(cfg.certFile, cfg.keyFile, addr)
 		if err != nil {
 			return err
 		}
 		if created {
-			fmt.Printf("Generated self-signed TLS certificate at %s and %s\n", cfg.certFile, cfg.keyFile)
+			Logger().Info("generated self-signed TLS certificate", "cert", cfg.certFile, "key", cfg.keyFile)
 		}
 		tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
 		ln, err = tlsListenFunc("tcp", addr, tlsConfig)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("MUD listening on %s (TLS enabled, telnet + ANSI ready)\n", ln.Addr())
-	} else {
+		Logger().Info("MUD listening (TLS enabled, telnet + ANSI ready)", "addr", ln.Addr().String())
+	default:
 		ln, err = netListenFunc("tcp", addr)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("MUD listening on %s (telnet + ANSI ready)\n", ln.Addr())
+		Logger().Info("MUD listening (telnet + ANSI ready)", "addr", ln.Addr().String())
 	}
 	defer ln.Close()
+	world.attachListener(ln, filepath.Join(accountsDir, "copyover.json"))
+	for _, r := range resumed {
+		go resumeSession(r.conn, r.session, world, dispatcher, r.player)
+	}
 
-	return acceptConnections(ln, func(conn net.Conn) {
+	stopping, release := closeOnSignal(ln)
+	defer release()
+	err = acceptConnections(ln, func(conn net.Conn) {
 		go handleConn(conn, world, accounts, dispatcher)
 	})
+	if stopping.Load() {
+		Logger().Info("shutting down")
+		return nil
+	}
+	return err
+}
+
+// closeOnSignal closes ln when the process is interrupted or terminated so
+// the server returns and its deferred cleanup, such as flushing queued saves,
+// runs before exit.
+func closeOnSignal(ln net.Listener) (*atomic.Bool, func()) {
+	stopping := new(atomic.Bool)
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			stopping.Store(true)
+			ln.Close()
+		case <-done:
+		}
+	}()
+	return stopping, func() {
+		signal.Stop(signals)
+		close(done)
+	}
 }
 
 const (
@@ -420,7 +899,7 @@ func acceptConnections(ln net.Listener, handle func(net.Conn)) error {
 		conn, err := ln.Accept()
 		if err != nil {
 			if isTemporaryAcceptError(err) {
-				fmt.Printf("Temporary error accepting connection: %v; retrying in %s\n", err, backoff)
+				Logger().Warn("temporary error accepting connection", "error", err, "retry", backoff)
 				acceptSleep(backoff)
 				backoff *= 2
 				if backoff > acceptBackoffMax {
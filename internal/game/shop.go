@@ -0,0 +1,337 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultShopPrice is charged for shop wares that do not set a price.
+	DefaultShopPrice = 10
+	// DefaultRareStock is how many of a shopkeeper's rares are stocked each
+	// restock cycle when the NPC does not set its own count.
+	DefaultRareStock = 2
+	// ShopRestockInterval is how long a restock cycle lasts.
+	ShopRestockInterval = time.Hour
+	// ShopSellShare is the percentage of an item's value a shopkeeper pays
+	// for it.
+	ShopSellShare = 40
+	// HaggleDiscount is the percentage a successful haggle takes off prices,
+	// and adds to what the shopkeeper pays, until the next restock.
+	HaggleDiscount = 10
+	// haggleBaseChance is the percentage chance of a haggle succeeding at
+	// neutral standing and base intelligence.
+	haggleBaseChance = 40
+)
+
+var (
+	// ErrNoShop indicates no one in the player's room sells anything.
+	ErrNoShop = errors.New("no one here has anything to sell")
+	// ErrNotForSale indicates the shopkeeper does not stock the item.
+	ErrNotForSale = errors.New("that is not for sale here")
+	// ErrNotEnoughGold indicates the player cannot afford the item.
+	ErrNotEnoughGold = errors.New("you cannot afford that")
+	// ErrSoldOut indicates the shopkeeper has sold the last of a rare item
+	// this restock cycle.
+	ErrSoldOut = errors.New("that is sold out until the next restock")
+)
+
+// ShopWare is an item a shopkeeper sells and what it costs the player.
+type ShopWare struct {
+	Item  Item
+	Price int
+	// Rare marks limited stock: one is for sale until the next restock.
+	Rare bool
+}
+
+// ShopListing is what the shopkeeper in a room offers a player.
+type ShopListing struct {
+	Keeper string
+	Wares  []ShopWare
+	// Standing is the player's standing with the keeper's faction; empty
+	// when the keeper has none.
+	Standing Standing
+	// Modifier is the percentage added to every price.
+	Modifier int
+	// Haggled reports whether the player talked the keeper down this cycle.
+	Haggled bool
+	// Restock is when the rare stock next turns over; zero when the keeper
+	// has no rares.
+	Restock time.Time
+}
+
+// HaggleResult reports how a haggle went.
+type HaggleResult struct {
+	Keeper  string
+	Success bool
+	// Chance is the percentage chance the haggle had of succeeding.
+	Chance int
+}
+
+// shopStock tracks a shopkeeper's rare wares and the haggles tried during
+// the current restock cycle.
+type shopStock struct {
+	restocked time.Time
+	rares     []Item
+	// haggled maps player names to whether their haggle succeeded.
+	haggled map[string]bool
+}
+
+// shopkeeperLocked returns the first NPC in p's room with wares to sell.
+func (w *World) shopkeeperLocked(p *Player) (*NPC, bool) {
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return nil, false
+	}
+	for i := range room.NPCs {
+		if len(room.NPCs[i].Shop) > 0 || len(room.NPCs[i].Rares) > 0 {
+			return &room.NPCs[i], true
+		}
+	}
+	return nil, false
+}
+
+// shopStockLocked returns keeper's stock for this cycle, restocking its
+// rares from the pool once the cycle has run out.
+func (w *World) shopStockLocked(room RoomID, keeper *NPC, now time.Time) *shopStock {
+	if w.shopStocks == nil {
+		w.shopStocks = make(map[string]*shopStock)
+	}
+	key := string(room) + "|" + strings.ToLower(keeper.Name)
+	stock, ok := w.shopStocks[key]
+	if ok && now.Sub(stock.restocked) < ShopRestockInterval {
+		return stock
+	}
+	count := keeper.RareStock
+	if count <= 0 {
+		count = DefaultRareStock
+	}
+	pool := cloneItems(keeper.Rares)
+	for i := len(pool) - 1; i > 0; i-- {
+		j := w.roll(i + 1)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	stock = &shopStock{restocked: now, rares: pool[:min(count, len(pool))], haggled: make(map[string]bool)}
+	w.shopStocks[key] = stock
+	return stock
+}
+
+// shopTermsLocked reports the price modifier keeper offers p, refusing
+// players its faction is hostile to.
+func (w *World) shopTermsLocked(p *Player, keeper *NPC, stock *shopStock) (Standing, int, error) {
+	modifier := 0
+	if stock.haggled[p.Name] {
+		modifier = -HaggleDiscount
+	}
+	standing, ok := w.npcStandingLocked(p, *keeper)
+	if !ok {
+		return "", modifier, nil
+	}
+	adjust, trades := standing.PriceModifier()
+	if !trades {
+		return standing, 0, fmt.Errorf("%s refuses to trade with you", keeper.Name)
+	}
+	return standing, modifier + adjust, nil
+}
+
+// rarityValue is how many times more than a common item an item of rarity
+// r is worth.
+func rarityValue(r Rarity) int {
+	switch r {
+	case Uncommon:
+		return 2
+	case Rare:
+		return 4
+	case Epic:
+		return 8
+	}
+	return 1
+}
+
+// itemValue is what an item is worth before any modifier.
+func itemValue(item Item) int {
+	base := item.Price
+	if base <= 0 {
+		base = DefaultShopPrice
+	}
+	return base * rarityValue(item.Rarity)
+}
+
+func shopPrice(item Item, modifier int) int {
+	return max(1, itemValue(item)*(100+modifier)/100)
+}
+
+// sellPrice is what a shopkeeper pays for item. Standing and haggling that
+// lower prices raise what the keeper pays by the same percentage.
+func sellPrice(item Item, modifier int) int {
+	return max(1, itemValue(item)*ShopSellShare/100*(100-modifier)/100)
+}
+
+// ShopWares lists what the shopkeeper in p's room sells and at what price.
+func (w *World) ShopWares(p *Player) (ShopListing, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	keeper, ok := w.shopkeeperLocked(p)
+	if !ok {
+		return ShopListing{}, ErrNoShop
+	}
+	stock := w.shopStockLocked(p.Room, keeper, time.Now())
+	standing, modifier, err := w.shopTermsLocked(p, keeper, stock)
+	if err != nil {
+		return ShopListing{}, err
+	}
+	listing := ShopListing{Keeper: keeper.Name, Standing: standing, Modifier: modifier, Haggled: stock.haggled[p.Name]}
+	for _, item := range keeper.Shop {
+		listing.Wares = append(listing.Wares, ShopWare{Item: item, Price: w.sinkGoldLocked(shopPrice(item, modifier))})
+	}
+	for _, item := range stock.rares {
+		listing.Wares = append(listing.Wares, ShopWare{Item: item, Price: w.sinkGoldLocked(shopPrice(item, modifier)), Rare: true})
+	}
+	if len(keeper.Rares) > 0 {
+		listing.Restock = stock.restocked.Add(ShopRestockInterval)
+	}
+	return listing, nil
+}
+
+// BuyFromShop buys the named item from the shopkeeper in p's room and
+// reports the price paid.
+func (w *World) BuyFromShop(p *Player, name string) (Item, int, error) {
+	target := strings.TrimSpace(name)
+	if target == "" {
+		return Item{}, 0, fmt.Errorf("item name must not be empty")
+	}
+	w.mu.Lock()
+	keeper, ok := w.shopkeeperLocked(p)
+	if !ok {
+		w.mu.Unlock()
+		return Item{}, 0, ErrNoShop
+	}
+	stock := w.shopStockLocked(p.Room, keeper, time.Now())
+	_, modifier, err := w.shopTermsLocked(p, keeper, stock)
+	if err != nil {
+		w.mu.Unlock()
+		return Item{}, 0, err
+	}
+	var item Item
+	rare := -1
+	if idx := findItemIndex(keeper.Shop, target); idx != -1 {
+		item = cloneItems(keeper.Shop[idx : idx+1])[0]
+	} else if idx := findItemIndex(stock.rares, target); idx != -1 {
+		item = cloneItems(stock.rares[idx : idx+1])[0]
+		rare = idx
+	} else {
+		err := ErrNotForSale
+		if findItemIndex(keeper.Rares, target) != -1 {
+			err = ErrSoldOut
+		}
+		w.mu.Unlock()
+		return Item{}, 0, err
+	}
+	price := w.sinkGoldLocked(shopPrice(item, modifier))
+	if p.Gold < price {
+		w.mu.Unlock()
+		return Item{}, 0, ErrNotEnoughGold
+	}
+	if !canCarryLocked(p, item.TotalWeight()) {
+		w.mu.Unlock()
+		return Item{}, 0, ErrTooHeavy
+	}
+	if rare != -1 {
+		stock.rares = append(stock.rares[:rare:rare], stock.rares[rare+1:]...)
+	}
+	p.Gold -= price
+	w.recordGoldLocked(EconomyShop, -price)
+	p.Inventory = append(p.Inventory, item)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return item, price, nil
+}
+
+// SellToShop sells the named item from p's inventory to the shopkeeper in
+// p's room and reports what it fetched. Rare and epic items join the
+// keeper's rare stock until the next restock.
+func (w *World) SellToShop(p *Player, name string) (Item, int, error) {
+	target := strings.TrimSpace(name)
+	if target == "" {
+		return Item{}, 0, fmt.Errorf("item name must not be empty")
+	}
+	w.mu.Lock()
+	keeper, ok := w.shopkeeperLocked(p)
+	if !ok {
+		w.mu.Unlock()
+		return Item{}, 0, ErrNoShop
+	}
+	stock := w.shopStockLocked(p.Room, keeper, time.Now())
+	_, modifier, err := w.shopTermsLocked(p, keeper, stock)
+	if err != nil {
+		w.mu.Unlock()
+		return Item{}, 0, err
+	}
+	idx := findItemIndex(p.Inventory, target)
+	if idx == -1 {
+		w.mu.Unlock()
+		return Item{}, 0, fmt.Errorf("you are not carrying %s", target)
+	}
+	item := p.Inventory[idx]
+	if item.Corpse {
+		w.mu.Unlock()
+		return Item{}, 0, fmt.Errorf("%s will not buy a corpse", keeper.Name)
+	}
+	if len(item.Contents) > 0 {
+		w.mu.Unlock()
+		return Item{}, 0, fmt.Errorf("empty %s first", item.Name)
+	}
+	price := w.faucetGoldLocked(sellPrice(item, modifier))
+	p.Inventory = append(p.Inventory[:idx:idx], p.Inventory[idx+1:]...)
+	p.Gold += price
+	w.recordGoldLocked(EconomyShop, price)
+	if item.Rarity == Rare || item.Rarity == Epic {
+		stock.rares = append(stock.rares, item)
+	}
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return item, price, nil
+}
+
+// haggleChance is the percentage chance p talks a shopkeeper down: quick
+// wits and good standing with the keeper's faction both help.
+func haggleChance(p *Player, standing Standing) int {
+	chance := haggleBaseChance + attributeBonus(p.Attributes().Int, 3)
+	switch standing {
+	case StandingUnfriendly:
+		chance -= 20
+	case StandingFriendly:
+		chance += 15
+	case StandingHonored:
+		chance += 30
+	}
+	return min(max(chance, 5), 95)
+}
+
+// Haggle has p try to talk the shopkeeper in their room into better prices.
+// Each player gets one try per restock cycle; success lowers what p pays and
+// raises what the keeper pays p by HaggleDiscount percent until the restock.
+func (w *World) Haggle(p *Player) (HaggleResult, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	keeper, ok := w.shopkeeperLocked(p)
+	if !ok {
+		return HaggleResult{}, ErrNoShop
+	}
+	stock := w.shopStockLocked(p.Room, keeper, time.Now())
+	standing, _, err := w.shopTermsLocked(p, keeper, stock)
+	if err != nil {
+		return HaggleResult{}, err
+	}
+	if _, tried := stock.haggled[p.Name]; tried {
+		return HaggleResult{}, fmt.Errorf("%s will not haggle with you again until the next restock", keeper.Name)
+	}
+	chance := haggleChance(p, standing)
+	success := w.roll(100) < chance
+	stock.haggled[p.Name] = success
+	return HaggleResult{Keeper: keeper.Name, Success: success, Chance: chance}, nil
+}
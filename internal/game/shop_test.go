@@ -0,0 +1,129 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRareStockSellsOutAndRestocks(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{},
+			NPCs: []NPC{{Name: "Trader", Shop: []Item{{Name: "Rope", Price: 20}},
+				Rares: []Item{{Name: "Amber Idol", Price: 50, Rarity: Rare}, {Name: "Jade Comb", Price: 30}}, RareStock: 1}}},
+	})
+	player := &Player{Name: "Hero", Room: "stall", Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+
+	listing, err := world.ShopWares(player)
+	if err != nil {
+		t.Fatalf("ShopWares: %v", err)
+	}
+	if len(listing.Wares) != 2 || !listing.Wares[1].Rare || listing.Wares[1].Item.Name != "Jade Comb" {
+		t.Fatalf("expected one rare in stock, got %+v", listing.Wares)
+	}
+	if listing.Restock.IsZero() {
+		t.Fatalf("expected a restock time")
+	}
+	if _, _, err := world.BuyFromShop(player, "amber"); !errors.Is(err, ErrSoldOut) {
+		t.Fatalf("expected unstocked rare to be sold out, got %v", err)
+	}
+	if _, price, err := world.BuyFromShop(player, "jade comb"); err != nil || price != 30 {
+		t.Fatalf("BuyFromShop = %d, %v", price, err)
+	}
+	if _, _, err := world.BuyFromShop(player, "jade comb"); !errors.Is(err, ErrSoldOut) {
+		t.Fatalf("expected the rare to sell out, got %v", err)
+	}
+
+	world.shopStocks["stall|trader"].restocked = time.Now().Add(-ShopRestockInterval)
+	world.SetDice(DiceFunc(func(n int) int { return n - 1 }))
+	listing, err = world.ShopWares(player)
+	if err != nil {
+		t.Fatalf("ShopWares: %v", err)
+	}
+	if len(listing.Wares) != 2 || listing.Wares[1].Item.Name != "Amber Idol" || listing.Wares[1].Price != 200 {
+		t.Fatalf("expected the rare stock to rotate, got %+v", listing.Wares)
+	}
+}
+
+func TestSellPricesFollowRarity(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{},
+			NPCs: []NPC{{Name: "Trader", Shop: []Item{{Name: "Rope", Price: 20}},
+				Rares: []Item{{Name: "Amber Idol", Price: 50, Rarity: Rare}, {Name: "Jade Comb", Price: 30}}, RareStock: 1}}},
+	})
+	player := &Player{Name: "Hero", Room: "stall", Alive: true, Gold: 500, Output: NewOutputQueue()}
+	world.AddPlayerForTest(player)
+	player.Inventory = []Item{
+		{Name: "Bent Nail"},
+		{Name: "Onyx Ring", Price: 25, Rarity: Epic},
+		{Name: "Sack", Container: true, Contents: []Item{{Name: "Pebble"}}},
+		{Name: "corpse of a rat", Corpse: true},
+	}
+	if _, price, err := world.SellToShop(player, "bent nail"); err != nil || price != DefaultShopPrice*ShopSellShare/100 {
+		t.Fatalf("SellToShop junk = %d, %v", price, err)
+	}
+	if _, price, err := world.SellToShop(player, "onyx"); err != nil || price != 80 {
+		t.Fatalf("SellToShop epic = %d, %v", price, err)
+	}
+	if player.Gold != 584 {
+		t.Fatalf("gold = %d, want 584", player.Gold)
+	}
+	if _, _, err := world.SellToShop(player, "sack"); err == nil || !strings.Contains(err.Error(), "empty Sack first") {
+		t.Fatalf("expected a full container to be refused, got %v", err)
+	}
+	if _, _, err := world.SellToShop(player, "corpse"); err == nil || !strings.Contains(err.Error(), "will not buy a corpse") {
+		t.Fatalf("expected a corpse to be refused, got %v", err)
+	}
+	if _, _, err := world.SellToShop(player, "lute"); err == nil {
+		t.Fatalf("expected selling a missing item to fail")
+	}
+	listing, err := world.ShopWares(player)
+	if err != nil {
+		t.Fatalf("ShopWares: %v", err)
+	}
+	last := listing.Wares[len(listing.Wares)-1]
+	if last.Item.Name != "Onyx Ring" || !last.Rare || last.Price != 200 {
+		t.Fatalf("expected the sold epic to join the rare stock, got %+v", listing.Wares)
+	}
+}
+
+func TestHaggleOncePerRestock(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"stall": {ID: "stall", Title: "Stall", Exits: map[string]Exit{},
+			NPCs: []NPC{{Name: "Trader", Faction: "guild", Shop: []Item{{Name: "Rope", Price: 20}},
+				Rares: []Item{{Name: "Amber Idol", Price: 50, Rarity: Rare}, {Name: "Jade Comb", Price: 30}}, RareStock: 1}}},
+	})
+	player := &Player{Name: "Hero", Room: "stall", Alive: true, Gold: 500, Output: NewOutputQueue(), Trained: Attributes{Int: 5}}
+	world.AddPlayerForTest(player)
+	if err := world.AddFactionForTest(Faction{ID: "guild", Name: "Guild", Start: 300}); err != nil {
+		t.Fatalf("AddFactionForTest: %v", err)
+	}
+
+	world.SetDice(DiceFunc(func(n int) int { return n - 1 }))
+	result, err := world.Haggle(player)
+	if err != nil {
+		t.Fatalf("Haggle: %v", err)
+	}
+	if result.Success || result.Chance != haggleBaseChance+15+15 {
+		t.Fatalf("unexpected haggle %+v", result)
+	}
+	if _, err := world.Haggle(player); err == nil || !strings.Contains(err.Error(), "until the next restock") {
+		t.Fatalf("expected a second haggle to be refused, got %v", err)
+	}
+
+	world.shopStocks["stall|trader"].restocked = time.Now().Add(-ShopRestockInterval)
+	world.SetDice(DiceFunc(func(int) int { return 0 }))
+	if result, err := world.Haggle(player); err != nil || !result.Success {
+		t.Fatalf("Haggle = %+v, %v", result, err)
+	}
+	listing, err := world.ShopWares(player)
+	if err != nil {
+		t.Fatalf("ShopWares: %v", err)
+	}
+	if !listing.Haggled || listing.Modifier != -20 || listing.Wares[0].Price != 16 {
+		t.Fatalf("expected haggling to stack with standing, got %+v", listing)
+	}
+}
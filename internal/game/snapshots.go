@@ -0,0 +1,415 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSnapshotInterval is how often the world is snapshotted.
+	DefaultSnapshotInterval = 30 * time.Minute
+	// DefaultSnapshotKeep is how many snapshot files are retained.
+	DefaultSnapshotKeep = 48
+
+	snapshotPrefix   = "snapshot-"
+	snapshotIDLayout = "20060102-150405"
+)
+
+// ErrSnapshotNotFound is returned when a snapshot id does not exist.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// SnapshotConfig controls where world snapshots are stored and how often
+// they are taken. A zero Interval disables periodic snapshots.
+type SnapshotConfig struct {
+	Dir      string
+	Interval time.Duration
+	Keep     int
+}
+
+// SnapshotInfo summarises a stored world snapshot.
+type SnapshotInfo struct {
+	ID      string
+	Created time.Time
+	Rooms   int
+	Players int
+}
+
+type snapshotPosition struct {
+	Name string `json:"name"`
+	Room RoomID `json:"room"`
+}
+
+type worldSnapshot struct {
+	ID      string             `json:"id"`
+	Created time.Time          `json:"created"`
+	Rooms   []Room             `json:"rooms"`
+	Sources map[RoomID]string  `json:"sources,omitempty"`
+	Builder *areaFile          `json:"builder,omitempty"`
+	Players []snapshotPosition `json:"players,omitempty"`
+}
+
+// ConfigureSnapshots sets the directory snapshots are written to and how many
+// are retained.
+func (w *World) ConfigureSnapshots(dir string, keep int) {
+	w.mu.Lock()
+	w.snapshotDir = strings.TrimSpace(dir)
+	w.snapshotKeep = keep
+	w.mu.Unlock()
+}
+
+// SaveSnapshot writes the current rooms, builder edits, and player positions
+// to a timestamped file and prunes old snapshots.
+func (w *World) SaveSnapshot() (SnapshotInfo, error) {
+	w.mu.RLock()
+	dir := w.snapshotDir
+	keep := w.snapshotKeep
+	if dir == "" {
+		w.mu.RUnlock()
+		return SnapshotInfo{}, fmt.Errorf("snapshots are not configured")
+	}
+	snapshot := w.captureSnapshotLocked(time.Now().UTC())
+	w.mu.RUnlock()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return SnapshotInfo{}, fmt.Errorf("create snapshot directory: %w", err)
+	}
+	base := snapshot.Created.Format(snapshotIDLayout)
+	snapshot.ID = base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(snapshotPath(dir, snapshot.ID)); os.IsNotExist(err) {
+			break
+		}
+		snapshot.ID = fmt.Sprintf("%s-%d", base, i)
+	}
+	tmp, err := os.CreateTemp(dir, "snapshot-*.tmp")
+	if err != nil {
+		return SnapshotInfo{}, fmt.Errorf("create temp snapshot file: %w", err)
+	}
+	enc := json.NewEncoder(tmp)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snapshot); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return SnapshotInfo{}, fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return SnapshotInfo{}, fmt.Errorf("close snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), snapshotPath(dir, snapshot.ID)); err != nil {
+		os.Remove(tmp.Name())
+		return SnapshotInfo{}, fmt.Errorf("replace snapshot: %w", err)
+	}
+	if keep > 0 {
+		pruneSnapshots(dir, keep)
+	}
+	return snapshot.info(), nil
+}
+
+func (w *World) captureSnapshotLocked(now time.Time) worldSnapshot {
+	snapshot := worldSnapshot{
+		Created: now,
+		Rooms:   make([]Room, 0, len(w.rooms)),
+		Sources: make(map[RoomID]string, len(w.roomSources)),
+	}
+	for id, room := range w.rooms {
+		if w.instanceRooms[id] != nil {
+			continue
+		}
+		copyRoom := *room
+		copyRoom.ID = id
+		copyRoom.Exits = cloneExits(room.Exits)
+		copyRoom.NPCs = append([]NPC(nil), room.NPCs...)
+		copyRoom.Items = make([]Item, 0, len(room.Items))
+		for _, item := range room.Items {
+			if item.Corpse {
+				continue
+			}
+			copyRoom.Items = append(copyRoom.Items, item)
+		}
+		copyRoom.Resets = append([]RoomReset(nil), room.Resets...)
+		snapshot.Rooms = append(snapshot.Rooms, copyRoom)
+	}
+	sort.Slice(snapshot.Rooms, func(i, j int) bool {
+		return snapshot.Rooms[i].ID < snapshot.Rooms[j].ID
+	})
+	builder := &areaFile{Name: "Builder Rooms"}
+	if meta, ok := w.areaMeta[builderAreaFile]; ok {
+		if strings.TrimSpace(meta.Name) != "" {
+			builder.Name = meta.Name
+		}
+		builder.Script = meta.Script
+	}
+	for id, source := range w.roomSources {
+		if w.instanceRooms[id] == nil {
+			snapshot.Sources[id] = source
+		}
+	}
+	for _, room := range snapshot.Rooms {
+		if snapshot.Sources[room.ID] == builderAreaFile {
+			builder.Rooms = append(builder.Rooms, room)
+		}
+	}
+	snapshot.Builder = builder
+	for _, name := range w.playerOrder {
+		p, ok := w.players[name]
+		if !ok || !p.Alive {
+			continue
+		}
+		snapshot.Players = append(snapshot.Players, snapshotPosition{Name: p.Name, Room: p.Room})
+	}
+	return snapshot
+}
+
+func (s worldSnapshot) info() SnapshotInfo {
+	return SnapshotInfo{ID: s.ID, Created: s.Created, Rooms: len(s.Rooms), Players: len(s.Players)}
+}
+
+// Snapshots lists stored snapshots, newest first.
+func (w *World) Snapshots() ([]SnapshotInfo, error) {
+	w.mu.RLock()
+	dir := w.snapshotDir
+	w.mu.RUnlock()
+	if dir == "" {
+		return nil, fmt.Errorf("snapshots are not configured")
+	}
+	ids, err := snapshotIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]SnapshotInfo, 0, len(ids))
+	for _, id := range ids {
+		snapshot, err := readSnapshot(dir, id)
+		if err != nil {
+			Logger().Warn("skipping unreadable snapshot", "id", id, "error", err)
+			continue
+		}
+		infos = append(infos, snapshot.info())
+	}
+	return infos, nil
+}
+
+// RestoreSnapshot rolls rooms and builder edits back to the given snapshot
+// and returns the connected players so callers can show them their
+// surroundings. Players recorded in the snapshot are moved back to the room
+// they occupied; others stay put unless their room no longer exists.
+func (w *World) RestoreSnapshot(id string) ([]*Player, error) {
+	w.mu.RLock()
+	dir := w.snapshotDir
+	w.mu.RUnlock()
+	if dir == "" {
+		return nil, fmt.Errorf("snapshots are not configured")
+	}
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, ErrSnapshotNotFound
+	}
+	snapshot, err := readSnapshot(dir, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrSnapshotNotFound
+		}
+		return nil, err
+	}
+	rooms := make(map[RoomID]*Room, len(snapshot.Rooms))
+	for i := range snapshot.Rooms {
+		room := snapshot.Rooms[i]
+		if room.Exits == nil {
+			room.Exits = make(map[string]Exit)
+		}
+		rooms[room.ID] = &room
+	}
+	if len(rooms) == 0 {
+		return nil, fmt.Errorf("snapshot %s contains no rooms", id)
+	}
+	sources := make(map[RoomID]string, len(snapshot.Sources))
+	for roomID, source := range snapshot.Sources {
+		if _, ok := rooms[roomID]; ok {
+			sources[roomID] = source
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prevRooms, prevSources, prevHistories := w.rooms, w.roomSources, w.roomHistories
+	prevMeta, hadMeta := w.areaMeta[builderAreaFile]
+	w.rooms = rooms
+	w.roomSources = sources
+	w.roomHistories = newRoomHistories(rooms)
+	if snapshot.Builder != nil {
+		if w.areaMeta == nil {
+			w.areaMeta = make(map[string]areaMetadata)
+		}
+		w.areaMeta[builderAreaFile] = areaMetadata{Name: snapshot.Builder.Name, Script: snapshot.Builder.Script}
+	}
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		w.rooms, w.roomSources, w.roomHistories = prevRooms, prevSources, prevHistories
+		if hadMeta {
+			w.areaMeta[builderAreaFile] = prevMeta
+		} else {
+			delete(w.areaMeta, builderAreaFile)
+		}
+		return nil, err
+	}
+	// Restored rooms no longer match the area files, so the next reload
+	// rebuilds all of them. Instances are not part of a snapshot.
+	w.roomDigests = nil
+	w.instances = nil
+	w.instanceRooms = nil
+
+	positions := make(map[string]RoomID, len(snapshot.Players))
+	for _, pos := range snapshot.Players {
+		positions[pos.Name] = pos.Room
+	}
+	restored := make([]*Player, 0, len(w.players))
+	for _, p := range w.players {
+		if !p.Alive {
+			continue
+		}
+		if room, ok := positions[p.Name]; ok {
+			if _, exists := rooms[room]; exists {
+				p.Room = room
+			}
+		}
+		if _, ok := rooms[p.Room]; !ok {
+			p.Room = StartRoom
+		}
+		w.syncPresenceLocked(p)
+		restored = append(restored, p)
+	}
+	return restored, nil
+}
+
+// StartSnapshotLoop saves a snapshot every interval until stop is closed.
+func (w *World) StartSnapshotLoop(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				info, err := w.SaveSnapshot()
+				if err != nil {
+					Logger().Error("world snapshot failed", "error", err)
+					continue
+				}
+				Logger().Debug("world snapshot saved", "id", info.ID)
+			}
+		}
+	}()
+}
+
+func snapshotPath(dir, id string) string {
+	return filepath.Join(dir, snapshotPrefix+id+".json")
+}
+
+func readSnapshot(dir, id string) (worldSnapshot, error) {
+	var snapshot worldSnapshot
+	data, err := os.ReadFile(snapshotPath(dir, id))
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("decode snapshot %s: %w", id, err)
+	}
+	snapshot.ID = id
+	return snapshot, nil
+}
+
+// snapshotIDs returns stored snapshot ids, newest first.
+func snapshotIDs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read snapshots: %w", err)
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || filepath.Ext(name) != ".json" {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), ".json"))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids, nil
+}
+
+func pruneSnapshots(dir string, keep int) {
+	ids, err := snapshotIDs(dir)
+	if err != nil {
+		Logger().Warn("could not prune snapshots", "error", err)
+		return
+	}
+	for _, id := range ids[min(keep, len(ids)):] {
+		if err := os.Remove(snapshotPath(dir, id)); err != nil {
+			Logger().Warn("could not remove old snapshot", "id", id, "error", err)
+		}
+	}
+}
+
+// recoverBuilderArea checks that the builder area file in areasPath can be
+// decoded. A corrupted file is moved aside and replaced with the builder
+// rooms from the newest snapshot in snapshotDir that has them.
+func recoverBuilderArea(areasPath, snapshotDir string) error {
+	storage := documentStorage()
+	path := filepath.Join(areasPath, builderAreaFile)
+	data, err := storage.Read(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read builder area: %w", err)
+	}
+	var file areaFile
+	err = json.Unmarshal(data, &file)
+	if err == nil {
+		return nil
+	}
+	Logger().Error("builder area is corrupted", "path", path, "error", err)
+	corrupt := path + ".corrupt-" + time.Now().UTC().Format(snapshotIDLayout)
+	if err := storage.Write(corrupt, data); err != nil {
+		return fmt.Errorf("move corrupted builder area aside: %w", err)
+	}
+	if err := storage.Remove(path); err != nil {
+		return fmt.Errorf("move corrupted builder area aside: %w", err)
+	}
+	if snapshotDir == "" {
+		Logger().Error("no snapshots configured; builder rooms were not recovered", "moved", corrupt)
+		return nil
+	}
+	ids, err := snapshotIDs(snapshotDir)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		snapshot, err := readSnapshot(snapshotDir, id)
+		if err != nil || snapshot.Builder == nil {
+			continue
+		}
+		out, err := encodeDocument(snapshot.Builder)
+		if err != nil {
+			return fmt.Errorf("encode recovered builder area: %w", err)
+		}
+		if err := storage.Write(path, out); err != nil {
+			return fmt.Errorf("write recovered builder area: %w", err)
+		}
+		Logger().Warn("recovered builder area from snapshot", "snapshot", id, "moved", corrupt)
+		return nil
+	}
+	Logger().Error("no snapshot contained builder rooms; builder rooms were not recovered", "moved", corrupt)
+	return nil
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSnapshotTestArea(t *testing.T, dir string) {
+	t.Helper()
+	area := `{"name":"Core","rooms":[{"id":"start","title":"Start","description":"","exits":{}}]}`
+	if err := os.WriteFile(filepath.Join(dir, "core.json"), []byte(area), 0o644); err != nil {
+		t.Fatalf("write area: %v", err)
+	}
+}
+
+func TestSnapshotRestoreRollsBackBuilderEditsAndPositions(t *testing.T) {
+	areas := t.TempDir()
+	writeSnapshotTestArea(t, areas)
+	world, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	world.ConfigureSnapshots(t.TempDir(), 0)
+	if _, err := world.CreateRoom("tower", "Tower", "Builder"); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	player := &Player{Name: "Alice", Room: "tower", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+
+	saved, err := world.SaveSnapshot()
+	if err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if saved.Rooms != 2 || saved.Players != 1 {
+		t.Fatalf("snapshot info = %#v", saved)
+	}
+
+	if _, err := world.UpdateRoomTitle("tower", "Ruined Tower", "Builder"); err != nil {
+		t.Fatalf("UpdateRoomTitle: %v", err)
+	}
+	if _, err := world.CreateRoom("cellar", "Cellar", "Builder"); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if err := world.MoveToRoom(player, "cellar"); err != nil {
+		t.Fatalf("MoveToRoom: %v", err)
+	}
+
+	players, err := world.RestoreSnapshot(saved.ID)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	if len(players) != 1 || player.Room != "tower" {
+		t.Fatalf("player room = %q after restore, want tower", player.Room)
+	}
+	if room, ok := world.GetRoom("tower"); !ok || room.Title != "Tower" {
+		t.Fatalf("tower title not rolled back: %#v", room)
+	}
+	if _, ok := world.GetRoom("cellar"); ok {
+		t.Fatalf("cellar should not exist after restore")
+	}
+	reloaded, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if _, ok := reloaded.GetRoom("cellar"); ok {
+		t.Fatalf("builder file still contains cellar after restore")
+	}
+	if _, err := world.RestoreSnapshot("missing"); err != ErrSnapshotNotFound {
+		t.Fatalf("RestoreSnapshot(missing) error = %v", err)
+	}
+}
+
+func TestSnapshotsArePrunedToKeepLimit(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	world.ConfigureSnapshots(t.TempDir(), 2)
+	var last SnapshotInfo
+	for i := 0; i < 3; i++ {
+		info, err := world.SaveSnapshot()
+		if err != nil {
+			t.Fatalf("SaveSnapshot: %v", err)
+		}
+		last = info
+	}
+	snapshots, err := world.Snapshots()
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].ID != last.ID {
+		t.Fatalf("snapshots = %#v, want 2 with newest %s first", snapshots, last.ID)
+	}
+}
+
+func TestRecoverBuilderAreaFromSnapshot(t *testing.T) {
+	areas := t.TempDir()
+	snapshots := t.TempDir()
+	writeSnapshotTestArea(t, areas)
+	world, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("NewWorld: %v", err)
+	}
+	world.ConfigureSnapshots(snapshots, 0)
+	if _, err := world.CreateRoom("tower", "Tower", "Builder"); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if _, err := world.SaveSnapshot(); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	builderPath := filepath.Join(areas, builderAreaFile)
+	if err := os.WriteFile(builderPath, []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("corrupt builder file: %v", err)
+	}
+	if _, err := NewWorld(areas); err == nil {
+		t.Fatalf("expected corrupted builder file to fail loading")
+	}
+	if err := recoverBuilderArea(areas, snapshots); err != nil {
+		t.Fatalf("recoverBuilderArea: %v", err)
+	}
+	recovered, err := NewWorld(areas)
+	if err != nil {
+		t.Fatalf("NewWorld after recovery: %v", err)
+	}
+	if room, ok := recovered.GetRoom("tower"); !ok || room.Title != "Tower" {
+		t.Fatalf("tower not recovered: %#v", room)
+	}
+	matches, _ := filepath.Glob(builderPath + ".corrupt-*")
+	if len(matches) != 1 {
+		t.Fatalf("expected corrupted file to be kept aside, got %v", matches)
+	}
+}
@@ -0,0 +1,308 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const socialsFileName = "socials.json"
+
+// Social is a canned emote such as "smile" or "bow". Its messages are
+// templates written once and rendered from each viewer's point of view:
+// $n is the actor, $N the target, $s and $S their possessives, and
+// {smile|smiles} picks the first form for the actor and the second for
+// everyone else.
+type Social struct {
+	Name   string `json:"name"`
+	Alone  string `json:"alone"`
+	Target string `json:"target,omitempty"`
+}
+
+type socialFile struct {
+	Socials []Social `json:"socials"`
+}
+
+type socialView int
+
+const (
+	socialViewActor socialView = iota
+	socialViewTarget
+	socialViewRoom
+)
+
+func socialsPath(areasPath string) string {
+	if strings.TrimSpace(areasPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(areasPath), socialsFileName)
+}
+
+func loadSocials(areasPath string) (map[string]*Social, error) {
+	path := socialsPath(areasPath)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var parsed socialFile
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse socials: %w", err)
+	}
+	socials := make(map[string]*Social, len(parsed.Socials))
+	for i := range parsed.Socials {
+		social := &parsed.Socials[i]
+		if err := normalizeSocial(social); err != nil {
+			return nil, fmt.Errorf("parse socials: %w", err)
+		}
+		socials[social.Name] = social
+	}
+	return socials, nil
+}
+
+func normalizeSocial(social *Social) error {
+	social.Name = strings.ToLower(strings.TrimSpace(social.Name))
+	social.Alone = strings.TrimSpace(social.Alone)
+	social.Target = strings.TrimSpace(social.Target)
+	if social.Name == "" {
+		return fmt.Errorf("social name must not be empty")
+	}
+	for _, r := range social.Name {
+		if !unicode.IsLetter(r) {
+			return fmt.Errorf("social name %q must be a single word of letters", social.Name)
+		}
+	}
+	if social.Alone == "" {
+		return fmt.Errorf("social %s needs a message", social.Name)
+	}
+	return nil
+}
+
+// renderSocial fills in a social template for one viewer.
+func renderSocial(template, actor, target string, view socialView) string {
+	var out strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == '$' && i+1 < len(template):
+			i++
+			switch template[i] {
+			case 'n':
+				if view == socialViewActor {
+					out.WriteString("you")
+				} else {
+					out.WriteString(HighlightName(actor))
+				}
+			case 's':
+				if view == socialViewActor {
+					out.WriteString("your")
+				} else {
+					out.WriteString(HighlightName(actor) + "'s")
+				}
+			case 'N':
+				if view == socialViewTarget {
+					out.WriteString("you")
+				} else {
+					out.WriteString(HighlightName(target))
+				}
+			case 'S':
+				if view == socialViewTarget {
+					out.WriteString("your")
+				} else {
+					out.WriteString(HighlightName(target) + "'s")
+				}
+			default:
+				out.WriteByte('$')
+				out.WriteByte(template[i])
+			}
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			first, second, ok := "", "", false
+			if end > 0 {
+				first, second, ok = strings.Cut(template[i+1:i+end], "|")
+			}
+			if !ok {
+				out.WriteByte(c)
+				continue
+			}
+			if view == socialViewActor {
+				out.WriteString(first)
+			} else {
+				out.WriteString(second)
+			}
+			i += end
+		default:
+			out.WriteByte(c)
+		}
+	}
+	text := out.String()
+	if r, size := utf8.DecodeRuneInString(text); unicode.IsLower(r) {
+		text = string(unicode.ToUpper(r)) + text[size:]
+	}
+	return text
+}
+
+// Social looks up a social by name.
+func (w *World) Social(name string) (Social, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	social, ok := w.socials[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Social{}, false
+	}
+	return *social, true
+}
+
+// Socials lists every social ordered by name.
+func (w *World) Socials() []Social {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	list := make([]Social, 0, len(w.socials))
+	for _, social := range w.socials {
+		list = append(list, *social)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// SetSocial adds or replaces a social and saves the socials file.
+func (w *World) SetSocial(social Social) error {
+	if err := normalizeSocial(&social); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev, existed := w.socials[social.Name]
+	if w.socials == nil {
+		w.socials = make(map[string]*Social)
+	}
+	w.socials[social.Name] = &social
+	if err := w.persistSocialsLocked(); err != nil {
+		if existed {
+			w.socials[social.Name] = prev
+		} else {
+			delete(w.socials, social.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveSocial deletes a social and saves the socials file.
+func (w *World) RemoveSocial(name string) error {
+	key := strings.ToLower(strings.TrimSpace(name))
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev, ok := w.socials[key]
+	if !ok {
+		return fmt.Errorf("unknown social: %s", key)
+	}
+	delete(w.socials, key)
+	if err := w.persistSocialsLocked(); err != nil {
+		w.socials[key] = prev
+		return err
+	}
+	return nil
+}
+
+// ReloadSocials re-reads the socials file.
+func (w *World) ReloadSocials() (int, error) {
+	w.mu.RLock()
+	areasPath := w.areasPath
+	w.mu.RUnlock()
+	if areasPath == "" {
+		return 0, fmt.Errorf("world does not have an areas path configured")
+	}
+	socials, err := loadSocials(areasPath)
+	if err != nil {
+		return 0, err
+	}
+	w.mu.Lock()
+	w.socials = socials
+	w.mu.Unlock()
+	return len(socials), nil
+}
+
+func (w *World) persistSocialsLocked() error {
+	path := socialsPath(w.areasPath)
+	if path == "" {
+		return nil
+	}
+	file := socialFile{Socials: make([]Social, 0, len(w.socials))}
+	for _, social := range w.socials {
+		file.Socials = append(file.Socials, *social)
+	}
+	sort.Slice(file.Socials, func(i, j int) bool { return file.Socials[i].Name < file.Socials[j].Name })
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode socials: %w", err)
+	}
+	return fileStorage{}.Write(path, append(data, '\n'))
+}
+
+// PerformSocial plays a social for the player, aimed at a player or NPC in
+// the same room when target is given. The actor, the target, and everyone
+// else in the room each see the message from their own point of view.
+func (w *World) PerformSocial(p *Player, name, target string) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	social, ok := w.socials[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return fmt.Errorf("unknown social: %s", name)
+	}
+	template, targetName := social.Alone, ""
+	var victim *Player
+	if target = strings.TrimSpace(target); target != "" {
+		if social.Target == "" {
+			return fmt.Errorf("%s does not take a target", social.Name)
+		}
+		var names []string
+		var players []*Player
+		for _, other := range w.players {
+			if other.Alive && other.Room == p.Room {
+				names = append(names, other.Name)
+				players = append(players, other)
+			}
+		}
+		if idx, ok := uniqueMatch(target, names, true); ok {
+			victim = players[idx]
+			targetName = victim.Name
+		} else if room, ok := w.rooms[p.Room]; ok {
+			if idx := findNPCIndex(room.NPCs, target); idx >= 0 {
+				targetName = room.NPCs[idx].Name
+			}
+		}
+		if targetName == "" {
+			return fmt.Errorf("you don't see %s here", target)
+		}
+		if victim != p {
+			template = social.Target
+		} else {
+			victim = nil
+		}
+	}
+	send := func(to *Player, view socialView) {
+		to.Output.Send(Ansi("\r\n" + renderSocial(template, p.Name, targetName, view)))
+	}
+	send(p, socialViewActor)
+	if victim != nil {
+		send(victim, socialViewTarget)
+	}
+	for _, other := range w.players {
+		if other.Alive && other.Room == p.Room && other != p && other != victim {
+			send(other, socialViewRoom)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSocialPerspectives(t *testing.T) {
+	template := "$n {ruffle|ruffles} $S hair."
+	if got := stripAnsi(renderSocial(template, "Ana", "Bo", socialViewActor)); got != "You ruffle Bo's hair." {
+		t.Fatalf("actor sees %q", got)
+	}
+	if got := stripAnsi(renderSocial(template, "Ana", "Bo", socialViewTarget)); got != "Ana ruffles your hair." {
+		t.Fatalf("target sees %q", got)
+	}
+	if got := stripAnsi(renderSocial(template, "Ana", "Bo", socialViewRoom)); got != "Ana ruffles Bo's hair." {
+		t.Fatalf("room sees %q", got)
+	}
+}
+
+func TestBundledSocialsLoad(t *testing.T) {
+	socials, err := loadSocials("../../data/areas")
+	if err != nil {
+		t.Fatalf("loadSocials: %v", err)
+	}
+	smile, ok := socials["smile"]
+	if !ok || smile.Target == "" {
+		t.Fatalf("expected a targeted smile social, got %+v", smile)
+	}
+}
+
+func TestPerformSocialReachesEachViewer(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"hall": {ID: "hall", NPCs: []NPC{{Name: "Old Guard"}}},
+	})
+	if err := world.SetSocial(Social{Name: "Wave", Alone: "$n {wave|waves}.", Target: "$n {wave|waves} at $N."}); err != nil {
+		t.Fatalf("SetSocial: %v", err)
+	}
+	ana := &Player{Name: "Ana", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	bo := &Player{Name: "Bo", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	cy := &Player{Name: "Cy", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	for _, p := range []*Player{ana, bo, cy} {
+		world.AddPlayerForTest(p)
+	}
+
+	if err := world.PerformSocial(ana, "wave", "bo"); err != nil {
+		t.Fatalf("PerformSocial: %v", err)
+	}
+	for p, want := range map[*Player]string{ana: "You wave at Bo.", bo: "Ana waves at you.", cy: "Ana waves at Bo."} {
+		if got := stripAnsi(strings.Join(drainOutput(p.Output), "")); !strings.Contains(got, want) {
+			t.Fatalf("%s saw %q, want %q", p.Name, got, want)
+		}
+	}
+	if err := world.PerformSocial(ana, "wave", "guard"); err != nil {
+		t.Fatalf("wave at NPC: %v", err)
+	}
+	if got := stripAnsi(strings.Join(drainOutput(cy.Output), "")); !strings.Contains(got, "Ana waves at Old Guard.") {
+		t.Fatalf("room saw %q", got)
+	}
+	if err := world.PerformSocial(ana, "wave", "nobody"); err == nil {
+		t.Fatalf("expected an unknown target to fail")
+	}
+}
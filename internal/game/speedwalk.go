@@ -0,0 +1,92 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxSpeedwalkSteps bounds how many moves one speedwalk may take.
+const MaxSpeedwalkSteps = 50
+
+// speedwalkDirections maps the letters a speedwalk accepts to exits.
+var speedwalkDirections = map[rune]string{
+	'n': "n", 's': "s", 'e': "e", 'w': "w", 'u': "u", 'd': "d",
+}
+
+// ParseSpeedwalk expands compact directions such as "3n2e u" into one exit
+// per step. A count applies to the direction that follows it.
+func ParseSpeedwalk(input string) ([]string, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil, fmt.Errorf("no directions given")
+	}
+	var steps []string
+	count := 0
+	for _, r := range input {
+		switch {
+		case r >= '0' && r <= '9':
+			count = count*10 + int(r-'0')
+			if count > MaxSpeedwalkSteps {
+				return nil, fmt.Errorf("a speedwalk may take at most %d steps", MaxSpeedwalkSteps)
+			}
+		case r == ' ' || r == '\t':
+			if count > 0 {
+				return nil, fmt.Errorf("a count must be followed by a direction")
+			}
+		default:
+			dir, ok := speedwalkDirections[r]
+			if !ok {
+				return nil, fmt.Errorf("'%c' is not a direction", r)
+			}
+			if count == 0 {
+				count = 1
+			}
+			if len(steps)+count > MaxSpeedwalkSteps {
+				return nil, fmt.Errorf("a speedwalk may take at most %d steps", MaxSpeedwalkSteps)
+			}
+			for ; count > 0; count-- {
+				steps = append(steps, dir)
+			}
+		}
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("a count must be followed by a direction")
+	}
+	return steps, nil
+}
+
+// LooksLikeSpeedwalk reports whether typed input should be read as a
+// speedwalk rather than a command. Input qualifies when it parses and either
+// carries a count or spans several words, so single words such as "news"
+// keep their usual meaning.
+func LooksLikeSpeedwalk(input string) bool {
+	input = strings.TrimSpace(input)
+	if !strings.ContainsAny(input, "0123456789") && len(strings.Fields(input)) < 2 {
+		return false
+	}
+	_, err := ParseSpeedwalk(input)
+	return err == nil
+}
+
+// InCombat reports whether the player is fighting or being attacked.
+func (w *World) InCombat(p *Player) bool {
+	return w.combatFoe(p) != ""
+}
+
+// SpeedwalkEnabled reports whether typed directions such as "3n2e" are
+// walked for the player.
+func (w *World) SpeedwalkEnabled(p *Player) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return !p.SpeedwalkOff
+}
+
+// SetSpeedwalk turns speedwalk parsing of typed directions on or off for the
+// player.
+func (w *World) SetSpeedwalk(p *Player, enabled bool) {
+	w.mu.Lock()
+	p.SpeedwalkOff = !enabled
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+}
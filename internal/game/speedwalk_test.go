@@ -0,0 +1,36 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSpeedwalk(t *testing.T) {
+	steps, err := ParseSpeedwalk("3n2e u")
+	if err != nil {
+		t.Fatalf("ParseSpeedwalk: %v", err)
+	}
+	if want := []string{"n", "n", "n", "e", "e", "u"}; !reflect.DeepEqual(steps, want) {
+		t.Fatalf("steps = %v, want %v", steps, want)
+	}
+	for _, input := range []string{"", "3", "3 n", "2x", "go north", "51n", "30n30s"} {
+		if _, err := ParseSpeedwalk(input); err == nil {
+			t.Errorf("ParseSpeedwalk(%q) should fail", input)
+		}
+	}
+}
+
+func TestLooksLikeSpeedwalk(t *testing.T) {
+	for input, want := range map[string]bool{
+		"3n2e":   true,
+		"n e":    true,
+		"n":      false,
+		"news":   false,
+		"say 2n": false,
+		"2n e":   true,
+	} {
+		if got := LooksLikeSpeedwalk(input); got != want {
+			t.Errorf("LooksLikeSpeedwalk(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
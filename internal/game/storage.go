@@ -0,0 +1,244 @@
+package game
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Storage persists the JSON documents that hold accounts, player profiles,
+// mail, tells, and builder rooms. Keys are the file paths the JSON backend
+// writes to, so every backend addresses the same documents.
+type Storage interface {
+	// Read returns the document stored under key, or an error satisfying
+	// errors.Is(err, os.ErrNotExist) when there is none.
+	Read(key string) ([]byte, error)
+	// Write replaces the document stored under key.
+	Write(key string, data []byte) error
+	// Remove deletes the document stored under key if it exists.
+	Remove(key string) error
+	Close() error
+}
+
+type storageHolder struct {
+	Storage
+}
+
+var activeStorage atomic.Pointer[storageHolder]
+
+func init() {
+	activeStorage.Store(&storageHolder{Storage: fileStorage{}})
+}
+
+// documentStorage returns the storage backend in use.
+func documentStorage() Storage {
+	return activeStorage.Load().Storage
+}
+
+// useStorage switches the storage backend and returns a function restoring
+// the previous one.
+func useStorage(storage Storage) func() {
+	previous := activeStorage.Swap(&storageHolder{Storage: storage})
+	return func() {
+		activeStorage.Store(previous)
+	}
+}
+
+// OpenStorage opens the backend described by spec: "json" (the default) keeps
+// one JSON file per document, while "sqlite:<path>" stores documents in a
+// SQLite database and imports existing JSON files the first time each one is
+// read.
+func OpenStorage(spec string) (Storage, error) {
+	spec = strings.TrimSpace(spec)
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", "json":
+		return fileStorage{}, nil
+	case "sqlite":
+		path := strings.TrimSpace(arg)
+		if path == "" {
+			return nil, fmt.Errorf("sqlite storage requires a database path (sqlite:<path>)")
+		}
+		return openSQLiteStorage(path)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", kind)
+	}
+}
+
+// encodeDocument formats v the way every stored document is written.
+func encodeDocument(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// fileStorage writes each document to its own file using an atomic rename.
+type fileStorage struct{}
+
+func (fileStorage) Read(key string) ([]byte, error) {
+	return os.ReadFile(key)
+}
+
+func (fileStorage) Write(key string, data []byte) error {
+	dir := filepath.Dir(key)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	base := strings.TrimSuffix(filepath.Base(key), filepath.Ext(key))
+	tmp, err := os.CreateTemp(dir, base+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), key); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("replace file: %w", err)
+	}
+	return nil
+}
+
+func (fileStorage) Remove(key string) error {
+	if err := os.Remove(key); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (fileStorage) Close() error {
+	return nil
+}
+
+// sqliteSchemaVersion is the latest schema understood by sqliteStorage.
+const sqliteSchemaVersion = 1
+
+var sqliteMigrations = []string{
+	`CREATE TABLE documents (
+		key TEXT PRIMARY KEY,
+		data BLOB NOT NULL,
+		updated_at TEXT NOT NULL
+	)`,
+}
+
+// sqliteStorage keeps documents in a single SQLite table. Documents missing
+// from the database are imported from their JSON file on first read.
+type sqliteStorage struct {
+	db *sql.DB
+}
+
+func openSQLiteStorage(path string) (*sqliteStorage, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create storage directory: %w", err)
+		}
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite storage: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`PRAGMA journal_mode=WAL; PRAGMA busy_timeout=5000`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("configure sqlite storage: %w", err)
+	}
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteStorage{db: db}, nil
+}
+
+func migrateSQLite(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("read sqlite schema version: %w", err)
+	}
+	if version > sqliteSchemaVersion {
+		return fmt.Errorf("sqlite storage schema version %d is newer than this server supports", version)
+	}
+	for ; version < sqliteSchemaVersion; version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("begin sqlite migration: %w", err)
+		}
+		if _, err := tx.Exec(sqliteMigrations[version]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("apply sqlite migration %d: %w", version+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, version+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("record sqlite migration %d: %w", version+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit sqlite migration %d: %w", version+1, err)
+		}
+	}
+	return nil
+}
+
+func sqliteKey(key string) string {
+	return filepath.ToSlash(filepath.Clean(key))
+}
+
+func (s *sqliteStorage) Read(key string) ([]byte, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM documents WHERE key = ?`, sqliteKey(key)).Scan(&data)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("read %s: %w", key, err)
+	}
+	data, err = os.ReadFile(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Write(key, data); err != nil {
+		return nil, fmt.Errorf("import %s: %w", key, err)
+	}
+	Logger().Info("imported JSON document into sqlite storage", "path", key)
+	return data, nil
+}
+
+func (s *sqliteStorage) Write(key string, data []byte) error {
+	_, err := s.db.Exec(`INSERT INTO documents (key, data, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		sqliteKey(key), data, time.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return fmt.Errorf("write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Remove deletes the document and its JSON file so a later read does not
+// import the stale file again.
+func (s *sqliteStorage) Remove(key string) error {
+	if _, err := s.db.Exec(`DELETE FROM documents WHERE key = ?`, sqliteKey(key)); err != nil {
+		return fmt.Errorf("remove %s: %w", key, err)
+	}
+	if err := os.Remove(key); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *sqliteStorage) Close() error {
+	return s.db.Close()
+}
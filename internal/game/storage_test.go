@@ -0,0 +1,103 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteStorageImportsJSONAccountsAndPersists(t *testing.T) {
+	dir := t.TempDir()
+	accountsPath := filepath.Join(dir, "accounts.json")
+	legacy, err := NewAccountManager(accountsPath)
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := legacy.Register("alice", "secret"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	before, err := os.ReadFile(accountsPath)
+	if err != nil {
+		t.Fatalf("read legacy accounts: %v", err)
+	}
+
+	dbPath := filepath.Join(dir, "lumenclay.db")
+	storage, err := OpenStorage("sqlite:" + dbPath)
+	if err != nil {
+		t.Fatalf("OpenStorage: %v", err)
+	}
+	restore := useStorage(storage)
+	accounts, err := NewAccountManager(accountsPath)
+	if err != nil {
+		restore()
+		t.Fatalf("NewAccountManager with sqlite: %v", err)
+	}
+	if !accounts.Authenticate("alice", "secret") {
+		restore()
+		t.Fatalf("expected migrated account to authenticate")
+	}
+	if err := accounts.Register("bob", "hunter2"); err != nil {
+		restore()
+		t.Fatalf("Register with sqlite: %v", err)
+	}
+	restore()
+	if err := storage.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	after, err := os.ReadFile(accountsPath)
+	if err != nil {
+		t.Fatalf("read legacy accounts: %v", err)
+	}
+	if string(after) != string(before) {
+		t.Fatalf("sqlite backend should not rewrite the JSON file")
+	}
+
+	reopened, err := OpenStorage("sqlite:" + dbPath)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	defer useStorage(reopened)()
+	reloaded, err := NewAccountManager(accountsPath)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !reloaded.Authenticate("bob", "hunter2") || !reloaded.Authenticate("alice", "secret") {
+		t.Fatalf("expected both accounts to persist in sqlite")
+	}
+}
+
+func TestSQLiteStorageRemoveDropsDocumentAndFile(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := OpenStorage("sqlite:" + filepath.Join(dir, "store.db"))
+	if err != nil {
+		t.Fatalf("OpenStorage: %v", err)
+	}
+	defer storage.Close()
+	key := filepath.Join(dir, "tells.json")
+	if err := os.WriteFile(key, []byte(`{"queue":{}}`), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if _, err := storage.Read(key); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if err := storage.Remove(key); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := storage.Read(key); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Read after Remove error = %v, want not exist", err)
+	}
+}
+
+func TestOpenStorageRejectsUnknownSpecs(t *testing.T) {
+	for _, spec := range []string{"sqlite", "sqlite:", "postgres:db"} {
+		if _, err := OpenStorage(spec); err == nil {
+			t.Fatalf("OpenStorage(%q) succeeded, want error", spec)
+		}
+	}
+	if storage, err := OpenStorage("json"); err != nil || storage == nil {
+		t.Fatalf("OpenStorage(json) = %v, %v", storage, err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -26,6 +25,14 @@ type OfflineTell struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// TellReceipt tells a sender that an offline tell has been read.
+type TellReceipt struct {
+	Sender    string    `json:"sender"`
+	Recipient string    `json:"recipient"`
+	Body      string    `json:"body"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
 // Default retention configuration for stored offline tells.
 const (
 	// DefaultTellMaxAge defines how long tells are retained before they expire.
@@ -54,10 +61,11 @@ func (p TellRetentionPolicy) normalized() TellRetentionPolicy {
 
 // TellSystem persists offline tells for delivery when players return.
 type TellSystem struct {
-	mu     sync.RWMutex
-	path   string
-	queue  map[string][]OfflineTell
-	policy TellRetentionPolicy
+	mu       sync.RWMutex
+	path     string
+	queue    map[string][]OfflineTell
+	receipts map[string][]TellReceipt
+	policy   TellRetentionPolicy
 }
 
 // NewTellSystem constructs an offline tell manager backed by the provided file path
@@ -76,15 +84,16 @@ func NewTellSystem(path string) (*TellSystem, error) {
 func NewTellSystemWithRetention(path string, policy TellRetentionPolicy) (*TellSystem, error) {
 	normalized := policy.normalized()
 	system := &TellSystem{
-		path:   path,
-		queue:  make(map[string][]OfflineTell),
-		policy: normalized,
+		path:     path,
+		queue:    make(map[string][]OfflineTell),
+		receipts: make(map[string][]TellReceipt),
+		policy:   normalized,
 	}
 	trimmed := strings.TrimSpace(path)
 	if trimmed == "" {
 		return system, nil
 	}
-	data, err := os.ReadFile(path)
+	data, err := documentStorage().Read(path)
 	if errors.Is(err, os.ErrNotExist) {
 		return system, nil
 	}
@@ -95,11 +104,17 @@ func NewTellSystemWithRetention(path string, policy TellRetentionPolicy) (*TellS
 		return system, nil
 	}
 	var file struct {
-		Queue map[string][]OfflineTell `json:"queue"`
+		Queue    map[string][]OfflineTell `json:"queue"`
+		Receipts map[string][]TellReceipt `json:"receipts"`
 	}
 	if err := json.Unmarshal(data, &file); err != nil {
 		return nil, fmt.Errorf("decode offline tells: %w", err)
 	}
+	for key, list := range file.Receipts {
+		if normalized := normalizeTellKey(key); normalized != "" && len(list) > 0 {
+			system.receipts[normalized] = list
+		}
+	}
 	now := time.Now().UTC()
 	for key, list := range file.Queue {
 		normalized := normalizeTellKey(key)
@@ -242,6 +257,54 @@ func (t *TellSystem) Queue(sender, recipient, body string, when time.Time) (Offl
 	return tell, nil
 }
 
+// AddReceipt records that recipient has read an offline tell from sender so
+// the sender can be told at their next login.
+func (t *TellSystem) AddReceipt(tell OfflineTell, readAt time.Time) error {
+	key := normalizeTellKey(tell.Sender)
+	if key == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous := t.receipts[key]
+	t.receipts[key] = append(append([]TellReceipt(nil), previous...), TellReceipt{
+		Sender:    tell.Sender,
+		Recipient: tell.Recipient,
+		Body:      tell.Body,
+		ReadAt:    readAt.UTC(),
+	})
+	if err := t.persistLocked(); err != nil {
+		if len(previous) == 0 {
+			delete(t.receipts, key)
+		} else {
+			t.receipts[key] = previous
+		}
+		return err
+	}
+	return nil
+}
+
+// ConsumeReceiptsFor retrieves and clears the delivery receipts waiting for
+// sender.
+func (t *TellSystem) ConsumeReceiptsFor(sender string) []TellReceipt {
+	key := normalizeTellKey(sender)
+	if key == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list := t.receipts[key]
+	if len(list) == 0 {
+		return nil
+	}
+	delete(t.receipts, key)
+	if err := t.persistLocked(); err != nil {
+		t.receipts[key] = list
+		return nil
+	}
+	return list
+}
+
 func (t *TellSystem) persistLocked() error {
 	if t.queue == nil {
 		t.queue = make(map[string][]OfflineTell)
@@ -263,36 +326,21 @@ func (t *TellSystem) persistLocked() error {
 		})
 		active[key] = copied
 	}
-	if len(active) == 0 {
-		if err := os.Remove(t.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+	if len(active) == 0 && len(t.receipts) == 0 {
+		if err := documentStorage().Remove(t.path); err != nil {
 			return fmt.Errorf("remove offline tells: %w", err)
 		}
 		return nil
 	}
-	dir := filepath.Dir(t.path)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("create offline tells directory: %w", err)
-	}
-	tmp, err := os.CreateTemp(dir, "tells-*.tmp")
+	data, err := encodeDocument(struct {
+		Queue    map[string][]OfflineTell `json:"queue"`
+		Receipts map[string][]TellReceipt `json:"receipts,omitempty"`
+	}{Queue: active, Receipts: t.receipts})
 	if err != nil {
-		return fmt.Errorf("create temp offline tells file: %w", err)
-	}
-	enc := json.NewEncoder(tmp)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(struct {
-		Queue map[string][]OfflineTell `json:"queue"`
-	}{Queue: active}); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
-		return fmt.Errorf("write offline tells: %w", err)
+		return fmt.Errorf("encode offline tells: %w", err)
 	}
-	if err := tmp.Close(); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("close offline tells file: %w", err)
-	}
-	if err := os.Rename(tmp.Name(), t.path); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("replace offline tells file: %w", err)
+	if err := documentStorage().Write(t.path, data); err != nil {
+		return fmt.Errorf("write offline tells: %w", err)
 	}
 	return nil
 }
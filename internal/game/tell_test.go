@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -173,3 +174,50 @@ func TestTellSystemRetentionPersistsPrunedEntries(t *testing.T) {
 		t.Fatalf("Reloaded pending unexpected: %#v", pending)
 	}
 }
+
+func TestOfflineTellReceiptsReachTheSender(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tells.json")
+	tells, err := NewTellSystem(path)
+	if err != nil {
+		t.Fatalf("NewTellSystem: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{"hall": {ID: "hall", Title: "Hall", Exits: map[string]Exit{}}})
+	world.AttachTellSystem(tells)
+	if _, err := tells.Queue("Alice", "Bob", "Meet me at dawn", time.Time{}); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	if _, err := tells.Queue("Cara", "Bob", "Hello", time.Time{}); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	cara := &Player{Name: "Cara", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	bob := &Player{Name: "Bob", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(cara)
+	world.AddPlayerForTest(bob)
+
+	world.DeliverOfflineTells(bob)
+	if got := world.ReplyTarget(bob); got != "Cara" {
+		t.Fatalf("ReplyTarget = %q, want the latest sender", got)
+	}
+	if history := world.TellHistory(bob, 0); len(history) != 2 {
+		t.Fatalf("history = %+v, want both tells", history)
+	}
+	if got := stripAnsi(strings.Join(drainOutput(cara.Output), "\n")); !strings.Contains(got, "Bob read your tell") {
+		t.Fatalf("online sender should get a receipt, got %q", got)
+	}
+
+	reloaded, err := NewTellSystem(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	world.AttachTellSystem(reloaded)
+	alice := &Player{Name: "Alice", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(alice)
+	world.DeliverTellReceipts(alice)
+	if got := stripAnsi(strings.Join(drainOutput(alice.Output), "\n")); !strings.Contains(got, `Bob read your tell "Meet me at dawn"`) {
+		t.Fatalf("offline sender should get a stored receipt, got %q", got)
+	}
+	if receipts := reloaded.ConsumeReceiptsFor("Alice"); len(receipts) != 0 {
+		t.Fatalf("receipts should be consumed, got %+v", receipts)
+	}
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TellHistoryLimit caps how many tells each player keeps in their history.
+const TellHistoryLimit = 30
+
+// ErrTellIgnored is returned when the recipient is ignoring the sender.
+var ErrTellIgnored = errors.New("recipient is ignoring the sender")
+
+// TellRecord is one private message in a player's tell history.
+type TellRecord struct {
+	Time   time.Time
+	From   string
+	To     string
+	Body   string
+	Queued bool
+}
+
+func (p *Player) rememberTellLocked(record TellRecord) {
+	p.tellHistory = append(p.tellHistory, record)
+	if excess := len(p.tellHistory) - TellHistoryLimit; excess > 0 {
+		p.tellHistory = append([]TellRecord(nil), p.tellHistory[excess:]...)
+	}
+}
+
+// SendTell delivers a private message to an online player, or queues it
+// when they are offline, and threads it into both players' histories so
+// reply and retell know who to answer. The returned record names the
+// canonical recipient even when an error is returned.
+func (w *World) SendTell(sender *Player, recipient, message string) (TellRecord, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return TellRecord{}, fmt.Errorf("what do you want to say?")
+	}
+	record := TellRecord{Time: time.Now(), From: sender.Name, Body: message}
+	w.mu.Lock()
+	target, online := w.findPlayerLocked(recipient)
+	if online {
+		record.To = target.Name
+		if target.isIgnoring(sender) {
+			w.mu.Unlock()
+			return record, ErrTellIgnored
+		}
+		target.rememberTellLocked(record)
+		target.replyTo = sender.Name
+		sender.rememberTellLocked(record)
+		sender.retellTo = target.Name
+		output := target.Output
+		w.mu.Unlock()
+		output.Send(Ansi(fmt.Sprintf("\r\n%s tells you: %s", HighlightName(sender.Name), message)))
+		return record, nil
+	}
+	w.mu.Unlock()
+
+	tell, canonical, err := w.QueueOfflineTell(sender, recipient, message)
+	record.To = canonical
+	if err != nil {
+		return record, err
+	}
+	record.To = tell.Recipient
+	record.Body = tell.Body
+	record.Queued = true
+	w.mu.Lock()
+	sender.rememberTellLocked(record)
+	sender.retellTo = tell.Recipient
+	w.mu.Unlock()
+	return record, nil
+}
+
+// TellHistory returns up to limit of the player's most recent tells, oldest
+// first. A non-positive limit returns the whole history.
+func (w *World) TellHistory(p *Player, limit int) []TellRecord {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	history := p.tellHistory
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return append([]TellRecord(nil), history...)
+}
+
+// ReplyTarget names the last player who sent p a tell.
+func (w *World) ReplyTarget(p *Player) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.replyTo
+}
+
+// RetellTarget names the last player p sent a tell to.
+func (w *World) RetellTarget(p *Player) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.retellTo
+}
+
+// threadOfflineTells records delivered offline tells in the player's history
+// and sends each sender a read receipt.
+func (w *World) threadOfflineTells(p *Player, delivered []OfflineTell) {
+	w.mu.Lock()
+	for _, tell := range delivered {
+		p.rememberTellLocked(TellRecord{Time: tell.CreatedAt.Local(), From: tell.Sender, To: p.Name, Body: tell.Body, Queued: true})
+		p.replyTo = tell.Sender
+	}
+	w.mu.Unlock()
+	w.sendTellReceipts(p.Name, delivered)
+}
+
+// sendTellReceipts lets each sender know reader has read their offline tell:
+// immediately when they are online, or at their next login otherwise.
+func (w *World) sendTellReceipts(reader string, delivered []OfflineTell) {
+	now := time.Now()
+	w.mu.RLock()
+	tells := w.tells
+	type notice struct {
+		output *OutputQueue
+		msg    string
+	}
+	var notices []notice
+	var offline []OfflineTell
+	for _, tell := range delivered {
+		if sender, ok := w.players[tell.Sender]; ok && sender.Alive {
+			notices = append(notices, notice{sender.Output, formatTellReceipt(TellReceipt{Recipient: reader, Body: tell.Body, ReadAt: now})})
+			continue
+		}
+		offline = append(offline, tell)
+	}
+	w.mu.RUnlock()
+	for _, n := range notices {
+		n.output.Send(n.msg)
+	}
+	if tells == nil {
+		return
+	}
+	for _, tell := range offline {
+		if err := tells.AddReceipt(tell, now); err != nil {
+			Logger().Warn("failed to store tell receipt", "sender", tell.Sender, "error", err)
+		}
+	}
+}
+
+func formatTellReceipt(receipt TellReceipt) string {
+	body := receipt.Body
+	if runes := []rune(body); len(runes) > 40 {
+		body = string(runes[:40]) + "..."
+	}
+	return Ansi(fmt.Sprintf("\r\n%s %s read your tell \"%s\" (%s).", Style("[Receipt]", AnsiGreen, AnsiBold),
+		HighlightName(receipt.Recipient), body, receipt.ReadAt.Local().Format("2006-01-02 15:04")))
+}
+
+// DeliverTellReceipts shows the player which of their offline tells were
+// read while they were away.
+func (w *World) DeliverTellReceipts(p *Player) {
+	w.mu.RLock()
+	tells := w.tells
+	w.mu.RUnlock()
+	if tells == nil {
+		return
+	}
+	for _, receipt := range tells.ConsumeReceiptsFor(p.Name) {
+		p.Output.Send(formatTellReceipt(receipt))
+	}
+}
+
+// PendingOfflineTells lists the offline tells waiting for name without
+// marking them read.
+func (w *World) PendingOfflineTells(name string) []OfflineTell {
+	w.mu.RLock()
+	tells := w.tells
+	w.mu.RUnlock()
+	if tells == nil {
+		return nil
+	}
+	return tells.PendingFor(name)
+}
+
+// ReadOfflineTells removes the offline tells waiting for name, such as after
+// they were read on the web portal, and sends their senders read receipts.
+func (w *World) ReadOfflineTells(name string) []OfflineTell {
+	read := w.consumeOfflineTells(name)
+	w.sendTellReceipts(name, read)
+	return read
+}
@@ -7,8 +7,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 
 	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -37,6 +39,7 @@ const (
 	telnetOptWindowSize   byte = 31
 	telnetOptLineMode     byte = 34
 	telnetOptCharset      byte = 42
+	telnetOptMXP          byte = 91
 )
 
 const (
@@ -198,6 +201,16 @@ type TelnetSession struct {
 	hasMTTS          bool
 	suppressGoAhead  bool
 	requestedCharset bool
+	mxp              bool
+	echoing          bool
+
+	history    [][]byte
+	historyPos int
+
+	// out queues output for a writer goroutine started by the first Send.
+	outboxOnce   sync.Once
+	out          *outbox
+	droppedTotal int
 }
 
 func NewTelnetSession(conn net.Conn) *TelnetSession {
@@ -218,6 +231,7 @@ func (s *TelnetSession) performHandshake() {
 	_ = s.writeCommand(telnetWILL, telnetOptSuppressGA)
 	_ = s.writeCommand(telnetDO, telnetOptSuppressGA)
 	_ = s.writeCommand(telnetWILL, telnetOptCharset)
+	_ = s.writeCommand(telnetWILL, telnetOptMXP)
 	_ = s.writeCommand(telnetWONT, telnetOptEcho)
 	_ = s.writeCommand(telnetDONT, telnetOptLineMode)
 	_ = s.writeCommand(telnetDO, telnetOptTerminalType)
@@ -239,7 +253,7 @@ func (s *TelnetSession) WriteString(msg string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	data := []byte(msg)
+	data := []byte(renderMXP(msg, s.mxp))
 	if s.charMap != nil {
 		data = encodeWithCharmap(s.charMap, data)
 	}
@@ -279,34 +293,58 @@ func translateForTelnet(data []byte) []byte {
 	return buf.Bytes()
 }
 
+// ReadLine reads one line of input. Backspace removes a whole character,
+// the up and down arrow keys recall earlier lines, and when the client has
+// asked the server to echo, typed text is echoed back as it arrives.
 func (s *TelnetSession) ReadLine() (string, error) {
+	return s.readLine(false)
+}
+
+// ReadPassword reads a line that is neither echoed nor kept in the input
+// history.
+func (s *TelnetSession) ReadPassword() (string, error) {
+	return s.readLine(true)
+}
+
+func (s *TelnetSession) readLine(secret bool) (string, error) {
 	var buf bytes.Buffer
+	s.historyPos = len(s.history)
 	for {
 		b, err := s.reader.ReadByte()
 		if err != nil {
 			return "", err
 		}
 		switch b {
-		case '\r':
-			if next, err := s.reader.Peek(1); err == nil && next[0] == '\n' {
-				_, _ = s.reader.ReadByte()
+		case '\r', '\n':
+			if b == '\r' {
+				if next, err := s.reader.Peek(1); err == nil && (next[0] == '\n' || next[0] == 0x00) {
+					_, _ = s.reader.ReadByte()
+				}
+			}
+			s.echo([]byte("\r\n"))
+			if !secret {
+				s.rememberInput(buf.Bytes())
 			}
-			return s.decodeInput(buf.Bytes()), nil
-		case '\n':
 			return s.decodeInput(buf.Bytes()), nil
 		case 0x08, 0x7f:
-			bs := buf.Bytes()
-			if len(bs) > 0 {
-				buf.Truncate(len(bs) - 1)
+			if s.deleteLastChar(&buf) && !secret {
+				s.echo([]byte("\b \b"))
 			}
 		case 0x00:
 			// ignore NULs
+		case 0x1b:
+			if err := s.handleEscape(&buf, secret); err != nil {
+				return "", err
+			}
 		case telnetIAC:
 			if err := s.handleIAC(&buf); err != nil {
 				return "", err
 			}
 		default:
 			buf.WriteByte(b)
+			if !secret {
+				s.echo([]byte{b})
+			}
 		}
 	}
 }
@@ -346,6 +384,15 @@ func (s *TelnetSession) handleNegotiation(cmd, opt byte) {
 			s.requestCharset()
 			return
 		}
+		if opt == telnetOptMXP {
+			s.startMXP()
+			return
+		}
+		if opt == telnetOptEcho {
+			s.echoing = true
+			_ = s.writeCommand(telnetWILL, opt)
+			return
+		}
 		if serverSupportedOptions[opt] {
 			_ = s.writeCommand(telnetWILL, opt)
 		} else {
@@ -359,6 +406,12 @@ func (s *TelnetSession) handleNegotiation(cmd, opt byte) {
 			s.requestedCharset = false
 			s.setCharset("UTF-8")
 		}
+		if opt == telnetOptMXP {
+			s.setMXP(false)
+		}
+		if opt == telnetOptEcho {
+			s.echoing = false
+		}
 		_ = s.writeCommand(telnetWONT, opt)
 	case telnetWILL:
 		if opt == telnetOptCharset {
@@ -639,15 +692,47 @@ func encodeWithCharmap(cm *charmap.Charmap, input []byte) []byte {
 	}
 	out := make([]byte, 0, len(input))
 	for _, r := range string(input) {
-		b, ok := cm.EncodeRune(r)
-		if !ok {
-			b = '?'
+		if b, ok := cm.EncodeRune(r); ok {
+			out = append(out, b)
+			continue
 		}
-		out = append(out, b)
+		out = appendTransliterated(cm, out, r)
 	}
 	return out
 }
 
+// transliterations spell out characters that have no accent-stripped form.
+var transliterations = map[rune]string{
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'Ø': "O", 'ø': "o",
+	'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"", '«': "\"", '»': "\"",
+	'–': "-", '—': "-", '…': "...", '•': "*", '€': "EUR",
+}
+
+// appendTransliterated writes the closest spelling of r the charmap can
+// encode, so translated text stays readable on clients without UTF-8: accents
+// are dropped and a few symbols are spelled out. Anything else becomes '?'.
+func appendTransliterated(cm *charmap.Charmap, out []byte, r rune) []byte {
+	replacement, ok := transliterations[r]
+	if !ok {
+		replacement = norm.NFD.String(string(r))
+	}
+	var encoded []byte
+	for _, part := range replacement {
+		if unicode.Is(unicode.Mn, part) {
+			continue
+		}
+		b, ok := cm.EncodeRune(part)
+		if !ok {
+			return append(out, '?')
+		}
+		encoded = append(encoded, b)
+	}
+	if len(encoded) == 0 {
+		return append(out, '?')
+	}
+	return append(out, encoded...)
+}
+
 func decodeWithCharmap(cm *charmap.Charmap, input []byte) string {
 	if cm == nil || len(input) == 0 {
 		return string(input)
@@ -660,14 +745,96 @@ func decodeWithCharmap(cm *charmap.Charmap, input []byte) string {
 }
 
 func (s *TelnetSession) Close() error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.flushOutbox()
 	if s.conn == nil {
 		return nil
 	}
 	return s.conn.Close()
 }
 
+// telnetState captures negotiated terminal settings so a session can resume
+// after a copyover without renegotiating.
+type telnetState struct {
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	Terminal        string `json:"terminal,omitempty"`
+	Charset         string `json:"charset,omitempty"`
+	Features        uint64 `json:"features"`
+	MTTS            bool   `json:"mtts,omitempty"`
+	SuppressGoAhead bool   `json:"suppress_go_ahead,omitempty"`
+	MXP             bool   `json:"mxp,omitempty"`
+	Echo            bool   `json:"echo,omitempty"`
+}
+
+func (s *TelnetSession) snapshotState() telnetState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return telnetState{
+		Width:           s.width,
+		Height:          s.height,
+		Terminal:        s.term,
+		Charset:         s.charset,
+		Features:        uint64(s.features),
+		MTTS:            s.hasMTTS,
+		SuppressGoAhead: s.suppressGoAhead,
+		MXP:             s.mxp,
+		Echo:            s.echoing,
+	}
+}
+
+// resumeTelnetSession wraps an inherited connection using previously
+// negotiated settings instead of performing a new handshake.
+func resumeTelnetSession(conn net.Conn, state telnetState) *TelnetSession {
+	s := &TelnetSession{
+		conn:            conn,
+		reader:          bufio.NewReader(conn),
+		width:           state.Width,
+		height:          state.Height,
+		term:            state.Terminal,
+		termTypes:       make(map[string]struct{}),
+		charset:         "UTF-8",
+		hasMTTS:         state.MTTS,
+		suppressGoAhead: state.SuppressGoAhead,
+		mxp:             state.MXP,
+		echoing:         state.Echo,
+	}
+	if s.width <= 0 {
+		s.width = 80
+	}
+	if s.height <= 0 {
+		s.height = 24
+	}
+	if state.Charset != "" && state.Charset != "UTF-8" {
+		s.setCharset(state.Charset)
+		if s.charMap == nil && state.Terminal != "" {
+			s.applyTerminalProfile(state.Terminal)
+		}
+	}
+	s.features = bitmask(state.Features)
+	return s
+}
+
+// RemoteIP returns the client's address without the port.
+func (s *TelnetSession) RemoteIP() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.conn == nil || s.conn.RemoteAddr() == nil {
+		return ""
+	}
+	return remoteIP(s.conn.RemoteAddr())
+}
+
+func remoteIP(addr net.Addr) string {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP.String()
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
 func (s *TelnetSession) Size() (int, int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
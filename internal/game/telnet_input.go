@@ -0,0 +1,111 @@
+package game
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// inputHistoryLimit caps how many lines each session remembers for the
+// arrow keys.
+const inputHistoryLimit = 50
+
+// echo writes data back to the client when it asked the server to echo.
+func (s *TelnetSession) echo(data []byte) {
+	if !s.echoing {
+		return
+	}
+	_ = s.writeRaw(translateForTelnet(data))
+}
+
+// deleteLastChar removes the final character from buf: a whole rune for
+// UTF-8 clients, or one byte for single-byte charsets.
+func (s *TelnetSession) deleteLastChar(buf *bytes.Buffer) bool {
+	data := buf.Bytes()
+	if len(data) == 0 {
+		return false
+	}
+	size := 1
+	if s.charMap == nil {
+		_, size = utf8.DecodeLastRune(data)
+	}
+	buf.Truncate(len(data) - size)
+	return true
+}
+
+func (s *TelnetSession) inputWidth(data []byte) int {
+	if s.charMap != nil {
+		return len(data)
+	}
+	return utf8.RuneCount(data)
+}
+
+// handleEscape consumes a cursor-key sequence (ESC [ A or ESC O A). Up and
+// down walk the input history; every other sequence is dropped so stray
+// escape codes never reach the command parser.
+func (s *TelnetSession) handleEscape(buf *bytes.Buffer, secret bool) error {
+	intro, err := s.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	if intro != '[' && intro != 'O' {
+		return s.reader.UnreadByte()
+	}
+	var final byte
+	for {
+		b, err := s.reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			final = b
+			break
+		}
+		if b < 0x20 || b > 0x3f {
+			return s.reader.UnreadByte()
+		}
+	}
+	if secret {
+		return nil
+	}
+	switch final {
+	case 'A':
+		if s.historyPos > 0 {
+			s.historyPos--
+			s.replaceInput(buf, s.history[s.historyPos])
+		}
+	case 'B':
+		if s.historyPos < len(s.history) {
+			s.historyPos++
+			var next []byte
+			if s.historyPos < len(s.history) {
+				next = s.history[s.historyPos]
+			}
+			s.replaceInput(buf, next)
+		}
+	}
+	return nil
+}
+
+// replaceInput swaps the line being typed for a history entry, redrawing it
+// when the server is echoing.
+func (s *TelnetSession) replaceInput(buf *bytes.Buffer, line []byte) {
+	if s.echoing {
+		erase := bytes.Repeat([]byte("\b \b"), s.inputWidth(buf.Bytes()))
+		s.echo(append(erase, line...))
+	}
+	buf.Reset()
+	buf.Write(line)
+}
+
+func (s *TelnetSession) rememberInput(line []byte) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	if n := len(s.history); n > 0 && bytes.Equal(s.history[n-1], line) {
+		return
+	}
+	s.history = append(s.history, append([]byte(nil), line...))
+	if excess := len(s.history) - inputHistoryLimit; excess > 0 {
+		s.history = append([][]byte(nil), s.history[excess:]...)
+	}
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"bufio"
+	"net"
 	"testing"
 
 	"golang.org/x/text/encoding/charmap"
@@ -56,3 +58,93 @@ func TestSanitizeTelnetString(t *testing.T) {
 		t.Fatalf("unexpected sanitized string: %q", got)
 	}
 }
+
+// newPipeSession wires a session to an in-memory connection. Everything the
+// server writes is delivered on the returned channel.
+func newPipeSession(t *testing.T) (*TelnetSession, net.Conn, <-chan []byte) {
+	t.Helper()
+	server, client := net.Pipe()
+	t.Cleanup(func() {
+		server.Close()
+		client.Close()
+	})
+	received := make(chan []byte, 64)
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, err := client.Read(buf)
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+	s := &TelnetSession{conn: server, reader: bufio.NewReader(server), termTypes: make(map[string]struct{})}
+	return s, client, received
+}
+
+func sendInput(t *testing.T, client net.Conn, input string) {
+	t.Helper()
+	go func() { _, _ = client.Write([]byte(input)) }()
+}
+
+func TestReadLineDeletesWholeRunes(t *testing.T) {
+	s, client, _ := newPipeSession(t)
+	sendInput(t, client, "caf\u00e9\x7f\x7fx\r\n")
+	line, err := s.ReadLine()
+	if err != nil || line != "cax" {
+		t.Fatalf("ReadLine = %q, %v; want cax", line, err)
+	}
+}
+
+func TestReadLineRecallsHistoryWithArrowKeys(t *testing.T) {
+	s, client, _ := newPipeSession(t)
+	sendInput(t, client, "look\r\nsay hi\r\nhunter2\r\n\x1b[A\x1b[A\x1b[B\r\n\x1bOA\x1b[D\r\n")
+	for _, want := range []string{"look", "say hi"} {
+		if line, err := s.ReadLine(); err != nil || line != want {
+			t.Fatalf("ReadLine = %q, %v; want %q", line, err, want)
+		}
+	}
+	if line, err := s.ReadPassword(); err != nil || line != "hunter2" {
+		t.Fatalf("ReadPassword = %q, %v", line, err)
+	}
+	if line, err := s.ReadLine(); err != nil || line != "say hi" {
+		t.Fatalf("up, up, down recalled %q, %v; want say hi", line, err)
+	}
+	if line, err := s.ReadLine(); err != nil || line != "say hi" {
+		t.Fatalf("SS3 up recalled %q, %v; passwords must stay out of the history", line, err)
+	}
+}
+
+func TestReadLineEchoesWhenClientAsks(t *testing.T) {
+	s, client, received := newPipeSession(t)
+	s.handleNegotiation(telnetDO, telnetOptEcho)
+	if got := <-received; string(got) != string([]byte{telnetIAC, telnetWILL, telnetOptEcho}) {
+		t.Fatalf("echo negotiation = %v", got)
+	}
+	sendInput(t, client, "ab\x7f\r\n")
+	if line, err := s.ReadLine(); err != nil || line != "a" {
+		t.Fatalf("ReadLine = %q, %v", line, err)
+	}
+	var echoed []byte
+	for len(echoed) < len("ab\b \b\r\n") {
+		echoed = append(echoed, <-received...)
+	}
+	if string(echoed) != "ab\b \b\r\n" {
+		t.Fatalf("echoed %q", echoed)
+	}
+	if !s.snapshotState().Echo {
+		t.Fatalf("echo state should survive a copyover")
+	}
+}
+
+func TestEncodeTransliteratesUnencodableRunes(t *testing.T) {
+	got := string(encodeWithCharmap(charmap.ISO8859_1, []byte("Œuvre — „Tür“ ŝ…")))
+	if got != "OEuvre - \"T\xfcr\" s..." {
+		t.Fatalf("unexpected transliteration %q", got)
+	}
+	if got := string(encodeWithCharmap(charmap.ISO8859_1, []byte("龍"))); got != "?" {
+		t.Fatalf("expected an unspellable rune to become '?', got %q", got)
+	}
+}
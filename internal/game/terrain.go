@@ -0,0 +1,189 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNeedSwim indicates the destination is water the player cannot
+	// cross.
+	ErrNeedSwim = errors.New("you would need to swim or fly to go there")
+	// ErrNeedFly indicates the destination is open air.
+	ErrNeedFly = errors.New("you would need to fly to go there")
+	// ErrRoomFull indicates the destination holds as many players as it can.
+	ErrRoomFull = errors.New("there is no room for you there")
+)
+
+const (
+	// SwimEffect is the status effect that lets a player cross water.
+	SwimEffect = "swim"
+	// FlyEffect is the status effect that lets a player cross water and
+	// enter open air.
+	FlyEffect = "fly"
+)
+
+// Terrain describes the ground underfoot in a room and sets what it costs to
+// enter.
+type Terrain string
+
+const (
+	// TerrainInside is the default for rooms that set no terrain.
+	TerrainInside   Terrain = ""
+	TerrainRoad     Terrain = "road"
+	TerrainField    Terrain = "field"
+	TerrainForest   Terrain = "forest"
+	TerrainHills    Terrain = "hills"
+	TerrainMountain Terrain = "mountain"
+	TerrainSwamp    Terrain = "swamp"
+	// TerrainWater needs swimming or flying to enter.
+	TerrainWater Terrain = "water"
+	// TerrainAir needs flying to enter.
+	TerrainAir Terrain = "air"
+)
+
+// Terrains lists the terrain types builders may set, in display order.
+func Terrains() []Terrain {
+	return []Terrain{TerrainRoad, TerrainField, TerrainForest, TerrainHills, TerrainMountain, TerrainSwamp, TerrainWater, TerrainAir}
+}
+
+// ParseTerrain accepts a terrain name, or "inside" for the default.
+func ParseTerrain(name string) (Terrain, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "inside" || name == "none" {
+		return TerrainInside, true
+	}
+	for _, terrain := range Terrains() {
+		if name == string(terrain) {
+			return terrain, true
+		}
+	}
+	return "", false
+}
+
+// Label returns the terrain's display name.
+func (t Terrain) Label() string {
+	if t == TerrainInside {
+		return "inside"
+	}
+	return string(t)
+}
+
+// surcharge is the stamina entering the terrain costs beyond ordinary
+// ground.
+func (t Terrain) surcharge() int {
+	switch t {
+	case TerrainRoad:
+		return -1
+	case TerrainForest, TerrainWater:
+		return 1
+	case TerrainHills:
+		return 2
+	case TerrainMountain, TerrainSwamp:
+		return 3
+	}
+	return 0
+}
+
+// canSwim reports whether p can cross water: a swim or fly effect, or a
+// carried item that floats or flies them across.
+func (p *Player) canSwim() bool {
+	if p.canFly() || p.HasEffect(SwimEffect) {
+		return true
+	}
+	for _, item := range p.Inventory {
+		if item.Swim {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Player) canFly() bool {
+	if p.HasEffect(FlyEffect) {
+		return true
+	}
+	for _, item := range p.Inventory {
+		if item.Fly {
+			return true
+		}
+	}
+	return false
+}
+
+// terrainAllowsLocked checks that p may enter room, given its terrain and
+// how many players it holds.
+func (w *World) terrainAllowsLocked(p *Player, room *Room) error {
+	switch room.Terrain {
+	case TerrainWater:
+		if !p.canSwim() {
+			return ErrNeedSwim
+		}
+	case TerrainAir:
+		if !p.canFly() {
+			return ErrNeedFly
+		}
+	}
+	if room.Capacity > 0 {
+		count := 0
+		for _, other := range w.players {
+			if other != p && other.Alive && other.Room == room.ID {
+				count++
+			}
+		}
+		if count >= room.Capacity {
+			return ErrRoomFull
+		}
+	}
+	return nil
+}
+
+// SetRoomTerrain changes a room's terrain and saves it with the builder
+// rooms.
+func (w *World) SetRoomTerrain(id RoomID, terrain Terrain, editor string) error {
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.Terrain
+		room.Terrain = terrain
+		return func() { room.Terrain = prev }
+	})
+}
+
+// SetRoomCapacity limits how many players a room holds; zero removes the
+// limit.
+func (w *World) SetRoomCapacity(id RoomID, capacity int, editor string) error {
+	if capacity < 0 {
+		return fmt.Errorf("capacity must not be negative")
+	}
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.Capacity
+		room.Capacity = capacity
+		return func() { room.Capacity = prev }
+	})
+}
+
+// editRoom applies a builder edit to a room and persists it,
+// undoing the edit when saving fails. edit returns the undo.
+func (w *World) editRoom(id RoomID, editor string, edit func(*Room) func()) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.checkBuildScopeLocked(editor, id); err != nil {
+		return err
+	}
+	room, ok := w.rooms[id]
+	if !ok {
+		return fmt.Errorf("unknown room: %s", id)
+	}
+	undo := edit(room)
+	prevSource, hadSource := w.markRoomAsBuilderLocked(id)
+	if err := w.persistBuilderRoomsLocked(); err != nil {
+		undo()
+		if hadSource {
+			w.roomSources[id] = prevSource
+		} else {
+			delete(w.roomSources, id)
+		}
+		return err
+	}
+	return nil
+}
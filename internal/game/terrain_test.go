@@ -0,0 +1,124 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTerrainSetsMovementCost(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"shore": {ID: "shore", Title: "Shore", Exits: map[string]Exit{
+			"east":  {To: "lake"},
+			"north": {To: "peak"},
+			"up":    {To: "sky"},
+			"west":  {To: "road"},
+		}},
+		"lake": {ID: "lake", Title: "Lake", Terrain: TerrainWater, Exits: map[string]Exit{"west": {To: "shore"}}},
+		"peak": {ID: "peak", Title: "Peak", Terrain: TerrainMountain, Exits: map[string]Exit{"south": {To: "shore"}}},
+		"sky":  {ID: "sky", Title: "Sky", Terrain: TerrainAir, Exits: map[string]Exit{"down": {To: "shore"}}},
+		"road": {ID: "road", Title: "Road", Terrain: TerrainRoad, Exits: map[string]Exit{"east": {To: "shore"}}},
+	})
+	p := &Player{Name: "Wader", Room: "shore", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if _, err := world.Move(p, "north"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if want := p.MaxMoves - WalkMoveCost - 3; p.Moves != want {
+		t.Fatalf("climbing left %d stamina, want %d", p.Moves, want)
+	}
+	if _, err := world.Move(p, "south"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	before := p.Moves
+	if _, err := world.Move(p, "west"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if cost := before - p.Moves; cost != WalkMoveCost-1 {
+		t.Fatalf("road cost %d stamina, want %d", cost, WalkMoveCost-1)
+	}
+}
+
+func TestWaterAndAirNeedSwimmingOrFlying(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"shore": {ID: "shore", Title: "Shore", Exits: map[string]Exit{
+			"east":  {To: "lake"},
+			"north": {To: "peak"},
+			"up":    {To: "sky"},
+			"west":  {To: "road"},
+		}},
+		"lake": {ID: "lake", Title: "Lake", Terrain: TerrainWater, Exits: map[string]Exit{"west": {To: "shore"}}},
+		"peak": {ID: "peak", Title: "Peak", Terrain: TerrainMountain, Exits: map[string]Exit{"south": {To: "shore"}}},
+		"sky":  {ID: "sky", Title: "Sky", Terrain: TerrainAir, Exits: map[string]Exit{"down": {To: "shore"}}},
+		"road": {ID: "road", Title: "Road", Terrain: TerrainRoad, Exits: map[string]Exit{"east": {To: "shore"}}},
+	})
+	p := &Player{Name: "Wader", Room: "shore", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if _, err := world.Move(p, "east"); !errors.Is(err, ErrNeedSwim) {
+		t.Fatalf("expected ErrNeedSwim, got %v", err)
+	}
+	p.Inventory = append(p.Inventory, Item{Name: "Cork Float", Swim: true})
+	if _, err := world.Move(p, "east"); err != nil {
+		t.Fatalf("Move with float: %v", err)
+	}
+	if _, err := world.Move(p, "west"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := world.Move(p, "up"); !errors.Is(err, ErrNeedFly) {
+		t.Fatalf("expected ErrNeedFly, got %v", err)
+	}
+	p.Inventory = nil
+	p.addEffect(StatusEffect{Name: FlyEffect, Expires: time.Now().Add(time.Minute)})
+	if _, err := world.Move(p, "up"); err != nil {
+		t.Fatalf("Move while flying: %v", err)
+	}
+	if p.Room != "sky" {
+		t.Fatalf("room = %s, want sky", p.Room)
+	}
+}
+
+func TestRoomCapacityTurnsPlayersAway(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"shore": {ID: "shore", Title: "Shore", Exits: map[string]Exit{
+			"east":  {To: "lake"},
+			"north": {To: "peak"},
+			"up":    {To: "sky"},
+			"west":  {To: "road"},
+		}},
+		"lake": {ID: "lake", Title: "Lake", Terrain: TerrainWater, Exits: map[string]Exit{"west": {To: "shore"}}},
+		"peak": {ID: "peak", Title: "Peak", Terrain: TerrainMountain, Exits: map[string]Exit{"south": {To: "shore"}}},
+		"sky":  {ID: "sky", Title: "Sky", Terrain: TerrainAir, Exits: map[string]Exit{"down": {To: "shore"}}},
+		"road": {ID: "road", Title: "Road", Terrain: TerrainRoad, Exits: map[string]Exit{"east": {To: "shore"}}},
+	})
+	p := &Player{Name: "Wader", Room: "shore", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	if err := world.SetRoomCapacity("road", 1, "Builder"); err != nil {
+		t.Fatalf("SetRoomCapacity: %v", err)
+	}
+	other := &Player{Name: "Camper", Room: "road", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(other)
+	if _, err := world.Move(p, "west"); !errors.Is(err, ErrRoomFull) {
+		t.Fatalf("expected ErrRoomFull, got %v", err)
+	}
+	if err := world.SetRoomCapacity("road", 0, "Builder"); err != nil {
+		t.Fatalf("SetRoomCapacity: %v", err)
+	}
+	if _, err := world.Move(p, "west"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if err := world.SetRoomCapacity("road", -1, "Builder"); err == nil {
+		t.Fatalf("expected negative capacity to be refused")
+	}
+}
+
+func TestParseTerrain(t *testing.T) {
+	if terrain, ok := ParseTerrain(" Swamp "); !ok || terrain != TerrainSwamp {
+		t.Fatalf("ParseTerrain(swamp) = %q, %v", terrain, ok)
+	}
+	if terrain, ok := ParseTerrain("inside"); !ok || terrain != TerrainInside {
+		t.Fatalf("ParseTerrain(inside) = %q, %v", terrain, ok)
+	}
+	if _, ok := ParseTerrain("lava"); ok {
+		t.Fatalf("ParseTerrain accepted lava")
+	}
+}
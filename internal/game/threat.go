@@ -0,0 +1,203 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// TauntThreat is how far a taunt lifts the taunter above the NPC's
+	// current highest threat.
+	TauntThreat = 50
+	// TauntCooldown is how long a player must wait between taunts.
+	TauntCooldown = 8 * time.Second
+	// healingThreatDivisor scales healing into threat; healers draw half as
+	// much attention as the damage they undo.
+	healingThreatDivisor = 2
+)
+
+// ErrTauntCooldown indicates the player taunted too recently.
+var ErrTauntCooldown = errors.New("you need a moment before taunting again")
+
+// ThreatEntry records how much threat a player holds with an NPC.
+type ThreatEntry struct {
+	Player string
+	Threat int
+}
+
+// Consideration describes an NPC sized up by a player.
+type Consideration struct {
+	NPC    NPC
+	Target string
+	Threat []ThreatEntry
+}
+
+// addThreatLocked adds amount to player's threat on npc. Callers must hold
+// c.mu.
+func (c *combatInstance) addThreatLocked(npc, player string, amount int) {
+	table, ok := c.threat[npc]
+	if !ok {
+		table = make(map[string]int)
+		c.threat[npc] = table
+	}
+	table[player] += amount
+}
+
+// addThreat adds threat for player on npc and turns an engaged NPC towards
+// whoever now leads its threat table.
+func (c *combatInstance) addThreat(npc, player string, amount int) {
+	if amount <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addThreatLocked(npc, player, amount)
+	if _, engaged := c.npcTargets[npc]; !engaged {
+		return
+	}
+	if top, ok := c.topThreatLocked(npc); ok {
+		c.npcTargets[npc] = combatTarget{kind: combatTargetPlayer, name: top}
+	}
+}
+
+// topThreatLocked returns the player holding the most threat on npc. The
+// current target keeps the NPC's attention on ties so aggro does not flicker.
+func (c *combatInstance) topThreatLocked(npc string) (string, bool) {
+	table := c.threat[npc]
+	if len(table) == 0 {
+		return "", false
+	}
+	best, bestThreat, held := "", 0, false
+	if current, ok := c.npcTargets[npc]; ok && current.kind == combatTargetPlayer {
+		if threat, ok := table[current.name]; ok {
+			best, bestThreat, held = current.name, threat, true
+		}
+	}
+	for player, threat := range table {
+		if player == best {
+			continue
+		}
+		if best == "" || threat > bestThreat || (threat == bestThreat && !held && player < best) {
+			best, bestThreat, held = player, threat, false
+		}
+	}
+	return best, best != ""
+}
+
+// taunt lifts player to the top of npc's threat table and forces the NPC to
+// attack them.
+func (c *combatInstance) taunt(npc, player string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	highest := 0
+	for _, threat := range c.threat[npc] {
+		highest = max(highest, threat)
+	}
+	c.addThreatLocked(npc, player, 0)
+	c.threat[npc][player] = highest + TauntThreat
+	c.npcTargets[npc] = combatTarget{kind: combatTargetPlayer, name: player}
+}
+
+// threatSnapshot returns npc's threat table, highest first, and its target.
+func (c *combatInstance) threatSnapshot(npc string) ([]ThreatEntry, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make([]ThreatEntry, 0, len(c.threat[npc]))
+	for player, threat := range c.threat[npc] {
+		entries = append(entries, ThreatEntry{Player: player, Threat: threat})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Threat != entries[j].Threat {
+			return entries[i].Threat > entries[j].Threat
+		}
+		return entries[i].Player < entries[j].Player
+	})
+	target := ""
+	if current, ok := c.npcTargets[npc]; ok && current.kind == combatTargetPlayer {
+		target = current.name
+	}
+	return entries, target
+}
+
+// combatIn returns the fight running in room, if any.
+func (w *World) combatIn(room RoomID) *combatInstance {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.combats[room]
+}
+
+// RecordDamageThreat credits p with threat on an NPC in p's room for damage
+// dealt outside the regular combat rounds, such as spells.
+func (w *World) RecordDamageThreat(p *Player, npc string, damage int) {
+	if combat := w.combatIn(p.Room); combat != nil {
+		combat.addThreat(npc, p.Name, damage)
+	}
+}
+
+// RecordHealingThreat credits p with threat on every NPC fighting in p's
+// room for healing done there.
+func (w *World) RecordHealingThreat(p *Player, amount int) {
+	combat := w.combatIn(p.Room)
+	if combat == nil {
+		return
+	}
+	combat.mu.Lock()
+	npcs := make([]string, 0, len(combat.npcTargets))
+	for npc := range combat.npcTargets {
+		npcs = append(npcs, npc)
+	}
+	combat.mu.Unlock()
+	for _, npc := range npcs {
+		combat.addThreat(npc, p.Name, amount/healingThreatDivisor)
+	}
+}
+
+// Taunt goads an NPC in p's room into attacking p, lifting p above everyone
+// else on its threat table.
+func (w *World) Taunt(p *Player, name string) (NPC, error) {
+	if !p.Alive {
+		return NPC{}, fmt.Errorf("you are in no condition to fight")
+	}
+	npc, ok := w.FindRoomNPC(p.Room, strings.TrimSpace(name))
+	if !ok {
+		return NPC{}, fmt.Errorf("no such opponent here")
+	}
+	now := time.Now()
+	w.mu.Lock()
+	if !p.lastTaunt.IsZero() && now.Sub(p.lastTaunt) < TauntCooldown {
+		w.mu.Unlock()
+		return NPC{}, ErrTauntCooldown
+	}
+	p.lastTaunt = now
+	w.mu.Unlock()
+
+	combat := w.ensureCombat(p.Room)
+	combat.mu.Lock()
+	_, fighting := combat.playerTargets[p.Name]
+	combat.mu.Unlock()
+	if !fighting {
+		combat.addPlayer(p.Name, combatTarget{kind: combatTargetNPC, name: npc.Name})
+	}
+	combat.addNPC(npc.Name, combatTarget{kind: combatTargetPlayer, name: p.Name})
+	combat.taunt(npc.Name, p.Name)
+	combat.startLoop()
+	return *npc, nil
+}
+
+// Consider sizes up an NPC in p's room, including who it is fighting and
+// the threat each player holds with it.
+func (w *World) Consider(p *Player, name string) (Consideration, error) {
+	npc, ok := w.FindRoomNPC(p.Room, strings.TrimSpace(name))
+	if !ok {
+		return Consideration{}, fmt.Errorf("you don't see %s here", strings.TrimSpace(name))
+	}
+	npc.EnsureStats()
+	result := Consideration{NPC: *npc}
+	if combat := w.combatIn(p.Room); combat != nil {
+		result.Threat, result.Target = combat.threatSnapshot(npc.Name)
+	}
+	return result, nil
+}
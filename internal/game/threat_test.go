@@ -0,0 +1,75 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestThreatTableDrivesNPCTargeting(t *testing.T) {
+	combat := newCombatInstance(nil, "arena", DefaultCombatRound)
+	combat.addPlayer("Tank", combatTarget{kind: combatTargetNPC, name: "Ogre"})
+	combat.addPlayer("Mage", combatTarget{kind: combatTargetNPC, name: "Ogre"})
+	combat.addNPC("Ogre", combatTarget{kind: combatTargetPlayer, name: "Tank"})
+
+	combat.addThreat("Ogre", "Tank", 20)
+	combat.addThreat("Ogre", "Mage", 21)
+	if target := combat.npcTargets["Ogre"].name; target != "Tank" {
+		t.Fatalf("a tie should keep the current target, got %s", target)
+	}
+	combat.addThreat("Ogre", "Mage", 5)
+	if target := combat.npcTargets["Ogre"].name; target != "Mage" {
+		t.Fatalf("expected the ogre to turn on the mage, got %s", target)
+	}
+
+	combat.taunt("Ogre", "Tank")
+	entries, target := combat.threatSnapshot("Ogre")
+	if target != "Tank" || entries[0].Player != "Tank" || entries[0].Threat != 26+TauntThreat {
+		t.Fatalf("taunt did not lift the tank to the top: target=%s entries=%v", target, entries)
+	}
+
+	if !combat.retargetNPC("Ogre") {
+		t.Fatalf("expected the ogre to find another target")
+	}
+	if target := combat.npcTargets["Ogre"].name; target != "Mage" {
+		t.Fatalf("expected the ogre to fall back to the mage, got %s", target)
+	}
+	if _, ok := combat.threat["Ogre"]["Tank"]; ok {
+		t.Fatalf("expected the departed tank to leave the threat table")
+	}
+}
+
+func TestTauntAndConsider(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"den": {ID: "den", Title: "Den", NPCs: []NPC{{Name: "Cave Bear", Level: 3}}},
+	})
+	tank := &Player{Name: "Tank", Room: "den", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(tank)
+
+	info, err := world.Consider(tank, "bear")
+	if err != nil {
+		t.Fatalf("Consider: %v", err)
+	}
+	if info.NPC.Name != "Cave Bear" || len(info.Threat) != 0 {
+		t.Fatalf("unexpected consideration before combat: %+v", info)
+	}
+
+	if _, err := world.Taunt(tank, "bear"); err != nil {
+		t.Fatalf("Taunt: %v", err)
+	}
+	combat := world.combatIn("den")
+	if combat == nil {
+		t.Fatalf("expected taunt to start combat")
+	}
+	defer combat.stopLoop()
+	if _, err := world.Taunt(tank, "bear"); !errors.Is(err, ErrTauntCooldown) {
+		t.Fatalf("expected ErrTauntCooldown, got %v", err)
+	}
+
+	info, err = world.Consider(tank, "bear")
+	if err != nil {
+		t.Fatalf("Consider: %v", err)
+	}
+	if info.Target != "Tank" || len(info.Threat) != 1 || info.Threat[0].Threat < TauntThreat {
+		t.Fatalf("expected the bear to be focused on the tank, got %+v", info)
+	}
+}
@@ -0,0 +1,298 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxTimersPerOwner caps the timers a single script or builder may keep
+	// scheduled at once so a misbehaving hook cannot flood the scheduler.
+	MaxTimersPerOwner = 5
+	// MinTimerInterval is the shortest repeat interval a timer may use.
+	MinTimerInterval = 10 * time.Second
+)
+
+// builderTimerPrefix marks timer owners that are builders rather than
+// scripts.
+const builderTimerPrefix = "builder:"
+
+// BuilderTimerOwner names the timer owner for a builder's hand-made timers.
+func BuilderTimerOwner(name string) string {
+	return builderTimerPrefix + name
+}
+
+// ErrTimerQuota indicates the owner already has MaxTimersPerOwner timers.
+var ErrTimerQuota = errors.New("timer quota reached")
+
+// TimerInfo describes a scheduled timer for listings.
+type TimerInfo struct {
+	ID       int
+	Owner    string
+	Room     RoomID
+	Schedule string
+	Label    string
+	Next     time.Time
+}
+
+type scheduledTimer struct {
+	id       int
+	owner    string
+	room     RoomID
+	interval time.Duration
+	hour     int
+	minute   int
+	label    string
+	next     time.Time
+	action   func()
+	timer    *time.Timer
+}
+
+// schedule describes when the timer repeats.
+func (t *scheduledTimer) schedule() string {
+	if t.interval > 0 {
+		return "every " + formatTimerInterval(t.interval)
+	}
+	return fmt.Sprintf("at %02d:%02d", t.hour, t.minute)
+}
+
+// following returns the next time the timer should fire after now.
+func (t *scheduledTimer) following(now time.Time) time.Time {
+	if t.interval > 0 {
+		return now.Add(t.interval)
+	}
+	return nextClockTime(now, t.hour, t.minute)
+}
+
+type timerScheduler struct {
+	mu     sync.Mutex
+	nextID int
+	timers map[int]*scheduledTimer
+}
+
+func newTimerScheduler() *timerScheduler {
+	return &timerScheduler{timers: make(map[int]*scheduledTimer)}
+}
+
+// ParseTimerInterval reads a repeat interval such as "5m" or "1h30m".
+func ParseTimerInterval(input string) (time.Duration, error) {
+	interval, err := time.ParseDuration(strings.TrimSpace(input))
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q", strings.TrimSpace(input))
+	}
+	if interval < MinTimerInterval {
+		return 0, fmt.Errorf("interval must be at least %s", formatTimerInterval(MinTimerInterval))
+	}
+	return interval, nil
+}
+
+// ParseTimerClock reads a 24-hour server clock time such as "20:00".
+func ParseTimerClock(input string) (int, int, error) {
+	trimmed := strings.TrimSpace(input)
+	hourText, minuteText, ok := strings.Cut(trimmed, ":")
+	hour, hourErr := strconv.Atoi(hourText)
+	minute, minuteErr := strconv.Atoi(minuteText)
+	if !ok || hourErr != nil || minuteErr != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time %q; use HH:MM", trimmed)
+	}
+	return hour, minute, nil
+}
+
+func formatTimerInterval(d time.Duration) string {
+	text := strings.TrimSuffix(d.String(), "0s")
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// nextClockTime returns the next moment after now that the local clock
+// reads hour:minute.
+func nextClockTime(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// ScheduleEvery runs action every interval on behalf of owner. Timers tied
+// to a room are listed against it.
+func (w *World) ScheduleEvery(owner string, room RoomID, interval time.Duration, label string, action func()) (int, error) {
+	if interval < MinTimerInterval {
+		return 0, fmt.Errorf("interval must be at least %s", formatTimerInterval(MinTimerInterval))
+	}
+	return w.schedule(&scheduledTimer{owner: owner, room: room, interval: interval, label: label, action: action})
+}
+
+// ScheduleAt runs action daily when the server clock reads hour:minute.
+func (w *World) ScheduleAt(owner string, room RoomID, hour, minute int, label string, action func()) (int, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time %02d:%02d", hour, minute)
+	}
+	return w.schedule(&scheduledTimer{owner: owner, room: room, hour: hour, minute: minute, label: label, action: action})
+}
+
+func (w *World) schedule(t *scheduledTimer) (int, error) {
+	if w.timers == nil || t.action == nil {
+		return 0, fmt.Errorf("timers are unavailable")
+	}
+	s := w.timers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, existing := range s.timers {
+		if existing.owner == t.owner {
+			count++
+		}
+	}
+	if count >= MaxTimersPerOwner {
+		return 0, fmt.Errorf("%w: %s already has %d timers", ErrTimerQuota, t.owner, count)
+	}
+	s.nextID++
+	t.id = s.nextID
+	t.next = t.following(time.Now())
+	id := t.id
+	t.timer = time.AfterFunc(time.Until(t.next), func() {
+		w.fireTimer(id)
+	})
+	s.timers[id] = t
+	return id, nil
+}
+
+// fireTimer runs a timer's action and schedules its next run.
+func (w *World) fireTimer(id int) {
+	s := w.timers
+	s.mu.Lock()
+	t, ok := s.timers[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	t.next = t.following(time.Now())
+	t.timer.Reset(time.Until(t.next))
+	action := t.action
+	s.mu.Unlock()
+	action()
+}
+
+// CancelTimer stops the timer with id.
+func (w *World) CancelTimer(id int) bool {
+	if w.timers == nil {
+		return false
+	}
+	s := w.timers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.timers[id]
+	if !ok {
+		return false
+	}
+	t.timer.Stop()
+	delete(s.timers, id)
+	return true
+}
+
+// CancelTimers stops every timer belonging to owner and returns how many
+// were cancelled.
+func (w *World) CancelTimers(owner string) int {
+	if w.timers == nil {
+		return 0
+	}
+	s := w.timers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cancelled := 0
+	for id, t := range s.timers {
+		if t.owner != owner {
+			continue
+		}
+		t.timer.Stop()
+		delete(s.timers, id)
+		cancelled++
+	}
+	return cancelled
+}
+
+// cancelScriptTimers stops every timer scheduled by a script, leaving those
+// builders set up by hand.
+func (w *World) cancelScriptTimers() int {
+	if w.timers == nil {
+		return 0
+	}
+	s := w.timers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cancelled := 0
+	for id, t := range s.timers {
+		if strings.HasPrefix(t.owner, builderTimerPrefix) {
+			continue
+		}
+		t.timer.Stop()
+		delete(s.timers, id)
+		cancelled++
+	}
+	return cancelled
+}
+
+// Timers lists scheduled timers ordered by id.
+func (w *World) Timers() []TimerInfo {
+	if w.timers == nil {
+		return nil
+	}
+	s := w.timers
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := make([]TimerInfo, 0, len(s.timers))
+	for _, t := range s.timers {
+		infos = append(infos, TimerInfo{
+			ID:       t.id,
+			Owner:    t.owner,
+			Room:     t.room,
+			Schedule: t.schedule(),
+			Label:    t.label,
+			Next:     t.next,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// addTimerHelpers lets a script schedule its own repeating callbacks. All
+// timers created by the script share owner's quota.
+func (e *scriptEngine) addTimerHelpers(payload map[string]any, w *World, owner string, room RoomID) {
+	if w == nil || w.timers == nil {
+		return
+	}
+	wrap := func(fn func()) func() {
+		return func() {
+			e.invoke(owner, "timer", fn)
+		}
+	}
+	payload["every"] = func(interval string, fn func()) bool {
+		d, err := ParseTimerInterval(interval)
+		if err != nil || fn == nil {
+			return false
+		}
+		_, err = w.ScheduleEvery(owner, room, d, "script", wrap(fn))
+		return err == nil
+	}
+	payload["at"] = func(clock string, fn func()) bool {
+		hour, minute, err := ParseTimerClock(clock)
+		if err != nil || fn == nil {
+			return false
+		}
+		_, err = w.ScheduleAt(owner, room, hour, minute, "script", wrap(fn))
+		return err == nil
+	}
+	payload["cancel_timers"] = func() int {
+		return w.CancelTimers(owner)
+	}
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimerQuotaAndCancel(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom}})
+	fired := 0
+	for i := 0; i < MaxTimersPerOwner; i++ {
+		if _, err := world.ScheduleEvery("room:start", StartRoom, time.Hour, "tick", func() { fired++ }); err != nil {
+			t.Fatalf("ScheduleEvery returned error: %v", err)
+		}
+	}
+	if _, err := world.ScheduleEvery("room:start", StartRoom, time.Hour, "tick", func() {}); !errors.Is(err, ErrTimerQuota) {
+		t.Fatalf("expected quota error, got %v", err)
+	}
+	if _, err := world.ScheduleEvery("room:other", StartRoom, time.Second, "tick", func() {}); err == nil {
+		t.Fatalf("expected intervals under the minimum to be rejected")
+	}
+
+	timers := world.Timers()
+	if len(timers) != MaxTimersPerOwner {
+		t.Fatalf("expected %d timers, got %d", MaxTimersPerOwner, len(timers))
+	}
+	if timers[0].Schedule != "every 1h" {
+		t.Fatalf("unexpected schedule description %q", timers[0].Schedule)
+	}
+	before := timers[0].Next
+	world.fireTimer(timers[0].ID)
+	if fired != 1 {
+		t.Fatalf("expected timer action to run once, got %d", fired)
+	}
+	if next := world.Timers()[0].Next; next.Before(before) {
+		t.Fatalf("expected timer to be rescheduled, got %v after %v", next, before)
+	}
+
+	if !world.CancelTimer(timers[0].ID) {
+		t.Fatalf("expected CancelTimer to succeed")
+	}
+	if got := world.CancelTimers("room:start"); got != MaxTimersPerOwner-1 {
+		t.Fatalf("expected remaining timers cancelled, got %d", got)
+	}
+	if len(world.Timers()) != 0 {
+		t.Fatalf("expected no timers left, got %+v", world.Timers())
+	}
+}
+
+func TestNextClockTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 21, 30, 0, 0, time.UTC)
+	if got := nextClockTime(now, 20, 0); !got.Equal(time.Date(2024, 5, 2, 20, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected tomorrow evening, got %v", got)
+	}
+	if got := nextClockTime(now, 22, 15); !got.Equal(time.Date(2024, 5, 1, 22, 15, 0, 0, time.UTC)) {
+		t.Fatalf("expected later today, got %v", got)
+	}
+	if _, _, err := ParseTimerClock("25:00"); err == nil {
+		t.Fatalf("expected invalid clock time to be rejected")
+	}
+}
+
+func TestScriptSchedulesTimer(t *testing.T) {
+	roomScript := `package main
+
+func OnLook(ctx map[string]any) {
+    broadcast := ctx["broadcast"].(func(string))
+    ctx["every"].(func(string, func()) bool)("5m", func() {
+        broadcast("The chimes ring the hour.")
+    })
+}`
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Chime Hall", Script: roomScript}})
+	player := &Player{Name: "Listener", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+
+	for i := 0; i < MaxTimersPerOwner+2; i++ {
+		world.TriggerRoomLook(player)
+	}
+	timers := world.Timers()
+	if len(timers) != MaxTimersPerOwner {
+		t.Fatalf("expected script timers capped at %d, got %d", MaxTimersPerOwner, len(timers))
+	}
+	if timers[0].Owner != "room:start" || timers[0].Schedule != "every 5m" {
+		t.Fatalf("unexpected timer %+v", timers[0])
+	}
+	world.fireTimer(timers[0].ID)
+	outputs := stripAnsi(strings.Join(drainOutput(player.Output), "\n"))
+	if !strings.Contains(outputs, "The chimes ring the hour.") {
+		t.Fatalf("expected timer callback to broadcast, got %q", outputs)
+	}
+	world.CancelTimers("room:start")
+}
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// TrackRange is how many rooms away track can find its quarry.
+	TrackRange = 12
+	// ScentDuration is how long a scent lingers in a room after someone
+	// leaves it.
+	ScentDuration = 15 * time.Minute
+	// maxScentsPerRoom caps the trails remembered in one room.
+	maxScentsPerRoom = 10
+	// TrackSkill is the race or class skill needed to track and read scents.
+	TrackSkill = "track"
+)
+
+var (
+	// ErrCannotTrack indicates the player's race and class do not teach
+	// tracking.
+	ErrCannotTrack = errors.New("you don't know how to track")
+	// ErrNoTrail indicates no path or scent leads to the quarry.
+	ErrNoTrail = errors.New("you can't find any trace of them")
+)
+
+// ScentTrail is the scent someone left in a room on their way out.
+type ScentTrail struct {
+	Name      string
+	Direction string
+	Left      time.Time
+}
+
+// Strength describes how much of the scent is left at now.
+func (s ScentTrail) Strength(now time.Time) string {
+	age := now.Sub(s.Left)
+	switch {
+	case age < ScentDuration/3:
+		return "fresh"
+	case age < 2*ScentDuration/3:
+		return "lingering"
+	default:
+		return "faint"
+	}
+}
+
+// TrackResult describes where a tracker's quarry went.
+type TrackResult struct {
+	// Target is the quarry's full name.
+	Target string
+	// Here is set when the quarry is in the tracker's room.
+	Here bool
+	// Direction is the exit to take next.
+	Direction string
+	// Distance is how many rooms away the quarry is, or zero when only
+	// a scent was found.
+	Distance int
+	// Scent holds the trail followed when the quarry was out of range.
+	Scent *ScentTrail
+}
+
+// leaveScentLocked records that name left room through dir. A newer trail
+// replaces the same person's older one.
+func (w *World) leaveScentLocked(room RoomID, name, dir string, now time.Time) {
+	if w.scents == nil {
+		w.scents = make(map[RoomID][]ScentTrail)
+	}
+	trails := w.liveScentsLocked(room, now)
+	kept := trails[:0]
+	for _, trail := range trails {
+		if !strings.EqualFold(trail.Name, name) {
+			kept = append(kept, trail)
+		}
+	}
+	kept = append(kept, ScentTrail{Name: name, Direction: dir, Left: now})
+	if excess := len(kept) - maxScentsPerRoom; excess > 0 {
+		kept = append([]ScentTrail(nil), kept[excess:]...)
+	}
+	w.scents[room] = kept
+}
+
+// liveScentsLocked drops the room's expired trails and returns the rest,
+// oldest first.
+func (w *World) liveScentsLocked(room RoomID, now time.Time) []ScentTrail {
+	trails := w.scents[room]
+	live := trails[:0]
+	for _, trail := range trails {
+		if now.Sub(trail.Left) < ScentDuration {
+			live = append(live, trail)
+		}
+	}
+	if len(live) == 0 {
+		delete(w.scents, room)
+		return nil
+	}
+	w.scents[room] = live
+	return live
+}
+
+// Scents lists the trails p can smell in their room, freshest first. Their
+// own scent is left out.
+func (w *World) Scents(p *Player) ([]ScentTrail, error) {
+	if !w.KnowsSkill(p, TrackSkill) {
+		return nil, ErrCannotTrack
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out []ScentTrail
+	for _, trail := range w.liveScentsLocked(p.Room, time.Now()) {
+		if !strings.EqualFold(trail.Name, p.Name) {
+			out = append(out, trail)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Left.After(out[j].Left) })
+	return out, nil
+}
+
+// Track finds the nearest player or NPC matching name within TrackRange
+// rooms and reports which exit leads toward them. When nobody matching is
+// in range, a scent they left in p's room still points the way.
+func (w *World) Track(p *Player, name string) (TrackResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return TrackResult{}, ErrNoTrail
+	}
+	if !w.KnowsSkill(p, TrackSkill) {
+		return TrackResult{}, ErrCannotTrack
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	start, ok := w.rooms[p.Room]
+	if !ok {
+		return TrackResult{}, ErrNoTrail
+	}
+	occupants := make(map[RoomID][]string)
+	for _, other := range w.players {
+		if other != p && other.Alive {
+			occupants[other.Room] = append(occupants[other.Room], other.Name)
+		}
+	}
+	type step struct {
+		room  RoomID
+		first string
+		depth int
+	}
+	visited := map[RoomID]bool{start.ID: true}
+	queue := []step{{room: start.ID}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		room := w.rooms[current.room]
+		names := append([]string(nil), occupants[current.room]...)
+		for _, npc := range room.NPCs {
+			names = append(names, npc.Name)
+		}
+		if idx, ok := uniqueMatch(name, names, true); ok {
+			return TrackResult{Target: names[idx], Here: current.depth == 0, Direction: current.first, Distance: current.depth}, nil
+		}
+		if current.depth >= TrackRange {
+			continue
+		}
+		dirs := make([]string, 0, len(room.Exits))
+		for dir, exit := range room.Exits {
+			if exitVisibleLocked(p, current.room, dir, exit) {
+				dirs = append(dirs, dir)
+			}
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			dest := room.Exits[dir].To
+			if visited[dest] {
+				continue
+			}
+			if _, ok := w.rooms[dest]; !ok {
+				continue
+			}
+			visited[dest] = true
+			first := current.first
+			if first == "" {
+				first = dir
+			}
+			queue = append(queue, step{room: dest, first: first, depth: current.depth + 1})
+		}
+	}
+	var trails []ScentTrail
+	for _, trail := range w.liveScentsLocked(p.Room, time.Now()) {
+		if !strings.EqualFold(trail.Name, p.Name) {
+			trails = append(trails, trail)
+		}
+	}
+	names := make([]string, len(trails))
+	for i, trail := range trails {
+		names[i] = trail.Name
+	}
+	if idx, ok := uniqueMatch(name, names, true); ok {
+		trail := trails[idx]
+		return TrackResult{Target: trail.Name, Direction: trail.Direction, Scent: &trail}, nil
+	}
+	return TrackResult{}, ErrNoTrail
+}
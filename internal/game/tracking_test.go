@@ -0,0 +1,81 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrackFindsNextDirection(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"camp":   {ID: "camp", Exits: map[string]Exit{"north": {To: "trail"}, "east": {To: "creek"}}},
+		"trail":  {ID: "trail", Exits: map[string]Exit{"south": {To: "camp"}, "north": {To: "ridge"}}},
+		"ridge":  {ID: "ridge", Exits: map[string]Exit{"south": {To: "trail"}}, NPCs: []NPC{{Name: "Grey Wolf"}}},
+		"creek":  {ID: "creek", Exits: map[string]Exit{"west": {To: "camp"}, "hidden": {To: "burrow", ExitRule: ExitRule{Hidden: true}}}},
+		"burrow": {ID: "burrow", Exits: map[string]Exit{"out": {To: "creek"}}, NPCs: []NPC{{Name: "Badger"}}},
+	})
+	world.characterOptions = CharacterOptions{Classes: []Archetype{
+		{ID: "ranger", Name: "Ranger", Skills: []string{TrackSkill}},
+		{ID: "mage", Name: "Mage", Skills: []string{"bolt"}},
+	}}
+	p := &Player{Name: "Scout", Class: "ranger", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	result, err := world.Track(p, "wolf")
+	if err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	if result.Target != "Grey Wolf" || result.Direction != "north" || result.Distance != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if _, err := world.Track(p, "badger"); !errors.Is(err, ErrNoTrail) {
+		t.Fatalf("expected hidden exits to hide the trail, got %v", err)
+	}
+
+	p.Class = "mage"
+	if _, err := world.Track(p, "wolf"); !errors.Is(err, ErrCannotTrack) {
+		t.Fatalf("expected mages to be unable to track, got %v", err)
+	}
+}
+
+func TestScentsLingerAndDecay(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"camp":   {ID: "camp", Exits: map[string]Exit{"north": {To: "trail"}, "east": {To: "creek"}}},
+		"trail":  {ID: "trail", Exits: map[string]Exit{"south": {To: "camp"}, "north": {To: "ridge"}}},
+		"ridge":  {ID: "ridge", Exits: map[string]Exit{"south": {To: "trail"}}, NPCs: []NPC{{Name: "Grey Wolf"}}},
+		"creek":  {ID: "creek", Exits: map[string]Exit{"west": {To: "camp"}, "hidden": {To: "burrow", ExitRule: ExitRule{Hidden: true}}}},
+		"burrow": {ID: "burrow", Exits: map[string]Exit{"out": {To: "creek"}}, NPCs: []NPC{{Name: "Badger"}}},
+	})
+	world.characterOptions = CharacterOptions{Classes: []Archetype{
+		{ID: "ranger", Name: "Ranger", Skills: []string{TrackSkill}},
+		{ID: "mage", Name: "Mage", Skills: []string{"bolt"}},
+	}}
+	p := &Player{Name: "Scout", Class: "ranger", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(p)
+	quarry := &Player{Name: "Fox", Room: "camp", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(quarry)
+	if _, err := world.Move(quarry, "east"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	world.mu.Lock()
+	quarry.Room = "nowhere"
+	world.mu.Unlock()
+
+	trails, err := world.Scents(p)
+	if err != nil || len(trails) != 1 || trails[0].Name != "Fox" || trails[0].Direction != "east" {
+		t.Fatalf("unexpected scents %+v, %v", trails, err)
+	}
+	if got := trails[0].Strength(time.Now()); got != "fresh" {
+		t.Fatalf("strength = %s, want fresh", got)
+	}
+	result, err := world.Track(p, "fox")
+	if err != nil || result.Scent == nil || result.Direction != "east" {
+		t.Fatalf("expected the scent to point east, got %+v, %v", result, err)
+	}
+
+	world.mu.Lock()
+	world.scents["camp"][0].Left = time.Now().Add(-ScentDuration)
+	world.mu.Unlock()
+	if trails, _ := world.Scents(p); len(trails) != 0 {
+		t.Fatalf("expected the scent to fade, got %+v", trails)
+	}
+}
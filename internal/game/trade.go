@@ -0,0 +1,374 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotTrading indicates the player has no open trade.
+	ErrNotTrading = errors.New("you are not trading with anyone")
+	// ErrTradePartnerGone indicates the other side of a trade left the room
+	// or the game.
+	ErrTradePartnerGone = errors.New("your trading partner is no longer here")
+)
+
+// trade is a two-party exchange. Offers point at items rather than holding
+// them, so nothing leaves either inventory until both sides confirm and the
+// swap happens at once.
+type trade struct {
+	offers [2]*tradeOffer
+	open   bool
+}
+
+type tradeOffer struct {
+	player    *Player
+	items     []tradeItem
+	gold      int
+	confirmed bool
+}
+
+// tradeItem is one offered item: where it sat in the inventory and a
+// fingerprint of its rarity and affixes, so the swap hands over the item
+// that was shown rather than another with the same name.
+type tradeItem struct {
+	index       int
+	name        string
+	fingerprint string
+}
+
+// itemFingerprint identifies an item by name, rarity, and affixes. Items
+// with the same fingerprint are interchangeable in a trade.
+func itemFingerprint(item Item) string {
+	ids := make([]string, len(item.Affixes))
+	for i, affix := range item.Affixes {
+		ids[i] = affix.ID
+	}
+	return strings.ToLower(item.Name) + "|" + string(item.Rarity) + "|" + strings.Join(ids, ",")
+}
+
+func (o *tradeOffer) names() []string {
+	names := make([]string, len(o.items))
+	for i, item := range o.items {
+		names[i] = item.name
+	}
+	return names
+}
+
+// offered reports whether the inventory item at index is already on the
+// table.
+func (o *tradeOffer) offered(index int) bool {
+	for _, item := range o.items {
+		if item.index == index {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *trade) sides(p *Player) (mine, theirs *tradeOffer) {
+	if t.offers[0].player == p {
+		return t.offers[0], t.offers[1]
+	}
+	return t.offers[1], t.offers[0]
+}
+
+// TradeTerms is what one side of a trade puts up.
+type TradeTerms struct {
+	Items     []string
+	Gold      int
+	Confirmed bool
+}
+
+// TradeView describes an open trade from one player's side.
+type TradeView struct {
+	Partner *Player
+	Mine    TradeTerms
+	Theirs  TradeTerms
+}
+
+func (o *tradeOffer) terms() TradeTerms {
+	return TradeTerms{Items: o.names(), Gold: o.gold, Confirmed: o.confirmed}
+}
+
+func (t *trade) view(p *Player) TradeView {
+	mine, theirs := t.sides(p)
+	return TradeView{Partner: theirs.player, Mine: mine.terms(), Theirs: theirs.terms()}
+}
+
+// openTradeLocked returns p's open trade, checking the partner is still in
+// the room.
+func (w *World) openTradeLocked(p *Player) (*trade, error) {
+	t := p.trade
+	if t == nil || !t.open {
+		return nil, ErrNotTrading
+	}
+	_, theirs := t.sides(p)
+	partner := theirs.player
+	if stored, ok := w.players[partner.Name]; !ok || stored != partner || !partner.Alive || partner.Room != p.Room {
+		return nil, ErrTradePartnerGone
+	}
+	return t, nil
+}
+
+// unconfirm clears both confirmations so any change to the terms must be
+// agreed again.
+func (t *trade) unconfirm() {
+	t.offers[0].confirmed = false
+	t.offers[1].confirmed = false
+}
+
+// cancelTradeLocked drops p's trade, or their pending invitation.
+func (w *World) cancelTradeLocked(p *Player) *Player {
+	t := p.trade
+	if t == nil {
+		return nil
+	}
+	_, theirs := t.sides(p)
+	partner := theirs.player
+	if partner.trade == t {
+		partner.trade = nil
+	}
+	p.trade = nil
+	if !t.open {
+		return nil
+	}
+	return partner
+}
+
+// RequestTrade invites target to trade, or opens the trade when target has
+// already invited p. It returns the other player and whether the trade is
+// now open.
+func (w *World) RequestTrade(p *Player, target string) (*Player, bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	other, ok := w.findPlayerLocked(target)
+	if !ok || other.Room != p.Room {
+		return nil, false, fmt.Errorf("you don't see %s here", strings.TrimSpace(target))
+	}
+	if other == p {
+		return nil, false, fmt.Errorf("you can't trade with yourself")
+	}
+	if p.trade != nil && p.trade.open {
+		_, theirs := p.trade.sides(p)
+		return nil, false, fmt.Errorf("you are already trading with %s", theirs.player.Name)
+	}
+	if invite := other.trade; invite != nil && !invite.open && invite.offers[1].player == p {
+		invite.open = true
+		p.trade = invite
+		return other, true, nil
+	}
+	if other.trade != nil && other.trade.open {
+		return nil, false, fmt.Errorf("%s is busy with another trade", other.Name)
+	}
+	p.trade = &trade{offers: [2]*tradeOffer{{player: p}, {player: other}}}
+	return other, false, nil
+}
+
+// TradeStatus returns p's open trade.
+func (w *World) TradeStatus(p *Player) (TradeView, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	t, err := w.openTradeLocked(p)
+	if err != nil {
+		return TradeView{}, err
+	}
+	return t.view(p), nil
+}
+
+// OfferTradeItem adds a carried item to p's side of the trade.
+func (w *World) OfferTradeItem(p *Player, name string) (Item, TradeView, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	t, err := w.openTradeLocked(p)
+	if err != nil {
+		return Item{}, TradeView{}, err
+	}
+	mine, _ := t.sides(p)
+	idx := findItemIndex(p.Inventory, name)
+	if idx == -1 {
+		return Item{}, TradeView{}, ErrItemNotCarried
+	}
+	name = p.Inventory[idx].Name
+	idx = -1
+	for i, held := range p.Inventory {
+		if strings.EqualFold(held.Name, name) && !mine.offered(i) {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return Item{}, TradeView{}, fmt.Errorf("you have already offered every %s you carry", name)
+	}
+	item := p.Inventory[idx]
+	mine.items = append(mine.items, tradeItem{index: idx, name: item.Name, fingerprint: itemFingerprint(item)})
+	t.unconfirm()
+	return item, t.view(p), nil
+}
+
+// OfferTradeGold sets how much gold p puts up.
+func (w *World) OfferTradeGold(p *Player, amount int) (TradeView, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	t, err := w.openTradeLocked(p)
+	if err != nil {
+		return TradeView{}, err
+	}
+	if amount < 0 {
+		return TradeView{}, fmt.Errorf("you can't offer negative gold")
+	}
+	if amount > p.Gold {
+		return TradeView{}, fmt.Errorf("you only have %d gold", p.Gold)
+	}
+	mine, _ := t.sides(p)
+	mine.gold = amount
+	t.unconfirm()
+	return t.view(p), nil
+}
+
+// WithdrawTradeItem takes an item back off p's side of the trade.
+func (w *World) WithdrawTradeItem(p *Player, name string) (string, TradeView, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	t, err := w.openTradeLocked(p)
+	if err != nil {
+		return "", TradeView{}, err
+	}
+	mine, _ := t.sides(p)
+	idx, ok := uniqueMatch(name, mine.names(), true)
+	if !ok {
+		return "", TradeView{}, fmt.Errorf("you haven't offered that")
+	}
+	removed := mine.items[idx].name
+	mine.items = append(mine.items[:idx], mine.items[idx+1:]...)
+	t.unconfirm()
+	return removed, t.view(p), nil
+}
+
+// CancelTrade ends p's trade or withdraws their invitation. It returns the
+// partner of an open trade so they can be told.
+func (w *World) CancelTrade(p *Player) (*Player, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if p.trade == nil {
+		return nil, ErrNotTrading
+	}
+	return w.cancelTradeLocked(p), nil
+}
+
+// ConfirmTrade records p's agreement to the current terms. Once both sides
+// agree every offered item and coin changes hands at once; the returned
+// boolean reports whether that happened. The exchange is written to the
+// moderation log so staff can settle disputes.
+func (w *World) ConfirmTrade(p *Player) (TradeView, bool, error) {
+	w.mu.Lock()
+	t, err := w.openTradeLocked(p)
+	if err != nil {
+		w.mu.Unlock()
+		return TradeView{}, false, err
+	}
+	mine, theirs := t.sides(p)
+	if len(mine.items) == 0 && mine.gold == 0 && len(theirs.items) == 0 && theirs.gold == 0 {
+		w.mu.Unlock()
+		return TradeView{}, false, fmt.Errorf("nothing has been offered yet")
+	}
+	mine.confirmed = true
+	view := t.view(p)
+	if !theirs.confirmed {
+		w.mu.Unlock()
+		return view, false, nil
+	}
+	if err := w.exchangeLocked(mine, theirs); err != nil {
+		t.unconfirm()
+		w.mu.Unlock()
+		return TradeView{}, false, err
+	}
+	p.trade = nil
+	theirs.player.trade = nil
+	key, snapshot := profileSnapshot(p)
+	partnerKey, partnerSnapshot := profileSnapshot(theirs.player)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	w.persistPlayerState(partnerKey, partnerSnapshot)
+	if err := w.Moderation().Record(ModerationAction{
+		Actor:  p.Name,
+		Action: "trade",
+		Target: theirs.player.Name,
+		Detail: fmt.Sprintf("gave %s; received %s", describeTradeTerms(view.Mine), describeTradeTerms(view.Theirs)),
+	}); err != nil {
+		Logger().Error("record trade failed", "player", p.Name, "partner", theirs.player.Name, "error", err)
+	}
+	return view, true, nil
+}
+
+// exchangeLocked swaps the two offers, or changes nothing and reports why
+// the swap cannot happen.
+func (w *World) exchangeLocked(a, b *tradeOffer) error {
+	aKeep, aGive, err := takeOffered(a)
+	if err != nil {
+		return err
+	}
+	bKeep, bGive, err := takeOffered(b)
+	if err != nil {
+		return err
+	}
+	if itemsWeight(aKeep)+itemsWeight(bGive) > a.player.CarryCapacity() {
+		return fmt.Errorf("%s can't carry that much weight", a.player.Name)
+	}
+	if itemsWeight(bKeep)+itemsWeight(aGive) > b.player.CarryCapacity() {
+		return fmt.Errorf("%s can't carry that much weight", b.player.Name)
+	}
+	a.player.Inventory = append(aKeep, bGive...)
+	b.player.Inventory = append(bKeep, aGive...)
+	a.player.Gold += b.gold - a.gold
+	b.player.Gold += a.gold - b.gold
+	return nil
+}
+
+// takeOffered splits an offer's items out of the player's inventory without
+// changing it. Each offered item must still match the rarity and affixes it
+// had when offered; if the inventory has shifted since, an identical item
+// elsewhere will do.
+func takeOffered(o *tradeOffer) (keep, give []Item, err error) {
+	if o.gold > o.player.Gold {
+		return nil, nil, fmt.Errorf("%s no longer has %d gold", o.player.Name, o.gold)
+	}
+	inventory := o.player.Inventory
+	taken := make([]bool, len(inventory))
+	for _, offered := range o.items {
+		idx := -1
+		if offered.index < len(inventory) && !taken[offered.index] && itemFingerprint(inventory[offered.index]) == offered.fingerprint {
+			idx = offered.index
+		} else {
+			for i, item := range inventory {
+				if !taken[i] && itemFingerprint(item) == offered.fingerprint {
+					idx = i
+					break
+				}
+			}
+		}
+		if idx == -1 {
+			return nil, nil, fmt.Errorf("%s no longer has %s", o.player.Name, offered.name)
+		}
+		taken[idx] = true
+		give = append(give, inventory[idx])
+	}
+	for i, item := range inventory {
+		if !taken[i] {
+			keep = append(keep, item)
+		}
+	}
+	return keep, give, nil
+}
+
+func describeTradeTerms(terms TradeTerms) string {
+	parts := append([]string(nil), terms.Items...)
+	if terms.Gold > 0 {
+		parts = append(parts, fmt.Sprintf("%d gold", terms.Gold))
+	}
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTradeSwapsOffersOnceBothAccept(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{}},
+		"yard":    {ID: "yard", Exits: map[string]Exit{}},
+	})
+	ilya := &Player{Name: "Ilya", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Gold: 30,
+		Inventory: []Item{{Name: "Healing Draught"}, {Name: "Healing Draught"}, {Name: "Star Chart"}}}
+	brom := &Player{Name: "Brom", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Gold: 5,
+		Inventory: []Item{{Name: "Brass Key"}}}
+	world.AddPlayerForTest(ilya)
+	world.AddPlayerForTest(brom)
+
+	if _, err := world.OfferTradeGold(ilya, 10); !errors.Is(err, ErrNotTrading) {
+		t.Fatalf("offering before a trade err = %v", err)
+	}
+	if _, opened, err := world.RequestTrade(ilya, "brom"); err != nil || opened {
+		t.Fatalf("invite opened=%v err=%v", opened, err)
+	}
+	if _, opened, err := world.RequestTrade(brom, "ilya"); err != nil || !opened {
+		t.Fatalf("accepting invite opened=%v err=%v", opened, err)
+	}
+
+	if _, _, err := world.OfferTradeItem(ilya, "draught"); err != nil {
+		t.Fatalf("offer draught: %v", err)
+	}
+	if _, _, err := world.OfferTradeItem(ilya, "draught"); err != nil {
+		t.Fatalf("offer second draught: %v", err)
+	}
+	if _, _, err := world.OfferTradeItem(ilya, "draught"); err == nil {
+		t.Fatalf("offering more draughts than carried should fail")
+	}
+	if _, err := world.OfferTradeGold(ilya, 50); err == nil {
+		t.Fatalf("offering more gold than carried should fail")
+	}
+	if _, err := world.OfferTradeGold(ilya, 10); err != nil {
+		t.Fatalf("offer gold: %v", err)
+	}
+	if _, _, err := world.OfferTradeItem(brom, "key"); err != nil {
+		t.Fatalf("offer key: %v", err)
+	}
+
+	if _, done, err := world.ConfirmTrade(ilya); err != nil || done {
+		t.Fatalf("first accept done=%v err=%v", done, err)
+	}
+	if _, _, err := world.WithdrawTradeItem(ilya, "draught"); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if _, done, err := world.ConfirmTrade(brom); err != nil || done {
+		t.Fatalf("changing the terms should clear earlier accepts, done=%v err=%v", done, err)
+	}
+	view, done, err := world.ConfirmTrade(ilya)
+	if err != nil || !done {
+		t.Fatalf("final accept done=%v err=%v", done, err)
+	}
+	if strings.Join(view.Mine.Items, ",") != "Healing Draught" || view.Mine.Gold != 10 {
+		t.Fatalf("completed trade view = %+v", view)
+	}
+
+	if ilya.Gold != 20 || brom.Gold != 15 {
+		t.Fatalf("gold after trade = %d/%d", ilya.Gold, brom.Gold)
+	}
+	if len(ilya.Inventory) != 3 || ilya.Inventory[2].Name != "Brass Key" {
+		t.Fatalf("ilya inventory = %+v", ilya.Inventory)
+	}
+	if len(brom.Inventory) != 1 || brom.Inventory[0].Name != "Healing Draught" {
+		t.Fatalf("brom inventory = %+v", brom.Inventory)
+	}
+	if _, err := world.TradeStatus(ilya); !errors.Is(err, ErrNotTrading) {
+		t.Fatalf("the trade should close after the swap, err = %v", err)
+	}
+	actions := world.Moderation().Actions(1)
+	if len(actions) != 1 || actions[0].Action != "trade" || actions[0].Detail != "gave Healing Draught, 10 gold; received Brass Key" {
+		t.Fatalf("trade log = %+v", actions)
+	}
+}
+
+func TestTradeFailsWithoutChangingAnything(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{}},
+		"yard":    {ID: "yard", Exits: map[string]Exit{}},
+	})
+	ilya := &Player{Name: "Ilya", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Gold: 30,
+		Inventory: []Item{{Name: "Healing Draught"}, {Name: "Healing Draught"}, {Name: "Star Chart"}}}
+	brom := &Player{Name: "Brom", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Gold: 5,
+		Inventory: []Item{{Name: "Brass Key"}}}
+	world.AddPlayerForTest(ilya)
+	world.AddPlayerForTest(brom)
+	world.RequestTrade(ilya, "brom")
+	world.RequestTrade(brom, "ilya")
+	world.OfferTradeItem(ilya, "chart")
+	world.ConfirmTrade(ilya)
+
+	ilya.Inventory = ilya.Inventory[:2]
+	if _, done, err := world.ConfirmTrade(brom); err == nil || done {
+		t.Fatalf("trading away a dropped item should fail, done=%v", done)
+	}
+	if len(brom.Inventory) != 1 || len(ilya.Inventory) != 2 {
+		t.Fatalf("a failed trade moved items: %+v / %+v", ilya.Inventory, brom.Inventory)
+	}
+
+	brom.Room = "yard"
+	if _, err := world.TradeStatus(ilya); !errors.Is(err, ErrTradePartnerGone) {
+		t.Fatalf("status with partner gone err = %v", err)
+	}
+	if partner, err := world.CancelTrade(ilya); err != nil || partner != brom || brom.trade != nil {
+		t.Fatalf("cancel partner=%v err=%v", partner, err)
+	}
+}
+
+func TestTradeHandsOverTheItemThatWasOffered(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Exits: map[string]Exit{}}})
+	rare := Item{Name: "Iron Sword", Base: "Iron Sword", Rarity: Rare, Affixes: []Affix{{ID: "keen", Name: "Keen", Damage: 2}}}
+	plain := Item{Name: "Iron Sword"}
+	ilya := &Player{Name: "Ilya", Room: StartRoom, Output: NewOutputQueue(), Alive: true,
+		Inventory: []Item{rare, plain}}
+	brom := &Player{Name: "Brom", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Gold: 50}
+	world.AddPlayerForTest(ilya)
+	world.AddPlayerForTest(brom)
+	world.RequestTrade(ilya, "brom")
+	world.RequestTrade(brom, "ilya")
+	if item, _, err := world.OfferTradeItem(ilya, "sword"); err != nil || item.Rarity != Rare {
+		t.Fatalf("offered %+v err=%v, want the rare sword", item, err)
+	}
+	world.OfferTradeGold(brom, 50)
+	world.ConfirmTrade(ilya)
+
+	ilya.Inventory = []Item{plain, rare}
+	if _, done, err := world.ConfirmTrade(brom); err != nil || !done {
+		t.Fatalf("accept done=%v err=%v", done, err)
+	}
+	if len(brom.Inventory) != 1 || brom.Inventory[0].Rarity != Rare {
+		t.Fatalf("brom received %+v, want the rare sword", brom.Inventory)
+	}
+
+	world.RequestTrade(ilya, "brom")
+	world.RequestTrade(brom, "ilya")
+	world.OfferTradeItem(brom, "sword")
+	world.ConfirmTrade(brom)
+	brom.Inventory = []Item{plain}
+	if _, done, err := world.ConfirmTrade(ilya); err == nil || done {
+		t.Fatalf("swapping in a plainer sword should void the trade, done=%v", done)
+	}
+	if len(ilya.Inventory) != 1 || len(brom.Inventory) != 1 || brom.Inventory[0].Rarity != "" {
+		t.Fatalf("a failed trade moved items: %+v / %+v", ilya.Inventory, brom.Inventory)
+	}
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TutorialStep names the task a player is working on in the new player
+// tutorial. Characters created before the tutorial existed have no step and
+// are never prompted.
+type TutorialStep string
+
+const (
+	TutorialMove  TutorialStep = "move"
+	TutorialLook  TutorialStep = "look"
+	TutorialGet   TutorialStep = "get"
+	TutorialTalk  TutorialStep = "talk"
+	TutorialQuest TutorialStep = "quest"
+	// TutorialDone marks a tutorial that was finished or skipped.
+	TutorialDone TutorialStep = "done"
+)
+
+type tutorialTask struct {
+	Step TutorialStep
+	Task string
+}
+
+var tutorialSteps = []tutorialTask{
+	{TutorialMove, "Walk somewhere new with go <exit>, or a short direction such as n or e."},
+	{TutorialLook, "Type look to study your surroundings again."},
+	{TutorialGet, "Pick something up with get <item>."},
+	{TutorialTalk, "Find someone and speak to them with say <message>."},
+	{TutorialQuest, "Ask around for work: quests available lists offers, and quests accept <id> takes one."},
+}
+
+// TutorialActive reports whether step is one of the guided tasks.
+func TutorialActive(step TutorialStep) bool {
+	_, ok := tutorialIndex(step)
+	return ok
+}
+
+func tutorialIndex(step TutorialStep) (int, bool) {
+	for i, task := range tutorialSteps {
+		if task.Step == step {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// TutorialProgress describes where a player is in the tutorial.
+type TutorialProgress struct {
+	Step   TutorialStep
+	Number int
+	Total  int
+	Task   string
+	Hint   string
+}
+
+// TutorialStatus reports the player's current tutorial task along with a
+// hint based on where they are standing.
+func (w *World) TutorialStatus(p *Player) TutorialProgress {
+	w.mu.RLock()
+	step := p.Tutorial
+	w.mu.RUnlock()
+	progress := TutorialProgress{Step: step, Total: len(tutorialSteps)}
+	index, ok := tutorialIndex(step)
+	if !ok {
+		return progress
+	}
+	progress.Number = index + 1
+	progress.Task = tutorialSteps[index].Task
+	progress.Hint = w.tutorialHint(p, step)
+	return progress
+}
+
+// SetTutorial moves the player to step, used by tutorial skip and restart.
+func (w *World) SetTutorial(p *Player, step TutorialStep) {
+	w.mu.Lock()
+	p.Tutorial = step
+	p.tutorialHint = ""
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+}
+
+// AdvanceTutorial completes step when it is the player's current task and
+// shows them the next one. Steps done out of order are ignored.
+func (w *World) AdvanceTutorial(p *Player, step TutorialStep) {
+	w.mu.Lock()
+	index, ok := tutorialIndex(p.Tutorial)
+	if !ok || p.Tutorial != step {
+		w.mu.Unlock()
+		return
+	}
+	next := TutorialDone
+	if index+1 < len(tutorialSteps) {
+		next = tutorialSteps[index+1].Step
+	}
+	p.Tutorial = next
+	p.tutorialHint = ""
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+
+	if next == TutorialDone {
+		w.sendTutorial(p, "Tutorial complete! You know the basics; type help newbie whenever you need a refresher.")
+		return
+	}
+	w.sendTutorial(p, "Well done!")
+	w.ShowTutorialHint(p)
+}
+
+// ShowTutorialHint reminds the player of their current task with a hint
+// about their surroundings. Repeating the same hint is suppressed so moving
+// between similar rooms does not spam the player.
+func (w *World) ShowTutorialHint(p *Player) {
+	progress := w.TutorialStatus(p)
+	if progress.Task == "" {
+		return
+	}
+	msg := progress.Task
+	if progress.Hint != "" {
+		msg += " " + progress.Hint
+	}
+	w.mu.Lock()
+	if p.tutorialHint == msg {
+		w.mu.Unlock()
+		return
+	}
+	p.tutorialHint = msg
+	w.mu.Unlock()
+	w.sendTutorial(p, msg)
+}
+
+func (w *World) sendTutorial(p *Player, msg string) {
+	p.Output.Send(Ansi(fmt.Sprintf("\r\n%s %s", Style("[Tutorial]", AnsiMagenta, AnsiBold), msg)))
+}
+
+func (w *World) tutorialHint(p *Player, step TutorialStep) string {
+	room, ok := w.GetRoom(p.Room)
+	if !ok {
+		return ""
+	}
+	switch step {
+	case TutorialMove:
+		if exits := strings.Fields(w.ExitsFor(p, room)); len(exits) > 0 && exits[0] != "none" {
+			return fmt.Sprintf("Exits here: %s.", strings.Join(exits, ", "))
+		}
+	case TutorialGet:
+		if items := w.RoomItems(p.Room); len(items) > 0 {
+			return fmt.Sprintf("Try get %s.", items[0].Name)
+		}
+		return "Nothing is lying around here; explore until a room lists something on the ground."
+	case TutorialTalk:
+		if npcs := w.RoomNPCs(p.Room); len(npcs) > 0 {
+			return fmt.Sprintf("%s is here; try say hello.", npcs[0].Name)
+		}
+		return "No one is here to listen; keep exploring."
+	case TutorialQuest:
+		if quests := w.AvailableQuests(p); len(quests) > 0 {
+			return fmt.Sprintf("%s offers %s; type quests accept %s.", quests[0].Giver, quests[0].Name, quests[0].ID)
+		}
+		return "No one here has work for you; look for a quest giver elsewhere."
+	}
+	return ""
+}
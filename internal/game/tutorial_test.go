@@ -0,0 +1,74 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAdvanceTutorialFollowsSteps(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"start": {ID: "start", Title: "Start", Exits: map[string]Exit{"north": {To: "start"}}, Items: []Item{{Name: "lantern"}}},
+	})
+	p := &Player{Name: "Newbie", Room: "start", Output: NewOutputQueue(), Alive: true, Tutorial: TutorialMove}
+	world.AddPlayerForTest(p)
+
+	world.AdvanceTutorial(p, TutorialLook)
+	if p.Tutorial != TutorialMove {
+		t.Fatalf("out of order step advanced the tutorial to %q", p.Tutorial)
+	}
+	world.AdvanceTutorial(p, TutorialMove)
+	world.AdvanceTutorial(p, TutorialLook)
+	if p.Tutorial != TutorialGet {
+		t.Fatalf("Tutorial = %q, want get", p.Tutorial)
+	}
+	got := stripAnsi(strings.Join(drainOutput(p.Output), "\n"))
+	if !strings.Contains(got, "Well done!") || !strings.Contains(got, "Try get lantern.") {
+		t.Fatalf("expected progress and a contextual hint, got %q", got)
+	}
+
+	world.ShowTutorialHint(p)
+	if extra := drainOutput(p.Output); len(extra) != 0 {
+		t.Fatalf("repeated hint should be suppressed, got %v", extra)
+	}
+
+	world.AdvanceTutorial(p, TutorialGet)
+	world.AdvanceTutorial(p, TutorialTalk)
+	world.AdvanceTutorial(p, TutorialQuest)
+	if p.Tutorial != TutorialDone {
+		t.Fatalf("Tutorial = %q, want done", p.Tutorial)
+	}
+	if got := stripAnsi(strings.Join(drainOutput(p.Output), "\n")); !strings.Contains(got, "Tutorial complete!") {
+		t.Fatalf("expected completion message, got %q", got)
+	}
+	world.ShowTutorialHint(p)
+	if extra := drainOutput(p.Output); len(extra) != 0 {
+		t.Fatalf("finished tutorial should not show hints, got %v", extra)
+	}
+}
+
+func TestNewCharactersStartTheTutorial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	accounts, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("NewAccountManager error: %v", err)
+	}
+	if err := accounts.Register("Alice", "password"); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	profile := accounts.Profile("Alice")
+	if profile.Tutorial != TutorialMove {
+		t.Fatalf("new profile Tutorial = %q, want move", profile.Tutorial)
+	}
+	profile.Tutorial = TutorialDone
+	if err := accounts.SaveProfile("Alice", profile); err != nil {
+		t.Fatalf("SaveProfile error: %v", err)
+	}
+	reloaded, err := NewAccountManager(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if got := reloaded.Profile("Alice").Tutorial; got != TutorialDone {
+		t.Fatalf("reloaded Tutorial = %q, want done", got)
+	}
+}
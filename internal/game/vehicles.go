@@ -0,0 +1,341 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	vehicleTick = time.Second
+	// defaultVehicleWait and defaultVehicleTravel apply when an area leaves
+	// a vehicle's timings unset.
+	defaultVehicleWait   = 60 * time.Second
+	defaultVehicleTravel = 60 * time.Second
+)
+
+var (
+	// ErrNoVehicle indicates nothing is docked in the player's room.
+	ErrNoVehicle = errors.New("there is nothing here to board")
+	// ErrNotAboard indicates the player is not inside a vehicle.
+	ErrNotAboard = errors.New("you are not aboard anything")
+	// ErrVehicleUnderway indicates the vehicle is between stops.
+	ErrVehicleUnderway = errors.New("you can't get off until it docks")
+)
+
+// Vehicle is a ferry, caravan, or other transport declared in an area file.
+// Its rooms travel together along Stops, waiting at each before moving on to
+// the next, and the route loops back to the first stop.
+type Vehicle struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Rooms are the vehicle's own rooms. Passengers board into the first.
+	Rooms []RoomID      `json:"rooms"`
+	Stops []VehicleStop `json:"stops"`
+	// Travel is how many seconds the trip between two stops takes.
+	Travel int `json:"travel,omitempty"`
+}
+
+// VehicleStop is one place a vehicle docks along its route.
+type VehicleStop struct {
+	Room RoomID `json:"room"`
+	// Wait is how many seconds the vehicle stays docked.
+	Wait int `json:"wait,omitempty"`
+}
+
+// vehicle tracks where a Vehicle is on its route. stop is the stop it is
+// docked at, or heading for while underway.
+type vehicle struct {
+	Vehicle
+	stop   int
+	docked bool
+	next   time.Time
+}
+
+func (v *vehicle) wait() time.Duration {
+	if seconds := v.Stops[v.stop].Wait; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultVehicleWait
+}
+
+func (v *vehicle) travel() time.Duration {
+	if v.Travel > 0 {
+		return time.Duration(v.Travel) * time.Second
+	}
+	return defaultVehicleTravel
+}
+
+func (v *vehicle) carries(room RoomID) bool {
+	for _, id := range v.Rooms {
+		if id == room {
+			return true
+		}
+	}
+	return false
+}
+
+// validateVehicle checks that a vehicle's rooms and stops exist and do not
+// overlap.
+func validateVehicle(config Vehicle, rooms map[RoomID]*Room) error {
+	if strings.TrimSpace(config.ID) == "" {
+		return fmt.Errorf("vehicle without an id")
+	}
+	if strings.TrimSpace(config.Name) == "" {
+		return fmt.Errorf("vehicle %s has no name", config.ID)
+	}
+	if len(config.Rooms) == 0 {
+		return fmt.Errorf("vehicle %s has no rooms", config.ID)
+	}
+	if len(config.Stops) < 2 {
+		return fmt.Errorf("vehicle %s needs at least two stops", config.ID)
+	}
+	aboard := make(map[RoomID]bool, len(config.Rooms))
+	for _, id := range config.Rooms {
+		if _, ok := rooms[id]; !ok {
+			return fmt.Errorf("vehicle %s uses unknown room %s", config.ID, id)
+		}
+		aboard[id] = true
+	}
+	for _, stop := range config.Stops {
+		if _, ok := rooms[stop.Room]; !ok {
+			return fmt.Errorf("vehicle %s stops at unknown room %s", config.ID, stop.Room)
+		}
+		if aboard[stop.Room] {
+			return fmt.Errorf("vehicle %s stops at one of its own rooms", config.ID)
+		}
+	}
+	return nil
+}
+
+// buildVehicles creates the vehicles declared across the loaded areas, each
+// docked at its first stop.
+func buildVehicles(areas map[string]areaMetadata, rooms map[RoomID]*Room, now time.Time) (map[string]*vehicle, error) {
+	vehicles := make(map[string]*vehicle)
+	owner := make(map[RoomID]string)
+	for source, meta := range areas {
+		for _, config := range meta.Vehicles {
+			if err := validateVehicle(config, rooms); err != nil {
+				return nil, fmt.Errorf("area %s: %w", source, err)
+			}
+			key := strings.ToLower(config.ID)
+			if _, exists := vehicles[key]; exists {
+				return nil, fmt.Errorf("duplicate vehicle id %s", config.ID)
+			}
+			for _, id := range config.Rooms {
+				if other, taken := owner[id]; taken {
+					return nil, fmt.Errorf("room %s belongs to vehicles %s and %s", id, other, config.ID)
+				}
+				owner[id] = config.ID
+			}
+			v := &vehicle{Vehicle: config, docked: true}
+			v.next = now.Add(v.wait())
+			vehicles[key] = v
+		}
+	}
+	return vehicles, nil
+}
+
+// keepVehiclePositions carries each surviving vehicle's place on its route
+// over from before a reload.
+func keepVehiclePositions(previous, next map[string]*vehicle) {
+	for key, v := range next {
+		old, ok := previous[key]
+		if !ok || old.stop >= len(v.Stops) {
+			continue
+		}
+		v.stop, v.docked, v.next = old.stop, old.docked, old.next
+	}
+}
+
+// AddVehicleForTest registers a vehicle docked at its first stop.
+func (w *World) AddVehicleForTest(config Vehicle) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := validateVehicle(config, w.rooms); err != nil {
+		return err
+	}
+	if w.vehicles == nil {
+		w.vehicles = make(map[string]*vehicle)
+	}
+	v := &vehicle{Vehicle: config, docked: true}
+	v.next = time.Now().Add(v.wait())
+	w.vehicles[strings.ToLower(config.ID)] = v
+	return nil
+}
+
+func (w *World) sortedVehiclesLocked() []*vehicle {
+	keys := make([]string, 0, len(w.vehicles))
+	for key := range w.vehicles {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	vehicles := make([]*vehicle, len(keys))
+	for i, key := range keys {
+		vehicles[i] = w.vehicles[key]
+	}
+	return vehicles
+}
+
+// vehicleAboardLocked returns the vehicle whose rooms include room.
+func (w *World) vehicleAboardLocked(room RoomID) *vehicle {
+	for _, v := range w.sortedVehiclesLocked() {
+		if v.carries(room) {
+			return v
+		}
+	}
+	return nil
+}
+
+// AdvanceVehicles moves every vehicle that is due along its route: docked
+// vehicles cast off for their next stop and travelling ones arrive.
+// Passengers and anyone at the stop hear about it.
+func (w *World) AdvanceVehicles(now time.Time) {
+	type notice struct {
+		rooms []RoomID
+		msg   string
+	}
+	var notices []notice
+	w.mu.Lock()
+	for _, v := range w.sortedVehiclesLocked() {
+		if now.Before(v.next) {
+			continue
+		}
+		name := Style(v.Name, AnsiCyan)
+		stop := v.Stops[v.stop].Room
+		if v.docked {
+			v.docked = false
+			v.stop = (v.stop + 1) % len(v.Stops)
+			v.next = now.Add(v.travel())
+			notices = append(notices,
+				notice{v.Rooms, fmt.Sprintf("The %s casts off, bound for %s.", name, w.roomTitleLocked(v.Stops[v.stop].Room))},
+				notice{[]RoomID{stop}, fmt.Sprintf("The %s casts off and moves away.", name)})
+			continue
+		}
+		v.docked = true
+		v.next = now.Add(v.wait())
+		stop = v.Stops[v.stop].Room
+		notices = append(notices,
+			notice{v.Rooms, fmt.Sprintf("The %s arrives at %s. Type 'disembark' to go ashore.", name, w.roomTitleLocked(stop))},
+			notice{[]RoomID{stop}, fmt.Sprintf("The %s arrives. Type 'board' to go aboard.", name)})
+	}
+	w.mu.Unlock()
+	for _, n := range notices {
+		for _, room := range n.rooms {
+			w.BroadcastToRoom(room, Ansi("\r\n"+n.msg))
+		}
+	}
+}
+
+// StartVehicleLoop keeps vehicles moving on schedule until stop is closed.
+func (w *World) StartVehicleLoop(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(vehicleTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				w.AdvanceVehicles(now)
+			}
+		}
+	}()
+}
+
+// vehicleNotesLocked describes the vehicles docked in a room, or the one the
+// room belongs to.
+func (w *World) vehicleNotesLocked(room RoomID) []string {
+	var notes []string
+	if v := w.vehicleAboardLocked(room); v != nil {
+		name := Style(v.Name, AnsiCyan)
+		if v.docked {
+			notes = append(notes, fmt.Sprintf("The %s is docked at %s.", name, w.roomTitleLocked(v.Stops[v.stop].Room)))
+		} else {
+			notes = append(notes, fmt.Sprintf("The %s is underway to %s.", name, w.roomTitleLocked(v.Stops[v.stop].Room)))
+		}
+	}
+	for _, v := range w.sortedVehiclesLocked() {
+		if v.docked && v.Stops[v.stop].Room == room {
+			notes = append(notes, fmt.Sprintf("The %s is docked here, waiting for passengers.", Style(v.Name, AnsiCyan)))
+		}
+	}
+	return notes
+}
+
+// BoardVehicle moves p aboard a vehicle docked in their room. name picks one
+// when several are docked. It returns the vehicle's name and the room p
+// left.
+func (w *World) BoardVehicle(p *Player, name string) (string, RoomID, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var docked []*vehicle
+	for _, v := range w.sortedVehiclesLocked() {
+		if v.docked && v.Stops[v.stop].Room == p.Room {
+			docked = append(docked, v)
+		}
+	}
+	if len(docked) == 0 {
+		return "", "", ErrNoVehicle
+	}
+	target := docked[0]
+	if name = strings.TrimSpace(name); name != "" {
+		names := make([]string, len(docked))
+		for i, v := range docked {
+			names[i] = v.Name
+		}
+		idx, ok := uniqueMatch(name, names, true)
+		if !ok {
+			return "", "", fmt.Errorf("you don't see %s docked here", name)
+		}
+		target = docked[idx]
+	} else if len(docked) > 1 {
+		return "", "", fmt.Errorf("board which? %s", joinVehicleNames(docked))
+	}
+	deck, ok := w.rooms[target.Rooms[0]]
+	if !ok {
+		return "", "", fmt.Errorf("unknown room: %s", target.Rooms[0])
+	}
+	if err := w.terrainAllowsLocked(p, deck); err != nil {
+		return "", "", err
+	}
+	from := p.Room
+	p.Room = deck.ID
+	w.syncPresenceLocked(p)
+	return target.Name, from, nil
+}
+
+// Disembark moves p from a docked vehicle to its current stop. It returns
+// the vehicle's name and the room p left.
+func (w *World) Disembark(p *Player) (string, RoomID, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v := w.vehicleAboardLocked(p.Room)
+	if v == nil {
+		return "", "", ErrNotAboard
+	}
+	if !v.docked {
+		return "", "", ErrVehicleUnderway
+	}
+	shore, ok := w.rooms[v.Stops[v.stop].Room]
+	if !ok {
+		return "", "", fmt.Errorf("unknown room: %s", v.Stops[v.stop].Room)
+	}
+	if err := w.terrainAllowsLocked(p, shore); err != nil {
+		return "", "", err
+	}
+	from := p.Room
+	p.Room = shore.ID
+	w.syncPresenceLocked(p)
+	return v.Name, from, nil
+}
+
+func joinVehicleNames(vehicles []*vehicle) string {
+	names := make([]string, len(vehicles))
+	for i, v := range vehicles {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFerryCarriesPassengersBetweenStops(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pier":  {ID: "pier", Title: "Pier", Exits: map[string]Exit{}},
+		"isle":  {ID: "isle", Title: "Isle", Exits: map[string]Exit{}},
+		"deck":  {ID: "deck", Title: "Deck", Exits: map[string]Exit{"down": {To: "cabin"}}},
+		"cabin": {ID: "cabin", Title: "Cabin", Exits: map[string]Exit{"up": {To: "deck"}}},
+	})
+	err := world.AddVehicleForTest(Vehicle{
+		ID:     "ferry",
+		Name:   "Ferry",
+		Rooms:  []RoomID{"deck", "cabin"},
+		Stops:  []VehicleStop{{Room: "pier", Wait: 10}, {Room: "isle", Wait: 20}},
+		Travel: 30,
+	})
+	if err != nil {
+		t.Fatalf("AddVehicleForTest: %v", err)
+	}
+	rider := &Player{Name: "Rider", Room: "pier", Output: NewOutputQueue(), Alive: true}
+	islander := &Player{Name: "Islander", Room: "isle", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(rider)
+	world.AddPlayerForTest(islander)
+	if _, _, err := world.Disembark(rider); !errors.Is(err, ErrNotAboard) {
+		t.Fatalf("expected ErrNotAboard, got %v", err)
+	}
+	name, from, err := world.BoardVehicle(rider, "")
+	if err != nil {
+		t.Fatalf("BoardVehicle: %v", err)
+	}
+	if name != "Ferry" || from != "pier" || rider.Room != "deck" {
+		t.Fatalf("boarded %q from %s into %s", name, from, rider.Room)
+	}
+	if _, err := world.Move(rider, "down"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+
+	now := time.Now().Add(time.Hour)
+	world.AdvanceVehicles(now)
+	if _, _, err := world.Disembark(rider); !errors.Is(err, ErrVehicleUnderway) {
+		t.Fatalf("expected ErrVehicleUnderway, got %v", err)
+	}
+	if msgs := stripAnsi(strings.Join(drainOutput(rider.Output), "")); !strings.Contains(msgs, "casts off, bound for Isle") {
+		t.Fatalf("missing departure notice: %q", msgs)
+	}
+	if notes := stripAnsi(strings.Join(world.RoomNotes(rider), "")); !strings.Contains(notes, "underway to Isle") {
+		t.Fatalf("unexpected notes %q", notes)
+	}
+
+	world.AdvanceVehicles(now.Add(10 * time.Second))
+	if msgs := drainOutput(rider.Output); len(msgs) != 0 {
+		t.Fatalf("ferry arrived early: %v", msgs)
+	}
+	world.AdvanceVehicles(now.Add(30 * time.Second))
+	if msgs := stripAnsi(strings.Join(drainOutput(rider.Output), "")); !strings.Contains(msgs, "arrives at Isle") {
+		t.Fatalf("missing arrival notice: %q", msgs)
+	}
+	if msgs := stripAnsi(strings.Join(drainOutput(islander.Output), "")); !strings.Contains(msgs, "Ferry arrives") {
+		t.Fatalf("stop did not hear arrival: %q", msgs)
+	}
+	if _, from, err := world.Disembark(rider); err != nil || from != "cabin" || rider.Room != "isle" {
+		t.Fatalf("Disembark from %s to %s: %v", from, rider.Room, err)
+	}
+}
+
+func TestBoardNeedsDockedVehicle(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"pier":  {ID: "pier", Title: "Pier", Exits: map[string]Exit{}},
+		"isle":  {ID: "isle", Title: "Isle", Exits: map[string]Exit{}},
+		"deck":  {ID: "deck", Title: "Deck", Exits: map[string]Exit{"down": {To: "cabin"}}},
+		"cabin": {ID: "cabin", Title: "Cabin", Exits: map[string]Exit{"up": {To: "deck"}}},
+	})
+	err := world.AddVehicleForTest(Vehicle{
+		ID:     "ferry",
+		Name:   "Ferry",
+		Rooms:  []RoomID{"deck", "cabin"},
+		Stops:  []VehicleStop{{Room: "pier", Wait: 10}, {Room: "isle", Wait: 20}},
+		Travel: 30,
+	})
+	if err != nil {
+		t.Fatalf("AddVehicleForTest: %v", err)
+	}
+	rider := &Player{Name: "Rider", Room: "pier", Output: NewOutputQueue(), Alive: true}
+	islander := &Player{Name: "Islander", Room: "isle", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(rider)
+	world.AddPlayerForTest(islander)
+	if _, _, err := world.BoardVehicle(islander, ""); !errors.Is(err, ErrNoVehicle) {
+		t.Fatalf("expected ErrNoVehicle, got %v", err)
+	}
+	if notes := stripAnsi(strings.Join(world.RoomNotes(rider), "")); !strings.Contains(notes, "Ferry is docked here") {
+		t.Fatalf("unexpected notes %q", notes)
+	}
+}
+
+func TestBuildVehiclesRejectsBadRoutes(t *testing.T) {
+	rooms := map[RoomID]*Room{"pier": {ID: "pier"}, "deck": {ID: "deck"}}
+	areas := map[string]areaMetadata{"harbor.json": {Vehicles: []Vehicle{{
+		ID: "ferry", Name: "Ferry", Rooms: []RoomID{"deck"},
+		Stops: []VehicleStop{{Room: "pier"}, {Room: "nowhere"}},
+	}}}}
+	if _, err := buildVehicles(areas, rooms, time.Now()); err == nil || !strings.Contains(err.Error(), "unknown room nowhere") {
+		t.Fatalf("expected unknown stop error, got %v", err)
+	}
+}
+
+func TestKeepVehiclePositions(t *testing.T) {
+	config := Vehicle{ID: "ferry", Name: "Ferry", Rooms: []RoomID{"deck"}, Stops: []VehicleStop{{Room: "a"}, {Room: "b"}}}
+	old := map[string]*vehicle{"ferry": {Vehicle: config, stop: 1}}
+	fresh := map[string]*vehicle{"ferry": {Vehicle: config, docked: true}}
+	keepVehiclePositions(old, fresh)
+	if fresh["ferry"].stop != 1 || fresh["ferry"].docked {
+		t.Fatalf("position not kept: %+v", fresh["ferry"])
+	}
+}
@@ -0,0 +1,183 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// WaypointCooldown is how long after waypoint travel the next trip is
+	// free again.
+	WaypointCooldown = 10 * time.Minute
+	// WaypointTravelGold is what travelling costs before the cooldown ends.
+	WaypointTravelGold = 50
+)
+
+var (
+	// ErrNoWaypoint indicates the player's room has no waypoint.
+	ErrNoWaypoint = errors.New("there is no waypoint here")
+	// ErrUnknownWaypoint indicates the player is not attuned to a waypoint by
+	// that name.
+	ErrUnknownWaypoint = errors.New("you are not attuned to a waypoint by that name")
+)
+
+// Waypoint is a waypoint a player is attuned to.
+type Waypoint struct {
+	Name string
+	Room RoomID
+	Here bool
+}
+
+// WaypointTrip reports a completed waypoint journey.
+type WaypointTrip struct {
+	Name string
+	From RoomID
+	To   RoomID
+	// Gold is what the trip cost; zero when the cooldown had passed.
+	Gold int
+}
+
+// SetRoomWaypoint places a waypoint with the given name in a room, or
+// removes it when name is empty. Waypoint names must be unique.
+func (w *World) SetRoomWaypoint(id RoomID, name, editor string) error {
+	name = strings.TrimSpace(name)
+	if name != "" {
+		w.mu.RLock()
+		for _, room := range w.rooms {
+			if room.ID != id && strings.EqualFold(room.Waypoint, name) {
+				w.mu.RUnlock()
+				return fmt.Errorf("%s already has a waypoint named %s", room.ID, room.Waypoint)
+			}
+		}
+		w.mu.RUnlock()
+	}
+	return w.editRoom(id, editor, func(room *Room) func() {
+		prev := room.Waypoint
+		room.Waypoint = name
+		return func() { room.Waypoint = prev }
+	})
+}
+
+// AttuneWaypoint binds the waypoint in p's room to their memory. It returns
+// the waypoint's name and whether p was newly attuned.
+func (w *World) AttuneWaypoint(p *Player) (string, bool, error) {
+	w.mu.Lock()
+	room, ok := w.rooms[p.Room]
+	if !ok || room.Waypoint == "" {
+		w.mu.Unlock()
+		return "", false, ErrNoWaypoint
+	}
+	if p.Waypoints[room.ID] {
+		w.mu.Unlock()
+		return room.Waypoint, false, nil
+	}
+	if p.Waypoints == nil {
+		p.Waypoints = make(map[RoomID]bool)
+	}
+	p.Waypoints[room.ID] = true
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return room.Waypoint, true, nil
+}
+
+// attunedWaypointsLocked lists p's waypoints by name, skipping rooms whose
+// waypoint has since been removed.
+func (w *World) attunedWaypointsLocked(p *Player) []Waypoint {
+	waypoints := make([]Waypoint, 0, len(p.Waypoints))
+	for id := range p.Waypoints {
+		room, ok := w.rooms[id]
+		if !ok || room.Waypoint == "" {
+			continue
+		}
+		waypoints = append(waypoints, Waypoint{Name: room.Waypoint, Room: id, Here: id == p.Room})
+	}
+	sort.Slice(waypoints, func(i, j int) bool {
+		return strings.ToLower(waypoints[i].Name) < strings.ToLower(waypoints[j].Name)
+	})
+	return waypoints
+}
+
+// Waypoints lists the waypoints p is attuned to and how long until the next
+// free trip.
+func (w *World) Waypoints(p *Player) ([]Waypoint, time.Duration) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.attunedWaypointsLocked(p), max(0, time.Until(p.waypointReady))
+}
+
+// WaypointFee is what travelling before the waypoints recharge costs, after
+// the gold sink rate.
+func (w *World) WaypointFee() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sinkGoldLocked(WaypointTravelGold)
+}
+
+// TravelToWaypoint carries p to an attuned waypoint. A trip is free once the
+// cooldown since the last one has passed and costs WaypointFee before
+// then.
+func (w *World) TravelToWaypoint(p *Player, name string) (WaypointTrip, error) {
+	w.mu.Lock()
+	stored, ok := w.players[p.Name]
+	if !ok || stored != p || !p.Alive {
+		w.mu.Unlock()
+		return WaypointTrip{}, fmt.Errorf("%s is not online", p.Name)
+	}
+	waypoints := w.attunedWaypointsLocked(p)
+	names := make([]string, len(waypoints))
+	for i, waypoint := range waypoints {
+		names[i] = waypoint.Name
+	}
+	idx, ok := uniqueMatch(name, names, true)
+	if !ok {
+		w.mu.Unlock()
+		return WaypointTrip{}, ErrUnknownWaypoint
+	}
+	waypoint := waypoints[idx]
+	if waypoint.Here {
+		w.mu.Unlock()
+		return WaypointTrip{}, fmt.Errorf("you are already at %s", waypoint.Name)
+	}
+	if err := w.terrainAllowsLocked(p, w.rooms[waypoint.Room]); err != nil {
+		w.mu.Unlock()
+		return WaypointTrip{}, err
+	}
+	trip := WaypointTrip{Name: waypoint.Name, From: p.Room, To: waypoint.Room}
+	now := time.Now()
+	if now.Before(p.waypointReady) {
+		fee := w.sinkGoldLocked(WaypointTravelGold)
+		if p.Gold < fee {
+			wait := p.waypointReady.Sub(now).Round(time.Second)
+			w.mu.Unlock()
+			return WaypointTrip{}, fmt.Errorf("the waypoints need %s to recharge, or %d gold to travel sooner", wait, fee)
+		}
+		p.Gold -= fee
+		w.recordGoldLocked(EconomyWaypoint, -fee)
+		trip.Gold = fee
+	} else {
+		p.waypointReady = now.Add(WaypointCooldown)
+	}
+	p.Room = waypoint.Room
+	w.syncPresenceLocked(p)
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return trip, nil
+}
+
+// waypointNoteLocked describes the waypoint in room, if any, from p's point
+// of view.
+func (w *World) waypointNoteLocked(p *Player, room *Room) string {
+	if room.Waypoint == "" {
+		return ""
+	}
+	name := Style(room.Waypoint, AnsiMagenta)
+	if p.Waypoints[room.ID] {
+		return fmt.Sprintf("The %s waypoint hums here.", name)
+	}
+	return fmt.Sprintf("The %s waypoint hums here. Type 'waypoint attune' to remember it.", name)
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAttuneAndTravelToWaypoints(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Pilgrim", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Waypoint: "Confluence", Exits: map[string]Exit{"east": {To: "grove"}}},
+		"grove":   {ID: "grove", Title: "Grove", Waypoint: "Grove", Exits: map[string]Exit{"west": {To: StartRoom}}},
+		"field":   {ID: "field", Title: "Field", Exits: map[string]Exit{}},
+	})
+	world.AttachAccountManager(accounts)
+	p, err := world.addPlayer("Pilgrim", nil, false, accounts.Profile("Pilgrim"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	if name, fresh, err := world.AttuneWaypoint(p); err != nil || !fresh || name != "Confluence" {
+		t.Fatalf("AttuneWaypoint = %q, %v, %v", name, fresh, err)
+	}
+	if _, fresh, _ := world.AttuneWaypoint(p); fresh {
+		t.Fatalf("attuning twice reported a new waypoint")
+	}
+	if _, err := world.Move(p, "east"); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, _, err := world.AttuneWaypoint(p); err != nil {
+		t.Fatalf("AttuneWaypoint: %v", err)
+	}
+	if saved := accounts.Profile("Pilgrim").Waypoints; !saved[StartRoom] || !saved["grove"] {
+		t.Fatalf("attuned waypoints not saved: %v", saved)
+	}
+	waypoints, wait := world.Waypoints(p)
+	if len(waypoints) != 2 || waypoints[0].Name != "Confluence" || !waypoints[1].Here || wait != 0 {
+		t.Fatalf("Waypoints = %+v, %v", waypoints, wait)
+	}
+
+	if _, err := world.TravelToWaypoint(p, "nowhere"); !errors.Is(err, ErrUnknownWaypoint) {
+		t.Fatalf("expected ErrUnknownWaypoint, got %v", err)
+	}
+	trip, err := world.TravelToWaypoint(p, "conf")
+	if err != nil {
+		t.Fatalf("TravelToWaypoint: %v", err)
+	}
+	if trip.From != "grove" || trip.To != StartRoom || trip.Gold != 0 || p.Room != StartRoom {
+		t.Fatalf("unexpected trip %+v, room %s", trip, p.Room)
+	}
+	if _, wait := world.Waypoints(p); wait <= WaypointCooldown-time.Minute {
+		t.Fatalf("cooldown not started: %v", wait)
+	}
+
+	if _, err := world.TravelToWaypoint(p, "grove"); err == nil {
+		t.Fatalf("expected travel during cooldown without gold to fail")
+	}
+	p.Gold = WaypointTravelGold + 5
+	trip, err = world.TravelToWaypoint(p, "grove")
+	if err != nil {
+		t.Fatalf("TravelToWaypoint: %v", err)
+	}
+	if trip.Gold != WaypointTravelGold || p.Gold != 5 || p.Room != "grove" {
+		t.Fatalf("paid trip %+v left %d gold in %s", trip, p.Gold, p.Room)
+	}
+}
+
+func TestWaypointNeedsAWaypointRoom(t *testing.T) {
+	accounts, err := NewAccountManager(filepath.Join(t.TempDir(), "accounts.json"))
+	if err != nil {
+		t.Fatalf("NewAccountManager: %v", err)
+	}
+	if err := accounts.Register("Pilgrim", "password"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Start", Waypoint: "Confluence", Exits: map[string]Exit{"east": {To: "grove"}}},
+		"grove":   {ID: "grove", Title: "Grove", Waypoint: "Grove", Exits: map[string]Exit{"west": {To: StartRoom}}},
+		"field":   {ID: "field", Title: "Field", Exits: map[string]Exit{}},
+	})
+	world.AttachAccountManager(accounts)
+	p, err := world.addPlayer("Pilgrim", nil, false, accounts.Profile("Pilgrim"))
+	if err != nil {
+		t.Fatalf("addPlayer: %v", err)
+	}
+	p.Room = "field"
+	if _, _, err := world.AttuneWaypoint(p); !errors.Is(err, ErrNoWaypoint) {
+		t.Fatalf("expected ErrNoWaypoint, got %v", err)
+	}
+	if err := world.SetRoomWaypoint("field", "grove", "Builder"); err == nil {
+		t.Fatalf("expected duplicate waypoint name to be refused")
+	}
+	if err := world.SetRoomWaypoint("field", "Meadow", "Builder"); err != nil {
+		t.Fatalf("SetRoomWaypoint: %v", err)
+	}
+	if name, _, err := world.AttuneWaypoint(p); err != nil || name != "Meadow" {
+		t.Fatalf("AttuneWaypoint = %q, %v", name, err)
+	}
+	if err := world.SetRoomWaypoint("field", "", "Builder"); err != nil {
+		t.Fatalf("SetRoomWaypoint clear: %v", err)
+	}
+	if waypoints, _ := world.Waypoints(p); len(waypoints) != 0 {
+		t.Fatalf("removed waypoint still listed: %+v", waypoints)
+	}
+}
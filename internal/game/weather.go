@@ -0,0 +1,333 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultGameHour is how much real time passes for each in-game hour.
+const DefaultGameHour = 2 * time.Minute
+
+// startingHour is the in-game hour a freshly started world wakes to.
+const startingHour = 8
+
+// TimeOfDay names a phase of the in-game day.
+type TimeOfDay string
+
+const (
+	TimeDawn  TimeOfDay = "dawn"
+	TimeDay   TimeOfDay = "day"
+	TimeDusk  TimeOfDay = "dusk"
+	TimeNight TimeOfDay = "night"
+)
+
+// Weather describes the sky over an area.
+type Weather string
+
+const (
+	WeatherClear  Weather = "clear"
+	WeatherCloudy Weather = "cloudy"
+	WeatherRain   Weather = "rain"
+	WeatherStorm  Weather = "storm"
+	WeatherFog    Weather = "fog"
+)
+
+// weatherChangePercent is the chance each hour that an area's weather moves
+// to a neighbouring state.
+const weatherChangePercent = 25
+
+var weatherTransitions = map[Weather][]Weather{
+	WeatherClear:  {WeatherCloudy, WeatherFog},
+	WeatherCloudy: {WeatherClear, WeatherRain, WeatherFog},
+	WeatherRain:   {WeatherCloudy, WeatherStorm},
+	WeatherStorm:  {WeatherRain},
+	WeatherFog:    {WeatherClear, WeatherCloudy},
+}
+
+var phaseMessages = map[TimeOfDay]string{
+	TimeDawn:  "The first light of dawn spills over the horizon.",
+	TimeDay:   "The sun climbs high and the day begins in earnest.",
+	TimeDusk:  "The sun sinks low, painting the sky in embers.",
+	TimeNight: "Night falls and the stars kindle one by one.",
+}
+
+var weatherMessages = map[Weather]string{
+	WeatherClear:  "The sky clears.",
+	WeatherCloudy: "Clouds gather overhead.",
+	WeatherRain:   "A soft rain begins to fall.",
+	WeatherStorm:  "Thunder rolls as a storm breaks overhead.",
+	WeatherFog:    "A thick fog rolls in.",
+}
+
+var weatherAmbience = map[Weather]string{
+	WeatherCloudy: "Clouds hang low overhead.",
+	WeatherRain:   "Rain patters down around you.",
+	WeatherStorm:  "Wind and rain lash the air as thunder rumbles.",
+	WeatherFog:    "Fog blurs everything beyond a few paces.",
+}
+
+var phaseAmbience = map[TimeOfDay]string{
+	TimeDawn:  "Pale dawn light colours the sky.",
+	TimeDay:   "Daylight washes over everything.",
+	TimeDusk:  "Long dusk shadows stretch across the ground.",
+	TimeNight: "Stars glitter in the night sky.",
+}
+
+// phaseForHour maps an hour from 0 to 23 onto a phase of the day.
+func phaseForHour(hour int) TimeOfDay {
+	switch {
+	case hour >= 5 && hour < 7:
+		return TimeDawn
+	case hour >= 7 && hour < 18:
+		return TimeDay
+	case hour >= 18 && hour < 20:
+		return TimeDusk
+	default:
+		return TimeNight
+	}
+}
+
+// Clock reports the in-game hour and phase of the day.
+func (w *World) Clock() (int, TimeOfDay) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.clockHour, phaseForHour(w.clockHour)
+}
+
+// SetClock moves the in-game clock to the given hour without announcing it.
+// Scheduled NPCs quietly take up their places for the new hour.
+func (w *World) SetClock(hour int) {
+	w.mu.Lock()
+	w.clockHour = ((hour % 24) + 24) % 24
+	w.mu.Unlock()
+	w.runSchedules(false)
+}
+
+// WeatherIn reports the weather over the area containing room.
+func (w *World) WeatherIn(room RoomID) Weather {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.weatherLocked(w.roomSources[room])
+}
+
+func (w *World) weatherLocked(area string) Weather {
+	if weather, ok := w.weather[area]; ok {
+		return weather
+	}
+	return WeatherClear
+}
+
+// SetWeather changes the weather over the area containing room and announces
+// it to players outdoors there.
+func (w *World) SetWeather(room RoomID, weather Weather) error {
+	if _, ok := weatherTransitions[weather]; !ok {
+		return fmt.Errorf("unknown weather: %s", weather)
+	}
+	w.mu.Lock()
+	area, ok := w.roomSources[room]
+	if !ok {
+		w.mu.Unlock()
+		return fmt.Errorf("room %s does not belong to an area", room)
+	}
+	previous := w.weatherLocked(area)
+	if w.weather == nil {
+		w.weather = make(map[string]Weather)
+	}
+	w.weather[area] = weather
+	meta := w.areaMeta[area]
+	w.mu.Unlock()
+	if previous != weather {
+		w.announceWeather(area, meta, previous, weather)
+	}
+	return nil
+}
+
+// AdvanceClock moves time forward one in-game hour, rolls for weather
+// changes in each area, announces the results on the ambient channel, and
+// walks scheduled NPCs to their next posts.
+func (w *World) AdvanceClock() {
+	type areaState struct {
+		source   string
+		meta     areaMetadata
+		previous Weather
+		weather  Weather
+	}
+	w.mu.Lock()
+	previousPhase := phaseForHour(w.clockHour)
+	w.clockHour = (w.clockHour + 1) % 24
+	hour := w.clockHour
+	phase := phaseForHour(hour)
+	sources := make([]string, 0, len(w.areaMeta))
+	for source := range w.areaMeta {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	areas := make([]areaState, 0, len(sources))
+	for _, source := range sources {
+		state := areaState{source: source, meta: w.areaMeta[source], previous: w.weatherLocked(source)}
+		state.weather = state.previous
+		if w.roll(100) < weatherChangePercent {
+			options := weatherTransitions[state.previous]
+			state.weather = options[w.roll(len(options))]
+			if w.weather == nil {
+				w.weather = make(map[string]Weather)
+			}
+			w.weather[source] = state.weather
+		}
+		areas = append(areas, state)
+	}
+	w.mu.Unlock()
+
+	if phase != previousPhase {
+		w.broadcastAmbient(Style(phaseMessages[phase], AnsiItalic), func(*Player, *Room) bool { return true })
+	}
+	for _, area := range areas {
+		clock := areaClock{hour: hour, phase: phase, weather: area.weather}
+		if area.weather != area.previous {
+			w.announceWeather(area.source, area.meta, area.previous, area.weather)
+		}
+		w.scripts.callAreaOnTime(w, area.source, area.meta, clock)
+	}
+	w.runSchedules(true)
+}
+
+func (w *World) announceWeather(source string, meta areaMetadata, previous, next Weather) {
+	message := Style(weatherMessages[next], AnsiItalic)
+	w.broadcastAmbient(message, func(p *Player, room *Room) bool {
+		return room.Outdoors && w.roomSources[p.Room] == source
+	})
+	hour, phase := w.Clock()
+	w.scripts.callAreaOnWeather(w, source, meta, areaClock{hour: hour, phase: phase, weather: next, previous: previous})
+}
+
+// broadcastAmbient delivers an atmospheric message to players listening on
+// the ambient channel whose room passes the filter. The filter runs with the
+// world lock held.
+func (w *World) broadcastAmbient(text string, include func(*Player, *Room) bool) {
+	msg := Ansi("\r\n" + text)
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, p := range w.players {
+		if !p.Alive || !p.channelEnabled(ChannelAmbient) {
+			continue
+		}
+		room, ok := w.rooms[p.Room]
+		if !ok || !include(p, room) {
+			continue
+		}
+		w.deliverChannelMessage(p, nil, msg, ChannelAmbient)
+	}
+}
+
+// broadcastToArea sends msg to every player standing in a room loaded from
+// the named area file.
+func (w *World) broadcastToArea(source, msg string) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, p := range w.players {
+		if !p.Alive || w.roomSources[p.Room] != source {
+			continue
+		}
+		p.Output.Send(msg)
+	}
+}
+
+// StartClock advances the in-game clock every hour of real time until stop
+// is closed.
+func (w *World) StartClock(hour time.Duration, stop <-chan struct{}) {
+	if hour <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				w.AdvanceClock()
+			}
+		}
+	}()
+}
+
+// RoomIsDark reports whether an outdoor room is too dark for the player to
+// see: it is night and nobody present carries a light, nor is one lying in
+// the room.
+func (w *World) RoomIsDark(room RoomID) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	r, ok := w.rooms[room]
+	if !ok || !r.Outdoors || phaseForHour(w.clockHour) != TimeNight {
+		return false
+	}
+	if hasLight(r.Items) {
+		return false
+	}
+	for _, p := range w.players {
+		if p.Alive && p.Room == room && hasLight(p.Inventory) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLight(items []Item) bool {
+	for _, item := range items {
+		if item.Light {
+			return true
+		}
+	}
+	return false
+}
+
+// Ambience describes the sky above an outdoor room, or returns an empty
+// string indoors.
+func (w *World) Ambience(room RoomID) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	r, ok := w.rooms[room]
+	if !ok || !r.Outdoors {
+		return ""
+	}
+	lines := []string{phaseAmbience[phaseForHour(w.clockHour)]}
+	if line := weatherAmbience[w.weatherLocked(w.roomSources[room])]; line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
+
+// FormatClock renders the hour as a twelve-hour clock reading.
+func FormatClock(hour int) string {
+	suffix := "am"
+	if hour >= 12 {
+		suffix = "pm"
+	}
+	display := hour % 12
+	if display == 0 {
+		display = 12
+	}
+	return fmt.Sprintf("%d %s", display, suffix)
+}
+
+// DescribeRoom renders a room's description for a terminal of the given
+// width, resolving its template tokens and adding the sky overhead for
+// outdoor rooms. The second return value reports whether the room was too
+// dark to see, in which case the description is replaced and the room's
+// contents should stay hidden.
+func DescribeRoom(world *World, r *Room, width int) (string, bool) {
+	if world.RoomIsDark(r.ID) {
+		return Style(WrapText("It is too dark to see much here. You will need a light.", width), AnsiItalic, AnsiDim), true
+	}
+	desc := Style(WrapText(world.RoomDescription(r), width), AnsiItalic, AnsiDim)
+	if warning := r.Hazard.Warning(); warning != "" {
+		desc += "\r\n" + Style(WrapText(warning, width), AnsiRed)
+	}
+	if ambience := world.Ambience(r.ID); ambience != "" {
+		desc += "\r\n" + Style(WrapText(ambience, width), AnsiDim)
+	}
+	return desc, false
+}
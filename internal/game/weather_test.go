@@ -0,0 +1,142 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPhaseForHour(t *testing.T) {
+	cases := map[int]TimeOfDay{0: TimeNight, 4: TimeNight, 5: TimeDawn, 7: TimeDay, 17: TimeDay, 18: TimeDusk, 20: TimeNight}
+	for hour, want := range cases {
+		if got := phaseForHour(hour); got != want {
+			t.Fatalf("phaseForHour(%d) = %s, want %s", hour, got, want)
+		}
+	}
+	if got := FormatClock(0); got != "12 am" {
+		t.Fatalf("FormatClock(0) = %q", got)
+	}
+	if got := FormatClock(13); got != "1 pm" {
+		t.Fatalf("FormatClock(13) = %q", got)
+	}
+}
+
+func TestAdvanceClockAnnouncesPhaseOnAmbientChannel(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Moonlit Terrace", Outdoors: true}})
+	player := &Player{Name: "Watcher", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	muted := &Player{Name: "Sleeper", Room: StartRoom, Output: NewOutputQueue(), Alive: true,
+		Channels: map[Channel]bool{ChannelAmbient: false}}
+	world.AddPlayerForTest(muted)
+	world.SetClock(4)
+	world.SetDice(DiceFunc(func(n int) int { return n - 1 }))
+
+	world.AdvanceClock()
+
+	if hour, phase := world.Clock(); hour != 5 || phase != TimeDawn {
+		t.Fatalf("clock = %d %s, want 5 dawn", hour, phase)
+	}
+	output := stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "first light of dawn") {
+		t.Fatalf("expected dawn announcement, got %q", output)
+	}
+	if got := drainOutput(muted.Output); len(got) != 0 {
+		t.Fatalf("muted player heard ambient messages: %q", got)
+	}
+}
+
+func TestAdvanceClockRollsWeatherWithWorldDice(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{StartRoom: {ID: StartRoom, Title: "Moonlit Terrace", Outdoors: true}})
+	world.roomSources[StartRoom] = "terrace.json"
+	world.areaMeta["terrace.json"] = areaMetadata{Name: "Terrace"}
+	world.SetDice(&scriptedDice{weatherChangePercent, weatherChangePercent - 1, 1})
+
+	world.AdvanceClock()
+	if got := world.WeatherIn(StartRoom); got != WeatherClear {
+		t.Fatalf("weather = %s after a failed roll, want clear", got)
+	}
+	world.AdvanceClock()
+	if got := world.WeatherIn(StartRoom); got != WeatherFog {
+		t.Fatalf("weather = %s after a successful roll, want fog", got)
+	}
+}
+
+func TestSetWeatherReachesOutdoorPlayersAndScripts(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {ID: StartRoom, Title: "Moonlit Terrace", Outdoors: true},
+		"cellar":  {ID: "cellar", Title: "Cellar"},
+	})
+	world.roomSources[StartRoom] = "terrace.json"
+	world.roomSources["cellar"] = "terrace.json"
+	player := &Player{Name: "Watcher", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	indoors := &Player{Name: "Cook", Room: "cellar", Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	world.AddPlayerForTest(indoors)
+	world.areaMeta["terrace.json"] = areaMetadata{Name: "Terrace", Script: `package main
+
+func OnWeather(ctx map[string]any) {
+    broadcast := ctx["broadcast"].(func(string))
+    broadcast("The chimes turn toward the " + ctx["weather"].(string) + " after the " + ctx["previous"].(string) + " sky.")
+}`}
+
+	if err := world.SetWeather(StartRoom, WeatherStorm); err != nil {
+		t.Fatalf("SetWeather: %v", err)
+	}
+	if got := world.WeatherIn("cellar"); got != WeatherStorm {
+		t.Fatalf("weather = %s, want storm", got)
+	}
+	outside := stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(outside, "storm breaks overhead") {
+		t.Fatalf("expected storm announcement outdoors, got %q", outside)
+	}
+	if !strings.Contains(outside, "toward the storm after the clear sky") {
+		t.Fatalf("expected OnWeather broadcast, got %q", outside)
+	}
+	inside := stripAnsi(strings.Join(drainOutput(indoors.Output), ""))
+	if strings.Contains(inside, "storm breaks overhead") {
+		t.Fatalf("indoor player heard weather change: %q", inside)
+	}
+	if !strings.Contains(inside, "toward the storm") {
+		t.Fatalf("expected area-wide script broadcast indoors, got %q", inside)
+	}
+	if err := world.SetWeather(StartRoom, Weather("hail")); err == nil {
+		t.Fatalf("expected unknown weather to be rejected")
+	}
+}
+
+func TestOutdoorRoomsGoDarkAtNightWithoutLight(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		StartRoom: {
+			ID:          StartRoom,
+			Title:       "Moonlit Terrace",
+			Description: "Glazed tiles catch whatever light the sky allows.",
+			Items:       []Item{{Name: "Clay Bell"}},
+			Outdoors:    true,
+		},
+		"cellar": {ID: "cellar", Title: "Cellar"},
+	})
+	player := &Player{Name: "Watcher", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	world.AddPlayerForTest(player)
+	world.SetClock(23)
+
+	EnterRoom(world, player, "")
+	output := stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "too dark to see") {
+		t.Fatalf("expected darkness, got %q", output)
+	}
+	if strings.Contains(output, "Glazed tiles") || strings.Contains(output, "Clay Bell") {
+		t.Fatalf("darkness should hide the description and items, got %q", output)
+	}
+	if world.RoomIsDark("cellar") {
+		t.Fatalf("indoor rooms should not darken at night")
+	}
+
+	player.Inventory = append(player.Inventory, Item{Name: "Firefly Lantern", Light: true})
+	EnterRoom(world, player, "")
+	output = stripAnsi(strings.Join(drainOutput(player.Output), ""))
+	if !strings.Contains(output, "Glazed tiles") || !strings.Contains(output, "Clay Bell") {
+		t.Fatalf("expected a light to reveal the room, got %q", output)
+	}
+	if !strings.Contains(output, "Stars glitter") {
+		t.Fatalf("expected the night sky in the description, got %q", output)
+	}
+}
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// websocketGUID is the fixed key suffix from RFC 6455 section 1.3.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// websocketMaxMessage caps the size of a message read from a client.
+const websocketMaxMessage = 64 * 1024
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// errWebSocketClosed reports that the peer closed the connection.
+var errWebSocketClosed = errors.New("websocket closed")
+
+// wsConn is a server-side WebSocket connection carrying text messages.
+type wsConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+// websocketAccept computes the Sec-WebSocket-Accept value for key.
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// sameOriginRequest reports whether a browser request came from a page served
+// by the same host. Cookies ride along on cross-site WebSocket handshakes, so
+// the origin must be checked explicitly.
+func sameOriginRequest(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(parsed.Host, r.Host)
+}
+
+// upgradeWebSocket completes the WebSocket handshake for r and takes over the
+// underlying connection. On failure an HTTP error has already been written.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !headerContainsToken(r.Header, "Connection", "upgrade") || !headerContainsToken(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("missing websocket upgrade headers")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusBadRequest)
+		return nil, fmt.Errorf("unsupported websocket version")
+	}
+	key := strings.TrimSpace(r.Header.Get("Sec-WebSocket-Key"))
+	if key == "" {
+		http.Error(w, "missing websocket key", http.StatusBadRequest)
+		return nil, fmt.Errorf("missing websocket key")
+	}
+	if !sameOriginRequest(r) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return nil, fmt.Errorf("cross-origin websocket request")
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket unsupported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("response writer cannot be hijacked")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadMessage returns the next text or binary message, answering pings and
+// reassembling fragments along the way.
+func (c *wsConn) ReadMessage() (string, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return "", err
+		}
+		switch opcode {
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return "", err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			_ = c.writeFrame(wsOpClose, nil)
+			return "", errWebSocketClosed
+		case wsOpText, wsOpBinary, wsOpContinuation:
+			message = append(message, payload...)
+			if len(message) > websocketMaxMessage {
+				return "", fmt.Errorf("websocket message exceeds %d bytes", websocketMaxMessage)
+			}
+			if fin {
+				return string(message), nil
+			}
+		default:
+			return "", fmt.Errorf("unknown websocket opcode %d", opcode)
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if !masked {
+		return false, 0, nil, fmt.Errorf("client websocket frames must be masked")
+	}
+	if length > websocketMaxMessage {
+		return false, 0, nil, fmt.Errorf("websocket frame exceeds %d bytes", websocketMaxMessage)
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// WriteText sends a text message. It is safe to call from several goroutines.
+func (c *wsConn) WriteText(message string) error {
+	return c.writeFrame(wsOpText, []byte(message))
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode}
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+	frame = append(frame, payload...)
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a close frame and closes the connection.
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(wsOpClose, nil)
+	return c.conn.Close()
+}
@@ -0,0 +1,192 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WhoEntry describes one connected player as shown by who.
+type WhoEntry struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title,omitempty"`
+	Level       int      `json:"level"`
+	Class       string   `json:"class,omitempty"`
+	Area        string   `json:"area"`
+	AreaName    string   `json:"area_name"`
+	Roles       []string `json:"roles"`
+	IdleSeconds int64    `json:"idle_seconds"`
+	Linkdead    bool     `json:"linkdead,omitempty"`
+	WizInvis    bool     `json:"wizinvis,omitempty"`
+}
+
+// WhoFilter narrows a who listing. Zero values match everyone.
+type WhoFilter struct {
+	// Role is "builder", "moderator", "admin", or "staff".
+	Role     string
+	Area     string
+	MinLevel int
+	MaxLevel int
+}
+
+// ParseWhoFilter reads the arguments to who: a role such as "builders" or
+// "staff", "area <name>", or "level <min>[-<max>]".
+func ParseWhoFilter(args string) (WhoFilter, error) {
+	var filter WhoFilter
+	fields := strings.Fields(strings.ToLower(args))
+	if len(fields) == 0 {
+		return filter, nil
+	}
+	switch fields[0] {
+	case "builder", "builders":
+		filter.Role = "builder"
+	case "moderator", "moderators", "mods":
+		filter.Role = "moderator"
+	case "admin", "admins":
+		filter.Role = "admin"
+	case "staff", "imms", "immortals":
+		filter.Role = "staff"
+	case "area":
+		if len(fields) < 2 {
+			return filter, fmt.Errorf("name the area to list")
+		}
+		filter.Area = strings.Join(fields[1:], " ")
+		return filter, nil
+	case "level":
+		if len(fields) != 2 {
+			return filter, fmt.Errorf("give a level or range such as 5-10")
+		}
+		low, high, ranged := strings.Cut(fields[1], "-")
+		minLevel, err := strconv.Atoi(low)
+		if err != nil || minLevel < 1 {
+			return filter, fmt.Errorf("%q is not a level", low)
+		}
+		maxLevel := minLevel
+		if ranged {
+			if maxLevel, err = strconv.Atoi(high); err != nil || maxLevel < minLevel {
+				return filter, fmt.Errorf("%q is not a level range", fields[1])
+			}
+		}
+		filter.MinLevel, filter.MaxLevel = minLevel, maxLevel
+		return filter, nil
+	default:
+		return filter, fmt.Errorf("unknown filter %q", fields[0])
+	}
+	if len(fields) > 1 {
+		return filter, fmt.Errorf("role filters take no arguments")
+	}
+	return filter, nil
+}
+
+// WhoList returns the connected players matching filter in login order.
+// Staff who turned on wizinvis are only listed when staffView is set.
+func (w *World) WhoList(staffView bool, filter WhoFilter, now time.Time) []WhoEntry {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	areaNames := make(map[string]string)
+	for _, info := range w.areasLocked() {
+		areaNames[info.Key] = info.Name
+	}
+	entries := make([]WhoEntry, 0, len(w.players))
+	seen := make(map[string]struct{}, len(w.players))
+	collect := func(p *Player) {
+		if p == nil || !p.Alive {
+			return
+		}
+		seen[p.Name] = struct{}{}
+		invisible := p.WizInvis && p.IsStaff()
+		if invisible && !staffView {
+			return
+		}
+		if !filter.matchesRole(p) {
+			return
+		}
+		level, _, _, _, _ := snapshotVitals(p)
+		_, class := w.archetypeNamesLocked(p)
+		if filter.MinLevel > 0 && (level < filter.MinLevel || level > filter.MaxLevel) {
+			return
+		}
+		area := w.roomAreaLocked(p.Room)
+		areaName := areaNames[area]
+		if areaName == "" {
+			areaName = area
+		}
+		if filter.Area != "" && !strings.EqualFold(filter.Area, area) && !strings.EqualFold(filter.Area, areaName) {
+			return
+		}
+		var idle time.Duration
+		if since := p.idleSince(); !since.IsZero() && now.After(since) {
+			idle = now.Sub(since)
+		}
+		entries = append(entries, WhoEntry{
+			Name:        p.Name,
+			Title:       p.Title,
+			Level:       level,
+			Class:       class,
+			Area:        area,
+			AreaName:    areaName,
+			Roles:       playerRoles(p),
+			IdleSeconds: int64(idle / time.Second),
+			Linkdead:    p.linkdead,
+			WizInvis:    invisible,
+		})
+	}
+	for _, name := range w.playerOrder {
+		collect(w.players[name])
+	}
+	if len(seen) != len(w.players) {
+		for _, p := range w.players {
+			if _, ok := seen[p.Name]; !ok {
+				collect(p)
+			}
+		}
+	}
+	return entries
+}
+
+func (f WhoFilter) matchesRole(p *Player) bool {
+	switch f.Role {
+	case "builder":
+		return p.IsBuilder
+	case "moderator":
+		return p.IsModerator
+	case "admin":
+		return p.IsAdmin
+	case "staff":
+		return p.IsStaff()
+	}
+	return true
+}
+
+func playerRoles(p *Player) []string {
+	return playerRolesForSnapshot(PlayerSnapshot{IsAdmin: p.IsAdmin, IsBuilder: p.IsBuilder, IsModerator: p.IsModerator})
+}
+
+// SetWizInvis hides or reveals a staff member in who listings for players.
+func (w *World) SetWizInvis(p *Player, enabled bool) error {
+	w.mu.Lock()
+	if !p.IsStaff() {
+		w.mu.Unlock()
+		return fmt.Errorf("only staff may turn invisible")
+	}
+	p.WizInvis = enabled
+	key, snapshot := profileSnapshot(p)
+	w.mu.Unlock()
+	w.persistPlayerState(key, snapshot)
+	return nil
+}
+
+// FormatIdle renders an idle duration compactly for who listings.
+func FormatIdle(idle time.Duration) string {
+	switch {
+	case idle < time.Minute:
+		return "-"
+	case idle < time.Hour:
+		return fmt.Sprintf("%dm", int(idle/time.Minute))
+	case idle < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(idle/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(idle/(24*time.Hour)))
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseWhoFilter(t *testing.T) {
+	cases := []struct {
+		args string
+		want WhoFilter
+	}{
+		{"", WhoFilter{}},
+		{"builders", WhoFilter{Role: "builder"}},
+		{"Staff", WhoFilter{Role: "staff"}},
+		{"area ember gate", WhoFilter{Area: "ember gate"}},
+		{"level 5-10", WhoFilter{MinLevel: 5, MaxLevel: 10}},
+		{"level 7", WhoFilter{MinLevel: 7, MaxLevel: 7}},
+	}
+	for _, tc := range cases {
+		got, err := ParseWhoFilter(tc.args)
+		if err != nil || got != tc.want {
+			t.Fatalf("ParseWhoFilter(%q) = %+v, %v; want %+v", tc.args, got, err, tc.want)
+		}
+	}
+	for _, bad := range []string{"level", "level 10-5", "level x", "area", "builders now", "wizards"} {
+		if _, err := ParseWhoFilter(bad); err == nil {
+			t.Fatalf("ParseWhoFilter(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWhoListFiltersAndHidesWizInvis(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"gate":  {ID: "gate", Title: "Gate", Area: "gate", Exits: map[string]Exit{}},
+		"annex": {ID: "annex", Title: "Annex", Area: "annex", Exits: map[string]Exit{}},
+	})
+	world.areaMeta = map[string]areaMetadata{"gate.json": {Name: "Ember Gate"}}
+	now := time.Now()
+	hero := &Player{Name: "Hero", Room: "gate", Level: 6, Alive: true, Output: NewOutputQueue(), JoinedAt: now.Add(-time.Hour)}
+	hero.markActive(now.Add(-5 * time.Minute))
+	imm := &Player{Name: "Imm", Room: "annex", Level: 50, IsBuilder: true, WizInvis: true, Alive: true, Output: NewOutputQueue()}
+	newbie := &Player{Name: "Newbie", Room: "annex", Level: 1, Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(hero)
+	world.AddPlayerForTest(imm)
+	world.AddPlayerForTest(newbie)
+
+	names := func(entries []WhoEntry) []string {
+		out := make([]string, len(entries))
+		for i, entry := range entries {
+			out[i] = entry.Name
+		}
+		return out
+	}
+	if got := names(world.WhoList(false, WhoFilter{}, now)); len(got) != 2 || got[0] != "Hero" || got[1] != "Newbie" {
+		t.Fatalf("player view = %v, want wizinvis staff hidden", got)
+	}
+	staff := world.WhoList(true, WhoFilter{Role: "builder"}, now)
+	if len(staff) != 1 || staff[0].Name != "Imm" || !staff[0].WizInvis {
+		t.Fatalf("staff builder view = %+v", staff)
+	}
+	levels := world.WhoList(true, WhoFilter{MinLevel: 5, MaxLevel: 10}, now)
+	if len(levels) != 1 || levels[0].Name != "Hero" || levels[0].IdleSeconds != 300 || levels[0].AreaName != "Ember Gate" {
+		t.Fatalf("level view = %+v", levels)
+	}
+	if got := names(world.WhoList(false, WhoFilter{Area: "ember gate"}, now)); len(got) != 1 || got[0] != "Hero" {
+		t.Fatalf("area view = %v", got)
+	}
+
+	newbie.IsBuilder = false
+	if err := world.SetWizInvis(newbie, true); err == nil {
+		t.Fatalf("players should not be able to turn invisible")
+	}
+}
+
+func TestAPIv1WhoListsPlayers(t *testing.T) {
+	portal, world, secret := newTestAPIPortal(t)
+	world.AddPlayerForTest(&Player{Name: "Hero", Room: "start", Alive: true, Output: NewOutputQueue()})
+	world.AddPlayerForTest(&Player{Name: "Imm", Room: "start", IsAdmin: true, WizInvis: true, Alive: true, Output: NewOutputQueue()})
+
+	rec := doAPIRequest(t, portal.handleAPIv1Who, secret, http.MethodGet, "/api/v1/who?filter=admins", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("who status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var entries []WhoEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "Imm" || !entries[0].WizInvis {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if rec := doAPIRequest(t, portal.handleAPIv1Who, secret, http.MethodGet, "/api/v1/who?filter=level+x", nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad filter status = %d", rec.Code)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,14 +25,56 @@ const builderAreaFile = "builder.json"
 type RoomID string
 
 type Room struct {
-	ID          RoomID            `json:"id"`
-	Title       string            `json:"title"`
-	Description string            `json:"description"`
-	Exits       map[string]RoomID `json:"exits"`
-	NPCs        []NPC             `json:"npcs"`
-	Items       []Item            `json:"items"`
-	Resets      []RoomReset       `json:"resets,omitempty"`
-	Script      string            `json:"script,omitempty"`
+	ID          RoomID          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Exits       map[string]Exit `json:"exits"`
+	NPCs        []NPC           `json:"npcs"`
+	Items       []Item          `json:"items"`
+	Resets      []RoomReset     `json:"resets,omitempty"`
+	Script      string          `json:"script,omitempty"`
+	Outdoors    bool            `json:"outdoors,omitempty"`
+	Market      bool            `json:"market,omitempty"`
+	// Terrain sets the stamina cost of entering the room and whether it
+	// needs swimming or flying.
+	Terrain Terrain `json:"terrain,omitempty"`
+	// Capacity limits how many players fit in the room; zero is no limit.
+	Capacity int `json:"capacity,omitempty"`
+	// Hazard tests players who enter or linger in the room.
+	Hazard Hazard `json:"hazard,omitempty"`
+	// Waypoint names a waypoint players can attune to here and travel back
+	// to from anywhere.
+	Waypoint string `json:"waypoint,omitempty"`
+	// Arena rooms let players duel each other once both agree. Peaceful
+	// rooms forbid all fighting.
+	Arena    bool `json:"arena,omitempty"`
+	Peaceful bool `json:"peaceful,omitempty"`
+	// Protected rooms are watched by guards: attacking another player
+	// there puts a bounty on the attacker.
+	Protected bool `json:"protected,omitempty"`
+	// NoScry rooms hide everyone and everything in them from scrying and
+	// locate magic.
+	NoScry bool `json:"no_scry,omitempty"`
+	// Extras maps keywords to detail text shown by "look <keyword>".
+	Extras map[string]string `json:"extras,omitempty"`
+	// Area keeps the area a room belongs to once builder edits move it into
+	// the builder area, so area grants keep applying to it.
+	Area string `json:"area,omitempty"`
+	// Ambience lists atmospheric lines shown now and then to players in the
+	// room, roughly every AmbienceEvery seconds.
+	Ambience      []string `json:"ambience,omitempty"`
+	AmbienceEvery int      `json:"ambience_every,omitempty"`
+	// Gather lists the fishing, mining, and foraging nodes players can work
+	// in the room.
+	Gather []GatherNode `json:"gather,omitempty"`
+	// Tavern rooms let players gamble with a dealer NPC standing there.
+	Tavern bool `json:"tavern,omitempty"`
+	// ClanHall rooms may be claimed by a clan; only its members may walk
+	// in once it is claimed.
+	ClanHall bool `json:"clan_hall,omitempty"`
+	// CapturePoint rooms may be captured by a clan, earning it clan points
+	// while it holds them.
+	CapturePoint bool `json:"capture_point,omitempty"`
 }
 
 // RoomRevision captures a snapshot of a room's editable fields.
@@ -102,6 +148,35 @@ type NPC struct {
 	Experience int    `json:"experience,omitempty"`
 	Loot       []Item `json:"loot,omitempty"`
 	Script     string `json:"script,omitempty"`
+	Gold       int    `json:"gold,omitempty"`
+	Banker     bool   `json:"banker,omitempty"`
+	Trainer    bool   `json:"trainer,omitempty"`
+	Mount      bool   `json:"mount,omitempty"`
+	Guard      bool   `json:"guard,omitempty"`
+	Dealer     bool   `json:"dealer,omitempty"`
+	// Healer lists the healing services the NPC sells.
+	Healer *HealerServices `json:"healer,omitempty"`
+	// Abilities are what the NPC may do in a fight besides attacking.
+	Abilities []NPCAbility `json:"abilities,omitempty"`
+	// Boss gives the NPC scripted fight phases and shared loot.
+	Boss *BossConfig `json:"boss,omitempty"`
+	// Faction names the faction the NPC belongs to, if any.
+	Faction string `json:"faction,omitempty"`
+	// Shop lists what the NPC sells.
+	Shop []Item `json:"shop,omitempty"`
+	// Rares is a pool of limited wares. RareStock of them (DefaultRareStock
+	// when unset) are stocked, one of each, every restock cycle.
+	Rares     []Item `json:"rares,omitempty"`
+	RareStock int    `json:"rare_stock,omitempty"`
+	// Schedule moves the NPC between rooms as the in-game clock turns.
+	Schedule []ScheduleEntry `json:"schedule,omitempty"`
+	// Home is where a scheduled NPC returns outside its schedule. It
+	// defaults to the room the NPC is defined in.
+	Home RoomID `json:"home,omitempty"`
+	// event is the world event that spawned the NPC, if any.
+	event string
+	// phase counts the boss phases the NPC has begun.
+	phase int
 }
 
 // ResetKind identifies the type of entity governed by a room reset.
@@ -114,12 +189,42 @@ const (
 
 // RoomReset describes how a room repopulates persistent content.
 type RoomReset struct {
-	Kind        ResetKind `json:"kind"`
-	Name        string    `json:"name"`
-	Count       int       `json:"count,omitempty"`
-	AutoGreet   string    `json:"auto_greet,omitempty"`
-	Description string    `json:"description,omitempty"`
-	Script      string    `json:"script,omitempty"`
+	Kind        ResetKind         `json:"kind"`
+	Name        string            `json:"name"`
+	Count       int               `json:"count,omitempty"`
+	AutoGreet   string            `json:"auto_greet,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Script      string            `json:"script,omitempty"`
+	Container   bool              `json:"container,omitempty"`
+	Capacity    int               `json:"capacity,omitempty"`
+	Contents    []Item            `json:"contents,omitempty"`
+	Light       bool              `json:"light,omitempty"`
+	Ranged      bool              `json:"ranged,omitempty"`
+	Damage      int               `json:"damage,omitempty"`
+	Ammo        string            `json:"ammo,omitempty"`
+	Weight      int               `json:"weight,omitempty"`
+	Consumable  *Consumable       `json:"consumable,omitempty"`
+	Swim        bool              `json:"swim,omitempty"`
+	Climb       bool              `json:"climb,omitempty"`
+	Fly         bool              `json:"fly,omitempty"`
+	Banker      bool              `json:"banker,omitempty"`
+	Trainer     bool              `json:"trainer,omitempty"`
+	Mount       bool              `json:"mount,omitempty"`
+	Guard       bool              `json:"guard,omitempty"`
+	Dealer      bool              `json:"dealer,omitempty"`
+	Healer      *HealerServices   `json:"healer,omitempty"`
+	Abilities   []NPCAbility      `json:"abilities,omitempty"`
+	Boss        *BossConfig       `json:"boss,omitempty"`
+	Faction     string            `json:"faction,omitempty"`
+	Shop        []Item            `json:"shop,omitempty"`
+	Rares       []Item            `json:"rares,omitempty"`
+	RareStock   int               `json:"rare_stock,omitempty"`
+	Schedule    []ScheduleEntry   `json:"schedule,omitempty"`
+	Extras      map[string]string `json:"extras,omitempty"`
+	Respawn     int               `json:"respawn,omitempty"`
+	RareSpawn   *NPC              `json:"rare_spawn,omitempty"`
+	RareChance  int               `json:"rare_chance,omitempty"`
+	Writing     *Writing          `json:"writing,omitempty"`
 }
 
 // Item represents an object that can exist in rooms or player inventories.
@@ -127,6 +232,44 @@ type Item struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 	Script      string `json:"script,omitempty"`
+	Container   bool   `json:"container,omitempty"`
+	Capacity    int    `json:"capacity,omitempty"`
+	Corpse      bool   `json:"corpse,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Contents    []Item `json:"contents,omitempty"`
+	Light       bool   `json:"light,omitempty"`
+	Ranged      bool   `json:"ranged,omitempty"`
+	Damage      int    `json:"damage,omitempty"`
+	Ammo        string `json:"ammo,omitempty"`
+	// Weight counts against the carrier's capacity; zero means
+	// DefaultItemWeight.
+	Weight int `json:"weight,omitempty"`
+	// Price is what a shopkeeper charges for the item; zero means
+	// DefaultShopPrice.
+	Price int `json:"price,omitempty"`
+	// Rarity, Affixes, and Base are set when the item drops from an NPC with
+	// rolled affixes. Base keeps the name without them.
+	Rarity  Rarity  `json:"rarity,omitempty"`
+	Affixes []Affix `json:"affixes,omitempty"`
+	Base    string  `json:"base,omitempty"`
+	// Consumable makes the item usable once with quaff, eat, or recite.
+	Consumable *Consumable `json:"consumable,omitempty"`
+	// Swim and Fly let the carrier cross water and open air. Climb gets
+	// them up cliffs.
+	Swim  bool `json:"swim,omitempty"`
+	Climb bool `json:"climb,omitempty"`
+	Fly   bool `json:"fly,omitempty"`
+	// Writing lets players write on the item and read it, page by page.
+	Writing *Writing `json:"writing,omitempty"`
+	// NoRent exempts the item from vault rent.
+	NoRent bool `json:"no_rent,omitempty"`
+	// Extras maps keywords to detail text shown by "look <keyword>".
+	Extras map[string]string `json:"extras,omitempty"`
+	// DecayAt is when a corpse crumbles away; zero means it never does.
+	DecayAt  time.Time `json:"decay_at,omitzero"`
+	corpseID uint64
+	// event is the world event that hid the item, if any.
+	event string
 }
 
 func normalizeNPC(n *NPC) {
@@ -183,6 +326,8 @@ var (
 	ErrItemNotFound = errors.New("item not found")
 	// ErrItemNotCarried indicates the player is not carrying the requested item.
 	ErrItemNotCarried = errors.New("item not carried")
+	// ErrNoExit indicates the player's room has no usable exit that way.
+	ErrNoExit = errors.New("you can't go that way")
 )
 
 type World struct {
@@ -194,18 +339,72 @@ type World struct {
 	areasPath         string
 	accounts          *AccountManager
 	mail              *MailSystem
+	market            *MarketSystem
+	reports           *ReportSystem
+	petitions         *PetitionSystem
+	announcements     *AnnouncementSystem
+	clans             *ClanSystem
 	tells             *TellSystem
 	roomSources       map[RoomID]string
 	roomHistories     map[RoomID]*roomHistory
+	roomDigests       map[RoomID]string
 	builderPath       string
 	forceAllAdmin     bool
 	criticalOpsLocked bool
 	disabledCommands  map[string]bool
 	quests            map[string]*Quest
 	questsByNPC       map[string][]*Quest
+	socials           map[string]*Social
+	achievements      []Achievement
+	characterOptions  CharacterOptions
+	lootTables        LootTables
+	vehicles          map[string]*vehicle
+	instances         map[string]*instance
+	instanceRooms     map[RoomID]*instance
+	nextInstanceID    int
+	nextGroupID       int
+	events            map[string]WorldEvent
+	activeEvents      map[string]*activeEvent
+	eventSchedules    map[string]*eventSchedule
+	factions          map[string]Faction
+	shopStocks        map[string]*shopStock
+	help              map[string]*HelpTopic
 	portal            PortalProvider
+	apiTokens         *APITokenStore
+	bans              *BanList
+	moderation        *ModerationLog
+	audit             *AuditLog
+	chatLog           *ChatLog
+	loginThrottle     *LoginThrottle
+	listener          net.Listener
+	copyoverPath      string
+	snapshotDir       string
+	snapshotKeep      int
+	clockHour         int
+	weather           map[string]Weather
+	bridge            *DiscordBridge
+	intermud          *Intermud
 	scripts           *scriptEngine
 	areaMeta          map[string]areaMetadata
+	roomFlags         map[RoomID]map[string]bool
+	timers            *timerScheduler
+	deathPenalty      *DeathPenalty
+	linkdeadGrace     *time.Duration
+	nextCorpseID      uint64
+	bus               EventBus
+	busOnce           sync.Once
+	tunables          *Tunables
+	configFile        *ConfigFile
+	catalogs          atomic.Pointer[map[string]*MessageCatalog]
+	dice              atomic.Pointer[Dice]
+	presence          roomPresence
+	awayNPCs          []NPC
+	scents            map[RoomID][]ScentTrail
+	respawns          map[string]time.Time
+	ambience          map[RoomID]ambienceState
+	gatherNodes       map[string]nodeState
+	gathering         map[string]gatherAttempt
+	economy           economyLedger
 }
 
 // ActivePlayer returns the currently connected player with the provided name.
@@ -225,8 +424,8 @@ func (w *World) ActivePlayer(name string) (*Player, bool) {
 
 // PrepareTakeover detaches the active session for the provided player so that
 // another connection can assume control. It returns the previous session and
-// output channel so the caller can notify and close them.
-func (w *World) PrepareTakeover(name string) (*TelnetSession, chan string, bool) {
+// output queue so the caller can notify and close them.
+func (w *World) PrepareTakeover(name string) (*TelnetSession, *OutputQueue, bool) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	if w.players == nil {
@@ -239,9 +438,12 @@ func (w *World) PrepareTakeover(name string) (*TelnetSession, chan string, bool)
 
 	oldSession := existing.Session
 	oldOutput := existing.Output
+	existing.linkMu.Lock()
 	existing.Session = nil
-	existing.Output = nil
+	existing.linkMu.Unlock()
 	existing.Alive = false
+	w.syncPresenceLocked(existing)
+	existing.Output = nil
 	w.removePlayerOrderLocked(name)
 
 	return oldSession, oldOutput, true
@@ -286,7 +488,7 @@ func snapshotVitals(p *Player) (level, health, maxHealth, mana, maxMana int) {
 		health = maxHealth
 	}
 	maxMana = p.MaxMana
-	if maxMana < 0 {
+	if maxMana <= 0 {
 		maxMana = 25 + (level-1)*5
 	}
 	mana = p.Mana
@@ -305,20 +507,71 @@ func NewWorld(areasPath string) (*World, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &World{
-		rooms:         rooms,
-		players:       make(map[string]*Player),
-		playerOrder:   make([]string, 0),
-		combats:       make(map[RoomID]*combatInstance),
-		areasPath:     areasPath,
-		roomSources:   sources,
-		areaMeta:      areas,
-		roomHistories: newRoomHistories(rooms),
-		builderPath:   filepath.Join(areasPath, builderAreaFile),
-		quests:        quests,
-		questsByNPC:   indexQuestsByNPC(quests),
-		scripts:       newScriptEngine(),
-	}, nil
+	socials, err := loadSocials(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	help, err := loadHelpTopics(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	achievements, err := loadAchievements(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	characterOptions, err := loadCharacterOptions(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	lootTables, err := loadLootTables(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	events, err := loadWorldEvents(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	factions, err := loadFactions(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	vehicles, err := buildVehicles(areas, rooms, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	catalogs, err := loadMessageCatalogs(areasPath)
+	if err != nil {
+		return nil, err
+	}
+	world := &World{
+		rooms:            rooms,
+		players:          make(map[string]*Player),
+		playerOrder:      make([]string, 0),
+		combats:          make(map[RoomID]*combatInstance),
+		areasPath:        areasPath,
+		roomSources:      sources,
+		areaMeta:         areas,
+		roomHistories:    newRoomHistories(rooms),
+		roomDigests:      roomDigests(rooms),
+		builderPath:      filepath.Join(areasPath, builderAreaFile),
+		quests:           quests,
+		questsByNPC:      indexQuestsByNPC(quests),
+		socials:          socials,
+		achievements:     achievements,
+		characterOptions: characterOptions,
+		lootTables:       lootTables,
+		vehicles:         vehicles,
+		events:           events,
+		factions:         factions,
+		help:             help,
+		scripts:          newScriptEngine(),
+		timers:           newTimerScheduler(),
+		clockHour:        startingHour,
+	}
+	world.catalogs.Store(&catalogs)
+	world.scheduleEventsLocked(time.Now())
+	world.placeScheduledNPCsLocked()
+	return world, nil
 }
 
 // NewWorldWithRooms constructs a world populated with the provided rooms.
@@ -332,7 +585,9 @@ func NewWorldWithRooms(rooms map[RoomID]*Room) *World {
 		roomHistories: newRoomHistories(rooms),
 		quests:        make(map[string]*Quest),
 		scripts:       newScriptEngine(),
+		timers:        newTimerScheduler(),
 		areaMeta:      make(map[string]areaMetadata),
+		clockHour:     startingHour,
 	}
 }
 
@@ -427,6 +682,113 @@ func (w *World) Portal() PortalProvider {
 	return w.portal
 }
 
+// AttachAPITokens connects the REST API token store to the world.
+func (w *World) AttachAPITokens(tokens *APITokenStore) {
+	w.mu.Lock()
+	w.apiTokens = tokens
+	w.mu.Unlock()
+}
+
+// APITokens returns the REST API token store, when configured.
+func (w *World) APITokens() *APITokenStore {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.apiTokens
+}
+
+// AttachBanList connects the persistent ban list to the world.
+func (w *World) AttachBanList(bans *BanList) {
+	w.mu.Lock()
+	w.bans = bans
+	w.mu.Unlock()
+}
+
+// BanList returns the configured ban list, when available.
+func (w *World) BanList() *BanList {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.bans
+}
+
+// AttachLoginThrottle connects the failed-login rate limiter to the world.
+func (w *World) AttachLoginThrottle(throttle *LoginThrottle) {
+	w.mu.Lock()
+	w.loginThrottle = throttle
+	w.mu.Unlock()
+}
+
+// LoginThrottle returns the failed-login rate limiter, when available.
+func (w *World) LoginThrottle() *LoginThrottle {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.loginThrottle
+}
+
+// KickBannedAddresses disconnects every player connected from a banned
+// address and returns their names.
+func (w *World) KickBannedAddresses(reason string) []string {
+	w.mu.RLock()
+	bans := w.bans
+	var targets []string
+	if bans != nil {
+		for _, name := range w.playerOrder {
+			p, ok := w.players[name]
+			if !ok || !p.Alive || p.Session == nil {
+				continue
+			}
+			if _, banned := bans.IPBan(p.Session.RemoteIP()); banned {
+				targets = append(targets, p.Name)
+			}
+		}
+	}
+	w.mu.RUnlock()
+	kicked := make([]string, 0, len(targets))
+	for _, name := range targets {
+		if target, err := w.KickPlayer(name, reason); err == nil {
+			kicked = append(kicked, target.Name)
+		}
+	}
+	return kicked
+}
+
+// AttachBridge connects the Discord channel bridge to the world.
+func (w *World) AttachBridge(bridge *DiscordBridge) {
+	w.mu.Lock()
+	w.bridge = bridge
+	w.mu.Unlock()
+}
+
+// Bridge returns the Discord channel bridge, when configured.
+func (w *World) Bridge() *DiscordBridge {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.bridge
+}
+
+// AttachIntermud connects the intermud gateway to the world.
+func (w *World) AttachIntermud(gateway *Intermud) {
+	w.mu.Lock()
+	w.intermud = gateway
+	w.mu.Unlock()
+}
+
+// Intermud returns the intermud gateway, when configured.
+func (w *World) Intermud() *Intermud {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.intermud
+}
+
+// RelayChannelMessage publishes a player's chat line so subscribers such as
+// the Discord bridge and intermud gateway can forward it.
+func (w *World) RelayChannelMessage(channel Channel, speaker, text string) {
+	ev := GameEvent{Type: EventChatMessage, Speaker: speaker, Channel: channel, Text: text}
+	if p, ok := w.FindPlayer(speaker); ok {
+		ev.Player = p
+	}
+	w.Publish(ev)
+}
+
 // AccountStats exposes account metadata for the provided name.
 func (w *World) AccountStats(name string) (AccountStats, bool) {
 	w.mu.RLock()
@@ -438,6 +800,76 @@ func (w *World) AccountStats(name string) (AccountStats, bool) {
 	return accounts.Stats(name)
 }
 
+// AccountEmail returns the email address bound to the account, if any.
+func (w *World) AccountEmail(name string) string {
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	if accounts == nil {
+		return ""
+	}
+	return accounts.Email(name)
+}
+
+// SetAccountEmail binds or clears the email address for the account.
+func (w *World) SetAccountEmail(name, email string) error {
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	if accounts == nil {
+		return fmt.Errorf("accounts are not available")
+	}
+	return accounts.SetEmail(name, email)
+}
+
+// IssuePasswordReset resolves the account name and issues a single-use reset
+// token for it.
+func (w *World) IssuePasswordReset(name string) (string, string, error) {
+	w.mu.RLock()
+	accounts := w.accounts
+	w.mu.RUnlock()
+	if accounts == nil {
+		return "", "", fmt.Errorf("accounts are not available")
+	}
+	account, ok := accounts.MatchAccountName(name)
+	if !ok {
+		return "", "", fmt.Errorf("no account named %s", strings.TrimSpace(name))
+	}
+	token, err := accounts.IssueResetToken(account, DefaultPasswordResetTTL)
+	if err != nil {
+		return "", "", err
+	}
+	return account, token, nil
+}
+
+// LinkedCharacters resolves a character or account name to its owning
+// account and every character that account owns.
+func (w *World) LinkedCharacters(name string) (string, []string, bool) {
+	name = strings.TrimSpace(name)
+	w.mu.RLock()
+	accounts := w.accounts
+	var account string
+	if p, ok := w.findPlayerLocked(name); ok {
+		account = p.Account
+	}
+	w.mu.RUnlock()
+	if accounts == nil {
+		return "", nil, false
+	}
+	if account == "" {
+		owner, ok := accounts.CharacterOwner(name)
+		if !ok {
+			return "", nil, false
+		}
+		account = owner
+	}
+	characters := accounts.Characters(account)
+	if len(characters) == 0 {
+		return "", nil, false
+	}
+	return account, characters, true
+}
+
 // AddPlayerForTest inserts a player into the world's tracking structures.
 func (w *World) AddPlayerForTest(p *Player) {
 	w.mu.Lock()
@@ -460,26 +892,41 @@ func (w *World) AddPlayerForTest(p *Player) {
 	}
 	now := time.Now()
 	p.JoinedAt = now
+	w.applyArchetypesLocked(p)
 	p.EnsureStats()
 	p.Health = p.MaxHealth
 	p.Mana = p.MaxMana
+	p.Moves = p.MaxMoves
 	if w.forceAllAdmin {
 		p.IsAdmin = true
 	}
+	p.world = w
 	w.players[p.Name] = p
 	w.removePlayerOrderLocked(p.Name)
 	w.playerOrder = append(w.playerOrder, p.Name)
+	w.syncPresenceLocked(p)
 }
 
 type areaFile struct {
 	Name   string `json:"name"`
 	Script string `json:"script,omitempty"`
-	Rooms  []Room `json:"rooms"`
+	// TrustedScripts lets the area's scripts change the world. Builder rooms
+	// are always trusted.
+	TrustedScripts bool   `json:"trusted_scripts,omitempty"`
+	Rooms          []Room `json:"rooms"`
+	// Vehicles are ferries and caravans whose rooms travel between stops.
+	Vehicles []Vehicle `json:"vehicles,omitempty"`
+	// Instanced makes the area a template: each group entering it from
+	// outside gets a private copy.
+	Instanced bool `json:"instanced,omitempty"`
 }
 
 type areaMetadata struct {
-	Name   string
-	Script string
+	Name      string
+	Script    string
+	Trusted   bool
+	Vehicles  []Vehicle
+	Instanced bool
 }
 
 func loadRooms(areasPath string) (map[RoomID]*Room, map[RoomID]string, map[string]areaMetadata, error) {
@@ -502,44 +949,63 @@ func loadRooms(areasPath string) (map[RoomID]*Room, map[RoomID]string, map[strin
 	rooms := make(map[RoomID]*Room)
 	sources := make(map[RoomID]string)
 	areas := make(map[string]areaMetadata)
-	var builderFileName string
 	for _, name := range names {
 		if name == builderAreaFile {
-			builderFileName = name
 			continue
 		}
-		if err := loadAreaFile(areasPath, name, rooms, sources, areas, false); err != nil {
+		data, err := os.ReadFile(filepath.Join(areasPath, name))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("read area %s: %w", name, err)
+		}
+		if err := loadAreaFile(name, data, rooms, sources, areas, false); err != nil {
 			return nil, nil, nil, err
 		}
 	}
-	if builderFileName != "" {
-		if err := loadAreaFile(areasPath, builderFileName, rooms, sources, areas, true); err != nil {
+	// Builder rooms live in the configured storage backend and override the
+	// bundled areas.
+	data, err := documentStorage().Read(filepath.Join(areasPath, builderAreaFile))
+	switch {
+	case err == nil:
+		if err := loadAreaFile(builderAreaFile, data, rooms, sources, areas, true); err != nil {
 			return nil, nil, nil, err
 		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, nil, nil, fmt.Errorf("read area %s: %w", builderAreaFile, err)
 	}
 	if len(rooms) == 0 {
 		return nil, nil, nil, fmt.Errorf("no rooms loaded")
 	}
+	if err := validateSchedules(rooms); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateNPCAbilities(rooms); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateBosses(rooms); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateRespawns(rooms); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateGatherNodes(rooms); err != nil {
+		return nil, nil, nil, err
+	}
 	return rooms, sources, areas, nil
 }
 
-func loadAreaFile(areasPath, name string, rooms map[RoomID]*Room, sources map[RoomID]string, areas map[string]areaMetadata, allowOverride bool) error {
-	data, err := os.ReadFile(filepath.Join(areasPath, name))
-	if err != nil {
-		return fmt.Errorf("read area %s: %w", name, err)
-	}
+func loadAreaFile(name string, data []byte, rooms map[RoomID]*Room, sources map[RoomID]string, areas map[string]areaMetadata, allowOverride bool) error {
 	var file areaFile
 	if err := json.Unmarshal(data, &file); err != nil {
 		return fmt.Errorf("decode area %s: %w", name, err)
 	}
-	areas[name] = areaMetadata{Name: file.Name, Script: strings.TrimSpace(file.Script)}
+	areas[name] = areaMetadata{Name: file.Name, Script: strings.TrimSpace(file.Script), Trusted: file.TrustedScripts, Vehicles: file.Vehicles, Instanced: file.Instanced}
 	for i := range file.Rooms {
 		room := file.Rooms[i]
 		if room.ID == "" {
 			return fmt.Errorf("area %s contains a room without an id", name)
 		}
 		if room.Exits == nil {
-			room.Exits = make(map[string]RoomID)
+			room.Exits = make(map[string]Exit)
 		}
 		if len(room.NPCs) > 0 {
 			for i := range room.NPCs {
@@ -561,6 +1027,9 @@ func (w *World) markRoomAsBuilderLocked(id RoomID) (string, bool) {
 		w.roomSources = make(map[RoomID]string)
 	}
 	prev, existed := w.roomSources[id]
+	if room, ok := w.rooms[id]; ok && room.Area == "" && existed && prev != builderAreaFile {
+		room.Area = AreaKey(prev)
+	}
 	w.roomSources[id] = builderAreaFile
 	return prev, existed
 }
@@ -574,7 +1043,21 @@ func (w *World) recordRoomRevisionLocked(room *Room, editor string) RoomRevision
 		history = &roomHistory{}
 		w.roomHistories[room.ID] = history
 	}
-	return history.append(room, editor)
+	var before string
+	if n := len(history.revisions); n > 0 {
+		before = roomRevisionText(history.revisions[n-1])
+	}
+	rev := history.append(room, editor)
+	if after := roomRevisionText(rev); editor != "" && after != before {
+		w.auditLocked(AuditEntry{Actor: editor, Action: AuditRoomEdit, Target: string(room.ID), Detail: fmt.Sprintf("revision %d", rev.Number), Before: before, After: after})
+	}
+	return rev
+}
+
+// roomRevisionText is a revision's title and description as compared in the
+// audit trail.
+func roomRevisionText(rev RoomRevision) string {
+	return rev.Title + "\n\n" + rev.Description
 }
 
 func (w *World) setExitLocked(roomID RoomID, direction string, target *RoomID) (func(), error) {
@@ -587,10 +1070,10 @@ func (w *World) setExitLocked(roomID RoomID, direction string, target *RoomID) (
 			return nil, fmt.Errorf("unknown room: %s", *target)
 		}
 	}
-	var prevTarget RoomID
+	var prevExit Exit
 	hadExit := false
 	if room.Exits != nil {
-		prevTarget, hadExit = room.Exits[direction]
+		prevExit, hadExit = room.Exits[direction]
 	}
 	if target == nil {
 		if room.Exits != nil {
@@ -598,17 +1081,20 @@ func (w *World) setExitLocked(roomID RoomID, direction string, target *RoomID) (
 		}
 	} else {
 		if room.Exits == nil {
-			room.Exits = make(map[string]RoomID)
+			room.Exits = make(map[string]Exit)
 		}
-		room.Exits[direction] = *target
+		// Re-pointing an exit keeps any door already fitted to it.
+		next := prevExit
+		next.To = *target
+		room.Exits[direction] = next
 	}
 	prevSource, hadSource := w.markRoomAsBuilderLocked(roomID)
 	undo := func() {
 		if hadExit {
 			if room.Exits == nil {
-				room.Exits = make(map[string]RoomID)
+				room.Exits = make(map[string]Exit)
 			}
-			room.Exits[direction] = prevTarget
+			room.Exits[direction] = prevExit
 		} else if room.Exits != nil {
 			delete(room.Exits, direction)
 		}
@@ -627,7 +1113,7 @@ func (w *World) persistBuilderRoomsLocked() error {
 	}
 	rooms := make([]Room, 0, len(w.roomSources))
 	for id, source := range w.roomSources {
-		if source != builderAreaFile {
+		if source != builderAreaFile || w.instanceRooms[id] != nil {
 			continue
 		}
 		room, ok := w.rooms[id]
@@ -637,18 +1123,27 @@ func (w *World) persistBuilderRoomsLocked() error {
 		copyRoom := *room
 		copyRoom.ID = id
 		if room.Exits == nil {
-			copyRoom.Exits = make(map[string]RoomID)
+			copyRoom.Exits = make(map[string]Exit)
 		} else {
 			copyRoom.Exits = cloneExits(room.Exits)
 		}
 		if room.NPCs != nil {
-			npcs := make([]NPC, len(room.NPCs))
-			copy(npcs, room.NPCs)
+			npcs := make([]NPC, 0, len(room.NPCs))
+			for _, npc := range room.NPCs {
+				if npc.event == "" {
+					npcs = append(npcs, npc)
+				}
+			}
 			copyRoom.NPCs = npcs
 		}
 		if room.Items != nil {
-			items := make([]Item, len(room.Items))
-			copy(items, room.Items)
+			items := make([]Item, 0, len(room.Items))
+			for _, item := range room.Items {
+				if item.Corpse || item.event != "" {
+					continue
+				}
+				items = append(items, item)
+			}
 			copyRoom.Items = items
 		}
 		if room.Resets != nil {
@@ -676,37 +1171,21 @@ func (w *World) persistBuilderRoomsLocked() error {
 		w.areaMeta = make(map[string]areaMetadata)
 	}
 	w.areaMeta[builderAreaFile] = areaMetadata{Name: name, Script: script}
-	dir := filepath.Dir(w.builderPath)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("create builder area directory: %w", err)
-	}
-	tmp, err := os.CreateTemp(dir, "builder-*.tmp")
+	data, err := encodeDocument(file)
 	if err != nil {
-		return fmt.Errorf("create temp builder area file: %w", err)
+		return fmt.Errorf("encode builder area: %w", err)
 	}
-	enc := json.NewEncoder(tmp)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(file); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
+	if err := writeLater(w.builderPath, data); err != nil {
 		return fmt.Errorf("write builder area: %w", err)
 	}
-	if err := tmp.Close(); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("close builder area: %w", err)
-	}
-	if err := os.Rename(tmp.Name(), w.builderPath); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("replace builder area: %w", err)
-	}
 	return nil
 }
 
-func cloneExits(exits map[string]RoomID) map[string]RoomID {
+func cloneExits(exits map[string]Exit) map[string]Exit {
 	if exits == nil {
 		return nil
 	}
-	clone := make(map[string]RoomID, len(exits))
+	clone := make(map[string]Exit, len(exits))
 	for dir, dest := range exits {
 		clone[dir] = dest
 	}
@@ -714,6 +1193,11 @@ func cloneExits(exits map[string]RoomID) map[string]RoomID {
 }
 
 func (w *World) addPlayer(name string, session *TelnetSession, isAdmin bool, profile PlayerProfile) (*Player, error) {
+	return w.addCharacter(name, name, session, isAdmin, profile)
+}
+
+// addCharacter brings the named character owned by account into the world.
+func (w *World) addCharacter(account, name string, session *TelnetSession, isAdmin bool, profile PlayerProfile) (*Player, error) {
 	room := profile.Room
 	if room == "" {
 		room = StartRoom
@@ -732,34 +1216,67 @@ func (w *World) addPlayer(name string, session *TelnetSession, isAdmin bool, pro
 	if w.forceAllAdmin {
 		isAdmin = true
 	}
+	if _, ok := w.rooms[room]; !ok && isInstanceRoomID(room) {
+		room = StartRoom
+	}
 	now := time.Now()
 	if existing, ok := w.players[name]; ok {
 		if existing.Alive {
 			w.mu.Unlock()
 			return nil, fmt.Errorf("%s is already connected", name)
 		}
+		existing.linkMu.Lock()
 		existing.Session = session
-		existing.Output = make(chan string, 32)
+		existing.resetPagerLocked()
+		existing.linkMu.Unlock()
+		existing.Output = NewOutputQueue()
 		existing.Room = room
 		existing.Home = home
 		existing.Alive = true
+		w.syncPresenceLocked(existing)
 		existing.IsAdmin = isAdmin
-		existing.Account = name
+		existing.Account = account
+		existing.Character = name
 		existing.Channels = cloneChannelSettings(channels)
 		existing.ChannelAliases = cloneChannelAliases(aliases)
+		existing.Ignored = append([]string(nil), profile.Ignored...)
+		existing.Friends = append([]string(nil), profile.Friends...)
+		existing.Tutorial = profile.Tutorial
+		existing.PromptFormat = profile.Prompt
+		existing.CombatPrompt = profile.Combat
+		existing.WizInvis = profile.WizInvis
+		existing.SpeedwalkOff = profile.SpeedOff
+		existing.PageLines = profile.PageLines
+		existing.Language = profile.Language
+		existing.Bio = profile.Bio
+		existing.BioFlag = profile.BioFlag
+		existing.Kills = profile.Kills
+		existing.Bounty = profile.Bounty
+		existing.Explored = profile.Explored
+		existing.Waypoints = profile.Waypoints
+		existing.Reputation = profile.Reputation
+		existing.CommandAliases = profile.CmdAliases
+		existing.Achievements = profile.Achieved
+		existing.Title = profile.Title
+		existing.Race = profile.Race
+		existing.Class = profile.Class
+		existing.Level = profile.Level
+		existing.Experience = profile.Experience
+		existing.Trained = profile.Trained
+		existing.TrainPoints = profile.Points
+		existing.Lockouts = profile.Lockouts
+		existing.Professions = profile.Trades
 		existing.JoinedAt = now
+		w.applyArchetypesLocked(existing)
 		existing.EnsureStats()
 		existing.Health = existing.MaxHealth
 		existing.Mana = existing.MaxMana
+		existing.Moves = existing.MaxMoves
 		w.removePlayerOrderLocked(name)
 		w.playerOrder = append(w.playerOrder, name)
-		persistChannels := cloneChannelSettings(existing.Channels)
-		persistAliases := cloneChannelAliases(existing.ChannelAliases)
-		account := existing.Account
-		currentRoom := existing.Room
-		currentHome := existing.Home
+		key, snapshot := profileSnapshot(existing)
 		w.mu.Unlock()
-		w.persistPlayerState(account, currentRoom, currentHome, persistChannels, persistAliases)
+		w.persistPlayerState(key, snapshot)
 		return existing, nil
 	}
 
@@ -767,45 +1284,86 @@ func (w *World) addPlayer(name string, session *TelnetSession, isAdmin bool, pro
 	playerAliases := cloneChannelAliases(aliases)
 	p := &Player{
 		Name:           name,
-		Account:        name,
+		Account:        account,
+		Character:      name,
 		Session:        session,
 		Room:           room,
 		Home:           home,
-		Output:         make(chan string, 32),
+		Output:         NewOutputQueue(),
 		Alive:          true,
 		IsAdmin:        isAdmin,
 		IsModerator:    false,
 		IsBuilder:      false,
 		Channels:       cloneChannelSettings(playerChannels),
 		ChannelAliases: cloneChannelAliases(playerAliases),
+		Inventory:      cloneItems(profile.Inventory),
+		Gold:           profile.Gold,
+		QuestLog:       cloneQuestLog(profile.QuestLog),
+		Ignored:        append([]string(nil), profile.Ignored...),
+		Friends:        append([]string(nil), profile.Friends...),
+		Tutorial:       profile.Tutorial,
+		PromptFormat:   profile.Prompt,
+		CombatPrompt:   profile.Combat,
+		WizInvis:       profile.WizInvis,
+		SpeedwalkOff:   profile.SpeedOff,
+		PageLines:      profile.PageLines,
+		Language:       profile.Language,
+		Bio:            profile.Bio,
+		BioFlag:        profile.BioFlag,
+		Kills:          profile.Kills,
+		Bounty:         profile.Bounty,
+		Explored:       profile.Explored,
+		Waypoints:      profile.Waypoints,
+		Reputation:     profile.Reputation,
+		CommandAliases: profile.CmdAliases,
+		Achievements:   profile.Achieved,
+		Title:          profile.Title,
+		Race:           profile.Race,
+		Class:          profile.Class,
+		Level:          profile.Level,
+		Experience:     profile.Experience,
+		Trained:        profile.Trained,
+		TrainPoints:    profile.Points,
+		Lockouts:       profile.Lockouts,
+		Professions:    profile.Trades,
 		JoinedAt:       now,
 	}
+	w.applyArchetypesLocked(p)
 	p.EnsureStats()
 	p.Health = p.MaxHealth
 	p.Mana = p.MaxMana
+	p.Moves = p.MaxMoves
+	p.world = w
 	w.players[name] = p
 	w.removePlayerOrderLocked(name)
 	w.playerOrder = append(w.playerOrder, name)
-	persistChannels := cloneChannelSettings(playerChannels)
-	persistAliases := cloneChannelAliases(playerAliases)
-	account := p.Account
-	currentRoom := p.Room
-	currentHome := p.Home
+	w.syncPresenceLocked(p)
+	key, snapshot := profileSnapshot(p)
 	w.mu.Unlock()
-	w.persistPlayerState(account, currentRoom, currentHome, persistChannels, persistAliases)
+	w.persistPlayerState(key, snapshot)
+	w.claimRestedXP(p)
 	return p, nil
 }
 
 func (w *World) removePlayer(name string) {
 	w.mu.Lock()
-	defer w.mu.Unlock()
-	if p, ok := w.players[name]; ok {
+	p, ok := w.players[name]
+	if ok {
+		w.releaseMountLocked(p)
+		w.cancelTradeLocked(p)
+		w.leaveGroupLocked(p)
+		w.endDuelLocked(p)
 		delete(w.players, name)
 		w.removePlayerOrderLocked(name)
+		w.syncPresenceLocked(p)
 		if p.Output != nil {
-			close(p.Output)
+			p.Output.Close()
 		}
 	}
+	w.mu.Unlock()
+	if ok {
+		w.recordLogout(p)
+	}
 }
 
 func (w *World) Reboot() ([]*Player, error) {
@@ -818,16 +1376,33 @@ func (w *World) Reboot() ([]*Player, error) {
 	if err != nil {
 		return nil, err
 	}
+	vehicles, err := buildVehicles(areas, rooms, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	keepVehiclePositions(w.vehicles, vehicles)
+	w.vehicles = vehicles
+	w.instances = nil
+	w.instanceRooms = nil
 	w.rooms = rooms
 	w.roomSources = sources
 	w.roomHistories = newRoomHistories(rooms)
+	w.roomDigests = roomDigests(rooms)
 	w.areaMeta = areas
 	if w.areasPath != "" {
 		w.builderPath = filepath.Join(w.areasPath, builderAreaFile)
 	}
+	w.awayNPCs = nil
+	w.respawns = nil
+	w.ambience = nil
+	w.gatherNodes = nil
+	w.placeScheduledNPCsLocked()
 	revived := make([]*Player, 0, len(w.players))
 	for _, p := range w.players {
-		p.Room = StartRoom
+		if _, ok := rooms[p.Room]; !ok {
+			p.Room = StartRoom
+		}
+		w.syncPresenceLocked(p)
 		revived = append(revived, p)
 	}
 	return revived, nil
@@ -840,6 +1415,52 @@ func (w *World) GetRoom(id RoomID) (*Room, bool) {
 	return r, ok
 }
 
+// RoomSummary describes a room for builder listings.
+type RoomSummary struct {
+	ID     RoomID
+	Title  string
+	Source string
+	Exits  int
+	Resets int
+}
+
+// RoomSummaries lists every loaded room sorted by identifier.
+func (w *World) RoomSummaries() []RoomSummary {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	summaries := make([]RoomSummary, 0, len(w.rooms))
+	for id, room := range w.rooms {
+		summaries = append(summaries, RoomSummary{
+			ID:     id,
+			Title:  room.Title,
+			Source: w.roomSources[id],
+			Exits:  len(room.Exits),
+			Resets: len(room.Resets),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries
+}
+
+// RoomSnapshot returns a deep copy of the room so callers can inspect it
+// without holding the world lock.
+func (w *World) RoomSnapshot(id RoomID) (Room, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	room, ok := w.rooms[id]
+	if !ok {
+		return Room{}, false
+	}
+	snapshot := *room
+	snapshot.Exits = cloneExits(room.Exits)
+	snapshot.NPCs = append([]NPC(nil), room.NPCs...)
+	snapshot.Items = cloneItems(room.Items)
+	snapshot.Resets = append([]RoomReset(nil), room.Resets...)
+	return snapshot, true
+}
+
 func (w *World) areaMetadataForRoom(id RoomID) (areaMetadata, bool) {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
@@ -857,17 +1478,11 @@ func (w *World) areaMetadataForRoom(id RoomID) (areaMetadata, bool) {
 	return meta, true
 }
 
-func (w *World) BroadcastToRoom(room RoomID, msg string, except *Player) {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-	for _, p := range w.players {
-		if p.Room == room && p != except && p.Alive {
-			select {
-			case p.Output <- msg:
-			default:
-			}
-		}
-	}
+// BroadcastToRoom sends msg to every player in room other than those in
+// except. It reads the room presence index rather than taking the world
+// lock, so it never waits on movement or combat elsewhere.
+func (w *World) BroadcastToRoom(room RoomID, msg string, except ...*Player) {
+	w.presence.send(room, msg, except)
 }
 
 func (w *World) sendToPlayer(name string, msg string) {
@@ -886,10 +1501,7 @@ func (w *World) sendToPlayer(name string, msg string) {
 	if output == nil {
 		return
 	}
-	select {
-	case output <- msg:
-	default:
-	}
+	output.Send(msg)
 }
 
 func (w *World) BroadcastToRoomChannel(room RoomID, msg string, except *Player, channel Channel) {
@@ -902,7 +1514,7 @@ func (w *World) BroadcastToRoomChannel(room RoomID, msg string, except *Player,
 		if !target.channelEnabled(channel) {
 			continue
 		}
-		w.deliverChannelMessage(target, msg, channel)
+		w.deliverChannelMessage(target, except, msg, channel)
 	}
 }
 
@@ -926,7 +1538,7 @@ func (w *World) BroadcastToRoomsChannel(rooms []RoomID, msg string, except *Play
 		if !target.channelEnabled(channel) {
 			continue
 		}
-		w.deliverChannelMessage(target, msg, channel)
+		w.deliverChannelMessage(target, except, msg, channel)
 	}
 }
 
@@ -940,19 +1552,96 @@ func (w *World) BroadcastToAllChannel(msg string, except *Player, channel Channe
 		if !target.channelEnabled(channel) {
 			continue
 		}
-		w.deliverChannelMessage(target, msg, channel)
+		w.deliverChannelMessage(target, except, msg, channel)
+	}
+	speaker := ""
+	if except != nil {
+		speaker = except.Name
 	}
+	w.recordChatLogLocked(channel, speaker, msg)
 }
 
-func (w *World) deliverChannelMessage(target *Player, msg string, channel Channel) {
-	if target == nil {
+// BroadcastSystem delivers a message to every connected player regardless of
+// their channel settings.
+func (w *World) BroadcastSystem(msg string) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	delivered := 0
+	for _, target := range w.players {
+		if !target.Alive || target.Output == nil {
+			continue
+		}
+		target.Output.Send(msg)
+		delivered++
+	}
+	return delivered
+}
+
+// BroadcastLog delivers a server log line to admins listening on the log
+// channel and reports how many received it.
+func (w *World) BroadcastLog(level slog.Level, line string) int {
+	color := AnsiYellow
+	if level >= slog.LevelError {
+		color = AnsiMagenta
+	}
+	msg := Ansi(fmt.Sprintf("\r\n%s %s", Style("[LOG "+level.String()+"]", color, AnsiBold), line))
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	delivered := 0
+	for _, target := range w.players {
+		if !target.Alive || !target.IsAdmin || target.Output == nil || !target.channelEnabled(ChannelLog) {
+			continue
+		}
+		target.Output.Send(msg)
+		delivered++
+	}
+	return delivered
+}
+
+// KickPlayer disconnects the named player after delivering the reason. The
+// connection handler performs the usual logout once the session closes.
+func (w *World) KickPlayer(name, reason string) (*Player, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	target, ok := w.findPlayerLocked(name)
+	if !ok || !target.Alive {
+		return nil, fmt.Errorf("%s is not online", name)
+	}
+	notice := "You have been disconnected by the staff."
+	if trimmed := strings.TrimSpace(reason); trimmed != "" {
+		notice = "You have been disconnected by the staff: " + trimmed
+	}
+	message := Ansi("\r\n" + Style(notice, AnsiYellow) + "\r\n")
+	if session := target.Session; session != nil {
+		session.Send(message)
+		// Closing waits for the notice to be written, so it must not hold
+		// the world lock.
+		go session.Close()
+		return target, nil
+	}
+	if target.Output != nil {
+		target.Output.Send(message)
+	}
+	target.Alive = false
+	w.releaseMountLocked(target)
+	w.cancelTradeLocked(target)
+	w.leaveGroupLocked(target)
+	delete(w.players, target.Name)
+	w.removePlayerOrderLocked(target.Name)
+	w.syncPresenceLocked(target)
+	if target.Output != nil {
+		target.Output.Close()
+	}
+	return target, nil
+}
+
+// deliverChannelMessage sends msg to target unless target is ignoring from.
+func (w *World) deliverChannelMessage(target, from *Player, msg string, channel Channel) {
+	if target == nil || target.isIgnoring(from) {
 		return
 	}
 	target.rememberChannelMessage(channel, msg, time.Now())
-	select {
-	case target.Output <- msg:
-	default:
-	}
+	target.Output.Send(msg)
 }
 
 // QueueOfflineTell stores a private message for delivery when the recipient returns.
@@ -1000,6 +1689,15 @@ func (w *World) consumeOfflineTells(name string) []OfflineTell {
 // DeliverOfflineTells notifies the player of any stored private messages.
 func (w *World) DeliverOfflineTells(p *Player) {
 	pending := w.consumeOfflineTells(p.Name)
+	w.mu.RLock()
+	kept := pending[:0]
+	for _, tell := range pending {
+		if !containsName(p.Ignored, tell.Sender) {
+			kept = append(kept, tell)
+		}
+	}
+	pending = kept
+	w.mu.RUnlock()
 	if len(pending) == 0 {
 		return
 	}
@@ -1018,8 +1716,9 @@ func (w *World) DeliverOfflineTells(p *Player) {
 		stamp := tell.CreatedAt.Local().Format("2006-01-02 15:04")
 		builder.WriteString(fmt.Sprintf("  [%s] %s tells you: %s\r\n", stamp, HighlightName(tell.Sender), tell.Body))
 	}
-	p.Output <- Ansi(builder.String())
-	p.Output <- Prompt(p)
+	p.Output.Send(Ansi(builder.String()))
+	p.Output.Send(Prompt(p))
+	w.threadOfflineTells(p, pending)
 }
 
 func (w *World) AdjacentRooms(room RoomID) []RoomID {
@@ -1031,7 +1730,8 @@ func (w *World) AdjacentRooms(room RoomID) []RoomID {
 	}
 	seen := make(map[RoomID]struct{}, len(current.Exits))
 	neighbors := make([]RoomID, 0, len(current.Exits))
-	for _, next := range current.Exits {
+	for _, exit := range current.Exits {
+		next := exit.To
 		if _, ok := seen[next]; ok {
 			continue
 		}
@@ -1051,13 +1751,9 @@ func (w *World) SetChannel(p *Player, channel Channel, enabled bool) {
 		p.Channels = defaultChannelSettings()
 	}
 	p.Channels[channel] = enabled
-	channels := cloneChannelSettings(p.Channels)
-	aliases := cloneChannelAliases(p.ChannelAliases)
-	account := p.Account
-	room := p.Room
-	home := p.Home
+	key, snapshot := profileSnapshot(p)
 	w.mu.Unlock()
-	w.persistPlayerState(account, room, home, channels, aliases)
+	w.persistPlayerState(key, snapshot)
 }
 
 func (w *World) ChannelStatuses(p *Player) map[Channel]bool {
@@ -1070,6 +1766,13 @@ func (w *World) ChannelStatuses(p *Player) map[Channel]bool {
 	return statuses
 }
 
+// ChannelEnabled reports whether the player is listening on the channel.
+func (w *World) ChannelEnabled(p *Player, channel Channel) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return p.channelEnabled(channel)
+}
+
 // ChannelAlias returns the display alias configured for the specified channel.
 func (w *World) ChannelAlias(p *Player, channel Channel) string {
 	w.mu.RLock()
@@ -1109,16 +1812,14 @@ func (w *World) SetChannelAlias(p *Player, channel Channel, alias string) {
 		return
 	}
 	p.setChannelAlias(channel, alias)
-	channels := cloneChannelSettings(p.Channels)
-	aliases := cloneChannelAliases(p.ChannelAliases)
-	account := p.Account
-	room := p.Room
-	home := p.Home
+	key, snapshot := profileSnapshot(p)
 	w.mu.Unlock()
-	w.persistPlayerState(account, room, home, channels, aliases)
+	w.persistPlayerState(key, snapshot)
 }
 
 // ChannelHistory returns the recent message log for the provided channel.
+// Global channels are read from the shared chat log when one is attached, so
+// players see scrollback from before they logged in.
 func (w *World) ChannelHistory(p *Player, channel Channel, limit int) []ChannelLogEntry {
 	w.mu.RLock()
 	stored, ok := w.players[p.Name]
@@ -1126,6 +1827,23 @@ func (w *World) ChannelHistory(p *Player, channel Channel, limit int) []ChannelL
 	if !ok || stored != p {
 		return nil
 	}
+	if log := w.ChatLog(); log != nil && chatLogged(channel) {
+		if limit <= 0 || limit > ChannelHistoryLimit {
+			limit = ChannelHistoryLimit
+		}
+		w.mu.RLock()
+		ignored := append([]string(nil), p.Ignored...)
+		w.mu.RUnlock()
+		recent := log.Recent(channel, limit)
+		entries := make([]ChannelLogEntry, 0, len(recent))
+		for _, entry := range recent {
+			if entry.Speaker != "" && containsName(ignored, entry.Speaker) {
+				continue
+			}
+			entries = append(entries, ChannelLogEntry{Timestamp: entry.Time.Local(), Message: entry.Message, Channel: channel})
+		}
+		return entries
+	}
 	return p.snapshotChannelHistory(channel, limit)
 }
 
@@ -1143,15 +1861,28 @@ func (w *World) RecordPlayerChannelMessage(p *Player, channel Channel, msg strin
 	p.rememberChannelMessage(channel, msg, time.Now())
 }
 
-// ChannelMuted reports whether the player is currently muted on the specified channel.
+// ChannelMuted reports whether the player is currently muted on the
+// specified channel, either for this session or by a moderation mute.
 func (w *World) ChannelMuted(p *Player, channel Channel) bool {
 	w.mu.RLock()
 	stored, ok := w.players[p.Name]
+	log := w.moderation
+	key := p.Account
+	if key == "" {
+		key = p.Name
+	}
 	w.mu.RUnlock()
 	if !ok || stored != p {
 		return false
 	}
-	return p.muted(channel)
+	if p.muted(channel) {
+		return true
+	}
+	if log == nil {
+		return false
+	}
+	_, muted := log.Muted(key, channel)
+	return muted
 }
 
 // SetChannelMute toggles the mute flag for a player's channel usage.
@@ -1180,17 +1911,61 @@ func (w *World) SetChannelMute(p *Player, channel Channel, muted bool) {
 	w.mu.Unlock()
 }
 
-func (w *World) persistPlayerState(account string, room, home RoomID, channels map[Channel]bool, aliases map[Channel]string) {
-	if account == "" {
+// profileSnapshot captures the persistent state of p and the character name it
+// is stored under. Callers must hold the world lock.
+func profileSnapshot(p *Player) (string, PlayerProfile) {
+	key := p.Character
+	if key == "" {
+		key = p.Account
+	}
+	return key, PlayerProfile{
+		Room:       p.Room,
+		Home:       p.Home,
+		Channels:   cloneChannelSettings(p.Channels),
+		Aliases:    cloneChannelAliases(p.ChannelAliases),
+		Inventory:  cloneItems(p.Inventory),
+		Gold:       p.Gold,
+		QuestLog:   cloneQuestLog(p.QuestLog),
+		Ignored:    append([]string(nil), p.Ignored...),
+		Friends:    append([]string(nil), p.Friends...),
+		Tutorial:   p.Tutorial,
+		Prompt:     p.PromptFormat,
+		Combat:     p.CombatPrompt,
+		WizInvis:   p.WizInvis,
+		SpeedOff:   p.SpeedwalkOff,
+		PageLines:  p.PageLines,
+		Language:   p.Language,
+		Bio:        p.Bio,
+		BioFlag:    p.BioFlag,
+		Kills:      p.Kills,
+		Bounty:     p.Bounty,
+		Explored:   maps.Clone(p.Explored),
+		Waypoints:  maps.Clone(p.Waypoints),
+		Reputation: maps.Clone(p.Reputation),
+		CmdAliases: maps.Clone(p.CommandAliases),
+		Achieved:   maps.Clone(p.Achievements),
+		Title:      p.Title,
+		Race:       p.Race,
+		Class:      p.Class,
+		Level:      p.Level,
+		Experience: p.Experience,
+		Trained:    p.Trained,
+		Points:     p.TrainPoints,
+		Lockouts:   maps.Clone(p.Lockouts),
+		Trades:     maps.Clone(p.Professions),
+	}
+}
+
+func (w *World) persistPlayerState(character string, profile PlayerProfile) {
+	if character == "" {
 		return
 	}
 	accounts := w.accounts
 	if accounts == nil {
 		return
 	}
-	profile := PlayerProfile{Room: room, Home: home, Channels: channels, Aliases: aliases}
-	if err := accounts.SaveProfile(account, profile); err != nil {
-		fmt.Printf("failed to persist state for %s: %v\n", account, err)
+	if err := accounts.SaveProfile(character, profile); err != nil {
+		Logger().Error("failed to persist player state", "character", character, "error", err)
 	}
 }
 
@@ -1200,13 +1975,9 @@ func (w *World) PersistPlayer(p *Player) {
 		return
 	}
 	w.mu.RLock()
-	account := p.Account
-	room := p.Room
-	home := p.Home
-	channels := cloneChannelSettings(p.Channels)
-	aliases := cloneChannelAliases(p.ChannelAliases)
+	key, snapshot := profileSnapshot(p)
 	w.mu.RUnlock()
-	w.persistPlayerState(account, room, home, channels, aliases)
+	w.persistPlayerState(key, snapshot)
 }
 
 func (w *World) RenamePlayer(p *Player, newName string) error {
@@ -1385,7 +2156,7 @@ func (w *World) TriggerRoomLook(player *Player) {
 	if !ok {
 		return
 	}
-	w.scripts.callRoomOnLook(w, room, player)
+	w.scripts.callRoomOnLook(w, room, player, "")
 }
 
 func (w *World) TriggerItemInspect(player *Player, room RoomID, item *Item, location string) {
@@ -1452,6 +2223,9 @@ type NPCDamageResult struct {
 	Damage   int
 	Defeated bool
 	Loot     []Item
+	Corpse   string
+	// Phases lists the boss phases the blow began.
+	Phases []BossPhase
 }
 
 // PlayerDamageResult describes the outcome of damaging a player.
@@ -1461,6 +2235,12 @@ type PlayerDamageResult struct {
 	Defeated     bool
 	PreviousRoom RoomID
 	Remaining    int
+	Death        DeathOutcome
+	// Duel is set when the blow won an arena duel; the target is restored
+	// instead of dying.
+	Duel *DuelOutcome
+	// Bounty is the bounty paid out or cleared by the blow.
+	Bounty int
 }
 
 // ApplyDamageToNPC reduces the health of an NPC located in the provided room.
@@ -1473,15 +2253,29 @@ func (w *World) ApplyDamageToNPC(room RoomID, name string, damage int) (*NPCDama
 		return nil, fmt.Errorf("target must not be empty")
 	}
 	w.mu.Lock()
-	defer w.mu.Unlock()
 	r, ok := w.rooms[room]
 	if !ok {
+		w.mu.Unlock()
 		return nil, fmt.Errorf("unknown room: %s", room)
 	}
+	if r.Peaceful {
+		w.mu.Unlock()
+		return nil, ErrPeaceful
+	}
 	idx := findNPCIndex(r.NPCs, trimmed)
 	if idx < 0 {
+		w.mu.Unlock()
 		return nil, fmt.Errorf("no such creature here")
 	}
+	result := w.damageNPCLocked(room, r, idx, damage)
+	w.mu.Unlock()
+	w.runBossPhases(room, result)
+	return result, nil
+}
+
+// damageNPCLocked applies damage to the NPC at idx in r, the room with id
+// room, leaving a corpse and removing the NPC when the blow defeats it.
+func (w *World) damageNPCLocked(room RoomID, r *Room, idx int, damage int) *NPCDamageResult {
 	npc := r.NPCs[idx]
 	normalizeNPC(&npc)
 	if damage > npc.Health {
@@ -1489,21 +2283,20 @@ func (w *World) ApplyDamageToNPC(room RoomID, name string, damage int) (*NPCDama
 	}
 	npc.Health -= damage
 	defeated := npc.Health <= 0
-	loot := make([]Item, len(npc.Loot))
-	if len(npc.Loot) > 0 {
-		copy(loot, npc.Loot)
+	loot := w.rollLootLocked(npc)
+	if defeated {
+		loot = append(loot, w.eventDropsLocked(room)...)
 	}
 	result := &NPCDamageResult{NPC: npc, Damage: damage, Defeated: defeated, Loot: loot}
 	if defeated {
 		npc.Health = 0
-		if len(loot) > 0 {
-			r.Items = append(r.Items, loot...)
-		}
+		result.Corpse = w.spawnCorpseLocked(r, npc.Name, "", loot)
 		r.NPCs = append(r.NPCs[:idx], r.NPCs[idx+1:]...)
 	} else {
+		result.Phases = advanceBossLocked(r, &npc)
 		r.NPCs[idx] = npc
 	}
-	return result, nil
+	return result
 }
 
 // ApplyDamageToPlayer reduces the health of a player in the attacker's room.
@@ -1519,17 +2312,30 @@ func (w *World) ApplyDamageToPlayer(attacker *Player, targetName string, damage
 		return nil, fmt.Errorf("target must not be empty")
 	}
 	w.mu.Lock()
-	defer w.mu.Unlock()
 	if !attacker.Alive {
+		w.mu.Unlock()
 		return nil, fmt.Errorf("you are in no condition to fight")
 	}
+	result, err := w.damagePlayerInRoomLocked(attacker, attacker.Room, trimmed, damage)
+	w.mu.Unlock()
+	if errors.Is(err, ErrCrime) {
+		w.reportCrime(attacker)
+	}
+	w.bountyClaimed(attacker, result)
+	w.warKillScored(attacker, result)
+	return result, err
+}
+
+// damagePlayerInRoomLocked applies damage from attacker to the player named
+// targetName standing in room.
+func (w *World) damagePlayerInRoomLocked(attacker *Player, room RoomID, targetName string, damage int) (*PlayerDamageResult, error) {
 	attacker.EnsureStats()
 	var (
 		candidates []string
 		indexes    []*Player
 	)
 	for _, p := range w.players {
-		if p == attacker || !p.Alive || p.Room != attacker.Room {
+		if p == attacker || !p.Alive || p.Room != room {
 			continue
 		}
 		candidates = append(candidates, p.Name)
@@ -1538,11 +2344,14 @@ func (w *World) ApplyDamageToPlayer(attacker *Player, targetName string, damage
 	if len(candidates) == 0 {
 		return nil, fmt.Errorf("no such opponent here")
 	}
-	idx, ok := uniqueMatch(trimmed, candidates, true)
+	idx, ok := uniqueMatch(targetName, candidates, true)
 	if !ok {
 		return nil, fmt.Errorf("no such opponent here")
 	}
 	target := indexes[idx]
+	if err := w.pvpAllowedLocked(attacker, target); err != nil {
+		return nil, err
+	}
 	target.EnsureStats()
 	if damage > target.Health {
 		damage = target.Health
@@ -1554,15 +2363,16 @@ func (w *World) ApplyDamageToPlayer(attacker *Player, targetName string, damage
 		remaining = 0
 	}
 	result := &PlayerDamageResult{Target: target, Damage: damage, Defeated: defeated, PreviousRoom: target.Room, Remaining: remaining}
-	if defeated {
-		if target.Home == "" {
-			target.Home = StartRoom
-		}
-		target.Room = target.Home
-		target.EnsureStats()
-		target.Health = target.MaxHealth
-		target.Mana = target.MaxMana
-	} else {
+	switch {
+	case defeated && attacker.duel == target:
+		outcome := w.settleDuelLocked(attacker, target)
+		result.Duel = &outcome
+	case defeated && target.Bounty > 0:
+		result.Bounty = w.claimBountyLocked(attacker, target)
+		result.Death = w.applyDeathLocked(target)
+	case defeated:
+		result.Death = w.applyDeathLocked(target)
+	default:
 		target.EnsureStats()
 		target.Health = remaining
 	}
@@ -1592,6 +2402,9 @@ func (w *World) ApplyDamageFromNPC(room RoomID, npcName string, target *Player,
 	if target.Room != room {
 		return nil, fmt.Errorf("no such opponent here")
 	}
+	if err := w.combatAllowedLocked(room); err != nil {
+		return nil, err
+	}
 
 	target.EnsureStats()
 	if damage > target.Health {
@@ -1615,13 +2428,14 @@ func (w *World) ApplyDamageFromNPC(room RoomID, npcName string, target *Player,
 	}
 
 	if defeated {
-		if target.Home == "" {
-			target.Home = StartRoom
+		if r, ok := w.rooms[room]; ok && target.Bounty > 0 {
+			if idx := findNPCIndex(r.NPCs, trimmed); idx >= 0 && r.NPCs[idx].Guard {
+				result.Bounty = target.Bounty
+				target.Bounty = 0
+				target.hunters = nil
+			}
 		}
-		target.Room = target.Home
-		target.EnsureStats()
-		target.Health = target.MaxHealth
-		target.Mana = target.MaxMana
+		result.Death = w.applyDeathLocked(target)
 	} else {
 		target.EnsureStats()
 		target.Health = remaining
@@ -1639,7 +2453,7 @@ func (w *World) ensureCombat(room RoomID) *combatInstance {
 	}
 	combat, ok := w.combats[room]
 	if !ok {
-		combat = newCombatInstance(w, room)
+		combat = newCombatInstance(w, room, w.tunablesLocked().CombatRound)
 		w.combats[room] = combat
 	}
 	return combat
@@ -1675,6 +2489,9 @@ func (w *World) StartCombat(attacker *Player, targetName string) error {
 	if trimmed == "" {
 		return fmt.Errorf("target must not be empty")
 	}
+	if err := w.CombatAllowed(attacker.Room); err != nil {
+		return err
+	}
 
 	attacker.EnsureStats()
 
@@ -1684,7 +2501,7 @@ func (w *World) StartCombat(attacker *Player, targetName string) error {
 		combat.addNPC(npc.Name, combatTarget{kind: combatTargetPlayer, name: attacker.Name})
 
 		if attacker.Output != nil {
-			attacker.Output <- Ansi(fmt.Sprintf("\r\nYou engage %s in combat!", HighlightNPCName(npc.Name)))
+			attacker.Output.Send(Ansi(fmt.Sprintf("\r\nYou engage %s in combat!", HighlightNPCName(npc.Name))))
 		}
 		w.BroadcastToRoom(attacker.Room, Ansi(fmt.Sprintf("\r\n%s engages %s in combat!", HighlightName(attacker.Name), HighlightNPCName(npc.Name))), attacker)
 
@@ -1715,16 +2532,25 @@ func (w *World) StartCombat(attacker *Player, targetName string) error {
 		return fmt.Errorf("no such opponent here")
 	}
 	target := matches[idx]
+	w.mu.Lock()
+	err := w.pvpAllowedLocked(attacker, target)
+	w.mu.Unlock()
+	if errors.Is(err, ErrCrime) {
+		w.reportCrime(attacker)
+	}
+	if err != nil {
+		return err
+	}
 
 	combat := w.ensureCombat(attacker.Room)
 	combat.addPlayer(attacker.Name, combatTarget{kind: combatTargetPlayer, name: target.Name})
 	combat.addPlayer(target.Name, combatTarget{kind: combatTargetPlayer, name: attacker.Name})
 
 	if attacker.Output != nil {
-		attacker.Output <- Ansi(fmt.Sprintf("\r\nYou engage %s in combat!", HighlightName(target.Name)))
+		attacker.Output.Send(Ansi(fmt.Sprintf("\r\nYou engage %s in combat!", HighlightName(target.Name))))
 	}
 	if target.Output != nil {
-		target.Output <- Ansi(fmt.Sprintf("\r\n%s engages you in combat!", HighlightName(attacker.Name)))
+		target.Output.Send(Ansi(fmt.Sprintf("\r\n%s engages you in combat!", HighlightName(attacker.Name))))
 	}
 	w.BroadcastToRoom(attacker.Room, Ansi(fmt.Sprintf("\r\n%s engages %s in combat!", HighlightName(attacker.Name), HighlightName(target.Name))), attacker)
 
@@ -1736,14 +2562,17 @@ func (w *World) StartCombat(attacker *Player, targetName string) error {
 	return nil
 }
 
-// AwardExperience grants experience to a player and reports level gains.
-func (w *World) AwardExperience(p *Player, amount int) int {
+// AwardExperience grants experience to a player, adding any rested bonus,
+// and reports the levels gained and the bonus applied.
+func (w *World) AwardExperience(p *Player, amount int) (levels, rested int) {
 	if p == nil || amount <= 0 {
-		return 0
+		return 0, 0
 	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	return p.GainExperience(amount)
+	amount = w.scaleExperienceLocked(amount)
+	rested = restedBonusLocked(p, amount)
+	return p.GainExperience(amount + rested), rested
 }
 
 // FindRoomItem attempts to locate an item lying in the specified room by name.
@@ -1824,28 +2653,40 @@ func (w *World) FindInventoryItem(p *Player, name string) (*Item, bool) {
 
 // TakeItem moves an item from the player's current room into their inventory.
 func (w *World) TakeItem(p *Player, name string) (*Item, error) {
+	item, room, err := w.takeItem(p, name)
+	if err != nil {
+		return nil, err
+	}
+	w.Publish(GameEvent{Type: EventItemTaken, Player: p, Room: room, Item: *item})
+	return item, nil
+}
+
+func (w *World) takeItem(p *Player, name string) (*Item, RoomID, error) {
 	target := strings.TrimSpace(name)
 	if target == "" {
-		return nil, fmt.Errorf("item name must not be empty")
+		return nil, "", fmt.Errorf("item name must not be empty")
 	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	stored, ok := w.players[p.Name]
 	if !ok || stored != p || !p.Alive {
-		return nil, fmt.Errorf("%s is not online", p.Name)
+		return nil, "", fmt.Errorf("%s is not online", p.Name)
 	}
 	room, ok := w.rooms[p.Room]
 	if !ok {
-		return nil, fmt.Errorf("unknown room: %s", p.Room)
+		return nil, "", fmt.Errorf("unknown room: %s", p.Room)
 	}
 	idx := findItemIndex(room.Items, target)
 	if idx == -1 {
-		return nil, ErrItemNotFound
+		return nil, "", ErrItemNotFound
 	}
 	item := room.Items[idx]
+	if !canCarryLocked(p, item.TotalWeight()) {
+		return nil, "", ErrTooHeavy
+	}
 	room.Items = append(room.Items[:idx], room.Items[idx+1:]...)
 	p.Inventory = append(p.Inventory, item)
-	return &item, nil
+	return &item, room.ID, nil
 }
 
 // DropItem places an item from the player's inventory into their current room.
@@ -1876,44 +2717,99 @@ func (w *World) DropItem(p *Player, name string) (*Item, error) {
 
 func (w *World) Move(p *Player, dir string) (string, error) {
 	w.mu.Lock()
+	from := p.Room
 	r, ok := w.rooms[p.Room]
 	if !ok {
 		w.mu.Unlock()
 		return "", fmt.Errorf("unknown room: %s", p.Room)
 	}
-	next, ok := r.Exits[dir]
-	if !ok {
+	exit, ok := r.Exits[dir]
+	if !ok || !exitVisibleLocked(p, r.ID, dir, exit) {
+		w.mu.Unlock()
+		return "", ErrNoExit
+	}
+	if exit.Closed {
+		w.mu.Unlock()
+		return "", doorClosedError(dir)
+	}
+	if err := w.exitAllowsLocked(p, dir, exit); err != nil {
+		w.mu.Unlock()
+		return "", err
+	}
+	next := w.instanceEntryLocked(p, r.ID, exit.To)
+	terrain := TerrainInside
+	dest, hasDest := w.rooms[next]
+	if hasDest {
+		if err := w.terrainAllowsLocked(p, dest); err != nil {
+			w.mu.Unlock()
+			return "", err
+		}
+		if err := w.clanHallAllowsLocked(p, dest); err != nil {
+			w.mu.Unlock()
+			return "", err
+		}
+		terrain = dest.Terrain
+	}
+	p.EnsureStats()
+	cost := p.moveCost(terrain)
+	if p.encumbrance().Overloaded() {
 		w.mu.Unlock()
-		return "", fmt.Errorf("you can't go that way")
+		return "", ErrOverburdened
+	}
+	if p.Moves < cost {
+		w.mu.Unlock()
+		return "", ErrExhausted
+	}
+	p.Moves -= cost
+	var hazard hazardOutcome
+	passed := true
+	if hasDest && dest.Hazard != HazardNone {
+		hazard, passed = w.testHazardLocked(p, dest, true)
+		if !passed && (hazard.defeated || hazardRules[dest.Hazard].blocks) {
+			w.mu.Unlock()
+			w.reportHazard(p, hazard)
+			return "", ErrHazard
+		}
 	}
 	p.Room = next
-	channels := cloneChannelSettings(p.Channels)
-	aliases := cloneChannelAliases(p.ChannelAliases)
-	account := p.Account
-	home := p.Home
+	w.syncPresenceLocked(p)
+	w.leaveScentLocked(from, p.Name, dir, time.Now())
+	opponent := w.endDuelLocked(p)
+	key, snapshot := profileSnapshot(p)
 	w.mu.Unlock()
-	w.persistPlayerState(account, next, home, channels, aliases)
+	w.persistPlayerState(key, snapshot)
+	if !passed {
+		w.reportHazard(p, hazard)
+	}
+	if opponent != nil && opponent.Output != nil {
+		opponent.Output.Send(Ansi(fmt.Sprintf("\r\n%s leaves the arena. Your duel is called off.", HighlightName(p.Name))))
+	}
+	w.Publish(GameEvent{Type: EventPlayerMoved, Player: p, From: from, Room: next, Direction: dir})
 	return string(next), nil
 }
 
-// ResolveExit attempts to match the provided direction against the room's exits.
-// It returns the canonical exit label and destination room when successful.
-func (w *World) ResolveExit(room RoomID, direction string) (string, RoomID, bool) {
+// ResolveExit attempts to match the provided direction against the exits the
+// player can see from their room. It returns the canonical exit label and
+// destination room when successful.
+func (w *World) ResolveExit(p *Player, direction string) (string, RoomID, bool) {
 	target := strings.TrimSpace(direction)
 	if target == "" {
 		return "", "", false
 	}
 	w.mu.RLock()
 	defer w.mu.RUnlock()
-	r, ok := w.rooms[room]
+	r, ok := w.rooms[p.Room]
 	if !ok || len(r.Exits) == 0 {
 		return "", "", false
 	}
 	names := make([]string, 0, len(r.Exits))
 	destinations := make([]RoomID, 0, len(r.Exits))
-	for dir, dest := range r.Exits {
+	for dir, exit := range r.Exits {
+		if !exitVisibleLocked(p, r.ID, dir, exit) {
+			continue
+		}
 		names = append(names, dir)
-		destinations = append(destinations, dest)
+		destinations = append(destinations, exit.To)
 	}
 	idx, ok := uniqueMatch(target, names, true)
 	if !ok {
@@ -1957,6 +2853,31 @@ func (w *World) FindPlayer(name string) (*Player, bool) {
 	return p, true
 }
 
+// FindRoomPlayer locates a connected player in room by name or unique
+// prefix.
+func (w *World) FindRoomPlayer(room RoomID, name string) (*Player, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return nil, false
+	}
+	candidates := make([]*Player, 0, len(w.players))
+	names := make([]string, 0, len(w.players))
+	for _, p := range w.players {
+		if !p.Alive || p.Room != room {
+			continue
+		}
+		candidates = append(candidates, p)
+		names = append(names, p.Name)
+	}
+	idx, ok := uniqueMatch(trimmed, names, false)
+	if !ok {
+		return nil, false
+	}
+	return candidates[idx], true
+}
+
 // SetBuilder toggles the builder flag for a connected player.
 func (w *World) SetBuilder(name string, enabled bool) (*Player, error) {
 	w.mu.Lock()
@@ -1994,12 +2915,10 @@ func (w *World) MoveToRoom(p *Player, room RoomID) error {
 		return fmt.Errorf("%s is not online", p.Name)
 	}
 	p.Room = room
-	account := p.Account
-	home := p.Home
-	channels := cloneChannelSettings(p.Channels)
-	aliases := cloneChannelAliases(p.ChannelAliases)
+	w.syncPresenceLocked(p)
+	key, snapshot := profileSnapshot(p)
 	w.mu.Unlock()
-	w.persistPlayerState(account, room, home, channels, aliases)
+	w.persistPlayerState(key, snapshot)
 	return nil
 }
 
@@ -2016,12 +2935,9 @@ func (w *World) SetHome(p *Player, room RoomID) error {
 		return fmt.Errorf("%s is not online", p.Name)
 	}
 	p.Home = room
-	account := p.Account
-	channels := cloneChannelSettings(p.Channels)
-	aliases := cloneChannelAliases(p.ChannelAliases)
-	currentRoom := p.Room
+	key, snapshot := profileSnapshot(p)
 	w.mu.Unlock()
-	w.persistPlayerState(account, currentRoom, room, channels, aliases)
+	w.persistPlayerState(key, snapshot)
 	return nil
 }
 
@@ -2040,11 +2956,17 @@ func (w *World) CreateRoom(id RoomID, title, editor string) (*Room, error) {
 	if title = strings.TrimSpace(title); title == "" {
 		title = trimmed
 	}
+	area, err := w.newRoomAreaLocked(editor)
+	if err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
 	room := &Room{
 		ID:          normalizedID,
 		Title:       title,
 		Description: "",
-		Exits:       make(map[string]RoomID),
+		Exits:       make(map[string]Exit),
+		Area:        area,
 	}
 	if w.rooms == nil {
 		w.rooms = make(map[RoomID]*Room)
@@ -2069,6 +2991,10 @@ func (w *World) CreateRoom(id RoomID, title, editor string) (*Room, error) {
 // UpdateRoomDescription modifies a room's description and persists the change.
 func (w *World) UpdateRoomDescription(id RoomID, description, editor string) (*Room, error) {
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, id); err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
 	room, ok := w.rooms[id]
 	if !ok {
 		w.mu.Unlock()
@@ -2099,6 +3025,10 @@ func (w *World) UpdateRoomTitle(id RoomID, title, editor string) (*Room, error)
 		return nil, fmt.Errorf("room title must not be empty")
 	}
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, id); err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
 	room, ok := w.rooms[id]
 	if !ok {
 		w.mu.Unlock()
@@ -2146,6 +3076,10 @@ func (w *World) RevertRoomToRevision(id RoomID, number int, editor string) (*Roo
 		return nil, fmt.Errorf("revision must be positive")
 	}
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, id); err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
 	history := w.roomHistories[id]
 	if history == nil || len(history.revisions) == 0 {
 		w.mu.Unlock()
@@ -2193,13 +3127,17 @@ func (w *World) RevertRoomToRevision(id RoomID, number int, editor string) (*Roo
 }
 
 // SetExit updates (or creates) an exit from one room to another.
-func (w *World) SetExit(from RoomID, direction string, to RoomID) error {
+func (w *World) SetExit(from RoomID, direction string, to RoomID, editor string) error {
 	dir := strings.ToLower(strings.TrimSpace(direction))
 	if dir == "" {
 		return fmt.Errorf("direction must not be empty")
 	}
 	target := to
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, from); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	undo, err := w.setExitLocked(from, dir, &target)
 	if err != nil {
 		w.mu.Unlock()
@@ -2215,12 +3153,16 @@ func (w *World) SetExit(from RoomID, direction string, to RoomID) error {
 }
 
 // ClearExit removes an exit from the specified room.
-func (w *World) ClearExit(from RoomID, direction string) error {
+func (w *World) ClearExit(from RoomID, direction, editor string) error {
 	dir := strings.ToLower(strings.TrimSpace(direction))
 	if dir == "" {
 		return fmt.Errorf("direction must not be empty")
 	}
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, from); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	undo, err := w.setExitLocked(from, dir, nil)
 	if err != nil {
 		w.mu.Unlock()
@@ -2236,13 +3178,17 @@ func (w *World) ClearExit(from RoomID, direction string) error {
 }
 
 // LinkRooms wires exits between two rooms, optionally adding a return path.
-func (w *World) LinkRooms(from RoomID, direction string, to RoomID, back string) error {
+func (w *World) LinkRooms(from RoomID, direction string, to RoomID, back, editor string) error {
 	dir := strings.ToLower(strings.TrimSpace(direction))
 	if dir == "" {
 		return fmt.Errorf("direction must not be empty")
 	}
 	reverse := strings.ToLower(strings.TrimSpace(back))
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, from, to); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	undoForward, err := w.setExitLocked(from, dir, &to)
 	if err != nil {
 		w.mu.Unlock()
@@ -2272,13 +3218,17 @@ func (w *World) LinkRooms(from RoomID, direction string, to RoomID, back string)
 }
 
 // UpsertRoomNPC creates or updates an NPC reset for the specified room.
-func (w *World) UpsertRoomNPC(roomID RoomID, name, autoGreet string) (*NPC, error) {
+func (w *World) UpsertRoomNPC(roomID RoomID, name, autoGreet, editor string) (*NPC, error) {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
 		return nil, fmt.Errorf("npc name must not be empty")
 	}
 	greet := strings.TrimSpace(autoGreet)
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
 	room, ok := w.rooms[roomID]
 	if !ok {
 		w.mu.Unlock()
@@ -2325,12 +3275,16 @@ func (w *World) UpsertRoomNPC(roomID RoomID, name, autoGreet string) (*NPC, erro
 }
 
 // RemoveRoomNPC deletes an NPC definition and associated reset from a room.
-func (w *World) RemoveRoomNPC(roomID RoomID, name string) error {
+func (w *World) RemoveRoomNPC(roomID RoomID, name, editor string) error {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
 		return fmt.Errorf("npc name must not be empty")
 	}
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	room, ok := w.rooms[roomID]
 	if !ok {
 		w.mu.Unlock()
@@ -2365,13 +3319,39 @@ func (w *World) RemoveRoomNPC(roomID RoomID, name string) error {
 }
 
 // UpsertRoomItemReset creates or updates an item reset for a room.
-func (w *World) UpsertRoomItemReset(roomID RoomID, name, description string) (*RoomReset, error) {
-	trimmed := strings.TrimSpace(name)
+func (w *World) UpsertRoomItemReset(roomID RoomID, name, description, editor string) (*RoomReset, error) {
+	return w.upsertItemReset(roomID, RoomReset{Name: name, Description: description}, editor)
+}
+
+// UpsertRoomContainerReset defines a container spawner for the room. A
+// capacity of zero uses DefaultContainerCapacity.
+func (w *World) UpsertRoomContainerReset(roomID RoomID, name, description string, capacity int, editor string) (*RoomReset, error) {
+	if capacity < 0 {
+		return nil, fmt.Errorf("capacity must not be negative")
+	}
+	return w.upsertItemReset(roomID, RoomReset{Name: name, Description: description, Container: true, Capacity: capacity}, editor)
+}
+
+// UpsertRoomBookReset adds or updates a reset that spawns a blank writable
+// item, such as a book or a sign, with room for pages pages.
+func (w *World) UpsertRoomBookReset(roomID RoomID, name, description string, pages int, editor string) (*RoomReset, error) {
+	if pages < 0 {
+		return nil, fmt.Errorf("pages must not be negative")
+	}
+	return w.upsertItemReset(roomID, RoomReset{Name: name, Description: description, Writing: &Writing{MaxPages: pages}}, editor)
+}
+
+func (w *World) upsertItemReset(roomID RoomID, spec RoomReset, editor string) (*RoomReset, error) {
+	trimmed := strings.TrimSpace(spec.Name)
 	if trimmed == "" {
 		return nil, fmt.Errorf("item name must not be empty")
 	}
-	desc := strings.TrimSpace(description)
+	desc := strings.TrimSpace(spec.Description)
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
 	room, ok := w.rooms[roomID]
 	if !ok {
 		w.mu.Unlock()
@@ -2383,11 +3363,14 @@ func (w *World) UpsertRoomItemReset(roomID RoomID, name, description string) (*R
 	if idx >= 0 {
 		room.Resets[idx].Name = trimmed
 		room.Resets[idx].Description = desc
+		room.Resets[idx].Container = spec.Container
+		room.Resets[idx].Capacity = spec.Capacity
+		room.Resets[idx].Writing = spec.Writing
 		if room.Resets[idx].Count < 1 {
 			room.Resets[idx].Count = 1
 		}
 	} else {
-		room.Resets = append(room.Resets, RoomReset{Kind: ResetKindItem, Name: trimmed, Description: desc, Count: 1})
+		room.Resets = append(room.Resets, RoomReset{Kind: ResetKindItem, Name: trimmed, Description: desc, Count: 1, Container: spec.Container, Capacity: spec.Capacity, Writing: spec.Writing})
 		idx = len(room.Resets) - 1
 	}
 	w.applyRoomResetsLocked(room)
@@ -2409,12 +3392,16 @@ func (w *World) UpsertRoomItemReset(roomID RoomID, name, description string) (*R
 }
 
 // RemoveRoomItemReset deletes an item reset and any matching items from a room.
-func (w *World) RemoveRoomItemReset(roomID RoomID, name string) error {
+func (w *World) RemoveRoomItemReset(roomID RoomID, name, editor string) error {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
 		return fmt.Errorf("item name must not be empty")
 	}
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	room, ok := w.rooms[roomID]
 	if !ok {
 		w.mu.Unlock()
@@ -2453,8 +3440,12 @@ func (w *World) RemoveRoomItemReset(roomID RoomID, name string) error {
 }
 
 // ApplyRoomResets enforces the configured resets for a room.
-func (w *World) ApplyRoomResets(roomID RoomID) error {
+func (w *World) ApplyRoomResets(roomID RoomID, editor string) error {
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, roomID); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	room, ok := w.rooms[roomID]
 	if !ok {
 		w.mu.Unlock()
@@ -2482,11 +3473,15 @@ func (w *World) ApplyRoomResets(roomID RoomID) error {
 }
 
 // CloneRoomPopulation copies NPCs, items, and resets from one room into another.
-func (w *World) CloneRoomPopulation(source, target RoomID) error {
+func (w *World) CloneRoomPopulation(source, target RoomID, editor string) error {
 	if source == "" {
 		return fmt.Errorf("source room must not be empty")
 	}
 	w.mu.Lock()
+	if err := w.checkBuildScopeLocked(editor, target); err != nil {
+		w.mu.Unlock()
+		return err
+	}
 	from, ok := w.rooms[source]
 	if !ok {
 		w.mu.Unlock()
@@ -2502,9 +3497,7 @@ func (w *World) CloneRoomPopulation(source, target RoomID) error {
 	prevResets := append([]RoomReset(nil), to.Resets...)
 
 	if len(from.Items) > 0 {
-		items := make([]Item, len(from.Items))
-		copy(items, from.Items)
-		to.Items = items
+		to.Items = cloneItems(from.Items)
 	} else {
 		to.Items = nil
 	}
@@ -2555,8 +3548,7 @@ func (w *World) applyRoomResetsLocked(room *Room) {
 		}
 		switch reset.Kind {
 		case ResetKindNPC:
-			npc := NPC{Name: reset.Name, AutoGreet: reset.AutoGreet, Script: reset.Script}
-			normalizeNPC(&npc)
+			npc := npcFromReset(reset, room.ID)
 			idx := findNPCIndex(room.NPCs, reset.Name)
 			if idx >= 0 {
 				room.NPCs[idx] = npc
@@ -2571,10 +3563,42 @@ func (w *World) applyRoomResetsLocked(room *Room) {
 					if reset.Description != "" {
 						room.Items[j].Description = reset.Description
 					}
+					room.Items[j].Container = reset.Container
+					room.Items[j].Capacity = reset.Capacity
+					room.Items[j].Light = reset.Light
+					room.Items[j].Ranged = reset.Ranged
+					room.Items[j].Damage = reset.Damage
+					room.Items[j].Ammo = reset.Ammo
+					room.Items[j].Weight = reset.Weight
+					room.Items[j].Consumable = reset.Consumable
+					room.Items[j].Swim = reset.Swim
+					room.Items[j].Climb = reset.Climb
+					room.Items[j].Fly = reset.Fly
+					room.Items[j].Extras = reset.Extras
+					if room.Items[j].Writing == nil {
+						room.Items[j].Writing = cloneWriting(reset.Writing)
+					}
 				}
 			}
 			for existing < reset.Count {
-				room.Items = append(room.Items, Item{Name: reset.Name, Description: reset.Description})
+				room.Items = append(room.Items, Item{
+					Name:        reset.Name,
+					Description: reset.Description,
+					Container:   reset.Container,
+					Capacity:    reset.Capacity,
+					Contents:    cloneItems(reset.Contents),
+					Light:       reset.Light,
+					Ranged:      reset.Ranged,
+					Damage:      reset.Damage,
+					Ammo:        reset.Ammo,
+					Weight:      reset.Weight,
+					Consumable:  reset.Consumable,
+					Swim:        reset.Swim,
+					Climb:       reset.Climb,
+					Fly:         reset.Fly,
+					Writing:     cloneWriting(reset.Writing),
+					Extras:      reset.Extras,
+				})
 				existing++
 			}
 		}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// newRingWorld builds rooms linked east to west in a loop and fills them
+// with players whose output is drained in the background.
+func newRingWorld(tb testing.TB, rooms, players int) (*World, []*Player) {
+	tb.Helper()
+	layout := make(map[RoomID]*Room, rooms)
+	for i := 0; i < rooms; i++ {
+		id := RoomID(fmt.Sprintf("r%d", i))
+		layout[id] = &Room{ID: id, Title: string(id), Exits: map[string]Exit{
+			"e": {To: RoomID(fmt.Sprintf("r%d", (i+1)%rooms))},
+			"w": {To: RoomID(fmt.Sprintf("r%d", (i+rooms-1)%rooms))},
+		}}
+	}
+	world := NewWorldWithRooms(layout)
+	var drained sync.WaitGroup
+	list := make([]*Player, players)
+	for i := range list {
+		p := &Player{Name: fmt.Sprintf("P%d", i), Room: RoomID(fmt.Sprintf("r%d", i%rooms)), Output: NewOutputQueue(), Alive: true}
+		p.EnsureStats()
+		world.AddPlayerForTest(p)
+		list[i] = p
+		drained.Add(1)
+		go func() {
+			defer drained.Done()
+			for {
+				if _, ok := p.Output.out.next(); !ok {
+					return
+				}
+			}
+		}()
+	}
+	tb.Cleanup(func() {
+		for _, p := range list {
+			p.Output.Close()
+		}
+		drained.Wait()
+	})
+	return world, list
+}
+
+func TestConcurrentMovementAndBroadcasts(t *testing.T) {
+	world, players := newRingWorld(t, 16, 64)
+	var wg sync.WaitGroup
+	for i, p := range players {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dir := "e"
+			if i%2 == 1 {
+				dir = "w"
+			}
+			for step := 0; step < 20; step++ {
+				world.mu.Lock()
+				p.Moves = p.MaxMoves
+				world.mu.Unlock()
+				if _, err := world.Move(p, dir); err != nil {
+					t.Errorf("%s Move: %v", p.Name, err)
+					return
+				}
+				world.BroadcastToRoom(RoomID(fmt.Sprintf("r%d", step%16)), "\r\nA breeze stirs.", p)
+				world.ListPlayers(true, RoomID(fmt.Sprintf("r%d", i%16)))
+				if _, ok := world.FindRoomPlayer(RoomID(fmt.Sprintf("r%d", step%16)), "P"); ok {
+					t.Errorf("FindRoomPlayer matched an ambiguous prefix")
+					return
+				}
+				if _, ok := world.FindPlayer(players[(i+1)%len(players)].Name); !ok {
+					t.Errorf("FindPlayer lost a neighbour of %s", p.Name)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for i := 0; i < 16; i++ {
+		total += len(world.ListPlayers(true, RoomID(fmt.Sprintf("r%d", i))))
+	}
+	if total != len(players) {
+		t.Fatalf("players across rooms = %d, want %d", total, len(players))
+	}
+	for _, p := range players {
+		if p.listedIn != p.Room {
+			t.Fatalf("%s is listed in %s but stands in %s", p.Name, p.listedIn, p.Room)
+		}
+	}
+}
+
+func BenchmarkParallelMove(b *testing.B) {
+	world, players := newRingWorld(b, 64, 256)
+	var next sync.Mutex
+	index := 0
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		next.Lock()
+		p := players[index%len(players)]
+		index++
+		next.Unlock()
+		for pb.Next() {
+			world.mu.Lock()
+			p.Moves = p.MaxMoves
+			world.mu.Unlock()
+			if _, err := world.Move(p, "e"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkBroadcastToRoom(b *testing.B) {
+	world, _ := newRingWorld(b, 64, 256)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			world.BroadcastToRoom(RoomID(fmt.Sprintf("r%d", i%64)), "\r\nA bell tolls.")
+			i++
+		}
+	})
+}
+
+// BenchmarkBroadcastDuringMovement measures room broadcasts while other
+// players keep walking, the mix a busy server sees.
+func BenchmarkBroadcastDuringMovement(b *testing.B) {
+	world, players := newRingWorld(b, 64, 256)
+	stop := make(chan struct{})
+	var walkers sync.WaitGroup
+	for _, p := range players[:32] {
+		walkers.Add(1)
+		go func() {
+			defer walkers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				world.mu.Lock()
+				p.Moves = p.MaxMoves
+				world.mu.Unlock()
+				world.Move(p, "e")
+			}
+		}()
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			world.BroadcastToRoom(RoomID(fmt.Sprintf("r%d", i%64)), "\r\nA bell tolls.")
+			i++
+		}
+	})
+	b.StopTimer()
+	close(stop)
+	walkers.Wait()
+}
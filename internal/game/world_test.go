@@ -37,7 +37,7 @@ func TestWorldTakeDropItem(t *testing.T) {
 		rooms: map[RoomID]*Room{
 			roomID: {
 				ID:    roomID,
-				Exits: map[string]RoomID{},
+				Exits: map[string]Exit{},
 				Items: []Item{item},
 			},
 		},
@@ -89,7 +89,7 @@ func TestWorldTakeItemMatchesPartialWord(t *testing.T) {
 		rooms: map[RoomID]*Room{
 			roomID: {
 				ID:    roomID,
-				Exits: map[string]RoomID{},
+				Exits: map[string]Exit{},
 				Items: []Item{item},
 			},
 		},
@@ -113,7 +113,7 @@ func TestWorldTakeItemPartialAmbiguous(t *testing.T) {
 		rooms: map[RoomID]*Room{
 			roomID: {
 				ID:    roomID,
-				Exits: map[string]RoomID{},
+				Exits: map[string]Exit{},
 				Items: []Item{{Name: "Silver Key"}, {Name: "Steel Key"}},
 			},
 		},
@@ -149,8 +149,12 @@ func TestApplyDamageToNPCLootDrops(t *testing.T) {
 	if len(result.Loot) != 1 || result.Loot[0].Name != loot.Name {
 		t.Fatalf("expected loot in result, got %+v", result.Loot)
 	}
-	if len(world.rooms[roomID].Items) != 1 || world.rooms[roomID].Items[0].Name != loot.Name {
-		t.Fatalf("expected loot dropped into room, got %+v", world.rooms[roomID].Items)
+	items := world.rooms[roomID].Items
+	if len(items) != 1 || !items[0].Corpse || items[0].Name != result.Corpse {
+		t.Fatalf("expected corpse left in room, got %+v", items)
+	}
+	if len(items[0].Contents) != 1 || items[0].Contents[0].Name != loot.Name {
+		t.Fatalf("expected loot inside corpse, got %+v", items[0].Contents)
 	}
 	if len(world.rooms[roomID].NPCs) != 0 {
 		t.Fatalf("expected NPC removed after defeat")
@@ -168,7 +172,7 @@ func TestCloneRoomPopulationUnknownSource(t *testing.T) {
 		},
 	}
 
-	err := world.CloneRoomPopulation(RoomID("missing"), targetID)
+	err := world.CloneRoomPopulation(RoomID("missing"), targetID, "")
 	if err == nil || err.Error() != "unknown room: missing" {
 		t.Fatalf("expected unknown room error, got %v", err)
 	}
@@ -189,7 +193,7 @@ func TestCloneRoomPopulationUnknownTarget(t *testing.T) {
 		},
 	}
 
-	err := world.CloneRoomPopulation(sourceID, RoomID("missing"))
+	err := world.CloneRoomPopulation(sourceID, RoomID("missing"), "")
 	if err == nil || err.Error() != "unknown room: missing" {
 		t.Fatalf("expected unknown room error, got %v", err)
 	}
@@ -232,8 +236,8 @@ func TestCloneRoomPopulationPersistFailureRollsBack(t *testing.T) {
 		builderPath: filepath.Join(blocker, "builder.json"),
 	}
 
-	err := world.CloneRoomPopulation(sourceID, targetID)
-	if err == nil || !strings.Contains(err.Error(), "create builder area directory") {
+	err := world.CloneRoomPopulation(sourceID, targetID, "")
+	if err == nil || !strings.Contains(err.Error(), "write builder area") {
 		t.Fatalf("expected persistence error, got %v", err)
 	}
 
@@ -300,7 +304,7 @@ func TestWorldChannelHistoryAndAliasResolution(t *testing.T) {
 	player := &Player{
 		Name:     "Alice",
 		Room:     StartRoom,
-		Output:   make(chan string, 8),
+		Output:   NewOutputQueue(),
 		Alive:    true,
 		Channels: DefaultChannelSettings(),
 	}
@@ -346,7 +350,7 @@ func TestWorldChannelMute(t *testing.T) {
 	player := &Player{
 		Name:     "Alice",
 		Room:     StartRoom,
-		Output:   make(chan string, 8),
+		Output:   NewOutputQueue(),
 		Alive:    true,
 		Channels: DefaultChannelSettings(),
 	}
@@ -587,8 +591,8 @@ func TestWorldPersistsState(t *testing.T) {
 	}
 
 	rooms := map[RoomID]*Room{
-		StartRoom: {ID: StartRoom, Exits: map[string]RoomID{"east": "hall"}},
-		"hall":    {ID: "hall", Exits: map[string]RoomID{}},
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{"east": {To: "hall"}}},
+		"hall":    {ID: "hall", Exits: map[string]Exit{}},
 	}
 	world := NewWorldWithRooms(rooms)
 	world.AttachAccountManager(manager)
@@ -642,7 +646,7 @@ func TestWorldPrepareTakeover(t *testing.T) {
 		t.Fatalf("PrepareTakeover should return nil session when none was set")
 	}
 	if oldOutput == nil {
-		t.Fatalf("PrepareTakeover should return the prior output channel")
+		t.Fatalf("PrepareTakeover should return the prior output queue")
 	}
 	if player.Alive {
 		t.Fatalf("player should be marked inactive after takeover preparation")
@@ -651,13 +655,13 @@ func TestWorldPrepareTakeover(t *testing.T) {
 		t.Fatalf("player session should be cleared during takeover preparation")
 	}
 	if player.Output != nil {
-		t.Fatalf("player output channel should be cleared during takeover preparation")
+		t.Fatalf("player output queue should be cleared during takeover preparation")
 	}
 	if _, ok := world.ActivePlayer("traveler"); ok {
 		t.Fatalf("ActivePlayer should not report a player pending takeover")
 	}
 
-	close(oldOutput)
+	oldOutput.Close()
 
 	rejoined, err := world.addPlayer("traveler", nil, false, profile)
 	if err != nil {
@@ -667,7 +671,7 @@ func TestWorldPrepareTakeover(t *testing.T) {
 		t.Fatalf("player should be marked alive after rejoining")
 	}
 	if rejoined.Output == nil {
-		t.Fatalf("rejoined player should have a fresh output channel")
+		t.Fatalf("rejoined player should have a fresh output queue")
 	}
 
 	names := world.ListPlayers(false, "")
@@ -689,8 +693,8 @@ func TestWorldSetHomePersists(t *testing.T) {
 	}
 
 	rooms := map[RoomID]*Room{
-		StartRoom: {ID: StartRoom, Exits: map[string]RoomID{"east": "hall"}},
-		"hall":    {ID: "hall", Exits: map[string]RoomID{"west": StartRoom}},
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{"east": {To: "hall"}}},
+		"hall":    {ID: "hall", Exits: map[string]Exit{"west": {To: StartRoom}}},
 	}
 	world := NewWorldWithRooms(rooms)
 	world.AttachAccountManager(manager)
@@ -716,14 +720,14 @@ func TestWorldSetHomePersists(t *testing.T) {
 
 func TestListPlayersUsesLoginOrder(t *testing.T) {
 	rooms := map[RoomID]*Room{
-		StartRoom: {ID: StartRoom, Exits: map[string]RoomID{"east": "hall"}},
-		"hall":    {ID: "hall", Exits: map[string]RoomID{"west": StartRoom}},
+		StartRoom: {ID: StartRoom, Exits: map[string]Exit{"east": {To: "hall"}}},
+		"hall":    {ID: "hall", Exits: map[string]Exit{"west": {To: StartRoom}}},
 	}
 	world := NewWorldWithRooms(rooms)
 
-	alpha := &Player{Name: "Alpha", Room: StartRoom, Output: make(chan string, 1), Alive: true}
-	bravo := &Player{Name: "Bravo", Room: "hall", Output: make(chan string, 1), Alive: true}
-	charlie := &Player{Name: "Charlie", Room: StartRoom, Output: make(chan string, 1), Alive: true}
+	alpha := &Player{Name: "Alpha", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
+	bravo := &Player{Name: "Bravo", Room: "hall", Output: NewOutputQueue(), Alive: true}
+	charlie := &Player{Name: "Charlie", Room: StartRoom, Output: NewOutputQueue(), Alive: true}
 
 	world.AddPlayerForTest(alpha)
 	world.AddPlayerForTest(bravo)
@@ -747,7 +751,7 @@ func TestListPlayersUsesLoginOrder(t *testing.T) {
 
 	world.removePlayer("Alpha")
 
-	returning := &Player{Name: "Alpha", Room: "hall", Output: make(chan string, 1), Alive: true}
+	returning := &Player{Name: "Alpha", Room: "hall", Output: NewOutputQueue(), Alive: true}
 	world.AddPlayerForTest(returning)
 
 	names = world.ListPlayers(false, "")
@@ -785,7 +789,7 @@ func TestStartCombatPlayerVsNPC(t *testing.T) {
 		},
 	}
 	world := NewWorldWithRooms(rooms)
-	player := &Player{Name: "Hero", Room: StartRoom, Output: make(chan string, 10), Alive: true, Level: 1}
+	player := &Player{Name: "Hero", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Level: 1}
 	world.AddPlayerForTest(player)
 
 	npc := rooms[StartRoom].NPCs[0]
@@ -828,7 +832,7 @@ func TestStartCombatNPCKillEndsCombat(t *testing.T) {
 		},
 	}
 	world := NewWorldWithRooms(rooms)
-	player := &Player{Name: "Hero", Room: StartRoom, Output: make(chan string, 10), Alive: true, Level: 5}
+	player := &Player{Name: "Hero", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Level: 5}
 	world.AddPlayerForTest(player)
 
 	if err := world.StartCombat(player, "chief"); err != nil {
@@ -851,14 +855,15 @@ func TestStartCombatNPCKillEndsCombat(t *testing.T) {
 }
 
 func TestStartCombatPlayerVsPlayer(t *testing.T) {
-	rooms := map[RoomID]*Room{StartRoom: {ID: StartRoom}}
+	rooms := map[RoomID]*Room{StartRoom: {ID: StartRoom, Arena: true}}
 	world := NewWorldWithRooms(rooms)
 
-	alpha := &Player{Name: "Alpha", Room: StartRoom, Output: make(chan string, 10), Alive: true, Level: 2}
-	bravo := &Player{Name: "Bravo", Room: StartRoom, Output: make(chan string, 10), Alive: true, Level: 2}
+	alpha := &Player{Name: "Alpha", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Level: 2}
+	bravo := &Player{Name: "Bravo", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Level: 2}
 
 	world.AddPlayerForTest(alpha)
 	world.AddPlayerForTest(bravo)
+	world.beginDuelLocked(alpha, bravo)
 
 	damageAlpha := alpha.AttackDamage()
 	damageBravo := bravo.AttackDamage()
@@ -887,7 +892,7 @@ func TestStartCombatPlayerVsPlayer(t *testing.T) {
 func TestStartCombatUnknownTarget(t *testing.T) {
 	rooms := map[RoomID]*Room{StartRoom: {ID: StartRoom}}
 	world := NewWorldWithRooms(rooms)
-	player := &Player{Name: "Hero", Room: StartRoom, Output: make(chan string, 10), Alive: true, Level: 1}
+	player := &Player{Name: "Hero", Room: StartRoom, Output: NewOutputQueue(), Alive: true, Level: 1}
 	world.AddPlayerForTest(player)
 
 	if err := world.StartCombat(player, "phantom"); err == nil {
@@ -919,7 +924,7 @@ func TestWorldDeliverOfflineTells(t *testing.T) {
 			ID:          "hall",
 			Title:       "Hall",
 			Description: "A quiet hall.",
-			Exits:       map[string]RoomID{},
+			Exits:       map[string]Exit{},
 		},
 	})
 	tells, err := NewTellSystem("")
@@ -945,7 +950,7 @@ func TestWorldDeliverOfflineTells(t *testing.T) {
 		Name:     "Sender",
 		Room:     "hall",
 		Home:     "hall",
-		Output:   make(chan string, 8),
+		Output:   NewOutputQueue(),
 		Alive:    true,
 		Channels: DefaultChannelSettings(),
 	}
@@ -959,7 +964,7 @@ func TestWorldDeliverOfflineTells(t *testing.T) {
 		Name:     "Friend",
 		Room:     "hall",
 		Home:     "hall",
-		Output:   make(chan string, 8),
+		Output:   NewOutputQueue(),
 		Alive:    true,
 		Channels: DefaultChannelSettings(),
 	}
@@ -967,24 +972,18 @@ func TestWorldDeliverOfflineTells(t *testing.T) {
 
 	world.DeliverOfflineTells(friend)
 
-	var first string
-	select {
-	case first = <-friend.Output:
-	default:
-		t.Fatalf("friend did not receive offline tell output")
+	output := drainOutput(friend.Output)
+	if len(output) < 2 {
+		t.Fatalf("friend did not receive offline tells and a prompt, got %q", output)
 	}
+	first := output[0]
 	if !strings.Contains(first, "You have 1 offline tell") {
 		t.Fatalf("header missing: %q", first)
 	}
 	if !strings.Contains(first, "Sender") || !strings.Contains(first, "Meet me under the lantern") {
 		t.Fatalf("message missing: %q", first)
 	}
-	var prompt string
-	select {
-	case prompt = <-friend.Output:
-	default:
-		t.Fatalf("friend did not receive prompt after offline tells")
-	}
+	prompt := output[1]
 	if !strings.Contains(prompt, ">") {
 		t.Fatalf("prompt not received: %q", prompt)
 	}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxPageLength caps how much text fits on one page of a writable item.
+	MaxPageLength = 600
+	// DefaultWritingPages is how many pages a writable item holds when it
+	// sets no limit of its own, enough for a sign or a letter.
+	DefaultWritingPages = 1
+)
+
+// ErrNotWritable is returned when the item has no room for writing.
+var ErrNotWritable = errors.New("you cannot write on that")
+
+// Writing makes an item something players can write on and read, such as a
+// sign, a letter, or a book.
+type Writing struct {
+	// Pages holds what has been written so far, one entry per page.
+	Pages []Page `json:"pages,omitempty"`
+	// MaxPages caps how many pages can be written; DefaultWritingPages
+	// when unset.
+	MaxPages int `json:"max_pages,omitempty"`
+}
+
+// Page is one page of an item's writing.
+type Page struct {
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"`
+}
+
+func (wr *Writing) maxPages() int {
+	if wr.MaxPages > 0 {
+		return wr.MaxPages
+	}
+	return DefaultWritingPages
+}
+
+// cloneWriting copies writing so items spawned from one reset do not share
+// their pages.
+func cloneWriting(wr *Writing) *Writing {
+	if wr == nil {
+		return nil
+	}
+	copied := *wr
+	copied.Pages = append([]Page(nil), wr.Pages...)
+	return &copied
+}
+
+// ParseBlockedWords reads a comma separated list of words that may not be
+// written on items.
+func ParseBlockedWords(spec string) []string {
+	var words []string
+	for _, word := range strings.Split(spec, ",") {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" && !slices.Contains(words, word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// containsBlockedWord reports whether any blocked word appears as a whole
+// word in text.
+func containsBlockedWord(text string, blocked []string) bool {
+	if len(blocked) == 0 {
+		return false
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if slices.Contains(blocked, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWriting reports why text cannot be written on an item.
+func (w *World) ValidateWriting(text string) error {
+	if text == "" {
+		return fmt.Errorf("write what?")
+	}
+	if len(text) > MaxPageLength {
+		return fmt.Errorf("a page holds at most %d characters", MaxPageLength)
+	}
+	if strings.ContainsRune(text, '\x1b') {
+		return fmt.Errorf("writing may not contain escape codes")
+	}
+	w.mu.RLock()
+	blocked := w.tunablesLocked().BlockedWords
+	w.mu.RUnlock()
+	if containsBlockedWord(text, blocked) {
+		return fmt.Errorf("that language is not allowed here")
+	}
+	return nil
+}
+
+// findReadableLocked resolves an item carried by p or, failing that, lying
+// in p's room. It reports whether the item is carried.
+func (w *World) findReadableLocked(p *Player, name string) (*Item, bool, error) {
+	if idx := findItemIndex(p.Inventory, strings.TrimSpace(name)); idx >= 0 {
+		return &p.Inventory[idx], true, nil
+	}
+	room, ok := w.rooms[p.Room]
+	if !ok {
+		return nil, false, ErrItemNotFound
+	}
+	if idx := findItemIndex(room.Items, strings.TrimSpace(name)); idx >= 0 {
+		return &room.Items[idx], false, nil
+	}
+	return nil, false, ErrItemNotFound
+}
+
+// FindReadable returns a copy of the item named name that p is carrying or
+// can see in the room.
+func (w *World) FindReadable(p *Player, name string) (Item, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	item, _, err := w.findReadableLocked(p, name)
+	if err != nil {
+		return Item{}, err
+	}
+	return *item, nil
+}
+
+// WriteOnItem writes text as a new page on a writable item p carries or can
+// see in the room, and returns the item and the number of the page written.
+// Writing on a carried item is saved with the character. Every page written
+// is published as an EventItemWritten for moderation.
+func (w *World) WriteOnItem(p *Player, name, text string) (Item, int, error) {
+	text = strings.TrimSpace(text)
+	if err := w.ValidateWriting(text); err != nil {
+		return Item{}, 0, err
+	}
+	w.mu.Lock()
+	item, carried, err := w.findReadableLocked(p, name)
+	if err != nil {
+		w.mu.Unlock()
+		return Item{}, 0, err
+	}
+	if item.Writing == nil {
+		w.mu.Unlock()
+		return Item{}, 0, ErrNotWritable
+	}
+	if len(item.Writing.Pages) >= item.Writing.maxPages() {
+		w.mu.Unlock()
+		return Item{}, 0, fmt.Errorf("%s has no blank pages left", item.Name)
+	}
+	// Items copied from one another share their writing until one is
+	// written on.
+	writing := cloneWriting(item.Writing)
+	writing.Pages = append(writing.Pages, Page{Text: text, Author: p.Name})
+	item.Writing = writing
+	written := *item
+	room := p.Room
+	var key string
+	var snapshot PlayerProfile
+	if carried {
+		key, snapshot = profileSnapshot(p)
+	}
+	w.mu.Unlock()
+	if carried {
+		w.persistPlayerState(key, snapshot)
+	}
+	Logger().Info("item written", "player", p.Name, "item", written.Name, "room", string(room), "page", len(writing.Pages))
+	w.Publish(GameEvent{Type: EventItemWritten, Player: p, Room: room, Item: written, Text: text})
+	return written, len(writing.Pages), nil
+}
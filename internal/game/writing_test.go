@@ -0,0 +1,63 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestWriteOnItemFillsPagesAndPublishes(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{
+		"study": {ID: "study", Title: "Study", Exits: map[string]Exit{}, Items: []Item{
+			{Name: "Notice Sign", Writing: &Writing{}},
+			{Name: "Plain Rock"},
+		}},
+	})
+	scribe := &Player{Name: "Scribe", Room: "study", Alive: true, Output: NewOutputQueue()}
+	world.AddPlayerForTest(scribe)
+	book := Item{Name: "Diary", Writing: &Writing{MaxPages: 2}}
+	scribe.Inventory = []Item{book}
+
+	var events []GameEvent
+	world.Subscribe(EventItemWritten, func(ev GameEvent) { events = append(events, ev) })
+
+	item, page, err := world.WriteOnItem(scribe, "diary", "Dear diary, the clay spoke today.")
+	if err != nil || page != 1 || item.Writing.Pages[0].Author != "Scribe" {
+		t.Fatalf("expected the first page, got %+v, %d, %v", item, page, err)
+	}
+	if len(book.Writing.Pages) != 0 {
+		t.Fatalf("copies of an item should not share what is written on one")
+	}
+	if _, page, _ = world.WriteOnItem(scribe, "diary", "It said very little."); page != 2 {
+		t.Fatalf("expected the second page, got %d", page)
+	}
+	if _, _, err := world.WriteOnItem(scribe, "diary", "More?"); err == nil {
+		t.Fatalf("expected a full diary to refuse more writing")
+	}
+	if _, _, err := world.WriteOnItem(scribe, "sign", "Mind the kiln."); err != nil {
+		t.Fatalf("expected to write on the sign in the room: %v", err)
+	}
+	if sign, _ := world.FindReadable(scribe, "sign"); len(sign.Writing.Pages) != 1 {
+		t.Fatalf("expected the sign to keep its writing, got %+v", sign.Writing)
+	}
+	if _, _, err := world.WriteOnItem(scribe, "rock", "Hello"); err != ErrNotWritable {
+		t.Fatalf("expected the rock to refuse writing, got %v", err)
+	}
+	if len(events) != 3 || events[0].Text != "Dear diary, the clay spoke today." || events[2].Item.Name != "Notice Sign" {
+		t.Fatalf("expected an event per page, got %+v", events)
+	}
+}
+
+func TestValidateWritingRefusesBlockedWords(t *testing.T) {
+	world := NewWorldWithRooms(map[RoomID]*Room{})
+	tunables := DefaultTunables()
+	tunables.BlockedWords = ParseBlockedWords(" Grub, grub ,mire")
+	world.ConfigureTunables(tunables)
+	if len(tunables.BlockedWords) != 2 {
+		t.Fatalf("expected duplicate blocked words to fold, got %v", tunables.BlockedWords)
+	}
+	if err := world.ValidateWriting("You GRUB!"); err == nil {
+		t.Fatalf("expected a blocked word to be refused")
+	}
+	if err := world.ValidateWriting("The grubby mirestone is lovely."); err != nil {
+		t.Fatalf("only whole words should be blocked: %v", err)
+	}
+}
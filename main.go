@@ -4,6 +4,7 @@ import (
 	"flag"
 	"log"
 	"net"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -12,6 +13,10 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "area" {
+		os.Exit(runAreaCommand(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	addr := flag.String("addr", ":4000", "TCP address to listen on")
 	useTLS := flag.Bool("tls", false, "Enable TLS using the provided certificate and key files")
 	certPath := flag.String("cert", ".", "Path to the TLS certificate directory or bundle (Certbot fullchain.pem/privkey.pem; defaults to project root)")
@@ -20,23 +25,178 @@ func main() {
 	accountsPath := flag.String("accounts", "data/accounts.json", "Path to the player accounts database")
 	areasPath := flag.String("areas", game.DefaultAreasPath, "Directory containing world area definitions")
 	mailPath := flag.String("mail", "", "Optional path to persistent mail storage (defaults beside the accounts file)")
+	mailboxQuota := flag.Int("mailbox-quota", game.DefaultMailboxQuota, "Maximum personal letters stored per player (0 disables the quota)")
 	tellsPath := flag.String("tells", "", "Optional path to offline tells storage (defaults beside the accounts file)")
+	apiTokensPath := flag.String("api-tokens", "", "Optional path to REST API token storage (defaults beside the accounts file)")
+	discordWebhook := flag.String("discord-webhook", "", "Discord webhook URL that receives bridged chat")
+	discordToken := flag.String("discord-bot-token", "", "Discord bot token used to read messages back into the game")
+	discordChannel := flag.String("discord-channel-id", "", "Discord channel id polled for inbound messages")
+	discordChannels := flag.String("discord-channels", "ooc", "Comma separated in-game channels relayed to Discord (the first receives inbound messages)")
+	discordPoll := flag.Duration("discord-poll", game.DefaultBridgePollInterval, "How often to poll Discord for inbound messages")
+	intermudAddr := flag.String("intermud-addr", "", "IMC2 hub address (host:port) to join an intermud network (empty disables)")
+	intermudName := flag.String("intermud-name", game.DefaultIntermudName, "Name this MUD announces on the intermud network")
+	intermudPassword := flag.String("intermud-password", "", "Client password registered with the intermud hub")
+	intermudServerPassword := flag.String("intermud-server-password", "", "Password the intermud hub is expected to answer with")
+	intermudChannels := flag.String("intermud-channels", "ooc=Server01:ichat", "Comma separated links from in-game channels to intermud channels (local=remote)")
 	webAddr := flag.String("web-addr", "auto", "HTTPS port for the staff web portal (auto uses 443 on the same host as --addr; empty disables)")
 	webCert := flag.String("web-cert", "auto", "Path to the web portal TLS certificate directory or bundle (auto uses --cert)")
 	webBase := flag.String("web-base-url", "", "Optional external base URL for portal links")
+	deathXPLoss := flag.Int("death-xp-loss", game.DefaultDeathPenalty.ExperienceLossPercent, "Percent of progress towards the next level lost on defeat")
+	deathWeakness := flag.Duration("death-weakness", game.DefaultDeathPenalty.WeaknessDuration, "How long defeated players deal reduced damage (0 disables)")
+	corpseDecay := flag.Duration("corpse-decay", game.DefaultDeathPenalty.CorpseDecay, "How long corpses remain before decaying")
+	metricsAddr := flag.String("metrics-addr", "", "Optional HTTP address that serves Prometheus metrics at /metrics (empty disables)")
+	logPath := flag.String("log-file", "", "Optional path to a rotating server log file (logs always go to stdout)")
+	logLevel := flag.String("log-level", "info", "Minimum log level: debug, info, warn, or error")
+	logMaxSize := flag.Int64("log-max-bytes", game.DefaultLogMaxBytes, "Rotate the log file once it reaches this many bytes")
+	logBackups := flag.Int("log-backups", game.DefaultLogMaxBackups, "How many rotated log files to keep")
+	snapshotDir := flag.String("snapshot-dir", "", "Optional directory for world snapshots (defaults beside the accounts file)")
+	snapshotInterval := flag.Duration("snapshot-interval", game.DefaultSnapshotInterval, "How often to snapshot the world (0 disables periodic snapshots)")
+	snapshotKeep := flag.Int("snapshot-keep", game.DefaultSnapshotKeep, "How many world snapshots to keep (0 keeps all)")
+	chatLogDir := flag.String("chat-log-dir", "", "Optional directory for global channel scrollback (defaults beside the accounts file)")
+	chatLogLines := flag.Int("chat-log-lines", game.DefaultChatLogLines, "How many messages each global channel keeps for scrollback")
+	chatLogRetention := flag.Duration("chat-log-retention", game.DefaultChatLogRetention, "How long global channel messages are kept (0 keeps them until pushed out)")
+	marketDuration := flag.Duration("market-listing-duration", game.DefaultListingDuration, "How long market listings stay up before unsold items are mailed back")
+	gameHour := flag.Duration("game-hour", game.DefaultGameHour, "Real time per in-game hour for the day/night cycle and weather (0 stops the clock)")
+	linkdeadGrace := flag.Duration("linkdead-grace", game.DefaultLinkdeadGrace, "How long players whose connection drops stay in the world waiting to reconnect (0 logs them out immediately)")
+	watchAreas := flag.Duration("watch-areas", 0, "Poll the area and quest files at this interval and hot-reload changes (0 disables)")
+	storageSpec := flag.String("storage", "json", "Persistence backend for accounts, mail, tells, and builder rooms: json or sqlite:<path>")
+	combatRound := flag.Duration("combat-round", game.DefaultCombatRound, "Length of each combat round")
+	xpRate := flag.Float64("xp-rate", game.DefaultXPRate, "Multiplier applied to experience from kills and quests")
+	idleTimeout := flag.Duration("idle-timeout", 0, "Disconnect players who send nothing for this long (0 disables; admins are exempt)")
+	channelDefaults := flag.String("channel-defaults", "say,whisper,yell,ooc,ambient,clan", "Comma separated chat channels new characters start with enabled")
+	blockedWords := flag.String("blocked-words", "", "Comma separated words players may not write on signs, letters, or books")
+	houseEdge := flag.Int("house-edge", game.DefaultHouseEdge, "Percent of a winning bet's payout tavern dealers keep")
+	goldFaucetRate := flag.Float64("gold-faucet-rate", game.DefaultGoldRate, "Multiplier applied to gold dropped by creatures and paid by shopkeepers")
+	goldSinkRate := flag.Float64("gold-sink-rate", game.DefaultGoldRate, "Multiplier applied to what shops, healers, and waypoints charge")
+	rentPerDay := flag.Int("rent-per-day", 0, "Gold each vault item costs per day beyond the free allowance (0 turns rent off)")
+	rentFreeItems := flag.Int("rent-free-items", game.DefaultRentFreeItems, "How many vault items each account stores without rent")
+	configPath := flag.String("config", "", "Optional YAML or TOML file of settings keyed by flag name, using flat key/value lines and lists only (flags given on the command line win)")
 	flag.Parse()
 
+	var configFile *game.ConfigFile
+	if path := strings.TrimSpace(*configPath); path != "" {
+		settings, err := game.LoadConfigFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		overridden, err := game.ApplyConfig(flag.CommandLine, settings)
+		if err != nil {
+			log.Fatal(err)
+		}
+		configFile = &game.ConfigFile{Path: path, Settings: settings, Overridden: overridden, Flags: flag.CommandLine}
+	}
+
 	mudCertFile, mudKeyFile := expandCertPaths(*certPath)
 	portalCertBase := resolveCertBase(*webCert, *certPath)
 	portalCertFile, portalKeyFile := expandCertPaths(portalCertBase)
 
 	var options []game.ServerOption
+	level, err := game.ParseLogLevel(*logLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	options = append(options, game.WithLogConfig(game.LogConfig{
+		Path:       strings.TrimSpace(*logPath),
+		Level:      level,
+		MaxBytes:   *logMaxSize,
+		MaxBackups: *logBackups,
+	}))
+	penalty := game.DefaultDeathPenalty
+	penalty.ExperienceLossPercent = *deathXPLoss
+	penalty.WeaknessDuration = *deathWeakness
+	penalty.CorpseDecay = *corpseDecay
+	options = append(options, game.WithDeathPenalty(penalty))
+	options = append(options, game.WithStorage(*storageSpec))
+	options = append(options, game.WithGameHour(*gameHour))
+	options = append(options, game.WithAreaWatch(*watchAreas))
+	options = append(options, game.WithLinkdeadGrace(*linkdeadGrace))
+	if *combatRound <= 0 {
+		log.Fatal("-combat-round must be a positive duration")
+	}
+	if *xpRate < 0 {
+		log.Fatal("-xp-rate must be zero or higher")
+	}
+	if *idleTimeout < 0 {
+		log.Fatal("-idle-timeout must be zero or higher")
+	}
+	if *houseEdge < 0 || *houseEdge > game.MaxHouseEdge {
+		log.Fatalf("-house-edge must be from 0 to %d", game.MaxHouseEdge)
+	}
+	if *goldFaucetRate <= 0 || *goldSinkRate <= 0 {
+		log.Fatal("-gold-faucet-rate and -gold-sink-rate must be positive")
+	}
+	if *rentPerDay < 0 || *rentFreeItems < 0 {
+		log.Fatal("-rent-per-day and -rent-free-items must be zero or higher")
+	}
+	channels, err := game.ParseChannelDefaults(*channelDefaults)
+	if err != nil {
+		log.Fatal(err)
+	}
+	options = append(options, game.WithTunables(game.Tunables{
+		CombatRound:    *combatRound,
+		XPRate:         *xpRate,
+		IdleTimeout:    *idleTimeout,
+		Channels:       channels,
+		BlockedWords:   game.ParseBlockedWords(*blockedWords),
+		HouseEdge:      *houseEdge,
+		GoldFaucetRate: *goldFaucetRate,
+		GoldSinkRate:   *goldSinkRate,
+		RentPerDay:     *rentPerDay,
+		RentFreeItems:  *rentFreeItems,
+	}))
+	if configFile != nil {
+		options = append(options, game.WithConfigFile(*configFile))
+	}
+	options = append(options, game.WithMailboxQuota(*mailboxQuota))
+	options = append(options, game.WithMarketListingDuration(*marketDuration))
+	options = append(options, game.WithSnapshotConfig(game.SnapshotConfig{
+		Dir:      strings.TrimSpace(*snapshotDir),
+		Interval: *snapshotInterval,
+		Keep:     *snapshotKeep,
+	}))
+	options = append(options, game.WithChatLogConfig(game.ChatLogConfig{
+		Dir:       strings.TrimSpace(*chatLogDir),
+		Lines:     *chatLogLines,
+		Retention: *chatLogRetention,
+	}))
 	if trimmed := strings.TrimSpace(*mailPath); trimmed != "" {
 		options = append(options, game.WithMailPath(trimmed))
 	}
 	if trimmed := strings.TrimSpace(*tellsPath); trimmed != "" {
 		options = append(options, game.WithTellPath(trimmed))
 	}
+	if trimmed := strings.TrimSpace(*apiTokensPath); trimmed != "" {
+		options = append(options, game.WithAPITokenPath(trimmed))
+	}
+	if trimmed := strings.TrimSpace(*metricsAddr); trimmed != "" {
+		options = append(options, game.WithMetricsAddr(trimmed))
+	}
+	if strings.TrimSpace(*discordWebhook) != "" || strings.TrimSpace(*discordToken) != "" {
+		channels, err := game.ParseBridgeChannels(*discordChannels)
+		if err != nil {
+			log.Fatal(err)
+		}
+		options = append(options, game.WithDiscordBridge(game.DiscordBridgeConfig{
+			WebhookURL:   *discordWebhook,
+			BotToken:     *discordToken,
+			ChannelID:    *discordChannel,
+			Channels:     channels,
+			PollInterval: *discordPoll,
+		}))
+	}
+	if strings.TrimSpace(*intermudAddr) != "" {
+		channels, err := game.ParseIntermudChannels(*intermudChannels)
+		if err != nil {
+			log.Fatal(err)
+		}
+		options = append(options, game.WithIntermud(game.IntermudConfig{
+			Address:        *intermudAddr,
+			MudName:        *intermudName,
+			ClientPassword: *intermudPassword,
+			ServerPassword: *intermudServerPassword,
+			Channels:       channels,
+		}))
+	}
 	if resolved := resolveWebAddr(*webAddr, *addr); resolved != "" {
 		portalCfg := game.PortalConfig{
 			Addr:     resolved,
@@ -47,7 +207,6 @@ func main() {
 		options = append(options, game.WithPortalConfig(portalCfg))
 	}
 
-	var err error
 	if *useTLS {
 		err = game.ListenAndServeTLS(*addr, *accountsPath, *areasPath, mudCertFile, mudKeyFile, *adminAccount, commands.Dispatch, *everyoneAdmin, options...)
 	} else {